@@ -8,6 +8,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 )
@@ -176,6 +177,55 @@ func nativeSessionIDFromPath(path string) string {
 	return strings.TrimSuffix(base, ".jsonl")
 }
 
+// ReadClaudeCodeLog returns the events Claude Code has logged for workDir at
+// or after since, oldest first. Every conversation file modified since then
+// is read, so a session that restarted Claude (handoff, crash) is covered
+// across its conversation files. A missing project directory yields no events.
+func ReadClaudeCodeLog(sessionID, workDir string, since time.Time) ([]AgentEvent, error) {
+	projectDir, err := claudeProjectDirFor(workDir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(projectDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var events []AgentEvent
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".jsonl") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil || info.ModTime().Before(since) {
+			continue
+		}
+		path := filepath.Join(projectDir, e.Name())
+		f, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		nativeID := nativeSessionIDFromPath(path)
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 256*1024), 16*1024*1024)
+		for scanner.Scan() {
+			for _, ev := range parseClaudeCodeLine(scanner.Text(), sessionID, "claudecode", nativeID) {
+				if !ev.Timestamp.Before(since) {
+					events = append(events, ev)
+				}
+			}
+		}
+		f.Close()
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].Timestamp.Before(events[j].Timestamp)
+	})
+	return events, nil
+}
+
 // tailJSONL reads all existing lines in path then polls for new ones, emitting
 // AgentEvents on ch. It returns (without closing ch) when:
 //   - a newer JSONL file appears in projectDir (new Claude session detected), or
@@ -248,9 +298,54 @@ type ccEntry struct {
 
 // ccMessage is the message field of a ccEntry.
 type ccMessage struct {
-	Role    string      `json:"role"`
-	Content []ccContent `json:"content"`
-	Usage   *ccUsage    `json:"usage,omitempty"`
+	Role    string     `json:"role"`
+	Content ccContents `json:"content"`
+	Usage   *ccUsage   `json:"usage,omitempty"`
+}
+
+// ccContents is a message's content blocks. Claude Code writes typed prompts
+// as a bare string rather than a block array; that form is decoded as a
+// single text block.
+type ccContents []ccContent
+
+func (c *ccContents) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*c = ccContents{{Type: "text", Text: text}}
+		return nil
+	}
+	var blocks []ccContent
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return err
+	}
+	*c = blocks
+	return nil
+}
+
+// ccText is a tool_result's content: either a string or an array of text
+// blocks, which are joined with newlines.
+type ccText string
+
+func (t *ccText) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*t = ccText(s)
+		return nil
+	}
+	var blocks []struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		return err
+	}
+	parts := make([]string, 0, len(blocks))
+	for _, b := range blocks {
+		if b.Text != "" {
+			parts = append(parts, b.Text)
+		}
+	}
+	*t = ccText(strings.Join(parts, "\n"))
+	return nil
 }
 
 // ccUsage holds Claude API token usage counts for an assistant turn.
@@ -275,8 +370,8 @@ type ccContent struct {
 	Name  string          `json:"name,omitempty"`
 	Input json.RawMessage `json:"input,omitempty"`
 
-	// tool_result (content is a string in the simple case)
-	Content string `json:"content,omitempty"`
+	// tool_result
+	Content ccText `json:"content,omitempty"`
 }
 
 // parseClaudeCodeLine parses one JSONL line and returns 0 or more AgentEvents.
@@ -316,7 +411,7 @@ func parseClaudeCodeLine(line, sessionID, agentType, nativeSessionID string) []A
 			content = c.Name + ": " + string(c.Input)
 		case "tool_result":
 			eventType = "tool_result"
-			content = c.Content
+			content = string(c.Content)
 		default:
 			continue
 		}
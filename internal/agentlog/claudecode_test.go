@@ -3,7 +3,9 @@ package agentlog
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestClaudeProjectDirFor(t *testing.T) {
@@ -85,6 +87,70 @@ func TestParseClaudeCodeLine_InvalidJSON(t *testing.T) {
 	}
 }
 
+func TestParseClaudeCodeLine_StringContent(t *testing.T) {
+	// Typed prompts are logged with a bare string as the message content.
+	line := `{"type":"user","message":{"role":"user","content":"fix the build"},"timestamp":"2026-02-23T10:00:00Z"}`
+	events := parseClaudeCodeLine(line, "s1", "claudecode", "test-uuid")
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].EventType != "text" || events[0].Role != "user" || events[0].Content != "fix the build" {
+		t.Errorf("got %+v, want user text %q", events[0], "fix the build")
+	}
+}
+
+func TestParseClaudeCodeLine_ToolResultBlocks(t *testing.T) {
+	line := `{"type":"user","message":{"role":"user","content":[{"type":"tool_result","content":[{"type":"text","text":"line one"},{"type":"text","text":"line two"}]}]}}`
+	events := parseClaudeCodeLine(line, "s1", "claudecode", "test-uuid")
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+	if events[0].EventType != "tool_result" || events[0].Content != "line one\nline two" {
+		t.Errorf("got %+v, want joined tool_result text", events[0])
+	}
+}
+
+func TestReadClaudeCodeLog(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	workDir := "/town/gastown/polecats/Toast"
+	projectDir, err := claudeProjectDirFor(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(projectDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := []string{
+		`{"type":"user","message":{"role":"user","content":"old prompt"},"timestamp":"2026-02-23T09:00:00Z"}`,
+		`{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash","input":{"command":"make"}}]},"timestamp":"2026-02-23T10:05:00Z"}`,
+		`{"type":"user","message":{"role":"user","content":"new prompt"},"timestamp":"2026-02-23T10:01:00Z"}`,
+	}
+	if err := os.WriteFile(filepath.Join(projectDir, "abc.jsonl"), []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	since := time.Date(2026, 2, 23, 10, 0, 0, 0, time.UTC)
+	events, err := ReadClaudeCodeLog("gt-toast", workDir, since)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events after since, got %d: %+v", len(events), events)
+	}
+	if events[0].Content != "new prompt" || events[1].EventType != "tool_use" {
+		t.Errorf("events not in time order: %+v", events)
+	}
+	if events[0].NativeSessionID != "abc" || events[0].SessionID != "gt-toast" {
+		t.Errorf("session IDs = %q/%q, want abc/gt-toast", events[0].NativeSessionID, events[0].SessionID)
+	}
+
+	if events, err := ReadClaudeCodeLog("x", "/no/such/dir", since); err != nil || len(events) != 0 {
+		t.Errorf("missing project dir: got %d events, err %v", len(events), err)
+	}
+}
+
 func TestNewAdapter(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,307 @@
+// Package backup snapshots a town's state into a single archive and restores
+// it, for disaster recovery and for moving a town to another machine.
+//
+// A backup holds the town's own state: mayor configs and quota state, town
+// and rig settings, beads metadata and routes, polecat name pools, rig
+// overlays and setup hooks, and the hooks base config and overrides. It does
+// not hold repo clones (they are re-cloned from their remotes) or beads issue
+// data (it lives in the Dolt server, which has its own backups).
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// FormatVersion is the archive format this gt writes. Restore rejects newer
+// archives.
+const FormatVersion = 1
+
+// Archive sections: town files live under "town/", hooks config under "hooks/".
+const (
+	manifestName  = "manifest.json"
+	townSection   = "town"
+	hooksSection  = "hooks"
+	maxMemberSize = 64 << 20 // refuse absurd members rather than fill the disk
+)
+
+// ErrInvalidArchive indicates a file that isn't a usable gt backup.
+var ErrInvalidArchive = errors.New("invalid backup archive")
+
+// Manifest describes a backup. It is the archive's first member.
+type Manifest struct {
+	Version   int         `json:"version"`
+	CreatedAt time.Time   `json:"created_at"`
+	Town      string      `json:"town"`              // town name from town.json
+	TownRoot  string      `json:"town_root"`         // where the backup was taken
+	Rigs      []RigRecord `json:"rigs,omitempty"`    // rigs registered at backup time
+	Files     []string    `json:"files"`             // archive paths, in order
+	Skipped   []string    `json:"skipped,omitempty"` // unreadable files left out
+}
+
+// RigRecord is a rig's registry entry, kept so a restore can say which
+// clones to recreate.
+type RigRecord struct {
+	Name   string `json:"name"`
+	GitURL string `json:"git_url"`
+}
+
+// beadsMetadataFiles are the .beads files that configure a beads database,
+// as opposed to holding its data or runtime state.
+var beadsMetadataFiles = []string{"config.yaml", "metadata.json", "routes.jsonl", "redirect"}
+
+// Create writes a backup of the town at townRoot, plus the hooks config in
+// hooksDir (the hooks config directory; "" to leave it out), to w as a gzipped tar.
+func Create(w io.Writer, townRoot, hooksDir string) (*Manifest, error) {
+	townFiles, rigs, err := townStateFiles(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	var hooksFiles []string
+	if hooksDir != "" {
+		if hooksFiles, err = hooksStateFiles(hooksDir); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest := &Manifest{
+		Version:   FormatVersion,
+		CreatedAt: time.Now().UTC(),
+		TownRoot:  townRoot,
+		Rigs:      rigs,
+	}
+	if townCfg, err := config.LoadTownConfigBase(filepath.Join(townRoot, constants.DirMayor, constants.FileTownJSON)); err == nil {
+		manifest.Town = townCfg.Name
+	}
+
+	// Read everything first so the manifest, written first, lists exactly
+	// what follows.
+	type member struct {
+		name string
+		data []byte
+		mode fs.FileMode
+	}
+	var members []member
+	add := func(section, root string, rels []string) {
+		for _, rel := range rels {
+			p := filepath.Join(root, rel)
+			info, err := os.Stat(p)
+			if err != nil {
+				manifest.Skipped = append(manifest.Skipped, p)
+				continue
+			}
+			data, err := os.ReadFile(p) //nolint:gosec // G304: files found under the town or hooks dir
+			if err != nil {
+				manifest.Skipped = append(manifest.Skipped, p)
+				continue
+			}
+			name := path.Join(section, filepath.ToSlash(rel))
+			members = append(members, member{name: name, data: data, mode: info.Mode().Perm()})
+			manifest.Files = append(manifest.Files, name)
+		}
+	}
+	add(townSection, townRoot, townFiles)
+	add(hooksSection, hooksDir, hooksFiles)
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeMember(tw, manifestName, manifestData, 0644, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		if err := writeMember(tw, m.name, m.data, m.mode, manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func writeMember(tw *tar.Writer, name string, data []byte, mode fs.FileMode, modTime time.Time) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    int64(mode),
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// townStateFiles lists the town-relative paths of the files a backup holds,
+// and the registered rigs.
+func townStateFiles(townRoot string) ([]string, []RigRecord, error) {
+	var files []string
+
+	// mayor/*.json: town, rigs, daemon, accounts, quota, overlays, ...
+	mayorFiles, err := filesIn(townRoot, constants.DirMayor, func(name string) bool {
+		return strings.HasSuffix(name, ".json")
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(mayorFiles) == 0 {
+		return nil, nil, fmt.Errorf("%s is not a town: no %s/*.json", townRoot, constants.DirMayor)
+	}
+	files = append(files, mayorFiles...)
+
+	settings, err := treeFiles(townRoot, constants.DirSettings)
+	if err != nil {
+		return nil, nil, err
+	}
+	files = append(files, settings...)
+
+	beadsFiles, err := beadsMetadata(townRoot, constants.DirBeads)
+	if err != nil {
+		return nil, nil, err
+	}
+	files = append(files, beadsFiles...)
+
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, constants.DirMayor, constants.FileRigsJSON))
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return nil, nil, fmt.Errorf("loading rigs config: %w", err)
+	}
+	var rigs []RigRecord
+	if rigsConfig != nil {
+		names := make([]string, 0, len(rigsConfig.Rigs))
+		for name := range rigsConfig.Rigs {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			rigs = append(rigs, RigRecord{Name: name, GitURL: rigsConfig.Rigs[name].GitURL})
+			rigFiles, err := rigStateFiles(townRoot, name)
+			if err != nil {
+				return nil, nil, err
+			}
+			files = append(files, rigFiles...)
+		}
+	}
+	return files, rigs, nil
+}
+
+// rigStateFiles lists a rig's own state: its config, settings, beads
+// metadata, polecat name pool, overlay and setup hooks. Not its clones.
+func rigStateFiles(townRoot, rig string) ([]string, error) {
+	var files []string
+	if _, err := os.Stat(filepath.Join(townRoot, rig, constants.FileConfigJSON)); err == nil {
+		files = append(files, filepath.Join(rig, constants.FileConfigJSON))
+	}
+	for _, collect := range []func() ([]string, error){
+		func() ([]string, error) { return treeFiles(townRoot, filepath.Join(rig, constants.DirSettings)) },
+		func() ([]string, error) { return beadsMetadata(townRoot, filepath.Join(rig, constants.DirBeads)) },
+		func() ([]string, error) {
+			return filesIn(townRoot, filepath.Join(rig, constants.DirRuntime), func(name string) bool {
+				return name == "namepool-state.json"
+			})
+		},
+		func() ([]string, error) {
+			return treeFiles(townRoot, filepath.Join(rig, constants.DirRuntime, "overlay"))
+		},
+		func() ([]string, error) {
+			return treeFiles(townRoot, filepath.Join(rig, constants.DirRuntime, "setup-hooks"))
+		},
+	} {
+		found, err := collect()
+		if err != nil {
+			return nil, fmt.Errorf("rig %s: %w", rig, err)
+		}
+		files = append(files, found...)
+	}
+	return files, nil
+}
+
+// hooksStateFiles lists the hooks base config and overrides in hooksDir.
+func hooksStateFiles(hooksDir string) ([]string, error) {
+	files, err := filesIn(hooksDir, ".", func(name string) bool { return name == "hooks-base.json" })
+	if err != nil {
+		return nil, err
+	}
+	overrides, err := filesIn(hooksDir, "hooks-overrides", func(name string) bool {
+		return strings.HasSuffix(name, ".json")
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append(files, overrides...), nil
+}
+
+func beadsMetadata(root, dir string) ([]string, error) {
+	return filesIn(root, dir, func(name string) bool {
+		for _, f := range beadsMetadataFiles {
+			if name == f {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// filesIn lists the regular files directly in root/dir that keep accepts,
+// as root-relative paths. A missing dir has no files.
+func filesIn(root, dir string, keep func(name string) bool) ([]string, error) {
+	entries, err := os.ReadDir(filepath.Join(root, dir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if e.Type().IsRegular() && keep(e.Name()) {
+			files = append(files, filepath.Join(dir, e.Name()))
+		}
+	}
+	return files, nil
+}
+
+// treeFiles lists every regular file under root/dir, as root-relative paths.
+func treeFiles(root, dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(filepath.Join(root, dir), func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if d.Type().IsRegular() {
+			rel, err := filepath.Rel(root, p)
+			if err != nil {
+				return err
+			}
+			files = append(files, rel)
+		}
+		return nil
+	})
+	return files, err
+}
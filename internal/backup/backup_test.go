@@ -0,0 +1,204 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	p := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func setupTown(t *testing.T) (townRoot, hooksDir string) {
+	t.Helper()
+	townRoot = t.TempDir()
+	hooksDir = t.TempDir()
+	writeFile(t, townRoot, "mayor/town.json", `{"type":"town","version":2,"name":"hq-town"}`)
+	writeFile(t, townRoot, "mayor/rigs.json", `{"version":1,"rigs":{"alpha":{"git_url":"https://example.com/alpha.git"}}}`)
+	writeFile(t, townRoot, "mayor/quota.json", `{"version":1}`)
+	writeFile(t, townRoot, "mayor/notes.txt", "not config")
+	writeFile(t, townRoot, "settings/config.json", `{"type":"town-settings"}`)
+	writeFile(t, townRoot, ".beads/routes.jsonl", `{"prefix":"al-","path":"alpha/mayor/rig"}`+"\n")
+	writeFile(t, townRoot, ".beads/config.yaml", "prefix: hq\n")
+	writeFile(t, townRoot, ".beads/daemon.log", "runtime noise")
+	writeFile(t, townRoot, "alpha/config.json", `{"type":"rig","name":"alpha"}`)
+	writeFile(t, townRoot, "alpha/settings/config.json", `{"type":"rig-settings"}`)
+	writeFile(t, townRoot, "alpha/.runtime/namepool-state.json", `{"in_use":["toast"]}`)
+	writeFile(t, townRoot, "alpha/.runtime/locks/x.lock", "")
+	writeFile(t, townRoot, "alpha/.runtime/overlay/.env", "A=1\n")
+	writeFile(t, townRoot, "alpha/mayor/rig/README.md", "clone contents")
+	writeFile(t, hooksDir, "hooks-base.json", `{}`)
+	writeFile(t, hooksDir, "hooks-overrides/alpha__crew.json", `{}`)
+	return townRoot, hooksDir
+}
+
+func TestCreate(t *testing.T) {
+	townRoot, hooksDir := setupTown(t)
+
+	var buf bytes.Buffer
+	manifest, err := Create(&buf, townRoot, hooksDir)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if manifest.Town != "hq-town" {
+		t.Errorf("Town = %q, want hq-town", manifest.Town)
+	}
+	if len(manifest.Rigs) != 1 || manifest.Rigs[0] != (RigRecord{Name: "alpha", GitURL: "https://example.com/alpha.git"}) {
+		t.Errorf("Rigs = %+v", manifest.Rigs)
+	}
+
+	want := []string{
+		"town/mayor/quota.json",
+		"town/mayor/rigs.json",
+		"town/mayor/town.json",
+		"town/settings/config.json",
+		"town/.beads/config.yaml",
+		"town/.beads/routes.jsonl",
+		"town/alpha/config.json",
+		"town/alpha/settings/config.json",
+		"town/alpha/.runtime/namepool-state.json",
+		"town/alpha/.runtime/overlay/.env",
+		"hooks/hooks-base.json",
+		"hooks/hooks-overrides/alpha__crew.json",
+	}
+	if !slices.Equal(manifest.Files, want) {
+		t.Errorf("Files =\n%v\nwant\n%v", manifest.Files, want)
+	}
+
+	read, err := ReadManifest(bytes.NewReader(buf.Bytes()))
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if !slices.Equal(read.Files, want) {
+		t.Errorf("archived manifest lists %v", read.Files)
+	}
+}
+
+func TestCreateRejectsNonTown(t *testing.T) {
+	if _, err := Create(&bytes.Buffer{}, t.TempDir(), ""); err == nil {
+		t.Fatal("expected an error for a directory without mayor configs")
+	}
+}
+
+func TestRestore(t *testing.T) {
+	townRoot, hooksDir := setupTown(t)
+	var buf bytes.Buffer
+	if _, err := Create(&buf, townRoot, hooksDir); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	archive := buf.Bytes()
+
+	t.Run("into an empty machine", func(t *testing.T) {
+		newTown, newHooks := t.TempDir(), t.TempDir()
+		result, err := Restore(bytes.NewReader(archive), newTown, newHooks, RestoreOptions{})
+		if err != nil {
+			t.Fatalf("Restore: %v", err)
+		}
+		if len(result.Written) != 12 || len(result.Conflicts) != 0 {
+			t.Errorf("written %d, conflicts %v", len(result.Written), result.Conflicts)
+		}
+		data, err := os.ReadFile(filepath.Join(newTown, "alpha", ".runtime", "namepool-state.json"))
+		if err != nil || string(data) != `{"in_use":["toast"]}` {
+			t.Errorf("namepool state = %q, %v", data, err)
+		}
+		if _, err := os.Stat(filepath.Join(newHooks, "hooks-overrides", "alpha__crew.json")); err != nil {
+			t.Errorf("hooks override not restored: %v", err)
+		}
+	})
+
+	t.Run("conflicts need force", func(t *testing.T) {
+		writeFile(t, townRoot, "mayor/quota.json", `{"version":1,"changed":true}`)
+		writeFile(t, townRoot, "mayor/daemon.json", `{"kept":true}`)
+
+		result, err := Restore(bytes.NewReader(archive), townRoot, hooksDir, RestoreOptions{})
+		if !errors.Is(err, ErrConflict) {
+			t.Fatalf("Restore = %v, want ErrConflict", err)
+		}
+		if len(result.Conflicts) != 1 || result.Conflicts[0] != filepath.Join(townRoot, "mayor", "quota.json") {
+			t.Errorf("Conflicts = %v", result.Conflicts)
+		}
+
+		dry, err := Restore(bytes.NewReader(archive), townRoot, hooksDir, RestoreOptions{Force: true, DryRun: true})
+		if err != nil {
+			t.Fatalf("dry run: %v", err)
+		}
+		if len(dry.Written) != 1 || len(dry.Unchanged) != 11 {
+			t.Errorf("dry run wrote %v, unchanged %d", dry.Written, len(dry.Unchanged))
+		}
+		if data, _ := os.ReadFile(filepath.Join(townRoot, "mayor", "quota.json")); string(data) != `{"version":1,"changed":true}` {
+			t.Error("dry run must not write")
+		}
+
+		if _, err := Restore(bytes.NewReader(archive), townRoot, hooksDir, RestoreOptions{Force: true}); err != nil {
+			t.Fatalf("forced restore: %v", err)
+		}
+		if data, _ := os.ReadFile(filepath.Join(townRoot, "mayor", "quota.json")); string(data) != `{"version":1}` {
+			t.Errorf("quota.json = %s, want the backed-up content", data)
+		}
+		if _, err := os.Stat(filepath.Join(townRoot, "mayor", "daemon.json")); err != nil {
+			t.Error("files not in the backup must be left alone")
+		}
+	})
+
+	t.Run("skip hooks", func(t *testing.T) {
+		newHooks := t.TempDir()
+		if _, err := Restore(bytes.NewReader(archive), t.TempDir(), newHooks, RestoreOptions{SkipHooks: true}); err != nil {
+			t.Fatalf("Restore: %v", err)
+		}
+		if entries, _ := os.ReadDir(newHooks); len(entries) != 0 {
+			t.Errorf("hooks dir should be untouched, has %d entries", len(entries))
+		}
+	})
+}
+
+func TestRestoreRejectsUnsafeArchives(t *testing.T) {
+	build := func(names ...string) []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for _, name := range names {
+			data := []byte("{}")
+			if name == manifestName {
+				data = []byte(`{"version":1}`)
+			}
+			_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg})
+			_, _ = tw.Write(data)
+		}
+		_ = tw.Close()
+		_ = gz.Close()
+		return buf.Bytes()
+	}
+
+	tests := map[string][]byte{
+		"no manifest":   build("town/mayor/town.json"),
+		"parent path":   build(manifestName, "town/../escape.json"),
+		"absolute path": build(manifestName, "town//etc/passwd"),
+		"other section": build(manifestName, "elsewhere/file.json"),
+		"not gzip":      []byte("plain text"),
+	}
+	for name, archive := range tests {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			_, err := Restore(bytes.NewReader(archive), filepath.Join(dir, "town"), "", RestoreOptions{})
+			if !errors.Is(err, ErrInvalidArchive) {
+				t.Errorf("Restore = %v, want ErrInvalidArchive", err)
+			}
+			if _, err := os.Stat(filepath.Join(dir, "escape.json")); err == nil {
+				t.Error("unsafe member was written")
+			}
+		})
+	}
+}
@@ -0,0 +1,189 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// ErrConflict means a restore would overwrite files that differ from the
+// backup and RestoreOptions.Force is not set.
+var ErrConflict = errors.New("restore would overwrite existing files")
+
+// RestoreOptions controls Restore.
+type RestoreOptions struct {
+	// Force overwrites existing files that differ from the backup.
+	Force bool
+
+	// DryRun reports what would be written without writing anything.
+	DryRun bool
+
+	// SkipHooks leaves the hooks config out of the restore.
+	SkipHooks bool
+}
+
+// RestoreResult describes what Restore did, or in a dry run would do.
+// Paths are absolute.
+type RestoreResult struct {
+	Manifest  *Manifest
+	Written   []string // created or overwritten
+	Unchanged []string // already identical
+	Conflicts []string // existing files that differ from the backup
+}
+
+// Restore unpacks a backup read from r into townRoot and hooksDir. Existing
+// files that differ from the backup are conflicts: without Force nothing is
+// written and the error is ErrConflict. Files not in the backup are left
+// alone.
+func Restore(r io.Reader, townRoot, hooksDir string, opts RestoreOptions) (*RestoreResult, error) {
+	manifest, members, err := readArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	result := &RestoreResult{Manifest: manifest}
+
+	type target struct {
+		path string
+		data []byte
+		mode fs.FileMode
+	}
+	var targets []target
+	for _, m := range members {
+		section, rel, _ := strings.Cut(m.name, "/")
+		var root string
+		switch section {
+		case townSection:
+			root = townRoot
+		case hooksSection:
+			if opts.SkipHooks || hooksDir == "" {
+				continue
+			}
+			root = hooksDir
+		}
+		p := filepath.Join(root, filepath.FromSlash(rel))
+		existing, err := os.ReadFile(p) //nolint:gosec // G304: validated archive path under the restore root
+		switch {
+		case err == nil && bytes.Equal(existing, m.data):
+			result.Unchanged = append(result.Unchanged, p)
+			continue
+		case err == nil:
+			result.Conflicts = append(result.Conflicts, p)
+		case !os.IsNotExist(err):
+			return result, fmt.Errorf("checking %s: %w", p, err)
+		}
+		targets = append(targets, target{path: p, data: m.data, mode: m.mode})
+	}
+
+	if len(result.Conflicts) > 0 && !opts.Force {
+		return result, fmt.Errorf("%w: %d file(s) differ from the backup", ErrConflict, len(result.Conflicts))
+	}
+	for _, t := range targets {
+		result.Written = append(result.Written, t.path)
+		if opts.DryRun {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(t.path), 0755); err != nil {
+			return result, fmt.Errorf("creating %s: %w", filepath.Dir(t.path), err)
+		}
+		if err := util.AtomicWriteFile(t.path, t.data, t.mode); err != nil {
+			return result, fmt.Errorf("writing %s: %w", t.path, err)
+		}
+	}
+	return result, nil
+}
+
+// ReadManifest returns a backup's manifest without restoring anything.
+func ReadManifest(r io.Reader) (*Manifest, error) {
+	manifest, _, err := readArchive(r)
+	return manifest, err
+}
+
+type archiveMember struct {
+	name string
+	data []byte
+	mode fs.FileMode
+}
+
+// readArchive reads and validates a whole backup. Members must be regular
+// files inside the town or hooks section; anything else (links, absolute or
+// ".." paths) makes the archive invalid, so a crafted backup can't write
+// outside the restore roots.
+func readArchive(r io.Reader) (*Manifest, []archiveMember, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	var manifest *Manifest
+	var members []archiveMember
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			return nil, nil, fmt.Errorf("%w: %s is not a regular file", ErrInvalidArchive, hdr.Name)
+		}
+		if hdr.Size > maxMemberSize {
+			return nil, nil, fmt.Errorf("%w: %s is too large (%d bytes)", ErrInvalidArchive, hdr.Name, hdr.Size)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxMemberSize))
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: reading %s: %v", ErrInvalidArchive, hdr.Name, err)
+		}
+
+		if manifest == nil {
+			if hdr.Name != manifestName {
+				return nil, nil, fmt.Errorf("%w: missing %s", ErrInvalidArchive, manifestName)
+			}
+			manifest = &Manifest{}
+			if err := json.Unmarshal(data, manifest); err != nil {
+				return nil, nil, fmt.Errorf("%w: parsing %s: %v", ErrInvalidArchive, manifestName, err)
+			}
+			if manifest.Version > FormatVersion {
+				return nil, nil, fmt.Errorf("%w: format version %d, max supported %d (upgrade gt)", ErrInvalidArchive, manifest.Version, FormatVersion)
+			}
+			continue
+		}
+
+		if !validMemberName(hdr.Name) {
+			return nil, nil, fmt.Errorf("%w: unsafe path %q", ErrInvalidArchive, hdr.Name)
+		}
+		mode := fs.FileMode(hdr.Mode).Perm()
+		if mode == 0 {
+			mode = 0644
+		}
+		members = append(members, archiveMember{name: hdr.Name, data: data, mode: mode})
+	}
+	if manifest == nil {
+		return nil, nil, fmt.Errorf("%w: empty archive", ErrInvalidArchive)
+	}
+	return manifest, members, nil
+}
+
+func validMemberName(name string) bool {
+	section, rel, ok := strings.Cut(name, "/")
+	if !ok || (section != townSection && section != hooksSection) {
+		return false
+	}
+	if rel == "" || path.IsAbs(rel) || strings.Contains(rel, `\`) || path.Clean(rel) != rel {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, "../")
+}
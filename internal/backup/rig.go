@@ -0,0 +1,380 @@
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Rig archive sections: the rig's state files live under "rig/", git
+// bundles of its branches under "git/".
+const (
+	rigSection = "rig"
+	gitSection = "git"
+)
+
+// RigManifest describes a rig archive. It is the archive's first member.
+type RigManifest struct {
+	Version       int             `json:"version"`
+	CreatedAt     time.Time       `json:"created_at"`
+	Rig           string          `json:"rig"`
+	Entry         config.RigEntry `json:"entry"` // mayor/rigs.json entry at archive time
+	DefaultBranch string          `json:"default_branch,omitempty"`
+	BeadsPrefix   string          `json:"beads_prefix,omitempty"`
+	Files         []string        `json:"files"`             // rig-relative state files
+	Bundles       []RigBundle     `json:"bundles,omitempty"` // one per repo with branches
+}
+
+// RigBundle is a git bundle of the local branches of one of a rig's repos.
+type RigBundle struct {
+	Name     string   `json:"name"` // "repo", "mayor" or "crew-<name>"
+	Repo     string   `json:"repo"` // rig-relative repo path
+	Branches []string `json:"branches"`
+}
+
+// RigArchiveOptions describes the rig being archived.
+type RigArchiveOptions struct {
+	Entry         config.RigEntry
+	DefaultBranch string
+	BeadsPrefix   string
+
+	// ScratchDir is where git bundles are built before being archived.
+	ScratchDir string
+}
+
+// ArchiveRig writes an archive of rig name in townRoot to w as a gzipped
+// tar: its settings, polecat name pool, overlay and setup hooks, and a git
+// bundle of the local branches of each of its repos (the shared bare repo,
+// the mayor clone and crew clones). Uncommitted changes are not archived.
+func ArchiveRig(w io.Writer, townRoot, name string, opts RigArchiveOptions) (*RigManifest, error) {
+	rigPath := filepath.Join(townRoot, name)
+	files, err := rigRestorableFiles(townRoot, name)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &RigManifest{
+		Version:       FormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		Rig:           name,
+		Entry:         opts.Entry,
+		DefaultBranch: opts.DefaultBranch,
+		BeadsPrefix:   opts.BeadsPrefix,
+		Files:         files,
+	}
+
+	for _, repo := range rigRepos(rigPath) {
+		branches, err := localBranches(filepath.Join(rigPath, repo.Repo))
+		if err != nil {
+			return nil, fmt.Errorf("listing branches of %s: %w", repo.Repo, err)
+		}
+		if len(branches) == 0 {
+			continue
+		}
+		out := filepath.Join(opts.ScratchDir, repo.Name+".bundle")
+		if err := runGit(filepath.Join(rigPath, repo.Repo), "bundle", "create", out, "--branches"); err != nil {
+			return nil, fmt.Errorf("bundling %s: %w", repo.Repo, err)
+		}
+		repo.Branches = branches
+		manifest.Bundles = append(manifest.Bundles, repo)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeMember(tw, manifestName, manifestData, 0644, manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+	for _, rel := range files {
+		p := filepath.Join(rigPath, rel)
+		data, err := os.ReadFile(p) //nolint:gosec // G304: files found under the rig
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", p, err)
+		}
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, err
+		}
+		if err := writeMember(tw, path.Join(rigSection, filepath.ToSlash(rel)), data, info.Mode().Perm(), manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	for _, b := range manifest.Bundles {
+		if err := writeFileMember(tw, path.Join(gitSection, b.Name+".bundle"), filepath.Join(opts.ScratchDir, b.Name+".bundle"), manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// writeFileMember streams the file at src into the archive; bundles can be
+// too large to hold in memory.
+func writeFileMember(tw *tar.Writer, name, src string, modTime time.Time) error {
+	f, err := os.Open(src) //nolint:gosec // G304: bundle built in the scratch dir
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	hdr := &tar.Header{Name: name, Mode: 0644, Size: info.Size(), ModTime: modTime}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	if _, err := io.Copy(tw, f); err != nil {
+		return fmt.Errorf("writing %s: %w", name, err)
+	}
+	return nil
+}
+
+// rigRestorableFiles lists the rig's state files, rig-relative, that a
+// restore puts back. config.json and beads metadata are left out: adding
+// the rig again recreates them.
+func rigRestorableFiles(townRoot, name string) ([]string, error) {
+	all, err := rigStateFiles(townRoot, name)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, f := range all {
+		rel, err := filepath.Rel(name, f)
+		if err != nil {
+			return nil, err
+		}
+		if rel == constants.FileConfigJSON || strings.HasPrefix(rel, constants.DirBeads+string(filepath.Separator)) {
+			continue
+		}
+		files = append(files, rel)
+	}
+	return files, nil
+}
+
+// rigRepos lists the rig's git repos: the shared bare repo, the mayor clone
+// and each crew clone.
+func rigRepos(rigPath string) []RigBundle {
+	var repos []RigBundle
+	if isDir(filepath.Join(rigPath, ".repo.git")) {
+		repos = append(repos, RigBundle{Name: "repo", Repo: ".repo.git"})
+	}
+	if exists(filepath.Join(rigPath, "mayor", "rig", ".git")) {
+		repos = append(repos, RigBundle{Name: "mayor", Repo: filepath.Join("mayor", "rig")})
+	}
+	entries, _ := os.ReadDir(filepath.Join(rigPath, "crew"))
+	for _, e := range entries {
+		if e.IsDir() && exists(filepath.Join(rigPath, "crew", e.Name(), ".git")) {
+			repos = append(repos, RigBundle{Name: "crew-" + e.Name(), Repo: filepath.Join("crew", e.Name())})
+		}
+	}
+	return repos
+}
+
+// ReadRigManifest returns a rig archive's manifest without extracting it.
+func ReadRigManifest(r io.Reader) (*RigManifest, error) {
+	tr, closeFn, err := openRigArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+	return readRigManifest(tr)
+}
+
+// ExtractRig unpacks a rig archive: state files into rigPath, overwriting
+// what is there, and git bundles into bundleDir.
+func ExtractRig(r io.Reader, rigPath, bundleDir string) (*RigManifest, error) {
+	tr, closeFn, err := openRigArchive(r)
+	if err != nil {
+		return nil, err
+	}
+	defer closeFn()
+	manifest, err := readRigManifest(tr)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+		}
+		section, rel, _ := strings.Cut(hdr.Name, "/")
+		if hdr.Typeflag != tar.TypeReg || !validRel(rel) {
+			return nil, fmt.Errorf("%w: unsafe member %q", ErrInvalidArchive, hdr.Name)
+		}
+		switch section {
+		case rigSection:
+			if hdr.Size > maxMemberSize {
+				return nil, fmt.Errorf("%w: %s is too large (%d bytes)", ErrInvalidArchive, hdr.Name, hdr.Size)
+			}
+			data, err := io.ReadAll(tr)
+			if err != nil {
+				return nil, fmt.Errorf("%w: reading %s: %v", ErrInvalidArchive, hdr.Name, err)
+			}
+			mode := fs.FileMode(hdr.Mode).Perm()
+			if mode == 0 {
+				mode = 0644
+			}
+			p := filepath.Join(rigPath, filepath.FromSlash(rel))
+			if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+				return nil, fmt.Errorf("creating %s: %w", filepath.Dir(p), err)
+			}
+			if err := util.AtomicWriteFile(p, data, mode); err != nil {
+				return nil, fmt.Errorf("writing %s: %w", p, err)
+			}
+		case gitSection:
+			if strings.Contains(rel, "/") {
+				return nil, fmt.Errorf("%w: unsafe member %q", ErrInvalidArchive, hdr.Name)
+			}
+			if err := copyToFile(filepath.Join(bundleDir, rel), tr); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%w: unexpected member %q", ErrInvalidArchive, hdr.Name)
+		}
+	}
+	return manifest, nil
+}
+
+// RestoreBundle creates the branches of bundle b, extracted to bundleDir, in
+// the repo at repoDir, named prefix+branch. Branches that already exist
+// with other commits are left alone and returned as kept.
+func RestoreBundle(b RigBundle, bundleDir, repoDir, prefix string) (restored, kept []string, err error) {
+	bundle := filepath.Join(bundleDir, b.Name+".bundle")
+	const staging = "refs/gt-restore/"
+	if err := runGit(repoDir, "fetch", "--no-tags", bundle, "+refs/heads/*:"+staging+"*"); err != nil {
+		return nil, nil, fmt.Errorf("fetching %s: %w", b.Name, err)
+	}
+	defer func() {
+		for _, branch := range b.Branches {
+			_ = runGit(repoDir, "update-ref", "-d", staging+branch)
+		}
+	}()
+	for _, branch := range b.Branches {
+		archived, err := gitOutput(repoDir, "rev-parse", "--verify", staging+branch)
+		if err != nil {
+			return restored, kept, fmt.Errorf("%s: %w", branch, err)
+		}
+		target := "refs/heads/" + prefix + branch
+		if current, err := gitOutput(repoDir, "rev-parse", "--verify", "--quiet", target); err == nil {
+			if current != archived {
+				kept = append(kept, prefix+branch)
+			}
+			continue
+		}
+		if err := runGit(repoDir, "update-ref", target, archived); err != nil {
+			return restored, kept, fmt.Errorf("creating %s: %w", prefix+branch, err)
+		}
+		restored = append(restored, prefix+branch)
+	}
+	return restored, kept, nil
+}
+
+func openRigArchive(r io.Reader) (*tar.Reader, func(), error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrInvalidArchive, err)
+	}
+	return tar.NewReader(gz), func() { _ = gz.Close() }, nil
+}
+
+func readRigManifest(tr *tar.Reader) (*RigManifest, error) {
+	hdr, err := tr.Next()
+	if err != nil || hdr.Name != manifestName {
+		return nil, fmt.Errorf("%w: missing %s", ErrInvalidArchive, manifestName)
+	}
+	data, err := io.ReadAll(io.LimitReader(tr, maxMemberSize))
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading %s: %v", ErrInvalidArchive, manifestName, err)
+	}
+	manifest := &RigManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("%w: parsing %s: %v", ErrInvalidArchive, manifestName, err)
+	}
+	if manifest.Rig == "" {
+		return nil, fmt.Errorf("%w: not a rig archive", ErrInvalidArchive)
+	}
+	if manifest.Version > FormatVersion {
+		return nil, fmt.Errorf("%w: format version %d, max supported %d (upgrade gt)", ErrInvalidArchive, manifest.Version, FormatVersion)
+	}
+	return manifest, nil
+}
+
+func validRel(rel string) bool {
+	if rel == "" || path.IsAbs(rel) || strings.Contains(rel, `\`) || path.Clean(rel) != rel {
+		return false
+	}
+	return rel != ".." && !strings.HasPrefix(rel, "../")
+}
+
+func copyToFile(dst string, r io.Reader) error {
+	f, err := os.Create(dst) //nolint:gosec // G304: bundle dir chosen by the caller
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing %s: %w", dst, err)
+	}
+	return f.Close()
+}
+
+func localBranches(repoDir string) ([]string, error) {
+	out, err := gitOutput(repoDir, "for-each-ref", "--format=%(refname:short)", "refs/heads")
+	if err != nil || out == "" {
+		return nil, err
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+func runGit(dir string, args ...string) error {
+	_, err := gitOutput(dir, args...)
+	return err
+}
+
+func gitOutput(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("git %s: %w", args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+func isDir(p string) bool {
+	info, err := os.Stat(p)
+	return err == nil && info.IsDir()
+}
+
+func exists(p string) bool {
+	_, err := os.Stat(p)
+	return err == nil
+}
@@ -0,0 +1,101 @@
+package backup
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestArchiveAndRestoreRig(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	git := func(dir string, args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return string(bytes.TrimSpace(out))
+	}
+
+	base := t.TempDir()
+	src := filepath.Join(base, "src")
+	git(base, "init", "-q", "-b", "main", src)
+	git(src, "commit", "-q", "--allow-empty", "-m", "init")
+
+	townRoot := filepath.Join(base, "town")
+	rigPath := filepath.Join(townRoot, "alpha")
+	writeFile(t, townRoot, "alpha/config.json", `{"type":"rig","name":"alpha"}`)
+	writeFile(t, townRoot, "alpha/settings/config.json", `{"type":"rig-settings"}`)
+	writeFile(t, townRoot, "alpha/.runtime/namepool-state.json", `{"in_use":["toast"]}`)
+	git(base, "clone", "-q", "--bare", src, filepath.Join(rigPath, ".repo.git"))
+	// A polecat branch with work that only exists in the rig.
+	git(src, "checkout", "-q", "-b", "polecat/toast")
+	git(src, "commit", "-q", "--allow-empty", "-m", "toast work")
+	git(filepath.Join(rigPath, ".repo.git"), "fetch", "-q", src, "polecat/toast:polecat/toast")
+	git(base, "clone", "-q", src, filepath.Join(rigPath, "crew", "max"))
+	git(filepath.Join(rigPath, "crew", "max"), "checkout", "-q", "-b", "spike")
+	git(filepath.Join(rigPath, "crew", "max"), "commit", "-q", "--allow-empty", "-m", "spike")
+
+	var buf bytes.Buffer
+	manifest, err := ArchiveRig(&buf, townRoot, "alpha", RigArchiveOptions{
+		Entry:       config.RigEntry{GitURL: src},
+		BeadsPrefix: "al",
+		ScratchDir:  t.TempDir(),
+	})
+	if err != nil {
+		t.Fatalf("ArchiveRig: %v", err)
+	}
+	if !slices.Equal(manifest.Files, []string{filepath.Join("settings", "config.json"), filepath.Join(".runtime", "namepool-state.json")}) {
+		t.Errorf("Files = %v", manifest.Files)
+	}
+	if len(manifest.Bundles) != 2 || manifest.Bundles[0].Name != "repo" || manifest.Bundles[1].Name != "crew-max" {
+		t.Fatalf("Bundles = %+v", manifest.Bundles)
+	}
+
+	got, err := ReadRigManifest(bytes.NewReader(buf.Bytes()))
+	if err != nil || got.Rig != "alpha" || got.BeadsPrefix != "al" {
+		t.Fatalf("ReadRigManifest = %+v, %v", got, err)
+	}
+
+	// Restore into a freshly added rig: the remote has main only.
+	newRig := filepath.Join(base, "restored")
+	git(src, "checkout", "-q", "main")
+	git(src, "branch", "-q", "-D", "polecat/toast")
+	git(base, "clone", "-q", "--bare", src, filepath.Join(newRig, ".repo.git"))
+	bundles := t.TempDir()
+	if _, err := ExtractRig(bytes.NewReader(buf.Bytes()), newRig, bundles); err != nil {
+		t.Fatalf("ExtractRig: %v", err)
+	}
+	if data, err := os.ReadFile(filepath.Join(newRig, ".runtime", "namepool-state.json")); err != nil || string(data) != `{"in_use":["toast"]}` {
+		t.Errorf("namepool state = %q, %v", data, err)
+	}
+
+	bare := filepath.Join(newRig, ".repo.git")
+	restored, kept, err := RestoreBundle(manifest.Bundles[0], bundles, bare, "")
+	if err != nil {
+		t.Fatalf("RestoreBundle: %v", err)
+	}
+	if !slices.Equal(restored, []string{"polecat/toast"}) || len(kept) != 0 {
+		t.Errorf("restored = %v, kept = %v", restored, kept)
+	}
+	restored, _, err = RestoreBundle(manifest.Bundles[1], bundles, bare, "crew/max/")
+	if err != nil {
+		t.Fatalf("RestoreBundle crew: %v", err)
+	}
+	if !slices.Contains(restored, "crew/max/spike") {
+		t.Errorf("crew restored = %v", restored)
+	}
+	if refs := git(bare, "for-each-ref", "refs/gt-restore"); refs != "" {
+		t.Errorf("staging refs left behind: %s", refs)
+	}
+}
@@ -298,6 +298,8 @@ type CreateOptions struct {
 	Parent      string
 	Actor       string // Who is creating this issue (populates created_by)
 	Ephemeral   bool   // Create as ephemeral (wisp) - not synced to git
+
+	AcceptanceCriteria string // Markdown checklist ("- [ ] ..." lines)
 }
 
 // UpdateOptions specifies options for updating an issue.
@@ -408,7 +410,45 @@ func (b *Beads) Init(prefix string) error {
 }
 
 // run executes a bd command and returns stdout.
-func (b *Beads) run(args ...string) (_ []byte, retErr error) {
+//
+// Mutations queued while the database was unreachable are replayed first,
+// so commands apply in the order they were issued. If a queueable mutation
+// fails because bd can't reach the database, it is journaled for replay
+// and run reports success (see offline_queue.go).
+func (b *Beads) run(args ...string) ([]byte, error) {
+	var out []byte
+	var err error
+	if _, replayErr := b.ReplayQueue(); isOfflineError(replayErr) && queueableMutation(args) {
+		err = replayErr // Still offline; don't let this mutation jump the queue
+	} else {
+		out, err = b.runBD(args...)
+	}
+
+	if err != nil && b.queueEnabled() && queueableMutation(args) && isOfflineError(err) {
+		if qErr := b.enqueue(args, err); qErr == nil {
+			fmt.Fprintf(os.Stderr, "Warning: beads unreachable; queued bd %s for replay\n", strings.Join(args, " "))
+			return nil, nil
+		}
+	}
+	return out, err
+}
+
+// runBD executes a bd command and returns stdout, without the offline queue.
+func (b *Beads) runBD(args ...string) (_ []byte, retErr error) {
+	// Serve repeated agent-work and ready-queue reads from the query cache;
+	// any write invalidates it.
+	queryArgs := args
+	var queryGen string
+	if CacheableQuery(queryArgs) {
+		if out, ok := b.cachedQuery(queryArgs); ok {
+			return out, nil
+		}
+		queryGen = b.queryGeneration()
+	}
+	if mutatingQuery(queryArgs) {
+		defer b.invalidateQueryCache()
+	}
+
 	start := time.Now()
 	// Declare buffers before defer so the closure captures them after cmd.Run.
 	var stdout, stderr bytes.Buffer
@@ -478,7 +518,9 @@ func (b *Beads) run(args ...string) (_ []byte, retErr error) {
 		return nil, b.wrapError(fmt.Errorf("command produced no output"), stderr.String(), args)
 	}
 
-	return stripStdoutWarnings(stdout.Bytes()), nil
+	out := stripStdoutWarnings(stdout.Bytes())
+	b.storeQuery(queryArgs, queryGen, out)
+	return out, nil
 }
 
 // runWithRouting executes a bd command without setting BEADS_DIR, allowing bd's
@@ -487,6 +529,9 @@ func (b *Beads) run(args ...string) (_ []byte, retErr error) {
 // (e.g., setting an hq-* hook bead on a gt-* agent bead).
 // See: sling_helpers.go verifyBeadExists/hookBeadWithRetry for the same pattern.
 func (b *Beads) runWithRouting(args ...string) (_ []byte, retErr error) { //nolint:unparam // mirrors run() signature for consistency
+	if mutatingQuery(args) {
+		defer b.invalidateRoutedQueryCache(args)
+	}
 	start := time.Now()
 	var stdout, stderr bytes.Buffer
 	defer func() {
@@ -1225,6 +1270,9 @@ func (b *Beads) Create(opts CreateOptions) (*Issue, error) {
 	if opts.Ephemeral {
 		args = append(args, "--ephemeral")
 	}
+	if opts.AcceptanceCriteria != "" {
+		args = append(args, "--acceptance="+opts.AcceptanceCriteria)
+	}
 	// Default Actor from BD_ACTOR env var if not specified
 	// Uses getActor() to respect isolated mode (tests)
 	actor := opts.Actor
@@ -0,0 +1,54 @@
+package beads
+
+import (
+	"context"
+	"time"
+)
+
+// Client is the set of bead reads and writes gt commands and patrols need.
+// It lets callers depend on typed results instead of parsing bd output.
+//
+// *Beads implements Client: with an in-process store set it reads and
+// writes the database directly, otherwise it runs the bd CLI and decodes
+// its JSON. OpenClient picks the best available backend.
+//
+// The in-process backend does not see agent slot columns (HookBead,
+// AgentState); read agent beads with New so they come from bd.
+type Client interface {
+	Show(id string) (*Issue, error)
+	ShowMultiple(ids []string) (map[string]*Issue, error)
+	List(opts ListOptions) ([]*Issue, error)
+	Update(id string, opts UpdateOptions) error
+	Close(ids ...string) error
+}
+
+var _ Client = (*Beads)(nil)
+
+// openClientTimeout bounds connecting to the beads database. A slow or
+// unreachable database falls back to the bd CLI rather than stalling.
+const openClientTimeout = 5 * time.Second
+
+// openStoreForClient is swapped out in tests.
+var openStoreForClient = func(b *Beads) (func(), error) {
+	ctx, cancel := context.WithTimeout(context.Background(), openClientTimeout)
+	defer cancel()
+	store, cleanup, err := b.OpenStore(ctx)
+	if err != nil {
+		return nil, err
+	}
+	b.SetStore(store)
+	return cleanup, nil
+}
+
+// OpenClient returns a Client for workDir's beads database. It opens the
+// database in-process when it can and falls back to the bd CLI when it
+// can't (no database found, server down, incompatible schema). Call the
+// returned cleanup when done; it is never nil.
+func OpenClient(workDir string) (Client, func()) {
+	b := New(workDir)
+	cleanup, err := openStoreForClient(b)
+	if err != nil {
+		return b, func() {}
+	}
+	return b, cleanup
+}
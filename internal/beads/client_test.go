@@ -0,0 +1,43 @@
+package beads
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOpenClient_FallsBackToCLI(t *testing.T) {
+	orig := openStoreForClient
+	t.Cleanup(func() { openStoreForClient = orig })
+	openStoreForClient = func(*Beads) (func(), error) {
+		return nil, errors.New("no database")
+	}
+
+	client, cleanup := OpenClient(t.TempDir())
+	if cleanup == nil {
+		t.Fatal("cleanup must never be nil")
+	}
+	defer cleanup()
+
+	b, ok := client.(*Beads)
+	if !ok {
+		t.Fatalf("client is %T, want *Beads", client)
+	}
+	if b.Store() != nil {
+		t.Error("fallback client should have no in-process store")
+	}
+}
+
+func TestOpenClient_UsesStore(t *testing.T) {
+	orig := openStoreForClient
+	t.Cleanup(func() { openStoreForClient = orig })
+	closed := false
+	openStoreForClient = func(*Beads) (func(), error) {
+		return func() { closed = true }, nil
+	}
+
+	_, cleanup := OpenClient(t.TempDir())
+	cleanup()
+	if !closed {
+		t.Error("cleanup should close the in-process store")
+	}
+}
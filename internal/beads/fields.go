@@ -594,6 +594,10 @@ type MRFields struct {
 	PreVerified     bool   // Polecat ran full gates after rebasing onto target
 	PreVerifiedAt   string // ISO 8601 timestamp when verification completed
 	PreVerifiedBase string // Target branch SHA at verification time
+
+	// TraceParent is the W3C trace context of the polecat's gt done, so the
+	// refinery's merge span joins the polecat's trace.
+	TraceParent string
 }
 
 // ParseMRFields extracts structured merge-request fields from an issue's description.
@@ -680,6 +684,9 @@ func ParseMRFields(issue *Issue) *MRFields {
 		case "pre_verified_base", "pre-verified-base", "preverifiedbase":
 			fields.PreVerifiedBase = value
 			hasFields = true
+		case "trace_parent", "trace-parent", "traceparent":
+			fields.TraceParent = value
+			hasFields = true
 		}
 	}
 
@@ -756,6 +763,9 @@ func FormatMRFields(fields *MRFields) string {
 	if fields.PreVerifiedBase != "" {
 		lines = append(lines, "pre_verified_base: "+fields.PreVerifiedBase)
 	}
+	if fields.TraceParent != "" {
+		lines = append(lines, "trace_parent: "+fields.TraceParent)
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -811,6 +821,9 @@ func SetMRFields(issue *Issue, fields *MRFields) string {
 		"pre_verified_base":  true,
 		"pre-verified-base":  true,
 		"preverifiedbase":    true,
+		"trace_parent":       true,
+		"trace-parent":       true,
+		"traceparent":        true,
 	}
 
 	// Collect non-MR lines from existing description
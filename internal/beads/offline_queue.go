@@ -0,0 +1,299 @@
+package beads
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// Offline mutation queue.
+//
+// Agents on flaky connections lose status updates when bd can't reach the
+// database: a polecat marks its bead done, bd fails with "connection
+// refused", and the update is gone. Instead, run() appends mutations that
+// fail for connectivity reasons to an append-only journal and reports
+// success. There is one journal per beads database, kept under the town's
+// .runtime rather than in the worktree, so it outlives the polecat
+// worktrees that gt done and nuke remove. The journal is replayed, in
+// order, before the next bd command against that database, by the daemon
+// heartbeat, and by hand with gt bead flush.
+//
+// Only fire-and-forget mutations are queued (status updates, closes,
+// labels, dependencies, comments). Creates are never queued because the
+// caller needs the new ID back.
+
+// QueueDirName is the directory under the town's .runtime that holds the
+// journals, one <escaped beads dir>.jsonl per database.
+const QueueDirName = "bead-queue"
+
+const (
+	queueExt       = ".jsonl"
+	queueFailedExt = ".failed.jsonl" // Mutations bd rejected on replay
+)
+
+// QueuedMutation is one bd command waiting to be replayed.
+type QueuedMutation struct {
+	At       time.Time `json:"at"`
+	Args     []string  `json:"args"`
+	BeadsDir string    `json:"beads_dir,omitempty"`
+	Error    string    `json:"error,omitempty"` // Why it was queued (or why replay failed)
+}
+
+// queueableCommands are the bd subcommands safe to defer: their output is
+// not used and replaying them later has the same effect.
+var queueableCommands = map[string]bool{
+	"update":   true,
+	"close":    true,
+	"reopen":   true,
+	"label":    true,
+	"dep":      true,
+	"comments": true,
+}
+
+// queueableMutation reports whether args may be deferred to the queue.
+func queueableMutation(args []string) bool {
+	if len(args) == 0 || !queueableCommands[args[0]] {
+		return false
+	}
+	for _, a := range args {
+		if a == "--json" {
+			return false // Caller parses the output
+		}
+	}
+	return true
+}
+
+// isOfflineError reports whether err means bd couldn't reach the database,
+// as opposed to bd rejecting the command.
+func isOfflineError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	for _, pattern := range []string{
+		"connection refused",
+		"connection reset",
+		"dial tcp",
+		"i/o timeout",
+		"no route to host",
+		"network is unreachable",
+		"server unreachable",
+		"broken pipe",
+		"bad connection",
+		"invalid connection",
+	} {
+		if strings.Contains(msg, pattern) {
+			return true
+		}
+	}
+	return false
+}
+
+// QueuePath returns the mutation journal path for the beads database at
+// beadsDir. The file name is beadsDir relative to townRoot, path-escaped,
+// so FindQueues can recover it.
+func QueuePath(townRoot, beadsDir string) string {
+	key := beadsDir
+	if rel, err := filepath.Rel(townRoot, beadsDir); err == nil && !strings.HasPrefix(rel, "..") {
+		key = rel
+	}
+	return filepath.Join(townRoot, constants.DirRuntime, QueueDirName, url.PathEscape(filepath.ToSlash(key))+queueExt)
+}
+
+// queuePath returns the journal path for this wrapper's database.
+func (b *Beads) queuePath() string {
+	return QueuePath(b.getTownRoot(), b.getResolvedBeadsDir())
+}
+
+// queueEnabled reports whether this wrapper journals failed mutations.
+// Isolated (test) instances, and wrappers outside a town, never do.
+func (b *Beads) queueEnabled() bool {
+	return !b.isolated && b.workDir != "" && b.getTownRoot() != ""
+}
+
+// lockQueue takes the journal's lock. Callers must call the returned unlock.
+func lockQueue(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	fl := flock.New(path + ".lock")
+	if err := fl.Lock(); err != nil {
+		return nil, fmt.Errorf("acquiring bead queue lock: %w", err)
+	}
+	return func() { _ = fl.Unlock() }, nil
+}
+
+// enqueue appends a mutation to the database's journal.
+func (b *Beads) enqueue(args []string, cause error) error {
+	path := b.queuePath()
+	unlock, err := lockQueue(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	m := QueuedMutation{At: time.Now(), Args: args, BeadsDir: b.getResolvedBeadsDir()}
+	if cause != nil {
+		m.Error = cause.Error()
+	}
+	return appendQueued(path, m)
+}
+
+func appendQueued(path string, m QueuedMutation) error {
+	data, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644) //nolint:gosec // G302: journal, not secret
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// LoadQueue returns the mutations queued for the beads database at
+// beadsDir, oldest first.
+func LoadQueue(townRoot, beadsDir string) ([]QueuedMutation, error) {
+	return loadQueueFile(QueuePath(townRoot, beadsDir))
+}
+
+func loadQueueFile(path string) ([]QueuedMutation, error) {
+	f, err := os.Open(path) //nolint:gosec // G304: path is constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var queued []QueuedMutation
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var m QueuedMutation
+		if err := json.Unmarshal([]byte(line), &m); err != nil || len(m.Args) == 0 {
+			continue // Torn write from a crash; nothing to replay
+		}
+		queued = append(queued, m)
+	}
+	return queued, scanner.Err()
+}
+
+// hasQueue reports whether the database has mutations waiting.
+func (b *Beads) hasQueue() bool {
+	if !b.queueEnabled() {
+		return false
+	}
+	info, err := os.Stat(b.queuePath())
+	return err == nil && info.Size() > 0
+}
+
+// ReplayQueue runs the database's queued mutations in order. It stops at
+// the first one that still can't reach the database, leaving it and the
+// rest queued. Mutations bd rejects outright are moved to
+// <journal>.failed.jsonl so one bad entry can't block the queue.
+// Returns the number replayed.
+func (b *Beads) ReplayQueue() (int, error) {
+	if !b.hasQueue() {
+		return 0, nil
+	}
+	path := b.queuePath()
+	unlock, err := lockQueue(path)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
+	queued, err := loadQueueFile(path)
+	if err != nil {
+		return 0, fmt.Errorf("reading bead queue: %w", err)
+	}
+
+	replayed := 0
+	var replayErr error
+	for len(queued) > 0 {
+		m := queued[0]
+		_, err := b.runBD(m.Args...)
+		if isOfflineError(err) {
+			replayErr = err
+			break
+		}
+		if err != nil {
+			m.Error = err.Error()
+			_ = appendQueued(strings.TrimSuffix(path, queueExt)+queueFailedExt, m)
+			fmt.Fprintf(os.Stderr, "Warning: dropped queued bd %s: %v\n", strings.Join(m.Args, " "), err)
+		} else {
+			replayed++
+		}
+		queued = queued[1:]
+	}
+
+	if err := rewriteQueue(path, queued); err != nil {
+		return replayed, fmt.Errorf("rewriting bead queue: %w", err)
+	}
+	return replayed, replayErr
+}
+
+// rewriteQueue atomically replaces the journal with the remaining entries.
+func rewriteQueue(path string, queued []QueuedMutation) error {
+	if len(queued) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	var buf strings.Builder
+	for _, m := range queued {
+		data, err := json.Marshal(m)
+		if err != nil {
+			return err
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(buf.String()), 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// FindQueues returns the beads directories in townRoot that have queued
+// mutations waiting.
+func FindQueues(townRoot string) []string {
+	matches, _ := filepath.Glob(filepath.Join(townRoot, constants.DirRuntime, QueueDirName, "*"+queueExt))
+	var dirs []string
+	for _, m := range matches {
+		name := filepath.Base(m)
+		if strings.HasSuffix(name, queueFailedExt) {
+			continue
+		}
+		key, err := url.PathUnescape(strings.TrimSuffix(name, queueExt))
+		if err != nil {
+			continue
+		}
+		dir := filepath.FromSlash(key)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(townRoot, dir)
+		}
+		dirs = append(dirs, dir)
+	}
+	return dirs
+}
@@ -0,0 +1,220 @@
+package beads
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+// installMockBDOffline installs a bd that fails with a connection error
+// while the returned flag file exists, and otherwise logs its args.
+func installMockBDOffline(t *testing.T) (offlineFlag, logPath string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("mock bd script requires sh")
+	}
+	binDir := t.TempDir()
+	offlineFlag = filepath.Join(binDir, "offline")
+	logPath = filepath.Join(binDir, "bd.log")
+	script := `#!/bin/sh
+if [ -f "` + offlineFlag + `" ]; then
+  echo "dial tcp 127.0.0.1:3307: connect: connection refused" >&2
+  exit 1
+fi
+echo "$*" >> "` + logPath + `"
+echo ok
+`
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return offlineFlag, logPath
+}
+
+func TestQueueableMutation(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"update", "gt-1", "--status=closed"}, true},
+		{[]string{"close", "gt-1", "--reason=done"}, true},
+		{[]string{"label", "add", "gt-1", "x"}, true},
+		{[]string{"create", "--title=x"}, false},
+		{[]string{"update", "gt-1", "--json"}, false},
+		{[]string{"list"}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := queueableMutation(tt.args); got != tt.want {
+			t.Errorf("queueableMutation(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestIsOfflineError(t *testing.T) {
+	if !isOfflineError(errors.New("bd update: dial tcp 127.0.0.1:3307: connect: connection refused")) {
+		t.Error("connection refused should be offline")
+	}
+	if isOfflineError(ErrNotFound) {
+		t.Error("not found should not be offline")
+	}
+	if isOfflineError(nil) {
+		t.Error("nil should not be offline")
+	}
+}
+
+// newQueueTown creates a town with a rig whose polecat worktree redirects
+// to the rig's beads, and returns the town root, the worktree and the rig's
+// beads dir.
+func newQueueTown(t *testing.T) (townRoot, workDir, beadsDir string) {
+	t.Helper()
+	townRoot = t.TempDir()
+	beadsDir = filepath.Join(townRoot, "gastown", ".beads")
+	workDir = filepath.Join(townRoot, "gastown", "polecats", "nux")
+	for _, dir := range []string{filepath.Join(townRoot, "mayor"), beadsDir, filepath.Join(workDir, ".beads")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, ".beads", "redirect"), []byte("../../.beads"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot, workDir, beadsDir
+}
+
+func readBDLog(t *testing.T, logPath string) string {
+	t.Helper()
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestOfflineQueue_QueuesAndReplaysInOrder(t *testing.T) {
+	offlineFlag, logPath := installMockBDOffline(t)
+	townRoot, workDir, beadsDir := newQueueTown(t)
+	b := New(workDir)
+
+	if err := os.WriteFile(offlineFlag, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.run("update", "gt-1", "--status=in_progress"); err != nil {
+		t.Fatalf("queued update returned error: %v", err)
+	}
+	if _, err := b.run("close", "gt-1", "--reason=done"); err != nil {
+		t.Fatalf("queued close returned error: %v", err)
+	}
+	if _, err := b.run("create", "--title=x"); err == nil {
+		t.Fatal("create should fail when offline, not be queued")
+	}
+
+	queued, err := LoadQueue(townRoot, beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(queued) != 2 || queued[0].Args[0] != "update" || queued[1].Args[0] != "close" {
+		t.Fatalf("queue = %+v, want update then close", queued)
+	}
+
+	// Back online: the next command replays the queue first.
+	if err := os.Remove(offlineFlag); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.run("label", "add", "gt-1", "done"); err != nil {
+		t.Fatalf("run after reconnect: %v", err)
+	}
+
+	var calls []string
+	for _, line := range strings.Split(strings.TrimSpace(readBDLog(t, logPath)), "\n") {
+		for _, cmd := range []string{"update", "close", "label"} {
+			if strings.Contains(line, cmd+" gt-1") || strings.Contains(line, cmd+" add gt-1") {
+				calls = append(calls, cmd)
+			}
+		}
+	}
+	if strings.Join(calls, ",") != "update,close,label" {
+		t.Errorf("bd calls = %v, want update,close,label", calls)
+	}
+	if _, err := os.Stat(QueuePath(townRoot, beadsDir)); !os.IsNotExist(err) {
+		t.Errorf("queue file should be removed after replay, stat err = %v", err)
+	}
+}
+
+func TestOfflineQueue_SurvivesWorktreeRemoval(t *testing.T) {
+	offlineFlag, logPath := installMockBDOffline(t)
+	townRoot, workDir, beadsDir := newQueueTown(t)
+
+	if err := os.WriteFile(offlineFlag, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(workDir).run("close", "gt-1", "--reason=done"); err != nil {
+		t.Fatalf("queued close returned error: %v", err)
+	}
+	// gt done / nuke remove the worktree; the queue lives in the town.
+	if err := os.RemoveAll(workDir); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(offlineFlag); err != nil {
+		t.Fatal(err)
+	}
+
+	dirs := FindQueues(townRoot)
+	if len(dirs) != 1 || dirs[0] != beadsDir {
+		t.Fatalf("FindQueues() = %v, want [%s]", dirs, beadsDir)
+	}
+	replayed, err := NewWithBeadsDir(filepath.Dir(dirs[0]), dirs[0]).ReplayQueue()
+	if err != nil || replayed != 1 {
+		t.Fatalf("ReplayQueue() = %d, %v; want 1, nil", replayed, err)
+	}
+	if !strings.Contains(readBDLog(t, logPath), "close gt-1") {
+		t.Error("queued close was not replayed")
+	}
+}
+
+func TestOfflineQueue_DisabledWhenIsolated(t *testing.T) {
+	offlineFlag, _ := installMockBDOffline(t)
+	if err := os.WriteFile(offlineFlag, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	townRoot, workDir, _ := newQueueTown(t)
+	if _, err := NewIsolated(workDir).run("update", "gt-1", "--status=closed"); err == nil {
+		t.Fatal("isolated instance should return the offline error")
+	}
+	if dirs := FindQueues(townRoot); len(dirs) != 0 {
+		t.Errorf("isolated instance should not write a queue, found %v", dirs)
+	}
+}
+
+func TestFindQueues(t *testing.T) {
+	townRoot := t.TempDir()
+	want := []string{
+		filepath.Join(townRoot, ".beads"),
+		filepath.Join(townRoot, "gastown", ".beads"),
+	}
+	for _, dir := range want {
+		path := QueuePath(townRoot, dir)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := appendQueued(path, QueuedMutation{Args: []string{"close", "gt-1"}}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Rejected entries are not a queue.
+	failed := strings.TrimSuffix(QueuePath(townRoot, want[0]), queueExt) + queueFailedExt
+	if err := appendQueued(failed, QueuedMutation{Args: []string{"close", "gt-2"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	got := FindQueues(townRoot)
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("FindQueues() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,234 @@
+package beads
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// Query is the standard bead filter shared by commands that select work
+// (gt ready, gt bead list, gt status, witness scans), so that each one
+// doesn't build its own bd arguments. The zero Query matches everything.
+type Query struct {
+	Statuses []string // Any of these statuses; empty means any
+	Assignee string   // Exact assignee; QueryUnassigned for none
+	Labels   []string // Every one of these labels
+	Age      Age      // Bound on time since creation
+}
+
+// QueryUnassigned as Query.Assignee matches beads with no assignee.
+const QueryUnassigned = "none"
+
+// Age bounds how long ago a bead was created. The zero Age matches
+// everything.
+type Age struct {
+	Duration time.Duration
+	Newer    bool // Created within Duration, rather than at least Duration ago
+}
+
+// ParseAge parses an age filter: "3d" or ">3d" means created at least three
+// days ago, "<2h" means created within the last two hours. Units are those
+// of time.ParseDuration plus d (days) and w (weeks).
+func ParseAge(s string) (Age, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Age{}, nil
+	}
+	var age Age
+	switch s[0] {
+	case '<':
+		age.Newer = true
+		s = s[1:]
+	case '>':
+		s = s[1:]
+	}
+
+	unit := time.Duration(0)
+	switch {
+	case strings.HasSuffix(s, "d"):
+		unit = 24 * time.Hour
+	case strings.HasSuffix(s, "w"):
+		unit = 7 * 24 * time.Hour
+	}
+	if unit != 0 {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil || n < 0 {
+			return Age{}, fmt.Errorf("invalid age %q", s)
+		}
+		age.Duration = time.Duration(n) * unit
+		return age, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return Age{}, fmt.Errorf("invalid age %q (e.g., 3d, >1w, <2h)", s)
+	}
+	age.Duration = d
+	return age, nil
+}
+
+// String returns the age in ParseAge syntax.
+func (a Age) String() string {
+	if a.Duration == 0 {
+		return ""
+	}
+	if a.Newer {
+		return "<" + a.Duration.String()
+	}
+	return ">" + a.Duration.String()
+}
+
+// IsZero reports whether q matches everything.
+func (q Query) IsZero() bool {
+	return len(q.Statuses) == 0 && q.Assignee == "" && len(q.Labels) == 0 && q.Age.Duration == 0
+}
+
+// Match reports whether issue satisfies q at time now. Beads whose creation
+// time can't be parsed never satisfy an age bound.
+func (q Query) Match(issue *Issue, now time.Time) bool {
+	if len(q.Statuses) > 0 && !slices.Contains(q.Statuses, issue.Status) {
+		return false
+	}
+	switch q.Assignee {
+	case "":
+	case QueryUnassigned:
+		if issue.Assignee != "" {
+			return false
+		}
+	default:
+		if issue.Assignee != q.Assignee {
+			return false
+		}
+	}
+	for _, l := range q.Labels {
+		if !HasLabel(issue, l) {
+			return false
+		}
+	}
+	if q.Age.Duration > 0 {
+		created, err := time.Parse(time.RFC3339, issue.CreatedAt)
+		if err != nil {
+			return false
+		}
+		age := now.Sub(created)
+		if q.Age.Newer != (age < q.Age.Duration) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the issues that satisfy q.
+func (q Query) Filter(issues []*Issue) []*Issue {
+	if q.IsZero() {
+		return issues
+	}
+	now := time.Now()
+	var out []*Issue
+	for _, issue := range issues {
+		if q.Match(issue, now) {
+			out = append(out, issue)
+		}
+	}
+	return out
+}
+
+// ListArgs returns bd list invocations covering q, one per status since bd
+// filters on a single status. Assignee and labels are filtered by bd; age is
+// not, so callers with an age bound must also apply Match.
+func (q Query) ListArgs() [][]string {
+	base := []string{"list", "--json", "--limit=0"}
+	switch q.Assignee {
+	case "":
+	case QueryUnassigned:
+		base = append(base, "--no-assignee")
+	default:
+		base = append(base, "--assignee="+q.Assignee)
+	}
+	for _, l := range q.Labels {
+		base = append(base, "--label="+l)
+	}
+
+	if len(q.Statuses) == 0 {
+		return [][]string{base}
+	}
+	calls := make([][]string, 0, len(q.Statuses))
+	for _, s := range q.Statuses {
+		calls = append(calls, append(slices.Clone(base), "--status="+s))
+	}
+	return calls
+}
+
+// Query returns the issues matching q, merging one List per status.
+func (b *Beads) Query(q Query) ([]*Issue, error) {
+	opts := ListOptions{Priority: -1}
+	switch q.Assignee {
+	case "":
+	case QueryUnassigned:
+		opts.NoAssignee = true
+	default:
+		opts.Assignee = q.Assignee
+	}
+	if len(q.Labels) > 0 {
+		opts.Label = q.Labels[0] // The rest are checked by Filter
+	}
+
+	statuses := q.Statuses
+	if len(statuses) == 0 {
+		statuses = []string{"all"}
+	}
+	seen := make(map[string]bool)
+	var issues []*Issue
+	for _, s := range statuses {
+		opts.Status = s
+		batch, err := b.List(opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, issue := range batch {
+			if !seen[issue.ID] {
+				seen[issue.ID] = true
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return q.Filter(issues), nil
+}
+
+// QueryFlags binds the standard filter flags (--status, --assignee,
+// --label, --age) to a command.
+type QueryFlags struct {
+	Status   []string
+	Assignee string
+	Labels   []string
+	Age      string
+}
+
+// Register adds the filter flags to fs.
+func (f *QueryFlags) Register(fs *pflag.FlagSet) {
+	fs.StringSliceVar(&f.Status, "status", nil, `Only beads with this status (repeatable or comma-separated; "all" for any)`)
+	fs.StringVar(&f.Assignee, "assignee", "", `Only beads assigned to this agent ("none" for unassigned)`)
+	fs.StringSliceVar(&f.Labels, "label", nil, "Only beads with this label (repeatable; all must match)")
+	fs.StringVar(&f.Age, "age", "", "Only beads created at least this long ago (3d, >1w), or within it (<2h)")
+}
+
+// Query returns the Query the flags describe.
+func (f *QueryFlags) Query() (Query, error) {
+	age, err := ParseAge(f.Age)
+	if err != nil {
+		return Query{}, fmt.Errorf("--age: %w", err)
+	}
+	statuses := f.Status
+	if slices.Contains(statuses, "all") {
+		statuses = nil
+	}
+	return Query{
+		Statuses: statuses,
+		Assignee: f.Assignee,
+		Labels:   f.Labels,
+		Age:      age,
+	}, nil
+}
@@ -0,0 +1,237 @@
+package beads
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// Bead query cache.
+//
+// prime, status, and the witness patrol ask the same questions over and
+// over — "what is hooked to this agent?", "what is ready?" — and each answer
+// costs a bd subprocess. The cache keeps the raw output of those queries for
+// a short TTL in the town's .runtime directory so every gt process shares it.
+//
+// Entries are keyed by the beads database and the exact query, and tagged
+// with the database's mutation generation. Every write gt makes through
+// Beads bumps the generation, so gt never reads back stale results of its
+// own changes; raw bd calls gt makes elsewhere (BdCmd, the witness) bump
+// it with InvalidateQueryCache. Writes made outside gt (an agent running bd
+// directly) are bounded by the TTL.
+
+// queryCacheDir returns the directory holding cache entries for a town.
+func queryCacheDir(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "bead-cache")
+}
+
+// queryCacheEntry is one cached bd query result.
+type queryCacheEntry struct {
+	Generation string    `json:"generation"`
+	At         time.Time `json:"at"`
+	Output     []byte    `json:"output"`
+}
+
+// queryCacheTTLs caches the configured TTL per town for the process.
+var queryCacheTTLs sync.Map // townRoot -> time.Duration
+
+func queryCacheTTL(townRoot string) time.Duration {
+	if v, ok := queryCacheTTLs.Load(townRoot); ok {
+		return v.(time.Duration)
+	}
+	ttl := config.LoadOperationalConfig(townRoot).GetDoltConfig().QueryCacheTTLD()
+	queryCacheTTLs.Store(townRoot, ttl)
+	return ttl
+}
+
+// CacheableQuery reports whether args is a bd read gt caches: hooked and
+// in-progress work (by status or by assignee) and the ready queue.
+func CacheableQuery(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	switch args[0] {
+	case "ready":
+		return true
+	case "list":
+		for _, a := range args[1:] {
+			switch {
+			case strings.HasPrefix(a, "--assignee"),
+				a == "--status=hooked", a == "--status=in_progress":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// readOnlyCommands are bd subcommands that never change the database. Any
+// other command invalidates the cache.
+var readOnlyCommands = map[string]bool{
+	"list": true, "ready": true, "show": true, "search": true, "query": true,
+	"blocked": true, "stats": true, "count": true, "status": true,
+	"version": true, "help": true, "prime": true, "where": true, "info": true,
+}
+
+// mutatingQuery reports whether running args may change the database.
+func mutatingQuery(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	return !readOnlyCommands[args[0]]
+}
+
+// queryCacheEnabled reports whether this wrapper may use the cache, and
+// returns the town root and beads directory to key it by.
+func (b *Beads) queryCacheEnabled() (townRoot, beadsDir string, ok bool) {
+	if b.isolated {
+		return "", "", false
+	}
+	townRoot = b.getTownRoot()
+	if townRoot == "" {
+		return "", "", false
+	}
+	return townRoot, b.getResolvedBeadsDir(), true
+}
+
+func queryCacheKey(beadsDir string, args []string) string {
+	sum := sha256.Sum256([]byte(beadsDir + "\x00" + strings.Join(args, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+func generationPath(townRoot, beadsDir string) string {
+	return filepath.Join(queryCacheDir(townRoot), queryCacheKey(beadsDir, nil)+".gen")
+}
+
+// readGeneration returns the database's current mutation generation.
+func readGeneration(townRoot, beadsDir string) string {
+	data, err := os.ReadFile(generationPath(townRoot, beadsDir)) //nolint:gosec // G304: path is under the town runtime dir
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+// cachedQuery returns the cached output of args, if fresh.
+func (b *Beads) cachedQuery(args []string) ([]byte, bool) {
+	townRoot, beadsDir, ok := b.queryCacheEnabled()
+	if !ok || !CacheableQuery(args) {
+		return nil, false
+	}
+	ttl := queryCacheTTL(townRoot)
+	if ttl <= 0 {
+		return nil, false
+	}
+
+	path := filepath.Join(queryCacheDir(townRoot), queryCacheKey(beadsDir, args)+".json")
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is under the town runtime dir
+	if err != nil {
+		return nil, false
+	}
+	var entry queryCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if time.Since(entry.At) > ttl || entry.Generation != readGeneration(townRoot, beadsDir) {
+		return nil, false
+	}
+	return entry.Output, true
+}
+
+// storeQuery records the output of args. gen is the generation read before
+// the query ran, so a write that lands mid-query leaves the entry stale.
+func (b *Beads) storeQuery(args []string, gen string, output []byte) {
+	townRoot, beadsDir, ok := b.queryCacheEnabled()
+	if !ok || !CacheableQuery(args) || queryCacheTTL(townRoot) <= 0 {
+		return
+	}
+	data, err := json.Marshal(queryCacheEntry{Generation: gen, At: time.Now(), Output: output})
+	if err != nil {
+		return
+	}
+	dir := queryCacheDir(townRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+	path := filepath.Join(dir, queryCacheKey(beadsDir, args)+".json")
+	tmp := path + ".tmp" + strconv.Itoa(os.Getpid())
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		_ = os.Remove(tmp)
+	}
+}
+
+// queryGeneration returns the generation to tag a query's result with.
+func (b *Beads) queryGeneration() string {
+	townRoot, beadsDir, ok := b.queryCacheEnabled()
+	if !ok {
+		return ""
+	}
+	return readGeneration(townRoot, beadsDir)
+}
+
+// InvalidateQueryCache marks cached queries stale after gt runs bd with
+// args outside Beads, from workDir. beadsDir is the BEADS_DIR the command
+// ran with; when empty, bd routes by bead prefix, so the database the write
+// was routed to is invalidated. A no-op unless args may write.
+func InvalidateQueryCache(workDir, beadsDir string, args []string) {
+	for len(args) > 0 && strings.HasPrefix(args[0], "-") {
+		args = args[1:] // --allow-stale and friends
+	}
+	if !mutatingQuery(args) {
+		return
+	}
+	if workDir == "" {
+		workDir, _ = os.Getwd()
+	}
+	b := NewWithBeadsDir(workDir, beadsDir)
+	if beadsDir != "" {
+		b.invalidateQueryCache()
+		return
+	}
+	b.invalidateRoutedQueryCache(args)
+}
+
+// invalidateRoutedQueryCache invalidates the database a write made with
+// prefix routing (no BEADS_DIR) lands in: the one routes.jsonl maps the
+// first bead ID in args to, or this wrapper's when nothing routes.
+func (b *Beads) invalidateRoutedQueryCache(args []string) {
+	if townRoot := b.getTownRoot(); townRoot != "" {
+		for _, a := range args[1:] {
+			if strings.HasPrefix(a, "-") || ExtractPrefix(a) == "" {
+				continue
+			}
+			if rigPath := GetRigPathForPrefix(townRoot, ExtractPrefix(a)); rigPath != "" {
+				NewWithBeadsDir(rigPath, ResolveBeadsDir(rigPath)).invalidateQueryCache()
+				return
+			}
+			break
+		}
+	}
+	b.invalidateQueryCache()
+}
+
+// invalidateQueryCache bumps the database's generation so every cached
+// query for it is stale. Called after any write gt makes.
+func (b *Beads) invalidateQueryCache() {
+	townRoot, beadsDir, ok := b.queryCacheEnabled()
+	if !ok || queryCacheTTL(townRoot) <= 0 {
+		return
+	}
+	path := generationPath(townRoot, beadsDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, []byte(strconv.FormatInt(time.Now().UnixNano(), 10)), 0644)
+}
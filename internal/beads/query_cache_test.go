@@ -0,0 +1,133 @@
+package beads
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func newCacheTestBeads(t *testing.T) *Beads {
+	t.Helper()
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return NewWithBeadsDir(townRoot, filepath.Join(townRoot, ".beads"))
+}
+
+func TestCacheableQuery(t *testing.T) {
+	tests := []struct {
+		args []string
+		want bool
+	}{
+		{[]string{"ready", "--json"}, true},
+		{[]string{"list", "--json", "--assignee=gastown/polecats/toast"}, true},
+		{[]string{"list", "--json", "--status=hooked"}, true},
+		{[]string{"list", "--json", "--label=gt:agent"}, false},
+		{[]string{"show", "gt-abc", "--json"}, false},
+		{[]string{"update", "gt-abc", "--status=hooked"}, false},
+		{nil, false},
+	}
+	for _, tt := range tests {
+		if got := CacheableQuery(tt.args); got != tt.want {
+			t.Errorf("CacheableQuery(%v) = %v, want %v", tt.args, got, tt.want)
+		}
+	}
+}
+
+func TestMutatingQuery(t *testing.T) {
+	for _, args := range [][]string{{"update", "x"}, {"close", "x"}, {"create", "--title=x"}, {"dep", "add", "a", "b"}} {
+		if !mutatingQuery(args) {
+			t.Errorf("%v should invalidate the cache", args)
+		}
+	}
+	for _, args := range [][]string{{"list"}, {"show", "x"}, {"ready"}} {
+		if mutatingQuery(args) {
+			t.Errorf("%v should not invalidate the cache", args)
+		}
+	}
+}
+
+func TestQueryCache_HitAndInvalidate(t *testing.T) {
+	b := newCacheTestBeads(t)
+	args := []string{"list", "--json", "--assignee=mayor"}
+
+	if _, ok := b.cachedQuery(args); ok {
+		t.Fatal("empty cache should miss")
+	}
+	b.storeQuery(args, b.queryGeneration(), []byte(`[{"id":"hq-1"}]`))
+	out, ok := b.cachedQuery(args)
+	if !ok || string(out) != `[{"id":"hq-1"}]` {
+		t.Fatalf("cachedQuery = %q, %v; want hit", out, ok)
+	}
+
+	// A different query against the same database misses.
+	if _, ok := b.cachedQuery([]string{"ready", "--json"}); ok {
+		t.Error("different query should miss")
+	}
+
+	b.invalidateQueryCache()
+	if _, ok := b.cachedQuery(args); ok {
+		t.Error("write should invalidate cached queries")
+	}
+}
+
+func TestQueryCache_StaleGeneration(t *testing.T) {
+	b := newCacheTestBeads(t)
+	args := []string{"ready", "--json"}
+
+	// A write that lands while the query runs leaves its result stale.
+	gen := b.queryGeneration()
+	b.invalidateQueryCache()
+	b.storeQuery(args, gen, []byte(`[]`))
+	if _, ok := b.cachedQuery(args); ok {
+		t.Error("result tagged with an old generation should miss")
+	}
+}
+
+func TestQueryCache_DisabledWhenIsolated(t *testing.T) {
+	b := newCacheTestBeads(t)
+	b.isolated = true
+	args := []string{"ready", "--json"}
+	b.storeQuery(args, "", []byte(`[]`))
+	if _, ok := b.cachedQuery(args); ok {
+		t.Error("isolated wrappers must not use the cache")
+	}
+}
+
+func TestInvalidateQueryCache_RoutedWrite(t *testing.T) {
+	town := newCacheTestBeads(t)
+	townRoot := town.getTownRoot()
+	rigPath := filepath.Join(townRoot, "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(townRoot, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteRoutes(filepath.Join(townRoot, ".beads"), []Route{{Prefix: "gt-", Path: "gastown"}}); err != nil {
+		t.Fatal(err)
+	}
+	rig := NewWithBeadsDir(rigPath, ResolveBeadsDir(rigPath))
+	args := []string{"list", "--json", "--status=hooked"}
+	rig.storeQuery(args, rig.queryGeneration(), []byte(`[]`))
+	town.storeQuery(args, town.queryGeneration(), []byte(`[]`))
+
+	// Reads never invalidate.
+	InvalidateQueryCache(townRoot, "", []string{"--allow-stale", "show", "gt-1"})
+	if _, ok := rig.cachedQuery(args); !ok {
+		t.Fatal("read should not invalidate")
+	}
+
+	// A write from the town root with no BEADS_DIR routes to the rig.
+	InvalidateQueryCache(townRoot, "", []string{"--allow-stale", "update", "gt-1", "--status=hooked"})
+	if _, ok := rig.cachedQuery(args); ok {
+		t.Error("routed write should invalidate the rig's cache")
+	}
+	if _, ok := town.cachedQuery(args); !ok {
+		t.Error("routed write should leave the town's cache alone")
+	}
+}
@@ -0,0 +1,117 @@
+package beads
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseAge(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Age
+		wantErr bool
+	}{
+		{"", Age{}, false},
+		{"3d", Age{Duration: 72 * time.Hour}, false},
+		{">1w", Age{Duration: 7 * 24 * time.Hour}, false},
+		{"<2h", Age{Duration: 2 * time.Hour, Newer: true}, false},
+		{"90m", Age{Duration: 90 * time.Minute}, false},
+		{"xd", Age{}, true},
+		{"-3d", Age{}, true},
+		{"soon", Age{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAge(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAge(%q) = %+v, want %+v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestQueryMatch(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	issue := &Issue{
+		ID:        "gt-1",
+		Status:    "open",
+		Assignee:  "gastown/polecats/toast",
+		Labels:    []string{"gastown", "gt:task"},
+		CreatedAt: now.Add(-4 * 24 * time.Hour).Format(time.RFC3339),
+	}
+	unassigned := &Issue{ID: "gt-2", Status: "hooked", CreatedAt: now.Format(time.RFC3339)}
+
+	tests := []struct {
+		name  string
+		q     Query
+		issue *Issue
+		want  bool
+	}{
+		{"zero matches", Query{}, issue, true},
+		{"status listed", Query{Statuses: []string{"hooked", "open"}}, issue, true},
+		{"status not listed", Query{Statuses: []string{"closed"}}, issue, false},
+		{"assignee", Query{Assignee: "gastown/polecats/toast"}, issue, true},
+		{"other assignee", Query{Assignee: "gastown/polecats/nux"}, issue, false},
+		{"unassigned wanted", Query{Assignee: QueryUnassigned}, issue, false},
+		{"unassigned", Query{Assignee: QueryUnassigned}, unassigned, true},
+		{"all labels", Query{Labels: []string{"gastown", "gt:task"}}, issue, true},
+		{"missing label", Query{Labels: []string{"gastown", "urgent"}}, issue, false},
+		{"old enough", Query{Age: Age{Duration: 3 * 24 * time.Hour}}, issue, true},
+		{"too new", Query{Age: Age{Duration: 3 * 24 * time.Hour}}, unassigned, false},
+		{"newer than", Query{Age: Age{Duration: time.Hour, Newer: true}}, unassigned, true},
+		{"not newer", Query{Age: Age{Duration: time.Hour, Newer: true}}, issue, false},
+		{"bad timestamp", Query{Age: Age{Duration: time.Hour}}, &Issue{CreatedAt: "yesterday"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.q.Match(tt.issue, now); got != tt.want {
+			t.Errorf("%s: Match = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestQueryListArgs(t *testing.T) {
+	q := Query{
+		Statuses: []string{"in_progress", "hooked"},
+		Assignee: QueryUnassigned,
+		Labels:   []string{"gastown"},
+	}
+	got := q.ListArgs()
+	want := [][]string{
+		{"list", "--json", "--limit=0", "--no-assignee", "--label=gastown", "--status=in_progress"},
+		{"list", "--json", "--limit=0", "--no-assignee", "--label=gastown", "--status=hooked"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ListArgs() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Errorf("ListArgs()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	if got := (Query{}).ListArgs(); len(got) != 1 || !slices.Equal(got[0], []string{"list", "--json", "--limit=0"}) {
+		t.Errorf("zero ListArgs() = %v", got)
+	}
+}
+
+func TestQueryFlags(t *testing.T) {
+	f := QueryFlags{Status: []string{"open", "all"}, Assignee: "none", Age: "<1d"}
+	q, err := f.Query()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.Statuses != nil {
+		t.Errorf("Statuses = %v, want nil for \"all\"", q.Statuses)
+	}
+	if q.Assignee != QueryUnassigned || !q.Age.Newer || q.Age.Duration != 24*time.Hour {
+		t.Errorf("Query() = %+v", q)
+	}
+
+	f.Age = "whenever"
+	if _, err := f.Query(); err == nil {
+		t.Error("expected an error for a bad --age")
+	}
+}
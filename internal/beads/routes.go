@@ -4,9 +4,11 @@ package beads
 import (
 	"bufio"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/config"
@@ -217,6 +219,18 @@ func CheckPrefixAvailable(townRoot string, prefix string, newPath string) error
 		}
 	}
 
+	// A rig can be registered with a prefix but have no route (e.g., a
+	// failed add or a hand-edited routes.jsonl); its beads still use it.
+	configured, err := LoadConfiguredPrefixes(townRoot)
+	if err != nil {
+		return err
+	}
+	for rig, p := range configured {
+		if p == prefix && rig != newRig {
+			return fmt.Errorf("prefix %q is already configured for %s in rigs.json; use --prefix to specify a different prefix", prefix, rig)
+		}
+	}
+
 	return nil
 }
 
@@ -245,6 +259,186 @@ func FindConflictingPrefixes(beadsDir string) (map[string][]string, error) {
 	return conflicts, nil
 }
 
+// LoadConfiguredPrefixes returns each registered rig's beads prefix from
+// mayor/rigs.json, with the trailing hyphen. Rigs without a prefix are
+// omitted; a town with no registry has none.
+func LoadConfiguredPrefixes(townRoot string) (map[string]string, error) {
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if errors.Is(err, config.ErrNotFound) {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("loading rigs.json: %w", err)
+	}
+	prefixes := make(map[string]string, len(rigsConfig.Rigs))
+	for rig, entry := range rigsConfig.Rigs {
+		if entry.BeadsConfig != nil && entry.BeadsConfig.Prefix != "" {
+			prefixes[rig] = strings.TrimSuffix(entry.BeadsConfig.Prefix, "-") + "-"
+		}
+	}
+	return prefixes, nil
+}
+
+// routeRig returns the rig a route points into ("." for the town root).
+func routeRig(r Route) string {
+	return strings.SplitN(r.Path, "/", 2)[0]
+}
+
+// routeRigsByPrefix maps each routed prefix to the rigs it points into, in
+// route order.
+func routeRigsByPrefix(routes []Route) map[string][]string {
+	rigsByPrefix := make(map[string][]string)
+	for _, r := range routes {
+		if rig := routeRig(r); !slices.Contains(rigsByPrefix[r.Prefix], rig) {
+			rigsByPrefix[r.Prefix] = append(rigsByPrefix[r.Prefix], rig)
+		}
+	}
+	return rigsByPrefix
+}
+
+// ValidateRigPrefixes checks that every rig's beads prefix is unique and
+// agrees with rigs.json: no prefix is routed into more than one rig or to
+// more than one path, no route to a rig uses a prefix configured for
+// another rig, every route uses its rig's configured beads.prefix, and no
+// two rigs are configured with the same prefix. Route findings come first,
+// in route order; each configured-prefix finding uses the rig name as its
+// Path.
+func ValidateRigPrefixes(townRoot string) ([]RouteProblem, error) {
+	routes, err := LoadRoutes(GetTownBeadsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading routes: %w", err)
+	}
+	configured, err := LoadConfiguredPrefixes(townRoot)
+	if err != nil {
+		return nil, err
+	}
+	byRoute, rigProblems := rigPrefixProblems(routes, configured)
+	var problems []RouteProblem
+	for i, reasons := range byRoute {
+		for _, reason := range reasons {
+			problems = append(problems, RouteProblem{routes[i].Prefix, routes[i].Path, reason})
+		}
+	}
+	return append(problems, rigProblems...), nil
+}
+
+// rigPrefixProblems implements ValidateRigPrefixes. byRoute holds the
+// reasons each route (by index) fails; rigProblems the rigs configured with
+// a shared prefix.
+func rigPrefixProblems(routes []Route, configured map[string]string) (byRoute [][]string, rigProblems []RouteProblem) {
+	rigsByConfigured := make(map[string][]string)
+	for rig, prefix := range configured {
+		rigsByConfigured[prefix] = append(rigsByConfigured[prefix], rig)
+	}
+	for _, rigs := range rigsByConfigured {
+		slices.Sort(rigs)
+	}
+
+	pathsByPrefix := make(map[string][]string)
+	for _, r := range routes {
+		pathsByPrefix[r.Prefix] = append(pathsByPrefix[r.Prefix], r.Path)
+	}
+
+	byRoute = make([][]string, len(routes))
+	rigsByPrefix := routeRigsByPrefix(routes)
+	for i, r := range routes {
+		if rigs := rigsByPrefix[r.Prefix]; len(rigs) > 1 {
+			byRoute[i] = append(byRoute[i], fmt.Sprintf("prefix collides across rigs: %s", strings.Join(rigs, ", ")))
+			continue
+		}
+		if paths := pathsByPrefix[r.Prefix]; len(paths) > 1 {
+			byRoute[i] = append(byRoute[i], fmt.Sprintf("prefix is routed to more than one path: %s", strings.Join(paths, ", ")))
+			continue
+		}
+		rig := routeRig(r)
+		if owner := otherConfiguredRig(rigsByConfigured[r.Prefix], rig); owner != "" {
+			byRoute[i] = append(byRoute[i], fmt.Sprintf("prefix is configured for rig %s", owner))
+		} else if want, ok := configured[rig]; ok && want != r.Prefix {
+			byRoute[i] = append(byRoute[i], fmt.Sprintf("prefix doesn't match the rig's configured prefix %s", want))
+		}
+	}
+
+	prefixes := make([]string, 0, len(rigsByConfigured))
+	for prefix := range rigsByConfigured {
+		prefixes = append(prefixes, prefix)
+	}
+	slices.Sort(prefixes)
+	for _, prefix := range prefixes {
+		rigs := rigsByConfigured[prefix]
+		if len(rigs) < 2 {
+			continue
+		}
+		for _, rig := range rigs {
+			rigProblems = append(rigProblems, RouteProblem{prefix, rig,
+				fmt.Sprintf("prefix is configured for more than one rig: %s", strings.Join(rigs, ", "))})
+		}
+	}
+	return byRoute, rigProblems
+}
+
+// otherConfiguredRig returns the first of owners that isn't rig, or "".
+func otherConfiguredRig(owners []string, rig string) string {
+	for _, owner := range owners {
+		if owner != rig {
+			return owner
+		}
+	}
+	return ""
+}
+
+// RouteProblem describes a route that fails validation.
+type RouteProblem struct {
+	Prefix string
+	Path   string
+	Reason string
+}
+
+// ValidateRoutes checks the town's routes.jsonl for malformed prefixes,
+// the prefix problems ValidateRigPrefixes reports, and paths that don't
+// exist or have no beads database. Returns one problem per finding, in route
+// order, followed by any rigs configured with the same prefix.
+func ValidateRoutes(townRoot string) ([]RouteProblem, error) {
+	routes, err := LoadRoutes(GetTownBeadsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading routes: %w", err)
+	}
+	configured, err := LoadConfiguredPrefixes(townRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	byRoute, rigProblems := rigPrefixProblems(routes, configured)
+
+	var problems []RouteProblem
+	for i, r := range routes {
+		if !strings.HasSuffix(r.Prefix, "-") {
+			problems = append(problems, RouteProblem{r.Prefix, r.Path, "prefix must end with '-'"})
+		}
+		for _, reason := range byRoute[i] {
+			problems = append(problems, RouteProblem{r.Prefix, r.Path, reason})
+		}
+		if reason := RoutePathProblem(townRoot, r.Path); reason != "" {
+			problems = append(problems, RouteProblem{r.Prefix, r.Path, reason})
+		}
+	}
+	return append(problems, rigProblems...), nil
+}
+
+// RoutePathProblem returns why a route path is unusable, or "" if it's fine.
+func RoutePathProblem(townRoot, path string) string {
+	dir := filepath.Join(townRoot, path)
+	if rel, err := filepath.Rel(townRoot, dir); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "path is outside the workspace"
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return "path does not exist"
+	}
+	if _, err := os.Stat(ResolveBeadsDir(dir)); err != nil {
+		return "path has no .beads directory"
+	}
+	return ""
+}
+
 // ExtractPrefix extracts the prefix from a bead ID.
 // For example, "ap-qtsup.16" returns "ap-", "hq-cv-abc" returns "hq-".
 // Returns empty string if no valid prefix found (empty input, no hyphen,
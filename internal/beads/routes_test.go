@@ -1,8 +1,10 @@
 package beads
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/steveyegge/gastown/internal/config"
@@ -446,3 +448,137 @@ func TestAgentBeadIDsWithPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRoutes(t *testing.T) {
+	townRoot := t.TempDir()
+	for _, dir := range []string{".beads", "gastown/mayor/rig/.beads", "beads/mayor/rig"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	routes := []Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+		{Prefix: "bd-", Path: "beads/mayor/rig"}, // no .beads
+		{Prefix: "xx-", Path: "missing"},
+		{Prefix: "gt-", Path: "other/mayor/rig"}, // collides with gastown
+		{Prefix: "ab", Path: "."},
+	}
+	if err := WriteRoutes(filepath.Join(townRoot, ".beads"), routes); err != nil {
+		t.Fatal(err)
+	}
+
+	problems, err := ValidateRoutes(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string][]string)
+	for _, p := range problems {
+		got[p.Prefix+" "+p.Path] = append(got[p.Prefix+" "+p.Path], p.Reason)
+	}
+
+	want := map[string][]string{
+		"gt- gastown/mayor/rig": {"prefix collides across rigs: gastown, other"},
+		"bd- beads/mayor/rig":   {"path has no .beads directory"},
+		"xx- missing":           {"path does not exist"},
+		"gt- other/mayor/rig":   {"prefix collides across rigs: gastown, other", "path does not exist"},
+		"ab .":                  {"prefix must end with '-'"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("ValidateRoutes() = %v, want %v", got, want)
+	}
+	for key, reasons := range want {
+		if strings.Join(got[key], "|") != strings.Join(reasons, "|") {
+			t.Errorf("%s: got %v, want %v", key, got[key], reasons)
+		}
+	}
+}
+
+func writeTestRigsJSON(t *testing.T, townRoot string, prefixes map[string]string) {
+	t.Helper()
+	var entries []string
+	for rig, prefix := range prefixes {
+		entries = append(entries, fmt.Sprintf(`%q: {"git_url": "https://example.com/%s", "beads": {"prefix": %q}}`, rig, rig, prefix))
+	}
+	content := `{"version": 1, "rigs": {` + strings.Join(entries, ",") + `}}`
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "rigs.json"), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestValidateRigPrefixes(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	routes := []Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "gt-", Path: "gastown/mayor/rig"},
+		{Prefix: "bd-", Path: "beads/mayor/rig"},    // configured for gastown too
+		{Prefix: "ks-", Path: "keepsake/mayor/rig"}, // fine
+		{Prefix: "ks-", Path: "other/mayor/rig"},    // collides with keepsake
+		{Prefix: "wy-", Path: "wyvern"},             // one rig, two paths
+		{Prefix: "wy-", Path: "wyvern/mayor/rig"},
+	}
+	if err := WriteRoutes(filepath.Join(townRoot, ".beads"), routes); err != nil {
+		t.Fatal(err)
+	}
+	writeTestRigsJSON(t, townRoot, map[string]string{
+		"gastown": "bd",
+		"beads":   "bd",
+		"wyvern":  "wy",
+	})
+
+	problems, err := ValidateRigPrefixes(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, p := range problems {
+		got = append(got, p.Prefix+" "+p.Path+": "+p.Reason)
+	}
+	want := []string{
+		"gt- gastown/mayor/rig: prefix doesn't match the rig's configured prefix bd-",
+		"bd- beads/mayor/rig: prefix is configured for rig gastown",
+		"ks- keepsake/mayor/rig: prefix collides across rigs: keepsake, other",
+		"ks- other/mayor/rig: prefix collides across rigs: keepsake, other",
+		"wy- wyvern: prefix is routed to more than one path: wyvern, wyvern/mayor/rig",
+		"wy- wyvern/mayor/rig: prefix is routed to more than one path: wyvern, wyvern/mayor/rig",
+		"bd- beads: prefix is configured for more than one rig: beads, gastown",
+		"bd- gastown: prefix is configured for more than one rig: beads, gastown",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("ValidateRigPrefixes() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	// ValidateRoutes also reports a route that doesn't use its rig's prefix.
+	problems, err = ValidateRoutes(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	found := false
+	for _, p := range problems {
+		if p.Prefix == "gt-" && p.Reason == "prefix doesn't match the rig's configured prefix bd-" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("ValidateRoutes() = %v, want a configured-prefix mismatch for gt-", problems)
+	}
+}
+
+func TestCheckPrefixAvailable_ConfiguredPrefix(t *testing.T) {
+	townRoot := t.TempDir()
+	// gastown is registered with prefix gt but has no route.
+	writeTestRigsJSON(t, townRoot, map[string]string{"gastown": "gt"})
+
+	if err := CheckPrefixAvailable(townRoot, "gt-", "getresearch"); err == nil {
+		t.Error("expected a collision with gastown's configured prefix")
+	}
+	if err := CheckPrefixAvailable(townRoot, "gt-", "gastown"); err != nil {
+		t.Errorf("re-adding gastown: %v", err)
+	}
+}
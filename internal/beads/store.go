@@ -283,12 +283,14 @@ func (b *Beads) storeShowMultiple(ids []string) (map[string]*Issue, error) {
 func (b *Beads) storeCreate(opts CreateOptions) (*Issue, error) {
 	ctx, cancel := storeCtx()
 	defer cancel()
+	defer b.invalidateQueryCache()
 
 	sdkIssue := &beadsdk.Issue{
-		Title:       opts.Title,
-		Description: opts.Description,
-		Priority:    opts.Priority,
-		Ephemeral:   opts.Ephemeral,
+		Title:              opts.Title,
+		Description:        opts.Description,
+		AcceptanceCriteria: opts.AcceptanceCriteria,
+		Priority:           opts.Priority,
+		Ephemeral:          opts.Ephemeral,
 	}
 
 	// Set issue type from Labels, Label, or Type (same precedence as CLI path)
@@ -329,6 +331,7 @@ func (b *Beads) storeCreate(opts CreateOptions) (*Issue, error) {
 func (b *Beads) storeUpdate(id string, opts UpdateOptions) error {
 	ctx, cancel := storeCtx()
 	defer cancel()
+	defer b.invalidateQueryCache()
 
 	updates := make(map[string]interface{})
 
@@ -394,6 +397,7 @@ func (b *Beads) storeUpdate(id string, opts UpdateOptions) error {
 func (b *Beads) storeClose(reason, session string, ids ...string) error {
 	ctx, cancel := storeCtx()
 	defer cancel()
+	defer b.invalidateQueryCache()
 
 	actor := b.getActor()
 
@@ -483,6 +487,7 @@ func (b *Beads) storeSearch(opts SearchOptions) ([]*Issue, error) {
 func (b *Beads) storeAddDependency(issue, dependsOn string) error {
 	ctx, cancel := storeCtx()
 	defer cancel()
+	defer b.invalidateQueryCache()
 
 	dep := &beadsdk.Dependency{
 		IssueID:     issue,
@@ -497,6 +502,7 @@ func (b *Beads) storeAddDependency(issue, dependsOn string) error {
 func (b *Beads) storeRemoveDependency(issue, dependsOn string) error {
 	ctx, cancel := storeCtx()
 	defer cancel()
+	defer b.invalidateQueryCache()
 
 	return b.store.RemoveDependency(ctx, issue, dependsOn, b.getActor())
 }
@@ -505,6 +511,7 @@ func (b *Beads) storeRemoveDependency(issue, dependsOn string) error {
 func (b *Beads) storeAddLabel(id, label string) error {
 	ctx, cancel := storeCtx()
 	defer cancel()
+	defer b.invalidateQueryCache()
 
 	return b.store.AddLabel(ctx, id, label, b.getActor())
 }
@@ -513,6 +520,7 @@ func (b *Beads) storeAddLabel(id, label string) error {
 func (b *Beads) storeRemoveLabel(id, label string) error {
 	ctx, cancel := storeCtx()
 	defer cancel()
+	defer b.invalidateQueryCache()
 
 	return b.store.RemoveLabel(ctx, id, label, b.getActor())
 }
@@ -531,6 +539,7 @@ func (b *Beads) storeGetLabels(id string) ([]string, error) {
 func (b *Beads) storeDelegationSet(childID string, d *Delegation) error {
 	ctx, cancel := storeCtx()
 	defer cancel()
+	defer b.invalidateQueryCache()
 
 	actor := b.getActor()
 
@@ -552,6 +561,7 @@ func (b *Beads) storeDelegationSet(childID string, d *Delegation) error {
 func (b *Beads) storeDelegationClear(childID string) error {
 	ctx, cancel := storeCtx()
 	defer cancel()
+	defer b.invalidateQueryCache()
 
 	actor := b.getActor()
 
@@ -0,0 +1,172 @@
+package beads
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Sizes a templated bead can be estimated at.
+var TemplateSizes = []string{"xs", "s", "m", "l", "xl"}
+
+// Template describes a standard Gas Town work item: the labels it is
+// created with and which fields must be filled in, so Mayor dispatch can
+// rely on every templated bead having the same structure.
+type Template struct {
+	Name        string
+	Description string
+	Labels      []string
+	Priority    int
+
+	RequireAcceptance bool
+	RequireSize       bool
+}
+
+// Templates are the built-in bead templates, by name.
+var Templates = map[string]*Template{
+	"polecat-task": {
+		Name:              "polecat-task",
+		Description:       "A unit of work sized for one polecat",
+		Labels:            []string{"gt:task"},
+		Priority:          2,
+		RequireAcceptance: true,
+		RequireSize:       true,
+	},
+	"bug": {
+		Name:              "bug",
+		Description:       "A defect, with criteria that show it is fixed",
+		Labels:            []string{"gt:task", "bug"},
+		Priority:          1,
+		RequireAcceptance: true,
+	},
+}
+
+// TemplateNames returns the built-in template names, sorted.
+func TemplateNames() []string {
+	names := make([]string, 0, len(Templates))
+	for name := range Templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupTemplate returns the named template.
+func LookupTemplate(name string) (*Template, error) {
+	t, ok := Templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown template %q (available: %s)", name, strings.Join(TemplateNames(), ", "))
+	}
+	return t, nil
+}
+
+// TemplateInput is what a caller supplies to create a bead from a template.
+type TemplateInput struct {
+	Title       string
+	Description string
+	Rig         string
+	Size        string
+	Acceptance  []string // One criterion each
+	Priority    int      // -1 for the template's default
+}
+
+// CreateOptions validates in against the template and returns the options
+// to create the bead with. Every missing required field is reported at once.
+//
+// The rig, size, and template name are recorded as "key: value" lines in the
+// description (see ParseTemplateFields), size also as a "size:<size>" label,
+// and acceptance criteria as a "- [ ]" checklist.
+func (t *Template) CreateOptions(in TemplateInput) (CreateOptions, error) {
+	var missing []string
+	if strings.TrimSpace(in.Title) == "" {
+		missing = append(missing, "title")
+	}
+	if in.Rig == "" {
+		missing = append(missing, "rig")
+	}
+	if t.RequireSize && in.Size == "" {
+		missing = append(missing, "size")
+	}
+	var criteria []string
+	for _, c := range in.Acceptance {
+		if c = strings.TrimSpace(c); c != "" {
+			criteria = append(criteria, c)
+		}
+	}
+	if t.RequireAcceptance && len(criteria) == 0 {
+		missing = append(missing, "acceptance criteria")
+	}
+	if len(missing) > 0 {
+		return CreateOptions{}, fmt.Errorf("template %s requires: %s", t.Name, strings.Join(missing, ", "))
+	}
+	if in.Size != "" && !slices.Contains(TemplateSizes, in.Size) {
+		return CreateOptions{}, fmt.Errorf("invalid size %q: must be one of %s", in.Size, strings.Join(TemplateSizes, ", "))
+	}
+
+	labels := slices.Clone(t.Labels)
+	fields := []string{"template: " + t.Name, "rig: " + in.Rig}
+	if in.Size != "" {
+		labels = append(labels, "size:"+in.Size)
+		fields = append(fields, "size: "+in.Size)
+	}
+
+	description := strings.Join(fields, "\n")
+	if d := strings.TrimSpace(in.Description); d != "" {
+		description = d + "\n\n" + description
+	}
+
+	var checklist []string
+	for _, c := range criteria {
+		c = strings.TrimPrefix(strings.TrimPrefix(c, "- [ ] "), "- ")
+		checklist = append(checklist, "- [ ] "+c)
+	}
+
+	priority := in.Priority
+	if priority < 0 {
+		priority = t.Priority
+	}
+
+	return CreateOptions{
+		Title:              strings.TrimSpace(in.Title),
+		Labels:             labels,
+		Priority:           priority,
+		Description:        description,
+		AcceptanceCriteria: strings.Join(checklist, "\n"),
+	}, nil
+}
+
+// TemplateFields are the fields a template records in a bead's description.
+type TemplateFields struct {
+	Template string
+	Rig      string
+	Size     string
+}
+
+// ParseTemplateFields extracts template fields from an issue's description.
+// Returns nil if the issue wasn't created from a template.
+func ParseTemplateFields(issue *Issue) *TemplateFields {
+	if issue == nil {
+		return nil
+	}
+	var fields TemplateFields
+	for _, line := range strings.Split(issue.Description, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch strings.ToLower(strings.TrimSpace(key)) {
+		case "template":
+			fields.Template = value
+		case "rig":
+			fields.Rig = value
+		case "size":
+			fields.Size = value
+		}
+	}
+	if fields.Template == "" {
+		return nil
+	}
+	return &fields
+}
@@ -0,0 +1,68 @@
+package beads
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTemplateCreateOptions(t *testing.T) {
+	tmpl, err := LookupTemplate("polecat-task")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opts, err := tmpl.CreateOptions(TemplateInput{
+		Title:       "Add retry",
+		Description: "Sync gives up too early.",
+		Rig:         "gastown",
+		Size:        "m",
+		Acceptance:  []string{"Transient errors retried", "- [ ] Summary shows pulls", " "},
+		Priority:    -1,
+	})
+	if err != nil {
+		t.Fatalf("CreateOptions: %v", err)
+	}
+	if !slices.Equal(opts.Labels, []string{"gt:task", "size:m"}) {
+		t.Errorf("Labels = %v", opts.Labels)
+	}
+	if opts.Priority != 2 {
+		t.Errorf("Priority = %d, want template default 2", opts.Priority)
+	}
+	if want := "- [ ] Transient errors retried\n- [ ] Summary shows pulls"; opts.AcceptanceCriteria != want {
+		t.Errorf("AcceptanceCriteria = %q, want %q", opts.AcceptanceCriteria, want)
+	}
+	if !strings.HasPrefix(opts.Description, "Sync gives up too early.\n\n") {
+		t.Errorf("Description = %q", opts.Description)
+	}
+
+	fields := ParseTemplateFields(&Issue{Description: opts.Description})
+	if fields == nil || *fields != (TemplateFields{Template: "polecat-task", Rig: "gastown", Size: "m"}) {
+		t.Errorf("ParseTemplateFields = %+v", fields)
+	}
+	if ParseTemplateFields(&Issue{Description: "plain"}) != nil {
+		t.Error("ParseTemplateFields should be nil for an untemplated bead")
+	}
+}
+
+func TestTemplateCreateOptions_RequiredFields(t *testing.T) {
+	tmpl, _ := LookupTemplate("polecat-task")
+	_, err := tmpl.CreateOptions(TemplateInput{Title: "x", Acceptance: []string{"  "}, Priority: -1})
+	if err == nil || !strings.Contains(err.Error(), "rig, size, acceptance criteria") {
+		t.Errorf("err = %v, want every missing field listed", err)
+	}
+
+	_, err = tmpl.CreateOptions(TemplateInput{Title: "x", Rig: "gastown", Size: "huge", Acceptance: []string{"ok"}, Priority: -1})
+	if err == nil || !strings.Contains(err.Error(), "invalid size") {
+		t.Errorf("err = %v, want invalid size", err)
+	}
+
+	bug, _ := LookupTemplate("bug")
+	if _, err := bug.CreateOptions(TemplateInput{Title: "x", Rig: "gastown", Acceptance: []string{"ok"}, Priority: -1}); err != nil {
+		t.Errorf("bug without size: %v", err)
+	}
+
+	if _, err := LookupTemplate("nope"); err == nil || !strings.Contains(err.Error(), "bug, polecat-task") {
+		t.Errorf("LookupTemplate(nope) = %v", err)
+	}
+}
@@ -204,11 +204,11 @@ func (b *Boot) spawnDegraded() error {
 	cmd.Dir = b.deaconDir
 	util.SetDetachedProcessGroup(cmd)
 
-	// Use centralized AgentEnv for consistency with tmux mode
-	envVars := config.AgentEnv(config.AgentEnvConfig{
+	// Use the shared agent session env for consistency with tmux mode
+	envVars := session.AgentSessionEnv(config.AgentEnvConfig{
 		Role:     "boot",
 		TownRoot: b.townRoot,
-	})
+	}, "")
 	cmd.Env = config.EnvForExecCommand(envVars)
 	cmd.Env = append(cmd.Env, "GT_DEGRADED=true")
 
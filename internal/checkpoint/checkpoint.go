@@ -57,7 +57,8 @@ func Path(polecatDir string) string {
 	return filepath.Join(polecatDir, Filename)
 }
 
-// Read loads a checkpoint from the polecat directory.
+// Read loads a checkpoint from the polecat directory, decrypting it with the
+// town's age identity if it is encrypted.
 // Returns nil, nil if no checkpoint exists.
 func Read(polecatDir string) (*Checkpoint, error) {
 	path := Path(polecatDir)
@@ -70,6 +71,16 @@ func Read(polecatDir string) (*Checkpoint, error) {
 		return nil, fmt.Errorf("reading checkpoint: %w", err)
 	}
 
+	if IsEncrypted(data) {
+		enc, err := encryptionConfig(polecatDir)
+		if err != nil {
+			return nil, fmt.Errorf("reading checkpoint: %w", err)
+		}
+		if data, err = decrypt(data, enc); err != nil {
+			return nil, fmt.Errorf("decrypting checkpoint: %w", err)
+		}
+	}
+
 	var cp Checkpoint
 	if err := json.Unmarshal(data, &cp); err != nil {
 		return nil, fmt.Errorf("parsing checkpoint: %w", err)
@@ -78,7 +89,9 @@ func Read(polecatDir string) (*Checkpoint, error) {
 	return &cp, nil
 }
 
-// Write saves a checkpoint to the polecat directory.
+// Write saves a checkpoint to the polecat directory. If the town configures
+// checkpoint encryption, the file is encrypted to its age recipients; a
+// failure to encrypt is an error, never a fallback to plaintext.
 func Write(polecatDir string, cp *Checkpoint) error {
 	// Set timestamp if not already set
 	if cp.Timestamp.IsZero() {
@@ -98,6 +111,16 @@ func Write(polecatDir string, cp *Checkpoint) error {
 		return fmt.Errorf("marshaling checkpoint: %w", err)
 	}
 
+	enc, err := encryptionConfig(polecatDir)
+	if err != nil {
+		return fmt.Errorf("writing checkpoint: %w", err)
+	}
+	if enc.Enabled() {
+		if data, err = encrypt(data, enc); err != nil {
+			return fmt.Errorf("encrypting checkpoint: %w", err)
+		}
+	}
+
 	path := Path(polecatDir)
 	if err := os.WriteFile(path, data, 0600); err != nil {
 		return fmt.Errorf("writing checkpoint: %w", err)
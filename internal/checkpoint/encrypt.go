@@ -0,0 +1,88 @@
+package checkpoint
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// ageArmorHeader begins an ASCII-armored age file. Encrypted checkpoints are
+// armored so the file stays text, and Read can tell them from plain JSON.
+const ageArmorHeader = "-----BEGIN AGE ENCRYPTED FILE-----"
+
+// ageBinary is the age command used to encrypt and decrypt checkpoints.
+// Tests replace it with a stub.
+var ageBinary = "age"
+
+// ErrNoIdentity is returned when reading an encrypted checkpoint without an
+// age identity configured.
+var ErrNoIdentity = errors.New("checkpoint is encrypted but no age identity is configured (town.json checkpoints.encryption.identity)")
+
+// IsEncrypted reports whether checkpoint file data is age-encrypted.
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte(ageArmorHeader))
+}
+
+// encryptionConfig returns the checkpoint encryption settings of the town
+// containing polecatDir, or nil if it has none.
+func encryptionConfig(polecatDir string) (*config.CheckpointEncryptionConfig, error) {
+	townRoot, err := workspace.Find(polecatDir)
+	if err != nil || townRoot == "" {
+		return nil, nil
+	}
+	cfg, err := config.LoadTownConfig(filepath.Join(townRoot, workspace.PrimaryMarker))
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("loading town config: %w", err)
+	}
+	if cfg.Checkpoints == nil {
+		return nil, nil
+	}
+	return cfg.Checkpoints.Encryption, nil
+}
+
+// encrypt encrypts data to the configured recipients with age.
+func encrypt(data []byte, enc *config.CheckpointEncryptionConfig) ([]byte, error) {
+	args := []string{"--encrypt", "--armor"}
+	for _, r := range enc.Recipients {
+		args = append(args, "--recipient", r)
+	}
+	return runAge(data, args...)
+}
+
+// decrypt decrypts age-encrypted data with the configured identity.
+func decrypt(data []byte, enc *config.CheckpointEncryptionConfig) ([]byte, error) {
+	if enc == nil || enc.Identity == "" {
+		return nil, ErrNoIdentity
+	}
+	return runAge(data, "--decrypt", "--identity", util.ExpandHome(enc.Identity))
+}
+
+// runAge runs age with data on stdin and returns its stdout.
+func runAge(data []byte, args ...string) ([]byte, error) {
+	if _, err := exec.LookPath(ageBinary); err != nil {
+		return nil, fmt.Errorf("checkpoint encryption needs %s in PATH: %w", ageBinary, err)
+	}
+	cmd := exec.Command(ageBinary, args...) //nolint:gosec // G204: args are from town config
+	util.SetDetachedProcessGroup(cmd)
+	cmd.Stdin = bytes.NewReader(data)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %s", ageBinary, msg)
+		}
+		return nil, fmt.Errorf("%s: %w", ageBinary, err)
+	}
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,142 @@
+package checkpoint
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// stubAge installs a fake age command that "encrypts" by base64-encoding
+// inside an age armor block, and records its arguments in args.log.
+func stubAge(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("stub age is a shell script")
+	}
+	dir := t.TempDir()
+	script := `#!/bin/sh
+echo "$@" >> "` + filepath.Join(dir, "args.log") + `"
+if [ "$1" = "--decrypt" ]; then
+	sed '1d;$d' | base64 -d
+else
+	echo "-----BEGIN AGE ENCRYPTED FILE-----"
+	base64
+	echo "-----END AGE ENCRYPTED FILE-----"
+fi
+`
+	path := filepath.Join(dir, "age")
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	old := ageBinary
+	ageBinary = path
+	t.Cleanup(func() { ageBinary = old })
+	return dir
+}
+
+// encryptedTown creates a town whose town.json sets checkpoint encryption and
+// returns a polecat directory within it.
+func encryptedTown(t *testing.T, enc *config.CheckpointEncryptionConfig) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	cfg := &config.TownConfig{
+		Type:        "town",
+		Version:     config.CurrentTownVersion,
+		Name:        "test",
+		Checkpoints: &config.CheckpointConfig{Encryption: enc},
+	}
+	if err := config.SaveTownConfig(filepath.Join(townRoot, "mayor", "town.json"), cfg); err != nil {
+		t.Fatal(err)
+	}
+	polecatDir := filepath.Join(townRoot, "gastown", "polecats", "toast")
+	if err := os.MkdirAll(polecatDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	return polecatDir
+}
+
+func TestWriteReadEncrypted(t *testing.T) {
+	stubDir := stubAge(t)
+	polecatDir := encryptedTown(t, &config.CheckpointEncryptionConfig{
+		Recipients: []string{"age1alice", "age1bob"},
+		Identity:   "/keys/gastown.txt",
+	})
+
+	if err := Write(polecatDir, &Checkpoint{Notes: "secret plan", Branch: "feature/x"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	data, err := os.ReadFile(Path(polecatDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !IsEncrypted(data) {
+		t.Fatalf("checkpoint file is not encrypted:\n%s", data)
+	}
+	if strings.Contains(string(data), "secret plan") {
+		t.Error("checkpoint file contains plaintext notes")
+	}
+
+	cp, err := Read(polecatDir)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if cp.Notes != "secret plan" || cp.Branch != "feature/x" {
+		t.Errorf("Read() = %+v, want the written checkpoint", cp)
+	}
+
+	log, err := os.ReadFile(filepath.Join(stubDir, "args.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"--recipient age1alice --recipient age1bob", "--decrypt --identity /keys/gastown.txt"} {
+		if !strings.Contains(string(log), want) {
+			t.Errorf("age args %q missing %q", log, want)
+		}
+	}
+}
+
+func TestReadEncryptedWithoutIdentity(t *testing.T) {
+	stubAge(t)
+	polecatDir := encryptedTown(t, &config.CheckpointEncryptionConfig{Recipients: []string{"age1alice"}})
+
+	if err := Write(polecatDir, &Checkpoint{Notes: "n"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if _, err := Read(polecatDir); !errors.Is(err, ErrNoIdentity) {
+		t.Errorf("Read() error = %v, want ErrNoIdentity", err)
+	}
+}
+
+func TestWriteEncryptedFailsClosed(t *testing.T) {
+	old := ageBinary
+	ageBinary = "gt-test-no-such-age"
+	t.Cleanup(func() { ageBinary = old })
+	polecatDir := encryptedTown(t, &config.CheckpointEncryptionConfig{Recipients: []string{"age1alice"}})
+
+	if err := Write(polecatDir, &Checkpoint{Notes: "secret"}); err == nil {
+		t.Fatal("Write succeeded without age")
+	}
+	if _, err := os.Stat(Path(polecatDir)); !os.IsNotExist(err) {
+		t.Errorf("checkpoint file written despite encryption failure: %v", err)
+	}
+}
+
+func TestWriteUnencryptedTown(t *testing.T) {
+	polecatDir := encryptedTown(t, nil)
+	if err := Write(polecatDir, &Checkpoint{Notes: "plain"}); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	data, err := os.ReadFile(Path(polecatDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if IsEncrypted(data) || !strings.Contains(string(data), "plain") {
+		t.Errorf("expected plaintext checkpoint, got:\n%s", data)
+	}
+}
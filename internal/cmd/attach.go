@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/tui/picker"
+)
+
+var attachCmd = &cobra.Command{
+	Use:     "attach [address]",
+	GroupID: GroupAgents,
+	Short:   "Attach to an agent session (fuzzy picker with no args)",
+	Long: `Attach to a Gas Town agent's tmux session.
+
+With an address, attaches directly. Without one, opens a picker over all
+running Gas Town sessions with live status: type to fuzzy-filter by address
+or session name, use the arrow keys to move, and press enter to attach.
+
+Inside tmux on the same server, switches the current client instead of
+nesting.
+
+Examples:
+  gt attach                      # Pick a session
+  gt attach gastown/Toast        # Polecat
+  gt attach gastown/crew/max     # Crew member
+  gt attach mayor`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runAttach,
+}
+
+func init() {
+	rootCmd.AddCommand(attachCmd)
+}
+
+func runAttach(cmd *cobra.Command, args []string) error {
+	t := tmux.NewTmux()
+
+	if len(args) == 1 {
+		sessionName, err := resolveAgentSessionName(args[0])
+		if err != nil {
+			return err
+		}
+		if ok, _ := t.HasSession(sessionName); !ok {
+			return fmt.Errorf("session %s is not running", sessionName)
+		}
+		return attachToTmuxSession(sessionName)
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return fmt.Errorf("the session picker needs a terminal; pass an address (see 'gt session list')")
+	}
+
+	items, err := loadAttachItems(t)
+	if err != nil {
+		return err
+	}
+	if len(items) == 0 {
+		return fmt.Errorf("no Gas Town sessions running")
+	}
+
+	m := picker.New(func() ([]picker.Item, error) { return loadAttachItems(t) })
+	if _, err := tea.NewProgram(m, tea.WithAltScreen()).Run(); err != nil {
+		return err
+	}
+	if m.Selected() == "" {
+		return nil
+	}
+	return attachToTmuxSession(m.Selected())
+}
+
+// loadAttachItems lists running Gas Town sessions with their agent status,
+// sorted by address.
+func loadAttachItems(t *tmux.Tmux) ([]picker.Item, error) {
+	details, err := t.ListSessionDetails()
+	if err != nil {
+		return nil, fmt.Errorf("listing sessions: %w", err)
+	}
+	var items []picker.Item
+	for _, d := range details {
+		if !session.IsKnownSession(d.Name) {
+			continue
+		}
+		address := d.Name
+		if identity, err := session.ParseSessionName(d.Name); err == nil {
+			address = identity.Address()
+		}
+		items = append(items, picker.Item{
+			Session:  d.Name,
+			Address:  address,
+			Status:   attachStatus(t, d.Name),
+			Attached: d.Clients > 0,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Address < items[j].Address })
+	return items, nil
+}
+
+// attachStatus summarizes what the agent in a session is doing.
+func attachStatus(t *tmux.Tmux, sessionName string) string {
+	if !t.IsAgentAlive(sessionName) {
+		return "no agent"
+	}
+	st, err := t.Idle(sessionName)
+	if err != nil {
+		return "unknown"
+	}
+	if st.Idle {
+		return "idle"
+	}
+	return "working"
+}
@@ -0,0 +1,212 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/backup"
+	"github.com/steveyegge/gastown/internal/hooks"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	backupOutput         string
+	backupNoHooks        bool
+	backupRestoreTo      string
+	backupRestoreForce   bool
+	backupRestoreDryRun  bool
+	backupRestoreNoHooks bool
+)
+
+var backupCmd = &cobra.Command{
+	Use:     "backup",
+	GroupID: GroupWorkspace,
+	Short:   "Back up and restore town state",
+	Long: `Snapshot a town's state into a single archive, and restore it.
+
+A backup holds what makes the town yours, not what can be re-fetched:
+  - mayor/*.json (town, rigs, daemon, accounts, quota state, overlays)
+  - Town and rig settings/
+  - Beads metadata and routes (.beads/config.yaml, metadata.json,
+    routes.jsonl, redirect)
+  - Each rig's config.json, polecat name pool, overlay and setup hooks
+  - Hooks base config and overrides (see 'gt hooks')
+
+Not included:
+  - Repo clones (mayor, refinery, polecat and crew checkouts): re-clone
+    them from their remotes
+  - Beads issue data: it lives in the Dolt server, back it up there
+
+Use it for disaster recovery, or to move a town to a new machine.`,
+	RunE: requireSubcommand,
+}
+
+var backupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Write a backup archive of the current town",
+	Long: `Write a backup archive (.tar.gz) of the current town's state.
+
+The archive goes to gt-backup-<town>-<timestamp>.tar.gz in the current
+directory unless -o names a file. An existing file is never overwritten.
+
+Examples:
+  gt backup create
+  gt backup create -o /mnt/backups/town.tar.gz
+  gt backup create --no-hooks       # Leave out the hooks config`,
+	Args: cobra.NoArgs,
+	RunE: runBackupCreate,
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <archive>",
+	Short: "Restore town state from a backup archive",
+	Long: `Restore town state from an archive written by 'gt backup create'.
+
+Files are restored into the current town, or into --to (created if
+needed) on a new machine. Files not in the backup are left alone, and
+identical files are skipped. If any existing file differs from the
+backup, nothing is written unless --force is given.
+
+Repo clones are not part of backups: after restoring on a new machine,
+recreate each rig's clone from the URLs listed at the end.
+
+Examples:
+  gt backup restore town.tar.gz --dry-run      # Show what would change
+  gt backup restore town.tar.gz --force        # Roll back the current town
+  gt backup restore town.tar.gz --to ~/gt      # Migrate to this machine`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBackupRestore,
+}
+
+func init() {
+	backupCreateCmd.Flags().StringVarP(&backupOutput, "output", "o", "", "Archive path (default: gt-backup-<town>-<timestamp>.tar.gz)")
+	backupCreateCmd.Flags().BoolVar(&backupNoHooks, "no-hooks", false, "Leave out the hooks base config and overrides")
+
+	backupRestoreCmd.Flags().StringVar(&backupRestoreTo, "to", "", "Town directory to restore into (default: current town)")
+	backupRestoreCmd.Flags().BoolVarP(&backupRestoreForce, "force", "f", false, "Overwrite existing files that differ from the backup")
+	backupRestoreCmd.Flags().BoolVar(&backupRestoreDryRun, "dry-run", false, "Show what would be restored without writing")
+	backupRestoreCmd.Flags().BoolVar(&backupRestoreNoHooks, "no-hooks", false, "Don't restore the hooks base config and overrides")
+
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	rootCmd.AddCommand(backupCmd)
+}
+
+// hooksConfigDir is the directory holding hooks-base.json and hooks-overrides/.
+func hooksConfigDir() string {
+	return filepath.Dir(hooks.BasePath())
+}
+
+func runBackupCreate(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	output := backupOutput
+	if output == "" {
+		output = fmt.Sprintf("gt-backup-%s-%s.tar.gz", filepath.Base(townRoot), time.Now().Format("20060102-150405"))
+	}
+	output = expandTownPath(output)
+
+	f, err := os.OpenFile(output, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600) //nolint:gosec // G304: user-chosen output path
+	if err != nil {
+		return fmt.Errorf("creating archive: %w", err)
+	}
+	hooksDir := hooksConfigDir()
+	if backupNoHooks {
+		hooksDir = ""
+	}
+	manifest, err := backup.Create(f, townRoot, hooksDir)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(output)
+		return fmt.Errorf("creating backup: %w", err)
+	}
+
+	for _, p := range manifest.Skipped {
+		fmt.Printf("  %s Skipped unreadable %s\n", style.WarningPrefix, p)
+	}
+	fmt.Printf("%s Backed up %d file(s), %d rig(s) to %s\n",
+		style.SuccessPrefix, len(manifest.Files), len(manifest.Rigs), output)
+	fmt.Printf("  %s\n", style.Dim.Render("Repo clones and beads issue data are not included"))
+	return nil
+}
+
+func runBackupRestore(cmd *cobra.Command, args []string) error {
+	townRoot := expandTownPath(backupRestoreTo)
+	if townRoot == "" {
+		root, err := workspace.FindFromCwdOrError()
+		if err != nil {
+			return fmt.Errorf("not in a Gas Town workspace (use --to to restore elsewhere): %w", err)
+		}
+		townRoot = root
+	}
+	townRoot, err := filepath.Abs(townRoot)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return fmt.Errorf("opening archive: %w", err)
+	}
+	defer f.Close()
+
+	hooksDir := hooksConfigDir()
+	if backupRestoreNoHooks {
+		hooksDir = ""
+	}
+	result, err := backup.Restore(f, townRoot, hooksDir, backup.RestoreOptions{
+		Force:  backupRestoreForce,
+		DryRun: backupRestoreDryRun,
+	})
+	if errors.Is(err, backup.ErrConflict) {
+		fmt.Printf("%s These files differ from the backup:\n", style.WarningPrefix)
+		for _, p := range result.Conflicts {
+			fmt.Printf("  %s\n", p)
+		}
+		fmt.Printf("\nRe-run with %s to overwrite them (%s to preview).\n",
+			style.Bold.Render("--force"), style.Bold.Render("--dry-run --force"))
+		return NewSilentExit(1)
+	}
+	if err != nil {
+		return fmt.Errorf("restoring backup: %w", err)
+	}
+
+	m := result.Manifest
+	fmt.Printf("Backup of %s taken %s\n", style.Bold.Render(m.Town), m.CreatedAt.Local().Format(time.RFC1123))
+	verb := "Restored"
+	if backupRestoreDryRun {
+		verb = "Would restore"
+	}
+	for _, p := range result.Written {
+		fmt.Printf("  %s\n", p)
+	}
+	fmt.Printf("%s %s %d file(s) into %s (%d already up to date)\n",
+		style.SuccessPrefix, verb, len(result.Written), townRoot, len(result.Unchanged))
+
+	// Point out rigs whose clones still need to be recreated.
+	var missing []backup.RigRecord
+	for _, r := range m.Rigs {
+		if _, err := os.Stat(filepath.Join(townRoot, r.Name, "mayor", "rig")); os.IsNotExist(err) {
+			missing = append(missing, r)
+		}
+	}
+	if len(missing) > 0 {
+		fmt.Printf("\nThese rigs have no repo clone on this machine:\n")
+		for _, r := range missing {
+			fmt.Printf("  %-20s %s\n", r.Name, style.Dim.Render(r.GitURL))
+		}
+		fmt.Printf("Recreate each (e.g. %s), then check the rig with %s.\n",
+			style.Dim.Render("git clone <url> <town>/<rig>/mayor/rig"), style.Dim.Render("gt doctor"))
+	}
+	return nil
+}
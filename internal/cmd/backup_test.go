@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBackupCreateAndRestore(t *testing.T) {
+	townRoot := setupTestTownForConfig(t)
+	t.Setenv("GT_HOME", t.TempDir())
+	t.Chdir(townRoot)
+
+	archive := filepath.Join(t.TempDir(), "town.tar.gz")
+	oldOutput := backupOutput
+	backupOutput = archive
+	t.Cleanup(func() { backupOutput = oldOutput })
+
+	out := captureStdout(t, func() {
+		if err := runBackupCreate(backupCreateCmd, nil); err != nil {
+			t.Fatalf("runBackupCreate: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Backed up") {
+		t.Errorf("create output = %q", out)
+	}
+	if err := runBackupCreate(backupCreateCmd, nil); err == nil {
+		t.Error("an existing archive must not be overwritten")
+	}
+
+	target := filepath.Join(t.TempDir(), "newtown")
+	oldTo := backupRestoreTo
+	backupRestoreTo = target
+	t.Cleanup(func() { backupRestoreTo = oldTo })
+
+	captureStdout(t, func() {
+		if err := runBackupRestore(backupRestoreCmd, []string{archive}); err != nil {
+			t.Fatalf("runBackupRestore: %v", err)
+		}
+	})
+	want, err := os.ReadFile(filepath.Join(townRoot, "mayor", "town.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(filepath.Join(target, "mayor", "town.json"))
+	if err != nil {
+		t.Fatalf("town.json not restored: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("restored town.json = %s, want %s", got, want)
+	}
+
+	// Restoring over a changed file needs --force.
+	if err := os.WriteFile(filepath.Join(target, "mayor", "town.json"), []byte(`{}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	out = captureStdout(t, func() {
+		if err := runBackupRestore(backupRestoreCmd, []string{archive}); err == nil {
+			t.Error("expected a conflict without --force")
+		}
+	})
+	if !strings.Contains(out, "differ from the backup") {
+		t.Errorf("conflict output = %q", out)
+	}
+}
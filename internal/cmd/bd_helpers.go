@@ -153,9 +153,27 @@ func (b *bdCmd) resolvedArgs() []string {
 	return filtered
 }
 
+// invalidateQueryCache marks the bead query cache stale if the command may
+// have written, so gt hook, prime and the witness don't read back what was
+// there before it (see beads.InvalidateQueryCache). Commands run through
+// Build() directly must do this themselves.
+func (b *bdCmd) invalidateQueryCache() {
+	beadsDir := b.beadsDir
+	if beadsDir == "" {
+		for _, e := range b.env {
+			if v, ok := strings.CutPrefix(e, "BEADS_DIR="); ok {
+				beadsDir = v
+				break
+			}
+		}
+	}
+	beads.InvalidateQueryCache(b.dir, beadsDir, b.args)
+}
+
 // Run builds and runs the command, returning any error.
 // This is a convenience method equivalent to Build().Run().
 func (b *bdCmd) Run() error {
+	defer b.invalidateQueryCache()
 	return b.Build().Run()
 }
 
@@ -164,6 +182,7 @@ func (b *bdCmd) Run() error {
 // Note: Output() captures stdout but Stderr must still be configured
 // separately if you want to capture stderr instead of it going to os.Stderr.
 func (b *bdCmd) Output() ([]byte, error) {
+	defer b.invalidateQueryCache()
 	return b.Build().Output()
 }
 
@@ -171,6 +190,7 @@ func (b *bdCmd) Output() ([]byte, error) {
 // This overrides the configured Stderr writer to capture both streams.
 // Useful for including command output in error messages.
 func (b *bdCmd) CombinedOutput() ([]byte, error) {
+	defer b.invalidateQueryCache()
 	args := b.resolvedArgs()
 	cmd := exec.Command("bd", args...)
 	cmd.Dir = b.dir
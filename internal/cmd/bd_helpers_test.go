@@ -4,9 +4,13 @@ import (
 	"bytes"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
 )
 
 func TestBdCmd_Build(t *testing.T) {
@@ -520,3 +524,60 @@ func filterEnv(env []string, key string) []string {
 	}
 	return out
 }
+
+func TestBdCmd_RunInvalidatesQueryCache(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("mock bd script requires sh")
+	}
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"type":"town"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	beadsDir := filepath.Join(townRoot, ".beads")
+
+	// bd list prints whatever the hooked file holds; anything else is a no-op.
+	binDir := t.TempDir()
+	hooked := filepath.Join(binDir, "hooked.json")
+	script := "#!/bin/sh\nfor a in \"$@\"; do [ \"$a\" = list ] && exec cat " + hooked + "; done\necho ok\n"
+	if err := os.WriteFile(filepath.Join(binDir, "bd"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	beads.ResetBdAllowStaleCacheForTest()
+
+	query := []string{"list", "--json", "--assignee=mayor"}
+	read := func() string {
+		t.Helper()
+		out, err := beads.NewWithBeadsDir(townRoot, beadsDir).Run(query...)
+		if err != nil {
+			t.Fatalf("bd list: %v", err)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	if err := os.WriteFile(hooked, []byte("[]"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(); got != "[]" {
+		t.Fatalf("first read = %q, want []", got)
+	}
+	if err := os.WriteFile(hooked, []byte(`[{"id":"hq-1"}]`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := read(); got != "[]" {
+		t.Fatalf("second read = %q, want the cached []", got)
+	}
+
+	if err := BdCmd("update", "hq-1", "--status=hooked", "--assignee=mayor").
+		WithBeadsDir(beadsDir).
+		Dir(townRoot).
+		Run(); err != nil {
+		t.Fatalf("bd update: %v", err)
+	}
+	if got := read(); got != `[{"id":"hq-1"}]` {
+		t.Errorf("read after BdCmd write = %q, want the uncached result", got)
+	}
+}
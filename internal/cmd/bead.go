@@ -24,8 +24,12 @@ moving beads between repos and viewing beads by ID with automatic
 prefix-based routing.
 
 Subcommands:
+  flush   Replay bead updates queued while beads was unreachable
+  list    List beads across rigs with the standard filters
   move    Move a bead from one repository to another
+  new     Create a bead from a standard work item template
   show    Show details of a bead (routes by prefix)
+  stats   Show a town-wide planning view of bead progress
   read    Alias for show`,
 }
 
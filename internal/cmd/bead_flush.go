@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var beadFlushList bool
+
+var beadFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Replay bead updates queued while beads was unreachable",
+	Long: `Replay bead mutations queued while the beads database was unreachable.
+
+When bd can't reach the database (server down, network drop), gt queues
+status updates, closes, labels and comments instead of losing them, one
+journal per database under the town's .runtime/bead-queue/. A queue is
+replayed automatically before the next bd command against its database and
+on every daemon heartbeat; flush replays every queue in the town now.
+
+Mutations bd rejects on replay are moved to a .failed.jsonl file next to
+the journal.
+
+Examples:
+  gt bead flush          # Replay all queued mutations
+  gt bead flush --list   # Show what is queued without replaying`,
+	Args: cobra.NoArgs,
+	RunE: runBeadFlush,
+}
+
+func init() {
+	beadFlushCmd.Flags().BoolVar(&beadFlushList, "list", false, "List queued mutations without replaying them")
+	beadCmd.AddCommand(beadFlushCmd)
+}
+
+func runBeadFlush(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	dirs := beads.FindQueues(townRoot)
+	if len(dirs) == 0 {
+		fmt.Printf("%s No queued bead mutations\n", style.Success.Render("✓"))
+		return nil
+	}
+
+	var failed int
+	for _, dir := range dirs {
+		rel, _ := filepath.Rel(townRoot, dir)
+		queued, err := beads.LoadQueue(townRoot, dir)
+		if err != nil {
+			fmt.Printf("%s %s: %v\n", style.Error.Render("✗"), rel, err)
+			failed++
+			continue
+		}
+
+		if beadFlushList {
+			fmt.Printf("%s (%d queued)\n", style.Bold.Render(rel), len(queued))
+			for _, m := range queued {
+				fmt.Printf("  %s  bd %s\n", style.Dim.Render(m.At.Format("2006-01-02 15:04:05")), strings.Join(m.Args, " "))
+			}
+			continue
+		}
+
+		replayed, err := beads.NewWithBeadsDir(filepath.Dir(dir), dir).ReplayQueue()
+		if err != nil {
+			fmt.Printf("%s %s: replayed %d of %d: %v\n", style.Error.Render("✗"), rel, replayed, len(queued), err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s %s: replayed %d\n", style.Success.Render("✓"), rel, replayed)
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d queue(s) could not be flushed", failed)
+	}
+	return nil
+}
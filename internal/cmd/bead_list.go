@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	beadListRig   string
+	beadListJSON  bool
+	beadListQuery beads.QueryFlags
+)
+
+// beadListDefaultStatuses are listed when --status isn't given: everything
+// not yet done.
+var beadListDefaultStatuses = []string{"open", "in_progress", "hooked", "blocked"}
+
+var beadListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List beads across rigs with the standard filters",
+	Long: `List beads from every rig (or just --rig), filtered with the standard
+bead query flags shared by gt ready and the witness scans.
+
+Without --status, beads that aren't done (open, in_progress, hooked,
+blocked) are listed; use --status=all to include closed ones.
+
+Examples:
+  gt bead list                                  # Unfinished beads everywhere
+  gt bead list --rig gastown --status hooked    # Hooked work in one rig
+  gt bead list --assignee none --age 3d         # Unclaimed for 3+ days
+  gt bead list --label gastown --age '<1d'      # Labelled, created today`,
+	Args: cobra.NoArgs,
+	RunE: runBeadList,
+}
+
+func init() {
+	beadListCmd.Flags().StringVar(&beadListRig, "rig", "", "Only list this rig's beads")
+	beadListCmd.Flags().BoolVar(&beadListJSON, "json", false, "Output as JSON")
+	beadListQuery.Register(beadListCmd.Flags())
+	beadCmd.AddCommand(beadListCmd)
+}
+
+// beadListEntry is one bead in gt bead list --json output.
+type beadListEntry struct {
+	Rig string `json:"rig"`
+	*beads.Issue
+}
+
+func runBeadList(cmd *cobra.Command, args []string) error {
+	query, err := beadListQuery.Query()
+	if err != nil {
+		return err
+	}
+	if !cmd.Flags().Changed("status") {
+		query.Statuses = beadListDefaultStatuses
+	}
+
+	var rigs []*rig.Rig
+	if beadListRig != "" {
+		_, r, err := getRig(beadListRig)
+		if err != nil {
+			return err
+		}
+		rigs = []*rig.Rig{r}
+	} else if rigs, err = getAllRigs(); err != nil {
+		return err
+	}
+
+	var entries []beadListEntry
+	for _, r := range rigs {
+		issues, err := beads.New(r.BeadsPath()).Query(query)
+		if err != nil {
+			style.PrintWarning("%s: %v", r.Name, err)
+			continue
+		}
+		for _, issue := range filterIdentityBeads(issues) {
+			entries = append(entries, beadListEntry{Rig: r.Name, Issue: issue})
+		}
+	}
+
+	if beadListJSON {
+		if entries == nil {
+			entries = []beadListEntry{}
+		}
+		return outputJSON(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Println(style.Dim.Render("No matching beads"))
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tRIG\tSTATUS\tASSIGNEE\tCREATED\tTITLE")
+	for _, e := range entries {
+		created := e.CreatedAt
+		if t, err := time.Parse(time.RFC3339, e.CreatedAt); err == nil {
+			created = formatAge(t)
+		}
+		assignee := e.Assignee
+		if assignee == "" {
+			assignee = "-"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", e.ID, e.Rig, e.Status, assignee, created, e.Title)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	beadNewTemplate    string
+	beadNewTitle       string
+	beadNewDescription string
+	beadNewRig         string
+	beadNewSize        string
+	beadNewAcceptance  []string
+	beadNewPriority    int
+	beadNewJSON        bool
+)
+
+var beadNewCmd = &cobra.Command{
+	Use:   "new",
+	Short: "Create a bead from a standard work item template",
+	Long: `Create a bead from a template that enforces the fields Gas Town relies on.
+
+Every required field must be given; anything missing is reported together
+and nothing is created. The bead is created in the rig's beads, and records
+its template, rig, and size as fields in the description so dispatch can
+read them back.
+
+Templates:
+  polecat-task   A unit of work sized for one polecat
+                 requires --rig, --size, and at least one --acceptance
+  bug            A defect, with criteria that show it is fixed
+                 requires --rig and at least one --acceptance
+
+Sizes: xs, s, m, l, xl
+
+Examples:
+  gt bead new --template polecat-task --rig gastown --size m \
+      --title "Add retry to dolt sync" \
+      --acceptance "Transient errors are retried" \
+      --acceptance "Summary reports pulled databases"
+  gt bead new --template bug --rig beads --title "Crash on empty route" \
+      --acceptance "gt doctor passes with an empty routes.jsonl"`,
+	Args: cobra.NoArgs,
+	RunE: runBeadNew,
+}
+
+func init() {
+	beadNewCmd.Flags().StringVar(&beadNewTemplate, "template", "polecat-task", "Template to create from ("+strings.Join(beads.TemplateNames(), ", ")+")")
+	beadNewCmd.Flags().StringVarP(&beadNewTitle, "title", "t", "", "Bead title")
+	beadNewCmd.Flags().StringVarP(&beadNewDescription, "description", "d", "", "Bead description")
+	beadNewCmd.Flags().StringVar(&beadNewRig, "rig", "", "Rig the work belongs to")
+	beadNewCmd.Flags().StringVar(&beadNewSize, "size", "", "Estimated size (xs, s, m, l, xl)")
+	beadNewCmd.Flags().StringArrayVar(&beadNewAcceptance, "acceptance", nil, "Acceptance criterion (repeatable)")
+	beadNewCmd.Flags().IntVarP(&beadNewPriority, "priority", "p", -1, "Priority 0-4 (default: the template's)")
+	beadNewCmd.Flags().BoolVar(&beadNewJSON, "json", false, "Output the created bead as JSON")
+	beadCmd.AddCommand(beadNewCmd)
+}
+
+func runBeadNew(cmd *cobra.Command, args []string) error {
+	tmpl, err := beads.LookupTemplate(beadNewTemplate)
+	if err != nil {
+		return err
+	}
+	if beadNewPriority > 4 {
+		return fmt.Errorf("invalid --priority %d: must be 0-4", beadNewPriority)
+	}
+
+	opts, err := tmpl.CreateOptions(beads.TemplateInput{
+		Title:       beadNewTitle,
+		Description: beadNewDescription,
+		Rig:         beadNewRig,
+		Size:        beadNewSize,
+		Acceptance:  beadNewAcceptance,
+		Priority:    beadNewPriority,
+	})
+	if err != nil {
+		return err
+	}
+
+	_, r, err := getRig(beadNewRig)
+	if err != nil {
+		return err
+	}
+
+	issue, err := beads.New(r.BeadsPath()).Create(opts)
+	if err != nil {
+		return fmt.Errorf("creating bead in %s: %w", r.Name, err)
+	}
+
+	if beadNewJSON {
+		return outputJSON(issue)
+	}
+	fmt.Printf("%s Created %s in %s from %s: %s\n", style.Success.Render("✓"), issue.ID, r.Name, tmpl.Name, opts.Title)
+	return nil
+}
@@ -0,0 +1,287 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	beadStatsRig   string
+	beadStatsJSON  bool
+	beadStatsDays  int
+	beadStatsStuck time.Duration
+	beadStatsTop   int
+)
+
+var beadStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show a town-wide planning view of bead progress",
+	Long: `Aggregate beads across every rig (or just --rig) into a planning view:
+
+  Counts       ready, in progress (in_progress or hooked), blocked, and done
+               beads, per rig and for the town
+  Throughput   beads closed per day over the last --days days
+  Cycle time   average time from creation to close, per polecat
+  Stuck        the oldest in-progress beads not updated within --stuck
+
+A bead is ready when it is open with nothing blocking it. Wisps and agent
+identity beads are not counted.
+
+Examples:
+  gt bead stats                      # Whole town, last 7 days
+  gt bead stats --rig gastown        # One rig
+  gt bead stats --days 30 --top 10   # Longer window, more stuck items
+  gt bead stats --json               # Machine-readable`,
+	Args: cobra.NoArgs,
+	RunE: runBeadStats,
+}
+
+func init() {
+	beadStatsCmd.Flags().StringVar(&beadStatsRig, "rig", "", "Only include this rig's beads")
+	beadStatsCmd.Flags().BoolVar(&beadStatsJSON, "json", false, "Output as JSON")
+	beadStatsCmd.Flags().IntVar(&beadStatsDays, "days", 7, "Days of throughput history to show")
+	beadStatsCmd.Flags().DurationVar(&beadStatsStuck, "stuck", 24*time.Hour, "Consider in-progress beads stuck after this long without an update")
+	beadStatsCmd.Flags().IntVar(&beadStatsTop, "top", 5, "Number of stuck beads to list")
+	beadCmd.AddCommand(beadStatsCmd)
+}
+
+// beadStatsCounts is the number of beads in each planning bucket.
+type beadStatsCounts struct {
+	Rig        string `json:"rig,omitempty"`
+	Ready      int    `json:"ready"`
+	InProgress int    `json:"in_progress"`
+	Blocked    int    `json:"blocked"`
+	Done       int    `json:"done"`
+}
+
+// beadStatsDay is the number of beads closed on one day.
+type beadStatsDay struct {
+	Date   string `json:"date"` // YYYY-MM-DD, local time
+	Closed int    `json:"closed"`
+}
+
+// beadStatsCycle is a polecat's average creation-to-close time.
+type beadStatsCycle struct {
+	Polecat      string        `json:"polecat"`
+	Closed       int           `json:"closed"`
+	Average      time.Duration `json:"-"`
+	AverageHours float64       `json:"average_hours"`
+}
+
+// beadStatsStuckBead is an in-progress bead that hasn't moved lately.
+type beadStatsStuckBead struct {
+	ID       string    `json:"id"`
+	Rig      string    `json:"rig"`
+	Status   string    `json:"status"`
+	Assignee string    `json:"assignee,omitempty"`
+	Title    string    `json:"title"`
+	Updated  time.Time `json:"updated_at"`
+}
+
+// beadStats is the output of gt bead stats.
+type beadStats struct {
+	Town       beadStatsCounts      `json:"town"`
+	Rigs       []beadStatsCounts    `json:"rigs"`
+	Throughput []beadStatsDay       `json:"throughput"`
+	CycleTime  []beadStatsCycle     `json:"cycle_time"`
+	Stuck      []beadStatsStuckBead `json:"stuck"`
+}
+
+func runBeadStats(cmd *cobra.Command, args []string) error {
+	if beadStatsDays < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+
+	var rigs []*rig.Rig
+	if beadStatsRig != "" {
+		_, r, err := getRig(beadStatsRig)
+		if err != nil {
+			return err
+		}
+		rigs = []*rig.Rig{r}
+	} else {
+		var err error
+		if rigs, err = getAllRigs(); err != nil {
+			return err
+		}
+	}
+
+	var entries []beadListEntry
+	for _, r := range rigs {
+		issues, err := beads.New(r.BeadsPath()).Query(beads.Query{})
+		if err != nil {
+			style.PrintWarning("%s: %v", r.Name, err)
+			continue
+		}
+		for _, issue := range filterIdentityBeads(issues) {
+			entries = append(entries, beadListEntry{Rig: r.Name, Issue: issue})
+		}
+	}
+
+	stats := computeBeadStats(entries, time.Now(), beadStatsDays, beadStatsStuck, beadStatsTop)
+	if beadStatsJSON {
+		return outputJSON(stats)
+	}
+	printBeadStats(stats)
+	return nil
+}
+
+// computeBeadStats aggregates entries as of now. Throughput covers the last
+// days days including today; up to top in-progress beads not updated within
+// stuckAfter are reported, oldest first.
+func computeBeadStats(entries []beadListEntry, now time.Time, days int, stuckAfter time.Duration, top int) beadStats {
+	stats := beadStats{Rigs: []beadStatsCounts{}, CycleTime: []beadStatsCycle{}, Stuck: []beadStatsStuckBead{}}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	first := today.AddDate(0, 0, -(days - 1))
+	closedOn := make(map[string]int)
+
+	perRig := make(map[string]*beadStatsCounts)
+	cycles := make(map[string][]time.Duration)
+
+	for _, e := range entries {
+		if e.Ephemeral {
+			continue
+		}
+		counts, ok := perRig[e.Rig]
+		if !ok {
+			counts = &beadStatsCounts{Rig: e.Rig}
+			perRig[e.Rig] = counts
+		}
+
+		switch e.Status {
+		case "open":
+			if len(e.BlockedBy) > 0 || e.BlockedByCount > 0 {
+				counts.Blocked++
+			} else {
+				counts.Ready++
+			}
+		case "blocked":
+			counts.Blocked++
+		case string(beads.StatusInProgress), "hooked":
+			counts.InProgress++
+			updated, err := time.Parse(time.RFC3339, e.UpdatedAt)
+			if err == nil && now.Sub(updated) >= stuckAfter {
+				stats.Stuck = append(stats.Stuck, beadStatsStuckBead{
+					ID: e.ID, Rig: e.Rig, Status: e.Status, Assignee: e.Assignee, Title: e.Title, Updated: updated,
+				})
+			}
+		case "closed":
+			counts.Done++
+			closed, err := time.Parse(time.RFC3339, e.ClosedAt)
+			if err != nil {
+				continue
+			}
+			if local := closed.In(now.Location()); !local.Before(first) {
+				closedOn[local.Format("2006-01-02")]++
+			}
+			created, err := time.Parse(time.RFC3339, e.CreatedAt)
+			if err == nil && strings.Contains(e.Assignee, "/polecats/") && !closed.Before(created) {
+				cycles[e.Assignee] = append(cycles[e.Assignee], closed.Sub(created))
+			}
+		}
+	}
+
+	for _, counts := range perRig {
+		stats.Rigs = append(stats.Rigs, *counts)
+		stats.Town.Ready += counts.Ready
+		stats.Town.InProgress += counts.InProgress
+		stats.Town.Blocked += counts.Blocked
+		stats.Town.Done += counts.Done
+	}
+	sort.Slice(stats.Rigs, func(i, j int) bool { return stats.Rigs[i].Rig < stats.Rigs[j].Rig })
+
+	for d := first; !d.After(today); d = d.AddDate(0, 0, 1) {
+		date := d.Format("2006-01-02")
+		stats.Throughput = append(stats.Throughput, beadStatsDay{Date: date, Closed: closedOn[date]})
+	}
+
+	for polecat, durations := range cycles {
+		var total time.Duration
+		for _, d := range durations {
+			total += d
+		}
+		avg := total / time.Duration(len(durations))
+		stats.CycleTime = append(stats.CycleTime, beadStatsCycle{
+			Polecat: polecat, Closed: len(durations), Average: avg, AverageHours: avg.Hours(),
+		})
+	}
+	sort.Slice(stats.CycleTime, func(i, j int) bool { return stats.CycleTime[i].Polecat < stats.CycleTime[j].Polecat })
+
+	sort.Slice(stats.Stuck, func(i, j int) bool { return stats.Stuck[i].Updated.Before(stats.Stuck[j].Updated) })
+	if len(stats.Stuck) > top {
+		stats.Stuck = stats.Stuck[:top]
+	}
+
+	return stats
+}
+
+func printBeadStats(stats beadStats) {
+	fmt.Println(style.Bold.Render("Beads"))
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  RIG\tREADY\tIN PROGRESS\tBLOCKED\tDONE")
+	for _, c := range stats.Rigs {
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\t%d\n", c.Rig, c.Ready, c.InProgress, c.Blocked, c.Done)
+	}
+	if len(stats.Rigs) > 1 {
+		t := stats.Town
+		fmt.Fprintf(tw, "  %s\t%d\t%d\t%d\t%d\n", style.Bold.Render("town"), t.Ready, t.InProgress, t.Blocked, t.Done)
+	}
+	_ = tw.Flush()
+
+	total := 0
+	for _, d := range stats.Throughput {
+		total += d.Closed
+	}
+	fmt.Printf("\n%s (%d closed in %d days)\n", style.Bold.Render("Throughput"), total, len(stats.Throughput))
+	for _, d := range stats.Throughput {
+		fmt.Printf("  %s  %3d %s\n", d.Date, d.Closed, strings.Repeat("█", min(d.Closed, 50)))
+	}
+
+	fmt.Printf("\n%s\n", style.Bold.Render("Cycle time (created → closed)"))
+	if len(stats.CycleTime) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("No beads closed by polecats"))
+	} else {
+		tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, c := range stats.CycleTime {
+			fmt.Fprintf(tw, "  %s\t%s\t%s\n", c.Polecat, formatStatsDuration(c.Average), style.Dim.Render(fmt.Sprintf("%d closed", c.Closed)))
+		}
+		_ = tw.Flush()
+	}
+
+	fmt.Printf("\n%s\n", style.Bold.Render("Oldest stuck"))
+	if len(stats.Stuck) == 0 {
+		fmt.Printf("  %s\n", style.Dim.Render("Nothing stuck"))
+		return
+	}
+	tw = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, s := range stats.Stuck {
+		assignee := s.Assignee
+		if assignee == "" {
+			assignee = "-"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", s.ID, formatAge(s.Updated), s.Status, assignee, s.Title)
+	}
+	_ = tw.Flush()
+}
+
+// formatStatsDuration renders a cycle time in its largest sensible unit.
+func formatStatsDuration(d time.Duration) string {
+	switch {
+	case d >= 24*time.Hour:
+		return fmt.Sprintf("%.1fd", d.Hours()/24)
+	case d >= time.Hour:
+		return fmt.Sprintf("%.1fh", d.Hours())
+	default:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	}
+}
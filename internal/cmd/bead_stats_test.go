@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+func TestComputeBeadStats(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	ts := func(d time.Duration) string { return now.Add(-d).Format(time.RFC3339) }
+	day := 24 * time.Hour
+
+	entries := []beadListEntry{
+		{Rig: "gastown", Issue: &beads.Issue{ID: "gt-1", Status: "open"}},
+		{Rig: "gastown", Issue: &beads.Issue{ID: "gt-2", Status: "open", BlockedBy: []string{"gt-1"}}},
+		{Rig: "gastown", Issue: &beads.Issue{ID: "gt-3", Status: "in_progress", UpdatedAt: ts(3 * day)}},
+		{Rig: "gastown", Issue: &beads.Issue{ID: "gt-4", Status: "hooked", UpdatedAt: ts(2 * day)}},
+		{Rig: "gastown", Issue: &beads.Issue{ID: "gt-5", Status: "in_progress", UpdatedAt: ts(time.Hour)}},
+		{Rig: "gastown", Issue: &beads.Issue{ID: "gt-6", Status: "closed", Assignee: "gastown/polecats/toast",
+			CreatedAt: ts(day + 4*time.Hour), ClosedAt: ts(day)}},
+		{Rig: "beads", Issue: &beads.Issue{ID: "bd-1", Status: "closed", Assignee: "beads/polecats/toast",
+			CreatedAt: ts(2 * time.Hour), ClosedAt: ts(time.Hour)}},
+		{Rig: "beads", Issue: &beads.Issue{ID: "bd-2", Status: "closed", Assignee: "mayor/",
+			CreatedAt: ts(10 * day), ClosedAt: ts(9 * day)}},
+		{Rig: "beads", Issue: &beads.Issue{ID: "bd-wisp", Status: "closed", Ephemeral: true, ClosedAt: ts(time.Hour)}},
+	}
+
+	stats := computeBeadStats(entries, now, 3, 24*time.Hour, 1)
+
+	want := beadStatsCounts{Ready: 1, InProgress: 3, Blocked: 1, Done: 3}
+	if stats.Town != want {
+		t.Errorf("Town = %+v, want %+v", stats.Town, want)
+	}
+	if len(stats.Rigs) != 2 || stats.Rigs[0].Rig != "beads" || stats.Rigs[1].Done != 1 {
+		t.Errorf("Rigs = %+v", stats.Rigs)
+	}
+
+	wantDays := []beadStatsDay{{"2026-03-08", 0}, {"2026-03-09", 1}, {"2026-03-10", 1}}
+	if len(stats.Throughput) != len(wantDays) {
+		t.Fatalf("Throughput = %+v, want %+v", stats.Throughput, wantDays)
+	}
+	for i := range wantDays {
+		if stats.Throughput[i] != wantDays[i] {
+			t.Errorf("Throughput[%d] = %+v, want %+v", i, stats.Throughput[i], wantDays[i])
+		}
+	}
+
+	if len(stats.CycleTime) != 2 {
+		t.Fatalf("CycleTime = %+v, want two polecats", stats.CycleTime)
+	}
+	if c := stats.CycleTime[1]; c.Polecat != "gastown/polecats/toast" || c.Average != 4*time.Hour || c.Closed != 1 {
+		t.Errorf("CycleTime[1] = %+v", c)
+	}
+
+	if len(stats.Stuck) != 1 || stats.Stuck[0].ID != "gt-3" {
+		t.Errorf("Stuck = %+v, want only the oldest (gt-3)", stats.Stuck)
+	}
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -12,15 +13,31 @@ import (
 )
 
 var (
-	broadcastRig    string
-	broadcastAll    bool
-	broadcastDryRun bool
+	broadcastRig      string
+	broadcastRoles    []string
+	broadcastLabels   []string
+	broadcastAll      bool
+	broadcastDryRun   bool
+	broadcastInterval time.Duration
 )
 
+// broadcastRoleTypes maps --role values to agent types.
+var broadcastRoleTypes = map[string]AgentType{
+	"mayor":    AgentMayor,
+	"deacon":   AgentDeacon,
+	"witness":  AgentWitness,
+	"refinery": AgentRefinery,
+	"crew":     AgentCrew,
+	"polecat":  AgentPolecat,
+}
+
 func init() {
 	broadcastCmd.Flags().StringVar(&broadcastRig, "rig", "", "Only broadcast to workers in this rig")
+	broadcastCmd.Flags().StringSliceVar(&broadcastRoles, "role", nil, "Only broadcast to these roles (crew, polecat, witness, refinery, mayor, deacon); repeatable")
+	broadcastCmd.Flags().StringSliceVar(&broadcastLabels, "label", nil, "Only broadcast to sessions with this label (see 'gt session label'); repeatable")
 	broadcastCmd.Flags().BoolVar(&broadcastAll, "all", false, "Include all agents (mayor, witness, etc.), not just workers")
 	broadcastCmd.Flags().BoolVar(&broadcastDryRun, "dry-run", false, "Show what would be sent without sending")
+	broadcastCmd.Flags().DurationVar(&broadcastInterval, "interval", 100*time.Millisecond, "Delay between nudges to pace delivery")
 	rootCmd.AddCommand(broadcastCmd)
 }
 
@@ -31,13 +48,19 @@ var broadcastCmd = &cobra.Command{
 	Long: `Broadcasts a message to all active workers (polecats and crew).
 
 By default, only workers (polecats and crew) receive the message.
-Use --all to include infrastructure agents (mayor, deacon, witness, refinery).
+Use --all to include infrastructure agents (mayor, deacon, witness, refinery),
+or --role to pick specific roles. --label narrows the targets to sessions
+tagged with 'gt session label'. Agents in DND mode are skipped.
 
-The message is sent as a nudge to each worker's Claude Code session.
+The message is sent as a nudge to each worker's Claude Code session, paced
+by --interval so tmux and the agents are not flooded.
 
 Examples:
   gt broadcast "Check your mail"
   gt broadcast --rig greenplace "New priority work available"
+  gt broadcast --role crew "main is frozen for release, stop pushing"
+  gt broadcast --role crew --role polecat --rig gastown "Rebase onto main"
+  gt broadcast --label demo "Wrap up, demo starts in 10 minutes"
   gt broadcast --all "System maintenance in 5 minutes"
   gt broadcast --dry-run "Test message"`,
 	Args: cobra.ExactArgs(1),
@@ -60,27 +83,23 @@ func runBroadcast(cmd *cobra.Command, args []string) error {
 	// Get sender identity to exclude self
 	sender := os.Getenv("BD_ACTOR")
 
-	// Filter to target agents
-	var targets []*AgentSession
-	for _, agent := range agents {
-		// Filter by rig if specified
-		if broadcastRig != "" && agent.Rig != broadcastRig {
-			continue
+	targets, err := filterBroadcastTargets(agents, broadcastRig, broadcastRoles, broadcastAll, sender)
+	if err != nil {
+		return err
+	}
+	townRoot, _ := workspace.FindFromCwd()
+	if len(broadcastLabels) > 0 {
+		meta, err := loadSessionMetaForFilter(townRoot, broadcastLabels)
+		if err != nil {
+			return err
 		}
-
-		// Unless --all, only include workers (crew + polecats)
-		if !broadcastAll {
-			if agent.Type != AgentCrew && agent.Type != AgentPolecat {
-				continue
+		var labeled []*AgentSession
+		for _, agent := range targets {
+			if meta[agent.Name].HasLabels(broadcastLabels) {
+				labeled = append(labeled, agent)
 			}
 		}
-
-		// Skip self to avoid interrupting own session
-		if sender != "" && formatAgentName(agent) == sender {
-			continue
-		}
-
-		targets = append(targets, agent)
+		targets = labeled
 	}
 
 	if len(targets) == 0 {
@@ -88,6 +107,12 @@ func runBroadcast(cmd *cobra.Command, args []string) error {
 		if broadcastRig != "" {
 			fmt.Printf("  (filtered by rig: %s)\n", broadcastRig)
 		}
+		if len(broadcastRoles) > 0 {
+			fmt.Printf("  (filtered by role: %s)\n", strings.Join(broadcastRoles, ", "))
+		}
+		if len(broadcastLabels) > 0 {
+			fmt.Printf("  (filtered by label: %s)\n", strings.Join(broadcastLabels, ", "))
+		}
 		return nil
 	}
 
@@ -103,7 +128,6 @@ func runBroadcast(cmd *cobra.Command, args []string) error {
 
 	// Send nudges
 	t := tmux.NewTmux()
-	townRoot, _ := workspace.FindFromCwd()
 	var succeeded, failed, skipped int
 	var failures []string
 
@@ -130,9 +154,9 @@ func runBroadcast(cmd *cobra.Command, args []string) error {
 			fmt.Printf("  %s %s %s\n", style.SuccessPrefix, AgentTypeIcons[agent.Type], agentName)
 		}
 
-		// Small delay between nudges to avoid overwhelming tmux
-		if i < len(targets)-1 {
-			time.Sleep(100 * time.Millisecond)
+		// Pace nudges to avoid overwhelming tmux
+		if i < len(targets)-1 && broadcastInterval > 0 {
+			time.Sleep(broadcastInterval)
 		}
 	}
 
@@ -157,6 +181,43 @@ func runBroadcast(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+// filterBroadcastTargets selects the agents a broadcast should reach.
+// With roles set, only those roles are included (--all is ignored); otherwise
+// only workers (crew + polecats) unless all is true. The sender is always
+// skipped to avoid interrupting its own session.
+func filterBroadcastTargets(agents []*AgentSession, rig string, roles []string, all bool, sender string) ([]*AgentSession, error) {
+	roleSet := make(map[AgentType]bool, len(roles))
+	for _, r := range roles {
+		at, ok := broadcastRoleTypes[strings.ToLower(strings.TrimSpace(r))]
+		if !ok {
+			return nil, fmt.Errorf("unknown role %q (valid: crew, polecat, witness, refinery, mayor, deacon)", r)
+		}
+		roleSet[at] = true
+	}
+
+	var targets []*AgentSession
+	for _, agent := range agents {
+		if rig != "" && agent.Rig != rig {
+			continue
+		}
+		switch {
+		case len(roleSet) > 0:
+			if !roleSet[agent.Type] {
+				continue
+			}
+		case !all:
+			if agent.Type != AgentCrew && agent.Type != AgentPolecat {
+				continue
+			}
+		}
+		if sender != "" && formatAgentName(agent) == sender {
+			continue
+		}
+		targets = append(targets, agent)
+	}
+	return targets, nil
+}
+
 // formatAgentName returns a display name for an agent.
 func formatAgentName(agent *AgentSession) string {
 	switch agent.Type {
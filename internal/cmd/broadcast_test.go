@@ -0,0 +1,60 @@
+package cmd
+
+import "testing"
+
+func TestFilterBroadcastTargets(t *testing.T) {
+	agents := []*AgentSession{
+		{Name: "hq-mayor", Type: AgentMayor},
+		{Name: "gt-witness", Type: AgentWitness, Rig: "gastown"},
+		{Name: "gt-crew-max", Type: AgentCrew, Rig: "gastown", AgentName: "max"},
+		{Name: "gt-Toast", Type: AgentPolecat, Rig: "gastown", AgentName: "Toast"},
+		{Name: "bd-crew-joe", Type: AgentCrew, Rig: "beads", AgentName: "joe"},
+	}
+
+	names := func(targets []*AgentSession) []string {
+		var out []string
+		for _, a := range targets {
+			out = append(out, a.Name)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name   string
+		rig    string
+		roles  []string
+		all    bool
+		sender string
+		want   []string
+	}{
+		{"workers by default", "", nil, false, "", []string{"gt-crew-max", "gt-Toast", "bd-crew-joe"}},
+		{"all agents", "", nil, true, "", []string{"hq-mayor", "gt-witness", "gt-crew-max", "gt-Toast", "bd-crew-joe"}},
+		{"role crew", "", []string{"crew"}, false, "", []string{"gt-crew-max", "bd-crew-joe"}},
+		{"role and rig", "gastown", []string{"crew", "witness"}, false, "", []string{"gt-witness", "gt-crew-max"}},
+		{"role overrides all", "", []string{"polecat"}, true, "", []string{"gt-Toast"}},
+		{"skips sender", "gastown", nil, false, "gastown/crew/max", []string{"gt-Toast"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := filterBroadcastTargets(agents, tt.rig, tt.roles, tt.all, tt.sender)
+			if err != nil {
+				t.Fatalf("filterBroadcastTargets: %v", err)
+			}
+			gotNames := names(got)
+			if len(gotNames) != len(tt.want) {
+				t.Fatalf("targets = %v, want %v", gotNames, tt.want)
+			}
+			for i := range tt.want {
+				if gotNames[i] != tt.want[i] {
+					t.Errorf("targets = %v, want %v", gotNames, tt.want)
+					break
+				}
+			}
+		})
+	}
+
+	if _, err := filterBroadcastTargets(agents, "", []string{"janitor"}, false, ""); err == nil {
+		t.Error("unknown role should be rejected")
+	}
+}
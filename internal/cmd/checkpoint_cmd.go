@@ -28,7 +28,23 @@ Checkpoint data includes:
 - Git branch and last commit
 - Timestamp
 
-Checkpoints are stored in .polecat-checkpoint.json in the polecat directory.`,
+Checkpoints are stored in .polecat-checkpoint.json in the polecat directory.
+gt prime treats a checkpoint as crash-recovery state for 24h; after that it is
+a stale leftover and discarded. Set the cutoff per role in mayor/town.json:
+
+  "checkpoints": {"stale_after": {"crew": "72h", "default": "12h"}}
+
+'gt prime --explain' shows a checkpoint's age against its threshold.
+
+To encrypt checkpoints at rest, set age recipients (and the identity used to
+read them back) in mayor/town.json; the age CLI must be in PATH:
+
+  "checkpoints": {
+    "encryption": {
+      "recipients": ["age1..."],
+      "identity": "~/.config/age/gastown.txt"
+    }
+  }`,
 }
 
 var checkpointWriteCmd = &cobra.Command{
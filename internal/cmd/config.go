@@ -34,7 +34,10 @@ Commands:
   gt config agent set <name> <cmd>   Set custom agent command
   gt config agent remove <name>      Remove custom agent
   gt config default-agent [name]     Get or set default agent
-  gt config default-agent list       List available agents`,
+  gt config default-agent list       List available agents
+  gt config effective <target>       Show an agent's resolved settings and sources
+  gt config validate                 Check town config files for errors
+  gt config migrate                  Upgrade config files to current schemas`,
 }
 
 // Agent subcommands
@@ -672,6 +675,15 @@ Supported keys:
   lifecycle.backup.enabled     Enable/disable JSONL + Dolt backups (true/false)
   lifecycle.backup.interval    Backup interval (default: 15m)
 
+  Any field, by its JSON path in a config file:
+  town.<path>                  mayor/town.json (e.g. town.owner)
+  settings.<path>              settings/config.json (e.g. settings.scheduler.max_polecats)
+  rig.<rig>.<path>             <rig>/settings/config.json (e.g. rig.gastown.namepool.max_before_numbering)
+
+  Values are checked against the field's type: numbers, true/false, and
+  JSON for lists and objects; strings may be given bare. The file is
+  validated before it is written.
+
 Examples:
   gt config set convoy.notify_on_complete true
   gt config set cli_theme dark
@@ -681,7 +693,9 @@ Examples:
   gt config set maintenance.window 03:00
   gt config set maintenance.interval daily
   gt config set lifecycle.reaper.delete_age 336h
-  gt config set lifecycle.compactor.threshold 1000`,
+  gt config set lifecycle.compactor.threshold 1000
+  gt config set town.public_name "Gas Town West"
+  gt config set rig.gastown.session_env.vars.HTTP_PROXY http://proxy:3128`,
 	Args: cobra.ExactArgs(2),
 	RunE: runConfigSet,
 }
@@ -716,11 +730,20 @@ Supported keys:
   lifecycle.backup.enabled     JSONL + Dolt backups enabled (true/false)
   lifecycle.backup.interval    Backup interval
 
+  Any field, by its JSON path in a config file:
+  town.<path>                  mayor/town.json
+  settings.<path>              settings/config.json
+  rig.<rig>.<path>             <rig>/settings/config.json
+
+  Scalars print bare, objects and lists as JSON; unset fields print nothing.
+
 Examples:
   gt config get convoy.notify_on_complete
   gt config get cli_theme
   gt config get maintenance.window
-  gt config get lifecycle.reaper.delete_age`,
+  gt config get lifecycle.reaper.delete_age
+  gt config get town.owner
+  gt config get rig.gastown.merge_queue`,
 	Args: cobra.ExactArgs(1),
 	RunE: runConfigGet,
 }
@@ -823,7 +846,10 @@ func runConfigSet(cmd *cobra.Command, args []string) error {
 		if strings.HasPrefix(key, "lifecycle.") {
 			return setLifecycleConfig(townRoot, key, value)
 		}
-		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
+		if handled, err := setConfigPath(townRoot, key, value); handled {
+			return err
+		}
+		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*\n  town.<path>, settings.<path>, rig.<rig>.<path>", key)
 	}
 
 	if err := config.SaveTownSettings(settingsPath, townSettings); err != nil {
@@ -908,7 +934,10 @@ func runConfigGet(cmd *cobra.Command, args []string) error {
 		if strings.HasPrefix(key, "lifecycle.") {
 			return getLifecycleConfig(townRoot, key)
 		}
-		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*", key)
+		if handled, err := getConfigPath(townRoot, key); handled {
+			return err
+		}
+		return fmt.Errorf("unknown config key: %q\n\nSupported keys:\n  convoy.notify_on_complete\n  cli_theme\n  default_agent\n  dolt.port\n  scheduler.max_polecats\n  scheduler.batch_size\n  scheduler.spawn_delay\n  maintenance.window\n  maintenance.interval\n  maintenance.threshold\n  lifecycle.reaper.*\n  lifecycle.compactor.*\n  lifecycle.doctor.*\n  lifecycle.backup.*\n  town.<path>, settings.<path>, rig.<rig>.<path>", key)
 	}
 
 	fmt.Println(value)
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var configEffectiveJSON bool
+
+var configEffectiveCmd = &cobra.Command{
+	Use:   "effective <target>",
+	Short: "Show the settings an agent resolves to, and where each comes from",
+	Long: `Show the resolved settings for an agent: which agent runs it, the full
+command line, model, extra args, permission mode, config dir, env and
+hooks options, and health limits, each with the config layer that set it.
+
+Agent session settings are layered town → rig → role. In town and rig
+settings (settings/config.json), "defaults" applies to every role and
+"roles.<role>" to one role; a rig's mayor/rigs.json entry can also set
+"roles.<role>". The most specific layer wins:
+
+  rig roles.<role>  >  rigs.json roles.<role>  >  town roles.<role>
+    >  rig defaults  >  town defaults
+
+Health limits come from role definitions: built-in, then
+<town>/roles/<role>.toml, then <rig>/roles/<role>.toml.
+
+Targets:
+  mayor, deacon, dog            Town-level roles
+  <rig>/witness, <rig>/refinery Rig agents
+  <rig>/polecat, <rig>/crew     Any polecat or crew member of a rig
+  <rig>/crew/<name>             A crew member (includes worker_agents)
+  <rig>/<polecat>               A polecat by name
+
+Examples:
+  gt config effective gastown/polecat
+  gt config effective gastown/crew/max
+  gt config effective mayor --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigEffective,
+}
+
+func init() {
+	configEffectiveCmd.Flags().BoolVar(&configEffectiveJSON, "json", false, "Output as JSON")
+	configCmd.AddCommand(configEffectiveCmd)
+}
+
+func runConfigEffective(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigName, role, worker, err := parseEffectiveTarget(args[0])
+	if err != nil {
+		return err
+	}
+	rigPath := ""
+	if rigName != "" {
+		rigPath = filepath.Join(townRoot, rigName)
+		if _, err := os.Stat(rigPath); err != nil {
+			return fmt.Errorf("rig '%s' not found", rigName)
+		}
+	}
+
+	settings, err := config.EffectiveRoleSettings(role, worker, townRoot, rigPath)
+	if err != nil {
+		return err
+	}
+
+	if configEffectiveJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(settings)
+	}
+
+	fmt.Printf("%s\n\n", style.Bold.Render(args[0]))
+	width := 0
+	for _, s := range settings {
+		width = max(width, len(s.Key))
+	}
+	for _, s := range settings {
+		fmt.Printf("  %-*s  %s  %s\n", width, s.Key, s.Value, style.Dim.Render("("+s.Source+")"))
+	}
+	return nil
+}
+
+// parseEffectiveTarget splits a 'gt config effective' target into its rig
+// (empty for town roles), role, and crew worker name.
+func parseEffectiveTarget(target string) (rigName, role, worker string, err error) {
+	t := strings.TrimSuffix(strings.TrimSpace(target), "/")
+	if t == string(session.RoleDog) {
+		return "", t, "", nil
+	}
+	if r, rest, ok := strings.Cut(t, "/"); ok && (rest == string(session.RolePolecat) || rest == string(session.RoleCrew)) {
+		return r, rest, "", nil
+	}
+
+	id, err := session.ParseAddress(t)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid target %q: want mayor, deacon, dog, <rig>/<role>, or an agent address like <rig>/crew/<name>", target)
+	}
+	if id.Role == session.RoleCrew {
+		worker = id.Name
+	}
+	return id.Rig, string(id.Role), worker, nil
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import "testing"
+
+func TestParseEffectiveTarget(t *testing.T) {
+	tests := []struct {
+		target            string
+		rig, role, worker string
+		wantErr           bool
+	}{
+		{target: "mayor", role: "mayor"},
+		{target: "dog", role: "dog"},
+		{target: "gastown/witness", rig: "gastown", role: "witness"},
+		{target: "gastown/polecat", rig: "gastown", role: "polecat"},
+		{target: "gastown/crew", rig: "gastown", role: "crew"},
+		{target: "gastown/crew/max", rig: "gastown", role: "crew", worker: "max"},
+		{target: "gastown/Toast", rig: "gastown", role: "polecat"},
+		{target: "gastown/polecats/Toast/", rig: "gastown", role: "polecat"},
+		{target: "gastown", wantErr: true},
+	}
+	for _, tt := range tests {
+		rig, role, worker, err := parseEffectiveTarget(tt.target)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("parseEffectiveTarget(%q) error = %v, wantErr %v", tt.target, err, tt.wantErr)
+			continue
+		}
+		if rig != tt.rig || role != tt.role || worker != tt.worker {
+			t.Errorf("parseEffectiveTarget(%q) = %q, %q, %q; want %q, %q, %q",
+				tt.target, rig, role, worker, tt.rig, tt.role, tt.worker)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var configMigrateDryRun bool
+
+var configMigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Upgrade town config files to the current schema versions",
+	Long: `Upgrade mayor/town.json, mayor/rigs.json, and mayor/quota.json to the
+schema versions this gt understands, one numbered migration at a time.
+
+Before a file is rewritten, the original is saved next to it as
+<file>.v<old version>.bak. An existing backup is never overwritten, so the
+oldest original survives repeated runs.
+
+gt upgrade runs this as its first step.
+
+Examples:
+  gt config migrate            # Apply pending migrations
+  gt config migrate --dry-run  # List them without changing anything`,
+	Args: cobra.NoArgs,
+	RunE: runConfigMigrate,
+}
+
+func init() {
+	configMigrateCmd.Flags().BoolVar(&configMigrateDryRun, "dry-run", false, "List pending migrations without applying them")
+	configCmd.AddCommand(configMigrateCmd)
+}
+
+func runConfigMigrate(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	result, err := config.MigrateTown(townRoot, config.MigrateOptions{DryRun: configMigrateDryRun})
+	if result != nil {
+		printMigrationSteps(result, configMigrateDryRun, "")
+	}
+	if err != nil {
+		return err
+	}
+	if len(result.Steps) == 0 {
+		fmt.Printf("%s Config files are at the current schema versions\n", style.SuccessPrefix)
+	}
+	return nil
+}
+
+// printMigrationSteps lists the migrations in result, indented by indent.
+func printMigrationSteps(result *config.MigrateResult, dryRun bool, indent string) {
+	prefix, verb := style.SuccessPrefix, "migrated"
+	if dryRun {
+		prefix, verb = style.WarningPrefix, "would migrate"
+	}
+	for _, s := range result.Steps {
+		fmt.Printf("%s%s %s %s v%d → v%d: %s\n", indent, prefix, s.File, style.Dim.Render(verb), s.From, s.To, s.Description)
+	}
+	for _, b := range result.Backups {
+		fmt.Printf("%s  %s\n", indent, style.Dim.Render("backup: "+b))
+	}
+}
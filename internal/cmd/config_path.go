@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// configDocument is a config file addressed by a 'gt config get/set' path:
+//
+//	town.<path>          mayor/town.json
+//	settings.<path>      settings/config.json
+//	rig.<rig>.<path>     <rig>/settings/config.json
+//
+// town.json is read with the selected environment overlay for get, and
+// without it for set, so set never copies overlay values into the base file.
+type configDocument struct {
+	label string // file shown to the user
+	value any    // pointer to the loaded config
+	save  func() error
+}
+
+// loadConfigDocument resolves key to the config file it addresses and the
+// dotted path within it. edit loads it for modification. ok is false if key
+// doesn't start with one of the document prefixes.
+func loadConfigDocument(townRoot, key string, edit bool) (doc *configDocument, path string, ok bool, err error) {
+	scope, rest, _ := strings.Cut(key, ".")
+	switch scope {
+	case "town":
+		file := filepath.Join(townRoot, "mayor", "town.json")
+		load := config.LoadTownConfig
+		if edit {
+			load = config.LoadTownConfigBase
+		}
+		cfg, err := load(file)
+		if err != nil {
+			return nil, "", true, fmt.Errorf("loading town config: %w", err)
+		}
+		return &configDocument{
+			label: "mayor/town.json",
+			value: cfg,
+			save:  func() error { return config.SaveTownConfig(file, cfg) },
+		}, rest, true, nil
+
+	case "settings":
+		file := config.TownSettingsPath(townRoot)
+		cfg, err := config.LoadOrCreateTownSettings(file)
+		if err != nil {
+			return nil, "", true, fmt.Errorf("loading town settings: %w", err)
+		}
+		return &configDocument{
+			label: "settings/config.json",
+			value: cfg,
+			save:  func() error { return config.SaveTownSettings(file, cfg) },
+		}, rest, true, nil
+
+	case "rig":
+		rigName, rest, _ := strings.Cut(rest, ".")
+		rigs, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+		if err != nil {
+			return nil, "", true, fmt.Errorf("loading rigs config: %w", err)
+		}
+		if _, exists := rigs.Rigs[rigName]; !exists {
+			return nil, "", true, fmt.Errorf("rig %q not found", rigName)
+		}
+		file := config.RigSettingsPath(filepath.Join(townRoot, rigName))
+		cfg, err := config.LoadRigSettings(file)
+		if errors.Is(err, config.ErrNotFound) {
+			cfg, err = config.NewRigSettings(), nil
+		}
+		if err != nil {
+			return nil, "", true, fmt.Errorf("loading rig settings: %w", err)
+		}
+		return &configDocument{
+			label: rigName + "/settings/config.json",
+			value: cfg,
+			save:  func() error { return config.SaveRigSettings(file, cfg) },
+		}, rest, true, nil
+	}
+	return nil, "", false, nil
+}
+
+// setConfigPath handles 'gt config set' for document-prefixed keys. The
+// value is parsed for the field's type, and the file is validated as it is
+// saved, so a bad value never reaches disk.
+func setConfigPath(townRoot, key, value string) (bool, error) {
+	doc, path, ok, err := loadConfigDocument(townRoot, key, true)
+	if !ok || err != nil {
+		return ok, err
+	}
+	if err := config.SetPath(doc.value, path, value); err != nil {
+		return true, err
+	}
+	if err := doc.save(); err != nil {
+		return true, fmt.Errorf("saving %s: %w", doc.label, err)
+	}
+	fmt.Printf("Set %s = %s %s\n", style.Bold.Render(key), value, style.Dim.Render("("+doc.label+")"))
+	return true, nil
+}
+
+// getConfigPath handles 'gt config get' for document-prefixed keys. Scalars
+// print bare; objects and lists print as JSON. Unset values print nothing.
+func getConfigPath(townRoot, key string) (bool, error) {
+	doc, path, ok, err := loadConfigDocument(townRoot, key, false)
+	if !ok || err != nil {
+		return ok, err
+	}
+	value, set, err := config.GetPath(doc.value, path)
+	if err != nil || !set {
+		return true, err
+	}
+	switch v := value.(type) {
+	case string:
+		fmt.Println(v)
+	case bool, int, int64, float64:
+		fmt.Println(v)
+	default:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return true, fmt.Errorf("encoding %s: %w", key, err)
+		}
+		fmt.Println(string(data))
+	}
+	return true, nil
+}
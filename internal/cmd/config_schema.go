@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/hooks"
+	"github.com/steveyegge/gastown/internal/jsonschema"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// configSchemaFile is a config file gt config schema can describe.
+type configSchemaFile struct {
+	Path  string // where the file lives, for the title
+	Value any    // the type the file is decoded into
+}
+
+var configSchemaFiles = map[string]configSchemaFile{
+	"town":     {"settings/config.json", config.TownSettings{}},
+	"rig":      {"<rig>/settings/config.json", config.RigSettings{}},
+	"rigs":     {"mayor/rigs.json", config.RigsConfig{}},
+	"hooks":    {"~/.gt/hooks-base.json and hooks-overrides/*.json", hooks.HooksConfig{}},
+	"accounts": {"mayor/accounts.json", config.AccountsConfig{}},
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema [town|rig|rigs|hooks|accounts]",
+	Short: "Print the JSON Schema for a config file",
+	Long: `Print the JSON Schema (draft 2020-12) for a Gas Town config file.
+
+The schema is derived from the types gt decodes the file into, so it
+always matches this gt version. Point an editor at it for completion and
+validation, or use it to generate configs from other tools.
+
+  town      settings/config.json
+  rig       <rig>/settings/config.json
+  rigs      mayor/rigs.json
+  hooks     ~/.gt/hooks-base.json and hooks-overrides/*.json
+  accounts  mayor/accounts.json
+
+Without an argument, lists the available schemas.
+
+Examples:
+  gt config schema town > town-settings.schema.json
+  gt config schema rigs | jq '.["$defs"].RigEntry'`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runConfigSchema,
+}
+
+func init() {
+	configCmd.AddCommand(configSchemaCmd)
+}
+
+func runConfigSchema(cmd *cobra.Command, args []string) error {
+	if len(args) == 0 {
+		for _, name := range configSchemaNames() {
+			fmt.Printf("  %-9s %s\n", name, style.Dim.Render(configSchemaFiles[name].Path))
+		}
+		return nil
+	}
+	schema, err := configSchema(args[0])
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(schema)
+}
+
+// configSchema returns the schema for the named config file.
+func configSchema(name string) (*jsonschema.Schema, error) {
+	file, ok := configSchemaFiles[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown config file %q (have: %s)", name, strings.Join(configSchemaNames(), ", "))
+	}
+	schema := jsonschema.Generate(file.Value)
+	schema.Title = "Gas Town " + name + " config (" + file.Path + ")"
+	return schema, nil
+}
+
+func configSchemaNames() []string {
+	names := make([]string, 0, len(configSchemaFiles))
+	for name := range configSchemaFiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/secrets"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+	"golang.org/x/term"
+)
+
+var configSecretCmd = &cobra.Command{
+	Use:   "secret",
+	Short: "Manage secrets exported into agent sessions",
+	Long: `Manage secrets exported into agent sessions.
+
+The "secrets" section of town or rig settings (settings/config.json) maps
+env var names to references, never to values:
+
+  "secrets": {
+    "vars":  { "GITHUB_TOKEN": "keychain:GITHUB_TOKEN" },
+    "roles": { "crew": { "JIRA_API_TOKEN": "gpg:~/.secrets/jira.gpg" } }
+  }
+
+  keychain:NAME  OS keychain item, service "gastown", account NAME
+  gpg:PATH       gpg-encrypted file
+  env:NAME       variable from gt's own environment
+
+References are resolved when a session is spawned. Rig secrets are layered
+over town secrets, like session_env.`,
+	RunE: requireSubcommand,
+}
+
+var configSecretSetCmd = &cobra.Command{
+	Use:   "set <name>",
+	Short: "Store a secret in the OS keychain",
+	Long: `Store a secret in the OS keychain, for use as keychain:<name>.
+
+The value is read from stdin, or prompted for without echo on a terminal.
+
+Examples:
+  gt config secret set GITHUB_TOKEN
+  pass show github | gt config secret set GITHUB_TOKEN`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConfigSecretSet,
+}
+
+var configSecretCheckCmd = &cobra.Command{
+	Use:   "check [rig]",
+	Short: "Check that configured secrets resolve",
+	Long: `Resolve every configured secret reference and report which fail.
+Values are never printed.
+
+Without a rig, checks town secrets; with a rig, also that rig's.`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runConfigSecretCheck,
+}
+
+func init() {
+	configSecretCmd.AddCommand(configSecretSetCmd)
+	configSecretCmd.AddCommand(configSecretCheckCmd)
+	configCmd.AddCommand(configSecretCmd)
+}
+
+func runConfigSecretSet(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	var value string
+	if fd := int(os.Stdin.Fd()); term.IsTerminal(fd) {
+		fmt.Fprintf(os.Stderr, "Value for %s: ", name)
+		b, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return fmt.Errorf("reading value: %w", err)
+		}
+		value = string(b)
+	} else {
+		b, err := io.ReadAll(bufio.NewReader(os.Stdin))
+		if err != nil {
+			return fmt.Errorf("reading value: %w", err)
+		}
+		value = strings.TrimRight(string(b), "\r\n")
+	}
+	if value == "" {
+		return fmt.Errorf("empty value for %s", name)
+	}
+	if err := secrets.Store(name, value); err != nil {
+		return err
+	}
+	fmt.Printf("%s Stored %s; reference it as %q\n", style.Success.Render("✓"), name, secrets.SchemeKeychain+":"+name)
+	return nil
+}
+
+func runConfigSecretCheck(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	type source struct {
+		label string
+		cfg   *config.SessionEnvConfig
+	}
+	var sources []source
+	townSettings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+	sources = append(sources, source{"town", townSettings.Secrets})
+	if len(args) == 1 {
+		rigSettings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(townRoot, args[0])))
+		if err != nil {
+			return fmt.Errorf("loading rig settings: %w", err)
+		}
+		sources = append(sources, source{"rig " + args[0], rigSettings.Secrets})
+	}
+
+	total, failed := 0, 0
+	for _, src := range sources {
+		if src.cfg == nil {
+			continue
+		}
+		scopes := map[string]map[string]string{"": src.cfg.Vars}
+		for role, vars := range src.cfg.Roles {
+			scopes[role] = vars
+		}
+		for _, scope := range sortedStringKeys(scopes) {
+			vars := scopes[scope]
+			for _, name := range sortedStringKeys(vars) {
+				total++
+				label := src.label
+				if scope != "" {
+					label += " " + scope
+				}
+				if _, err := secrets.Resolve(vars[name]); err != nil {
+					failed++
+					fmt.Printf("%s %s (%s): %s → %v\n", style.Error.Render("✗"), name, label, vars[name], err)
+					continue
+				}
+				fmt.Printf("%s %s (%s): %s\n", style.Success.Render("✓"), name, label, vars[name])
+			}
+		}
+	}
+
+	if total == 0 {
+		fmt.Println(style.Dim.Render("No secrets configured."))
+		return nil
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d secret(s) failed to resolve", failed, total)
+	}
+	return nil
+}
+
+func sortedStringKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
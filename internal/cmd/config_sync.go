@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/sharedconfig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	configSyncSource string
+	configSyncRef    string
+	configSyncPath   string
+	configSyncDryRun bool
+)
+
+var configSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Fetch the org-shared town config",
+	Long: `Fetch the town's shared config from a git repo or archive URL.
+
+A team keeps one repo of defaults for everyone's towns:
+
+  town.json               Defaults for mayor/town.json (hooks, schedule,
+                          roles, ...). Name and owner are never shared.
+  rigs.json               The rigs every town should have (mayor/rigs.json
+                          format)
+  hooks-base.json         Hooks base config (see 'gt hooks base')
+  hooks-overrides/*.json  Hooks overrides, e.g. crew.json, gastown__crew.json
+
+Each town's own files layer on top: keys set in mayor/town.json win over
+the shared town.json (set one to null to drop a shared value), and the
+user's hooks base and overrides apply after the shared ones.
+
+The source is set in mayor/town.json under "shared" (source, ref, path),
+or with --source here. Shared files only change on sync: run 'gt hooks
+sync' afterwards to apply hooks changes. Rigs in the shared list that this
+town doesn't have are listed for 'gt rig add'; sync doesn't clone them.
+
+Examples:
+  gt config sync --source git@github.com:acme/gt-config.git
+  gt config sync --source https://example.com/gt-config.tar.gz
+  gt config sync --path teams/platform   # Subdirectory of the source
+  gt config sync --dry-run               # Show what would change
+  gt config sync`,
+	Args: cobra.NoArgs,
+	RunE: runConfigSync,
+}
+
+func init() {
+	configSyncCmd.Flags().StringVar(&configSyncSource, "source", "", "Set the shared config source (git URL or .tar.gz URL)")
+	configSyncCmd.Flags().StringVar(&configSyncRef, "ref", "", "Set the branch or tag to use (git sources)")
+	configSyncCmd.Flags().StringVar(&configSyncPath, "path", "", "Set the subdirectory of the source holding the shared files")
+	configSyncCmd.Flags().BoolVar(&configSyncDryRun, "dry-run", false, "Show what would change without writing")
+	configCmd.AddCommand(configSyncCmd)
+}
+
+func runConfigSync(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	townPath := filepath.Join(townRoot, "mayor", "town.json")
+	townCfg, err := config.LoadTownConfigBase(townPath)
+	if err != nil {
+		return fmt.Errorf("loading town config: %w", err)
+	}
+
+	src := townCfg.Shared
+	flags := cmd.Flags()
+	if flags.Changed("source") || flags.Changed("ref") || flags.Changed("path") {
+		updated := &config.SharedConfigSource{}
+		if src != nil {
+			*updated = *src
+		}
+		if flags.Changed("source") {
+			// A new repo's branch and layout are its own.
+			updated = &config.SharedConfigSource{Source: configSyncSource}
+		}
+		if flags.Changed("ref") {
+			updated.Ref = configSyncRef
+		}
+		if flags.Changed("path") {
+			updated.Path = configSyncPath
+		}
+		src = updated
+	}
+	if src == nil || src.Source == "" {
+		return fmt.Errorf("no shared config source: use --source, or set it with 'gt config set town.shared.source <url>'")
+	}
+
+	result, err := sharedconfig.Sync(townRoot, src, configSyncDryRun)
+	if err != nil {
+		return fmt.Errorf("syncing shared config: %w", err)
+	}
+
+	// Save a changed source only once it has synced, so a typo doesn't
+	// leave the town pointing at nothing.
+	if src != townCfg.Shared && !configSyncDryRun {
+		townCfg.Shared = src
+		if err := config.SaveTownConfig(townPath, townCfg); err != nil {
+			return fmt.Errorf("saving town config: %w", err)
+		}
+	}
+
+	printConfigSyncResult(result)
+	return nil
+}
+
+func printConfigSyncResult(result *sharedconfig.Result) {
+	revision := result.Stamp.Revision
+	if len(revision) > 12 {
+		revision = revision[:12]
+	}
+	fmt.Printf("Shared config: %s %s\n", result.Stamp.Source, style.Dim.Render("("+revision+")"))
+
+	prefix := ""
+	if configSyncDryRun {
+		prefix = "would be "
+	}
+	for _, change := range []struct {
+		label string
+		files []string
+	}{
+		{"added", result.Added},
+		{"updated", result.Changed},
+		{"removed", result.Removed},
+	} {
+		for _, f := range change.files {
+			fmt.Printf("  %s %s\n", f, style.Dim.Render(prefix+change.label))
+		}
+	}
+
+	switch {
+	case result.Unchanged():
+		fmt.Printf("%s Already up to date\n", style.SuccessPrefix)
+	case configSyncDryRun:
+		fmt.Printf("%s Dry run: nothing written\n", style.SuccessPrefix)
+	default:
+		fmt.Printf("%s Synced %d shared file(s)\n", style.SuccessPrefix, len(result.Stamp.Files))
+		if touchesHooks(result) {
+			fmt.Printf("  Run %s to apply the hooks changes\n", style.Bold.Render("gt hooks sync"))
+		}
+	}
+
+	if len(result.MissingRigs) > 0 {
+		fmt.Printf("\n%s The shared rig list has %d rig(s) this town doesn't:\n", style.WarningPrefix, len(result.MissingRigs))
+		for _, r := range result.MissingRigs {
+			fmt.Printf("  gt rig add %s %s\n", r.Name, r.GitURL)
+		}
+	}
+}
+
+// touchesHooks reports whether a sync changed any shared hooks config.
+func touchesHooks(result *sharedconfig.Result) bool {
+	for _, files := range [][]string{result.Added, result.Changed, result.Removed} {
+		for _, f := range files {
+			if f == config.SharedHooksBaseFile || strings.HasPrefix(f, config.SharedHooksOverrides+"/") {
+				return true
+			}
+		}
+	}
+	return false
+}
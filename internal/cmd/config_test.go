@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -1002,3 +1004,118 @@ func TestParseBool(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigSetGetPath(t *testing.T) {
+	townRoot := setupTestTownForConfig(t)
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	if err := config.SaveRigsConfig(rigsPath, &config.RigsConfig{
+		Version: 1,
+		Rigs:    map[string]config.RigEntry{"gastown": {GitURL: "https://example.com/gastown.git"}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	originalWd, _ := os.Getwd()
+	defer os.Chdir(originalWd)
+	if err := os.Chdir(townRoot); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	cmd := &cobra.Command{}
+
+	t.Run("town path", func(t *testing.T) {
+		if err := runConfigSet(cmd, []string{"town.owner", "ops@example.com"}); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		town, err := config.LoadTownConfig(filepath.Join(townRoot, "mayor", "town.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if town.Owner != "ops@example.com" {
+			t.Errorf("owner = %q", town.Owner)
+		}
+		out := captureStdout(t, func() {
+			if err := runConfigGet(cmd, []string{"town.owner"}); err != nil {
+				t.Errorf("get: %v", err)
+			}
+		})
+		if strings.TrimSpace(out) != "ops@example.com" {
+			t.Errorf("get town.owner = %q", out)
+		}
+	})
+
+	t.Run("rig path", func(t *testing.T) {
+		if err := runConfigSet(cmd, []string{"rig.gastown.namepool.max_before_numbering", "20"}); err != nil {
+			t.Fatalf("set: %v", err)
+		}
+		settings, err := config.LoadRigSettings(config.RigSettingsPath(filepath.Join(townRoot, "gastown")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if settings.Namepool == nil || settings.Namepool.MaxBeforeNumbering != 20 {
+			t.Errorf("namepool = %+v", settings.Namepool)
+		}
+	})
+
+	t.Run("type errors are rejected before writing", func(t *testing.T) {
+		err := runConfigSet(cmd, []string{"settings.scheduler.max_polecats", "lots"})
+		if !errors.Is(err, config.ErrInvalidValue) {
+			t.Errorf("err = %v, want ErrInvalidValue", err)
+		}
+		err = runConfigSet(cmd, []string{"town.ownr", "x"})
+		if !errors.Is(err, config.ErrUnknownPath) {
+			t.Errorf("err = %v, want ErrUnknownPath", err)
+		}
+	})
+
+	t.Run("validation runs on save", func(t *testing.T) {
+		if err := runConfigSet(cmd, []string{"town.type", "rig"}); err == nil {
+			t.Error("expected town.json validation to reject type=rig")
+		}
+		town, _ := config.LoadTownConfig(filepath.Join(townRoot, "mayor", "town.json"))
+		if town == nil || town.Type != "town" {
+			t.Errorf("invalid value reached disk: %+v", town)
+		}
+	})
+
+	t.Run("unknown rig", func(t *testing.T) {
+		err := runConfigGet(cmd, []string{"rig.nope.agent"})
+		if err == nil || !strings.Contains(err.Error(), `rig "nope" not found`) {
+			t.Errorf("err = %v", err)
+		}
+	})
+}
+
+func TestConfigSchema_CoversExampleSettings(t *testing.T) {
+	for name, example := range map[string]string{
+		"town": "../../docs/examples/town-settings.example.json",
+		"rig":  "../../docs/examples/rig-settings.example.json",
+	} {
+		schema, err := configSchema(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		root := schema.Defs[strings.TrimPrefix(schema.Ref, "#/$defs/")]
+		if root == nil {
+			t.Fatalf("%s: root %q not in $defs", name, schema.Ref)
+		}
+		data, err := os.ReadFile(example)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			t.Fatalf("%s: %v", example, err)
+		}
+		for field := range fields {
+			if strings.HasPrefix(field, "_") {
+				continue // _comment keys
+			}
+			if _, ok := root.Properties[field]; !ok {
+				t.Errorf("%s schema has no property %q used in %s", name, field, example)
+			}
+		}
+	}
+	if _, err := configSchema("daemon"); err == nil {
+		t.Error("unknown config file should fail")
+	}
+}
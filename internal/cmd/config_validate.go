@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check town config files against their schemas",
+	Long: `Check mayor/town.json, mayor/rigs.json, and mayor/accounts.json against
+the fields Gas Town understands.
+
+Reports, with file and line:
+  - unknown fields (often a typo; the value is silently ignored)
+  - missing required fields
+  - values of the wrong type
+
+Files that don't exist are skipped. gt doctor runs the same check.
+Exits non-zero if any issue is found.`,
+	Args: cobra.NoArgs,
+	RunE: runConfigValidate,
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+func runConfigValidate(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	issues, checked, err := config.ValidateTownSchemas(townRoot)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		fmt.Printf("%s %s\n", style.Error.Render("✗"), issue)
+	}
+	if len(issues) > 0 {
+		return NewSilentExit(1)
+	}
+	fmt.Printf("%s %d config file(s) valid\n", style.Success.Render("✓"), len(checked))
+	return nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/state"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var configMigrateXDGDryRun bool
+
+var configMigrateXDGCmd = &cobra.Command{
+	Use:   "migrate-xdg",
+	Short: "Move ~/.gt files to the XDG config and state directories",
+	Long: `Move gt's per-user files out of the legacy ~/.gt directory:
+
+  hooks-base.json, hooks-overrides/  ->  $XDG_CONFIG_HOME/gastown
+                                         (default ~/.config/gastown)
+  costs.jsonl, cmd-usage.jsonl       ->  $XDG_STATE_HOME/gastown
+                                         (default ~/.local/state/gastown)
+
+Until moved, gt keeps reading and writing them in ~/.gt. Files that
+already exist at the destination are left where they are. ~/.gt is
+removed once empty.
+
+Not needed when GT_HOME is set: $GT_HOME/.gt takes precedence.
+
+Examples:
+  gt config migrate-xdg --dry-run   # Show what would move
+  gt config migrate-xdg`,
+	Args: cobra.NoArgs,
+	RunE: runConfigMigrateXDG,
+}
+
+func init() {
+	configMigrateXDGCmd.Flags().BoolVar(&configMigrateXDGDryRun, "dry-run", false, "Show what would move without moving anything")
+	configCmd.AddCommand(configMigrateXDGCmd)
+}
+
+func runConfigMigrateXDG(cmd *cobra.Command, args []string) error {
+	moved, skipped, err := state.MigrateLegacyDir(configMigrateXDGDryRun)
+	if errors.Is(err, state.ErrGTHomeSet) {
+		fmt.Printf("%s Nothing to migrate: %v\n", style.SuccessPrefix, err)
+		return nil
+	}
+
+	verb := "Moved"
+	if configMigrateXDGDryRun {
+		verb = "Would move"
+	}
+	for _, m := range moved {
+		fmt.Printf("  %s %s → %s\n", verb, m.From, m.To)
+	}
+	for _, m := range skipped {
+		fmt.Printf("  %s Kept %s: %s already exists\n", style.WarningPrefix, m.From, m.To)
+	}
+	if err != nil {
+		return fmt.Errorf("migrating %s: %w", state.LegacyDir(), err)
+	}
+
+	switch {
+	case len(moved) == 0 && len(skipped) == 0:
+		fmt.Printf("%s Nothing to migrate in %s\n", style.SuccessPrefix, state.LegacyDir())
+	case configMigrateXDGDryRun:
+		fmt.Printf("%s %d file(s) would move; run without --dry-run to apply\n", style.SuccessPrefix, len(moved))
+	default:
+		fmt.Printf("%s Moved %d file(s) to the XDG directories\n", style.SuccessPrefix, len(moved))
+	}
+	return nil
+}
@@ -1985,10 +1985,7 @@ func detectBlockedRigs(dag *ConvoyDAG) []StagingFinding {
 	for _, rigName := range rigNames {
 		info := blockedRigs[rigName]
 		sort.Strings(info.beadIDs)
-		undoCmd := "gt rig unpark"
-		if info.reason == "docked" {
-			undoCmd = "gt rig undock"
-		}
+		undoCmd := rigResumeCmd(info.reason)
 		findings = append(findings, StagingFinding{
 			Severity:     "warning",
 			Category:     "blocked-rig",
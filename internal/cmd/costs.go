@@ -76,8 +76,8 @@ This command is intended to be called from a Claude Code Stop hook.
 It reads token usage from the Claude Code transcript file
 ($CLAUDE_CONFIG_DIR/projects/... or ~/.claude/projects/...)
 and calculates the cost based on model pricing, then appends it to
-~/.gt/costs.jsonl. This is a simple append operation that never fails
-due to database availability.
+costs.jsonl in ~/.local/state/gastown. This is a simple append operation
+that never fails due to database availability.
 
 Session costs are aggregated daily by 'gt costs digest' into a single
 permanent "Cost Report YYYY-MM-DD" bead for audit purposes.
@@ -94,7 +94,7 @@ var costsDigestCmd = &cobra.Command{
 	Long: `Aggregate session cost log entries into a permanent daily digest.
 
 This command is intended to be run by Deacon patrol (daily) or manually.
-It reads entries from ~/.gt/costs.jsonl for a target date, creates a single
+It reads entries from costs.jsonl for a target date, creates a single
 aggregate "Cost Report YYYY-MM-DD" bead, then removes the source entries.
 
 The resulting digest bead is permanent (synced via git) and provides
@@ -924,7 +924,7 @@ type CostLogEntry struct {
 }
 
 // getCostsLogPath returns the path to the costs log file.
-// Location: $GT_HOME/.gt/costs.jsonl when GT_HOME is set, otherwise ~/.gt/.
+// Location: costs.jsonl in gtDataDir().
 func getCostsLogPath() string {
 	return filepath.Join(gtDataDir(), "costs.jsonl")
 }
@@ -183,9 +183,11 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 			return fmt.Errorf("creating session: %w", err)
 		}
 
-		// Set environment (non-fatal: session works without these)
-		// Use centralized AgentEnv for consistency across all role startup paths
-		envVars := config.AgentEnv(config.AgentEnvConfig{
+		// Set environment (non-fatal: session works without these).
+		// The runtime is respawned into this session below, so it inherits
+		// the session table, secrets included, without them touching its
+		// command line.
+		envVars := session.AgentSessionEnv(config.AgentEnvConfig{
 			Role:             "crew",
 			Rig:              r.Name,
 			AgentName:        name,
@@ -194,14 +196,12 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 			Agent:            crewAgentOverride,
 			Topic:            "start",
 			SessionName:      sessionID,
-		})
+		}, r.Path)
 		// Merge liveness-critical env vars (GT_AGENT, GT_PROCESS_NAMES) so that
 		// IsAgentAlive can detect non-Claude runtimes. Without this, attach
 		// misclassifies live sessions as dead and recreates them.
 		envVars = session.MergeRuntimeLivenessEnv(envVars, runtimeConfig)
-		for k, v := range envVars {
-			_ = t.SetEnvironment(sessionID, k, v)
-		}
+		_ = session.SetSessionEnv(t, sessionID, envVars)
 
 		// Apply rig-based theming (non-fatal: theming failure doesn't affect operation)
 		// Note: ConfigureGasTownSession includes cycle bindings
@@ -269,7 +269,7 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 
 			// Refresh liveness env vars before restart so the next IsAgentAlive
 			// check can detect non-Claude runtimes.
-			restartEnv := config.AgentEnv(config.AgentEnvConfig{
+			restartEnv := session.AgentSessionEnv(config.AgentEnvConfig{
 				Role:             "crew",
 				Rig:              r.Name,
 				AgentName:        name,
@@ -278,11 +278,9 @@ func runCrewAt(cmd *cobra.Command, args []string) error {
 				Agent:            crewAgentOverride,
 				Topic:            "restart",
 				SessionName:      sessionID,
-			})
+			}, r.Path)
 			restartEnv = session.MergeRuntimeLivenessEnv(restartEnv, runtimeConfig)
-			for k, v := range restartEnv {
-				_ = t.SetEnvironment(sessionID, k, v)
-			}
+			_ = session.SetSessionEnv(t, sessionID, restartEnv)
 
 			// Get pane ID for respawn
 			paneID, err := t.GetPaneID(sessionID)
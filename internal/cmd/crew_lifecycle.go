@@ -17,6 +17,7 @@ import (
 	"github.com/steveyegge/gastown/internal/crew"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/runtime"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/townlog"
@@ -447,6 +448,8 @@ func runCrewRestart(cmd *cobra.Command, args []string) error {
 			continue
 		}
 
+		session.RunSessionHooks(filepath.Dir(r.Path), session.HookRestart, crewMgr.SessionName(name), "")
+
 		fmt.Printf("%s Restarted crew workspace: %s/%s\n",
 			style.Bold.Render("✓"), r.Name, name)
 		fmt.Printf("Attach with: %s\n", style.Dim.Render(fmt.Sprintf("gt crew at %s", name)))
@@ -528,6 +531,9 @@ func runCrewRestartAll() error {
 		} else {
 			succeeded++
 			fmt.Printf("  %s %s\n", style.SuccessPrefix, agentName)
+			if townRoot, err := workspace.FindFromCwd(); err == nil {
+				session.RunSessionHooks(townRoot, session.HookRestart, agent.Name, "")
+			}
 		}
 
 		crewRig = savedRig
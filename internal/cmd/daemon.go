@@ -109,6 +109,39 @@ Examples:
 	RunE: runDaemonEnableSupervisor,
 }
 
+var daemonInstallCmd = &cobra.Command{
+	Use:   "install",
+	Short: "Start this town at boot via systemd/launchd",
+	Long: `Install a boot service for this town.
+
+On Linux this writes a systemd user unit (gastown-town.service) and on macOS
+a launchd agent (com.gastown.town). The service runs 'gt start' at
+login/boot and 'gt shutdown --graceful' when it is stopped, so the town
+survives reboots without manual intervention.
+
+Only one town is started at boot: installing replaces any existing town
+service. 'gt daemon status' shows what is installed.
+
+This is separate from 'gt daemon enable-supervisor', which keeps the daemon
+process itself alive.
+
+Examples:
+  gt daemon install      # Start this town at boot
+  gt daemon uninstall    # Stop starting it at boot`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonInstall,
+}
+
+var daemonUninstallCmd = &cobra.Command{
+	Use:   "uninstall",
+	Short: "Remove the town boot service",
+	Long: `Disable and remove the boot service installed by 'gt daemon install'.
+
+The running town is left alone.`,
+	Args: cobra.NoArgs,
+	RunE: runDaemonUninstall,
+}
+
 var daemonRotateLogsCmd = &cobra.Command{
 	Use:   "rotate-logs",
 	Short: "Rotate daemon log files",
@@ -132,14 +165,18 @@ var daemonClearBackoffCmd = &cobra.Command{
 	Short: "Clear crash loop backoff for an agent",
 	Long: `Clear the crash loop and restart backoff state for an agent.
 
-When an agent crashes repeatedly, the daemon enters crash loop mode and
-stops restarting it. Use this command to reset the crash loop counter so
-the daemon will resume restarting the agent.
+The daemon restarts supervised agents with exponential backoff. When an
+agent is restarted too often within the crash-loop window (by default 5
+times in 15 minutes), the daemon stops restarting it and files a
+high-severity escalation. Use this command, once the cause is fixed, to
+reset the crash loop counter so the daemon will resume restarting the agent.
 
-The agent name is the session identity (e.g., "deacon", "mayor").
+The agent name is "deacon" or "mayor", or the tmux session name for rig
+agents (e.g., "gt-witness", "gt-refinery").
 
 Examples:
-  gt daemon clear-backoff deacon   # Reset deacon crash loop`,
+  gt daemon clear-backoff deacon       # Reset deacon crash loop
+  gt daemon clear-backoff gt-witness   # Reset a rig's witness`,
 	Args: cobra.ExactArgs(1),
 	RunE: runDaemonClearBackoff,
 }
@@ -156,6 +193,8 @@ func init() {
 	daemonCmd.AddCommand(daemonLogsCmd)
 	daemonCmd.AddCommand(daemonRunCmd)
 	daemonCmd.AddCommand(daemonEnableSupervisorCmd)
+	daemonCmd.AddCommand(daemonInstallCmd)
+	daemonCmd.AddCommand(daemonUninstallCmd)
 	daemonCmd.AddCommand(daemonClearBackoffCmd)
 	daemonCmd.AddCommand(daemonRotateLogsCmd)
 
@@ -301,9 +340,27 @@ func runDaemonStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("\nStart with: %s\n", style.Dim.Render("gt daemon start"))
 	}
 
+	printTownServiceStatus(townRoot)
 	return nil
 }
 
+// printTownServiceStatus reports the boot service installed by
+// 'gt daemon install', if any.
+func printTownServiceStatus(townRoot string) {
+	st, err := templates.GetTownServiceStatus()
+	if err != nil || !st.Installed {
+		return
+	}
+	state := "installed"
+	if st.Active {
+		state = "installed, active"
+	}
+	fmt.Printf("\nBoot service: %s (%s)\n", st.Path, state)
+	if st.TownRoot != "" && st.TownRoot != townRoot {
+		fmt.Printf("  %s Starts a different town: %s\n", style.Bold.Render("⚠"), st.TownRoot)
+	}
+}
+
 // getBinaryModTime returns the modification time of the current executable
 func getBinaryModTime() (time.Time, error) {
 	exePath, err := os.Executable()
@@ -412,6 +469,41 @@ func runDaemonEnableSupervisor(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runDaemonInstall(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	if st, err := templates.GetTownServiceStatus(); err == nil && st.Installed && st.TownRoot != "" && st.TownRoot != townRoot {
+		style.PrintWarning("replacing boot service for %s", st.TownRoot)
+	}
+
+	msg, err := templates.InstallTownService(townRoot)
+	if err != nil {
+		return fmt.Errorf("installing boot service: %w", err)
+	}
+
+	fmt.Printf("%s %s\n", style.Bold.Render("✓"), msg)
+	fmt.Printf("\nGas Town at %s will now:\n", townRoot)
+	fmt.Println("  - Start with 'gt start' on login/boot")
+	fmt.Println("  - Shut down with 'gt shutdown --graceful' when the service stops")
+	if runtime.GOOS == "linux" {
+		fmt.Println("\nUser services only start at login. To start at boot without logging in:")
+		fmt.Printf("  %s\n", style.Dim.Render("loginctl enable-linger $USER"))
+	}
+	return nil
+}
+
+func runDaemonUninstall(cmd *cobra.Command, args []string) error {
+	msg, err := templates.UninstallTownService()
+	if err != nil {
+		return fmt.Errorf("removing boot service: %w", err)
+	}
+	fmt.Printf("%s %s\n", style.Bold.Render("✓"), msg)
+	return nil
+}
+
 func runDaemonClearBackoff(cmd *cobra.Command, args []string) error {
 	agentID := args[0]
 
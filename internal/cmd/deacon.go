@@ -532,22 +532,26 @@ func startDeaconSession(t *tmux.Tmux, sessionName, agentOverride string) error {
 
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
-	fmt.Println("Starting Deacon session...")
-	if err := t.NewSessionWithCommand(sessionName, deaconDir, startupCmd); err != nil {
-		return fmt.Errorf("creating session: %w", err)
-	}
-
-	// Set environment (non-fatal: session works without these)
-	// Use centralized AgentEnv for consistency across all role startup paths
-	envVars := config.AgentEnv(config.AgentEnvConfig{
+	// Use the shared agent session env (session_env, secrets, AgentEnv) for
+	// consistency across all role startup paths.
+	envVars := session.AgentSessionEnv(config.AgentEnvConfig{
 		Role:     "deacon",
 		TownRoot: townRoot,
 		Agent:    agentOverride,
-	})
-	for k, v := range envVars {
-		_ = t.SetEnvironment(sessionName, k, v)
+	}, "")
+	fmt.Println("Starting Deacon session...")
+	if err := session.NewAgentSession(t, sessionName, deaconDir, startupCmd, envVars, townRoot, "", "deacon"); err != nil {
+		return fmt.Errorf("creating session: %w", err)
 	}
 
+	// Capture pane output to deacon/logs so scrollback survives crashes (non-fatal).
+	_ = session.EnableSessionLogCapture(t, townRoot, sessionName)
+
+	// Create the deacon's configured panes/windows (non-fatal).
+	_ = session.ApplySessionLayout(t, townRoot, "deacon", sessionName, deaconDir)
+
+	session.RunSessionHooks(townRoot, session.HookStart, sessionName, deaconDir)
+
 	// Record agent's pane_id for ZFC-compliant liveness checks (gt-qmsx).
 	if paneID, err := t.GetPaneID(sessionName); err == nil {
 		_ = t.SetEnvironment(sessionName, "GT_PANE_ID", paneID)
@@ -866,14 +870,20 @@ func runDeaconHealthCheck(cmd *cobra.Command, args []string) error {
 
 	t := tmux.NewTmux()
 
-	// Check if session exists
-	exists, err := t.HasSession(sessionName)
-	if err != nil {
-		return fmt.Errorf("checking session: %w", err)
-	}
-	if !exists {
-		fmt.Printf("%s Agent %s session not running\n", style.Dim.Render("○"), agent)
-		return nil
+	// Ping the session: a session whose pane process has died, or whose tmux
+	// server has stopped answering, can't respond to a nudge, so count it as
+	// a failure straight away instead of waiting out the timeout.
+	if err := t.Ping(sessionName); err != nil {
+		switch {
+		case errors.Is(err, tmux.ErrSessionNotFound), errors.Is(err, tmux.ErrNoServer):
+			fmt.Printf("%s Agent %s session not running\n", style.Dim.Render("○"), agent)
+			return nil
+		case errors.Is(err, tmux.ErrPaneDead), errors.Is(err, tmux.ErrPingTimeout):
+			agentState.RecordPing()
+			return recordHealthCheckFailure(townRoot, state, agentState, agent, err.Error())
+		default:
+			return fmt.Errorf("checking session: %w", err)
+		}
 	}
 
 	// Record ping
@@ -943,14 +953,19 @@ Done:
 		return nil
 	}
 
-	// No response - record failure
+	return recordHealthCheckFailure(townRoot, state, agentState, agent, "did not respond")
+}
+
+// recordHealthCheckFailure records a failed health check for agent and
+// returns exit code 2 once the force-kill threshold is reached.
+func recordHealthCheckFailure(townRoot string, state *deacon.HealthCheckState, agentState *deacon.AgentHealthState, agent, why string) error {
 	agentState.RecordFailure()
 	if err := deacon.SaveHealthCheckState(townRoot, state); err != nil {
 		style.PrintWarning("failed to save health check state: %v", err)
 	}
 
-	fmt.Printf("%s Agent %s did not respond (consecutive failures: %d/%d)\n",
-		style.Dim.Render("⚠"), agent, agentState.ConsecutiveFailures, healthCheckFailures)
+	fmt.Printf("%s Agent %s %s (consecutive failures: %d/%d)\n",
+		style.Dim.Render("⚠"), agent, why, agentState.ConsecutiveFailures, healthCheckFailures)
 
 	// Check if force-kill threshold reached
 	if agentState.ShouldForceKill(healthCheckFailures) {
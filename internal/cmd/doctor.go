@@ -44,6 +44,9 @@ Town root protection:
 Infrastructure checks:
   - stale-binary             Check if gt binary is up to date with repo
   - beads-binary             Check that beads (bd) is installed and meets minimum version
+  - tmux-binary              Check that tmux is installed and meets minimum version
+  - agent-binary             Check that the configured agent CLI (claude by default) is installed
+  - keychain                 Check macOS keychain access for agent credentials
   - daemon                   Check if daemon is running (fixable)
   - boot-health              Check Boot watchdog health (vet mode)
   - town-beads-config        Verify town .beads/config.yaml exists (fixable)
@@ -59,9 +62,17 @@ Cleanup checks (fixable):
 
 Clone divergence checks:
   - persistent-role-branches Detect witness/refinery not on main (excludes crew)
-  - clone-divergence         Detect clones significantly behind origin/main
+  - clone-divergence         Detect clones significantly behind their default branch
   - default-branch-all-rigs  Verify default_branch exists on remote for all rigs
+  - default-branch-recorded  Verify each rig's default_branch is in rigs.json (fixable)
   - worktree-gitdir-valid    Verify worktree .git files reference existing paths (fixable)
+  - dangling-worktrees       Detect worktree registrations whose directories are gone (fixable)
+
+Workspace layout checks (also 'gt workspace doctor'):
+  - town-location            Detect a moved town whose stored paths are stale (fixable)
+  - rig-layout               Verify every registered rig has its directories (fixable)
+  - polecat-worktrees        Verify every polecat directory is a registered worktree
+  - orphan-state-files       Detect lock files for polecats/crew that no longer exist (fixable)
 
 Crew workspace checks:
   - crew-state               Validate crew worker state.json files (fixable)
@@ -152,6 +163,8 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 
 	// Register workspace-level checks first (fundamental)
 	d.RegisterAll(doctor.WorkspaceChecks()...)
+	// A moved town breaks worktree links; repair it before anything shells out to git
+	d.Register(doctor.NewTownLocationCheck())
 
 	d.Register(doctor.NewGlobalStateCheck())
 
@@ -166,6 +179,11 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewDoltBinaryCheck())
 	d.Register(doctor.NewDoltServerReachableCheck())
 
+	// Agent runtime prerequisites: tmux, the agent CLI, and its credentials.
+	d.Register(doctor.NewTmuxBinaryCheck())
+	d.Register(doctor.NewAgentBinaryCheck())
+	d.Register(doctor.NewKeychainCheck())
+
 	d.Register(doctor.NewTownGitCheck())
 	d.Register(doctor.NewTownRootBranchCheck())
 	d.Register(doctor.NewForeignRemoteCheck())
@@ -207,6 +225,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 	d.Register(doctor.NewBranchCheck())
 	d.Register(doctor.NewCloneDivergenceCheck())
 	d.Register(doctor.NewDefaultBranchAllRigsCheck())
+	d.Register(doctor.NewDefaultBranchRecordedCheck())
 	d.Register(doctor.NewIdentityCollisionCheck())
 	d.Register(doctor.NewLinkedPaneCheck())
 	d.Register(doctor.NewSocketSplitBrainCheck())
@@ -229,6 +248,7 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 
 	// Config architecture checks
 	d.Register(doctor.NewSettingsCheck())
+	d.Register(doctor.NewConfigSchemaCheck())
 	d.Register(doctor.NewSessionHookCheck())
 	d.Register(doctor.NewRuntimeGitignoreCheck())
 	d.Register(doctor.NewLegacyGastownCheck())
@@ -272,6 +292,13 @@ func runDoctor(cmd *cobra.Command, args []string) error {
 
 	// Worktree gitdir validity (runs across all rigs, or specific rig with --rig)
 	d.Register(doctor.NewWorktreeGitdirCheck())
+	d.Register(doctor.NewDanglingWorktreeCheck())
+
+	// Workspace layout: rig directories, polecat worktree registration,
+	// per-worker state files (also run by 'gt workspace doctor')
+	d.Register(doctor.NewRigLayoutCheck())
+	d.Register(doctor.NewPolecatWorktreeCheck())
+	d.Register(doctor.NewOrphanStateFilesCheck())
 
 	// Rig-specific checks (only when --rig is specified)
 	if doctorRig != "" {
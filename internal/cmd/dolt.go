@@ -233,6 +233,11 @@ This command automates the tedious process of pushing each database individually
   3. For each database with a configured remote, pushes via SQL or CLI
   4. Reports success/failure per database
 
+Transient failures (network errors, lock contention) are retried with backoff.
+If a remote has moved ahead, its changes are pulled and merged, then the push
+is retried. Merge conflicts abort the merge unless --resolve picks a side:
+--resolve theirs keeps the remote's rows, --resolve ours keeps the local ones.
+
 Use --db to sync a single database, --dry-run to preview, or --force for force-push.
 Use --gc to purge closed ephemeral beads (wisps, convoys) before pushing.
 
@@ -241,6 +246,7 @@ Examples:
   gt dolt sync --dry-run      # Preview what would be pushed
   gt dolt sync --db gastown   # Push only the gastown database
   gt dolt sync --force        # Force-push all databases
+  gt dolt sync --resolve ours # Keep local rows if a merge with the remote conflicts
   gt dolt sync --gc           # Purge closed ephemeral beads, then push
   gt dolt sync --gc --dry-run # Preview purge + push without changes`,
 	RunE: runDoltSync,
@@ -338,6 +344,7 @@ var (
 	doltSyncForce       bool
 	doltSyncDB          string
 	doltSyncGC          bool
+	doltSyncResolve     string
 	doltPullDry         bool
 	doltPullDB          string
 )
@@ -379,6 +386,7 @@ func init() {
 	doltSyncCmd.Flags().BoolVar(&doltSyncForce, "force", false, "Force-push to remotes")
 	doltSyncCmd.Flags().StringVar(&doltSyncDB, "db", "", "Sync a single database instead of all")
 	doltSyncCmd.Flags().BoolVar(&doltSyncGC, "gc", false, "Purge closed ephemeral beads before push (requires bd purge)")
+	doltSyncCmd.Flags().StringVar(&doltSyncResolve, "resolve", "", "Resolve merge conflicts with the remote: theirs or ours")
 
 	doltPullCmd.Flags().BoolVar(&doltPullDry, "dry-run", false, "Preview what would be pulled without pulling")
 	doltPullCmd.Flags().StringVar(&doltPullDB, "db", "", "Pull a single database instead of all")
@@ -1458,6 +1466,14 @@ func runDoltSync(cmd *cobra.Command, args []string) error {
 	if doltSyncDB != "" && !doltserver.DatabaseExists(townRoot, doltSyncDB) {
 		return fmt.Errorf("database %q not found in .dolt-data/\nRun 'gt dolt list' to see available databases", doltSyncDB)
 	}
+	switch doltSyncResolve {
+	case "", doltserver.ResolveTheirs, doltserver.ResolveOurs:
+	default:
+		return fmt.Errorf("invalid --resolve %q: must be %q or %q", doltSyncResolve, doltserver.ResolveTheirs, doltserver.ResolveOurs)
+	}
+	if doltSyncResolve != "" && doltSyncForce {
+		return fmt.Errorf("--resolve and --force are mutually exclusive (force-push never merges)")
+	}
 
 	// Check server state
 	wasRunning, _, _ := doltserver.IsRunning(townRoot)
@@ -1490,9 +1506,10 @@ func runDoltSync(cmd *cobra.Command, args []string) error {
 	}
 
 	opts := doltserver.SyncOptions{
-		Force:  doltSyncForce,
-		DryRun: doltSyncDry,
-		Filter: doltSyncDB,
+		Force:   doltSyncForce,
+		DryRun:  doltSyncDry,
+		Filter:  doltSyncDB,
+		Resolve: doltSyncResolve,
 	}
 
 	// Use SQL push through the running server (no downtime).
@@ -1513,7 +1530,7 @@ func runDoltSync(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("\nSyncing %d database(s)...\n", len(results))
 
-	var pushed, skipped, failed, totalPurged int
+	var pushed, pulled, skipped, failed, totalPurged int
 	for _, r := range results {
 		fmt.Println()
 		// Show purge results if --gc was used
@@ -1535,6 +1552,17 @@ func runDoltSync(cmd *cobra.Command, args []string) error {
 		case r.Pushed:
 			fmt.Printf("  %s %s → origin main\n", style.Bold.Render("✓"), r.Database)
 			fmt.Printf("    %s\n", style.Dim.Render(r.Remote))
+			if r.Pulled {
+				merged := "merged remote changes before pushing"
+				if r.Resolved != "" {
+					merged += fmt.Sprintf(" (conflicts resolved: %s)", r.Resolved)
+				}
+				fmt.Printf("    %s\n", merged)
+				pulled++
+			}
+			if r.Retries > 0 {
+				fmt.Printf("    %s\n", style.Dim.Render(fmt.Sprintf("succeeded after %d retries", r.Retries)))
+			}
 			pushed++
 		case r.DryRun:
 			fmt.Printf("  %s %s → origin main (dry run)\n", style.Bold.Render("~"), r.Database)
@@ -1546,11 +1574,15 @@ func runDoltSync(cmd *cobra.Command, args []string) error {
 		case r.Error != nil:
 			fmt.Printf("  %s %s → origin main\n", style.Bold.Render("✗"), r.Database)
 			fmt.Printf("    error: %v\n", r.Error)
+			if r.Pulled {
+				fmt.Printf("    %s\n", style.Dim.Render("remote changes were merged locally; rerun to push"))
+				pulled++
+			}
 			failed++
 		}
 	}
 
-	summary := fmt.Sprintf("Summary: %d pushed, %d skipped, %d failed", pushed, skipped, failed)
+	summary := fmt.Sprintf("Summary: %d pushed, %d pulled, %d skipped, %d failed", pushed, pulled, skipped, failed)
 	if doltSyncGC && totalPurged > 0 {
 		if doltSyncDry {
 			summary += fmt.Sprintf(", %d would be purged", totalPurged)
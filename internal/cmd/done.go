@@ -25,6 +25,7 @@ import (
 	"github.com/steveyegge/gastown/internal/templates"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/townlog"
+	"github.com/steveyegge/gastown/internal/tracker"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -388,10 +389,7 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 	}
 
 	// Get configured default branch for this rig
-	defaultBranch := "main" // fallback
-	if rigCfg, err := rig.LoadRigConfig(filepath.Join(townRoot, rigName)); err == nil && rigCfg.DefaultBranch != "" {
-		defaultBranch = rigCfg.DefaultBranch
-	}
+	defaultBranch := rig.ResolveDefaultBranch(filepath.Join(townRoot, rigName))
 
 	// For COMPLETED, we need an issue ID and branch must not be the default branch
 	var mrID string
@@ -1024,6 +1022,10 @@ func runDone(cmd *cobra.Command, args []string) (retErr error) {
 			if agentBeadID != "" {
 				description += fmt.Sprintf("\nagent_bead: %s", agentBeadID)
 			}
+			// Carry the polecat's trace so the refinery's merge span joins it.
+			if tp := telemetry.TraceParent(cmd.Context()); tp != "" {
+				description += fmt.Sprintf("\ntrace_parent: %s", tp)
+			}
 
 			// Add conflict resolution tracking fields (initialized, updated by Refinery)
 			description += "\nretry_count: 0"
@@ -1579,6 +1581,15 @@ func updateAgentStateOnDone(cwd, townRoot, exitType, issueID string) {
 			} else if err := bd.Close(hookedBeadID); err != nil {
 				// Non-fatal: warn but continue
 				fmt.Fprintf(os.Stderr, "Warning: couldn't close hooked bead %s: %v\n", hookedBeadID, err)
+			} else if beadsPath != townRoot {
+				// Move the linked tracker ticket (GitHub, Jira) now rather
+				// than at the next scheduled sync. Best-effort: the daemon
+				// catches up on anything missed here.
+				syncCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				if err := tracker.SyncRigBead(syncCtx, beadsPath, hookedBeadID); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: couldn't sync %s to issue tracker: %v\n", hookedBeadID, err)
+				}
+				cancel()
 			}
 		}
 	}
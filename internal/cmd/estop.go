@@ -362,9 +362,20 @@ func isGTSession(name string, rigPrefixes map[string]bool) bool {
 	return false
 }
 
-// addEstopToStatus checks for E-stop and prints a banner if active.
+// addEstopToStatus checks for E-stop or a town pause and prints a banner if
+// either is active.
 // Called from gt status to surface E-stop state.
 func addEstopToStatus(townRoot string) {
+	if state := estop.ReadPause(townRoot); state != nil {
+		age := time.Since(state.PausedAt).Round(time.Second)
+		fmt.Printf("%s  TOWN PAUSED (%s ago", style.Warning.Render("⏸"), age)
+		if state.Reason != "" {
+			fmt.Printf(": %s", state.Reason)
+		}
+		fmt.Println(") — unpause with gt unpause")
+		fmt.Println()
+	}
+
 	if estop.IsActive(townRoot) {
 		info := estop.Read(townRoot)
 		if info != nil {
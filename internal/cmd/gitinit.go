@@ -95,6 +95,11 @@ logs/
 # =============================================================================
 events/
 
+# =============================================================================
+# Rig archives (gt rig archive)
+# =============================================================================
+.archive/
+
 # =============================================================================
 # HQ beads directory
 # =============================================================================
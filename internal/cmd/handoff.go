@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -22,6 +25,7 @@ import (
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/ui"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -51,11 +55,34 @@ Examples:
   gt handoff -c                       # Collect state into handoff message
   gt handoff crew                     # Hand off crew session
   gt handoff mayor                    # Hand off mayor session
+  gt handoff --to gastown/crew/max    # Hand my work to another agent
+  gt handoff gt-abc --to gastown/witness
 
 The --collect (-c) flag gathers current state (hooked work, inbox, ready beads,
 in-progress items) and includes it in the handoff mail. This provides context
 for the next session without manual summarization.
 
+Every handoff mail also carries a work summary of the current branch: commits
+since the default branch, changed-file stats (including uncommitted changes),
+and TODO/FIXME markers added along the way, so the next session can assess
+the work without replaying the transcript.
+
+When town or rig settings define a handoff template for the agent's role
+(handoff_templates), the handoff mail is laid out in its sections so the
+Witness and Mayor can parse it. A message given with -m or --stdin must fill
+the template's required sections and fields or the handoff is refused;
+automated handoffs (--auto, --cycle) only warn. --template prints the
+structure to fill in.
+
+The successor's gt prime reads a handoff marker naming this session, the
+handoff mail bead, and any --next hints ("gt handoff --next 'rerun the
+migration test'"), and shows them in its post-handoff briefing.
+
+The --to flag hands work to another agent instead of restarting this session.
+The handoff mail (and any given bead) is hooked on the recipient, its handoff
+marker is written, and its session is notified. If the recipient isn't
+running, the Mayor is asked to dispatch it.
+
 The --cycle flag triggers automatic session cycling (used by PreCompact hooks).
 Unlike --auto (state only) or normal handoff (polecat→gt-done redirect), --cycle
 always does a full respawn regardless of role. This enables crew workers and
@@ -78,6 +105,8 @@ var (
 	handoffReason     string
 	handoffNoGitCheck bool
 	handoffYes        bool
+	handoffTo         string
+	handoffNext       []string
 )
 
 func init() {
@@ -92,6 +121,9 @@ func init() {
 	handoffCmd.Flags().StringVar(&handoffReason, "reason", "", "Reason for handoff (e.g., 'compaction', 'idle')")
 	handoffCmd.Flags().BoolVar(&handoffNoGitCheck, "no-git-check", false, "Skip git workspace cleanliness check")
 	handoffCmd.Flags().BoolVarP(&handoffYes, "yes", "y", false, "Skip confirmation prompt (for automation and scripting)")
+	handoffCmd.Flags().BoolVar(&handoffShowTemplate, "template", false, "Print this role's handoff template and exit")
+	handoffCmd.Flags().StringArrayVar(&handoffNext, "next", nil, "Next-step hint for the successor, shown by its gt prime (repeatable)")
+	handoffCmd.Flags().StringVar(&handoffTo, "to", "", "Hand work off to another agent (<rig>/<role>[/<name>], mayor, deacon)")
 	rootCmd.AddCommand(handoffCmd)
 }
 
@@ -108,6 +140,15 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		handoffMessage = strings.TrimRight(string(data), "\n")
 	}
 
+	if handoffShowTemplate {
+		return runHandoffTemplate()
+	}
+
+	// --to: directed handoff to another agent. This session keeps running.
+	if handoffTo != "" {
+		return runHandoffTo(args)
+	}
+
 	// --auto mode: save state only, no session cycling.
 	// Used by PreCompact hook to preserve state before compaction.
 	// Note: auto-mode exits here, before the git-status warning check below.
@@ -175,6 +216,9 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 	// it can hand off immediately and the daemon respawns, creating a crash loop.
 	enforceHandoffCooldown()
 
+	// A message the agent wrote must satisfy the role's handoff template.
+	authored := handoffMessage != ""
+
 	// If --collect flag is set, auto-collect state into the message
 	if handoffCollect {
 		collected := collectHandoffState()
@@ -188,6 +232,14 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// Lay the message out in the role's handoff template so the successor,
+	// Witness, and Mayor can parse it.
+	message, err := applyHandoffTemplate(handoffMessage, authored)
+	if err != nil {
+		return err
+	}
+	handoffMessage = message
+
 	// Use a socket-aware Tmux for pane operations. The calling process may be
 	// on a different tmux server than the town socket (e.g., default socket).
 	// For self-handoff, pane operations (clear-history, respawn-pane) must target
@@ -258,6 +310,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 	if targetSession != currentSession {
 		// Update tmux session env before respawn (not during dry-run — see below)
 		updateSessionEnvForHandoff(townTmux, targetSession, "")
+		updateSessionTraceForHandoff(townTmux, targetSession)
 		return handoffRemoteSession(townTmux, targetSession, restartCmd)
 	}
 
@@ -290,6 +343,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 	// process, but we must also update the session env so liveness checks work.
 	// Placed after the dry-run guard to avoid mutating session state during dry-run.
 	updateSessionEnvForHandoff(t, currentSession, "")
+	updateSessionTraceForHandoff(t, currentSession)
 
 	// Send handoff mail to self (defaults applied inside sendHandoffMail).
 	// The mail is auto-hooked so the next session picks it up.
@@ -330,10 +384,7 @@ func runHandoff(cmd *cobra.Command, args []string) error {
 	// The marker is cleared by gt prime after it outputs the warning.
 	// This tells the new session "you're post-handoff, don't re-run /handoff"
 	if cwd, err := os.Getwd(); err == nil {
-		runtimeDir := filepath.Join(cwd, constants.DirRuntime)
-		_ = os.MkdirAll(runtimeDir, 0755)
-		markerPath := filepath.Join(runtimeDir, constants.FileHandoffMarker)
-		_ = os.WriteFile(markerPath, []byte(currentSession), 0644)
+		_ = writeHandoffMarker(cwd, currentSession, "", beadID)
 	}
 
 	// Record handoff time for cooldown enforcement (gt-058d).
@@ -391,6 +442,8 @@ func runHandoffAuto() error {
 	if message == "" {
 		message = collectHandoffState()
 	}
+	// Automated: template gaps are warnings, never failures.
+	message, _ = applyHandoffTemplate(message, false)
 
 	if handoffDryRun {
 		fmt.Printf("[auto-handoff] Would send mail: subject=%q\n", subject)
@@ -412,16 +465,13 @@ func runHandoffAuto() error {
 
 	// Write handoff marker so post-compact prime knows it's post-handoff
 	if cwd, err := os.Getwd(); err == nil {
-		runtimeDir := filepath.Join(cwd, constants.DirRuntime)
-		_ = os.MkdirAll(runtimeDir, 0755)
-		markerPath := filepath.Join(runtimeDir, constants.FileHandoffMarker)
 		sessionName := "auto-handoff"
 		if tmux.IsInsideTmux() {
 			if name, err := getCurrentTmuxSession(); err == nil {
 				sessionName = name
 			}
 		}
-		_ = os.WriteFile(markerPath, []byte(sessionName), 0644)
+		_ = writeHandoffMarker(cwd, sessionName, "", beadID)
 	}
 
 	// Log handoff event
@@ -498,6 +548,9 @@ func runHandoffCycle() error {
 	callerSocket := tmux.SocketFromEnv()
 	t := tmux.NewTmuxWithSocket(callerSocket)
 
+	// Applied after the fallbacks above, which template in runHandoffAuto.
+	message, _ = applyHandoffTemplate(message, false)
+
 	if handoffDryRun {
 		fmt.Printf("[cycle] Would send handoff mail: subject=%q\n", subject)
 		fmt.Printf("[cycle] Would write handoff marker\n")
@@ -527,18 +580,11 @@ func runHandoffCycle() error {
 	fmt.Fprintf(os.Stderr, "handoff --cycle: saved state to %s\n", beadID)
 
 	// Write handoff marker so post-cycle prime knows it's post-handoff.
-	// Format: "session_id\nreason" — the reason enables isCompactResume()
-	// to detect compaction-triggered cycles and use a lighter continuation
-	// directive instead of full re-initialization. (GH#1965)
+	// The reason enables isCompactResume() to detect compaction-triggered
+	// cycles and use a lighter continuation directive instead of full
+	// re-initialization. (GH#1965)
 	if cwd, err := os.Getwd(); err == nil {
-		runtimeDir := filepath.Join(cwd, constants.DirRuntime)
-		_ = os.MkdirAll(runtimeDir, 0755)
-		markerPath := filepath.Join(runtimeDir, constants.FileHandoffMarker)
-		markerContent := currentSession
-		if handoffReason != "" {
-			markerContent += "\n" + handoffReason
-		}
-		_ = os.WriteFile(markerPath, []byte(markerContent), 0644)
+		_ = writeHandoffMarker(cwd, currentSession, handoffReason, beadID)
 	}
 
 	// Record handoff time for cooldown enforcement (gt-058d).
@@ -978,6 +1024,18 @@ func buildRestartCommandWithOpts(sessionName string, opts buildRestartCommandOpt
 	return cdPrefix + envCmd, nil
 }
 
+// updateSessionTraceForHandoff points the session's TRACEPARENT at this
+// handoff's span so the next session continues the trace. Sessions started
+// without one (long-lived agents) are left untraced.
+func updateSessionTraceForHandoff(t *tmux.Tmux, sessionName string) {
+	if val, err := t.GetEnvironment(sessionName, telemetry.EnvTraceParent); err != nil || val == "" {
+		return
+	}
+	if tp := telemetry.TraceParent(context.Background()); tp != "" {
+		_ = t.SetEnvironment(sessionName, telemetry.EnvTraceParent, tp)
+	}
+}
+
 // updateSessionEnvForHandoff updates the tmux session environment with the
 // agent name and process names for liveness detection. IsAgentAlive reads
 // GT_PROCESS_NAMES from the tmux session env (via tmux show-environment), not
@@ -1229,7 +1287,20 @@ func handoffRemoteSession(t *tmux.Tmux, targetSession, restartCmd string) error
 }
 
 // getSessionPane returns the pane identifier for a session's main pane.
+// Sessions on a remote host are returned by name: pane IDs are per tmux
+// server, and a remote one would be resolved against the local server.
 func getSessionPane(sessionName string) (string, error) {
+	if t := tmuxForSession(sessionName); t.RemoteHost() != "" {
+		exists, err := t.HasSession(sessionName)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return "", fmt.Errorf("no panes found in session")
+		}
+		return sessionName, nil
+	}
+
 	// Get the pane ID for the first pane in the session
 	out, err := tmux.BuildCommand("list-panes", "-t", sessionName, "-F", "#{pane_id}").Output()
 	if err != nil {
@@ -1245,6 +1316,13 @@ func getSessionPane(sessionName string) (string, error) {
 // sendHandoffMail sends a handoff mail to self and auto-hooks it.
 // Returns the created bead ID and any error.
 func sendHandoffMail(subject, message string) (string, error) {
+	return sendHandoffMailTo(subject, message, "")
+}
+
+// sendHandoffMailTo sends a handoff mail and auto-hooks it on the recipient,
+// an agent address. An empty recipient means self.
+// Returns the created bead ID and any error.
+func sendHandoffMailTo(subject, message, recipient string) (string, error) {
 	// Build subject with handoff prefix if not already present
 	if subject == "" {
 		subject = "🤝 HANDOFF: Session cycling"
@@ -1257,6 +1335,12 @@ func sendHandoffMail(subject, message string) (string, error) {
 		message = "Context cycling. Check bd ready for pending work."
 	}
 
+	// Record what was done on this branch so the successor can assess it
+	// without replaying the transcript.
+	if summary := collectWorkSummary(); summary != "" && !strings.Contains(message, "## Work Summary") {
+		message += "\n\n" + summary
+	}
+
 	// Detect agent identity for self-mail
 	agentID, _, _, err := resolveSelfTarget()
 	if err != nil {
@@ -1265,6 +1349,10 @@ func sendHandoffMail(subject, message string) (string, error) {
 
 	// Normalize identity to match mailbox query format
 	agentID = mail.AddressToIdentity(agentID)
+	assignee := agentID
+	if recipient != "" {
+		assignee = mail.AddressToIdentity(recipient)
+	}
 
 	// Detect town root for beads location
 	townRoot := detectTownRootFromCwd()
@@ -1281,7 +1369,7 @@ func sendHandoffMail(subject, message string) (string, error) {
 	// This prevents subjects like "--help" from being parsed as flags.
 	args := []string{
 		"create",
-		"--assignee", agentID,
+		"--assignee", assignee,
 		"-d", message,
 		"--priority", "1", // high — handoffs should float above normal mail
 		"--labels", labels + ",gt:message",
@@ -1316,14 +1404,11 @@ func sendHandoffMail(subject, message string) (string, error) {
 	}
 
 	// Auto-hook the created mail bead
-	hookCmd := BdCmd("update", beadID, "--status=hooked", "--assignee="+agentID).
+	if err := BdCmd("update", beadID, "--status=hooked", "--assignee="+assignee).
 		WithAutoCommit().
+		WithBeadsDir(filepath.Join(townRoot, ".beads")).
 		Dir(townRoot).
-		Build()
-	hookCmd.Env = append(hookCmd.Env, "BEADS_DIR="+filepath.Join(townRoot, ".beads"))
-	hookCmd.Stderr = os.Stderr
-
-	if err := hookCmd.Run(); err != nil {
+		Run(); err != nil {
 		// Non-fatal: mail was created, just couldn't hook
 		style.PrintWarning("created mail %s but failed to auto-hook: %v", beadID, err)
 		return beadID, nil
@@ -1406,6 +1491,12 @@ func looksLikeBeadID(s string) bool {
 
 // hookBeadForHandoff attaches a bead to the current agent's hook.
 func hookBeadForHandoff(beadID string) error {
+	return hookBeadForHandoffTo(beadID, "")
+}
+
+// hookBeadForHandoffTo attaches a bead to an agent's hook. An empty
+// recipient means the current agent.
+func hookBeadForHandoffTo(beadID, recipient string) error {
 	// Verify the bead exists first
 	verifyCmd := exec.Command("bd", "show", beadID, "--json")
 	if err := verifyCmd.Run(); err != nil {
@@ -1413,9 +1504,12 @@ func hookBeadForHandoff(beadID string) error {
 	}
 
 	// Determine agent identity
-	agentID, _, _, err := resolveSelfTarget()
-	if err != nil {
-		return fmt.Errorf("detecting agent identity: %w", err)
+	agentID := mail.AddressToIdentity(recipient)
+	if recipient == "" {
+		var err error
+		if agentID, _, _, err = resolveSelfTarget(); err != nil {
+			return fmt.Errorf("detecting agent identity: %w", err)
+		}
 	}
 
 	fmt.Printf("%s Hooking %s...\n", style.Bold.Render("🪝"), beadID)
@@ -1565,6 +1659,144 @@ func collectGitState() string {
 	return "## Workspace State\n" + strings.Join(lines, "\n")
 }
 
+// collectWorkSummary summarizes the work on the current branch relative to
+// the default branch: commits since it, changed-file stats (including
+// uncommitted changes to tracked files), and TODOs added along the way. It
+// lets the next session (or the Witness) assess the work without replaying
+// the transcript. Returns empty string outside a git repo or when no base
+// branch can be found.
+func collectWorkSummary() string {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+
+	g := git.NewGit(cwd)
+	if !g.IsRepo() {
+		return ""
+	}
+	base := handoffBaseRef(g)
+	if base == "" {
+		return ""
+	}
+	mergeBase, err := g.MergeBase(base, "HEAD")
+	if err != nil {
+		return ""
+	}
+
+	var lines []string
+
+	if commits, err := g.CommitsSince(base); err == nil && commits != "" {
+		list := strings.Split(commits, "\n")
+		header := fmt.Sprintf("Commits since %s: %d", base, len(list))
+		if len(list) > 10 {
+			list = append(list[:10], fmt.Sprintf("... (+%d more)", len(list)-10))
+		}
+		lines = append(lines, header+"\n"+strings.Join(list, "\n"))
+	}
+
+	// Diff against the merge base rather than base...HEAD so that
+	// uncommitted changes are counted too.
+	if stat, err := g.DiffStat(mergeBase); err == nil && stat != "" {
+		lines = append(lines, "Changed files:\n"+limitDiffStat(stat, 15))
+	}
+
+	if patch, err := g.DiffPatch(mergeBase); err == nil {
+		if todos := addedTODOs(patch, 10); len(todos) > 0 {
+			lines = append(lines, "Open TODOs:\n"+strings.Join(todos, "\n"))
+		}
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	header := "## Work Summary"
+	if branch, err := g.CurrentBranch(); err == nil && branch != "" {
+		header += "\nBranch: " + branch
+	}
+	return header + "\n" + strings.Join(lines, "\n")
+}
+
+// handoffBaseRef returns the branch the work summary is measured against:
+// the rig's default branch as fetched from origin if available, otherwise a
+// local one.
+func handoffBaseRef(g *git.Git) string {
+	def := cwdDefaultBranch(g)
+	seen := make(map[string]bool)
+	for _, ref := range []string{"origin/" + def, def, "main", "master"} {
+		if seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		if ok, err := g.RefExists(ref); err == nil && ok {
+			return ref
+		}
+	}
+	return ""
+}
+
+// limitDiffStat trims git diff --stat output to at most maxFiles file lines,
+// keeping the trailing "N files changed" summary.
+func limitDiffStat(stat string, maxFiles int) string {
+	lines := strings.Split(strings.TrimSpace(stat), "\n")
+	for i := range lines {
+		lines[i] = strings.TrimSpace(lines[i])
+	}
+	files, summary := lines[:len(lines)-1], lines[len(lines)-1]
+	if len(files) > maxFiles {
+		files = append(files[:maxFiles], fmt.Sprintf("... (+%d more)", len(files)-maxFiles))
+	}
+	return strings.Join(append(files, summary), "\n")
+}
+
+// todoMarker matches the comment markers reported as open TODOs.
+var todoMarker = regexp.MustCompile(`\b(TODO|FIXME|XXX)\b`)
+
+// addedTODOs scans a zero-context patch for added lines carrying a TODO
+// marker and returns up to limit of them as "file:line: text".
+func addedTODOs(patch string, limit int) []string {
+	var todos []string
+	var file string
+	var line, more int
+	for _, l := range strings.Split(patch, "\n") {
+		switch {
+		case strings.HasPrefix(l, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(l, "+++ "), "b/")
+			if file == "/dev/null" {
+				file = ""
+			}
+		case strings.HasPrefix(l, "@@ "):
+			// @@ -a,b +c,d @@: added lines start at c.
+			line = 0
+			if i := strings.Index(l, " +"); i >= 0 {
+				start, _, _ := strings.Cut(l[i+2:], " ")
+				start, _, _ = strings.Cut(start, ",")
+				line, _ = strconv.Atoi(start)
+			}
+		case strings.HasPrefix(l, "+"):
+			text := strings.TrimSpace(l[1:])
+			if file != "" && todoMarker.MatchString(text) {
+				if len(todos) < limit {
+					if r := []rune(text); len(r) > 120 {
+						text = string(r[:117]) + "..."
+					}
+					todos = append(todos, fmt.Sprintf("%s:%d: %s", file, line, text))
+				} else {
+					more++
+				}
+			}
+			line++
+		case strings.HasPrefix(l, " "):
+			line++
+		}
+	}
+	if more > 0 {
+		todos = append(todos, fmt.Sprintf("... (+%d more)", more))
+	}
+	return todos
+}
+
 // cleanupMoleculeOnHandoff closes any in-progress molecule steps before session
 // handoff, preventing orphaned wisps from accumulating. (gt-e26g)
 //
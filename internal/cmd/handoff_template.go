@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// handoffShowTemplate is the --template flag.
+var handoffShowTemplate bool
+
+// currentHandoffTemplate returns the handoff template configured for the
+// current agent's role, or nil.
+func currentHandoffTemplate() (*config.HandoffTemplate, RoleInfo) {
+	townRoot, err := workspace.FindFromCwd()
+	if err != nil || townRoot == "" {
+		return nil, RoleInfo{}
+	}
+	info, err := GetRole()
+	if err != nil {
+		return nil, info
+	}
+	rigPath := ""
+	if info.Rig != "" {
+		rigPath = filepath.Join(townRoot, info.Rig)
+	}
+	return config.ResolveHandoffTemplate(townRoot, rigPath, string(info.Role)), info
+}
+
+// applyHandoffTemplate renders message into the role's handoff template, if
+// one is configured. With strict set (the agent wrote the message), a message
+// missing required sections or fields is rejected; otherwise the gaps are
+// only warned about, so automated handoffs are never blocked.
+func applyHandoffTemplate(message string, strict bool) (string, error) {
+	tmpl, info := currentHandoffTemplate()
+	if tmpl == nil {
+		return message, nil
+	}
+	if problems := tmpl.Validate(message); len(problems) > 0 {
+		detail := "  - " + strings.Join(problems, "\n  - ")
+		if strict {
+			return "", fmt.Errorf("handoff does not match the %s handoff template:\n%s\nRun 'gt handoff --template' for the expected structure", info.Role, detail)
+		}
+		style.PrintWarning("handoff is missing parts of the %s handoff template:\n%s", info.Role, detail)
+	}
+	return tmpl.Render(message), nil
+}
+
+// runHandoffTemplate prints the current role's handoff template skeleton.
+func runHandoffTemplate() error {
+	tmpl, info := currentHandoffTemplate()
+	if tmpl == nil {
+		fmt.Printf("%s No handoff template configured for role %s\n", style.Dim.Render("○"), info.Role)
+		fmt.Println("Handoff mail is free-form. Set handoff_templates in settings/config.json to add one.")
+		return nil
+	}
+	fmt.Println(tmpl.Skeleton())
+	return nil
+}
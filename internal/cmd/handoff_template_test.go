@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func setupHandoffTemplateTown(t *testing.T) {
+	t.Helper()
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(`{"name":"test"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	crewDir := filepath.Join(townRoot, "gastown", "crew", "max")
+	if err := os.MkdirAll(crewDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	settings := config.NewTownSettings()
+	settings.HandoffTemplates = map[string]*config.HandoffTemplate{
+		"crew": {Sections: []config.HandoffSection{
+			{Title: "Status", Required: true, Fields: []config.HandoffField{{Name: "Bead", Required: true}}},
+			{Title: "Next Steps"},
+		}},
+	}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatal(err)
+	}
+	for _, k := range []string{EnvGTRole, "GT_RIG", "GT_CREW", "GT_POLECAT"} {
+		t.Setenv(k, "")
+	}
+	t.Chdir(crewDir)
+}
+
+func TestApplyHandoffTemplate(t *testing.T) {
+	setupHandoffTemplateTown(t)
+
+	got, err := applyHandoffTemplate("## Status\nBead: gt-abc", true)
+	if err != nil {
+		t.Fatalf("complete handoff rejected: %v", err)
+	}
+	if !strings.Contains(got, "## Next Steps\n"+config.HandoffPlaceholder) {
+		t.Errorf("rendered handoff missing placeholder section:\n%s", got)
+	}
+
+	_, err = applyHandoffTemplate("just some notes", true)
+	if err == nil || !strings.Contains(err.Error(), `missing required section "Status"`) {
+		t.Errorf("strict: err = %v, want missing Status", err)
+	}
+
+	// Automated handoffs are rendered, not rejected.
+	got, err = applyHandoffTemplate("just some notes", false)
+	if err != nil {
+		t.Fatalf("non-strict: %v", err)
+	}
+	if !strings.HasPrefix(got, "just some notes\n\n## Status\n") {
+		t.Errorf("non-strict render:\n%s", got)
+	}
+}
+
+func TestApplyHandoffTemplate_NoTemplate(t *testing.T) {
+	t.Chdir(t.TempDir())
+	got, err := applyHandoffTemplate("free-form", true)
+	if err != nil || got != "free-form" {
+		t.Errorf("applyHandoffTemplate() = %q, %v; want unchanged", got, err)
+	}
+}
@@ -859,3 +859,98 @@ func TestEnforceHandoffCooldown(t *testing.T) {
 		}
 	})
 }
+
+func TestAddedTODOs(t *testing.T) {
+	patch := `diff --git a/main.go b/main.go
+--- a/main.go
++++ b/main.go
+@@ -10,0 +11,2 @@ func main() {
++	// TODO: handle retries
++	run()
+@@ -20 +22 @@ func other() {
+-	// TODO: removed, not reported
++	x := 1 // FIXME overflow
+diff --git a/gone.go b/gone.go
+--- a/gone.go
++++ /dev/null
+@@ -1 +0,0 @@
+-// TODO: deleted file
+diff --git a/notes.md b/notes.md
+--- /dev/null
++++ b/notes.md
+@@ -0,0 +1,2 @@
++TODOS are not markers
++XXX check this`
+
+	got := addedTODOs(patch, 10)
+	want := []string{
+		"main.go:11: // TODO: handle retries",
+		"main.go:22: x := 1 // FIXME overflow",
+		"notes.md:2: XXX check this",
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("addedTODOs() =\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	limited := addedTODOs(patch, 1)
+	if len(limited) != 2 || limited[1] != "... (+2 more)" {
+		t.Errorf("addedTODOs(limit 1) = %q", limited)
+	}
+}
+
+func TestLimitDiffStat(t *testing.T) {
+	stat := "a.go | 2 +-\n b.go | 1 +\n c.go | 4 ++--\n 3 files changed, 4 insertions(+), 3 deletions(-)"
+	got := limitDiffStat(stat, 2)
+	want := "a.go | 2 +-\nb.go | 1 +\n... (+1 more)\n3 files changed, 4 insertions(+), 3 deletions(-)"
+	if got != want {
+		t.Errorf("limitDiffStat() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCollectWorkSummary(t *testing.T) {
+	tmpDir := t.TempDir()
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Dir = tmpDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("%v failed: %s", args, out)
+		}
+	}
+	run("git", "init", "-b", "main")
+	run("git", "config", "user.email", "test@test.com")
+	run("git", "config", "user.name", "Test")
+	if err := os.WriteFile(filepath.Join(tmpDir, "file.go"), []byte("package x\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	run("git", "add", "file.go")
+	run("git", "commit", "-m", "initial commit")
+	run("git", "checkout", "-b", "polecat/work")
+	if err := os.WriteFile(filepath.Join(tmpDir, "file.go"), []byte("package x\n\n// TODO: finish this\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	run("git", "commit", "-am", "add work in progress")
+	// Uncommitted changes count too.
+	if err := os.WriteFile(filepath.Join(tmpDir, "file.go"), []byte("package x\n\n// TODO: finish this\nvar y = 1\n"), 0644); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+
+	t.Chdir(tmpDir)
+	summary := collectWorkSummary()
+
+	for _, want := range []string{
+		"## Work Summary",
+		"Branch: polecat/work",
+		"Commits since main: 1",
+		"add work in progress",
+		"1 file changed, 3 insertions(+)",
+		"file.go:3: // TODO: finish this",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("expected %q in summary, got:\n%s", want, summary)
+		}
+	}
+	if strings.Contains(summary, "initial commit") {
+		t.Errorf("summary should not include commits already on main:\n%s", summary)
+	}
+}
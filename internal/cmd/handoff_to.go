@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/nudge"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// handoffRecipient is the agent a directed handoff (--to) goes to.
+type handoffRecipient struct {
+	Address  string // As given, e.g. "gastown/crew/max"
+	Identity *session.AgentIdentity
+	Session  string // tmux session name
+	WorkDir  string // Agent's home directory, where its handoff marker goes
+	Running  bool
+}
+
+// runHandoffTo hands work off to another agent instead of cycling this
+// session: the handoff mail is hooked on the recipient, its handoff marker is
+// written, and its session is nudged. If the recipient isn't running, the
+// Mayor gets a sling request to dispatch it.
+func runHandoffTo(args []string) error {
+	if handoffAuto || handoffCycle {
+		return fmt.Errorf("--to cannot be combined with --auto or --cycle")
+	}
+	var workBead string
+	if len(args) > 0 {
+		if !looksLikeBeadID(args[0]) {
+			return fmt.Errorf("with --to, the argument must be a bead ID (got %q)", args[0])
+		}
+		workBead = args[0]
+	}
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return err
+	}
+	_ = session.InitRegistry(townRoot)
+
+	t := tmux.NewTmux()
+	recipient, err := resolveHandoffRecipient(handoffTo, townRoot, func(name string) bool {
+		exists, _ := t.HasSession(name)
+		return exists
+	})
+	if err != nil {
+		return err
+	}
+
+	sender := detectSender()
+	senderSession := sender
+	if tmux.IsInsideTmux() {
+		if name, err := getCurrentTmuxSession(); err == nil {
+			senderSession = name
+		}
+	}
+
+	subject := handoffSubject
+	if subject == "" {
+		subject = fmt.Sprintf("🤝 HANDOFF: %s → %s", sender, recipient.Address)
+		if workBead != "" {
+			subject = fmt.Sprintf("🤝 HANDOFF: %s → %s", workBead, recipient.Address)
+		}
+	}
+	message := handoffMessage
+	if handoffCollect {
+		collected := collectHandoffState()
+		if message == "" {
+			message = collected
+		} else {
+			message = message + "\n\n---\n" + collected
+		}
+	}
+	message, err = applyHandoffTemplate(message, handoffMessage != "")
+	if err != nil {
+		return err
+	}
+	message = strings.TrimSpace(fmt.Sprintf("Handed off by %s to %s.\n\n%s", sender, recipient.Address, message))
+
+	if handoffDryRun {
+		if workBead != "" {
+			fmt.Printf("Would hook %s on %s\n", workBead, recipient.Address)
+		}
+		fmt.Printf("Would send handoff mail to %s: subject=%q (auto-hooked)\n", recipient.Address, subject)
+		fmt.Printf("Would write handoff marker in %s\n", recipient.WorkDir)
+		if recipient.Running {
+			fmt.Printf("Would nudge session %s\n", recipient.Session)
+		} else if recipient.Identity.Rig != "" {
+			fmt.Printf("Would ask the mayor to dispatch %s (session %s not running)\n", recipient.Address, recipient.Session)
+		}
+		return nil
+	}
+
+	if workBead != "" {
+		if err := hookBeadForHandoffTo(workBead, recipient.Address); err != nil {
+			return fmt.Errorf("hooking bead: %w", err)
+		}
+	}
+
+	beadID, err := sendHandoffMailTo(subject, message, recipient.Address)
+	if err != nil {
+		_ = LogHandoffNoPersist(townRoot, sender, subject, err)
+		return fmt.Errorf("handoff mail failed to persist (Dolt may be down): %w", err)
+	}
+	fmt.Printf("%s Sent handoff mail %s to %s (auto-hooked)\n", style.Bold.Render("📬"), beadID, recipient.Address)
+
+	if err := writeHandoffMarker(recipient.WorkDir, senderSession, "directed", beadID, workBead); err != nil {
+		style.PrintWarning("could not write handoff marker for %s: %v", recipient.Address, err)
+	}
+
+	switch {
+	case recipient.Running:
+		notice := fmt.Sprintf("Handoff from %s: %s (%s). Run `gt hook` to pick it up.", sender, subject, beadID)
+		if err := nudge.Enqueue(townRoot, recipient.Session, nudge.QueuedNudge{
+			Sender:   sender,
+			Message:  notice,
+			Priority: nudge.PriorityUrgent,
+		}); err != nil {
+			style.PrintWarning("could not notify %s: %v", recipient.Session, err)
+		} else {
+			fmt.Printf("%s Notified %s\n", style.Bold.Render("✓"), recipient.Session)
+		}
+	case recipient.Identity.Rig != "":
+		dispatchBead := beadID
+		if workBead != "" {
+			dispatchBead = workBead
+		}
+		if err := requestHandoffDispatch(townRoot, sender, recipient, dispatchBead, subject); err != nil {
+			style.PrintWarning("could not queue dispatch for the mayor: %v", err)
+		} else {
+			fmt.Printf("%s %s is not running; asked the mayor to dispatch it\n", style.Bold.Render("✓"), recipient.Address)
+		}
+	default:
+		fmt.Printf("%s %s is not running; it will find the handoff on its hook when it starts\n",
+			style.Dim.Render("○"), recipient.Address)
+	}
+
+	_ = LogHandoff(townRoot, sender, fmt.Sprintf("to %s: %s", recipient.Address, subject))
+	payload := events.HandoffPayload(subject, false)
+	payload["to"] = recipient.Address
+	_ = events.LogFeed(events.TypeHandoff, sender, payload)
+
+	return nil
+}
+
+// resolveHandoffRecipient resolves a --to address to the recipient's session
+// and home directory. Short rig addresses (rig/name) may be crew or polecat;
+// a running session wins, then one whose home directory exists.
+func resolveHandoffRecipient(address, townRoot string, hasSession func(string) bool) (*handoffRecipient, error) {
+	identity, err := session.ParseAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --to address: %w", err)
+	}
+
+	candidates := mail.AddressToSessionIDs(strings.TrimSuffix(address, "/"))
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("invalid --to address %q", address)
+	}
+
+	var fallback *handoffRecipient
+	for _, name := range candidates {
+		workDir, err := sessionWorkDir(name, townRoot)
+		if err != nil {
+			continue
+		}
+		r := &handoffRecipient{Address: address, Identity: identity, Session: name, WorkDir: workDir}
+		if hasSession(name) {
+			r.Running = true
+			return r, nil
+		}
+		if fallback == nil {
+			if _, err := os.Stat(workDir); err == nil {
+				fallback = r
+			}
+		}
+	}
+	if fallback == nil {
+		return nil, fmt.Errorf("no agent found for %q (no running session or workspace)", address)
+	}
+	return fallback, nil
+}
+
+// writeHandoffMarker writes the handoff marker in workDir so the agent's next
+// prime knows it is picking up a handoff. beadIDs (the handoff mail, hooked
+// work) and the --next hints are passed along for the successor's briefing.
+func writeHandoffMarker(workDir, fromSession, reason string, beadIDs ...string) error {
+	m := &session.HandoffMarker{
+		PrevSession: fromSession,
+		Reason:      reason,
+		NextSteps:   handoffNext,
+	}
+	for _, id := range beadIDs {
+		if id != "" {
+			m.Beads = append(m.Beads, id)
+		}
+	}
+	return session.WriteHandoffMarker(workDir, m)
+}
+
+// requestHandoffDispatch asks the Mayor to start the recipient by mailing it
+// a SLING_REQUEST, which the Mayor's callbacks already handle.
+func requestHandoffDispatch(townRoot, sender string, recipient *handoffRecipient, beadID, subject string) error {
+	router := mail.NewRouter(townRoot)
+	defer router.WaitPendingNotifications()
+	return router.Send(&mail.Message{
+		From:    sender,
+		To:      "mayor/",
+		Subject: fmt.Sprintf("SLING_REQUEST: %s", beadID),
+		Body: fmt.Sprintf("Rig: %s\nTarget: %s\nHandoff from: %s\n\n%s",
+			recipient.Identity.Rig, recipient.Address, sender, subject),
+		Priority: mail.PriorityHigh,
+	})
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func TestResolveHandoffRecipient(t *testing.T) {
+	setupHandoffTestRegistry(t)
+	townRoot := t.TempDir()
+	for _, dir := range []string{"gastown/crew/max", "gastown/polecats/toast", "gastown/witness"} {
+		if err := os.MkdirAll(filepath.Join(townRoot, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	none := func(string) bool { return false }
+
+	tests := []struct {
+		name        string
+		address     string
+		running     map[string]bool
+		wantSession string
+		wantWorkDir string
+		wantRunning bool
+		wantRole    session.Role
+	}{
+		{"crew_explicit", "gastown/crew/max", nil, "gt-crew-max", "gastown/crew/max", false, session.RoleCrew},
+		{"short_address_finds_crew_workspace", "gastown/max", nil, "gt-crew-max", "gastown/crew/max", false, session.RolePolecat},
+		{"short_address_finds_polecat_workspace", "gastown/toast", nil, "gt-toast", "gastown/polecats/toast", false, session.RolePolecat},
+		{"running_session_wins", "gastown/toast", map[string]bool{"gt-toast": true}, "gt-toast", "gastown/polecats/toast", true, session.RolePolecat},
+		{"witness", "gastown/witness", nil, "gt-witness", "gastown/witness", false, session.RoleWitness},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hasSession := none
+			if tt.running != nil {
+				hasSession = func(name string) bool { return tt.running[name] }
+			}
+			r, err := resolveHandoffRecipient(tt.address, townRoot, hasSession)
+			if err != nil {
+				t.Fatalf("resolveHandoffRecipient(%q) error: %v", tt.address, err)
+			}
+			if r.Session != tt.wantSession {
+				t.Errorf("Session = %q, want %q", r.Session, tt.wantSession)
+			}
+			if want := filepath.Join(townRoot, tt.wantWorkDir); filepath.Clean(r.WorkDir) != want {
+				t.Errorf("WorkDir = %q, want %q", r.WorkDir, want)
+			}
+			if r.Running != tt.wantRunning {
+				t.Errorf("Running = %v, want %v", r.Running, tt.wantRunning)
+			}
+			if r.Identity.Role != tt.wantRole || r.Identity.Rig != "gastown" {
+				t.Errorf("Identity = %+v, want role %s in gastown", r.Identity, tt.wantRole)
+			}
+		})
+	}
+
+	for _, address := range []string{"gastown/nobody", "gastown", "overseer"} {
+		if _, err := resolveHandoffRecipient(address, townRoot, none); err == nil {
+			t.Errorf("resolveHandoffRecipient(%q) expected error", address)
+		}
+	}
+}
+
+func TestWriteHandoffMarker_DetectedAsPostHandoff(t *testing.T) {
+	workDir := t.TempDir()
+	if err := writeHandoffMarker(workDir, "gt-crew-joe", "directed"); err != nil {
+		t.Fatalf("writeHandoffMarker: %v", err)
+	}
+
+	state := detectSessionState(RoleContext{Role: RoleCrew, Rig: "gastown", Polecat: "max", WorkDir: workDir})
+	if state.State != "post-handoff" {
+		t.Fatalf("expected state 'post-handoff', got %q", state.State)
+	}
+	if state.PrevSession != "gt-crew-joe" {
+		t.Errorf("expected prev_session gt-crew-joe, got %q", state.PrevSession)
+	}
+}
+
+func TestWriteHandoffMarker_StructuredState(t *testing.T) {
+	old := handoffNext
+	handoffNext = []string{"Rebase onto main", "Rerun the flaky test"}
+	t.Cleanup(func() { handoffNext = old })
+
+	workDir := t.TempDir()
+	if err := writeHandoffMarker(workDir, "gt-crew-joe", "compaction", "hq-mail1", "", "gt-abc"); err != nil {
+		t.Fatalf("writeHandoffMarker: %v", err)
+	}
+
+	state := detectSessionState(RoleContext{Role: RoleCrew, Rig: "gastown", Polecat: "max", WorkDir: workDir})
+	if got, want := strings.Join(state.HandoffBeads, ","), "hq-mail1,gt-abc"; got != want {
+		t.Errorf("handoff beads = %q, want %q", got, want)
+	}
+	if len(state.NextSteps) != 2 {
+		t.Errorf("next steps = %q, want 2", state.NextSteps)
+	}
+
+	primeHandoffReason = ""
+	t.Cleanup(func() { primeHandoffReason = "" })
+	out := captureStdout(t, func() { checkHandoffMarker(workDir) })
+	if primeHandoffReason != "compaction" {
+		t.Errorf("primeHandoffReason = %q, want compaction", primeHandoffReason)
+	}
+	for _, want := range []string{"gt-crew-joe", "Rebase onto main", "hq-mail1, gt-abc"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("handoff warning missing %q:\n%s", want, out)
+		}
+	}
+	if _, err := os.Stat(session.HandoffMarkerPath(workDir)); !os.IsNotExist(err) {
+		t.Error("marker not removed after checkHandoffMarker")
+	}
+}
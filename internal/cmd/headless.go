@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/headless"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var headlessCmd = &cobra.Command{
+	Use:     "headless",
+	GroupID: GroupServices,
+	Short:   "Inspect Mayor/Deacon running headless",
+	Long: `Headless mode runs the Mayor and/or Deacon as supervised background
+processes instead of interactive tmux sessions. Each cycle runs the
+agent's non-interactive mode with a prompt to do one round of work, appends
+the output to logs/headless-<role>.log, then waits for the next cycle.
+
+Enable it in settings/config.json:
+
+  "headless": {
+    "roles": ["mayor", "deacon"],
+    "interval": "5m"
+  }
+
+Once enabled, gt start, gt mayor start, gt deacon start and gt shutdown
+manage the headless supervisors instead of tmux sessions.`,
+	RunE: requireSubcommand,
+}
+
+var headlessStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show headless supervisors and their logs",
+	RunE:  runHeadlessStatus,
+}
+
+var headlessRunCmd = &cobra.Command{
+	Use:    "run <role>",
+	Short:  "Run the headless supervisor for a role in the foreground",
+	Hidden: true, // Started by gt start / gt mayor start
+	Args:   cobra.ExactArgs(1),
+	RunE:   runHeadlessRun,
+}
+
+func init() {
+	headlessCmd.AddCommand(headlessStatusCmd)
+	headlessCmd.AddCommand(headlessRunCmd)
+	rootCmd.AddCommand(headlessCmd)
+}
+
+func runHeadlessRun(cmd *cobra.Command, args []string) error {
+	role := args[0]
+	if !slices.Contains(headless.Roles, role) {
+		return fmt.Errorf("role %q cannot run headless (valid: %s)", role, strings.Join(headless.Roles, ", "))
+	}
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return headless.Supervise(ctx, townRoot, role)
+}
+
+func runHeadlessStatus(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	for _, role := range headless.Roles {
+		if !headless.Enabled(townRoot, role) {
+			fmt.Printf("%s  %s\n", role, style.Dim.Render("interactive (tmux)"))
+			continue
+		}
+		state := style.Error.Render("stopped")
+		if pid, running := headless.Running(townRoot, role); running {
+			state = style.Success.Render(fmt.Sprintf("running (PID %d)", pid))
+		}
+		fmt.Printf("%s  headless, %s, every %s\n", role, state, headless.Interval(townRoot))
+		fmt.Printf("  log: %s\n", headless.LogFilePath(townRoot, role))
+	}
+	return nil
+}
+
+// stopHeadlessAgents stops any running headless supervisors during a
+// whole-town shutdown. Returns the number stopped.
+func stopHeadlessAgents(townRoot string) int {
+	if townRoot == "" {
+		return 0
+	}
+	stopped := 0
+	for _, role := range headless.Roles {
+		if _, running := headless.Running(townRoot, role); !running {
+			continue
+		}
+		if err := headless.Stop(townRoot, role); err != nil {
+			fmt.Printf("  %s headless %s: %v\n", style.Error.Render("✗"), role, err)
+			continue
+		}
+		fmt.Printf("  %s headless %s stopped\n", style.Bold.Render("✓"), role)
+		stopped++
+	}
+	return stopped
+}
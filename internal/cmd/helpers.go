@@ -11,6 +11,7 @@ import (
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 // inferRigFromCwd tries to determine the rig from the current directory.
@@ -37,6 +38,20 @@ func inferRigFromCwd(townRoot string) (string, error) {
 	return "", fmt.Errorf("could not infer rig from current directory")
 }
 
+// cwdDefaultBranch returns the default branch of the rig containing the
+// current directory, or g's remote default branch outside a rig.
+func cwdDefaultBranch(g *git.Git) string {
+	if townRoot, err := workspace.FindFromCwd(); err == nil && townRoot != "" {
+		if rigName, err := inferRigFromCwd(townRoot); err == nil {
+			rigPath := filepath.Join(townRoot, rigName)
+			if _, err := os.Stat(filepath.Join(rigPath, "config.json")); err == nil {
+				return rig.ResolveDefaultBranch(rigPath)
+			}
+		}
+	}
+	return g.RemoteDefaultBranch()
+}
+
 // inferRigFromCrewName scans all rigs in the town root for a crew member
 // with the given name. Returns the rig name if the crew member is unique
 // across all rigs. Returns an error if not found or ambiguous.
@@ -130,11 +145,7 @@ func ensureDefaultBranch(dir, roleName, rigPath string) error {
 		return fmt.Errorf("could not determine current branch: %w", err)
 	}
 
-	// Get configured default branch for this rig
-	defaultBranch := "main" // fallback
-	if rigCfg, err := rig.LoadRigConfig(rigPath); err == nil && rigCfg.DefaultBranch != "" {
-		defaultBranch = rigCfg.DefaultBranch
-	}
+	defaultBranch := rig.ResolveDefaultBranch(rigPath)
 
 	if branch == defaultBranch {
 		// Already on default branch — still pull to ensure up-to-date
@@ -171,10 +182,7 @@ func warnIfNotDefaultBranch(dir, roleName, rigPath string) {
 		return
 	}
 
-	defaultBranch := "main"
-	if rigCfg, err := rig.LoadRigConfig(rigPath); err == nil && rigCfg.DefaultBranch != "" {
-		defaultBranch = rigCfg.DefaultBranch
-	}
+	defaultBranch := rig.ResolveDefaultBranch(rigPath)
 
 	if branch == defaultBranch {
 		return
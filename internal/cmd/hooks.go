@@ -25,8 +25,12 @@ Subcommands:
   install    Install a hook from the registry
 
 Config structure:
-  Base:      ~/.gt/hooks-base.json
-  Overrides: ~/.gt/hooks-overrides/<target>.json
+  Base:      ~/.config/gastown/hooks-base.json
+  Overrides: ~/.config/gastown/hooks-overrides/<target>.json
+
+The directory is $XDG_CONFIG_HOME/gastown ($GT_HOME/.gt when GT_HOME is set).
+Installs that predate XDG support keep using ~/.gt until moved with
+'gt config migrate-xdg'.
 
 Merge strategy: base → role → rig+role (more specific wins)
 
@@ -15,8 +15,9 @@ var hooksBaseCmd = &cobra.Command{
 	Long: `Edit the shared base hook configuration.
 
 The base config defines hooks that apply to all agents. It is stored
-at ~/.gt/hooks-base.json. If the file doesn't exist, it will be
-created with sensible defaults (PATH setup, gt prime, etc.).
+at ~/.config/gastown/hooks-base.json (see 'gt hooks' for the directory).
+If the file doesn't exist, it will be created with sensible defaults
+(PATH setup, gt prime, etc.).
 
 After editing, run 'gt hooks sync' to propagate changes.
 
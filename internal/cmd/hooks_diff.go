@@ -69,7 +69,7 @@ func runHooksDiff(cmd *cobra.Command, args []string) error {
 	hasChanges := false
 
 	for _, target := range targets {
-		expected, err := hooks.ComputeExpected(target.Key)
+		expected, err := hooks.ComputeExpectedForTown(target.TownRoot, target.Key)
 		if err != nil {
 			return fmt.Errorf("computing expected config for %s: %w", target.DisplayKey(), err)
 		}
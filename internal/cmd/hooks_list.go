@@ -96,7 +96,7 @@ func buildTargetInfo(target hooks.Target) listTargetInfo {
 	// Determine sync status
 	status := "missing"
 	if exists {
-		expected, err := hooks.ComputeExpected(target.Key)
+		expected, err := hooks.ComputeExpectedForTown(target.TownRoot, target.Key)
 		if err != nil {
 			status = "error"
 		} else {
@@ -21,7 +21,8 @@ Valid targets:
 Overrides are merged on top of the base config during sync.
 Hooks with the same matcher replace the base hook entirely.
 
-Override files are stored in ~/.gt/hooks-overrides/<target>.json.
+Override files are stored in ~/.config/gastown/hooks-overrides/<target>.json
+(see 'gt hooks' for the directory).
 
 Examples:
   gt hooks override crew              # Edit crew role overrides
@@ -253,7 +253,7 @@ const (
 // Uses MarshalSettings/UnmarshalSettings to preserve unknown fields.
 func syncTarget(target hooks.Target, dryRun bool) (syncResult, error) {
 	// Compute expected hooks for this target
-	expected, err := hooks.ComputeExpected(target.Key)
+	expected, err := hooks.ComputeExpectedForTown(target.TownRoot, target.Key)
 	if err != nil {
 		return 0, fmt.Errorf("computing expected config: %w", err)
 	}
@@ -13,31 +13,73 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/ui"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
 var initForce bool
 
 var initCmd = &cobra.Command{
-	Use:     "init",
+	Use:     "init [path]",
 	GroupID: GroupWorkspace,
-	Short:   "Initialize current directory as a Gas Town rig",
+	Short:   "Initialize a Gas Town rig, or bootstrap a new town",
 	Long: `Initialize the current directory for use as a Gas Town rig.
 
 This creates the standard agent directories (polecats/, witness/, refinery/,
 mayor/) and updates .git/info/exclude to ignore them.
 
 The current directory must be a git repository. Use --force to reinitialize
-an existing rig structure.`,
+an existing rig structure.
+
+TOWN BOOTSTRAP:
+With --town, or when run outside both a git repository and a town, gt init
+walks through setting up a new town instead: where it lives (default ~/gt),
+its name and owner, and optionally a first rig to clone. It then creates the
+HQ (mayor/town.json, rigs.json, deacon/, .beads routes; see gt install),
+writes the base hooks config if there is none, and adds the rig.
+
+Flags pre-fill the answers; --yes skips the questions entirely:
+
+  gt init --town                                  # Ask everything
+  gt init --town ~/gt --rig-url git@github.com:me/app.git --yes
+
+TEMPLATES:
+--template pre-configures the new town for a common setup (and implies
+--town). It sets the default gt start profile, witness thresholds and
+other town settings, and picks the base hooks config:
+
+  solo   One person driving agents: gt start brings up Mayor and Deacon
+         only; witness stall detection is relaxed
+  team   A shared town: gt start brings up every rig's Witness and
+         Refinery; failing work escalates to the Mayor sooner
+  ci     Unattended servers: headless Mayor and Deacon, every rig agent,
+         strict witness thresholds, CI=true in sessions, no prompt hooks
+
+  gt init --template team ~/gt --yes`,
+	Args: cobra.MaximumNArgs(1),
 	RunE: runInit,
 }
 
 func init() {
 	initCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Reinitialize existing structure")
+	initCmd.Flags().BoolVar(&initTown, "town", false, "Bootstrap a new town instead of a rig")
+	initCmd.Flags().StringVar(&initName, "name", "", "Town name (with --town; defaults to directory name)")
+	initCmd.Flags().StringVar(&initOwner, "owner", "", "Owner email (with --town; defaults to git config user.email)")
+	initCmd.Flags().StringVar(&initRigURL, "rig-url", "", "Git URL of a first rig to add (with --town)")
+	initCmd.Flags().StringVar(&initRigName, "rig-name", "", "Name of the first rig (with --town; defaults from --rig-url)")
+	initCmd.Flags().BoolVarP(&initYes, "yes", "y", false, "Accept defaults without prompting (with --town)")
+	initCmd.Flags().StringVar(&initTemplate, "template", "", "Pre-configure the new town: solo, team or ci (implies --town)")
 	rootCmd.AddCommand(initCmd)
 }
 
 func runInit(cmd *cobra.Command, args []string) error {
+	if initTown || initTemplate != "" {
+		return runInitTown(cmd, args)
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("a path is only accepted with --town (rigs are initialized in the current directory)")
+	}
+
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("getting current directory: %w", err)
@@ -46,7 +88,12 @@ func runInit(cmd *cobra.Command, args []string) error {
 	// Check if it's a git repository
 	g := git.NewGit(cwd)
 	if _, err := g.CurrentBranch(); err != nil {
-		return fmt.Errorf("not a git repository (run 'git init' first)")
+		// Outside a repo and outside a town there is no rig to set up;
+		// offer to bootstrap a town instead.
+		if townRoot, _ := workspace.Find(cwd); townRoot == "" && ui.IsTerminal() {
+			return runInitTown(cmd, args)
+		}
+		return fmt.Errorf("not a git repository (run 'git init' first, or 'gt init --town' to create a town)")
 	}
 
 	// Check if already initialized
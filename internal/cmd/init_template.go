@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/hooks"
+)
+
+// townTemplate pre-configures a new town for a common way of working.
+// The templates are described in gt init's help.
+type townTemplate struct {
+	// Settings adjusts the new town's settings/config.json.
+	Settings func(s *config.TownSettings)
+
+	// Hooks adjusts the default base hooks config. Nil keeps the default.
+	Hooks func(base *hooks.HooksConfig)
+}
+
+// townTemplates are the setups offered by gt init --template.
+var townTemplates = map[string]townTemplate{
+	"solo": {
+		Settings: func(s *config.TownSettings) {
+			s.DefaultStartupProfile = config.StartupProfileMinimal
+			w := templateWitness(s)
+			w.StartupStallThreshold = "3m"
+			w.StartupActivityGrace = "2m"
+		},
+	},
+	"team": {
+		Settings: func(s *config.TownSettings) {
+			s.DefaultStartupProfile = config.StartupProfileFull
+			maxRespawns := 2
+			templateWitness(s).MaxBeadRespawns = &maxRespawns
+		},
+	},
+	"ci": {
+		Settings: func(s *config.TownSettings) {
+			s.DefaultStartupProfile = config.StartupProfileFull
+			s.Headless = &config.HeadlessConfig{Roles: []string{"mayor", "deacon"}}
+			w := templateWitness(s)
+			w.StartupStallThreshold = "60s"
+			w.StartupActivityGrace = "30s"
+			w.DoneIntentStuckTimeout = "30s"
+			maxRespawns := 1
+			w.MaxBeadRespawns = &maxRespawns
+			if s.SessionEnv == nil {
+				s.SessionEnv = &config.SessionEnvConfig{}
+			}
+			if s.SessionEnv.Vars == nil {
+				s.SessionEnv.Vars = make(map[string]string)
+			}
+			s.SessionEnv.Vars["CI"] = "true"
+		},
+		Hooks: func(base *hooks.HooksConfig) {
+			// Nobody types prompts on a CI box; agents pick up mail on patrol.
+			base.UserPromptSubmit = nil
+		},
+	},
+}
+
+// templateWitness returns the town's witness thresholds, creating them.
+func templateWitness(s *config.TownSettings) *config.WitnessThresholds {
+	if s.Operational == nil {
+		s.Operational = config.DefaultOperationalConfig()
+	}
+	if s.Operational.Witness == nil {
+		s.Operational.Witness = &config.WitnessThresholds{}
+	}
+	return s.Operational.Witness
+}
+
+// townTemplateNames returns the template names, sorted.
+func townTemplateNames() []string {
+	names := make([]string, 0, len(townTemplates))
+	for name := range townTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookupTownTemplate returns the named template.
+func lookupTownTemplate(name string) (townTemplate, error) {
+	t, ok := townTemplates[name]
+	if !ok {
+		return townTemplate{}, fmt.Errorf("unknown template %q (have: %s)", name, strings.Join(townTemplateNames(), ", "))
+	}
+	return t, nil
+}
+
+// applyTownTemplate writes the template's settings into the town at townRoot.
+func applyTownTemplate(townRoot string, t townTemplate) error {
+	path := config.TownSettingsPath(townRoot)
+	settings, err := config.LoadOrCreateTownSettings(path)
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+	t.Settings(settings)
+	return config.SaveTownSettings(path, settings)
+}
+
+// templateHooksBase returns the base hooks config for t.
+func templateHooksBase(t townTemplate) *hooks.HooksConfig {
+	base := hooks.DefaultBase()
+	if t.Hooks != nil {
+		t.Hooks(base)
+	}
+	return base
+}
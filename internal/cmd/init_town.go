@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/hooks"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/ui"
+)
+
+// gt init --town flags.
+var (
+	initTown     bool
+	initName     string
+	initOwner    string
+	initRigURL   string
+	initRigName  string
+	initYes      bool
+	initTemplate string
+)
+
+// defaultTownPath is where the wizard offers to create a town.
+const defaultTownPath = "~/gt"
+
+// townWizardAnswers are the choices that shape a new town.
+type townWizardAnswers struct {
+	Path    string // town root (~ allowed)
+	Name    string // town name; defaults to the directory name
+	Owner   string // owner email for entity identity
+	RigURL  string // first rig's git URL; "" to add no rig
+	RigName string // first rig's name; defaults from RigURL
+}
+
+// askTownWizard prompts on out for each answer not already set in defaults,
+// reading replies from in. An empty reply keeps the default shown in brackets.
+func askTownWizard(in io.Reader, out io.Writer, defaults townWizardAnswers) townWizardAnswers {
+	reader := bufio.NewReader(in)
+	ask := func(question, def string) string {
+		if def != "" {
+			fmt.Fprintf(out, "%s [%s]: ", question, def)
+		} else {
+			fmt.Fprintf(out, "%s: ", question)
+		}
+		line, _ := reader.ReadString('\n')
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+		return def
+	}
+
+	a := defaults
+	a.Path = ask("Town directory", a.Path)
+	if a.Name == "" {
+		a.Name = filepath.Base(expandTownPath(a.Path))
+	}
+	a.Name = ask("Town name", a.Name)
+	a.Owner = ask("Owner email", a.Owner)
+	a.RigURL = ask("Git URL of a first rig (blank to skip)", a.RigURL)
+	if a.RigURL != "" {
+		if a.RigName == "" {
+			a.RigName = rigNameFromURL(a.RigURL)
+		}
+		a.RigName = ask("Rig name", a.RigName)
+	}
+	return a
+}
+
+// rigNameFromURL derives a valid rig name from a git URL: the repository
+// name, lowercased, with the characters rig names forbid replaced by "_".
+func rigNameFromURL(url string) string {
+	name := strings.TrimRight(url, "/")
+	if i := strings.LastIndexAny(name, "/:"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSuffix(name, ".git")
+	name = strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(name)
+	return strings.ToLower(strings.Trim(name, "_"))
+}
+
+// expandTownPath expands a leading ~ to the home directory.
+func expandTownPath(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// gitUserEmail returns git's configured user.email, or "".
+func gitUserEmail() string {
+	out, err := exec.Command("git", "config", "user.email").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// runInitTown bootstraps a complete town: the HQ that gt install creates,
+// the base hooks config, and optionally a first rig.
+func runInitTown(cmd *cobra.Command, args []string) error {
+	answers := townWizardAnswers{
+		Path:    defaultTownPath,
+		Name:    initName,
+		Owner:   initOwner,
+		RigURL:  initRigURL,
+		RigName: initRigName,
+	}
+	if len(args) > 0 {
+		answers.Path = args[0]
+	}
+	if answers.Owner == "" {
+		answers.Owner = gitUserEmail()
+	}
+	var tmpl *townTemplate
+	if initTemplate != "" {
+		t, err := lookupTownTemplate(initTemplate)
+		if err != nil {
+			return err
+		}
+		tmpl = &t
+	}
+
+	if !initYes {
+		if !ui.IsTerminal() {
+			return fmt.Errorf("gt init --town needs a terminal to ask questions (use --yes to accept defaults and flags)")
+		}
+		fmt.Printf("%s Setting up a new Gas Town\n\n", style.Bold.Render("⚙️"))
+		answers = askTownWizard(os.Stdin, os.Stdout, answers)
+		fmt.Println()
+	}
+	if answers.RigURL != "" && answers.RigName == "" {
+		answers.RigName = rigNameFromURL(answers.RigURL)
+	}
+	if answers.RigURL != "" && !isGitRemoteURL(answers.RigURL) {
+		return fmt.Errorf("invalid rig URL %q: expected a remote URL (e.g. https://, git@host:, ssh://, file:///abs/path)", answers.RigURL)
+	}
+
+	townRoot, err := filepath.Abs(expandTownPath(answers.Path))
+	if err != nil {
+		return fmt.Errorf("resolving path: %w", err)
+	}
+
+	// The HQ itself (mayor/town.json, rigs.json, deacon/, .beads routes, ...)
+	// is exactly what gt install builds.
+	installName = answers.Name
+	installOwner = answers.Owner
+	installForce = initForce
+	if err := runInstall(cmd, []string{townRoot}); err != nil {
+		return err
+	}
+
+	base := hooks.DefaultBase()
+	if tmpl != nil {
+		if err := applyTownTemplate(townRoot, *tmpl); err != nil {
+			return fmt.Errorf("applying template %s: %w", initTemplate, err)
+		}
+		fmt.Printf("   ✓ Applied %s template to %s\n", initTemplate, config.TownSettingsPath(townRoot))
+		base = templateHooksBase(*tmpl)
+	}
+	if created, err := ensureHooksBase(base); err != nil {
+		fmt.Printf("   %s Could not write base hooks config: %v\n", style.Dim.Render("⚠"), err)
+	} else if created {
+		fmt.Printf("   ✓ Created %s\n", hooks.BasePath())
+	} else if tmpl != nil && tmpl.Hooks != nil {
+		fmt.Printf("   %s Kept existing %s; the %s template's hooks were not applied\n", style.Dim.Render("⚠"), hooks.BasePath(), initTemplate)
+	}
+
+	if answers.RigURL == "" {
+		return nil
+	}
+	fmt.Printf("\n%s Adding first rig %s\n\n", style.Bold.Render("⚙️"), answers.RigName)
+	// gt rig add finds the town from the working directory.
+	if err := os.Chdir(townRoot); err != nil {
+		return fmt.Errorf("entering town: %w", err)
+	}
+	if err := runRigAdd(cmd, []string{answers.RigName, answers.RigURL}); err != nil {
+		return fmt.Errorf("town created, but adding rig %s failed (retry with 'gt rig add %s %s'): %w",
+			answers.RigName, answers.RigName, answers.RigURL, err)
+	}
+	return nil
+}
+
+// ensureHooksBase writes base as the base hooks config unless one already
+// exists. It reports whether it created the file.
+func ensureHooksBase(base *hooks.HooksConfig) (bool, error) {
+	if _, err := hooks.LoadBase(); err == nil {
+		return false, nil
+	} else if !os.IsNotExist(err) {
+		return false, err
+	}
+	if err := hooks.SaveBase(base); err != nil {
+		return false, err
+	}
+	return true, nil
+}
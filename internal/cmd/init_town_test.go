@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/hooks"
+)
+
+func TestRigNameFromURL(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/acme/my-app.git":   "my_app",
+		"git@github.com:acme/Web.Site.git":     "web_site",
+		"ssh://git@host/acme/tools/":           "tools",
+		"file:///srv/repos/gastown":            "gastown",
+		"git@host:backend":                     "backend",
+		"https://github.com/acme/-leading.git": "leading",
+	}
+	for url, want := range tests {
+		if got := rigNameFromURL(url); got != want {
+			t.Errorf("rigNameFromURL(%q) = %q, want %q", url, got, want)
+		}
+	}
+}
+
+func TestAskTownWizard(t *testing.T) {
+	t.Run("empty replies keep defaults", func(t *testing.T) {
+		var out bytes.Buffer
+		got := askTownWizard(strings.NewReader("\n\n\n\n"), &out, townWizardAnswers{
+			Path:  "/tmp/towns/hq",
+			Owner: "me@example.com",
+		})
+		want := townWizardAnswers{Path: "/tmp/towns/hq", Name: "hq", Owner: "me@example.com"}
+		if got != want {
+			t.Errorf("answers = %+v, want %+v", got, want)
+		}
+		if !strings.Contains(out.String(), "Town name [hq]") {
+			t.Errorf("prompt should offer the directory name, got:\n%s", out.String())
+		}
+	})
+
+	t.Run("replies override defaults and name the rig", func(t *testing.T) {
+		in := strings.NewReader("/srv/gt\ncity\nops@example.com\ngit@github.com:acme/api-server.git\n\n")
+		got := askTownWizard(in, &bytes.Buffer{}, townWizardAnswers{Path: defaultTownPath})
+		want := townWizardAnswers{
+			Path:    "/srv/gt",
+			Name:    "city",
+			Owner:   "ops@example.com",
+			RigURL:  "git@github.com:acme/api-server.git",
+			RigName: "api_server",
+		}
+		if got != want {
+			t.Errorf("answers = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("flags skip nothing but pre-fill", func(t *testing.T) {
+		got := askTownWizard(strings.NewReader(""), &bytes.Buffer{}, townWizardAnswers{
+			Path:    "/srv/gt",
+			Name:    "town",
+			RigURL:  "https://example.com/x.git",
+			RigName: "custom",
+		})
+		if got.Name != "town" || got.RigName != "custom" {
+			t.Errorf("pre-filled answers lost: %+v", got)
+		}
+	})
+}
+
+func TestEnsureHooksBase(t *testing.T) {
+	t.Setenv("GT_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	created, err := ensureHooksBase(hooks.DefaultBase())
+	if err != nil {
+		t.Fatalf("ensureHooksBase: %v", err)
+	}
+	if !created {
+		t.Fatal("expected the base config to be created")
+	}
+
+	created, err = ensureHooksBase(hooks.DefaultBase())
+	if err != nil {
+		t.Fatalf("ensureHooksBase (second run): %v", err)
+	}
+	if created {
+		t.Error("existing base config must not be rewritten")
+	}
+}
+
+func TestRunInitRejectsPathWithoutTown(t *testing.T) {
+	oldTown := initTown
+	initTown = false
+	t.Cleanup(func() { initTown = oldTown })
+
+	err := runInit(initCmd, []string{os.TempDir()})
+	if err == nil || !strings.Contains(err.Error(), "--town") {
+		t.Errorf("runInit with a path = %v, want an error pointing at --town", err)
+	}
+}
+
+func TestApplyTownTemplate(t *testing.T) {
+	for _, name := range townTemplateNames() {
+		t.Run(name, func(t *testing.T) {
+			townRoot := t.TempDir()
+			tmpl, err := lookupTownTemplate(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := applyTownTemplate(townRoot, tmpl); err != nil {
+				t.Fatalf("applyTownTemplate: %v", err)
+			}
+			settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, _, err := config.ResolveStartupProfile(settings, ""); err != nil {
+				t.Errorf("default startup profile: %v", err)
+			}
+			if settings.Operational == nil || settings.Operational.Witness == nil {
+				t.Error("template should set witness thresholds")
+			}
+		})
+	}
+
+	ci, _ := lookupTownTemplate("ci")
+	base := templateHooksBase(ci)
+	if len(base.UserPromptSubmit) != 0 || len(base.SessionStart) == 0 {
+		t.Errorf("ci hooks base: UserPromptSubmit=%d SessionStart=%d", len(base.UserPromptSubmit), len(base.SessionStart))
+	}
+	if _, err := lookupTownTemplate("enterprise"); err == nil || !strings.Contains(err.Error(), "solo") {
+		t.Errorf("unknown template error = %v, want list of templates", err)
+	}
+}
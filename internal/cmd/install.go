@@ -274,6 +274,14 @@ func runInstall(cmd *cobra.Command, args []string) error {
 		fmt.Printf("   • mayor/rigs.json already exists, preserving\n")
 	}
 
+	// Record where the town lives so a later rename can be detected and
+	// repaired. Keep an older record on --force so a pending move is not lost.
+	if workspace.PreviousLocation(absPath) == "" {
+		if err := workspace.RecordLocation(absPath); err != nil {
+			fmt.Printf("   %s Could not record town location: %v\n", style.Dim.Render("⚠"), err)
+		}
+	}
+
 	// Create a generic CLAUDE.md at the town root as an identity anchor.
 	// Claude Code sets its CWD to the git root (~/gt/), so mayor/CLAUDE.md is
 	// not loaded directly. This town-root file ensures agents running from within
@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/logging"
+)
+
+// Global logging flags (see root.go). Empty means use GT_LOG_LEVEL /
+// GT_LOG_FORMAT, then the defaults.
+var (
+	logLevelFlag  string
+	logFormatFlag string
+)
+
+// closeLogging closes the log files opened by setupLogging.
+var closeLogging = func() error { return nil }
+
+// setupLogging installs the structured logger for this command, writing
+// to stderr and, inside a town, to the component log files. The chosen
+// level and format are exported so that gt commands run by agents this
+// command starts log the same way.
+func setupLogging(cmd *cobra.Command, townRoot string) error {
+	levelName := logLevelFlag
+	if levelName == "" {
+		levelName = os.Getenv(logging.EnvLevel)
+	}
+	level := logging.DefaultLevel
+	if levelName != "" {
+		var err error
+		if level, err = logging.ParseLevel(levelName); err != nil {
+			return err
+		}
+	}
+	format := logFormatFlag
+	if format == "" {
+		format = os.Getenv(logging.EnvFormat)
+	}
+
+	closeFn, err := logging.Setup(logging.Options{
+		Level:    level,
+		Format:   format,
+		TownRoot: townRoot,
+		Command:  buildCommandPath(cmd),
+	})
+	if err != nil {
+		return err
+	}
+	closeLogging = closeFn
+
+	if logLevelFlag != "" {
+		_ = os.Setenv(logging.EnvLevel, logLevelFlag)
+	}
+	if logFormatFlag != "" {
+		_ = os.Setenv(logging.EnvFormat, logFormatFlag)
+	}
+	return nil
+}
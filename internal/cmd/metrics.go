@@ -30,8 +30,8 @@ var metricsCmd = &cobra.Command{
 	Use:     "metrics",
 	GroupID: GroupDiag,
 	Short:   "Show command usage statistics",
-	Long: `Reads ~/.gt/cmd-usage.jsonl and reports which gt commands are used,
-how often, and by whom. Helps identify dead commands before pruning.`,
+	Long: `Reads cmd-usage.jsonl (in ~/.local/state/gastown) and reports which gt
+commands are used, how often, and by whom. Helps identify dead commands before pruning.`,
 	RunE: runMetrics,
 }
 
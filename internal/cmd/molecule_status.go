@@ -837,17 +837,17 @@ func showGitDivergenceWarning() {
 	ahead, aErr := g.CommitsAhead(remote, "HEAD")
 	behind, bErr := g.CountCommitsBehind(remote)
 
-	// Also check divergence from origin/main as a fallback — polecats
-	// work on feature branches that may not have a remote tracking branch,
-	// but we still want to warn if they're behind main.
+	// Also check divergence from the rig's default branch as a fallback —
+	// polecats work on feature branches that may not have a remote tracking
+	// branch, but we still want to warn if they're behind it.
 	if aErr != nil || bErr != nil {
-		// No tracking branch for current branch; check against origin/main
-		ahead, aErr = g.CommitsAhead("origin/main", "HEAD")
-		behind, bErr = g.CountCommitsBehind("origin/main")
+		// No tracking branch for current branch; check against the default
+		remote = "origin/" + cwdDefaultBranch(g)
+		ahead, aErr = g.CommitsAhead(remote, "HEAD")
+		behind, bErr = g.CountCommitsBehind(remote)
 		if aErr != nil || bErr != nil {
 			return // Can't determine divergence at all — skip silently
 		}
-		remote = "origin/main"
 	}
 
 	if ahead == 0 && behind == 0 {
@@ -15,6 +15,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/vcs"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -128,6 +129,12 @@ func createLandWorktree(rigPath, startBranch string) (*git.Git, func(), error) {
 	}
 	bareGit := git.NewGitWithDir(bareRepoPath, "")
 
+	wl, err := vcs.LockWorktrees(rigPath)
+	if err != nil {
+		_ = fl.Unlock()
+		return nil, noop, err
+	}
+
 	// Clean up any stale worktree from a previous failed run
 	if _, err := os.Stat(landPath); err == nil {
 		_ = bareGit.WorktreeRemove(landPath, true)
@@ -136,12 +143,17 @@ func createLandWorktree(rigPath, startBranch string) (*git.Git, func(), error) {
 
 	// Create worktree checked out to the target branch.
 	// Use --force because the branch may already be checked out in refinery/rig.
-	if err := bareGit.WorktreeAddExistingForce(landPath, startBranch); err != nil {
+	err = bareGit.WorktreeAddExistingForce(landPath, startBranch)
+	_ = wl.Unlock()
+	if err != nil {
 		_ = fl.Unlock()
 		return nil, noop, fmt.Errorf("creating land worktree: %w", err)
 	}
 
 	cleanup := func() {
+		if wl, err := vcs.LockWorktrees(rigPath); err == nil {
+			defer func() { _ = wl.Unlock() }()
+		}
 		_ = bareGit.WorktreeRemove(landPath, true)
 		_ = os.RemoveAll(landPath)
 		_ = fl.Unlock()
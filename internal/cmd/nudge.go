@@ -540,6 +540,9 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 			}
 		}
 
+		// Polecats of a remote-host rig are nudged on that host.
+		t := tmuxForSession(sessionName)
+
 		// For queue/wait-idle modes, verify session exists before enqueuing.
 		// Without this, queue mode silently succeeds for nonexistent sessions —
 		// the file is written but never drained.
@@ -570,6 +573,7 @@ func runNudge(cmd *cobra.Command, args []string) (retErr error) {
 		// Raw session name (legacy)
 		// Check for ACP session - ACP agents don't have tmux sessions but can receive nudges via queue
 		hasACP := hasACPSessionByName(townRoot, target)
+		t := tmuxForSession(target)
 
 		if !hasACP {
 			exists, err := t.HasSession(target)
@@ -649,7 +653,6 @@ func runNudgeChannel(channelName, message, sender string) error {
 	}
 
 	// Send nudges via deliverNudge (respects --mode flag)
-	t := tmux.NewTmux()
 	var succeeded, failed, skipped int
 	var failures []string
 
@@ -667,7 +670,7 @@ func runNudgeChannel(channelName, message, sender string) error {
 			}
 		}
 
-		if err := deliverNudge(t, sessionName, message, sender); err != nil {
+		if err := deliverNudge(tmuxForSession(sessionName), sessionName, message, sender); err != nil {
 			failed++
 			failures = append(failures, fmt.Sprintf("%s: %v", sessionName, err))
 			fmt.Printf("  %s %s\n", style.ErrorPrefix, sessionName)
@@ -1,24 +1,18 @@
 package cmd
 
 import (
-	"os"
-	"path/filepath"
+	"github.com/steveyegge/gastown/internal/state"
 )
 
 // gtDataDir returns the directory used for GT's runtime data files
 // (logs, telemetry, cost records, etc.).
 //
 // Resolution order:
-//  1. $GT_HOME/.gt  — when GT_HOME is set, data is kept alongside the GT
-//     workspace rather than in the user's home directory.
-//  2. ~/.gt         — default location when GT_HOME is not set.
+//  1. $GT_HOME/.gt            — when GT_HOME is set, data is kept alongside the
+//     GT workspace rather than in the user's home directory.
+//  2. ~/.gt                   — installs whose logs predate XDG support and
+//     haven't been moved by 'gt config migrate-xdg'.
+//  3. $XDG_STATE_HOME/gastown — default ~/.local/state/gastown.
 func gtDataDir() string {
-	if h := os.Getenv("GT_HOME"); h != "" {
-		return filepath.Join(h, ".gt")
-	}
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(os.TempDir(), ".gt")
-	}
-	return filepath.Join(home, ".gt")
+	return state.LogDir()
 }
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/estop"
+	"github.com/steveyegge/gastown/internal/scheduler/capacity"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var townPauseReason string
+
+var townPauseCmd = &cobra.Command{
+	Use:     "pause",
+	GroupID: GroupServices,
+	Short:   "Pause the whole town without losing context",
+	Long: `Pause Gas Town: stop dispatching work and suspend every agent session
+in place, for laptop suspend, meetings, or conserving quota.
+
+Pausing:
+  - pauses the scheduler, so no new work is dispatched
+  - pauses Deacon patrols, and the daemon stops restarting agents
+  - freezes every agent session, the Mayor included (SIGTSTP)
+
+Nothing is killed, so every agent keeps its context. 'gt unpause' thaws the
+sessions and undoes exactly what the pause changed: a scheduler or Deacon
+that was already paused stays paused.
+
+Unlike 'gt estop', a pause is a planned stop: it includes the Mayor and
+doesn't nudge agents when it ends, so they carry on mid-task.
+
+Examples:
+  gt pause                       # Pause the town
+  gt pause -r "flight to SFO"    # Pause with a reason
+  gt unpause                     # Pick up where things left off`,
+	Args: cobra.NoArgs,
+	RunE: runTownPause,
+}
+
+var townUnpauseCmd = &cobra.Command{
+	Use:     "unpause",
+	GroupID: GroupServices,
+	Short:   "Unpause a town paused with gt pause",
+	Long: `Undo 'gt pause': thaw the frozen agent sessions and resume dispatch and
+Deacon patrols, if the pause was what paused them.
+
+Agents carry on mid-task; nothing is nudged.
+
+Examples:
+  gt unpause    # Pick up where things left off`,
+	Args: cobra.NoArgs,
+	RunE: runTownUnpause,
+}
+
+func init() {
+	townPauseCmd.Flags().StringVarP(&townPauseReason, "reason", "r", "", "Reason for the pause")
+	rootCmd.AddCommand(townPauseCmd)
+	rootCmd.AddCommand(townUnpauseCmd)
+}
+
+func runTownPause(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	if state := estop.ReadPause(townRoot); state != nil {
+		fmt.Printf("%s Town already paused (since %s)\n", style.Dim.Render("⏸"), state.PausedAt.Local().Format(time.Kitchen))
+		return nil
+	}
+	if estop.IsActive(townRoot) {
+		return fmt.Errorf("E-stop is active; run 'gt thaw' before pausing")
+	}
+
+	state := &estop.PauseState{
+		Reason:   townPauseReason,
+		PausedAt: time.Now().UTC(),
+		PausedBy: detectActor(),
+	}
+
+	// Mark the town paused before freezing anything, so the daemon stops
+	// restarting agents before they go quiet.
+	if err := estop.WritePause(townRoot, state); err != nil {
+		return fmt.Errorf("writing pause file: %w", err)
+	}
+
+	fmt.Printf("%s Pausing Gas Town\n", style.Bold.Render("⏸"))
+	if townPauseReason != "" {
+		fmt.Printf("   Reason: %s\n", townPauseReason)
+	}
+	fmt.Println()
+
+	// Stop dispatch.
+	if sched, err := capacity.LoadState(townRoot); err != nil {
+		style.PrintWarning("could not load scheduler state: %v", err)
+	} else if !sched.Paused {
+		sched.SetPaused("gt pause")
+		if err := capacity.SaveState(townRoot, sched); err != nil {
+			style.PrintWarning("could not pause scheduler: %v", err)
+		} else {
+			state.PausedScheduler = true
+			fmt.Printf("   %s Scheduler paused\n", style.Dim.Render("○"))
+		}
+	}
+
+	// Stop patrols.
+	if paused, _, err := deacon.IsPaused(townRoot); err != nil {
+		style.PrintWarning("could not check Deacon pause state: %v", err)
+	} else if !paused {
+		if err := deacon.Pause(townRoot, "town paused", "gt pause"); err != nil {
+			style.PrintWarning("could not pause Deacon: %v", err)
+		} else {
+			state.PausedDeacon = true
+			fmt.Printf("   %s Deacon patrols paused\n", style.Dim.Render("○"))
+		}
+	}
+
+	// Freeze every agent session.
+	t := tmux.NewTmux()
+	if t.IsAvailable() {
+		for _, sess := range collectGTSessions(t, townRoot) {
+			if err := signalSessionGroup(t, sess, sigFreeze); err != nil {
+				fmt.Printf("   %s %s: %v\n", style.Warning.Render("!"), sess, err)
+				continue
+			}
+			fmt.Printf("   %s %s\n", style.Dim.Render("⏸"), sess)
+			state.Sessions = append(state.Sessions, sess)
+		}
+	}
+
+	if err := estop.WritePause(townRoot, state); err != nil {
+		return fmt.Errorf("writing pause file: %w", err)
+	}
+
+	fmt.Println()
+	fmt.Printf("%s Town paused (%d session(s) frozen)\n", style.Bold.Render("⏸"), len(state.Sessions))
+	fmt.Printf("   Resume with: %s\n", style.Bold.Render("gt unpause"))
+	return nil
+}
+
+// runTownUnpause undoes a gt pause: thaws the sessions it froze and resumes
+// the Deacon and scheduler if the pause paused them.
+func runTownUnpause(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	if !estop.IsPaused(townRoot) {
+		fmt.Printf("%s Town is not paused\n", style.Dim.Render("▶"))
+		return nil
+	}
+	state := estop.ReadPause(townRoot)
+	if state == nil {
+		// Unreadable pause file: nothing to undo, but still clear it.
+		state = &estop.PauseState{}
+	}
+
+	t := tmux.NewTmux()
+	thawed := 0
+	if t.IsAvailable() {
+		for _, sess := range state.Sessions {
+			if ok, _ := t.HasSession(sess); !ok {
+				continue
+			}
+			if err := signalSessionGroup(t, sess, sigThaw); err != nil {
+				fmt.Printf("   %s %s: %v\n", style.Warning.Render("!"), sess, err)
+				continue
+			}
+			thawed++
+		}
+	}
+
+	if state.PausedDeacon {
+		if err := deacon.Resume(townRoot); err != nil {
+			style.PrintWarning("could not resume Deacon: %v", err)
+		}
+	}
+	if state.PausedScheduler {
+		if sched, err := capacity.LoadState(townRoot); err != nil {
+			style.PrintWarning("could not load scheduler state: %v", err)
+		} else if sched.Paused && sched.PausedBy == "gt pause" {
+			sched.SetResumed()
+			if err := capacity.SaveState(townRoot, sched); err != nil {
+				style.PrintWarning("could not resume scheduler: %v", err)
+			}
+		}
+	}
+
+	// Clear the pause last, so the daemon keeps its hands off until every
+	// session is running again.
+	if err := estop.ClearPause(townRoot); err != nil {
+		return fmt.Errorf("removing pause file: %w", err)
+	}
+
+	fmt.Printf("%s Town unpaused (%d session(s) thawed)\n", style.Success.Render("▶"), thawed)
+	if !state.PausedAt.IsZero() {
+		fmt.Printf("   Paused for %s\n", time.Since(state.PausedAt).Round(time.Second))
+	}
+	return nil
+}
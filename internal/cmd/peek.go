@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
@@ -108,7 +109,8 @@ func runPeek(cmd *cobra.Command, args []string) error {
 	if strings.HasPrefix(polecatName, "crew/") {
 		crewName := strings.TrimPrefix(polecatName, "crew/")
 		sessionID := session.CrewSessionName(session.PrefixFor(rigName), crewName)
-		output, err = mgr.CaptureSession(sessionID, lines)
+		// Crew run on the local server even when the rig's polecats don't.
+		output, err = captureSession(tmuxForSession(sessionID), sessionID, lines)
 	} else {
 		output, err = mgr.Capture(polecatName, lines)
 	}
@@ -120,3 +122,15 @@ func runPeek(cmd *cobra.Command, args []string) error {
 	fmt.Print(output)
 	return nil
 }
+
+// captureSession captures a session's pane on t's server.
+func captureSession(t *tmux.Tmux, sessionID string, lines int) (string, error) {
+	running, err := t.HasSession(sessionID)
+	if err != nil {
+		return "", fmt.Errorf("checking session: %w", err)
+	}
+	if !running {
+		return "", polecat.ErrSessionNotFound
+	}
+	return t.CapturePane(sessionID, lines)
+}
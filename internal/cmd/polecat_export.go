@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	polecatExportFormat string
+	polecatExportOutput string
+)
+
+var polecatExportCmd = &cobra.Command{
+	Use:   "export <rig>/<polecat>",
+	Short: "Export a polecat's work as patches or a bundle",
+	Long: `Export a polecat's work so it can be reviewed or moved out of the town
+without pushing to the shared remote.
+
+Exports the commits on the polecat's branch since origin/<default-branch>,
+plus its uncommitted changes (untracked files included) as a separate diff.
+The worktree is not modified.
+
+Formats:
+  patch   A format-patch series (apply with 'git am') in a directory,
+          plus uncommitted.diff (apply with 'git apply').
+          Default output: ./<rig>-<polecat>-patches/
+  bundle  A git bundle of the branch (fetch from it with 'git fetch
+          <file> <branch>'; the receiving repo needs the base commit),
+          plus <file>.uncommitted.diff.
+          Default output: ./<rig>-<polecat>.bundle
+
+Examples:
+  gt polecat export greenplace/Toast
+  gt polecat export greenplace/Toast --format bundle -o /tmp/toast.bundle`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runPolecatExport,
+}
+
+func init() {
+	polecatExportCmd.Flags().StringVar(&polecatExportFormat, "format", polecat.ExportPatch, "Export format: patch or bundle")
+	polecatExportCmd.Flags().StringVarP(&polecatExportOutput, "output", "o", "", "Output directory (patch) or file (bundle)")
+	polecatCmd.AddCommand(polecatExportCmd)
+}
+
+func runPolecatExport(cmd *cobra.Command, args []string) error {
+	rigName, polecatName, err := parseAddress(args[0])
+	if err != nil {
+		return err
+	}
+	mgr, _, err := getPolecatManager(rigName)
+	if err != nil {
+		return err
+	}
+
+	out := polecatExportOutput
+	if out == "" {
+		switch polecatExportFormat {
+		case polecat.ExportBundle:
+			out = fmt.Sprintf("%s-%s.bundle", rigName, polecatName)
+		default:
+			out = fmt.Sprintf("%s-%s-patches", rigName, polecatName)
+		}
+	}
+
+	res, err := mgr.Export(polecatName, polecatExportFormat, out)
+	if err != nil {
+		if errors.Is(err, polecat.ErrPolecatNotFound) {
+			return fmt.Errorf("polecat '%s' not found in rig '%s'", polecatName, rigName)
+		}
+		return err
+	}
+
+	if len(res.Files) == 0 && res.Uncommitted == "" {
+		fmt.Printf("%s %s/%s has no work since %s; nothing exported\n", style.Dim.Render("•"), rigName, polecatName, res.Base)
+		return nil
+	}
+
+	fmt.Printf("%s Exported %s/%s (%s, %d commit(s) since %s)\n", style.Success.Render("✓"), rigName, polecatName, res.Branch, res.Commits, res.Base)
+	switch polecatExportFormat {
+	case polecat.ExportBundle:
+		for _, f := range res.Files {
+			fmt.Printf("  Bundle: %s\n", f)
+		}
+	default:
+		if len(res.Files) > 0 {
+			fmt.Printf("  Patches: %d in %s\n", len(res.Files), out)
+		}
+	}
+	if res.Uncommitted != "" {
+		fmt.Printf("  Uncommitted changes: %s\n", res.Uncommitted)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var polecatRebaseAll bool
+
+var polecatRebaseCmd = &cobra.Command{
+	Use:   "rebase <rig>/<polecat>... | <rig> --all",
+	Short: "Rebase polecat branches onto the rig's default branch",
+	Long: `Rebase polecat branches onto the rig's default branch so long-lived
+branches don't rot.
+
+Fetches origin, then rebases each polecat's branch onto
+origin/<default-branch>. Trivial stops are continued automatically:
+conflicts git rerere has a recorded resolution for, and commits that
+became empty because their change already landed upstream.
+
+On any other conflict the rebase is aborted (the branch is left as it
+was), and the polecat is marked stuck with a conflict report listing the
+files, for the polecat or an operator to resolve by hand.
+
+Polecats with uncommitted changes to tracked files are skipped.
+
+Examples:
+  gt polecat rebase greenplace/Toast
+  gt polecat rebase greenplace --all`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runPolecatRebase,
+}
+
+func init() {
+	polecatRebaseCmd.Flags().BoolVar(&polecatRebaseAll, "all", false, "Rebase all polecats in the rig")
+	polecatCmd.AddCommand(polecatRebaseCmd)
+}
+
+func runPolecatRebase(cmd *cobra.Command, args []string) error {
+	targets, err := resolvePolecatTargets(args, polecatRebaseAll)
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Println("No polecats to rebase.")
+		return nil
+	}
+
+	var failures []string
+	stuck := 0
+	for _, p := range targets {
+		addr := p.rigName + "/" + p.polecatName
+		res, err := p.mgr.Rebase(p.polecatName)
+		if err != nil {
+			if errors.Is(err, polecat.ErrHasChanges) {
+				err = errors.New("has uncommitted changes")
+			}
+			failures = append(failures, fmt.Sprintf("%s: %v", addr, err))
+			continue
+		}
+
+		switch {
+		case len(res.Conflicts) > 0:
+			stuck++
+			report := fmt.Sprintf("rebase of %s onto %s conflicts in: %s", res.Branch, res.Onto, strings.Join(res.Conflicts, ", "))
+			markPolecatRebaseStuck(p, report)
+			fmt.Printf("%s %s: %s\n", style.Warning.Render("⚠"), addr, report)
+			fmt.Printf("  %s\n", style.Dim.Render("Branch left unchanged; polecat marked stuck"))
+		case !res.Rebased:
+			fmt.Printf("%s %s: already on %s\n", style.Dim.Render("•"), addr, res.Onto)
+		case res.AutoResolved > 0:
+			fmt.Printf("%s %s: rebased onto %s (%d trivial stop(s) auto-continued)\n", style.Success.Render("✓"), addr, res.Onto, res.AutoResolved)
+		default:
+			fmt.Printf("%s %s: rebased onto %s\n", style.Success.Render("✓"), addr, res.Onto)
+		}
+	}
+
+	if len(failures) > 0 {
+		fmt.Printf("\n%s Some rebases failed:\n", style.Warning.Render("Warning:"))
+		for _, f := range failures {
+			fmt.Printf("  - %s\n", f)
+		}
+	}
+	if len(failures) > 0 || stuck > 0 {
+		return fmt.Errorf("%d rebase(s) failed, %d conflicted", len(failures), stuck)
+	}
+	return nil
+}
+
+// markPolecatRebaseStuck records a rebase conflict on the polecat: its agent
+// bead goes to stuck, and its heartbeat carries the report so the Witness
+// escalates with the conflicting files.
+func markPolecatRebaseStuck(p polecatTarget, report string) {
+	if err := p.mgr.SetAgentState(p.polecatName, string(beads.AgentStateStuck)); err != nil {
+		style.PrintWarning("could not mark %s/%s stuck: %v", p.rigName, p.polecatName, err)
+	}
+	sessionName := session.PolecatSessionName(session.PrefixFor(p.rigName), p.polecatName)
+	polecat.TouchSessionHeartbeatWithState(filepath.Dir(p.r.Path), sessionName, polecat.HeartbeatStuck, report, "")
+}
@@ -352,7 +352,7 @@ func (s *SpawnedPolecatInfo) StartSession() (string, error) {
 	}
 
 	// Start session
-	t := tmux.NewTmux()
+	t := polecat.TmuxForRig(tmux.NewTmux(), r)
 	polecatSessMgr := polecat.NewSessionManager(t, r)
 
 	fmt.Printf("Starting session for %s/%s...\n", s.RigName, s.PolecatName)
@@ -3,7 +3,6 @@ package cmd
 import (
 	"bytes"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -91,7 +90,45 @@ HOOK MODE (--hook):
   Gemini CLI / other runtimes (in .gemini/settings.json):
     "SessionStart": "export GT_SESSION_ID=$(uuidgen) GT_HOOK_SOURCE=startup && gt prime --hook"
     "PreCompress":  "export GT_HOOK_SOURCE=compact && gt prime --hook"
-    Set GT_SESSION_ID + GT_HOOK_SOURCE as env vars to skip the stdin read entirely.`,
+    Set GT_SESSION_ID + GT_HOOK_SOURCE as env vars to skip the stdin read entirely.
+
+CONTEXT BUDGET (--budget):
+  Prime estimates the size of the context it injects (about 4 bytes per
+  token) and keeps it under a budget: --budget, else GT_PRIME_BUDGET, else
+  20000 tokens. Role context, hooked work, and startup directives are never
+  trimmed. Over budget, other sections are cut in this order: bd prime
+  output, memories, molecule progress, checkpoint, then mail and escalations.
+  A trimmed section ends with a note naming the command that shows it in
+  full. --explain reports the per-section breakdown.
+
+HOOK CACHE (--no-cache):
+  The hook lookup is cached in .runtime/prime_cache.json for 30s
+  (GT_PRIME_CACHE_TTL, a duration; "0" disables), so rapid restart loops
+  don't repeat its bead queries. The cache is keyed on the agent, its
+  handoff marker and checkpoint, and the bead databases' modification
+  times, so hooking new work invalidates it. --no-cache bypasses it.
+
+CRASH RECOVERY (--auto-resume):
+  When a polecat or crew session finds a recent checkpoint from a session
+  that died, prime normally just shows it. With --auto-resume (or
+  GT_AUTO_RESUME=1 in the agent's environment), prime also checks out the
+  checkpoint's branch (if the worktree is clean), puts the hooked bead back
+  on the agent's hook with the current step in progress, and prints a
+  resume briefing. Beads that are closed or reassigned are left alone.
+
+QUIET MODE (--quiet, -q):
+  Prints only an essential context block, one "gt:" line each: who the
+  agent is, its hooked work, and any state it must handle first (an
+  unfinished merge or rebase, a completed handoff, a crash checkpoint).
+  No banners, role context, or mail, and the handoff marker is left for the
+  next full prime. For hooks that fire on every prompt:
+    "UserPromptSubmit": [{"hooks": [{"type": "command", "command": "gt prime --quiet"}]}]
+
+JSON OUTPUT (--json):
+  Runs prime as usual but prints one JSON object instead of the context:
+  the detected session state and why, the role context, the agent bead ID,
+  and the injected context (its section headings, size, and full text).
+  With --state, only the session state is printed.`,
 	RunE: runPrime,
 }
 
@@ -101,11 +138,19 @@ func init() {
 	primeCmd.Flags().BoolVar(&primeDryRun, "dry-run", false,
 		"Show what would be injected without side effects (no marker removal, no bd prime, no mail)")
 	primeCmd.Flags().BoolVar(&primeState, "state", false,
-		"Show detected session state only (normal/post-handoff/crash/autonomous)")
+		"Show detected session state only (normal/git-in-progress/post-handoff/crash/autonomous)")
 	primeCmd.Flags().BoolVar(&primeStateJSON, "json", false,
-		"Output state as JSON (requires --state)")
+		"Output as JSON: state, role context, agent bead, and injected context (with --state, state only)")
 	primeCmd.Flags().BoolVar(&primeExplain, "explain", false,
 		"Show why each section was included")
+	primeCmd.Flags().IntVar(&primeBudget, "budget", -1,
+		"Approximate token budget for injected context; 0 disables trimming (default: GT_PRIME_BUDGET or 20000)")
+	primeCmd.Flags().BoolVar(&primeNoCache, "no-cache", false,
+		"Always query beads for hooked work instead of reusing a recent result (or set GT_PRIME_CACHE_TTL=0)")
+	primeCmd.Flags().BoolVarP(&primeQuiet, "quiet", "q", false,
+		"Print only the essential context block (identity, hook, blocking state), for per-prompt hooks")
+	primeCmd.Flags().BoolVar(&primeAutoResume, "auto-resume", false,
+		"On crash recovery, restore the checkpoint's branch and hooked beads (or set GT_AUTO_RESUME=1)")
 	rootCmd.AddCommand(primeCmd)
 }
 
@@ -118,7 +163,14 @@ func runPrime(cmd *cobra.Command, args []string) (retErr error) {
 	if err := validatePrimeFlags(); err != nil {
 		return err
 	}
+	if primeStateJSON && !primeState {
+		return runPrimeJSON()
+	}
+	return runPrimeFlow()
+}
 
+// runPrimeFlow detects the role and outputs its context.
+func runPrimeFlow() error {
 	cwd, townRoot, err := resolvePrimeWorkspace()
 	if err != nil {
 		return err
@@ -131,6 +183,11 @@ func runPrime(cmd *cobra.Command, args []string) (retErr error) {
 		handlePrimeHookMode(townRoot, cwd)
 	}
 
+	// --quiet: essential context only, without consuming the handoff marker
+	if primeQuiet {
+		return runPrimeQuiet(cwd, townRoot)
+	}
+
 	// Check for handoff marker (prevents handoff loop bug)
 	if primeDryRun {
 		checkHandoffMarkerDryRun(cwd)
@@ -177,7 +234,15 @@ func runPrime(cmd *cobra.Command, args []string) (retErr error) {
 	// injectWorkContext sets GT_WORK_RIG/BEAD/MOL in the current process env and
 	// in the tmux session env so all subsequent subprocesses (bd, mail, …) carry
 	// the correct work attribution until the next gt prime overwrites it.
-	hookedBead, hookErr := findAgentWork(ctx)
+	// Crash recovery (opt-in): restore the checkpoint's branch and hook
+	// before looking for work, so findAgentWork sees the re-hooked bead.
+	var resumed *resumeResult
+	if autoResumeEnabled() && !primeDryRun {
+		resumed = autoResumeFromCheckpoint(ctx)
+		explain(resumed != nil, "Auto-resume: restored working context from crash checkpoint")
+	}
+
+	hookedBead, hookErr := findAgentWorkCached(ctx)
 	if hookErr != nil {
 		// Database error during hook query — NOT the same as "no work assigned".
 		// Emit a loud warning so the agent does NOT run gt done / close the bead.
@@ -190,8 +255,15 @@ func runPrime(cmd *cobra.Command, args []string) (retErr error) {
 	}
 	injectWorkContext(ctx, hookedBead)
 
-	formula, err := outputRoleContext(ctx)
+	// From here on, output is captured per section and printed at the end,
+	// trimmed to the context budget. Role context and directives are never
+	// trimmed; supplementary sections are, most expendable first.
+	budget := &primeBudgeter{}
+
+	var formula string
+	budget.capture("role", 0, "", func() { formula, err = outputRoleContext(ctx) })
 	if err != nil {
+		budget.flush(0)
 		return err
 	}
 	// Log the rendered formula to OTEL so it's visible in VictoriaLogs alongside
@@ -199,22 +271,36 @@ func runPrime(cmd *cobra.Command, args []string) (retErr error) {
 	// started with. Only emitted when GT telemetry is active (GT_OTEL_LOGS_URL set).
 	telemetry.RecordPrimeContext(context.Background(), formula, os.Getenv("GT_ROLE"), primeHookMode)
 
-	hasSlungWork := checkSlungWork(ctx, hookedBead)
-	explain(hasSlungWork, "Autonomous mode: hooked/in-progress work detected")
+	var hasSlungWork bool
+	budget.capture("work", 0, "", func() {
+		hasSlungWork = checkSlungWork(ctx, hookedBead)
+		explain(hasSlungWork, "Autonomous mode: hooked/in-progress work detected")
+	})
 
-	outputMoleculeContext(ctx)
-	outputCheckpointContext(ctx)
-	runPrimeExternalTools(cwd)
+	budget.capture("git", 0, "", func() { outputGitInProgressContext(ctx) })
+	budget.capture("molecule", 3, "gt mol status", func() { outputMoleculeContext(ctx) })
+	budget.capture("checkpoint", 2, "gt checkpoint read", func() {
+		if resumed != nil {
+			explainCheckpointAge(ctx, resumed.Checkpoint)
+			outputResumeBriefing(resumed)
+		} else {
+			outputCheckpointContext(ctx)
+		}
+	})
+	runPrimeExternalTools(budget, cwd)
 
 	if ctx.Role == RoleMayor {
-		checkPendingEscalations(ctx)
+		budget.capture("escalations", 1, "gt escalate list", func() { checkPendingEscalations(ctx) })
 	}
 
 	if !hasSlungWork {
-		explain(true, "Startup directive: normal mode (no hooked work)")
-		outputStartupDirective(ctx)
+		budget.capture("directive", 0, "", func() {
+			explain(true, "Startup directive: normal mode (no hooked work)")
+			outputStartupDirective(ctx)
+		})
 	}
 
+	budget.flush(resolvePrimeBudget())
 	return nil
 }
 
@@ -254,11 +340,11 @@ func runPrimeCompactResume(ctx RoleContext) {
 
 // validatePrimeFlags checks that CLI flag combinations are valid.
 func validatePrimeFlags() error {
-	if primeState && (primeHookMode || primeDryRun || primeExplain) {
+	if primeState && (primeHookMode || primeDryRun || primeExplain || primeQuiet) {
 		return fmt.Errorf("--state cannot be combined with other flags (except --json)")
 	}
-	if primeStateJSON && !primeState {
-		return fmt.Errorf("--json requires --state")
+	if primeQuiet && (primeStateJSON || primeExplain || primeAutoResume) {
+		return fmt.Errorf("--quiet cannot be combined with --json, --explain, or --auto-resume")
 	}
 	return nil
 }
@@ -321,7 +407,7 @@ func handlePrimeHookMode(townRoot, cwd string) {
 // tries to auto-detect JSON, sees the leading '[', and misclassifies the startup
 // stream as JSON instead of plain text metadata.
 func hookSessionBeaconLines(sessionID, source string) []string {
-	if primeStructuredSessionStartOutput {
+	if primeStructuredSessionStartOutput || primeQuiet {
 		return nil
 	}
 	lines := []string{fmt.Sprintf("[session:%s]", sessionID)}
@@ -489,16 +575,18 @@ func outputRoleContext(ctx RoleContext) (string, error) {
 
 // runPrimeExternalTools runs bd prime, memory injection, and gt mail check --inject.
 // Skipped in dry-run mode with explain output.
-func runPrimeExternalTools(cwd string) {
+func runPrimeExternalTools(budget *primeBudgeter, cwd string) {
 	if primeDryRun {
-		explain(true, "bd prime: skipped in dry-run mode")
-		explain(true, "memory injection: skipped in dry-run mode")
-		explain(true, "gt mail check --inject: skipped in dry-run mode")
+		budget.capture("tools", 0, "", func() {
+			explain(true, "bd prime: skipped in dry-run mode")
+			explain(true, "memory injection: skipped in dry-run mode")
+			explain(true, "gt mail check --inject: skipped in dry-run mode")
+		})
 		return
 	}
-	runBdPrime(cwd)
-	runMemoryInject()
-	runMailCheckInject(cwd)
+	budget.capture("beads", 5, "bd prime", func() { runBdPrime(cwd) })
+	budget.capture("memories", 4, "gt memories", runMemoryInject)
+	budget.capture("mail", 1, "gt mail inbox", func() { runMailCheckInject(cwd) })
 }
 
 // runBdPrime runs `bd prime` and outputs the result.
@@ -708,7 +796,8 @@ func findAgentWorkOnce(ctx RoleContext, agentID string) (*beads.Issue, error) {
 	// rely on .beads/redirect which can fail to resolve in edge cases, causing
 	// polecats to miss hooked work and exit immediately. The rig root directory
 	// always has the authoritative .beads/ database. (GH#2503)
-	b := beads.New(rigBeadsRoot(ctx))
+	b, closeBeads := beads.OpenClient(rigBeadsRoot(ctx))
+	defer closeBeads()
 
 	// Agent bead's hook_bead field. NOTE: updateAgentHookBead was made a no-op
 	// (see sling_helpers.go), so HookBead is typically empty. Kept for backward
@@ -1205,34 +1294,19 @@ func setTmuxWorkContext(workRig, workBead, workMol string) {
 // checkPendingEscalations queries for open escalation beads and displays them prominently.
 // This is called on Mayor startup to surface issues needing human attention.
 func checkPendingEscalations(ctx RoleContext) {
-	// Query for open escalations using bd list with tag filter
-	cmd := exec.Command("bd", "list", "--status=open", "--tag=escalation", "--json")
-	cmd.Dir = ctx.WorkDir
-	cmd.Env = os.Environ()
+	client, cleanup := beads.OpenClient(ctx.WorkDir)
+	defer cleanup()
 
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-
-	if err := cmd.Run(); err != nil {
+	escalations, err := client.List(beads.ListOptions{
+		Status:   "open",
+		Label:    "gt:escalation",
+		Priority: -1,
+	})
+	if err != nil || len(escalations) == 0 {
 		// Silently skip - escalation check is best-effort
 		return
 	}
 
-	// Parse JSON output
-	var escalations []struct {
-		ID          string `json:"id"`
-		Title       string `json:"title"`
-		Priority    int    `json:"priority"`
-		Description string `json:"description"`
-		Created     string `json:"created"`
-	}
-
-	if err := json.Unmarshal(stdout.Bytes(), &escalations); err != nil || len(escalations) == 0 {
-		// No escalations or parse error
-		return
-	}
-
 	// Count by severity
 	critical := 0
 	high := 0
@@ -1285,7 +1359,7 @@ func checkPendingEscalations(ctx RoleContext) {
 	}
 	fmt.Println()
 
-	fmt.Println("**Action required:** Review escalations with `bd list --tag=escalation`")
+	fmt.Println("**Action required:** Review escalations with `bd list --label=gt:escalation`")
 	fmt.Println("Close resolved ones with `bd close <id> --reason \"resolution\"`")
 	fmt.Println()
 }
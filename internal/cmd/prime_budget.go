@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// defaultPrimeBudget is the default cap, in estimated tokens, on the context
+// prime injects. Role context is never trimmed; supplementary sections are.
+const defaultPrimeBudget = 20000
+
+// primeBudget is the --budget flag. -1 means GT_PRIME_BUDGET or the default;
+// 0 disables trimming.
+var primeBudget = -1
+
+// primeSection is one block of prime output, captured so its size can be
+// counted against the budget before it is printed.
+type primeSection struct {
+	Name string
+	Text string
+
+	// TrimRank orders trimming: 0 is never trimmed, otherwise higher ranks
+	// are trimmed first.
+	TrimRank int
+	// Hint is the command that shows the section in full.
+	Hint string
+
+	// Original is the estimated size before trimming.
+	Original int
+}
+
+// Tokens estimates the section's current size.
+func (s *primeSection) Tokens() int {
+	return estimateTokens(s.Text)
+}
+
+// primeBudgeter collects prime's output sections in order.
+type primeBudgeter struct {
+	sections []*primeSection
+}
+
+// capture runs fn and records what it printed as a section.
+func (b *primeBudgeter) capture(name string, trimRank int, hint string, fn func()) {
+	out, err := capturePrimeOutput(func() error { fn(); return nil })
+	if err != nil {
+		// Couldn't redirect stdout; fn never ran. Run it uncaptured so the
+		// context is still delivered, just outside the budget.
+		fn()
+		return
+	}
+	b.sections = append(b.sections, &primeSection{Name: name, Text: out, TrimRank: trimRank, Hint: hint, Original: estimateTokens(out)})
+}
+
+// flush trims the sections to budget, prints them in order, and with
+// --explain reports the breakdown.
+func (b *primeBudgeter) flush(budget int) {
+	applyPrimeBudget(b.sections, budget)
+	for _, s := range b.sections {
+		fmt.Print(s.Text)
+	}
+	if primeExplain {
+		fmt.Print(formatPrimeBudget(b.sections, budget))
+	}
+}
+
+// resolvePrimeBudget returns the token budget: --budget, else
+// GT_PRIME_BUDGET, else the default. 0 means unlimited.
+func resolvePrimeBudget() int {
+	if primeBudget >= 0 {
+		return primeBudget
+	}
+	if v := os.Getenv("GT_PRIME_BUDGET"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n >= 0 {
+			return n
+		}
+	}
+	return defaultPrimeBudget
+}
+
+// estimateTokens approximates the token count of text at ~4 bytes per token.
+func estimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// applyPrimeBudget trims sections, highest TrimRank first, until their
+// estimated total fits budget. Each trimmed section keeps as many leading
+// lines as fit and ends with a note pointing at its Hint command.
+func applyPrimeBudget(sections []*primeSection, budget int) {
+	if budget <= 0 {
+		return
+	}
+	total := 0
+	maxRank := 0
+	for _, s := range sections {
+		total += s.Tokens()
+		maxRank = max(maxRank, s.TrimRank)
+	}
+	for rank := maxRank; rank > 0 && total > budget; rank-- {
+		for _, s := range sections {
+			if s.TrimRank != rank || total <= budget {
+				continue
+			}
+			before := s.Tokens()
+			s.Text = trimPrimeSection(s, before-(total-budget))
+			total -= before - s.Tokens()
+		}
+	}
+}
+
+// trimPrimeSection cuts s down to about keep tokens of whole lines plus a
+// note saying what was left out.
+func trimPrimeSection(s *primeSection, keep int) string {
+	lines := strings.SplitAfter(s.Text, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	note := func(dropped int) string {
+		msg := fmt.Sprintf("\n> [%s: %d lines trimmed to fit the prime context budget", s.Name, dropped)
+		if s.Hint != "" {
+			msg += fmt.Sprintf("; run `%s` for the full output", s.Hint)
+		}
+		return msg + "]\n"
+	}
+
+	var kept strings.Builder
+	for i, line := range lines {
+		if estimateTokens(kept.String()+line+note(len(lines)-i-1)) > keep {
+			return kept.String() + note(len(lines)-i)
+		}
+		kept.WriteString(line)
+	}
+	return s.Text
+}
+
+// formatPrimeBudget renders the --explain breakdown of section sizes.
+func formatPrimeBudget(sections []*primeSection, budget int) string {
+	var b strings.Builder
+	total := 0
+	for _, s := range sections {
+		total += s.Tokens()
+	}
+	limit := "unlimited"
+	if budget > 0 {
+		limit = fmt.Sprintf("%d", budget)
+	}
+	fmt.Fprintf(&b, "\n[EXPLAIN] Context budget: ~%d tokens injected (budget %s)\n", total, limit)
+	for _, s := range sections {
+		if s.Original == 0 {
+			continue
+		}
+		line := fmt.Sprintf("  %-12s ~%d", s.Name, s.Tokens())
+		if s.Tokens() < s.Original {
+			line += fmt.Sprintf(" (trimmed from ~%d)", s.Original)
+		}
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
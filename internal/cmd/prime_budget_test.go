@@ -0,0 +1,111 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func budgetTestSection(name string, rank, lines int) *primeSection {
+	text := strings.Repeat(name+" line of prime context output\n", lines)
+	return &primeSection{Name: name, Text: text, TrimRank: rank, Hint: "gt " + name, Original: estimateTokens(text)}
+}
+
+func TestApplyPrimeBudget(t *testing.T) {
+	t.Run("under_budget_untouched", func(t *testing.T) {
+		role := budgetTestSection("role", 0, 10)
+		beads := budgetTestSection("beads", 5, 10)
+		applyPrimeBudget([]*primeSection{role, beads}, 10000)
+		if role.Tokens() != role.Original || beads.Tokens() != beads.Original {
+			t.Error("sections under budget should not be trimmed")
+		}
+	})
+
+	t.Run("trims_highest_rank_first", func(t *testing.T) {
+		role := budgetTestSection("role", 0, 40)
+		mail := budgetTestSection("mail", 1, 20)
+		beads := budgetTestSection("beads", 5, 100)
+		sections := []*primeSection{role, mail, beads}
+		budget := role.Tokens() + mail.Tokens() + 100
+
+		applyPrimeBudget(sections, budget)
+
+		if role.Tokens() != role.Original {
+			t.Error("role context must never be trimmed")
+		}
+		if mail.Tokens() != mail.Original {
+			t.Error("mail should be kept while trimming beads is enough")
+		}
+		if beads.Tokens() >= beads.Original {
+			t.Fatal("beads should be trimmed")
+		}
+		total := role.Tokens() + mail.Tokens() + beads.Tokens()
+		if total > budget {
+			t.Errorf("total %d exceeds budget %d", total, budget)
+		}
+		if !strings.Contains(beads.Text, "lines trimmed to fit the prime context budget; run `gt beads`") {
+			t.Errorf("trimmed section should end with a note, got:\n%s", beads.Text)
+		}
+		if !strings.HasPrefix(beads.Text, "beads line") {
+			t.Errorf("trimmed section should keep its leading lines, got:\n%s", beads.Text)
+		}
+	})
+
+	t.Run("falls_through_to_lower_ranks", func(t *testing.T) {
+		role := budgetTestSection("role", 0, 40)
+		mail := budgetTestSection("mail", 1, 40)
+		beads := budgetTestSection("beads", 5, 40)
+		applyPrimeBudget([]*primeSection{role, mail, beads}, role.Tokens()+60)
+
+		if role.Tokens() != role.Original {
+			t.Error("role context must never be trimmed")
+		}
+		if mail.Tokens() >= mail.Original || beads.Tokens() >= beads.Original {
+			t.Error("both trimmable sections should be trimmed")
+		}
+	})
+
+	t.Run("zero_budget_disables", func(t *testing.T) {
+		beads := budgetTestSection("beads", 5, 100)
+		applyPrimeBudget([]*primeSection{beads}, 0)
+		if beads.Tokens() != beads.Original {
+			t.Error("budget 0 should disable trimming")
+		}
+	})
+}
+
+func TestResolvePrimeBudget(t *testing.T) {
+	old := primeBudget
+	t.Cleanup(func() { primeBudget = old })
+
+	primeBudget = -1
+	t.Setenv("GT_PRIME_BUDGET", "")
+	if got := resolvePrimeBudget(); got != defaultPrimeBudget {
+		t.Errorf("default = %d, want %d", got, defaultPrimeBudget)
+	}
+	t.Setenv("GT_PRIME_BUDGET", "5000")
+	if got := resolvePrimeBudget(); got != 5000 {
+		t.Errorf("env = %d, want 5000", got)
+	}
+	primeBudget = 0
+	if got := resolvePrimeBudget(); got != 0 {
+		t.Errorf("flag = %d, want 0", got)
+	}
+}
+
+func TestFormatPrimeBudget(t *testing.T) {
+	role := budgetTestSection("role", 0, 10)
+	beads := budgetTestSection("beads", 5, 100)
+	empty := &primeSection{Name: "mail", TrimRank: 1}
+	applyPrimeBudget([]*primeSection{role, beads, empty}, role.Tokens()+50)
+
+	out := formatPrimeBudget([]*primeSection{role, beads, empty}, role.Tokens()+50)
+	if !strings.Contains(out, "[EXPLAIN] Context budget:") {
+		t.Errorf("missing header:\n%s", out)
+	}
+	if !strings.Contains(out, "trimmed from") {
+		t.Errorf("expected trimmed section in breakdown:\n%s", out)
+	}
+	if strings.Contains(out, "mail") {
+		t.Errorf("empty sections should be omitted:\n%s", out)
+	}
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/doltserver"
+)
+
+// primeCacheFile holds the last hook lookup, in the worktree's runtime dir.
+const primeCacheFile = "prime_cache.json"
+
+// defaultPrimeCacheTTL bounds how long a hook lookup is reused. It only needs
+// to cover rapid restart loops.
+const defaultPrimeCacheTTL = 30 * time.Second
+
+// primeNoCache is the --no-cache flag.
+var primeNoCache bool
+
+// primeCacheEntry is a cached findAgentWork result.
+type primeCacheEntry struct {
+	Key        string       `json:"key"`
+	CreatedAt  time.Time    `json:"created_at"`
+	HookedBead *beads.Issue `json:"hooked_bead"`
+}
+
+// primeCacheTTL returns how long cached results are valid: GT_PRIME_CACHE_TTL
+// (a duration, "0" to disable), else the default.
+func primeCacheTTL() time.Duration {
+	if primeNoCache {
+		return 0
+	}
+	if v := os.Getenv("GT_PRIME_CACHE_TTL"); v != "" {
+		if v == "0" {
+			return 0
+		}
+		if d, err := time.ParseDuration(v); err == nil && d >= 0 {
+			return d
+		}
+	}
+	return defaultPrimeCacheTTL
+}
+
+// findAgentWorkCached wraps findAgentWork with a short-lived cache so restart
+// loops don't repeat its bd queries (and, when nothing is hooked, its ~15s of
+// retries). Lookup errors are never cached.
+func findAgentWorkCached(ctx RoleContext) (*beads.Issue, error) {
+	ttl := primeCacheTTL()
+	if ttl <= 0 {
+		return findAgentWork(ctx)
+	}
+	key := primeCacheKey(ctx)
+	if issue, ok := readPrimeCache(ctx.WorkDir, key, ttl, time.Now()); ok {
+		explain(true, "Hook lookup: reused cached result (unchanged worktree and beads within "+ttl.String()+")")
+		return issue, nil
+	}
+
+	issue, err := findAgentWork(ctx)
+	if err == nil && !primeDryRun {
+		writePrimeCache(ctx.WorkDir, primeCacheEntry{Key: key, CreatedAt: time.Now(), HookedBead: issue})
+	}
+	return issue, err
+}
+
+// primeCacheKey fingerprints everything a hook lookup depends on: the agent,
+// its worktree, and the modification times of its runtime state and of the
+// rig and town bead databases. Any bead write (such as a sling hooking new
+// work) changes the database fingerprint and so misses the cache.
+func primeCacheKey(ctx RoleContext) string {
+	parts := []string{ctx.WorkDir, getAgentIdentity(ctx)}
+	paths := []string{
+		filepath.Join(ctx.WorkDir, constants.DirRuntime, constants.FileHandoffMarker),
+		checkpoint.Path(ctx.WorkDir),
+		filepath.Join(rigBeadsRoot(ctx), ".beads"),
+	}
+	if ctx.TownRoot != "" {
+		paths = append(paths, filepath.Join(ctx.TownRoot, ".beads"))
+	}
+	for _, p := range paths {
+		parts = append(parts, fmt.Sprintf("%d", latestModTime(p)))
+	}
+	if ctx.TownRoot != "" {
+		dbs := []string{"hq"}
+		if ctx.Rig != "" {
+			dbs = append(dbs, ctx.Rig)
+		}
+		for _, db := range dbs {
+			noms := filepath.Join(doltserver.RigDatabaseDir(ctx.TownRoot, db), ".dolt", "noms")
+			parts = append(parts, fmt.Sprintf("%d", latestModTime(noms)))
+		}
+	}
+	return strings.Join(parts, "|")
+}
+
+// latestModTime returns the newest modification time (UnixNano) of path and,
+// if it is a directory, its immediate entries. Missing paths return 0.
+func latestModTime(path string) int64 {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0
+	}
+	latest := info.ModTime().UnixNano()
+	if !info.IsDir() {
+		return latest
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return latest
+	}
+	for _, e := range entries {
+		if fi, err := e.Info(); err == nil && fi.ModTime().UnixNano() > latest {
+			latest = fi.ModTime().UnixNano()
+		}
+	}
+	return latest
+}
+
+// readPrimeCache returns the cached hooked bead if the entry matches key and
+// is younger than ttl.
+func readPrimeCache(workDir, key string, ttl time.Duration, now time.Time) (*beads.Issue, bool) {
+	data, err := os.ReadFile(filepath.Join(workDir, constants.DirRuntime, primeCacheFile))
+	if err != nil {
+		return nil, false
+	}
+	var entry primeCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	if entry.Key != key || now.Sub(entry.CreatedAt) > ttl || now.Before(entry.CreatedAt) {
+		return nil, false
+	}
+	return entry.HookedBead, true
+}
+
+// writePrimeCache stores entry, best-effort.
+func writePrimeCache(workDir string, entry primeCacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	runtimeDir := filepath.Join(workDir, constants.DirRuntime)
+	if err := os.MkdirAll(runtimeDir, 0755); err != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(runtimeDir, primeCacheFile), data, 0644)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
+)
+
+func TestPrimeCacheRoundTrip(t *testing.T) {
+	workDir := t.TempDir()
+	now := time.Now()
+	issue := &beads.Issue{ID: "gt-abc", Title: "Hooked work", Status: beads.StatusHooked}
+	writePrimeCache(workDir, primeCacheEntry{Key: "k1", CreatedAt: now, HookedBead: issue})
+
+	got, ok := readPrimeCache(workDir, "k1", time.Minute, now.Add(10*time.Second))
+	if !ok || got == nil || got.ID != "gt-abc" {
+		t.Fatalf("readPrimeCache() = %+v, %v; want gt-abc", got, ok)
+	}
+	if _, ok := readPrimeCache(workDir, "k2", time.Minute, now); ok {
+		t.Error("different key should miss")
+	}
+	if _, ok := readPrimeCache(workDir, "k1", time.Minute, now.Add(2*time.Minute)); ok {
+		t.Error("expired entry should miss")
+	}
+
+	// "Nothing hooked" is cached too, as a hit with a nil bead.
+	writePrimeCache(workDir, primeCacheEntry{Key: "k1", CreatedAt: now})
+	if got, ok := readPrimeCache(workDir, "k1", time.Minute, now); !ok || got != nil {
+		t.Errorf("readPrimeCache() = %+v, %v; want nil, true", got, ok)
+	}
+}
+
+func TestPrimeCacheKeyTracksState(t *testing.T) {
+	townRoot := t.TempDir()
+	workDir := filepath.Join(townRoot, "gastown", "polecats", "toast")
+	if err := os.MkdirAll(workDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	ctx := RoleContext{Role: RolePolecat, Rig: "gastown", Polecat: "toast", TownRoot: townRoot, WorkDir: workDir}
+
+	key := primeCacheKey(ctx)
+	if primeCacheKey(ctx) != key {
+		t.Fatal("key should be stable when nothing changes")
+	}
+
+	if err := checkpoint.Write(workDir, &checkpoint.Checkpoint{Timestamp: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	afterCheckpoint := primeCacheKey(ctx)
+	if afterCheckpoint == key {
+		t.Error("writing a checkpoint should change the key")
+	}
+
+	noms := filepath.Join(townRoot, ".dolt-data", "gastown", ".dolt", "noms")
+	if err := os.MkdirAll(noms, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(noms, "journal"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if primeCacheKey(ctx) == afterCheckpoint {
+		t.Error("a write to the rig database should change the key")
+	}
+
+	other := ctx
+	other.Polecat = "nux"
+	if primeCacheKey(other) == primeCacheKey(ctx) {
+		t.Error("different agents should not share a key")
+	}
+}
+
+func TestPrimeCacheTTL(t *testing.T) {
+	old := primeNoCache
+	t.Cleanup(func() { primeNoCache = old })
+
+	primeNoCache = false
+	t.Setenv("GT_PRIME_CACHE_TTL", "")
+	if got := primeCacheTTL(); got != defaultPrimeCacheTTL {
+		t.Errorf("default = %v, want %v", got, defaultPrimeCacheTTL)
+	}
+	t.Setenv("GT_PRIME_CACHE_TTL", "5s")
+	if got := primeCacheTTL(); got != 5*time.Second {
+		t.Errorf("env = %v, want 5s", got)
+	}
+	t.Setenv("GT_PRIME_CACHE_TTL", "0")
+	if got := primeCacheTTL(); got != 0 {
+		t.Errorf("env 0 = %v, want disabled", got)
+	}
+	t.Setenv("GT_PRIME_CACHE_TTL", "")
+	primeNoCache = true
+	if got := primeCacheTTL(); got != 0 {
+		t.Errorf("--no-cache = %v, want disabled", got)
+	}
+}
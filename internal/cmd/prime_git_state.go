@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// gitInProgress is a git operation the previous session left unfinished in
+// the agent's worktree.
+type gitInProgress struct {
+	Operation string   // git.OpMerge, git.OpRebase, ...; "" if only conflicts remain
+	Conflicts []string // unmerged paths
+}
+
+// detectGitInProgress returns the unfinished git operation in workDir, or nil
+// if the worktree is not mid-merge, mid-rebase, or conflicted.
+func detectGitInProgress(workDir string) *gitInProgress {
+	g := git.NewGit(workDir)
+	if !g.IsRepo() {
+		return nil
+	}
+	op, _ := g.InProgressOperation()
+	conflicts, _ := g.GetConflictingFiles()
+	if op == "" && len(conflicts) == 0 {
+		return nil
+	}
+	return &gitInProgress{Operation: op, Conflicts: conflicts}
+}
+
+// describe returns a short description, e.g. "mid-rebase with 2 conflicted files".
+func (s *gitInProgress) describe() string {
+	desc := "conflicted"
+	if s.Operation != "" {
+		desc = "mid-" + s.Operation
+		if len(s.Conflicts) > 0 {
+			desc += " with conflicts"
+		}
+	}
+	if n := len(s.Conflicts); n > 0 {
+		desc += fmt.Sprintf(" (%d file", n)
+		if n > 1 {
+			desc += "s"
+		}
+		desc += ")"
+	}
+	return desc
+}
+
+// gitRecoverySteps returns how to finish or back out of op.
+func gitRecoverySteps(op string) (cont, abort string) {
+	switch op {
+	case git.OpMerge:
+		return "git add <files> && git commit --no-edit", "git merge --abort"
+	case git.OpRebase:
+		return "git add <files> && git rebase --continue", "git rebase --abort"
+	case git.OpCherryPick:
+		return "git add <files> && git cherry-pick --continue", "git cherry-pick --abort"
+	case git.OpRevert:
+		return "git add <files> && git revert --continue", "git revert --abort"
+	default:
+		return "git add <files> && git commit", "git checkout -- <files> (discards the conflicted changes)"
+	}
+}
+
+// outputGitInProgressContext warns about an unfinished git operation in the
+// worktree and explains how to recover, before the agent starts new work on
+// top of it.
+func outputGitInProgressContext(ctx RoleContext) {
+	state := detectGitInProgress(ctx.WorkDir)
+	if state == nil {
+		return
+	}
+	explain(true, "Git state: worktree is "+state.describe())
+
+	title := "## ⚠️ Unfinished Git Operation"
+	if state.Operation != "" {
+		title = fmt.Sprintf("## ⚠️ Unfinished Git %s", state.Operation)
+	}
+	fmt.Println()
+	fmt.Printf("%s\n\n", style.Bold.Render(title))
+	fmt.Printf("Your worktree is %s. The previous session stopped partway through;\n", state.describe())
+	fmt.Println("finish or abort it before doing anything else. Do NOT commit, pull, or start new work on top of it.")
+	fmt.Println()
+
+	if len(state.Conflicts) > 0 {
+		fmt.Println("**Conflicted files:**")
+		for i, f := range state.Conflicts {
+			if i == 10 {
+				fmt.Printf("  ... and %d more (`git diff --name-only --diff-filter=U`)\n", len(state.Conflicts)-10)
+				break
+			}
+			fmt.Printf("  - %s\n", f)
+		}
+		fmt.Println()
+	}
+
+	cont, abort := gitRecoverySteps(state.Operation)
+	fmt.Println("**To recover:**")
+	fmt.Println("  1. Run `git status` to see where things stand.")
+	if len(state.Conflicts) > 0 {
+		fmt.Println("  2. Resolve each conflicted file (remove the <<<<<<< / >>>>>>> markers), then:")
+	} else {
+		fmt.Println("  2. Check the partial result, then:")
+	}
+	fmt.Printf("     `%s`\n", cont)
+	fmt.Printf("  3. If the operation was a mistake or you can't resolve it: `%s`\n", abort)
+	fmt.Println()
+}
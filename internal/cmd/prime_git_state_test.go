@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// conflictedRepo returns a repo left mid-merge with main.go conflicted.
+func conflictedRepo(t *testing.T) string {
+	t.Helper()
+	dir := initResumeRepo(t)
+	run := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	write := func(content string) {
+		if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run("checkout", "polecat/toast-work")
+	write("package main // work\n")
+	run("commit", "-am", "work")
+	run("checkout", "main")
+	write("package main // main\n")
+	run("commit", "-am", "main")
+	cmd := exec.Command("git", "merge", "polecat/toast-work")
+	cmd.Dir = dir
+	_ = cmd.Run() // conflicts
+	return dir
+}
+
+func TestDetectSessionState_GitInProgress(t *testing.T) {
+	dir := conflictedRepo(t)
+	// A handoff marker doesn't hide the conflicted tree.
+	if err := writeHandoffMarker(dir, "gt-toast", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	state := detectSessionState(RoleContext{Role: RolePolecat, Rig: "gastown", Polecat: "toast", WorkDir: dir})
+	if state.State != "git-in-progress" {
+		t.Fatalf("state = %q, want git-in-progress", state.State)
+	}
+	if state.GitOperation != git.OpMerge {
+		t.Errorf("git operation = %q, want merge", state.GitOperation)
+	}
+	if len(state.Conflicts) != 1 || state.Conflicts[0] != "main.go" {
+		t.Errorf("conflicts = %v, want [main.go]", state.Conflicts)
+	}
+}
+
+func TestOutputGitInProgressContext(t *testing.T) {
+	dir := conflictedRepo(t)
+	out := captureStdout(t, func() { outputGitInProgressContext(RoleContext{WorkDir: dir}) })
+	for _, want := range []string{"Unfinished Git merge", "main.go", "git merge --abort", "git commit --no-edit"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+
+	clean := initResumeRepo(t)
+	if out := captureStdout(t, func() { outputGitInProgressContext(RoleContext{WorkDir: clean}) }); out != "" {
+		t.Errorf("clean worktree produced output:\n%s", out)
+	}
+	if s := detectGitInProgress(t.TempDir()); s != nil {
+		t.Errorf("non-repo: got %+v, want nil", s)
+	}
+}
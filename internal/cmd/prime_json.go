@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// primeJSONOutput is gt prime --json output.
+type primeJSONOutput struct {
+	SessionState
+	RoleContext RoleInfo            `json:"role_context"`
+	AgentBeadID string              `json:"agent_bead_id,omitempty"`
+	Context     primeContextSummary `json:"context"`
+	Error       string              `json:"error,omitempty"`
+}
+
+// primeContextSummary describes the context prime injected.
+type primeContextSummary struct {
+	Sections []string `json:"sections"` // Markdown headings, in order
+	Lines    int      `json:"lines"`
+	Bytes    int      `json:"bytes"`
+	Output   string   `json:"output"`
+}
+
+// runPrimeJSON runs prime with its output captured and prints it, with the
+// detected state and role, as one JSON object.
+func runPrimeJSON() error {
+	cwd, townRoot, err := resolvePrimeWorkspace()
+	if err != nil {
+		return err
+	}
+	if townRoot == "" {
+		return nil // Silent exit - not in workspace and not enabled
+	}
+
+	roleInfo, err := GetRoleWithContext(cwd, townRoot)
+	if err != nil {
+		return fmt.Errorf("detecting role: %w", err)
+	}
+	ctx := RoleContext{
+		Role:     roleInfo.Role,
+		Rig:      roleInfo.Rig,
+		Polecat:  roleInfo.Polecat,
+		TownRoot: townRoot,
+		WorkDir:  cwd,
+	}
+
+	// Detect state first: the prime flow consumes the handoff marker.
+	state := detectSessionState(ctx)
+
+	output, flowErr := capturePrimeOutput(runPrimeFlow)
+	out := primeJSONOutput{
+		SessionState: state,
+		RoleContext:  roleInfo,
+		AgentBeadID:  getAgentBeadID(ctx),
+		Context:      summarizePrimeContext(output),
+	}
+	if flowErr != nil {
+		out.Error = flowErr.Error()
+	}
+	if err := outputJSON(out); err != nil {
+		return err
+	}
+	return flowErr
+}
+
+// capturePrimeOutput runs fn with stdout redirected and returns what it
+// printed, including output from subprocesses that inherit stdout.
+func capturePrimeOutput(fn func() error) (string, error) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return "", fmt.Errorf("capturing prime output: %w", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+
+	var buf bytes.Buffer
+	done := make(chan struct{})
+	go func() {
+		_, _ = io.Copy(&buf, r)
+		close(done)
+	}()
+
+	fnErr := fn()
+
+	os.Stdout = stdout
+	_ = w.Close()
+	<-done
+	_ = r.Close()
+	return buf.String(), fnErr
+}
+
+var ansiEscape = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+
+// summarizePrimeContext strips styling from prime's output and lists its
+// section headings.
+func summarizePrimeContext(output string) primeContextSummary {
+	output = ansiEscape.ReplaceAllString(output, "")
+	summary := primeContextSummary{Sections: []string{}, Bytes: len(output), Output: output}
+	if output == "" {
+		return summary
+	}
+	for _, line := range strings.Split(strings.TrimRight(output, "\n"), "\n") {
+		summary.Lines++
+		if heading := strings.TrimLeft(line, "#"); heading != line && strings.HasPrefix(heading, " ") {
+			summary.Sections = append(summary.Sections, strings.TrimSpace(heading))
+		}
+	}
+	return summary
+}
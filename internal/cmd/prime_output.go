@@ -53,6 +53,10 @@ func outputPrimeContext(ctx RoleContext) (string, error) {
 	case RoleDog:
 		roleName = "dog"
 	default:
+		if cfg, ok := lookupCustomRole(ctx.Role); ok {
+			outputCustomRoleContext(ctx, cfg)
+			return "", nil
+		}
 		// Unknown role - use fallback
 		outputPrimeContextFallback(ctx)
 		return "", nil
@@ -62,13 +66,10 @@ func outputPrimeContext(ctx RoleContext) (string, error) {
 	// Get town name for session names
 	townName, _ := workspace.GetTownName(ctx.TownRoot)
 
-	// Get default branch from rig config (default to "main" if not set)
+	// Get the rig's default branch ("main" outside a rig)
 	defaultBranch := "main"
 	if ctx.Rig != "" && ctx.TownRoot != "" {
-		rigPath := filepath.Join(ctx.TownRoot, ctx.Rig)
-		if rigCfg, err := rig.LoadRigConfig(rigPath); err == nil && rigCfg.DefaultBranch != "" {
-			defaultBranch = rigCfg.DefaultBranch
-		}
+		defaultBranch = rig.ResolveDefaultBranch(filepath.Join(ctx.TownRoot, ctx.Rig))
 	}
 
 	data := templates.RoleData{
@@ -677,14 +678,24 @@ func outputContinuationDirective(hookedBead *beads.Issue, hasMolecule bool) {
 }
 
 // outputHandoffWarning outputs the post-handoff warning message.
-func outputHandoffWarning(prevSession string) {
+func outputHandoffWarning(marker *session.HandoffMarker) {
 	fmt.Println()
 	fmt.Println(style.Bold.Render("╔══════════════════════════════════════════════════════════════════╗"))
 	fmt.Println(style.Bold.Render("║  ✅ HANDOFF COMPLETE - You are the NEW session                   ║"))
 	fmt.Println(style.Bold.Render("╚══════════════════════════════════════════════════════════════════╝"))
 	fmt.Println()
-	if prevSession != "" {
-		fmt.Printf("Your predecessor (%s) handed off to you.\n", prevSession)
+	if marker.PrevSession != "" {
+		fmt.Printf("Your predecessor (%s) handed off to you.\n", marker.PrevSession)
+	}
+	if len(marker.NextSteps) > 0 {
+		fmt.Println()
+		fmt.Println("Your predecessor's next steps:")
+		for _, step := range marker.NextSteps {
+			fmt.Printf("  - %s\n", step)
+		}
+	}
+	if len(marker.Beads) > 0 {
+		fmt.Printf("Handoff beads: %s\n", strings.Join(marker.Beads, ", "))
 	}
 	fmt.Println()
 	fmt.Println(style.Bold.Render("⚠️  DO NOT run /handoff - that was your predecessor's action."))
@@ -715,10 +726,20 @@ func outputState(ctx RoleContext, jsonOutput bool) {
 	fmt.Printf("role: %s\n", state.Role)
 
 	switch state.State {
+	case "git-in-progress":
+		if state.GitOperation != "" {
+			fmt.Printf("git_operation: %s\n", state.GitOperation)
+		}
+		if len(state.Conflicts) > 0 {
+			fmt.Printf("conflicts: %s\n", strings.Join(state.Conflicts, ","))
+		}
 	case "post-handoff":
 		if state.PrevSession != "" {
 			fmt.Printf("prev_session: %s\n", state.PrevSession)
 		}
+		if len(state.HandoffBeads) > 0 {
+			fmt.Printf("handoff_beads: %s\n", strings.Join(state.HandoffBeads, ","))
+		}
 	case "crash-recovery":
 		if state.CheckpointAge != "" {
 			fmt.Printf("checkpoint_age: %s\n", state.CheckpointAge)
@@ -749,8 +770,9 @@ func outputCheckpointContext(ctx RoleContext) {
 		return
 	}
 
-	// Check if checkpoint is stale (older than 24 hours)
-	if cp.IsStale(24 * time.Hour) {
+	// Check if checkpoint is stale (older than the role's threshold)
+	explainCheckpointAge(ctx, cp)
+	if cp.IsStale(checkpointStaleThreshold(ctx)) {
 		// Remove stale checkpoint
 		_ = checkpoint.Remove(ctx.WorkDir)
 		return
@@ -180,3 +180,21 @@ func TestOutputRoleDirectives(t *testing.T) {
 		}
 	})
 }
+
+func TestSummarizePrimeContext(t *testing.T) {
+	output := "\x1b[1m# Mayor Context\x1b[0m\nYou are the mayor.\n\n## 📬 Mail\n#not-a-heading\nnone\n"
+	got := summarizePrimeContext(output)
+
+	if want := []string{"Mayor Context", "📬 Mail"}; strings.Join(got.Sections, "|") != strings.Join(want, "|") {
+		t.Errorf("Sections = %q, want %q", got.Sections, want)
+	}
+	if got.Lines != 6 {
+		t.Errorf("Lines = %d, want 6", got.Lines)
+	}
+	if strings.Contains(got.Output, "\x1b[") {
+		t.Errorf("Output still has ANSI escapes: %q", got.Output)
+	}
+	if empty := summarizePrimeContext(""); empty.Lines != 0 || empty.Sections == nil {
+		t.Errorf("empty summary = %+v", empty)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/cli"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+// primeQuiet emits only the essential context block (--quiet).
+var primeQuiet bool
+
+// runPrimeQuiet prints the essential context block: who the agent is, its
+// hooked work, and any state it must deal with first. There are no banners,
+// role templates, or mail, and nothing is consumed: the handoff marker is
+// left for the next full prime. It is meant for hooks that run on every
+// prompt (UserPromptSubmit, PreCompact), where the full output would eat
+// context window each time.
+//
+// Lines start with "gt:" rather than a bracket so runtimes that sniff for
+// JSON treat the block as plain text.
+func runPrimeQuiet(cwd, townRoot string) error {
+	roleInfo, err := GetRoleWithContext(cwd, townRoot)
+	if err != nil {
+		return fmt.Errorf("detecting role: %w", err)
+	}
+	ctx := RoleContext{
+		Role:     roleInfo.Role,
+		Rig:      roleInfo.Rig,
+		Polecat:  roleInfo.Polecat,
+		TownRoot: townRoot,
+		WorkDir:  cwd,
+	}
+	for _, line := range primeQuietLines(ctx) {
+		fmt.Println("gt: " + line)
+	}
+	return nil
+}
+
+// primeQuietLines returns the lines of the quiet context block.
+func primeQuietLines(ctx RoleContext) []string {
+	agentID := getAgentIdentity(ctx)
+	if agentID == "" {
+		agentID = string(ctx.Role)
+		if ctx.Polecat != "" {
+			agentID += "/" + ctx.Polecat
+		}
+	}
+	lines := []string{fmt.Sprintf("you are %s (%s)", agentID, ctx.Role)}
+
+	if inProgress := detectGitInProgress(ctx.WorkDir); inProgress != nil {
+		lines = append(lines, fmt.Sprintf("worktree is %s; finish or abort it before anything else (`%s prime` shows how)",
+			inProgress.describe(), cli.Name()))
+	}
+	if marker, err := session.ReadHandoffMarker(ctx.WorkDir); err == nil {
+		lines = append(lines, fmt.Sprintf("handoff from %s is done; do not run /handoff", marker.PrevSession))
+	} else if cp := crashRecoveryCheckpoint(ctx); cp != nil {
+		lines = append(lines, fmt.Sprintf("previous session crashed %s ago (%s); `%s checkpoint read` for details",
+			formatDuration(cp.Age()), cp.Summary(), cli.Name()))
+	}
+
+	hooked, err := findAgentWorkCached(ctx)
+	switch {
+	case err != nil:
+		lines = append(lines, fmt.Sprintf("hook query failed (database error); your work may still be assigned, do NOT run `%s done`", cli.Name()))
+	case hooked != nil:
+		lines = append(lines, fmt.Sprintf("hooked %s: %s [%s]", hooked.ID, hooked.Title, hooked.Status))
+		if ctx.Role == RolePolecat {
+			lines = append(lines, fmt.Sprintf("when the work is committed and tests pass, run `%s done`", cli.Name()))
+		}
+	case getAgentIdentity(ctx) != "":
+		lines = append(lines, "no hooked work")
+	}
+	return lines
+}
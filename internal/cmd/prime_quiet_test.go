@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func TestPrimeQuietLines(t *testing.T) {
+	t.Setenv("GT_PRIME_CACHE_TTL", "")
+	workDir := conflictedRepo(t)
+	if err := writeHandoffMarker(workDir, "gt-toast-old", ""); err != nil {
+		t.Fatal(err)
+	}
+	ctx := RoleContext{Role: RolePolecat, Rig: "gastown", Polecat: "toast", TownRoot: t.TempDir(), WorkDir: workDir}
+
+	// Seed the hook cache so no beads database is needed.
+	issue := &beads.Issue{ID: "gt-abc", Title: "Fix the widget", Status: beads.StatusHooked}
+	writePrimeCache(workDir, primeCacheEntry{Key: primeCacheKey(ctx), CreatedAt: time.Now(), HookedBead: issue})
+
+	out := strings.Join(primeQuietLines(ctx), "\n")
+	for _, want := range []string{
+		"you are gastown/polecats/toast (polecat)",
+		"worktree is mid-merge with conflicts",
+		"handoff from gt-toast-old is done",
+		"hooked gt-abc: Fix the widget [hooked]",
+		"done`",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("quiet block missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "═") || strings.Contains(out, "##") {
+		t.Errorf("quiet block has decorative output:\n%s", out)
+	}
+
+	// The marker is left for the next full prime.
+	if _, err := session.ReadHandoffMarker(workDir); err != nil {
+		t.Errorf("quiet mode consumed the handoff marker: %v", err)
+	}
+}
+
+func TestValidatePrimeFlags_Quiet(t *testing.T) {
+	oldQuiet, oldExplain := primeQuiet, primeExplain
+	t.Cleanup(func() { primeQuiet, primeExplain = oldQuiet, oldExplain })
+
+	primeQuiet, primeExplain = true, false
+	if err := validatePrimeFlags(); err != nil {
+		t.Errorf("--quiet alone: %v", err)
+	}
+	primeExplain = true
+	if err := validatePrimeFlags(); err == nil {
+		t.Error("--quiet --explain should be rejected")
+	}
+}
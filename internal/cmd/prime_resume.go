@@ -0,0 +1,222 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// primeAutoResume enables automatic crash recovery (--auto-resume or
+// GT_AUTO_RESUME=1).
+var primeAutoResume bool
+
+// resumeBeadStore is the subset of beads operations auto-resume needs.
+type resumeBeadStore interface {
+	Show(id string) (*beads.Issue, error)
+	Update(id string, opts beads.UpdateOptions) error
+}
+
+// newResumeBeadStore opens the beads database holding id. Var so tests can
+// substitute a fake.
+var newResumeBeadStore = func(ctx RoleContext, id string) resumeBeadStore {
+	return beads.New(beads.ResolveHookDir(ctx.TownRoot, id, ctx.WorkDir))
+}
+
+// resumeResult records what auto-resume did, for the resume briefing.
+type resumeResult struct {
+	Checkpoint *checkpoint.Checkpoint
+	Actions    []string
+	Warnings   []string
+}
+
+// autoResumeEnabled reports whether crash recovery should reconstruct the
+// working context instead of only describing the checkpoint.
+func autoResumeEnabled() bool {
+	return primeAutoResume || os.Getenv("GT_AUTO_RESUME") == "1"
+}
+
+// crashRecoveryCheckpoint returns the checkpoint that puts ctx in the
+// crash-recovery state, or nil. Only polecats and crew leave checkpoints.
+func crashRecoveryCheckpoint(ctx RoleContext) *checkpoint.Checkpoint {
+	if ctx.Role != RolePolecat && ctx.Role != RoleCrew {
+		return nil
+	}
+	cp, err := checkpoint.Read(ctx.WorkDir)
+	if err != nil || cp == nil || cp.IsStale(checkpointStaleThreshold(ctx)) {
+		return nil
+	}
+	return cp
+}
+
+// checkpointStaleThreshold returns how old a checkpoint of ctx's role may be
+// and still count as crash recovery (town.json checkpoints.stale_after).
+func checkpointStaleThreshold(ctx RoleContext) time.Duration {
+	var cfg *config.CheckpointConfig
+	if ctx.TownRoot != "" {
+		if town, err := config.LoadTownConfig(filepath.Join(ctx.TownRoot, workspace.PrimaryMarker)); err == nil {
+			cfg = town.Checkpoints
+		}
+	}
+	return cfg.StaleThreshold(string(ctx.Role))
+}
+
+// explainCheckpointAge reports, under --explain, a checkpoint's age against
+// the role's stale threshold.
+func explainCheckpointAge(ctx RoleContext, cp *checkpoint.Checkpoint) {
+	threshold := checkpointStaleThreshold(ctx)
+	verdict := "fresh: crash recovery"
+	if cp.IsStale(threshold) {
+		verdict = "stale: discarded"
+	}
+	explain(true, fmt.Sprintf("Checkpoint age %s, %s stale threshold %s (%s)",
+		formatDuration(cp.Age()), ctx.Role, formatDuration(threshold), verdict))
+}
+
+// autoResumeFromCheckpoint reconstructs the working context a crashed
+// session left in its checkpoint: it checks out the checkpoint's branch and
+// puts the hooked bead back on the agent's hook with the current step in
+// progress. Nothing is forced: a dirty worktree or closed bead is reported
+// as a warning instead. Returns nil when there is nothing to recover.
+func autoResumeFromCheckpoint(ctx RoleContext) *resumeResult {
+	cp := crashRecoveryCheckpoint(ctx)
+	if cp == nil {
+		return nil
+	}
+	res := &resumeResult{Checkpoint: cp}
+	resumeBranch(ctx, cp, res)
+
+	agentID := getAgentIdentity(ctx)
+	hook := cp.HookedBead
+	if hook == "" {
+		hook = cp.MoleculeID
+	}
+	if hook != "" {
+		resumeBead(ctx, res, hook, agentID, beads.StatusHooked, "hook")
+	}
+	if cp.CurrentStep != "" && cp.CurrentStep != hook {
+		resumeBead(ctx, res, cp.CurrentStep, agentID, string(beads.StatusInProgress), "step")
+	}
+	return res
+}
+
+// resumeBranch checks out the checkpoint's branch if the worktree is on a
+// different one and has no uncommitted changes to tracked files.
+func resumeBranch(ctx RoleContext, cp *checkpoint.Checkpoint, res *resumeResult) {
+	if cp.Branch == "" {
+		return
+	}
+	g := git.NewGit(ctx.WorkDir)
+	if !g.IsRepo() {
+		return
+	}
+	current, err := g.CurrentBranch()
+	if err != nil {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("could not read current branch: %v", err))
+		return
+	}
+	if current == cp.Branch {
+		res.Actions = append(res.Actions, fmt.Sprintf("Already on branch %s", cp.Branch))
+		return
+	}
+	// Untracked files (the checkpoint itself, runtime dirs) carry over a
+	// checkout; only changes to tracked files would be at risk.
+	if work, err := g.CheckUncommittedWork(); err != nil || len(work.ModifiedFiles) > 0 {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("not switching from %s to %s: worktree has uncommitted changes", current, cp.Branch))
+		return
+	}
+	if err := g.Checkout(cp.Branch); err != nil {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("could not check out %s: %v", cp.Branch, err))
+		return
+	}
+	res.Actions = append(res.Actions, fmt.Sprintf("Checked out branch %s (was %s)", cp.Branch, current))
+}
+
+// resumeBead puts bead id back in status for agentID, unless it is closed or
+// already there.
+func resumeBead(ctx RoleContext, res *resumeResult, id, agentID, status, kind string) {
+	store := newResumeBeadStore(ctx, id)
+	issue, err := store.Show(id)
+	if err != nil {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("could not read %s %s: %v", kind, id, err))
+		return
+	}
+	switch {
+	case issue.Status == "closed":
+		res.Warnings = append(res.Warnings, fmt.Sprintf("%s %s is closed; not re-hooking it", kind, id))
+		return
+	case issue.Assignee == agentID && (issue.Status == status || issue.Status == beads.StatusHooked || issue.Status == string(beads.StatusInProgress)):
+		res.Actions = append(res.Actions, fmt.Sprintf("%s %s is still yours (%s)", kind, id, issue.Status))
+		return
+	case issue.Assignee != "" && issue.Assignee != agentID:
+		res.Warnings = append(res.Warnings, fmt.Sprintf("%s %s is now assigned to %s; leaving it", kind, id, issue.Assignee))
+		return
+	}
+	if err := store.Update(id, beads.UpdateOptions{Status: &status, Assignee: &agentID}); err != nil {
+		res.Warnings = append(res.Warnings, fmt.Sprintf("could not re-hook %s %s: %v", kind, id, err))
+		return
+	}
+	res.Actions = append(res.Actions, fmt.Sprintf("Re-hooked %s %s (%s → %s)", kind, id, issue.Status, status))
+}
+
+// outputResumeBriefing prints what auto-resume restored and where to pick up.
+// It replaces the plain checkpoint context in crash recovery.
+func outputResumeBriefing(res *resumeResult) {
+	cp := res.Checkpoint
+	fmt.Println()
+	fmt.Printf("%s\n\n", style.Bold.Render("## 🔄 Crash Recovery: Resumed From Checkpoint"))
+	fmt.Printf("The previous session ended unexpectedly %s ago. Its working context has been restored.\n\n",
+		cp.Age().Round(time.Minute))
+
+	if len(res.Actions) > 0 {
+		fmt.Println("**Restored:**")
+		for _, a := range res.Actions {
+			fmt.Printf("  - %s\n", a)
+		}
+		fmt.Println()
+	}
+	if len(res.Warnings) > 0 {
+		fmt.Println("**Needs attention:**")
+		for _, w := range res.Warnings {
+			fmt.Printf("  - ⚠ %s\n", w)
+		}
+		fmt.Println()
+	}
+
+	fmt.Println("**Resume here:**")
+	if cp.StepTitle != "" {
+		fmt.Printf("  - Working on: %s\n", cp.StepTitle)
+	}
+	if cp.CurrentStep != "" {
+		fmt.Printf("  - Step: %s\n", cp.CurrentStep)
+	}
+	if cp.MoleculeID != "" {
+		fmt.Printf("  - Molecule: %s\n", cp.MoleculeID)
+	}
+	if cp.LastCommit != "" {
+		fmt.Printf("  - Last commit: %s\n", cp.LastCommit)
+	}
+	if n := len(cp.ModifiedFiles); n > 0 {
+		fmt.Printf("  - Files modified but not committed at the crash: %d\n", n)
+		for i, f := range cp.ModifiedFiles {
+			if i == 5 {
+				fmt.Printf("    ... and %d more\n", n-5)
+				break
+			}
+			fmt.Printf("    - %s\n", f)
+		}
+	}
+	if cp.Notes != "" {
+		fmt.Printf("  - Notes: %s\n", cp.Notes)
+	}
+	fmt.Println()
+	fmt.Println("Check `git status` against the list above, then continue the step. The checkpoint will be updated as you progress.")
+	fmt.Println()
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/checkpoint"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+type fakeResumeStore struct {
+	issues  map[string]*beads.Issue
+	updates []string
+}
+
+func (f *fakeResumeStore) Show(id string) (*beads.Issue, error) {
+	issue, ok := f.issues[id]
+	if !ok {
+		return nil, fmt.Errorf("no issue found: %s", id)
+	}
+	return issue, nil
+}
+
+func (f *fakeResumeStore) Update(id string, opts beads.UpdateOptions) error {
+	f.updates = append(f.updates, fmt.Sprintf("%s status=%s assignee=%s", id, *opts.Status, *opts.Assignee))
+	return nil
+}
+
+func useFakeResumeStore(t *testing.T, store *fakeResumeStore) {
+	t.Helper()
+	old := newResumeBeadStore
+	newResumeBeadStore = func(RoleContext, string) resumeBeadStore { return store }
+	t.Cleanup(func() { newResumeBeadStore = old })
+}
+
+func initResumeRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for _, args := range [][]string{
+		{"init", "-b", "main"},
+		{"config", "user.email", "test@test.com"},
+		{"config", "user.name", "Test"},
+		{"add", "."},
+		{"commit", "-m", "initial"},
+		{"branch", "polecat/toast-work"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	return dir
+}
+
+func currentGitBranch(t *testing.T, dir string) string {
+	t.Helper()
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--abbrev-ref", "HEAD").Output()
+	if err != nil {
+		t.Fatalf("rev-parse: %v", err)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestAutoResumeFromCheckpoint(t *testing.T) {
+	workDir := initResumeRepo(t)
+	ctx := RoleContext{Role: RolePolecat, Rig: "gastown", Polecat: "toast", WorkDir: workDir}
+	if err := checkpoint.Write(workDir, &checkpoint.Checkpoint{
+		Branch:      "polecat/toast-work",
+		HookedBead:  "gt-work",
+		CurrentStep: "gt-work.2",
+		StepTitle:   "Write tests",
+		Timestamp:   time.Now(),
+	}); err != nil {
+		t.Fatalf("write checkpoint: %v", err)
+	}
+
+	store := &fakeResumeStore{issues: map[string]*beads.Issue{
+		"gt-work":   {ID: "gt-work", Status: "open"},
+		"gt-work.2": {ID: "gt-work.2", Status: "in_progress", Assignee: "gastown/polecats/toast"},
+	}}
+	useFakeResumeStore(t, store)
+
+	res := autoResumeFromCheckpoint(ctx)
+	if res == nil {
+		t.Fatal("expected a resume result")
+	}
+	if got := currentGitBranch(t, workDir); got != "polecat/toast-work" {
+		t.Errorf("branch = %q, want polecat/toast-work", got)
+	}
+	want := []string{"gt-work status=hooked assignee=gastown/polecats/toast"}
+	if strings.Join(store.updates, "\n") != strings.Join(want, "\n") {
+		t.Errorf("updates = %q, want %q", store.updates, want)
+	}
+	if len(res.Warnings) != 0 {
+		t.Errorf("unexpected warnings: %q", res.Warnings)
+	}
+	if len(res.Actions) != 3 {
+		t.Errorf("expected 3 actions (branch, hook, step), got %q", res.Actions)
+	}
+
+	out := captureStdout(t, func() { outputResumeBriefing(res) })
+	for _, s := range []string{"Crash Recovery", "Checked out branch polecat/toast-work", "Working on: Write tests"} {
+		if !strings.Contains(out, s) {
+			t.Errorf("briefing missing %q:\n%s", s, out)
+		}
+	}
+}
+
+func TestAutoResumeFromCheckpoint_LeavesDirtyWorktreeAndOthersBeads(t *testing.T) {
+	workDir := initResumeRepo(t)
+	ctx := RoleContext{Role: RoleCrew, Rig: "gastown", Polecat: "max", WorkDir: workDir}
+	if err := checkpoint.Write(workDir, &checkpoint.Checkpoint{
+		Branch:      "polecat/toast-work",
+		HookedBead:  "gt-taken",
+		CurrentStep: "gt-done",
+		Timestamp:   time.Now(),
+	}); err != nil {
+		t.Fatalf("write checkpoint: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, "main.go"), []byte("package main // edited\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	store := &fakeResumeStore{issues: map[string]*beads.Issue{
+		"gt-taken": {ID: "gt-taken", Status: "hooked", Assignee: "gastown/crew/joe"},
+		"gt-done":  {ID: "gt-done", Status: "closed"},
+	}}
+	useFakeResumeStore(t, store)
+
+	res := autoResumeFromCheckpoint(ctx)
+	if res == nil {
+		t.Fatal("expected a resume result")
+	}
+	if got := currentGitBranch(t, workDir); got != "main" {
+		t.Errorf("branch = %q, want main (tracked file modified)", got)
+	}
+	if len(store.updates) != 0 {
+		t.Errorf("expected no updates, got %q", store.updates)
+	}
+	if len(res.Warnings) != 3 {
+		t.Errorf("expected 3 warnings (branch, reassigned, closed), got %q", res.Warnings)
+	}
+}
+
+func TestAutoResumeFromCheckpoint_NoCheckpoint(t *testing.T) {
+	ctx := RoleContext{Role: RolePolecat, Rig: "gastown", Polecat: "toast", WorkDir: t.TempDir()}
+	if res := autoResumeFromCheckpoint(ctx); res != nil {
+		t.Errorf("expected nil without a checkpoint, got %+v", res)
+	}
+	ctx.Role = RoleMayor
+	if cp := crashRecoveryCheckpoint(ctx); cp != nil {
+		t.Errorf("mayor should never be in crash recovery")
+	}
+}
+
+func TestCrashRecoveryCheckpoint_StaleThreshold(t *testing.T) {
+	townRoot := t.TempDir()
+	cfg := &config.TownConfig{
+		Type:    "town",
+		Version: config.CurrentTownVersion,
+		Name:    "test",
+		Checkpoints: &config.CheckpointConfig{
+			StaleAfter: map[string]string{"crew": "72h", "default": "1h"},
+		},
+	}
+	if err := config.SaveTownConfig(filepath.Join(townRoot, "mayor", "town.json"), cfg); err != nil {
+		t.Fatal(err)
+	}
+	workDir := t.TempDir()
+	if err := checkpoint.Write(workDir, &checkpoint.Checkpoint{Timestamp: time.Now().Add(-30 * time.Hour)}); err != nil {
+		t.Fatal(err)
+	}
+
+	crew := RoleContext{Role: RoleCrew, Rig: "gastown", Polecat: "max", TownRoot: townRoot, WorkDir: workDir}
+	if got := checkpointStaleThreshold(crew); got != 72*time.Hour {
+		t.Errorf("crew threshold = %v, want 72h", got)
+	}
+	if cp := crashRecoveryCheckpoint(crew); cp == nil {
+		t.Error("30h-old checkpoint should be fresh for crew (72h threshold)")
+	}
+
+	polecat := crew
+	polecat.Role = RolePolecat
+	if got := checkpointStaleThreshold(polecat); got != time.Hour {
+		t.Errorf("polecat threshold = %v, want default 1h", got)
+	}
+	if cp := crashRecoveryCheckpoint(polecat); cp != nil {
+		t.Error("30h-old checkpoint should be stale for polecats (1h threshold)")
+	}
+
+	// Without town config the built-in cutoff applies.
+	polecat.TownRoot = ""
+	if got := checkpointStaleThreshold(polecat); got != config.DefaultCheckpointStaleAfter {
+		t.Errorf("threshold without town = %v, want %v", got, config.DefaultCheckpointStaleAfter)
+	}
+}
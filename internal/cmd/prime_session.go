@@ -11,10 +11,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/steveyegge/gastown/internal/beads"
-	"github.com/steveyegge/gastown/internal/checkpoint"
-	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/runtime"
+	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -273,35 +272,53 @@ func formatSessionMetadataLine(actor, sessionID string) string {
 
 // SessionState represents the detected session state for observability.
 type SessionState struct {
-	State         string `json:"state"`                    // normal, post-handoff, crash-recovery, autonomous
-	Role          Role   `json:"role"`                     // detected role
-	PrevSession   string `json:"prev_session,omitempty"`   // for post-handoff
-	CheckpointAge string `json:"checkpoint_age,omitempty"` // for crash-recovery
-	HookedBead    string `json:"hooked_bead,omitempty"`    // for autonomous
+	State         string   `json:"state"`                    // normal, git-in-progress, post-handoff, crash-recovery, autonomous
+	GitOperation  string   `json:"git_operation,omitempty"`  // for git-in-progress: merge, rebase, cherry-pick, revert
+	Conflicts     []string `json:"conflicts,omitempty"`      // for git-in-progress
+	Role          Role     `json:"role"`                     // detected role
+	PrevSession   string   `json:"prev_session,omitempty"`   // for post-handoff
+	HandoffBeads  []string `json:"handoff_beads,omitempty"`  // for post-handoff
+	NextSteps     []string `json:"next_steps,omitempty"`     // for post-handoff
+	CheckpointAge string   `json:"checkpoint_age,omitempty"` // for crash-recovery
+	HookedBead    string   `json:"hooked_bead,omitempty"`    // for autonomous
+	Reason        string   `json:"reason"`                   // why this state was detected
 }
 
 // detectSessionState returns the current session state without side effects.
 func detectSessionState(ctx RoleContext) SessionState {
 	state := SessionState{
-		State: "normal",
-		Role:  ctx.Role,
+		State:  "normal",
+		Role:   ctx.Role,
+		Reason: "no handoff marker, recent checkpoint, or hooked work",
+	}
+
+	// Check for an unfinished merge/rebase or conflicts (git-in-progress
+	// state). It comes first: whatever else the session should do, a
+	// conflicted worktree has to be sorted out before any of it.
+	if inProgress := detectGitInProgress(ctx.WorkDir); inProgress != nil {
+		state.State = "git-in-progress"
+		state.GitOperation = inProgress.Operation
+		state.Conflicts = inProgress.Conflicts
+		state.Reason = "worktree is " + inProgress.describe()
+		return state
 	}
 
 	// Check for handoff marker (post-handoff state)
-	markerPath := filepath.Join(ctx.WorkDir, constants.DirRuntime, constants.FileHandoffMarker)
-	if data, err := os.ReadFile(markerPath); err == nil {
+	if marker, err := session.ReadHandoffMarker(ctx.WorkDir); err == nil {
 		state.State = "post-handoff"
-		state.PrevSession = strings.TrimSpace(string(data))
+		state.PrevSession = marker.PrevSession
+		state.HandoffBeads = marker.Beads
+		state.NextSteps = marker.NextSteps
+		state.Reason = "handoff marker left by the previous session"
 		return state
 	}
 
 	// Check for checkpoint (crash-recovery state) - only for polecat/crew
-	if ctx.Role == RolePolecat || ctx.Role == RoleCrew {
-		if cp, err := checkpoint.Read(ctx.WorkDir); err == nil && cp != nil && !cp.IsStale(24*time.Hour) {
-			state.State = "crash-recovery"
-			state.CheckpointAge = cp.Age().Round(time.Minute).String()
-			return state
-		}
+	if cp := crashRecoveryCheckpoint(ctx); cp != nil {
+		state.State = "crash-recovery"
+		state.CheckpointAge = cp.Age().Round(time.Minute).String()
+		state.Reason = fmt.Sprintf("checkpoint from a previous session less than %s old", formatDuration(checkpointStaleThreshold(ctx)))
+		return state
 	}
 
 	// Check for hooked work (autonomous state).
@@ -318,7 +335,8 @@ func detectSessionState(ctx RoleContext) SessionState {
 				beadsDir = rigDir
 			}
 		}
-		b := beads.New(beadsDir)
+		b, closeBeads := beads.OpenClient(beadsDir)
+		defer closeBeads()
 		// Primary: agent bead's hook_bead field (authoritative, set by bd slot set during sling)
 		agentBeadID := buildAgentBeadID(agentID, ctx.Role, ctx.TownRoot)
 		if agentBeadID != "" {
@@ -333,6 +351,7 @@ func detectSessionState(ctx RoleContext) SessionState {
 					(hookBead.Status == beads.StatusHooked || hookBead.Status == "in_progress") {
 					state.State = "autonomous"
 					state.HookedBead = agentBead.HookBead
+					state.Reason = "agent bead's hook_bead is hooked or in progress"
 					return state
 				}
 			}
@@ -347,6 +366,7 @@ func detectSessionState(ctx RoleContext) SessionState {
 		if err == nil && len(hookedBeads) > 0 {
 			state.State = "autonomous"
 			state.HookedBead = hookedBeads[0].ID
+			state.Reason = "hooked bead assigned to this agent"
 			return state
 		}
 		// Also check in_progress beads
@@ -358,6 +378,7 @@ func detectSessionState(ctx RoleContext) SessionState {
 		if err == nil && len(inProgressBeads) > 0 {
 			state.State = "autonomous"
 			state.HookedBead = inProgressBeads[0].ID
+			state.Reason = "in-progress bead assigned to this agent"
 			return state
 		}
 		// Town-level fallback: rig-level agents may have hooked HQ beads
@@ -371,6 +392,7 @@ func detectSessionState(ctx RoleContext) SessionState {
 			}); err == nil && len(townHooked) > 0 {
 				state.State = "autonomous"
 				state.HookedBead = townHooked[0].ID
+				state.Reason = "hooked town bead assigned to this agent"
 				return state
 			}
 			if townIP, err := townB.List(beads.ListOptions{
@@ -380,6 +402,7 @@ func detectSessionState(ctx RoleContext) SessionState {
 			}); err == nil && len(townIP) > 0 {
 				state.State = "autonomous"
 				state.HookedBead = townIP[0].ID
+				state.Reason = "in-progress town bead assigned to this agent"
 				return state
 			}
 		}
@@ -393,51 +416,37 @@ func detectSessionState(ctx RoleContext) SessionState {
 // and incorrectly runs it again. The marker tells the new session: "handoff is DONE,
 // the /handoff you see in context was from YOUR PREDECESSOR, not a request for you."
 //
-// The marker format is: "session_id\nreason" (reason is optional, on second line).
-// When present, the reason is stored in primeHandoffReason for compact/resume detection.
-// This enables compaction-triggered handoff cycles to route through the lighter
+// The marker is a session.HandoffMarker. Its reason is stored in
+// primeHandoffReason for compact/resume detection. This enables
+// compaction-triggered handoff cycles to route through the lighter
 // compact/resume path instead of full re-initialization. (GH#1965)
 func checkHandoffMarker(workDir string) {
-	markerPath := filepath.Join(workDir, constants.DirRuntime, constants.FileHandoffMarker)
-	data, err := os.ReadFile(markerPath)
+	marker, err := session.ReadHandoffMarker(workDir)
 	if err != nil {
 		// No marker = not post-handoff, normal startup
 		return
 	}
-
-	// Parse marker: first line is session ID, optional second line is reason
-	lines := strings.SplitN(strings.TrimSpace(string(data)), "\n", 2)
-	prevSession := strings.TrimSpace(lines[0])
-	if len(lines) > 1 {
-		primeHandoffReason = strings.TrimSpace(lines[1])
-	}
+	primeHandoffReason = marker.Reason
 
 	// Remove the marker FIRST so we don't warn twice
-	_ = os.Remove(markerPath)
+	_ = os.Remove(session.HandoffMarkerPath(workDir))
 
 	// Output prominent warning
-	outputHandoffWarning(prevSession)
+	outputHandoffWarning(marker)
 }
 
 // checkHandoffMarkerDryRun checks for handoff marker without removing it (for --dry-run).
 func checkHandoffMarkerDryRun(workDir string) {
-	markerPath := filepath.Join(workDir, constants.DirRuntime, constants.FileHandoffMarker)
-	data, err := os.ReadFile(markerPath)
+	marker, err := session.ReadHandoffMarker(workDir)
 	if err != nil {
 		// No marker = not post-handoff, normal startup
 		explain(true, "Post-handoff: no handoff marker found")
 		return
 	}
+	primeHandoffReason = marker.Reason
 
-	// Parse marker: first line is session ID, optional second line is reason
-	lines := strings.SplitN(strings.TrimSpace(string(data)), "\n", 2)
-	prevSession := strings.TrimSpace(lines[0])
-	if len(lines) > 1 {
-		primeHandoffReason = strings.TrimSpace(lines[1])
-	}
-
-	explain(true, fmt.Sprintf("Post-handoff: marker found (predecessor: %s, reason: %s), marker NOT removed in dry-run", prevSession, primeHandoffReason))
+	explain(true, fmt.Sprintf("Post-handoff: marker found (predecessor: %s, reason: %s, schema v%d), marker NOT removed in dry-run", marker.PrevSession, primeHandoffReason, marker.Version))
 
 	// Output the warning but don't remove marker
-	outputHandoffWarning(prevSession)
+	outputHandoffWarning(marker)
 }
@@ -287,6 +287,9 @@ func TestDetectSessionState(t *testing.T) {
 		if state.Role != RoleMayor {
 			t.Fatalf("expected role Mayor, got %q", state.Role)
 		}
+		if state.Reason == "" {
+			t.Fatal("expected a reason for the normal state")
+		}
 	})
 
 	t.Run("post_handoff_state", func(t *testing.T) {
@@ -318,6 +321,9 @@ func TestDetectSessionState(t *testing.T) {
 		if state.PrevSession != prevSession {
 			t.Fatalf("expected prev_session %q, got %q", prevSession, state.PrevSession)
 		}
+		if !strings.Contains(state.Reason, "handoff marker") {
+			t.Fatalf("expected reason to mention the handoff marker, got %q", state.Reason)
+		}
 	})
 
 	t.Run("crash_recovery_state", func(t *testing.T) {
@@ -478,7 +478,7 @@ func runQuotaRotate(cmd *cobra.Command, args []string) error {
 	skippedBusy := 0
 	if rotateIdle {
 		for session := range plan.Assignments {
-			if !t.IsIdle(session) {
+			if st, err := t.Idle(session); err != nil || !st.Idle {
 				if !quotaJSON {
 					fmt.Printf(" %s %-25s %s\n",
 						style.Dim.Render("-"), session,
@@ -22,6 +22,7 @@ import (
 
 var readyJSON bool
 var readyRig string
+var readyQuery beads.QueryFlags
 
 var readyCmd = &cobra.Command{
 	Use:     "ready",
@@ -36,16 +37,21 @@ Aggregates ready issues from:
 Ready items have no blockers and can be worked immediately.
 Results are sorted by priority (highest first) then by source.
 
+The standard bead filters (--status, --assignee, --label, --age) narrow
+the queue, e.g. to pick what to dispatch next.
+
 Examples:
   gt ready              # Show all ready work
   gt ready --json       # Output as JSON
-  gt ready --rig=gastown  # Show only one rig`,
+  gt ready --rig=gastown  # Show only one rig
+  gt ready --assignee=none --age=3d   # Unclaimed work waiting 3+ days`,
 	RunE: runReady,
 }
 
 func init() {
 	readyCmd.Flags().BoolVar(&readyJSON, "json", false, "Output as JSON")
 	readyCmd.Flags().StringVar(&readyRig, "rig", "", "Filter to a specific rig")
+	readyQuery.Register(readyCmd.Flags())
 	rootCmd.AddCommand(readyCmd)
 }
 
@@ -75,6 +81,11 @@ type ReadySummary struct {
 }
 
 func runReady(cmd *cobra.Command, args []string) error {
+	query, err := readyQuery.Query()
+	if err != nil {
+		return err
+	}
+
 	// Find town root
 	townRoot, err := workspace.FindFromCwdOrError()
 	if err != nil {
@@ -138,7 +149,7 @@ func runReady(cmd *cobra.Command, args []string) error {
 				wispIDs := getWispIDs(townBeadsPath)
 				filtered = filterWisps(filtered, wispIDs)
 				// Filter identity beads (agents, roles, rigs) - not actionable work
-				src.Issues = filterIdentityBeads(filtered)
+				src.Issues = query.Filter(filterIdentityBeads(filtered))
 			}
 			sources = append(sources, src)
 		}()
@@ -167,7 +178,7 @@ func runReady(cmd *cobra.Command, args []string) error {
 				wispIDs := getWispIDs(r.BeadsPath())
 				filtered = filterWisps(filtered, wispIDs)
 				// Filter identity beads (agents, roles, rigs) - not actionable work
-				src.Issues = filterIdentityBeads(filtered)
+				src.Issues = query.Filter(filterIdentityBeads(filtered))
 			}
 			sources = append(sources, src)
 		}(r)
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/mayor"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	restartGraceful bool
+	restartWait     int
+	restartAll      bool
+	restartRespawn  bool
+	restartYes      bool
+)
+
+var restartCmd = &cobra.Command{
+	Use:     "restart",
+	GroupID: GroupServices,
+	Short:   "Stop and start Gas Town in one step",
+	Long: `Restart Gas Town: stop the running agents, then start them again.
+
+Sessions stop in shutdown order (workers, refineries, witnesses, then Mayor,
+Boot, and Deacon last) and come back up with the Mayor first, then the
+Deacon and daemon. Configured crew is started as with 'gt start'.
+
+Unlike 'gt shutdown', a restart keeps polecat worktrees and branches, so
+--respawn can bring back every session that was running when the restart
+began: witnesses, refineries, crew, and polecats, in that order.
+
+Crew sessions are preserved unless --all is given, matching 'gt shutdown'.
+
+The town hooks in mayor/town.json run as they would for 'gt shutdown'
+followed by 'gt start': pre_shutdown and post_shutdown around the stop,
+pre_start and post_start around the start. A failing required pre hook
+aborts that half of the restart.
+
+Examples:
+  gt restart                     # Bounce Mayor, Deacon, and rig agents
+  gt restart --graceful          # Ask agents to hand off first
+  gt restart --all --respawn     # Bounce everything and bring it all back`,
+	Args: cobra.NoArgs,
+	RunE: runRestart,
+}
+
+func init() {
+	restartCmd.Flags().BoolVarP(&restartGraceful, "graceful", "g", false,
+		"Send ESC to agents and wait for them to handoff before stopping")
+	restartCmd.Flags().IntVarP(&restartWait, "wait", "w", 30,
+		"Seconds to wait for graceful handoff (default 30)")
+	restartCmd.Flags().BoolVarP(&restartAll, "all", "a", false,
+		"Also restart crew sessions (by default, crew is preserved)")
+	restartCmd.Flags().BoolVar(&restartRespawn, "respawn", false,
+		"Start every session that was running before the restart, not just Mayor and Deacon")
+	restartCmd.Flags().BoolVarP(&restartYes, "yes", "y", false,
+		"Skip confirmation prompt")
+
+	rootCmd.AddCommand(restartCmd)
+}
+
+func runRestart(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	t := tmux.NewTmux()
+	sessions, err := t.ListSessions()
+	if err != nil && !errors.Is(err, tmux.ErrNoServer) {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	toStop := restartSessions(sessions, restartAll)
+
+	if len(toStop) > 0 {
+		fmt.Println("Sessions to restart:")
+		for _, sess := range toStop {
+			fmt.Printf("  %s %s\n", style.Bold.Render("→"), sess)
+		}
+		fmt.Println()
+
+		if !restartYes {
+			fmt.Printf("Proceed with restart? [y/N] ")
+			reader := bufio.NewReader(os.Stdin)
+			response, _ := reader.ReadString('\n')
+			response = strings.TrimSpace(strings.ToLower(response))
+			if response != "y" && response != "yes" {
+				fmt.Println("Restart canceled.")
+				return nil
+			}
+		}
+	}
+
+	mayorSession := getMayorSessionName()
+	deaconSession := getDeaconSessionName()
+
+	// The town's shutdown and start hooks run around each half, as they
+	// would for 'gt shutdown' followed by 'gt start'.
+	if err := stopWithTownHooks(townRoot, func() error {
+		restartStop(t, townRoot, toStop, mayorSession, deaconSession)
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	fmt.Println("Starting Gas Town...")
+	var failed int
+	if err := startWithTownHooks(townRoot, func() error {
+		var err error
+		failed, err = restartStart(t, townRoot, toStop, mayorSession, deaconSession)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Gas Town restarted\n", style.Bold.Render("✓"))
+	if failed > 0 {
+		return fmt.Errorf("%d session(s) failed to respawn", failed)
+	}
+	return nil
+}
+
+// restartStop stops the daemon and then sessions, in shutdown order.
+func restartStop(t *tmux.Tmux, townRoot string, toStop []string, mayorSession, deaconSession string) {
+	// Stop the daemon first so its heartbeat doesn't race us by restarting
+	// agents we're about to kill.
+	fmt.Println("Stopping daemon...")
+	stopDaemonIfRunning(townRoot)
+
+	if len(toStop) > 0 {
+		stopped := 0
+		if restartGraceful {
+			fmt.Println()
+			restartMsg := "[SHUTDOWN] Gas Town is restarting. Please save your state and update your handoff bead, then type /exit or wait to be terminated."
+			stopped = requestHandoffAndWait(t, townRoot, toStop, restartMsg, restartWait)
+		}
+		fmt.Println()
+		fmt.Println("Stopping sessions...")
+		stopped += killSessionsInOrder(t, toStop, mayorSession, deaconSession)
+		fmt.Printf("  %s %d session(s) stopped\n", style.Dim.Render("○"), stopped)
+	}
+	fmt.Println()
+}
+
+// restartStart brings the town back: Mayor, Deacon, daemon, configured crew,
+// and with --respawn the other sessions that were stopped. Returns how many
+// respawns failed.
+func restartStart(t *tmux.Tmux, townRoot string, stopped []string, mayorSession, deaconSession string) (int, error) {
+	if err := startMayorThenDeacon(townRoot); err != nil {
+		return 0, err
+	}
+	if err := ensureDaemon(townRoot); err != nil {
+		fmt.Printf("  %s Daemon failed: %v\n", style.Dim.Render("○"), err)
+	} else {
+		fmt.Printf("  %s Daemon running\n", style.Bold.Render("✓"))
+	}
+
+	if rigs, err := discoverAllRigs(townRoot); err != nil {
+		fmt.Printf("  %s Could not discover rigs: %v\n", style.Dim.Render("○"), err)
+	} else {
+		var mu sync.Mutex
+		startConfiguredCrew(t, rigs, townRoot, &mu)
+	}
+
+	var failed int
+	if restartRespawn {
+		failed = respawnSessions(t, townRoot, restartStartOrder(stopped, mayorSession, deaconSession))
+	}
+	fmt.Println()
+	return failed, nil
+}
+
+// restartSessions returns the Gas Town sessions a restart stops. Crew is
+// left running unless all is set.
+func restartSessions(sessions []string, all bool) []string {
+	var out []string
+	for _, sess := range sessions {
+		if !session.IsKnownSession(sess) {
+			continue
+		}
+		if !all {
+			if identity, err := session.ParseSessionName(sess); err == nil && identity.Role == session.RoleCrew {
+				continue
+			}
+		}
+		out = append(out, sess)
+	}
+	return out
+}
+
+// restartStartOrder returns the sessions to respawn after a restart, in
+// startup order: witnesses, refineries, crew, then polecats. The Mayor and
+// Deacon are started separately, and Boot is left to the daemon, so all
+// three are dropped.
+func restartStartOrder(sessions []string, mayorSession, deaconSession string) []string {
+	bootSession := session.BootSessionName()
+	var witnesses, refineries, crew, polecats []string
+	for _, sess := range sessions {
+		if sess == mayorSession || sess == deaconSession || sess == bootSession {
+			continue
+		}
+		identity, err := session.ParseSessionName(sess)
+		if err != nil {
+			continue
+		}
+		switch identity.Role {
+		case session.RoleWitness:
+			witnesses = append(witnesses, sess)
+		case session.RoleRefinery:
+			refineries = append(refineries, sess)
+		case session.RoleCrew:
+			crew = append(crew, sess)
+		case session.RolePolecat:
+			polecats = append(polecats, sess)
+		}
+	}
+	order := append(witnesses, refineries...)
+	order = append(order, crew...)
+	return append(order, polecats...)
+}
+
+// startMayorThenDeacon brings up the town agents one at a time, Mayor first,
+// so the coordinator is ready before the Deacon starts patrolling.
+func startMayorThenDeacon(townRoot string) error {
+	if err := mayor.NewManager(townRoot).Start(""); err != nil {
+		if !errors.Is(err, mayor.ErrAlreadyRunning) && !errors.Is(err, mayor.ErrACPActive) {
+			return fmt.Errorf("starting Mayor: %w", err)
+		}
+		fmt.Printf("  %s Mayor already running\n", style.Dim.Render("○"))
+	} else {
+		fmt.Printf("  %s Mayor started\n", style.Bold.Render("✓"))
+	}
+
+	if err := deacon.NewManager(townRoot).Start(""); err != nil {
+		if !errors.Is(err, deacon.ErrAlreadyRunning) {
+			return fmt.Errorf("starting Deacon: %w", err)
+		}
+		fmt.Printf("  %s Deacon already running\n", style.Dim.Render("○"))
+	} else {
+		fmt.Printf("  %s Deacon started\n", style.Bold.Render("✓"))
+	}
+	return nil
+}
+
+// respawnSessions starts each session in order that isn't already running
+// and returns how many failed.
+func respawnSessions(t *tmux.Tmux, townRoot string, sessions []string) int {
+	failed := 0
+	for _, sess := range sessions {
+		if ok, _ := t.HasSession(sess); ok {
+			continue
+		}
+		identity, err := session.ParseSessionName(sess)
+		if err != nil {
+			continue
+		}
+		if err := startAgentSession(townRoot, identity); err != nil {
+			fmt.Printf("  %s %s failed: %v\n", style.Dim.Render("○"), identity.Address(), err)
+			failed++
+			continue
+		}
+		fmt.Printf("  %s %s started\n", style.Bold.Render("✓"), identity.Address())
+	}
+	return failed
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func TestRestartSessions(t *testing.T) {
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	old := session.DefaultRegistry()
+	session.SetDefaultRegistry(reg)
+	defer session.SetDefaultRegistry(old)
+
+	crew := session.CrewSessionName("gt", "max")
+	witness := session.WitnessSessionName("gt")
+	sessions := []string{"scratch", session.MayorSessionName(), witness, crew}
+
+	if got, want := restartSessions(sessions, false), []string{session.MayorSessionName(), witness}; !reflect.DeepEqual(got, want) {
+		t.Errorf("restartSessions(all=false) = %v, want %v", got, want)
+	}
+	if got, want := restartSessions(sessions, true), []string{session.MayorSessionName(), witness, crew}; !reflect.DeepEqual(got, want) {
+		t.Errorf("restartSessions(all=true) = %v, want %v", got, want)
+	}
+}
+
+func TestRestartStartOrder(t *testing.T) {
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	old := session.DefaultRegistry()
+	session.SetDefaultRegistry(reg)
+	defer session.SetDefaultRegistry(old)
+
+	mayorSession := session.MayorSessionName()
+	deaconSession := session.DeaconSessionName()
+	polecat := session.PolecatSessionName("gt", "Toast")
+	crew := session.CrewSessionName("gt", "max")
+	refinery := session.RefinerySessionName("gt")
+	witness := session.WitnessSessionName("gt")
+
+	got := restartStartOrder([]string{polecat, deaconSession, crew, refinery, session.BootSessionName(), witness, mayorSession}, mayorSession, deaconSession)
+	want := []string{witness, refinery, crew, polecat}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("restartStartOrder() = %v, want %v", got, want)
+	}
+}
@@ -74,10 +74,15 @@ Use --adopt to register an existing directory instead of creating new:
   - Auto-detects git URL from origin remote (git-url argument not required)
   - Adds entry to mayor/rigs.json
 
+To build a rig around a repo you already have checked out, without cloning
+it again, use 'gt rig import <path>'.
+
 Example:
   gt rig add gastown https://github.com/steveyegge/gastown
   gt rig add my_project git@github.com:user/repo.git --prefix mp
-  gt rig add existing_rig --adopt`,
+  gt rig add existing_rig --adopt
+  gt rig add heavy git@github.com:user/heavy.git --remote-host build-01
+  gt rig add monorepo git@github.com:user/mono.git --depth 50 --filter blob:none`,
 	Args: cobra.RangeArgs(1, 2),
 	RunE: runRigAdd,
 }
@@ -101,22 +106,27 @@ Examples:
 
 var rigRemoveCmd = &cobra.Command{
 	Use:   "remove <name>",
-	Short: "Remove a rig from the registry (does not delete files)",
+	Short: "Remove a rig from the registry (--delete to delete its files)",
 	Long: `Remove a rig from the Gas Town registry.
 
-This only removes the rig entry from mayor/rigs.json and cleans up
-the beads route. The rig's files on disk are NOT deleted.
+This removes the rig entry from mayor/rigs.json and cleans up the beads
+route and daemon.json patrols. By default the rig's files on disk are
+NOT deleted.
+
+With --delete, the rig is torn down completely: its directory (mayor
+clone, refinery, witness, polecat worktrees, crew, rig beads) and its
+hooks overrides are deleted too. Polecats with uncommitted or unpushed
+work block deletion; --force asks for confirmation instead, --nuclear
+skips the check.
 
 If the rig has running tmux sessions (witness, refinery, polecats, crew),
 you must shut them down first with 'gt rig shutdown' or use --force to
 kill them automatically.
 
-To fully remove a rig, delete the directory manually after unregistering.
-
 Examples:
   gt rig remove myproject                    # Unregister (fails if sessions running)
   gt rig remove myproject --force            # Kill sessions then unregister
-  gt rig remove myproject && rm -rf myproject # Unregister and delete files`,
+  gt rig remove myproject --delete           # Unregister and delete all files`,
 	Args: cobra.ExactArgs(1),
 	RunE: runRigRemove,
 }
@@ -304,7 +314,9 @@ var (
 	rigAddAdoptURL       string
 	rigAddAdoptForce     bool
 	rigAddFilter         string
+	rigAddDepth          int
 	rigAddSparseCheckout []string
+	rigAddRemoteHost     string
 	rigResetHandoff    bool
 	rigResetMail       bool
 	rigResetStale      bool
@@ -320,6 +332,8 @@ var (
 	rigRestartNuclear  bool
 	rigListJSON        bool
 	rigRemoveForce     bool
+	rigRemoveDelete    bool
+	rigRemoveNuclear   bool
 )
 
 var (
@@ -357,6 +371,8 @@ func init() {
 	rigListCmd.Flags().BoolVar(&rigListJSON, "json", false, "Output as JSON")
 
 	rigRemoveCmd.Flags().BoolVarP(&rigRemoveForce, "force", "f", false, "Kill running tmux sessions before removing (may lose uncommitted work)")
+	rigRemoveCmd.Flags().BoolVar(&rigRemoveDelete, "delete", false, "Also delete the rig directory and its hooks overrides")
+	rigRemoveCmd.Flags().BoolVar(&rigRemoveNuclear, "nuclear", false, "With --delete, skip the uncommitted work check (DANGER: will lose work!)")
 
 	rigAddCmd.Flags().StringVar(&rigAddPrefix, "prefix", "", "Beads issue prefix (default: derived from name)")
 	rigAddCmd.Flags().StringVar(&rigAddLocalRepo, "local-repo", "", "Local repo path to share git objects (optional)")
@@ -367,7 +383,9 @@ func init() {
 	rigAddCmd.Flags().StringVar(&rigAddAdoptURL, "url", "", "Git remote URL for --adopt (default: auto-detected from origin)")
 	rigAddCmd.Flags().BoolVar(&rigAddAdoptForce, "force", false, "With --adopt, register even if git remote cannot be detected")
 	rigAddCmd.Flags().StringVar(&rigAddFilter, "filter", "", "Partial clone filter (e.g. \"blob:none\", \"tree:0\") to reduce clone size")
+	rigAddCmd.Flags().IntVar(&rigAddDepth, "depth", 0, "Clone only the last N commits of history (combines with --filter); deepen later with 'gt rig deepen'")
 	rigAddCmd.Flags().StringSliceVar(&rigAddSparseCheckout, "sparse-checkout", nil, "Sparse checkout paths (cone mode); comma-separated or repeated")
+	rigAddCmd.Flags().StringVar(&rigAddRemoteHost, "remote-host", "", "Run polecat sessions on this ssh host (needs tmux, gt, and the town at the same path)")
 
 	rigResetCmd.Flags().BoolVar(&rigResetHandoff, "handoff", false, "Clear handoff content")
 	rigResetCmd.Flags().BoolVar(&rigResetMail, "mail", false, "Clear stale mail messages")
@@ -475,6 +493,41 @@ func checkUncommittedWork(r *rig.Rig, rigName, operation string, force bool) (pr
 	return confirmUnsafeProceed(force)
 }
 
+// checkCrewWork checks a rig's crew workspaces for uncommitted changes,
+// stashes or unpushed commits, which deleting the rig would destroy.
+// Returns true if the caller should proceed; prompts like
+// checkUncommittedWork when force is set.
+func checkCrewWork(rigPath, rigName, operation string, force bool) (proceed bool) {
+	entries, _ := os.ReadDir(filepath.Join(rigPath, "crew"))
+	var problems []string
+	for _, e := range entries {
+		dir := filepath.Join(rigPath, "crew", e.Name())
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+			continue
+		}
+		status, err := checkPolecatWorkStatus(dir)
+		switch {
+		case err != nil:
+			problems = append(problems, fmt.Sprintf("%s: could not check: %v", style.Bold.Render(e.Name()), err))
+		case !status.Clean():
+			problems = append(problems, fmt.Sprintf("%s: %s", style.Bold.Render(e.Name()), status.String()))
+		}
+	}
+	if len(problems) == 0 {
+		return true
+	}
+
+	fmt.Printf("\n%s Cannot %s %s - crew workspaces have uncommitted or unpushed work:\n",
+		style.Warning.Render("⚠"), operation, rigName)
+	for _, p := range problems {
+		fmt.Printf("  %s\n", p)
+	}
+	return confirmUnsafeProceed(force)
+}
+
 func runRigAdd(cmd *cobra.Command, args []string) error {
 	name := args[0]
 
@@ -493,6 +546,19 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid git URL %q: expected a remote URL (e.g. https://, git@host:, ssh://, s3://, file:///abs/path)\n\nTo use a local repo as the source, pass a file:// URL. To register an already-assembled rig directory, use:\n  gt rig add %s --adopt", gitURL, name)
 	}
 
+	return createRig(name, gitURL, nil)
+}
+
+// rigImport is an existing checkout 'gt rig import' adopts as the new rig's
+// mayor clone.
+type rigImport struct {
+	path string // checkout to adopt
+	link bool   // symlink it instead of moving it
+}
+
+// createRig creates and registers rig name from gitURL: 'gt rig add', or
+// with imp, 'gt rig import'.
+func createRig(name, gitURL string, imp *rigImport) error {
 	// Ensure beads (bd) is available before proceeding
 	if err := deps.EnsureBeads(true); err != nil {
 		return fmt.Errorf("beads dependency check failed: %w", err)
@@ -521,6 +587,9 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("Creating rig %s...\n", style.Bold.Render(name))
 	fmt.Printf("  Repository: %s\n", gitURL)
+	if imp != nil {
+		fmt.Printf("  Importing:  %s\n", imp.path)
+	}
 	if rigAddLocalRepo != "" {
 		fmt.Printf("  Local repo: %s\n", rigAddLocalRepo)
 	}
@@ -531,6 +600,12 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid push URL %q: expected a remote URL (e.g. https://, git@host:, ssh://, s3://)", rigAddPushURL)
 	}
 
+	// Validate remote host if provided
+	rigAddRemoteHost = strings.TrimSpace(rigAddRemoteHost)
+	if !isValidRemoteHost(rigAddRemoteHost) {
+		return fmt.Errorf("invalid remote host %q: expected an ssh destination (e.g. build-01, user@host)", rigAddRemoteHost)
+	}
+
 	// Validate upstream URL if provided
 	rigAddUpstreamURL = strings.TrimSpace(rigAddUpstreamURL)
 	if rigAddUpstreamURL != "" && !isGitRemoteURL(rigAddUpstreamURL) {
@@ -552,6 +627,12 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 		}
 		fmt.Printf("  Partial clone: --filter=%s\n", rigAddFilter)
 	}
+	if rigAddDepth < 0 {
+		return fmt.Errorf("invalid --depth %d: must not be negative", rigAddDepth)
+	}
+	if rigAddDepth > 0 {
+		fmt.Printf("  Shallow clone: --depth=%d\n", rigAddDepth)
+	}
 	if len(rigAddSparseCheckout) > 0 {
 		fmt.Printf("  Sparse checkout: %v\n", rigAddSparseCheckout)
 	}
@@ -559,7 +640,7 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 	startTime := time.Now()
 
 	// Add the rig
-	newRig, err := mgr.AddRig(rig.AddRigOptions{
+	opts := rig.AddRigOptions{
 		Name:           name,
 		GitURL:         gitURL,
 		PushURL:        rigAddPushURL,
@@ -568,12 +649,20 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 		LocalRepo:      rigAddLocalRepo,
 		DefaultBranch:  rigAddBranch,
 		CloneFilter:    rigAddFilter,
+		CloneDepth:     rigAddDepth,
 		SparseCheckout: rigAddSparseCheckout,
-	})
+	}
+	if imp != nil {
+		opts.ImportPath = imp.path
+		opts.ImportLink = imp.link
+	}
+	newRig, err := mgr.AddRig(opts)
 	if err != nil {
 		return fmt.Errorf("adding rig: %w", err)
 	}
 
+	setRigRemoteHost(rigsConfig, name, rigAddRemoteHost)
+
 	// Save updated rigs config
 	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
 		return fmt.Errorf("saving rigs config: %w", err)
@@ -658,10 +747,7 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 	elapsed := time.Since(startTime)
 
 	// Read default branch from rig config
-	defaultBranch := "main"
-	if rigCfg, err := rig.LoadRigConfig(filepath.Join(townRoot, name)); err == nil && rigCfg.DefaultBranch != "" {
-		defaultBranch = rigCfg.DefaultBranch
-	}
+	defaultBranch := rig.ResolveDefaultBranch(filepath.Join(townRoot, name))
 
 	fmt.Printf("\n%s Rig created in %.1fs\n", style.Success.Render("✓"), elapsed.Seconds())
 	fmt.Printf("\nStructure:\n")
@@ -670,7 +756,13 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  ├── .repo.git/        (shared bare repo for refinery+polecats)\n")
 	fmt.Printf("  ├── .beads/           (prefix: %s)\n", newRig.Config.Prefix)
 	fmt.Printf("  ├── plugins/          (rig-level plugins)\n")
-	fmt.Printf("  ├── mayor/rig/        (clone: %s)\n", defaultBranch)
+	if imp != nil && imp.link {
+		fmt.Printf("  ├── mayor/rig/        (link: %s)\n", imp.path)
+	} else if imp != nil {
+		fmt.Printf("  ├── mayor/rig/        (imported from %s)\n", imp.path)
+	} else {
+		fmt.Printf("  ├── mayor/rig/        (clone: %s)\n", defaultBranch)
+	}
 	fmt.Printf("  ├── refinery/rig/     (worktree: %s, sees polecat branches)\n", defaultBranch)
 	fmt.Printf("  ├── crew/             (empty - add crew with 'gt crew add')\n")
 	fmt.Printf("  ├── witness/\n")
@@ -690,6 +782,7 @@ func runRigAdd(cmd *cobra.Command, args []string) error {
 //   - ⚫ = nothing running (stopped)
 //   - 🅿️ = parked (intentionally paused)
 //   - 🛑 = docked (global shutdown)
+//   - 🚧 = disabled for maintenance
 func GetRigLED(hasWitness, hasRefinery bool, opState string) string {
 	// Check operational state FIRST — parked/docked overrides session state.
 	// Sessions may still be running during the race window after park/dock
@@ -699,6 +792,8 @@ func GetRigLED(hasWitness, hasRefinery bool, opState string) string {
 		return "🅿️"
 	case "DOCKED":
 		return "🛑"
+	case "DISABLED":
+		return "🚧"
 	}
 
 	if hasWitness && hasRefinery {
@@ -711,7 +806,7 @@ func GetRigLED(hasWitness, hasRefinery bool, opState string) string {
 }
 
 // rigStatePriority returns a sort priority for a rig's state.
-// Lower values sort first: active > partial > stopped > parked > docked.
+// Lower values sort first: active > partial > stopped > parked > docked > disabled.
 func rigStatePriority(hasWitness, hasRefinery bool, opState string) int {
 	if hasWitness && hasRefinery {
 		return 0
@@ -724,6 +819,8 @@ func rigStatePriority(hasWitness, hasRefinery bool, opState string) int {
 		return 3
 	case "DOCKED":
 		return 4
+	case "DISABLED":
+		return 5
 	default:
 		return 2
 	}
@@ -948,6 +1045,10 @@ func runRigMenu(cmd *cobra.Command, args []string) error {
 			menuArgs = append(menuArgs,
 				"   Undock", "", fmt.Sprintf("run-shell 'gt rig undock %s'", r.name),
 			)
+		} else if r.opState == "DISABLED" {
+			menuArgs = append(menuArgs,
+				"   Enable", "", fmt.Sprintf("run-shell 'gt rig enable %s'", r.name),
+			)
 		} else {
 			// Stopped but not parked/docked
 			menuArgs = append(menuArgs,
@@ -955,8 +1056,8 @@ func runRigMenu(cmd *cobra.Command, args []string) error {
 			)
 		}
 
-		// Park/dock available for non-parked/docked rigs
-		if r.opState != "PARKED" && r.opState != "DOCKED" {
+		// Park/dock available for non-parked/docked/disabled rigs
+		if r.opState != "PARKED" && r.opState != "DOCKED" && r.opState != "DISABLED" {
 			menuArgs = append(menuArgs,
 				"   Park", "", fmt.Sprintf("run-shell 'gt rig park %s'", r.name),
 			)
@@ -1004,6 +1105,30 @@ func runRigRemove(cmd *cobra.Command, args []string) error {
 	g := git.NewGit(townRoot)
 	mgr := rig.NewManager(townRoot, rigsConfig, g)
 
+	// Deleting files loses polecat and crew work for good: check before
+	// touching sessions or the registry. An unregistered rig is refused by
+	// RemoveRig below, before anything is deleted.
+	if rigRemoveDelete && !rigRemoveNuclear {
+		r, err := mgr.GetRig(name)
+		switch {
+		case err == nil:
+			if !checkUncommittedWork(r, name, "delete", rigRemoveForce) {
+				return fmt.Errorf("aborted: rig %s has polecats with uncommitted work", name)
+			}
+		case !errors.Is(err, rig.ErrRigNotFound):
+			if !rigRemoveForce {
+				return fmt.Errorf("loading rig %s to check for uncommitted work: %w (use --force to delete anyway)", name, err)
+			}
+			fmt.Printf("%s Could not load rig %s to check polecats: %v (proceeding due to --force)\n",
+				style.Warning.Render("⚠"), name, err)
+		}
+		if !errors.Is(err, rig.ErrRigNotFound) {
+			if !checkCrewWork(filepath.Join(townRoot, name), name, "delete", rigRemoveForce) {
+				return fmt.Errorf("aborted: rig %s has crew workspaces with uncommitted or unpushed work", name)
+			}
+		}
+	}
+
 	// Check for running tmux sessions before removing
 	t := tmux.NewTmux()
 	sessions, sessErr := findRigSessions(t, name)
@@ -1085,9 +1210,33 @@ func runRigRemove(cmd *cobra.Command, args []string) error {
 	}
 
 	fmt.Printf("%s Rig %s removed from registry\n", style.Success.Render("✓"), name)
-	fmt.Printf("\nNote: Files at %s were NOT deleted.\n", filepath.Join(townRoot, name))
-	fmt.Printf("To delete: %s\n", style.Dim.Render(fmt.Sprintf("rm -rf %s", filepath.Join(townRoot, name))))
 
+	rigPath := filepath.Join(townRoot, name)
+	if !rigRemoveDelete {
+		fmt.Printf("\nNote: Files at %s were NOT deleted.\n", rigPath)
+		fmt.Printf("To delete: %s\n", style.Dim.Render(fmt.Sprintf("gt rig remove %s --delete", name)))
+		return nil
+	}
+	if err := removeRigDirectory(townRoot, name); err != nil {
+		return err
+	}
+	fmt.Printf("%s Deleted %s\n", style.Success.Render("✓"), rigPath)
+
+	return nil
+}
+
+// removeRigDirectory deletes a rig's files after it was unregistered,
+// along with its hooks overrides.
+func removeRigDirectory(townRoot, name string) error {
+	rigPath := filepath.Join(townRoot, name)
+	if err := os.RemoveAll(rigPath); err != nil {
+		return fmt.Errorf("deleting %s: %w", rigPath, err)
+	}
+	if targets, err := hooks.RemoveRigOverrides(name); err != nil {
+		fmt.Printf("  %s Could not remove hooks overrides: %v\n", style.Warning.Render("!"), err)
+	} else if len(targets) > 0 {
+		fmt.Printf("  Removed hooks overrides: %s\n", strings.Join(targets, ", "))
+	}
 	return nil
 }
 
@@ -1143,6 +1292,12 @@ func runRigAdopt(_ *cobra.Command, args []string) error {
 		return fmt.Errorf("invalid push URL %q: expected a remote URL (e.g. https://, git@host:, ssh://, s3://, file:///abs/path)", rigAddPushURL)
 	}
 
+	// Validate --remote-host if provided
+	rigAddRemoteHost = strings.TrimSpace(rigAddRemoteHost)
+	if !isValidRemoteHost(rigAddRemoteHost) {
+		return fmt.Errorf("invalid remote host %q: expected an ssh destination (e.g. build-01, user@host)", rigAddRemoteHost)
+	}
+
 	// Validate --upstream-url if provided
 	rigAddUpstreamURL = strings.TrimSpace(rigAddUpstreamURL)
 	if rigAddUpstreamURL != "" && !isGitRemoteURL(rigAddUpstreamURL) {
@@ -1161,6 +1316,7 @@ func runRigAdopt(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("adopting rig: %w", err)
 	}
+	setRigRemoteHost(rigsConfig, name, rigAddRemoteHost)
 
 	// Save updated config
 	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
@@ -1600,7 +1756,7 @@ func runRigBoot(cmd *cobra.Command, args []string) error {
 
 	// Check if rig is parked or docked (uses bead labels + wisp state)
 	if blocked, reason := IsRigParkedOrDocked(townRoot, rigName); blocked {
-		return fmt.Errorf("rig '%s' is %s - use 'gt rig unpark' or 'gt rig undock' first", rigName, reason)
+		return fmt.Errorf("rig '%s' is %s - use '%s %s' first", rigName, reason, rigResumeCmd(reason), rigName)
 	}
 
 	fmt.Printf("Booting rig %s...\n", style.Bold.Render(rigName))
@@ -1687,8 +1843,8 @@ func runRigStart(cmd *cobra.Command, args []string) error {
 
 		// Check if rig is parked or docked (uses bead labels + wisp state)
 		if blocked, reason := IsRigParkedOrDocked(townRoot, rigName); blocked {
-			fmt.Printf("%s Rig '%s' is %s - skipping (use 'gt rig unpark' or 'gt rig undock' first)\n",
-				style.Warning.Render("⚠"), rigName, reason)
+			fmt.Printf("%s Rig '%s' is %s - skipping (use '%s %s' first)\n",
+				style.Warning.Render("⚠"), rigName, reason, rigResumeCmd(reason), rigName)
 			continue
 		}
 
@@ -1897,6 +2053,11 @@ func runRigStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Status: %s (%s)\n", style.Warning.Render(opState), opSource)
 	} else if opState == "DOCKED" {
 		fmt.Printf("  Status: %s (%s)\n", style.Dim.Render(opState), opSource)
+	} else if opState == "DISABLED" {
+		fmt.Printf("  Status: %s (%s)\n", style.Warning.Render(opState), opSource)
+		if _, reason := config.IsRigDisabled(townRoot, rigName); reason != "" {
+			fmt.Printf("  Reason: %s\n", reason)
+		}
 	}
 
 	fmt.Printf("  Path: %s\n", r.Path)
@@ -2266,9 +2427,15 @@ func runRigRestart(cmd *cobra.Command, args []string) error {
 }
 
 // getRigOperationalState returns the operational state and source for a rig.
-// It checks the wisp layer first (local/ephemeral), then rig bead labels (global).
-// Returns state ("OPERATIONAL", "PARKED", or "DOCKED") and source ("local", "global - synced", or "default").
+// It checks maintenance mode in rigs.json first, then the wisp layer
+// (local/ephemeral), then rig bead labels (global).
+// Returns state ("OPERATIONAL", "PARKED", "DOCKED", or "DISABLED") and source
+// ("rigs.json", "local", "global - synced", or "default").
 func getRigOperationalState(townRoot, rigName string) (state string, source string) {
+	if disabled, _ := config.IsRigDisabled(townRoot, rigName); disabled {
+		return "DISABLED", "rigs.json"
+	}
+
 	// Check wisp layer first (local/ephemeral overrides)
 	wispConfig := wisp.NewConfig(townRoot, rigName)
 	if status := wispConfig.GetString("status"); status != "" {
@@ -2366,6 +2533,11 @@ func findRigSessions(t *tmux.Tmux, rigName string) ([]string, error) {
 // routes.jsonl) to the town repo after rig add/adopt. Without this commit, changes
 // are silently reverted by any process that does a git restore/checkout.
 func commitTownConfigChanges(townRoot, rigName string) {
+	commitTownConfig(townRoot, fmt.Sprintf("chore: register rig %s in town config", rigName))
+}
+
+// commitTownConfig commits the town-level rig config files with msg.
+func commitTownConfig(townRoot, msg string) {
 	g := git.NewGit(townRoot)
 
 	// Collect the town-level files that rig add/adopt modifies.
@@ -2391,7 +2563,6 @@ func commitTownConfigChanges(townRoot, rigName string) {
 		return
 	}
 
-	msg := fmt.Sprintf("chore: register rig %s in town config", rigName)
 	if err := g.Commit(msg); err != nil {
 		// If nothing changed (already committed), git commit returns an error — that's fine.
 		if !strings.Contains(err.Error(), "nothing to commit") {
@@ -2400,6 +2571,30 @@ func commitTownConfigChanges(townRoot, rigName string) {
 	}
 }
 
+// setRigRemoteHost records the ssh host for a rig's polecat sessions in its
+// rigs.json entry. No-op when host is empty.
+func setRigRemoteHost(rigsConfig *config.RigsConfig, name, host string) {
+	if host == "" {
+		return
+	}
+	entry, ok := rigsConfig.Rigs[name]
+	if !ok {
+		return
+	}
+	entry.RemoteHost = host
+	rigsConfig.Rigs[name] = entry
+}
+
+// isValidRemoteHost reports whether s is usable as an ssh destination.
+// Empty is valid (local sessions). Rejects flag-like values and whitespace,
+// which ssh would otherwise parse as options or extra arguments.
+func isValidRemoteHost(s string) bool {
+	if s == "" {
+		return true
+	}
+	return !strings.HasPrefix(s, "-") && !strings.ContainsAny(s, " \t\n'\"")
+}
+
 // isGitRemoteURL returns true if s looks like a remote git URL rather than a
 // local path. Accepts any scheme:// URL (including file:// for explicit local
 // mirrors) as well as SCP-style SSH URLs.
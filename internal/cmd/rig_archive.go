@@ -0,0 +1,388 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/backup"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// rigArchiveDir is where gt rig archive keeps archives, relative to the town.
+var rigArchiveDir = filepath.Join(".archive", "rigs")
+
+var (
+	rigArchiveForce  bool
+	rigArchiveOutput string
+	rigArchiveKeep   bool
+	rigRestoreFrom   string
+)
+
+var rigArchiveCmd = &cobra.Command{
+	Use:   "archive <rig>",
+	Short: "Shut down a rig and pack it away until 'gt rig restore'",
+	Long: `Archive a rig that isn't needed for a while, so it stops costing
+witness, refinery and deacon attention.
+
+Archiving a rig:
+  - Shuts down its agents (polecats, refinery, witness, crew sessions)
+  - Bundles the local branches of its repos (shared bare repo, mayor
+    clone, crew clones) and its state: settings, polecat name pool,
+    overlay and setup hooks
+  - Removes it from mayor/rigs.json, daemon.json patrols and beads routes
+  - Deletes the rig directory (--keep-files to leave it)
+
+The archive goes to .archive/rigs/<rig>-<timestamp>.tar.gz in the town,
+or -o. Uncommitted changes are not archived: polecats or crew with
+uncommitted work block the archive unless --force. Beads issues stay in
+the Dolt server and reappear on restore. Hooks overrides stay in place.
+
+Examples:
+  gt rig archive summer_camp
+  gt rig archive summer_camp -o /mnt/cold/summer_camp.tar.gz`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRigArchive,
+}
+
+var rigRestoreCmd = &cobra.Command{
+	Use:   "restore [rig]",
+	Short: "Bring back a rig archived with 'gt rig archive'",
+	Long: `Restore an archived rig.
+
+The rig is added again from its git URL with its archived settings
+(beads prefix, default branch, push/upstream URLs, remote host, roles),
+its state files are put back, and its archived branches are recreated in
+the shared bare repo and mayor clone. Branches that exist on the remote
+with other commits keep the remote's version; the archived tip is
+reported. Crew branches come back in the shared bare repo as
+crew/<name>/<branch>; recreate crew workspaces with 'gt crew add'.
+
+Without --from, uses the newest archive of the rig in .archive/rigs.
+Without a rig, lists the archives there.
+
+Examples:
+  gt rig restore                     # List archived rigs
+  gt rig restore summer_camp
+  gt rig restore --from /mnt/cold/summer_camp.tar.gz`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runRigRestore,
+}
+
+func init() {
+	rigArchiveCmd.Flags().BoolVarP(&rigArchiveForce, "force", "f", false, "Archive even if polecats or crew have uncommitted work (it is lost)")
+	rigArchiveCmd.Flags().StringVarP(&rigArchiveOutput, "output", "o", "", "Archive path (default: .archive/rigs/<rig>-<timestamp>.tar.gz)")
+	rigArchiveCmd.Flags().BoolVar(&rigArchiveKeep, "keep-files", false, "Unregister the rig but leave its directory on disk")
+	rigRestoreCmd.Flags().StringVar(&rigRestoreFrom, "from", "", "Archive file to restore from")
+	rigCmd.AddCommand(rigArchiveCmd)
+	rigCmd.AddCommand(rigRestoreCmd)
+}
+
+func runRigArchive(cmd *cobra.Command, args []string) error {
+	name := args[0]
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		return fmt.Errorf("loading rigs config: %w", err)
+	}
+	entry, ok := rigsConfig.Rigs[name]
+	if !ok {
+		return fmt.Errorf("rig %q is not registered", name)
+	}
+	rigPath := filepath.Join(townRoot, name)
+
+	if dirty := dirtyCrew(rigPath); len(dirty) > 0 && !rigArchiveForce {
+		fmt.Printf("%s Crew with uncommitted changes (not archived):\n", style.Warning.Render("⚠"))
+		for _, c := range dirty {
+			fmt.Printf("  - %s\n", c)
+		}
+		return fmt.Errorf("refusing to archive rig %s: commit crew work first, or use --force", name)
+	}
+
+	// Polecats, refinery and witness; the uncommitted work check is the
+	// same as for shutdown.
+	rigShutdownForce = rigArchiveForce
+	rigShutdownNuclear = false
+	if err := runRigShutdown(cmd, args); err != nil {
+		return err
+	}
+	t := tmux.NewTmux()
+	sessions, err := findRigSessions(t, name)
+	if err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		if err := t.KillSessionWithProcesses(s); err != nil {
+			return fmt.Errorf("stopping session %s: %w", s, err)
+		}
+		fmt.Printf("  Stopped %s\n", s)
+	}
+
+	out := rigArchiveOutput
+	if out == "" {
+		out = filepath.Join(townRoot, rigArchiveDir, fmt.Sprintf("%s-%s.tar.gz", name, time.Now().Format("20060102T150405")))
+	}
+	opts := backup.RigArchiveOptions{Entry: entry}
+	if rigCfg, err := rig.LoadRigConfig(rigPath); err == nil {
+		opts.DefaultBranch = rigCfg.DefaultBranch
+		if rigCfg.Beads != nil {
+			opts.BeadsPrefix = rigCfg.Beads.Prefix
+		}
+	}
+	if opts.BeadsPrefix == "" && entry.BeadsConfig != nil {
+		opts.BeadsPrefix = entry.BeadsConfig.Prefix
+	}
+	manifest, err := writeRigArchive(out, townRoot, name, opts)
+	if err != nil {
+		return err
+	}
+	var branches int
+	for _, b := range manifest.Bundles {
+		branches += len(b.Branches)
+	}
+	fmt.Printf("%s Archived %s: %d branch(es), %d state file(s) → %s\n",
+		style.Success.Render("✓"), name, branches, len(manifest.Files), out)
+
+	// Unregister, as gt rig remove does.
+	mgr := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot))
+	if err := mgr.RemoveRig(name); err != nil {
+		return fmt.Errorf("removing rig (archive kept at %s): %w", out, err)
+	}
+	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+		return fmt.Errorf("saving rigs config (archive kept at %s): %w", out, err)
+	}
+	if err := config.RemoveRigFromDaemonPatrols(townRoot, name); err != nil {
+		fmt.Printf("  %s Could not update daemon.json patrols: %v\n", style.Warning.Render("!"), err)
+	}
+	if opts.BeadsPrefix != "" {
+		if err := beads.RemoveRoute(townRoot, opts.BeadsPrefix+"-"); err != nil {
+			fmt.Printf("  %s Could not remove route from routes.jsonl: %v\n", style.Warning.Render("!"), err)
+		}
+	}
+	commitTownConfig(townRoot, fmt.Sprintf("chore: archive rig %s", name))
+	fmt.Printf("%s Rig %s removed from registry\n", style.Success.Render("✓"), name)
+
+	if rigArchiveKeep {
+		fmt.Printf("\nNote: Files at %s were NOT deleted.\n", rigPath)
+	} else if err := os.RemoveAll(rigPath); err != nil {
+		return fmt.Errorf("deleting %s: %w", rigPath, err)
+	} else {
+		fmt.Printf("%s Deleted %s\n", style.Success.Render("✓"), rigPath)
+	}
+	fmt.Printf("\nRestore with: %s\n", style.Dim.Render("gt rig restore "+name))
+	return nil
+}
+
+// writeRigArchive archives the rig to out, removing a partial file on error.
+func writeRigArchive(out, townRoot, name string, opts backup.RigArchiveOptions) (*backup.RigManifest, error) {
+	scratch, err := os.MkdirTemp("", "gt-rig-archive-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(scratch)
+	opts.ScratchDir = scratch
+
+	if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+		return nil, fmt.Errorf("creating %s: %w", filepath.Dir(out), err)
+	}
+	f, err := os.OpenFile(out, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600) //nolint:gosec // G304: user-chosen output path
+	if err != nil {
+		return nil, fmt.Errorf("creating archive: %w", err)
+	}
+	manifest, err := backup.ArchiveRig(f, townRoot, name, opts)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		_ = os.Remove(out)
+		return nil, fmt.Errorf("archiving rig %s: %w", name, err)
+	}
+	return manifest, nil
+}
+
+// dirtyCrew lists crew workspaces with uncommitted changes or stashes,
+// which an archive would lose.
+func dirtyCrew(rigPath string) []string {
+	entries, _ := os.ReadDir(filepath.Join(rigPath, "crew"))
+	var dirty []string
+	for _, e := range entries {
+		dir := filepath.Join(rigPath, "crew", e.Name())
+		if !e.IsDir() {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+			continue
+		}
+		status, err := git.NewGit(dir).CheckUncommittedWork()
+		if err != nil || status.HasUncommittedChanges || status.StashCount > 0 {
+			dirty = append(dirty, e.Name())
+		}
+	}
+	return dirty
+}
+
+func runRigRestore(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	archive := rigRestoreFrom
+	if archive == "" {
+		if len(args) == 0 {
+			return listRigArchives(townRoot)
+		}
+		if archive, err = latestRigArchive(townRoot, args[0]); err != nil {
+			return err
+		}
+	}
+
+	manifest, err := readRigArchiveManifest(archive)
+	if err != nil {
+		return err
+	}
+	name := manifest.Rig
+	if len(args) > 0 && args[0] != name {
+		return fmt.Errorf("%s is an archive of rig %s, not %s", archive, name, args[0])
+	}
+	rigPath := filepath.Join(townRoot, name)
+	if _, err := os.Stat(rigPath); err == nil {
+		return fmt.Errorf("%s already exists; move it aside before restoring", rigPath)
+	}
+	fmt.Printf("Restoring rig %s from %s (archived %s)\n\n",
+		style.Bold.Render(name), archive, manifest.CreatedAt.Local().Format("2006-01-02 15:04"))
+
+	// Re-add the rig with its archived settings.
+	entry := manifest.Entry
+	rigAddPrefix = manifest.BeadsPrefix
+	rigAddBranch = manifest.DefaultBranch
+	rigAddPushURL = entry.PushURL
+	rigAddUpstreamURL = entry.UpstreamURL
+	rigAddLocalRepo = entry.LocalRepo
+	rigAddRemoteHost = entry.RemoteHost
+	if err := createRig(name, entry.GitURL, nil); err != nil {
+		return err
+	}
+	if len(entry.Roles) > 0 {
+		rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+		if rigsConfig, err := config.LoadRigsConfig(rigsPath); err == nil {
+			e := rigsConfig.Rigs[name]
+			e.Roles = entry.Roles
+			rigsConfig.Rigs[name] = e
+			if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+				fmt.Printf("  %s Could not restore roles in rigs.json: %v\n", style.Warning.Render("!"), err)
+			}
+		}
+	}
+
+	scratch, err := os.MkdirTemp("", "gt-rig-restore-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(scratch)
+	f, err := os.Open(archive) //nolint:gosec // G304: user-chosen archive
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := backup.ExtractRig(f, rigPath, scratch); err != nil {
+		return fmt.Errorf("extracting archive: %w", err)
+	}
+	fmt.Printf("\n%s Restored %d state file(s)\n", style.Success.Render("✓"), len(manifest.Files))
+
+	var failed bool
+	for _, b := range manifest.Bundles {
+		repo, prefix := filepath.Join(rigPath, b.Repo), ""
+		if crew, ok := strings.CutPrefix(b.Name, "crew-"); ok {
+			repo, prefix = filepath.Join(rigPath, ".repo.git"), "crew/"+crew+"/"
+		}
+		restored, kept, err := backup.RestoreBundle(b, scratch, repo, prefix)
+		if err != nil {
+			fmt.Printf("  %s %s branches: %v\n", style.Warning.Render("!"), b.Name, err)
+			failed = true
+			continue
+		}
+		fmt.Printf("%s %s: restored %d branch(es)\n", style.Success.Render("✓"), b.Repo, len(restored))
+		for _, k := range kept {
+			fmt.Printf("  %s %s exists with other commits; kept it (the archived tip is in %s)\n",
+				style.Dim.Render("·"), k, archive)
+		}
+	}
+	if failed {
+		return errors.New("rig restored, but some branches could not be recreated; the archive is unchanged")
+	}
+	fmt.Printf("\n%s Rig %s restored. Start it with: %s\n", style.Success.Render("✓"), name, style.Dim.Render("gt rig start "+name))
+	return nil
+}
+
+func readRigArchiveManifest(archive string) (*backup.RigManifest, error) {
+	f, err := os.Open(archive) //nolint:gosec // G304: user-chosen archive
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return backup.ReadRigManifest(f)
+}
+
+// rigArchives returns the archives in the town's archive dir, oldest first
+// per rig (timestamps sort lexically).
+func rigArchives(townRoot string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(townRoot, rigArchiveDir, "*.tar.gz"))
+	sort.Strings(matches)
+	return matches, err
+}
+
+// latestRigArchive returns the newest archive of rig name.
+func latestRigArchive(townRoot, name string) (string, error) {
+	all, err := rigArchives(townRoot)
+	if err != nil {
+		return "", err
+	}
+	latest := ""
+	for _, a := range all {
+		if strings.HasPrefix(filepath.Base(a), name+"-") {
+			latest = a
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no archive of rig %s in %s (use --from)", name, filepath.Join(townRoot, rigArchiveDir))
+	}
+	return latest, nil
+}
+
+func listRigArchives(townRoot string) error {
+	all, err := rigArchives(townRoot)
+	if err != nil {
+		return err
+	}
+	if len(all) == 0 {
+		fmt.Println(style.Dim.Render("No archived rigs."))
+		return nil
+	}
+	for _, a := range all {
+		m, err := readRigArchiveManifest(a)
+		if err != nil {
+			fmt.Printf("  %s %s: %v\n", style.Warning.Render("!"), filepath.Base(a), err)
+			continue
+		}
+		fmt.Printf("  %-20s %s  %s\n", m.Rig, m.CreatedAt.Local().Format("2006-01-02 15:04"), style.Dim.Render(a))
+	}
+	return nil
+}
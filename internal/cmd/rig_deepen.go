@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	rigDeepenDepth     int
+	rigDeepenUnshallow bool
+)
+
+var rigDeepenCmd = &cobra.Command{
+	Use:   "deepen <rig>",
+	Short: "Fetch more history into a shallow rig",
+	Long: `Fetch more history into a rig created with 'gt rig add --depth'.
+
+Deepens the rig's shared bare repo (.repo.git) and the mayor's clone by
+--depth commits, or fetches their full history with --unshallow. Repos
+that already have full history are left alone.
+
+The refinery deepens on its own when a merge needs an older merge base,
+so this is only needed when you want the history yourself (git log,
+blame, bisect).
+
+Examples:
+  gt rig deepen gastown --depth 200
+  gt rig deepen gastown --unshallow`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE:         runRigDeepen,
+}
+
+func init() {
+	rigDeepenCmd.Flags().IntVar(&rigDeepenDepth, "depth", 100, "Number of additional commits to fetch")
+	rigDeepenCmd.Flags().BoolVar(&rigDeepenUnshallow, "unshallow", false, "Fetch the complete history")
+	rigCmd.AddCommand(rigDeepenCmd)
+}
+
+func runRigDeepen(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	_, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+	if !rigDeepenUnshallow && rigDeepenDepth <= 0 {
+		return fmt.Errorf("invalid --depth %d: must be positive (or use --unshallow)", rigDeepenDepth)
+	}
+	n := rigDeepenDepth
+	if rigDeepenUnshallow {
+		n = 0
+	}
+
+	type repo struct {
+		label string
+		g     *git.Git
+	}
+	var repos []repo
+	bareRepoPath := filepath.Join(r.Path, ".repo.git")
+	if info, err := os.Stat(bareRepoPath); err == nil && info.IsDir() {
+		repos = append(repos, repo{".repo.git", git.NewGitWithDir(bareRepoPath, "")})
+	}
+	mayorPath := filepath.Join(r.Path, "mayor", "rig")
+	if _, err := os.Stat(mayorPath); err == nil {
+		repos = append(repos, repo{"mayor/rig", git.NewGit(mayorPath)})
+	}
+
+	deepened := 0
+	for _, rp := range repos {
+		shallow, err := rp.g.IsShallow()
+		if err != nil {
+			return fmt.Errorf("checking %s: %w", rp.label, err)
+		}
+		if !shallow {
+			fmt.Printf("  %s %s already has full history\n", style.Dim.Render("•"), rp.label)
+			continue
+		}
+		if err := rp.g.Deepen("origin", n); err != nil {
+			return fmt.Errorf("deepening %s: %w", rp.label, err)
+		}
+		deepened++
+		if n == 0 {
+			fmt.Printf("  %s %s unshallowed\n", style.Success.Render("✓"), rp.label)
+		} else {
+			fmt.Printf("  %s %s deepened by %d commits\n", style.Success.Render("✓"), rp.label, n)
+		}
+	}
+	if deepened == 0 {
+		fmt.Printf("%s Rig %s is not shallow\n", style.Dim.Render("•"), rigName)
+	}
+	return nil
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+var rigDisableReason string
+
+var rigDisableCmd = &cobra.Command{
+	Use:   "disable <rig>",
+	Short: "Take a rig out of rotation for maintenance",
+	Long: `Disable a rig while its repo is being worked on by hand (history
+rewrites, large migrations, re-cloning).
+
+Disabling a rig:
+  - Sets "disabled": true on the rig in mayor/rigs.json
+  - Stops the witness and refinery if running
+  - Blocks sling and convoy dispatch to the rig
+  - Makes the daemon skip the rig in witness and refinery patrols
+  - Makes 'gt start', 'gt up' and 'gt rig start' skip the rig, even
+    with auto_start_on_up set
+
+Running polecats are left to finish; they get no new work. Use
+'gt rig shutdown' to stop them too.
+
+Unlike 'gt rig park', the flag lives in the rig registry, so it survives
+wisp cleanup and is committed with the town config.
+
+Examples:
+  gt rig disable gastown
+  gt rig disable gastown --reason "rewriting history, back Monday"`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRigDisable,
+}
+
+var rigEnableCmd = &cobra.Command{
+	Use:   "enable <rig>",
+	Short: "Put a disabled rig back into rotation",
+	Long: `Enable a rig disabled with 'gt rig disable'.
+
+Clears the flag in mayor/rigs.json so dispatch, patrols and starts
+include the rig again. Does NOT start agents (use 'gt rig start').
+
+Examples:
+  gt rig enable gastown`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRigEnable,
+}
+
+func init() {
+	rigDisableCmd.Flags().StringVar(&rigDisableReason, "reason", "", "Why the rig is disabled (shown in 'gt rig status')")
+	rigCmd.AddCommand(rigDisableCmd)
+	rigCmd.AddCommand(rigEnableCmd)
+}
+
+func runRigDisable(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	townRoot, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	changed, err := setRigDisabled(townRoot, rigName, true, rigDisableReason)
+	if err != nil {
+		return err
+	}
+	if !changed {
+		fmt.Printf("%s Rig %s is already disabled\n", style.Dim.Render("•"), rigName)
+		return nil
+	}
+	commitTownConfig(townRoot, fmt.Sprintf("chore: disable rig %s", rigName))
+
+	fmt.Printf("Disabling rig %s...\n", style.Bold.Render(rigName))
+
+	var stoppedAgents []string
+
+	t := tmux.NewTmux()
+
+	// Stop witness if running
+	witnessSession := session.WitnessSessionName(session.PrefixFor(rigName))
+	if running, _ := t.HasSession(witnessSession); running {
+		fmt.Printf("  Stopping witness...\n")
+		if err := witness.NewManager(r).Stop(); err != nil {
+			fmt.Printf("  %s Failed to stop witness: %v\n", style.Warning.Render("!"), err)
+		} else {
+			stoppedAgents = append(stoppedAgents, "Witness stopped")
+		}
+	}
+
+	// Stop refinery if running
+	refinerySession := session.RefinerySessionName(session.PrefixFor(rigName))
+	if running, _ := t.HasSession(refinerySession); running {
+		fmt.Printf("  Stopping refinery...\n")
+		if err := refinery.NewManager(r).Stop(); err != nil {
+			fmt.Printf("  %s Failed to stop refinery: %v\n", style.Warning.Render("!"), err)
+		} else {
+			stoppedAgents = append(stoppedAgents, "Refinery stopped")
+		}
+	}
+
+	fmt.Printf("%s Rig %s disabled\n", style.Success.Render("✓"), rigName)
+	for _, msg := range stoppedAgents {
+		fmt.Printf("  %s\n", msg)
+	}
+	if infos, err := polecat.NewSessionManager(t, r).List(); err == nil && len(infos) > 0 {
+		fmt.Printf("  %d polecat session(s) still running; stop them with '%s'\n",
+			len(infos), style.Dim.Render("gt rig shutdown "+rigName))
+	}
+	fmt.Printf("  Use '%s' when maintenance is done\n", style.Dim.Render("gt rig enable "+rigName))
+
+	return nil
+}
+
+func runRigEnable(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	townRoot, _, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+
+	changed, err := setRigDisabled(townRoot, rigName, false, "")
+	if err != nil {
+		return err
+	}
+	if !changed {
+		fmt.Printf("%s Rig %s is not disabled\n", style.Dim.Render("•"), rigName)
+		return nil
+	}
+	commitTownConfig(townRoot, fmt.Sprintf("chore: enable rig %s", rigName))
+
+	fmt.Printf("%s Rig %s enabled\n", style.Success.Render("✓"), rigName)
+	fmt.Printf("  Use '%s' to start agents immediately\n", style.Dim.Render("gt rig start "+rigName))
+
+	return nil
+}
+
+// setRigDisabled sets or clears maintenance mode for a rig in rigs.json.
+// Reports whether anything changed.
+func setRigDisabled(townRoot, rigName string, disabled bool, reason string) (bool, error) {
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		return false, fmt.Errorf("loading rigs config: %w", err)
+	}
+	entry, ok := rigsConfig.Rigs[rigName]
+	if !ok {
+		return false, fmt.Errorf("rig '%s' not found", rigName)
+	}
+	if entry.Disabled == disabled {
+		return false, nil
+	}
+
+	entry.Disabled = disabled
+	entry.DisabledReason = ""
+	if disabled {
+		entry.DisabledReason = reason
+	}
+	rigsConfig.Rigs[rigName] = entry
+	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+		return false, fmt.Errorf("saving rigs config: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestSetRigDisabled(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	if err := config.SaveRigsConfig(rigsPath, &config.RigsConfig{
+		Version: 1,
+		Rigs: map[string]config.RigEntry{
+			"surgery": {GitURL: "https://example.com/surgery.git"},
+			"other":   {GitURL: "https://example.com/other.git"},
+		},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	changed, err := setRigDisabled(townRoot, "surgery", true, "history rewrite")
+	if err != nil || !changed {
+		t.Fatalf("setRigDisabled = %v, %v; want changed", changed, err)
+	}
+	if disabled, reason := config.IsRigDisabled(townRoot, "surgery"); !disabled || reason != "history rewrite" {
+		t.Errorf("IsRigDisabled = %v, %q", disabled, reason)
+	}
+	if changed, _ := setRigDisabled(townRoot, "surgery", true, ""); changed {
+		t.Error("disabling a disabled rig should be a no-op")
+	}
+
+	// Every dispatch path goes through IsRigParkedOrDocked; disabled wins
+	// without needing beads or wisp state.
+	if blocked, reason := IsRigParkedOrDocked(townRoot, "surgery"); !blocked || reason != "disabled" {
+		t.Errorf("IsRigParkedOrDocked = %v, %q; want blocked as disabled", blocked, reason)
+	}
+	if got := rigResumeCmd("disabled"); got != "gt rig enable" {
+		t.Errorf("rigResumeCmd(disabled) = %q", got)
+	}
+	if state, source := getRigOperationalState(townRoot, "surgery"); state != "DISABLED" || source != "rigs.json" {
+		t.Errorf("getRigOperationalState = %s, %s", state, source)
+	}
+	rigs := enabledRigs(townRoot, []*rig.Rig{{Name: "surgery"}, {Name: "other"}})
+	if len(rigs) != 1 || rigs[0].Name != "other" {
+		t.Errorf("enabledRigs kept %v", rigs)
+	}
+
+	if changed, err := setRigDisabled(townRoot, "surgery", false, ""); err != nil || !changed {
+		t.Fatalf("enable = %v, %v; want changed", changed, err)
+	}
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := rigsConfig.Rigs["surgery"]; e.Disabled || e.DisabledReason != "" {
+		t.Errorf("entry after enable = %+v", e)
+	}
+
+	if _, err := setRigDisabled(townRoot, "missing", true, ""); err == nil {
+		t.Error("expected an error for an unknown rig")
+	}
+}
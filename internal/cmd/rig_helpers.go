@@ -9,7 +9,10 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/wisp"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
@@ -23,6 +26,10 @@ func checkRigNotParkedOrDocked(rigName string) error {
 		return err
 	}
 
+	if disabled, _ := config.IsRigDisabled(townRoot, rigName); disabled {
+		return fmt.Errorf("rig '%s' is disabled for maintenance - use 'gt rig enable %s' first", rigName, rigName)
+	}
+
 	if IsRigParked(townRoot, rigName) {
 		return fmt.Errorf("rig '%s' is parked - use 'gt rig unpark %s' first", rigName, rigName)
 	}
@@ -64,6 +71,24 @@ func getRig(rigName string) (string, *rig.Rig, error) {
 	return townRoot, r, nil
 }
 
+// tmuxForSession returns a Tmux wrapper for the server that hosts
+// sessionName. Polecats of a rig with a remote host run on that host (see
+// polecat.TmuxForRig); all other sessions are on the local server. Commands
+// that address an existing session (nudge, peek, sling) resolve it here so
+// they reach the server the session was started on.
+func tmuxForSession(sessionName string) *tmux.Tmux {
+	t := tmux.NewTmux()
+	identity, err := session.ParseSessionName(sessionName)
+	if err != nil || identity.Role != session.RolePolecat || identity.Rig == "" {
+		return t
+	}
+	_, r, err := getRig(identity.Rig)
+	if err != nil {
+		return t
+	}
+	return polecat.TmuxForRig(t, r)
+}
+
 // hasRigBeadLabel checks if a rig's identity bead has a specific label.
 // Returns false if the rig config or bead can't be loaded (safe default).
 func hasRigBeadLabel(townRoot, rigName, label string) bool {
@@ -94,8 +119,9 @@ func hasRigBeadLabel(townRoot, rigName, label string) bool {
 	return false
 }
 
-// IsRigParkedOrDocked checks if a rig is parked or docked by any mechanism
-// (wisp ephemeral state or persistent bead labels). Returns (blocked, reason).
+// IsRigParkedOrDocked checks if a rig is disabled, parked or docked by any
+// mechanism (rigs.json, wisp ephemeral state or persistent bead labels).
+// Returns (blocked, reason), where reason is "disabled", "parked" or "docked".
 // This is the single entry point for all dispatch paths (sling, convoy launch,
 // convoy stage) to check rig availability.
 //
@@ -105,6 +131,11 @@ func hasRigBeadLabel(townRoot, rigName, label string) bool {
 // because "gt rig dock" never writes to wisp — it persists exclusively via
 // the rig identity bead's status:docked label.
 func IsRigParkedOrDocked(townRoot, rigName string) (bool, string) {
+	// Maintenance mode in the rig registry wins over everything else
+	if disabled, _ := config.IsRigDisabled(townRoot, rigName); disabled {
+		return true, "disabled"
+	}
+
 	// Check wisp layer first (fast, local) — only relevant for parked state
 	wispCfg := wisp.NewConfig(townRoot, rigName)
 	if wispCfg.GetString(RigStatusKey) == RigStatusParked {
@@ -144,6 +175,18 @@ func IsRigParkedOrDocked(townRoot, rigName string) (bool, string) {
 	return false, ""
 }
 
+// rigResumeCmd returns the command that puts a rig blocked for reason (as
+// returned by IsRigParkedOrDocked) back into rotation.
+func rigResumeCmd(reason string) string {
+	switch reason {
+	case "disabled":
+		return "gt rig enable"
+	case "docked":
+		return "gt rig undock"
+	}
+	return "gt rig unpark"
+}
+
 func rigBeadsPrefix(townRoot, rigPath, rigName string) string {
 	rigsConfigPath := constants.MayorRigsPath(townRoot)
 	if rigsConfig, err := config.LoadRigsConfig(rigsConfigPath); err == nil {
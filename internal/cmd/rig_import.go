@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+var (
+	rigImportName string
+	rigImportURL  string
+	rigImportLink bool
+)
+
+var rigImportCmd = &cobra.Command{
+	Use:   "import <path>",
+	Short: "Add a rig from an existing local checkout",
+	Long: `Add a rig from a repo you already have checked out, instead of cloning
+it again.
+
+The checkout becomes the rig's mayor clone (mayor/rig): it is moved into
+the town, or with --link stays where it is and is symlinked. The shared
+bare repo for the refinery and polecats borrows its objects, so only what
+the checkout lacks is fetched from the remote. The rest of the rig is set
+up as with 'gt rig add': beads, routes, refinery, crew and polecat
+directories.
+
+The rig name defaults to the checkout's directory name (with '-' and '.'
+replaced by '_'), and the git URL to its origin remote. Uncommitted work
+in the checkout is kept. Linked worktrees of a moved checkout are
+re-linked.
+
+Examples:
+  gt rig import ~/src/webapp
+  gt rig import ~/src/my-api --name api --prefix api
+  gt rig import /mnt/data/monorepo --link   # Keep it on its own disk`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRigImport,
+}
+
+func init() {
+	rigImportCmd.Flags().StringVar(&rigImportName, "name", "", "Rig name (default: derived from the checkout's directory name)")
+	rigImportCmd.Flags().StringVar(&rigImportURL, "url", "", "Git remote URL (default: the checkout's origin)")
+	rigImportCmd.Flags().BoolVar(&rigImportLink, "link", false, "Symlink the checkout into the rig instead of moving it")
+	rigImportCmd.Flags().StringVar(&rigAddPrefix, "prefix", "", "Beads issue prefix (default: derived from name)")
+	rigImportCmd.Flags().StringVar(&rigAddBranch, "branch", "", "Default branch name (default: auto-detected from remote)")
+	rigCmd.AddCommand(rigImportCmd)
+}
+
+func runRigImport(cmd *cobra.Command, args []string) error {
+	checkout, err := rig.InspectCheckout(expandTownPath(args[0]))
+	if err != nil {
+		return err
+	}
+
+	name := rigImportName
+	if name == "" {
+		name = rigNameFromDir(checkout.Path)
+	}
+
+	gitURL := rigImportURL
+	if gitURL == "" {
+		gitURL = checkout.GitURL
+	}
+	if gitURL == "" {
+		return fmt.Errorf("%s has no origin remote; pass --url", checkout.Path)
+	}
+	if !isGitRemoteURL(gitURL) {
+		return fmt.Errorf("invalid git URL %q: expected a remote URL (e.g. https://, git@host:, ssh://, file:///abs/path); pass --url", gitURL)
+	}
+
+	if checkout.Dirty {
+		fmt.Printf("%s %s has uncommitted changes; they stay in the rig's mayor clone\n",
+			style.WarningPrefix, checkout.Path)
+	}
+	return createRig(name, gitURL, &rigImport{path: checkout.Path, link: rigImportLink})
+}
+
+// rigNameFromDir derives a rig name from a checkout directory: rig names
+// can't contain '-' or '.', which are common in repo names.
+func rigNameFromDir(dir string) string {
+	name := strings.ToLower(filepath.Base(dir))
+	return strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(name)
+}
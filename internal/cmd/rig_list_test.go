@@ -16,6 +16,7 @@ func TestGetRigLED(t *testing.T) {
 		{"parked partial", true, false, "PARKED", "🅿️"},
 		{"docked no sessions", false, false, "DOCKED", "🛑"},
 		{"docked with sessions", true, true, "DOCKED", "🛑"},
+		{"disabled with sessions", true, true, "DISABLED", "🚧"},
 
 		// Both running - fully active
 		{"both running", true, true, "OPERATIONAL", "🟢"},
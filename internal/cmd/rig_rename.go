@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/hooks"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/suggest"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var rigRenameCmd = &cobra.Command{
+	Use:   "rename <old> <new>",
+	Short: "Rename a rig",
+	Long: `Rename a rig, moving its directory and everything keyed by its name.
+
+Renaming a rig:
+  - Moves <town>/<old> to <town>/<new>
+  - Moves polecat worktrees (polecats/<name>/<old> -> polecats/<name>/<new>)
+  - Repairs git worktree links for the refinery and polecats
+  - Updates the name in the rig's config.json
+  - Re-registers the rig in mayor/rigs.json
+  - Rewrites beads routes that point into the rig
+  - Renames the rig in daemon.json patrols and its hooks overrides
+
+The beads prefix is unchanged, so existing issue IDs keep working.
+The rig must be shut down first: running agents hold the old paths.
+
+Examples:
+  gt rig shutdown myproject
+  gt rig rename myproject webapp
+  gt rig boot webapp`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRigRename,
+}
+
+func init() {
+	rigCmd.AddCommand(rigRenameCmd)
+}
+
+func runRigRename(cmd *cobra.Command, args []string) error {
+	oldName, newName := args[0], args[1]
+
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		return fmt.Errorf("loading rigs config: %w", err)
+	}
+	mgr := rig.NewManager(townRoot, rigsConfig, git.NewGit(townRoot))
+	if !mgr.RigExists(oldName) {
+		suggestions := suggest.FindSimilar(oldName, mgr.ListRigNames(), 3)
+		return fmt.Errorf("renaming rig: %s", suggest.FormatSuggestion("rig", oldName, suggestions, ""))
+	}
+
+	// Running agents have the old paths as their working directories.
+	sessions, err := findRigSessions(tmux.NewTmux(), oldName)
+	if err != nil {
+		return fmt.Errorf("could not verify session state for rig %s: %w", oldName, err)
+	}
+	if len(sessions) > 0 {
+		fmt.Printf("%s Rig %s has %d running tmux session(s):\n",
+			style.Warning.Render("⚠"), oldName, len(sessions))
+		for _, s := range sessions {
+			fmt.Printf("  - %s\n", s)
+		}
+		fmt.Printf("\nShut them down first:\n")
+		fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("gt rig shutdown %s", oldName)))
+		return fmt.Errorf("refusing to rename rig with running sessions")
+	}
+
+	if err := mgr.RenameRig(oldName, newName); err != nil {
+		return fmt.Errorf("renaming rig: %w", err)
+	}
+	fmt.Printf("  Moved %s → %s\n", filepath.Join(townRoot, oldName), filepath.Join(townRoot, newName))
+
+	// Routes and rigs.json change together: if either write fails, put the
+	// directory back so the registry keeps matching the disk.
+	beadsDir := filepath.Join(townRoot, ".beads")
+	routes, err := beads.LoadRoutes(beadsDir)
+	if err != nil {
+		return rollbackRigRename(mgr, oldName, newName, fmt.Errorf("loading routes: %w", err))
+	}
+	renamedRoutes, changed := renameRigRoutes(routes, oldName, newName)
+	if changed {
+		if err := beads.WriteRoutes(beadsDir, renamedRoutes); err != nil {
+			return rollbackRigRename(mgr, oldName, newName, fmt.Errorf("writing routes: %w", err))
+		}
+	}
+	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+		if changed {
+			if rerr := beads.WriteRoutes(beadsDir, routes); rerr != nil {
+				fmt.Printf("  %s Could not restore routes.jsonl: %v\n", style.Warning.Render("!"), rerr)
+			}
+		}
+		return rollbackRigRename(mgr, oldName, newName, fmt.Errorf("saving rigs config: %w", err))
+	}
+	fmt.Printf("  Updated rigs.json and beads routes\n")
+
+	// Non-fatal: both are rebuilt or re-set by hand easily.
+	if err := config.RenameRigInDaemonPatrols(townRoot, oldName, newName); err != nil {
+		fmt.Printf("  %s Could not update daemon.json patrols: %v\n", style.Warning.Render("!"), err)
+	}
+	if targets, err := hooks.RenameRigOverrides(oldName, newName); err != nil {
+		fmt.Printf("  %s Could not rename hooks overrides: %v\n", style.Warning.Render("!"), err)
+	} else if len(targets) > 0 {
+		fmt.Printf("  Renamed hooks overrides: %s\n", strings.Join(targets, ", "))
+	}
+
+	commitTownConfig(townRoot, fmt.Sprintf("chore: rename rig %s to %s in town config", oldName, newName))
+
+	fmt.Printf("%s Rig %s renamed to %s\n", style.Success.Render("✓"), oldName, newName)
+	fmt.Printf("\nNext steps:\n")
+	fmt.Printf("  %s  # Recreate agent beads and settings under the new name\n", style.Dim.Render("gt doctor --fix"))
+	fmt.Printf("  %s\n", style.Dim.Render(fmt.Sprintf("gt rig boot %s", newName)))
+	return nil
+}
+
+// renameRigRoutes points routes into oldName's directory at newName's.
+// Prefixes are unchanged.
+func renameRigRoutes(routes []beads.Route, oldName, newName string) ([]beads.Route, bool) {
+	renamed := make([]beads.Route, len(routes))
+	changed := false
+	for i, r := range routes {
+		if r.Path == oldName || strings.HasPrefix(r.Path, oldName+"/") {
+			r.Path = newName + strings.TrimPrefix(r.Path, oldName)
+			changed = true
+		}
+		renamed[i] = r
+	}
+	return renamed, changed
+}
+
+// rollbackRigRename renames the rig back after a failed registry update
+// and returns cause.
+func rollbackRigRename(mgr *rig.Manager, oldName, newName string, cause error) error {
+	if err := mgr.RenameRig(newName, oldName); err != nil {
+		return errors.Join(cause, fmt.Errorf("rolling back rename (rig is at %s, rigs.json still says %s): %w", newName, oldName, err))
+	}
+	return cause
+}
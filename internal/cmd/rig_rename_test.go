@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestRenameRigRoutes(t *testing.T) {
+	routes := []beads.Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "ab-", Path: "alpha/mayor/rig"},
+		{Prefix: "ax-", Path: "alpha"},
+		{Prefix: "al-", Path: "alphabet/mayor/rig"},
+	}
+	got, changed := renameRigRoutes(routes, "alpha", "beta")
+	if !changed {
+		t.Fatal("expected routes to change")
+	}
+	want := []beads.Route{
+		{Prefix: "hq-", Path: "."},
+		{Prefix: "ab-", Path: "beta/mayor/rig"},
+		{Prefix: "ax-", Path: "beta"},
+		{Prefix: "al-", Path: "alphabet/mayor/rig"},
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("routes = %v, want %v", got, want)
+	}
+	if routes[1].Path != "alpha/mayor/rig" {
+		t.Error("input routes must not be modified")
+	}
+
+	if _, changed := renameRigRoutes(routes, "gamma", "delta"); changed {
+		t.Error("renaming a rig without routes should change nothing")
+	}
+}
+
+func TestRunRigRename(t *testing.T) {
+	townRoot := setupTestTownForConfig(t)
+	t.Setenv("GT_HOME", t.TempDir())
+
+	rigPath := filepath.Join(townRoot, "gtrenamesrc")
+	if err := os.MkdirAll(filepath.Join(rigPath, "witness"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(`{"type":"rig","name":"gtrenamesrc"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig := &config.RigsConfig{Version: 1, Rigs: map[string]config.RigEntry{
+		"gtrenamesrc": {GitURL: "https://example.com/src.git", BeadsConfig: &config.BeadsConfig{Prefix: "gs"}},
+	}}
+	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+		t.Fatal(err)
+	}
+	beadsDir := filepath.Join(townRoot, ".beads")
+	if err := beads.WriteRoutes(beadsDir, []beads.Route{{Prefix: "gs-", Path: "gtrenamesrc/mayor/rig"}}); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(townRoot)
+
+	out := captureStdout(t, func() {
+		if err := runRigRename(rigRenameCmd, []string{"gtrenamesrc", "gtrenamedst"}); err != nil {
+			t.Fatalf("runRigRename: %v", err)
+		}
+	})
+	if !strings.Contains(out, "renamed to gtrenamedst") {
+		t.Errorf("output = %q", out)
+	}
+
+	if _, err := os.Stat(filepath.Join(townRoot, "gtrenamedst", "witness")); err != nil {
+		t.Errorf("rig directory not moved: %v", err)
+	}
+	loaded, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loaded.Rigs["gtrenamesrc"]; ok {
+		t.Error("old name still registered")
+	}
+	if entry, ok := loaded.Rigs["gtrenamedst"]; !ok || entry.BeadsConfig == nil || entry.BeadsConfig.Prefix != "gs" {
+		t.Errorf("new entry = %+v, want the old entry under the new name", entry)
+	}
+	routes, err := beads.LoadRoutes(beadsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(routes) != 1 || routes[0].Path != "gtrenamedst/mayor/rig" {
+		t.Errorf("routes = %v, want gs- routed to gtrenamedst/mayor/rig", routes)
+	}
+}
@@ -64,6 +64,25 @@ func TestIsGitRemoteURL(t *testing.T) {
 	}
 }
 
+func TestIsValidRemoteHost(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"", true},
+		{"build-01", true},
+		{"deploy@build-01.internal", true},
+		{"-oProxyCommand=evil", false},
+		{"host extra-arg", false},
+		{"host'; rm -rf ~", false},
+	}
+	for _, tt := range tests {
+		if got := isValidRemoteHost(tt.input); got != tt.want {
+			t.Errorf("isValidRemoteHost(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
+
 func setupRigTestRegistry(t *testing.T) {
 	t.Helper()
 	reg := session.NewPrefixRegistry()
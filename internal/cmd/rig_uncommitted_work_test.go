@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -198,3 +200,42 @@ func TestCheckUncommittedWork_DirtyForceTTYPrompts(t *testing.T) {
 		t.Fatal("expected proceed=true after force+TTY confirmation")
 	}
 }
+
+func TestCheckCrewWork_UnpushedCrewBlocksWithoutForce(t *testing.T) {
+	rigPath := t.TempDir()
+	for _, name := range []string{"max", "joe"} {
+		if err := os.MkdirAll(filepath.Join(rigPath, "crew", name, ".git"), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stubUncommittedWorkCheckDeps(
+		t,
+		func(*rig.Rig) ([]*polecat.Polecat, error) { return nil, nil },
+		func(dir string) (*git.UncommittedWorkStatus, error) {
+			if filepath.Base(dir) == "max" {
+				return &git.UncommittedWorkStatus{UnpushedCommits: 2}, nil
+			}
+			return &git.UncommittedWorkStatus{}, nil
+		},
+		func() bool { return false },
+		func(string) bool {
+			t.Fatalf("prompt should not be called without --force")
+			return false
+		},
+	)
+
+	var proceed bool
+	output := captureStdout(t, func() {
+		proceed = checkCrewWork(rigPath, "testrig", "delete", false)
+	})
+	if proceed {
+		t.Fatal("expected proceed=false with unpushed crew work")
+	}
+	if !strings.Contains(output, "max") || strings.Contains(output, "joe") {
+		t.Fatalf("expected only max reported, got: %q", output)
+	}
+
+	if !checkCrewWork(t.TempDir(), "testrig", "delete", false) {
+		t.Error("expected proceed=true for a rig without crew")
+	}
+}
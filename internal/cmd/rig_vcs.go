@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/vcs"
+)
+
+var rigVCSCmd = &cobra.Command{
+	Use:   "vcs <rig> [git|jj]",
+	Short: "Show or set the rig's version-control backend",
+	Long: `Show or set how polecat worktrees are managed for a rig.
+
+  git  git worktrees on the rig's shared repo (default)
+  jj   Jujutsu workspaces
+
+Switching to jj creates a jj repo at <rig>/.repo.jj backed by the rig's
+shared .repo.git, so the refinery and the remote keep seeing ordinary git
+branches. Each polecat gets a jj workspace with a bookmark named like the
+git branch it would have had; move the bookmark to the work you want
+merged before 'gt done'.
+
+The rig must have no polecats when switching, since their worktrees belong
+to the current backend. The setting is stored as "vcs" on the rig in
+mayor/rigs.json.
+
+Examples:
+  gt rig vcs gastown
+  gt rig vcs gastown jj`,
+	Args:         cobra.RangeArgs(1, 2),
+	SilenceUsage: true,
+	RunE:         runRigVCS,
+}
+
+func init() {
+	rigCmd.AddCommand(rigVCSCmd)
+}
+
+func runRigVCS(cmd *cobra.Command, args []string) error {
+	rigName := args[0]
+	townRoot, r, err := getRig(rigName)
+	if err != nil {
+		return err
+	}
+	current := vcs.RigKind(r.Path)
+	if len(args) == 1 {
+		fmt.Println(current)
+		return nil
+	}
+
+	kind := args[1]
+	if kind != config.VCSGit && kind != config.VCSJujutsu {
+		return fmt.Errorf("unknown vcs %q (have: %s, %s)", kind, config.VCSGit, config.VCSJujutsu)
+	}
+	if kind == current {
+		fmt.Printf("%s Rig %s already uses %s\n", style.Dim.Render("•"), rigName, kind)
+		return nil
+	}
+	if entries, err := os.ReadDir(filepath.Join(r.Path, "polecats")); err == nil {
+		n := 0
+		for _, e := range entries {
+			if e.IsDir() && e.Name()[0] != '.' {
+				n++
+			}
+		}
+		if n > 0 {
+			return fmt.Errorf("rig %s has %d polecat(s); nuke them before switching from %s to %s", rigName, n, current, kind)
+		}
+	}
+
+	if kind == config.VCSJujutsu {
+		if _, err := exec.LookPath("jj"); err != nil {
+			return fmt.Errorf("jj not found in PATH")
+		}
+		if err := vcs.InitJJ(r.Path); err != nil {
+			return fmt.Errorf("creating jj repo: %w", err)
+		}
+	}
+
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsConfig, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		return fmt.Errorf("loading rigs config: %w", err)
+	}
+	entry, ok := rigsConfig.Rigs[rigName]
+	if !ok {
+		return fmt.Errorf("rig '%s' not found", rigName)
+	}
+	entry.VCS = kind
+	if kind == config.VCSGit {
+		entry.VCS = ""
+	}
+	rigsConfig.Rigs[rigName] = entry
+	if err := config.SaveRigsConfig(rigsPath, rigsConfig); err != nil {
+		return fmt.Errorf("saving rigs config: %w", err)
+	}
+	commitTownConfig(townRoot, fmt.Sprintf("chore: switch rig %s to %s", rigName, kind))
+
+	fmt.Printf("%s Rig %s now uses %s for polecat worktrees\n", style.Success.Render("✓"), rigName, kind)
+	return nil
+}
@@ -26,16 +26,17 @@ const (
 // and detectRole() functions.
 type RoleInfo struct {
 	Role          Role   `json:"role"`
-	Source        string `json:"source"` // "env", "cwd", or "explicit"
+	Source        string `json:"source"` // "manifest", "env", "cwd", or "explicit"
 	Home          string `json:"home"`
 	Rig           string `json:"rig,omitempty"`
 	Polecat       string `json:"polecat,omitempty"`
-	EnvRole       string `json:"env_role,omitempty"`    // Value of GT_ROLE if set
-	CwdRole       Role   `json:"cwd_role,omitempty"`    // Role detected from cwd
-	Mismatch      bool   `json:"mismatch,omitempty"`    // True if env != cwd detection
+	EnvRole       string `json:"env_role,omitempty"`       // Value of GT_ROLE if set
+	CwdRole       Role   `json:"cwd_role,omitempty"`       // Role detected from cwd
+	Mismatch      bool   `json:"mismatch,omitempty"`       // True if env != cwd detection
 	EnvIncomplete bool   `json:"env_incomplete,omitempty"` // True if env was set but missing rig/polecat, filled from cwd
 	TownRoot      string `json:"town_root,omitempty"`
-	WorkDir       string `json:"work_dir,omitempty"`    // Current working directory
+	WorkDir       string `json:"work_dir,omitempty"` // Current working directory
+	Manifest      string `json:"manifest,omitempty"` // Path of the .gt/role.json that set the role
 }
 
 var roleCmd = &cobra.Command{
@@ -45,10 +46,15 @@ var roleCmd = &cobra.Command{
 	Long: `Display the current agent role and its detection source.
 
 Role is determined by:
-1. GT_ROLE environment variable (authoritative if set)
-2. Current working directory (fallback)
+1. .gt/role.json manifest in the current directory or an ancestor
+   below the town root (authoritative if present)
+2. GT_ROLE environment variable (authoritative if set)
+3. Current working directory (fallback)
 
-If both are available and disagree, a warning is shown.`,
+A manifest declares the role for layouts path detection can't read:
+  {"role": "polecat", "rig": "gastown", "polecat": "toast"}
+
+If GT_ROLE and the cwd disagree, a warning is shown.`,
 	RunE: runRoleShow,
 }
 
@@ -92,7 +98,8 @@ var roleListCmd = &cobra.Command{
 	Short: "List all known roles",
 	Long: `List all known Gas Town agent roles and their descriptions.
 
-Roles include mayor, deacon, witness, refinery, polecat, and crew.
+Roles include mayor, deacon, witness, refinery, polecat, and crew, plus
+any custom roles defined under "roles" in mayor/town.json.
 Each role has a specific scope and responsibilities within the
 Gas Town multi-agent architecture.`,
 	RunE: runRoleList,
@@ -183,8 +190,29 @@ func GetRoleWithContext(cwd, townRoot string) (RoleInfo, error) {
 	cwdCtx := detectRole(cwd, townRoot)
 	info.CwdRole = cwdCtx.Role
 
+	// A role manifest is explicit, so it beats path heuristics and a
+	// hand-exported GT_ROLE. A GT_ROLE gt set for the session wins over it.
+	var manifest *roleManifest
+	var manifestPath string
+	if !roleEnvSetByGT(townRoot) {
+		var err error
+		if manifest, manifestPath, err = findRoleManifest(cwd, townRoot); err != nil {
+			return info, err
+		}
+	}
+
 	// Determine authoritative role
-	if envRole != "" {
+	if manifest != nil {
+		role, rig, polecat, err := manifest.resolve()
+		if err != nil {
+			return info, fmt.Errorf("role manifest %s: %w", manifestPath, err)
+		}
+		info.Role = role
+		info.Rig = rig
+		info.Polecat = polecat
+		info.Source = "manifest"
+		info.Manifest = manifestPath
+	} else if envRole != "" {
 		// Parse env role - it might be simple ("mayor") or compound ("gastown/witness")
 		parsedRole, rig, polecat := parseRoleString(envRole)
 		info.Role = parsedRole
@@ -292,6 +320,15 @@ func detectRole(cwd, townRoot string) RoleInfo {
 		return ctx
 	}
 
+	// Check for town-scoped custom roles: <dir>/<name>/
+	if len(parts) >= 2 {
+		if name, _, ok := config.CustomRoleForDir(parts[0], true); ok {
+			ctx.Role = Role(name)
+			ctx.Polecat = parts[1]
+			return ctx
+		}
+	}
+
 	// At this point, first part should be a rig name
 	if len(parts) < 1 {
 		return ctx
@@ -331,6 +368,15 @@ func detectRole(cwd, townRoot string) RoleInfo {
 		return ctx
 	}
 
+	// Check for rig-scoped custom roles: <rig>/<dir>/<name>/
+	if len(parts) >= 3 {
+		if name, _, ok := config.CustomRoleForDir(parts[1], false); ok {
+			ctx.Role = Role(name)
+			ctx.Polecat = parts[2]
+			return ctx
+		}
+	}
+
 	// Default: could be rig root - treat as unknown
 	return ctx
 }
@@ -364,6 +410,11 @@ func parseRoleString(s string) (Role, string, string) {
 		return Role(s), "", ""
 	}
 
+	// Town-scoped custom role: role/name
+	if cfg, ok := lookupCustomRole(Role(parts[0])); ok && cfg.IsTownScoped() && len(parts) == 2 {
+		return Role(parts[0]), "", parts[1]
+	}
+
 	rig := parts[0]
 
 	switch parts[1] {
@@ -388,6 +439,13 @@ func parseRoleString(s string) (Role, string, string) {
 		}
 		return RoleCrew, rig, ""
 	default:
+		// Rig-scoped custom role: rig/role/name
+		if cfg, ok := lookupCustomRole(Role(parts[1])); ok && !cfg.IsTownScoped() {
+			if len(parts) >= 3 {
+				return Role(parts[1]), rig, parts[2]
+			}
+			return Role(parts[1]), rig, ""
+		}
 		// Might be rig/polecatName format
 		return RolePolecat, rig, parts[1]
 	}
@@ -467,7 +525,7 @@ func getRoleHome(role Role, rig, polecat, townRoot string) string {
 		}
 		return filepath.Join(townRoot, "deacon", "dogs", polecat)
 	default:
-		return ""
+		return customRoleHome(role, rig, polecat, townRoot)
 	}
 }
 
@@ -480,6 +538,9 @@ func runRoleShow(cmd *cobra.Command, args []string) error {
 	// Header
 	fmt.Printf("%s\n", style.Bold.Render(string(info.Role)))
 	fmt.Printf("Source: %s\n", info.Source)
+	if info.Manifest != "" {
+		fmt.Printf("Manifest: %s\n", info.Manifest)
+	}
 
 	if info.Home != "" {
 		fmt.Printf("Home: %s\n", info.Home)
@@ -598,6 +659,21 @@ func runRoleDetect(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// A manifest overrides both
+	if m, path, err := findRoleManifest(cwd, townRoot); err != nil {
+		fmt.Println()
+		fmt.Printf("%s %v\n", style.Bold.Render("⚠️  Invalid role manifest:"), err)
+	} else if m != nil {
+		fmt.Println()
+		fmt.Printf("%s\n", style.Bold.Render("ℹ️  Role manifest present"))
+		fmt.Printf("  %s declares role=%q\n", path, m.Role)
+		if roleEnvSetByGT(townRoot) {
+			fmt.Println("  $GT_ROLE was set by gt for this session, so it takes precedence over the manifest.")
+		} else {
+			fmt.Println("  The manifest takes precedence over $GT_ROLE and cwd in normal operation.")
+		}
+	}
+
 	return nil
 }
 
@@ -614,6 +690,18 @@ func runRoleList(cmd *cobra.Command, args []string) error {
 		{RoleCrew, "Persistent worker with own worktree"},
 	}
 
+	for _, name := range config.CustomRoleNames() {
+		cfg, _ := config.LookupCustomRole(name)
+		desc := cfg.Description
+		if desc == "" {
+			desc = "Custom role (town.json)"
+		}
+		roles = append(roles, struct {
+			name Role
+			desc string
+		}{Role(name), desc})
+	}
+
 	fmt.Println("Available roles:")
 	fmt.Println()
 	for _, r := range roles {
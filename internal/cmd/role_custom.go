@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/cli"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// lookupCustomRole returns the town.json definition of role, if it is a
+// custom role.
+func lookupCustomRole(role Role) (*config.CustomRoleConfig, bool) {
+	return config.LookupCustomRole(string(role))
+}
+
+// customRoleHome returns the home directory of a custom role's worker, or ""
+// if the identity is incomplete.
+func customRoleHome(role Role, rig, name, townRoot string) string {
+	cfg, ok := lookupCustomRole(role)
+	if !ok || name == "" {
+		return ""
+	}
+	dir := cfg.WorkerDir(string(role))
+	if cfg.IsTownScoped() {
+		return filepath.Join(townRoot, dir, name)
+	}
+	if rig == "" {
+		return ""
+	}
+	return filepath.Join(townRoot, rig, dir, name)
+}
+
+// outputCustomRoleContext prints the prime context of a custom role: its
+// identity, then the role's prime file from the town.
+func outputCustomRoleContext(ctx RoleContext, cfg *config.CustomRoleConfig) {
+	role := string(ctx.Role)
+	title := strings.ToUpper(role[:1]) + role[1:]
+	fmt.Printf("%s\n\n", style.Bold.Render("# "+title+" Context"))
+
+	who := fmt.Sprintf("You are **%s**, a %s", ctx.Polecat, role)
+	if ctx.Rig != "" {
+		who += fmt.Sprintf(" in rig **%s**", ctx.Rig)
+	}
+	fmt.Println(who + ".")
+	if cfg.Description != "" {
+		fmt.Println()
+		fmt.Println(cfg.Description)
+	}
+	fmt.Println()
+
+	primePath := cfg.PrimePath(ctx.TownRoot, role)
+	explain(true, "Custom role context: "+primePath)
+	if data, err := os.ReadFile(primePath); err == nil { //nolint:gosec // G304: path is from town config
+		if s := strings.TrimSpace(string(data)); s != "" {
+			fmt.Println(s)
+			fmt.Println()
+		}
+	} else {
+		fmt.Printf("%s\n\n", style.Dim.Render("(No role context at "+primePath+"; add one to describe this role's job.)"))
+	}
+
+	fmt.Println("## Key Commands")
+	fmt.Println("- `" + cli.Name() + " hook` - Check your hooked work")
+	fmt.Println("- `" + cli.Name() + " mail inbox` - Check your messages")
+	fmt.Println("- `" + cli.Name() + " handoff` - Hand off to a fresh session")
+	fmt.Println("- `bd ready` - Issues ready to work")
+	fmt.Println()
+	fmt.Printf("Town root: %s\n", style.Dim.Render(ctx.TownRoot))
+}
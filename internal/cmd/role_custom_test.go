@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func setTestCustomRoles(t *testing.T) {
+	t.Helper()
+	config.SetCustomRoles(map[string]*config.CustomRoleConfig{
+		"reviewer":   {Dir: "reviewers"},
+		"researcher": {Scope: config.CustomRoleScopeTown},
+	})
+	t.Cleanup(func() { config.SetCustomRoles(nil) })
+}
+
+func TestDetectRole_CustomRoles(t *testing.T) {
+	setTestCustomRoles(t)
+	townRoot := "/town"
+
+	tests := []struct {
+		cwd     string
+		role    Role
+		rig     string
+		polecat string
+	}{
+		{filepath.Join(townRoot, "gastown", "reviewers", "ann"), "reviewer", "gastown", "ann"},
+		{filepath.Join(townRoot, "gastown", "reviewers", "ann", "src"), "reviewer", "gastown", "ann"},
+		{filepath.Join(townRoot, "researcher", "bob"), "researcher", "", "bob"},
+		// The role's dir without a worker name is not a role home.
+		{filepath.Join(townRoot, "gastown", "reviewers"), RoleUnknown, "gastown", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cwd, func(t *testing.T) {
+			info := detectRole(tt.cwd, townRoot)
+			if info.Role != tt.role || info.Rig != tt.rig || info.Polecat != tt.polecat {
+				t.Errorf("detectRole(%s) = %s %s/%s, want %s %s/%s",
+					tt.cwd, info.Role, info.Rig, info.Polecat, tt.role, tt.rig, tt.polecat)
+			}
+		})
+	}
+}
+
+func TestParseRoleString_CustomRoles(t *testing.T) {
+	setTestCustomRoles(t)
+
+	tests := []struct {
+		in      string
+		role    Role
+		rig     string
+		polecat string
+	}{
+		{"gastown/reviewer/ann", "reviewer", "gastown", "ann"},
+		{"researcher/bob", "researcher", "", "bob"},
+		// Unregistered roles keep the rig/polecat reading.
+		{"gastown/auditor", RolePolecat, "gastown", "auditor"},
+	}
+	for _, tt := range tests {
+		role, rig, polecat := parseRoleString(tt.in)
+		if role != tt.role || rig != tt.rig || polecat != tt.polecat {
+			t.Errorf("parseRoleString(%q) = %s %s/%s, want %s %s/%s",
+				tt.in, role, rig, polecat, tt.role, tt.rig, tt.polecat)
+		}
+	}
+}
+
+func TestGetRoleHome_CustomRoles(t *testing.T) {
+	setTestCustomRoles(t)
+	townRoot := "/town"
+
+	if got, want := getRoleHome("reviewer", "gastown", "ann", townRoot), filepath.Join(townRoot, "gastown", "reviewers", "ann"); got != want {
+		t.Errorf("reviewer home = %q, want %q", got, want)
+	}
+	if got, want := getRoleHome("researcher", "", "bob", townRoot), filepath.Join(townRoot, "researcher", "bob"); got != want {
+		t.Errorf("researcher home = %q, want %q", got, want)
+	}
+	if got := getRoleHome("reviewer", "", "ann", townRoot); got != "" {
+		t.Errorf("reviewer home without rig = %q, want empty", got)
+	}
+	if got := getRoleHome("auditor", "gastown", "ann", townRoot); got != "" {
+		t.Errorf("unregistered role home = %q, want empty", got)
+	}
+}
+
+func TestRoleManifestResolve_CustomRoles(t *testing.T) {
+	setTestCustomRoles(t)
+
+	m := &roleManifest{Role: "reviewer", Rig: "gastown", Polecat: "ann"}
+	role, rig, polecat, err := m.resolve()
+	if err != nil || role != "reviewer" || rig != "gastown" || polecat != "ann" {
+		t.Errorf("resolve() = %s %s/%s, %v", role, rig, polecat, err)
+	}
+	if _, _, _, err := (&roleManifest{Role: "reviewer", Polecat: "ann"}).resolve(); err == nil {
+		t.Error("resolve() accepted a rig role without a rig")
+	}
+	if _, _, _, err := (&roleManifest{Role: "researcher", Polecat: "bob"}).resolve(); err != nil {
+		t.Errorf("resolve() town role: %v", err)
+	}
+	if _, _, _, err := (&roleManifest{Role: "auditor", Polecat: "cat"}).resolve(); err == nil {
+		t.Error("resolve() accepted an unregistered role")
+	}
+}
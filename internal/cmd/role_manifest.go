@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// roleManifestPath is the role manifest's location relative to a working
+// directory.
+var roleManifestPath = filepath.Join(".gt", "role.json")
+
+// roleManifest is a .gt/role.json file declaring the role of the agent that
+// works in its directory. It takes precedence over path-based detection and
+// a hand-exported GT_ROLE, for layouts the path heuristics can't read
+// (nonstandard directory names, nested worktrees). A GT_ROLE that gt set for
+// the agent's session still wins.
+//
+// Only a local manifest counts: one committed to the project repo would
+// assign its role to every clone, so tracked manifests are ignored.
+//
+// Role may be simple ("polecat") with Rig and Polecat given separately, or
+// compound like GT_ROLE ("gastown/polecats/toast"). Explicit fields win over
+// parts of a compound role.
+type roleManifest struct {
+	Role    string `json:"role"`
+	Rig     string `json:"rig,omitempty"`
+	Polecat string `json:"polecat,omitempty"` // Polecat, crew member, or dog name
+}
+
+// findRoleManifest looks for a role manifest in cwd and its ancestors,
+// stopping at the root of the git worktree cwd is in or below townRoot,
+// whichever comes first. The town root itself is never consulted, so a
+// manifest there can't assign one role to the whole town, and manifests
+// tracked by git are skipped. Returns the manifest and its path, or nil if
+// there is none.
+func findRoleManifest(cwd, townRoot string) (*roleManifest, string, error) {
+	dir := filepath.Clean(cwd)
+	town := filepath.Clean(townRoot)
+	for dir != town {
+		path := filepath.Join(dir, roleManifestPath)
+		if _, err := os.Stat(path); err == nil && !isTrackedByGit(dir, roleManifestPath) {
+			m, err := readRoleManifest(path)
+			if err != nil {
+				return nil, path, err
+			}
+			if m != nil {
+				return m, path, nil
+			}
+		}
+
+		if isWorktreeRoot(dir) {
+			return nil, "", nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir || !isWithin(parent, town) {
+			return nil, "", nil
+		}
+		dir = parent
+	}
+	return nil, "", nil
+}
+
+// isWorktreeRoot reports whether dir is the top of a git checkout (a .git
+// directory, or a .git file for linked worktrees).
+func isWorktreeRoot(dir string) bool {
+	_, err := os.Lstat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// isTrackedByGit reports whether rel (relative to dir) is tracked in the git
+// repo dir is in. Outside a repo, or without git, nothing is tracked.
+func isTrackedByGit(dir, rel string) bool {
+	cmd := exec.Command("git", "ls-files", "--error-unmatch", "--", filepath.ToSlash(rel))
+	cmd.Dir = dir
+	return cmd.Run() == nil
+}
+
+// roleEnvSetByGT reports whether GT_ROLE came from gt's session env for
+// this town rather than being exported by hand: gt sets GT_ROOT alongside
+// it for every agent.
+func roleEnvSetByGT(townRoot string) bool {
+	root := os.Getenv("GT_ROOT")
+	return os.Getenv(EnvGTRole) != "" && root != "" && filepath.Clean(root) == filepath.Clean(townRoot)
+}
+
+// readRoleManifest reads and validates the manifest at path. A missing file
+// is not an error.
+func readRoleManifest(path string) (*roleManifest, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading role manifest %s: %w", path, err)
+	}
+	var m roleManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing role manifest %s: %w", path, err)
+	}
+	if strings.TrimSpace(m.Role) == "" {
+		return nil, fmt.Errorf("role manifest %s: missing \"role\"", path)
+	}
+	return &m, nil
+}
+
+// resolve returns the role, rig, and worker name the manifest declares.
+func (m *roleManifest) resolve() (Role, string, string, error) {
+	role, rig, polecat := parseRoleString(m.Role)
+	if m.Rig != "" {
+		rig = m.Rig
+	}
+	if m.Polecat != "" {
+		polecat = m.Polecat
+	}
+
+	switch role {
+	case RoleMayor, RoleDeacon, RoleBoot:
+	case RoleWitness, RoleRefinery:
+		if rig == "" {
+			return "", "", "", fmt.Errorf("role %s requires \"rig\"", role)
+		}
+	case RolePolecat, RoleCrew:
+		if rig == "" || polecat == "" {
+			return "", "", "", fmt.Errorf("role %s requires \"rig\" and \"polecat\"", role)
+		}
+	case RoleDog:
+		if polecat == "" {
+			return "", "", "", fmt.Errorf("role dog requires \"polecat\" (the dog's name)")
+		}
+	default:
+		cfg, ok := lookupCustomRole(role)
+		if !ok {
+			return "", "", "", fmt.Errorf("unknown role %q", m.Role)
+		}
+		if polecat == "" || (!cfg.IsTownScoped() && rig == "") {
+			return "", "", "", fmt.Errorf("role %s requires \"polecat\" (the worker's name) and, for rig roles, \"rig\"", role)
+		}
+	}
+	return role, rig, polecat, nil
+}
+
+// isWithin reports whether path is root or below it.
+func isWithin(path, root string) bool {
+	rel, err := filepath.Rel(root, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeRoleManifest(t *testing.T, dir, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, roleManifestPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestGetRoleWithContext_Manifest(t *testing.T) {
+	townRoot := t.TempDir()
+	// A nonstandard layout path detection reads as a bare rig directory.
+	workDir := filepath.Join(townRoot, "gastown", "worktrees", "feature-x")
+	nested := filepath.Join(workDir, "sub", "pkg")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := writeRoleManifest(t, workDir, `{"role": "polecat", "rig": "gastown", "polecat": "toast"}`)
+	// Exported by hand: no GT_ROOT, so the manifest wins.
+	t.Setenv(EnvGTRole, "mayor")
+	t.Setenv("GT_ROOT", "")
+
+	for _, cwd := range []string{workDir, nested} {
+		info, err := GetRoleWithContext(cwd, townRoot)
+		if err != nil {
+			t.Fatalf("GetRoleWithContext(%s): %v", cwd, err)
+		}
+		if info.Role != RolePolecat || info.Rig != "gastown" || info.Polecat != "toast" {
+			t.Errorf("from %s: got %s %s/%s, want polecat gastown/toast", cwd, info.Role, info.Rig, info.Polecat)
+		}
+		if info.Source != "manifest" || info.Manifest != path {
+			t.Errorf("from %s: source=%q manifest=%q, want manifest %s", cwd, info.Source, info.Manifest, path)
+		}
+		if info.Mismatch {
+			t.Errorf("from %s: manifest role should not be flagged as a mismatch", cwd)
+		}
+		if want := filepath.Join(townRoot, "gastown", "polecats", "toast"); info.Home != want {
+			t.Errorf("Home = %s, want %s", info.Home, want)
+		}
+	}
+}
+
+func TestGetRoleWithContext_ManifestAtTownRootIgnored(t *testing.T) {
+	townRoot := t.TempDir()
+	writeRoleManifest(t, townRoot, `{"role": "mayor"}`)
+	cwd := filepath.Join(townRoot, "gastown", "crew", "max")
+	if err := os.MkdirAll(cwd, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvGTRole, "")
+
+	info, err := GetRoleWithContext(cwd, townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Source != "cwd" || info.Role != RoleCrew || info.Polecat != "max" {
+		t.Errorf("got %s from %s, want crew from cwd", info.Role, info.Source)
+	}
+}
+
+func TestRoleManifestResolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest roleManifest
+		role     Role
+		rig      string
+		polecat  string
+		wantErr  string
+	}{
+		{name: "simple", manifest: roleManifest{Role: "mayor"}, role: RoleMayor},
+		{name: "compound", manifest: roleManifest{Role: "gastown/crew/max"}, role: RoleCrew, rig: "gastown", polecat: "max"},
+		{name: "fields override compound", manifest: roleManifest{Role: "gastown/witness", Rig: "beads"}, role: RoleWitness, rig: "beads"},
+		{name: "dog", manifest: roleManifest{Role: "dog", Polecat: "rex"}, role: RoleDog, polecat: "rex"},
+		{name: "polecat missing name", manifest: roleManifest{Role: "polecat", Rig: "gastown"}, wantErr: "requires"},
+		{name: "witness missing rig", manifest: roleManifest{Role: "witness"}, wantErr: "requires"},
+		{name: "unknown", manifest: roleManifest{Role: "janitor"}, wantErr: "unknown role"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			role, rig, polecat, err := tt.manifest.resolve()
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("err = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if role != tt.role || rig != tt.rig || polecat != tt.polecat {
+				t.Errorf("got %s %q/%q, want %s %q/%q", role, rig, polecat, tt.role, tt.rig, tt.polecat)
+			}
+		})
+	}
+}
+
+func TestFindRoleManifest_Invalid(t *testing.T) {
+	townRoot := t.TempDir()
+	workDir := filepath.Join(townRoot, "gastown", "polecats", "toast")
+	writeRoleManifest(t, workDir, `{"rig": "gastown"`)
+	if _, _, err := findRoleManifest(workDir, townRoot); err == nil {
+		t.Fatal("expected error for malformed manifest")
+	}
+	if _, err := GetRoleWithContext(workDir, townRoot); err == nil {
+		t.Fatal("GetRoleWithContext should surface a malformed manifest")
+	}
+}
+
+func TestGetRoleWithContext_GTSetRoleBeatsManifest(t *testing.T) {
+	townRoot := t.TempDir()
+	workDir := filepath.Join(townRoot, "gastown", "worktrees", "feature-x")
+	writeRoleManifest(t, workDir, `{"role": "polecat", "rig": "gastown", "polecat": "toast"}`)
+	t.Setenv(EnvGTRole, "gastown/polecats/nux")
+	t.Setenv("GT_ROOT", townRoot)
+
+	info, err := GetRoleWithContext(workDir, townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Source != "env" || info.Polecat != "nux" {
+		t.Errorf("got %s %s from %s, want polecat nux from env", info.Role, info.Polecat, info.Source)
+	}
+}
+
+func TestFindRoleManifest_StopsAtWorktreeRoot(t *testing.T) {
+	townRoot := t.TempDir()
+	worktrees := filepath.Join(townRoot, "gastown", "worktrees")
+	writeRoleManifest(t, worktrees, `{"role": "mayor"}`)
+	workDir := filepath.Join(worktrees, "feature-x")
+	nested := filepath.Join(workDir, "pkg")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(workDir, ".git"), []byte("gitdir: elsewhere\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _, err := findRoleManifest(nested, townRoot)
+	if err != nil || m != nil {
+		t.Errorf("findRoleManifest = %+v, %v; want none above the worktree root", m, err)
+	}
+}
+
+func TestFindRoleManifest_IgnoresTrackedManifest(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	townRoot := t.TempDir()
+	workDir := filepath.Join(townRoot, "gastown", "polecats", "toast")
+	writeRoleManifest(t, workDir, `{"role": "mayor"}`)
+	for _, args := range [][]string{{"init", "-q"}, {"add", roleManifestPath}} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = workDir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v (%s)", args, err, out)
+		}
+	}
+
+	m, _, err := findRoleManifest(workDir, townRoot)
+	if err != nil || m != nil {
+		t.Errorf("findRoleManifest = %+v, %v; want a tracked manifest ignored", m, err)
+	}
+}
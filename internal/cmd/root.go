@@ -4,6 +4,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"log/slog"
 	"os"
 	"os/exec"
 	"strings"
@@ -12,6 +13,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/steveyegge/gastown/internal/cli"
 	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
@@ -73,7 +75,9 @@ var beadsExemptCommands = map[string]bool{
 	"run-migration":       true, // Migration orchestrator handles its own beads checks
 	"health":              true, // Health check doesn't require beads
 	"upgrade":             true, // Post-install migration orchestrator
+	"backup":              true, // Disaster recovery must work without bd
 	"heartbeat":           true, // Heartbeat state update — must be fast and dependency-free
+	"log-pipe":            true, // Long-lived pane output writer spawned by tmux pipe-pane
 }
 
 // Commands exempt from the town root branch warning.
@@ -88,6 +92,7 @@ var branchCheckExemptCommands = map[string]bool{
 	"install":    true, // Initial setup
 	"git-init":   true, // Git setup
 	"upgrade":    true, // Post-install migration
+	"backup":     true, // Restores onto fresh machines
 }
 
 // persistentPreRun runs before every command.
@@ -106,9 +111,24 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 		os.Exit(1)
 	}
 
+	// Select the town config overlay before anything loads town.json.
+	// Exported so agents started by this command use it too.
+	if townEnvFlag != "" {
+		_ = os.Setenv(config.TownEnvVar, townEnvFlag)
+	}
+
 	// Initialize CLI theme (dark/light mode support)
 	initCLITheme()
 
+	// Structured logging: stderr plus per-component files inside a town.
+	townRoot := detectTownRootFromCwd()
+	if err := setupLogging(cmd, townRoot); err != nil {
+		return err
+	}
+
+	// Tracing: a span for this command, exported when town.json configures it.
+	setupTracing(cmd, townRoot)
+
 	// Log command usage telemetry (fire-and-forget, excludes tap/signal)
 	logCommandUsage(cmd, args)
 
@@ -117,9 +137,9 @@ func persistentPreRun(cmd *cobra.Command, args []string) error {
 	// Env var fallback ensures commands invoked from outside the town directory
 	// (e.g., "gt agents menu" via a cross-socket tmux binding) still connect to
 	// the correct town socket rather than silently using the wrong server.
-	if townRoot := detectTownRootFromCwd(); townRoot != "" {
+	if townRoot != "" {
 		if err := session.InitRegistry(townRoot); err != nil {
-			fmt.Fprintf(os.Stderr, "WARNING: failed to initialize town registry: %v\n", err)
+			slog.Warn("failed to initialize town registry", "town", townRoot, "err", err)
 		}
 	}
 
@@ -305,7 +325,11 @@ func Execute() int {
 		telemetry.SetProcessOTELAttrs()
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	defer func() { _ = closeLogging() }()
+
+	err = rootCmd.Execute()
+	finishTracing(err)
+	if err != nil {
 		// Check for silent exit (scripting commands that signal status via exit code)
 		if code, ok := IsSilentExit(err); ok {
 			return code
@@ -346,10 +370,17 @@ func init() {
 	rootCmd.SetHelpCommandGroupID(GroupDiag)
 	rootCmd.SetCompletionCommandGroupID(GroupConfig)
 
-	// Global flags can be added here
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file")
+	rootCmd.PersistentFlags().StringVar(&townEnvFlag, "town-env", "",
+		"Town config environment: merge mayor/town.<env>.json over town.json (sets "+config.TownEnvVar+")")
+	rootCmd.PersistentFlags().StringVar(&logLevelFlag, "log-level", "",
+		"Log level on stderr: debug, info, warn or error (default warn; sets "+logging.EnvLevel+")")
+	rootCmd.PersistentFlags().StringVar(&logFormatFlag, "log-format", "",
+		"Log format for stderr and log files: text or json (default text; sets "+logging.EnvFormat+")")
 }
 
+// townEnvFlag is the --town-env global flag.
+var townEnvFlag string
+
 // buildCommandPath walks the command hierarchy to build the full command path.
 // For example: "gt mail send", "gt status", etc.
 func buildCommandPath(cmd *cobra.Command) string {
@@ -0,0 +1,275 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Routes command flags
+var (
+	routesListJSON    bool
+	routesAddForce    bool
+	routesRemoveForce bool
+)
+
+var routesCmd = &cobra.Command{
+	Use:     "routes",
+	GroupID: GroupConfig,
+	Short:   "Manage bead prefix routes (.beads/routes.jsonl)",
+	Long: `Manage the town's bead prefix routes.
+
+Routes map a bead ID prefix (e.g. "gt-") to the directory holding that
+prefix's beads database, relative to the town root. bd and gt use them to
+find the right database for any bead ID, so every prefix must be unique
+across rigs and every path must exist.
+
+Routes live in .beads/routes.jsonl at the town root. gt rig add and
+gt install maintain them; use these commands to inspect or repair them.
+
+Examples:
+  gt routes list
+  gt routes add gt- gastown/mayor/rig
+  gt routes remove gt-
+  gt routes validate`,
+	RunE: requireSubcommand,
+}
+
+var routesListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List routes and whether each one resolves",
+	Args:  cobra.NoArgs,
+	RunE:  runRoutesList,
+}
+
+var routesAddCmd = &cobra.Command{
+	Use:   "add <prefix> <path>",
+	Short: "Add or update a route",
+	Long: `Add a route from a bead prefix to a beads directory.
+
+The prefix gets a trailing "-" if it lacks one. The path is relative to the
+town root ("." for town beads); an absolute path inside the town is
+converted. If the prefix is already routed to the same rig, its path is
+updated.
+
+Fails if another rig already uses the prefix, or if the path doesn't exist
+or has no .beads directory (use --force to add it anyway).`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRoutesAdd,
+}
+
+var routesRemoveCmd = &cobra.Command{
+	Use:   "remove <prefix>",
+	Short: "Remove a route",
+	Long: `Remove the route for a bead prefix.
+
+The town routes (hq-, hq-cv-) are required for mail, convoys and agent
+beads; removing them needs --force.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRoutesRemove,
+}
+
+var routesValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check routes for prefix collisions and missing paths",
+	Long: `Check every route in .beads/routes.jsonl.
+
+Reports prefixes without a trailing "-", prefixes routed more than once or
+claimed by more than one rig, and paths that don't exist or have no beads
+database. Exits non-zero if any route has a problem.`,
+	Args: cobra.NoArgs,
+	RunE: runRoutesValidate,
+}
+
+func init() {
+	routesListCmd.Flags().BoolVar(&routesListJSON, "json", false, "Output as JSON")
+	routesAddCmd.Flags().BoolVarP(&routesAddForce, "force", "f", false, "Add even if the path does not exist")
+	routesRemoveCmd.Flags().BoolVarP(&routesRemoveForce, "force", "f", false, "Allow removing the town routes")
+
+	routesCmd.AddCommand(routesListCmd)
+	routesCmd.AddCommand(routesAddCmd)
+	routesCmd.AddCommand(routesRemoveCmd)
+	routesCmd.AddCommand(routesValidateCmd)
+	rootCmd.AddCommand(routesCmd)
+}
+
+// townRoutePrefixes are the routes every town needs.
+var townRoutePrefixes = []string{"hq-", "hq-cv-"}
+
+// routeListEntry is one route in gt routes list --json.
+type routeListEntry struct {
+	Prefix  string `json:"prefix"`
+	Path    string `json:"path"`
+	Rig     string `json:"rig,omitempty"`
+	OK      bool   `json:"ok"`
+	Problem string `json:"problem,omitempty"`
+}
+
+func runRoutesList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	routes, err := beads.LoadRoutes(beads.GetTownBeadsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading routes: %w", err)
+	}
+	problems, err := beads.ValidateRoutes(townRoot)
+	if err != nil {
+		return err
+	}
+	problemFor := make(map[beads.Route][]string)
+	for _, p := range problems {
+		key := beads.Route{Prefix: p.Prefix, Path: p.Path}
+		problemFor[key] = append(problemFor[key], p.Reason)
+	}
+
+	entries := make([]routeListEntry, 0, len(routes))
+	for _, r := range routes {
+		e := routeListEntry{Prefix: r.Prefix, Path: r.Path, OK: true}
+		if r.Path != "." {
+			e.Rig = strings.SplitN(r.Path, "/", 2)[0]
+		}
+		if reasons := problemFor[r]; len(reasons) > 0 {
+			e.OK = false
+			e.Problem = strings.Join(reasons, "; ")
+		}
+		entries = append(entries, e)
+	}
+
+	if routesListJSON {
+		return outputJSON(entries)
+	}
+	if len(entries) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("No routes configured"))
+		return nil
+	}
+	for _, e := range entries {
+		mark := style.Success.Render("✓")
+		note := ""
+		if !e.OK {
+			mark = style.Error.Render("✗")
+			note = "  " + style.Dim.Render(e.Problem)
+		}
+		fmt.Printf("%s %-10s %s%s\n", mark, e.Prefix, e.Path, note)
+	}
+	return nil
+}
+
+func runRoutesAdd(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	prefix, err := normalizeRoutePrefix(args[0])
+	if err != nil {
+		return err
+	}
+	path, err := normalizeRoutePath(townRoot, args[1])
+	if err != nil {
+		return err
+	}
+
+	routes, err := beads.LoadRoutes(beads.GetTownBeadsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading routes: %w", err)
+	}
+	for _, r := range routes {
+		if r.Prefix == prefix && strings.SplitN(r.Path, "/", 2)[0] != strings.SplitN(path, "/", 2)[0] {
+			return fmt.Errorf("prefix %s is already routed to %s", prefix, r.Path)
+		}
+	}
+	if problem := beads.RoutePathProblem(townRoot, path); problem != "" {
+		if !routesAddForce {
+			return fmt.Errorf("%s: %s (use --force to add anyway)", path, problem)
+		}
+		fmt.Printf("%s %s: %s\n", style.Warning.Render("!"), path, problem)
+	}
+
+	if err := beads.AppendRoute(townRoot, beads.Route{Prefix: prefix, Path: path}); err != nil {
+		return fmt.Errorf("writing routes: %w", err)
+	}
+	fmt.Printf("%s Routed %s → %s\n", style.Success.Render("✓"), prefix, path)
+	return nil
+}
+
+func runRoutesRemove(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	prefix, err := normalizeRoutePrefix(args[0])
+	if err != nil {
+		return err
+	}
+	if beads.GetRigPathForPrefix(townRoot, prefix) == "" {
+		return fmt.Errorf("no route for prefix %s", prefix)
+	}
+	for _, p := range townRoutePrefixes {
+		if prefix == p && !routesRemoveForce {
+			return fmt.Errorf("%s is a required town route (use --force to remove anyway)", prefix)
+		}
+	}
+
+	if err := beads.RemoveRoute(townRoot, prefix); err != nil {
+		return fmt.Errorf("writing routes: %w", err)
+	}
+	fmt.Printf("%s Removed route %s\n", style.Success.Render("✓"), prefix)
+	return nil
+}
+
+func runRoutesValidate(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	problems, err := beads.ValidateRoutes(townRoot)
+	if err != nil {
+		return err
+	}
+	if len(problems) == 0 {
+		fmt.Printf("%s All routes valid\n", style.Success.Render("✓"))
+		return nil
+	}
+	for _, p := range problems {
+		fmt.Printf("%s %s → %s: %s\n", style.Error.Render("✗"), p.Prefix, p.Path, p.Reason)
+	}
+	return fmt.Errorf("%d route problem(s) found", len(problems))
+}
+
+// normalizeRoutePrefix validates a prefix and adds the trailing hyphen.
+func normalizeRoutePrefix(prefix string) (string, error) {
+	prefix = strings.TrimSpace(prefix)
+	if !strings.HasSuffix(prefix, "-") {
+		prefix += "-"
+	}
+	if prefix == "-" || strings.ContainsAny(prefix, " /\\") {
+		return "", fmt.Errorf("invalid prefix %q", prefix)
+	}
+	return prefix, nil
+}
+
+// normalizeRoutePath converts a route path to the town-relative, slash
+// separated form routes.jsonl uses, dropping a trailing .beads.
+func normalizeRoutePath(townRoot, path string) (string, error) {
+	if filepath.IsAbs(path) {
+		rel, err := filepath.Rel(townRoot, path)
+		if err != nil {
+			return "", fmt.Errorf("path %s: %w", path, err)
+		}
+		path = rel
+	}
+	path = filepath.Clean(path)
+	if filepath.Base(path) == ".beads" {
+		path = filepath.Dir(path)
+	}
+	if path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %s is outside the workspace", path)
+	}
+	return filepath.ToSlash(path), nil
+}
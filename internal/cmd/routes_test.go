@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestNormalizeRoutePrefix(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"gt", "gt-", false},
+		{"gt-", "gt-", false},
+		{" hq-cv- ", "hq-cv-", false},
+		{"", "", true},
+		{"-", "", true},
+		{"a/b", "", true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeRoutePrefix(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("normalizeRoutePrefix(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("normalizeRoutePrefix(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeRoutePath(t *testing.T) {
+	townRoot := t.TempDir()
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{".", ".", false},
+		{"gastown/mayor/rig", "gastown/mayor/rig", false},
+		{"gastown/mayor/rig/.beads", "gastown/mayor/rig", false},
+		{filepath.Join(townRoot, "beads", "mayor", "rig"), "beads/mayor/rig", false},
+		{filepath.Join(townRoot, ".beads"), ".", false},
+		{"../elsewhere", "", true},
+		{filepath.Dir(townRoot), "", true},
+	}
+	for _, tt := range tests {
+		got, err := normalizeRoutePath(townRoot, tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("normalizeRoutePath(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("normalizeRoutePath(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
@@ -512,7 +512,8 @@ func countActivePolecats() int {
 // countWorkingPolecats counts polecat sessions that are actively working.
 // A polecat is "working" if its agent bead has a non-null hook_bead.
 // Idle polecats (completed work, hook_bead=null) don't count toward capacity
-// since they're available for re-sling under the persistent polecat model.
+// since they're available for re-sling under the persistent polecat model,
+// unless tmux.Idle shows the agent still mid-turn.
 func countWorkingPolecats() int {
 	townRoot, err := workspace.FindFromCwd()
 	if err != nil {
@@ -526,6 +527,7 @@ func countWorkingPolecats() int {
 	}
 
 	bd := beads.New(townRoot)
+	t := tmux.NewTmux()
 	count := 0
 	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
 		if line == "" {
@@ -550,7 +552,12 @@ func countWorkingPolecats() int {
 
 		fields := beads.ParseAgentFields(issue.Description)
 		if fields.HookBead == "" {
-			continue // Idle — don't count toward cap
+			// No hooked work, but an agent still mid-turn (e.g. finishing
+			// gt done) holds its slot until it is back at its prompt.
+			if st, err := t.Idle(line); err == nil && st.Busy {
+				count++
+			}
+			continue
 		}
 		count++
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -30,8 +31,10 @@ var (
 	sessionMessage    string
 	sessionFile       string
 	sessionRigFilter  string
+	sessionRoleFilter string
 	sessionListJSON   bool
 	sessionStatusJSON bool
+	sessionLabels     []string
 )
 
 var sessionCmd = &cobra.Command{
@@ -88,10 +91,20 @@ Attaches the current terminal to the tmux session. Detach with Ctrl-B D.`,
 
 var sessionListCmd = &cobra.Command{
 	Use:   "list",
-	Short: "List all sessions",
-	Long: `List all running polecat sessions.
+	Short: "List all Gas Town sessions",
+	Long: `List all running Gas Town tmux sessions.
 
-Shows session status, rig, and polecat name. Use --rig to filter by rig.`,
+Shows every gt-managed session (mayor, deacon, witnesses, refineries, crew,
+polecats, dogs) with its role and rig parsed from the session name, the
+number of attached clients, last activity time, pane PID, and working
+directory. Filled dots mark sessions with an attached client.
+
+Examples:
+  gt session list                  # All sessions
+  gt session list --rig gastown    # Only sessions in one rig
+  gt session list --role polecat   # Only polecat sessions
+  gt session list --label urgent   # Only sessions labeled "urgent"
+  gt session list --json           # Machine-readable output`,
 	RunE: runSessionList,
 }
 
@@ -177,6 +190,8 @@ func init() {
 
 	// List flags
 	sessionListCmd.Flags().StringVar(&sessionRigFilter, "rig", "", "Filter by rig name")
+	sessionListCmd.Flags().StringVar(&sessionRoleFilter, "role", "", "Filter by role (mayor, deacon, boot, witness, refinery, crew, polecat, dog)")
+	sessionListCmd.Flags().StringSliceVar(&sessionLabels, "label", nil, "Only sessions with this label (see 'gt session label'); repeatable")
 	sessionListCmd.Flags().BoolVar(&sessionListJSON, "json", false, "Output as JSON")
 
 	// Capture flags
@@ -340,70 +355,52 @@ func runSessionAttach(cmd *cobra.Command, args []string) error {
 	return polecatMgr.Attach(polecatName)
 }
 
-// SessionListItem represents a session in list output.
+// SessionListItem represents a Gas Town session in list output.
 type SessionListItem struct {
-	Rig       string `json:"rig"`
-	Polecat   string `json:"polecat"`
-	SessionID string `json:"session_id"`
-	Running   bool   `json:"running"`
+	Rig          string    `json:"rig"`
+	Polecat      string    `json:"polecat"`
+	SessionID    string    `json:"session_id"`
+	Running      bool      `json:"running"`
+	Role         string    `json:"role"`
+	Name         string    `json:"name,omitempty"`
+	Address      string    `json:"address,omitempty"`
+	Clients      int       `json:"attached_clients"`
+	LastActivity time.Time `json:"last_activity,omitzero"`
+	PanePID      int       `json:"pane_pid,omitempty"`
+	WorkDir      string    `json:"work_dir,omitempty"`
+	DisplayName  string    `json:"display_name,omitempty"`
+	Labels       []string  `json:"labels,omitempty"`
 }
 
 func runSessionList(cmd *cobra.Command, args []string) error {
-	// Find town root
-	townRoot, err := workspace.FindFromCwdOrError()
-	if err != nil {
-		return fmt.Errorf("not in a Gas Town workspace: %w", err)
-	}
-
-	// Load rigs config
-	rigsConfigPath := filepath.Join(townRoot, "mayor", "rigs.json")
-	rigsConfig, err := config.LoadRigsConfig(rigsConfigPath)
-	if err != nil {
-		rigsConfig = &config.RigsConfig{Rigs: make(map[string]config.RigEntry)}
+	// Initialize the prefix registry so rig-level sessions parse to rig names.
+	townRoot, _ := workspace.FindFromCwd()
+	if townRoot != "" {
+		_ = session.InitRegistry(townRoot)
 	}
 
-	// Get all rigs
-	g := git.NewGit(townRoot)
-	rigMgr := rig.NewManager(townRoot, rigsConfig, g)
-	rigs, err := rigMgr.DiscoverRigs()
+	t := tmux.NewTmux()
+	details, err := t.ListSessionDetails()
 	if err != nil {
-		return fmt.Errorf("discovering rigs: %w", err)
+		return fmt.Errorf("listing sessions: %w", err)
 	}
 
-	// Filter if requested
-	if sessionRigFilter != "" {
-		var filtered []*rig.Rig
-		for _, r := range rigs {
-			if r.Name == sessionRigFilter {
-				filtered = append(filtered, r)
-			}
-		}
-		rigs = filtered
-	}
-
-	// Collect sessions from all rigs
-	t := tmux.NewTmux()
-	var allSessions []SessionListItem
-
-	for _, r := range rigs {
-		polecatMgr := polecat.NewSessionManager(t, r)
-		infos, err := polecatMgr.List()
+	allSessions := buildSessionListItems(details, sessionRigFilter, sessionRoleFilter)
+	if townRoot != "" {
+		meta, err := session.LoadSessionMeta(townRoot)
 		if err != nil {
-			continue
-		}
-
-		for _, info := range infos {
-			allSessions = append(allSessions, SessionListItem{
-				Rig:       r.Name,
-				Polecat:   info.Polecat,
-				SessionID: info.SessionID,
-				Running:   info.Running,
-			})
+			return fmt.Errorf("loading session labels: %w", err)
 		}
+		allSessions = applySessionMeta(allSessions, meta, sessionLabels)
+	} else if len(sessionLabels) > 0 {
+		return fmt.Errorf("--label requires a Gas Town workspace")
 	}
 
 	// Output
 	if sessionListJSON {
+		if allSessions == nil {
+			allSessions = []SessionListItem{}
+		}
 		enc := json.NewEncoder(os.Stdout)
 		enc.SetIndent("", "  ")
 		return enc.Encode(allSessions)
@@ -416,17 +413,100 @@ func runSessionList(cmd *cobra.Command, args []string) error {
 
 	fmt.Printf("%s\n\n", style.Bold.Render("Active Sessions"))
 	for _, s := range allSessions {
-		status := style.Bold.Render("●")
-		if !s.Running {
-			status = style.Dim.Render("○")
+		status := style.Dim.Render("○")
+		if s.Clients > 0 {
+			status = style.Bold.Render("●")
+		}
+		label := s.Address
+		if label == "" {
+			label = s.SessionID
+		}
+		if s.DisplayName != "" {
+			label += " " + strconv.Quote(s.DisplayName)
+		}
+		activity := "-"
+		if !s.LastActivity.IsZero() {
+			activity = formatDuration(time.Since(s.LastActivity)) + " ago"
+		}
+		fmt.Printf("  %s %-32s %-9s clients:%d  active:%-12s pid:%d\n",
+			status, label, s.Role, s.Clients, activity, s.PanePID)
+		fmt.Printf("    %s\n", style.Dim.Render(s.SessionID+"  "+s.WorkDir))
+		if len(s.Labels) > 0 {
+			fmt.Printf("    labels: %s\n", strings.Join(s.Labels, ", "))
 		}
-		fmt.Printf("  %s %s/%s\n", status, s.Rig, s.Polecat)
-		fmt.Printf("    %s\n", style.Dim.Render(s.SessionID))
 	}
 
 	return nil
 }
 
+// buildSessionListItems converts raw tmux session details into list items,
+// keeping only Gas Town sessions and applying the optional rig/role filters.
+// Results are sorted by address for stable output.
+func buildSessionListItems(details []tmux.SessionDetail, rigFilter, roleFilter string) []SessionListItem {
+	var items []SessionListItem
+	for _, d := range details {
+		if !session.IsKnownSession(d.Name) {
+			continue
+		}
+		identity, err := session.ParseSessionName(d.Name)
+		if err != nil {
+			continue
+		}
+		role := string(identity.Role)
+		if identity.Role == session.RoleDeacon && identity.Name == "boot" {
+			role = "boot"
+		}
+		if rigFilter != "" && identity.Rig != rigFilter {
+			continue
+		}
+		if roleFilter != "" && role != roleFilter {
+			continue
+		}
+		var polecatName string
+		if identity.Role == session.RolePolecat {
+			polecatName = identity.Name
+		}
+		items = append(items, SessionListItem{
+			Rig:          identity.Rig,
+			Polecat:      polecatName,
+			SessionID:    d.Name,
+			Running:      true,
+			Role:         role,
+			Name:         identity.Name,
+			Address:      identity.GTRole(),
+			Clients:      d.Clients,
+			LastActivity: d.Activity,
+			PanePID:      d.PanePID,
+			WorkDir:      d.WorkDir,
+		})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Address != items[j].Address {
+			return items[i].Address < items[j].Address
+		}
+		return items[i].SessionID < items[j].SessionID
+	})
+	return items
+}
+
+// applySessionMeta attaches display names and labels to list items and keeps
+// only the items carrying every label in labels.
+func applySessionMeta(items []SessionListItem, meta map[string]*session.SessionMeta, labels []string) []SessionListItem {
+	var out []SessionListItem
+	for _, item := range items {
+		m := meta[item.SessionID]
+		if !m.HasLabels(labels) {
+			continue
+		}
+		if m != nil {
+			item.DisplayName = m.DisplayName
+			item.Labels = m.Labels
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
 func runSessionCapture(cmd *cobra.Command, args []string) error {
 	rigName, polecatName, err := parseAddress(args[0])
 	if err != nil {
@@ -500,7 +580,7 @@ func runSessionRestart(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	polecatMgr, _, err := getSessionManager(rigName)
+	polecatMgr, r, err := getSessionManager(rigName)
 	if err != nil {
 		return err
 	}
@@ -540,6 +620,7 @@ func runSessionRestart(cmd *cobra.Command, args []string) error {
 	if err := polecatMgr.Start(polecatName, opts); err != nil {
 		return fmt.Errorf("starting session: %w", err)
 	}
+	session.RunSessionHooks(filepath.Dir(r.Path), session.HookRestart, polecatMgr.SessionName(polecatName), "")
 
 	fmt.Printf("%s Session restarted. Attach with: %s\n",
 		style.Bold.Render("✓"),
@@ -586,6 +667,12 @@ func runSessionStatus(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  Attached: no\n")
 	}
 
+	if info.Idle {
+		fmt.Printf("  Agent: %s\n", style.Dim.Render("idle (waiting for input)"))
+	} else {
+		fmt.Printf("  Agent: working\n")
+	}
+
 	if !info.Created.IsZero() {
 		uptime := time.Since(info.Created)
 		fmt.Printf("  Created: %s\n", info.Created.Format("2006-01-02 15:04:05"))
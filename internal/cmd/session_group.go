@@ -0,0 +1,322 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/mayor"
+	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/witness"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var sessionGroupCmd = &cobra.Command{
+	Use:   "group",
+	Short: "Start and stop named groups of sessions",
+	Long: `Manage named session groups defined in town settings.
+
+Groups live under session_groups in settings/config.json:
+
+  "session_groups": {
+    "nightly": {
+      "description": "Overnight gastown crew",
+      "members": ["gastown/witness", "gastown/refinery",
+                  "gastown/polecats/Toast", "gastown/polecats/Nux"]
+    }
+  }
+
+Members are agent addresses. Polecats must already exist.`,
+	RunE: requireSubcommand,
+}
+
+var sessionGroupListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List session groups and how many members are running",
+	Args:  cobra.NoArgs,
+	RunE:  runSessionGroupList,
+}
+
+var sessionGroupStartCmd = &cobra.Command{
+	Use:   "start <name>",
+	Short: "Start every session in a group",
+	Long: `Start every member of a session group, in the order listed.
+
+Members that are already running are left alone. A member that fails to
+start is reported and the rest are still started.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionGroupStart,
+}
+
+var sessionGroupStopCmd = &cobra.Command{
+	Use:   "stop <name>",
+	Short: "Stop every session in a group",
+	Long: `Stop every running member of a session group.
+
+Sessions are stopped in the same order as 'gt shutdown': workers first,
+then refineries and witnesses, then town agents.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionGroupStop,
+}
+
+func init() {
+	sessionGroupCmd.AddCommand(sessionGroupListCmd)
+	sessionGroupCmd.AddCommand(sessionGroupStartCmd)
+	sessionGroupCmd.AddCommand(sessionGroupStopCmd)
+
+	sessionCmd.AddCommand(sessionGroupCmd)
+}
+
+// groupMember is a resolved session group member.
+type groupMember struct {
+	Address  string
+	Identity *session.AgentIdentity
+}
+
+// loadSessionGroup loads the named group from town settings and resolves its
+// members. Every member must be a valid agent address.
+func loadSessionGroup(townRoot, name string) ([]groupMember, error) {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return nil, fmt.Errorf("loading town settings: %w", err)
+	}
+	group := settings.SessionGroups[name]
+	if group == nil {
+		var names []string
+		for n := range settings.SessionGroups {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no session group %q (no groups defined in %s)", name, config.TownSettingsPath(townRoot))
+		}
+		return nil, fmt.Errorf("no session group %q (have: %s)", name, strings.Join(names, ", "))
+	}
+	return resolveGroupMembers(group.Members)
+}
+
+// resolveGroupMembers parses member addresses, rejecting duplicates and
+// addresses that don't name an agent.
+func resolveGroupMembers(addrs []string) ([]groupMember, error) {
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("session group has no members")
+	}
+	seen := make(map[string]bool, len(addrs))
+	members := make([]groupMember, 0, len(addrs))
+	for _, addr := range addrs {
+		identity, err := session.ParseAddress(addr)
+		if err != nil {
+			return nil, fmt.Errorf("member %q: %w", addr, err)
+		}
+		sessionName := identity.SessionName()
+		if seen[sessionName] {
+			return nil, fmt.Errorf("member %q is listed twice", addr)
+		}
+		seen[sessionName] = true
+		members = append(members, groupMember{Address: addr, Identity: identity})
+	}
+	return members, nil
+}
+
+func runSessionGroupList(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return fmt.Errorf("loading town settings: %w", err)
+	}
+	if len(settings.SessionGroups) == 0 {
+		fmt.Println("No session groups defined.")
+		fmt.Printf("  %s\n", style.Dim.Render("Add session_groups to "+config.TownSettingsPath(townRoot)))
+		return nil
+	}
+
+	names := make([]string, 0, len(settings.SessionGroups))
+	for n := range settings.SessionGroups {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	t := tmux.NewTmux()
+	for _, name := range names {
+		group := settings.SessionGroups[name]
+		running := 0
+		members, err := resolveGroupMembers(group.Members)
+		for _, m := range members {
+			if ok, _ := t.HasSession(m.Identity.SessionName()); ok {
+				running++
+			}
+		}
+		line := fmt.Sprintf("%s  %d/%d running", style.Bold.Render(name), running, len(group.Members))
+		if group.Description != "" {
+			line += "  " + style.Dim.Render(group.Description)
+		}
+		fmt.Println(line)
+		if err != nil {
+			fmt.Printf("  %s %v\n", style.WarningPrefix, err)
+		}
+		fmt.Printf("  %s\n", style.Dim.Render(strings.Join(group.Members, ", ")))
+	}
+	return nil
+}
+
+func runSessionGroupStart(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	members, err := loadSessionGroup(townRoot, args[0])
+	if err != nil {
+		return err
+	}
+
+	t := tmux.NewTmux()
+	var started, failed int
+	for _, m := range members {
+		if ok, _ := t.HasSession(m.Identity.SessionName()); ok {
+			fmt.Printf("  %s %s already running\n", style.Dim.Render("○"), m.Address)
+			continue
+		}
+		if err := startAgentSession(townRoot, m.Identity); err != nil {
+			fmt.Printf("  %s %s failed: %v\n", style.Dim.Render("○"), m.Address, err)
+			failed++
+			continue
+		}
+		fmt.Printf("  %s %s started\n", style.Bold.Render("✓"), m.Address)
+		started++
+	}
+
+	fmt.Println()
+	fmt.Printf("%s Group %s: started %d of %d\n", style.Bold.Render("✓"), args[0], started, len(members))
+	if failed > 0 {
+		return fmt.Errorf("%d member(s) of group %s failed to start", failed, args[0])
+	}
+	return nil
+}
+
+func runSessionGroupStop(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	members, err := loadSessionGroup(townRoot, args[0])
+	if err != nil {
+		return err
+	}
+
+	t := tmux.NewTmux()
+	var running []string
+	for _, m := range members {
+		name := m.Identity.SessionName()
+		if ok, _ := t.HasSession(name); ok {
+			running = append(running, name)
+		}
+	}
+	if len(running) == 0 {
+		fmt.Printf("No sessions in group %s are running.\n", args[0])
+		return nil
+	}
+
+	stopped := killSessionsInOrder(t, running, getMayorSessionName(), getDeaconSessionName())
+	fmt.Println()
+	fmt.Printf("%s Group %s: stopped %d of %d running\n", style.Bold.Render("✓"), args[0], stopped, len(running))
+	return nil
+}
+
+// startAgentSession starts the session for a single agent identity using the
+// role's manager. An agent that is already running is not an error.
+func startAgentSession(townRoot string, identity *session.AgentIdentity) error {
+	switch identity.Role {
+	case session.RoleMayor:
+		if err := mayor.NewManager(townRoot).Start(""); err != nil && !errors.Is(err, mayor.ErrAlreadyRunning) {
+			return err
+		}
+	case session.RoleDeacon:
+		if identity.Name == "boot" {
+			return fmt.Errorf("boot is started by the daemon")
+		}
+		if err := deacon.NewManager(townRoot).Start(""); err != nil && !errors.Is(err, deacon.ErrAlreadyRunning) {
+			return err
+		}
+	case session.RoleWitness:
+		_, r, err := getRig(identity.Rig)
+		if err != nil {
+			return err
+		}
+		if err := witness.NewManager(r).Start(false, "", nil); err != nil && !errors.Is(err, witness.ErrAlreadyRunning) {
+			return err
+		}
+	case session.RoleRefinery:
+		_, r, err := getRig(identity.Rig)
+		if err != nil {
+			return err
+		}
+		if err := refinery.NewManager(r).Start(false, ""); err != nil && !errors.Is(err, refinery.ErrAlreadyRunning) {
+			return err
+		}
+	case session.RoleCrew:
+		return startCrewMember(identity.Rig, identity.Name, townRoot)
+	case session.RolePolecat:
+		mgr, r, err := getSessionManager(identity.Rig)
+		if err != nil {
+			return err
+		}
+		found := false
+		for _, p := range r.Polecats {
+			if p == identity.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("polecat %s/%s does not exist", identity.Rig, identity.Name)
+		}
+		if err := mgr.Start(identity.Name, polecat.SessionStartOptions{}); err != nil && !errors.Is(err, polecat.ErrSessionRunning) {
+			return err
+		}
+	default:
+		return fmt.Errorf("can't start %s sessions", identity.Role)
+	}
+	return nil
+}
+
+// planAgentSession returns the session startAgentSession would create for
+// identity, without starting anything.
+func planAgentSession(townRoot string, identity *session.AgentIdentity) (*session.StartPlan, error) {
+	switch identity.Role {
+	case session.RoleMayor:
+		return mayor.NewManager(townRoot).Plan("")
+	case session.RoleDeacon:
+		if identity.Name == "boot" {
+			return nil, fmt.Errorf("boot is started by the daemon")
+		}
+		return deacon.NewManager(townRoot).Plan("")
+	case session.RoleWitness, session.RoleRefinery, session.RoleCrew:
+		_, r, err := getRig(identity.Rig)
+		if err != nil {
+			return nil, err
+		}
+		switch identity.Role {
+		case session.RoleWitness:
+			return witness.NewManager(r).Plan("", nil)
+		case session.RoleRefinery:
+			return refinery.NewManager(r).Plan("")
+		default:
+			return crew.NewManager(r, git.NewGit(r.Path)).Plan(identity.Name, crew.StartOptions{})
+		}
+	default:
+		return nil, fmt.Errorf("no start plan for %s sessions", identity.Role)
+	}
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func TestResolveGroupMembers(t *testing.T) {
+	members, err := resolveGroupMembers([]string{"mayor", "gastown/witness", "gastown/crew/max", "gastown/polecats/Toast", "gastown/Nux"})
+	if err != nil {
+		t.Fatalf("resolveGroupMembers: %v", err)
+	}
+	wantRoles := []session.Role{session.RoleMayor, session.RoleWitness, session.RoleCrew, session.RolePolecat, session.RolePolecat}
+	for i, m := range members {
+		if m.Identity.Role != wantRoles[i] {
+			t.Errorf("member %d (%s) role = %s, want %s", i, m.Address, m.Identity.Role, wantRoles[i])
+		}
+	}
+
+	bad := []struct {
+		name    string
+		members []string
+		want    string
+	}{
+		{"empty", nil, "no members"},
+		{"duplicate via short form", []string{"gastown/polecats/Toast", "gastown/Toast"}, "listed twice"},
+		{"invalid", []string{"gastown/crew"}, "invalid address"},
+	}
+	for _, tt := range bad {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveGroupMembers(tt.members)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("resolveGroupMembers(%v) error = %v, want %q", tt.members, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadSessionGroup(t *testing.T) {
+	townRoot := t.TempDir()
+	settings := config.NewTownSettings()
+	settings.SessionGroups = map[string]*config.SessionGroup{
+		"nightly": {Members: []string{"gastown/witness", "gastown/polecats/Toast"}},
+	}
+	if err := os.MkdirAll(filepath.Dir(config.TownSettingsPath(townRoot)), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	members, err := loadSessionGroup(townRoot, "nightly")
+	if err != nil {
+		t.Fatalf("loadSessionGroup: %v", err)
+	}
+	if len(members) != 2 || members[1].Identity.Name != "Toast" {
+		t.Errorf("members = %+v", members)
+	}
+
+	if _, err := loadSessionGroup(townRoot, "daily"); err == nil || !strings.Contains(err.Error(), "have: nightly") {
+		t.Errorf("unknown group error = %v, want list of groups", err)
+	}
+}
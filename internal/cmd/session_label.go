@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var sessionLabelRemove bool
+
+var sessionRenameCmd = &cobra.Command{
+	Use:   "rename <address> [name]",
+	Short: "Give a session a display name",
+	Long: `Give a session a human-friendly display name.
+
+The name is shown by 'gt session list' and stored in the session's @gt_name
+tmux option. The tmux session name itself does not change, because Gas Town
+derives agent identity from it. Omit the name to clear it.
+
+Examples:
+  gt session rename gastown/Toast "auth refactor"
+  gt session rename gastown/Toast          # Clear the display name`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSessionRename,
+}
+
+var sessionLabelCmd = &cobra.Command{
+	Use:   "label <address> <label>...",
+	Short: "Add or remove session labels",
+	Long: `Tag a session with free-form labels such as "urgent" or "demo".
+
+Labels are saved in Gas Town state (so they survive the agent restarting) and
+mirrored to the session's @gt_labels tmux option. Filter by label with
+'gt session list --label', 'gt broadcast --label', and 'gt shutdown --label'.
+
+With no labels, prints the session's current labels.
+
+Examples:
+  gt session label gastown/Toast urgent
+  gt session label gastown/crew/max demo review
+  gt session label gastown/Toast urgent --remove
+  gt session label gastown/Toast            # Show labels`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runSessionLabel,
+}
+
+func init() {
+	sessionLabelCmd.Flags().BoolVar(&sessionLabelRemove, "remove", false, "Remove the given labels instead of adding them")
+
+	sessionCmd.AddCommand(sessionRenameCmd)
+	sessionCmd.AddCommand(sessionLabelCmd)
+}
+
+func runSessionRename(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	sessionName, err := resolveAgentSessionName(args[0])
+	if err != nil {
+		return err
+	}
+	name := ""
+	if len(args) == 2 {
+		name = args[1]
+	}
+
+	if err := session.RenameSession(tmux.NewTmux(), townRoot, sessionName, name); err != nil {
+		return err
+	}
+	if name == "" {
+		fmt.Printf("%s Cleared display name for %s\n", style.SuccessPrefix, args[0])
+	} else {
+		fmt.Printf("%s %s is now %q\n", style.SuccessPrefix, args[0], name)
+	}
+	return nil
+}
+
+func runSessionLabel(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	sessionName, err := resolveAgentSessionName(args[0])
+	if err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		meta, err := session.LoadSessionMeta(townRoot)
+		if err != nil {
+			return err
+		}
+		if m := meta[sessionName]; m != nil && len(m.Labels) > 0 {
+			fmt.Println(strings.Join(m.Labels, ", "))
+		} else {
+			fmt.Println(style.Dim.Render("(no labels)"))
+		}
+		return nil
+	}
+
+	var add, remove []string
+	if sessionLabelRemove {
+		remove = args[1:]
+	} else {
+		add = args[1:]
+	}
+	labels, err := session.UpdateSessionLabels(tmux.NewTmux(), townRoot, sessionName, add, remove)
+	if err != nil {
+		return err
+	}
+	current := style.Dim.Render("(no labels)")
+	if len(labels) > 0 {
+		current = strings.Join(labels, ", ")
+	}
+	fmt.Printf("%s %s: %s\n", style.SuccessPrefix, args[0], current)
+	return nil
+}
+
+// filterSessionNamesByLabel keeps the sessions that carry every label in
+// labels. With no labels, sessions are returned unchanged.
+func filterSessionNamesByLabel(sessions []string, meta map[string]*session.SessionMeta, labels []string) []string {
+	if len(labels) == 0 {
+		return sessions
+	}
+	var out []string
+	for _, s := range sessions {
+		if meta[s].HasLabels(labels) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// loadSessionMetaForFilter loads session metadata when a label filter is in
+// use. Returns nil (no filtering needed) when labels is empty.
+func loadSessionMetaForFilter(townRoot string, labels []string) (map[string]*session.SessionMeta, error) {
+	if len(labels) == 0 {
+		return nil, nil
+	}
+	if townRoot == "" {
+		return nil, fmt.Errorf("--label requires a Gas Town workspace")
+	}
+	return session.LoadSessionMeta(townRoot)
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/workspace"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+var (
+	sessionLogsFollow  bool
+	sessionLogsLines   int
+	sessionLogPipeFile string
+)
+
+var sessionLogsCmd = &cobra.Command{
+	Use:   "logs <address>",
+	Short: "Show captured output for an agent session",
+	Long: `Show the persistent output log captured from an agent's tmux session.
+
+Every gt-managed session pipes its pane output to a rotating log under the
+agent's directory (e.g., <rig>/polecats/<name>/logs/). The log survives the
+session, so you can read what an agent was doing after it crashed.
+
+Set GT_SESSION_LOGS=0 to disable capture for newly started sessions.
+
+Examples:
+  gt session logs gastown/Toast            # Last 100 lines
+  gt session logs gastown/crew/max -n 500  # Last 500 lines
+  gt session logs mayor --follow           # Follow in real time`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionLogs,
+}
+
+var sessionLogPipeCmd = &cobra.Command{
+	Use:    "log-pipe",
+	Short:  "Write piped pane output to a rotating log (invoked by tmux pipe-pane)",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	RunE:   runSessionLogPipe,
+}
+
+func init() {
+	sessionLogsCmd.Flags().BoolVarP(&sessionLogsFollow, "follow", "f", false, "Follow log output")
+	sessionLogsCmd.Flags().IntVarP(&sessionLogsLines, "lines", "n", 100, "Number of lines to show")
+
+	sessionLogPipeCmd.Flags().StringVar(&sessionLogPipeFile, "file", "", "Log file to write")
+	_ = sessionLogPipeCmd.MarkFlagRequired("file")
+
+	sessionCmd.AddCommand(sessionLogsCmd)
+	sessionCmd.AddCommand(sessionLogPipeCmd)
+}
+
+// resolveAgentSessionName converts an agent address (mayor, gastown/witness,
+// gastown/crew/max, gastown/Toast) to its tmux session name. Bare polecat
+// names fall back to inferring the rig from the current directory.
+func resolveAgentSessionName(addr string) (string, error) {
+	if identity, err := session.ParseAddress(addr); err == nil {
+		return identity.SessionName(), nil
+	}
+	rigName, polecatName, err := parseAddress(addr)
+	if err != nil {
+		return "", err
+	}
+	return session.PolecatSessionName(session.PrefixFor(rigName), polecatName), nil
+}
+
+func runSessionLogs(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	sessionName, err := resolveAgentSessionName(args[0])
+	if err != nil {
+		return err
+	}
+
+	logFile, err := session.SessionLogPath(townRoot, sessionName)
+	if err != nil {
+		return fmt.Errorf("resolving log path: %w", err)
+	}
+	if _, err := os.Stat(logFile); os.IsNotExist(err) {
+		return fmt.Errorf("no session log found at %s", logFile)
+	}
+
+	if sessionLogsFollow {
+		// -F keeps following across rotation (file is renamed and recreated).
+		tailCmd := exec.Command("tail", "-n", fmt.Sprintf("%d", sessionLogsLines), "-F", logFile)
+		tailCmd.Stdout = os.Stdout
+		tailCmd.Stderr = os.Stderr
+		return tailCmd.Run()
+	}
+
+	tailCmd := exec.Command("tail", "-n", fmt.Sprintf("%d", sessionLogsLines), logFile)
+	tailCmd.Stdout = os.Stdout
+	tailCmd.Stderr = os.Stderr
+	return tailCmd.Run()
+}
+
+func runSessionLogPipe(cmd *cobra.Command, args []string) error {
+	w := &lumberjack.Logger{
+		Filename:   sessionLogPipeFile,
+		MaxSize:    session.SessionLogMaxSizeMB,
+		MaxBackups: session.SessionLogMaxBackups,
+	}
+	defer w.Close()
+
+	// Runs until the pane closes its end of the pipe (session exit).
+	if _, err := io.Copy(w, os.Stdin); err != nil {
+		return fmt.Errorf("copying pane output: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var sessionRestoreDryRun bool
+
+var sessionSnapshotCmd = &cobra.Command{
+	Use:   "snapshot [address...]",
+	Short: "Save agent sessions to disk for later restore",
+	Long: `Snapshot agent sessions so they can be restored after a reboot or a
+tmux server crash.
+
+A snapshot records the session's pane contents, environment, working
+directory, and the agent runtime's session ID (e.g., Claude's), and is stored
+under <town>/.runtime/snapshots/. With no arguments, every running Gas Town
+session is snapshotted.
+
+Examples:
+  gt session snapshot                     # Snapshot all sessions
+  gt session snapshot gastown/crew/max    # Snapshot one agent`,
+	RunE: runSessionSnapshot,
+}
+
+var sessionRestoreCmd = &cobra.Command{
+	Use:   "restore [address...]",
+	Short: "Recreate agent sessions from snapshots",
+	Long: `Recreate agent sessions from snapshots taken with 'gt session snapshot'.
+
+Restored agents resume their previous conversation when the runtime supports
+it (claude --resume <id>); otherwise they start fresh. A handoff marker is
+written so gt prime treats the new session as a successor and picks up
+hooked work instead of starting over.
+
+With no arguments, every snapshotted session that is not currently running
+is restored.
+
+Examples:
+  gt session restore                      # Restore everything that is down
+  gt session restore gastown/Toast        # Restore one agent
+  gt session restore --dry-run            # Show what would be restored`,
+	RunE: runSessionRestore,
+}
+
+func init() {
+	sessionRestoreCmd.Flags().BoolVarP(&sessionRestoreDryRun, "dry-run", "n", false, "Show what would be restored")
+
+	sessionCmd.AddCommand(sessionSnapshotCmd)
+	sessionCmd.AddCommand(sessionRestoreCmd)
+}
+
+func runSessionSnapshot(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	t := tmux.NewTmux()
+
+	var targets []string
+	if len(args) == 0 {
+		sessions, err := t.ListSessions()
+		if err != nil {
+			return fmt.Errorf("listing sessions: %w", err)
+		}
+		for _, s := range sessions {
+			if session.IsKnownSession(s) {
+				targets = append(targets, s)
+			}
+		}
+	} else {
+		for _, addr := range args {
+			name, err := resolveAgentSessionName(addr)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, name)
+		}
+	}
+
+	if len(targets) == 0 {
+		fmt.Println("No sessions to snapshot.")
+		return nil
+	}
+
+	var failed int
+	for _, name := range targets {
+		snap, err := session.TakeSnapshot(t, townRoot, name)
+		if err != nil {
+			style.PrintWarning("%s: %v", name, err)
+			failed++
+			continue
+		}
+		resume := style.Dim.Render("(no runtime session ID)")
+		if snap.RuntimeSessionID != "" {
+			resume = style.Dim.Render("resume " + snap.RuntimeSessionID)
+		}
+		fmt.Printf("%s Snapshotted %s %s\n", style.SuccessPrefix, snap.Address, resume)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d snapshots failed", failed, len(targets))
+	}
+	return nil
+}
+
+func runSessionRestore(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	t := tmux.NewTmux()
+
+	var snaps []*session.Snapshot
+	if len(args) == 0 {
+		snaps, err = session.ListSnapshots(townRoot)
+		if err != nil {
+			return fmt.Errorf("listing snapshots: %w", err)
+		}
+	} else {
+		for _, addr := range args {
+			name, err := resolveAgentSessionName(addr)
+			if err != nil {
+				return err
+			}
+			snap, err := session.LoadSnapshot(townRoot, name)
+			if err != nil {
+				if os.IsNotExist(err) {
+					return fmt.Errorf("no snapshot for %s", addr)
+				}
+				return err
+			}
+			snaps = append(snaps, snap)
+		}
+	}
+
+	var restored, failed int
+	for _, snap := range snaps {
+		if running, _ := t.HasSession(snap.Session); running {
+			if len(args) > 0 {
+				style.PrintWarning("%s is already running", snap.Address)
+			}
+			continue
+		}
+		if sessionRestoreDryRun {
+			fmt.Printf("Would restore %s: %s\n", snap.Address, snap.RestoreCommand(townRoot))
+			continue
+		}
+		if err := session.RestoreSnapshot(t, townRoot, snap); err != nil {
+			style.PrintWarning("%s: %v", snap.Address, err)
+			failed++
+			continue
+		}
+		restored++
+		fmt.Printf("%s Restored %s\n", style.SuccessPrefix, snap.Address)
+		if snap.PaneFile != "" {
+			fmt.Printf("  %s\n", style.Dim.Render("previous pane: "+snap.PaneFile))
+		}
+	}
+
+	if !sessionRestoreDryRun && restored == 0 && failed == 0 {
+		fmt.Println("Nothing to restore.")
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d session(s) failed to restore", failed)
+	}
+	return nil
+}
@@ -6,6 +6,8 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/polecat"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
 )
 
 func TestSessionInfoJSONOutput(t *testing.T) {
@@ -78,3 +80,96 @@ func TestSessionInfoJSONOutputNotRunning(t *testing.T) {
 		t.Errorf("running = %v, want false", parsed["running"])
 	}
 }
+
+func TestSessionListItemJSONOmitsZeroActivity(t *testing.T) {
+	data, err := json.Marshal(SessionListItem{SessionID: "gt-Toast"})
+	if err != nil {
+		t.Fatalf("json.Marshal failed: %v", err)
+	}
+
+	var parsed map[string]interface{}
+	if err := json.Unmarshal(data, &parsed); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+
+	if _, ok := parsed["last_activity"]; ok {
+		t.Errorf("last_activity = %v, want omitted when unknown", parsed["last_activity"])
+	}
+}
+
+func TestBuildSessionListItems(t *testing.T) {
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	reg.Register("bd", "beads")
+	old := session.DefaultRegistry()
+	session.SetDefaultRegistry(reg)
+	defer session.SetDefaultRegistry(old)
+
+	activity := time.Date(2026, 2, 20, 10, 0, 0, 0, time.UTC)
+	details := []tmux.SessionDetail{
+		{Name: "gt-Toast", Clients: 0, Activity: activity, PanePID: 101, WorkDir: "/town/gastown/polecats/Toast/gastown"},
+		{Name: "hq-mayor", Clients: 2, PanePID: 100, WorkDir: "/town/mayor"},
+		{Name: "gt-crew-max", Clients: 1, PanePID: 102},
+		{Name: "bd-witness", PanePID: 103},
+		{Name: "hq-boot", PanePID: 104},
+		{Name: "dotfiles-main", PanePID: 999}, // personal session, not Gas Town
+	}
+
+	items := buildSessionListItems(details, "", "")
+	var addrs []string
+	for _, it := range items {
+		addrs = append(addrs, it.Address)
+	}
+	want := []string{"beads/witness", "boot", "gastown/crew/max", "gastown/polecats/Toast", "mayor"}
+	if len(addrs) != len(want) {
+		t.Fatalf("addresses = %v, want %v", addrs, want)
+	}
+	for i := range want {
+		if addrs[i] != want[i] {
+			t.Errorf("addresses[%d] = %q, want %q", i, addrs[i], want[i])
+		}
+	}
+
+	toast := items[3]
+	if toast.Role != "polecat" || toast.Rig != "gastown" || toast.Name != "Toast" {
+		t.Errorf("toast identity = %+v", toast)
+	}
+	if toast.Polecat != "Toast" || !toast.Running {
+		t.Errorf("toast polecat/running = %q/%v, want Toast/true", toast.Polecat, toast.Running)
+	}
+	if mayor := items[4]; mayor.Polecat != "" {
+		t.Errorf("mayor polecat = %q, want empty", mayor.Polecat)
+	}
+	if toast.PanePID != 101 || !toast.LastActivity.Equal(activity) || toast.WorkDir == "" {
+		t.Errorf("toast runtime details not carried through: %+v", toast)
+	}
+
+	if got := buildSessionListItems(details, "gastown", ""); len(got) != 2 {
+		t.Errorf("--rig gastown returned %d items, want 2", len(got))
+	}
+	got := buildSessionListItems(details, "", "crew")
+	if len(got) != 1 || got[0].SessionID != "gt-crew-max" {
+		t.Errorf("--role crew returned %+v, want only gt-crew-max", got)
+	}
+}
+
+func TestApplySessionMeta_FiltersAndAnnotates(t *testing.T) {
+	items := []SessionListItem{{SessionID: "gt-Toast"}, {SessionID: "gt-Nux"}}
+	meta := map[string]*session.SessionMeta{
+		"gt-Toast": {DisplayName: "auth", Labels: []string{"urgent"}},
+	}
+
+	all := applySessionMeta(items, meta, nil)
+	if len(all) != 2 || all[0].DisplayName != "auth" || all[1].DisplayName != "" {
+		t.Errorf("unfiltered = %+v", all)
+	}
+
+	urgent := applySessionMeta(items, meta, []string{"urgent"})
+	if len(urgent) != 1 || urgent[0].SessionID != "gt-Toast" {
+		t.Errorf("filtered = %+v", urgent)
+	}
+
+	if got := filterSessionNamesByLabel([]string{"gt-Toast", "gt-Nux"}, meta, []string{"urgent"}); len(got) != 1 || got[0] != "gt-Toast" {
+		t.Errorf("filterSessionNamesByLabel = %v", got)
+	}
+}
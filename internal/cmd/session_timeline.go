@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/agentlog"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	sessionTimelineSince time.Duration
+	sessionTimelineJSON  bool
+	sessionTimelineTools bool
+)
+
+// timelineSummaryLen caps the length of a timeline entry's summary.
+const timelineSummaryLen = 120
+
+var sessionTimelineCmd = &cobra.Command{
+	Use:   "timeline <address>",
+	Short: "Show what an agent did, in time order",
+	Long: `Reconstruct an agent's recent activity as a single timeline.
+
+Merges three sources:
+  agent  Prompts, tool calls, and replies from the agent's conversation log
+  event  Gas Town events for the agent (sling, hook, nudge, mail, handoff, done, ...)
+  bead   Beads assigned to the agent that were updated or closed
+
+Examples:
+  gt session timeline gastown/Toast              # Last hour
+  gt session timeline gastown/crew/max --since 4h
+  gt session timeline mayor --json`,
+	Args: cobra.ExactArgs(1),
+	RunE: runSessionTimeline,
+}
+
+func init() {
+	sessionTimelineCmd.Flags().DurationVar(&sessionTimelineSince, "since", time.Hour, "How far back to look")
+	sessionTimelineCmd.Flags().BoolVar(&sessionTimelineJSON, "json", false, "Output as JSON")
+	sessionTimelineCmd.Flags().BoolVar(&sessionTimelineTools, "tools-only", false, "Show only prompts and tool calls from the conversation log")
+
+	sessionCmd.AddCommand(sessionTimelineCmd)
+}
+
+// TimelineEntry is one step in an agent's activity timeline.
+type TimelineEntry struct {
+	Time    time.Time `json:"time"`
+	Source  string    `json:"source"` // "agent", "event", or "bead"
+	Kind    string    `json:"kind"`
+	Summary string    `json:"summary"`
+}
+
+func runSessionTimeline(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	sessionName, err := resolveAgentSessionName(args[0])
+	if err != nil {
+		return err
+	}
+	identity, err := session.ParseSessionName(sessionName)
+	if err != nil {
+		return fmt.Errorf("parsing session %s: %w", sessionName, err)
+	}
+	since := time.Now().Add(-sessionTimelineSince)
+
+	var entries []TimelineEntry
+	for _, dir := range timelineWorkDirs(townRoot, identity, sessionName) {
+		agentEvents, err := agentlog.ReadClaudeCodeLog(sessionName, dir, since)
+		if err != nil {
+			style.PrintWarning("reading conversation log: %v", err)
+			continue
+		}
+		if len(agentEvents) > 0 {
+			entries = append(entries, timelineFromAgentEvents(agentEvents, sessionTimelineTools)...)
+			break
+		}
+	}
+
+	if !sessionTimelineTools {
+		evs, err := readTimelineEvents(filepath.Join(townRoot, events.EventsFile), timelineAliases(identity, sessionName), since)
+		if err != nil {
+			style.PrintWarning("reading events: %v", err)
+		}
+		entries = append(entries, evs...)
+
+		issues, err := beads.New(timelineBeadsDir(townRoot, identity)).List(beads.ListOptions{
+			Status:   "all",
+			Assignee: identity.Address(),
+			Priority: -1,
+		})
+		if err != nil {
+			style.PrintWarning("listing beads: %v", err)
+		}
+		entries = append(entries, timelineFromBeads(issues, since)...)
+	}
+
+	sortTimeline(entries)
+
+	if sessionTimelineJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if entries == nil {
+			entries = []TimelineEntry{}
+		}
+		return enc.Encode(entries)
+	}
+
+	if len(entries) == 0 {
+		fmt.Printf("No activity for %s in the last %s\n", args[0], sessionTimelineSince)
+		return nil
+	}
+	fmt.Printf("%s %s (last %s)\n\n", style.Bold.Render("Timeline:"), args[0], sessionTimelineSince)
+	for _, e := range entries {
+		fmt.Printf("%s  %-5s  %-12s %s\n",
+			style.Dim.Render(e.Time.Local().Format("15:04:05")), e.Source, e.Kind, e.Summary)
+	}
+	return nil
+}
+
+// timelineAliases returns the names an agent appears under in event actors
+// and payloads: its mail address, GT_ROLE, tmux session name, and for
+// polecats the short rig/name form.
+func timelineAliases(identity *session.AgentIdentity, sessionName string) map[string]bool {
+	aliases := map[string]bool{
+		identity.Address(): true,
+		identity.GTRole():  true,
+		sessionName:        true,
+	}
+	if identity.Role == session.RolePolecat {
+		aliases[identity.Rig+"/"+identity.Name] = true
+	}
+	return aliases
+}
+
+// readTimelineEvents returns the events in path at or after since that were
+// performed by, or directed at, one of aliases.
+func readTimelineEvents(path string, aliases map[string]bool, since time.Time) ([]TimelineEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	var entries []TimelineEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var e events.Event
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		ts, err := time.Parse(time.RFC3339, e.Timestamp)
+		if err != nil || ts.Before(since) {
+			continue
+		}
+		inbound, ok := eventInvolves(e, aliases)
+		if !ok {
+			continue
+		}
+		entries = append(entries, TimelineEntry{
+			Time:    ts,
+			Source:  "event",
+			Kind:    e.Type,
+			Summary: summarizeEvent(e, inbound),
+		})
+	}
+	return entries, scanner.Err()
+}
+
+// eventInvolves reports whether e involves one of aliases. inbound is true
+// when the agent is the event's target rather than its actor.
+func eventInvolves(e events.Event, aliases map[string]bool) (inbound, ok bool) {
+	if aliases[e.Actor] {
+		return false, true
+	}
+	for _, key := range []string{"target", "to", "session", "agent"} {
+		if aliases[getPayloadString(e.Payload, key)] {
+			return true, true
+		}
+	}
+	if rig, pc := getPayloadString(e.Payload, "rig"), getPayloadString(e.Payload, "polecat"); rig != "" && pc != "" {
+		if aliases[rig+"/"+pc] {
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// summarizeEvent renders a one-line description of e. Inbound events name
+// their actor, so the timeline shows who prompted the agent.
+func summarizeEvent(e events.Event, inbound bool) string {
+	p := func(key string) string { return getPayloadString(e.Payload, key) }
+	var s string
+	switch e.Type {
+	case events.TypeSling:
+		s = "work slung: " + p("bead")
+	case events.TypeHook, events.TypeUnhook:
+		s = p("bead")
+	case events.TypeHandoff:
+		s = p("subject")
+	case events.TypeDone:
+		s = p("bead")
+		if b := p("branch"); b != "" {
+			s += " (" + b + ")"
+		}
+	case events.TypeMail:
+		s = p("subject")
+		if !inbound {
+			s = "to " + p("to") + ": " + s
+		}
+	case events.TypeNudge:
+		s = p("reason")
+	default:
+		keys := make([]string, 0, len(e.Payload))
+		for k := range e.Payload {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		var parts []string
+		for _, k := range keys {
+			if v := p(k); v != "" {
+				parts = append(parts, k+"="+v)
+			}
+		}
+		s = strings.Join(parts, " ")
+	}
+	if inbound && e.Actor != "" {
+		s = "from " + e.Actor + ": " + s
+	}
+	return truncateTimeline(strings.TrimSpace(s))
+}
+
+// timelineFromAgentEvents converts conversation-log events to timeline
+// entries: user prompts, tool calls, and (unless toolsOnly) assistant replies.
+// Thinking, tool results, and token usage are left out as noise.
+func timelineFromAgentEvents(evs []agentlog.AgentEvent, toolsOnly bool) []TimelineEntry {
+	var entries []TimelineEntry
+	for _, ev := range evs {
+		var kind string
+		switch {
+		case ev.EventType == "text" && ev.Role == "user":
+			kind = "prompt"
+		case ev.EventType == "tool_use":
+			kind = "tool"
+		case ev.EventType == "text" && ev.Role == "assistant" && !toolsOnly:
+			kind = "reply"
+		default:
+			continue
+		}
+		entries = append(entries, TimelineEntry{
+			Time:    ev.Timestamp,
+			Source:  "agent",
+			Kind:    kind,
+			Summary: truncateTimeline(ev.Content),
+		})
+	}
+	return entries
+}
+
+// timelineFromBeads returns entries for issues updated or closed at or after
+// since. A close is reported instead of the update that performed it.
+func timelineFromBeads(issues []*beads.Issue, since time.Time) []TimelineEntry {
+	var entries []TimelineEntry
+	for _, issue := range issues {
+		label := issue.ID + " " + issue.Title
+		if closed, err := time.Parse(time.RFC3339, issue.ClosedAt); err == nil && !closed.Before(since) {
+			entries = append(entries, TimelineEntry{Time: closed, Source: "bead", Kind: "closed", Summary: truncateTimeline(label)})
+			continue
+		}
+		if updated, err := time.Parse(time.RFC3339, issue.UpdatedAt); err == nil && !updated.Before(since) {
+			entries = append(entries, TimelineEntry{Time: updated, Source: "bead", Kind: "updated", Summary: truncateTimeline(label + " [" + issue.Status + "]")})
+		}
+	}
+	return entries
+}
+
+// timelineWorkDirs returns candidate working directories for the agent's
+// conversation log, most specific first: the live pane's directory, the
+// polecat's worktree, then the role's home directory.
+func timelineWorkDirs(townRoot string, identity *session.AgentIdentity, sessionName string) []string {
+	var dirs []string
+	if dir, err := tmux.NewTmux().GetPaneWorkDir(sessionName); err == nil && dir != "" {
+		dirs = append(dirs, dir)
+	}
+	home := identity.RoleDir(townRoot)
+	if home == "" {
+		return dirs
+	}
+	if identity.Role == session.RolePolecat {
+		dirs = append(dirs, filepath.Join(home, identity.Rig))
+	}
+	return append(dirs, home)
+}
+
+// timelineBeadsDir returns the beads directory holding the agent's work:
+// the rig's beads for rig-scoped agents, town beads otherwise.
+func timelineBeadsDir(townRoot string, identity *session.AgentIdentity) string {
+	if identity.Rig != "" {
+		return filepath.Join(townRoot, identity.Rig, "mayor", "rig")
+	}
+	return townRoot
+}
+
+func sortTimeline(entries []TimelineEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Time.Before(entries[j].Time)
+	})
+}
+
+// truncateTimeline flattens s to a single line of at most timelineSummaryLen runes.
+func truncateTimeline(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > timelineSummaryLen {
+		return string(r[:timelineSummaryLen-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/agentlog"
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func TestReadTimelineEvents_MatchesAgent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".events.jsonl")
+	lines := []string{
+		`{"ts":"2026-03-01T09:00:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-old","target":"gastown/polecats/Toast"}}`,
+		`{"ts":"2026-03-01T10:01:00Z","type":"sling","actor":"mayor","payload":{"bead":"gt-abc","target":"gastown/polecats/Toast"}}`,
+		`{"ts":"2026-03-01T10:02:00Z","type":"hook","actor":"gastown/polecats/Toast","payload":{"bead":"gt-abc"}}`,
+		`{"ts":"2026-03-01T10:03:00Z","type":"hook","actor":"gastown/polecats/Nux","payload":{"bead":"gt-xyz"}}`,
+		`not json`,
+		`{"ts":"2026-03-01T10:04:00Z","type":"spawn","actor":"gt","payload":{"rig":"gastown","polecat":"Toast"}}`,
+		`{"ts":"2026-03-01T10:05:00Z","type":"done","actor":"gastown/Toast","payload":{"bead":"gt-abc","branch":"polecat/Toast"}}`,
+	}
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	identity := &session.AgentIdentity{Role: session.RolePolecat, Rig: "gastown", Name: "Toast", Prefix: "gt"}
+	since := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	entries, err := readTimelineEvents(path, timelineAliases(identity, "gt-Toast"), since)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct{ kind, summary string }{
+		{"sling", "from mayor: work slung: gt-abc"},
+		{"hook", "gt-abc"},
+		{"spawn", "from gt: polecat=Toast rig=gastown"},
+		{"done", "gt-abc (polecat/Toast)"},
+	}
+	if len(entries) != len(want) {
+		t.Fatalf("got %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+	for i, w := range want {
+		if entries[i].Kind != w.kind || entries[i].Summary != w.summary {
+			t.Errorf("entry %d = %s %q, want %s %q", i, entries[i].Kind, entries[i].Summary, w.kind, w.summary)
+		}
+	}
+}
+
+func TestTimelineFromAgentEvents(t *testing.T) {
+	ts := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	evs := []agentlog.AgentEvent{
+		{EventType: "text", Role: "user", Content: "Run gt prime", Timestamp: ts},
+		{EventType: "thinking", Role: "assistant", Content: "hmm", Timestamp: ts},
+		{EventType: "tool_use", Role: "assistant", Content: `Bash: {"command":"go test ./..."}`, Timestamp: ts},
+		{EventType: "tool_result", Role: "user", Content: "ok", Timestamp: ts},
+		{EventType: "text", Role: "assistant", Content: "Tests pass.", Timestamp: ts},
+		{EventType: "usage", Role: "assistant", Timestamp: ts},
+	}
+
+	got := timelineFromAgentEvents(evs, false)
+	var kinds []string
+	for _, e := range got {
+		kinds = append(kinds, e.Kind)
+	}
+	if strings.Join(kinds, ",") != "prompt,tool,reply" {
+		t.Errorf("kinds = %v, want prompt,tool,reply", kinds)
+	}
+
+	if got := timelineFromAgentEvents(evs, true); len(got) != 2 {
+		t.Errorf("tools-only: got %d entries, want 2", len(got))
+	}
+}
+
+func TestTimelineFromBeads(t *testing.T) {
+	since := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	issues := []*beads.Issue{
+		{ID: "gt-1", Title: "Fix auth", Status: "in_progress", UpdatedAt: "2026-03-01T10:30:00Z"},
+		{ID: "gt-2", Title: "Docs", Status: "closed", UpdatedAt: "2026-03-01T10:40:00Z", ClosedAt: "2026-03-01T10:40:00Z"},
+		{ID: "gt-3", Title: "Stale", Status: "open", UpdatedAt: "2026-02-01T10:00:00Z"},
+	}
+	got := timelineFromBeads(issues, since)
+	if len(got) != 2 {
+		t.Fatalf("got %d entries, want 2: %+v", len(got), got)
+	}
+	if got[0].Kind != "updated" || got[0].Summary != "gt-1 Fix auth [in_progress]" {
+		t.Errorf("entry 0 = %+v", got[0])
+	}
+	if got[1].Kind != "closed" || got[1].Summary != "gt-2 Docs" {
+		t.Errorf("entry 1 = %+v", got[1])
+	}
+}
+
+func TestTruncateTimeline(t *testing.T) {
+	if got := truncateTimeline("a\n  b\tc"); got != "a b c" {
+		t.Errorf("truncateTimeline flattened = %q", got)
+	}
+	long := strings.Repeat("é", timelineSummaryLen+10)
+	if got := []rune(truncateTimeline(long)); len(got) != timelineSummaryLen {
+		t.Errorf("truncated length = %d, want %d", len(got), timelineSummaryLen)
+	}
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func TestFilterShutdownSessions(t *testing.T) {
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	reg.Register("bd", "beads")
+	old := session.DefaultRegistry()
+	session.SetDefaultRegistry(reg)
+	defer session.SetDefaultRegistry(old)
+
+	mayorSession := session.MayorSessionName()
+	toast := session.PolecatSessionName("gt", "Toast")
+	nux := session.PolecatSessionName("bd", "Nux")
+	gtWitness := session.WitnessSessionName("gt")
+	bdRefinery := session.RefinerySessionName("bd")
+	all := []string{mayorSession, toast, nux, gtWitness, bdRefinery}
+
+	tests := []struct {
+		name                string
+		rigs, roles, except []string
+		want                []string
+	}{
+		{"rig", []string{"gastown"}, nil, nil, []string{toast, gtWitness}},
+		{"role", nil, []string{"polecat"}, nil, []string{toast, nux}},
+		{"rig and role", []string{"beads"}, []string{"polecat"}, nil, []string{nux}},
+		{"except role", nil, nil, []string{"mayor"}, []string{toast, nux, gtWitness, bdRefinery}},
+		{"except address", nil, []string{"polecat"}, []string{"gastown/polecats/Toast"}, []string{nux}},
+		{"except short address", []string{"gastown"}, nil, []string{"gastown/Toast"}, []string{gtWitness}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := filterShutdownSessions(all, tt.rigs, tt.roles, tt.except)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("filterShutdownSessions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateShutdownRoles(t *testing.T) {
+	if err := validateShutdownRoles([]string{"polecat", "boot", "gastown/witness"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if err := validateShutdownRoles([]string{"polecats"}); err == nil {
+		t.Error("expected an error for an unknown role")
+	}
+}
+
+func TestDaemonRespawnedRigs(t *testing.T) {
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	reg.Register("bd", "beads")
+	reg.Register("wy", "wyvern")
+	old := session.DefaultRegistry()
+	session.SetDefaultRegistry(reg)
+	defer session.SetDefaultRegistry(old)
+
+	sessions := []string{
+		session.PolecatSessionName("gt", "Toast"),
+		session.WitnessSessionName("gt"),
+		session.RefinerySessionName("gt"),
+		session.RefinerySessionName("bd"),
+		session.WitnessSessionName("wy"),
+	}
+	got := daemonRespawnedRigs(sessions, func(rigName string) bool { return rigName == "wyvern" })
+	want := []string{"gastown", "beads"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("daemonRespawnedRigs = %v, want %v", got, want)
+	}
+
+	if got := daemonRespawnedRigs([]string{session.PolecatSessionName("gt", "Toast")}, func(string) bool { return false }); len(got) != 0 {
+		t.Errorf("polecats alone should not warn, got %v", got)
+	}
+}
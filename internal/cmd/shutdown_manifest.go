@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// shutdownManifestTimeout bounds snapshotting one session and looking up
+// its hooked issue while writing the shutdown manifest.
+const shutdownManifestTimeout = 10 * time.Second
+
+// writeShutdownManifest snapshots every session and records the town's
+// topology for 'gt start --resume-last'. Sessions that can't be snapshotted
+// in time are still listed, and will be started fresh.
+func writeShutdownManifest(t *tmux.Tmux, townRoot string, sessions []string) (*session.ShutdownManifest, error) {
+	var mu sync.Mutex
+	entries := make(map[string]session.ManifestSession, len(sessions))
+	for _, sess := range sessions {
+		identity, err := session.ParseSessionName(sess)
+		if err != nil {
+			continue
+		}
+		entries[sess] = session.ManifestSession{
+			Session: sess,
+			Address: identity.Address(),
+			Role:    string(identity.Role),
+			Rig:     identity.Rig,
+		}
+	}
+
+	var targets []string
+	for sess := range entries {
+		targets = append(targets, sess)
+	}
+	runPerSession(targets, shutdownManifestTimeout, func(sess string) {
+		snap, err := session.TakeSnapshot(t, townRoot, sess)
+		if err != nil {
+			return
+		}
+		hooked := hookedIssueFor(snap.WorkDir, snap.Address)
+		mu.Lock()
+		defer mu.Unlock()
+		e := entries[sess]
+		e.Snapshotted = true
+		e.RuntimeSessionID = snap.RuntimeSessionID
+		e.HookedIssue = hooked
+		entries[sess] = e
+	})
+
+	mu.Lock()
+	m := &session.ShutdownManifest{WrittenAt: time.Now().UTC()}
+	for _, e := range entries {
+		m.Sessions = append(m.Sessions, e)
+	}
+	mu.Unlock()
+	sort.Slice(m.Sessions, func(i, j int) bool { return m.Sessions[i].Address < m.Sessions[j].Address })
+	return m, session.WriteShutdownManifest(townRoot, m)
+}
+
+// hookedIssueFor returns the ID of the issue hooked to the agent at
+// address, using the beads visible from workDir. Returns "" if none.
+func hookedIssueFor(workDir, address string) string {
+	if workDir == "" {
+		return ""
+	}
+	hooked, err := beads.New(workDir).List(beads.ListOptions{
+		Status:   beads.StatusHooked,
+		Assignee: mail.AddressToIdentity(address),
+		Priority: -1,
+	})
+	if err != nil || len(hooked) == 0 {
+		return ""
+	}
+	return hooked[0].ID
+}
+
+// manifestStartRank orders manifest sessions the way gt start does: town
+// agents, then rig agents, then workers.
+func manifestStartRank(role string) int {
+	switch session.Role(role) {
+	case session.RoleMayor:
+		return 0
+	case session.RoleDeacon:
+		return 1
+	case session.RoleWitness, session.RoleRefinery:
+		return 2
+	}
+	return 3
+}
+
+// orderManifestSessions returns the manifest's sessions in start order.
+func orderManifestSessions(m *session.ShutdownManifest) []session.ManifestSession {
+	ordered := append([]session.ManifestSession(nil), m.Sessions...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		ri, rj := manifestStartRank(ordered[i].Role), manifestStartRank(ordered[j].Role)
+		if ri != rj {
+			return ri < rj
+		}
+		return ordered[i].Address < ordered[j].Address
+	})
+	return ordered
+}
+
+// runStartResumeLast recreates the topology recorded by the last graceful
+// shutdown. Snapshotted agents resume their previous conversation where the
+// runtime supports it; the rest start fresh.
+func runStartResumeLast(townRoot string, dryRun bool) error {
+	m, err := session.LoadShutdownManifest(townRoot)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("no shutdown manifest found; one is written by 'gt shutdown --graceful'")
+		}
+		return err
+	}
+
+	fmt.Printf("Resuming %d session(s) from the shutdown at %s\n\n",
+		len(m.Sessions), m.WrittenAt.Local().Format("2006-01-02 15:04"))
+
+	if dryRun {
+		for _, e := range orderManifestSessions(m) {
+			fmt.Printf("  %s %s%s\n", style.Dim.Render("→"), e.Address, manifestDetail(e))
+		}
+		return nil
+	}
+
+	if err := runTownHooks(townRoot, session.TownHookPreStart); err != nil {
+		return fmt.Errorf("not starting: %w", err)
+	}
+	ensureDoltForStart(townRoot)
+	fmt.Println()
+
+	t := tmux.NewTmux()
+	var resumed, failed int
+	for _, e := range orderManifestSessions(m) {
+		if running, _ := t.HasSession(e.Session); running {
+			fmt.Printf("  %s %s already running\n", style.Dim.Render("○"), e.Address)
+			continue
+		}
+		if err := resumeManifestSession(t, townRoot, e); err != nil {
+			fmt.Printf("  %s %s: %v\n", style.Warning.Render("⚠"), e.Address, err)
+			failed++
+			continue
+		}
+		resumed++
+		fmt.Printf("  %s %s%s\n", style.Bold.Render("✓"), e.Address, manifestDetail(e))
+	}
+
+	fmt.Println()
+	_ = runTownHooks(townRoot, session.TownHookPostStart)
+	fmt.Printf("%s Resumed %d of %d session(s)\n", style.Bold.Render("✓"), resumed, len(m.Sessions))
+	if failed > 0 {
+		return fmt.Errorf("%d session(s) could not be resumed", failed)
+	}
+	return nil
+}
+
+// resumeManifestSession brings back one session: from its snapshot when
+// there is one and its work dir survived the shutdown, otherwise fresh.
+func resumeManifestSession(t *tmux.Tmux, townRoot string, e session.ManifestSession) error {
+	if e.Snapshotted {
+		snap, err := session.LoadSnapshot(townRoot, e.Session)
+		if err == nil {
+			if _, statErr := os.Stat(snap.WorkDir); statErr == nil {
+				return session.RestoreSnapshot(t, townRoot, snap)
+			}
+		}
+	}
+	identity, err := session.ParseSessionName(e.Session)
+	if err != nil {
+		return err
+	}
+	if identity.Role == session.RolePolecat {
+		return fmt.Errorf("worktree was cleaned up at shutdown; re-sling %s", manifestIssueOr(e, "its work"))
+	}
+	return startAgentSession(townRoot, identity)
+}
+
+// manifestDetail describes what resuming a manifest session brings back.
+func manifestDetail(e session.ManifestSession) string {
+	detail := ""
+	if e.HookedIssue != "" {
+		detail += " hooked " + e.HookedIssue
+	}
+	if e.RuntimeSessionID != "" {
+		detail += " resume " + e.RuntimeSessionID
+	} else {
+		detail += " fresh start"
+	}
+	return style.Dim.Render(" (" + detail[1:] + ")")
+}
+
+func manifestIssueOr(e session.ManifestSession, fallback string) string {
+	if e.HookedIssue != "" {
+		return e.HookedIssue
+	}
+	return fallback
+}
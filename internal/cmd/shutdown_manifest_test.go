@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/session"
+)
+
+func TestOrderManifestSessions(t *testing.T) {
+	m := &session.ShutdownManifest{Sessions: []session.ManifestSession{
+		{Address: "gastown/crew/max", Role: "crew"},
+		{Address: "gastown/polecats/Toast", Role: "polecat"},
+		{Address: "gastown/witness", Role: "witness"},
+		{Address: "deacon/", Role: "deacon"},
+		{Address: "mayor/", Role: "mayor"},
+		{Address: "gastown/refinery", Role: "refinery"},
+	}}
+	var got []string
+	for _, e := range orderManifestSessions(m) {
+		got = append(got, e.Address)
+	}
+	want := []string{"mayor/", "deacon/", "gastown/refinery", "gastown/witness", "gastown/crew/max", "gastown/polecats/Toast"}
+	if !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// shutdownParallelism caps how many sessions a shutdown signals or kills at
+// once, so a large town doesn't fork hundreds of kill/ps processes together.
+const shutdownParallelism = 16
+
+// Per-session deadlines for shutdown. A session that misses its deadline is
+// reported and left behind rather than holding up the rest of the town.
+const (
+	// shutdownNotifyTimeout bounds interrupting one agent and sending it the
+	// handoff request.
+	shutdownNotifyTimeout = 5 * time.Second
+
+	// shutdownKillTimeout bounds killing one session and its processes,
+	// which includes two SIGTERM grace periods.
+	shutdownKillTimeout = 15 * time.Second
+)
+
+// runPerSession calls fn for every session concurrently, at most
+// shutdownParallelism at a time, and waits up to timeout for each call.
+// Returns the sessions whose call missed its deadline, sorted; those calls
+// are abandoned and finish in the background.
+func runPerSession(sessions []string, timeout time.Duration, fn func(sess string)) []string {
+	var (
+		mu       sync.Mutex
+		timedOut []string
+		wg       sync.WaitGroup
+	)
+	sem := make(chan struct{}, shutdownParallelism)
+	for _, sess := range sessions {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(sess string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				fn(sess)
+			}()
+			timer := time.NewTimer(timeout)
+			defer timer.Stop()
+			select {
+			case <-done:
+			case <-timer.C:
+				mu.Lock()
+				timedOut = append(timedOut, sess)
+				mu.Unlock()
+			}
+		}(sess)
+	}
+	wg.Wait()
+	sort.Strings(timedOut)
+	return timedOut
+}
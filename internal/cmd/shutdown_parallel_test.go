@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRunPerSession_RunsInParallel(t *testing.T) {
+	var sessions []string
+	for i := 0; i < 10; i++ {
+		sessions = append(sessions, fmt.Sprintf("s%d", i))
+	}
+	var calls atomic.Int32
+	start := time.Now()
+	timedOut := runPerSession(sessions, time.Second, func(string) {
+		time.Sleep(100 * time.Millisecond)
+		calls.Add(1)
+	})
+	if len(timedOut) != 0 {
+		t.Errorf("timedOut = %v, want none", timedOut)
+	}
+	if got := calls.Load(); got != 10 {
+		t.Errorf("calls = %d, want 10", got)
+	}
+	// Serially this would take a second.
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Errorf("took %s, want sessions handled in parallel", elapsed)
+	}
+}
+
+func TestRunPerSession_ReportsTimeouts(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+	timedOut := runPerSession([]string{"fast", "hung-b", "hung-a"}, 50*time.Millisecond, func(sess string) {
+		if sess != "fast" {
+			<-block
+		}
+	})
+	if want := []string{"hung-a", "hung-b"}; !slices.Equal(timedOut, want) {
+		t.Errorf("timedOut = %v, want %v", timedOut, want)
+	}
+}
@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/mail"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// handoffPollInterval is how often a graceful shutdown re-checks agents that
+// have not finished handing off.
+const handoffPollInterval = 2 * time.Second
+
+// handoffProbe is the subset of tmux a graceful shutdown uses to decide
+// whether an agent has finished handing off.
+type handoffProbe interface {
+	HasSession(name string) (bool, error)
+	IsAgentAlive(session string) bool
+	IdleWithQuiet(session string, quiet time.Duration) (*tmux.IdleState, error)
+}
+
+// requestHandoffAndWait runs the first three phases of a graceful shutdown:
+// interrupt every agent, ask it to hand off with msg, then wait up to
+// waitSecs for each agent to finish.
+//
+// An agent is finished once its session or agent process has exited, or it
+// is back at an idle prompt having sent its handoff mail. Finished workers
+// (polecats and crew) are stopped straight away; town and rig agents are
+// left for the caller to stop in shutdown order. Agents are interrupted,
+// notified, and checked in parallel, each with its own deadline. Returns the
+// number of sessions stopped early.
+func requestHandoffAndWait(t *tmux.Tmux, townRoot string, sessions []string, msg string, waitSecs int) int {
+	// Phase 1: Send ESC to all agents to interrupt them
+	fmt.Printf("Phase 1: Sending ESC to %d agent(s)...\n", len(sessions))
+	for _, sess := range sessions {
+		fmt.Printf("  %s Interrupting %s\n", style.Bold.Render("→"), sess)
+	}
+	reportNotifyTimeouts(runPerSession(sessions, shutdownNotifyTimeout, func(sess string) {
+		_ = t.SendKeysRaw(sess, "Escape") // best-effort interrupt
+	}))
+
+	// Phase 2: Send shutdown message asking agents to handoff
+	fmt.Printf("\nPhase 2: Requesting handoff from agents...\n")
+	requestedAt := time.Now()
+	reportNotifyTimeouts(runPerSession(sessions, shutdownNotifyTimeout, func(sess string) {
+		// Small delay so the interrupt lands, then send the message
+		time.Sleep(constants.ShutdownNotifyDelay)
+		_ = t.SendKeys(sess, msg) // best-effort notification
+	}))
+
+	// Phase 3: Wait for agents to complete handoff
+	fmt.Printf("\nPhase 3: Waiting up to %ds for agents to complete handoff...\n", waitSecs)
+	fmt.Printf("  %s\n", style.Dim.Render("(Press Ctrl-C to force immediate shutdown)"))
+
+	mayorSession := getMayorSessionName()
+	deaconSession := getDeaconSessionName()
+	pending := append([]string(nil), sessions...)
+	stopped := 0
+	deadline := time.Now().Add(time.Duration(waitSecs) * time.Second)
+	lastReport := time.Now()
+	for len(pending) > 0 && time.Now().Before(deadline) {
+		handedOff := recentHandoffs(townRoot, requestedAt)
+		reasons := make(map[string]string, len(pending))
+		var reasonsMu sync.Mutex
+		runPerSession(pending, shutdownNotifyTimeout, func(sess string) {
+			reason := handoffDone(t, sess, handedOff)
+			reasonsMu.Lock()
+			reasons[sess] = reason
+			reasonsMu.Unlock()
+		})
+		var still, finishedWorkers []string
+		reasonsMu.Lock()
+		for _, sess := range pending {
+			reason := reasons[sess]
+			if reason == "" {
+				still = append(still, sess)
+				continue
+			}
+			fmt.Printf("  %s %s %s\n", style.Bold.Render("✓"), sess, reason)
+			if isWorkerSession(sess) {
+				finishedWorkers = append(finishedWorkers, sess)
+			}
+		}
+		reasonsMu.Unlock()
+		if len(finishedWorkers) > 0 {
+			stopped += killSessionsInOrder(t, finishedWorkers, mayorSession, deaconSession)
+		}
+		pending = still
+		if len(pending) == 0 {
+			break
+		}
+
+		if time.Since(lastReport) >= 10*time.Second {
+			remaining := time.Until(deadline).Round(time.Second)
+			fmt.Printf("  %s %d agent(s) still working, %s remaining...\n", style.Dim.Render("⏳"), len(pending), remaining)
+			lastReport = time.Now()
+		}
+		wait := handoffPollInterval
+		if left := time.Until(deadline); left < wait {
+			wait = left
+		}
+		time.Sleep(wait)
+	}
+
+	if len(pending) == 0 {
+		fmt.Printf("  %s All agents done\n", style.Bold.Render("✓"))
+	} else {
+		fmt.Printf("  %s Timed out waiting for %d agent(s)\n", style.Dim.Render("○"), len(pending))
+	}
+	return stopped
+}
+
+// reportNotifyTimeouts warns about agents that didn't take a shutdown
+// notification in time. They are still stopped in the kill phase.
+func reportNotifyTimeouts(timedOut []string) {
+	for _, sess := range timedOut {
+		fmt.Printf("  %s %s not responding after %s, skipping\n", style.Warning.Render("⚠"), sess, shutdownNotifyTimeout)
+	}
+}
+
+// handoffDone reports why sess has finished handing off, or "" if it
+// hasn't. handedOff holds the identities that have sent handoff mail since
+// the shutdown was requested.
+func handoffDone(p handoffProbe, sess string, handedOff map[string]bool) string {
+	if ok, err := p.HasSession(sess); err == nil && !ok {
+		return "exited"
+	}
+	if !p.IsAgentAlive(sess) {
+		return "agent exited"
+	}
+	identity, err := session.ParseSessionName(sess)
+	if err != nil || !handedOff[mail.AddressToIdentity(identity.Address())] {
+		return ""
+	}
+	if st, err := p.IdleWithQuiet(sess, tmux.DefaultIdleQuietPeriod); err == nil && st.Idle {
+		return "handed off"
+	}
+	return ""
+}
+
+// recentHandoffs returns the identities that have created handoff mail since
+// since. Handoff mail is hooked to its sender by 'gt handoff'. Lookup errors
+// yield an empty set, so agents then finish only by exiting.
+func recentHandoffs(townRoot string, since time.Time) map[string]bool {
+	handedOff := make(map[string]bool)
+	if townRoot == "" {
+		return handedOff
+	}
+	issues, err := beads.New(townRoot).List(beads.ListOptions{
+		Status:   beads.StatusHooked,
+		Label:    "gt:message",
+		Priority: -1,
+	})
+	if err != nil {
+		return handedOff
+	}
+	for _, issue := range issues {
+		if !strings.Contains(issue.Title, "HANDOFF") || issue.Assignee == "" {
+			continue
+		}
+		created, err := time.Parse(time.RFC3339, issue.CreatedAt)
+		if err != nil || created.Before(since.Truncate(time.Second)) {
+			continue
+		}
+		handedOff[mail.AddressToIdentity(issue.Assignee)] = true
+	}
+	return handedOff
+}
+
+// isWorkerSession reports whether sess is a polecat or crew session, which
+// can be stopped as soon as it is done without upsetting shutdown order.
+func isWorkerSession(sess string) bool {
+	identity, err := session.ParseSessionName(sess)
+	if err != nil {
+		return false
+	}
+	return identity.Role == session.RolePolecat || identity.Role == session.RoleCrew
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+type fakeHandoffProbe struct {
+	missing map[string]bool
+	dead    map[string]bool
+	idle    map[string]bool
+}
+
+func (f *fakeHandoffProbe) HasSession(name string) (bool, error) { return !f.missing[name], nil }
+func (f *fakeHandoffProbe) IsAgentAlive(s string) bool           { return !f.dead[s] }
+func (f *fakeHandoffProbe) IdleWithQuiet(s string, _ time.Duration) (*tmux.IdleState, error) {
+	return &tmux.IdleState{Idle: f.idle[s]}, nil
+}
+
+func TestHandoffDone(t *testing.T) {
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	old := session.DefaultRegistry()
+	session.SetDefaultRegistry(reg)
+	defer session.SetDefaultRegistry(old)
+
+	gone := session.PolecatSessionName("gt", "Gone")
+	dead := session.PolecatSessionName("gt", "Dead")
+	done := session.PolecatSessionName("gt", "Toast")
+	busy := session.CrewSessionName("gt", "max")
+	silent := session.WitnessSessionName("gt")
+
+	p := &fakeHandoffProbe{
+		missing: map[string]bool{gone: true},
+		dead:    map[string]bool{dead: true},
+		idle:    map[string]bool{done: true, silent: true},
+	}
+	handedOff := map[string]bool{"gastown/Toast": true, "gastown/max": true}
+
+	tests := []struct {
+		sess string
+		want string
+	}{
+		{gone, "exited"},
+		{dead, "agent exited"},
+		{done, "handed off"},
+		{busy, ""},   // handed off but still working
+		{silent, ""}, // idle but never handed off
+	}
+	for _, tt := range tests {
+		if got := handoffDone(p, tt.sess, handedOff); got != tt.want {
+			t.Errorf("handoffDone(%s) = %q, want %q", tt.sess, got, tt.want)
+		}
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -372,9 +371,10 @@ func storeFieldsInBead(beadID string, updates beadFieldUpdates) error {
 	return nil
 }
 
-// injectStartPrompt sends a prompt to the target pane to start working.
-// Uses the reliable nudge pattern: literal mode + 500ms debounce + separate Enter.
-func injectStartPrompt(pane, beadID, subject, args string) error {
+// injectStartPrompt sends a prompt to the target pane on t's server to start
+// working. Uses the reliable nudge pattern: literal mode + 500ms debounce +
+// separate Enter.
+func injectStartPrompt(t *tmux.Tmux, pane, beadID, subject, args string) error {
 	if pane == "" {
 		return fmt.Errorf("no target pane")
 	}
@@ -400,7 +400,6 @@ func injectStartPrompt(pane, beadID, subject, args string) error {
 	}
 
 	// Use the reliable nudge pattern (same as gt nudge / tmux.NudgeSession)
-	t := tmux.NewTmux()
 	return t.NudgePane(pane, prompt)
 }
 
@@ -427,14 +426,14 @@ func getSessionFromPane(pane string) string {
 
 // ensureAgentReady waits for an agent to be ready before nudging an existing session.
 // Uses a pragmatic approach: wait for the pane to leave a shell, then (Claude-only)
-// accept the bypass permissions warning and give it a moment to finish initializing.
+// accept the bypass permissions warning and wait for it to reach its input prompt.
 func ensureAgentReady(sessionName string) error {
-	t := tmux.NewTmux()
+	t := tmuxForSession(sessionName)
 
 	if t.IsAgentRunning(sessionName) {
 		// Agent process is detected, but it may have just started (fresh spawn).
 		// Check session age — if < 15s old, the agent likely isn't ready for input yet.
-		if !isSessionYoung(sessionName, 15*time.Second) {
+		if !isSessionYoung(t, sessionName, 15*time.Second) {
 			return nil
 		}
 		// Fall through to apply startup delay for young sessions.
@@ -452,34 +451,11 @@ func ensureAgentReady(sessionName string) error {
 		_ = t.AcceptBypassPermissionsWarning(sessionName)
 	}
 
-	// Use prompt-detection polling instead of fixed sleep.
-	// For known presets: uses ReadyPromptPrefix (e.g. "❯ " for Claude) polled every 200ms.
-	// For unknown/custom agents: falls back to a 1s fixed delay (mirrors old behavior).
-	// Note: uses preset-only resolution (not ResolveRoleAgentConfig) because
-	// ensureAgentReady lacks rig/town context — only has the session name.
-	effectiveName := agentName
-	if effectiveName == "" {
-		effectiveName = "claude" // Default sessions without GT_AGENT are Claude
-	}
-	var rc *config.RuntimeConfig
-	if preset := config.GetAgentPreset(config.AgentPreset(effectiveName)); preset != nil {
-		rc = config.RuntimeConfigFromPreset(config.AgentPreset(effectiveName))
-	} else {
-		// Unknown agent — use minimal config: no prompt detection, short fixed delay.
-		rc = &config.RuntimeConfig{
-			Tmux: &config.RuntimeTmuxConfig{
-				ReadyDelayMs: 1000,
-			},
-		}
-	}
-	// Ensure a minimum 1s readiness delay for presets without prompt detection.
-	// Without this, agents with ReadyPromptPrefix="" and ReadyDelayMs=0
-	// (e.g. gemini, cursor) would skip the readiness guard entirely,
-	// reintroducing early-input races that this function exists to prevent.
-	if rc.Tmux != nil && rc.Tmux.ReadyPromptPrefix == "" && rc.Tmux.ReadyDelayMs < 1000 {
-		rc.Tmux.ReadyDelayMs = 1000
-	}
-	if err := t.WaitForRuntimeReady(sessionName, rc, constants.ClaudeStartTimeout); err != nil {
+	// Wait until the agent has finished starting (prompt drawn, SessionStart
+	// hooks done, pane quiet) so the dispatch nudge isn't typed mid-startup.
+	// Agents without prompt detection wait for the pane to go quiet instead,
+	// for at most their ready_delay_ms.
+	if err := t.WaitForPrompt(sessionName, constants.ClaudeStartTimeout); err != nil {
 		// Graceful degradation: warn but proceed (matches original behavior of always continuing)
 		fmt.Fprintf(os.Stderr, "Warning: agent readiness detection timed out for %s: %v\n", sessionName, err)
 	}
@@ -488,13 +464,9 @@ func ensureAgentReady(sessionName string) error {
 }
 
 // isSessionYoung returns true if the tmux session was created less than maxAge ago.
-func isSessionYoung(sessionName string, maxAge time.Duration) bool {
-	out, err := tmux.BuildCommand("display-message", "-t", sessionName, "-p", "#{session_created}").Output()
-	if err != nil {
-		return false
-	}
-	createdUnix, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
-	if err != nil {
+func isSessionYoung(t *tmux.Tmux, sessionName string, maxAge time.Duration) bool {
+	createdUnix, err := t.GetSessionCreatedUnix(sessionName)
+	if err != nil || createdUnix <= 0 {
 		return false
 	}
 	return time.Since(time.Unix(createdUnix, 0)) < maxAge
@@ -983,8 +955,7 @@ func isHookedAgentDead(assignee string) bool {
 	if sessionName == "" {
 		return false // Unknown format, can't determine
 	}
-	t := tmux.NewTmux()
-	alive, err := t.HasSession(sessionName)
+	alive, err := tmuxForSession(sessionName).HasSession(sessionName)
 	if err != nil {
 		return false // tmux not available or error, be conservative
 	}
@@ -1106,12 +1077,9 @@ func loadRigCommandVars(townRoot, rig string) []string {
 	}
 	var vars []string
 
-	// Load default_branch from rig root config.json (single source of truth per 5ee9abcc).
-	// This sets base_branch for formula instantiation so polecats fork from the right branch.
-	rigCfg, err := rigpkg.LoadRigConfig(filepath.Join(townRoot, rig))
-	if err == nil && rigCfg != nil && rigCfg.DefaultBranch != "" {
-		vars = append(vars, fmt.Sprintf("base_branch=%s", rigCfg.DefaultBranch))
-	}
+	// Set base_branch from the rig's default branch (rigs.json, then config.json,
+	// then detection) so polecats fork from the right branch.
+	vars = append(vars, fmt.Sprintf("base_branch=%s", rigpkg.ResolveDefaultBranch(filepath.Join(townRoot, rig))))
 
 	// Load repo-sourced settings (floor — committed to git, always present after clone)
 	var repoMQ *config.MergeQueueConfig
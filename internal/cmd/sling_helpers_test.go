@@ -109,6 +109,9 @@ func TestWakeRigAgentsDoesNotNudgeRefinery(t *testing.T) {
 func TestNudgeRefineryNoOpWithoutLog(t *testing.T) {
 	// Ensure test log is NOT set so we exercise the real tmux path
 	t.Setenv("GT_TEST_NUDGE_LOG", "")
+	// nudgeRefinery emits an MQ_SUBMIT event into the town found from cwd;
+	// run outside the source tree so it doesn't land in internal/events/.
+	t.Chdir(t.TempDir())
 
 	// Should not panic even though no tmux session exists
 	nudgeRefinery("nonexistent-rig", "test message")
@@ -6,7 +6,6 @@ import (
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/session"
-	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -34,8 +33,7 @@ func resolveTargetAgent(target string) (agentID string, pane string, hookRoot st
 	}
 
 	// Get the target's working directory for hook storage
-	t := tmux.NewTmux()
-	hookRoot, err = t.GetPaneWorkDir(sessionName)
+	hookRoot, err = tmuxForSession(sessionName).GetPaneWorkDir(sessionName)
 	if err != nil {
 		return "", "", "", fmt.Errorf("getting working dir for %s: %w", sessionName, err)
 	}
@@ -187,10 +185,7 @@ func resolveTarget(target string, opts ResolveTargetOptions) (*ResolvedTarget, e
 		}
 		if townRoot != "" {
 			if blocked, reason := IsRigParkedOrDocked(townRoot, rigName); blocked {
-				undoCmd := "gt rig unpark"
-				if reason == "docked" {
-					undoCmd = "gt rig undock"
-				}
+				undoCmd := rigResumeCmd(reason)
 				return nil, fmt.Errorf("cannot sling to %s rig %q\n%s %s", reason, rigName, undoCmd, rigName)
 			}
 		}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -16,10 +17,9 @@ import (
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/crew"
 	"github.com/steveyegge/gastown/internal/daemon"
-	"github.com/steveyegge/gastown/internal/deacon"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/git"
-	"github.com/steveyegge/gastown/internal/mayor"
+	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/refinery"
 	"github.com/steveyegge/gastown/internal/rig"
@@ -44,6 +44,11 @@ var (
 	startCrewAccount            string
 	startCrewAgentOverride      string
 	startCostTier               string
+	startDryRun                 bool
+	startResumeLast             bool
+	startProfile                string
+	startReadyTimeout           time.Duration
+	startRigs                   []string
 	shutdownGraceful            bool
 	shutdownWait                int
 	shutdownAll                 bool
@@ -53,6 +58,11 @@ var (
 	shutdownNuclear             bool
 	shutdownCleanupOrphans      bool
 	shutdownCleanupOrphansGrace int
+	shutdownLabels              []string
+	shutdownRigs                []string
+	shutdownRoles               []string
+	shutdownExcept              []string
+	shutdownKeepDaemon          bool
 )
 
 var startCmd = &cobra.Command{
@@ -64,8 +74,56 @@ var startCmd = &cobra.Command{
 The Deacon is the health-check orchestrator that monitors Mayor and Witnesses.
 The Mayor is the global coordinator that dispatches work.
 
-By default, other agents (Witnesses, Refineries) are started lazily as needed.
-Use --all to start Witnesses and Refineries for all registered rigs immediately.
+Which other agents start is set by a startup profile:
+  minimal  - Mayor and Deacon only (the default)
+  full     - also every rig's Witness and Refinery (same as --all)
+
+Define more profiles, or override these, under startup_profiles in
+settings/config.json, and pick the default with default_startup_profile:
+
+  "startup_profiles": {
+    "frontend": {"rig_agents": true, "rigs": ["webapp"],
+                 "members": ["webapp/crew/max"]}
+  }
+
+Agents not in the profile are started lazily as needed.
+
+Agents start in dependency order: Mayor, then Deacon, then each rig's
+Witness and Refinery, then crew and polecats once their rig's agents are up.
+An agent counts as up once its session answers and it has finished priming
+(back at a prompt). --ready-timeout bounds that wait: an agent still busy
+after it (e.g. already working its hook) is reported and its dependents
+start anyway. Agents that depend on one that failed to start are skipped and
+reported.
+
+Use --rig to bring a single rig online without touching the rest of the
+town: the Dolt server is started if needed, then the rig's Witness, its
+Refinery, and its configured crew, in that order.
+
+Use --dry-run to validate a profile or a new rig's config: it prints the
+sessions gt start would create, in start order, with each one's working
+directory, environment, and command, without touching tmux or Dolt.
+
+Use --resume-last after 'gt shutdown --graceful' to bring back exactly the
+agents that were running then. Each resumes its previous conversation where
+the agent runtime supports it (claude --resume) and starts fresh otherwise.
+
+Commands listed under hooks.pre_start and hooks.post_start in
+mayor/town.json run before and after the agents start (e.g. to start a local
+model proxy). A failing pre_start hook marked "required" aborts the start.
+
+A "schedule" in mayor/town.json lets the daemon shut the town down and start
+it on a timetable (quiet hours), or pause dispatch during set windows:
+
+  "schedule": [
+    {"cron": "0 22 * * *",   "action": "shutdown"},
+    {"cron": "0 7 * * 1-5",  "action": "start"},
+    {"cron": "0 12 * * *",   "action": "pause_dispatch"},
+    {"cron": "0 13 * * *",   "action": "resume_dispatch"}
+  ]
+
+During quiet hours the daemon keeps running but does not restart agents.
+Running gt start by hand ends quiet hours early.
 
 Crew shortcut:
   If a path like "rig/crew/name" is provided, starts that crew workspace.
@@ -99,8 +157,26 @@ Shutdown levels (progressively more aggressive):
   --all           - Also stop crew sessions
   --polecats-only - Only stop polecats (leaves infrastructure running)
 
+Scoped shutdowns narrow any level, and combine:
+  --rig <name>     - Only sessions of this rig (repeatable)
+  --role <role>    - Only sessions with this role, e.g. polecat (repeatable)
+  --except <agent> - Keep this role or agent address running, e.g. mayor
+  --label <label>  - Only sessions tagged with 'gt session label'
+A scoped shutdown only stops the matching sessions: polecat worktrees and the
+daemon are left alone. --role crew includes crew without --all.
+
+Commands listed under hooks.pre_shutdown and hooks.post_shutdown in
+mayor/town.json run before and after a whole-town shutdown (e.g. to snapshot
+beads or send a notification). A failing pre_shutdown hook marked "required"
+aborts the shutdown.
+
+Use --keep-daemon to leave the daemon running; scheduled quiet hours use it
+so the daemon is still there to start the town in the morning.
+
 Use --force or --yes to skip confirmation prompt.
-Use --graceful to allow agents time to save state before killing.
+Use --graceful to allow agents time to save state before killing. A graceful
+shutdown also records which sessions were running, their hooked issues, and
+their conversation IDs, so 'gt start --resume-last' can bring them back.
 Use --nuclear to force cleanup even if polecats have uncommitted work (DANGER).
 Use --cleanup-orphans to use a longer grace period for orphan cleanup (default 60s).
 Use --cleanup-orphans-grace-secs to set that grace period.
@@ -132,9 +208,15 @@ Examples:
 
 func init() {
 	startCmd.Flags().BoolVarP(&startAll, "all", "a", false,
-		"Also start Witnesses and Refineries for all rigs")
+		"Also start Witnesses and Refineries for all rigs (same as --profile full)")
 	startCmd.Flags().StringVar(&startAgentOverride, "agent", "", "Agent alias to run Mayor/Deacon with (overrides town default)")
 	startCmd.Flags().StringVar(&startCostTier, "cost-tier", "", "Ephemeral cost tier for this session (standard/economy/budget)")
+	startCmd.Flags().StringSliceVar(&startRigs, "rig", nil, "Only bring up these rigs' agents (Witness, Refinery, configured crew); repeatable")
+	startCmd.Flags().DurationVar(&startReadyTimeout, "ready-timeout", defaultStartReadyTimeout,
+		"How long to wait for each agent to go idle before starting agents that depend on it anyway (0 = only wait for the session)")
+	startCmd.Flags().BoolVar(&startDryRun, "dry-run", false, "Print the sessions that would be created, without starting anything")
+	startCmd.Flags().BoolVar(&startResumeLast, "resume-last", false, "Recreate the sessions recorded by the last graceful shutdown, resuming their conversations")
+	startCmd.Flags().StringVar(&startProfile, "profile", "", "Startup profile choosing which agents to start (default: town's default_startup_profile, else minimal)")
 
 	startCrewCmd.Flags().StringVar(&startCrewRig, "rig", "", "Rig to use")
 	startCrewCmd.Flags().StringVar(&startCrewAccount, "account", "", "Claude Code account handle to use")
@@ -144,7 +226,7 @@ func init() {
 	shutdownCmd.Flags().BoolVarP(&shutdownGraceful, "graceful", "g", false,
 		"Send ESC to agents and wait for them to handoff before killing")
 	shutdownCmd.Flags().IntVarP(&shutdownWait, "wait", "w", 30,
-		"Seconds to wait for graceful shutdown (default 30)")
+		"Maximum seconds to wait for agents to hand off during graceful shutdown (default 30)")
 	shutdownCmd.Flags().BoolVarP(&shutdownAll, "all", "a", false,
 		"Also stop crew sessions (by default, crew is preserved)")
 	shutdownCmd.Flags().BoolVarP(&shutdownForce, "force", "f", false,
@@ -159,6 +241,16 @@ func init() {
 		"Use longer grace period (--cleanup-orphans-grace-secs) for orphan cleanup instead of default 5s")
 	shutdownCmd.Flags().IntVar(&shutdownCleanupOrphansGrace, "cleanup-orphans-grace-secs", 60,
 		"Grace period in seconds between SIGTERM and SIGKILL when cleaning orphans (default 60)")
+	shutdownCmd.Flags().StringSliceVar(&shutdownLabels, "label", nil,
+		"Only stop sessions with this label (see 'gt session label'); repeatable")
+	shutdownCmd.Flags().StringSliceVar(&shutdownRigs, "rig", nil,
+		"Only stop sessions belonging to this rig; repeatable")
+	shutdownCmd.Flags().StringSliceVar(&shutdownRoles, "role", nil,
+		"Only stop sessions with this role (polecat, crew, witness, refinery, mayor, deacon, boot); repeatable")
+	shutdownCmd.Flags().StringSliceVar(&shutdownExcept, "except", nil,
+		"Keep sessions with this role or agent address running (e.g. mayor, gastown/witness); repeatable")
+	shutdownCmd.Flags().BoolVar(&shutdownKeepDaemon, "keep-daemon", false,
+		"Leave the daemon running (used by scheduled quiet hours so the morning start can run)")
 
 	rootCmd.AddCommand(startCmd)
 	rootCmd.AddCommand(shutdownCmd)
@@ -191,10 +283,67 @@ func runStart(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Using ephemeral cost tier: %s\n", style.Bold.Render(startCostTier))
 	}
 
+	// Starting by hand ends scheduled quiet hours, so the daemon goes back
+	// to keeping agents running.
+	if !startDryRun && daemon.InQuietHours(townRoot) {
+		if err := daemon.ClearQuietHours(townRoot); err != nil {
+			style.PrintWarning("could not end quiet hours: %v", err)
+		} else {
+			fmt.Printf("%s Ending scheduled quiet hours\n", style.Dim.Render("○"))
+		}
+	}
+
+	if startResumeLast {
+		if len(startRigs) > 0 || startAll || startProfile != "" {
+			return fmt.Errorf("--resume-last cannot be combined with --rig, --all, or --profile")
+		}
+		return runStartResumeLast(townRoot, startDryRun)
+	}
+
+	if startDryRun {
+		if len(startRigs) > 0 {
+			return fmt.Errorf("--dry-run cannot be combined with --rig")
+		}
+		return runStartDryRun(townRoot)
+	}
+
+	if len(startRigs) > 0 && (startAll || startProfile != "") {
+		return fmt.Errorf("--rig cannot be combined with --all or --profile")
+	}
+
 	if err := config.EnsureDaemonPatrolConfig(townRoot); err != nil {
 		fmt.Printf("  %s Could not ensure daemon config: %v\n", style.Dim.Render("○"), err)
 	}
 
+	if len(startRigs) > 0 {
+		return startWithTownHooks(townRoot, func() error {
+			return runStartRigs(cmd, townRoot, startRigs)
+		})
+	}
+
+	profile, profileName, err := resolveStartProfile(townRoot)
+	if err != nil {
+		return err
+	}
+
+	if err := startWithTownHooks(townRoot, func() error {
+		return startTownAgents(townRoot, profile, profileName)
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s Gas Town is running\n", style.Bold.Render("✓"))
+	fmt.Println()
+	fmt.Printf("  Attach to Mayor:  %s\n", style.Dim.Render("gt mayor attach"))
+	fmt.Printf("  Attach to Deacon: %s\n", style.Dim.Render("gt deacon attach"))
+	fmt.Printf("  Check status:     %s\n", style.Dim.Render("gt status"))
+
+	return nil
+}
+
+// startTownAgents starts Dolt and then every agent in profile, in
+// dependency order.
+func startTownAgents(townRoot string, profile *config.StartupProfile, profileName string) error {
 	t := tmux.NewTmux()
 
 	// Clean up orphaned tmux sessions before starting new agents.
@@ -206,8 +355,8 @@ func runStart(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  %s Cleaned up %d orphaned session(s)\n", style.Bold.Render("✓"), cleaned)
 	}
 
-	fmt.Printf("Starting Gas Town from %s\n\n", style.Dim.Render(townRoot))
-	fmt.Println("Starting all agents in parallel...")
+	fmt.Printf("Starting Gas Town from %s (profile: %s)\n\n", style.Dim.Render(townRoot), profileName)
+	fmt.Println("Starting agents in dependency order...")
 	fmt.Println()
 
 	// Discover rigs once upfront to avoid redundant calls from parallel goroutines
@@ -221,6 +370,121 @@ func runStart(cmd *cobra.Command, args []string) error {
 	// Agents run bd commands on startup (via gt prime → patrol_helpers) that
 	// connect to the Dolt SQL server. Without this sequencing, they race the
 	// server and bd auto-spawns orphan embedded servers. (gt-t2zf)
+	ensureDoltForStart(townRoot)
+
+	// Phase 2: Start agents in dependency order (Dolt is now ready). Each
+	// agent must be up, and primed or past --ready-timeout, before the agents
+	// that depend on it start; independent agents start in parallel.
+	nodes, err := buildStartGraph(t, townRoot, rigs, profile, startAgentOverride)
+	if err != nil {
+		return err
+	}
+	failures, err := runStartGraph(nodes, startReadiness(t, startReadyTimeout))
+	if err != nil {
+		return err
+	}
+	if err := failures["mayor"]; err != nil {
+		return fmt.Errorf("starting Mayor: %w", err)
+	}
+	if err := failures["deacon"]; err != nil {
+		return fmt.Errorf("starting Deacon: %w", err)
+	}
+	if len(failures) > 0 {
+		fmt.Println()
+		fmt.Printf("%s %d agent(s) did not start\n", style.Warning.Render("⚠"), len(failures))
+	}
+
+	fmt.Println()
+	return nil
+}
+
+// runStartDryRun prints the sessions gt start would create, without
+// starting Dolt or touching tmux beyond checking which sessions exist.
+func runStartDryRun(townRoot string) error {
+	profile, profileName, err := resolveStartProfile(townRoot)
+	if err != nil {
+		return err
+	}
+	rigs, err := discoverAllRigs(townRoot)
+	if err != nil {
+		fmt.Printf("  %s Could not discover rigs: %v\n", style.Dim.Render("○"), err)
+	}
+
+	t := tmux.NewTmux()
+	nodes, err := buildStartGraph(t, townRoot, rigs, profile, startAgentOverride)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Dry run: gt start from %s (profile: %s)\n\n", style.Dim.Render(townRoot), profileName)
+	printTownHooks(townRoot, session.TownHookPreStart)
+	if _, err := os.Stat(doltserver.DefaultConfig(townRoot).DataDir); err == nil {
+		if running, _, _ := doltserver.IsRunning(townRoot); running {
+			fmt.Printf("%s Dolt server already running\n\n", style.Dim.Render("○"))
+		} else {
+			fmt.Printf("Dolt server would be started first (port %d)\n\n", doltserver.DefaultPort)
+		}
+	}
+
+	var running func(string) bool
+	if t.IsAvailable() {
+		running = func(sess string) bool {
+			ok, _ := t.HasSession(sess)
+			return ok
+		}
+	}
+	if err := printStartPlan(nodes, running); err != nil {
+		return err
+	}
+	fmt.Println()
+	printTownHooks(townRoot, session.TownHookPostStart)
+	fmt.Printf("%s Nothing was started (dry run)\n", style.Dim.Render("○"))
+	return nil
+}
+
+// runStartRigs brings the named rigs online in dependency order: Dolt, then
+// each rig's Witness and Refinery (via 'gt rig start'), then its configured
+// crew. Town agents are left alone.
+func runStartRigs(cmd *cobra.Command, townRoot string, rigNames []string) error {
+	fmt.Printf("Starting rig(s) %s from %s\n\n", strings.Join(rigNames, ", "), style.Dim.Render(townRoot))
+	ensureDoltForStart(townRoot)
+	fmt.Println()
+
+	// Witness and Refinery, skipping parked/docked rigs.
+	rigErr := runRigStart(cmd, rigNames)
+
+	t := tmux.NewTmux()
+	if rigs, err := discoverAllRigs(townRoot); err == nil {
+		var selected []*rig.Rig
+		for _, r := range rigs {
+			for _, name := range rigNames {
+				if r.Name == name {
+					if blocked, _ := IsRigParkedOrDocked(townRoot, name); !blocked {
+						selected = append(selected, r)
+					}
+				}
+			}
+		}
+		if len(selected) > 0 {
+			fmt.Println()
+			var mu sync.Mutex
+			startConfiguredCrew(t, selected, townRoot, &mu)
+		}
+	}
+
+	// Rig agents are supervised by the Deacon; point out when it isn't up.
+	if ok, _ := t.HasSession(getDeaconSessionName()); !ok {
+		fmt.Println()
+		fmt.Printf("%s Deacon is not running, so these agents are unsupervised. Run %s to start it.\n",
+			style.Warning.Render("⚠"), style.Dim.Render("gt start"))
+	}
+	return rigErr
+}
+
+// ensureDoltForStart starts the Dolt server if the town has a data dir and
+// it isn't running, then repairs beads metadata so agents started next don't
+// spawn orphan servers.
+func ensureDoltForStart(townRoot string) {
 	var doltOK bool
 	cfg := doltserver.DefaultConfig(townRoot)
 	if _, err := os.Stat(cfg.DataDir); os.IsNotExist(err) {
@@ -244,154 +508,35 @@ func runStart(cmd *cobra.Command, args []string) error {
 	if doltOK {
 		_, _ = doltserver.EnsureAllMetadata(townRoot)
 	}
+}
 
-	// Phase 2: Start all agents in parallel (Dolt is now ready)
-	var wg sync.WaitGroup
-	var mu sync.Mutex // Protects stdout
-	var coreErr error
-
-	// Start core agents (Mayor and Deacon) in background
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		if err := startCoreAgents(townRoot, startAgentOverride, &mu); err != nil {
-			mu.Lock()
-			coreErr = err
-			mu.Unlock()
+// resolveStartProfile returns the startup profile selected by --profile or
+// --all, falling back to the town's default.
+func resolveStartProfile(townRoot string) (*config.StartupProfile, string, error) {
+	name := startProfile
+	if startAll {
+		if name != "" && name != config.StartupProfileFull {
+			return nil, "", fmt.Errorf("--all and --profile %s cannot be used together", name)
 		}
-	}()
-
-	// Start rig agents (witnesses, refineries) if --all
-	if startAll && rigs != nil {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			startRigAgents(rigs, &mu)
-		}()
-	}
-
-	// Start configured crew
-	if rigs != nil {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			startConfiguredCrew(t, rigs, townRoot, &mu)
-		}()
+		name = config.StartupProfileFull
 	}
-
-	wg.Wait()
-
-	if coreErr != nil {
-		return coreErr
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return nil, "", fmt.Errorf("loading town settings: %w", err)
 	}
-
-	fmt.Println()
-	fmt.Printf("%s Gas Town is running\n", style.Bold.Render("✓"))
-	fmt.Println()
-	fmt.Printf("  Attach to Mayor:  %s\n", style.Dim.Render("gt mayor attach"))
-	fmt.Printf("  Attach to Deacon: %s\n", style.Dim.Render("gt deacon attach"))
-	fmt.Printf("  Check status:     %s\n", style.Dim.Render("gt status"))
-
-	return nil
-}
-
-// startCoreAgents starts Mayor and Deacon sessions in parallel using the Manager pattern.
-// The mutex is used to synchronize output with other parallel startup operations.
-func startCoreAgents(townRoot string, agentOverride string, mu *sync.Mutex) error {
-	var wg sync.WaitGroup
-	var firstErr error
-	var errMu sync.Mutex
-
-	// Start Mayor in goroutine
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		mayorMgr := mayor.NewManager(townRoot)
-		if err := mayorMgr.Start(agentOverride); err != nil {
-			if errors.Is(err, mayor.ErrAlreadyRunning) {
-				mu.Lock()
-				fmt.Printf("  %s Mayor already running\n", style.Dim.Render("○"))
-				mu.Unlock()
-			} else if errors.Is(err, mayor.ErrACPActive) {
-				mu.Lock()
-				fmt.Printf("  %s Mayor already running (ACP mode)\n", style.Dim.Render("○"))
-				mu.Unlock()
-			} else {
-				errMu.Lock()
-				if firstErr == nil {
-					firstErr = fmt.Errorf("starting Mayor: %w", err)
-				}
-				errMu.Unlock()
-				mu.Lock()
-				fmt.Printf("  %s Mayor failed: %v\n", style.Dim.Render("○"), err)
-				mu.Unlock()
-			}
-		} else {
-			mu.Lock()
-			fmt.Printf("  %s Mayor started\n", style.Bold.Render("✓"))
-			mu.Unlock()
-		}
-	}()
-
-	// Start Deacon in goroutine
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		deaconMgr := deacon.NewManager(townRoot)
-		if err := deaconMgr.Start(agentOverride); err != nil {
-			if errors.Is(err, deacon.ErrAlreadyRunning) {
-				mu.Lock()
-				fmt.Printf("  %s Deacon already running\n", style.Dim.Render("○"))
-				mu.Unlock()
-			} else {
-				errMu.Lock()
-				if firstErr == nil {
-					firstErr = fmt.Errorf("starting Deacon: %w", err)
-				}
-				errMu.Unlock()
-				mu.Lock()
-				fmt.Printf("  %s Deacon failed: %v\n", style.Dim.Render("○"), err)
-				mu.Unlock()
-			}
-		} else {
-			mu.Lock()
-			fmt.Printf("  %s Deacon started\n", style.Bold.Render("✓"))
-			mu.Unlock()
-		}
-	}()
-
-	wg.Wait()
-	return firstErr
+	return config.ResolveStartupProfile(settings, name)
 }
 
-// startRigAgents starts witness and refinery for all rigs in parallel.
-// Called when --all flag is passed to gt start.
-func startRigAgents(rigs []*rig.Rig, mu *sync.Mutex) {
-	var wg sync.WaitGroup
-
+// filterProfileRigs returns the rigs whose Witness and Refinery profile
+// starts.
+func filterProfileRigs(rigs []*rig.Rig, profile *config.StartupProfile) []*rig.Rig {
+	var out []*rig.Rig
 	for _, r := range rigs {
-		wg.Add(2) // Witness + Refinery
-
-		// Start Witness in goroutine
-		go func(r *rig.Rig) {
-			defer wg.Done()
-			msg := startWitnessForRig(r)
-			mu.Lock()
-			fmt.Print(msg)
-			mu.Unlock()
-		}(r)
-
-		// Start Refinery in goroutine
-		go func(r *rig.Rig) {
-			defer wg.Done()
-			msg := startRefineryForRig(r)
-			mu.Lock()
-			fmt.Print(msg)
-			mu.Unlock()
-		}(r)
+		if profile.IncludesRig(r.Name) {
+			out = append(out, r)
+		}
 	}
-
-	wg.Wait()
+	return out
 }
 
 // startWitnessForRig starts the witness for a single rig and returns a status message.
@@ -450,10 +595,34 @@ func startConfiguredCrew(t *tmux.Tmux, rigs []*rig.Rig, townRoot string, mu *syn
 }
 
 // startOrRestartCrewMember starts or restarts a single crew member and returns a status message.
+func startOrRestartCrewMember(t *tmux.Tmux, r *rig.Rig, crewName, townRoot string) (msg string, started bool) {
+	action, err := ensureCrewMember(t, r, crewName, townRoot)
+	if err != nil {
+		if action != "" {
+			return fmt.Sprintf("  %s %s/%s %s failed: %v\n", style.Dim.Render("○"), r.Name, crewName, action, err), false
+		}
+		return fmt.Sprintf("  %s %s/%s failed: %v\n", style.Dim.Render("○"), r.Name, crewName, err), false
+	}
+	if action == crewAlreadyRunning {
+		return fmt.Sprintf("  %s %s/%s already running\n", style.Dim.Render("○"), r.Name, crewName), false
+	}
+	return fmt.Sprintf("  %s %s/%s %s\n", style.Bold.Render("✓"), r.Name, crewName, action), true
+}
+
+// ensureCrewMember actions, as reported by ensureCrewMember.
+const (
+	crewAlreadyRunning = "already running"
+	crewStarted        = "started"
+	crewRestarted      = "agent restarted"
+)
+
+// ensureCrewMember makes sure a crew member's agent is running and returns
+// what it did. On error the action is "restart" if restarting a dead agent
+// failed, or "" if starting a new session failed.
 // Uses IsAgentAlive for robust zombie detection (checks pane command + descendant processes),
 // and delegates zombie cleanup to crewMgr.Start() which kills the zombie session and recreates
 // it with fresh env vars and runtime settings.
-func startOrRestartCrewMember(t *tmux.Tmux, r *rig.Rig, crewName, townRoot string) (msg string, started bool) {
+func ensureCrewMember(t *tmux.Tmux, r *rig.Rig, crewName, townRoot string) (string, error) {
 	sessionID := crewSessionName(r.Name, crewName)
 	if running, _ := t.HasSession(sessionID); running {
 		// Session exists - check if agent is still alive
@@ -470,18 +639,18 @@ func startOrRestartCrewMember(t *tmux.Tmux, r *rig.Rig, crewName, townRoot strin
 			})
 			agentCmd := config.BuildCrewStartupCommand(r.Name, crewName, r.Path, beacon)
 			if err := t.SendKeys(sessionID, agentCmd); err != nil {
-				return fmt.Sprintf("  %s %s/%s restart failed: %v\n", style.Dim.Render("○"), r.Name, crewName, err), false
+				return "restart", err
 			}
-			return fmt.Sprintf("  %s %s/%s agent restarted\n", style.Bold.Render("✓"), r.Name, crewName), true
+			return crewRestarted, nil
 		}
 		// Agent is alive — nothing to do
-		return fmt.Sprintf("  %s %s/%s already running\n", style.Dim.Render("○"), r.Name, crewName), false
+		return crewAlreadyRunning, nil
 	}
 
 	if err := startCrewMember(r.Name, crewName, townRoot); err != nil {
-		return fmt.Sprintf("  %s %s/%s failed: %v\n", style.Dim.Render("○"), r.Name, crewName, err), false
+		return "", err
 	}
-	return fmt.Sprintf("  %s %s/%s started\n", style.Bold.Render("✓"), r.Name, crewName), true
+	return crewStarted, nil
 }
 
 // discoverAllRigs finds all rigs in the workspace.
@@ -511,12 +680,43 @@ func runShutdown(cmd *cobra.Command, args []string) error {
 	}
 
 	toStop, preserved := categorizeSessions(sessions)
+	scoped := len(shutdownLabels) > 0 || len(shutdownRigs) > 0 || len(shutdownRoles) > 0 || len(shutdownExcept) > 0
+	if len(shutdownRigs) > 0 || len(shutdownRoles) > 0 || len(shutdownExcept) > 0 {
+		if err := validateShutdownRoles(append(append([]string(nil), shutdownRoles...), shutdownExcept...)); err != nil {
+			return err
+		}
+		if slices.Contains(shutdownRoles, string(session.RoleCrew)) {
+			// Asking for crew by role is explicit enough to override the
+			// default crew preservation.
+			toStop, preserved = append(toStop, preserved...), nil
+		}
+		toStop = filterShutdownSessions(toStop, shutdownRigs, shutdownRoles, shutdownExcept)
+		if len(toStop) == 0 {
+			fmt.Printf("%s No running sessions match the shutdown filters\n", style.Dim.Render("○"))
+			return nil
+		}
+	}
+	if len(shutdownLabels) > 0 {
+		meta, err := loadSessionMetaForFilter(townRoot, shutdownLabels)
+		if err != nil {
+			return err
+		}
+		toStop = filterSessionNamesByLabel(toStop, meta, shutdownLabels)
+		if len(toStop) == 0 {
+			fmt.Printf("%s No running sessions labeled %s\n", style.Dim.Render("○"), strings.Join(shutdownLabels, ", "))
+			return nil
+		}
+	}
 
 	if len(toStop) == 0 {
-		fmt.Printf("%s Gas Town was not running\n", style.Dim.Render("○"))
+		if !scoped && stopHeadlessAgents(townRoot) > 0 {
+			fmt.Printf("%s No tmux sessions were running\n", style.Dim.Render("○"))
+		} else {
+			fmt.Printf("%s Gas Town was not running\n", style.Dim.Render("○"))
+		}
 
 		// Still check for orphaned daemons even if no sessions are running
-		if townRoot != "" {
+		if townRoot != "" && !shutdownKeepDaemon {
 			fmt.Println()
 			fmt.Println("Checking for orphaned daemon...")
 			stopDaemonIfRunning(townRoot)
@@ -551,10 +751,127 @@ func runShutdown(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	if scoped {
+		return runScopedShutdown(t, townRoot, toStop)
+	}
+
+	return stopWithTownHooks(townRoot, func() error {
+		if shutdownGraceful {
+			return runGracefulShutdown(t, toStop, townRoot)
+		}
+		return runImmediateShutdown(t, toStop, townRoot)
+	})
+}
+
+// runScopedShutdown stops only the sessions selected by --label, --rig,
+// --role, or --except, in shutdown order. Town-wide cleanup (polecat
+// worktrees, orphan sweep, daemon) is skipped since the rest of the town
+// keeps running.
+func runScopedShutdown(t *tmux.Tmux, townRoot string, sessions []string) error {
+	if townRoot != "" {
+		if running, _, _ := daemon.IsRunning(townRoot); running {
+			warnDaemonRespawns(townRoot, sessions)
+		}
+	}
+
+	stopped := 0
 	if shutdownGraceful {
-		return runGracefulShutdown(t, toStop, townRoot)
+		shutdownMsg := "[SHUTDOWN] This session is being stopped. Please save your state and update your handoff bead, then type /exit or wait to be terminated."
+		stopped = requestHandoffAndWait(t, townRoot, sessions, shutdownMsg, shutdownWait)
+		fmt.Println()
+	}
+
+	stopped += killSessionsInOrder(t, sessions, getMayorSessionName(), getDeaconSessionName())
+	fmt.Println()
+	fmt.Printf("%s Stopped %d session(s)\n", style.Bold.Render("✓"), stopped)
+	return nil
+}
+
+// warnDaemonRespawns warns that the running daemon will restart the
+// witnesses and refineries being stopped, unless their rig is parked or
+// docked.
+func warnDaemonRespawns(townRoot string, sessions []string) {
+	rigs := daemonRespawnedRigs(sessions, func(rigName string) bool {
+		blocked, _ := IsRigParkedOrDocked(townRoot, rigName)
+		return blocked
+	})
+	if len(rigs) == 0 {
+		return
+	}
+	style.PrintWarning("the daemon is running and will restart the witness/refinery of %s", strings.Join(rigs, ", "))
+	fmt.Printf("  To keep them stopped: %s\n\n", style.Dim.Render("gt rig park "+strings.Join(rigs, " ")))
+}
+
+// daemonRespawnedRigs returns, in order, the rigs whose witness or refinery
+// is among sessions and which the daemon would restart (parked reports
+// whether a rig is parked or docked).
+func daemonRespawnedRigs(sessions []string, parked func(rigName string) bool) []string {
+	var rigs []string
+	for _, sess := range sessions {
+		identity, err := session.ParseSessionName(sess)
+		if err != nil || (identity.Role != session.RoleWitness && identity.Role != session.RoleRefinery) {
+			continue
+		}
+		if slices.Contains(rigs, identity.Rig) || parked(identity.Rig) {
+			continue
+		}
+		rigs = append(rigs, identity.Rig)
+	}
+	return rigs
+}
+
+// shutdownRoleNames are the role names accepted by --role and --except.
+var shutdownRoleNames = []string{"polecat", "crew", "witness", "refinery", "mayor", "deacon", "boot", "dog"}
+
+// validateShutdownRoles rejects values that look like role names (no slash)
+// but aren't one, so a typo doesn't silently match nothing.
+func validateShutdownRoles(values []string) error {
+	for _, v := range values {
+		if strings.Contains(v, "/") || slices.Contains(shutdownRoleNames, v) {
+			continue
+		}
+		return fmt.Errorf("unknown role %q (valid: %s, or an agent address)", v, strings.Join(shutdownRoleNames, ", "))
+	}
+	return nil
+}
+
+// filterShutdownSessions narrows sessions to those in any of rigs (if
+// given) and with any of roles (if given), then drops those matching except.
+// except entries are role names or agent addresses.
+func filterShutdownSessions(sessions, rigs, roles, except []string) []string {
+	var out []string
+	for _, sess := range sessions {
+		identity, err := session.ParseSessionName(sess)
+		if err != nil {
+			// Can't place it in a rig or role; only an unfiltered scope keeps it.
+			if len(rigs) == 0 && len(roles) == 0 {
+				out = append(out, sess)
+			}
+			continue
+		}
+		role := shutdownRoleOf(identity)
+		if len(rigs) > 0 && !slices.Contains(rigs, identity.Rig) {
+			continue
+		}
+		if len(roles) > 0 && !slices.Contains(roles, role) {
+			continue
+		}
+		if slices.Contains(except, role) || slices.Contains(except, identity.Address()) ||
+			slices.Contains(except, mail.AddressToIdentity(identity.Address())) {
+			continue
+		}
+		out = append(out, sess)
 	}
-	return runImmediateShutdown(t, toStop, townRoot)
+	return out
+}
+
+// shutdownRoleOf returns the role name --role and --except match against,
+// distinguishing Boot from the Deacon.
+func shutdownRoleOf(identity *session.AgentIdentity) string {
+	if identity.Role == session.RoleDeacon && identity.Name == "boot" {
+		return "boot"
+	}
+	return string(identity.Role)
 }
 
 // categorizeSessions splits sessions into those to stop and those to preserve.
@@ -603,43 +920,26 @@ func categorizeSessions(sessions []string) (toStop, preserved []string) {
 func runGracefulShutdown(t *tmux.Tmux, gtSessions []string, townRoot string) error {
 	fmt.Printf("Graceful shutdown of Gas Town (waiting up to %ds)...\n\n", shutdownWait)
 
-	// Phase 1: Send ESC to all agents to interrupt them
-	fmt.Printf("Phase 1: Sending ESC to %d agent(s)...\n", len(gtSessions))
-	for _, sess := range gtSessions {
-		fmt.Printf("  %s Interrupting %s\n", style.Bold.Render("→"), sess)
-		_ = t.SendKeysRaw(sess, "Escape") // best-effort interrupt
-	}
-
-	// Phase 2: Send shutdown message asking agents to handoff
-	fmt.Printf("\nPhase 2: Requesting handoff from agents...\n")
 	shutdownMsg := "[SHUTDOWN] Gas Town is shutting down. Please save your state and update your handoff bead, then type /exit or wait to be terminated."
-	for _, sess := range gtSessions {
-		// Small delay then send the message
-		time.Sleep(constants.ShutdownNotifyDelay)
-		_ = t.SendKeys(sess, shutdownMsg) // best-effort notification
-	}
-
-	// Phase 3: Wait for agents to complete handoff
-	fmt.Printf("\nPhase 3: Waiting %ds for agents to complete handoff...\n", shutdownWait)
-	fmt.Printf("  %s\n", style.Dim.Render("(Press Ctrl-C to force immediate shutdown)"))
+	mayorSession := getMayorSessionName()
+	deaconSession := getDeaconSessionName()
 
-	// Wait with countdown
-	for remaining := shutdownWait; remaining > 0; remaining -= 5 {
-		if remaining < shutdownWait {
-			fmt.Printf("  %s %ds remaining...\n", style.Dim.Render("⏳"), remaining)
-		}
-		sleepTime := 5
-		if remaining < 5 {
-			sleepTime = remaining
+	// Record the topology first, while every session is still up, so
+	// 'gt start --resume-last' can bring it back.
+	if townRoot != "" {
+		if m, err := writeShutdownManifest(t, townRoot, gtSessions); err != nil {
+			style.PrintWarning("could not write shutdown manifest: %v", err)
+		} else {
+			fmt.Printf("%s Recorded %d session(s) for 'gt start --resume-last'\n\n", style.Dim.Render("○"), len(m.Sessions))
 		}
-		time.Sleep(time.Duration(sleepTime) * time.Second)
 	}
 
-	// Phase 4: Kill sessions in correct order
+	stopped := requestHandoffAndWait(t, townRoot, gtSessions, shutdownMsg, shutdownWait)
+
+	// Phase 4: Kill the remaining sessions in correct order
 	fmt.Printf("\nPhase 4: Terminating sessions...\n")
-	mayorSession := getMayorSessionName()
-	deaconSession := getDeaconSessionName()
-	stopped := killSessionsInOrder(t, gtSessions, mayorSession, deaconSession)
+	stopped += killSessionsInOrder(t, gtSessions, mayorSession, deaconSession)
+	stopped += stopHeadlessAgents(townRoot)
 
 	// Phase 5: Always clean up orphaned Claude processes after killing sessions.
 	// Processes can survive session kills if they caught/ignored SIGHUP or called setsid().
@@ -660,7 +960,9 @@ func runGracefulShutdown(t *tmux.Tmux, gtSessions []string, townRoot string) err
 
 	// Phase 7: Stop the daemon
 	fmt.Printf("\nPhase 7: Stopping daemon...\n")
-	if townRoot != "" {
+	if shutdownKeepDaemon {
+		fmt.Printf("  %s Daemon left running (--keep-daemon)\n", style.Dim.Render("○"))
+	} else if townRoot != "" {
 		stopDaemonIfRunning(townRoot)
 	}
 
@@ -679,6 +981,7 @@ func runImmediateShutdown(t *tmux.Tmux, gtSessions []string, townRoot string) er
 	mayorSession := getMayorSessionName()
 	deaconSession := getDeaconSessionName()
 	stopped := killSessionsInOrder(t, gtSessions, mayorSession, deaconSession)
+	stopped += stopHeadlessAgents(townRoot)
 
 	// Always clean up orphaned Claude processes after killing sessions.
 	// Processes can survive session kills if they caught/ignored SIGHUP or called setsid().
@@ -700,7 +1003,7 @@ func runImmediateShutdown(t *tmux.Tmux, gtSessions []string, townRoot string) er
 	}
 
 	// Stop the daemon
-	if townRoot != "" {
+	if townRoot != "" && !shutdownKeepDaemon {
 		fmt.Println()
 		fmt.Println("Stopping daemon...")
 		stopDaemonIfRunning(townRoot)
@@ -724,6 +1027,10 @@ func runImmediateShutdown(t *tmux.Tmux, gtSessions []string, townRoot string) er
 //  4. Town sessions: Mayor, Boot, Deacon
 //     Boot monitors Deacon, so must be stopped before Deacon.
 //
+// Sessions within a tier are killed in parallel, each with shutdownKillTimeout
+// to finish; the next tier starts once every session in this one is stopped
+// or has timed out.
+//
 // mayorSession and deaconSession are the dynamic session names for the current town.
 //
 // Returns the count of sessions that were successfully stopped (verified by checking
@@ -731,6 +1038,7 @@ func runImmediateShutdown(t *tmux.Tmux, gtSessions []string, townRoot string) er
 func killSessionsInOrder(t *tmux.Tmux, sessions []string, mayorSession, deaconSession string) int {
 	stopped := 0
 	bootSession := session.BootSessionName()
+	townRoot, _ := workspace.FindFromCwd()
 
 	// Build a set for O(1) lookup of town-level sessions
 	sessionSet := make(map[string]bool, len(sessions))
@@ -780,48 +1088,43 @@ func killSessionsInOrder(t *tmux.Tmux, sessions []string, mayorSession, deaconSe
 		stillExists, _ := t.HasSession(sess)
 		if !stillExists {
 			fmt.Printf("  %s %s stopped\n", style.Bold.Render("✓"), sess)
+			session.RunSessionHooks(townRoot, session.HookStop, sess, "")
 			return true
 		}
 		return false
 	}
 
-	// 1. Stop polecats and crew first (workers)
-	for _, sess := range polecats {
-		if killAndVerify(sess) {
-			stopped++
-		}
-	}
-
-	// 2. Stop refineries (work processors)
-	for _, sess := range refineries {
-		if killAndVerify(sess) {
-			stopped++
-		}
-	}
-
-	// 3. Stop witnesses (monitors)
-	for _, sess := range witnesses {
-		if killAndVerify(sess) {
-			stopped++
-		}
-	}
-
-	// 4. Stop town sessions: Mayor, Boot, Deacon (matching TownSessions() order)
-	if sessionSet[mayorSession] {
-		if killAndVerify(mayorSession) {
-			stopped++
-		}
-	}
-	if sessionSet[bootSession] {
-		if killAndVerify(bootSession) {
-			stopped++
+	// Stop each tier in parallel, one tier at a time, so workers are gone
+	// before the agents that supervise them. Each session gets its own
+	// deadline; one that hangs is reported and left behind.
+	var townFirst, townLast []string
+	for _, sess := range []string{mayorSession, bootSession} {
+		if sessionSet[sess] {
+			townFirst = append(townFirst, sess)
 		}
 	}
 	if sessionSet[deaconSession] {
-		if killAndVerify(deaconSession) {
-			stopped++
+		townLast = append(townLast, deaconSession)
+	}
+	tiers := [][]string{
+		polecats,   // 1. Workers: polecats and crew
+		refineries, // 2. Work processors
+		witnesses,  // 3. Monitors
+		townFirst,  // 4. Mayor and Boot (matching TownSessions() order)
+		townLast,   // 5. Deacon last
+	}
+	var stoppedCount atomic.Int32
+	for _, tier := range tiers {
+		timedOut := runPerSession(tier, shutdownKillTimeout, func(sess string) {
+			if killAndVerify(sess) {
+				stoppedCount.Add(1)
+			}
+		})
+		for _, sess := range timedOut {
+			fmt.Printf("  %s %s still stopping after %s, moving on\n", style.Warning.Render("⚠"), sess, shutdownKillTimeout)
 		}
 	}
+	stopped = int(stoppedCount.Load())
 
 	return stopped
 }
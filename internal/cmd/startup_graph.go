@@ -0,0 +1,488 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/crew"
+	"github.com/steveyegge/gastown/internal/deacon"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/headless"
+	"github.com/steveyegge/gastown/internal/mayor"
+	"github.com/steveyegge/gastown/internal/refinery"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+	"github.com/steveyegge/gastown/internal/witness"
+)
+
+// defaultStartReadyTimeout is how long gt start waits for each agent to
+// settle at an idle prompt before starting the agents that depend on it
+// anyway.
+const defaultStartReadyTimeout = 2 * time.Minute
+
+// errStartNotIdle reports an agent that is running but never went idle
+// within the readiness timeout, typically because it went straight to work
+// (the Mayor acting on its hook, a Witness patrolling). Its dependents still
+// start.
+var errStartNotIdle = errors.New("not idle")
+
+// startNode is one agent in the startup dependency graph.
+type startNode struct {
+	name    string   // Display name and graph key, e.g. "mayor", "gastown/witness"
+	session string   // tmux session checked for readiness; "" skips the check
+	deps    []string // Nodes that must be ready before this one starts
+
+	// start launches the agent. alreadyRunning reports that nothing was
+	// started because the agent was up already.
+	start func() (alreadyRunning bool, err error)
+
+	// plan describes the session start would create, for --dry-run.
+	plan func() (*session.StartPlan, error)
+}
+
+// startReadyFunc checks that a node's agent is ready for dependents to
+// start. fresh is true when the agent was just started, false when it was
+// already running. An error wrapping errStartNotIdle is only a warning.
+type startReadyFunc func(n *startNode, fresh bool) error
+
+// validateStartGraph rejects duplicate nodes, unknown dependencies, and
+// cycles, any of which would leave runStartGraph waiting forever.
+func validateStartGraph(nodes []*startNode) error {
+	byName := make(map[string]*startNode, len(nodes))
+	for _, n := range nodes {
+		if byName[n.name] != nil {
+			return fmt.Errorf("startup graph: %s listed twice", n.name)
+		}
+		byName[n.name] = n
+	}
+	for _, n := range nodes {
+		for _, d := range n.deps {
+			if byName[d] == nil {
+				return fmt.Errorf("startup graph: %s depends on unknown %s", n.name, d)
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(nodes))
+	var visit func(n *startNode, path []string) error
+	visit = func(n *startNode, path []string) error {
+		switch state[n.name] {
+		case visiting:
+			return fmt.Errorf("startup graph: dependency cycle %s", strings.Join(append(path, n.name), " → "))
+		case visited:
+			return nil
+		}
+		state[n.name] = visiting
+		for _, d := range n.deps {
+			if err := visit(byName[d], append(path, n.name)); err != nil {
+				return err
+			}
+		}
+		state[n.name] = visited
+		return nil
+	}
+	for _, n := range nodes {
+		if err := visit(n, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runStartGraph starts every node once all of its dependencies are ready,
+// running independent nodes in parallel. A node whose dependency failed to
+// start or whose session died is not started; one that is alive but never
+// went idle is reported and its dependents start anyway. Returns each
+// failed or skipped node's error, keyed by name.
+func runStartGraph(nodes []*startNode, ready startReadyFunc) (map[string]error, error) {
+	if err := validateStartGraph(nodes); err != nil {
+		return nil, err
+	}
+
+	done := make(map[string]chan struct{}, len(nodes))
+	for _, n := range nodes {
+		done[n.name] = make(chan struct{})
+	}
+
+	var mu sync.Mutex // Protects failures and stdout
+	failures := make(map[string]error)
+	report := func(ok bool, format string, args ...interface{}) {
+		icon := style.Dim.Render("○")
+		if ok {
+			icon = style.Bold.Render("✓")
+		}
+		fmt.Printf("  %s %s\n", icon, fmt.Sprintf(format, args...))
+	}
+
+	var wg sync.WaitGroup
+	for _, n := range nodes {
+		wg.Add(1)
+		go func(n *startNode) {
+			defer wg.Done()
+			defer close(done[n.name])
+
+			for _, d := range n.deps {
+				<-done[d]
+			}
+			mu.Lock()
+			var blocked []string
+			for _, d := range n.deps {
+				if failures[d] != nil {
+					blocked = append(blocked, d)
+				}
+			}
+			if len(blocked) > 0 {
+				sort.Strings(blocked)
+				failures[n.name] = fmt.Errorf("not started: %s not ready", strings.Join(blocked, ", "))
+				report(false, "%s skipped (%s not ready)", n.name, strings.Join(blocked, ", "))
+				mu.Unlock()
+				return
+			}
+			mu.Unlock()
+
+			already, err := n.start()
+			var busy error
+			if err == nil && ready != nil {
+				if rerr := ready(n, !already); errors.Is(rerr, errStartNotIdle) {
+					busy = rerr
+				} else if rerr != nil {
+					err = fmt.Errorf("not ready: %w", rerr)
+				}
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err != nil:
+				failures[n.name] = err
+				report(false, "%s failed: %v", n.name, err)
+			case busy != nil:
+				report(true, "%s started (%v)", n.name, busy)
+			case already:
+				report(false, "%s already running", n.name)
+			default:
+				report(true, "%s started", n.name)
+			}
+		}(n)
+	}
+	wg.Wait()
+	return failures, nil
+}
+
+// startGraphOrder returns nodes in an order where every node follows its
+// dependencies, keeping graph order among nodes that are ready together.
+func startGraphOrder(nodes []*startNode) ([]*startNode, error) {
+	if err := validateStartGraph(nodes); err != nil {
+		return nil, err
+	}
+	placed := make(map[string]bool, len(nodes))
+	ordered := make([]*startNode, 0, len(nodes))
+	for len(ordered) < len(nodes) {
+		var wave []*startNode
+		for _, n := range nodes {
+			if placed[n.name] {
+				continue
+			}
+			ready := true
+			for _, d := range n.deps {
+				if !placed[d] {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				wave = append(wave, n)
+			}
+		}
+		for _, n := range wave {
+			placed[n.name] = true
+		}
+		ordered = append(ordered, wave...)
+	}
+	return ordered, nil
+}
+
+// printStartPlan prints the sessions the graph would create, in start
+// order, without starting anything. running reports whether a session is
+// already up; those are listed but left alone, as gt start would.
+func printStartPlan(nodes []*startNode, running func(sess string) bool) error {
+	ordered, err := startGraphOrder(nodes)
+	if err != nil {
+		return err
+	}
+	for i, n := range ordered {
+		header := fmt.Sprintf("%d. %s", i+1, n.name)
+		if len(n.deps) > 0 {
+			header += style.Dim.Render(fmt.Sprintf("  (after %s)", strings.Join(n.deps, ", ")))
+		}
+		fmt.Println(style.Bold.Render(header))
+		if n.session != "" && running != nil && running(n.session) {
+			fmt.Printf("   %s %s already running, left alone\n", style.Dim.Render("○"), n.session)
+			continue
+		}
+		if n.plan == nil {
+			fmt.Printf("   session: %s\n", n.session)
+			continue
+		}
+		plan, err := n.plan()
+		if err != nil {
+			fmt.Printf("   %s %s: %v\n", style.Warning.Render("!"), n.session, err)
+			continue
+		}
+		fmt.Printf("   session: %s\n", plan.SessionID)
+		fmt.Printf("   workdir: %s\n", plan.WorkDir)
+		fmt.Printf("   command: %s\n", plan.Command)
+		if len(plan.Env) > 0 {
+			keys := make([]string, 0, len(plan.Env))
+			for k := range plan.Env {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			fmt.Println("   env:")
+			for _, k := range keys {
+				fmt.Printf("     %s=%s\n", k, plan.Env[k])
+			}
+		}
+	}
+	return nil
+}
+
+// headlessStartPlan describes the supervisor gt start would launch for a
+// headless agent, in place of a tmux session.
+func headlessStartPlan(townRoot, role string) (*session.StartPlan, error) {
+	cmd, err := headless.Command(context.Background(), townRoot, role, headless.Interval(townRoot))
+	if err != nil {
+		return nil, err
+	}
+	return &session.StartPlan{
+		SessionID: fmt.Sprintf("(headless, log %s)", headless.LogFilePath(townRoot, role)),
+		WorkDir:   cmd.Dir,
+		Command:   fmt.Sprintf("gt headless run %s  # every %s: %s ...", role, headless.Interval(townRoot), cmd.Path),
+	}, nil
+}
+
+// startReadiness returns the readiness check gt start uses. Every agent
+// must answer Ping. A fresh agent is then given up to timeout to reach an
+// idle prompt (its SessionStart prime has finished); if it is still alive
+// but busy after that, errStartNotIdle is returned so dependents start
+// anyway, since agents often begin working straight away. A zero timeout
+// checks only that the session is up.
+func startReadiness(t *tmux.Tmux, timeout time.Duration) startReadyFunc {
+	return func(n *startNode, fresh bool) error {
+		if n.session == "" {
+			return nil
+		}
+		if err := t.Ping(n.session); err != nil {
+			return err
+		}
+		if !fresh || timeout <= 0 {
+			return nil
+		}
+		if err := t.WaitForPrompt(n.session, timeout); err != nil {
+			if perr := t.Ping(n.session); perr != nil {
+				return fmt.Errorf("session exited while starting: %w", perr)
+			}
+			return fmt.Errorf("%w after %s, still working", errStartNotIdle, timeout)
+		}
+		return nil
+	}
+}
+
+// buildStartGraph returns the agents gt start brings up and their
+// dependencies: the Mayor first, then the Deacon, then each rig's Witness
+// and Refinery, then crew and other workers once their rig's agents are
+// ready. Crew in a rig whose agents aren't being started waits only for the
+// Deacon. Profile members already in the graph are not added twice. Rigs
+// disabled for maintenance get no agents at all.
+func buildStartGraph(t *tmux.Tmux, townRoot string, rigs []*rig.Rig, profile *config.StartupProfile, agentOverride string) ([]*startNode, error) {
+	const mayorNode, deaconNode = "mayor", "deacon"
+	rigs = enabledRigs(townRoot, rigs)
+	var nodes []*startNode
+	bySession := make(map[string]string) // session -> node name
+	add := func(n *startNode) {
+		nodes = append(nodes, n)
+		if n.session != "" {
+			bySession[n.session] = n.name
+		}
+	}
+
+	mayorN := &startNode{name: mayorNode, session: getMayorSessionName()}
+	mayorN.start = func() (bool, error) {
+		err := mayor.NewManager(townRoot).Start(agentOverride)
+		switch {
+		case err == nil:
+			return false, nil
+		case errors.Is(err, mayor.ErrAlreadyRunning):
+			return true, nil
+		case errors.Is(err, mayor.ErrACPActive):
+			// The ACP Mayor has no tmux session to check.
+			mayorN.session = ""
+			return true, nil
+		}
+		return false, err
+	}
+	mayorN.plan = func() (*session.StartPlan, error) {
+		return mayor.NewManager(townRoot).Plan(agentOverride)
+	}
+	add(mayorN)
+	add(&startNode{
+		name:    deaconNode,
+		session: getDeaconSessionName(),
+		deps:    []string{mayorNode},
+		start: func() (bool, error) {
+			err := deacon.NewManager(townRoot).Start(agentOverride)
+			if errors.Is(err, deacon.ErrAlreadyRunning) {
+				return true, nil
+			}
+			return false, err
+		},
+		plan: func() (*session.StartPlan, error) {
+			return deacon.NewManager(townRoot).Plan(agentOverride)
+		},
+	})
+
+	// Headless agents have no tmux session to check for readiness.
+	for _, n := range nodes {
+		if headless.Enabled(townRoot, n.name) {
+			role := n.name
+			n.session = ""
+			n.plan = func() (*session.StartPlan, error) { return headlessStartPlan(townRoot, role) }
+		}
+	}
+
+	rigDeps := make(map[string][]string) // rig name -> infrastructure node names
+	for _, r := range filterProfileRigs(rigs, profile) {
+		witMgr := witness.NewManager(r)
+		refMgr := refinery.NewManager(r)
+		witName, refName := r.Name+"/witness", r.Name+"/refinery"
+		add(&startNode{
+			name:    witName,
+			session: witMgr.SessionName(),
+			deps:    []string{deaconNode},
+			start: func() (bool, error) {
+				err := witMgr.Start(false, "", nil)
+				if errors.Is(err, witness.ErrAlreadyRunning) {
+					return true, nil
+				}
+				return false, err
+			},
+			plan: func() (*session.StartPlan, error) { return witMgr.Plan("", nil) },
+		})
+		add(&startNode{
+			name:    refName,
+			session: refMgr.SessionName(),
+			deps:    []string{deaconNode},
+			start: func() (bool, error) {
+				err := refMgr.Start(false, "")
+				if errors.Is(err, refinery.ErrAlreadyRunning) {
+					return true, nil
+				}
+				return false, err
+			},
+			plan: func() (*session.StartPlan, error) { return refMgr.Plan("") },
+		})
+		rigDeps[r.Name] = []string{witName, refName}
+	}
+	// Profile members may add rig agents for rigs outside the profile's
+	// rig list; add those before the workers that depend on them.
+	var members []groupMember
+	if len(profile.Members) > 0 {
+		var err error
+		if members, err = resolveGroupMembers(profile.Members); err != nil {
+			return nil, fmt.Errorf("startup profile: %w", err)
+		}
+	}
+	addMember := func(m groupMember, deps []string) {
+		sess, identity := m.Identity.SessionName(), m.Identity
+		add(&startNode{
+			name:    m.Address,
+			session: sess,
+			deps:    deps,
+			start: func() (bool, error) {
+				if ok, _ := t.HasSession(sess); ok {
+					return true, nil
+				}
+				return false, startAgentSession(townRoot, identity)
+			},
+			plan: func() (*session.StartPlan, error) { return planAgentSession(townRoot, identity) },
+		})
+	}
+	var workers []groupMember
+	for _, m := range members {
+		if _, ok := bySession[m.Identity.SessionName()]; ok {
+			continue
+		}
+		if m.Identity.Rig != "" {
+			if disabled, _ := config.IsRigDisabled(townRoot, m.Identity.Rig); disabled {
+				continue
+			}
+		}
+		switch m.Identity.Role {
+		case session.RoleMayor:
+			addMember(m, nil)
+		case session.RoleWitness, session.RoleRefinery:
+			addMember(m, []string{deaconNode})
+			rigDeps[m.Identity.Rig] = append(rigDeps[m.Identity.Rig], m.Address)
+		case session.RoleCrew, session.RolePolecat:
+			workers = append(workers, m)
+		default:
+			addMember(m, []string{deaconNode})
+		}
+	}
+
+	workerDeps := func(rigName string) []string {
+		if deps := rigDeps[rigName]; len(deps) > 0 {
+			return deps
+		}
+		return []string{deaconNode}
+	}
+
+	for _, r := range rigs {
+		for _, crewName := range getCrewToStart(r) {
+			r, crewName := r, crewName
+			add(&startNode{
+				name:    r.Name + "/crew/" + crewName,
+				session: crewSessionName(r.Name, crewName),
+				deps:    workerDeps(r.Name),
+				start: func() (bool, error) {
+					action, err := ensureCrewMember(t, r, crewName, townRoot)
+					return action == crewAlreadyRunning, err
+				},
+				plan: func() (*session.StartPlan, error) {
+					return crew.NewManager(r, git.NewGit(r.Path)).Plan(crewName, crew.StartOptions{})
+				},
+			})
+		}
+	}
+	for _, m := range workers {
+		if _, ok := bySession[m.Identity.SessionName()]; ok {
+			continue
+		}
+		addMember(m, workerDeps(m.Identity.Rig))
+	}
+	return nodes, nil
+}
+
+// enabledRigs returns rigs without those disabled for maintenance.
+func enabledRigs(townRoot string, rigs []*rig.Rig) []*rig.Rig {
+	var out []*rig.Rig
+	for _, r := range rigs {
+		if disabled, _ := config.IsRigDisabled(townRoot, r.Name); !disabled {
+			out = append(out, r)
+		}
+	}
+	return out
+}
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeStartNodes builds nodes that record their start order. Nodes named in
+// fail return an error from start.
+func fakeStartNodes(deps map[string][]string, fail map[string]bool) ([]*startNode, func() []string) {
+	var mu sync.Mutex
+	var order []string
+	var nodes []*startNode
+	for _, name := range []string{"mayor", "deacon", "r/witness", "r/refinery", "r/crew/a", "other"} {
+		d, ok := deps[name]
+		if !ok {
+			continue
+		}
+		name := name
+		nodes = append(nodes, &startNode{
+			name: name,
+			deps: d,
+			start: func() (bool, error) {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				if fail[name] {
+					return false, errors.New("boom")
+				}
+				return false, nil
+			},
+		})
+	}
+	return nodes, func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+		return append([]string(nil), order...)
+	}
+}
+
+func TestRunStartGraph_StartsDependenciesFirst(t *testing.T) {
+	deps := map[string][]string{
+		"mayor":      nil,
+		"deacon":     {"mayor"},
+		"r/witness":  {"deacon"},
+		"r/refinery": {"deacon"},
+		"r/crew/a":   {"r/witness", "r/refinery"},
+	}
+	nodes, order := fakeStartNodes(deps, nil)
+	failures, err := runStartGraph(nodes, nil)
+	if err != nil {
+		t.Fatalf("runStartGraph: %v", err)
+	}
+	if len(failures) != 0 {
+		t.Fatalf("failures = %v, want none", failures)
+	}
+	got := order()
+	if len(got) != len(deps) {
+		t.Fatalf("started %v, want all %d nodes", got, len(deps))
+	}
+	for name, ds := range deps {
+		for _, d := range ds {
+			if slices.Index(got, d) > slices.Index(got, name) {
+				t.Errorf("%s started before its dependency %s: %v", name, d, got)
+			}
+		}
+	}
+}
+
+func TestRunStartGraph_SkipsDependentsOfFailedNode(t *testing.T) {
+	deps := map[string][]string{
+		"mayor":      nil,
+		"deacon":     {"mayor"},
+		"r/witness":  {"deacon"},
+		"r/refinery": {"deacon"},
+		"r/crew/a":   {"r/witness", "r/refinery"},
+	}
+	nodes, order := fakeStartNodes(deps, map[string]bool{"r/witness": true})
+	failures, err := runStartGraph(nodes, nil)
+	if err != nil {
+		t.Fatalf("runStartGraph: %v", err)
+	}
+	if slices.Index(order(), "r/crew/a") >= 0 {
+		t.Errorf("crew started despite failed witness: %v", order())
+	}
+	if slices.Index(order(), "r/refinery") < 0 {
+		t.Errorf("refinery should still start: %v", order())
+	}
+	if failures["r/witness"] == nil {
+		t.Error("expected witness failure")
+	}
+	if err := failures["r/crew/a"]; err == nil || !strings.Contains(err.Error(), "r/witness") {
+		t.Errorf("crew failure = %v, want it to name r/witness", err)
+	}
+}
+
+func TestRunStartGraph_ReadinessGatesDependents(t *testing.T) {
+	deps := map[string][]string{
+		"mayor":  nil,
+		"deacon": {"mayor"},
+		"other":  nil,
+	}
+	nodes, order := fakeStartNodes(deps, nil)
+	ready := func(n *startNode, fresh bool) error {
+		if !fresh {
+			t.Errorf("%s: fresh = false for a newly started node", n.name)
+		}
+		if n.name == "mayor" {
+			return errors.New("no prompt")
+		}
+		return nil
+	}
+	failures, err := runStartGraph(nodes, ready)
+	if err != nil {
+		t.Fatalf("runStartGraph: %v", err)
+	}
+	if slices.Index(order(), "deacon") >= 0 {
+		t.Errorf("deacon started before mayor was ready: %v", order())
+	}
+	if slices.Index(order(), "other") < 0 {
+		t.Errorf("independent node should still start: %v", order())
+	}
+	if failures["mayor"] == nil || failures["deacon"] == nil {
+		t.Errorf("failures = %v, want mayor and deacon", failures)
+	}
+}
+
+func TestValidateStartGraph(t *testing.T) {
+	tests := []struct {
+		name string
+		deps map[string][]string
+		want string
+	}{
+		{"cycle", map[string][]string{"mayor": {"deacon"}, "deacon": {"mayor"}}, "cycle"},
+		{"unknown", map[string][]string{"mayor": {"nope"}}, "unknown nope"},
+		{"ok", map[string][]string{"mayor": nil, "deacon": {"mayor"}}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes, _ := fakeStartNodes(tt.deps, nil)
+			err := validateStartGraph(nodes)
+			if tt.want == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Fatalf("err = %v, want containing %q", err, tt.want)
+			}
+		})
+	}
+
+	dup := []*startNode{{name: "mayor"}, {name: "mayor"}}
+	if err := validateStartGraph(dup); err == nil {
+		t.Error("expected error for duplicate node")
+	}
+}
+
+func TestStartGraphOrder(t *testing.T) {
+	deps := map[string][]string{
+		"mayor":      nil,
+		"deacon":     {"mayor"},
+		"r/witness":  {"deacon"},
+		"r/refinery": {"deacon"},
+		"r/crew/a":   {"r/witness", "r/refinery"},
+		"other":      nil,
+	}
+	nodes, order := fakeStartNodes(deps, nil)
+	ordered, err := startGraphOrder(nodes)
+	if err != nil {
+		t.Fatalf("startGraphOrder: %v", err)
+	}
+	var got []string
+	for _, n := range ordered {
+		got = append(got, n.name)
+	}
+	want := []string{"mayor", "other", "deacon", "r/witness", "r/refinery", "r/crew/a"}
+	if !slices.Equal(got, want) {
+		t.Errorf("order = %v, want %v", got, want)
+	}
+	if len(order()) != 0 {
+		t.Errorf("startGraphOrder started nodes: %v", order())
+	}
+}
+
+func TestRunStartGraph_BusyAgentStillStartsDependents(t *testing.T) {
+	deps := map[string][]string{
+		"mayor":  nil,
+		"deacon": {"mayor"},
+	}
+	nodes, order := fakeStartNodes(deps, nil)
+	ready := func(n *startNode, fresh bool) error {
+		if n.name == "mayor" {
+			return fmt.Errorf("%w after 2m0s, still working", errStartNotIdle)
+		}
+		return nil
+	}
+	var failures map[string]error
+	out := captureStdout(t, func() {
+		var err error
+		if failures, err = runStartGraph(nodes, ready); err != nil {
+			t.Fatalf("runStartGraph: %v", err)
+		}
+	})
+	if slices.Index(order(), "deacon") < 0 {
+		t.Errorf("deacon should start after a busy mayor: %v", order())
+	}
+	if len(failures) != 0 {
+		t.Errorf("failures = %v, want none for a busy agent", failures)
+	}
+	if !strings.Contains(out, "mayor started (not idle") {
+		t.Errorf("output = %q, want the busy mayor reported", out)
+	}
+}
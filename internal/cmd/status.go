@@ -21,8 +21,10 @@ import (
 	"github.com/steveyegge/gastown/internal/daemon"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/headless"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/mayor"
+	"github.com/steveyegge/gastown/internal/quota"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
@@ -36,6 +38,7 @@ var statusFast bool
 var statusWatch bool
 var statusInterval int
 var statusVerbose bool
+var statusCheck bool
 
 var statusCmd = &cobra.Command{
 	Use:         "status",
@@ -48,7 +51,19 @@ var statusCmd = &cobra.Command{
 Shows town name, registered rigs, polecats, and witness status.
 
 Use --fast to skip mail lookups for faster execution.
-Use --watch to continuously refresh status at regular intervals.`,
+Use --watch for a full-screen view that refreshes every --interval seconds,
+with a summary line of running agents, zombie sessions (tmux alive, agent
+dead), and account quota.
+
+Use --check to print only a one-line health verdict, for cron jobs and CI.
+
+Exit codes (all modes except --watch):
+  0 - Healthy: Mayor, Deacon, and every active rig's Witness and Refinery running
+  1 - Error: status could not be determined
+  2 - Degraded: some expected agents down, zombie sessions, or daemon/Dolt down
+  3 - Down: none of the expected agents are running
+
+Parked and docked rigs are not expected to have their agents running.`,
 	RunE: runStatus,
 }
 
@@ -58,6 +73,7 @@ func init() {
 	statusCmd.Flags().BoolVarP(&statusWatch, "watch", "w", false, "Watch mode: refresh status continuously")
 	statusCmd.Flags().IntVarP(&statusInterval, "interval", "n", 2, "Refresh interval in seconds")
 	statusCmd.Flags().BoolVarP(&statusVerbose, "verbose", "v", false, "Show detailed multi-line output per agent")
+	statusCmd.Flags().BoolVar(&statusCheck, "check", false, "Print only a one-line health verdict; the exit code carries the result")
 	rootCmd.AddCommand(statusCmd)
 }
 
@@ -74,6 +90,7 @@ type TownStatus struct {
 	Agents   []AgentRuntime `json:"agents"`             // Global agents (Mayor, Deacon)
 	Rigs     []RigStatus    `json:"rigs"`
 	Summary  StatusSum      `json:"summary"`
+	Health   *TownHealth    `json:"health,omitempty"` // Healthy, degraded, or down
 }
 
 // ServiceInfo represents a background service status.
@@ -126,6 +143,7 @@ type AgentRuntime struct {
 	Role         string `json:"role"`                    // Role type
 	Running      bool   `json:"running"`                 // Is tmux session running?
 	ACP          bool   `json:"acp"`                     // Is ACP session active?
+	Headless     bool   `json:"headless,omitempty"`      // Runs as a headless supervisor?
 	HasWork      bool   `json:"has_work"`                // Has pinned work?
 	WorkTitle    string `json:"work_title,omitempty"`    // Title of pinned work
 	HookBead     string `json:"hook_bead,omitempty"`     // Pinned bead ID from agent bead
@@ -177,6 +195,7 @@ type StatusSum struct {
 	WitnessCount  int `json:"witness_count"`
 	RefineryCount int `json:"refinery_count"`
 	ActiveHooks   int `json:"active_hooks"`
+	Zombies       int `json:"zombies"` // Gas Town sessions whose agent is not running
 }
 
 // resolveAgentDisplay inspects the actual running process in the tmux session
@@ -465,6 +484,9 @@ func runStatusWatch(_ *cobra.Command, _ []string) error {
 	if statusJSON {
 		return fmt.Errorf("--json and --watch cannot be used together")
 	}
+	if statusCheck {
+		return fmt.Errorf("--check and --watch cannot be used together")
+	}
 	if statusInterval <= 0 {
 		return fmt.Errorf("interval must be positive, got %d", statusInterval)
 	}
@@ -477,6 +499,15 @@ func runStatusWatch(_ *cobra.Command, _ []string) error {
 	defer ticker.Stop()
 
 	isTTY := term.IsTerminal(int(os.Stdout.Fd()))
+	if isTTY {
+		// Draw on the alternate screen so the watch view is full-screen and
+		// the operator's scrollback is restored on exit.
+		fmt.Print("\033[?1049h")
+		defer func() {
+			fmt.Print("\033[?1049l")
+			fmt.Println("Stopped.")
+		}()
+	}
 
 	// Cache the last successful status to handle transient tmux/beads
 	// failures. Watch mode spawns many tmux subprocesses per iteration;
@@ -554,6 +585,7 @@ func runStatusWatch(_ *cobra.Command, _ []string) error {
 					fmt.Fprintf(&buf, "%s\n", staleNote)
 				}
 			}
+			fmt.Fprintf(&buf, "%s\n\n", formatWatchSummary(status, loadWatchQuota(status.Location)))
 			if err := outputStatusText(&buf, status); err != nil {
 				fmt.Fprintf(&buf, "Error: %v\n", err)
 			}
@@ -565,15 +597,55 @@ func runStatusWatch(_ *cobra.Command, _ []string) error {
 
 		select {
 		case <-sigChan:
-			if isTTY {
-				fmt.Println("\nStopped.")
-			}
 			return nil
 		case <-ticker.C:
 		}
 	}
 }
 
+// watchQuota is the account quota summary shown in watch mode.
+type watchQuota struct {
+	Accounts  int
+	Available int
+}
+
+// loadWatchQuota returns the quota summary for townRoot, or nil when no
+// accounts are configured.
+func loadWatchQuota(townRoot string) *watchQuota {
+	acctCfg, err := config.LoadAccountsConfig(constants.MayorAccountsPath(townRoot))
+	if err != nil || len(acctCfg.Accounts) == 0 {
+		return nil
+	}
+	mgr := quota.NewManager(townRoot)
+	state, err := mgr.Load()
+	if err != nil {
+		return nil
+	}
+	mgr.EnsureAccountsTracked(state, acctCfg.Accounts)
+	// Count expired limits as available without persisting: watch mode only reads.
+	mgr.ClearExpired(state)
+	return &watchQuota{Accounts: len(acctCfg.Accounts), Available: len(mgr.AvailableAccounts(state))}
+}
+
+// formatWatchSummary renders the one-line health summary at the top of the
+// watch view: running agents, zombie sessions, and account quota.
+func formatWatchSummary(status TownStatus, q *watchQuota) string {
+	parts := []string{fmt.Sprintf("%d agent(s) running", countRunningAgents(status))}
+	if status.Summary.Zombies > 0 {
+		parts = append(parts, style.Warning.Render(fmt.Sprintf("%d zombie session(s)", status.Summary.Zombies)))
+	} else {
+		parts = append(parts, "0 zombies")
+	}
+	if q != nil {
+		quotaPart := fmt.Sprintf("quota: %d/%d account(s) available", q.Available, q.Accounts)
+		if q.Available == 0 {
+			quotaPart = style.Error.Render(quotaPart)
+		}
+		parts = append(parts, quotaPart)
+	}
+	return strings.Join(parts, " · ")
+}
+
 // countRunningAgents returns the number of agents with Running=true
 // across all global agents and rig agents in the status.
 func countRunningAgents(s TownStatus) int {
@@ -593,15 +665,37 @@ func countRunningAgents(s TownStatus) int {
 	return count
 }
 
-func runStatusOnce(_ *cobra.Command, _ []string) error {
+func runStatusOnce(cmd *cobra.Command, _ []string) error {
 	status, err := gatherStatus()
 	if err != nil {
 		return err
 	}
-	if statusJSON {
-		return outputStatusJSON(status)
+	status.Health = assessTownHealth(status, func(rigName string) bool {
+		resting, _ := IsRigParkedOrDocked(status.Location, rigName)
+		return resting
+	})
+	if status.Health.State == HealthDown && daemon.InQuietHours(status.Location) {
+		status.Health.Problems = []string{"quiet hours (scheduled shutdown)"}
+	}
+
+	switch {
+	case statusCheck && statusJSON:
+		data, err := json.MarshalIndent(status.Health, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case statusCheck:
+		fmt.Println(formatHealthLine(status.Health))
+	case statusJSON:
+		err = outputStatusJSON(status)
+	default:
+		err = outputStatusText(os.Stdout, status)
 	}
-	return outputStatusText(os.Stdout, status)
+	if err != nil {
+		return err
+	}
+	return healthExit(cmd, status.Health)
 }
 
 func gatherStatus() (TownStatus, error) {
@@ -641,6 +735,7 @@ func gatherStatus() (TownStatus, error) {
 	// A Gas Town session is only considered "running" if the agent process is
 	// alive inside it, not merely if the tmux session exists. This prevents
 	// zombie sessions (tmux alive, agent dead) from showing as running.
+	// Ping first so dead panes and a wedged tmux server are caught cheaply.
 	// See: gt-bd6i3
 	allSessions := make(map[string]bool)
 	if sessions, err := t.ListSessions(); err == nil {
@@ -651,7 +746,7 @@ func gatherStatus() (TownStatus, error) {
 				sessionWg.Add(1)
 				go func(name string) {
 					defer sessionWg.Done()
-					alive := t.IsAgentAlive(name)
+					alive := t.Ping(name) == nil && t.IsAgentAlive(name)
 					sessionMu.Lock()
 					allSessions[name] = alive
 					sessionMu.Unlock()
@@ -943,6 +1038,11 @@ func gatherStatus() (TownStatus, error) {
 		}
 	}
 	status.Summary.RigCount = len(rigs)
+	for name, alive := range allSessions {
+		if !alive && session.IsKnownSession(name) {
+			status.Summary.Zombies++
+		}
+	}
 
 	return status, nil
 }
@@ -1451,6 +1551,9 @@ func buildStatusIndicator(agent AgentRuntime) string {
 	if agent.ACP {
 		indicator += style.Dim.Render(" acp")
 	}
+	if agent.Headless {
+		indicator += style.Dim.Render(" headless")
+	}
 
 	// Add non-observable state suffix if present
 	beadState := agent.State
@@ -1592,6 +1695,12 @@ func discoverGlobalAgents(townRoot string, allSessions map[string]bool, allAgent
 				}
 			}
 
+			// Headless agents run under a supervisor, not tmux
+			if headless.Enabled(townRoot, d.name) {
+				agent.Headless = true
+				_, agent.Running = headless.Running(townRoot, d.name)
+			}
+
 			// Look up agent bead from preloaded map (O(1))
 			if issue, ok := allAgentBeads[d.beadID]; ok {
 				// Prefer database columns over description parsing
@@ -1810,23 +1919,16 @@ func getMQSummary(r *rig.Rig) *MQSummary {
 	// Create beads instance for the rig
 	b := beads.New(r.BeadsPath())
 
-	// Query for all open merge-request issues
-	opts := beads.ListOptions{
-		Label:    "gt:merge-request",
-		Status:   "open",
-		Priority: -1, // No priority filter
-	}
-	openMRs, err := b.List(opts)
-	if err != nil {
-		return nil
-	}
-
-	// Query for in-progress merge-requests
-	opts.Status = "in_progress"
-	inProgressMRs, err := b.List(opts)
+	// Query for open and in-progress merge-request issues
+	mrs, err := b.Query(beads.Query{
+		Statuses: []string{"open", "in_progress"},
+		Labels:   []string{"gt:merge-request"},
+	})
 	if err != nil {
 		return nil
 	}
+	openMRs := beads.Query{Statuses: []string{"open"}}.Filter(mrs)
+	inProgressMRs := beads.Query{Statuses: []string{"in_progress"}}.Filter(mrs)
 
 	// Count pending (open with no blockers) vs blocked
 	pending := 0
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// Town health states reported by gt status.
+const (
+	HealthHealthy  = "healthy"
+	HealthDegraded = "degraded"
+	HealthDown     = "down"
+)
+
+// Exit codes for gt status. 1 is left to command errors (e.g. not in a
+// workspace) so scripts can tell "the town is unhealthy" from "status
+// could not be determined".
+const (
+	StatusExitHealthy  = 0
+	StatusExitDegraded = 2
+	StatusExitDown     = 3
+)
+
+// TownHealth summarizes whether the agents a town is expected to run are up.
+type TownHealth struct {
+	State    string   `json:"state"`              // healthy, degraded, or down
+	Problems []string `json:"problems,omitempty"` // Why the town is not healthy
+}
+
+// ExitCode returns the gt status exit code for the health state.
+func (h *TownHealth) ExitCode() int {
+	switch h.State {
+	case HealthDegraded:
+		return StatusExitDegraded
+	case HealthDown:
+		return StatusExitDown
+	default:
+		return StatusExitHealthy
+	}
+}
+
+// assessTownHealth derives town health from a status snapshot. The Mayor,
+// the Deacon, and each rig's Witness and Refinery are expected to run;
+// polecats and crew come and go. restingRig reports whether a rig is parked
+// or docked, whose agents are down on purpose; it is only consulted for
+// rigs with a stopped agent.
+//
+// The town is down when none of the expected agents are running, degraded
+// when some are not or the daemon, Dolt, or an agent process is missing,
+// and healthy otherwise.
+func assessTownHealth(s TownStatus, restingRig func(rig string) bool) *TownHealth {
+	expected := 0
+	var stopped []string
+	for _, a := range s.Agents {
+		expected++
+		if !a.Running {
+			stopped = append(stopped, a.Name)
+		}
+	}
+	for _, r := range s.Rigs {
+		var rigStopped []string
+		var rigExpected int
+		for _, a := range r.Agents {
+			if a.Role != constants.RoleWitness && a.Role != constants.RoleRefinery {
+				continue
+			}
+			rigExpected++
+			if !a.Running {
+				rigStopped = append(rigStopped, a.Address)
+			}
+		}
+		if len(rigStopped) > 0 && restingRig != nil && restingRig(r.Name) {
+			continue
+		}
+		expected += rigExpected
+		stopped = append(stopped, rigStopped...)
+	}
+
+	h := &TownHealth{State: HealthHealthy}
+	if expected > 0 && len(stopped) == expected {
+		h.State = HealthDown
+		h.Problems = append(h.Problems, "no agents running")
+		return h
+	}
+
+	for _, name := range stopped {
+		h.Problems = append(h.Problems, name+" not running")
+	}
+	if s.Summary.Zombies > 0 {
+		h.Problems = append(h.Problems, fmt.Sprintf("%d zombie session(s)", s.Summary.Zombies))
+	}
+	if s.Daemon != nil && !s.Daemon.Running {
+		h.Problems = append(h.Problems, "daemon not running")
+	}
+	if s.Dolt != nil && !s.Dolt.Remote && !s.Dolt.Running {
+		h.Problems = append(h.Problems, "dolt server not running")
+	}
+	if len(h.Problems) > 0 {
+		h.State = HealthDegraded
+	}
+	return h
+}
+
+// healthExit turns a health assessment into the command's result: nil when
+// healthy, otherwise a silent exit carrying the health exit code.
+func healthExit(cmd *cobra.Command, h *TownHealth) error {
+	code := h.ExitCode()
+	if code == StatusExitHealthy {
+		return nil
+	}
+	// The exit code is the message; don't let cobra print "Error: exit 2".
+	cmd.SilenceErrors = true
+	cmd.SilenceUsage = true
+	return NewSilentExit(code)
+}
+
+// formatHealthLine renders health as a single line, e.g.
+// "degraded: gastown/witness not running".
+func formatHealthLine(h *TownHealth) string {
+	if len(h.Problems) == 0 {
+		return h.State
+	}
+	return h.State + ": " + strings.Join(h.Problems, "; ")
+}
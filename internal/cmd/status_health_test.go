@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+func healthTestStatus(mayor, deacon, witness, refinery bool) TownStatus {
+	return TownStatus{
+		Daemon: &ServiceInfo{Running: true},
+		Dolt:   &DoltInfo{Running: true},
+		Agents: []AgentRuntime{
+			{Name: "mayor", Running: mayor},
+			{Name: "deacon", Running: deacon},
+		},
+		Rigs: []RigStatus{{
+			Name: "gastown",
+			Agents: []AgentRuntime{
+				{Address: "gastown/witness", Role: constants.RoleWitness, Running: witness},
+				{Address: "gastown/refinery", Role: constants.RoleRefinery, Running: refinery},
+				{Address: "gastown/toast", Role: constants.RolePolecat, Running: false},
+			},
+		}},
+	}
+}
+
+func TestAssessTownHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   TownStatus
+		resting  bool
+		want     string
+		wantCode int
+	}{
+		{"all running", healthTestStatus(true, true, true, true), false, HealthHealthy, StatusExitHealthy},
+		{"witness down", healthTestStatus(true, true, false, true), false, HealthDegraded, StatusExitDegraded},
+		{"witness down in parked rig", healthTestStatus(true, true, false, false), true, HealthHealthy, StatusExitHealthy},
+		{"nothing running", healthTestStatus(false, false, false, false), false, HealthDown, StatusExitDown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := assessTownHealth(tt.status, func(string) bool { return tt.resting })
+			if h.State != tt.want {
+				t.Errorf("State = %q, want %q (problems: %v)", h.State, tt.want, h.Problems)
+			}
+			if got := h.ExitCode(); got != tt.wantCode {
+				t.Errorf("ExitCode() = %d, want %d", got, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAssessTownHealth_ServicesDown(t *testing.T) {
+	s := healthTestStatus(true, true, true, true)
+	s.Daemon.Running = false
+	s.Summary.Zombies = 1
+
+	h := assessTownHealth(s, nil)
+	if h.State != HealthDegraded {
+		t.Fatalf("State = %q, want degraded", h.State)
+	}
+	if len(h.Problems) != 2 {
+		t.Errorf("Problems = %v, want daemon and zombie problems", h.Problems)
+	}
+
+	s = healthTestStatus(true, true, true, true)
+	s.Dolt = &DoltInfo{Remote: true}
+	if h := assessTownHealth(s, nil); h.State != HealthHealthy {
+		t.Errorf("remote Dolt should not count as down, got %q: %v", h.State, h.Problems)
+	}
+}
+
+func TestFormatHealthLine(t *testing.T) {
+	if got := formatHealthLine(&TownHealth{State: HealthHealthy}); got != "healthy" {
+		t.Errorf("got %q, want healthy", got)
+	}
+	h := &TownHealth{State: HealthDegraded, Problems: []string{"mayor not running", "daemon not running"}}
+	if got, want := formatHealthLine(h), "degraded: mayor not running; daemon not running"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
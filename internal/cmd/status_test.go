@@ -551,3 +551,21 @@ func TestExtractBaseName(t *testing.T) {
 		})
 	}
 }
+
+func TestFormatWatchSummary(t *testing.T) {
+	status := TownStatus{
+		Agents: []AgentRuntime{{Name: "mayor", Running: true}, {Name: "deacon"}},
+		Rigs:   []RigStatus{{Agents: []AgentRuntime{{Name: "witness", Running: true}}}},
+	}
+
+	got := formatWatchSummary(status, nil)
+	if !strings.Contains(got, "2 agent(s) running") || !strings.Contains(got, "0 zombies") || strings.Contains(got, "quota") {
+		t.Errorf("formatWatchSummary() = %q", got)
+	}
+
+	status.Summary.Zombies = 3
+	got = formatWatchSummary(status, &watchQuota{Accounts: 2, Available: 1})
+	if !strings.Contains(got, "3 zombie session(s)") || !strings.Contains(got, "quota: 1/2 account(s) available") {
+		t.Errorf("formatWatchSummary() = %q", got)
+	}
+}
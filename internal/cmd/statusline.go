@@ -217,7 +217,7 @@ func runMayorStatusLine(t *tmux.Tmux) error {
 	type rigStatus struct {
 		hasWitness  bool
 		hasRefinery bool
-		opState     string // "OPERATIONAL", "PARKED", or "DOCKED"
+		opState     string // "OPERATIONAL", "PARKED", "DOCKED", or "DISABLED"
 	}
 	rigStatuses := make(map[string]*rigStatus)
 
@@ -278,7 +278,7 @@ func runMayorStatusLine(t *tmux.Tmux) error {
 	// Get operational state for each rig
 	for rigName, status := range rigStatuses {
 		opState, _ := getRigOperationalState(townRoot, rigName)
-		if opState == "PARKED" || opState == "DOCKED" {
+		if opState == "PARKED" || opState == "DOCKED" || opState == "DISABLED" {
 			status.opState = opState
 		} else {
 			status.opState = "OPERATIONAL"
@@ -339,8 +339,8 @@ func runMayorStatusLine(t *tmux.Tmux) error {
 			return isRunningI
 		}
 
-		// Secondary sort: operational state (for non-running rigs: OPERATIONAL < PARKED < DOCKED)
-		stateOrder := map[string]int{"OPERATIONAL": 0, "PARKED": 1, "DOCKED": 2}
+		// Secondary sort: operational state (for non-running rigs: OPERATIONAL < PARKED < DOCKED < DISABLED)
+		stateOrder := map[string]int{"OPERATIONAL": 0, "PARKED": 1, "DOCKED": 2, "DISABLED": 3}
 		stateI := stateOrder[rigs[i].status.opState]
 		stateJ := stateOrder[rigs[j].status.opState]
 		if stateI != stateJ {
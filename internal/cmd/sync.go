@@ -0,0 +1,491 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tracker"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// Sync command flags
+var (
+	syncRig      string
+	syncDryRun   bool
+	syncAll      bool
+	syncJobs     int
+	syncTrackers bool
+)
+
+var syncCmd = &cobra.Command{
+	Use:     "sync",
+	GroupID: GroupWork,
+	Short:   "Sync beads with their Dolt remotes and external issue trackers",
+	Long: `Sync a rig's beads with an external issue tracker, both ways.
+
+Beads carrying the connector's label (default "gastown") are mirrored to
+the tracker, and tracker issues carrying the label are imported into the
+rig's beads, where they join the ready queue. For linked pairs, title,
+status and assignee follow whichever side changed; if both
+changed, the bead wins.
+
+Connectors are configured per rig in <rig>/settings/config.json. The
+daemon also syncs each configured connector on its interval, and gt done
+pushes a polecat's closed bead to its linked issues right away.
+
+Use --all to sync the beads database of the town (hq) and of every rig
+with its Dolt remote, a few at a time (--jobs), with one report at the
+end. Polecat and crew worktrees share their rig's database, so syncing
+the rig covers them. Add --trackers to also run every configured tracker
+connector afterwards. For --force or --resolve, use gt dolt sync.
+
+Examples:
+  gt sync --all                # Every database, town and rigs
+  gt sync --all --rig gastown  # Just one rig's database
+  gt sync --all --jobs 8       # More syncs at once
+  gt sync --all --trackers     # Databases, then every tracker connector
+  gt sync --all --dry-run      # Show what would be pushed everywhere`,
+	RunE: runSync,
+}
+
+var syncGitHubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Sync beads with GitHub Issues",
+	Long: `Sync beads with the issues of a GitHub repository.
+
+Configure in <rig>/settings/config.json and set GITHUB_TOKEN:
+
+  "github": {
+    "repo": "owner/name",
+    "label": "gastown",
+    "interval": "15m",
+    "assignees": {"gastown/crew/max": "max-on-github"}
+  }
+
+Examples:
+  gt sync github                 # Sync every rig with GitHub configured
+  gt sync github --rig gastown   # Sync one rig
+  gt sync github --dry-run       # Show what would change`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTrackerSync("github")
+	},
+}
+
+var syncJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Sync beads with a Jira project",
+	Long: `Sync beads with the tickets of a Jira project.
+
+Unlike GitHub, Jira tracks progress: a bead that is hooked or in progress
+moves its ticket to "In Progress".
+
+Configure in <rig>/settings/config.json and set JIRA_API_TOKEN:
+
+  "jira": {
+    "url": "https://acme.atlassian.net",
+    "project": "ENG",
+    "email": "bot@acme.com",
+    "issue_type": "Task",
+    "fields": {"title": "summary", "description": "description"},
+    "statuses": {"open": "To Do", "in_progress": "In Progress", "closed": "Done"},
+    "assignees": {"gastown/crew/max": "<account-id>"}
+  }
+
+Omit "email" for Jira Data Center, where the token is a personal access
+token and assignees are usernames.
+
+Examples:
+  gt sync jira                 # Sync every rig with Jira configured
+  gt sync jira --rig gastown   # Sync one rig
+  gt sync jira --dry-run       # Show what would change`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTrackerSync("jira")
+	},
+}
+
+var syncLinearCmd = &cobra.Command{
+	Use:   "linear",
+	Short: "Sync beads with a Linear team",
+	Long: `Sync beads with the issues of a Linear team.
+
+Like Jira, Linear tracks progress: hooked and in-progress beads move their
+issue to a started state. Linear users are identified by email.
+
+Configure in <rig>/settings/config.json and set LINEAR_API_KEY:
+
+  "linear": {
+    "team": "ENG",
+    "statuses": {"open": "Todo", "in_progress": "In Progress", "closed": "Done"},
+    "assignees": {"gastown/crew/max": "max@example.com"}
+  }
+
+Without "statuses", the team's first unstarted, started, and completed
+workflow states are used. The sync label is created on the team if needed.
+
+Examples:
+  gt sync linear                 # Sync every rig with Linear configured
+  gt sync linear --rig gastown   # Sync one rig
+  gt sync linear --dry-run       # Show what would change`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTrackerSync("linear")
+	},
+}
+
+func init() {
+	syncCmd.PersistentFlags().StringVar(&syncRig, "rig", "", "Only sync this rig")
+	syncCmd.PersistentFlags().BoolVarP(&syncDryRun, "dry-run", "n", false, "Show what would change without writing")
+	syncCmd.Flags().BoolVar(&syncAll, "all", false, "Sync the town's and every rig's beads database with its Dolt remote")
+	syncCmd.Flags().IntVarP(&syncJobs, "jobs", "j", 4, "With --all, how many syncs to run at once")
+	syncCmd.Flags().BoolVar(&syncTrackers, "trackers", false, "With --all, also sync every configured tracker connector")
+	syncCmd.AddCommand(syncGitHubCmd)
+	syncCmd.AddCommand(syncJiraCmd)
+	syncCmd.AddCommand(syncLinearCmd)
+	rootCmd.AddCommand(syncCmd)
+}
+
+func runSync(cmd *cobra.Command, args []string) error {
+	if !syncAll {
+		return requireSubcommand(cmd, args)
+	}
+	if len(args) > 0 {
+		return fmt.Errorf("--all syncs every database; drop %q or use it without --all", args[0])
+	}
+	if syncJobs < 1 {
+		return fmt.Errorf("--jobs must be at least 1")
+	}
+	return runSyncAll()
+}
+
+// runSyncAll syncs every beads database (or just --rig's) with its Dolt
+// remote and, with --trackers, every tracker connector after that.
+func runSyncAll() error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+	dbErr := runDoltSyncAll(townRoot)
+	if !syncTrackers {
+		return dbErr
+	}
+	fmt.Println()
+	return errors.Join(dbErr, runTrackerSyncAll())
+}
+
+// runDoltSyncAll pushes each database through the worker pool, via SQL if
+// the server is running and via the CLI otherwise, and reports per
+// database.
+func runDoltSyncAll(townRoot string) error {
+	config := doltserver.DefaultConfig(townRoot)
+	if config.IsRemote() {
+		return fmt.Errorf("Dolt server is remote (%s) — sync requires local server access", config.HostPort())
+	}
+
+	var databases []string
+	if syncRig != "" {
+		if _, _, err := getRig(syncRig); err != nil {
+			return err
+		}
+		db := doltserver.RigDatabase(townRoot, syncRig)
+		if db == "" {
+			return fmt.Errorf("rig %s has no Dolt database", syncRig)
+		}
+		databases = []string{db}
+	} else {
+		all, err := doltserver.ListDatabases(townRoot)
+		if err != nil {
+			return fmt.Errorf("listing databases: %w", err)
+		}
+		databases = all
+	}
+	if len(databases) == 0 {
+		fmt.Println("No databases to sync.")
+		return nil
+	}
+
+	syncOne := doltserver.SyncDatabase
+	via := "CLI (server not running)"
+	if running, _, _ := doltserver.IsRunning(townRoot); running {
+		syncOne = doltserver.SyncDatabaseSQL
+		via = "SQL"
+	}
+	opts := doltserver.SyncOptions{DryRun: syncDryRun}
+
+	fmt.Printf("Syncing %d database(s) via %s, %d at a time...\n\n", len(databases), via, min(syncJobs, len(databases)))
+	results := runPool(databases, syncJobs, func(db string) doltserver.SyncResult {
+		return syncOne(townRoot, db, opts)
+	})
+	return printDoltSyncAllReport(results, doltserver.CollectDatabaseOwners(townRoot))
+}
+
+// doltSyncStatus describes one database's sync result for the --all report.
+func doltSyncStatus(r doltserver.SyncResult) string {
+	switch {
+	case r.Error != nil:
+		status := fmt.Sprintf("%s %v", style.Error.Render("✗"), r.Error)
+		if r.Pulled {
+			status += " (remote changes merged locally; rerun to push)"
+		}
+		return status
+	case r.DryRun:
+		return style.Dim.Render("~") + " would push"
+	case r.Skipped:
+		return style.Dim.Render("○ no remote configured")
+	}
+	status := style.Success.Render("✓") + " pushed"
+	if r.Pulled {
+		status += ", merged remote changes"
+		if r.Resolved != "" {
+			status += fmt.Sprintf(" (conflicts resolved: %s)", r.Resolved)
+		}
+	}
+	if r.Retries > 0 {
+		status += fmt.Sprintf(" after %d retries", r.Retries)
+	}
+	return status
+}
+
+func printDoltSyncAllReport(results []doltserver.SyncResult, owners map[string]string) error {
+	var pushed, pulled, skipped, failed int
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  DATABASE\tOWNER\tRESULT")
+	for _, r := range results {
+		owner := owners[r.Database]
+		if owner == "" {
+			owner = "-"
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\n", r.Database, owner, doltSyncStatus(r))
+		switch {
+		case r.Error != nil:
+			failed++
+		case r.Skipped:
+			skipped++
+		default:
+			pushed++
+		}
+		if r.Pulled {
+			pulled++
+		}
+	}
+	_ = tw.Flush()
+
+	verb := "pushed"
+	if syncDryRun {
+		verb = "would push"
+	}
+	fmt.Printf("\nDatabases: %d %s, %d pulled, %d skipped, %d failed\n", pushed, verb, pulled, skipped, failed)
+	if failed > 0 {
+		return fmt.Errorf("%d database(s) failed to sync", failed)
+	}
+	return nil
+}
+
+// syncJob is one connector of one rig to sync.
+type syncJob struct {
+	rig       *rig.Rig
+	connector tracker.Configured
+}
+
+// syncJobResult is the outcome of a syncJob. connector is empty when the
+// rig's connectors couldn't be loaded.
+type syncJobResult struct {
+	rig       string
+	connector string
+	result    tracker.Result
+	err       error
+}
+
+// runTrackerSyncAll syncs every configured connector of every rig (or just
+// --rig) in parallel and prints a consolidated report.
+func runTrackerSyncAll() error {
+	var rigs []*rig.Rig
+	if syncRig != "" {
+		_, r, err := getRig(syncRig)
+		if err != nil {
+			return err
+		}
+		rigs = []*rig.Rig{r}
+	} else {
+		all, err := getAllRigs()
+		if err != nil {
+			return err
+		}
+		rigs = all
+	}
+
+	var jobs []syncJob
+	var results []syncJobResult
+	for _, r := range rigs {
+		connectors, err := tracker.RigConnectors(r.Path)
+		if err != nil {
+			results = append(results, syncJobResult{rig: r.Name, err: err})
+		}
+		for _, c := range connectors {
+			jobs = append(jobs, syncJob{rig: r, connector: c})
+		}
+	}
+	if len(jobs) == 0 && len(results) == 0 {
+		fmt.Printf("%s\n", style.Dim.Render("No rigs have tracker sync configured"))
+		return nil
+	}
+
+	fmt.Printf("Syncing %d connector(s), %d at a time...\n\n", len(jobs), min(syncJobs, len(jobs)))
+	results = append(results, runSyncJobs(jobs, syncJobs, func(j syncJob) (tracker.Result, error) {
+		return tracker.SyncRig(context.Background(), j.rig.Path, j.connector, syncDryRun)
+	})...)
+
+	return printSyncAllReport(results)
+}
+
+// runSyncJobs runs fn for every tracker job, at most parallelism at a time,
+// and returns the results in job order.
+func runSyncJobs(jobs []syncJob, parallelism int, fn func(syncJob) (tracker.Result, error)) []syncJobResult {
+	return runPool(jobs, parallelism, func(j syncJob) syncJobResult {
+		res, err := fn(j)
+		return syncJobResult{rig: j.rig.Name, connector: j.connector.Connector.Name(), result: res, err: err}
+	})
+}
+
+// runPool runs fn for every job, at most parallelism at a time, and returns
+// the results in job order.
+func runPool[J, R any](jobs []J, parallelism int, fn func(J) R) []R {
+	results := make([]R, len(jobs))
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, parallelism)
+	for i, j := range jobs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, j J) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			results[i] = fn(j)
+		}(i, j)
+	}
+	wg.Wait()
+	return results
+}
+
+func printSyncAllReport(results []syncJobResult) error {
+	var total tracker.Result
+	failed := 0
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  RIG\tCONNECTOR\tCREATED\tIMPORTED\tPUSHED\tPULLED\tRESULT")
+	for _, r := range results {
+		connector := r.connector
+		if connector == "" {
+			connector = "-"
+		}
+		if r.err != nil {
+			failed++
+			fmt.Fprintf(tw, "  %s\t%s\t\t\t\t\t%s %v\n", r.rig, connector, style.Error.Render("✗"), r.err)
+			continue
+		}
+		res := r.result
+		total.Created += res.Created
+		total.Imported += res.Imported
+		total.Pushed += res.Pushed
+		total.Pulled += res.Pulled
+		total.Conflicts = append(total.Conflicts, res.Conflicts...)
+		status := style.Success.Render("✓")
+		if len(res.Conflicts) > 0 {
+			status = fmt.Sprintf("%s %d conflict(s), bead kept", style.Warning.Render("!"), len(res.Conflicts))
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%d\t%d\t%d\t%d\t%s\n", r.rig, connector, res.Created, res.Imported, res.Pushed, res.Pulled, status)
+	}
+	_ = tw.Flush()
+
+	verb := "Synced"
+	if syncDryRun {
+		verb = "Would sync"
+	}
+	fmt.Printf("\n%s %d connector(s): %d created, %d imported, %d pushed, %d pulled",
+		verb, len(results)-failed, total.Created, total.Imported, total.Pushed, total.Pulled)
+	if len(total.Conflicts) > 0 {
+		fmt.Printf(", %d conflict(s)", len(total.Conflicts))
+	}
+	fmt.Println()
+	if len(total.Conflicts) > 0 {
+		fmt.Printf("  %s conflicts (bead kept): %s\n", style.Warning.Render("!"), strings.Join(total.Conflicts, ", "))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d sync(s) failed", failed)
+	}
+	return nil
+}
+
+// runTrackerSync syncs the named connector for every rig that configures it
+// (or just --rig).
+func runTrackerSync(name string) error {
+	var rigs []*rig.Rig
+	if syncRig != "" {
+		_, r, err := getRig(syncRig)
+		if err != nil {
+			return err
+		}
+		rigs = []*rig.Rig{r}
+	} else {
+		all, err := getAllRigs()
+		if err != nil {
+			return err
+		}
+		rigs = all
+	}
+
+	synced, failed := 0, 0
+	for _, r := range rigs {
+		connectors, err := tracker.RigConnectors(r.Path)
+		if err != nil {
+			fmt.Printf("%s %s: %v\n", style.Error.Render("✗"), r.Name, err)
+			failed++
+		}
+		for _, c := range connectors {
+			if c.Connector.Name() != name {
+				continue
+			}
+			res, err := tracker.SyncRig(context.Background(), r.Path, c, syncDryRun)
+			if err != nil {
+				fmt.Printf("%s %s: %v\n", style.Error.Render("✗"), r.Name, err)
+				failed++
+				continue
+			}
+			synced++
+			printTrackerSyncResult(r.Name, res)
+		}
+	}
+
+	if synced == 0 && failed == 0 {
+		if syncRig != "" {
+			return fmt.Errorf("rig %s has no %s sync configured", syncRig, name)
+		}
+		fmt.Printf("%s\n", style.Dim.Render(fmt.Sprintf("No rigs have %s sync configured", name)))
+		return nil
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d rig(s) failed to sync", failed)
+	}
+	return nil
+}
+
+func printTrackerSyncResult(rigName string, res tracker.Result) {
+	verb := "synced"
+	if syncDryRun {
+		verb = "would sync"
+	}
+	fmt.Printf("%s %s %s: %d created, %d imported, %d pushed, %d pulled\n",
+		style.Success.Render("✓"), rigName, verb, res.Created, res.Imported, res.Pushed, res.Pulled)
+	if len(res.Conflicts) > 0 {
+		fmt.Printf("  %s conflicts (bead kept): %s\n", style.Warning.Render("!"), strings.Join(res.Conflicts, ", "))
+	}
+}
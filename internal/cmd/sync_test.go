@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/tracker"
+)
+
+// namedConnector is a tracker.Connector that only knows its name.
+type namedConnector struct {
+	tracker.Connector
+	name string
+}
+
+func (c namedConnector) Name() string { return c.name }
+
+func TestRunSyncJobs_BoundedAndOrdered(t *testing.T) {
+	var jobs []syncJob
+	for i := 0; i < 10; i++ {
+		jobs = append(jobs, syncJob{
+			rig:       &rig.Rig{Name: fmt.Sprintf("rig%d", i)},
+			connector: tracker.Configured{Connector: namedConnector{name: "github"}},
+		})
+	}
+
+	var running, peak atomic.Int32
+	results := runSyncJobs(jobs, 3, func(j syncJob) (tracker.Result, error) {
+		n := running.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		running.Add(-1)
+		if j.rig.Name == "rig4" {
+			return tracker.Result{}, errors.New("boom")
+		}
+		return tracker.Result{Pushed: 1}, nil
+	})
+
+	if peak.Load() > 3 {
+		t.Errorf("peak concurrency = %d, want at most 3", peak.Load())
+	}
+	if len(results) != len(jobs) {
+		t.Fatalf("got %d results, want %d", len(results), len(jobs))
+	}
+	for i, r := range results {
+		if r.rig != jobs[i].rig.Name || r.connector != "github" {
+			t.Errorf("results[%d] = %+v, want rig %s", i, r, jobs[i].rig.Name)
+		}
+		if (r.err != nil) != (r.rig == "rig4") {
+			t.Errorf("results[%d].err = %v", i, r.err)
+		}
+	}
+}
+
+func TestPrintDoltSyncAllReport_ReportsEachDatabase(t *testing.T) {
+	results := runPool([]string{"hq", "gastown", "beads"}, 2, func(db string) doltserver.SyncResult {
+		switch db {
+		case "gastown":
+			return doltserver.SyncResult{Database: db, Error: errors.New("boom")}
+		case "beads":
+			return doltserver.SyncResult{Database: db, Skipped: true}
+		}
+		return doltserver.SyncResult{Database: db, Pushed: true, Pulled: true}
+	})
+	if results[0].Database != "hq" || results[1].Database != "gastown" || results[2].Database != "beads" {
+		t.Fatalf("results out of order: %+v", results)
+	}
+
+	if got := doltSyncStatus(results[0]); !strings.Contains(got, "pushed, merged remote changes") {
+		t.Errorf("status(hq) = %q", got)
+	}
+	if got := doltSyncStatus(results[1]); !strings.Contains(got, "boom") {
+		t.Errorf("status(gastown) = %q", got)
+	}
+	if got := doltSyncStatus(results[2]); !strings.Contains(got, "no remote") {
+		t.Errorf("status(beads) = %q", got)
+	}
+
+	err := printDoltSyncAllReport(results, map[string]string{"hq": "town beads"})
+	if err == nil || !strings.Contains(err.Error(), "1 database(s) failed") {
+		t.Errorf("printDoltSyncAllReport error = %v, want one failure", err)
+	}
+}
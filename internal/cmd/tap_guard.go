@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"regexp"
 	"strings"
 
 	"github.com/spf13/cobra"
@@ -23,6 +24,8 @@ Available guards:
   bd-init            - Block bd init in wrong directories
   mol-patrol         - Block mol patrol from agent contexts
   dangerous-command  - Block rm -rf, force push, hard reset, git clean
+  protected-branch   - Block commits and pushes to the rig's protected branches
+  push               - Check git push targets against rig policy
 
 External guards (standalone scripts, not compiled into gt):
   context-budget   - scripts/guards/context-budget-guard.sh
@@ -152,3 +155,91 @@ func isMaintainerOrigin() bool {
 	// - git@github.com:steveyegge/gastown.git
 	return strings.Contains(url, "steveyegge/gastown")
 }
+
+// shellSeparators splits a command line into its simple commands.
+var shellSeparators = regexp.MustCompile(`&&|\|\||[;|\n]`)
+
+// gitInvocation is one git command found in a shell command line.
+type gitInvocation struct {
+	dir  string   // -C directory, "" for cwd
+	sub  string   // subcommand (commit, push, ...)
+	args []string // arguments after the subcommand
+}
+
+// parseGitInvocations returns the git commands in a shell command line,
+// skipping leading VAR=value assignments and git's global options.
+func parseGitInvocations(command string) []gitInvocation {
+	var invocations []gitInvocation
+	for _, segment := range shellSeparators.Split(command, -1) {
+		fields := strings.Fields(segment)
+		for len(fields) > 0 && strings.Contains(fields[0], "=") && !strings.HasPrefix(fields[0], "-") {
+			fields = fields[1:]
+		}
+		if len(fields) == 0 || fields[0] != "git" {
+			continue
+		}
+
+		dir := ""
+		i := 1
+		for i < len(fields) && strings.HasPrefix(fields[i], "-") {
+			if (fields[i] == "-C" || fields[i] == "-c") && i+1 < len(fields) {
+				if fields[i] == "-C" {
+					dir = fields[i+1]
+				}
+				i++
+			}
+			i++
+		}
+		if i >= len(fields) {
+			continue
+		}
+		invocations = append(invocations, gitInvocation{dir: dir, sub: fields[i], args: fields[i+1:]})
+	}
+	return invocations
+}
+
+// pushRemote returns the remote a git push targets ("" when omitted).
+func (inv gitInvocation) pushRemote() string {
+	for _, a := range inv.args {
+		if !strings.HasPrefix(a, "-") {
+			return a
+		}
+	}
+	return ""
+}
+
+// pushRefspecs returns the refspecs of a git push, after the remote.
+func (inv gitInvocation) pushRefspecs() []string {
+	var positional []string
+	for _, a := range inv.args {
+		if !strings.HasPrefix(a, "-") {
+			positional = append(positional, a)
+		}
+	}
+	if len(positional) < 2 {
+		return nil
+	}
+	return positional[1:]
+}
+
+// pushDestinations returns the branches a git push writes to, as short
+// names. With no refspec that is the current branch; HEAD resolves to it
+// too. currentBranch resolves the branch checked out in a directory.
+func (inv gitInvocation) pushDestinations(currentBranch func(dir string) string) []string {
+	refspecs := inv.pushRefspecs()
+	if len(refspecs) == 0 {
+		return []string{currentBranch(inv.dir)}
+	}
+	dsts := make([]string, 0, len(refspecs))
+	for _, refspec := range refspecs {
+		dst := strings.TrimPrefix(refspec, "+")
+		if idx := strings.LastIndex(dst, ":"); idx >= 0 {
+			dst = dst[idx+1:]
+		}
+		if dst == "HEAD" {
+			dst = currentBranch(inv.dir)
+		}
+		dsts = append(dsts, strings.TrimPrefix(dst, "refs/heads/"))
+	}
+	return dsts
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var tapGuardProtectedBranchCmd = &cobra.Command{
+	Use:   "protected-branch",
+	Short: "Block commits and merges on protected branches",
+	Long: `Block commits and merges on the rig's protected branches via
+Claude Code PreToolUse hooks.
+
+Protected branches are the rig's default branch plus any names or globs in
+branches.protected in <rig>/settings/config.json. Polecats work on their
+own branches and land through the refinery, so a commit on a protected
+branch is always a mistake.
+
+This guard blocks:
+  - git commit / git merge while on a protected branch
+
+Pushes are checked by 'gt tap guard push', which the default hooks run on
+every git push and which applies the same protected-branch policy.
+
+The guard reads the tool input from stdin (Claude Code hook protocol).
+Outside a rig it allows everything.
+
+Exit codes:
+  0 - Operation allowed
+  2 - Operation BLOCKED`,
+	RunE: runTapGuardProtectedBranch,
+}
+
+func init() {
+	tapGuardCmd.AddCommand(tapGuardProtectedBranchCmd)
+}
+
+func runTapGuardProtectedBranch(cmd *cobra.Command, args []string) error {
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil // fail open
+	}
+	command := extractCommand(input)
+	if command == "" {
+		return nil
+	}
+
+	rigPath := guardRigPath()
+	if rigPath == "" {
+		return nil
+	}
+
+	branch, op := protectedBranchViolation(command, rig.LoadBranchPolicy(rigPath), guardCurrentBranch)
+	if branch == "" {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintf(os.Stderr, "❌ BLOCKED: git %s to protected branch %q\n", op, branch)
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Work on your polecat branch and submit with 'gt done';")
+	fmt.Fprintln(os.Stderr, "the refinery lands it on the protected branch for you.")
+	fmt.Fprintln(os.Stderr, "")
+	return NewSilentExit(2) // Exit 2 = BLOCK in Claude Code hooks
+}
+
+// guardRigPath returns the path of the rig the agent works in: GT_RIG if
+// set, else the rig directory containing cwd. Empty outside a rig.
+func guardRigPath() string {
+	townRoot, cwd, _ := workspace.FindFromCwdWithFallback()
+	if townRoot == "" {
+		return ""
+	}
+	if rigName := os.Getenv("GT_RIG"); rigName != "" {
+		return filepath.Join(townRoot, rigName)
+	}
+	rel, err := filepath.Rel(townRoot, cwd)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	rigName := strings.Split(filepath.ToSlash(rel), "/")[0]
+	if rigName == "mayor" || rigName == "deacon" || strings.HasPrefix(rigName, ".") {
+		return ""
+	}
+	return filepath.Join(townRoot, rigName)
+}
+
+// guardCurrentBranch returns the branch checked out in dir ("" for cwd),
+// or "" if it can't tell.
+func guardCurrentBranch(dir string) string {
+	if dir == "" {
+		dir = "."
+	}
+	branch, err := git.NewGit(dir).CurrentBranch()
+	if err != nil {
+		return ""
+	}
+	return branch
+}
+
+// protectedBranchViolation returns the protected branch, and the git
+// operation, that command would commit or push to. currentBranch resolves
+// the branch checked out in a directory (from git -C; "" means cwd).
+// Returns "" when the command is allowed.
+func protectedBranchViolation(command string, policy *rig.BranchPolicy, currentBranch func(dir string) string) (branch, op string) {
+	for _, inv := range parseGitInvocations(command) {
+		switch inv.sub {
+		case "commit", "merge":
+			if b := currentBranch(inv.dir); policy.IsProtected(b) {
+				return b, inv.sub
+			}
+		case "push":
+			for _, dst := range inv.pushDestinations(currentBranch) {
+				if policy.IsProtected(dst) {
+					return dst, inv.sub
+				}
+			}
+		}
+	}
+	return "", ""
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestProtectedBranchViolation(t *testing.T) {
+	policy := &rig.BranchPolicy{Protected: []string{"main", "release/*"}}
+	branches := map[string]string{
+		"":         "polecat/toast-abc",
+		"../mayor": "main",
+	}
+	currentBranch := func(dir string) string { return branches[dir] }
+
+	tests := []struct {
+		name       string
+		command    string
+		wantBranch string
+		wantOp     string
+	}{
+		{"commit on polecat branch", `git commit -m "fix"`, "", ""},
+		{"commit via -C on main", `git -C ../mayor commit -am "fix"`, "main", "commit"},
+		{"merge on main", `cd x && git -C ../mayor merge feature`, "main", "merge"},
+		{"push polecat branch", "git push origin polecat/toast-abc", "", ""},
+		{"push to main", "git push origin main", "main", "push"},
+		{"push HEAD to main", "git push origin HEAD:main", "main", "push"},
+		{"force push full ref", "git push -f origin +HEAD:refs/heads/main", "main", "push"},
+		{"push to release glob", "git add . && git push origin feature:release/2.0", "release/2.0", "push"},
+		{"push with no refspec", "git push", "", ""},
+		{"push with no refspec on main", "git -C ../mayor push", "main", "push"},
+		{"push HEAD on polecat branch", "git push -u origin HEAD", "", ""},
+		{"env prefix", "GIT_TRACE=1 git push origin main", "main", "push"},
+		{"global -c option", "git -c core.hooksPath=/dev/null push origin main", "main", "push"},
+		{"not git", "echo git push origin main", "", ""},
+		{"read-only git", "git log main", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			branch, op := protectedBranchViolation(tt.command, policy, currentBranch)
+			if branch != tt.wantBranch || op != tt.wantOp {
+				t.Errorf("protectedBranchViolation(%q) = (%q, %q), want (%q, %q)", tt.command, branch, op, tt.wantBranch, tt.wantOp)
+			}
+		})
+	}
+}
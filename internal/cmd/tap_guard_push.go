@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+var tapGuardPushCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Check git push targets against rig policy",
+	Long: `Check the remote and branches of a git push against the rig's policy
+via Claude Code PreToolUse hooks.
+
+For every agent this guard blocks:
+  - force pushes (--force, -f, --force-with-lease, +refspec) to a
+    protected branch: the rig's default branch or any branches.protected
+    entry in <rig>/settings/config.json
+
+For polecats (GT_POLECAT set) it also blocks any push to a protected
+branch, forced or not, and any push that isn't to the polecat's own
+branch on origin:
+  - pushes to other remotes or URLs
+  - pushes to any branch other than the one checked out in the worktree
+  - --all, --mirror and --tags
+
+The guard reads the tool input from stdin (Claude Code hook protocol).
+Outside a rig it allows everything.
+
+Exit codes:
+  0 - Operation allowed
+  2 - Operation BLOCKED`,
+	RunE: runTapGuardPush,
+}
+
+func init() {
+	tapGuardCmd.AddCommand(tapGuardPushCmd)
+}
+
+func runTapGuardPush(cmd *cobra.Command, args []string) error {
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return nil // fail open
+	}
+	command := extractCommand(input)
+	if command == "" {
+		return nil
+	}
+
+	rigPath := guardRigPath()
+	if rigPath == "" {
+		return nil
+	}
+
+	polecat := os.Getenv("GT_POLECAT") != ""
+	reason := pushViolation(command, rig.LoadBranchPolicy(rigPath), polecat, guardCurrentBranch)
+	if reason == "" {
+		return nil
+	}
+
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintf(os.Stderr, "❌ BLOCKED: %s\n", reason)
+	if polecat {
+		fmt.Fprintln(os.Stderr, "")
+		fmt.Fprintln(os.Stderr, "Polecats push only their own branch to origin: git push origin HEAD")
+		fmt.Fprintln(os.Stderr, "Submit with 'gt done'; the refinery lands it.")
+	}
+	fmt.Fprintln(os.Stderr, "")
+	return NewSilentExit(2) // Exit 2 = BLOCK in Claude Code hooks
+}
+
+// pushViolation returns why command's git pushes break the rig's policy, or
+// "" if they don't. polecat applies the own-branch rules. currentBranch
+// resolves the branch checked out in a directory ("" means cwd); the
+// polecat's own branch is the one checked out in cwd.
+func pushViolation(command string, policy *rig.BranchPolicy, polecat bool, currentBranch func(dir string) string) string {
+	for _, inv := range parseGitInvocations(command) {
+		if inv.sub != "push" {
+			continue
+		}
+		dsts := inv.pushDestinations(currentBranch)
+
+		if inv.isForcePush() {
+			for _, dst := range dsts {
+				if policy.IsProtected(dst) {
+					return fmt.Sprintf("force-push to protected branch %q", dst)
+				}
+			}
+		}
+
+		if !polecat {
+			continue
+		}
+		for _, dst := range dsts {
+			if policy.IsProtected(dst) {
+				return fmt.Sprintf("push to protected branch %q (the refinery lands polecat work)", dst)
+			}
+		}
+		for _, a := range inv.args {
+			if a == "--all" || a == "--mirror" || a == "--tags" {
+				return fmt.Sprintf("git push %s from a polecat (only its own branch may be pushed)", a)
+			}
+		}
+		if remote := inv.pushRemote(); remote != "" && remote != "origin" {
+			return fmt.Sprintf("push to remote %q (polecats push only to origin)", remote)
+		}
+		own := currentBranch("")
+		if own == "" || own == "HEAD" {
+			continue // detached or unknown: nothing to compare against
+		}
+		for _, dst := range dsts {
+			if dst != own {
+				return fmt.Sprintf("push to %q (polecat's own branch is %q)", dst, own)
+			}
+		}
+	}
+	return ""
+}
+
+// isForcePush reports whether a git push may rewrite remote history.
+func (inv gitInvocation) isForcePush() bool {
+	for _, a := range inv.args {
+		switch {
+		case a == "--force", strings.HasPrefix(a, "--force-with-lease"):
+			return true
+		case strings.HasPrefix(a, "-") && !strings.HasPrefix(a, "--") && strings.Contains(a, "f"):
+			return true // -f, -uf, ...
+		}
+	}
+	for _, refspec := range inv.pushRefspecs() {
+		if strings.HasPrefix(refspec, "+") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/rig"
+)
+
+func TestPushViolation(t *testing.T) {
+	policy := &rig.BranchPolicy{Protected: []string{"main", "release/*"}}
+	branches := map[string]string{
+		"":         "polecat/toast-abc",
+		"../mayor": "main",
+	}
+	currentBranch := func(dir string) string { return branches[dir] }
+
+	tests := []struct {
+		name    string
+		command string
+		polecat bool
+		want    string // substring of the reason; "" means allowed
+	}{
+		// Force pushes, every agent
+		{"crew push main", "git push origin main", false, ""},
+		{"crew force main", "git push --force origin main", false, "force-push"},
+		{"crew force with lease main", "git push --force-with-lease origin main", false, "force-push"},
+		{"crew plus refspec main", "git push origin +HEAD:main", false, "force-push"},
+		{"crew short flag cluster", "git -C ../mayor push -uf", false, "force-push"},
+		{"crew force release glob", "git push -f origin feature:release/1.0", false, "force-push"},
+		{"crew force feature", "git push -f origin feature", false, ""},
+
+		// Polecat own-branch rules
+		{"polecat own branch", "git push origin polecat/toast-abc", true, ""},
+		{"polecat HEAD", "git push -u origin HEAD", true, ""},
+		{"polecat bare push", "git push", true, ""},
+		{"polecat force own branch", "git push --force-with-lease origin HEAD", true, ""},
+		{"polecat other branch", "git push origin polecat/nux-xyz", true, "own branch"},
+		{"polecat main", "git add . && git commit -m x && git push origin HEAD:main", true, "protected branch"},
+		{"polecat delete", "git push origin :polecat/nux-xyz", true, "own branch"},
+		{"polecat fork remote", "git push fork HEAD", true, "remote \"fork\""},
+		{"polecat url remote", "git push https://example.com/r.git HEAD", true, "remote"},
+		{"polecat all", "git push --all origin", true, "--all"},
+		{"polecat tags", "git push origin --tags", true, "--tags"},
+		{"not a push", "git log origin/main", true, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pushViolation(tt.command, policy, tt.polecat, currentBranch)
+			if tt.want == "" && got != "" {
+				t.Errorf("pushViolation(%q) = %q, want allowed", tt.command, got)
+			}
+			if tt.want != "" && !strings.Contains(got, tt.want) {
+				t.Errorf("pushViolation(%q) = %q, want reason containing %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPushViolation_DetachedPolecat(t *testing.T) {
+	policy := &rig.BranchPolicy{Protected: []string{"main", "release/*"}}
+	detached := func(dir string) string { return "HEAD" }
+
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{"HEAD to main", "git push origin HEAD:main", "protected branch \"main\""},
+		{"release glob", "git push origin HEAD:release/1.0", "protected branch"},
+		{"feature branch", "git push origin HEAD:polecat/toast-abc", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pushViolation(tt.command, policy, true, detached)
+			if tt.want == "" && got != "" {
+				t.Errorf("pushViolation(%q) = %q, want allowed", tt.command, got)
+			}
+			if tt.want != "" && !strings.Contains(got, tt.want) {
+				t.Errorf("pushViolation(%q) = %q, want reason containing %q", tt.command, got, tt.want)
+			}
+		})
+	}
+}
@@ -61,6 +61,22 @@ func runTapList(cmd *cobra.Command, args []string) error {
 			matchers:    []string{"Bash(sudo *)", "Bash(apt install*)", "Bash(dnf install*)", "Bash(brew install*)", "Bash(rm -rf /*)", "Bash(git push --force*)", "Bash(git push -f*)"},
 			implemented: true,
 		},
+		{
+			name:        "protected-branch",
+			kind:        "guard",
+			description: "Block polecat commits and merges on protected branches",
+			event:       "PreToolUse",
+			matchers:    []string{"Bash(*git commit*)", "Bash(*git merge*)"},
+			implemented: true,
+		},
+		{
+			name:        "push",
+			kind:        "guard",
+			description: "Block force pushes to protected branches and polecat pushes outside their own branch",
+			event:       "PreToolUse",
+			matchers:    []string{"Bash(*git push*)"},
+			implemented: true,
+		},
 	}
 
 	// Try to load registry for additional handlers
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+)
+
+// runTownHooks runs the town.json hooks for event, printing each hook's
+// outcome. Returns an error if a required hook failed; other failures are
+// printed as warnings.
+func runTownHooks(townRoot, event string) error {
+	results := session.RunTownHooks(townRoot, event)
+	if len(results) == 0 {
+		return nil
+	}
+	fmt.Printf("Running %s hooks...\n", event)
+	var requiredFailed int
+	for _, r := range results {
+		switch {
+		case r.Err == nil:
+			fmt.Printf("  %s %s\n", style.Bold.Render("✓"), r.Hook.Command)
+		case r.Hook.Required:
+			requiredFailed++
+			fmt.Printf("  %s %v\n", style.Error.Render("✗"), r.Err)
+		default:
+			fmt.Printf("  %s %v\n", style.Warning.Render("⚠"), r.Err)
+		}
+	}
+	fmt.Println()
+	if requiredFailed > 0 {
+		return fmt.Errorf("%d required %s hook(s) failed", requiredFailed, event)
+	}
+	return nil
+}
+
+// startWithTownHooks brings the town up with start, wrapped in the
+// pre_start and post_start hooks. A failed required pre_start hook aborts
+// before start runs; post_start runs whether or not start succeeded.
+// Shared by gt start and gt restart.
+func startWithTownHooks(townRoot string, start func() error) error {
+	if err := runTownHooks(townRoot, session.TownHookPreStart); err != nil {
+		return fmt.Errorf("not starting: %w", err)
+	}
+	err := start()
+	_ = runTownHooks(townRoot, session.TownHookPostStart)
+	return err
+}
+
+// stopWithTownHooks takes the whole town down with stop, wrapped in the
+// pre_shutdown and post_shutdown hooks. A failed required pre_shutdown hook
+// aborts before stop runs. Shared by gt shutdown and gt restart; scoped
+// shutdowns leave the town running and skip the hooks.
+func stopWithTownHooks(townRoot string, stop func() error) error {
+	if err := runTownHooks(townRoot, session.TownHookPreShutdown); err != nil {
+		return fmt.Errorf("not shutting down: %w", err)
+	}
+	err := stop()
+	_ = runTownHooks(townRoot, session.TownHookPostShutdown)
+	return err
+}
+
+// printTownHooks lists the town.json hooks for event without running them.
+func printTownHooks(townRoot, event string) {
+	hooks := session.TownHooks(townRoot, event)
+	if len(hooks) == 0 {
+		return
+	}
+	fmt.Printf("%s hooks would run:\n", event)
+	for _, h := range hooks {
+		fmt.Printf("  %s %s\n", style.Dim.Render("→"), h.Command)
+	}
+	fmt.Println()
+}
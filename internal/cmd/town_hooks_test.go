@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func writeTownHooksConfig(t *testing.T, hooks *config.TownHooksConfig) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	if err := config.SaveTownConfig(filepath.Join(townRoot, "mayor", "town.json"), &config.TownConfig{
+		Type:      "town",
+		Version:   config.CurrentTownVersion,
+		Name:      "test",
+		CreatedAt: time.Now(),
+		Hooks:     hooks,
+	}); err != nil {
+		t.Fatalf("SaveTownConfig: %v", err)
+	}
+	return townRoot
+}
+
+func TestStartWithTownHooks_WrapsStart(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run with sh -c")
+	}
+	townRoot := writeTownHooksConfig(t, &config.TownHooksConfig{
+		PreStart:  []config.TownHook{{Command: "echo pre >> order.out"}},
+		PostStart: []config.TownHook{{Command: "echo post >> order.out"}},
+	})
+	orderPath := filepath.Join(townRoot, "order.out")
+
+	err := startWithTownHooks(townRoot, func() error {
+		f, err := os.OpenFile(orderPath, os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = f.WriteString("start\n")
+		return err
+	})
+	if err != nil {
+		t.Fatalf("startWithTownHooks: %v", err)
+	}
+	data, _ := os.ReadFile(orderPath)
+	if got := strings.Fields(string(data)); strings.Join(got, ",") != "pre,start,post" {
+		t.Errorf("order = %v, want pre,start,post", got)
+	}
+}
+
+func TestStopWithTownHooks_RequiredPreHookAborts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run with sh -c")
+	}
+	townRoot := writeTownHooksConfig(t, &config.TownHooksConfig{
+		PreShutdown:  []config.TownHook{{Command: "exit 1", Required: true}},
+		PostShutdown: []config.TownHook{{Command: "touch post.out"}},
+	})
+
+	stopped := false
+	err := stopWithTownHooks(townRoot, func() error {
+		stopped = true
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "not shutting down") {
+		t.Errorf("stopWithTownHooks error = %v, want required hook failure", err)
+	}
+	if stopped {
+		t.Error("stop ran despite a failed required pre_shutdown hook")
+	}
+	if _, err := os.Stat(filepath.Join(townRoot, "post.out")); err == nil {
+		t.Error("post_shutdown hooks ran for an aborted shutdown")
+	}
+}
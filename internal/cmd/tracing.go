@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/logging"
+	"github.com/steveyegge/gastown/internal/telemetry"
+)
+
+// endCommandSpan ends the command's span, set by setupTracing.
+var endCommandSpan = func(error) {}
+
+// shutdownTracing flushes and stops the tracer provider, set by setupTracing.
+var shutdownTracing = func() {}
+
+// setupTracing starts the command's span when town.json configures
+// tracing. Best-effort: a bad tracing config is logged, never fatal.
+// Per-tool-use commands (noLogCommands) aren't traced; flushing spans on
+// every hook call would slow each tool call down.
+func setupTracing(cmd *cobra.Command, townRoot string) {
+	if townRoot == "" || noLogCommands[topLevelCommand(cmd).Name()] {
+		return
+	}
+	townCfg, err := config.LoadTownConfig(constants.MayorTownPath(townRoot))
+	if err != nil || !townCfg.Tracing.Enabled() {
+		return
+	}
+	tc := townCfg.Tracing
+	provider, err := telemetry.InitTracing(context.Background(), telemetry.TracingOptions{
+		Endpoint:    tc.Endpoint,
+		Headers:     tc.Headers,
+		SampleRatio: tc.Ratio(),
+	}, "gastown", Version)
+	if err != nil {
+		logging.Component("telemetry").Warn("tracing init failed", "endpoint", tc.Endpoint, "err", err)
+		return
+	}
+	if provider == nil {
+		return
+	}
+	shutdownTracing = func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		_ = provider.Shutdown(ctx)
+	}
+
+	ctx, end := telemetry.StartCommandSpan(buildCommandPath(cmd))
+	cmd.SetContext(ctx)
+	endCommandSpan = end
+}
+
+// finishTracing ends the command span with the command's outcome and
+// flushes spans. Silent exits with a non-zero code count as errors.
+func finishTracing(err error) {
+	if code, ok := IsSilentExit(err); ok {
+		err = nil
+		if code != 0 {
+			err = fmt.Errorf("exit status %d", code)
+		}
+	}
+	endCommandSpan(err)
+	shutdownTracing()
+}
+
+// topLevelCommand returns the subcommand of root that cmd belongs to.
+func topLevelCommand(cmd *cobra.Command) *cobra.Command {
+	for cmd.Parent() != nil && cmd.Parent().Parent() != nil {
+		cmd = cmd.Parent()
+	}
+	return cmd
+}
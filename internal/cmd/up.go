@@ -709,10 +709,15 @@ func startRigAgentsWithPrefetch(rigNames []string, prefetchedRigs map[string]*ri
 }
 
 // upStartWitness starts a witness for the given rig and returns a result struct.
-// Respects parked/docked status - skips starting if rig is not operational.
+// Respects disabled/parked/docked status - skips starting if rig is not operational.
 func upStartWitness(rigName string, r *rig.Rig) agentStartResult {
 	name := "Witness (" + rigName + ")"
 
+	// A rig disabled for maintenance is skipped even with auto_start_on_up.
+	if disabled, _ := config.IsRigDisabled(filepath.Dir(r.Path), rigName); disabled {
+		return agentStartResult{name: name, ok: true, detail: "skipped (rig disabled)"}
+	}
+
 	// Check if rig is parked or docked (wisp + bead labels).
 	// Skip the check if auto_start_on_up is set — that overrides dock status.
 	// Also check deprecated auto_start_on_boot for backwards compatibility with
@@ -735,10 +740,15 @@ func upStartWitness(rigName string, r *rig.Rig) agentStartResult {
 }
 
 // upStartRefinery starts a refinery for the given rig and returns a result struct.
-// Respects parked/docked status - skips starting if rig is not operational.
+// Respects disabled/parked/docked status - skips starting if rig is not operational.
 func upStartRefinery(rigName string, r *rig.Rig) agentStartResult {
 	name := "Refinery (" + rigName + ")"
 
+	// A rig disabled for maintenance is skipped even with auto_start_on_up.
+	if disabled, _ := config.IsRigDisabled(filepath.Dir(r.Path), rigName); disabled {
+		return agentStartResult{name: name, ok: true, detail: "skipped (rig disabled)"}
+	}
+
 	// Check if rig is parked or docked (wisp + bead labels).
 	// Skip the check if auto_start_on_up is set — that overrides dock status.
 	// Also check deprecated auto_start_on_boot for backwards compatibility with
@@ -20,6 +20,11 @@ var (
 	upgradeDryRun  bool
 	upgradeVerbose bool
 	upgradeNoStart bool
+
+	upgradeBinary    string
+	upgradeSource    string
+	upgradeDrainWait int
+	upgradeNoRestart bool
 )
 
 var upgradeCmd = &cobra.Command{
@@ -31,19 +36,34 @@ var upgradeCmd = &cobra.Command{
 This is the user-facing entry point for upgrading Gas Town after installing
 a new binary. It orchestrates all migration steps in the right order:
 
-  1. Structural checks   Run gt doctor --fix to repair workspace structure
-  2. CLAUDE.md sync       Update town root CLAUDE.md from embedded template
-  3. Daemon defaults      Ensure daemon.json has lifecycle defaults
-  4. Hooks sync           Regenerate settings.json from hook registry
-  5. Formula update       Update formulas from embedded copies
+  1. Config migrations   Upgrade town.json, rigs.json, quota.json schemas
+                          (see gt config migrate)
+  2. Structural checks   Run gt doctor --fix to repair workspace structure
+  3. CLAUDE.md sync       Update town root CLAUDE.md from embedded template
+  4. Daemon defaults      Ensure daemon.json has lifecycle defaults
+  5. Hooks sync           Regenerate settings.json from hook registry
+  6. Formula update       Update formulas from embedded copies
 
 Each step reports what changed. Use --dry-run to preview without modifying.
 
+With --binary or --source, gt upgrade also installs the new binary, so
+agents never run a mix of old and new tooling:
+
+  1. Stage     Build (--source) or fetch (--binary) the new gt and check it runs
+  2. Drain     Ask every agent to hand off, then stop the town. Polecat
+               worktrees are kept so in-flight work survives
+  3. Swap      Replace the running gt (the old one is kept as gt.old)
+  4. Migrate   Run the migration steps above with the new binary
+  5. Restart   Start the daemon and 'gt start --resume-last'
+
 Examples:
   gt upgrade                  # Run all migration steps
   gt upgrade --dry-run        # Show what would change
   gt upgrade --verbose        # Show detailed output
-  gt upgrade --no-start       # Suppress starting daemon during doctor fix`,
+  gt upgrade --no-start       # Suppress starting daemon during doctor fix
+  gt upgrade --source ~/src/gastown        # Build, drain, swap, restart
+  gt upgrade --binary https://example.com/gt-linux-amd64
+  gt upgrade --binary ./gt --no-restart    # Leave the town stopped after`,
 	RunE:         runUpgrade,
 	SilenceUsage: true,
 }
@@ -52,6 +72,10 @@ func init() {
 	upgradeCmd.Flags().BoolVar(&upgradeDryRun, "dry-run", false, "Show what would change without modifying anything")
 	upgradeCmd.Flags().BoolVarP(&upgradeVerbose, "verbose", "v", false, "Show detailed output")
 	upgradeCmd.Flags().BoolVar(&upgradeNoStart, "no-start", false, "Suppress starting daemon/agents during doctor fix")
+	upgradeCmd.Flags().StringVar(&upgradeBinary, "binary", "", "Install this gt binary (path or http(s) URL), draining agents around the swap")
+	upgradeCmd.Flags().StringVar(&upgradeSource, "source", "", "Build gt from this gastown checkout and install it, draining agents around the swap")
+	upgradeCmd.Flags().IntVar(&upgradeDrainWait, "wait", 60, "Seconds to wait for agents to hand off before the binary swap")
+	upgradeCmd.Flags().BoolVar(&upgradeNoRestart, "no-restart", false, "Leave the town stopped after a binary upgrade")
 	rootCmd.AddCommand(upgradeCmd)
 }
 
@@ -69,6 +93,10 @@ func runUpgrade(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("not in a Gas Town workspace: %w", err)
 	}
 
+	if upgradeBinary != "" || upgradeSource != "" {
+		return runBinaryUpgrade(townRoot)
+	}
+
 	if upgradeDryRun {
 		fmt.Printf("\n%s Dry run — showing what would change\n", style.Bold.Render("gt upgrade"))
 	} else {
@@ -77,37 +105,64 @@ func runUpgrade(cmd *cobra.Command, args []string) error {
 
 	var results []upgradeResult
 
-	// Step 1: Run doctor --fix for structural checks
-	r1 := upgradeDoctor(townRoot)
+	// Step 1: Migrate config files first, so later steps load current schemas
+	r1 := upgradeConfigMigrations(townRoot)
 	results = append(results, r1)
 
-	// Step 2: Sync CLAUDE.md from embedded template
-	r2 := upgradeCLAUDEMD(townRoot)
+	// Step 2: Run doctor --fix for structural checks
+	r2 := upgradeDoctor(townRoot)
 	results = append(results, r2)
 
-	// Step 3: Ensure daemon.json lifecycle defaults
-	r3 := upgradeDaemonConfig(townRoot)
+	// Step 3: Sync CLAUDE.md from embedded template
+	r3 := upgradeCLAUDEMD(townRoot)
 	results = append(results, r3)
 
-	// Step 4: Sync hooks registry to settings.json
-	r4 := upgradeHooksSync(townRoot)
+	// Step 4: Ensure daemon.json lifecycle defaults
+	r4 := upgradeDaemonConfig(townRoot)
 	results = append(results, r4)
 
-	// Step 5: Update formulas from embedded copies
-	r5 := upgradeFormulas(townRoot)
+	// Step 5: Sync hooks registry to settings.json
+	r5 := upgradeHooksSync(townRoot)
 	results = append(results, r5)
 
+	// Step 6: Update formulas from embedded copies
+	r6 := upgradeFormulas(townRoot)
+	results = append(results, r6)
+
 	// Print summary
 	printUpgradeSummary(results)
 
 	return nil
 }
 
+// upgradeConfigMigrations brings versioned config files to their current
+// schema versions.
+func upgradeConfigMigrations(townRoot string) upgradeResult {
+	result := upgradeResult{step: "Config migrations"}
+
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("1."), "Migrating config files...")
+
+	migrated, err := config.MigrateTown(townRoot, config.MigrateOptions{DryRun: upgradeDryRun})
+	if migrated != nil {
+		printMigrationSteps(migrated, upgradeDryRun, "     ")
+		result.changed = len(migrated.Steps)
+	}
+	if err != nil {
+		result.details = append(result.details, fmt.Sprintf("error: %v", err))
+		fmt.Printf("     %s %v\n", style.ErrorPrefix, err)
+		return result
+	}
+	if result.changed == 0 {
+		fmt.Printf("     %s Config files %s\n", style.SuccessPrefix, style.Dim.Render("up-to-date"))
+	}
+	return result
+}
+
 // upgradeDoctor runs doctor --fix and returns the result.
 func upgradeDoctor(townRoot string) upgradeResult {
 	result := upgradeResult{step: "Structural checks"}
 
-	fmt.Printf("\n  %s %s\n", style.Bold.Render("1."), "Running structural checks (doctor --fix)...")
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("2."), "Running structural checks (doctor --fix)...")
 
 	ctx := &doctor.CheckContext{
 		TownRoot: townRoot,
@@ -181,7 +236,7 @@ func upgradeDoctor(townRoot string) upgradeResult {
 func upgradeCLAUDEMD(townRoot string) upgradeResult {
 	result := upgradeResult{step: "CLAUDE.md sync"}
 
-	fmt.Printf("\n  %s %s\n", style.Bold.Render("2."), "Syncing CLAUDE.md from template...")
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("3."), "Syncing CLAUDE.md from template...")
 
 	expected := generateCLAUDEMD()
 	claudePath := filepath.Join(townRoot, "CLAUDE.md")
@@ -254,7 +309,7 @@ Your role is set by the GT_ROLE environment variable and injected by ` + "`" + c
 func upgradeDaemonConfig(townRoot string) upgradeResult {
 	result := upgradeResult{step: "Daemon config"}
 
-	fmt.Printf("\n  %s %s\n", style.Bold.Render("3."), "Ensuring daemon.json lifecycle defaults...")
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("4."), "Ensuring daemon.json lifecycle defaults...")
 
 	daemonPath := config.DaemonPatrolConfigPath(townRoot)
 
@@ -299,7 +354,7 @@ func upgradeDaemonConfig(townRoot string) upgradeResult {
 func upgradeHooksSync(townRoot string) upgradeResult {
 	result := upgradeResult{step: "Hooks sync"}
 
-	fmt.Printf("\n  %s %s\n", style.Bold.Render("4."), "Syncing hooks to settings.json...")
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("5."), "Syncing hooks to settings.json...")
 
 	targets, err := hooks.DiscoverTargets(townRoot)
 	if err != nil {
@@ -389,7 +444,7 @@ func upgradeHooksSync(townRoot string) upgradeResult {
 func upgradeFormulas(townRoot string) upgradeResult {
 	result := upgradeResult{step: "Formulas"}
 
-	fmt.Printf("\n  %s %s\n", style.Bold.Render("5."), "Updating formulas from embedded copies...")
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("6."), "Updating formulas from embedded copies...")
 
 	if upgradeDryRun {
 		// In dry-run mode, just check health
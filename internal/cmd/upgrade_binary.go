@@ -0,0 +1,289 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// upgradeDownloadTimeout bounds fetching a new binary over HTTP.
+const upgradeDownloadTimeout = 5 * time.Minute
+
+// upgradeBinarySource describes where --binary or --source gets the new gt.
+func upgradeBinarySource() string {
+	if upgradeSource != "" {
+		return "build from " + upgradeSource
+	}
+	if isUpgradeURL(upgradeBinary) {
+		return "download " + upgradeBinary
+	}
+	return "copy " + upgradeBinary
+}
+
+func isUpgradeURL(s string) bool {
+	return strings.HasPrefix(s, "https://") || strings.HasPrefix(s, "http://")
+}
+
+// currentBinaryPath returns the path of the running gt with symlinks
+// resolved, which is the file the upgrade replaces.
+func currentBinaryPath() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("locating gt binary: %w", err)
+	}
+	resolved, err := filepath.EvalSymlinks(exe)
+	if err != nil {
+		return "", fmt.Errorf("resolving gt binary: %w", err)
+	}
+	return resolved, nil
+}
+
+// runBinaryUpgrade installs a new gt binary without letting agents run
+// mixed-version tooling: the new binary is staged and verified first, the
+// town is drained with a graceful handoff, the binary is swapped, the
+// workspace migrations run under the new binary, and the drained sessions
+// are resumed with it.
+func runBinaryUpgrade(townRoot string) error {
+	if upgradeBinary != "" && upgradeSource != "" {
+		return fmt.Errorf("--binary and --source are mutually exclusive")
+	}
+
+	target, err := currentBinaryPath()
+	if err != nil {
+		return err
+	}
+
+	t := tmux.NewTmux()
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return fmt.Errorf("listing sessions: %w", err)
+	}
+	var running []string
+	for _, sess := range sessions {
+		if session.IsKnownSession(sess) {
+			running = append(running, sess)
+		}
+	}
+
+	fmt.Printf("\n%s Binary upgrade\n\n", style.Bold.Render("gt upgrade"))
+	fmt.Printf("  New binary:  %s\n", upgradeBinarySource())
+	fmt.Printf("  Replaces:    %s\n", target)
+	fmt.Printf("  Drain:       %d session(s), waiting up to %ds for handoff\n", len(running), upgradeDrainWait)
+
+	if upgradeDryRun {
+		for _, sess := range running {
+			fmt.Printf("    %s %s\n", style.Dim.Render("→"), sess)
+		}
+		fmt.Printf("\n  %s Dry run — nothing was changed\n\n", style.WarningPrefix)
+		return nil
+	}
+
+	// Stage next to the target so the swap is a same-filesystem rename.
+	staged := target + ".new"
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("1."), "Staging new binary...")
+	if err := stageUpgradeBinary(staged); err != nil {
+		_ = os.Remove(staged)
+		return err
+	}
+	version, err := verifyUpgradeBinary(staged)
+	if err != nil {
+		_ = os.Remove(staged)
+		return err
+	}
+	fmt.Printf("     %s %s\n", style.SuccessPrefix, version)
+
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("2."), "Draining agents...")
+	if len(running) == 0 {
+		fmt.Printf("     %s %s\n", style.SuccessPrefix, style.Dim.Render("no sessions running"))
+	} else if err := drainForUpgrade(t, townRoot, running); err != nil {
+		_ = os.Remove(staged)
+		return err
+	}
+
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("3."), "Swapping binary...")
+	backup, err := swapUpgradeBinary(staged, target)
+	if err != nil {
+		return fmt.Errorf("%w (the town is stopped; 'gt start --resume-last' brings it back on the old binary)", err)
+	}
+	fmt.Printf("     %s %s %s\n", style.SuccessPrefix, target, style.Dim.Render("(previous binary kept at "+backup+")"))
+
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("4."), "Running migrations with the new binary...")
+	migrateArgs := []string{"upgrade", "--no-start"}
+	if upgradeVerbose {
+		migrateArgs = append(migrateArgs, "--verbose")
+	}
+	if err := runUpgradedBinary(target, townRoot, migrateArgs...); err != nil {
+		style.PrintWarning("migrations failed: %v (run 'gt upgrade' to retry)", err)
+	}
+
+	if len(running) == 0 || upgradeNoRestart {
+		fmt.Printf("\n%s Upgrade complete; town left stopped (run 'gt start --resume-last' to bring it back)\n\n", style.Bold.Render("✓"))
+		return nil
+	}
+
+	fmt.Printf("\n  %s %s\n", style.Bold.Render("5."), "Restarting the town...")
+	if err := runUpgradedBinary(target, townRoot, "daemon", "start"); err != nil {
+		style.PrintWarning("could not start daemon: %v", err)
+	}
+	if err := runUpgradedBinary(target, townRoot, "start", "--resume-last"); err != nil {
+		return fmt.Errorf("restarting town: %w", err)
+	}
+
+	fmt.Printf("\n%s Upgrade complete\n\n", style.Bold.Render("✓"))
+	return nil
+}
+
+// stageUpgradeBinary writes the new gt to staged from --source, an HTTP
+// URL, or a local path.
+func stageUpgradeBinary(staged string) error {
+	switch {
+	case upgradeSource != "":
+		return buildUpgradeBinary(upgradeSource, staged)
+	case isUpgradeURL(upgradeBinary):
+		return downloadUpgradeBinary(upgradeBinary, staged)
+	default:
+		return copyUpgradeBinary(upgradeBinary, staged)
+	}
+}
+
+// buildUpgradeBinary runs 'make build' in a gastown checkout, so the
+// binary gets the same ldflags as 'make install', and stages the result.
+func buildUpgradeBinary(sourceDir, staged string) error {
+	if _, err := os.Stat(filepath.Join(sourceDir, "Makefile")); err != nil {
+		return fmt.Errorf("%s is not a gastown checkout (no Makefile)", sourceDir)
+	}
+	cmd := exec.Command("make", "build")
+	cmd.Dir = sourceDir
+	if upgradeVerbose {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("building gt: %w", err)
+		}
+	} else if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("building gt: %w\n%s", err, strings.TrimSpace(string(out)))
+	}
+	return copyUpgradeBinary(filepath.Join(sourceDir, "gt"), staged)
+}
+
+func downloadUpgradeBinary(url, staged string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), upgradeDownloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fmt.Errorf("downloading gt: %w", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("downloading gt: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("downloading gt: %s", resp.Status)
+	}
+	return writeUpgradeBinary(resp.Body, staged)
+}
+
+func copyUpgradeBinary(src, staged string) error {
+	f, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("reading new binary: %w", err)
+	}
+	defer f.Close()
+	return writeUpgradeBinary(f, staged)
+}
+
+func writeUpgradeBinary(r io.Reader, staged string) error {
+	f, err := os.OpenFile(staged, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+	if err != nil {
+		return fmt.Errorf("staging new binary: %w", err)
+	}
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return fmt.Errorf("staging new binary: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("staging new binary: %w", err)
+	}
+	return nil
+}
+
+// verifyUpgradeBinary runs 'version' on the staged binary so a corrupt or
+// wrong-platform download is caught before any agent is drained.
+func verifyUpgradeBinary(path string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "version").CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("new binary failed to run 'version': %w\n%s", err, strings.TrimSpace(string(out)))
+	}
+	version := strings.TrimSpace(string(out))
+	if i := strings.IndexByte(version, '\n'); i >= 0 {
+		version = version[:i]
+	}
+	return version, nil
+}
+
+// drainForUpgrade gracefully stops every session, recording them in the
+// shutdown manifest first. Unlike 'gt shutdown --graceful' it leaves polecat
+// worktrees in place, so in-flight polecat work resumes on the new binary
+// instead of being cleaned up.
+func drainForUpgrade(t *tmux.Tmux, townRoot string, sessions []string) error {
+	if err := runTownHooks(townRoot, session.TownHookPreShutdown); err != nil {
+		return fmt.Errorf("not upgrading: %w", err)
+	}
+
+	m, err := writeShutdownManifest(t, townRoot, sessions)
+	if err != nil {
+		return fmt.Errorf("recording sessions before drain: %w", err)
+	}
+	fmt.Printf("     %s Recorded %d session(s) for resume\n", style.Dim.Render("○"), len(m.Sessions))
+
+	msg := "[UPGRADE] Gas Town is upgrading gt. Please save your state and update your handoff bead, then type /exit or wait to be terminated. You will be resumed on the new version."
+	requestHandoffAndWait(t, townRoot, sessions, msg, upgradeDrainWait)
+	killSessionsInOrder(t, sessions, getMayorSessionName(), getDeaconSessionName())
+	stopHeadlessAgents(townRoot)
+	cleanupOrphanedClaude(defaultOrphanGraceSecs)
+	stopDaemonIfRunning(townRoot)
+
+	_ = runTownHooks(townRoot, session.TownHookPostShutdown)
+	return nil
+}
+
+// swapUpgradeBinary moves target aside to target.old and renames staged
+// into its place, restoring the old binary if the second rename fails.
+// Returns the backup path.
+func swapUpgradeBinary(staged, target string) (string, error) {
+	backup := target + ".old"
+	if err := os.Rename(target, backup); err != nil {
+		return "", fmt.Errorf("moving old binary aside: %w", err)
+	}
+	if err := os.Rename(staged, target); err != nil {
+		if restoreErr := os.Rename(backup, target); restoreErr != nil {
+			return "", fmt.Errorf("installing new binary: %w (restoring old binary also failed: %v)", err, restoreErr)
+		}
+		return "", fmt.Errorf("installing new binary: %w", err)
+	}
+	return backup, nil
+}
+
+// runUpgradedBinary runs the freshly installed gt in townRoot, streaming
+// its output.
+func runUpgradedBinary(path, townRoot string, args ...string) error {
+	cmd := exec.Command(path, args...)
+	cmd.Dir = townRoot
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Stdin = os.Stdin
+	return cmd.Run()
+}
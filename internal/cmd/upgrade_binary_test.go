@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSwapUpgradeBinary(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "gt")
+	staged := target + ".new"
+	if err := os.WriteFile(target, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(staged, []byte("new"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	backup, err := swapUpgradeBinary(staged, target)
+	if err != nil {
+		t.Fatalf("swapUpgradeBinary: %v", err)
+	}
+	if got, _ := os.ReadFile(target); string(got) != "new" {
+		t.Errorf("target = %q, want new binary", got)
+	}
+	if got, _ := os.ReadFile(backup); string(got) != "old" {
+		t.Errorf("backup = %q, want old binary", got)
+	}
+	if _, err := os.Stat(staged); !os.IsNotExist(err) {
+		t.Errorf("staged binary should be gone after swap, stat err = %v", err)
+	}
+}
+
+func TestSwapUpgradeBinary_RestoresOnFailure(t *testing.T) {
+	dir := t.TempDir()
+	target := filepath.Join(dir, "gt")
+	if err := os.WriteFile(target, []byte("old"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := swapUpgradeBinary(filepath.Join(dir, "missing.new"), target); err == nil {
+		t.Fatal("expected error when staged binary is missing")
+	}
+	if got, _ := os.ReadFile(target); string(got) != "old" {
+		t.Errorf("target = %q, want old binary restored", got)
+	}
+}
+
+func TestStageUpgradeBinary_CopiesLocalPath(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "gt-built")
+	if err := os.WriteFile(src, []byte("binary"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	staged := filepath.Join(dir, "gt.new")
+
+	upgradeBinary, upgradeSource = src, ""
+	defer func() { upgradeBinary = "" }()
+
+	if err := stageUpgradeBinary(staged); err != nil {
+		t.Fatalf("stageUpgradeBinary: %v", err)
+	}
+	info, err := os.Stat(staged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("staged binary should be executable, mode %v", info.Mode())
+	}
+}
+
+func TestBuildUpgradeBinary_RequiresCheckout(t *testing.T) {
+	if err := buildUpgradeBinary(t.TempDir(), filepath.Join(t.TempDir(), "gt.new")); err == nil {
+		t.Error("expected error for a directory without a Makefile")
+	}
+}
+
+func TestUpgradeBinarySource(t *testing.T) {
+	defer func() { upgradeBinary, upgradeSource = "", "" }()
+
+	tests := []struct {
+		binary, source, want string
+	}{
+		{"", "/src/gastown", "build from /src/gastown"},
+		{"https://example.com/gt", "", "download https://example.com/gt"},
+		{"./gt", "", "copy ./gt"},
+	}
+	for _, tt := range tests {
+		upgradeBinary, upgradeSource = tt.binary, tt.source
+		if got := upgradeBinarySource(); got != tt.want {
+			t.Errorf("upgradeBinarySource(%q, %q) = %q, want %q", tt.binary, tt.source, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/doctor"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	workspaceDoctorFix     bool
+	workspaceDoctorVerbose bool
+	workspaceDoctorRig     string
+)
+
+var workspaceCmd = &cobra.Command{
+	Use:     "workspace",
+	GroupID: GroupWorkspace,
+	Short:   "Inspect and repair the town's on-disk structure",
+	RunE:    requireSubcommand,
+}
+
+var workspaceDoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the workspace directory layout for drift",
+	Long: `Check the structural integrity of the town on disk, without the
+service, beads and session checks of 'gt doctor'. Fast and safe to run
+when the daemon or Dolt is down.
+
+Checks:
+  - town-config-exists       mayor/town.json exists
+  - town-config-valid        mayor/town.json is valid
+  - rigs-registry-exists     mayor/rigs.json exists (fixable)
+  - rigs-registry-valid      Every rigs.json entry has a directory (fixable)
+  - mayor-exists             mayor/ directory structure
+  - town-location            Stored paths match the town's location (fixable)
+  - rig-layout               Every rig has config.json, mayor/rig, refinery/rig,
+                             witness/, polecats/ and crew/ (fixable)
+  - polecat-worktrees        Every polecat dir is a registered worktree
+  - dangling-worktrees       No worktree registrations for deleted dirs (fixable)
+  - worktree-gitdir-valid    Worktree .git files point at existing paths (fixable)
+  - stale-runtime-files      No PID files or wisp configs for removed rigs (fixable)
+  - orphan-state-files       No lock files for removed polecats/crew (fixable)
+
+Use --fix to repair what can be repaired automatically. Nothing with
+possible unsaved work (polecat or crew directories) is ever removed.
+
+Examples:
+  gt workspace doctor
+  gt workspace doctor --fix
+  gt workspace doctor --rig gastown -v`,
+	SilenceUsage: true,
+	RunE:         runWorkspaceDoctor,
+}
+
+func init() {
+	workspaceDoctorCmd.Flags().BoolVar(&workspaceDoctorFix, "fix", false, "Attempt to automatically fix issues")
+	workspaceDoctorCmd.Flags().BoolVarP(&workspaceDoctorVerbose, "verbose", "v", false, "Show detailed output")
+	workspaceDoctorCmd.Flags().StringVar(&workspaceDoctorRig, "rig", "", "Check specific rig only")
+	workspaceCmd.AddCommand(workspaceDoctorCmd)
+	rootCmd.AddCommand(workspaceCmd)
+}
+
+func runWorkspaceDoctor(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	ctx := &doctor.CheckContext{
+		TownRoot: townRoot,
+		RigName:  workspaceDoctorRig,
+		Verbose:  workspaceDoctorVerbose,
+	}
+
+	d := doctor.NewDoctor()
+	d.RegisterAll(doctor.WorkspaceChecks()...)
+	d.RegisterAll(doctor.WorkspaceLayoutChecks()...)
+
+	fmt.Println()
+	var report *doctor.Report
+	if workspaceDoctorFix {
+		report = d.FixStreaming(ctx, os.Stdout, 0)
+	} else {
+		report = d.RunStreaming(ctx, os.Stdout, 0)
+	}
+	report.PrintSummaryOnly(os.Stdout, workspaceDoctorVerbose, 0)
+
+	if report.HasErrors() {
+		return fmt.Errorf("workspace doctor found %d error(s)", report.Summary.Errors)
+	}
+	return nil
+}
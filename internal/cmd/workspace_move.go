@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/steveyegge/gastown/internal/daemon"
+	"github.com/steveyegge/gastown/internal/doltserver"
+	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+var (
+	workspaceMoveFrom   string
+	workspaceMoveDryRun bool
+)
+
+var workspaceMoveCmd = &cobra.Command{
+	Use:   "move [<new-root>]",
+	Short: "Move the town to a new directory and fix up stored paths",
+	Long: `Move the town directory and rewrite the absolute paths stored inside it.
+
+Several files record the town's absolute path: git worktree links
+(.git files and .repo.git/worktrees/*/gitdir), crew state.json, daemon
+and Dolt state, and account/quota config. After a plain 'mv' they all
+point at the old location and polecats, crew and the refinery break
+without a clear error.
+
+With <new-root>, the town is renamed to that path and then fixed up.
+The new path must not exist. Moves across filesystems are not supported;
+copy the town yourself and use --from instead.
+
+With --from <old-root> and no <new-root>, the town has already been
+moved by hand: paths are rewritten from <old-root> to the current town
+root. 'gt workspace doctor --fix' does the same when it detects a move.
+
+Stop the town first ('gt down'): running sessions keep the old paths.
+
+Examples:
+  gt workspace move ~/work/gt
+  gt workspace move --from /old/place/gt
+  gt workspace move ~/work/gt --dry-run`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE:         runWorkspaceMove,
+}
+
+func init() {
+	workspaceMoveCmd.Flags().StringVar(&workspaceMoveFrom, "from", "", "Old town root, for a town already moved by hand")
+	workspaceMoveCmd.Flags().BoolVar(&workspaceMoveDryRun, "dry-run", false, "Show what would be done without doing it")
+	workspaceCmd.AddCommand(workspaceMoveCmd)
+}
+
+func runWorkspaceMove(cmd *cobra.Command, args []string) error {
+	townRoot, err := workspace.FindFromCwdOrError()
+	if err != nil {
+		return fmt.Errorf("not in a Gas Town workspace: %w", err)
+	}
+
+	if len(args) == 0 {
+		oldRoot := workspaceMoveFrom
+		if oldRoot == "" {
+			oldRoot = workspace.PreviousLocation(townRoot)
+		}
+		if oldRoot == "" {
+			return fmt.Errorf("town has not moved; pass <new-root> to move it, or --from <old-root>")
+		}
+		return fixupMovedTown(filepath.Clean(oldRoot), townRoot)
+	}
+	if workspaceMoveFrom != "" {
+		return fmt.Errorf("--from and <new-root> cannot be combined")
+	}
+
+	newRoot, err := expandMovePath(args[0])
+	if err != nil {
+		return err
+	}
+	if newRoot == townRoot || strings.HasPrefix(newRoot, townRoot+string(filepath.Separator)) {
+		return fmt.Errorf("cannot move town into itself: %s", newRoot)
+	}
+	if _, err := os.Stat(newRoot); err == nil {
+		return fmt.Errorf("%s already exists", newRoot)
+	}
+	if info, err := os.Stat(filepath.Dir(newRoot)); err != nil || !info.IsDir() {
+		return fmt.Errorf("parent directory %s does not exist", filepath.Dir(newRoot))
+	}
+	if err := checkTownStopped(townRoot); err != nil {
+		return err
+	}
+
+	if workspaceMoveDryRun {
+		fmt.Printf("Would move %s → %s and rewrite stored paths\n", townRoot, newRoot)
+		return nil
+	}
+
+	if err := os.Rename(townRoot, newRoot); err != nil {
+		if errors.Is(err, syscall.EXDEV) {
+			return fmt.Errorf("%s is on a different filesystem; copy the town there, then run 'gt workspace move --from %s' inside it", newRoot, townRoot)
+		}
+		return fmt.Errorf("moving town: %w", err)
+	}
+	fmt.Printf("%s Moved %s → %s\n", style.Success.Render("✓"), townRoot, newRoot)
+
+	return fixupMovedTown(townRoot, newRoot)
+}
+
+// fixupMovedTown rewrites stored paths from oldRoot to newRoot and reports
+// what changed.
+func fixupMovedTown(oldRoot, newRoot string) error {
+	if workspaceMoveDryRun {
+		fmt.Printf("Would rewrite stored paths %s → %s\n", oldRoot, newRoot)
+		return nil
+	}
+	changed, err := workspace.Relocate(oldRoot, newRoot)
+	if err != nil {
+		return fmt.Errorf("rewriting stored paths: %w", err)
+	}
+	fmt.Printf("%s Rewrote %d file(s) referencing %s\n", style.Success.Render("✓"), len(changed), oldRoot)
+	for _, rel := range changed {
+		fmt.Printf("  %s\n", style.Dim.Render(rel))
+	}
+	fmt.Printf("\n  Run '%s' from the new location to verify\n", style.Dim.Render("gt workspace doctor"))
+	return nil
+}
+
+// checkTownStopped refuses to move a town whose daemon or local Dolt server
+// is running; both hold files open under the town root.
+func checkTownStopped(townRoot string) error {
+	if running, pid, _ := daemon.IsRunning(townRoot); running {
+		return fmt.Errorf("daemon is running (PID %d); run 'gt down' first", pid)
+	}
+	if !doltserver.DefaultConfig(townRoot).IsRemote() {
+		if running, pid, _ := doltserver.IsRunning(townRoot); running {
+			return fmt.Errorf("dolt server is running (PID %d); run 'gt down' first", pid)
+		}
+	}
+	return nil
+}
+
+// expandMovePath expands a leading ~ and makes path absolute.
+func expandMovePath(path string) (string, error) {
+	if strings.HasPrefix(path, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("getting home directory: %w", err)
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("resolving path: %w", err)
+	}
+	return abs, nil
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/vcs"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -338,7 +339,13 @@ func runWorktreeRemove(cmd *cobra.Command, args []string) error {
 	g := git.NewGit(targetMayorRig)
 
 	// Remove the worktree
-	if err := g.WorktreeRemove(worktreePath, worktreeRemoveForce); err != nil {
+	fl, err := vcs.LockWorktrees(targetRigInfo.Path)
+	if err != nil {
+		return err
+	}
+	err = g.WorktreeRemove(worktreePath, worktreeRemoveForce)
+	_ = fl.Unlock()
+	if err != nil {
 		return fmt.Errorf("removing worktree: %w", err)
 	}
 
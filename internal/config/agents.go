@@ -3,6 +3,7 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -833,6 +834,52 @@ func ResolveACPConfig(agentName, command string) *ACPConfig {
 	return nil
 }
 
+// ResolveNonInteractiveConfig returns the non-interactive settings for an
+// agent, looked up the same way as ResolveACPConfig. Claude has no preset
+// entry because its non-interactive mode is just "-p".
+func ResolveNonInteractiveConfig(agentName, command string) *NonInteractiveConfig {
+	registryMu.Lock()
+	initRegistryLocked()
+	defer registryMu.Unlock()
+
+	if info, ok := globalRegistry.Agents[agentName]; ok && info.NonInteractive != nil {
+		return info.NonInteractive
+	}
+	if command != "" {
+		cmdBase := filepath.Base(command)
+		for _, info := range globalRegistry.Agents {
+			if (info.Command == command || filepath.Base(info.Command) == cmdBase) && info.NonInteractive != nil {
+				return info.NonInteractive
+			}
+		}
+		if cmdBase == "claude" {
+			return &NonInteractiveConfig{PromptFlag: "-p"}
+		}
+	}
+	return nil
+}
+
+// BuildNonInteractiveArgs returns the command and args that run the agent
+// once on prompt and exit, for agents with a non-interactive mode.
+func (rc *RuntimeConfig) BuildNonInteractiveArgs(prompt string) ([]string, error) {
+	resolved := normalizeRuntimeConfig(rc)
+	ni := ResolveNonInteractiveConfig(resolved.ResolvedAgent, resolved.Command)
+	if ni == nil {
+		return nil, fmt.Errorf("agent %q has no non-interactive mode", resolved.Command)
+	}
+
+	args := append([]string{}, resolved.ExecWrapper...)
+	args = append(args, resolved.Command)
+	if ni.Subcommand != "" {
+		args = append(args, ni.Subcommand)
+	}
+	args = append(args, resolved.Args...)
+	if ni.PromptFlag != "" {
+		args = append(args, ni.PromptFlag)
+	}
+	return append(args, prompt), nil
+}
+
 // SupportsACP checks if an agent supports ACP (Agent Communication Protocol).
 // Returns true if the agent has ACP configured.
 func SupportsACP(agentName string) bool {
@@ -1632,3 +1632,34 @@ func TestACPModeConstants(t *testing.T) {
 		t.Errorf("ACPModeFlag = %q, want flag", ACPModeFlag)
 	}
 }
+
+func TestBuildNonInteractiveArgs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		rc   *RuntimeConfig
+		want []string
+	}{
+		{"claude", &RuntimeConfig{Command: "claude", Args: []string{"--dangerously-skip-permissions"}},
+			[]string{"claude", "--dangerously-skip-permissions", "-p", "hi"}},
+		{"gemini", &RuntimeConfig{Command: "gemini", Args: []string{"--approval-mode", "yolo"}},
+			[]string{"gemini", "--approval-mode", "yolo", "-p", "hi"}},
+		{"codex", &RuntimeConfig{Command: "codex", Args: []string{"--dangerously-bypass-approvals-and-sandbox"}},
+			[]string{"codex", "exec", "--dangerously-bypass-approvals-and-sandbox", "hi"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rc.BuildNonInteractiveArgs("hi")
+			if err != nil {
+				t.Fatalf("BuildNonInteractiveArgs: %v", err)
+			}
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := (&RuntimeConfig{Command: "some-unknown-agent"}).BuildNonInteractiveArgs("hi"); err == nil {
+		t.Error("expected error for an agent without a non-interactive mode")
+	}
+}
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CheckpointConfig configures crash-recovery checkpoints
+// (.polecat-checkpoint.json), set in town.json under "checkpoints".
+type CheckpointConfig struct {
+	// Encryption, if set, encrypts checkpoint files at rest with age.
+	// Checkpoints can hold snippets of code and plans, and agent homes
+	// often live in synced directories.
+	Encryption *CheckpointEncryptionConfig `json:"encryption,omitempty"`
+
+	// StaleAfter maps role names to how old a checkpoint may be (a Go
+	// duration, e.g. "6h") before gt prime treats it as a stale leftover
+	// instead of a crash to recover from. "default" applies to roles
+	// without their own entry. Default: 24h.
+	StaleAfter map[string]string `json:"stale_after,omitempty"`
+}
+
+// DefaultCheckpointStaleAfter is the checkpoint freshness cutoff used when
+// town.json doesn't set one.
+const DefaultCheckpointStaleAfter = 24 * time.Hour
+
+// StaleThreshold returns the checkpoint freshness cutoff for role: its
+// stale_after entry, else "default", else DefaultCheckpointStaleAfter.
+// Safe on a nil receiver.
+func (c *CheckpointConfig) StaleThreshold(role string) time.Duration {
+	if c == nil {
+		return DefaultCheckpointStaleAfter
+	}
+	for _, key := range []string{role, "default"} {
+		if v, ok := c.StaleAfter[key]; ok {
+			if d, err := time.ParseDuration(v); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return DefaultCheckpointStaleAfter
+}
+
+// CheckpointEncryptionConfig names the age keys used for checkpoints.
+type CheckpointEncryptionConfig struct {
+	// Recipients are age public keys ("age1...") or SSH public keys
+	// ("ssh-ed25519 ...") checkpoints are encrypted to.
+	Recipients []string `json:"recipients"`
+
+	// Identity is the path of the age identity file used to decrypt
+	// checkpoints (~ is expanded). Without it, encrypted checkpoints can be
+	// written but not read back.
+	Identity string `json:"identity,omitempty"`
+}
+
+// Enabled reports whether checkpoints should be encrypted.
+func (c *CheckpointEncryptionConfig) Enabled() bool {
+	return c != nil && len(c.Recipients) > 0
+}
+
+// ErrInvalidCheckpointConfig indicates a malformed town.json checkpoints entry.
+var ErrInvalidCheckpointConfig = errors.New("invalid checkpoints config")
+
+// validateCheckpointConfig checks stale_after durations and that encryption
+// recipients look like age or SSH public keys.
+func validateCheckpointConfig(c *CheckpointConfig) error {
+	if c == nil {
+		return nil
+	}
+	for role, v := range c.StaleAfter {
+		if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+			return fmt.Errorf("%w: stale_after[%s]: %q is not a positive duration", ErrInvalidCheckpointConfig, role, v)
+		}
+	}
+	if c.Encryption == nil {
+		return nil
+	}
+	if len(c.Encryption.Recipients) == 0 {
+		return fmt.Errorf("%w: encryption needs at least one recipient", ErrInvalidCheckpointConfig)
+	}
+	for _, r := range c.Encryption.Recipients {
+		if !strings.HasPrefix(r, "age1") && !strings.HasPrefix(r, "ssh-") {
+			return fmt.Errorf("%w: recipient %q is not an age or SSH public key", ErrInvalidCheckpointConfig, r)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestValidateCheckpointConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *CheckpointConfig
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"no encryption", &CheckpointConfig{}, false},
+		{"age recipient", &CheckpointConfig{Encryption: &CheckpointEncryptionConfig{Recipients: []string{"age1abc"}}}, false},
+		{"ssh recipient", &CheckpointConfig{Encryption: &CheckpointEncryptionConfig{Recipients: []string{"ssh-ed25519 AAAA"}}}, false},
+		{"no recipients", &CheckpointConfig{Encryption: &CheckpointEncryptionConfig{}}, true},
+		{"stale after", &CheckpointConfig{StaleAfter: map[string]string{"crew": "72h", "default": "6h"}}, false},
+		{"bad stale after", &CheckpointConfig{StaleAfter: map[string]string{"crew": "3 days"}}, true},
+		{"zero stale after", &CheckpointConfig{StaleAfter: map[string]string{"crew": "0s"}}, true},
+		{"bad recipient", &CheckpointConfig{Encryption: &CheckpointEncryptionConfig{Recipients: []string{"alice@example.com"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCheckpointConfig(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateCheckpointConfig() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidCheckpointConfig) {
+				t.Errorf("error %v is not ErrInvalidCheckpointConfig", err)
+			}
+		})
+	}
+}
+
+func TestCheckpointStaleThreshold(t *testing.T) {
+	var none *CheckpointConfig
+	if got := none.StaleThreshold("crew"); got != DefaultCheckpointStaleAfter {
+		t.Errorf("nil config: got %v, want %v", got, DefaultCheckpointStaleAfter)
+	}
+
+	c := &CheckpointConfig{StaleAfter: map[string]string{"crew": "72h", "default": "6h"}}
+	if got := c.StaleThreshold("crew"); got != 72*time.Hour {
+		t.Errorf("crew: got %v, want 72h", got)
+	}
+	if got := c.StaleThreshold("polecat"); got != 6*time.Hour {
+		t.Errorf("polecat: got %v, want default 6h", got)
+	}
+
+	c = &CheckpointConfig{StaleAfter: map[string]string{"crew": "72h"}}
+	if got := c.StaleThreshold("polecat"); got != DefaultCheckpointStaleAfter {
+		t.Errorf("polecat without default: got %v, want %v", got, DefaultCheckpointStaleAfter)
+	}
+}
@@ -0,0 +1,183 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Scopes for custom roles.
+const (
+	// CustomRoleScopeRig roles have named workers in each rig, like crew:
+	// <rig>/<dir>/<name>, session <prefix>-<role>-<name>,
+	// address <rig>/<role>/<name>.
+	CustomRoleScopeRig = "rig"
+
+	// CustomRoleScopeTown roles have named workers at the town level, like
+	// dogs: <town>/<dir>/<name>, session hq-<role>-<name>,
+	// address <role>/<name>.
+	CustomRoleScopeTown = "town"
+)
+
+// CustomRoleConfig defines a role beyond the built-in set, declared in
+// town.json under "roles" and keyed by role name (e.g. "reviewer").
+type CustomRoleConfig struct {
+	// Description is shown in 'gt role list' and the role's prime context.
+	Description string `json:"description,omitempty"`
+
+	// Scope is "rig" (default) or "town".
+	Scope string `json:"scope,omitempty"`
+
+	// Dir is the directory holding the role's workers, relative to the rig
+	// (rig scope) or town root (town scope). Default: the role name.
+	Dir string `json:"dir,omitempty"`
+
+	// Prime is the Markdown file, relative to the town root, that gt prime
+	// shows as the role's context. Default: roles/<role>.md.
+	Prime string `json:"prime,omitempty"`
+}
+
+// IsTownScoped reports whether the role's workers live at the town level.
+func (c *CustomRoleConfig) IsTownScoped() bool {
+	return c.Scope == CustomRoleScopeTown
+}
+
+// WorkerDir returns the directory holding the role's workers.
+func (c *CustomRoleConfig) WorkerDir(role string) string {
+	if c.Dir != "" {
+		return c.Dir
+	}
+	return role
+}
+
+// PrimePath returns the role's prime context file under townRoot.
+func (c *CustomRoleConfig) PrimePath(townRoot, role string) string {
+	if c.Prime != "" {
+		return filepath.Join(townRoot, c.Prime)
+	}
+	return filepath.Join(townRoot, "roles", role+".md")
+}
+
+// ErrInvalidCustomRole indicates a malformed town.json roles entry.
+var ErrInvalidCustomRole = errors.New("invalid custom role")
+
+// customRoleName is the allowed form of a custom role name. No hyphens:
+// session names use them as separators.
+var customRoleName = regexp.MustCompile(`^[a-z][a-z0-9]*$`)
+
+// reservedRoleNames are built-in role names and the directory and address
+// segments they use, which custom roles may not reuse.
+var reservedRoleNames = map[string]bool{
+	"mayor": true, "deacon": true, "boot": true, "dog": true, "dogs": true,
+	"witness": true, "refinery": true, "polecat": true, "polecats": true,
+	"crew": true, "overseer": true, "hq": true, "default": true,
+}
+
+// reservedRoleDirs are directories built-in roles or the town itself own.
+var reservedRoleDirs = map[string]bool{
+	"mayor": true, "deacon": true, "witness": true, "refinery": true,
+	"polecats": true, "crew": true, "settings": true, "roles": true,
+	"directives": true, "logs": true, "plugins": true,
+}
+
+// validateCustomRoles checks role names, scopes, and directories.
+func validateCustomRoles(roles map[string]*CustomRoleConfig) error {
+	dirs := make(map[string]string)
+	for name, role := range roles {
+		if !customRoleName.MatchString(name) {
+			return fmt.Errorf("%w: name %q must be lowercase letters and digits", ErrInvalidCustomRole, name)
+		}
+		if reservedRoleNames[name] {
+			return fmt.Errorf("%w: %q is a built-in role", ErrInvalidCustomRole, name)
+		}
+		if role == nil {
+			return fmt.Errorf("%w: %s: empty definition", ErrInvalidCustomRole, name)
+		}
+		if role.Scope != "" && role.Scope != CustomRoleScopeRig && role.Scope != CustomRoleScopeTown {
+			return fmt.Errorf("%w: %s: scope %q, want %q or %q", ErrInvalidCustomRole, name, role.Scope, CustomRoleScopeRig, CustomRoleScopeTown)
+		}
+		dir := role.WorkerDir(name)
+		if strings.ContainsAny(dir, `/\`) || dir == "." || dir == ".." || strings.HasPrefix(dir, ".") {
+			return fmt.Errorf("%w: %s: dir %q must be a single directory name", ErrInvalidCustomRole, name, dir)
+		}
+		if reservedRoleDirs[dir] {
+			return fmt.Errorf("%w: %s: dir %q belongs to a built-in role", ErrInvalidCustomRole, name, dir)
+		}
+		key := fmt.Sprintf("%v/%s", role.IsTownScoped(), dir)
+		if other, ok := dirs[key]; ok {
+			return fmt.Errorf("%w: %s and %s share dir %q", ErrInvalidCustomRole, name, other, dir)
+		}
+		dirs[key] = name
+		if role.Prime != "" && (filepath.IsAbs(role.Prime) || strings.HasPrefix(filepath.Clean(role.Prime), "..")) {
+			return fmt.Errorf("%w: %s: prime %q must be relative to the town root", ErrInvalidCustomRole, name, role.Prime)
+		}
+	}
+	return nil
+}
+
+var (
+	customRolesMu sync.RWMutex
+	customRoles   map[string]*CustomRoleConfig
+)
+
+// LoadCustomRoles loads the custom roles declared in the town's town.json
+// into the process-wide role set, replacing any previously loaded. A town
+// without town.json has no custom roles.
+func LoadCustomRoles(townRoot string) error {
+	cfg, err := LoadTownConfig(filepath.Join(townRoot, "mayor", "town.json"))
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			SetCustomRoles(nil)
+			return nil
+		}
+		return err
+	}
+	SetCustomRoles(cfg.Roles)
+	return nil
+}
+
+// SetCustomRoles replaces the process-wide custom role set.
+func SetCustomRoles(roles map[string]*CustomRoleConfig) {
+	customRolesMu.Lock()
+	defer customRolesMu.Unlock()
+	customRoles = roles
+}
+
+// LookupCustomRole returns the custom role named name, if one is loaded.
+func LookupCustomRole(name string) (*CustomRoleConfig, bool) {
+	customRolesMu.RLock()
+	defer customRolesMu.RUnlock()
+	role, ok := customRoles[name]
+	return role, ok && role != nil
+}
+
+// CustomRoleNames returns the loaded custom role names, sorted.
+func CustomRoleNames() []string {
+	customRolesMu.RLock()
+	defer customRolesMu.RUnlock()
+	names := make([]string, 0, len(customRoles))
+	for name, role := range customRoles {
+		if role != nil {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// CustomRoleForDir returns the custom role whose workers live in dir for the
+// given scope.
+func CustomRoleForDir(dir string, townScoped bool) (string, *CustomRoleConfig, bool) {
+	customRolesMu.RLock()
+	defer customRolesMu.RUnlock()
+	for name, role := range customRoles {
+		if role != nil && role.IsTownScoped() == townScoped && role.WorkerDir(name) == dir {
+			return name, role, true
+		}
+	}
+	return "", nil, false
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestValidateCustomRoles(t *testing.T) {
+	tests := []struct {
+		name    string
+		roles   map[string]*CustomRoleConfig
+		wantErr bool
+	}{
+		{"none", nil, false},
+		{"rig and town", map[string]*CustomRoleConfig{
+			"reviewer":   {Dir: "reviewers"},
+			"researcher": {Scope: CustomRoleScopeTown, Prime: "roles/research.md"},
+		}, false},
+		{"same dir different scope", map[string]*CustomRoleConfig{
+			"reviewer":   {Dir: "team"},
+			"researcher": {Scope: CustomRoleScopeTown, Dir: "team"},
+		}, false},
+		{"built-in name", map[string]*CustomRoleConfig{"witness": {}}, true},
+		{"hyphenated name", map[string]*CustomRoleConfig{"code-reviewer": {}}, true},
+		{"uppercase name", map[string]*CustomRoleConfig{"Reviewer": {}}, true},
+		{"nil definition", map[string]*CustomRoleConfig{"reviewer": nil}, true},
+		{"bad scope", map[string]*CustomRoleConfig{"reviewer": {Scope: "global"}}, true},
+		{"built-in dir", map[string]*CustomRoleConfig{"reviewer": {Dir: "crew"}}, true},
+		{"nested dir", map[string]*CustomRoleConfig{"reviewer": {Dir: "a/b"}}, true},
+		{"hidden dir", map[string]*CustomRoleConfig{"reviewer": {Dir: ".reviewers"}}, true},
+		{"shared dir", map[string]*CustomRoleConfig{
+			"reviewer": {Dir: "team"},
+			"auditor":  {Dir: "team"},
+		}, true},
+		{"absolute prime", map[string]*CustomRoleConfig{"reviewer": {Prime: "/etc/passwd"}}, true},
+		{"escaping prime", map[string]*CustomRoleConfig{"reviewer": {Prime: "../outside.md"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCustomRoles(tt.roles)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateCustomRoles() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidCustomRole) {
+				t.Errorf("error %v is not ErrInvalidCustomRole", err)
+			}
+		})
+	}
+}
+
+func TestLoadCustomRoles(t *testing.T) {
+	t.Cleanup(func() { SetCustomRoles(nil) })
+
+	townRoot := t.TempDir()
+	cfg := &TownConfig{
+		Type:    "town",
+		Version: CurrentTownVersion,
+		Name:    "test",
+		Roles: map[string]*CustomRoleConfig{
+			"reviewer":   {Description: "Reviews PRs", Dir: "reviewers"},
+			"researcher": {Scope: CustomRoleScopeTown},
+		},
+	}
+	if err := SaveTownConfig(filepath.Join(townRoot, "mayor", "town.json"), cfg); err != nil {
+		t.Fatalf("SaveTownConfig: %v", err)
+	}
+	if err := LoadCustomRoles(townRoot); err != nil {
+		t.Fatalf("LoadCustomRoles: %v", err)
+	}
+
+	if got, want := CustomRoleNames(), []string{"researcher", "reviewer"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CustomRoleNames() = %v, want %v", got, want)
+	}
+	role, ok := LookupCustomRole("reviewer")
+	if !ok || role.Description != "Reviews PRs" {
+		t.Fatalf("LookupCustomRole(reviewer) = %+v, %v", role, ok)
+	}
+	if _, ok := LookupCustomRole("witness"); ok {
+		t.Error("LookupCustomRole(witness) found a built-in role")
+	}
+
+	if name, _, ok := CustomRoleForDir("reviewers", false); !ok || name != "reviewer" {
+		t.Errorf("CustomRoleForDir(reviewers, rig) = %q, %v", name, ok)
+	}
+	if _, _, ok := CustomRoleForDir("reviewers", true); ok {
+		t.Error("CustomRoleForDir(reviewers, town) matched a rig-scoped role")
+	}
+	if name, _, ok := CustomRoleForDir("researcher", true); !ok || name != "researcher" {
+		t.Errorf("CustomRoleForDir(researcher, town) = %q, %v", name, ok)
+	}
+
+	researcher, _ := LookupCustomRole("researcher")
+	if got, want := researcher.PrimePath(townRoot, "researcher"), filepath.Join(townRoot, "roles", "researcher.md"); got != want {
+		t.Errorf("PrimePath() = %q, want %q", got, want)
+	}
+
+	// A town without town.json clears the set.
+	if err := LoadCustomRoles(t.TempDir()); err != nil {
+		t.Fatalf("LoadCustomRoles(empty town): %v", err)
+	}
+	if names := CustomRoleNames(); len(names) != 0 {
+		t.Errorf("CustomRoleNames() after empty town = %v, want none", names)
+	}
+}
+
+func TestLoadTownConfigRejectsInvalidCustomRole(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "town.json")
+	data := `{"type":"town","version":2,"name":"test","roles":{"mayor":{}}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadTownConfig(path); !errors.Is(err, ErrInvalidCustomRole) {
+		t.Errorf("LoadTownConfig() error = %v, want ErrInvalidCustomRole", err)
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/secrets"
 )
 
 // IdentityEnvVars are agent identity env vars that must not leak across
@@ -319,6 +320,11 @@ func AgentEnv(cfg AgentEnvConfig) map[string]string {
 		}
 	}
 
+	// Agents load town.json the way the command that started them did.
+	if v := TownEnv(); v != "" {
+		env[TownEnvVar] = v
+	}
+
 	// Suppress bd's Dolt auto-start for all Gas Town agents (GH#2930).
 	// Gas Town manages its own Dolt server (gt dolt start/stop). When the
 	// server is momentarily unreachable (restart, journal hiccup), bd's
@@ -508,6 +514,162 @@ func AgentEnvSimple(role, rig, agentName string) map[string]string {
 	})
 }
 
+// ConfiguredSessionEnv returns the custom session environment for role from
+// town and rig settings (session_env), plus the resolved values of the
+// configured secrets. Layering, lowest to highest precedence: town vars,
+// town role vars, rig vars, rig role vars, then secrets in the same order.
+// Reserved names are dropped so custom vars can never shadow agent
+// identity; see validateSessionEnv. A secret that cannot be resolved is
+// left out with a warning. rigPath may be empty for town-level agents.
+func ConfiguredSessionEnv(townRoot, rigPath, role string) map[string]string {
+	env := ConfiguredSessionVars(townRoot, rigPath, role)
+	for k, v := range ResolveSessionSecrets(townRoot, rigPath, role) {
+		env[k] = v
+	}
+	return env
+}
+
+// ResolveSessionSecrets returns the resolved values of the secrets
+// configured for role, keyed by env var. A secret that cannot be resolved
+// is left out with a warning. Secret values must reach a session through
+// tmux (-e or set-environment), never on a command line where ps shows them.
+func ResolveSessionSecrets(townRoot, rigPath, role string) map[string]string {
+	env := make(map[string]string)
+	refs := ConfiguredSecretRefs(townRoot, rigPath, role)
+	for _, k := range sortedKeys(refs) {
+		value, err := secrets.Resolve(refs[k])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "warning: secrets.%s: %v\n", k, err)
+			continue
+		}
+		env[k] = value
+	}
+	return env
+}
+
+// PlannedSessionEnv is ConfiguredSessionEnv for dry runs: secrets are not
+// resolved and show as "<secret REF>".
+func PlannedSessionEnv(townRoot, rigPath, role string) map[string]string {
+	env := ConfiguredSessionVars(townRoot, rigPath, role)
+	for k, ref := range ConfiguredSecretRefs(townRoot, rigPath, role) {
+		env[k] = "<secret " + ref + ">"
+	}
+	return env
+}
+
+// ConfiguredSessionVars returns the session_env vars for role, without the
+// secrets. Layered as in ConfiguredSessionEnv.
+func ConfiguredSessionVars(townRoot, rigPath, role string) map[string]string {
+	env := make(map[string]string)
+	if townRoot != "" {
+		if settings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot)); err == nil {
+			mergeSessionEnv(env, settings.SessionEnv, role)
+		}
+	}
+	if rigPath != "" {
+		if settings, err := LoadRigSettings(RigSettingsPath(rigPath)); err == nil {
+			mergeSessionEnv(env, settings.SessionEnv, role)
+		}
+	}
+	return env
+}
+
+// ConfiguredSecretRefs returns the secret references (keychain:NAME,
+// gpg:PATH, env:NAME) configured for role in town and rig settings, keyed
+// by the env var they are exported as. Layered like session_env.
+func ConfiguredSecretRefs(townRoot, rigPath, role string) map[string]string {
+	refs := make(map[string]string)
+	if townRoot != "" {
+		if settings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot)); err == nil {
+			mergeSessionEnv(refs, settings.Secrets, role)
+		}
+	}
+	if rigPath != "" {
+		if settings, err := LoadRigSettings(RigSettingsPath(rigPath)); err == nil {
+			mergeSessionEnv(refs, settings.Secrets, role)
+		}
+	}
+	return refs
+}
+
+func mergeSessionEnv(env map[string]string, cfg *SessionEnvConfig, role string) {
+	if cfg == nil {
+		return
+	}
+	for _, vars := range []map[string]string{cfg.Vars, cfg.Roles[role]} {
+		for k, v := range vars {
+			if checkSessionEnvName(k) == nil {
+				env[k] = v
+			}
+		}
+	}
+}
+
+// validateSessionEnv rejects session_env names that are not valid shell
+// identifiers or that are reserved for AgentEnv.
+func validateSessionEnv(cfg *SessionEnvConfig) error {
+	if cfg == nil {
+		return nil
+	}
+	for k := range cfg.Vars {
+		if err := checkSessionEnvName(k); err != nil {
+			return err
+		}
+	}
+	for role, vars := range cfg.Roles {
+		for k := range vars {
+			if err := checkSessionEnvName(k); err != nil {
+				return fmt.Errorf("role %s: %w", role, err)
+			}
+		}
+	}
+	return nil
+}
+
+// validateSecrets checks secret names like session_env names and requires
+// every value to be a secret reference, never a plaintext value.
+func validateSecrets(cfg *SessionEnvConfig) error {
+	if err := validateSessionEnv(cfg); err != nil {
+		return err
+	}
+	if cfg == nil {
+		return nil
+	}
+	for k, ref := range cfg.Vars {
+		if _, _, err := secrets.ParseRef(ref); err != nil {
+			return fmt.Errorf("secrets.%s: %w", k, err)
+		}
+	}
+	for role, vars := range cfg.Roles {
+		for k, ref := range vars {
+			if _, _, err := secrets.ParseRef(ref); err != nil {
+				return fmt.Errorf("secrets role %s: %s: %w", role, k, err)
+			}
+		}
+	}
+	return nil
+}
+
+func checkSessionEnvName(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidSessionEnv)
+	}
+	for i, r := range name {
+		if r != '_' && !(r >= 'A' && r <= 'Z') && !(r >= 'a' && r <= 'z') && !(i > 0 && r >= '0' && r <= '9') {
+			return fmt.Errorf("%w: %q is not a valid variable name", ErrInvalidSessionEnv, name)
+		}
+	}
+	if name == "GT_ROOT" {
+		return fmt.Errorf("%w: %s is reserved", ErrInvalidSessionEnv, name)
+	}
+	for _, reserved := range IdentityEnvVars {
+		if name == reserved {
+			return fmt.Errorf("%w: %s is reserved", ErrInvalidSessionEnv, name)
+		}
+	}
+	return nil
+}
+
 // ShellQuote returns a shell-safe quoted string.
 // Values containing special characters are wrapped in single quotes.
 // Single quotes within the value are escaped using the '\” idiom.
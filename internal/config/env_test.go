@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"strings"
@@ -1279,3 +1280,121 @@ func TestClaudeConfigDir_EnvVar(t *testing.T) {
 		t.Errorf("ClaudeConfigDir() = %q, want %q", got, customDir)
 	}
 }
+
+func TestConfiguredSessionEnv_Layering(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+
+	town := NewTownSettings()
+	town.SessionEnv = &SessionEnvConfig{
+		Vars:  map[string]string{"HTTP_PROXY": "http://town", "EDITOR": "vi"},
+		Roles: map[string]map[string]string{"polecat": {"EDITOR": "nano"}},
+	}
+	if err := SaveTownSettings(TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+	rig := NewRigSettings()
+	rig.SessionEnv = &SessionEnvConfig{Vars: map[string]string{"HTTP_PROXY": "http://rig"}}
+	if err := SaveRigSettings(RigSettingsPath(rigPath), rig); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ConfiguredSessionEnv(townRoot, rigPath, "polecat")
+	if got["HTTP_PROXY"] != "http://rig" || got["EDITOR"] != "nano" {
+		t.Errorf("polecat env = %v", got)
+	}
+	got = ConfiguredSessionEnv(townRoot, "", "mayor")
+	if got["HTTP_PROXY"] != "http://town" || got["EDITOR"] != "vi" {
+		t.Errorf("mayor env = %v", got)
+	}
+}
+
+func TestConfiguredSessionEnv_DropsReserved(t *testing.T) {
+	townRoot := t.TempDir()
+	town := NewTownSettings()
+	town.SessionEnv = &SessionEnvConfig{Vars: map[string]string{"GT_ROLE": "mayor", "BAD-NAME": "x", "OK": "1"}}
+	if err := SaveTownSettings(TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ConfiguredSessionEnv(townRoot, "", "crew")
+	if len(got) != 1 || got["OK"] != "1" {
+		t.Errorf("env = %v, want only OK", got)
+	}
+}
+
+func TestValidateSessionEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *SessionEnvConfig
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"valid", &SessionEnvConfig{Vars: map[string]string{"MY_VAR": "x", "_X1": "y"}}, false},
+		{"identity var", &SessionEnvConfig{Vars: map[string]string{"GT_POLECAT": "x"}}, true},
+		{"gt root", &SessionEnvConfig{Vars: map[string]string{"GT_ROOT": "/x"}}, true},
+		{"leading digit", &SessionEnvConfig{Vars: map[string]string{"1X": "x"}}, true},
+		{"role shell meta", &SessionEnvConfig{Roles: map[string]map[string]string{"crew": {"A;B": "x"}}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateSessionEnv(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateSessionEnv() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidSessionEnv) {
+				t.Errorf("error %v does not wrap ErrInvalidSessionEnv", err)
+			}
+		})
+	}
+}
+
+func TestConfiguredSessionEnv_Secrets(t *testing.T) {
+	townRoot := t.TempDir()
+	t.Setenv("GT_TEST_API_TOKEN", "tok-123")
+
+	town := NewTownSettings()
+	town.SessionEnv = &SessionEnvConfig{Vars: map[string]string{"API_TOKEN": "plain"}}
+	town.Secrets = &SessionEnvConfig{Vars: map[string]string{
+		"API_TOKEN": "env:GT_TEST_API_TOKEN",
+		"MISSING":   "env:GT_TEST_UNSET_TOKEN",
+	}}
+	if err := SaveTownSettings(TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+
+	got := ConfiguredSessionEnv(townRoot, "", "crew")
+	if got["API_TOKEN"] != "tok-123" {
+		t.Errorf("API_TOKEN = %q, want resolved secret", got["API_TOKEN"])
+	}
+	if _, ok := got["MISSING"]; ok {
+		t.Errorf("unresolvable secret should be dropped, got %v", got)
+	}
+
+	planned := PlannedSessionEnv(townRoot, "", "crew")
+	if planned["API_TOKEN"] != "<secret env:GT_TEST_API_TOKEN>" {
+		t.Errorf("planned API_TOKEN = %q, want masked reference", planned["API_TOKEN"])
+	}
+}
+
+func TestValidateSecrets(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *SessionEnvConfig
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"keychain", &SessionEnvConfig{Vars: map[string]string{"GITHUB_TOKEN": "keychain:GITHUB_TOKEN"}}, false},
+		{"gpg role", &SessionEnvConfig{Roles: map[string]map[string]string{"crew": {"JIRA": "gpg:~/.secrets/jira.gpg"}}}, false},
+		{"plaintext", &SessionEnvConfig{Vars: map[string]string{"GITHUB_TOKEN": "ghp_abc"}}, true},
+		{"plaintext role", &SessionEnvConfig{Roles: map[string]map[string]string{"crew": {"JIRA": "hunter2"}}}, true},
+		{"reserved name", &SessionEnvConfig{Vars: map[string]string{"GT_ROLE": "env:X"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateSecrets(tt.cfg); (err != nil) != tt.wantErr {
+				t.Errorf("validateSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Errors from GetPath and SetPath.
+var (
+	// ErrUnknownPath means a dotted path names a field the config type
+	// doesn't have.
+	ErrUnknownPath = errors.New("unknown config path")
+
+	// ErrInvalidValue means a value doesn't fit the type of its field.
+	ErrInvalidValue = errors.New("invalid config value")
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// GetPath returns the value at a dotted path of JSON field names (e.g.
+// "scheduler.max_polecats") in cfg, a pointer to a config struct. Map
+// entries are addressed by key ("session_env.vars.FOO"). ok is false if the
+// path is valid but unset: a nil pointer or missing map entry on the way.
+func GetPath(cfg any, path string) (value any, ok bool, err error) {
+	v, ok, err := getPath(reflect.ValueOf(cfg), splitPath(path), path)
+	if err != nil || !ok {
+		return nil, ok, err
+	}
+	return v.Interface(), true, nil
+}
+
+func getPath(v reflect.Value, segs []string, path string) (reflect.Value, bool, error) {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			// Keep checking the rest of the path so typos still fail.
+			if err := checkPath(v.Type(), segs, path); err != nil {
+				return reflect.Value{}, false, err
+			}
+			return reflect.Value{}, false, nil
+		}
+		v = v.Elem()
+	}
+	if len(segs) == 0 {
+		return v, true, nil
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		index, ok := jsonFieldIndex(v.Type(), segs[0])
+		if !ok {
+			return reflect.Value{}, false, unknownPath(path, segs[0])
+		}
+		return getPath(v.FieldByIndex(index), segs[1:], path)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return reflect.Value{}, false, notAnObject(path, segs[0])
+		}
+		elem := v.MapIndex(reflect.ValueOf(segs[0]).Convert(v.Type().Key()))
+		if !elem.IsValid() {
+			return reflect.Value{}, false, checkPath(v.Type().Elem(), segs[1:], path)
+		}
+		return getPath(elem, segs[1:], path)
+	default:
+		return reflect.Value{}, false, notAnObject(path, segs[0])
+	}
+}
+
+// checkPath validates segs against type t without a value to walk.
+func checkPath(t reflect.Type, segs []string, path string) error {
+	for _, seg := range segs {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			index, ok := jsonFieldIndex(t, seg)
+			if !ok {
+				return unknownPath(path, seg)
+			}
+			t = t.FieldByIndex(index).Type
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Interface:
+			return nil
+		default:
+			return notAnObject(path, seg)
+		}
+	}
+	return nil
+}
+
+// SetPath sets the field at a dotted path in cfg, a pointer to a config
+// struct, creating nil structs and maps along the way. value is parsed for
+// the field's type: JSON ("5", "true", `["a","b"]`, `{"k":"v"}`), a bare
+// string for string fields, or a Go duration ("5m") for time.Duration
+// fields. A value that doesn't fit is an ErrInvalidValue. On error cfg may
+// be partly modified; callers should not save it.
+func SetPath(cfg any, path, value string) error {
+	root := reflect.ValueOf(cfg)
+	if root.Kind() != reflect.Pointer || root.IsNil() {
+		return fmt.Errorf("SetPath needs a non-nil pointer, got %T", cfg)
+	}
+	segs := splitPath(path)
+	if len(segs) == 0 {
+		return fmt.Errorf("%w: empty path", ErrUnknownPath)
+	}
+	return setPath(root.Elem(), segs, path, value)
+}
+
+func setPath(v reflect.Value, segs []string, path, value string) error {
+	if len(segs) == 0 {
+		return decodePathValue(v, path, value)
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return setPath(v.Elem(), segs, path, value)
+	case reflect.Struct:
+		index, ok := jsonFieldIndex(v.Type(), segs[0])
+		if !ok {
+			return unknownPath(path, segs[0])
+		}
+		return setPath(v.FieldByIndex(index), segs[1:], path, value)
+	case reflect.Map:
+		if v.Type().Key().Kind() != reflect.String {
+			return notAnObject(path, segs[0])
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(v.Type()))
+		}
+		// Map elements aren't addressable: edit a copy and store it back.
+		key := reflect.ValueOf(segs[0]).Convert(v.Type().Key())
+		elem := reflect.New(v.Type().Elem()).Elem()
+		if cur := v.MapIndex(key); cur.IsValid() {
+			elem.Set(cur)
+		}
+		if err := setPath(elem, segs[1:], path, value); err != nil {
+			return err
+		}
+		v.SetMapIndex(key, elem)
+		return nil
+	default:
+		return notAnObject(path, segs[0])
+	}
+}
+
+// decodePathValue parses value into v according to v's type.
+func decodePathValue(v reflect.Value, path, value string) error {
+	base := v.Type()
+	for base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+	target := reflect.New(v.Type())
+	if base == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("%w: %s expects a duration (e.g. 30s, 5m): %q", ErrInvalidValue, path, value)
+		}
+		value = fmt.Sprint(int64(d))
+	}
+	err := json.Unmarshal([]byte(value), target.Interface())
+	if err != nil && base.Kind() == reflect.String {
+		quoted, _ := json.Marshal(value)
+		err = json.Unmarshal(quoted, target.Interface())
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %s expects %s: %q", ErrInvalidValue, path, schemaTypeName(base), value)
+	}
+	v.Set(target.Elem())
+	return nil
+}
+
+func splitPath(path string) []string {
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+func unknownPath(path, seg string) error {
+	return fmt.Errorf("%w: %s (no field %q)", ErrUnknownPath, path, seg)
+}
+
+func notAnObject(path, seg string) error {
+	return fmt.Errorf("%w: %s (%q is below a value that is not an object)", ErrUnknownPath, path, seg)
+}
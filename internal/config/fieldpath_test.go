@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSetPath(t *testing.T) {
+	s := NewTownSettings()
+
+	if err := SetPath(s, "scheduler.max_polecats", "5"); err != nil {
+		t.Fatalf("SetPath: %v", err)
+	}
+	if s.Scheduler == nil || s.Scheduler.MaxPolecats == nil || *s.Scheduler.MaxPolecats != 5 {
+		t.Errorf("scheduler.max_polecats not set: %+v", s.Scheduler)
+	}
+
+	if err := SetPath(s, "session_env.vars.HTTP_PROXY", "http://proxy:3128"); err != nil {
+		t.Fatalf("SetPath map entry: %v", err)
+	}
+	if got := s.SessionEnv.Vars["HTTP_PROXY"]; got != "http://proxy:3128" {
+		t.Errorf("session_env.vars.HTTP_PROXY = %q", got)
+	}
+	if err := SetPath(s, "session_env.roles.polecat.LANG", "C"); err != nil {
+		t.Fatalf("SetPath nested map: %v", err)
+	}
+	if got := s.SessionEnv.Roles["polecat"]["LANG"]; got != "C" {
+		t.Errorf("session_env.roles.polecat.LANG = %q", got)
+	}
+}
+
+func TestSetPath_Errors(t *testing.T) {
+	tests := []struct {
+		path, value string
+		want        error
+	}{
+		{"scheduler.max_polecats", "many", ErrInvalidValue},
+		{"scheduler.max_polecat", "5", ErrUnknownPath},
+		{"default_agent.name", "x", ErrUnknownPath},
+		{"", "x", ErrUnknownPath},
+	}
+	for _, tt := range tests {
+		err := SetPath(NewTownSettings(), tt.path, tt.value)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("SetPath(%q, %q) = %v, want %v", tt.path, tt.value, err, tt.want)
+		}
+	}
+}
+
+func TestSetPath_Types(t *testing.T) {
+	var v struct {
+		Name    string            `json:"name"`
+		On      *bool             `json:"on"`
+		Tags    []string          `json:"tags"`
+		Wait    time.Duration     `json:"wait"`
+		Labels  map[string]string `json:"labels"`
+		Created time.Time         `json:"created"`
+	}
+	for path, value := range map[string]string{
+		"name":    "plain words",
+		"on":      "true",
+		"tags":    `["a","b"]`,
+		"wait":    "90s",
+		"labels":  `{"k":"v"}`,
+		"created": `"2026-01-02T03:04:05Z"`,
+	} {
+		if err := SetPath(&v, path, value); err != nil {
+			t.Errorf("SetPath(%s, %s): %v", path, value, err)
+		}
+	}
+	if v.Name != "plain words" || v.On == nil || !*v.On || len(v.Tags) != 2 || v.Wait != 90*time.Second || v.Labels["k"] != "v" || v.Created.Year() != 2026 {
+		t.Errorf("unexpected result: %+v", v)
+	}
+	if err := SetPath(&v, "on", "yes"); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("bool from %q: err = %v, want ErrInvalidValue", "yes", err)
+	}
+}
+
+func TestGetPath(t *testing.T) {
+	s := NewTownSettings()
+	n := 3
+	s.DefaultAgent = "claude"
+
+	got, ok, err := GetPath(s, "default_agent")
+	if err != nil || !ok || got != "claude" {
+		t.Errorf("default_agent = %v, %v, %v", got, ok, err)
+	}
+
+	// Unset pointer: valid path, not set.
+	if _, ok, err := GetPath(s, "scheduler.max_polecats"); err != nil || ok {
+		t.Errorf("unset scheduler.max_polecats: ok=%v err=%v", ok, err)
+	}
+	// Typos are caught even below unset fields.
+	if _, _, err := GetPath(s, "scheduler.max_polecatz"); !errors.Is(err, ErrUnknownPath) {
+		t.Errorf("typo below nil: err = %v, want ErrUnknownPath", err)
+	}
+
+	if err := SetPath(s, "scheduler.max_polecats", "3"); err != nil {
+		t.Fatal(err)
+	}
+	got, ok, err = GetPath(s, "scheduler.max_polecats")
+	if err != nil || !ok || got != n {
+		t.Errorf("scheduler.max_polecats = %v, %v, %v", got, ok, err)
+	}
+}
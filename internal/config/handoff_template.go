@@ -0,0 +1,258 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// HandoffTemplate defines the structure of a role's handoff mail body: a
+// sequence of "## <Title>" sections, some required, some carrying
+// "Name: value" fields. gt handoff renders agent-written handoffs into this
+// shape so the Witness and Mayor can parse them.
+//
+// Templates are set per role in town and rig settings (handoff_templates).
+// A rig's template for a role replaces the town's; the "default" key applies
+// to roles without their own.
+type HandoffTemplate struct {
+	Sections []HandoffSection `json:"sections"`
+}
+
+// HandoffSection is one "## <Title>" section of a handoff.
+type HandoffSection struct {
+	Title string `json:"title"`
+
+	// Hint tells the agent what belongs in the section (gt handoff --template).
+	Hint string `json:"hint,omitempty"`
+
+	// Required sections must be present and non-empty.
+	Required bool `json:"required,omitempty"`
+
+	// Fields are "Name: value" lines expected in the section, optionally
+	// written as list items ("- Name: value").
+	Fields []HandoffField `json:"fields,omitempty"`
+}
+
+// HandoffField is a "Name: value" line within a handoff section.
+type HandoffField struct {
+	Name     string `json:"name"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// HandoffPlaceholder marks a templated section or field the handoff left empty.
+const HandoffPlaceholder = "_(not provided)_"
+
+// ErrInvalidHandoffTemplate indicates a malformed handoff_templates entry.
+var ErrInvalidHandoffTemplate = errors.New("invalid handoff template")
+
+// ResolveHandoffTemplate returns the handoff template for role from rig and
+// town settings, or nil if none is configured. Lookup order: rig role, rig
+// "default", town role, town "default". rigPath may be empty for town-level
+// agents.
+func ResolveHandoffTemplate(townRoot, rigPath, role string) *HandoffTemplate {
+	var rigTemplates, townTemplates map[string]*HandoffTemplate
+	if rigPath != "" {
+		if settings, err := LoadRigSettings(RigSettingsPath(rigPath)); err == nil {
+			rigTemplates = settings.HandoffTemplates
+		}
+	}
+	if townRoot != "" {
+		if settings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot)); err == nil {
+			townTemplates = settings.HandoffTemplates
+		}
+	}
+	for _, templates := range []map[string]*HandoffTemplate{rigTemplates, townTemplates} {
+		for _, key := range []string{role, "default"} {
+			if t := templates[key]; t != nil && len(t.Sections) > 0 {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+// validateHandoffTemplates rejects templates with untitled or duplicate
+// sections or fields.
+func validateHandoffTemplates(templates map[string]*HandoffTemplate) error {
+	for role, t := range templates {
+		if t == nil {
+			continue
+		}
+		seen := make(map[string]bool)
+		for _, s := range t.Sections {
+			key := strings.ToLower(strings.TrimSpace(s.Title))
+			if key == "" {
+				return fmt.Errorf("%w: role %s: section with empty title", ErrInvalidHandoffTemplate, role)
+			}
+			if seen[key] {
+				return fmt.Errorf("%w: role %s: duplicate section %q", ErrInvalidHandoffTemplate, role, s.Title)
+			}
+			seen[key] = true
+			fields := make(map[string]bool)
+			for _, f := range s.Fields {
+				name := strings.ToLower(strings.TrimSpace(f.Name))
+				if name == "" || strings.Contains(name, ":") {
+					return fmt.Errorf("%w: role %s: section %q: bad field name %q", ErrInvalidHandoffTemplate, role, s.Title, f.Name)
+				}
+				if fields[name] {
+					return fmt.Errorf("%w: role %s: section %q: duplicate field %q", ErrInvalidHandoffTemplate, role, s.Title, f.Name)
+				}
+				fields[name] = true
+			}
+		}
+	}
+	return nil
+}
+
+// HandoffSectionText is one "## " section of a handoff body.
+type HandoffSectionText struct {
+	Title string
+	Body  string
+}
+
+// ParseHandoffSections splits a handoff body into its "## " sections, in
+// order. Text before the first heading is returned as preamble. Headings
+// inside fenced code blocks are not section breaks, and whole-line HTML
+// comments (the hints in a Skeleton) are dropped.
+func ParseHandoffSections(body string) (preamble string, sections []HandoffSectionText) {
+	var cur *HandoffSectionText
+	var buf []string
+	inFence := false
+	flush := func() {
+		text := strings.TrimSpace(strings.Join(buf, "\n"))
+		if cur == nil {
+			preamble = text
+		} else {
+			cur.Body = text
+			sections = append(sections, *cur)
+		}
+		buf = nil
+	}
+	for _, line := range strings.Split(body, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+		}
+		if trimmed := strings.TrimSpace(line); !inFence && strings.HasPrefix(trimmed, "<!--") && strings.HasSuffix(trimmed, "-->") {
+			continue
+		}
+		if !inFence && strings.HasPrefix(line, "## ") {
+			flush()
+			cur = &HandoffSectionText{Title: strings.TrimSpace(strings.TrimPrefix(line, "## "))}
+			continue
+		}
+		buf = append(buf, line)
+	}
+	flush()
+	return preamble, sections
+}
+
+// HandoffFieldValue returns the value of the "Name: value" line for name in a
+// section body (case-insensitive, optional list marker), and whether the line
+// is present.
+func HandoffFieldValue(body, name string) (string, bool) {
+	for _, line := range strings.Split(body, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimSpace(strings.TrimLeft(line, "-*"))
+		key, value, ok := strings.Cut(line, ":")
+		if ok && strings.EqualFold(strings.TrimSpace(key), name) {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
+
+// Validate reports how body falls short of the template: required sections
+// that are missing or empty, and required fields without a value.
+func (t *HandoffTemplate) Validate(body string) []string {
+	_, sections := ParseHandoffSections(body)
+	var problems []string
+	for _, s := range t.Sections {
+		text, ok := findHandoffSection(sections, s.Title)
+		empty := !ok || text == "" || text == HandoffPlaceholder
+		if s.Required && empty {
+			problems = append(problems, fmt.Sprintf("missing required section %q", s.Title))
+			continue
+		}
+		for _, f := range s.Fields {
+			if !f.Required {
+				continue
+			}
+			if v, _ := HandoffFieldValue(text, f.Name); v == "" || v == HandoffPlaceholder {
+				problems = append(problems, fmt.Sprintf("section %q: missing required field %q", s.Title, f.Name))
+			}
+		}
+	}
+	return problems
+}
+
+// Render lays body out in the template's shape: the preamble, then every
+// template section in order (with HandoffPlaceholder for missing sections
+// and fields), then any sections the template doesn't define.
+func (t *HandoffTemplate) Render(body string) string {
+	preamble, sections := ParseHandoffSections(body)
+	var out []string
+	if preamble != "" {
+		out = append(out, preamble)
+	}
+	used := make(map[string]bool)
+	for _, s := range t.Sections {
+		text, ok := findHandoffSection(sections, s.Title)
+		used[strings.ToLower(s.Title)] = ok
+		var missing []string
+		for _, f := range s.Fields {
+			if _, present := HandoffFieldValue(text, f.Name); !present {
+				missing = append(missing, fmt.Sprintf("- %s: %s", f.Name, HandoffPlaceholder))
+			}
+		}
+		if text == "" && len(missing) == 0 {
+			text = HandoffPlaceholder
+		}
+		if len(missing) > 0 {
+			text = strings.TrimSpace(text + "\n" + strings.Join(missing, "\n"))
+		}
+		out = append(out, "## "+s.Title+"\n"+text)
+	}
+	for _, s := range sections {
+		if !used[strings.ToLower(s.Title)] {
+			out = append(out, strings.TrimSpace("## "+s.Title+"\n"+s.Body))
+		}
+	}
+	return strings.Join(out, "\n\n")
+}
+
+// Skeleton returns an empty handoff in the template's shape, with hints as
+// HTML comments, for agents to fill in.
+func (t *HandoffTemplate) Skeleton() string {
+	var out []string
+	for _, s := range t.Sections {
+		lines := []string{"## " + s.Title}
+		note := s.Hint
+		if s.Required {
+			note = strings.TrimSpace("(required) " + note)
+		}
+		if note != "" {
+			lines = append(lines, "<!-- "+note+" -->")
+		}
+		for _, f := range s.Fields {
+			lines = append(lines, "- "+f.Name+": ")
+		}
+		out = append(out, strings.Join(lines, "\n"))
+	}
+	return strings.Join(out, "\n\n")
+}
+
+// findHandoffSection returns the body of the section titled title
+// (case-insensitive). Repeated sections are joined.
+func findHandoffSection(sections []HandoffSectionText, title string) (string, bool) {
+	var parts []string
+	found := false
+	for _, s := range sections {
+		if strings.EqualFold(s.Title, title) {
+			found = true
+			if s.Body != "" {
+				parts = append(parts, s.Body)
+			}
+		}
+	}
+	return strings.Join(parts, "\n\n"), found
+}
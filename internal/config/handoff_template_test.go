@@ -0,0 +1,133 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testHandoffTemplate() *HandoffTemplate {
+	return &HandoffTemplate{Sections: []HandoffSection{
+		{Title: "Status", Required: true, Fields: []HandoffField{{Name: "Bead", Required: true}, {Name: "Branch"}}},
+		{Title: "Next Steps", Required: true},
+		{Title: "Blockers", Hint: "Anything waiting on others"},
+	}}
+}
+
+func TestParseHandoffSections(t *testing.T) {
+	body := "Intro line\n\n## Status\n- Bead: gt-abc\n\n```\n## not a heading\n```\n## Next Steps\n<!-- hint -->\nRun tests\n"
+	preamble, sections := ParseHandoffSections(body)
+	if preamble != "Intro line" {
+		t.Errorf("preamble = %q", preamble)
+	}
+	if len(sections) != 2 || sections[0].Title != "Status" || sections[1].Title != "Next Steps" {
+		t.Fatalf("sections = %+v", sections)
+	}
+	if !strings.Contains(sections[0].Body, "## not a heading") {
+		t.Errorf("fenced heading should stay in the body: %q", sections[0].Body)
+	}
+	if sections[1].Body != "Run tests" {
+		t.Errorf("comment lines should be dropped: %q", sections[1].Body)
+	}
+	if v, ok := HandoffFieldValue(sections[0].Body, "bead"); !ok || v != "gt-abc" {
+		t.Errorf("HandoffFieldValue = %q, %v", v, ok)
+	}
+}
+
+func TestHandoffTemplateValidate(t *testing.T) {
+	tmpl := testHandoffTemplate()
+
+	ok := "## Status\nBead: gt-abc\n\n## next steps\nShip it"
+	if problems := tmpl.Validate(ok); len(problems) != 0 {
+		t.Errorf("Validate(complete) = %v", problems)
+	}
+
+	problems := tmpl.Validate("## Status\nBranch: main\n")
+	if len(problems) != 2 {
+		t.Fatalf("Validate() = %v, want missing field and section", problems)
+	}
+	if !strings.Contains(problems[0], `"Bead"`) || !strings.Contains(problems[1], `"Next Steps"`) {
+		t.Errorf("Validate() = %v", problems)
+	}
+
+	// A rendered skeleton is not a filled-in handoff.
+	if problems := tmpl.Validate(tmpl.Render("")); len(problems) != 2 {
+		t.Errorf("Validate(rendered empty) = %v", problems)
+	}
+}
+
+func TestHandoffTemplateRender(t *testing.T) {
+	tmpl := testHandoffTemplate()
+	got := tmpl.Render("Context first.\n\n## Notes\nextra\n\n## Next Steps\nShip it\n\n## Status\nBead: gt-abc")
+	want := "Context first.\n\n" +
+		"## Status\nBead: gt-abc\n- Branch: " + HandoffPlaceholder + "\n\n" +
+		"## Next Steps\nShip it\n\n" +
+		"## Blockers\n" + HandoffPlaceholder + "\n\n" +
+		"## Notes\nextra"
+	if got != want {
+		t.Errorf("Render() =\n%s\nwant\n%s", got, want)
+	}
+	if again := tmpl.Render(got); again != got {
+		t.Errorf("Render should be idempotent, got\n%s", again)
+	}
+
+	skeleton := tmpl.Skeleton()
+	for _, want := range []string{"## Status\n<!-- (required) -->\n- Bead: \n- Branch: ", "## Blockers\n<!-- Anything waiting on others -->"} {
+		if !strings.Contains(skeleton, want) {
+			t.Errorf("Skeleton() missing %q:\n%s", want, skeleton)
+		}
+	}
+}
+
+func TestResolveHandoffTemplate(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+
+	if ResolveHandoffTemplate(townRoot, rigPath, "crew") != nil {
+		t.Fatal("expected no template without settings")
+	}
+
+	town := NewTownSettings()
+	town.HandoffTemplates = map[string]*HandoffTemplate{
+		"default": {Sections: []HandoffSection{{Title: "Town Default"}}},
+		"crew":    {Sections: []HandoffSection{{Title: "Town Crew"}}},
+	}
+	if err := SaveTownSettings(TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+	rig := NewRigSettings()
+	rig.HandoffTemplates = map[string]*HandoffTemplate{
+		"polecat": {Sections: []HandoffSection{{Title: "Rig Polecat"}}},
+	}
+	if err := SaveRigSettings(RigSettingsPath(rigPath), rig); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		rigPath, role, want string
+	}{
+		{rigPath, "polecat", "Rig Polecat"},
+		{rigPath, "crew", "Town Crew"},
+		{rigPath, "witness", "Town Default"},
+		{"", "polecat", "Town Default"},
+	}
+	for _, tt := range tests {
+		got := ResolveHandoffTemplate(townRoot, tt.rigPath, tt.role)
+		if got == nil || got.Sections[0].Title != tt.want {
+			t.Errorf("ResolveHandoffTemplate(%q, %s) = %+v, want %s", tt.rigPath, tt.role, got, tt.want)
+		}
+	}
+}
+
+func TestRigSettingsRejectsBadHandoffTemplate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"type": "rig-settings", "version": 1, "handoff_templates": {"crew": {"sections": [{"title": "A"}, {"title": "a"}]}}}`
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRigSettings(path); !errors.Is(err, ErrInvalidHandoffTemplate) {
+		t.Errorf("LoadRigSettings() err = %v, want ErrInvalidHandoffTemplate", err)
+	}
+}
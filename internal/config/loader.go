@@ -6,8 +6,10 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
@@ -37,7 +39,22 @@ var (
 )
 
 // LoadTownConfig loads and validates a town configuration file.
+//
+// The config is layered over the town's shared config, if it has one (see
+// applySharedTownDefaults). If a town environment is selected (GT_TOWN_ENV),
+// its overlay file is then deep-merged over the result; see applyTownOverlay.
 func LoadTownConfig(path string) (*TownConfig, error) {
+	return loadTownConfig(path, true)
+}
+
+// LoadTownConfigBase loads a town config file without shared defaults or
+// any environment overlay. Use it to edit and save town.json, so those
+// values are not written into the base file.
+func LoadTownConfigBase(path string) (*TownConfig, error) {
+	return loadTownConfig(path, false)
+}
+
+func loadTownConfig(path string, overlay bool) (*TownConfig, error) {
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is from trusted config location
 	if err != nil {
 		if os.IsNotExist(err) {
@@ -45,6 +62,14 @@ func LoadTownConfig(path string) (*TownConfig, error) {
 		}
 		return nil, fmt.Errorf("reading config: %w", err)
 	}
+	if overlay {
+		if data, err = applySharedTownDefaults(path, data); err != nil {
+			return nil, err
+		}
+		if data, err = applyTownOverlay(path, data); err != nil {
+			return nil, err
+		}
+	}
 
 	var config TownConfig
 	if err := json.Unmarshal(data, &config); err != nil {
@@ -135,6 +160,15 @@ func validateTownConfig(c *TownConfig) error {
 	if c.Name == "" {
 		return fmt.Errorf("%w: name", ErrMissingField)
 	}
+	if err := validateCustomRoles(c.Roles); err != nil {
+		return err
+	}
+	if err := validateCheckpointConfig(c.Checkpoints); err != nil {
+		return err
+	}
+	if err := validateTracingConfig(c.Tracing); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -146,6 +180,13 @@ func validateRigsConfig(c *RigsConfig) error {
 	if c.Rigs == nil {
 		c.Rigs = make(map[string]RigEntry)
 	}
+	for name, entry := range c.Rigs {
+		switch entry.VCS {
+		case "", VCSGit, VCSJujutsu:
+		default:
+			return fmt.Errorf("%w: rig %s: vcs must be %q or %q, got %q", ErrInvalidValue, name, VCSGit, VCSJujutsu, entry.VCS)
+		}
+	}
 	return nil
 }
 
@@ -220,9 +261,47 @@ func validateRigSettings(c *RigSettings) error {
 			return err
 		}
 	}
+	if c.Container != nil {
+		if r := c.Container.Runtime; r != "" && r != "docker" && r != "podman" {
+			return fmt.Errorf("%w: got '%s', want 'docker' or 'podman'", ErrInvalidContainerRuntime, r)
+		}
+	}
+	if c.Signing != nil {
+		if f := c.Signing.Format; f != "" && f != "ssh" && f != "gpg" {
+			return fmt.Errorf("%w: got '%s', want 'ssh' or 'gpg'", ErrInvalidSigningFormat, f)
+		}
+	}
+	if c.Branches != nil {
+		for _, pattern := range c.Branches.Protected {
+			if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+				return fmt.Errorf("%w: %q", ErrInvalidProtectedBranch, pattern)
+			}
+		}
+	}
+	if err := validateSessionEnv(c.SessionEnv); err != nil {
+		return err
+	}
+	if err := validateSecrets(c.Secrets); err != nil {
+		return err
+	}
+	if err := validateHandoffTemplates(c.HandoffTemplates); err != nil {
+		return err
+	}
 	return nil
 }
 
+// ErrInvalidContainerRuntime indicates an unsupported container runtime.
+var ErrInvalidContainerRuntime = errors.New("invalid container runtime")
+
+// ErrInvalidSigningFormat indicates an unsupported commit signature format.
+var ErrInvalidSigningFormat = errors.New("invalid signing format")
+
+// ErrInvalidProtectedBranch indicates a malformed protected branch pattern.
+var ErrInvalidProtectedBranch = errors.New("invalid protected branch pattern")
+
+// ErrInvalidSessionEnv indicates a session_env variable that is malformed or reserved.
+var ErrInvalidSessionEnv = errors.New("invalid session_env variable")
+
 // ErrInvalidOnConflict indicates an invalid on_conflict strategy.
 var ErrInvalidOnConflict = errors.New("invalid on_conflict strategy")
 
@@ -680,6 +759,36 @@ func AddRigToDaemonPatrols(townRoot string, rigName string) error {
 // in daemon.json. Uses raw JSON manipulation to preserve fields not in PatrolConfig
 // (e.g., dolt_server config). If daemon.json doesn't exist, this is a no-op.
 func RemoveRigFromDaemonPatrols(townRoot string, rigName string) error {
+	return editDaemonPatrolRigs(townRoot, func(rigs []string) []string {
+		var filtered []string
+		for _, r := range rigs {
+			if r != rigName {
+				filtered = append(filtered, r)
+			}
+		}
+		return filtered
+	})
+}
+
+// RenameRigInDaemonPatrols renames a rig in the witness and refinery patrol rigs
+// arrays in daemon.json, keeping its position. Patrols the rig isn't in (e.g. while
+// docked) are left alone. If daemon.json doesn't exist, this is a no-op.
+func RenameRigInDaemonPatrols(townRoot, oldName, newName string) error {
+	return editDaemonPatrolRigs(townRoot, func(rigs []string) []string {
+		renamed := make([]string, len(rigs))
+		for i, r := range rigs {
+			if r == oldName {
+				r = newName
+			}
+			renamed[i] = r
+		}
+		return renamed
+	})
+}
+
+// editDaemonPatrolRigs applies edit to the rigs array of the witness and refinery
+// patrols in daemon.json, writing the file only if a list changed.
+func editDaemonPatrolRigs(townRoot string, edit func(rigs []string) []string) error {
 	path := DaemonPatrolConfigPath(townRoot)
 	data, err := os.ReadFile(path) //nolint:gosec // G304: path is constructed internally
 	if err != nil {
@@ -724,20 +833,13 @@ func RemoveRigFromDaemonPatrols(townRoot string, rigName string) error {
 			}
 		}
 
-		// Filter out the rig
-		var filtered []string
-		for _, r := range rigs {
-			if r != rigName {
-				filtered = append(filtered, r)
-			}
-		}
-
-		if len(filtered) == len(rigs) {
+		edited := edit(rigs)
+		if slices.Equal(edited, rigs) {
 			continue // Rig wasn't present
 		}
 
-		// Update with filtered list
-		rigsJSON, err := json.Marshal(filtered)
+		// Update with edited list
+		rigsJSON, err := json.Marshal(edited)
 		if err != nil {
 			return fmt.Errorf("encoding rigs: %w", err)
 		}
@@ -1316,7 +1418,8 @@ func lookupAgentConfigIfExists(name string, townSettings *TownSettings, rigSetti
 //  3. Fall back to ResolveAgentConfig (rig's Agent → town's DefaultAgent → "claude")
 //
 // If a configured agent is not found or its binary doesn't exist, a warning is
-// printed to stderr and it falls back to the default agent.
+// printed to stderr and it falls back to the default agent. The role's
+// RoleDefaults (model, args, env, hooks) are then applied to the result.
 //
 // role is one of: "mayor", "deacon", "witness", "refinery", "polecat", "crew", "boot".
 // townRoot is the path to the town directory (e.g., ~/gt).
@@ -1325,6 +1428,7 @@ func ResolveRoleAgentConfig(role, townRoot, rigPath string) *RuntimeConfig {
 	resolveConfigMu.Lock()
 	defer resolveConfigMu.Unlock()
 	rc := resolveRoleAgentConfigCore(role, townRoot, rigPath)
+	rc = withRoleDefaults(rc, role, townRoot, rigPath)
 	return withRoleSettingsFlag(rc, role, rigPath)
 }
 
@@ -1368,7 +1472,7 @@ func ResolveWorkerAgentConfig(workerName, townRoot, rigPath string) *RuntimeConf
 				_ = LoadAgentRegistry(DefaultAgentRegistryPath(townRoot))
 				_ = LoadRigAgentRegistry(RigAgentRegistryPath(rigPath))
 				if rc := tryResolveNamedAgent(agentName, fmt.Sprintf("worker_agents[%s]", workerName), townSettings, rigSettings); rc != nil {
-					return withRoleSettingsFlag(rc, "crew", rigPath)
+					return withRoleSettingsFlag(withRoleDefaults(rc, "crew", townRoot, rigPath), "crew", rigPath)
 				}
 			}
 		}
@@ -1388,7 +1492,7 @@ func ResolveWorkerAgentConfig(workerName, townRoot, rigPath string) *RuntimeConf
 					_ = LoadRigAgentRegistry(RigAgentRegistryPath(rigPath))
 				}
 				if rc := tryResolveNamedAgent(agentName, fmt.Sprintf("crew_agents[%s]", workerName), townSettings, rigSettings); rc != nil {
-					return withRoleSettingsFlag(rc, "crew", rigPath)
+					return withRoleSettingsFlag(withRoleDefaults(rc, "crew", townRoot, rigPath), "crew", rigPath)
 				}
 			}
 		}
@@ -1396,6 +1500,7 @@ func ResolveWorkerAgentConfig(workerName, townRoot, rigPath string) *RuntimeConf
 
 	// Tier 3: fall back to crew role resolution (already holds lock; use core function)
 	rc := resolveRoleAgentConfigCore("crew", townRoot, rigPath)
+	rc = withRoleDefaults(rc, "crew", townRoot, rigPath)
 	return withRoleSettingsFlag(rc, "crew", rigPath)
 }
 
@@ -1668,44 +1773,17 @@ func resolveRoleAgentConfigCore(role, townRoot, rigPath string) *RuntimeConfig {
 // (GT_COST_TIER env var). It reflects persisted config only. For the actual
 // runtime agent config, use ResolveRoleAgentConfig.
 func ResolveRoleAgentName(role, townRoot, rigPath string) (agentName string, isRoleSpecific bool) {
-	// Load rig settings
 	var rigSettings *RigSettings
 	if rigPath != "" {
-		var err error
-		rigSettings, err = LoadRigSettings(RigSettingsPath(rigPath))
-		if err != nil {
-			rigSettings = nil
-		}
+		rigSettings, _ = LoadRigSettings(RigSettingsPath(rigPath))
 	}
-
-	// Load town settings
 	townSettings, err := LoadOrCreateTownSettings(TownSettingsPath(townRoot))
 	if err != nil {
 		townSettings = NewTownSettings()
 	}
 
-	// Check rig's RoleAgents first
-	if rigSettings != nil && rigSettings.RoleAgents != nil {
-		if name, ok := rigSettings.RoleAgents[role]; ok && name != "" {
-			return name, true
-		}
-	}
-
-	// Check town's RoleAgents
-	if townSettings.RoleAgents != nil {
-		if name, ok := townSettings.RoleAgents[role]; ok && name != "" {
-			return name, true
-		}
-	}
-
-	// Fall back to existing resolution
-	if rigSettings != nil && rigSettings.Agent != "" {
-		return rigSettings.Agent, false
-	}
-	if townSettings.DefaultAgent != "" {
-		return townSettings.DefaultAgent, false
-	}
-	return "claude", false
+	name, source := roleAgentNameWithSource(role, "", townSettings, rigSettings)
+	return name, strings.HasSuffix(source, "role_agents."+role)
 }
 
 // ResolveAgentConfigByName looks up an agent's RuntimeConfig by name without requiring
@@ -2702,6 +2780,20 @@ func GetRigPrefix(townRoot, rigName string) string {
 	return strings.TrimSuffix(prefix, "-")
 }
 
+// IsRigDisabled reports whether a rig is disabled for maintenance in
+// rigs.json, and the reason given when it was disabled.
+func IsRigDisabled(townRoot, rigName string) (bool, string) {
+	rigsConfig, err := LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return false, ""
+	}
+	entry, ok := rigsConfig.Rigs[rigName]
+	if !ok || !entry.Disabled {
+		return false, ""
+	}
+	return true, entry.DisabledReason
+}
+
 // AllRigPrefixes returns a sorted list of all rig beads prefixes from rigs.json.
 // Trailing hyphens are stripped (e.g. "gt-" becomes "gt").
 // Returns nil on error (caller should handle the fallback).
@@ -2,10 +2,12 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -125,6 +127,17 @@ func TestRigsConfigRoundTrip(t *testing.T) {
 	}
 }
 
+func TestLoadRigsConfigRejectsUnknownVCS(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "rigs.json")
+	if err := os.WriteFile(path, []byte(`{"version":1,"rigs":{"gastown":{"git_url":"x","vcs":"hg"}}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRigsConfig(path); !errors.Is(err, ErrInvalidValue) {
+		t.Errorf("LoadRigsConfig with vcs=hg: err = %v, want ErrInvalidValue", err)
+	}
+}
+
 func TestLoadTownConfigNotFound(t *testing.T) {
 	t.Parallel()
 	_, err := LoadTownConfig("/nonexistent/path.json")
@@ -146,6 +159,35 @@ func TestValidationErrors(t *testing.T) {
 	if err := validateTownConfig(tc); err == nil {
 		t.Error("expected error for wrong type")
 	}
+
+	// Sample ratio out of range
+	ratio := 1.5
+	tc = &TownConfig{Type: "town", Version: 1, Name: "test",
+		Tracing: &TracingConfig{Endpoint: "http://localhost:4318/v1/traces", SampleRatio: &ratio}}
+	if err := validateTownConfig(tc); !errors.Is(err, ErrInvalidTracingConfig) {
+		t.Errorf("sample_ratio 1.5: err = %v, want ErrInvalidTracingConfig", err)
+	}
+
+	// Endpoint must be an OTLP HTTP URL, not a bare gRPC host:port
+	tc = &TownConfig{Type: "town", Version: 1, Name: "test",
+		Tracing: &TracingConfig{Endpoint: "localhost:4317"}}
+	if err := validateTownConfig(tc); !errors.Is(err, ErrInvalidTracingConfig) {
+		t.Errorf("endpoint localhost:4317: err = %v, want ErrInvalidTracingConfig", err)
+	}
+}
+
+func TestTracingConfigDefaults(t *testing.T) {
+	t.Parallel()
+	var nilCfg *TracingConfig
+	if nilCfg.Enabled() || nilCfg.Ratio() != 1 {
+		t.Error("nil tracing config should be disabled with ratio 1")
+	}
+	if (&TracingConfig{}).Enabled() {
+		t.Error("tracing without an endpoint should be disabled")
+	}
+	if !(&TracingConfig{Endpoint: "http://localhost:4318/v1/traces"}).Enabled() {
+		t.Error("tracing with an endpoint should be enabled")
+	}
 }
 
 func TestRigConfigRoundTrip(t *testing.T) {
@@ -2681,6 +2723,52 @@ func TestRemoveRigFromDaemonPatrols(t *testing.T) {
 		}
 	})
 }
+func TestRenameRigInDaemonPatrols(t *testing.T) {
+	t.Parallel()
+	townRoot := t.TempDir()
+	mayorDir := filepath.Join(townRoot, "mayor")
+	if err := os.MkdirAll(mayorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// "beads" is docked: only in the witness patrol. Renaming must not add it
+	// to the refinery patrol.
+	daemonJSON := `{
+  "type": "daemon-patrol-config",
+  "version": 1,
+  "patrols": {
+    "witness": {"enabled": true, "rigs": ["gastown", "beads", "myrig"]},
+    "refinery": {"enabled": true, "rigs": ["gastown", "myrig"]}
+  },
+  "dolt_server": {"port": 3307}
+}`
+	if err := os.WriteFile(filepath.Join(mayorDir, "daemon.json"), []byte(daemonJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RenameRigInDaemonPatrols(townRoot, "beads", "issues"); err != nil {
+		t.Fatalf("RenameRigInDaemonPatrols: %v", err)
+	}
+
+	cfg, err := LoadDaemonPatrolConfig(DaemonPatrolConfigPath(townRoot))
+	if err != nil {
+		t.Fatalf("LoadDaemonPatrolConfig: %v", err)
+	}
+	if got := cfg.Patrols["witness"].Rigs; !slices.Equal(got, []string{"gastown", "issues", "myrig"}) {
+		t.Errorf("witness rigs = %v, want [gastown issues myrig]", got)
+	}
+	if got := cfg.Patrols["refinery"].Rigs; !slices.Equal(got, []string{"gastown", "myrig"}) {
+		t.Errorf("refinery rigs = %v, want [gastown myrig]", got)
+	}
+
+	data, err := os.ReadFile(filepath.Join(mayorDir, "daemon.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "dolt_server") {
+		t.Errorf("dolt_server dropped:\n%s", data)
+	}
+}
 
 func TestSaveTownSettings(t *testing.T) {
 	t.Parallel()
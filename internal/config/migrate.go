@@ -0,0 +1,253 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// Migration upgrades one config file by one schema version. Migrations work
+// on the raw JSON document rather than the Go type, so they can read fields
+// the current type no longer has.
+//
+// To change a versioned file's format: bump its Current*Version constant and
+// append a Migration whose From is the previous version.
+type Migration struct {
+	// File is the town-relative path of the file migrated, e.g. "mayor/town.json".
+	File string
+
+	// From is the version this migration upgrades; the result is From+1.
+	From int
+
+	// Description says what changes, shown by 'gt config migrate'.
+	Description string
+
+	// Apply edits doc in place. nil if only the version number changes.
+	Apply func(doc map[string]any) error
+}
+
+// Files with versioned schemas that migrations keep up to date.
+var (
+	townConfigFile  = filepath.Join(constants.DirMayor, "town.json")
+	rigsConfigFile  = filepath.Join(constants.DirMayor, "rigs.json")
+	quotaConfigFile = filepath.Join(constants.DirMayor, constants.FileQuotaJSON)
+)
+
+// migratedFileVersions maps each migrated file to its current version.
+var migratedFileVersions = map[string]int{
+	townConfigFile:  CurrentTownVersion,
+	rigsConfigFile:  CurrentRigsVersion,
+	quotaConfigFile: CurrentQuotaVersion,
+}
+
+// migrations is the ordered migration history. Never edit or remove an
+// entry that has shipped: towns may still be on any earlier version.
+var migrations = []Migration{
+	{
+		File:        townConfigFile,
+		From:        0,
+		Description: "record schema version",
+	},
+	{
+		File:        townConfigFile,
+		From:        1,
+		Description: "add public_name (federation identity), defaulting to the town name",
+		Apply: func(doc map[string]any) error {
+			if _, ok := doc["public_name"]; !ok {
+				if name, ok := doc["name"].(string); ok && name != "" {
+					doc["public_name"] = name
+				}
+			}
+			return nil
+		},
+	},
+	{
+		File:        rigsConfigFile,
+		From:        0,
+		Description: "record schema version",
+	},
+	{
+		File:        quotaConfigFile,
+		From:        0,
+		Description: "record schema version",
+	},
+}
+
+// MigrationStep is one migration applied (or, in a dry run, pending) to a file.
+type MigrationStep struct {
+	File        string // town-relative path
+	From, To    int
+	Description string
+}
+
+// MigrateOptions controls MigrateTown.
+type MigrateOptions struct {
+	// DryRun reports the pending steps without writing anything.
+	DryRun bool
+}
+
+// MigrateResult describes what MigrateTown did.
+type MigrateResult struct {
+	Steps   []MigrationStep
+	Backups []string // backup files written, absolute paths
+}
+
+// ErrNoMigration means a file is at a version no migration starts from.
+var ErrNoMigration = errors.New("no migration path")
+
+// MigrateTown brings every versioned config file under townRoot up to its
+// current schema version. Each file that changes is first copied to
+// "<file>.v<old version>.bak" (an existing backup is kept, so the oldest
+// original survives repeated runs). Missing files are skipped. A file newer
+// than this gt understands is an ErrInvalidVersion error: downgrades are not
+// supported.
+func MigrateTown(townRoot string, opts MigrateOptions) (*MigrateResult, error) {
+	result := &MigrateResult{}
+	files := make([]string, 0, len(migratedFileVersions))
+	for file := range migratedFileVersions {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	for _, file := range files {
+		path := filepath.Join(townRoot, file)
+		data, err := os.ReadFile(path) //nolint:gosec // G304: fixed town config paths
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return result, fmt.Errorf("reading %s: %w", file, err)
+		}
+
+		doc, steps, err := migrateDocument(file, data)
+		if err != nil {
+			return result, err
+		}
+		if len(steps) == 0 {
+			continue
+		}
+		result.Steps = append(result.Steps, steps...)
+		if opts.DryRun {
+			continue
+		}
+
+		backup, err := backupConfigFile(path, data, steps[0].From)
+		if err != nil {
+			return result, fmt.Errorf("backing up %s: %w", file, err)
+		}
+		if backup != "" {
+			result.Backups = append(result.Backups, backup)
+		}
+		out, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			return result, fmt.Errorf("encoding %s: %w", file, err)
+		}
+		perm := os.FileMode(0644)
+		if info, err := os.Stat(path); err == nil {
+			perm = info.Mode().Perm()
+		}
+		if err := os.WriteFile(path, append(out, '\n'), perm); err != nil {
+			return result, fmt.Errorf("writing %s: %w", file, err)
+		}
+	}
+	return result, nil
+}
+
+// PendingMigrations returns the migrations MigrateTown would apply.
+func PendingMigrations(townRoot string) ([]MigrationStep, error) {
+	result, err := MigrateTown(townRoot, MigrateOptions{DryRun: true})
+	if err != nil {
+		return nil, err
+	}
+	return result.Steps, nil
+}
+
+// migrateDocument applies file's pending migrations to data and returns the
+// upgraded document and the steps taken.
+func migrateDocument(file string, data []byte) (map[string]any, []MigrationStep, error) {
+	var doc map[string]any
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, nil, fmt.Errorf("parsing %s: %w", file, err)
+	}
+	if doc == nil {
+		return nil, nil, fmt.Errorf("parsing %s: not a JSON object", file)
+	}
+
+	version, err := documentVersion(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", file, err)
+	}
+	current := migratedFileVersions[file]
+	if version > current {
+		return nil, nil, fmt.Errorf("%w: %s is version %d, max supported %d (upgrade gt)", ErrInvalidVersion, file, version, current)
+	}
+
+	var steps []MigrationStep
+	for version < current {
+		m := findMigration(file, version)
+		if m == nil {
+			return nil, nil, fmt.Errorf("%w: %s from version %d", ErrNoMigration, file, version)
+		}
+		if m.Apply != nil {
+			if err := m.Apply(doc); err != nil {
+				return nil, nil, fmt.Errorf("migrating %s from version %d: %w", file, version, err)
+			}
+		}
+		doc["version"] = version + 1
+		steps = append(steps, MigrationStep{File: file, From: version, To: version + 1, Description: m.Description})
+		version++
+	}
+	return doc, steps, nil
+}
+
+// documentVersion returns doc's "version" field; a missing one is version 0.
+func documentVersion(doc map[string]any) (int, error) {
+	raw, ok := doc["version"]
+	if !ok || raw == nil {
+		return 0, nil
+	}
+	n, ok := raw.(json.Number)
+	if !ok {
+		return 0, fmt.Errorf("version is not a number: %v", raw)
+	}
+	v, err := n.Int64()
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("version is not a non-negative integer: %s", n)
+	}
+	return int(v), nil
+}
+
+func findMigration(file string, from int) *Migration {
+	for i := range migrations {
+		if migrations[i].File == file && migrations[i].From == from {
+			return &migrations[i]
+		}
+	}
+	return nil
+}
+
+// backupConfigFile saves data as path.v<version>.bak unless that backup
+// already exists. It returns the backup path, or "" if one was kept.
+func backupConfigFile(path string, data []byte, version int) (string, error) {
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	f, err := os.OpenFile(backup, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600) //nolint:gosec // G304: derived from town config path
+	if errors.Is(err, os.ErrExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		return "", err
+	}
+	return backup, f.Close()
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTownFile(t *testing.T, townRoot, file, content string) string {
+	t.Helper()
+	path := filepath.Join(townRoot, file)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestMigrations_CompleteChains checks every migrated file has a migration
+// from each version below its current one, so no town can get stuck.
+func TestMigrations_CompleteChains(t *testing.T) {
+	for file, current := range migratedFileVersions {
+		for v := 0; v < current; v++ {
+			if findMigration(file, v) == nil {
+				t.Errorf("%s: no migration from version %d (current %d)", file, v, current)
+			}
+		}
+	}
+	for _, m := range migrations {
+		if _, ok := migratedFileVersions[m.File]; !ok {
+			t.Errorf("migration for unregistered file %s", m.File)
+		}
+		if m.From >= migratedFileVersions[m.File] {
+			t.Errorf("%s: migration from %d is past the current version", m.File, m.From)
+		}
+	}
+}
+
+func TestMigrateTown(t *testing.T) {
+	townRoot := t.TempDir()
+	townPath := writeTownFile(t, townRoot, townConfigFile, `{"type":"town","version":1,"name":"hq","created_at":"2025-01-01T00:00:00Z"}`)
+	rigsPath := writeTownFile(t, townRoot, rigsConfigFile, `{"rigs":{"app":{"git_url":"https://x/app.git"}}}`)
+
+	result, err := MigrateTown(townRoot, MigrateOptions{})
+	if err != nil {
+		t.Fatalf("MigrateTown: %v", err)
+	}
+	if len(result.Steps) != 2 {
+		t.Fatalf("steps = %+v, want rigs 0->1 and town 1->2", result.Steps)
+	}
+	if len(result.Backups) != 2 {
+		t.Errorf("backups = %v, want one per migrated file", result.Backups)
+	}
+
+	town, err := LoadTownConfig(townPath)
+	if err != nil {
+		t.Fatalf("migrated town.json does not load: %v", err)
+	}
+	if town.Version != CurrentTownVersion || town.PublicName != "hq" {
+		t.Errorf("town = version %d, public_name %q; want %d, %q", town.Version, town.PublicName, CurrentTownVersion, "hq")
+	}
+	rigs, err := LoadRigsConfig(rigsPath)
+	if err != nil {
+		t.Fatalf("migrated rigs.json does not load: %v", err)
+	}
+	if rigs.Version != CurrentRigsVersion || rigs.Rigs["app"].GitURL != "https://x/app.git" {
+		t.Errorf("rigs = %+v", rigs)
+	}
+
+	backup, err := os.ReadFile(townPath + ".v1.bak")
+	if err != nil {
+		t.Fatalf("reading backup: %v", err)
+	}
+	if !strings.Contains(string(backup), `"version":1`) {
+		t.Errorf("backup should hold the original, got %s", backup)
+	}
+
+	// Already current: nothing to do.
+	again, err := MigrateTown(townRoot, MigrateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(again.Steps) != 0 || len(again.Backups) != 0 {
+		t.Errorf("second run = %+v, want no-op", again)
+	}
+}
+
+func TestMigrateTown_DryRun(t *testing.T) {
+	townRoot := t.TempDir()
+	original := `{"type":"town","version":1,"name":"hq"}`
+	townPath := writeTownFile(t, townRoot, townConfigFile, original)
+
+	steps, err := PendingMigrations(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 1 || steps[0].From != 1 || steps[0].To != 2 {
+		t.Errorf("pending = %+v, want town 1->2", steps)
+	}
+	data, _ := os.ReadFile(townPath)
+	if string(data) != original {
+		t.Errorf("dry run modified town.json: %s", data)
+	}
+	if _, err := os.Stat(townPath + ".v1.bak"); !os.IsNotExist(err) {
+		t.Error("dry run wrote a backup")
+	}
+}
+
+func TestMigrateTown_NewerVersion(t *testing.T) {
+	townRoot := t.TempDir()
+	writeTownFile(t, townRoot, townConfigFile, `{"type":"town","version":99,"name":"hq"}`)
+
+	_, err := MigrateTown(townRoot, MigrateOptions{})
+	if !errors.Is(err, ErrInvalidVersion) {
+		t.Errorf("err = %v, want ErrInvalidVersion", err)
+	}
+}
+
+func TestMigrateTown_KeepsExistingBackup(t *testing.T) {
+	townRoot := t.TempDir()
+	townPath := writeTownFile(t, townRoot, townConfigFile, `{"type":"town","version":1,"name":"hq"}`)
+	writeTownFile(t, townRoot, townConfigFile+".v1.bak", "oldest")
+
+	result, err := MigrateTown(townRoot, MigrateOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Backups) != 0 {
+		t.Errorf("backups = %v, want the existing one kept", result.Backups)
+	}
+	if data, _ := os.ReadFile(townPath + ".v1.bak"); string(data) != "oldest" {
+		t.Errorf("existing backup overwritten: %s", data)
+	}
+}
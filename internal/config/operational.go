@@ -86,6 +86,7 @@ const (
 	DefaultDoltCmdTimeout          = 15 * time.Second
 	DefaultDoltMaxConnections      = 1000
 	DefaultDoltSlowQueryThreshold  = 1 * time.Second
+	DefaultBeadQueryCacheTTL       = 5 * time.Second
 )
 
 // Mail defaults.
@@ -598,6 +599,14 @@ func (dt *DoltThresholds) SlowQueryThresholdD() time.Duration {
 	return DefaultDoltSlowQueryThreshold
 }
 
+// QueryCacheTTLD returns the configured or default bead query cache TTL.
+func (dt *DoltThresholds) QueryCacheTTLD() time.Duration {
+	if dt != nil {
+		return ParseDurationOrDefault(dt.QueryCacheTTL, DefaultBeadQueryCacheTTL)
+	}
+	return DefaultBeadQueryCacheTTL
+}
+
 // --- Mail accessors ---
 
 // GetMailConfig returns the mail thresholds, never nil.
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// TownEnvVar selects a town config overlay: with GT_TOWN_ENV=prod,
+// mayor/town.prod.json is deep-merged over mayor/town.json. 'gt --town-env'
+// sets it for one command (and the agents it starts).
+const TownEnvVar = "GT_TOWN_ENV"
+
+// ErrInvalidTownEnv indicates a malformed or missing town config overlay.
+var ErrInvalidTownEnv = errors.New("invalid town environment")
+
+var townEnvPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// TownEnv returns the selected town environment, or "" for none.
+func TownEnv() string {
+	return strings.TrimSpace(os.Getenv(TownEnvVar))
+}
+
+// TownOverlayPath returns the overlay file for env next to the town config
+// at path: mayor/town.json -> mayor/town.<env>.json.
+func TownOverlayPath(path, env string) string {
+	ext := filepath.Ext(path)
+	return strings.TrimSuffix(path, ext) + "." + env + ext
+}
+
+// applyTownOverlay deep-merges the selected environment's overlay over the
+// town config data read from path. Without a selected environment, data is
+// returned unchanged. Selecting an environment with no overlay file is an
+// error: running prod with dev's settings by mistake is worse than failing.
+func applyTownOverlay(path string, data []byte) ([]byte, error) {
+	env := TownEnv()
+	if env == "" {
+		return data, nil
+	}
+	if !townEnvPattern.MatchString(env) {
+		return nil, fmt.Errorf("%w: %s=%q (letters, digits, '-' and '_' only)", ErrInvalidTownEnv, TownEnvVar, env)
+	}
+	overlayPath := TownOverlayPath(path, env)
+	overlayData, err := os.ReadFile(overlayPath) //nolint:gosec // G304: derived from the town config path
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s=%s but %s does not exist", ErrInvalidTownEnv, TownEnvVar, env, overlayPath)
+		}
+		return nil, fmt.Errorf("reading overlay: %w", err)
+	}
+
+	var base, overlay map[string]any
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+	if err := json.Unmarshal(overlayData, &overlay); err != nil {
+		return nil, fmt.Errorf("parsing overlay %s: %w", filepath.Base(overlayPath), err)
+	}
+	return json.Marshal(mergeOverlay(base, overlay))
+}
+
+// mergeOverlay merges overlay into base and returns base. Objects merge key
+// by key, recursively; any other overlay value (including a list) replaces
+// the base value, and null removes it.
+func mergeOverlay(base, overlay map[string]any) map[string]any {
+	if base == nil {
+		base = make(map[string]any)
+	}
+	for key, value := range overlay {
+		if value == nil {
+			delete(base, key)
+			continue
+		}
+		if sub, ok := value.(map[string]any); ok {
+			if baseSub, ok := base[key].(map[string]any); ok {
+				base[key] = mergeOverlay(baseSub, sub)
+				continue
+			}
+		}
+		base[key] = value
+	}
+	return base
+}
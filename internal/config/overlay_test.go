@@ -0,0 +1,94 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestMergeOverlay(t *testing.T) {
+	base := map[string]any{
+		"name":  "hq",
+		"owner": "dev@example.com",
+		"hooks": map[string]any{"pre_start": []any{"a"}, "post_start": []any{"b"}},
+		"roles": map[string]any{"reviewer": map[string]any{"dir": "reviewers"}},
+	}
+	overlay := map[string]any{
+		"owner": "ops@example.com",
+		"hooks": map[string]any{"pre_start": []any{"c", "d"}},
+		"roles": nil,
+	}
+	want := map[string]any{
+		"name":  "hq",
+		"owner": "ops@example.com",
+		"hooks": map[string]any{"pre_start": []any{"c", "d"}, "post_start": []any{"b"}},
+	}
+	if got := mergeOverlay(base, overlay); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeOverlay =\n  %v\nwant\n  %v", got, want)
+	}
+}
+
+func TestLoadTownConfig_Overlay(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "town.json")
+	if err := os.WriteFile(path, []byte(`{"type":"town","version":2,"name":"hq","owner":"dev@example.com","public_name":"HQ"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(TownOverlayPath(path, "prod"), []byte(`{"owner":"ops@example.com"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(TownEnvVar, "")
+	cfg, err := LoadTownConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Owner != "dev@example.com" {
+		t.Errorf("no env: owner = %q, want base value", cfg.Owner)
+	}
+
+	t.Setenv(TownEnvVar, "prod")
+	cfg, err = LoadTownConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Owner != "ops@example.com" || cfg.PublicName != "HQ" {
+		t.Errorf("prod: owner = %q, public_name = %q; want overlay owner and base public_name", cfg.Owner, cfg.PublicName)
+	}
+
+	base, err := LoadTownConfigBase(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if base.Owner != "dev@example.com" {
+		t.Errorf("LoadTownConfigBase applied the overlay: owner = %q", base.Owner)
+	}
+
+	for _, env := range []string{"staging", "../prod"} {
+		t.Setenv(TownEnvVar, env)
+		if _, err := LoadTownConfig(path); !errors.Is(err, ErrInvalidTownEnv) {
+			t.Errorf("%s=%q: err = %v, want ErrInvalidTownEnv", TownEnvVar, env, err)
+		}
+	}
+}
+
+func TestValidateTownSchemas_Overlays(t *testing.T) {
+	t.Setenv(TownEnvVar, "")
+	townRoot := t.TempDir()
+	writeTownFile(t, townRoot, townConfigFile, `{"type":"town","version":2,"name":"hq"}`)
+	writeTownFile(t, townRoot, filepath.Join("mayor", "town.dev.json"), `{"owner":"dev@example.com","ownr":"x"}`)
+
+	issues, checked, err := ValidateTownSchemas(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checked) != 2 {
+		t.Errorf("checked = %v, want town.json and town.dev.json", checked)
+	}
+	// The overlay needs no required fields, but unknown ones are still caught.
+	if len(issues) != 1 || issues[0].Path != "ownr" {
+		t.Errorf("issues = %v, want only the unknown ownr field", issueStrings(issues))
+	}
+}
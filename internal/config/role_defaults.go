@@ -0,0 +1,469 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// RoleDefaults are agent session settings layered from town to rig to role.
+// They are set in town and rig settings (settings/config.json) under
+// "defaults" for every role and under "roles" per role, and per rig in the
+// rig's mayor/rigs.json entry under "roles".
+//
+// Each setting comes from the most specific layer that sets it, in the same
+// order as role_agents:
+//  1. Rig roles[role]
+//  2. rigs.json <rig>.roles[role]
+//  3. Town roles[role]
+//  4. Rig defaults
+//  5. Town defaults
+//
+// Env is layered per variable and hooks per field; the other settings are
+// replaced whole.
+type RoleDefaults struct {
+	// Model selects the model for Claude agents, passed as --model and
+	// replacing any model the agent preset sets.
+	Model string `json:"model,omitempty"`
+
+	// Args are extra flags appended to the agent command.
+	Args []string `json:"args,omitempty"`
+
+	// PermissionMode sets Claude's --permission-mode (e.g. "acceptEdits",
+	// "plan", "bypassPermissions"), replacing --dangerously-skip-permissions.
+	PermissionMode string `json:"permission_mode,omitempty"`
+
+	// ConfigDir is the agent's config directory, exported through the
+	// agent's config dir variable (CLAUDE_CONFIG_DIR for Claude). It takes
+	// precedence over the account's config dir.
+	ConfigDir string `json:"config_dir,omitempty"`
+
+	// Env sets environment variables for the agent process.
+	Env map[string]string `json:"env,omitempty"`
+
+	// Hooks overrides the agent's hook installation settings.
+	Hooks *RuntimeHooksConfig `json:"hooks,omitempty"`
+}
+
+// roleDefaultsLayer is one layer of RoleDefaults and its source name.
+type roleDefaultsLayer struct {
+	source   string
+	defaults *RoleDefaults
+}
+
+// roleDefaultsLayers returns the RoleDefaults layers for role, least
+// specific first. Either settings and entryRoles (the rig's rigs.json
+// roles) may be nil.
+func roleDefaultsLayers(role string, townSettings *TownSettings, rigSettings *RigSettings, entryRoles map[string]*RoleDefaults) []roleDefaultsLayer {
+	var layers []roleDefaultsLayer
+	add := func(source string, d *RoleDefaults) {
+		if d != nil {
+			layers = append(layers, roleDefaultsLayer{source: source, defaults: d})
+		}
+	}
+	if townSettings != nil {
+		add("town defaults", townSettings.Defaults)
+	}
+	if rigSettings != nil {
+		add("rig defaults", rigSettings.Defaults)
+	}
+	if townSettings != nil {
+		add("town roles."+role, townSettings.Roles[role])
+	}
+	add("rigs.json roles."+role, entryRoles[role])
+	if rigSettings != nil {
+		add("rig roles."+role, rigSettings.Roles[role])
+	}
+	return layers
+}
+
+// mergeRoleDefaults resolves the layers into one RoleDefaults, recording
+// the layer each setting came from in sources, keyed by setting name
+// ("model", "args", "permission_mode", "config_dir", "env.<NAME>",
+// "hooks.<field>").
+func mergeRoleDefaults(layers []roleDefaultsLayer) (*RoleDefaults, map[string]string) {
+	merged := &RoleDefaults{}
+	sources := make(map[string]string)
+	for _, l := range layers {
+		d := l.defaults
+		if d.Model != "" {
+			merged.Model = d.Model
+			sources["model"] = l.source
+		}
+		if d.Args != nil {
+			merged.Args = append([]string(nil), d.Args...)
+			sources["args"] = l.source
+		}
+		if d.PermissionMode != "" {
+			merged.PermissionMode = d.PermissionMode
+			sources["permission_mode"] = l.source
+		}
+		if d.ConfigDir != "" {
+			merged.ConfigDir = d.ConfigDir
+			sources["config_dir"] = l.source
+		}
+		for k, v := range d.Env {
+			if merged.Env == nil {
+				merged.Env = make(map[string]string)
+			}
+			merged.Env[k] = v
+			sources["env."+k] = l.source
+		}
+		if h := d.Hooks; h != nil {
+			if merged.Hooks == nil {
+				merged.Hooks = &RuntimeHooksConfig{}
+			}
+			if h.Provider != "" {
+				merged.Hooks.Provider = h.Provider
+				sources["hooks.provider"] = l.source
+			}
+			if h.Dir != "" {
+				merged.Hooks.Dir = h.Dir
+				sources["hooks.dir"] = l.source
+			}
+			if h.SettingsFile != "" {
+				merged.Hooks.SettingsFile = h.SettingsFile
+				sources["hooks.settings_file"] = l.source
+			}
+			if h.Informational {
+				merged.Hooks.Informational = true
+				sources["hooks.informational"] = l.source
+			}
+		}
+	}
+	return merged, sources
+}
+
+// ResolveRoleDefaults returns the RoleDefaults for role from town and rig
+// settings and the rig's rigs.json entry, and the layer each setting came
+// from. rigPath may be empty for town-level roles.
+func ResolveRoleDefaults(role, townRoot, rigPath string) (*RoleDefaults, map[string]string) {
+	townSettings, rigSettings := loadLayeredSettings(townRoot, rigPath)
+	return mergeRoleDefaults(roleDefaultsLayers(role, townSettings, rigSettings, loadRigEntryRoles(townRoot, rigPath)))
+}
+
+// loadRigEntryRoles returns the per-role settings in the rigs.json entry
+// for the rig at rigPath, or nil if there are none.
+func loadRigEntryRoles(townRoot, rigPath string) map[string]*RoleDefaults {
+	if townRoot == "" || rigPath == "" {
+		return nil
+	}
+	rigsConfig, err := LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil
+	}
+	return rigsConfig.Rigs[filepath.Base(rigPath)].Roles
+}
+
+// loadLayeredSettings loads town and rig settings, either of which may be
+// nil if missing or invalid.
+func loadLayeredSettings(townRoot, rigPath string) (*TownSettings, *RigSettings) {
+	var townSettings *TownSettings
+	var rigSettings *RigSettings
+	if townRoot != "" {
+		townSettings, _ = LoadOrCreateTownSettings(TownSettingsPath(townRoot))
+	}
+	if rigPath != "" {
+		rigSettings, _ = LoadRigSettings(RigSettingsPath(rigPath))
+	}
+	return townSettings, rigSettings
+}
+
+// withRoleDefaults applies the role's RoleDefaults to rc. Slices, maps and
+// hooks are copied before they are changed, since rc may share them with
+// loaded settings.
+func withRoleDefaults(rc *RuntimeConfig, role, townRoot, rigPath string) *RuntimeConfig {
+	if rc == nil {
+		return rc
+	}
+	d, _ := ResolveRoleDefaults(role, townRoot, rigPath)
+
+	if d.Model != "" && isClaudeAgent(rc) {
+		rc.Args = withModelArg(rc.Args, d.Model)
+	}
+	if d.PermissionMode != "" && isClaudeAgent(rc) {
+		rc.Args = withPermissionModeArg(rc.Args, d.PermissionMode)
+	}
+	if len(d.Args) > 0 {
+		rc.Args = append(append([]string(nil), rc.Args...), d.Args...)
+	}
+	env := d.Env
+	if d.ConfigDir != "" {
+		if name := configDirEnvName(rc); name != "" {
+			env = make(map[string]string, len(d.Env)+1)
+			for k, v := range d.Env {
+				env[k] = v
+			}
+			env[name] = expandHomePath(d.ConfigDir)
+		}
+	}
+	if len(env) > 0 {
+		merged := make(map[string]string, len(rc.Env)+len(env))
+		for k, v := range rc.Env {
+			merged[k] = v
+		}
+		for k, v := range env {
+			merged[k] = v
+		}
+		rc.Env = merged
+	}
+	if h := d.Hooks; h != nil {
+		hooks := RuntimeHooksConfig{}
+		if rc.Hooks != nil {
+			hooks = *rc.Hooks
+		}
+		if h.Provider != "" {
+			hooks.Provider = h.Provider
+		}
+		if h.Dir != "" {
+			hooks.Dir = h.Dir
+		}
+		if h.SettingsFile != "" {
+			hooks.SettingsFile = h.SettingsFile
+		}
+		if h.Informational {
+			hooks.Informational = true
+		}
+		rc.Hooks = &hooks
+	}
+	return rc
+}
+
+// withModelArg returns a copy of args with --model set to model, replacing
+// an existing --model flag.
+func withModelArg(args []string, model string) []string {
+	out := make([]string, 0, len(args)+2)
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--model" && i+1 < len(args):
+			i++
+		case strings.HasPrefix(args[i], "--model="):
+		default:
+			out = append(out, args[i])
+		}
+	}
+	return append(out, "--model", model)
+}
+
+// withPermissionModeArg returns a copy of args with --permission-mode set to
+// mode, dropping --dangerously-skip-permissions and any existing mode.
+func withPermissionModeArg(args []string, mode string) []string {
+	out := make([]string, 0, len(args)+2)
+	for i := 0; i < len(args); i++ {
+		switch {
+		case args[i] == "--permission-mode" && i+1 < len(args):
+			i++
+		case strings.HasPrefix(args[i], "--permission-mode="), args[i] == "--dangerously-skip-permissions":
+		default:
+			out = append(out, args[i])
+		}
+	}
+	return append(out, "--permission-mode", mode)
+}
+
+// configDirEnvName returns the environment variable that selects rc's
+// config directory, or "" if the agent has none.
+func configDirEnvName(rc *RuntimeConfig) string {
+	if rc.Session != nil && rc.Session.ConfigDirEnv != "" {
+		return rc.Session.ConfigDirEnv
+	}
+	if name := defaultConfigDirEnv(rc.Provider); name != "" {
+		return name
+	}
+	if isClaudeAgent(rc) {
+		return "CLAUDE_CONFIG_DIR"
+	}
+	return ""
+}
+
+// expandHomePath expands a leading ~/ to the user's home directory.
+func expandHomePath(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// EffectiveSetting is one resolved setting of an agent and where it came from.
+type EffectiveSetting struct {
+	Key    string `json:"key"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// EffectiveRoleSettings resolves the settings an agent of role gets: its
+// agent, command line, the role's RoleDefaults, and the health limits from
+// role definitions, each with its source. worker names a crew member for
+// per-worker agent overrides; rigPath is empty for town-level roles.
+func EffectiveRoleSettings(role, worker, townRoot, rigPath string) ([]EffectiveSetting, error) {
+	townSettings, rigSettings := loadLayeredSettings(townRoot, rigPath)
+
+	agent, agentSource := roleAgentNameWithSource(role, worker, townSettings, rigSettings)
+	var rc *RuntimeConfig
+	if role == "crew" && worker != "" {
+		rc = ResolveWorkerAgentConfig(worker, townRoot, rigPath)
+	} else {
+		rc = ResolveRoleAgentConfig(role, townRoot, rigPath)
+	}
+	if rc.ResolvedAgent != "" && rc.ResolvedAgent != agent {
+		// Cost tiers, role built-ins (dogs) or an unusable configured
+		// agent changed the result.
+		agent, agentSource = rc.ResolvedAgent, "resolved at runtime"
+	}
+	agentLayer := "agent " + agent
+	settings := []EffectiveSetting{
+		{Key: "agent", Value: agent, Source: agentSource},
+		{Key: "command", Value: strings.TrimSpace(strings.Join(append([]string{rc.Command}, rc.Args...), " ")), Source: agentLayer},
+	}
+
+	d, sources := mergeRoleDefaults(roleDefaultsLayers(role, townSettings, rigSettings, loadRigEntryRoles(townRoot, rigPath)))
+	if d.Model != "" {
+		settings = append(settings, EffectiveSetting{Key: "model", Value: d.Model, Source: sources["model"]})
+	}
+	if d.Args != nil {
+		settings = append(settings, EffectiveSetting{Key: "args", Value: strings.Join(d.Args, " "), Source: sources["args"]})
+	}
+	if d.PermissionMode != "" {
+		settings = append(settings, EffectiveSetting{Key: "permission_mode", Value: d.PermissionMode, Source: sources["permission_mode"]})
+	}
+	if d.ConfigDir != "" {
+		settings = append(settings, EffectiveSetting{Key: "config_dir", Value: d.ConfigDir, Source: sources["config_dir"]})
+	}
+	for _, k := range sortedKeys(d.Env) {
+		settings = append(settings, EffectiveSetting{Key: "env." + k, Value: d.Env[k], Source: sources["env."+k]})
+	}
+	if rc.Hooks != nil {
+		for _, f := range []struct{ key, value string }{
+			{"hooks.provider", rc.Hooks.Provider},
+			{"hooks.dir", rc.Hooks.Dir},
+			{"hooks.settings_file", rc.Hooks.SettingsFile},
+		} {
+			if f.value == "" {
+				continue
+			}
+			source := sources[f.key]
+			if source == "" {
+				source = agentLayer
+			}
+			settings = append(settings, EffectiveSetting{Key: f.key, Value: f.value, Source: source})
+		}
+	}
+
+	limits, err := roleHealthWithSources(role, townRoot, rigPath)
+	if err != nil {
+		return nil, err
+	}
+	return append(settings, limits...), nil
+}
+
+// roleAgentNameWithSource returns the agent name configured for role (and
+// crew worker, if set) and the setting it came from. It reflects persisted
+// config only, like ResolveRoleAgentName.
+func roleAgentNameWithSource(role, worker string, townSettings *TownSettings, rigSettings *RigSettings) (string, string) {
+	if worker != "" {
+		if rigSettings != nil && rigSettings.WorkerAgents[worker] != "" {
+			return rigSettings.WorkerAgents[worker], "rig worker_agents." + worker
+		}
+		if townSettings != nil && townSettings.CrewAgents[worker] != "" {
+			return townSettings.CrewAgents[worker], "town crew_agents." + worker
+		}
+	}
+	if rigSettings != nil && rigSettings.RoleAgents[role] != "" {
+		return rigSettings.RoleAgents[role], "rig role_agents." + role
+	}
+	if townSettings != nil && townSettings.RoleAgents[role] != "" {
+		return townSettings.RoleAgents[role], "town role_agents." + role
+	}
+	if rigSettings != nil && rigSettings.Agent != "" {
+		return rigSettings.Agent, "rig agent"
+	}
+	if townSettings != nil && townSettings.DefaultAgent != "" {
+		return townSettings.DefaultAgent, "town default_agent"
+	}
+	return "claude", "built-in"
+}
+
+// roleHealthWithSources returns the role's health limits from its role
+// definition layers (built-in, town roles/<role>.toml, rig
+// roles/<role>.toml), each with the layer that set it.
+func roleHealthWithSources(role, townRoot, rigPath string) ([]EffectiveSetting, error) {
+	if !isValidRoleName(role) {
+		return nil, nil // custom roles have no role definition
+	}
+	def, err := loadBuiltinRoleDefinition(role)
+	if err != nil {
+		return nil, err
+	}
+	type layer struct {
+		source string
+		def    *RoleDefinition
+	}
+	layers := []layer{{"built-in", def}}
+	overrides := []layer{{"town roles/" + role + ".toml", nil}}
+	paths := []string{filepath.Join(townRoot, "roles", role+".toml")}
+	if rigPath != "" {
+		overrides = append(overrides, layer{"rig roles/" + role + ".toml", nil})
+		paths = append(paths, filepath.Join(rigPath, "roles", role+".toml"))
+	}
+	for i, p := range paths {
+		override, err := loadRoleOverride(p)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("role override %s: %w", p, err)
+		}
+		layers = append(layers, layer{overrides[i].source, override})
+	}
+
+	fields := []struct {
+		key   string
+		value func(h RoleHealthConfig) string
+	}{
+		{"limits.ping_timeout", func(h RoleHealthConfig) string { return durationOrEmpty(h.PingTimeout) }},
+		{"limits.consecutive_failures", func(h RoleHealthConfig) string { return intOrEmpty(h.ConsecutiveFailures) }},
+		{"limits.kill_cooldown", func(h RoleHealthConfig) string { return durationOrEmpty(h.KillCooldown) }},
+		{"limits.stuck_threshold", func(h RoleHealthConfig) string { return durationOrEmpty(h.StuckThreshold) }},
+		{"limits.hung_session_threshold", func(h RoleHealthConfig) string { return durationOrEmpty(h.HungSessionThreshold) }},
+	}
+	var settings []EffectiveSetting
+	for _, f := range fields {
+		var s EffectiveSetting
+		for _, l := range layers {
+			if v := f.value(l.def.Health); v != "" {
+				s = EffectiveSetting{Key: f.key, Value: v, Source: l.source}
+			}
+		}
+		if s.Key != "" {
+			settings = append(settings, s)
+		}
+	}
+	return settings, nil
+}
+
+func durationOrEmpty(d Duration) string {
+	if d.Duration == 0 {
+		return ""
+	}
+	return d.String()
+}
+
+func intOrEmpty(n int) string {
+	if n == 0 {
+		return ""
+	}
+	return fmt.Sprint(n)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,159 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func setupRoleDefaultsTown(t *testing.T) (townRoot, rigPath string) {
+	t.Helper()
+	townRoot = t.TempDir()
+	rigPath = filepath.Join(townRoot, "myrig")
+
+	town := NewTownSettings()
+	town.Defaults = &RoleDefaults{Model: "opus", Env: map[string]string{"A": "town", "B": "town"}}
+	town.Roles = map[string]*RoleDefaults{"polecat": {Args: []string{"--verbose"}}}
+	if err := SaveTownSettings(TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+
+	rig := NewRigSettings()
+	rig.Defaults = &RoleDefaults{Env: map[string]string{"B": "rig"}}
+	rig.Roles = map[string]*RoleDefaults{"polecat": {Model: "sonnet", Hooks: &RuntimeHooksConfig{SettingsFile: "gt.json"}}}
+	if err := SaveRigSettings(RigSettingsPath(rigPath), rig); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot, rigPath
+}
+
+func TestResolveRoleDefaults_Layering(t *testing.T) {
+	t.Parallel()
+	townRoot, rigPath := setupRoleDefaultsTown(t)
+
+	d, sources := ResolveRoleDefaults("polecat", townRoot, rigPath)
+	if d.Model != "sonnet" || sources["model"] != "rig roles.polecat" {
+		t.Errorf("model = %q from %q, want sonnet from rig roles.polecat", d.Model, sources["model"])
+	}
+	if !slices.Equal(d.Args, []string{"--verbose"}) || sources["args"] != "town roles.polecat" {
+		t.Errorf("args = %v from %q", d.Args, sources["args"])
+	}
+	if d.Env["A"] != "town" || sources["env.A"] != "town defaults" {
+		t.Errorf("env.A = %q from %q", d.Env["A"], sources["env.A"])
+	}
+	if d.Env["B"] != "rig" || sources["env.B"] != "rig defaults" {
+		t.Errorf("env.B = %q from %q", d.Env["B"], sources["env.B"])
+	}
+
+	// Other roles in the rig only get the defaults.
+	d, sources = ResolveRoleDefaults("witness", townRoot, rigPath)
+	if d.Model != "opus" || sources["model"] != "town defaults" || d.Args != nil {
+		t.Errorf("witness defaults = %+v, %v", d, sources)
+	}
+}
+
+func TestResolveRoleAgentConfig_AppliesRoleDefaults(t *testing.T) {
+	t.Parallel()
+	townRoot, rigPath := setupRoleDefaultsTown(t)
+
+	rc := ResolveRoleAgentConfig("polecat", townRoot, rigPath)
+	i := slices.Index(rc.Args, "--model")
+	if i < 0 || i+1 >= len(rc.Args) || rc.Args[i+1] != "sonnet" || slices.Index(rc.Args[i+1:], "--model") >= 0 {
+		t.Errorf("Args = %v, want a single --model sonnet", rc.Args)
+	}
+	if !slices.Contains(rc.Args, "--verbose") {
+		t.Errorf("Args = %v, want --verbose from town roles.polecat", rc.Args)
+	}
+	if rc.Env["A"] != "town" || rc.Env["B"] != "rig" {
+		t.Errorf("Env = %v", rc.Env)
+	}
+	if rc.Hooks == nil || rc.Hooks.SettingsFile != "gt.json" {
+		t.Errorf("Hooks = %+v, want settings_file gt.json", rc.Hooks)
+	}
+}
+
+func TestWithModelArg(t *testing.T) {
+	t.Parallel()
+	got := withModelArg([]string{"--dangerously-skip-permissions", "--model", "haiku", "--model=opus"}, "sonnet")
+	want := []string{"--dangerously-skip-permissions", "--model", "sonnet"}
+	if !slices.Equal(got, want) {
+		t.Errorf("withModelArg = %v, want %v", got, want)
+	}
+}
+
+func TestEffectiveRoleSettings(t *testing.T) {
+	t.Parallel()
+	townRoot, rigPath := setupRoleDefaultsTown(t)
+	if err := os.MkdirAll(filepath.Join(townRoot, "roles"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	override := "[health]\nstuck_threshold = \"3h\"\n"
+	if err := os.WriteFile(filepath.Join(townRoot, "roles", "polecat.toml"), []byte(override), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings, err := EffectiveRoleSettings("polecat", "", townRoot, rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byKey := make(map[string]EffectiveSetting)
+	for _, s := range settings {
+		byKey[s.Key] = s
+	}
+	for key, want := range map[string]EffectiveSetting{
+		"agent":                  {Value: "claude", Source: "town default_agent"},
+		"model":                  {Value: "sonnet", Source: "rig roles.polecat"},
+		"env.B":                  {Value: "rig", Source: "rig defaults"},
+		"hooks.settings_file":    {Value: "gt.json", Source: "rig roles.polecat"},
+		"limits.stuck_threshold": {Value: "3h0m0s", Source: "town roles/polecat.toml"},
+	} {
+		got := byKey[key]
+		if got.Value != want.Value || got.Source != want.Source {
+			t.Errorf("%s = %q from %q, want %q from %q", key, got.Value, got.Source, want.Value, want.Source)
+		}
+	}
+	if s, ok := byKey["limits.ping_timeout"]; !ok || s.Source != "built-in" {
+		t.Errorf("limits.ping_timeout = %+v, want a built-in value", s)
+	}
+}
+
+func TestResolveRoleAgentConfig_RigsJSONRoles(t *testing.T) {
+	t.Parallel()
+	townRoot, rigPath := setupRoleDefaultsTown(t)
+	if err := SaveRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"), &RigsConfig{
+		Version: 1,
+		Rigs: map[string]RigEntry{"myrig": {Roles: map[string]*RoleDefaults{
+			"polecat": {Model: "haiku", PermissionMode: "acceptEdits", ConfigDir: "/accounts/cheap"},
+			"witness": {Model: "haiku"},
+		}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	// rig roles.polecat (settings/config.json) still beats rigs.json.
+	d, sources := ResolveRoleDefaults("polecat", townRoot, rigPath)
+	if d.Model != "sonnet" || d.PermissionMode != "acceptEdits" || sources["permission_mode"] != "rigs.json roles.polecat" {
+		t.Errorf("polecat = %+v, %v", d, sources)
+	}
+	// rigs.json beats town defaults.
+	if d, sources := ResolveRoleDefaults("witness", townRoot, rigPath); d.Model != "haiku" || sources["model"] != "rigs.json roles.witness" {
+		t.Errorf("witness model = %q from %q", d.Model, sources["model"])
+	}
+
+	rc := ResolveRoleAgentConfig("polecat", townRoot, rigPath)
+	if slices.Contains(rc.Args, "--dangerously-skip-permissions") {
+		t.Errorf("Args = %v, --dangerously-skip-permissions should be replaced", rc.Args)
+	}
+	if i := slices.Index(rc.Args, "--permission-mode"); i < 0 || rc.Args[i+1] != "acceptEdits" {
+		t.Errorf("Args = %v, want --permission-mode acceptEdits", rc.Args)
+	}
+	if rc.Env["CLAUDE_CONFIG_DIR"] != "/accounts/cheap" {
+		t.Errorf("Env = %v, want CLAUDE_CONFIG_DIR from rigs.json", rc.Env)
+	}
+
+	// Town-level roles ignore rigs.json.
+	if d, _ := ResolveRoleDefaults("witness", townRoot, ""); d.Model != "opus" {
+		t.Errorf("town-level witness model = %q, want opus", d.Model)
+	}
+}
@@ -0,0 +1,463 @@
+package config
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Schema describes the expected shape of a config file. Field names and
+// types come from the Go type the file is loaded into, so the schema cannot
+// drift from the loader; Required adds what the type alone can't express.
+type Schema struct {
+	// File is the config file's conventional path, relative to the town root.
+	File string
+
+	// Type is the Go type the file decodes into.
+	Type reflect.Type
+
+	// Required lists dotted field paths that must be present. "*" matches
+	// any map key, e.g. "rigs.*.git_url".
+	Required []string
+}
+
+// Schemas for the town-level config files.
+var (
+	TownSchema = &Schema{
+		File:     filepath.Join("mayor", "town.json"),
+		Type:     reflect.TypeOf(TownConfig{}),
+		Required: []string{"type", "version", "name"},
+	}
+	RigsSchema = &Schema{
+		File:     filepath.Join("mayor", "rigs.json"),
+		Type:     reflect.TypeOf(RigsConfig{}),
+		Required: []string{"version", "rigs", "rigs.*.git_url"},
+	}
+	AccountsSchema = &Schema{
+		File:     filepath.Join("mayor", "accounts.json"),
+		Type:     reflect.TypeOf(AccountsConfig{}),
+		Required: []string{"version", "accounts", "accounts.*.email", "accounts.*.config_dir"},
+	}
+)
+
+// TownSchemas returns the schemas checked by 'gt config validate'.
+func TownSchemas() []*Schema {
+	return []*Schema{TownSchema, RigsSchema, AccountsSchema}
+}
+
+// SchemaIssue is one problem found validating a config file.
+type SchemaIssue struct {
+	File    string // path of the file
+	Line    int    // 1-based line of the offending key or value
+	Path    string // dotted field path, "" for the document itself
+	Message string
+}
+
+func (i SchemaIssue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("%s:%d: %s", i.File, i.Line, i.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", i.File, i.Line, i.Path, i.Message)
+}
+
+// ValidateSchemaFile checks the file at path against s. It returns the
+// issues found; the error is only for a file that can't be read (including
+// one that doesn't exist).
+func ValidateSchemaFile(path string, s *Schema) ([]SchemaIssue, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is a town config file
+	if err != nil {
+		return nil, err
+	}
+	return ValidateSchema(path, data, s), nil
+}
+
+// ValidateTownSchemas checks each of TownSchemas() under townRoot, plus any
+// town.json environment overlays, and returns the issues, labelled with
+// town-relative paths, and the files it checked. Files that don't exist are
+// skipped; accounts.json is optional.
+func ValidateTownSchemas(townRoot string) (issues []SchemaIssue, checked []string, err error) {
+	for _, s := range TownSchemas() {
+		data, err := os.ReadFile(filepath.Join(townRoot, s.File)) //nolint:gosec // G304: fixed town config paths
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading %s: %w", s.File, err)
+		}
+		checked = append(checked, s.File)
+		issues = append(issues, ValidateSchema(s.File, data, s)...)
+	}
+
+	// Environment overlays (town.<env>.json) hold any subset of town.json.
+	overlays, _ := filepath.Glob(TownOverlayPath(filepath.Join(townRoot, TownSchema.File), "*"))
+	partial := &Schema{File: TownSchema.File, Type: TownSchema.Type}
+	for _, path := range overlays {
+		rel, _ := filepath.Rel(townRoot, path)
+		data, err := os.ReadFile(path) //nolint:gosec // G304: town overlay files
+		if err != nil {
+			return nil, nil, fmt.Errorf("reading %s: %w", rel, err)
+		}
+		checked = append(checked, rel)
+		issues = append(issues, ValidateSchema(rel, data, partial)...)
+	}
+	return issues, checked, nil
+}
+
+// ValidateSchema checks JSON data against s, reporting unknown fields,
+// missing required fields, and values of the wrong type. file is only used
+// to label the issues.
+func ValidateSchema(file string, data []byte, s *Schema) []SchemaIssue {
+	v := &schemaValidator{file: file, data: data, schema: s}
+	v.dec = json.NewDecoder(bytes.NewReader(data))
+	v.dec.UseNumber()
+	if err := v.value(s.Type, ""); err != nil {
+		v.syntaxError(err)
+		return v.issues
+	}
+	if _, err := v.dec.Token(); err != io.EOF {
+		v.issue(v.dec.InputOffset(), "", "unexpected data after the top-level object")
+	}
+	return v.issues
+}
+
+// schemaValidator walks a JSON document token by token alongside the Go
+// type it should decode into. Walking tokens rather than unmarshalling keeps
+// byte offsets, which become line numbers in the issues.
+type schemaValidator struct {
+	file   string
+	data   []byte
+	schema *Schema
+	dec    *json.Decoder
+	issues []SchemaIssue
+}
+
+var (
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	timeType            = reflect.TypeOf(time.Time{})
+)
+
+// value consumes the next JSON value and checks it against t. path is the
+// value's dotted path. It returns an error only for malformed JSON.
+func (v *schemaValidator) value(t reflect.Type, path string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	offset := v.dec.InputOffset()
+	tok, err := v.dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil // null is accepted anywhere, as encoding/json does
+	}
+
+	// Types that decode themselves are opaque to this walker, except the
+	// text-based ones (time.Time, ...), which must be strings.
+	pt := reflect.PointerTo(t)
+	if t == timeType || (pt.Implements(textUnmarshalerType) && !pt.Implements(jsonUnmarshalerType)) {
+		if _, ok := tok.(string); !ok {
+			v.issue(offset, path, fmt.Sprintf("expected a string, got %s", jsonTokenKind(tok)))
+		}
+		return v.skipRest(tok)
+	}
+	if pt.Implements(jsonUnmarshalerType) {
+		return v.skipRest(tok)
+	}
+
+	switch t.Kind() {
+	case reflect.Interface:
+		return v.skipRest(tok)
+	case reflect.Struct:
+		if tok != json.Delim('{') {
+			v.mismatch(offset, path, t, tok)
+			return v.skipRest(tok)
+		}
+		return v.object(path, offset, func(key string) (reflect.Type, bool) {
+			return jsonField(t, key)
+		})
+	case reflect.Map:
+		if tok != json.Delim('{') {
+			v.mismatch(offset, path, t, tok)
+			return v.skipRest(tok)
+		}
+		return v.object(path, offset, func(string) (reflect.Type, bool) {
+			return t.Elem(), true
+		})
+	case reflect.Slice, reflect.Array:
+		if tok != json.Delim('[') {
+			v.mismatch(offset, path, t, tok)
+			return v.skipRest(tok)
+		}
+		for i := 0; v.dec.More(); i++ {
+			if err := v.value(t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		_, err := v.dec.Token() // ']'
+		return err
+	case reflect.String:
+		if _, ok := tok.(string); !ok {
+			v.mismatch(offset, path, t, tok)
+		}
+	case reflect.Bool:
+		if _, ok := tok.(bool); !ok {
+			v.mismatch(offset, path, t, tok)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, ok := tok.(json.Number)
+		if !ok {
+			v.mismatch(offset, path, t, tok)
+		} else if _, err := n.Int64(); err != nil {
+			v.issue(offset, path, fmt.Sprintf("expected an integer, got %s", n))
+		}
+	case reflect.Float32, reflect.Float64:
+		if _, ok := tok.(json.Number); !ok {
+			v.mismatch(offset, path, t, tok)
+		}
+	}
+	return v.skipRest(tok)
+}
+
+// object consumes the members of an object whose '{' was just read at
+// offset. field resolves a key to the type of its value; unknown keys are
+// reported and skipped. Missing required fields are reported at the '{'.
+func (v *schemaValidator) object(path string, offset int64, field func(string) (reflect.Type, bool)) error {
+	seen := make(map[string]bool)
+	for v.dec.More() {
+		keyOffset := v.dec.InputOffset()
+		tok, err := v.dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := tok.(string)
+		childPath := joinSchemaPath(path, key)
+		seen[key] = true
+		t, ok := field(key)
+		if !ok {
+			v.issue(keyOffset, childPath, "unknown field")
+			if err := v.skipValue(); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := v.value(t, childPath); err != nil {
+			return err
+		}
+	}
+	if _, err := v.dec.Token(); err != nil { // '}'
+		return err
+	}
+	for _, name := range v.requiredAt(path) {
+		if !seen[name] {
+			v.issue(offset, joinSchemaPath(path, name), "missing required field")
+		}
+	}
+	return nil
+}
+
+// requiredAt returns the required field names directly under path.
+func (v *schemaValidator) requiredAt(path string) []string {
+	var names []string
+	for _, req := range v.schema.Required {
+		i := strings.LastIndex(req, ".")
+		parent, name := "", req
+		if i >= 0 {
+			parent, name = req[:i], req[i+1:]
+		}
+		if schemaPathMatch(parent, path) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// schemaPathMatch reports whether path matches pattern segment by segment,
+// where a "*" segment matches any map key.
+func schemaPathMatch(pattern, path string) bool {
+	if pattern == "" || path == "" {
+		return pattern == path
+	}
+	ps, ks := strings.Split(pattern, "."), strings.Split(path, ".")
+	if len(ps) != len(ks) {
+		return false
+	}
+	for i := range ps {
+		if ps[i] != "*" && ps[i] != ks[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// skipValue consumes the next JSON value whole.
+func (v *schemaValidator) skipValue() error {
+	tok, err := v.dec.Token()
+	if err != nil {
+		return err
+	}
+	return v.skipRest(tok)
+}
+
+// skipRest consumes the remainder of a value whose first token was tok.
+func (v *schemaValidator) skipRest(tok json.Token) error {
+	if tok != json.Delim('{') && tok != json.Delim('[') {
+		return nil
+	}
+	for depth := 1; depth > 0; {
+		tok, err := v.dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+	}
+	return nil
+}
+
+func (v *schemaValidator) mismatch(offset int64, path string, t reflect.Type, tok json.Token) {
+	v.issue(offset, path, fmt.Sprintf("expected %s, got %s", schemaTypeName(t), jsonTokenKind(tok)))
+}
+
+func (v *schemaValidator) syntaxError(err error) {
+	offset := v.dec.InputOffset()
+	var syntax *json.SyntaxError
+	if errors.As(err, &syntax) {
+		offset = syntax.Offset
+	}
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		err = errors.New("unexpected end of file")
+	}
+	v.issue(offset, "", "invalid JSON: "+err.Error())
+}
+
+func (v *schemaValidator) issue(offset int64, path, msg string) {
+	v.issues = append(v.issues, SchemaIssue{
+		File:    v.file,
+		Line:    lineAt(v.data, offset),
+		Path:    path,
+		Message: msg,
+	})
+}
+
+// lineAt returns the 1-based line of the first non-blank byte at or after
+// offset; decoder offsets sit just past the previous token.
+func lineAt(data []byte, offset int64) int {
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	for offset < int64(len(data)) && strings.ContainsRune(" \t\r\n:,", rune(data[offset])) {
+		offset++
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// jsonField returns the type of t's field encoded under key.
+func jsonField(t reflect.Type, key string) (reflect.Type, bool) {
+	index, ok := jsonFieldIndex(t, key)
+	if !ok {
+		return nil, false
+	}
+	return t.FieldByIndex(index).Type, true
+}
+
+// jsonFieldIndex returns the index of t's field encoded under key, matching
+// the way encoding/json does: exact tag or name first, then
+// case-insensitively, looking into embedded structs.
+func jsonFieldIndex(t reflect.Type, key string) ([]int, bool) {
+	var fold []int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if sub, ok := jsonFieldIndex(ft, key); ok {
+					return append([]int{i}, sub...), true
+				}
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if name == key {
+			return []int{i}, true
+		}
+		if fold == nil && strings.EqualFold(name, key) {
+			fold = []int{i}
+		}
+	}
+	return fold, fold != nil
+}
+
+func joinSchemaPath(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// schemaTypeName names the JSON kind a Go type decodes from.
+func schemaTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Map:
+		return "an object"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	default:
+		if t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64 {
+			return "an integer"
+		}
+		return t.String()
+	}
+}
+
+// jsonTokenKind names the JSON kind of a value's first token.
+func jsonTokenKind(tok json.Token) string {
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '{' {
+			return "an object"
+		}
+		return "an array"
+	case string:
+		return "a string"
+	case bool:
+		return "a boolean"
+	case json.Number:
+		return "a number"
+	default:
+		return "null"
+	}
+}
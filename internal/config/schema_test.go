@@ -0,0 +1,164 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func issueStrings(issues []SchemaIssue) []string {
+	out := make([]string, len(issues))
+	for i, is := range issues {
+		out[i] = is.String()
+	}
+	return out
+}
+
+func TestValidateSchema_Valid(t *testing.T) {
+	town := `{
+  "type": "town",
+  "version": 2,
+  "name": "hq",
+  "created_at": "2026-01-02T03:04:05Z",
+  "roles": {"reviewer": {"description": "Reviews PRs"}},
+  "checkpoints": {"stale_after": {"default": "6h"}}
+}`
+	if issues := ValidateSchema("town.json", []byte(town), TownSchema); len(issues) != 0 {
+		t.Errorf("valid town.json reported issues: %v", issueStrings(issues))
+	}
+
+	rigs := `{"version": 1, "rigs": {"app": {"git_url": "https://x/app.git", "beads": {"repo": "local", "prefix": "ap"}}}}`
+	if issues := ValidateSchema("rigs.json", []byte(rigs), RigsSchema); len(issues) != 0 {
+		t.Errorf("valid rigs.json reported issues: %v", issueStrings(issues))
+	}
+}
+
+func TestValidateSchema_Issues(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *Schema
+		data   string
+		want   []string
+	}{
+		{
+			name:   "unknown field",
+			schema: TownSchema,
+			data:   "{\n  \"type\": \"town\",\n  \"version\": 2,\n  \"name\": \"hq\",\n  \"ownr\": \"me\"\n}",
+			want:   []string{"f:5: ownr: unknown field"},
+		},
+		{
+			name:   "missing required",
+			schema: TownSchema,
+			data:   "{\n  \"type\": \"town\"\n}",
+			want:   []string{"f:1: name: missing required field", "f:1: version: missing required field"},
+		},
+		{
+			name:   "wrong types",
+			schema: TownSchema,
+			data:   "{\n  \"type\": \"town\",\n  \"version\": \"2\",\n  \"name\": \"hq\",\n  \"created_at\": 5\n}",
+			want:   []string{"f:3: version: expected an integer, got a string", "f:5: created_at: expected a string, got a number"},
+		},
+		{
+			name:   "nested map entries",
+			schema: RigsSchema,
+			data:   "{\n  \"version\": 1,\n  \"rigs\": {\n    \"app\": {\n      \"push_url\": 1,\n      \"colour\": \"red\"\n    }\n  }\n}",
+			want: []string{
+				"f:5: rigs.app.push_url: expected a string, got a number",
+				"f:6: rigs.app.colour: unknown field",
+				"f:4: rigs.app.git_url: missing required field",
+			},
+		},
+		{
+			name:   "accounts",
+			schema: AccountsSchema,
+			data:   `{"version": 1, "accounts": {"work": {"email": "a@b"}}, "default": ["work"]}`,
+			want: []string{
+				"f:1: accounts.work.config_dir: missing required field",
+				"f:1: default: expected a string, got an array",
+			},
+		},
+		{
+			name:   "syntax error",
+			schema: RigsSchema,
+			data:   "{\n  \"version\": 1,\n  \"rigs\": {,}\n}",
+			want:   []string{"f:3: invalid JSON: invalid character ',' looking for beginning of value"},
+		},
+		{
+			name:   "empty",
+			schema: TownSchema,
+			data:   "",
+			want:   []string{"f:1: invalid JSON: unexpected end of file"},
+		},
+		{
+			name:   "truncated",
+			schema: RigsSchema,
+			data:   "{\n  \"version\": 1,",
+			want:   []string{"f:2: invalid JSON: unexpected end of JSON input"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := issueStrings(ValidateSchema("f", []byte(tt.data), tt.schema))
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("issues =\n  %s\nwant\n  %s", strings.Join(got, "\n  "), strings.Join(tt.want, "\n  "))
+			}
+		})
+	}
+}
+
+func TestValidateSchemaFile_Missing(t *testing.T) {
+	_, err := ValidateSchemaFile(filepath.Join(t.TempDir(), "town.json"), TownSchema)
+	if !os.IsNotExist(err) {
+		t.Errorf("err = %v, want not-exist", err)
+	}
+}
+
+// TestTownSchemas_MatchSavedConfigs checks that what the config package
+// writes passes its own schemas.
+func TestTownSchemas_MatchSavedConfigs(t *testing.T) {
+	dir := t.TempDir()
+	townPath := filepath.Join(dir, "town.json")
+	if err := SaveTownConfig(townPath, &TownConfig{Type: "town", Version: CurrentTownVersion, Name: "hq"}); err != nil {
+		t.Fatal(err)
+	}
+	rigsPath := filepath.Join(dir, "rigs.json")
+	if err := SaveRigsConfig(rigsPath, &RigsConfig{Version: 1, Rigs: map[string]RigEntry{"app": {GitURL: "https://x/app.git"}}}); err != nil {
+		t.Fatal(err)
+	}
+	for path, s := range map[string]*Schema{townPath: TownSchema, rigsPath: RigsSchema} {
+		issues, err := ValidateSchemaFile(path, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(issues) != 0 {
+			t.Errorf("%s: %v", filepath.Base(path), issueStrings(issues))
+		}
+	}
+}
+
+func TestValidateTownSchemas(t *testing.T) {
+	townRoot := t.TempDir()
+	mayor := filepath.Join(townRoot, "mayor")
+	if err := os.MkdirAll(mayor, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mayor, "town.json"), []byte(`{"type":"town","version":2,"name":"hq","extra":1}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mayor, "rigs.json"), []byte(`{"version":1,"rigs":{}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	issues, checked, err := ValidateTownSchemas(townRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(checked) != 2 {
+		t.Errorf("checked = %v, want town.json and rigs.json (accounts.json is absent)", checked)
+	}
+	want := filepath.Join("mayor", "town.json") + ":1: extra: unknown field"
+	if len(issues) != 1 || issues[0].String() != want {
+		t.Errorf("issues = %v, want [%s]", issueStrings(issues), want)
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// SharedConfigSource is where a town's org-shared config comes from.
+type SharedConfigSource struct {
+	// Source is a git repository URL (or local path), or an http(s) URL of
+	// a .tar.gz archive.
+	Source string `json:"source"`
+
+	// Ref is the branch or tag to check out. Git sources only; defaults to
+	// the repository's default branch.
+	Ref string `json:"ref,omitempty"`
+
+	// Path is the subdirectory of the source holding the shared files, for
+	// repos that keep config for several teams.
+	Path string `json:"path,omitempty"`
+}
+
+// Files a shared config source may provide, relative to its root.
+const (
+	SharedTownFile       = "town.json"       // defaults under mayor/town.json
+	SharedRigsFile       = "rigs.json"       // rigs every town should have
+	SharedHooksBaseFile  = "hooks-base.json" // hooks base, under the user's
+	SharedHooksOverrides = "hooks-overrides" // hooks overrides, under the user's
+)
+
+// sharedIdentityKeys are town.json fields that identify a town. A shared
+// town.json can't set them.
+var sharedIdentityKeys = []string{"type", "version", "name", "owner", "public_name", "created_at", "shared"}
+
+// SharedConfigDir returns the directory 'gt config sync' keeps the town's
+// last fetched shared config in.
+func SharedConfigDir(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "shared-config")
+}
+
+// applySharedTownDefaults layers the town config data read from path (the
+// town's mayor/town.json) over the shared town.json, if the town has one.
+// Objects merge key by key, so the town only needs to list what it changes;
+// a null in the town's file drops a shared value.
+func applySharedTownDefaults(path string, data []byte) ([]byte, error) {
+	townRoot := filepath.Dir(filepath.Dir(path))
+	sharedPath := filepath.Join(SharedConfigDir(townRoot), SharedTownFile)
+	sharedData, err := os.ReadFile(sharedPath) //nolint:gosec // G304: derived from the town config path
+	if err != nil {
+		if os.IsNotExist(err) {
+			return data, nil
+		}
+		return nil, fmt.Errorf("reading shared config: %w", err)
+	}
+
+	var shared, local map[string]any
+	if err := json.Unmarshal(sharedData, &shared); err != nil {
+		return nil, fmt.Errorf("parsing shared config %s: %w", sharedPath, err)
+	}
+	if err := json.Unmarshal(data, &local); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+	for _, key := range sharedIdentityKeys {
+		delete(shared, key)
+	}
+	return json.Marshal(mergeOverlay(shared, local))
+}
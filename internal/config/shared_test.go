@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadTownConfig_SharedDefaults(t *testing.T) {
+	townRoot := t.TempDir()
+	path := filepath.Join(townRoot, "mayor", "town.json")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(`{"type":"town","version":2,"name":"hq","owner":"dev@example.com",
+		"hooks":{"pre_start":[{"command":"./local.sh"}]},"roles":null}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	sharedDir := SharedConfigDir(townRoot)
+	if err := os.MkdirAll(sharedDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sharedDir, SharedTownFile), []byte(`{"name":"team","owner":"lead@example.com",
+		"hooks":{"pre_start":[{"command":"./team.sh"}],"post_shutdown":[{"command":"./team-down.sh"}]},
+		"roles":{"reviewer":{"dir":"reviewers"}},
+		"schedule":[{"cron":"0 22 * * 1-5","action":"shutdown"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(TownEnvVar, "")
+
+	cfg, err := LoadTownConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "hq" || cfg.Owner != "dev@example.com" {
+		t.Errorf("name, owner = %q, %q; identity must not come from the shared config", cfg.Name, cfg.Owner)
+	}
+	if cfg.Hooks == nil || len(cfg.Hooks.PreStart) != 1 || cfg.Hooks.PreStart[0].Command != "./local.sh" {
+		t.Fatalf("hooks = %+v; the town's pre_start should win", cfg.Hooks)
+	}
+	if len(cfg.Hooks.PostShutdown) != 1 {
+		t.Errorf("post_shutdown = %v; want the shared default", cfg.Hooks.PostShutdown)
+	}
+	if len(cfg.Schedule) != 1 {
+		t.Errorf("schedule = %v; want the shared default", cfg.Schedule)
+	}
+	if cfg.Roles != nil {
+		t.Errorf("roles = %v; a null in the town's file drops the shared value", cfg.Roles)
+	}
+
+	base, err := LoadTownConfigBase(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(base.Schedule) != 0 {
+		t.Error("LoadTownConfigBase applied the shared defaults")
+	}
+}
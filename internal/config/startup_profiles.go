@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Built-in startup profile names.
+const (
+	StartupProfileMinimal = "minimal"
+	StartupProfileFull    = "full"
+)
+
+// builtinStartupProfiles are available in every town unless overridden in
+// town settings.
+var builtinStartupProfiles = map[string]*StartupProfile{
+	StartupProfileMinimal: {Description: "Mayor and Deacon only"},
+	StartupProfileFull:    {Description: "Mayor, Deacon, and every rig's Witness and Refinery", RigAgents: true},
+}
+
+// ResolveStartupProfile returns the startup profile called name, or the
+// town's default profile when name is empty. Town settings take precedence
+// over the built-in profiles. settings may be nil.
+func ResolveStartupProfile(settings *TownSettings, name string) (*StartupProfile, string, error) {
+	if name == "" && settings != nil {
+		name = settings.DefaultStartupProfile
+	}
+	if name == "" {
+		name = StartupProfileMinimal
+	}
+	if settings != nil {
+		if p := settings.StartupProfiles[name]; p != nil {
+			return p, name, nil
+		}
+	}
+	if p := builtinStartupProfiles[name]; p != nil {
+		return p, name, nil
+	}
+	return nil, name, fmt.Errorf("unknown startup profile %q (have: %s)", name, strings.Join(StartupProfileNames(settings), ", "))
+}
+
+// StartupProfileNames returns the built-in and configured profile names,
+// sorted.
+func StartupProfileNames(settings *TownSettings) []string {
+	seen := make(map[string]bool)
+	for n := range builtinStartupProfiles {
+		seen[n] = true
+	}
+	if settings != nil {
+		for n, p := range settings.StartupProfiles {
+			if p != nil {
+				seen[n] = true
+			}
+		}
+	}
+	names := make([]string, 0, len(seen))
+	for n := range seen {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// IncludesRig reports whether the profile starts agents for rigName.
+func (p *StartupProfile) IncludesRig(rigName string) bool {
+	if !p.RigAgents {
+		return false
+	}
+	if len(p.Rigs) == 0 {
+		return true
+	}
+	for _, r := range p.Rigs {
+		if r == rigName {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestResolveStartupProfile(t *testing.T) {
+	p, name, err := ResolveStartupProfile(nil, "")
+	if err != nil || name != StartupProfileMinimal || p.RigAgents {
+		t.Errorf("default profile = %+v, %q, %v; want minimal", p, name, err)
+	}
+
+	settings := NewTownSettings()
+	settings.DefaultStartupProfile = "frontend"
+	settings.StartupProfiles = map[string]*StartupProfile{
+		"frontend": {RigAgents: true, Rigs: []string{"webapp"}, Members: []string{"webapp/crew/max"}},
+		"minimal":  {Members: []string{"gastown/witness"}},
+	}
+
+	p, name, err = ResolveStartupProfile(settings, "")
+	if err != nil || name != "frontend" {
+		t.Fatalf("ResolveStartupProfile(default) = %q, %v; want frontend", name, err)
+	}
+	if !p.IncludesRig("webapp") || p.IncludesRig("gastown") {
+		t.Errorf("frontend IncludesRig: webapp=%v gastown=%v", p.IncludesRig("webapp"), p.IncludesRig("gastown"))
+	}
+
+	p, _, err = ResolveStartupProfile(settings, StartupProfileMinimal)
+	if err != nil || len(p.Members) != 1 {
+		t.Errorf("configured minimal should override the built-in, got %+v, %v", p, err)
+	}
+
+	p, _, err = ResolveStartupProfile(settings, StartupProfileFull)
+	if err != nil || !p.IncludesRig("anything") {
+		t.Errorf("built-in full = %+v, %v; want every rig", p, err)
+	}
+
+	_, _, err = ResolveStartupProfile(settings, "nope")
+	if err == nil || !strings.Contains(err.Error(), "frontend, full, minimal") {
+		t.Errorf("unknown profile error = %v, want the available names", err)
+	}
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// TracingConfig configures OpenTelemetry tracing of gt commands and agent
+// workflows, set in town.json under "tracing". Spans are exported over
+// OTLP HTTP, like metrics and logs; tracing is off when Endpoint is empty.
+type TracingConfig struct {
+	// Endpoint is the collector's OTLP HTTP traces URL, e.g.
+	// "http://localhost:4318/v1/traces" (Jaeger, Tempo and the
+	// OpenTelemetry Collector all accept it). Use https:// for TLS.
+	Endpoint string `json:"endpoint"`
+
+	// Headers are sent with every export, e.g. an API key for a hosted
+	// backend.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// SampleRatio is the fraction of new traces recorded, 0 to 1.
+	// Traces started elsewhere (an agent's spawn) follow their parent's
+	// decision. Default: 1.
+	SampleRatio *float64 `json:"sample_ratio,omitempty"`
+}
+
+// Enabled reports whether tracing is configured. Safe on a nil receiver.
+func (c *TracingConfig) Enabled() bool {
+	return c != nil && c.Endpoint != ""
+}
+
+// Ratio returns the sample ratio, defaulting to 1.
+func (c *TracingConfig) Ratio() float64 {
+	if c == nil || c.SampleRatio == nil {
+		return 1
+	}
+	return *c.SampleRatio
+}
+
+// ErrInvalidTracingConfig indicates a malformed town.json tracing entry.
+var ErrInvalidTracingConfig = errors.New("invalid tracing config")
+
+// validateTracingConfig checks the endpoint is an http(s) URL and the
+// sample ratio is a fraction.
+func validateTracingConfig(c *TracingConfig) error {
+	if c == nil {
+		return nil
+	}
+	if c.Endpoint != "" {
+		u, err := url.Parse(c.Endpoint)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: endpoint %q is not an http(s) URL", ErrInvalidTracingConfig, c.Endpoint)
+		}
+	}
+	if r := c.Ratio(); r < 0 || r > 1 {
+		return fmt.Errorf("%w: sample_ratio %v is not between 0 and 1", ErrInvalidTracingConfig, r)
+	}
+	return nil
+}
@@ -21,6 +21,93 @@ type TownConfig struct {
 	Owner      string    `json:"owner,omitempty"`       // owner email (entity identity)
 	PublicName string    `json:"public_name,omitempty"` // public display name
 	CreatedAt  time.Time `json:"created_at"`
+
+	// Hooks runs commands before and after 'gt start' and 'gt shutdown',
+	// e.g. to start a local model proxy or snapshot beads.
+	Hooks *TownHooksConfig `json:"hooks,omitempty"`
+
+	// Schedule lists cron-like entries the daemon acts on, e.g. to shut
+	// the town down at night and start it again in the morning.
+	Schedule []ScheduleEntry `json:"schedule,omitempty"`
+
+	// Roles defines roles beyond the built-in set, keyed by role name.
+	// Example: {"reviewer": {"description": "Reviews PRs", "dir": "reviewers"}}
+	Roles map[string]*CustomRoleConfig `json:"roles,omitempty"`
+
+	// Checkpoints configures crash-recovery checkpoints, e.g. encryption.
+	Checkpoints *CheckpointConfig `json:"checkpoints,omitempty"`
+
+	// Shared points the town at an org-shared config repo, fetched by
+	// 'gt config sync'. Its town.json, rig list and hooks config are
+	// defaults; this town's own files layer on top.
+	Shared *SharedConfigSource `json:"shared,omitempty"`
+
+	// Tracing exports OpenTelemetry spans for gt commands and agent
+	// workflows (spawn, work, handoff, merge) to an OTLP collector.
+	Tracing *TracingConfig `json:"tracing,omitempty"`
+}
+
+// Actions for town schedule entries.
+const (
+	// ScheduleShutdown gracefully shuts the town down and keeps the daemon
+	// from restarting agents until a "start" entry or a manual 'gt start'.
+	ScheduleShutdown = "shutdown"
+
+	// ScheduleStart starts the town.
+	ScheduleStart = "start"
+
+	// SchedulePauseDispatch pauses scheduler dispatch ('gt scheduler pause').
+	SchedulePauseDispatch = "pause_dispatch"
+
+	// ScheduleResumeDispatch resumes scheduler dispatch ('gt scheduler resume').
+	ScheduleResumeDispatch = "resume_dispatch"
+)
+
+// ScheduleEntry is one town schedule entry: an action the daemon performs
+// whenever Cron matches the current minute.
+type ScheduleEntry struct {
+	// Cron is a five-field cron expression (minute hour day-of-month month
+	// day-of-week) in local time, e.g. "0 22 * * 1-5".
+	Cron string `json:"cron"`
+
+	// Action is shutdown, start, pause_dispatch, or resume_dispatch.
+	Action string `json:"action"`
+}
+
+// TownHooksConfig lists commands run around town startup and shutdown.
+// Unlike session_hooks, these run once per command, not once per session.
+type TownHooksConfig struct {
+	// PreStart hooks run before 'gt start' starts Dolt or any agent, and
+	// before 'gt restart' brings the town back up.
+	PreStart []TownHook `json:"pre_start,omitempty"`
+
+	// PostStart hooks run after 'gt start' or 'gt restart' has started the
+	// agents.
+	PostStart []TownHook `json:"post_start,omitempty"`
+
+	// PreShutdown hooks run before 'gt shutdown' or 'gt restart' stops any
+	// session.
+	PreShutdown []TownHook `json:"pre_shutdown,omitempty"`
+
+	// PostShutdown hooks run after 'gt shutdown' has stopped the town, and
+	// after 'gt restart' has stopped it, before it starts again.
+	PostShutdown []TownHook `json:"post_shutdown,omitempty"`
+}
+
+// TownHook is a shell command run around 'gt start' or 'gt shutdown'. The
+// command runs with sh -c in the town root, with GT_HOOK_EVENT and
+// GT_TOWN_ROOT set.
+type TownHook struct {
+	// Command is the shell command to run.
+	Command string `json:"command"`
+
+	// Timeout bounds how long the command may run, as a duration string
+	// (default "30s").
+	Timeout string `json:"timeout,omitempty"`
+
+	// Required makes a failing pre_start or pre_shutdown hook abort the
+	// command. Other failures are reported as warnings.
+	Required bool `json:"required,omitempty"`
 }
 
 // MayorConfig represents town-level behavioral configuration (mayor/config.json).
@@ -110,9 +197,107 @@ type TownSettings struct {
 	// in daemon.json patrols section (e.g., "deacon", "witness", "refinery",
 	// "doctor_dog", "compactor_dog", "checkpoint_dog", "wisp_reaper",
 	// "dolt_remotes", "dolt_backup", "jsonl_git_backup", "scheduled_maintenance",
-	// "main_branch_test", "handler").
+	// "main_branch_test", "mayor_fetch", "handler").
 	// Example: ["doctor_dog", "compactor_dog"]
 	DisabledPatrols []string `json:"disabled_patrols,omitempty"`
+
+	// SessionLayouts defines per-role tmux layouts created when an agent session
+	// is spawned. Keys are role names ("polecat", "crew", "witness", "refinery",
+	// "mayor", "deacon"). Each layout adds panes beside the agent and extra
+	// windows, e.g. a shell in the worktree or a tail of the session log.
+	// Roles without an entry get the single agent pane.
+	SessionLayouts map[string]*SessionLayout `json:"session_layouts,omitempty"`
+
+	// SessionEnv adds custom environment variables to every agent session in
+	// the town. Rig settings can add to or override these.
+	SessionEnv *SessionEnvConfig `json:"session_env,omitempty"`
+
+	// Secrets exports secrets into agent sessions as env vars. Same shape as
+	// session_env, but each value is a reference resolved at spawn time
+	// (keychain:NAME, gpg:PATH, env:NAME), never the secret itself.
+	// Rig settings can add to or override these.
+	Secrets *SessionEnvConfig `json:"secrets,omitempty"`
+
+	// SessionGroups defines named sets of agents that are started and stopped
+	// together with 'gt session group start/stop <name>'.
+	// Example: {"nightly": {"members": ["gastown/witness", "gastown/polecats/Toast"]}}
+	SessionGroups map[string]*SessionGroup `json:"session_groups,omitempty"`
+
+	// SessionHooks runs commands when agent sessions start, stop, or restart,
+	// e.g. to register sessions in an external inventory or mount credentials.
+	SessionHooks *SessionHooksConfig `json:"session_hooks,omitempty"`
+
+	// StartupProfiles defines which agents 'gt start --profile <name>' brings
+	// up. The built-in "minimal" and "full" profiles may be overridden here.
+	// Example: {"frontend": {"rig_agents": true, "rigs": ["webapp"]}}
+	StartupProfiles map[string]*StartupProfile `json:"startup_profiles,omitempty"`
+
+	// DefaultStartupProfile is the profile 'gt start' uses without --profile.
+	// Default: "minimal".
+	DefaultStartupProfile string `json:"default_startup_profile,omitempty"`
+
+	// Headless runs the Mayor and/or Deacon as supervised background
+	// processes that log to files, instead of tmux sessions. For servers
+	// where nobody attaches.
+	Headless *HeadlessConfig `json:"headless,omitempty"`
+
+	// Webhooks are HTTP endpoints the daemon notifies when beads change
+	// state (hooked, in_progress, done, escalated).
+	Webhooks []*WebhookConfig `json:"webhooks,omitempty"`
+
+	// HandoffTemplates defines the structure of handoff mail per role, keyed
+	// by role name or "default". See HandoffTemplate.
+	HandoffTemplates map[string]*HandoffTemplate `json:"handoff_templates,omitempty"`
+
+	// Defaults are agent session settings (model, args, env, hooks) for every
+	// role in the town. Rig settings and Roles override them; see RoleDefaults.
+	Defaults *RoleDefaults `json:"defaults,omitempty"`
+
+	// Roles overrides Defaults per role, keyed by role name.
+	// Example: {"polecat": {"model": "sonnet"}, "witness": {"model": "haiku"}}
+	Roles map[string]*RoleDefaults `json:"roles,omitempty"`
+}
+
+// WebhookConfig is one endpoint notified of bead state changes. Each
+// delivery is a JSON POST; with Secret set, it carries an
+// X-Gastown-Signature header of "sha256=" plus the hex HMAC-SHA256 of the
+// body, keyed by Secret.
+type WebhookConfig struct {
+	// URL is the endpoint to POST to.
+	URL string `json:"url"`
+
+	// Secret signs each delivery. A value of the form "$NAME" is read from
+	// the environment variable NAME instead of being stored in settings.
+	Secret string `json:"secret,omitempty"`
+
+	// States limits deliveries to these bead states. Default: all.
+	States []string `json:"states,omitempty"`
+}
+
+// HeadlessConfig configures headless town agents. A headless agent has no
+// terminal: a supervisor process runs the agent's non-interactive mode
+// (e.g. claude -p) once per cycle, appending its output to
+// logs/headless-<role>.log, and restarts it with backoff if it fails.
+type HeadlessConfig struct {
+	// Roles lists the agents to run headless: "mayor", "deacon".
+	Roles []string `json:"roles,omitempty"`
+
+	// Interval is the pause between cycles, as a duration string.
+	// Default: "5m".
+	Interval string `json:"interval,omitempty"`
+}
+
+// IsHeadless reports whether role is configured to run headless.
+func (s *TownSettings) IsHeadless(role string) bool {
+	if s == nil || s.Headless == nil {
+		return false
+	}
+	for _, r := range s.Headless.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
 }
 
 // NewTownSettings creates a new TownSettings with defaults.
@@ -154,6 +339,113 @@ func DefaultWebTimeoutsConfig() *WebTimeoutsConfig {
 	}
 }
 
+// SessionLayout describes the tmux windows and panes created around an agent.
+// Commands may use placeholders expanded at spawn time:
+//   - {workdir}: the agent's working directory
+//   - {session}: the tmux session name
+//   - {log}: the captured session log file (see gt session logs)
+//
+// An empty command starts the default shell.
+type SessionLayout struct {
+	// Panes are split off the agent's window. The agent pane keeps focus.
+	Panes []SessionPaneConfig `json:"panes,omitempty"`
+
+	// Windows are created after the agent's window, in order.
+	Windows []SessionWindowConfig `json:"windows,omitempty"`
+}
+
+// SessionPaneConfig describes a pane split off the agent's window.
+type SessionPaneConfig struct {
+	// Command runs in the pane. Empty starts a shell.
+	Command string `json:"command,omitempty"`
+
+	// Split is "horizontal" (side by side) or "vertical" (stacked, default).
+	Split string `json:"split,omitempty"`
+
+	// Size is the pane size as a percentage of the window (default: tmux's 50%).
+	Size int `json:"size,omitempty"`
+}
+
+// SessionWindowConfig describes an extra window in an agent's session.
+type SessionWindowConfig struct {
+	// Name is the window name shown in the status bar.
+	Name string `json:"name,omitempty"`
+
+	// Command runs in the window. Empty starts a shell.
+	Command string `json:"command,omitempty"`
+}
+
+// SessionEnvConfig declares custom environment variables for agent sessions.
+// Identity variables (GT_ROLE, GT_RIG, GT_POLECAT, BD_ACTOR, ...) and GT_ROOT
+// are owned by AgentEnv and cannot be set here.
+type SessionEnvConfig struct {
+	// Vars apply to every role.
+	Vars map[string]string `json:"vars,omitempty"`
+
+	// Roles adds or overrides vars for specific roles, keyed by role name
+	// ("mayor", "deacon", "boot", "witness", "refinery", "polecat", "crew", "dog").
+	Roles map[string]map[string]string `json:"roles,omitempty"`
+}
+
+// SessionGroup is a named set of agent sessions managed as a unit.
+type SessionGroup struct {
+	// Description is shown by 'gt session group list'.
+	Description string `json:"description,omitempty"`
+
+	// Members are agent addresses, started in the order listed:
+	// "mayor", "deacon", "<rig>/witness", "<rig>/refinery",
+	// "<rig>/crew/<name>", or "<rig>/polecats/<name>" (also "<rig>/<name>").
+	Members []string `json:"members"`
+}
+
+// StartupProfile is a named set of agents started by 'gt start'. The Mayor
+// and Deacon always start; a profile adds to them.
+type StartupProfile struct {
+	// Description is shown when the profile is unknown.
+	Description string `json:"description,omitempty"`
+
+	// RigAgents starts each rig's Witness and Refinery.
+	RigAgents bool `json:"rig_agents,omitempty"`
+
+	// Rigs limits RigAgents to the named rigs. Empty means every rig.
+	Rigs []string `json:"rigs,omitempty"`
+
+	// Members are further agent addresses to start, in order, using the
+	// same forms as SessionGroup members.
+	Members []string `json:"members,omitempty"`
+}
+
+// SessionHooksConfig lists commands run on session lifecycle events. These are
+// Gas Town's own hooks, run by the session managers, and are separate from the
+// agent runtime's hooks (such as Claude Code's SessionStart).
+type SessionHooksConfig struct {
+	// Start hooks run after a session is created, while the agent starts up.
+	Start []SessionHook `json:"start,omitempty"`
+
+	// Stop hooks run after a session has been killed.
+	Stop []SessionHook `json:"stop,omitempty"`
+
+	// Restart hooks run after a session has been restarted in place
+	// ('gt session restart', 'gt crew restart', or the daemon's supervisor).
+	Restart []SessionHook `json:"restart,omitempty"`
+}
+
+// SessionHook is a shell command run on a session lifecycle event. The
+// command runs with sh -c in the town root, with GT_HOOK_EVENT, GT_SESSION,
+// GT_SESSION_ROLE, GT_SESSION_RIG, GT_SESSION_AGENT, GT_SESSION_WORKDIR, and
+// GT_TOWN_ROOT set.
+type SessionHook struct {
+	// Command is the shell command to run.
+	Command string `json:"command"`
+
+	// Roles limits the hook to these roles; empty means every role.
+	Roles []string `json:"roles,omitempty"`
+
+	// Timeout bounds how long the command may run, as a duration string
+	// (default "30s").
+	Timeout string `json:"timeout,omitempty"`
+}
+
 // WorkerStatusConfig configures activity-age thresholds for worker status classification.
 type WorkerStatusConfig struct {
 	// StaleThreshold is the activity age after which a worker is considered "stale".
@@ -423,6 +715,10 @@ type DoltThresholds struct {
 
 	// SlowQueryThreshold is duration above which a query is flagged slow (default "1s").
 	SlowQueryThreshold string `json:"slow_query_threshold,omitempty"`
+
+	// QueryCacheTTL is how long gt reuses the result of a hooked-work or
+	// ready-queue bead query (default "5s"). "0s" disables the cache.
+	QueryCacheTTL string `json:"query_cache_ttl,omitempty"`
 }
 
 // MailThresholds configures mail system thresholds.
@@ -585,6 +881,12 @@ type RigsConfig struct {
 	Rigs    map[string]RigEntry `json:"rigs"`
 }
 
+// VCS backends a rig can use (RigEntry.VCS).
+const (
+	VCSGit     = "git"
+	VCSJujutsu = "jj"
+)
+
 // RigEntry represents a single rig in the registry.
 type RigEntry struct {
 	GitURL      string       `json:"git_url"`
@@ -593,6 +895,32 @@ type RigEntry struct {
 	LocalRepo   string       `json:"local_repo,omitempty"`
 	AddedAt     time.Time    `json:"added_at"`
 	BeadsConfig *BeadsConfig `json:"beads,omitempty"`
+
+	// RemoteHost is an optional ssh destination (user@host or ssh_config alias)
+	// where the rig's polecat sessions run. The host needs tmux, gt, and the
+	// town at the same path (e.g., a shared mount). Empty runs sessions locally.
+	RemoteHost string `json:"remote_host,omitempty"`
+
+	// DefaultBranch is the branch polecats fork from and merges land on
+	// (main, master, trunk, ...). Recorded at 'gt rig add' from the remote;
+	// when empty, gt falls back to <rig>/config.json, then detection.
+	DefaultBranch string `json:"default_branch,omitempty"`
+
+	// VCS selects the version-control backend polecat worktrees are managed
+	// with: "git" (default) or "jj" (Jujutsu, see 'gt rig vcs').
+	VCS string `json:"vcs,omitempty"`
+
+	// Disabled takes the rig out of rotation for maintenance ('gt rig
+	// disable'): nothing is spawned, dispatched, patrolled or started on it
+	// until 'gt rig enable'. DisabledReason is shown in status output.
+	Disabled       bool   `json:"disabled,omitempty"`
+	DisabledReason string `json:"disabled_reason,omitempty"`
+
+	// Roles sets agent runtime settings per role for this rig (model,
+	// args, permission mode, config dir, env). It layers between town
+	// roles.<role> and the rig's own settings/config.json roles.<role>;
+	// see RoleDefaults.
+	Roles map[string]*RoleDefaults `json:"roles,omitempty"`
 }
 
 // BeadsConfig represents beads configuration for a rig.
@@ -646,6 +974,42 @@ type RigSettings struct {
 	Workflow   *WorkflowConfig   `json:"workflow,omitempty"`    // workflow settings
 	Runtime    *RuntimeConfig    `json:"runtime,omitempty"`     // LLM runtime settings (deprecated: use Agent)
 
+	// Container runs polecat sessions inside a per-polecat container.
+	// Nil (or an empty image) runs polecats directly on the host.
+	Container *ContainerConfig `json:"container,omitempty"`
+
+	// Signing configures commit signing for this rig's worktrees.
+	// Nil leaves signing to each agent's own git config.
+	Signing *SigningConfig `json:"signing,omitempty"`
+
+	// Branches sets the polecat branch naming scheme and the branches
+	// agents must never commit to.
+	Branches *BranchPolicyConfig `json:"branches,omitempty"`
+
+	// LFS controls Git LFS setup in new polecat worktrees.
+	// Nil auto-detects LFS from the repo's .gitattributes.
+	LFS *LFSConfig `json:"lfs,omitempty"`
+
+	// SessionEnv adds custom environment variables to this rig's agent
+	// sessions, layered over the town's session_env.
+	SessionEnv *SessionEnvConfig `json:"session_env,omitempty"`
+
+	// Secrets exports secrets into this rig's agent sessions, layered over
+	// the town's secrets. Values are references, as in TownSettings.Secrets.
+	Secrets *SessionEnvConfig `json:"secrets,omitempty"`
+
+	// GitHub syncs this rig's beads with GitHub Issues (gt sync github).
+	// Nil disables the connector.
+	GitHub *GitHubSyncConfig `json:"github,omitempty"`
+
+	// Jira syncs this rig's beads with a Jira project (gt sync jira).
+	// Nil disables the connector.
+	Jira *JiraSyncConfig `json:"jira,omitempty"`
+
+	// Linear syncs this rig's beads with a Linear team (gt sync linear).
+	// Nil disables the connector.
+	Linear *LinearSyncConfig `json:"linear,omitempty"`
+
 	// Agent selects which agent preset to use for this rig.
 	// Can be a built-in preset ("claude", "gemini", "codex", "cursor", "auggie", "amp", "opencode", "copilot")
 	// or a custom agent defined in settings/agents.json.
@@ -670,6 +1034,247 @@ type RigSettings struct {
 	// Takes precedence over RoleAgents["crew"] but is overridden by explicit --agent flags.
 	// Example: {"denali": "codex", "glacier": "gemini"}
 	WorkerAgents map[string]string `json:"worker_agents,omitempty"`
+
+	// HandoffTemplates overrides the town's handoff templates for this rig's
+	// agents, per role.
+	HandoffTemplates map[string]*HandoffTemplate `json:"handoff_templates,omitempty"`
+
+	// Defaults overrides the town's defaults for every role in this rig.
+	// See RoleDefaults.
+	Defaults *RoleDefaults `json:"defaults,omitempty"`
+
+	// Roles overrides defaults per role for this rig, keyed by role name.
+	Roles map[string]*RoleDefaults `json:"roles,omitempty"`
+}
+
+// ContainerConfig configures containerized polecat sessions for a rig.
+// Each polecat runs in its own container named after its tmux session, with
+// its worktree bind-mounted read-write at the same path and the town root
+// mounted read-only so gt and bd resolve config exactly as on the host.
+type ContainerConfig struct {
+	// Image is the container image. Must provide the agent CLI, gt, bd, and git.
+	Image string `json:"image"`
+
+	// Runtime is the container CLI: "docker" (default) or "podman".
+	Runtime string `json:"runtime,omitempty"`
+
+	// Network is the container network mode. Default: "host", so bd can reach
+	// the town's Dolt server on localhost.
+	Network string `json:"network,omitempty"`
+
+	// Mounts are extra bind mounts in runtime syntax (e.g., "~/.claude:/root/.claude").
+	Mounts []string `json:"mounts,omitempty"`
+
+	// Args are extra arguments passed to "<runtime> run" before the image.
+	Args []string `json:"args,omitempty"`
+}
+
+// SigningConfig configures commit signing for a rig. Polecat worktrees get
+// it in their per-worktree git config at creation; when Required, the
+// refinery also refuses to land branches carrying unsigned commits.
+type SigningConfig struct {
+	// Required enforces signed commits: worktree setup failures fail polecat
+	// creation, and the merge queue verifies signatures before landing.
+	Required bool `json:"required,omitempty"`
+
+	// Format is the signature format: "ssh" or "gpg". Empty uses git's
+	// default (gpg).
+	Format string `json:"format,omitempty"`
+
+	// Key is the default signing key: a GPG key ID, or for ssh a public key
+	// path or literal "key::ssh-ed25519 ..." string.
+	Key string `json:"key,omitempty"`
+
+	// RoleKeys overrides Key per role (e.g., "polecat", "refinery").
+	RoleKeys map[string]string `json:"role_keys,omitempty"`
+
+	// AccountKeys overrides Key per account handle (see mayor/accounts.json).
+	// Takes precedence over RoleKeys.
+	AccountKeys map[string]string `json:"account_keys,omitempty"`
+
+	// AllowedSigners is the ssh allowed-signers file used to verify ssh
+	// signatures. Required to verify when Format is "ssh".
+	AllowedSigners string `json:"allowed_signers,omitempty"`
+}
+
+// KeyFor returns the signing key for an agent, preferring the account's key,
+// then the role's, then the default. Empty means no key is configured.
+func (c *SigningConfig) KeyFor(role, account string) string {
+	if c == nil {
+		return ""
+	}
+	if k := c.AccountKeys[account]; account != "" && k != "" {
+		return k
+	}
+	if k := c.RoleKeys[role]; k != "" {
+		return k
+	}
+	return c.Key
+}
+
+// BranchPolicyConfig is a rig's branch naming and protection policy.
+type BranchPolicyConfig struct {
+	// Prefix is the template for the per-polecat part of branch names,
+	// which gt appends "/<issue>@<timestamp>" (or "-<timestamp>") to.
+	// Supports the polecat_branch_template variables ({name}, {user}, ...).
+	// Default: "polecat/{name}". A full polecat_branch_template overrides it.
+	Prefix string `json:"prefix,omitempty"`
+
+	// Protected lists branches agents must not commit or push to, as exact
+	// names or path.Match globs (e.g., "release/*"). The rig's default
+	// branch is always protected.
+	Protected []string `json:"protected,omitempty"`
+}
+
+// LFSConfig controls how polecat worktrees fetch Git LFS content.
+type LFSConfig struct {
+	// Enabled forces LFS setup on (true) or off (false). Nil sets it up
+	// only when the repo's .gitattributes use the lfs filter.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Include limits fetched LFS objects to these paths (git lfs
+	// --include patterns). Empty fetches everything not excluded.
+	Include []string `json:"include,omitempty"`
+
+	// Exclude skips LFS objects under these paths (git lfs --exclude
+	// patterns). Skipped files stay pointer files.
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+// DefaultTrackerSyncLabel marks beads and external issues that are synced
+// when a connector doesn't set its own label.
+const DefaultTrackerSyncLabel = "gastown"
+
+// DefaultTrackerSyncInterval is how often the daemon syncs a connector
+// that doesn't set its own interval.
+const DefaultTrackerSyncInterval = "15m"
+
+// TrackerSyncConfig holds the settings every external tracker connector
+// shares.
+type TrackerSyncConfig struct {
+	// Label selects what is synced: beads carrying it are pushed to the
+	// tracker, and tracker issues carrying it are pulled into the rig's
+	// beads. Default: "gastown".
+	Label string `json:"label,omitempty"`
+
+	// Interval is how often the daemon syncs (e.g., "15m"). "0" disables
+	// scheduled sync, leaving only gt sync. Default: "15m".
+	Interval string `json:"interval,omitempty"`
+
+	// Assignees maps bead assignees (agent addresses like
+	// "gastown/crew/max") to tracker users. Unmapped assignees are not
+	// synced in either direction.
+	Assignees map[string]string `json:"assignees,omitempty"`
+}
+
+// GetLabel returns the sync label, or DefaultTrackerSyncLabel.
+func (c *TrackerSyncConfig) GetLabel() string {
+	if c == nil || c.Label == "" {
+		return DefaultTrackerSyncLabel
+	}
+	return c.Label
+}
+
+// GetInterval returns the scheduled sync interval; zero means manual only.
+func (c *TrackerSyncConfig) GetInterval() time.Duration {
+	def := ParseDurationOrDefault(DefaultTrackerSyncInterval, 15*time.Minute)
+	if c == nil {
+		return def
+	}
+	return ParseDurationOrDefault(c.Interval, def)
+}
+
+// GitHubSyncConfig configures two-way sync between a rig's beads and the
+// issues of a GitHub repository. Authenticates with GITHUB_TOKEN.
+type GitHubSyncConfig struct {
+	// Repo is the repository as "owner/name".
+	Repo string `json:"repo"`
+
+	TrackerSyncConfig
+}
+
+// DefaultJiraIssueType is the issue type created for beads when a Jira
+// connector doesn't set its own.
+const DefaultJiraIssueType = "Task"
+
+// defaultJiraStatuses are the Jira statuses synced bead statuses move to.
+var defaultJiraStatuses = map[string]string{
+	"open":        "To Do",
+	"in_progress": "In Progress",
+	"closed":      "Done",
+}
+
+// JiraSyncConfig configures two-way sync between a rig's beads and a Jira
+// project. Authenticates with JIRA_API_TOKEN: with Email set, as a Jira
+// Cloud API token; without it, as a Data Center personal access token.
+type JiraSyncConfig struct {
+	// URL is the site's base URL (e.g., "https://acme.atlassian.net").
+	URL string `json:"url"`
+
+	// Project is the project key (e.g., "ENG").
+	Project string `json:"project"`
+
+	// Email is the Jira Cloud account the API token belongs to. On Cloud,
+	// assignees are account IDs; on Data Center, usernames.
+	Email string `json:"email,omitempty"`
+
+	// IssueType is the type of issue created for beads. Default: "Task".
+	IssueType string `json:"issue_type,omitempty"`
+
+	// Fields maps bead fields ("title", "description") to Jira field IDs,
+	// for projects that keep them in custom fields (e.g.,
+	// {"title": "customfield_10042"}). Default: "summary" and "description".
+	Fields map[string]string `json:"fields,omitempty"`
+
+	// Statuses maps synced statuses ("open", "in_progress", "closed") to
+	// the Jira status a ticket is transitioned to. Defaults: "To Do",
+	// "In Progress", "Done". Jira statuses are read back by category.
+	Statuses map[string]string `json:"statuses,omitempty"`
+
+	TrackerSyncConfig
+}
+
+// GetIssueType returns the issue type for new tickets, or DefaultJiraIssueType.
+func (c *JiraSyncConfig) GetIssueType() string {
+	if c == nil || c.IssueType == "" {
+		return DefaultJiraIssueType
+	}
+	return c.IssueType
+}
+
+// Field returns the Jira field ID a bead field maps to. Unmapped fields
+// use Jira's own: "title" is "summary", anything else keeps its name.
+func (c *JiraSyncConfig) Field(name string) string {
+	if c != nil && c.Fields[name] != "" {
+		return c.Fields[name]
+	}
+	if name == "title" {
+		return "summary"
+	}
+	return name
+}
+
+// StatusName returns the Jira status a synced status maps to.
+func (c *JiraSyncConfig) StatusName(status string) string {
+	if c != nil && c.Statuses[status] != "" {
+		return c.Statuses[status]
+	}
+	return defaultJiraStatuses[status]
+}
+
+// LinearSyncConfig configures two-way sync between a rig's beads and the
+// issues of a Linear team. Authenticates with LINEAR_API_KEY.
+type LinearSyncConfig struct {
+	// Team is the team key (e.g., "ENG").
+	Team string `json:"team"`
+
+	// Statuses maps synced statuses ("open", "in_progress", "closed") to
+	// the workflow state an issue is moved to. By default the team's first
+	// unstarted, started, and completed states are used. Linear states are
+	// read back by type.
+	Statuses map[string]string `json:"statuses,omitempty"`
+
+	TrackerSyncConfig
 }
 
 // CrewConfig represents crew workspace settings for a rig.
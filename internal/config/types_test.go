@@ -623,3 +623,27 @@ func TestParseDurationOrDefault_AllWebTimeoutDefaults(t *testing.T) {
 }
 
 
+
+func TestSigningConfigKeyFor(t *testing.T) {
+	cfg := &SigningConfig{
+		Key:         "default",
+		RoleKeys:    map[string]string{"refinery": "role"},
+		AccountKeys: map[string]string{"work": "account"},
+	}
+	tests := []struct {
+		role, account, want string
+	}{
+		{"polecat", "", "default"},
+		{"refinery", "", "role"},
+		{"refinery", "work", "account"},
+		{"polecat", "personal", "default"},
+	}
+	for _, tt := range tests {
+		if got := cfg.KeyFor(tt.role, tt.account); got != tt.want {
+			t.Errorf("KeyFor(%q, %q) = %q, want %q", tt.role, tt.account, got, tt.want)
+		}
+	}
+	if got := (*SigningConfig)(nil).KeyFor("polecat", ""); got != "" {
+		t.Errorf("nil KeyFor = %q, want empty", got)
+	}
+}
@@ -126,6 +126,10 @@ const (
 	// DirRig is the subdirectory containing the actual git clone.
 	DirRig = "rig"
 
+	// DirLogs is the directory for gt's structured log files, in mayor/
+	// and in each rig.
+	DirLogs = "logs"
+
 	// DirBeads is the beads database directory.
 	DirBeads = ".beads"
 
@@ -406,6 +410,16 @@ func MayorQuotaPath(townRoot string) string {
 	return townRoot + "/" + DirMayor + "/" + FileQuotaJSON
 }
 
+// MayorLogsPath returns the path to mayor/logs within a town root.
+func MayorLogsPath(townRoot string) string {
+	return townRoot + "/" + DirMayor + "/" + DirLogs
+}
+
+// RigLogsPath returns the path to logs/ within a rig.
+func RigLogsPath(rigPath string) string {
+	return rigPath + "/" + DirLogs
+}
+
 // DefaultRateLimitPatterns are the default patterns that indicate a session
 // is rate-limited. These are matched against tmux pane content.
 // Note: patterns are compiled with (?i) for case-insensitive matching.
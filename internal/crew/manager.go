@@ -724,79 +724,14 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 	// These are passed via tmux -e flags so the initial shell inherits the correct
 	// env from the start, preventing parent env (e.g., GT_ROLE=mayor) from leaking
 	// into crew sessions. See: https://github.com/steveyegge/gastown/issues/1289
-	envVars := config.AgentEnv(config.AgentEnvConfig{
-		Role:             "crew",
-		Rig:              m.rig.Name,
-		AgentName:        name,
-		TownRoot:         townRoot,
-		RuntimeConfigDir: opts.ClaudeConfigDir,
-		Agent:            opts.AgentOverride,
-	})
-	envVars = session.MergeRuntimeLivenessEnv(envVars, runtimeConfig)
+	envVars := m.sessionEnv(name, townRoot, opts, runtimeConfig)
 
-	// Build startup command (also includes env vars via 'exec env' for
-	// WaitForCommand detection — belt and suspenders with -e flags)
-	// SessionStart hook handles context loading (gt prime --hook)
-	//
 	// IMPORTANT: All validation and command building happens BEFORE killing
 	// any existing session, so a validation failure cannot leave the user
 	// without a running session.
-	var claudeCmd string
-	if opts.ResumeSessionID != "" {
-		// Validate session ID to prevent shell injection. The ID is interpolated
-		// into a shell command string, so reject anything with metacharacters.
-		if err := validateSessionID(opts.ResumeSessionID); err != nil {
-			return err
-		}
-
-		// Resume mode: build command without prompt, then append resume flag.
-		// No beacon is passed as prompt - the resumed session already has context.
-		// The SessionStart hook still fires and injects Gas Town metadata.
-		claudeCmd, err = config.BuildCrewStartupCommandWithAgentOverride(m.rig.Name, name, m.rig.Path, "", opts.AgentOverride)
-		if err != nil {
-			return fmt.Errorf("building resume command: %w", err)
-		}
-
-		// Determine agent preset for resume flag.
-		// Try worker-level agent config first, fall back to "claude".
-		agentName := opts.AgentOverride
-		if agentName == "" {
-			if rc := config.ResolveWorkerAgentConfig(name, townRoot, m.rig.Path); rc != nil && rc.Provider != "" {
-				agentName = rc.Provider
-			} else {
-				agentName = "claude"
-			}
-		}
-		resumeArgs, err := buildResumeArgs(agentName, opts.ResumeSessionID)
-		if err != nil {
-			return err
-		}
-		claudeCmd += " " + resumeArgs
-	} else {
-		// Normal start: build beacon for predecessor discovery via /resume.
-		// Only used in fresh-start mode — resumed sessions already have context.
-		address := session.BeaconRecipient("crew", name, m.rig.Name)
-		topic := opts.Topic
-		if topic == "" {
-			topic = "start"
-		}
-		beacon := session.FormatStartupBeacon(session.BeaconConfig{
-			Recipient: address,
-			Sender:    "human",
-			Topic:     topic,
-		})
-		claudeCmd, err = config.BuildStartupCommandFromConfig(config.AgentEnvConfig{
-			Role:        "crew",
-			Rig:         m.rig.Name,
-			AgentName:   name,
-			TownRoot:    townRoot,
-			Prompt:      beacon,
-			Topic:       topic,
-			SessionName: m.SessionName(name),
-		}, m.rig.Path, beacon, opts.AgentOverride)
-		if err != nil {
-			return fmt.Errorf("building startup command: %w", err)
-		}
+	claudeCmd, err := m.startupCommand(name, townRoot, opts)
+	if err != nil {
+		return err
 	}
 
 	t := tmux.NewTmux()
@@ -828,11 +763,6 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 		}
 	}
 
-	// For interactive/refresh mode, remove --dangerously-skip-permissions
-	if opts.Interactive {
-		claudeCmd = strings.Replace(claudeCmd, " --dangerously-skip-permissions", "", 1)
-	}
-
 	// Create session with command and env vars via -e flags.
 	// The -e flags set session-level env BEFORE the shell starts, ensuring the
 	// initial shell inherits the correct GT_ROLE (not the parent's).
@@ -842,6 +772,17 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 		return fmt.Errorf("creating session: %w", err)
 	}
 
+	// Capture pane output to crew/<name>/.logs so scrollback survives crashes (non-fatal).
+	_ = session.EnableSessionLogCapture(t, townRoot, sessionID)
+
+	// Create crew's configured panes/windows (non-fatal).
+	_ = session.ApplySessionLayout(t, townRoot, "crew", sessionID, worker.ClonePath)
+
+	session.RunSessionHooks(townRoot, session.HookStart, sessionID, worker.ClonePath)
+
+	// Restore operator-assigned labels and display name (non-fatal).
+	_ = session.ApplySessionMeta(t, townRoot, sessionID)
+
 	// Record agent's pane_id for ZFC-compliant liveness checks (gt-qmsx).
 	if paneID, err := t.GetPaneID(sessionID); err == nil {
 		_ = t.SetEnvironment(sessionID, "GT_PANE_ID", paneID)
@@ -894,6 +835,122 @@ func (m *Manager) Start(name string, opts StartOptions) error {
 	return nil
 }
 
+// sessionEnv returns the environment a crew member's session starts with.
+func (m *Manager) sessionEnv(name, townRoot string, opts StartOptions, runtimeConfig *config.RuntimeConfig) map[string]string {
+	envVars := session.AgentSessionEnv(m.agentEnvConfig(name, townRoot, opts), m.rig.Path)
+	return session.MergeRuntimeLivenessEnv(envVars, runtimeConfig)
+}
+
+// plannedSessionEnv is sessionEnv for dry runs, with secrets unresolved.
+func (m *Manager) plannedSessionEnv(name, townRoot string, opts StartOptions, runtimeConfig *config.RuntimeConfig) map[string]string {
+	envVars := session.PlannedAgentSessionEnv(m.agentEnvConfig(name, townRoot, opts), m.rig.Path)
+	return session.MergeRuntimeLivenessEnv(envVars, runtimeConfig)
+}
+
+// agentEnvConfig returns the AgentEnv settings for a crew member's session.
+func (m *Manager) agentEnvConfig(name, townRoot string, opts StartOptions) config.AgentEnvConfig {
+	return config.AgentEnvConfig{
+		Role:             "crew",
+		Rig:              m.rig.Name,
+		AgentName:        name,
+		TownRoot:         townRoot,
+		RuntimeConfigDir: opts.ClaudeConfigDir,
+		Agent:            opts.AgentOverride,
+		SessionName:      m.SessionName(name),
+	}
+}
+
+// startupCommand builds the command a crew member's session runs. The
+// command also includes env vars via 'exec env' for WaitForCommand
+// detection — belt and suspenders with -e flags. SessionStart hook handles
+// context loading (gt prime --hook).
+func (m *Manager) startupCommand(name, townRoot string, opts StartOptions) (string, error) {
+	var claudeCmd string
+	var err error
+	if opts.ResumeSessionID != "" {
+		// Validate session ID to prevent shell injection. The ID is interpolated
+		// into a shell command string, so reject anything with metacharacters.
+		if err := validateSessionID(opts.ResumeSessionID); err != nil {
+			return "", err
+		}
+
+		// Resume mode: build command without prompt, then append resume flag.
+		// No beacon is passed as prompt - the resumed session already has context.
+		// The SessionStart hook still fires and injects Gas Town metadata.
+		claudeCmd, err = config.BuildCrewStartupCommandWithAgentOverride(m.rig.Name, name, m.rig.Path, "", opts.AgentOverride)
+		if err != nil {
+			return "", fmt.Errorf("building resume command: %w", err)
+		}
+
+		// Determine agent preset for resume flag.
+		// Try worker-level agent config first, fall back to "claude".
+		agentName := opts.AgentOverride
+		if agentName == "" {
+			if rc := config.ResolveWorkerAgentConfig(name, townRoot, m.rig.Path); rc != nil && rc.Provider != "" {
+				agentName = rc.Provider
+			} else {
+				agentName = "claude"
+			}
+		}
+		resumeArgs, err := buildResumeArgs(agentName, opts.ResumeSessionID)
+		if err != nil {
+			return "", err
+		}
+		claudeCmd += " " + resumeArgs
+	} else {
+		// Normal start: build beacon for predecessor discovery via /resume.
+		// Only used in fresh-start mode — resumed sessions already have context.
+		address := session.BeaconRecipient("crew", name, m.rig.Name)
+		topic := opts.Topic
+		if topic == "" {
+			topic = "start"
+		}
+		beacon := session.FormatStartupBeacon(session.BeaconConfig{
+			Recipient: address,
+			Sender:    "human",
+			Topic:     topic,
+		})
+		claudeCmd, err = config.BuildStartupCommandFromConfig(config.AgentEnvConfig{
+			Role:        "crew",
+			Rig:         m.rig.Name,
+			AgentName:   name,
+			TownRoot:    townRoot,
+			Prompt:      beacon,
+			Topic:       topic,
+			SessionName: m.SessionName(name),
+		}, m.rig.Path, beacon, opts.AgentOverride)
+		if err != nil {
+			return "", fmt.Errorf("building startup command: %w", err)
+		}
+	}
+
+	// For interactive/refresh mode, remove --dangerously-skip-permissions
+	if opts.Interactive {
+		claudeCmd = strings.Replace(claudeCmd, " --dangerously-skip-permissions", "", 1)
+	}
+	return claudeCmd, nil
+}
+
+// Plan returns the tmux session Start would create for a crew member,
+// without creating the session or the crew workspace.
+func (m *Manager) Plan(name string, opts StartOptions) (*session.StartPlan, error) {
+	if err := validateCrewName(name); err != nil {
+		return nil, err
+	}
+	townRoot := filepath.Dir(m.rig.Path)
+	command, err := m.startupCommand(name, townRoot, opts)
+	if err != nil {
+		return nil, err
+	}
+	runtimeConfig := config.ResolveWorkerAgentConfig(name, townRoot, m.rig.Path)
+	return &session.StartPlan{
+		SessionID: m.SessionName(name),
+		WorkDir:   m.crewDir(name),
+		Command:   command,
+		Env:       m.plannedSessionEnv(name, townRoot, opts, runtimeConfig),
+	}, nil
+}
+
 // Stop terminates a crew member's tmux session.
 func (m *Manager) Stop(name string) error {
 	if err := validateCrewName(name); err != nil {
@@ -919,14 +976,9 @@ func (m *Manager) Stop(name string) error {
 		style.PrintWarning("could not stop nudge poller for %s: %v", name, pollerErr)
 	}
 
-	// Kill the session.
-	// Use KillSessionWithProcesses to ensure all descendant processes are killed.
+	// Kill the session and all descendant processes, then run stop hooks.
 	// This prevents orphan bash processes from Claude's Bash tool surviving session termination.
-	if err := t.KillSessionWithProcesses(sessionID); err != nil {
-		return fmt.Errorf("killing session: %w", err)
-	}
-
-	return nil
+	return session.TerminateSession(t, townRoot, sessionID)
 }
 
 // IsRunning checks if a crew member's session is active.
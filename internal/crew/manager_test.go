@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 )
@@ -1006,3 +1007,30 @@ func runCmd(name string, args ...string) error {
 	cmd := exec.Command(name, args...)
 	return cmd.Run()
 }
+
+func TestSessionEnv_IncludesConfiguredSessionEnv(t *testing.T) {
+	townRoot := t.TempDir()
+	t.Setenv("GT_TEST_CREW_TOKEN", "s3cret")
+	rigPath := filepath.Join(townRoot, "test-rig")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	town := config.NewTownSettings()
+	town.SessionEnv = &config.SessionEnvConfig{Roles: map[string]map[string]string{"crew": {"TEAM": "core"}}}
+	town.Secrets = &config.SessionEnvConfig{Vars: map[string]string{"API_TOKEN": "env:GT_TEST_CREW_TOKEN"}}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+
+	mgr := NewManager(&rig.Rig{Name: "test-rig", Path: rigPath}, git.NewGit(rigPath))
+	env := mgr.sessionEnv("alice", townRoot, StartOptions{}, nil)
+	if env["TEAM"] != "core" {
+		t.Errorf("TEAM = %q, want %q", env["TEAM"], "core")
+	}
+	if env["API_TOKEN"] != "s3cret" {
+		t.Errorf("API_TOKEN = %q, want the resolved secret", env["API_TOKEN"])
+	}
+	if env["GT_CREW"] != "alice" {
+		t.Errorf("GT_CREW = %q, want %q", env["GT_CREW"], "alice")
+	}
+}
@@ -0,0 +1,24 @@
+package daemon
+
+import (
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// replayBeadQueues replays bead mutations that were queued while the beads
+// database was unreachable. Queues are otherwise only replayed by the next
+// bd command against the same database, which may never come once the
+// agent that queued them is gone.
+func (d *Daemon) replayBeadQueues() {
+	for _, dir := range beads.FindQueues(d.config.TownRoot) {
+		replayed, err := beads.NewWithBeadsDir(filepath.Dir(dir), dir).ReplayQueue()
+		if err != nil {
+			d.logger.Printf("Bead queue %s: replayed %d, still pending: %v", dir, replayed, err)
+			continue
+		}
+		if replayed > 0 {
+			d.logger.Printf("Bead queue %s: replayed %d queued mutation(s)", dir, replayed)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// restartAllowed reports whether the daemon may restart agentID now: not
+// while the agent is crash-looping or inside its restart backoff.
+func (d *Daemon) restartAllowed(agentID string) bool {
+	if d.restartTracker == nil {
+		return true
+	}
+	if d.restartTracker.IsInCrashLoop(agentID) {
+		d.logger.Printf("%s is in crash loop, skipping restart (use 'gt daemon clear-backoff %s' to reset)", agentID, agentID)
+		return false
+	}
+	if !d.restartTracker.CanRestart(agentID) {
+		remaining := d.restartTracker.GetBackoffRemaining(agentID)
+		d.logger.Printf("%s restart in backoff, %s remaining", agentID, remaining.Round(time.Second))
+		return false
+	}
+	return true
+}
+
+// recordAgentRestart records a daemon restart of agentID for backoff and
+// files a crash-loop incident if the restart trips the circuit breaker.
+func (d *Daemon) recordAgentRestart(agentID string) {
+	if d.restartTracker == nil {
+		return
+	}
+	tripped := d.restartTracker.RecordRestart(agentID)
+	if err := d.restartTracker.Save(); err != nil {
+		d.logger.Printf("Warning: failed to save restart state: %v", err)
+	}
+	if tripped {
+		d.fileCrashLoopIncident(agentID)
+	}
+}
+
+// crashLoopIncident returns the title and reason of the escalation filed
+// when agentID trips the crash-loop circuit breaker.
+func crashLoopIncident(agentID string, info *AgentRestartInfo, window time.Duration) (title, reason string) {
+	restarts := 0
+	if info != nil {
+		restarts = len(info.Restarts)
+	}
+	title = fmt.Sprintf("Crash loop: %s restarted %d times in %s", agentID, restarts, window)
+	reason = fmt.Sprintf("The daemon has stopped restarting %s to avoid burning quota on a crashing agent. "+
+		"Fix the cause, then run 'gt daemon clear-backoff %s' to let the daemon restart it.", agentID, agentID)
+	return title, reason
+}
+
+// fileCrashLoopIncident files a high-severity escalation bead for an agent
+// the daemon has stopped restarting.
+func (d *Daemon) fileCrashLoopIncident(agentID string) {
+	title, reason := crashLoopIncident(agentID, d.restartTracker.RestartInfo(agentID), d.restartTracker.config.CrashLoopWindow)
+	d.logger.Printf("CRASH LOOP: %s", title)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "gt", "escalate", title,
+		"-s", "high", "--source", "daemon:restart-tracker", "--reason", reason)
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = append(os.Environ(), "BD_ACTOR=daemon")
+	util.SetDetachedProcessGroup(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		d.logger.Printf("Warning: failed to file crash-loop incident for %s: %v (%s)", agentID, err, strings.TrimSpace(string(output)))
+	}
+}
@@ -0,0 +1,59 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordRestart_TripsBreakerOnceInsideWindow(t *testing.T) {
+	rt := NewRestartTracker(t.TempDir(), RestartTrackerConfig{CrashLoopCount: 3})
+
+	for i := 1; i <= 2; i++ {
+		if rt.RecordRestart("gt-witness") {
+			t.Fatalf("restart %d tripped the breaker early", i)
+		}
+	}
+	if !rt.RecordRestart("gt-witness") {
+		t.Fatal("third restart inside the window should trip the breaker")
+	}
+	if !rt.IsInCrashLoop("gt-witness") || rt.CanRestart("gt-witness") {
+		t.Error("agent should be crash-looping and not restartable")
+	}
+	if rt.RecordRestart("gt-witness") {
+		t.Error("breaker should trip only once")
+	}
+
+	rt.ClearCrashLoop("gt-witness")
+	if !rt.CanRestart("gt-witness") {
+		t.Error("clear-backoff should allow restarts again")
+	}
+}
+
+func TestRecordRestart_IgnoresRestartsOutsideWindow(t *testing.T) {
+	rt := NewRestartTracker(t.TempDir(), RestartTrackerConfig{CrashLoopCount: 3, CrashLoopWindow: time.Minute})
+	old := time.Now().Add(-10 * time.Minute)
+	rt.state.Agents["deacon"] = &AgentRestartInfo{
+		LastRestart:  time.Now().Add(-time.Second),
+		RestartCount: 4,
+		Restarts:     []time.Time{old, old, old, old},
+	}
+
+	if rt.RecordRestart("deacon") {
+		t.Fatal("restarts outside the window should not count toward a crash loop")
+	}
+	if got := len(rt.RestartInfo("deacon").Restarts); got != 1 {
+		t.Errorf("restarts in window = %d, want 1", got)
+	}
+}
+
+func TestCrashLoopIncident(t *testing.T) {
+	info := &AgentRestartInfo{Restarts: make([]time.Time, 5)}
+	title, reason := crashLoopIncident("gt-refinery", info, 15*time.Minute)
+	if title != "Crash loop: gt-refinery restarted 5 times in 15m0s" {
+		t.Errorf("title = %q", title)
+	}
+	if !strings.Contains(reason, "gt daemon clear-backoff gt-refinery") {
+		t.Errorf("reason should say how to reset: %q", reason)
+	}
+}
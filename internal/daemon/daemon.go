@@ -32,6 +32,7 @@ import (
 	"github.com/steveyegge/gastown/internal/events"
 	"github.com/steveyegge/gastown/internal/feed"
 	gitpkg "github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/headless"
 	"github.com/steveyegge/gastown/internal/mayor"
 	"github.com/steveyegge/gastown/internal/polecat"
 	"github.com/steveyegge/gastown/internal/refinery"
@@ -61,6 +62,8 @@ type Daemon struct {
 	beadsStores   map[string]beadsdk.Storage
 	doltServer    *DoltServerManager
 	krcPruner     *KRCPruner
+	trackerSyncer *TrackerSyncer
+	webhooks      *WebhookNotifier
 
 	// disabledPatrols is loaded from town settings (disabled_patrols field).
 	// Provides a simple way to disable individual patrol dogs without editing
@@ -93,6 +96,10 @@ type Daemon struct {
 	// Restart tracking with exponential backoff to prevent crash loops
 	restartTracker *RestartTracker
 
+	// idleStopPending maps session names to when the idle_stop patrol asked
+	// them to hand off. Only accessed from heartbeat loop goroutine - no sync needed.
+	idleStopPending map[string]time.Time
+
 	// telemetry exports metrics and logs to VictoriaMetrics / VictoriaLogs.
 	// Nil when telemetry is disabled (GT_OTEL_METRICS_URL / GT_OTEL_LOGS_URL not set).
 	otelProvider *telemetry.Provider
@@ -111,6 +118,17 @@ type Daemon struct {
 	// Only accessed from heartbeat loop goroutine - no sync needed.
 	lastMaintenanceRun time.Time
 
+	// lastScheduleMinute is the last minute the town schedule was acted on,
+	// so an entry fires once even if the ticker drifts within a minute.
+	// Only accessed from heartbeat loop goroutine - no sync needed.
+	lastScheduleMinute time.Time
+
+	// scheduleInFlight holds the town schedule actions whose gt command is
+	// still running. Actions run off the heartbeat loop, so it is guarded by
+	// scheduleMu.
+	scheduleMu       sync.Mutex
+	scheduleInFlight map[string]bool
+
 	// mayorZombieCount tracks consecutive patrol cycles where the Mayor tmux
 	// session exists but the agent process is not detected. A count >= 3
 	// triggers a zombie restart, debouncing transient gaps during handoffs.
@@ -452,6 +470,18 @@ func (d *Daemon) Run() (err error) {
 		}
 	}
 
+	// Start scheduled sync with external issue trackers (no-op for rigs
+	// without a connector configured)
+	d.trackerSyncer = NewTrackerSyncer(d.config.TownRoot, d.getKnownRigs, d.logger.Printf)
+	d.trackerSyncer.Start()
+
+	// Start webhook notifier (no-op until webhooks are configured)
+	d.webhooks = NewWebhookNotifier(d.config.TownRoot, d.getKnownRigs, d.logger.Printf)
+	if err := d.webhooks.Start(); err != nil {
+		d.logger.Printf("Warning: failed to start webhook notifier: %v", err)
+		d.webhooks = nil
+	}
+
 	// Start dedicated Dolt health check ticker if Dolt server is configured.
 	// This runs at a much higher frequency (default 30s) than the general
 	// heartbeat (3 min) so Dolt crashes are detected quickly.
@@ -588,6 +618,23 @@ func (d *Daemon) Run() (err error) {
 		d.logger.Printf("Quota dog ticker started (interval %v)", interval)
 	}
 
+	// Start mayor fetch ticker if configured.
+	// Keeps each rig's mayor clone and bare repo fetched from origin.
+	var mayorFetchTicker *time.Ticker
+	var mayorFetchChan <-chan time.Time
+	if d.isPatrolActive("mayor_fetch") {
+		interval := mayorFetchInterval(d.patrolConfig)
+		mayorFetchTicker = time.NewTicker(interval)
+		mayorFetchChan = mayorFetchTicker.C
+		defer mayorFetchTicker.Stop()
+		d.logger.Printf("Mayor fetch ticker started (interval %v)", interval)
+	}
+
+	// Town schedule ticker: town.json "schedule" entries (quiet hours,
+	// dispatch windows). Cheap when no schedule is configured.
+	townScheduleTicker := time.NewTicker(townScheduleCheckInterval)
+	defer townScheduleTicker.Stop()
+
 	// Note: PATCH-010 uses per-session hooks in deacon/manager.go (SetAutoRespawnHook).
 	// Global pane-died hooks don't fire reliably in tmux 3.2a, so we rely on the
 	// per-session approach which has been tested to work for continuous recovery.
@@ -697,6 +744,19 @@ func (d *Daemon) Run() (err error) {
 				d.runQuotaDog()
 			}
 
+		case <-mayorFetchChan:
+			// Mayor fetch — fetches origin into each rig's mayor clone and
+			// bare repo so new polecats and divergence stats see fresh refs.
+			if !d.isShutdownInProgress() {
+				d.runMayorFetch()
+			}
+
+		case now := <-townScheduleTicker.C:
+			// Town schedule — scheduled shutdown/start and dispatch pauses.
+			if !d.isShutdownInProgress() {
+				d.runTownSchedule(now)
+			}
+
 		case <-timer.C:
 			d.heartbeat(state)
 
@@ -739,6 +799,20 @@ func (d *Daemon) heartbeat(state *State) {
 		return
 	}
 
+	// Likewise while the town is paused (gt pause): agents are frozen on
+	// purpose and come back with gt unpause.
+	if estop.IsPaused(d.config.TownRoot) {
+		d.logger.Println("Town paused, skipping agent management")
+		return
+	}
+
+	// And during quiet hours (a town.json schedule shut the town down):
+	// agents come back with the schedule's start entry or gt start.
+	if InQuietHours(d.config.TownRoot) {
+		d.logger.Println("Quiet hours, skipping agent management")
+		return
+	}
+
 	d.metrics.recordHeartbeat(d.ctx)
 	d.logger.Println("Heartbeat starting (recovery-focused)")
 
@@ -756,6 +830,9 @@ func (d *Daemon) heartbeat(state *State) {
 	// This must happen before beads operations that depend on Dolt.
 	d.ensureDoltServerRunning()
 
+	// 0c. Replay bead mutations queued while the database was unreachable.
+	d.replayBeadQueues()
+
 	// 1. Ensure Deacon is running (restart if dead)
 	// Check patrol config - can be disabled in mayor/daemon.json
 	if d.isPatrolActive("deacon") {
@@ -770,15 +847,17 @@ func (d *Daemon) heartbeat(state *State) {
 
 	// 2. Poke Boot for intelligent triage (stuck/nudge/interrupt)
 	// Boot handles nuanced "is Deacon responsive" decisions
-	// Only run if Deacon patrol is enabled
-	if d.isPatrolActive("deacon") {
+	// Only run if Deacon patrol is enabled. A headless Deacon has no tmux
+	// session to triage; its supervisor restarts failed cycles itself.
+	deaconHeadless := headless.Enabled(d.config.TownRoot, constants.RoleDeacon)
+	if d.isPatrolActive("deacon") && !deaconHeadless {
 		d.ensureBootRunning()
 	}
 
 	// 3. Direct Deacon heartbeat check (belt-and-suspenders)
 	// Boot may not detect all stuck states; this provides a fallback
 	// Only run if Deacon patrol is enabled
-	if d.isPatrolActive("deacon") {
+	if d.isPatrolActive("deacon") && !deaconHeadless {
 		d.checkDeaconHeartbeat()
 	}
 
@@ -827,6 +906,16 @@ func (d *Daemon) heartbeat(state *State) {
 	// 7. Process lifecycle requests
 	d.processLifecycleRequests()
 
+	// 8. Respawn crashed agent panes per role restart policy (opt-in).
+	if d.isPatrolActive("session_supervisor") {
+		d.superviseSessions()
+	}
+
+	// 8b. Stop sessions idle past their role's timeout (opt-in).
+	if d.isPatrolActive("idle_stop") {
+		d.stopIdleSessions()
+	}
+
 	// 9. (Removed) Stale agent check - violated "discover, don't track"
 
 	// 10. Check for GUPP violations (agents with work-on-hook not progressing)
@@ -1250,16 +1339,8 @@ func (d *Daemon) ensureDeaconRunning() {
 	const agentID = "deacon"
 
 	// Check restart tracker for backoff/crash loop
-	if d.restartTracker != nil {
-		if d.restartTracker.IsInCrashLoop(agentID) {
-			d.logger.Printf("Deacon is in crash loop, skipping restart (use 'gt daemon clear-backoff deacon' to reset)")
-			return
-		}
-		if !d.restartTracker.CanRestart(agentID) {
-			remaining := d.restartTracker.GetBackoffRemaining(agentID)
-			d.logger.Printf("Deacon restart in backoff, %s remaining", remaining.Round(time.Second))
-			return
-		}
+	if !d.restartAllowed(agentID) {
+		return
 	}
 
 	mgr := deacon.NewManager(d.config.TownRoot)
@@ -1277,12 +1358,7 @@ func (d *Daemon) ensureDeaconRunning() {
 	}
 
 	// Record this restart attempt for backoff tracking
-	if d.restartTracker != nil {
-		d.restartTracker.RecordRestart(agentID)
-		if err := d.restartTracker.Save(); err != nil {
-			d.logger.Printf("Warning: failed to save restart state: %v", err)
-		}
-	}
+	d.recordAgentRestart(agentID)
 
 	// Track when we started the Deacon to prevent race condition in checkDeaconHeartbeat.
 	// The heartbeat file will still be stale until the Deacon runs a full patrol cycle.
@@ -1461,6 +1537,7 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 		Path: filepath.Join(d.config.TownRoot, rigName),
 	}
 	mgr := witness.NewManager(r)
+	agentID := mgr.SessionName()
 
 	// NOTE: Hung session detection removed for witnesses (serial killer bug).
 	// Idle witnesses legitimately produce no tmux output while waiting for work.
@@ -1468,16 +1545,26 @@ func (d *Daemon) ensureWitnessRunning(rigName string) {
 	// context (checks for active work before declaring something stuck).
 	// See: daemon.log "is hung (no activity for 30m0s), killing for restart"
 
+	// A live agent needs no restart; a dead or zombie one is held off while
+	// it is crash-looping or in backoff.
+	if !d.tmux.IsAgentAlive(agentID) && !d.restartAllowed(agentID) {
+		return
+	}
+
 	if err := mgr.Start(false, "", nil); err != nil {
 		if err == witness.ErrAlreadyRunning {
 			// Already running - this is the expected case
 			d.logger.Printf("Witness for %s already running, skipping spawn", rigName)
+			if d.restartTracker != nil {
+				d.restartTracker.RecordSuccess(agentID)
+			}
 			return
 		}
 		d.logger.Printf("Error starting witness for %s: %v", rigName, err)
 		return
 	}
 
+	d.recordAgentRestart(agentID)
 	d.metrics.recordRestart(d.ctx, "witness")
 	telemetry.RecordDaemonRestart(d.ctx, "witness-"+rigName)
 	d.logger.Printf("Witness session for %s started successfully", rigName)
@@ -1537,6 +1624,7 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 		Path: filepath.Join(d.config.TownRoot, rigName),
 	}
 	mgr := refinery.NewManager(r)
+	agentID := mgr.SessionName()
 
 	// NOTE: Hung session detection removed for refineries (serial killer bug).
 	// Idle refineries legitimately produce no tmux output while waiting for MRs.
@@ -1544,16 +1632,26 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 	// context (checks for active work before declaring something stuck).
 	// See: daemon.log "is hung (no activity for 30m0s), killing for restart"
 
+	// A live agent needs no restart; a dead or zombie one is held off while
+	// it is crash-looping or in backoff.
+	if !d.tmux.IsAgentAlive(agentID) && !d.restartAllowed(agentID) {
+		return
+	}
+
 	if err := mgr.Start(false, ""); err != nil {
 		if err == refinery.ErrAlreadyRunning {
 			// Already running - this is the expected case when fix is working
 			d.logger.Printf("Refinery for %s already running, skipping spawn", rigName)
+			if d.restartTracker != nil {
+				d.restartTracker.RecordSuccess(agentID)
+			}
 			return
 		}
 		d.logger.Printf("Error starting refinery for %s: %v", rigName, err)
 		return
 	}
 
+	d.recordAgentRestart(agentID)
 	d.metrics.recordRestart(d.ctx, "refinery")
 	telemetry.RecordDaemonRestart(d.ctx, "refinery-"+rigName)
 	d.logger.Printf("Refinery session for %s started successfully", rigName)
@@ -1564,8 +1662,13 @@ func (d *Daemon) ensureRefineryRunning(rigName string) {
 // If the tmux session exists but the agent is dead (zombie), the daemon
 // stops the zombie session and starts a fresh one.
 func (d *Daemon) ensureMayorRunning() {
+	const agentID = "mayor"
 	mgr := mayor.NewManager(d.config.TownRoot)
 
+	if !d.isMayorAgentAlive(mgr) && !d.restartAllowed(agentID) {
+		return
+	}
+
 	if err := mgr.Start(""); err != nil {
 		if err == mayor.ErrAlreadyRunning {
 			// Session exists — verify agent is actually alive.
@@ -1585,12 +1688,16 @@ func (d *Daemon) ensureMayorRunning() {
 						d.logger.Printf("Error restarting Mayor after zombie cleanup: %v", startErr)
 						return
 					}
+					d.recordAgentRestart(agentID)
 					d.logger.Println("Mayor restarted after zombie cleanup")
 				} else {
 					d.logger.Printf("Mayor agent not detected (cycle %d/3), waiting before restart", d.mayorZombieCount)
 				}
 			} else {
 				d.mayorZombieCount = 0
+				if d.restartTracker != nil {
+					d.restartTracker.RecordSuccess(agentID)
+				}
 			}
 			return
 		}
@@ -1599,11 +1706,17 @@ func (d *Daemon) ensureMayorRunning() {
 	}
 
 	d.mayorZombieCount = 0
+	d.recordAgentRestart(agentID)
 	d.logger.Println("Mayor started successfully")
 }
 
-// isMayorAgentAlive checks if the Mayor's agent process is running in tmux.
+// isMayorAgentAlive checks if the Mayor's agent process is running in tmux,
+// or for a headless Mayor, whether its supervisor is running.
 func (d *Daemon) isMayorAgentAlive(mgr *mayor.Manager) bool {
+	if headless.Enabled(d.config.TownRoot, constants.RoleMayor) {
+		_, running := headless.Running(d.config.TownRoot, constants.RoleMayor)
+		return running
+	}
 	t := tmux.NewTmux()
 	return t.IsAgentAlive(mgr.SessionName())
 }
@@ -1703,17 +1816,18 @@ func (d *Daemon) killDefaultPrefixGhosts() {
 		if err != nil {
 			continue
 		}
+		pt := d.polecatTmux(rigName)
 		for _, entry := range entries {
 			if !entry.IsDir() {
 				continue
 			}
 			polecatName := entry.Name()
 			ghostName := fmt.Sprintf("%s-%s", session.DefaultPrefix, polecatName)
-			exists, _ := d.tmux.HasSession(ghostName)
+			exists, _ := pt.HasSession(ghostName)
 			if exists {
 				// Verify the correct session isn't also running (avoid killing legit sessions)
 				correctName := session.PolecatSessionName(rigPrefix, polecatName)
-				correctExists, _ := d.tmux.HasSession(correctName)
+				correctExists, _ := pt.HasSession(correctName)
 				if !correctExists {
 					// Ghost is the only session — it might be doing real work.
 					// Log but don't kill; the registry reload will prevent new ghosts.
@@ -1721,7 +1835,7 @@ func (d *Daemon) killDefaultPrefixGhosts() {
 				} else {
 					// Both exist — ghost is definitely a duplicate, kill it.
 					d.logger.Printf("Killing duplicate ghost polecat session %s (correct session %s exists)", ghostName, correctName)
-					if err := d.tmux.KillSessionWithProcesses(ghostName); err != nil {
+					if err := pt.KillSessionWithProcesses(ghostName); err != nil {
 						d.logger.Printf("Error killing ghost session %s: %v", ghostName, err)
 					}
 				}
@@ -1799,6 +1913,18 @@ func (d *Daemon) getKnownRigs() []string {
 	return rigs
 }
 
+// polecatTmux returns the tmux that runs a rig's polecat sessions: on the
+// rig's remote host when rigs.json sets one, otherwise the daemon's own.
+// Polecat liveness checks must go through it, or every remote polecat
+// looks dead.
+func (d *Daemon) polecatTmux(rigName string) *tmux.Tmux {
+	rigsConfig, err := d.loadRigsConfig()
+	if err != nil {
+		return d.tmux
+	}
+	return d.tmux.OnHost(strings.TrimSpace(rigsConfig.Rigs[rigName].RemoteHost))
+}
+
 // getPatrolRigs returns the list of operational rigs for a patrol.
 // If the patrol config specifies a rigs filter, only those rigs are returned.
 // Otherwise, all known rigs are returned. In both cases, non-operational
@@ -1826,7 +1952,8 @@ func (d *Daemon) getPatrolRigs(patrol string) []string {
 
 // isRigOperational checks if a rig is in an operational state.
 // Returns true if the rig can have agents auto-started.
-// Returns false (with reason) if the rig is parked, docked, or has auto_restart blocked/disabled.
+// Returns false (with reason) if the rig is disabled for maintenance, parked,
+// docked, or has auto_restart blocked/disabled.
 //
 // TODO(#2120): This duplicates parked/docked checking logic from
 // cmd.IsRigParkedOrDocked and cmd.hasRigBeadLabel. Consolidating into a
@@ -1834,6 +1961,11 @@ func (d *Daemon) getPatrolRigs(patrol string) []string {
 // and reduce drift risk. Not done here due to circular import constraints
 // (daemon cannot import cmd).
 func (d *Daemon) isRigOperational(rigName string) (bool, string) {
+	// Maintenance mode lives in the rig registry (mayor/rigs.json)
+	if disabled, _ := agentconfig.IsRigDisabled(d.config.TownRoot, rigName); disabled {
+		return false, "rig is disabled for maintenance"
+	}
+
 	cfg := wisp.NewConfig(d.config.TownRoot, rigName)
 
 	// Warn if wisp config is missing - parked/docked state may have been lost
@@ -1930,6 +2062,16 @@ func (d *Daemon) shutdown(state *State) error { //nolint:unparam // error return
 		d.logger.Println("KRC pruner stopped")
 	}
 
+	// Stop tracker syncer
+	if d.trackerSyncer != nil {
+		d.trackerSyncer.Stop()
+	}
+
+	// Stop webhook notifier
+	if d.webhooks != nil {
+		d.webhooks.Stop()
+	}
+
 	// Push Dolt remotes before stopping the server (if patrol is enabled)
 	d.pushDoltRemotes()
 
@@ -2281,8 +2423,9 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	// Build the expected tmux session name
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
 
-	// Check if tmux session exists
-	sessionAlive, err := d.tmux.HasSession(sessionName)
+	// Check if tmux session exists (on the rig's remote host, if any)
+	pt := d.polecatTmux(rigName)
+	sessionAlive, err := pt.HasSession(sessionName)
 	if err != nil {
 		d.logger.Printf("Error checking session %s: %v", sessionName, err)
 		return
@@ -2366,7 +2509,7 @@ func (d *Daemon) checkPolecatHealth(rigName, polecatName string) {
 	// TOCTOU guard: re-verify session is still dead before restarting.
 	// Between the initial check and now, the session may have been restarted
 	// by another heartbeat cycle, witness, or the polecat itself.
-	sessionRevived, err := d.tmux.HasSession(sessionName)
+	sessionRevived, err := pt.HasSession(sessionName)
 	if err == nil && sessionRevived {
 		return // Session came back - no restart needed
 	}
@@ -2542,8 +2685,9 @@ func (d *Daemon) reapRigIdlePolecats(rigName string, timeout time.Duration) {
 func (d *Daemon) reapIdlePolecat(rigName, polecatName string, timeout time.Duration) {
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
 
-	// Only check sessions that are actually alive
-	alive, err := d.tmux.HasSession(sessionName)
+	// Only check sessions that are actually alive (on the rig's remote host, if any)
+	pt := d.polecatTmux(rigName)
+	alive, err := pt.HasSession(sessionName)
 	if err != nil || !alive {
 		return
 	}
@@ -2580,7 +2724,7 @@ func (d *Daemon) reapIdlePolecat(rigName, polecatName string, timeout time.Durat
 			// If heartbeat is stale enough (2x timeout), reap anyway to prevent
 			// indefinite API burn when bead infrastructure is degraded.
 			// But first check if the agent is actually running (GH#3342).
-			if staleDuration >= timeout*2 && !d.tmux.IsAgentRunning(sessionName) {
+			if staleDuration >= timeout*2 && !pt.IsAgentRunning(sessionName) {
 				d.killIdlePolecat(rigName, polecatName, sessionName, staleDuration, timeout, "working-bead-lookup-failed")
 			}
 			return
@@ -2608,7 +2752,7 @@ func (d *Daemon) reapIdlePolecat(rigName, polecatName string, timeout time.Durat
 		// No hooked work + stale heartbeat — but check if the agent process
 		// is still actively running before reaping. A failed gt sling rollback
 		// can clear the hook while the agent is still working (GH#3342).
-		if d.tmux.IsAgentRunning(sessionName) {
+		if pt.IsAgentRunning(sessionName) {
 			return
 		}
 		d.killIdlePolecat(rigName, polecatName, sessionName, staleDuration, timeout, "working-no-hook")
@@ -2621,7 +2765,7 @@ func (d *Daemon) killIdlePolecat(rigName, polecatName, sessionName string, idleD
 		rigName, polecatName, reason, idleDuration.Truncate(time.Second), timeout)
 
 	// Kill the tmux session (and all descendant processes)
-	if err := d.tmux.KillSessionWithProcesses(sessionName); err != nil {
+	if err := d.polecatTmux(rigName).KillSessionWithProcesses(sessionName); err != nil {
 		d.logger.Printf("Warning: failed to kill idle polecat session %s: %v", sessionName, err)
 		return
 	}
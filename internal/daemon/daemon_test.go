@@ -13,6 +13,8 @@ import (
 	"time"
 
 	"github.com/gofrs/flock"
+
+	"github.com/steveyegge/gastown/internal/tmux"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -661,3 +663,45 @@ func TestIsRigOperational_DockedRig(t *testing.T) {
 	}
 	t.Logf("Docked rig check returned: operational=%v, reason=%q", operational, reason)
 }
+
+// TestIsRigOperational_DisabledRig verifies that a rig disabled for
+// maintenance in rigs.json is not operational, without touching beads.
+func TestIsRigOperational_DisabledRig(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rigsJSON := `{"version":1,"rigs":{"surgery":{"git_url":"https://example.com/s.git","disabled":true,"disabled_reason":"history rewrite"}}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "mayor", "rigs.json"), []byte(rigsJSON), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := &Daemon{
+		config: &Config{TownRoot: tmpDir},
+		logger: log.New(io.Discard, "", 0),
+	}
+
+	operational, reason := d.isRigOperational("surgery")
+	if operational || !strings.Contains(reason, "maintenance") {
+		t.Errorf("isRigOperational = %v, %q; want not operational for maintenance", operational, reason)
+	}
+}
+
+func TestPolecatTmux_RemoteHost(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rigs := `{"version":1,"rigs":{"heavy":{"git_url":"x","remote_host":"build-01"},"local":{"git_url":"y"}}}`
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "rigs.json"), []byte(rigs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	d := &Daemon{config: &Config{TownRoot: townRoot}, tmux: tmux.NewTmux()}
+
+	if got := d.polecatTmux("heavy").RemoteHost(); got != "build-01" {
+		t.Errorf("polecatTmux(heavy) host = %q, want build-01", got)
+	}
+	if got := d.polecatTmux("local"); got != d.tmux {
+		t.Error("polecatTmux(local) should be the daemon's own tmux")
+	}
+}
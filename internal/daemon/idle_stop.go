@@ -0,0 +1,214 @@
+package daemon
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// defaultIdleStopGrace is how long a session has to hand off after being
+// asked before the idle_stop patrol kills it.
+const defaultIdleStopGrace = 5 * time.Minute
+
+// IdleStopConfig holds configuration for the idle_stop patrol.
+// The patrol stops sessions that have sat at their prompt with no output and
+// no hooked work for longer than their role's timeout, freeing API quota and
+// memory on long-running towns. Before stopping a session it nudges the agent
+// to save its state with 'gt handoff --auto', then waits out a grace period.
+type IdleStopConfig struct {
+	// Enabled controls whether the patrol runs.
+	Enabled bool `json:"enabled"`
+
+	// Timeouts maps role names (crew, polecat, dog) to an idle timeout, as a
+	// string (e.g., "2h"). Roles not listed are never stopped. The roles the
+	// daemon keeps running (see supervisedRoles) cannot be idle-stopped.
+	Timeouts map[string]string `json:"timeouts,omitempty"`
+
+	// GraceStr is how long to wait after requesting a handoff before
+	// stopping the session (default 5m).
+	GraceStr string `json:"grace,omitempty"`
+}
+
+// supervisedRoles are the roles the daemon heartbeat keeps running
+// (ensure*Running). Stopping one would only get it restarted on the next
+// tick, counted against the crash-loop tracker, so idle_stop leaves them be.
+var supervisedRoles = map[string]bool{
+	"mayor":    true,
+	"deacon":   true,
+	"boot":     true,
+	"witness":  true,
+	"refinery": true,
+}
+
+// timeout returns the idle timeout for role, or zero if the role is not
+// subject to idle stop.
+func (c *IdleStopConfig) timeout(role string) (time.Duration, error) {
+	if c == nil || supervisedRoles[role] {
+		return 0, nil
+	}
+	s, ok := c.Timeouts[role]
+	if !ok || s == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid idle timeout %q for %s", s, role)
+	}
+	return d, nil
+}
+
+// grace returns the handoff grace period.
+func (c *IdleStopConfig) grace() time.Duration {
+	if c != nil && c.GraceStr != "" {
+		if d, err := time.ParseDuration(c.GraceStr); err == nil && d > 0 {
+			return d
+		}
+	}
+	return defaultIdleStopGrace
+}
+
+// idleStopOps is the subset of tmux operations used by the idle_stop patrol.
+type idleStopOps interface {
+	ListSessions() ([]string, error)
+	IdleWithQuiet(session string, quiet time.Duration) (*tmux.IdleState, error)
+	NudgeSession(session, message string) error
+	KillSessionWithProcesses(name string) error
+}
+
+// stopIdleSessions runs one idle_stop pass.
+func (d *Daemon) stopIdleSessions() {
+	var cfg *IdleStopConfig
+	if d.patrolConfig != nil && d.patrolConfig.Patrols != nil {
+		cfg = d.patrolConfig.Patrols.IdleStop
+	}
+	if d.idleStopPending == nil {
+		d.idleStopPending = make(map[string]time.Time)
+		if cfg != nil {
+			for role := range cfg.Timeouts {
+				if supervisedRoles[role] {
+					d.logger.Printf("idle_stop: ignoring timeout for %s, the daemon keeps it running", role)
+				}
+			}
+		}
+	}
+	for _, msg := range stopIdle(d.tmux, d.config.TownRoot, d.hasHookedWork, cfg, d.idleStopPending, time.Now()) {
+		d.logger.Printf("idle_stop: %s", msg)
+	}
+}
+
+// stopIdle does one idle_stop pass and returns log lines describing what it
+// did. pending records sessions that have been asked to hand off and is
+// updated in place.
+//
+// A session goes through two passes: the first, once it has been idle for
+// its role's timeout, nudges it to hand off; a later pass, after the grace
+// period, stops it (running the town's stop hooks) if it is still at its
+// prompt with no hooked work. A session that picks up work or starts
+// producing output in between is left running and its timeout starts over.
+func stopIdle(t idleStopOps, townRoot string, hasWork func(*session.AgentIdentity) bool, cfg *IdleStopConfig, pending map[string]time.Time, now time.Time) []string {
+	sessions, err := t.ListSessions()
+	if err != nil {
+		return []string{fmt.Sprintf("listing sessions: %v", err)}
+	}
+
+	var msgs []string
+	live := make(map[string]bool, len(sessions))
+	for _, name := range sessions {
+		live[name] = true
+		if !session.IsKnownSession(name) {
+			continue
+		}
+		identity, err := session.ParseSessionName(name)
+		if err != nil {
+			continue
+		}
+		role := string(identity.Role)
+		if identity.Role == session.RoleDeacon && identity.Name == "boot" {
+			role = "boot"
+		}
+		timeout, err := cfg.timeout(role)
+		if err != nil {
+			msgs = append(msgs, err.Error())
+			continue
+		}
+		if timeout == 0 {
+			delete(pending, name)
+			continue
+		}
+
+		asked, isPending := pending[name]
+		if !isPending {
+			st, err := t.IdleWithQuiet(name, timeout)
+			if err != nil || !st.Idle || hasWork(identity) {
+				continue
+			}
+			msg := fmt.Sprintf("[idle-stop] This session has been idle for %s and will be stopped in %s. "+
+				"Run 'gt handoff --auto --reason idle' now to save your state.",
+				st.QuietFor.Round(time.Minute), cfg.grace())
+			if err := t.NudgeSession(name, msg); err != nil {
+				msgs = append(msgs, fmt.Sprintf("nudging %s: %v", name, err))
+				continue
+			}
+			pending[name] = now
+			msgs = append(msgs, fmt.Sprintf("%s idle for %s, requested handoff", name, st.QuietFor.Round(time.Second)))
+			continue
+		}
+
+		if now.Sub(asked) < cfg.grace() {
+			continue
+		}
+		delete(pending, name)
+		// The handoff itself produces output, so only require the agent to be
+		// back at its prompt, not quiet for the whole timeout again.
+		st, err := t.IdleWithQuiet(name, tmux.DefaultIdleQuietPeriod)
+		if err != nil || !st.Idle {
+			msgs = append(msgs, fmt.Sprintf("%s became active, not stopping", name))
+			continue
+		}
+		if hasWork(identity) {
+			msgs = append(msgs, fmt.Sprintf("%s has hooked work, not stopping", name))
+			continue
+		}
+		if err := session.TerminateSession(t, townRoot, name); err != nil {
+			msgs = append(msgs, fmt.Sprintf("stopping %s: %v", name, err))
+			continue
+		}
+		msgs = append(msgs, fmt.Sprintf("stopped %s (idle past %s)", name, timeout))
+	}
+
+	for name := range pending {
+		if !live[name] {
+			delete(pending, name)
+		}
+	}
+	return msgs
+}
+
+// hasHookedWork reports whether any bead is hooked or in progress for the
+// agent. Errors count as having work, so a degraded beads store never causes
+// a session to be stopped.
+func (d *Daemon) hasHookedWork(identity *session.AgentIdentity) bool {
+	for _, status := range []string{"hooked", "in_progress"} {
+		args := []string{"list", "--assignee=" + identity.Address(), "--status=" + status, "--json"}
+		if identity.Rig != "" {
+			args = append(args, "--rig="+identity.Rig)
+		}
+		cmd := exec.Command(d.bdPath, args...) //nolint:gosec // G204: args are constructed internally
+		cmd.Dir = d.config.TownRoot
+		cmd.Env = os.Environ()
+		output, err := cmd.Output()
+		if err != nil {
+			return true
+		}
+		var issues []json.RawMessage
+		if err := json.Unmarshal(output, &issues); err != nil || len(issues) > 0 {
+			return true
+		}
+	}
+	return false
+}
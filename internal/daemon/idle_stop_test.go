@@ -0,0 +1,123 @@
+package daemon
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+type fakeIdleStopOps struct {
+	sessions []string
+	idle     map[string]bool
+	nudged   []string
+	killed   []string
+}
+
+func (f *fakeIdleStopOps) ListSessions() ([]string, error) { return f.sessions, nil }
+
+func (f *fakeIdleStopOps) IdleWithQuiet(name string, quiet time.Duration) (*tmux.IdleState, error) {
+	return &tmux.IdleState{Idle: f.idle[name], QuietFor: quiet}, nil
+}
+
+func (f *fakeIdleStopOps) NudgeSession(name, message string) error {
+	f.nudged = append(f.nudged, name)
+	return nil
+}
+
+func (f *fakeIdleStopOps) KillSessionWithProcesses(name string) error {
+	f.killed = append(f.killed, name)
+	return nil
+}
+
+func TestStopIdle(t *testing.T) {
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	old := session.DefaultRegistry()
+	session.SetDefaultRegistry(reg)
+	defer session.SetDefaultRegistry(old)
+
+	ops := &fakeIdleStopOps{
+		sessions: []string{"gt-crew-max", "gt-crew-joe", "gt-witness", "gt-Toast", "personal"},
+		idle:     map[string]bool{"gt-crew-max": true, "gt-crew-joe": true, "gt-witness": true, "personal": true},
+	}
+	cfg := &IdleStopConfig{Enabled: true, Timeouts: map[string]string{"crew": "1h", "polecat": "30m", "witness": "1h"}}
+	hasWork := func(id *session.AgentIdentity) bool { return id.Name == "joe" }
+	pending := map[string]time.Time{"gt-gone": time.Now()}
+	now := time.Now()
+
+	// First pass: only the idle crew member without work is asked to hand off.
+	// The daemon keeps the witness running, so its timeout is ignored, and
+	// the polecat is busy.
+	stopIdle(ops, t.TempDir(), hasWork, cfg, pending, now)
+	if strings.Join(ops.nudged, ",") != "gt-crew-max" || len(ops.killed) != 0 {
+		t.Fatalf("nudged=%v killed=%v, want only gt-crew-max nudged", ops.nudged, ops.killed)
+	}
+	if _, ok := pending["gt-gone"]; ok {
+		t.Error("pending entry for a dead session was not dropped")
+	}
+
+	// Within the grace period nothing happens.
+	stopIdle(ops, t.TempDir(), hasWork, cfg, pending, now.Add(time.Minute))
+	if len(ops.nudged) != 1 || len(ops.killed) != 0 {
+		t.Fatalf("acted during grace: nudged=%v killed=%v", ops.nudged, ops.killed)
+	}
+
+	// After the grace period the session is stopped.
+	stopIdle(ops, t.TempDir(), hasWork, cfg, pending, now.Add(defaultIdleStopGrace))
+	if strings.Join(ops.killed, ",") != "gt-crew-max" {
+		t.Fatalf("killed = %v, want [gt-crew-max]", ops.killed)
+	}
+	if len(pending) != 0 {
+		t.Errorf("pending = %v, want empty", pending)
+	}
+}
+
+func TestStopIdle_ActiveAfterNudge(t *testing.T) {
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	old := session.DefaultRegistry()
+	session.SetDefaultRegistry(reg)
+	defer session.SetDefaultRegistry(old)
+
+	ops := &fakeIdleStopOps{sessions: []string{"gt-crew-max"}, idle: map[string]bool{"gt-crew-max": true}}
+	cfg := &IdleStopConfig{Timeouts: map[string]string{"crew": "1h"}, GraceStr: "1m"}
+	noWork := func(*session.AgentIdentity) bool { return false }
+	pending := map[string]time.Time{}
+	now := time.Now()
+
+	stopIdle(ops, t.TempDir(), noWork, cfg, pending, now)
+	ops.idle["gt-crew-max"] = false // the user came back
+	msgs := stopIdle(ops, t.TempDir(), noWork, cfg, pending, now.Add(2*time.Minute))
+	if len(ops.killed) != 0 {
+		t.Fatalf("killed an active session: %v", ops.killed)
+	}
+	if len(msgs) != 1 || !strings.Contains(msgs[0], "became active") {
+		t.Errorf("msgs = %v", msgs)
+	}
+	if len(pending) != 0 {
+		t.Errorf("pending = %v, want the request cleared", pending)
+	}
+}
+
+func TestIdleStopConfigTimeout(t *testing.T) {
+	cfg := &IdleStopConfig{Timeouts: map[string]string{"crew": "2h", "mayor": "soon"}}
+	if d, err := cfg.timeout("crew"); err != nil || d != 2*time.Hour {
+		t.Errorf("crew timeout = %v, %v", d, err)
+	}
+	if d, err := cfg.timeout("witness"); err != nil || d != 0 {
+		t.Errorf("unlisted role timeout = %v, %v; want 0", d, err)
+	}
+	if _, err := cfg.timeout("mayor"); err == nil {
+		t.Error("expected error for invalid duration")
+	}
+	var nilCfg *IdleStopConfig
+	if d, _ := nilCfg.timeout("crew"); d != 0 {
+		t.Errorf("nil config timeout = %v", d)
+	}
+	if nilCfg.grace() != defaultIdleStopGrace {
+		t.Errorf("nil config grace = %v", nilCfg.grace())
+	}
+}
@@ -377,7 +377,10 @@ func (d *Daemon) restartSession(sessionName, identity string) error {
 
 	// Create session with command as initial process (replaces EnsureSessionFresh + SendKeys).
 	// EnsureSessionFreshWithCommand kills zombie sessions and creates a new one atomically.
-	if err := d.tmux.EnsureSessionFreshWithCommand(sessionName, workDir, startCmd); err != nil {
+	// Secrets are handed to tmux with -e so the agent starts with them but
+	// they never appear on a command line.
+	secretEnv := d.sessionSecrets(parsed)
+	if err := d.tmux.EnsureSessionFreshWithCommandAndEnv(sessionName, workDir, startCmd, secretEnv); err != nil {
 		if errors.Is(err, tmux.ErrSessionRunning) {
 			d.logger.Printf("Session %s already running with healthy agent, skipping restart", sessionName)
 			return nil
@@ -527,14 +530,16 @@ func (d *Daemon) getStartCommand(roleConfig *beads.RoleConfig, parsed *ParsedIde
 		if runtimeConfig.Session != nil {
 			sessionIDEnv = runtimeConfig.Session.SessionIDEnv
 		}
-		envVars := config.AgentEnv(config.AgentEnvConfig{
+		envVars := session.AgentSessionEnv(config.AgentEnvConfig{
 			Role:         constants.RolePolecat,
 			Rig:          parsed.RigName,
 			AgentName:    parsed.AgentName,
 			TownRoot:     d.config.TownRoot,
 			SessionIDEnv: sessionIDEnv,
-		})
+		}, rigPath)
 		config.SanitizeAgentEnv(envVars, map[string]string{})
+		// Secrets reach the session via tmux (see restartSession), not the command line.
+		envVars, _ = session.SplitSecretEnv(envVars, d.config.TownRoot, rigPath, parsed.RoleType)
 		return config.PrependEnv("exec "+runtimeConfig.BuildCommandWithPrompt(prompt), envVars)
 	}
 
@@ -543,22 +548,34 @@ func (d *Daemon) getStartCommand(roleConfig *beads.RoleConfig, parsed *ParsedIde
 		if runtimeConfig.Session != nil {
 			sessionIDEnv = runtimeConfig.Session.SessionIDEnv
 		}
-		envVars := config.AgentEnv(config.AgentEnvConfig{
+		envVars := session.AgentSessionEnv(config.AgentEnvConfig{
 			Role:         constants.RoleCrew,
 			Rig:          parsed.RigName,
 			AgentName:    parsed.AgentName,
 			TownRoot:     d.config.TownRoot,
 			SessionIDEnv: sessionIDEnv,
-		})
+		}, rigPath)
 		config.SanitizeAgentEnv(envVars, map[string]string{})
+		// Secrets reach the session via tmux (see restartSession), not the command line.
+		envVars, _ = session.SplitSecretEnv(envVars, d.config.TownRoot, rigPath, parsed.RoleType)
 		return config.PrependEnv("exec "+runtimeConfig.BuildCommandWithPrompt(prompt), envVars)
 	}
 
 	return defaultCmd
 }
 
+// sessionSecrets resolves the secrets configured for the agent's session.
+func (d *Daemon) sessionSecrets(parsed *ParsedIdentity) map[string]string {
+	rigPath := ""
+	if parsed.RigName != "" {
+		rigPath = filepath.Join(d.config.TownRoot, parsed.RigName)
+	}
+	return config.ResolveSessionSecrets(d.config.TownRoot, rigPath, parsed.RoleType)
+}
+
 // setSessionEnvironment sets environment variables for the tmux session.
-// Uses centralized AgentEnv for consistency, plus custom env vars from role config if available.
+// Uses the centralized session env (AgentEnv plus configured session_env vars),
+// plus custom env vars from role config if available.
 func (d *Daemon) setSessionEnvironment(sessionName string, roleConfig *beads.RoleConfig, parsed *ParsedIdentity) {
 	// Resolve CLAUDE_CONFIG_DIR from accounts.json so daemon-restarted sessions
 	// use the correct account. Mirrors the crew startup path (start.go).
@@ -568,18 +585,19 @@ func (d *Daemon) setSessionEnvironment(sessionName string, roleConfig *beads.Rol
 		runtimeConfigDir = os.Getenv("CLAUDE_CONFIG_DIR")
 	}
 
-	// Use centralized AgentEnv for base environment variables
-	envVars := config.AgentEnv(config.AgentEnvConfig{
+	rigPath := ""
+	if parsed.RigName != "" {
+		rigPath = filepath.Join(d.config.TownRoot, parsed.RigName)
+	}
+	envVars := session.AgentSessionEnv(config.AgentEnvConfig{
 		Role:             parsed.RoleType,
 		Rig:              parsed.RigName,
 		AgentName:        parsed.AgentName,
 		TownRoot:         d.config.TownRoot,
 		RuntimeConfigDir: runtimeConfigDir,
 		SessionName:      sessionName,
-	})
-	for k, v := range envVars {
-		_ = d.tmux.SetEnvironment(sessionName, k, v)
-	}
+	}, rigPath)
+	_ = session.SetSessionEnv(d.tmux, sessionName, envVars)
 
 	// Record agent's pane_id for ZFC-compliant liveness checks (gt-qmsx).
 	if paneID, err := d.tmux.GetPaneID(sessionName); err == nil {
@@ -630,9 +648,7 @@ func (d *Daemon) syncWorkspace(workDir string) {
 		parts := strings.Split(rel, string(filepath.Separator))
 		if len(parts) > 0 {
 			rigPath := filepath.Join(d.config.TownRoot, parts[0])
-			if rigCfg, err := rig.LoadRigConfig(rigPath); err == nil && rigCfg.DefaultBranch != "" {
-				defaultBranch = rigCfg.DefaultBranch
-			}
+			defaultBranch = rig.ResolveDefaultBranch(rigPath)
 		}
 	}
 
@@ -0,0 +1,118 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+const (
+	defaultMayorFetchInterval = 15 * time.Minute
+	defaultMayorFetchTimeout  = 5 * time.Minute
+)
+
+// MayorFetchConfig holds configuration for the mayor_fetch patrol.
+// This patrol periodically fetches origin into each rig's mayor clone and
+// shared bare repo, so new polecat worktrees and divergence stats start from
+// fresh refs instead of whatever was fetched at rig setup. Opt-in.
+type MayorFetchConfig struct {
+	// Enabled controls whether the fetcher runs.
+	Enabled bool `json:"enabled"`
+
+	// IntervalStr is how often to fetch, as a string (e.g., "15m").
+	IntervalStr string `json:"interval,omitempty"`
+
+	// TimeoutStr is the maximum time a single repo's fetch can take.
+	// Default: "5m".
+	TimeoutStr string `json:"timeout,omitempty"`
+
+	// Rigs limits fetching to specific rigs. If empty, all operational rigs
+	// are fetched.
+	Rigs []string `json:"rigs,omitempty"`
+}
+
+// mayorFetchInterval returns the configured interval, or the default (15m).
+func mayorFetchInterval(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.MayorFetch != nil {
+		if config.Patrols.MayorFetch.IntervalStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.MayorFetch.IntervalStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultMayorFetchInterval
+}
+
+// mayorFetchTimeout returns the configured per-repo timeout, or the default (5m).
+func mayorFetchTimeout(config *DaemonPatrolConfig) time.Duration {
+	if config != nil && config.Patrols != nil && config.Patrols.MayorFetch != nil {
+		if config.Patrols.MayorFetch.TimeoutStr != "" {
+			if d, err := time.ParseDuration(config.Patrols.MayorFetch.TimeoutStr); err == nil && d > 0 {
+				return d
+			}
+		}
+	}
+	return defaultMayorFetchTimeout
+}
+
+// runMayorFetch fetches origin into each operational rig's mayor clone and
+// shared bare repo. Failures are logged and retried on the next cycle; a
+// stale ref is never worth an escalation.
+func (d *Daemon) runMayorFetch() {
+	if !d.isPatrolActive("mayor_fetch") {
+		return
+	}
+
+	rigNames := d.getPatrolRigs("mayor_fetch")
+	timeout := mayorFetchTimeout(d.patrolConfig)
+
+	var fetched, failed int
+	for _, rigName := range rigNames {
+		rigPath := filepath.Join(d.config.TownRoot, rigName)
+		for _, repo := range mayorFetchRepos(rigPath) {
+			if err := fetchOrigin(d.ctx, repo, timeout); err != nil {
+				d.logger.Printf("mayor_fetch: %s: %v", rigName, err)
+				failed++
+				continue
+			}
+			fetched++
+		}
+	}
+
+	d.logger.Printf("mayor_fetch: cycle complete (%d fetched, %d failed)", fetched, failed)
+}
+
+// mayorFetchRepos returns the rig's repos that exist on disk: the shared
+// bare repo polecat worktrees are cut from, and the mayor's clone.
+func mayorFetchRepos(rigPath string) []string {
+	var repos []string
+	if info, err := os.Stat(filepath.Join(rigPath, ".repo.git")); err == nil && info.IsDir() {
+		repos = append(repos, filepath.Join(rigPath, ".repo.git"))
+	}
+	if _, err := os.Stat(filepath.Join(rigPath, "mayor", "rig", ".git")); err == nil {
+		repos = append(repos, filepath.Join(rigPath, "mayor", "rig"))
+	}
+	return repos
+}
+
+// fetchOrigin runs git fetch origin in dir. Credential prompts are disabled
+// so a remote that wants a password fails instead of hanging the daemon.
+func fetchOrigin(ctx context.Context, dir string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "git", "fetch", "--quiet", "origin")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	util.SetDetachedProcessGroup(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git fetch in %s failed: %v (%s)", dir, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMayorFetchConfig(t *testing.T) {
+	if got := mayorFetchInterval(nil); got != defaultMayorFetchInterval {
+		t.Errorf("expected default interval %v, got %v", defaultMayorFetchInterval, got)
+	}
+	if got := mayorFetchTimeout(nil); got != defaultMayorFetchTimeout {
+		t.Errorf("expected default timeout %v, got %v", defaultMayorFetchTimeout, got)
+	}
+	if IsPatrolEnabled(nil, "mayor_fetch") {
+		t.Error("mayor_fetch should be opt-in")
+	}
+
+	config := &DaemonPatrolConfig{
+		Patrols: &PatrolsConfig{
+			MayorFetch: &MayorFetchConfig{
+				Enabled:     true,
+				IntervalStr: "2m",
+				TimeoutStr:  "30s",
+				Rigs:        []string{"gastown"},
+			},
+		},
+	}
+	if got := mayorFetchInterval(config); got != 2*time.Minute {
+		t.Errorf("expected 2m, got %v", got)
+	}
+	if got := mayorFetchTimeout(config); got != 30*time.Second {
+		t.Errorf("expected 30s, got %v", got)
+	}
+	if !IsPatrolEnabled(config, "mayor_fetch") {
+		t.Error("expected mayor_fetch to be enabled")
+	}
+	if got := GetPatrolRigs(config, "mayor_fetch"); len(got) != 1 || got[0] != "gastown" {
+		t.Errorf("expected rigs [gastown], got %v", got)
+	}
+}
+
+func TestFetchOriginUpdatesMayorClone(t *testing.T) {
+	gitEnv := append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	src := t.TempDir()
+	run("init", "-b", "main", src)
+	run("-C", src, "commit", "--allow-empty", "-m", "init")
+
+	rigPath := t.TempDir()
+	run("clone", "--bare", src, filepath.Join(rigPath, ".repo.git"))
+	run("-C", filepath.Join(rigPath, ".repo.git"), "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*")
+	run("clone", src, filepath.Join(rigPath, "mayor", "rig"))
+
+	repos := mayorFetchRepos(rigPath)
+	if len(repos) != 2 {
+		t.Fatalf("mayorFetchRepos = %v, want bare repo and mayor clone", repos)
+	}
+
+	run("-C", src, "commit", "--allow-empty", "-m", "upstream work")
+	want := run("-C", src, "rev-parse", "HEAD")
+	for _, repo := range repos {
+		if err := fetchOrigin(context.Background(), repo, time.Minute); err != nil {
+			t.Fatalf("fetchOrigin(%s): %v", repo, err)
+		}
+		if got := run("-C", repo, "rev-parse", "origin/main"); got != want {
+			t.Errorf("%s: origin/main = %s after fetch, want %s", repo, got, want)
+		}
+	}
+
+	if mayorFetchRepos(t.TempDir()) != nil {
+		t.Error("expected no repos for an empty rig dir")
+	}
+}
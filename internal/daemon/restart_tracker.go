@@ -88,6 +88,9 @@ type AgentRestartInfo struct {
 	RestartCount   int       `json:"restart_count"`
 	BackoffUntil   time.Time `json:"backoff_until"`
 	CrashLoopSince time.Time `json:"crash_loop_since,omitempty"`
+
+	// Restarts are the restart times inside the crash-loop window.
+	Restarts []time.Time `json:"restarts,omitempty"`
 }
 
 // NewRestartTracker creates a new restart tracker with the given config.
@@ -154,7 +157,10 @@ func (rt *RestartTracker) CanRestart(agentID string) bool {
 }
 
 // RecordRestart records a restart attempt and calculates next backoff.
-func (rt *RestartTracker) RecordRestart(agentID string) {
+// Returns true when this restart trips the circuit breaker: CrashLoopCount
+// restarts inside CrashLoopWindow put the agent in crash-loop state, and
+// no further restarts are allowed until it is cleared.
+func (rt *RestartTracker) RecordRestart(agentID string) bool {
 	rt.mu.Lock()
 	defer rt.mu.Unlock()
 
@@ -170,6 +176,7 @@ func (rt *RestartTracker) RecordRestart(agentID string) {
 		// Reset backoff - agent was stable
 		info.RestartCount = 0
 		info.CrashLoopSince = time.Time{}
+		info.Restarts = nil
 	}
 
 	info.LastRestart = now
@@ -185,13 +192,35 @@ func (rt *RestartTracker) RecordRestart(agentID string) {
 	}
 	info.BackoffUntil = now.Add(backoffDuration)
 
-	// Check for crash loop
-	if info.RestartCount >= rt.config.CrashLoopCount {
-		windowStart := now.Add(-rt.config.CrashLoopWindow)
-		if info.LastRestart.After(windowStart) {
-			info.CrashLoopSince = now
+	// Check for crash loop: too many restarts inside the window.
+	windowStart := now.Add(-rt.config.CrashLoopWindow)
+	recent := info.Restarts[:0]
+	for _, t := range info.Restarts {
+		if t.After(windowStart) {
+			recent = append(recent, t)
 		}
 	}
+	info.Restarts = append(recent, now)
+	if len(info.Restarts) >= rt.config.CrashLoopCount && info.CrashLoopSince.IsZero() {
+		info.CrashLoopSince = now
+		return true
+	}
+	return false
+}
+
+// RestartInfo returns a copy of the restart info for an agent, or nil if
+// it has never been restarted.
+func (rt *RestartTracker) RestartInfo(agentID string) *AgentRestartInfo {
+	rt.mu.RLock()
+	defer rt.mu.RUnlock()
+
+	info, exists := rt.state.Agents[agentID]
+	if !exists {
+		return nil
+	}
+	cp := *info
+	cp.Restarts = append([]time.Time(nil), info.Restarts...)
+	return &cp
 }
 
 // RecordSuccess records that an agent is running successfully.
@@ -210,6 +239,7 @@ func (rt *RestartTracker) RecordSuccess(agentID string) {
 		info.RestartCount = 0
 		info.CrashLoopSince = time.Time{}
 		info.BackoffUntil = time.Time{}
+		info.Restarts = nil
 	}
 }
 
@@ -252,6 +282,7 @@ func (rt *RestartTracker) ClearCrashLoop(agentID string) {
 		info.CrashLoopSince = time.Time{}
 		info.RestartCount = 0
 		info.BackoffUntil = time.Time{}
+		info.Restarts = nil
 	}
 }
 
@@ -0,0 +1,170 @@
+package daemon
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// Restart policies for the session supervisor.
+const (
+	// RestartAlways respawns a dead agent pane regardless of exit status.
+	RestartAlways = "always"
+
+	// RestartOnFailure respawns only when the agent exited non-zero.
+	RestartOnFailure = "on-failure"
+
+	// RestartNever leaves dead panes alone.
+	RestartNever = "never"
+)
+
+// defaultRestartPolicies apply to roles not listed in SessionSupervisorConfig.Roles.
+// Polecats, dogs, and boot are left alone: the witness and deacon own their
+// lifecycles, and a clean exit is how they finish.
+var defaultRestartPolicies = map[string]string{
+	string(session.RoleMayor):    RestartOnFailure,
+	string(session.RoleDeacon):   RestartOnFailure,
+	string(session.RoleWitness):  RestartOnFailure,
+	string(session.RoleRefinery): RestartOnFailure,
+	string(session.RoleCrew):     RestartOnFailure,
+	string(session.RolePolecat):  RestartNever,
+	string(session.RoleDog):      RestartNever,
+	"boot":                       RestartNever,
+}
+
+// SessionSupervisorConfig holds configuration for the session_supervisor patrol.
+// The supervisor finds Gas Town sessions whose agent pane has exited (left as a
+// dead pane by remain-on-exit) and respawns them according to a per-role
+// policy, with exponential backoff and crash-loop detection from the daemon's
+// restart tracker.
+type SessionSupervisorConfig struct {
+	// Enabled controls whether the supervisor runs.
+	Enabled bool `json:"enabled"`
+
+	// Roles maps role names (mayor, deacon, witness, refinery, crew, polecat,
+	// dog, boot) to a restart policy: "always", "on-failure", or "never".
+	// Unlisted roles use the built-in defaults.
+	Roles map[string]string `json:"roles,omitempty"`
+}
+
+// restartPolicy returns the policy for role.
+func (c *SessionSupervisorConfig) restartPolicy(role string) string {
+	if c != nil {
+		if p, ok := c.Roles[role]; ok {
+			return p
+		}
+	}
+	if p, ok := defaultRestartPolicies[role]; ok {
+		return p
+	}
+	return RestartNever
+}
+
+// shouldRestart reports whether a pane that exited with status should be
+// respawned under policy.
+func shouldRestart(policy string, status int) bool {
+	switch policy {
+	case RestartAlways:
+		return true
+	case RestartOnFailure:
+		return status != 0
+	default:
+		return false
+	}
+}
+
+// superviseOps is the subset of tmux operations used by the supervisor.
+type superviseOps interface {
+	ListDeadPanes() ([]tmux.DeadPane, error)
+	RespawnDeadPane(pane string) error
+}
+
+// superviseSessions respawns dead agent panes in Gas Town sessions.
+// Restart attempts are keyed by session name in the restart tracker, so a
+// crash-looping session can be reset with 'gt daemon clear-backoff <session>'.
+func (d *Daemon) superviseSessions() {
+	var cfg *SessionSupervisorConfig
+	if d.patrolConfig != nil && d.patrolConfig.Patrols != nil {
+		cfg = d.patrolConfig.Patrols.SessionSupervisor
+	}
+	onRestart := func(sessionName string) {
+		if err := session.RunLifecycleHooks(d.config.TownRoot, session.HookRestart, sessionName, ""); err != nil {
+			d.logger.Printf("session_supervisor: %v", err)
+		}
+	}
+	for _, msg := range superviseDeadPanes(d.tmux, d.restartTracker, cfg, onRestart, d.fileCrashLoopIncident) {
+		d.logger.Printf("session_supervisor: %s", msg)
+	}
+}
+
+// superviseDeadPanes does one supervision pass and returns log lines
+// describing what it did. onRestart, if set, is called for each session
+// whose pane was respawned; onCrashLoop, if set, for each session whose
+// restart tripped the crash-loop circuit breaker.
+func superviseDeadPanes(t superviseOps, rt *RestartTracker, cfg *SessionSupervisorConfig, onRestart, onCrashLoop func(sessionName string)) []string {
+	panes, err := t.ListDeadPanes()
+	if err != nil {
+		return []string{fmt.Sprintf("listing panes: %v", err)}
+	}
+
+	var msgs []string
+	var crashLooping []string
+	restarted := false
+	for _, p := range panes {
+		if !session.IsKnownSession(p.Session) {
+			continue
+		}
+		identity, err := session.ParseSessionName(p.Session)
+		if err != nil {
+			continue
+		}
+		role := string(identity.Role)
+		if identity.Role == session.RoleDeacon && identity.Name == "boot" {
+			role = "boot"
+		}
+		if !shouldRestart(cfg.restartPolicy(role), p.ExitStatus) {
+			continue
+		}
+
+		if rt != nil {
+			if rt.IsInCrashLoop(p.Session) {
+				msgs = append(msgs, fmt.Sprintf("%s is crash-looping, not restarting (use 'gt daemon clear-backoff %s' to reset)", p.Session, p.Session))
+				continue
+			}
+			if !rt.CanRestart(p.Session) {
+				msgs = append(msgs, fmt.Sprintf("%s restart in backoff, %s remaining", p.Session, rt.GetBackoffRemaining(p.Session).Round(time.Second)))
+				continue
+			}
+		}
+
+		if err := t.RespawnDeadPane(p.PaneID); err != nil {
+			msgs = append(msgs, fmt.Sprintf("respawning %s (%s): %v", p.Session, p.PaneID, err))
+			continue
+		}
+		if rt != nil {
+			if rt.RecordRestart(p.Session) {
+				crashLooping = append(crashLooping, p.Session)
+			}
+			restarted = true
+		}
+		msgs = append(msgs, fmt.Sprintf("restarted %s after exit %d", p.Session, p.ExitStatus))
+		if onRestart != nil {
+			onRestart(p.Session)
+		}
+	}
+
+	if restarted {
+		if err := rt.Save(); err != nil {
+			msgs = append(msgs, fmt.Sprintf("saving restart state: %v", err))
+		}
+	}
+	for _, sess := range crashLooping {
+		msgs = append(msgs, fmt.Sprintf("%s is crash-looping, no further restarts until 'gt daemon clear-backoff %s'", sess, sess))
+		if onCrashLoop != nil {
+			onCrashLoop(sess)
+		}
+	}
+	return msgs
+}
@@ -0,0 +1,91 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+type fakeSuperviseOps struct {
+	dead      []tmux.DeadPane
+	respawned []string
+}
+
+func (f *fakeSuperviseOps) ListDeadPanes() ([]tmux.DeadPane, error) { return f.dead, nil }
+
+func (f *fakeSuperviseOps) RespawnDeadPane(pane string) error {
+	f.respawned = append(f.respawned, pane)
+	return nil
+}
+
+func TestSuperviseDeadPanes(t *testing.T) {
+	reg := session.NewPrefixRegistry()
+	reg.Register("gt", "gastown")
+	old := session.DefaultRegistry()
+	session.SetDefaultRegistry(reg)
+	defer session.SetDefaultRegistry(old)
+
+	ops := &fakeSuperviseOps{dead: []tmux.DeadPane{
+		{Session: "gt-witness", PaneID: "%1", ExitStatus: 1},  // on-failure: restart
+		{Session: "gt-crew-max", PaneID: "%2", ExitStatus: 0}, // clean exit: leave
+		{Session: "gt-Toast", PaneID: "%3", ExitStatus: 1},    // polecat: never
+		{Session: "hq-boot", PaneID: "%4", ExitStatus: 1},     // boot: never
+		{Session: "personal", PaneID: "%5", ExitStatus: 1},    // not Gas Town
+		{Session: "gt-refinery", PaneID: "%6", ExitStatus: 0}, // overridden to always
+	}}
+	cfg := &SessionSupervisorConfig{Enabled: true, Roles: map[string]string{"refinery": RestartAlways}}
+
+	town := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(town, "daemon"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rt := NewRestartTracker(town, RestartTrackerConfig{})
+
+	msgs := superviseDeadPanes(ops, rt, cfg, nil, nil)
+	if strings.Join(ops.respawned, ",") != "%1,%6" {
+		t.Fatalf("respawned = %v, want [%%1 %%6] (msgs=%v)", ops.respawned, msgs)
+	}
+
+	// A second pass inside the backoff window must not respawn again.
+	ops.respawned = nil
+	msgs = superviseDeadPanes(ops, rt, cfg, nil, nil)
+	if len(ops.respawned) != 0 {
+		t.Errorf("respawned during backoff: %v", ops.respawned)
+	}
+	if len(msgs) != 2 || !strings.Contains(msgs[0], "backoff") {
+		t.Errorf("msgs = %v, want backoff notices", msgs)
+	}
+
+	// Restart counts persist so the daemon survives its own restarts.
+	reloaded := NewRestartTracker(town, RestartTrackerConfig{})
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.CanRestart("gt-witness") {
+		t.Error("persisted state should keep gt-witness in backoff")
+	}
+}
+
+func TestShouldRestart(t *testing.T) {
+	tests := []struct {
+		policy string
+		status int
+		want   bool
+	}{
+		{RestartAlways, 0, true},
+		{RestartAlways, 1, true},
+		{RestartOnFailure, 0, false},
+		{RestartOnFailure, 137, true},
+		{RestartNever, 1, false},
+		{"bogus", 1, false},
+	}
+	for _, tt := range tests {
+		if got := shouldRestart(tt.policy, tt.status); got != tt.want {
+			t.Errorf("shouldRestart(%q, %d) = %v, want %v", tt.policy, tt.status, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,309 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// townScheduleCheckInterval is how often the daemon evaluates the town
+// schedule. Cron entries have minute resolution.
+const townScheduleCheckInterval = time.Minute
+
+// townScheduleActionTimeout bounds a scheduled gt command. A graceful
+// shutdown waits for agents to hand off, so this is generous.
+const townScheduleActionTimeout = 10 * time.Minute
+
+// cronSpec is a parsed five-field cron expression. Each field is a bitmask
+// of the values it matches.
+type cronSpec struct {
+	minute, hour, dom, month, dow uint64
+	domAny, dowAny                bool
+}
+
+// cronFields are the bounds of each cron field, in order.
+var cronFields = []struct {
+	name     string
+	min, max int
+}{
+	{"minute", 0, 59},
+	{"hour", 0, 23},
+	{"day-of-month", 1, 31},
+	{"month", 1, 12},
+	{"day-of-week", 0, 7}, // 0 and 7 are both Sunday
+}
+
+// parseCron parses a five-field cron expression. Each field accepts "*",
+// single values, ranges ("1-5"), steps ("*/15", "0-30/10"), and
+// comma-separated lists of those.
+func parseCron(expr string) (*cronSpec, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != len(cronFields) {
+		return nil, fmt.Errorf("cron %q: want 5 fields (minute hour day-of-month month day-of-week), got %d", expr, len(fields))
+	}
+	var masks [5]uint64
+	for i, f := range fields {
+		mask, err := parseCronField(f, cronFields[i].min, cronFields[i].max)
+		if err != nil {
+			return nil, fmt.Errorf("cron %q: %s: %w", expr, cronFields[i].name, err)
+		}
+		masks[i] = mask
+	}
+	// Fold Sunday-as-7 onto 0 so matching only needs time.Weekday.
+	if masks[4]&(1<<7) != 0 {
+		masks[4] |= 1
+	}
+	return &cronSpec{
+		minute: masks[0],
+		hour:   masks[1],
+		dom:    masks[2],
+		month:  masks[3],
+		dow:    masks[4],
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var mask uint64
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.IndexByte(part, '/'); i >= 0 {
+			s, err := strconv.Atoi(part[i+1:])
+			if err != nil || s <= 0 {
+				return 0, fmt.Errorf("invalid step in %q", part)
+			}
+			rangePart, step = part[:i], s
+		}
+
+		lo, hi := min, max
+		switch {
+		case rangePart == "*":
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err1, err2 error
+			lo, err1 = strconv.Atoi(bounds[0])
+			hi, err2 = strconv.Atoi(bounds[1])
+			if err1 != nil || err2 != nil {
+				return 0, fmt.Errorf("invalid range %q", rangePart)
+			}
+		default:
+			v, err := strconv.Atoi(rangePart)
+			if err != nil {
+				return 0, fmt.Errorf("invalid value %q", rangePart)
+			}
+			lo, hi = v, v
+			if step > 1 {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%q out of range %d-%d", rangePart, min, max)
+		}
+		for v := lo; v <= hi; v += step {
+			mask |= 1 << uint(v)
+		}
+	}
+	return mask, nil
+}
+
+// matches reports whether t falls in a minute the spec selects. As in
+// standard cron, when both day-of-month and day-of-week are restricted a
+// day matching either one matches.
+func (c *cronSpec) matches(t time.Time) bool {
+	if c.minute&(1<<uint(t.Minute())) == 0 ||
+		c.hour&(1<<uint(t.Hour())) == 0 ||
+		c.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+	domOK := c.dom&(1<<uint(t.Day())) != 0
+	dowOK := c.dow&(1<<uint(t.Weekday())) != 0
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dowOK
+	case c.dowAny:
+		return domOK
+	default:
+		return domOK || dowOK
+	}
+}
+
+// ValidateScheduleEntry checks that a town schedule entry has a parseable
+// cron expression and a known action.
+func ValidateScheduleEntry(e config.ScheduleEntry) error {
+	if _, err := parseCron(e.Cron); err != nil {
+		return err
+	}
+	switch e.Action {
+	case config.ScheduleShutdown, config.ScheduleStart,
+		config.SchedulePauseDispatch, config.ScheduleResumeDispatch:
+		return nil
+	}
+	return fmt.Errorf("unknown schedule action %q (valid: shutdown, start, pause_dispatch, resume_dispatch)", e.Action)
+}
+
+// dueScheduleEntries returns the entries of schedule whose cron matches
+// now, logging and skipping invalid ones via warn.
+func dueScheduleEntries(schedule []config.ScheduleEntry, now time.Time, warn func(string)) []config.ScheduleEntry {
+	var due []config.ScheduleEntry
+	for _, e := range schedule {
+		if err := ValidateScheduleEntry(e); err != nil {
+			warn(err.Error())
+			continue
+		}
+		spec, _ := parseCron(e.Cron)
+		if spec.matches(now) {
+			due = append(due, e)
+		}
+	}
+	return due
+}
+
+// QuietHoursState records a scheduled shutdown. While it exists the daemon
+// stays up but does not restart agents.
+type QuietHoursState struct {
+	Since time.Time `json:"since"`
+	Cron  string    `json:"cron,omitempty"` // Schedule entry that started quiet hours
+}
+
+// QuietHoursFilePath returns the path of the quiet-hours marker.
+func QuietHoursFilePath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "quiet-hours.json")
+}
+
+// InQuietHours reports whether a scheduled shutdown is in effect.
+func InQuietHours(townRoot string) bool {
+	_, err := os.Stat(QuietHoursFilePath(townRoot))
+	return err == nil
+}
+
+func writeQuietHours(townRoot string, state *QuietHoursState) error {
+	path := QuietHoursFilePath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ClearQuietHours ends quiet hours so the daemon manages agents again.
+func ClearQuietHours(townRoot string) error {
+	err := os.Remove(QuietHoursFilePath(townRoot))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
+
+// runTownSchedule performs the town.json schedule entries due this minute.
+func (d *Daemon) runTownSchedule(now time.Time) {
+	townCfg, err := config.LoadTownConfig(constants.MayorTownPath(d.config.TownRoot))
+	if err != nil || len(townCfg.Schedule) == 0 {
+		return
+	}
+
+	minute := now.Truncate(time.Minute)
+	if !d.lastScheduleMinute.IsZero() && !minute.After(d.lastScheduleMinute) {
+		return // Already handled this minute
+	}
+	d.lastScheduleMinute = minute
+
+	warn := func(msg string) { d.logger.Printf("town_schedule: %s", msg) }
+	for _, e := range dueScheduleEntries(townCfg.Schedule, now, warn) {
+		d.runScheduleAction(e, now)
+	}
+}
+
+// beginScheduleAction marks action as running and reports whether it was
+// idle. At most one gt command per action runs at a time, so a shutdown that
+// outlasts its cron interval isn't started again on top of itself.
+func (d *Daemon) beginScheduleAction(action string) bool {
+	d.scheduleMu.Lock()
+	defer d.scheduleMu.Unlock()
+	if d.scheduleInFlight[action] {
+		return false
+	}
+	if d.scheduleInFlight == nil {
+		d.scheduleInFlight = make(map[string]bool)
+	}
+	d.scheduleInFlight[action] = true
+	return true
+}
+
+// endScheduleAction marks action as no longer running.
+func (d *Daemon) endScheduleAction(action string) {
+	d.scheduleMu.Lock()
+	defer d.scheduleMu.Unlock()
+	delete(d.scheduleInFlight, action)
+}
+
+// runScheduleAction performs one due schedule entry. The gt command runs in
+// its own goroutine: a graceful shutdown can take minutes, and the heartbeat
+// loop must keep running its other checks meanwhile. An action still running
+// from an earlier minute is skipped.
+func (d *Daemon) runScheduleAction(e config.ScheduleEntry, now time.Time) {
+	if !d.beginScheduleAction(e.Action) {
+		d.logger.Printf("town_schedule: %q due, but the previous %s is still running; skipping", e.Cron, e.Action)
+		return
+	}
+
+	var args []string
+	switch e.Action {
+	case config.ScheduleShutdown:
+		// Mark quiet hours first so the heartbeat doesn't restart agents
+		// as the shutdown stops them.
+		if err := writeQuietHours(d.config.TownRoot, &QuietHoursState{Since: now, Cron: e.Cron}); err != nil {
+			d.logger.Printf("town_schedule: not shutting down, could not mark quiet hours: %v", err)
+			d.endScheduleAction(e.Action)
+			return
+		}
+		args = []string{"shutdown", "--graceful", "--yes", "--keep-daemon"}
+	case config.ScheduleStart:
+		if err := ClearQuietHours(d.config.TownRoot); err != nil {
+			d.logger.Printf("town_schedule: clearing quiet hours: %v", err)
+		}
+		args = []string{"start"}
+	case config.SchedulePauseDispatch:
+		args = []string{"scheduler", "pause"}
+	case config.ScheduleResumeDispatch:
+		args = []string{"scheduler", "resume"}
+	}
+
+	d.logger.Printf("town_schedule: %q due, running gt %s", e.Cron, strings.Join(args, " "))
+	go func() {
+		defer d.endScheduleAction(e.Action)
+		d.execScheduleAction(e, args)
+	}()
+}
+
+// execScheduleAction runs the gt command for a schedule entry, escalating on
+// failure.
+func (d *Daemon) execScheduleAction(e config.ScheduleEntry, args []string) {
+	ctx, cancel := context.WithTimeout(d.ctx, townScheduleActionTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, d.gtPath, args...)
+	cmd.Dir = d.config.TownRoot
+	cmd.Env = append(os.Environ(), "BD_ACTOR=daemon")
+	util.SetDetachedProcessGroup(cmd)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		d.logger.Printf("town_schedule: gt %s failed: %v\nOutput: %s", strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+		d.escalate("town_schedule", fmt.Sprintf("scheduled %s failed: %v", e.Action, err))
+		return
+	}
+	d.logger.Printf("town_schedule: gt %s completed", strings.Join(args, " "))
+}
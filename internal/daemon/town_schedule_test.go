@@ -0,0 +1,127 @@
+package daemon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestParseCron_Matches(t *testing.T) {
+	// 2026-03-02 is a Monday.
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.March, day, hour, minute, 0, 0, time.Local)
+	}
+	tests := []struct {
+		expr string
+		t    time.Time
+		want bool
+	}{
+		{"0 22 * * *", at(2, 22, 0), true},
+		{"0 22 * * *", at(2, 22, 1), false},
+		{"*/15 * * * *", at(2, 9, 45), true},
+		{"*/15 * * * *", at(2, 9, 50), false},
+		{"0 7 * * 1-5", at(2, 7, 0), true},  // Monday
+		{"0 7 * * 1-5", at(1, 7, 0), false}, // Sunday
+		{"0 7 * * 7", at(1, 7, 0), true},    // 7 is Sunday too
+		{"30 8,12 * * *", at(2, 12, 30), true},
+		{"0 0 1 * *", at(1, 0, 0), true},
+		// Both day fields restricted: either one matching is enough.
+		{"0 0 15 * 1", at(2, 0, 0), true},
+		{"0 0 15 * 2", at(2, 0, 0), false},
+	}
+	for _, tt := range tests {
+		spec, err := parseCron(tt.expr)
+		if err != nil {
+			t.Fatalf("parseCron(%q): %v", tt.expr, err)
+		}
+		if got := spec.matches(tt.t); got != tt.want {
+			t.Errorf("%q matches %s = %v, want %v", tt.expr, tt.t.Format("Mon 15:04"), got, tt.want)
+		}
+	}
+}
+
+func TestParseCron_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"0 22 * *",
+		"60 * * * *",
+		"0 24 * * *",
+		"0 0 0 * *",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"a * * * *",
+	} {
+		if _, err := parseCron(expr); err == nil {
+			t.Errorf("parseCron(%q) should fail", expr)
+		}
+	}
+}
+
+func TestValidateScheduleEntry(t *testing.T) {
+	if err := ValidateScheduleEntry(config.ScheduleEntry{Cron: "0 22 * * *", Action: config.ScheduleShutdown}); err != nil {
+		t.Errorf("valid entry rejected: %v", err)
+	}
+	if err := ValidateScheduleEntry(config.ScheduleEntry{Cron: "0 22 * * *", Action: "sleep"}); err == nil {
+		t.Error("unknown action should be rejected")
+	}
+}
+
+func TestDueScheduleEntries(t *testing.T) {
+	schedule := []config.ScheduleEntry{
+		{Cron: "0 22 * * *", Action: config.ScheduleShutdown},
+		{Cron: "0 7 * * *", Action: config.ScheduleStart},
+		{Cron: "bogus", Action: config.ScheduleStart},
+	}
+	var warnings []string
+	now := time.Date(2026, time.March, 2, 22, 0, 30, 0, time.Local)
+
+	due := dueScheduleEntries(schedule, now, func(msg string) { warnings = append(warnings, msg) })
+	if len(due) != 1 || due[0].Action != config.ScheduleShutdown {
+		t.Errorf("due = %+v, want only the shutdown entry", due)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("warnings = %v, want one for the invalid entry", warnings)
+	}
+}
+
+func TestQuietHoursMarker(t *testing.T) {
+	townRoot := t.TempDir()
+	if InQuietHours(townRoot) {
+		t.Fatal("fresh town should not be in quiet hours")
+	}
+	if err := writeQuietHours(townRoot, &QuietHoursState{Since: time.Now(), Cron: "0 22 * * *"}); err != nil {
+		t.Fatal(err)
+	}
+	if !InQuietHours(townRoot) {
+		t.Error("expected quiet hours after writing marker")
+	}
+	if err := ClearQuietHours(townRoot); err != nil {
+		t.Fatal(err)
+	}
+	if InQuietHours(townRoot) {
+		t.Error("expected quiet hours to end after clearing")
+	}
+	if err := ClearQuietHours(townRoot); err != nil {
+		t.Errorf("clearing twice should be a no-op, got %v", err)
+	}
+}
+
+func TestScheduleActionInFlightGuard(t *testing.T) {
+	d := &Daemon{}
+
+	if !d.beginScheduleAction(config.ScheduleShutdown) {
+		t.Fatal("first shutdown should start")
+	}
+	if d.beginScheduleAction(config.ScheduleShutdown) {
+		t.Error("second shutdown started while the first is in flight")
+	}
+	if !d.beginScheduleAction(config.SchedulePauseDispatch) {
+		t.Error("a different action should not be blocked by an in-flight shutdown")
+	}
+
+	d.endScheduleAction(config.ScheduleShutdown)
+	if !d.beginScheduleAction(config.ScheduleShutdown) {
+		t.Error("shutdown should start again once the previous one finished")
+	}
+}
@@ -0,0 +1,95 @@
+package daemon
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/tracker"
+)
+
+// trackerSyncCheckInterval is how often the syncer checks which rigs'
+// connectors are due. Each connector's own interval decides when it runs.
+const trackerSyncCheckInterval = time.Minute
+
+// TrackerSyncer runs scheduled syncs between rig beads and external issue
+// trackers (see internal/tracker). It runs as a background goroutine
+// within the daemon.
+type TrackerSyncer struct {
+	townRoot string
+	rigs     func() []string
+	logger   func(format string, args ...interface{})
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+// NewTrackerSyncer creates a tracker syncer. rigs lists the rigs to check.
+func NewTrackerSyncer(townRoot string, rigs func() []string, logger func(format string, args ...interface{})) *TrackerSyncer {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TrackerSyncer{
+		townRoot: townRoot,
+		rigs:     rigs,
+		logger:   logger,
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// Start begins the syncer goroutine.
+func (s *TrackerSyncer) Start() {
+	s.wg.Add(1)
+	go s.run()
+}
+
+// Stop gracefully stops the syncer, canceling any sync in progress.
+func (s *TrackerSyncer) Stop() {
+	s.cancel()
+	s.wg.Wait()
+}
+
+func (s *TrackerSyncer) run() {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(trackerSyncCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.syncDue()
+		}
+	}
+}
+
+// syncDue syncs every configured connector whose interval has elapsed.
+func (s *TrackerSyncer) syncDue() {
+	now := time.Now()
+	for _, rigName := range s.rigs() {
+		rigPath := filepath.Join(s.townRoot, rigName)
+		connectors, err := tracker.RigConnectors(rigPath)
+		if err != nil {
+			s.logger("Tracker sync: %s: %v", rigName, err)
+		}
+		for _, c := range connectors {
+			if s.ctx.Err() != nil {
+				return
+			}
+			if !tracker.Due(rigPath, c, now) {
+				continue
+			}
+			res, err := tracker.SyncRig(s.ctx, rigPath, c, false)
+			if err != nil {
+				s.logger("Tracker sync: %s %s: %v", rigName, c.Connector.Name(), err)
+				continue
+			}
+			if res.Created+res.Imported+res.Pushed+res.Pulled > 0 || len(res.Conflicts) > 0 {
+				s.logger("Tracker sync: %s %s: %d created, %d imported, %d pushed, %d pulled, %d conflicts",
+					rigName, c.Connector.Name(), res.Created, res.Imported, res.Pushed, res.Pulled, len(res.Conflicts))
+			}
+		}
+	}
+}
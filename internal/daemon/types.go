@@ -131,6 +131,9 @@ type PatrolsConfig struct {
 	MainBranchTest         *MainBranchTestConfig          `json:"main_branch_test,omitempty"`
 	QuotaDog               *QuotaDogConfig                `json:"quota_dog,omitempty"`
 	RestartTracker         *RestartTrackerConfig          `json:"restart_tracker,omitempty"`
+	SessionSupervisor      *SessionSupervisorConfig       `json:"session_supervisor,omitempty"`
+	IdleStop               *IdleStopConfig                `json:"idle_stop,omitempty"`
+	MayorFetch             *MayorFetchConfig              `json:"mayor_fetch,omitempty"`
 }
 
 // DoltRemotesConfig holds configuration for the dolt_remotes patrol.
@@ -308,6 +311,24 @@ func IsPatrolEnabled(config *DaemonPatrolConfig, patrol string) bool {
 		}
 		return config.Patrols.QuotaDog.Enabled
 	}
+	if patrol == "session_supervisor" {
+		if config == nil || config.Patrols == nil || config.Patrols.SessionSupervisor == nil {
+			return false
+		}
+		return config.Patrols.SessionSupervisor.Enabled
+	}
+	if patrol == "idle_stop" {
+		if config == nil || config.Patrols == nil || config.Patrols.IdleStop == nil {
+			return false
+		}
+		return config.Patrols.IdleStop.Enabled
+	}
+	if patrol == "mayor_fetch" {
+		if config == nil || config.Patrols == nil || config.Patrols.MayorFetch == nil {
+			return false
+		}
+		return config.Patrols.MayorFetch.Enabled
+	}
 
 	if config == nil || config.Patrols == nil {
 		return true // Default: enabled
@@ -349,6 +370,10 @@ func GetPatrolRigs(config *DaemonPatrolConfig, patrol string) []string {
 		if config.Patrols.Witness != nil {
 			return config.Patrols.Witness.Rigs
 		}
+	case "mayor_fetch":
+		if config.Patrols.MayorFetch != nil {
+			return config.Patrols.MayorFetch.Rigs
+		}
 	}
 	return nil // All rigs
 }
@@ -0,0 +1,280 @@
+package daemon
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/webhook"
+)
+
+const (
+	// webhookTailInterval is how often the events log is checked for new
+	// state changes.
+	webhookTailInterval = time.Second
+
+	// webhookScanInterval is how often rigs are scanned for beads that
+	// agents moved to in_progress with bd directly.
+	webhookScanInterval = 30 * time.Second
+
+	// webhookQueueSize is how many deliveries may wait for one webhook
+	// before further ones to it are dropped.
+	webhookQueueSize = 256
+)
+
+// WebhookNotifier delivers bead state changes to the town's configured
+// webhooks (see internal/webhook). It runs as a background goroutine
+// within the daemon, and does nothing while no webhooks are configured.
+// Each webhook URL gets its own delivery queue and worker, so a slow or
+// failing endpoint only delays its own deliveries.
+type WebhookNotifier struct {
+	townRoot string
+	rigs     func() []string
+	logger   func(format string, args ...interface{})
+	sender   *webhook.Sender
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+
+	// queues holds the delivery queue of each webhook URL, created on its
+	// first delivery. Only touched by the run goroutine.
+	queues map[string]chan hookDelivery
+
+	// inProgress holds the beads seen in_progress by the last scan, per
+	// rig; nil until a rig's first scan, which only seeds it.
+	inProgress map[string]map[string]bool
+}
+
+// hookDelivery is one delivery waiting in a webhook's queue. The hook is
+// carried along so edits to its secret or states apply to later deliveries.
+type hookDelivery struct {
+	hook *config.WebhookConfig
+	d    webhook.Delivery
+}
+
+// NewWebhookNotifier creates a webhook notifier. rigs lists the rigs to scan.
+func NewWebhookNotifier(townRoot string, rigs func() []string, logger func(format string, args ...interface{})) *WebhookNotifier {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WebhookNotifier{
+		townRoot:   townRoot,
+		rigs:       rigs,
+		logger:     logger,
+		sender:     webhook.NewSender(nil),
+		ctx:        ctx,
+		cancel:     cancel,
+		queues:     make(map[string]chan hookDelivery),
+		inProgress: make(map[string]map[string]bool),
+	}
+}
+
+// Start begins tailing the events log from its current end.
+func (n *WebhookNotifier) Start() error {
+	file, err := os.OpenFile(n.eventsPath(), os.O_RDONLY|os.O_CREATE, 0600)
+	if err != nil {
+		return err
+	}
+	pos, err := file.Seek(0, io.SeekEnd)
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	n.wg.Add(1)
+	go n.run(&eventsTail{file: file, reader: bufio.NewReader(file), pos: pos})
+	return nil
+}
+
+// Stop gracefully stops the notifier, abandoning any delivery in progress.
+func (n *WebhookNotifier) Stop() {
+	n.cancel()
+	n.wg.Wait()
+}
+
+func (n *WebhookNotifier) eventsPath() string {
+	return filepath.Join(n.townRoot, events.EventsFile)
+}
+
+// eventsTail is the notifier's read position in the events log.
+type eventsTail struct {
+	file    *os.File
+	reader  *bufio.Reader
+	pos     int64  // Offset of the next byte the reader returns
+	partial []byte // A line still being written
+}
+
+func (n *WebhookNotifier) run(tail *eventsTail) {
+	defer n.wg.Done()
+	defer func() { _ = tail.file.Close() }()
+
+	tailTick := time.NewTicker(webhookTailInterval)
+	defer tailTick.Stop()
+	scan := time.NewTicker(webhookScanInterval)
+	defer scan.Stop()
+
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case <-tailTick.C:
+			n.readNew(tail)
+			n.followRotation(tail)
+		case <-scan.C:
+			n.scanInProgress()
+		}
+	}
+}
+
+// readNew handles every complete line appended since the last read.
+func (n *WebhookNotifier) readNew(tail *eventsTail) {
+	for {
+		line, err := tail.reader.ReadBytes('\n')
+		tail.pos += int64(len(line))
+		if err != nil {
+			// Keep a line still being written for the next tick.
+			tail.partial = append(tail.partial, line...)
+			return
+		}
+		if len(tail.partial) > 0 {
+			line = append(tail.partial, line...)
+			tail.partial = nil
+		}
+		n.handleLine(line)
+	}
+}
+
+// followRotation reopens the events log when it was replaced (a new inode
+// at its path) and rewinds when it was truncated, so rotation doesn't
+// silently end delivery. Lines already in the new file are read next tick.
+func (n *WebhookNotifier) followRotation(tail *eventsTail) {
+	info, err := os.Stat(n.eventsPath())
+	if err != nil {
+		return // Mid-rotation; look again next tick
+	}
+	cur, err := tail.file.Stat()
+	if err == nil && !os.SameFile(info, cur) {
+		file, err := os.Open(n.eventsPath())
+		if err != nil {
+			return
+		}
+		_ = tail.file.Close()
+		*tail = eventsTail{file: file, reader: bufio.NewReader(file)}
+		return
+	}
+	if info.Size() < tail.pos {
+		if _, err := tail.file.Seek(0, io.SeekStart); err != nil {
+			return
+		}
+		tail.reader.Reset(tail.file)
+		tail.pos = 0
+		tail.partial = nil
+	}
+}
+
+// hooks returns the configured webhooks, reloaded each time so edits to
+// town settings apply without a daemon restart.
+func (n *WebhookNotifier) hooks() []*config.WebhookConfig {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(n.townRoot))
+	if err != nil {
+		return nil
+	}
+	return settings.Webhooks
+}
+
+func (n *WebhookNotifier) handleLine(line []byte) {
+	var e events.Event
+	if err := json.Unmarshal(line, &e); err != nil {
+		return
+	}
+	d, ok := webhook.FromEvent(e)
+	if !ok {
+		return
+	}
+	n.deliver(n.hooks(), d)
+}
+
+// deliver queues d for each hook without waiting for any of them.
+func (n *WebhookNotifier) deliver(hooks []*config.WebhookConfig, d webhook.Delivery) {
+	for _, hook := range hooks {
+		if hook == nil || hook.URL == "" {
+			continue
+		}
+		select {
+		case n.queue(hook.URL) <- hookDelivery{hook: hook, d: d}:
+		default:
+			n.logger("Webhook: %s queue full, dropping %s %s", hook.URL, d.Event, d.Bead)
+		}
+	}
+}
+
+// queue returns url's delivery queue, starting its worker on first use.
+func (n *WebhookNotifier) queue(url string) chan hookDelivery {
+	q, ok := n.queues[url]
+	if !ok {
+		q = make(chan hookDelivery, webhookQueueSize)
+		n.queues[url] = q
+		n.wg.Add(1)
+		go n.sendLoop(q)
+	}
+	return q
+}
+
+// sendLoop posts one webhook's deliveries in order until the notifier stops.
+func (n *WebhookNotifier) sendLoop(q <-chan hookDelivery) {
+	defer n.wg.Done()
+	for {
+		select {
+		case <-n.ctx.Done():
+			return
+		case hd := <-q:
+			err := n.sender.Send(n.ctx, []*config.WebhookConfig{hd.hook}, hd.d)
+			if err != nil && n.ctx.Err() == nil {
+				n.logger("Webhook: %s %s: %v", hd.d.Event, hd.d.Bead, err)
+			}
+		}
+	}
+}
+
+// scanInProgress delivers in_progress for beads that entered it since the
+// last scan.
+func (n *WebhookNotifier) scanInProgress() {
+	hooks := n.hooks()
+	if len(hooks) == 0 {
+		n.inProgress = make(map[string]map[string]bool) // Reseed if re-enabled
+		return
+	}
+
+	query := beads.Query{Statuses: []string{string(beads.StatusInProgress)}}
+	for _, rigName := range n.rigs() {
+		if n.ctx.Err() != nil {
+			return
+		}
+		issues, err := beads.New(filepath.Join(n.townRoot, rigName)).Query(query)
+		if err != nil {
+			continue // Try again next scan; keep the previous snapshot
+		}
+		seen := make(map[string]bool, len(issues))
+		for _, issue := range issues {
+			seen[issue.ID] = true
+		}
+
+		prev, scanned := n.inProgress[rigName]
+		n.inProgress[rigName] = seen
+		if !scanned {
+			continue
+		}
+		for _, issue := range issues {
+			if prev[issue.ID] {
+				continue
+			}
+			n.deliver(hooks, webhook.NewDelivery(webhook.StateInProgress, issue.ID, issue.Assignee, time.Now(),
+				map[string]interface{}{"rig": rigName, "title": issue.Title}))
+		}
+	}
+}
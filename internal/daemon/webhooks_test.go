@@ -0,0 +1,199 @@
+package daemon
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+	"github.com/steveyegge/gastown/internal/webhook"
+)
+
+func TestWebhookNotifier_DeliversEventsLogChanges(t *testing.T) {
+	var mu sync.Mutex
+	var got []webhook.Delivery
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var d webhook.Delivery
+		_ = json.NewDecoder(r.Body).Decode(&d)
+		mu.Lock()
+		got = append(got, d)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	townRoot := t.TempDir()
+	settings := config.NewTownSettings()
+	settings.Webhooks = []*config.WebhookConfig{{URL: srv.URL}}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	n := NewWebhookNotifier(townRoot, func() []string { return nil }, t.Logf)
+	if err := n.Start(); err != nil {
+		t.Fatal(err)
+	}
+	defer n.Stop()
+
+	// Write one event in two pieces to exercise partial-line handling.
+	line, _ := json.Marshal(events.Event{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Type:      events.TypeDone,
+		Actor:     "gastown/polecats/toast",
+		Payload:   events.DonePayload("gt-7", "polecat/toast"),
+	})
+	f, err := os.OpenFile(filepath.Join(townRoot, events.EventsFile), os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	half := len(line) / 2
+	_, _ = f.Write(line[:half])
+	time.Sleep(webhookTailInterval + 200*time.Millisecond)
+	_, _ = f.Write(append(line[half:], '\n'))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		mu.Lock()
+		n := len(got)
+		mu.Unlock()
+		if n > 0 {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 1 || got[0].Event != "bead.done" || got[0].Bead != "gt-7" {
+		t.Errorf("deliveries = %+v, want one bead.done for gt-7", got)
+	}
+}
+
+// collectingServer records the deliveries POSTed to it.
+type collectingServer struct {
+	*httptest.Server
+	mu  sync.Mutex
+	got []webhook.Delivery
+}
+
+func newCollectingServer(t *testing.T) *collectingServer {
+	t.Helper()
+	c := &collectingServer{}
+	c.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var d webhook.Delivery
+		_ = json.NewDecoder(r.Body).Decode(&d)
+		c.mu.Lock()
+		c.got = append(c.got, d)
+		c.mu.Unlock()
+	}))
+	t.Cleanup(c.Close)
+	return c
+}
+
+// waitFor waits up to 5s for n deliveries and returns what arrived.
+func (c *collectingServer) waitFor(n int) []webhook.Delivery {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		got := append([]webhook.Delivery(nil), c.got...)
+		c.mu.Unlock()
+		if len(got) >= n || time.Now().After(deadline) {
+			return got
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+// startTestNotifier configures hooks for urls in a fresh town and starts a
+// notifier tailing its events log.
+func startTestNotifier(t *testing.T, urls ...string) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	settings := config.NewTownSettings()
+	for _, u := range urls {
+		settings.Webhooks = append(settings.Webhooks, &config.WebhookConfig{URL: u})
+	}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), settings); err != nil {
+		t.Fatal(err)
+	}
+	n := NewWebhookNotifier(townRoot, func() []string { return nil }, t.Logf)
+	if err := n.Start(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(n.Stop)
+	return townRoot
+}
+
+// appendDoneEvent appends a done event for bead to the town's events log.
+func appendDoneEvent(t *testing.T, townRoot, bead string) {
+	t.Helper()
+	line, _ := json.Marshal(events.Event{
+		Timestamp: time.Now().UTC().Format(time.RFC3339),
+		Type:      events.TypeDone,
+		Actor:     "gastown/polecats/toast",
+		Payload:   events.DonePayload(bead, "polecat/toast"),
+	})
+	f, err := os.OpenFile(filepath.Join(townRoot, events.EventsFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWebhookNotifier_FollowsRotationAndTruncation(t *testing.T) {
+	srv := newCollectingServer(t)
+	townRoot := startTestNotifier(t, srv.URL)
+	eventsPath := filepath.Join(townRoot, events.EventsFile)
+
+	appendDoneEvent(t, townRoot, "gt-1")
+	if got := srv.waitFor(1); len(got) != 1 {
+		t.Fatalf("before rotation: deliveries = %+v, want 1", got)
+	}
+
+	// Rotate: move the log aside; the next event creates a new file.
+	if err := os.Rename(eventsPath, eventsPath+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendDoneEvent(t, townRoot, "gt-2")
+	if got := srv.waitFor(2); len(got) != 2 || got[1].Bead != "gt-2" {
+		t.Fatalf("after rotation: deliveries = %+v, want gt-2 delivered", got)
+	}
+
+	// Truncate in place, then write a shorter log.
+	time.Sleep(webhookTailInterval + 200*time.Millisecond)
+	if err := os.Truncate(eventsPath, 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(webhookTailInterval + 200*time.Millisecond)
+	appendDoneEvent(t, townRoot, "gt-3")
+	if got := srv.waitFor(3); len(got) != 3 || got[2].Bead != "gt-3" {
+		t.Fatalf("after truncation: deliveries = %+v, want gt-3 delivered", got)
+	}
+}
+
+func TestWebhookNotifier_SlowHookDoesNotStallOthers(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	fast := newCollectingServer(t)
+	townRoot := startTestNotifier(t, slow.URL, fast.URL)
+
+	appendDoneEvent(t, townRoot, "gt-1")
+	appendDoneEvent(t, townRoot, "gt-2")
+	if got := fast.waitFor(2); len(got) != 2 {
+		t.Errorf("fast hook deliveries = %+v, want 2 while the slow hook blocks", got)
+	}
+}
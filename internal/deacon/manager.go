@@ -9,6 +9,7 @@ import (
 
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/headless"
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -25,8 +26,13 @@ type tmuxOps interface {
 	HasSession(name string) (bool, error)
 	IsAgentAlive(session string) bool
 	KillSessionWithProcesses(name string) error
-	NewSessionWithCommand(name, workDir, command string) error
+	NewSessionWithCommandAndEnv(name, workDir, command string, env map[string]string) error
 	SetRemainOnExit(pane string, on bool) error
+	PipePane(target, command string) error
+	NewWindow(session, name, workDir, command string, env map[string]string) (string, error)
+	SplitWindow(target, workDir, command string, horizontal bool, percent int, env map[string]string) (string, error)
+	GetAllEnvironment(session string) (map[string]string, error)
+	SetPaneRemainOnExit(pane string, on bool) error
 	SetEnvironment(session, key, value string) error
 	GetPaneID(session string) (string, error)
 	ConfigureGasTownSession(session string, theme *tmux.Theme, rig, worker, role string) error
@@ -73,6 +79,16 @@ func (m *Manager) deaconDir() string {
 // agentOverride allows specifying an alternate agent alias (e.g., for testing).
 // Restarts are handled by daemon via ensureDeaconRunning on each heartbeat.
 func (m *Manager) Start(agentOverride string) error {
+	if headless.Enabled(m.townRoot, constants.RoleDeacon) {
+		if err := headless.Start(m.townRoot, constants.RoleDeacon); err != nil {
+			if errors.Is(err, headless.ErrAlreadyRunning) {
+				return ErrAlreadyRunning
+			}
+			return err
+		}
+		return nil
+	}
+
 	t := m.tmux
 	sessionID := m.SessionName()
 
@@ -105,25 +121,15 @@ func (m *Manager) Start(agentOverride string) error {
 		return fmt.Errorf("ensuring runtime settings: %w", err)
 	}
 
-	initialPrompt := session.BuildStartupPrompt(session.BeaconConfig{
-		Recipient: "deacon",
-		Sender:    "daemon",
-		Topic:     "patrol",
-	}, "I am Deacon. Start patrol: run gt deacon heartbeat, then check gt hook. If no hook, create mol-deacon-patrol wisp and execute it.")
-	startupCmd, err := config.BuildStartupCommandFromConfig(config.AgentEnvConfig{
-		Role:        "deacon",
-		TownRoot:    m.townRoot,
-		Prompt:      initialPrompt,
-		Topic:       "patrol",
-		SessionName: sessionID,
-	}, "", initialPrompt, agentOverride)
+	startupCmd, err := m.startupCommand(agentOverride)
 	if err != nil {
-		return fmt.Errorf("building startup command: %w", err)
+		return err
 	}
 
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
-	if err := t.NewSessionWithCommand(sessionID, deaconDir, startupCmd); err != nil {
+	envVars := m.sessionEnv(session.AgentSessionEnv(m.agentEnvConfig(agentOverride), ""), runtimeConfig)
+	if err := session.NewAgentSession(t, sessionID, deaconDir, startupCmd, envVars, m.townRoot, "", "deacon"); err != nil {
 		return fmt.Errorf("creating tmux session: %w", err)
 	}
 
@@ -132,18 +138,13 @@ func (m *Manager) Start(agentOverride string) error {
 	// The pane will show "[Exited]" status but remain available for respawn.
 	_ = t.SetRemainOnExit(sessionID, true)
 
-	// Set environment variables (non-fatal: session works without these)
-	// Use centralized AgentEnv for consistency across all role startup paths
-	envVars := config.AgentEnv(config.AgentEnvConfig{
-		Role:        "deacon",
-		TownRoot:    m.townRoot,
-		Agent:       agentOverride,
-		SessionName: sessionID,
-	})
-	envVars = session.MergeRuntimeLivenessEnv(envVars, runtimeConfig)
-	for k, v := range envVars {
-		_ = t.SetEnvironment(sessionID, k, v)
-	}
+	// Capture pane output to deacon/logs so scrollback survives crashes (non-fatal).
+	_ = session.EnableSessionLogCapture(t, m.townRoot, sessionID)
+
+	// Create the deacon's configured panes/windows (non-fatal).
+	_ = session.ApplySessionLayout(t, m.townRoot, "deacon", sessionID, deaconDir)
+
+	session.RunSessionHooks(m.townRoot, session.HookStart, sessionID, deaconDir)
 
 	// Record agent's pane_id for ZFC-compliant liveness checks (gt-qmsx).
 	if paneID, err := t.GetPaneID(sessionID); err == nil {
@@ -184,8 +185,64 @@ func (m *Manager) Start(agentOverride string) error {
 	return nil
 }
 
-// Stop stops the deacon session.
+// startupCommand builds the command the deacon session runs.
+func (m *Manager) startupCommand(agentOverride string) (string, error) {
+	initialPrompt := session.BuildStartupPrompt(session.BeaconConfig{
+		Recipient: "deacon",
+		Sender:    "daemon",
+		Topic:     "patrol",
+	}, "I am Deacon. Start patrol: run gt deacon heartbeat, then check gt hook. If no hook, create mol-deacon-patrol wisp and execute it.")
+	startupCmd, err := config.BuildStartupCommandFromConfig(config.AgentEnvConfig{
+		Role:        "deacon",
+		TownRoot:    m.townRoot,
+		Prompt:      initialPrompt,
+		Topic:       "patrol",
+		SessionName: m.SessionName(),
+	}, "", initialPrompt, agentOverride)
+	if err != nil {
+		return "", fmt.Errorf("building startup command: %w", err)
+	}
+	return startupCmd, nil
+}
+
+// agentEnvConfig returns the AgentEnv settings for the deacon session.
+func (m *Manager) agentEnvConfig(agentOverride string) config.AgentEnvConfig {
+	return config.AgentEnvConfig{
+		Role:        "deacon",
+		TownRoot:    m.townRoot,
+		Agent:       agentOverride,
+		SessionName: m.SessionName(),
+	}
+}
+
+// sessionEnv returns the environment set on the deacon session: agentEnv
+// (see session.AgentSessionEnv) plus the runtime liveness vars.
+func (m *Manager) sessionEnv(agentEnv map[string]string, runtimeConfig *config.RuntimeConfig) map[string]string {
+	return session.MergeRuntimeLivenessEnv(agentEnv, runtimeConfig)
+}
+
+// Plan returns the tmux session Start would create, without creating it.
+func (m *Manager) Plan(agentOverride string) (*session.StartPlan, error) {
+	deaconDir := m.deaconDir()
+	command, err := m.startupCommand(agentOverride)
+	if err != nil {
+		return nil, err
+	}
+	runtimeConfig := config.ResolveRoleAgentConfig("deacon", m.townRoot, deaconDir)
+	return &session.StartPlan{
+		SessionID: m.SessionName(),
+		WorkDir:   deaconDir,
+		Command:   session.AgentSessionCommand(command, m.townRoot, "", "deacon"),
+		Env:       m.sessionEnv(session.PlannedAgentSessionEnv(m.agentEnvConfig(agentOverride), ""), runtimeConfig),
+	}, nil
+}
+
+// Stop stops the deacon session, or its headless supervisor.
 func (m *Manager) Stop() error {
+	if _, running := headless.Running(m.townRoot, constants.RoleDeacon); running {
+		return headless.Stop(m.townRoot, constants.RoleDeacon)
+	}
+
 	t := m.tmux
 	sessionID := m.SessionName()
 
@@ -202,14 +259,9 @@ func (m *Manager) Stop() error {
 	_ = t.SendKeysRaw(sessionID, "C-c")
 	time.Sleep(100 * time.Millisecond)
 
-	// Kill the session.
-	// Use KillSessionWithProcesses to ensure all descendant processes are killed.
+	// Kill the session and all descendant processes, then run stop hooks.
 	// This prevents orphan bash processes from Claude's Bash tool surviving session termination.
-	if err := t.KillSessionWithProcesses(sessionID); err != nil {
-		return fmt.Errorf("killing session: %w", err)
-	}
-
-	return nil
+	return session.TerminateSession(t, m.townRoot, sessionID)
 }
 
 // IsRunning checks if the deacon session is active.
@@ -39,14 +39,23 @@ func (m *mockTmux) KillSessionWithProcesses(name string) error {
 	return m.killErr
 }
 
-func (m *mockTmux) NewSessionWithCommand(_, _, _ string) error {
+func (m *mockTmux) NewSessionWithCommandAndEnv(_, _, _ string, _ map[string]string) error {
 	m.newSessionCalls++
 	return m.newSessionErr
 }
 
 func (m *mockTmux) SetRemainOnExit(_ string, _ bool) error { return nil }
-func (m *mockTmux) SetEnvironment(_, _, _ string) error    { return nil }
-func (m *mockTmux) GetPaneID(_ string) (string, error)     { return "%0", nil }
+func (m *mockTmux) PipePane(_, _ string) error             { return nil }
+func (m *mockTmux) NewWindow(_, _, _, _ string, _ map[string]string) (string, error) {
+	return "%1", nil
+}
+func (m *mockTmux) SplitWindow(_, _, _ string, _ bool, _ int, _ map[string]string) (string, error) {
+	return "%2", nil
+}
+func (m *mockTmux) GetAllEnvironment(_ string) (map[string]string, error) { return nil, nil }
+func (m *mockTmux) SetPaneRemainOnExit(_ string, _ bool) error            { return nil }
+func (m *mockTmux) SetEnvironment(_, _, _ string) error                   { return nil }
+func (m *mockTmux) GetPaneID(_ string) (string, error)                    { return "%0", nil }
 func (m *mockTmux) ConfigureGasTownSession(_ string, _ *tmux.Theme, _, _, _ string) error {
 	return nil
 }
@@ -192,7 +201,7 @@ func TestStart_HasSessionError(t *testing.T) {
 }
 
 func TestStart_SessionCreateFails(t *testing.T) {
-	// Test that NewSessionWithCommand failure is propagated.
+	// Test that NewSessionWithCommandAndEnv failure is propagated.
 	mock := &mockTmux{
 		hasSessionResult: false,
 		newSessionErr:    errors.New("tmux server not running"),
@@ -202,7 +211,7 @@ func TestStart_SessionCreateFails(t *testing.T) {
 	err := m.Start("claude")
 	if err == nil {
 		// If we got past config without error, session creation should have failed.
-		// But config may have failed first - check if NewSessionWithCommand was called.
+		// But config may have failed first - check if NewSessionWithCommandAndEnv was called.
 		if mock.newSessionCalls > 0 {
 			t.Fatal("Start() should return error when session creation fails")
 		}
@@ -210,7 +219,7 @@ func TestStart_SessionCreateFails(t *testing.T) {
 		return
 	}
 
-	// If NewSessionWithCommand was called and failed, error should wrap it.
+	// If NewSessionWithCommandAndEnv was called and failed, error should wrap it.
 	if mock.newSessionCalls > 0 {
 		if got := err.Error(); got == "" {
 			t.Error("error should have content")
@@ -244,7 +253,7 @@ func TestStart_WaitForCommandFails(t *testing.T) {
 			t.Error("expected cleanup kill call after WaitForCommand failure")
 		}
 	}
-	// If config failed before reaching NewSessionWithCommand, that's
+	// If config failed before reaching NewSessionWithCommandAndEnv, that's
 	// acceptable - the WaitForCommand path isn't reachable in test env.
 }
 
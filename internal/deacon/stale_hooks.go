@@ -108,8 +108,9 @@ func ScanStaleHooks(townRoot string, cfg *StaleHookConfig) (*StaleHookScanResult
 		if bead.Assignee != "" {
 			sessionName := assigneeToSessionName(bead.Assignee)
 			if sessionName != "" {
-				alive, _ := t.HasSession(sessionName)
-				hookResult.AgentAlive = alive
+				// Ping rather than HasSession: a session left behind with a
+				// dead pane holds the hook but can never work it.
+				hookResult.AgentAlive = t.Ping(sessionName) == nil
 				sessionChecked = true
 			}
 		}
@@ -0,0 +1,75 @@
+package deps
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// MinTmuxVersion is the oldest tmux Gas Town is tested against.
+const MinTmuxVersion = "3.0"
+
+// TmuxInstallHint tells the user how to install tmux.
+const TmuxInstallHint = "brew install tmux (macOS) or apt install tmux (Debian/Ubuntu)"
+
+// TmuxStatus represents the state of the tmux installation.
+type TmuxStatus int
+
+const (
+	TmuxOK         TmuxStatus = iota // tmux found, version compatible
+	TmuxNotFound                     // tmux not in PATH
+	TmuxTooOld                       // tmux found but version too old
+	TmuxExecFailed                   // tmux found but 'tmux -V' failed to execute
+	TmuxUnknown                      // tmux -V ran but output couldn't be parsed
+)
+
+// CheckTmux checks if tmux is installed and compatible.
+// Returns status, the installed version (if found), and diagnostic detail
+// for failure cases.
+func CheckTmux() (TmuxStatus, string, string) {
+	path, err := exec.LookPath("tmux")
+	if err != nil {
+		return TmuxNotFound, "", ""
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, path, "-V")
+	util.SetDetachedProcessGroup(cmd)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		detail := strings.TrimSpace(string(output))
+		if detail == "" {
+			detail = err.Error()
+		}
+		return TmuxExecFailed, "", fmt.Sprintf("at %s: %s", path, detail)
+	}
+
+	version := parseTmuxVersion(string(output))
+	if version == "" {
+		return TmuxUnknown, "", strings.TrimSpace(string(output))
+	}
+
+	if CompareVersions(version, MinTmuxVersion) < 0 {
+		return TmuxTooOld, version, ""
+	}
+
+	return TmuxOK, version, ""
+}
+
+// parseTmuxVersion extracts the numeric version from "tmux X.Y[a]" output,
+// including development builds ("tmux next-3.5"). The letter suffix on
+// patch releases is dropped.
+func parseTmuxVersion(output string) string {
+	re := regexp.MustCompile(`tmux (?:next-)?(\d+\.\d+)`)
+	matches := re.FindStringSubmatch(output)
+	if len(matches) >= 2 {
+		return matches[1]
+	}
+	return ""
+}
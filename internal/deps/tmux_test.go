@@ -0,0 +1,33 @@
+package deps
+
+import "testing"
+
+func TestParseTmuxVersion(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"tmux 3.3a", "3.3"},
+		{"tmux 3.4\n", "3.4"},
+		{"tmux 2.9", "2.9"},
+		{"tmux next-3.5", "3.5"},
+		{"tmux master", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		result := parseTmuxVersion(tt.input)
+		if result != tt.expected {
+			t.Errorf("parseTmuxVersion(%q) = %q, want %q", tt.input, result, tt.expected)
+		}
+	}
+}
+
+func TestTmuxVersionComparison(t *testing.T) {
+	if CompareVersions("2.9", MinTmuxVersion) >= 0 {
+		t.Errorf("2.9 should be older than %s", MinTmuxVersion)
+	}
+	if CompareVersions("3.3", MinTmuxVersion) < 0 {
+		t.Errorf("3.3 should satisfy %s", MinTmuxVersion)
+	}
+}
@@ -0,0 +1,93 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// claudeInstallURL is where to get Claude Code, the default agent runtime.
+const claudeInstallURL = "https://claude.ai/code"
+
+// AgentBinaryCheck verifies that the agent CLIs the town agents are
+// configured to run (claude by default) can be found. A missing CLI leaves
+// the agent's tmux session sitting at a shell prompt.
+type AgentBinaryCheck struct {
+	BaseCheck
+}
+
+// NewAgentBinaryCheck creates a new agent CLI availability check.
+func NewAgentBinaryCheck() *AgentBinaryCheck {
+	return &AgentBinaryCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "agent-binary",
+			CheckDescription: "Check that the configured agent CLI (claude by default) is installed",
+			CheckCategory:    CategoryInfrastructure,
+		},
+	}
+}
+
+// Run resolves the Mayor's and Deacon's agent commands and checks each one
+// is on PATH, or exists if configured as an absolute path.
+func (c *AgentBinaryCheck) Run(ctx *CheckContext) *CheckResult {
+	commands := make(map[string][]string) // command -> roles using it
+	for _, role := range []string{"mayor", "deacon"} {
+		rc := config.ResolveRoleAgentConfig(role, ctx.TownRoot, filepath.Join(ctx.TownRoot, role))
+		if rc == nil || rc.Command == "" {
+			continue
+		}
+		commands[rc.Command] = append(commands[rc.Command], role)
+	}
+
+	var found, missing []string
+	for cmd := range commands {
+		if agentCommandExists(cmd) {
+			found = append(found, cmd)
+		} else {
+			missing = append(missing, cmd)
+		}
+	}
+	sort.Strings(found)
+	sort.Strings(missing)
+
+	if len(missing) == 0 {
+		msg := "No agent command configured"
+		if len(found) > 0 {
+			msg = fmt.Sprintf("Agent CLI found: %s", filepath.Base(found[0]))
+			if len(found) > 1 {
+				msg = fmt.Sprintf("%d agent CLIs found", len(found))
+			}
+		}
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: msg}
+	}
+
+	var details []string
+	fixHint := "Install the agent CLI, or change the agent in settings/config.json"
+	for _, cmd := range missing {
+		details = append(details, fmt.Sprintf("%s (used by %v) not found", cmd, commands[cmd]))
+		if filepath.Base(cmd) == "claude" {
+			fixHint = "Install Claude Code: " + claudeInstallURL
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusError,
+		Message: fmt.Sprintf("%d agent CLI(s) not found", len(missing)),
+		Details: details,
+		FixHint: fixHint,
+	}
+}
+
+// agentCommandExists reports whether an agent command can be run.
+func agentCommandExists(cmd string) bool {
+	if filepath.IsAbs(cmd) {
+		info, err := os.Stat(cmd)
+		return err == nil && !info.IsDir()
+	}
+	_, err := exec.LookPath(cmd)
+	return err == nil
+}
@@ -0,0 +1,36 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestAgentBinaryCheck(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake agent script is POSIX-only")
+	}
+	townRoot := t.TempDir()
+	t.Setenv("HOME", t.TempDir()) // keep ~/.claude/local out of the lookup
+
+	binDir := t.TempDir()
+	t.Setenv("PATH", binDir)
+	check := NewAgentBinaryCheck()
+
+	result := check.Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusError {
+		t.Fatalf("without claude: status = %v (%s), want error", result.Status, result.Message)
+	}
+	if result.FixHint == "" {
+		t.Error("expected a fix hint when the agent CLI is missing")
+	}
+
+	if err := os.WriteFile(filepath.Join(binDir, "claude"), []byte("#!/bin/sh\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	result = check.Run(&CheckContext{TownRoot: townRoot})
+	if result.Status != StatusOK {
+		t.Errorf("with claude: status = %v (%s %v), want OK", result.Status, result.Message, result.Details)
+	}
+}
@@ -11,8 +11,11 @@ import (
 	"github.com/steveyegge/gastown/internal/beads"
 )
 
-// PrefixConflictCheck detects duplicate prefixes across rigs in routes.jsonl.
-// Duplicate prefixes break prefix-based routing.
+// PrefixConflictCheck detects beads prefixes shared by more than one rig,
+// whether in routes.jsonl or in the prefixes configured in rigs.json, a
+// prefix routed to more than one path, and routes that don't use their
+// rig's configured prefix. Duplicate prefixes break prefix-based routing
+// and let two rigs write beads with colliding IDs.
 type PrefixConflictCheck struct {
 	BaseCheck
 }
@@ -28,31 +31,18 @@ func NewPrefixConflictCheck() *PrefixConflictCheck {
 	}
 }
 
-// Run checks for duplicate prefixes in routes.jsonl.
+// Run checks that every rig's prefix is unique and matches rigs.json.
 func (c *PrefixConflictCheck) Run(ctx *CheckContext) *CheckResult {
-	beadsDir := filepath.Join(ctx.TownRoot, ".beads")
-
-	// Check if routes.jsonl exists
-	routesPath := filepath.Join(beadsDir, beads.RoutesFileName)
-	if _, err := os.Stat(routesPath); os.IsNotExist(err) {
-		return &CheckResult{
-			Name:    c.Name(),
-			Status:  StatusOK,
-			Message: "No routes.jsonl file (prefix routing not configured)",
-		}
-	}
-
-	// Find conflicts
-	conflicts, err := beads.FindConflictingPrefixes(beadsDir)
+	problems, err := beads.ValidateRigPrefixes(ctx.TownRoot)
 	if err != nil {
 		return &CheckResult{
 			Name:    c.Name(),
 			Status:  StatusWarning,
-			Message: fmt.Sprintf("Could not check routes.jsonl: %v", err),
+			Message: fmt.Sprintf("Could not check prefixes: %v", err),
 		}
 	}
 
-	if len(conflicts) == 0 {
+	if len(problems) == 0 {
 		return &CheckResult{
 			Name:    c.Name(),
 			Status:  StatusOK,
@@ -61,17 +51,19 @@ func (c *PrefixConflictCheck) Run(ctx *CheckContext) *CheckResult {
 	}
 
 	// Build details
+	prefixes := make(map[string]bool)
 	var details []string
-	for prefix, paths := range conflicts {
-		details = append(details, fmt.Sprintf("Prefix %q used by: %s", prefix, strings.Join(paths, ", ")))
+	for _, p := range problems {
+		prefixes[p.Prefix] = true
+		details = append(details, fmt.Sprintf("%s (%s): %s", p.Prefix, p.Path, p.Reason))
 	}
 
 	return &CheckResult{
 		Name:    c.Name(),
 		Status:  StatusError,
-		Message: fmt.Sprintf("%d prefix conflict(s) found in routes.jsonl", len(conflicts)),
+		Message: fmt.Sprintf("%d prefix conflict(s) found across rigs", len(prefixes)),
 		Details: details,
-		FixHint: "Use 'bd rename-prefix <new-prefix>' in one of the conflicting rigs to resolve",
+		FixHint: "Use 'bd rename-prefix <new-prefix>' in one of the conflicting rigs, then update rigs.json and routes.jsonl",
 	}
 }
 
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -552,3 +553,80 @@ func TestDatabasePrefixCheck_MixedOwnAndRedirect(t *testing.T) {
 		t.Errorf("expected mismatch for mission_manager, got %s", check.mismatches[0].rigPath)
 	}
 }
+
+func TestPrefixConflictCheck_ConfiguredPrefixCollision(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tmpDir, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// No routes at all: the collision is only visible in rigs.json.
+	rigsContent := `{
+		"version": 1,
+		"rigs": {
+			"gastown": {"git_url": "https://github.com/example/gastown", "beads": {"prefix": "gt"}},
+			"getresearch": {"git_url": "https://github.com/example/getresearch", "beads": {"prefix": "gt"}}
+		}
+	}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "mayor", "rigs.json"), []byte(rigsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewPrefixConflictCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusError {
+		t.Fatalf("expected StatusError, got %v: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 2 {
+		t.Errorf("expected one detail per rig, got %v", result.Details)
+	}
+}
+
+func TestPrefixConflictCheck_PrefixRoutedToTwoPaths(t *testing.T) {
+	tmpDir := t.TempDir()
+	townBeads := filepath.Join(tmpDir, ".beads")
+	if err := os.MkdirAll(townBeads, 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Same rig, two paths: not a cross-rig collision, but routing is ambiguous.
+	routesContent := `{"prefix":"hq-","path":"."}
+{"prefix":"gt-","path":"gastown"}
+{"prefix":"gt-","path":"gastown/mayor/rig"}`
+	if err := os.WriteFile(filepath.Join(townBeads, "routes.jsonl"), []byte(routesContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewPrefixConflictCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusError {
+		t.Fatalf("expected StatusError, got %v: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 2 || !strings.Contains(result.Details[0], "more than one path") {
+		t.Errorf("expected one detail per route naming both paths, got %v", result.Details)
+	}
+}
+
+func TestPrefixConflictCheck_RouteDoesNotMatchConfiguredPrefix(t *testing.T) {
+	tmpDir := t.TempDir()
+	townBeads := filepath.Join(tmpDir, ".beads")
+	for _, dir := range []string{townBeads, filepath.Join(tmpDir, "mayor")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	routesContent := `{"prefix":"hq-","path":"."}
+{"prefix":"gs-","path":"gastown/mayor/rig"}`
+	if err := os.WriteFile(filepath.Join(townBeads, "routes.jsonl"), []byte(routesContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rigsContent := `{"version": 1, "rigs": {"gastown": {"git_url": "https://github.com/example/gastown", "beads": {"prefix": "gt"}}}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "mayor", "rigs.json"), []byte(rigsContent), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	result := NewPrefixConflictCheck().Run(&CheckContext{TownRoot: tmpDir})
+	if result.Status != StatusError {
+		t.Fatalf("expected StatusError, got %v: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 1 || !strings.Contains(result.Details[0], "configured prefix gt-") {
+		t.Errorf("expected a mismatch against gt-, got %v", result.Details)
+	}
+}
@@ -90,7 +90,7 @@ func (c *BranchCheck) Run(ctx *CheckContext) *CheckResult {
 }
 
 // Fix switches all off-main directories to their expected branch.
-// Uses the rig's default_branch if configured, otherwise "main".
+// Uses the rig's default branch (see rig.ResolveDefaultBranch).
 func (c *BranchCheck) Fix(ctx *CheckContext) error {
 	if len(c.offMainDirs) == 0 {
 		return nil
@@ -187,7 +187,7 @@ func parseWorktreeConflict(output string) string {
 }
 
 // expectedBranch returns the branch a persistent role directory should be on.
-// Checks the rig's default_branch config, falling back to "main".
+// See rig.ResolveDefaultBranch.
 func (c *BranchCheck) expectedBranch(townRoot, dir string) string {
 	rel, err := filepath.Rel(townRoot, dir)
 	if err != nil {
@@ -197,12 +197,7 @@ func (c *BranchCheck) expectedBranch(townRoot, dir string) string {
 	if len(parts) < 1 {
 		return "main"
 	}
-	rigPath := filepath.Join(townRoot, parts[0])
-	cfg, err := rig.LoadRigConfig(rigPath)
-	if err != nil || cfg.DefaultBranch == "" {
-		return "main"
-	}
-	return cfg.DefaultBranch
+	return rig.ResolveDefaultBranch(filepath.Join(townRoot, parts[0]))
 }
 
 // isExpectedBranch checks if a directory is on the expected branch.
@@ -222,12 +217,7 @@ func (c *BranchCheck) isExpectedBranch(townRoot, dir, branch string) bool {
 	if len(parts) < 1 {
 		return false
 	}
-	rigPath := filepath.Join(townRoot, parts[0])
-	cfg, err := rig.LoadRigConfig(rigPath)
-	if err != nil || cfg.DefaultBranch == "" {
-		return false
-	}
-	return branch == cfg.DefaultBranch
+	return branch == rig.ResolveDefaultBranch(filepath.Join(townRoot, parts[0]))
 }
 
 // findPersistentRoleDirs finds infrastructure directories that should be on main:
@@ -333,7 +323,8 @@ type cloneInfo struct {
 	path     string
 	branch   string
 	headSHA  string
-	behindBy int // commits behind origin/main
+	base     string // the rig's default branch
+	behindBy int    // commits behind origin/<base>
 }
 
 // Run checks for significant divergence between clones.
@@ -350,7 +341,7 @@ func (c *CloneDivergenceCheck) Run(ctx *CheckContext) *CheckResult {
 	// Gather info about each clone
 	var infos []cloneInfo
 	for _, path := range clones {
-		info, err := c.getCloneInfo(path)
+		info, err := c.getCloneInfo(ctx.TownRoot, path)
 		if err != nil {
 			continue // Skip problematic clones
 		}
@@ -365,22 +356,22 @@ func (c *CloneDivergenceCheck) Run(ctx *CheckContext) *CheckResult {
 		}
 	}
 
-	// Check for clones significantly behind origin/main
+	// Check for clones significantly behind their rig's default branch
 	var warnings []string
 	var errors []string
 
 	for _, info := range infos {
 		relPath := c.relativePath(ctx.TownRoot, info.path)
 
-		// Only check clones on main branch (others are caught by BranchCheck)
-		if info.branch != "main" && info.branch != "master" {
+		// Only check clones on the default branch (others are caught by BranchCheck)
+		if info.branch != info.base {
 			continue
 		}
 
 		if info.behindBy > 50 {
-			errors = append(errors, fmt.Sprintf("%s: %d commits behind origin/main (EMERGENCY)", relPath, info.behindBy))
+			errors = append(errors, fmt.Sprintf("%s: %d commits behind origin/%s (EMERGENCY)", relPath, info.behindBy, info.base))
 		} else if info.behindBy > 10 {
-			warnings = append(warnings, fmt.Sprintf("%s: %d commits behind origin/main", relPath, info.behindBy))
+			warnings = append(warnings, fmt.Sprintf("%s: %d commits behind origin/%s", relPath, info.behindBy, info.base))
 		}
 	}
 
@@ -398,7 +389,7 @@ func (c *CloneDivergenceCheck) Run(ctx *CheckContext) *CheckResult {
 		return &CheckResult{
 			Name:    c.Name(),
 			Status:  StatusWarning,
-			Message: fmt.Sprintf("%d clone(s) behind origin/main", len(warnings)),
+			Message: fmt.Sprintf("%d clone(s) behind their default branch", len(warnings)),
 			Details: warnings,
 			FixHint: "Run 'git pull --rebase' in affected directories",
 		}
@@ -407,7 +398,7 @@ func (c *CloneDivergenceCheck) Run(ctx *CheckContext) *CheckResult {
 	return &CheckResult{
 		Name:    c.Name(),
 		Status:  StatusOK,
-		Message: fmt.Sprintf("All %d clones in sync with origin/main", len(infos)),
+		Message: fmt.Sprintf("All %d clones in sync with their default branch", len(infos)),
 	}
 }
 
@@ -489,8 +480,12 @@ func (c *CloneDivergenceCheck) isGitRepo(path string) bool {
 }
 
 // getCloneInfo gathers information about a clone.
-func (c *CloneDivergenceCheck) getCloneInfo(path string) (cloneInfo, error) {
-	info := cloneInfo{path: path}
+func (c *CloneDivergenceCheck) getCloneInfo(townRoot, path string) (cloneInfo, error) {
+	info := cloneInfo{path: path, base: "main"}
+	if rel, err := filepath.Rel(townRoot, path); err == nil {
+		rigName := strings.SplitN(filepath.ToSlash(rel), "/", 2)[0]
+		info.base = rig.ResolveDefaultBranch(filepath.Join(townRoot, rigName))
+	}
 
 	// Get current branch
 	cmd := exec.Command("git", "branch", "--show-current")
@@ -510,12 +505,12 @@ func (c *CloneDivergenceCheck) getCloneInfo(path string) (cloneInfo, error) {
 	}
 	info.headSHA = strings.TrimSpace(string(out))
 
-	// Count commits behind origin/main (uses existing refs, may be stale)
-	cmd = exec.Command("git", "rev-list", "--count", "HEAD..origin/main")
+	// Count commits behind origin/<base> (uses existing refs, may be stale)
+	cmd = exec.Command("git", "rev-list", "--count", "HEAD..origin/"+info.base)
 	cmd.Dir = path
 	out, err = cmd.Output()
 	if err != nil {
-		// origin/main might not exist, treat as 0 behind
+		// origin/<base> might not exist, treat as 0 behind
 		info.behindBy = 0
 		return info, nil
 	}
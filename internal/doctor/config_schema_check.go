@@ -0,0 +1,61 @@
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// ConfigSchemaCheck validates mayor/town.json, rigs.json, and accounts.json
+// against their schemas. Unknown fields are silently dropped when the files
+// load, so a typo'd key looks set but does nothing.
+type ConfigSchemaCheck struct {
+	BaseCheck
+}
+
+// NewConfigSchemaCheck creates a new config schema check.
+func NewConfigSchemaCheck() *ConfigSchemaCheck {
+	return &ConfigSchemaCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "config-schema",
+			CheckDescription: "Check town config files for unknown fields and type errors",
+			CheckCategory:    CategoryConfig,
+		},
+	}
+}
+
+// Run validates the town config files. Unknown fields are a warning; missing
+// required fields, type errors, and invalid JSON are errors.
+func (c *ConfigSchemaCheck) Run(ctx *CheckContext) *CheckResult {
+	issues, checked, err := config.ValidateTownSchemas(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("Could not validate config: %v", err),
+		}
+	}
+	if len(issues) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("%d config file(s) match their schemas", len(checked)),
+		}
+	}
+
+	status := StatusWarning
+	details := make([]string, len(issues))
+	for i, issue := range issues {
+		details[i] = issue.String()
+		if issue.Message != "unknown field" {
+			status = StatusError
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  status,
+		Message: fmt.Sprintf("Found %d config issue(s)", len(issues)),
+		Details: details,
+		FixHint: "Edit the files listed above; 'gt config validate' re-checks them",
+	}
+}
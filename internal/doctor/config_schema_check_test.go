@@ -0,0 +1,46 @@
+package doctor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeMayorFile(t *testing.T, townRoot, name, content string) {
+	t.Helper()
+	mayorDir := filepath.Join(townRoot, "mayor")
+	if err := os.MkdirAll(mayorDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(mayorDir, name), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestConfigSchemaCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		town    string
+		want    CheckStatus
+		details int
+	}{
+		{"valid", `{"type":"town","version":2,"name":"hq"}`, StatusOK, 0},
+		{"unknown field warns", `{"type":"town","version":2,"name":"hq","nmae":"x"}`, StatusWarning, 1},
+		{"type error fails", `{"type":"town","version":"2","name":"hq","nmae":"x"}`, StatusError, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			townRoot := t.TempDir()
+			writeMayorFile(t, townRoot, "town.json", tt.town)
+			writeMayorFile(t, townRoot, "rigs.json", `{"version":1,"rigs":{}}`)
+
+			result := NewConfigSchemaCheck().Run(&CheckContext{TownRoot: townRoot})
+			if result.Status != tt.want {
+				t.Errorf("status = %s, want %s (%s: %v)", result.Status, tt.want, result.Message, result.Details)
+			}
+			if len(result.Details) != tt.details {
+				t.Errorf("details = %v, want %d", result.Details, tt.details)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// DanglingWorktreeCheck finds worktrees registered in a rig's shared
+// .repo.git whose directories are gone, typically polecats removed by hand.
+// The stale registrations keep their branches checked out, so creating a
+// new polecat on the same branch fails.
+type DanglingWorktreeCheck struct {
+	FixableCheck
+	repos []string // .repo.git paths with prunable worktrees
+}
+
+// NewDanglingWorktreeCheck creates a new dangling worktree check.
+func NewDanglingWorktreeCheck() *DanglingWorktreeCheck {
+	return &DanglingWorktreeCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "dangling-worktrees",
+				CheckDescription: "Detect worktree registrations whose directories are gone",
+				CheckCategory:    CategoryCleanup,
+			},
+		},
+	}
+}
+
+// Run lists each rig's worktrees and collects the ones git marks prunable.
+func (c *DanglingWorktreeCheck) Run(ctx *CheckContext) *CheckResult {
+	c.repos = nil
+
+	entries, err := os.ReadDir(ctx.TownRoot)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("Cannot read town root: %v", err),
+		}
+	}
+
+	var details []string
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		if ctx.RigName != "" && entry.Name() != ctx.RigName {
+			continue
+		}
+		rigPath := filepath.Join(ctx.TownRoot, entry.Name())
+		repo := filepath.Join(rigPath, ".repo.git")
+		if !isRigDir(rigPath) {
+			continue
+		}
+		if _, err := os.Stat(repo); err != nil {
+			continue
+		}
+
+		out, err := exec.Command("git", "-C", repo, "worktree", "list", "--porcelain").Output()
+		if err != nil {
+			continue
+		}
+		dangling := parsePrunableWorktrees(string(out))
+		if len(dangling) == 0 {
+			continue
+		}
+		c.repos = append(c.repos, repo)
+		for _, wt := range dangling {
+			rel, err := filepath.Rel(ctx.TownRoot, wt)
+			if err != nil {
+				rel = wt
+			}
+			details = append(details, fmt.Sprintf("%s: %s", entry.Name(), rel))
+		}
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No dangling worktrees",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d dangling worktree(s)", len(details)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to prune them (git worktree prune)",
+	}
+}
+
+// Fix prunes the stale worktree registrations.
+func (c *DanglingWorktreeCheck) Fix(ctx *CheckContext) error {
+	var errs []string
+	for _, repo := range c.repos {
+		if out, err := exec.Command("git", "-C", repo, "worktree", "prune").CombinedOutput(); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %s", repo, strings.TrimSpace(string(out))))
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// parsePrunableWorktrees returns the paths of worktrees that
+// 'git worktree list --porcelain' marks prunable.
+func parsePrunableWorktrees(porcelain string) []string {
+	var out []string
+	var current string
+	for _, line := range strings.Split(porcelain, "\n") {
+		if path, ok := strings.CutPrefix(line, "worktree "); ok {
+			current = path
+			continue
+		}
+		if strings.HasPrefix(line, "prunable") && current != "" {
+			out = append(out, current)
+		}
+	}
+	return out
+}
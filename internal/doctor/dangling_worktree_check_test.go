@@ -0,0 +1,70 @@
+package doctor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestParsePrunableWorktrees(t *testing.T) {
+	porcelain := `worktree /town/gastown/.repo.git
+bare
+
+worktree /town/gastown/polecats/toast/gastown
+HEAD 1234
+branch refs/heads/polecat/toast
+prunable gitdir file points to non-existent location
+
+worktree /town/gastown/refinery/rig
+HEAD 5678
+branch refs/heads/main
+`
+	got := parsePrunableWorktrees(porcelain)
+	want := []string{"/town/gastown/polecats/toast/gastown"}
+	if !slices.Equal(got, want) {
+		t.Errorf("parsePrunableWorktrees = %v, want %v", got, want)
+	}
+}
+
+func TestDanglingWorktreeCheck_FindsAndPrunes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, "polecats"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "src")
+	runGit(t, townRoot, "init", "-q", "-b", "main", src)
+	runGit(t, src, "commit", "-q", "--allow-empty", "-m", "init")
+	repo := filepath.Join(rigPath, ".repo.git")
+	runGit(t, townRoot, "clone", "-q", "--bare", src, repo)
+
+	wt := filepath.Join(rigPath, "polecats", "toast")
+	runGit(t, repo, "worktree", "add", "-q", "-b", "polecat/toast", wt, "main")
+
+	check := NewDanglingWorktreeCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Fatalf("live worktree: status = %v (%s), want OK", result.Status, result.Message)
+	}
+
+	if err := os.RemoveAll(wt); err != nil {
+		t.Fatal(err)
+	}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning || len(result.Details) != 1 {
+		t.Fatalf("removed worktree: status = %v, details = %v; want one warning", result.Status, result.Details)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("after Fix: status = %v (%v), want OK", result.Status, result.Details)
+	}
+}
@@ -64,7 +64,7 @@ func (c *HooksSyncCheck) Run(ctx *CheckContext) *CheckResult {
 	for _, target := range targets {
 		totalTargets++
 
-		expected, err := hooks.ComputeExpected(target.Key)
+		expected, err := hooks.ComputeExpectedForTown(target.TownRoot, target.Key)
 		if err != nil {
 			details = append(details, fmt.Sprintf("%s: error computing expected: %v", target.DisplayKey(), err))
 			continue
@@ -201,7 +201,7 @@ func (c *HooksSyncCheck) Fix(ctx *CheckContext) error {
 
 	// Fix Claude targets via merge system.
 	for _, target := range c.outOfSync {
-		expected, err := hooks.ComputeExpected(target.Key)
+		expected, err := hooks.ComputeExpectedForTown(target.TownRoot, target.Key)
 		if err != nil {
 			errs = append(errs, fmt.Sprintf("%s: %v", target.DisplayKey(), err))
 			continue
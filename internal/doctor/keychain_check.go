@@ -0,0 +1,71 @@
+package doctor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/quota"
+)
+
+// KeychainCheck verifies on macOS that the login keychain is reachable and
+// holds Claude Code credentials. Agents started from tmux or launchd can't
+// answer a keychain unlock prompt, so a locked keychain shows up as agents
+// stuck at a login screen.
+type KeychainCheck struct {
+	BaseCheck
+}
+
+// NewKeychainCheck creates a new keychain access check.
+func NewKeychainCheck() *KeychainCheck {
+	return &KeychainCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "keychain",
+			CheckDescription: "Check macOS keychain access for agent credentials",
+			CheckCategory:    CategoryInfrastructure,
+		},
+	}
+}
+
+// Run looks up the Claude Code credential entry without reading the secret,
+// so it never triggers an access prompt.
+func (c *KeychainCheck) Run(ctx *CheckContext) *CheckResult {
+	if runtime.GOOS != "darwin" {
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: "Not applicable (macOS only)"}
+	}
+
+	configDir := os.Getenv("CLAUDE_CONFIG_DIR")
+	if configDir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return &CheckResult{Name: c.Name(), Status: StatusWarning, Message: "Cannot find home directory"}
+		}
+		configDir = filepath.Join(home, ".claude")
+	}
+	service := quota.KeychainServiceName(configDir)
+
+	out, err := exec.Command("security", "find-generic-password", "-s", service).CombinedOutput()
+	if err == nil {
+		return &CheckResult{Name: c.Name(), Status: StatusOK, Message: "Claude Code credentials found in keychain"}
+	}
+
+	detail := strings.TrimSpace(string(out))
+	if strings.Contains(detail, "could not be found") {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "No Claude Code credentials in keychain",
+			Details: []string{"Looked for keychain service " + service},
+			FixHint: "Run 'claude' once and log in, or ignore if you authenticate with an API key",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusError,
+		Message: "Cannot read the login keychain",
+		Details: []string{detail},
+		FixHint: "Unlock it with 'security unlock-keychain ~/Library/Keychains/login.keychain-db'",
+	}
+}
@@ -8,12 +8,15 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/beads"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/vcs"
 )
 
 // RigIsGitRepoCheck verifies the rig has a valid mayor/rig git clone.
@@ -579,23 +582,18 @@ func (c *RefineryExistsCheck) Fix(ctx *CheckContext) error {
 			return fmt.Errorf("cannot auto-create refinery/rig/ worktree: bare repo not found at %s", bareRepoPath)
 		}
 
+		fl, err := vcs.LockWorktrees(c.rigPath)
+		if err != nil {
+			return err
+		}
 		bareGit := git.NewGitWithDir(bareRepoPath, "")
 		_ = bareGit.WorktreePrune()
 
 		rigClone := filepath.Join(refineryDir, "rig")
-		// Detect default branch from rig config
-		rigCfgPath := filepath.Join(c.rigPath, "settings", "rig.json")
-		defaultBranch := "main"
-		if data, err := os.ReadFile(rigCfgPath); err == nil {
-			var cfg struct {
-				DefaultBranch string `json:"default_branch"`
-			}
-			if json.Unmarshal(data, &cfg) == nil && cfg.DefaultBranch != "" {
-				defaultBranch = cfg.DefaultBranch
-			}
-		}
-
-		if err := bareGit.WorktreeAddExisting(rigClone, defaultBranch); err != nil {
+		defaultBranch := rig.ResolveDefaultBranch(c.rigPath)
+		err = bareGit.WorktreeAddExisting(rigClone, defaultBranch)
+		_ = fl.Unlock()
+		if err != nil {
 			return fmt.Errorf("creating refinery worktree from bare repo: %w", err)
 		}
 
@@ -1337,6 +1335,12 @@ func (c *DefaultBranchAllRigsCheck) Run(ctx *CheckContext) *CheckResult {
 		}
 	}
 
+	// rigs.json's default_branch takes precedence over config.json's.
+	var registry map[string]config.RigEntry
+	if rigsCfg, err := config.LoadRigsConfig(filepath.Join(ctx.TownRoot, "mayor", "rigs.json")); err == nil {
+		registry = rigsCfg.Rigs
+	}
+
 	var errors []string
 	rigsChecked := 0
 
@@ -1360,6 +1364,9 @@ func (c *DefaultBranchAllRigsCheck) Run(ctx *CheckContext) *CheckResult {
 		if err := json.Unmarshal(data, &cfg); err != nil {
 			continue
 		}
+		if e, ok := registry[entry.Name()]; ok && e.DefaultBranch != "" {
+			cfg.DefaultBranch = e.DefaultBranch
+		}
 
 		if cfg.DefaultBranch == "" {
 			continue // Using default "main", skip
@@ -1386,7 +1393,7 @@ func (c *DefaultBranchAllRigsCheck) Run(ctx *CheckContext) *CheckResult {
 			Status:  StatusError,
 			Message: fmt.Sprintf("%d rig(s) with invalid default_branch", len(errors)),
 			Details: errors,
-			FixHint: "Fix the branch name in <rig>/config.json, or create the branch on the remote",
+			FixHint: "Fix default_branch in mayor/rigs.json (or <rig>/config.json), or create the branch on the remote",
 		}
 	}
 
@@ -1405,6 +1412,98 @@ func (c *DefaultBranchAllRigsCheck) Run(ctx *CheckContext) *CheckResult {
 	}
 }
 
+// DefaultBranchRecordedCheck verifies that every registered rig has its
+// default branch recorded in mayor/rigs.json. Rigs added before the field
+// existed fall back to detection on every lookup, and to "main" when that
+// fails; --fix records the branch from config.json or the rig's repos.
+type DefaultBranchRecordedCheck struct {
+	FixableCheck
+	detected map[string]string // rig name -> branch to record
+}
+
+// NewDefaultBranchRecordedCheck creates a new default branch recorded check.
+func NewDefaultBranchRecordedCheck() *DefaultBranchRecordedCheck {
+	return &DefaultBranchRecordedCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "default-branch-recorded",
+				CheckDescription: "Verify each rig's default_branch is recorded in rigs.json",
+				CheckCategory:    CategoryRig,
+			},
+		},
+	}
+}
+
+// Run finds registered rigs without a default_branch in rigs.json.
+func (c *DefaultBranchRecordedCheck) Run(ctx *CheckContext) *CheckResult {
+	rigsCfg, err := config.LoadRigsConfig(filepath.Join(ctx.TownRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No rigs registry (skipped)",
+		}
+	}
+
+	c.detected = make(map[string]string)
+	var details []string
+	for name, entry := range rigsCfg.Rigs {
+		if entry.DefaultBranch != "" {
+			continue
+		}
+		rigPath := filepath.Join(ctx.TownRoot, name)
+		branch := ""
+		if cfg, err := rig.LoadRigConfig(rigPath); err == nil && cfg.DefaultBranch != "" {
+			branch = cfg.DefaultBranch
+		} else {
+			branch = rig.DetectDefaultBranch(rigPath)
+		}
+		if branch == "" {
+			details = append(details, fmt.Sprintf("%s: default_branch not recorded and could not be detected", name))
+			continue
+		}
+		c.detected[name] = branch
+		details = append(details, fmt.Sprintf("%s: default_branch not recorded (will record %q)", name, branch))
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("All %d rig(s) have default_branch recorded", len(rigsCfg.Rigs)),
+		}
+	}
+	sort.Strings(details)
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d rig(s) without default_branch in rigs.json", len(details)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to record detected branches, or set default_branch in mayor/rigs.json",
+	}
+}
+
+// Fix records the detected default branches in rigs.json.
+func (c *DefaultBranchRecordedCheck) Fix(ctx *CheckContext) error {
+	if len(c.detected) == 0 {
+		return nil
+	}
+	rigsPath := filepath.Join(ctx.TownRoot, "mayor", "rigs.json")
+	rigsCfg, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		return fmt.Errorf("loading rigs registry: %w", err)
+	}
+	for name, branch := range c.detected {
+		entry, ok := rigsCfg.Rigs[name]
+		if !ok || entry.DefaultBranch != "" {
+			continue
+		}
+		entry.DefaultBranch = branch
+		rigsCfg.Rigs[name] = entry
+	}
+	return config.SaveRigsConfig(rigsPath, rigsCfg)
+}
+
 // BareRepoExistsCheck verifies that .repo.git exists when worktrees depend on it.
 // Worktrees (refinery/rig, polecats) created from the shared bare repo have .git files
 // pointing to .repo.git/worktrees/<name>. If .repo.git is missing (deleted, moved, or
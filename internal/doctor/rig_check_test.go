@@ -6,6 +6,8 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 func installMockBdInitOnly(t *testing.T) {
@@ -511,6 +513,48 @@ func TestDefaultBranchExistsCheck_NotFixable(t *testing.T) {
 	}
 }
 
+func TestDefaultBranchRecordedCheck_RecordsFromConfig(t *testing.T) {
+	townRoot := t.TempDir()
+	rigsPath := filepath.Join(townRoot, "mayor", "rigs.json")
+	rigsCfg := &config.RigsConfig{Version: config.CurrentRigsVersion, Rigs: map[string]config.RigEntry{
+		"legacy":  {},
+		"current": {DefaultBranch: "main"},
+	}}
+	if err := config.SaveRigsConfig(rigsPath, rigsCfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(townRoot, "legacy"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "legacy", "config.json"), []byte(`{"type":"rig","name":"legacy","default_branch":"master"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewDefaultBranchRecordedCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning {
+		t.Fatalf("expected StatusWarning, got %v: %s", result.Status, result.Message)
+	}
+	if len(result.Details) != 1 || !strings.Contains(result.Details[0], `"master"`) {
+		t.Errorf("details = %v, want one entry recording master", result.Details)
+	}
+
+	if err := check.Fix(ctx); err != nil {
+		t.Fatalf("Fix: %v", err)
+	}
+	got, err := config.LoadRigsConfig(rigsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b := got.Rigs["legacy"].DefaultBranch; b != "master" {
+		t.Errorf("legacy default_branch = %q, want master", b)
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("after fix: expected StatusOK, got %v: %v", result.Status, result.Details)
+	}
+}
+
 func TestBeadsRedirectCheck_FixConflictingLocalBeads(t *testing.T) {
 	tmpDir := t.TempDir()
 	rigName := "testrig"
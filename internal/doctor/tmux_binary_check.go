@@ -0,0 +1,82 @@
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/deps"
+)
+
+// TmuxBinaryCheck verifies that tmux is installed and meets the minimum
+// version. Every agent runs in a tmux session, so without tmux nothing but
+// the CLI works.
+type TmuxBinaryCheck struct {
+	BaseCheck
+}
+
+// NewTmuxBinaryCheck creates a new tmux availability check.
+func NewTmuxBinaryCheck() *TmuxBinaryCheck {
+	return &TmuxBinaryCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "tmux-binary",
+			CheckDescription: "Check that tmux is installed and meets minimum version",
+			CheckCategory:    CategoryInfrastructure,
+		},
+	}
+}
+
+// Run checks if tmux is available in PATH and reports its version status.
+func (c *TmuxBinaryCheck) Run(ctx *CheckContext) *CheckResult {
+	status, version, detail := deps.CheckTmux()
+
+	switch status {
+	case deps.TmuxOK:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("tmux %s", version),
+		}
+
+	case deps.TmuxNotFound:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: "tmux not found in PATH",
+			Details: []string{
+				"Agents run in tmux sessions; gt start and gt sling cannot launch them without it",
+			},
+			FixHint: "Install tmux: " + deps.TmuxInstallHint,
+		}
+
+	case deps.TmuxTooOld:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("tmux %s is older than %s", version, deps.MinTmuxVersion),
+			Details: []string{
+				"Older tmux releases lack options Gas Town sets on agent sessions",
+			},
+			FixHint: "Upgrade tmux: " + deps.TmuxInstallHint,
+		}
+
+	case deps.TmuxExecFailed:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusError,
+			Message: fmt.Sprintf("tmux found but 'tmux -V' failed: %s", detail),
+			FixHint: "Reinstall tmux: " + deps.TmuxInstallHint,
+		}
+
+	case deps.TmuxUnknown:
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: fmt.Sprintf("tmux found but version could not be parsed: %s", detail),
+		}
+	}
+
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: "unexpected tmux check status",
+	}
+}
@@ -0,0 +1,440 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gofrs/flock"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/workspace"
+)
+
+// rigLayoutDirs are the plain directories every rig needs. They hold no
+// state of their own, so a missing one can simply be recreated.
+var rigLayoutDirs = []string{"witness", "polecats", "crew"}
+
+// rigLayoutClones are rig paths that are git checkouts. A missing one
+// needs the rig checks (gt doctor --rig <rig> --fix) or a re-add.
+var rigLayoutClones = []string{
+	filepath.Join("mayor", "rig"),
+	filepath.Join("refinery", "rig"),
+}
+
+// registeredRigNames returns the rigs in mayor/rigs.json whose directories
+// exist, sorted. Missing rig directories are reported by rigs-registry-valid.
+func registeredRigNames(ctx *CheckContext) ([]string, error) {
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(ctx.TownRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for name := range rigsConfig.Rigs {
+		if ctx.RigName != "" && name != ctx.RigName {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(ctx.TownRoot, name)); err == nil && info.IsDir() {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// TownLocationCheck detects a town directory that was renamed or moved.
+// Git worktree links, crew state and daemon state store absolute paths,
+// which all still point at the old location after a plain mv.
+type TownLocationCheck struct {
+	FixableCheck
+	oldRoot string
+}
+
+// NewTownLocationCheck creates a new town location check.
+func NewTownLocationCheck() *TownLocationCheck {
+	return &TownLocationCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "town-location",
+				CheckDescription: "Detect a moved town whose stored paths are stale",
+				CheckCategory:    CategoryCore,
+			},
+		},
+	}
+}
+
+// Run compares the recorded (or inferred) town location with the current root.
+func (c *TownLocationCheck) Run(ctx *CheckContext) *CheckResult {
+	c.oldRoot = workspace.PreviousLocation(ctx.TownRoot)
+	if c.oldRoot == "" {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "Stored paths match the town location",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusError,
+		Message: fmt.Sprintf("Town was moved from %s", c.oldRoot),
+		Details: []string{"Worktree links, crew state and daemon state still reference the old path"},
+		FixHint: "Run 'gt doctor --fix' or 'gt workspace move --from " + c.oldRoot + "' to rewrite them",
+	}
+}
+
+// Fix rewrites stored paths from the old location to the current one.
+func (c *TownLocationCheck) Fix(ctx *CheckContext) error {
+	if c.oldRoot == "" {
+		return nil
+	}
+	_, err := workspace.Relocate(c.oldRoot, ctx.TownRoot)
+	return err
+}
+
+// RigLayoutCheck verifies that every registered rig has the expected
+// directory layout: config.json, mayor/rig, refinery/rig, witness/,
+// polecats/ and crew/.
+type RigLayoutCheck struct {
+	FixableCheck
+	missingDirs []string // absolute paths of plain dirs to recreate
+}
+
+// NewRigLayoutCheck creates a new rig layout check.
+func NewRigLayoutCheck() *RigLayoutCheck {
+	return &RigLayoutCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "rig-layout",
+				CheckDescription: "Verify every registered rig has its directories",
+				CheckCategory:    CategoryCore,
+			},
+		},
+	}
+}
+
+// Run checks each registered rig for missing files and directories.
+func (c *RigLayoutCheck) Run(ctx *CheckContext) *CheckResult {
+	c.missingDirs = nil
+
+	rigs, err := registeredRigNames(ctx)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Could not load rigs registry",
+			Details: []string{err.Error()},
+		}
+	}
+
+	var details []string
+	unfixable := false
+	for _, rigName := range rigs {
+		rigPath := filepath.Join(ctx.TownRoot, rigName)
+		if _, err := os.Stat(filepath.Join(rigPath, "config.json")); os.IsNotExist(err) {
+			details = append(details, fmt.Sprintf("%s: missing config.json", rigName))
+			unfixable = true
+		}
+		for _, rel := range rigLayoutClones {
+			if _, err := os.Stat(filepath.Join(rigPath, rel)); os.IsNotExist(err) {
+				details = append(details, fmt.Sprintf("%s: missing %s/", rigName, rel))
+				unfixable = true
+			}
+		}
+		for _, rel := range rigLayoutDirs {
+			path := filepath.Join(rigPath, rel)
+			if _, err := os.Stat(path); os.IsNotExist(err) {
+				c.missingDirs = append(c.missingDirs, path)
+				details = append(details, fmt.Sprintf("%s: missing %s/ (fixable)", rigName, rel))
+			}
+		}
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("All %d rig(s) have the expected layout", len(rigs)),
+		}
+	}
+
+	hint := "Run 'gt doctor --fix' to recreate missing directories"
+	if unfixable {
+		hint += "; for missing clones or config run 'gt doctor --rig <rig> --fix'"
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusError,
+		Message: fmt.Sprintf("%d layout problem(s) across registered rigs", len(details)),
+		Details: details,
+		FixHint: hint,
+	}
+}
+
+// Fix recreates the missing plain directories.
+func (c *RigLayoutCheck) Fix(ctx *CheckContext) error {
+	for _, dir := range c.missingDirs {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("creating %s: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// PolecatWorktreeCheck verifies that every directory under a rig's
+// polecats/ is a worktree registered with the rig's repo base (.repo.git,
+// or mayor/rig on older rigs). An unregistered directory is usually a
+// polecat copied or restored by hand; git and the polecat manager cannot
+// see it, so it never gets cleaned up.
+type PolecatWorktreeCheck struct {
+	BaseCheck
+}
+
+// NewPolecatWorktreeCheck creates a new polecat worktree registration check.
+func NewPolecatWorktreeCheck() *PolecatWorktreeCheck {
+	return &PolecatWorktreeCheck{
+		BaseCheck: BaseCheck{
+			CheckName:        "polecat-worktrees",
+			CheckDescription: "Verify every polecat directory is a registered worktree",
+			CheckCategory:    CategoryRig,
+		},
+	}
+}
+
+// Run compares each rig's polecat directories with its worktree list.
+func (c *PolecatWorktreeCheck) Run(ctx *CheckContext) *CheckResult {
+	rigs, err := registeredRigNames(ctx)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Could not load rigs registry",
+			Details: []string{err.Error()},
+		}
+	}
+
+	var details []string
+	checked := 0
+	for _, rigName := range rigs {
+		rigPath := filepath.Join(ctx.TownRoot, rigName)
+		entries, err := os.ReadDir(filepath.Join(rigPath, "polecats"))
+		if err != nil {
+			continue
+		}
+		var names []string
+		for _, entry := range entries {
+			name := entry.Name()
+			if !entry.IsDir() || strings.HasPrefix(name, ".") {
+				continue
+			}
+			// A .pending marker means the polecat is being created right now.
+			if _, err := os.Stat(filepath.Join(rigPath, "polecats", name+".pending")); err == nil {
+				continue
+			}
+			names = append(names, name)
+		}
+		if len(names) == 0 {
+			continue
+		}
+
+		repo := polecatRepoBase(rigPath)
+		if repo == "" {
+			details = append(details, fmt.Sprintf("%s: no .repo.git or mayor/rig to register polecats with", rigName))
+			continue
+		}
+		out, err := exec.Command("git", "-C", repo, "worktree", "list", "--porcelain").Output()
+		if err != nil {
+			details = append(details, fmt.Sprintf("%s: cannot list worktrees: %v", rigName, err))
+			continue
+		}
+		registered := make(map[string]bool)
+		for _, wt := range parseWorktreePaths(string(out)) {
+			registered[canonicalPath(wt)] = true
+		}
+
+		for _, name := range names {
+			checked++
+			newPath := filepath.Join(rigPath, "polecats", name, rigName)
+			oldPath := filepath.Join(rigPath, "polecats", name)
+			if !registered[canonicalPath(newPath)] && !registered[canonicalPath(oldPath)] {
+				details = append(details, fmt.Sprintf("%s/%s: not a registered worktree", rigName, name))
+			}
+		}
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: fmt.Sprintf("All %d polecat dir(s) are registered worktrees", checked),
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d polecat worktree problem(s)", len(details)),
+		Details: details,
+		FixHint: "Inspect for unsaved work, then remove with 'gt polecat nuke <rig>/<name>' or rm -rf",
+	}
+}
+
+// polecatRepoBase returns the repo polecat worktrees are added from,
+// matching polecat.Manager: .repo.git, else mayor/rig. Empty if neither exists.
+func polecatRepoBase(rigPath string) string {
+	if info, err := os.Stat(filepath.Join(rigPath, ".repo.git")); err == nil && info.IsDir() {
+		return filepath.Join(rigPath, ".repo.git")
+	}
+	if _, err := os.Stat(filepath.Join(rigPath, "mayor", "rig")); err == nil {
+		return filepath.Join(rigPath, "mayor", "rig")
+	}
+	return ""
+}
+
+// parseWorktreePaths returns every worktree path in
+// 'git worktree list --porcelain' output.
+func parseWorktreePaths(porcelain string) []string {
+	var out []string
+	for _, line := range strings.Split(porcelain, "\n") {
+		if path, ok := strings.CutPrefix(line, "worktree "); ok {
+			out = append(out, path)
+		}
+	}
+	return out
+}
+
+// canonicalPath resolves symlinks so paths from git compare equal to ones
+// built from the town root (e.g. /tmp vs /private/tmp on macOS).
+func canonicalPath(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return filepath.Clean(path)
+}
+
+// OrphanStateFilesCheck finds per-worker lock files in <rig>/.runtime/locks
+// whose polecat or crew directory no longer exists. They are left behind
+// when workers are removed by hand rather than with gt polecat nuke or
+// gt crew remove.
+type OrphanStateFilesCheck struct {
+	FixableCheck
+	orphans []string
+}
+
+// NewOrphanStateFilesCheck creates a new orphaned state files check.
+func NewOrphanStateFilesCheck() *OrphanStateFilesCheck {
+	return &OrphanStateFilesCheck{
+		FixableCheck: FixableCheck{
+			BaseCheck: BaseCheck{
+				CheckName:        "orphan-state-files",
+				CheckDescription: "Detect worker state files for polecats and crew that no longer exist",
+				CheckCategory:    CategoryCleanup,
+			},
+		},
+	}
+}
+
+// Run scans each rig's lock directory for files naming missing workers.
+func (c *OrphanStateFilesCheck) Run(ctx *CheckContext) *CheckResult {
+	c.orphans = nil
+
+	rigs, err := registeredRigNames(ctx)
+	if err != nil {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusWarning,
+			Message: "Could not load rigs registry",
+			Details: []string{err.Error()},
+		}
+	}
+
+	var details []string
+	for _, rigName := range rigs {
+		rigPath := filepath.Join(ctx.TownRoot, rigName)
+		locksDir := filepath.Join(rigPath, ".runtime", "locks")
+		entries, err := os.ReadDir(locksDir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() {
+				continue
+			}
+			workerDir := lockWorkerDir(entry.Name())
+			if workerDir == "" {
+				continue
+			}
+			if _, err := os.Stat(filepath.Join(rigPath, workerDir)); os.IsNotExist(err) {
+				c.orphans = append(c.orphans, filepath.Join(locksDir, entry.Name()))
+				details = append(details, fmt.Sprintf("%s: %s (no %s/)", rigName, entry.Name(), workerDir))
+			}
+		}
+	}
+
+	if len(details) == 0 {
+		return &CheckResult{
+			Name:    c.Name(),
+			Status:  StatusOK,
+			Message: "No orphaned state files",
+		}
+	}
+	return &CheckResult{
+		Name:    c.Name(),
+		Status:  StatusWarning,
+		Message: fmt.Sprintf("%d orphaned state file(s)", len(details)),
+		Details: details,
+		FixHint: "Run 'gt doctor --fix' to remove them",
+	}
+}
+
+// Fix removes orphaned lock files that nobody currently holds. A held lock
+// means a gt process is creating that worker right now.
+func (c *OrphanStateFilesCheck) Fix(ctx *CheckContext) error {
+	var errs []string
+	for _, path := range c.orphans {
+		fl := flock.New(path)
+		locked, err := fl.TryLock()
+		if err != nil || !locked {
+			continue
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			errs = append(errs, err.Error())
+		}
+		_ = fl.Unlock()
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// lockWorkerDir maps a worker lock file name to the rig-relative directory
+// it guards: polecat-<name>.lock → polecats/<name>, crew-<name>.lock →
+// crew/<name>. Returns "" for other locks (e.g. polecat-pool.lock).
+func lockWorkerDir(lockName string) string {
+	base, ok := strings.CutSuffix(lockName, ".lock")
+	if !ok {
+		return ""
+	}
+	if name, ok := strings.CutPrefix(base, "polecat-"); ok && name != "" && name != "pool" {
+		return filepath.Join("polecats", name)
+	}
+	if name, ok := strings.CutPrefix(base, "crew-"); ok && name != "" {
+		return filepath.Join("crew", name)
+	}
+	return ""
+}
+
+// WorkspaceLayoutChecks returns the structural checks run by
+// 'gt workspace doctor' on top of WorkspaceChecks.
+func WorkspaceLayoutChecks() []Check {
+	return []Check{
+		NewTownLocationCheck(),
+		NewRigLayoutCheck(),
+		NewPolecatWorktreeCheck(),
+		NewDanglingWorktreeCheck(),
+		NewWorktreeGitdirCheck(),
+		NewStaleRuntimeFilesCheck(),
+		NewOrphanStateFilesCheck(),
+	}
+}
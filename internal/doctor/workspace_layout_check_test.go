@@ -0,0 +1,124 @@
+package doctor
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func setupLayoutTown(t *testing.T, rigs ...string) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	entries := make(map[string]config.RigEntry)
+	for _, name := range rigs {
+		entries[name] = config.RigEntry{GitURL: "https://example.com/" + name + ".git"}
+	}
+	if err := config.SaveRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"), &config.RigsConfig{Version: 1, Rigs: entries}); err != nil {
+		t.Fatal(err)
+	}
+	return townRoot
+}
+
+func TestRigLayoutCheck_FixesMissingDirs(t *testing.T) {
+	townRoot := setupLayoutTown(t, "gastown", "gone")
+	rigPath := filepath.Join(townRoot, "gastown")
+	for _, dir := range []string{"mayor/rig", "refinery/rig", "witness"} {
+		if err := os.MkdirAll(filepath.Join(rigPath, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	check := NewRigLayoutCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	// "gone" has no directory at all; that is rigs-registry-valid's job.
+	if result.Status != StatusError || len(result.Details) != 2 {
+		t.Fatalf("Run = %v %v, want polecats/ and crew/ missing", result.Status, result.Details)
+	}
+	if err := check.Fix(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Errorf("after fix: %v %v", result.Status, result.Details)
+	}
+
+	if err := os.RemoveAll(filepath.Join(rigPath, "refinery")); err != nil {
+		t.Fatal(err)
+	}
+	result = check.Run(ctx)
+	if result.Status != StatusError || len(check.missingDirs) != 0 {
+		t.Errorf("missing refinery/rig should be reported but not auto-fixed: %v, %v", result.Details, check.missingDirs)
+	}
+}
+
+func TestPolecatWorktreeCheck(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	townRoot := setupLayoutTown(t, "gastown")
+	rigPath := filepath.Join(townRoot, "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, "polecats", ".claude"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "src")
+	runGit(t, townRoot, "init", "-q", "-b", "main", src)
+	runGit(t, src, "commit", "-q", "--allow-empty", "-m", "init")
+	repo := filepath.Join(rigPath, ".repo.git")
+	runGit(t, townRoot, "clone", "-q", "--bare", src, repo)
+	runGit(t, repo, "worktree", "add", "-q", "-b", "polecat/toast", filepath.Join(rigPath, "polecats", "toast", "gastown"), "main")
+
+	check := NewPolecatWorktreeCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	if result := check.Run(ctx); result.Status != StatusOK {
+		t.Fatalf("registered worktree: %v %v", result.Status, result.Details)
+	}
+
+	if err := os.MkdirAll(filepath.Join(rigPath, "polecats", "stray", "gastown"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning || len(result.Details) != 1 || result.Details[0] != "gastown/stray: not a registered worktree" {
+		t.Errorf("Run = %v %v, want stray reported", result.Status, result.Details)
+	}
+}
+
+func TestOrphanStateFilesCheck(t *testing.T) {
+	townRoot := setupLayoutTown(t, "gastown")
+	rigPath := filepath.Join(townRoot, "gastown")
+	locks := filepath.Join(rigPath, ".runtime", "locks")
+	for _, dir := range []string{locks, filepath.Join(rigPath, "polecats", "toast"), filepath.Join(rigPath, "crew", "max")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"polecat-toast.lock", "polecat-nux.lock", "polecat-pool.lock", "crew-max.lock", "crew-joe.lock"} {
+		if err := os.WriteFile(filepath.Join(locks, name), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	check := NewOrphanStateFilesCheck()
+	ctx := &CheckContext{TownRoot: townRoot}
+	result := check.Run(ctx)
+	if result.Status != StatusWarning || len(result.Details) != 2 {
+		t.Fatalf("Run = %v %v, want nux and joe locks", result.Status, result.Details)
+	}
+	if err := check.Fix(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]bool{"polecat-toast.lock": true, "polecat-pool.lock": true, "crew-max.lock": true, "polecat-nux.lock": false, "crew-joe.lock": false} {
+		if _, err := os.Stat(filepath.Join(locks, name)); (err == nil) != want {
+			t.Errorf("%s exists = %v, want %v", name, err == nil, want)
+		}
+	}
+}
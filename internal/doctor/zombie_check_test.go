@@ -65,6 +65,9 @@ func TestZombieSessionCheck_FixProtectsCrewSessions(t *testing.T) {
 	}
 
 	ctx := &CheckContext{TownRoot: t.TempDir()}
+	// Fix logs session_death events to the town found from cwd; keep them
+	// out of the source tree.
+	t.Chdir(ctx.TownRoot)
 
 	// Fix should skip crew sessions due to safeguard
 	// (We can't fully test this without mocking tmux, but the safeguard is in place)
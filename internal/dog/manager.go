@@ -16,6 +16,7 @@ import (
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/vcs"
 )
 
 // Common errors
@@ -177,11 +178,7 @@ func (m *Manager) createRigWorktree(dogPath, dogName, rigName string) (string, e
 
 	// Determine the start point for the new worktree
 	// Use origin/<default-branch> to ensure we start from the rig's configured branch
-	defaultBranch := "main"
-	if rigCfg, err := rig.LoadRigConfig(rigPath); err == nil && rigCfg.DefaultBranch != "" {
-		defaultBranch = rigCfg.DefaultBranch
-	}
-	startPoint := fmt.Sprintf("origin/%s", defaultBranch)
+	startPoint := fmt.Sprintf("origin/%s", rig.ResolveDefaultBranch(rigPath))
 
 	// Unique branch per dog-rig combination
 	branchName := fmt.Sprintf("dog/%s-%s-%d", dogName, rigName, time.Now().UnixMilli())
@@ -211,6 +208,16 @@ func (m *Manager) findRepoBase(rigPath string) (*git.Git, error) {
 	return git.NewGit(mayorPath), nil
 }
 
+// removeWorktree runs fn, which removes or prunes worktrees in the rig at
+// rigPath, under the rig's worktree lock. Cleanup proceeds unlocked if the
+// lock can't be taken.
+func removeWorktree(rigPath string, fn func()) {
+	if fl, err := vcs.LockWorktrees(rigPath); err == nil {
+		defer func() { _ = fl.Unlock() }()
+	}
+	fn()
+}
+
 // Remove deletes a dog from the kennel.
 // Removes all worktrees and the dog directory.
 func (m *Manager) Remove(name string) error {
@@ -240,14 +247,16 @@ func (m *Manager) Remove(name string) error {
 			continue
 		}
 
-		// Try to remove worktree properly
-		if err := repoGit.WorktreeRemove(worktreePath, true); err != nil {
-			// Log but continue - will remove directory below
-			style.PrintWarning("could not remove worktree %s: %v", worktreePath, err)
-		}
+		removeWorktree(rigPath, func() {
+			// Try to remove worktree properly
+			if err := repoGit.WorktreeRemove(worktreePath, true); err != nil {
+				// Log but continue - will remove directory below
+				style.PrintWarning("could not remove worktree %s: %v", worktreePath, err)
+			}
 
-		// Prune stale entries
-		_ = repoGit.WorktreePrune()
+			// Prune stale entries
+			_ = repoGit.WorktreePrune()
+		})
 	}
 
 	// Remove dog directory
@@ -449,9 +458,11 @@ func (m *Manager) Refresh(name string) error {
 
 		// Remove old worktree if it exists
 		if oldWorktreePath != "" {
-			_ = repoGit.WorktreeRemove(oldWorktreePath, true)
-			_ = os.RemoveAll(oldWorktreePath)
-			_ = repoGit.WorktreePrune()
+			removeWorktree(rigPath, func() {
+				_ = repoGit.WorktreeRemove(oldWorktreePath, true)
+				_ = os.RemoveAll(oldWorktreePath)
+				_ = repoGit.WorktreePrune()
+			})
 		}
 
 		// Fetch latest from origin
@@ -523,9 +534,11 @@ func (m *Manager) RefreshRig(name, rigName string) error {
 
 	// Remove old worktree if it exists
 	if oldWorktreePath != "" {
-		_ = repoGit.WorktreeRemove(oldWorktreePath, true)
-		_ = os.RemoveAll(oldWorktreePath)
-		_ = repoGit.WorktreePrune()
+		removeWorktree(rigPath, func() {
+			_ = repoGit.WorktreeRemove(oldWorktreePath, true)
+			_ = os.RemoveAll(oldWorktreePath)
+			_ = repoGit.WorktreePrune()
+		})
 	}
 
 	// Fetch latest
@@ -168,8 +168,8 @@ func (m *SessionManager) Stop(dogName string, force bool) error {
 		session.WaitForSessionExit(m.tmux, sessionID, constants.GracefulShutdownTimeout)
 	}
 
-	if err := m.tmux.KillSessionWithProcesses(sessionID); err != nil {
-		return fmt.Errorf("killing session: %w", err)
+	if err := session.TerminateSession(m.tmux, m.townRoot, sessionID); err != nil {
+		return err
 	}
 
 	// Update persistent state to idle so dog is available for reassignment
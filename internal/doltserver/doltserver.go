@@ -2541,6 +2541,16 @@ func FindOrphanedDatabases(townRoot string) ([]OrphanedDatabase, error) {
 	return orphans, nil
 }
 
+// RigDatabase returns the Dolt database a rig's beads live in ("hq" for the
+// town), or "" if its metadata.json doesn't name one.
+func RigDatabase(townRoot, rigName string) string {
+	beadsDir := FindRigBeadsDir(townRoot, rigName)
+	if beadsDir == "" {
+		return ""
+	}
+	return readExistingDoltDatabase(beadsDir)
+}
+
 // readExistingDoltDatabase reads the dolt_database field from an existing metadata.json.
 // Returns empty string if the file doesn't exist or can't be read.
 func readExistingDoltDatabase(beadsDir string) string {
@@ -3,7 +3,9 @@ package doltserver
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -24,8 +26,19 @@ type SyncOptions struct {
 
 	// Filter restricts sync to a single database name. Empty means all.
 	Filter string
+
+	// Resolve settles merge conflicts with the remote by taking one side
+	// (ResolveTheirs or ResolveOurs). Empty aborts the merge and reports the
+	// conflict instead.
+	Resolve string
 }
 
+// Conflict resolution strategies for SyncOptions.Resolve.
+const (
+	ResolveTheirs = "theirs"
+	ResolveOurs   = "ours"
+)
+
 // SyncResult records the outcome of syncing a single database.
 type SyncResult struct {
 	// Database is the rig database name.
@@ -45,6 +58,17 @@ type SyncResult struct {
 
 	// Remote is the origin push URL, or empty if none configured.
 	Remote string
+
+	// Pulled is true if the remote had diverged and its changes were merged
+	// in before pushing.
+	Pulled bool
+
+	// Resolved is the strategy used to settle merge conflicts, or empty if
+	// there were none.
+	Resolved string
+
+	// Retries counts attempts repeated after transient failures.
+	Retries int
 }
 
 // FindRemote returns the name and URL of the first configured remote in a Dolt database.
@@ -404,6 +428,18 @@ func FindRemoteSQL(townRoot, db string) (name, url string, err error) {
 // SyncDatabases iterates all databases (or a filtered subset), checks for remotes,
 // commits working changes, and pushes to origin. Never fails fast — collects all results.
 func SyncDatabases(townRoot string, opts SyncOptions) []SyncResult {
+	return syncEach(townRoot, opts, SyncDatabase)
+}
+
+// SyncDatabasesSQL iterates all databases (or a filtered subset) and pushes via SQL
+// through the running Dolt server. Unlike SyncDatabases, this does NOT require
+// stopping the server, so it won't crash running agents.
+func SyncDatabasesSQL(townRoot string, opts SyncOptions) []SyncResult {
+	return syncEach(townRoot, opts, SyncDatabaseSQL)
+}
+
+// syncEach runs syncOne for every database that passes opts.Filter, in order.
+func syncEach(townRoot string, opts SyncOptions, syncOne func(townRoot, db string, opts SyncOptions) SyncResult) []SyncResult {
 	databases, err := ListDatabases(townRoot)
 	if err != nil {
 		return []SyncResult{{
@@ -413,171 +449,477 @@ func SyncDatabases(townRoot string, opts SyncOptions) []SyncResult {
 	}
 
 	var results []SyncResult
-
 	for _, db := range databases {
-		// Apply filter if set
 		if opts.Filter != "" && db != opts.Filter {
 			continue
 		}
+		results = append(results, syncOne(townRoot, db, opts))
+	}
+	return results
+}
 
-		dbDir := RigDatabaseDir(townRoot, db)
-		result := SyncResult{Database: db}
-
-		// Skip databases with a .no-sync marker file (local-only databases),
-		// unless explicitly requested via Filter (--db flag).
-		if opts.Filter == "" {
-			if _, err := os.Stat(filepath.Join(dbDir, ".no-sync")); err == nil {
-				result.Skipped = true
-				results = append(results, result)
-				continue
-			}
+// SyncDatabase commits one database's working changes and pushes it to its
+// remote via the CLI. The server must not be running. Databases in
+// different directories can be synced concurrently.
+func SyncDatabase(townRoot, db string, opts SyncOptions) SyncResult {
+	dbDir := RigDatabaseDir(townRoot, db)
+	result := SyncResult{Database: db}
+
+	// Skip databases with a .no-sync marker file (local-only databases),
+	// unless explicitly requested via Filter (--db flag).
+	if opts.Filter == "" {
+		if _, err := os.Stat(filepath.Join(dbDir, ".no-sync")); err == nil {
+			result.Skipped = true
+			return result
 		}
+	}
 
-		// Check for remote (any name — "origin", "github", etc.)
-		remoteName, remoteURL, err := FindRemote(dbDir)
-		if err != nil {
-			result.Error = fmt.Errorf("checking remote: %w", err)
-			results = append(results, result)
-			continue
+	// Check for remote (any name — "origin", "github", etc.)
+	remoteName, remoteURL, err := FindRemote(dbDir)
+	if err != nil {
+		result.Error = fmt.Errorf("checking remote: %w", err)
+		return result
+	}
+	result.Remote = remoteURL
+
+	if remoteURL == "" {
+		// Auto-setup DoltHub remote if credentials are available.
+		token := DoltHubToken()
+		org := DoltHubOrg()
+		if token == "" || org == "" {
+			result.Skipped = true
+			return result
+		}
+		if err := SetupDoltHubRemote(dbDir, org, db, token); err != nil {
+			// Setup failed — skip this database for now.
+			result.Error = fmt.Errorf("auto-setup DoltHub remote: %w", err)
+			return result
+		}
+		// Remote is now configured; re-read it.
+		remoteName, remoteURL, err = FindRemote(dbDir)
+		if err != nil || remoteURL == "" {
+			result.Error = fmt.Errorf("remote not found after auto-setup")
+			return result
 		}
 		result.Remote = remoteURL
+	}
 
-		if remoteURL == "" {
-			// Auto-setup DoltHub remote if credentials are available.
-			token := DoltHubToken()
-			org := DoltHubOrg()
-			if token != "" && org != "" {
-				if err := SetupDoltHubRemote(dbDir, org, db, token); err != nil {
-					// Setup failed — skip this database for now.
-					result.Error = fmt.Errorf("auto-setup DoltHub remote: %w", err)
-					results = append(results, result)
-					continue
-				}
-				// Remote is now configured; re-read it.
-				remoteName, remoteURL, err = FindRemote(dbDir)
-				if err != nil || remoteURL == "" {
-					result.Error = fmt.Errorf("remote not found after auto-setup")
-					results = append(results, result)
-					continue
-				}
-				result.Remote = remoteURL
-			} else {
-				result.Skipped = true
-				results = append(results, result)
-				continue
-			}
-		}
+	if opts.DryRun {
+		result.DryRun = true
+		return result
+	}
 
-		if opts.DryRun {
-			result.DryRun = true
-			results = append(results, result)
-			continue
-		}
+	// Commit working set
+	if err := CommitWorkingSet(dbDir); err != nil {
+		result.Error = fmt.Errorf("committing: %w", err)
+		return result
+	}
 
-		// Commit working set
-		if err := CommitWorkingSet(dbDir); err != nil {
-			result.Error = fmt.Errorf("committing: %w", err)
-			results = append(results, result)
-			continue
+	// Push, merging the remote in if it has moved ahead
+	if err := pushWithRecovery(&result, opts,
+		func() error { return PushDatabase(dbDir, remoteName, opts.Force) },
+		func() (bool, error) { return mergeRemote(dbDir, remoteName, opts.Resolve) },
+	); err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Pushed = true
+	return result
+}
+
+// SyncDatabaseSQL pushes one database to its remote via SQL through the
+// running Dolt server. Each call uses its own server sessions, so several
+// databases can be synced concurrently.
+func SyncDatabaseSQL(townRoot, db string, opts SyncOptions) SyncResult {
+	result := SyncResult{Database: db}
+
+	// Skip databases with a .no-sync marker file (local-only databases),
+	// unless explicitly requested via Filter (--db flag).
+	dbDir := RigDatabaseDir(townRoot, db)
+	if opts.Filter == "" {
+		if _, err := os.Stat(filepath.Join(dbDir, ".no-sync")); err == nil {
+			result.Skipped = true
+			return result
 		}
+	}
 
-		// Push
-		if err := PushDatabase(dbDir, remoteName, opts.Force); err != nil {
-			result.Error = err
-			results = append(results, result)
-			continue
+	// Check for remote via SQL
+	remoteName, remoteURL, err := FindRemoteSQL(townRoot, db)
+	if err != nil {
+		result.Error = fmt.Errorf("checking remote: %w", err)
+		return result
+	}
+	result.Remote = remoteURL
+
+	if remoteURL == "" {
+		// Try auto-setup if credentials are available
+		token := DoltHubToken()
+		org := DoltHubOrg()
+		if token == "" || org == "" {
+			result.Skipped = true
+			return result
 		}
+		if err := SetupDoltHubRemote(dbDir, org, db, token); err != nil {
+			result.Error = fmt.Errorf("auto-setup DoltHub remote: %w", err)
+			return result
+		}
+		remoteName, remoteURL, err = FindRemoteSQL(townRoot, db)
+		if err != nil || remoteURL == "" {
+			result.Error = fmt.Errorf("remote not found after auto-setup")
+			return result
+		}
+		result.Remote = remoteURL
+	}
 
-		result.Pushed = true
-		results = append(results, result)
+	if opts.DryRun {
+		result.DryRun = true
+		return result
 	}
 
-	return results
+	// Push via SQL (server stays running), merging the remote in if it
+	// has moved ahead
+	if err := pushWithRecovery(&result, opts,
+		func() error { return PushDatabaseSQL(townRoot, db, remoteName, opts.Force) },
+		func() (bool, error) { return mergeRemoteSQL(townRoot, db, remoteName, opts.Resolve) },
+	); err != nil {
+		result.Error = err
+		return result
+	}
+
+	result.Pushed = true
+	return result
 }
 
-// SyncDatabasesSQL iterates all databases (or a filtered subset) and pushes via SQL
-// through the running Dolt server. Unlike SyncDatabases, this does NOT require
-// stopping the server, so it won't crash running agents.
-func SyncDatabasesSQL(townRoot string, opts SyncOptions) []SyncResult {
-	databases, err := ListDatabases(townRoot)
-	if err != nil {
-		return []SyncResult{{
-			Database: "(list)",
-			Error:    fmt.Errorf("listing databases: %w", err),
-		}}
-	}
+// Sync retry tuning. Variables so tests can shorten the backoff.
+var (
+	syncMaxAttempts = 4
+	syncBaseBackoff = 2 * time.Second
+	syncMaxBackoff  = 15 * time.Second
+)
 
-	var results []SyncResult
+// ErrSyncConflict reports a merge with the remote that needs a human (or
+// --resolve) to pick a side.
+var ErrSyncConflict = errors.New("merge conflicts with remote")
 
-	for _, db := range databases {
-		if opts.Filter != "" && db != opts.Filter {
-			continue
-		}
+// isSyncTransientError returns true if a push or pull failure is likely to
+// clear up on its own: network hiccups, remote throttling, and the lock
+// contention isDoltRetryableError already covers.
+func isSyncTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if isDoltRetryableError(err) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{
+		"connection refused",
+		"connection reset",
+		"broken pipe",
+		"i/o timeout",
+		"timed out",
+		"deadline exceeded",
+		"tls handshake",
+		"no such host",
+		"temporary failure",
+		"unexpected eof",
+		"rpc error: code = unavailable",
+		"too many requests",
+		"503 service unavailable",
+		"502 bad gateway",
+	} {
+		if strings.Contains(msg, s) {
+			return true
+		}
+	}
+	return false
+}
 
-		result := SyncResult{Database: db}
+// isNonFastForward returns true if a push was rejected because the remote
+// has commits the local branch doesn't.
+func isNonFastForward(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "non-fast-forward") ||
+		strings.Contains(msg, "not fast forward") ||
+		strings.Contains(msg, "fetch first") ||
+		strings.Contains(msg, "tip of your current branch is behind")
+}
 
-		// Skip databases with a .no-sync marker file (local-only databases),
-		// unless explicitly requested via Filter (--db flag).
-		dbDir := RigDatabaseDir(townRoot, db)
-		if opts.Filter == "" {
-			if _, err := os.Stat(filepath.Join(dbDir, ".no-sync")); err == nil {
-				result.Skipped = true
-				results = append(results, result)
-				continue
+// isMergeConflict returns true if a pull stopped on conflicting changes.
+func isMergeConflict(output string) bool {
+	msg := strings.ToLower(output)
+	return strings.Contains(msg, "conflict") && !strings.Contains(msg, "no conflicts")
+}
+
+// retrySync runs op, retrying transient failures with exponential backoff.
+// Each repeated attempt is counted in result.Retries.
+func retrySync(result *SyncResult, op func() error) error {
+	var err error
+	for attempt := 1; attempt <= syncMaxAttempts; attempt++ {
+		if err = op(); err == nil || !isSyncTransientError(err) {
+			return err
+		}
+		if attempt < syncMaxAttempts {
+			backoff := syncBaseBackoff << (attempt - 1)
+			if backoff > syncMaxBackoff {
+				backoff = syncMaxBackoff
 			}
+			time.Sleep(backoff)
+			result.Retries++
 		}
+	}
+	return fmt.Errorf("after %d attempts: %w", syncMaxAttempts, err)
+}
 
-		// Check for remote via SQL
-		remoteName, remoteURL, err := FindRemoteSQL(townRoot, db)
-		if err != nil {
-			result.Error = fmt.Errorf("checking remote: %w", err)
-			results = append(results, result)
-			continue
-		}
-		result.Remote = remoteURL
+// pushWithRecovery pushes, retrying transient failures. If the remote has
+// diverged, merge pulls it in (resolving conflicts per opts.Resolve, and
+// reporting whether it had to) and the push is tried once more. Force pushes
+// never merge.
+func pushWithRecovery(result *SyncResult, opts SyncOptions, push func() error, merge func() (bool, error)) error {
+	err := retrySync(result, push)
+	if err == nil || opts.Force || !isNonFastForward(err) {
+		return err
+	}
 
-		if remoteURL == "" {
-			// Try auto-setup if credentials are available
-			token := DoltHubToken()
-			org := DoltHubOrg()
-			if token != "" && org != "" {
-				if err := SetupDoltHubRemote(dbDir, org, db, token); err != nil {
-					result.Error = fmt.Errorf("auto-setup DoltHub remote: %w", err)
-					results = append(results, result)
-					continue
-				}
-				remoteName, remoteURL, err = FindRemoteSQL(townRoot, db)
-				if err != nil || remoteURL == "" {
-					result.Error = fmt.Errorf("remote not found after auto-setup")
-					results = append(results, result)
-					continue
-				}
-				result.Remote = remoteURL
-			} else {
-				result.Skipped = true
-				results = append(results, result)
-				continue
-			}
+	var resolved bool
+	if err := retrySync(result, func() (mergeErr error) {
+		resolved, mergeErr = merge()
+		return mergeErr
+	}); err != nil {
+		return fmt.Errorf("merging remote changes: %w", err)
+	}
+	result.Pulled = true
+	if resolved {
+		result.Resolved = opts.Resolve
+	}
+
+	return retrySync(result, push)
+}
+
+// conflictError explains how to get past a conflicted merge.
+func conflictError(output string) error {
+	return fmt.Errorf("%w; rerun with --resolve theirs|ours to keep one side (%s)", ErrSyncConflict, output)
+}
+
+// mergeRemote pulls remote's main into a database directory via the CLI.
+// Conflicts are settled with resolve ("theirs" or "ours") and committed; with
+// no resolve the merge is aborted so the database is left as it was.
+// Returns whether conflicts were resolved.
+func mergeRemote(dbDir, remote, resolve string) (bool, error) {
+	run := func(args ...string) (string, error) {
+		cmd := exec.Command("dolt", args...)
+		cmd.Dir = dbDir
+		setProcessGroup(cmd)
+		output, err := cmd.CombinedOutput()
+		return strings.TrimSpace(string(output)), err
+	}
+
+	output, err := run("pull", remote, "main")
+	if err == nil && !isMergeConflict(output) {
+		return false, nil
+	}
+	if !isMergeConflict(output) {
+		return false, fmt.Errorf("dolt pull: %w (%s)", err, output)
+	}
+
+	if resolve == "" {
+		if abortOut, abortErr := run("merge", "--abort"); abortErr != nil {
+			return false, fmt.Errorf("%w; aborting merge also failed: %v (%s)", conflictError(output), abortErr, abortOut)
 		}
+		return false, conflictError(output)
+	}
 
-		if opts.DryRun {
-			result.DryRun = true
-			results = append(results, result)
-			continue
+	if out, err := run("conflicts", "resolve", "--"+resolve, "."); err != nil {
+		return false, fmt.Errorf("dolt conflicts resolve --%s: %w (%s)", resolve, err, out)
+	}
+	if err := CommitWorkingSet(dbDir); err != nil {
+		return false, fmt.Errorf("committing resolved merge: %w", err)
+	}
+	return true, nil
+}
+
+// syncMergeBranch is the scratch branch mergeRemoteSQL merges a diverged
+// remote on, so main never holds a half-done merge.
+const syncMergeBranch = "gt-sync-merge"
+
+// sqlSession is one SQL session on the server: statements share its
+// checked-out branch and transaction.
+type sqlSession interface {
+	Exec(query string, args ...any) error
+	// Query returns each row as column name to value (NULL as "").
+	Query(query string, args ...any) ([]map[string]string, error)
+}
+
+// connSession is a sqlSession on a dedicated database/sql connection.
+type connSession struct {
+	ctx  context.Context
+	conn *sql.Conn
+}
+
+func (s connSession) Exec(query string, args ...any) error {
+	_, err := s.conn.ExecContext(s.ctx, query, args...)
+	return err
+}
+
+func (s connSession) Query(query string, args ...any) ([]map[string]string, error) {
+	rows, err := s.conn.QueryContext(s.ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	var out []map[string]string
+	for rows.Next() {
+		vals := make([]sql.NullString, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range vals {
+			ptrs[i] = &vals[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
 		}
+		row := make(map[string]string, len(cols))
+		for i, col := range cols {
+			row[col] = vals[i].String
+		}
+		out = append(out, row)
+	}
+	return out, rows.Err()
+}
 
-		// Push via SQL (server stays running)
-		if err := PushDatabaseSQL(townRoot, db, remoteName, opts.Force); err != nil {
-			result.Error = err
-			results = append(results, result)
-			continue
+// mergeRemoteSQL is mergeRemote through the running server, in a single
+// session of its own (see mergeRemoteInSession).
+func mergeRemoteSQL(townRoot, db, remote, resolve string) (bool, error) {
+	if !validSQLName(db) {
+		return false, fmt.Errorf("invalid database name %q: must match [a-zA-Z0-9_.-]+", db)
+	}
+	if !validSQLName(remote) {
+		return false, fmt.Errorf("invalid remote name %q: must match [a-zA-Z0-9_.-]+", remote)
+	}
+
+	config := DefaultConfig(townRoot)
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Second)
+	defer cancel()
+
+	dsn := fmt.Sprintf("%s@tcp(%s:%d)/%s?interpolateParams=true", config.User, config.EffectiveHost(), config.Port, db)
+	sqlDB, err := sql.Open("mysql", dsn)
+	if err != nil {
+		return false, fmt.Errorf("opening mysql connection: %w", err)
+	}
+	defer sqlDB.Close()
+	conn, err := sqlDB.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("connecting to %s: %w", db, err)
+	}
+	defer conn.Close()
+
+	return mergeRemoteInSession(connSession{ctx: ctx, conn: conn}, remote, resolve)
+}
+
+// mergeRemoteInSession merges remote's main into main. The merge runs on
+// syncMergeBranch inside one transaction, so main's working set, which
+// agents keep writing to, never sees it until it is done. Conflicts are
+// settled with resolve ("theirs" or "ours") and only the tables the merge
+// touched are committed; with no resolve the merge is aborted. Main then
+// takes the merged branch. Returns whether conflicts were resolved.
+func mergeRemoteInSession(s sqlSession, remote, resolve string) (bool, error) {
+	if err := s.Exec("CALL DOLT_FETCH(?, 'main')", remote); err != nil {
+		return false, fmt.Errorf("DOLT_FETCH: %w", err)
+	}
+	if err := s.Exec("CALL DOLT_BRANCH('-f', ?, 'main')", syncMergeBranch); err != nil {
+		return false, fmt.Errorf("creating %s: %w", syncMergeBranch, err)
+	}
+	if err := s.Exec("CALL DOLT_CHECKOUT(?)", syncMergeBranch); err != nil {
+		return false, fmt.Errorf("checking out %s: %w", syncMergeBranch, err)
+	}
+	defer func() {
+		_ = s.Exec("ROLLBACK")
+		_ = s.Exec("SET @@autocommit = 1")
+		_ = s.Exec("CALL DOLT_CHECKOUT('main')")
+		_ = s.Exec("CALL DOLT_BRANCH('-D', ?)", syncMergeBranch)
+	}()
+	if err := s.Exec("SET @@autocommit = 0"); err != nil {
+		return false, err
+	}
+
+	resolved, err := mergeInTransaction(s, remote+"/main", resolve)
+	if err != nil {
+		return false, err
+	}
+	if err := s.Exec("COMMIT"); err != nil {
+		return false, fmt.Errorf("committing merge to %s: %w", syncMergeBranch, err)
+	}
+
+	// Main may have moved on while merging; a conflict now is with local
+	// work, which is never settled automatically.
+	if err := s.Exec("CALL DOLT_CHECKOUT('main')"); err != nil {
+		return false, fmt.Errorf("checking out main: %w", err)
+	}
+	if _, err := mergeInTransaction(s, syncMergeBranch, ""); err != nil {
+		return false, err
+	}
+	if err := s.Exec("COMMIT"); err != nil {
+		return false, fmt.Errorf("committing merge to main: %w", err)
+	}
+	return resolved, nil
+}
+
+// mergeInTransaction merges branch into the session's branch. On conflict
+// it either resolves them and commits the touched tables, or aborts the
+// merge and returns a conflict error.
+func mergeInTransaction(s sqlSession, branch, resolve string) (bool, error) {
+	rows, err := s.Query("CALL DOLT_MERGE(?)", branch)
+	if err != nil {
+		return false, fmt.Errorf("DOLT_MERGE %s: %w", branch, err)
+	}
+	conflicts, message := mergeResult(rows)
+	if !conflicts {
+		return false, nil
+	}
+
+	if resolve == "" {
+		if abortErr := s.Exec("CALL DOLT_MERGE('--abort')"); abortErr != nil {
+			return false, fmt.Errorf("%w; aborting merge also failed: %v", conflictError(message), abortErr)
 		}
+		return false, conflictError(message)
+	}
 
-		result.Pushed = true
-		results = append(results, result)
+	if err := s.Exec("CALL DOLT_CONFLICTS_RESOLVE(?, '.')", "--"+resolve); err != nil {
+		return false, fmt.Errorf("resolving conflicts --%s: %w", resolve, err)
+	}
+	// The merge branch started clean, so its status is exactly what the
+	// merge changed.
+	status, err := s.Query("SELECT DISTINCT table_name FROM dolt_status")
+	if err != nil {
+		return false, fmt.Errorf("reading merge status: %w", err)
 	}
+	for _, row := range status {
+		if err := s.Exec("CALL DOLT_ADD(?)", row["table_name"]); err != nil {
+			return false, fmt.Errorf("staging %s: %w", row["table_name"], err)
+		}
+	}
+	msg := fmt.Sprintf("gt dolt sync: merge %s (resolved --%s)", branch, resolve)
+	if err := s.Exec("CALL DOLT_COMMIT('-m', ?, '--author', 'Gas Town Sync <sync@gastown.local>')", msg); err != nil {
+		return false, fmt.Errorf("committing resolved merge: %w", err)
+	}
+	return true, nil
+}
 
-	return results
+// mergeResult reads CALL DOLT_MERGE's result: whether its conflicts column
+// is non-zero, and its message.
+func mergeResult(rows []map[string]string) (conflicts bool, message string) {
+	if len(rows) == 0 {
+		return false, ""
+	}
+	c := strings.TrimSpace(rows[0]["conflicts"])
+	return c != "" && c != "0", rows[0]["message"]
 }
 
 // PurgeClosedEphemerals runs "bd purge" for a specific rig database to remove
@@ -1,9 +1,12 @@
 package doltserver
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 )
@@ -127,6 +130,211 @@ func TestValidSQLName(t *testing.T) {
 	}
 }
 
+// fakeSession is a sqlSession that records statements and answers
+// DOLT_MERGE and dolt_status queries from canned rows.
+type fakeSession struct {
+	stmts     []string
+	conflicts map[string]bool // merged branch -> whether DOLT_MERGE conflicts
+	status    []string        // tables in dolt_status
+}
+
+func (f *fakeSession) record(query string, args []any) string {
+	stmt := query
+	for _, a := range args {
+		stmt = strings.Replace(stmt, "?", fmt.Sprintf("'%v'", a), 1)
+	}
+	f.stmts = append(f.stmts, stmt)
+	return stmt
+}
+
+func (f *fakeSession) Exec(query string, args ...any) error {
+	f.record(query, args)
+	return nil
+}
+
+func (f *fakeSession) Query(query string, args ...any) ([]map[string]string, error) {
+	f.record(query, args)
+	if strings.Contains(query, "dolt_status") {
+		var rows []map[string]string
+		for _, table := range f.status {
+			rows = append(rows, map[string]string{"table_name": table})
+		}
+		return rows, nil
+	}
+	conflicts := "0"
+	if f.conflicts[fmt.Sprint(args[0])] {
+		conflicts = "1"
+	}
+	return []map[string]string{{"hash": "abc", "fast_forward": "0", "conflicts": conflicts, "message": "merge"}}, nil
+}
+
+func (f *fakeSession) ran(stmt string) bool {
+	for _, s := range f.stmts {
+		if s == stmt {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMergeRemoteInSession_AbortsOnConflict(t *testing.T) {
+	s := &fakeSession{conflicts: map[string]bool{"origin/main": true}}
+	resolved, err := mergeRemoteInSession(s, "origin", "")
+	if !errors.Is(err, ErrSyncConflict) || resolved {
+		t.Fatalf("mergeRemoteInSession = %v, %v; want a conflict error", resolved, err)
+	}
+	if !s.ran("CALL DOLT_CHECKOUT('gt-sync-merge')") {
+		t.Error("merge should run on the scratch branch")
+	}
+	if !s.ran("CALL DOLT_MERGE('--abort')") {
+		t.Error("conflicted merge was not aborted")
+	}
+	for _, stmt := range s.stmts {
+		if strings.Contains(stmt, "DOLT_COMMIT") || stmt == "CALL DOLT_MERGE('gt-sync-merge')" {
+			t.Errorf("nothing should reach main after an aborted merge, ran %s", stmt)
+		}
+	}
+	if last := s.stmts[len(s.stmts)-1]; last != "CALL DOLT_BRANCH('-D', 'gt-sync-merge')" {
+		t.Errorf("scratch branch not dropped, last statement %s", last)
+	}
+}
+
+func TestMergeRemoteInSession_ResolveCommitsOnlyMergedTables(t *testing.T) {
+	s := &fakeSession{conflicts: map[string]bool{"origin/main": true}, status: []string{"issues"}}
+	resolved, err := mergeRemoteInSession(s, "origin", ResolveTheirs)
+	if err != nil || !resolved {
+		t.Fatalf("mergeRemoteInSession = %v, %v; want resolved", resolved, err)
+	}
+	if !s.ran("CALL DOLT_CONFLICTS_RESOLVE('--theirs', '.')") || !s.ran("CALL DOLT_ADD('issues')") {
+		t.Errorf("expected resolve and DOLT_ADD of the merged table, ran %v", s.stmts)
+	}
+	for _, stmt := range s.stmts {
+		if strings.Contains(stmt, "'-A'") || strings.Contains(stmt, "'-a'") {
+			t.Errorf("resolved merge must not stage everything: %s", stmt)
+		}
+	}
+	if !s.ran("CALL DOLT_MERGE('gt-sync-merge')") {
+		t.Error("main never took the merged branch")
+	}
+}
+
+func TestMergeResult(t *testing.T) {
+	if c, _ := mergeResult([]map[string]string{{"conflicts": "0"}}); c {
+		t.Error("0 conflicts reported as conflicted")
+	}
+	if c, msg := mergeResult([]map[string]string{{"conflicts": "2", "message": "conflicts found"}}); !c || msg != "conflicts found" {
+		t.Errorf("mergeResult = %v, %q", c, msg)
+	}
+	if c, _ := mergeResult(nil); c {
+		t.Error("no rows reported as conflicted")
+	}
+}
+
+func shortSyncBackoff(t *testing.T) {
+	t.Helper()
+	oldBase, oldMax := syncBaseBackoff, syncMaxBackoff
+	syncBaseBackoff, syncMaxBackoff = time.Millisecond, time.Millisecond
+	t.Cleanup(func() { syncBaseBackoff, syncMaxBackoff = oldBase, oldMax })
+}
+
+// TestIsSyncTransientError verifies network-level failures are retried
+// while unrelated errors that merely contain a pattern's letters are not.
+func TestIsSyncTransientError(t *testing.T) {
+	tests := []struct {
+		msg  string
+		want bool
+	}{
+		{"dolt push: unexpected EOF", true},
+		{"read tcp 10.0.0.1:443: i/o timeout", true},
+		{"unknown column 'geofence' in field list", false},
+		{"permission denied", false},
+	}
+	for _, tt := range tests {
+		if got := isSyncTransientError(errors.New(tt.msg)); got != tt.want {
+			t.Errorf("isSyncTransientError(%q) = %v, want %v", tt.msg, got, tt.want)
+		}
+	}
+}
+
+// TestPushWithRecovery_RetriesTransient verifies transient push failures are
+// retried and counted, while permanent ones fail immediately.
+func TestPushWithRecovery_RetriesTransient(t *testing.T) {
+	shortSyncBackoff(t)
+
+	var result SyncResult
+	calls := 0
+	push := func() error {
+		calls++
+		if calls < 3 {
+			return errors.New("dolt push: exit status 1 (connection reset by peer)")
+		}
+		return nil
+	}
+	noMerge := func() (bool, error) { t.Fatal("unexpected merge"); return false, nil }
+	if err := pushWithRecovery(&result, SyncOptions{}, push, noMerge); err != nil {
+		t.Fatalf("pushWithRecovery: %v", err)
+	}
+	if calls != 3 || result.Retries != 2 {
+		t.Errorf("calls = %d, retries = %d; want 3, 2", calls, result.Retries)
+	}
+
+	result, calls = SyncResult{}, 0
+	push = func() error { calls++; return errors.New("permission denied") }
+	if err := pushWithRecovery(&result, SyncOptions{}, push, noMerge); err == nil {
+		t.Error("expected permanent error")
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1 for a permanent error", calls)
+	}
+}
+
+// TestPushWithRecovery_MergesDivergedRemote verifies a non-fast-forward push
+// merges the remote and pushes again, unless forcing.
+func TestPushWithRecovery_MergesDivergedRemote(t *testing.T) {
+	shortSyncBackoff(t)
+
+	merged := false
+	push := func() error {
+		if !merged {
+			return errors.New("DOLT_PUSH: exit status 1 (failed to push some refs: non-fast-forward)")
+		}
+		return nil
+	}
+	merge := func() (bool, error) { merged = true; return true, nil }
+
+	var result SyncResult
+	if err := pushWithRecovery(&result, SyncOptions{Resolve: ResolveTheirs}, push, merge); err != nil {
+		t.Fatalf("pushWithRecovery: %v", err)
+	}
+	if !result.Pulled || result.Resolved != ResolveTheirs {
+		t.Errorf("result = %+v, want pulled and resolved theirs", result)
+	}
+
+	merged = false
+	result = SyncResult{}
+	if err := pushWithRecovery(&result, SyncOptions{Force: true}, push, merge); err == nil || merged {
+		t.Errorf("force push: err = %v, merged = %v; want error without merge", err, merged)
+	}
+}
+
+// TestPushWithRecovery_ConflictNeedsResolve verifies an unresolved conflict
+// surfaces ErrSyncConflict.
+func TestPushWithRecovery_ConflictNeedsResolve(t *testing.T) {
+	shortSyncBackoff(t)
+
+	push := func() error { return errors.New("rejected: non-fast-forward") }
+	merge := func() (bool, error) { return false, conflictError("CONFLICT (content): issues") }
+
+	var result SyncResult
+	err := pushWithRecovery(&result, SyncOptions{}, push, merge)
+	if !errors.Is(err, ErrSyncConflict) {
+		t.Fatalf("err = %v, want ErrSyncConflict", err)
+	}
+	if result.Pulled {
+		t.Error("Pulled should be false after an aborted merge")
+	}
+}
+
 // tempDirRetryCleanup creates a temp directory with cleanup that tolerates
 // brief file-lock delays on Windows (e.g., dolt subprocess handle release).
 func tempDirRetryCleanup(t *testing.T) string {
@@ -195,3 +195,40 @@ func TestDeactivateNonExistent(t *testing.T) {
 		t.Fatalf("Deactivate non-existent: %v", err)
 	}
 }
+
+func TestPauseRoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+
+	if IsPaused(townRoot) || ReadPause(townRoot) != nil {
+		t.Fatal("should not be paused before WritePause")
+	}
+
+	want := &PauseState{
+		Reason:          "meeting",
+		Sessions:        []string{"hq-mayor", "gt-witness"},
+		PausedScheduler: true,
+	}
+	if err := WritePause(townRoot, want); err != nil {
+		t.Fatalf("WritePause: %v", err)
+	}
+	if !IsPaused(townRoot) {
+		t.Fatal("should be paused after WritePause")
+	}
+	got := ReadPause(townRoot)
+	if got == nil {
+		t.Fatal("ReadPause returned nil")
+	}
+	if got.Reason != want.Reason || len(got.Sessions) != 2 || !got.PausedScheduler || got.PausedDeacon {
+		t.Errorf("ReadPause = %+v, want %+v", got, want)
+	}
+
+	if err := ClearPause(townRoot); err != nil {
+		t.Fatalf("ClearPause: %v", err)
+	}
+	if IsPaused(townRoot) {
+		t.Fatal("should not be paused after ClearPause")
+	}
+	if err := ClearPause(townRoot); err != nil {
+		t.Fatalf("ClearPause when not paused: %v", err)
+	}
+}
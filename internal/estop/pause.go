@@ -0,0 +1,72 @@
+package estop
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// PauseState records a planned town pause (gt pause). Unlike an E-stop it
+// includes the Mayor, and it remembers what it changed so gt unpause can
+// undo exactly that and no more.
+type PauseState struct {
+	Reason   string    `json:"reason,omitempty"`
+	PausedAt time.Time `json:"paused_at"`
+	PausedBy string    `json:"paused_by,omitempty"`
+
+	// Sessions are the agent sessions frozen by the pause.
+	Sessions []string `json:"sessions,omitempty"`
+
+	// PausedScheduler and PausedDeacon are set when the pause paused
+	// dispatch or Deacon patrols itself, rather than finding them paused.
+	PausedScheduler bool `json:"paused_scheduler,omitempty"`
+	PausedDeacon    bool `json:"paused_deacon,omitempty"`
+}
+
+// PauseFilePath returns the path to the town pause file.
+func PauseFilePath(townRoot string) string {
+	return filepath.Join(townRoot, ".runtime", "town-paused.json")
+}
+
+// IsPaused reports whether the town is paused.
+func IsPaused(townRoot string) bool {
+	_, err := os.Stat(PauseFilePath(townRoot))
+	return err == nil
+}
+
+// ReadPause reads the town pause state. Returns nil if the town isn't
+// paused or the file can't be parsed.
+func ReadPause(townRoot string) *PauseState {
+	data, err := os.ReadFile(PauseFilePath(townRoot)) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		return nil
+	}
+	var state PauseState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil
+	}
+	return &state
+}
+
+// WritePause marks the town paused with state, replacing any earlier state.
+func WritePause(townRoot string, state *PauseState) error {
+	path := PauseFilePath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// ClearPause removes the town pause file.
+func ClearPause(townRoot string) error {
+	err := os.Remove(PauseFilePath(townRoot))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/steveyegge/gastown/internal/util"
@@ -166,6 +167,7 @@ func (g *Git) wrapError(err error, stdout, stderr string, args []string) error {
 type cloneOptions struct {
 	bare         bool   // Pass --bare to git clone
 	reference    string // Pass --reference-if-able <path> to git clone
+	dissociate   bool   // Pass --dissociate: copy borrowed objects instead of keeping reference as an alternate
 	singleBranch bool   // Pass --single-branch to git clone (only fetch default branch)
 	depth        int    // Pass --depth N to git clone (shallow clone); 0 means full history
 	branch       string // Pass --branch <name> to git clone (checkout specific branch)
@@ -214,18 +216,30 @@ func (g *Git) cloneInternal(url, dest string, opts cloneOptions) error {
 	}
 	if opts.reference != "" {
 		args = append(args, "--reference-if-able", opts.reference)
+		if opts.dissociate {
+			args = append(args, "--dissociate")
+		}
 	}
 	args = append(args, url, tmpDest)
 
-	cmd := exec.Command("git", args...)
-	util.SetDetachedProcessGroup(cmd)
-	cmd.Dir = tmpDir
-	cmd.Env = append(os.Environ(), "GIT_CEILING_DIRECTORIES="+tmpDir)
-	var stdout, stderr bytes.Buffer
-	cmd.Stdout = &stdout
-	cmd.Stderr = &stderr
-	if err := cmd.Run(); err != nil {
-		return g.wrapError(err, stdout.String(), stderr.String(), args)
+	err = withNetworkRetry("clone", func() error {
+		// A failed clone can leave a partial directory behind; git refuses
+		// to clone into a non-empty one.
+		_ = os.RemoveAll(tmpDest)
+		cmd := exec.Command("git", args...)
+		util.SetDetachedProcessGroup(cmd)
+		cmd.Dir = tmpDir
+		cmd.Env = append(os.Environ(), "GIT_CEILING_DIRECTORIES="+tmpDir)
+		var stdout, stderr bytes.Buffer
+		cmd.Stdout = &stdout
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			return g.wrapError(err, stdout.String(), stderr.String(), args)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Move to final destination (handles cross-filesystem moves)
@@ -238,7 +252,7 @@ func (g *Git) cloneInternal(url, dest string, opts cloneOptions) error {
 		// Configure refspec so worktrees can fetch and see origin/* refs.
 		// For single-branch shallow clones, only set the config without
 		// fetching all branches (which would defeat the purpose of --single-branch).
-		return configureRefspec(dest, opts.singleBranch)
+		return configureRefspec(dest, opts.singleBranch, opts.depth)
 	}
 	// Configure hooks path for Gas Town clones
 	if err := configureHooksPath(dest); err != nil {
@@ -315,6 +329,19 @@ func (g *Git) CloneBranchPartial(url, dest, branch, filter string) error {
 	return g.cloneInternal(url, dest, cloneOptions{singleBranch: true, filter: filter, branch: branch})
 }
 
+// CloneBareShallow clones a bare repo with only the last depth commits of
+// branch's history, optionally also with a partial clone filter ("" for
+// none) and borrowing objects from reference ("" for none). Deepen it later
+// with Deepen.
+func (g *Git) CloneBareShallow(url, dest, branch, filter, reference string, depth int) error {
+	return g.cloneInternal(url, dest, cloneOptions{bare: true, singleBranch: true, depth: depth, filter: filter, branch: branch, reference: reference})
+}
+
+// CloneBranchShallow is CloneBareShallow for a regular clone of branch.
+func (g *Git) CloneBranchShallow(url, dest, branch, filter, reference string, depth int) error {
+	return g.cloneInternal(url, dest, cloneOptions{singleBranch: true, depth: depth, filter: filter, branch: branch, reference: reference})
+}
+
 // configureHooksPath sets core.hooksPath to use the repo's .githooks directory
 // if it exists. This ensures Gas Town agents use the pre-push hook that blocks
 // pushes to non-main branches (internal PRs are not allowed).
@@ -348,8 +375,9 @@ func (g *Git) ConfigureHooksPath() error {
 //
 // When singleBranch is true, fetches only the default branch's ref instead of all
 // branches. This prevents failures on repos with many branches where a full fetch
-// would error with "some local refs could not be updated".
-func configureRefspec(repoPath string, singleBranch bool) error {
+// would error with "some local refs could not be updated". That fetch keeps the
+// clone's depth (1 when the clone wasn't shallow).
+func configureRefspec(repoPath string, singleBranch bool, depth int) error {
 	gitDir := repoPath
 	if _, err := os.Stat(filepath.Join(repoPath, ".git")); err == nil {
 		gitDir = filepath.Join(repoPath, ".git")
@@ -365,6 +393,11 @@ func configureRefspec(repoPath string, singleBranch bool) error {
 	}
 
 	if singleBranch {
+		fetchDepth := "1"
+		if depth > 0 {
+			fetchDepth = strconv.Itoa(depth)
+		}
+
 		// For shallow single-branch clones, fetch only the HEAD branch to create
 		// the origin/<branch> ref that worktrees need. A full `git fetch origin`
 		// would try to fetch ALL remote branches (due to the refspec we just set),
@@ -378,7 +411,7 @@ func configureRefspec(repoPath string, singleBranch bool) error {
 		headCmd.Stderr = &stderr
 		if err := headCmd.Run(); err != nil {
 			// Fallback: if HEAD is detached, try fetching all (shouldn't happen for clones)
-			fetchCmd := exec.Command("git", "--git-dir", gitDir, "fetch", "--depth", "1", "origin")
+			fetchCmd := exec.Command("git", "--git-dir", gitDir, "fetch", "--depth", fetchDepth, "origin")
 			util.SetDetachedProcessGroup(fetchCmd)
 			fetchCmd.Stderr = &stderr
 			if fetchErr := fetchCmd.Run(); fetchErr != nil {
@@ -390,7 +423,7 @@ func configureRefspec(repoPath string, singleBranch bool) error {
 		branch := strings.TrimPrefix(headRef, "refs/heads/")  // e.g. "main"
 		refspec := branch + ":refs/remotes/origin/" + branch   // e.g. "main:refs/remotes/origin/main"
 
-		fetchCmd := exec.Command("git", "--git-dir", gitDir, "fetch", "--depth", "1", "origin", refspec)
+		fetchCmd := exec.Command("git", "--git-dir", gitDir, "fetch", "--depth", fetchDepth, "origin", refspec)
 		util.SetDetachedProcessGroup(fetchCmd)
 		fetchCmd.Stderr = &stderr
 		if err := fetchCmd.Run(); err != nil {
@@ -420,6 +453,14 @@ func (g *Git) CloneBareWithReferenceAndBranch(url, dest, reference, branch strin
 	return g.cloneInternal(url, dest, cloneOptions{bare: true, reference: reference, singleBranch: true, depth: 1, branch: branch})
 }
 
+// CloneBareDissociated clones a bare repo borrowing objects from a local
+// reference, then copies them in so the clone doesn't depend on reference.
+// Full history, since the reference usually has it: only objects it lacks
+// are downloaded.
+func (g *Git) CloneBareDissociated(url, dest, reference, branch string) error {
+	return g.cloneInternal(url, dest, cloneOptions{bare: true, reference: reference, dissociate: true, singleBranch: true, branch: branch})
+}
+
 // Checkout checks out the given ref.
 func (g *Git) Checkout(ref string) error {
 	_, err := g.run("checkout", ref)
@@ -433,22 +474,23 @@ func (g *Git) CheckoutNewBranch(branch, startPoint string) error {
 	return err
 }
 
-// Fetch fetches from the remote.
+// Fetch fetches from the remote. Like the other fetch, pull and push
+// helpers, it retries transient network failures with backoff.
 func (g *Git) Fetch(remote string) error {
-	_, err := g.run("fetch", remote)
+	_, err := g.runNetwork("fetch", remote)
 	return err
 }
 
 // FetchPrune fetches from the remote and prunes stale remote-tracking refs.
 // This removes remote-tracking branches for branches that no longer exist on the remote.
 func (g *Git) FetchPrune(remote string) error {
-	_, err := g.run("fetch", "--prune", remote)
+	_, err := g.runNetwork("fetch", "--prune", remote)
 	return err
 }
 
 // FetchBranch fetches a specific branch from the remote.
 func (g *Git) FetchBranch(remote, branch string) error {
-	_, err := g.run("fetch", remote, branch)
+	_, err := g.runNetwork("fetch", remote, branch)
 	return err
 }
 
@@ -457,13 +499,54 @@ func (g *Git) FetchBranch(remote, branch string) error {
 // clones to add a branch that wasn't included in the initial clone.
 func (g *Git) FetchBranchShallow(remote, branch string) error {
 	refspec := branch + ":refs/remotes/" + remote + "/" + branch
-	_, err := g.run("fetch", "--depth", "1", remote, refspec)
+	_, err := g.runNetwork("fetch", "--depth", "1", remote, refspec)
 	return err
 }
 
+// IsShallow reports whether the repository has truncated history.
+func (g *Git) IsShallow() (bool, error) {
+	out, err := g.run("rev-parse", "--is-shallow-repository")
+	if err != nil {
+		return false, err
+	}
+	return out == "true", nil
+}
+
+// Deepen fetches n more commits of history from remote into a shallow
+// repository, or all of it when n is 0.
+func (g *Git) Deepen(remote string, n int) error {
+	if n <= 0 {
+		_, err := g.runNetwork("fetch", "--unshallow", remote)
+		return err
+	}
+	_, err := g.runNetwork("fetch", "--deepen="+strconv.Itoa(n), remote)
+	return err
+}
+
+// DeepenUntilMergeBase deepens a shallow repository in growing steps until
+// a and b have a merge base, unshallowing as a last resort. It does nothing
+// in a repository with full history.
+func (g *Git) DeepenUntilMergeBase(remote, a, b string) error {
+	if shallow, err := g.IsShallow(); err != nil || !shallow {
+		return err
+	}
+	for _, n := range []int{50, 500} {
+		if _, err := g.MergeBase(a, b); err == nil {
+			return nil
+		}
+		if err := g.Deepen(remote, n); err != nil {
+			return err
+		}
+	}
+	if _, err := g.MergeBase(a, b); err == nil {
+		return nil
+	}
+	return g.Deepen(remote, 0)
+}
+
 // Pull pulls from the remote branch.
 func (g *Git) Pull(remote, branch string) error {
-	_, err := g.run("pull", remote, branch)
+	_, err := g.runNetwork("pull", remote, branch)
 	return err
 }
 
@@ -506,13 +589,13 @@ func (g *Git) GetPushURL(remote string) (string, error) {
 	return strings.TrimSpace(out), nil
 }
 
-// Push pushes to the remote branch.
+// Push pushes to the remote branch, retrying transient network failures.
 func (g *Git) Push(remote, branch string, force bool) error {
 	args := []string{"push", remote, branch}
 	if force {
 		args = append(args, "--force")
 	}
-	_, err := g.run(args...)
+	_, err := g.runNetwork(args...)
 	return err
 }
 
@@ -524,7 +607,7 @@ func (g *Git) PushWithEnv(remote, branch string, force bool, env []string) error
 	if force {
 		args = append(args, "--force")
 	}
-	_, err := g.runWithEnv(args, env)
+	_, err := g.runNetworkWithEnv(args, env)
 	return err
 }
 
@@ -722,6 +805,38 @@ func (g *Git) RemoteDefaultBranch() string {
 	return "main" // final fallback
 }
 
+// DetectDefaultBranch returns the repository's default branch without
+// guessing: origin/HEAD if set, else HEAD's branch in a bare clone (git
+// points it at the remote's default during clone --bare), else the first
+// of main, master and trunk that exists. Returns "" if none can be found.
+func (g *Git) DetectDefaultBranch() string {
+	if out, err := g.run("symbolic-ref", "refs/remotes/origin/HEAD"); err == nil && out != "" {
+		return strings.TrimPrefix(out, "refs/remotes/origin/")
+	}
+
+	bare := false
+	if out, err := g.run("rev-parse", "--is-bare-repository"); err == nil && out == "true" {
+		bare = true
+		if head, err := g.run("symbolic-ref", "--short", "HEAD"); err == nil && head != "" {
+			if _, err := g.run("rev-parse", "--verify", "--quiet", "refs/heads/"+head); err == nil {
+				return head
+			}
+		}
+	}
+
+	for _, b := range []string{"main", "master", "trunk"} {
+		if _, err := g.run("rev-parse", "--verify", "--quiet", "refs/remotes/origin/"+b); err == nil {
+			return b
+		}
+		if bare {
+			if _, err := g.run("rev-parse", "--verify", "--quiet", "refs/heads/"+b); err == nil {
+				return b
+			}
+		}
+	}
+	return ""
+}
+
 // HasUncommittedChanges returns true if there are uncommitted changes.
 func (g *Git) HasUncommittedChanges() (bool, error) {
 	status, err := g.Status()
@@ -796,7 +911,7 @@ func (g *Git) HasUpstreamRemote() (bool, error) {
 
 // FetchUpstream fetches from the upstream remote.
 func (g *Git) FetchUpstream() error {
-	_, err := g.run("fetch", "upstream")
+	_, err := g.runNetwork("fetch", "upstream")
 	return err
 }
 
@@ -823,6 +938,118 @@ func (g *Git) ConfigGet(key string) (string, error) {
 	return out, nil
 }
 
+// EnableWorktreeConfig turns on per-worktree config (extensions.worktreeConfig)
+// for the repository, so SetWorktreeConfig can scope settings to a single
+// worktree. When the shared repo is bare, core.bare is moved into the bare
+// repo's own config.worktree first; left in the common config it would mark
+// every linked worktree bare. Safe to call repeatedly.
+func (g *Git) EnableWorktreeConfig() error {
+	if v, _ := g.ConfigGet("extensions.worktreeConfig"); v == "true" {
+		return nil
+	}
+	commonDir, err := g.run("rev-parse", "--path-format=absolute", "--git-common-dir")
+	if err != nil {
+		return err
+	}
+	commonConfig := filepath.Join(commonDir, "config")
+	if bare, _ := g.run("config", "--file", commonConfig, "--get", "core.bare"); bare == "true" {
+		if _, err := g.run("config", "--file", filepath.Join(commonDir, "config.worktree"), "core.bare", "true"); err != nil {
+			return err
+		}
+		if _, err := g.run("config", "--file", commonConfig, "--unset", "core.bare"); err != nil {
+			return err
+		}
+	}
+	_, err = g.run("config", "--file", commonConfig, "extensions.worktreeConfig", "true")
+	return err
+}
+
+// SetWorktreeConfig sets a git config key for this worktree only.
+// Requires EnableWorktreeConfig.
+func (g *Git) SetWorktreeConfig(key, value string) error {
+	_, err := g.run("config", "--worktree", key, value)
+	return err
+}
+
+// UsesLFS reports whether HEAD's tree routes any paths through the Git LFS
+// filter, i.e. some .gitattributes has a filter=lfs rule.
+func (g *Git) UsesLFS() (bool, error) {
+	out, err := g.run("grep", "-l", "filter=lfs", "HEAD", "--", ":(glob)**/.gitattributes")
+	if err != nil {
+		// git grep exits 1 when nothing matches.
+		var ge *GitError
+		if errors.As(err, &ge) {
+			var exitErr *exec.ExitError
+			if errors.As(ge.Err, &exitErr) && exitErr.ExitCode() == 1 {
+				return false, nil
+			}
+		}
+		return false, err
+	}
+	return out != "", nil
+}
+
+// LFSInstall configures the LFS filters and hooks in the repository's own
+// config, so checkouts smudge pointer files and pushes upload LFS objects
+// even when git-lfs isn't set up globally.
+func (g *Git) LFSInstall() error {
+	_, err := g.run("lfs", "install", "--local")
+	return err
+}
+
+// LFSSetFetchFilters sets the repository's lfs.fetchinclude and
+// lfs.fetchexclude patterns, which limit what LFS downloads on checkout and
+// pull. Empty lists clear the setting.
+func (g *Git) LFSSetFetchFilters(include, exclude []string) error {
+	for key, patterns := range map[string][]string{
+		"lfs.fetchinclude": include,
+		"lfs.fetchexclude": exclude,
+	} {
+		if len(patterns) == 0 {
+			_, _ = g.run("config", "--local", "--unset", key) // exits 5 when unset
+			continue
+		}
+		if _, err := g.run("config", "--local", key, strings.Join(patterns, ",")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LFSPull fetches LFS objects for the checked-out tree (honoring the fetch
+// filters) and replaces pointer files with their content.
+func (g *Git) LFSPull() error {
+	_, err := g.run("lfs", "pull")
+	return err
+}
+
+// UnsignedCommits returns the commits in base..head that don't carry a good
+// signature, as "<short-sha> <subject>" lines. allowedSigners, if set, is
+// the ssh allowed-signers file to verify ssh signatures against.
+func (g *Git) UnsignedCommits(base, head, allowedSigners string) ([]string, error) {
+	args := []string{}
+	if allowedSigners != "" {
+		args = append(args, "-c", "gpg.ssh.allowedSignersFile="+allowedSigners)
+	}
+	args = append(args, "log", "--format=%G? %h %s", base+".."+head)
+	out, err := g.run(args...)
+	if err != nil {
+		return nil, err
+	}
+	var unsigned []string
+	for _, line := range strings.Split(out, "\n") {
+		status, commit, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		// G: good signature; U: good signature from a key of unknown trust.
+		if status != "G" && status != "U" {
+			unsigned = append(unsigned, commit)
+		}
+	}
+	return unsigned, nil
+}
+
 // Merge merges the given branch into the current branch.
 func (g *Git) Merge(branch string) error {
 	_, err := g.run("merge", branch)
@@ -870,6 +1097,69 @@ func (g *Git) RecentCommits(n int) (string, error) {
 	return g.run("log", "--oneline", fmt.Sprintf("-%d", n))
 }
 
+// CommitsSince returns the commits on HEAD that are not on base as one-line
+// summaries (hash + subject), newest first.
+func (g *Git) CommitsSince(base string) (string, error) {
+	return g.run("log", "--oneline", base+"..HEAD")
+}
+
+// MergeBase returns the best common ancestor of two refs.
+func (g *Git) MergeBase(a, b string) (string, error) {
+	return g.run("merge-base", a, b)
+}
+
+// DiffPatch returns the zero-context patch between ref and the working tree,
+// so uncommitted changes to tracked files are included.
+func (g *Git) DiffPatch(ref string) (string, error) {
+	return g.run("diff", "--no-color", "--unified=0", ref)
+}
+
+// WorkingTreeDiff returns a binary patch from HEAD to the working tree,
+// covering staged and unstaged changes and untracked files (ignored files
+// excluded). It stages into a throwaway index, so the real index is left
+// alone. Empty when the worktree is clean.
+func (g *Git) WorkingTreeDiff() (string, error) {
+	tmpDir, err := os.MkdirTemp("", "gt-index-")
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = os.RemoveAll(tmpDir) }()
+	env := []string{"GIT_INDEX_FILE=" + filepath.Join(tmpDir, "index")}
+
+	if _, err := g.runWithEnv([]string{"read-tree", "HEAD"}, env); err != nil {
+		return "", err
+	}
+	if _, err := g.runWithEnv([]string{"add", "-A"}, env); err != nil {
+		return "", err
+	}
+	out, err := g.runWithEnv([]string{"diff", "--cached", "--binary", "--no-color", "HEAD"}, env)
+	if err != nil || out == "" {
+		return "", err
+	}
+	return out + "\n", nil
+}
+
+// FormatPatch writes one mailbox-format patch per commit in base..head to
+// outDir and returns the files written, in commit order.
+func (g *Git) FormatPatch(base, head, outDir string) ([]string, error) {
+	out, err := g.run("format-patch", "--binary", "-o", outDir, base+".."+head)
+	if err != nil {
+		return nil, err
+	}
+	if out == "" {
+		return nil, nil
+	}
+	return strings.Split(out, "\n"), nil
+}
+
+// CreateBundle writes a bundle of the commits in base..ref to path. The
+// bundle's head is ref; base's commits are prerequisites the receiving
+// repo must already have.
+func (g *Git) CreateBundle(path, base, ref string) error {
+	_, err := g.run("bundle", "create", path, base+".."+ref)
+	return err
+}
+
 // DeleteRemoteBranch deletes a branch on the remote.
 func (g *Git) DeleteRemoteBranch(remote, branch string) error {
 	_, err := g.run("push", remote, "--delete", branch)
@@ -1008,6 +1298,45 @@ func (g *Git) Rebase(onto string) error {
 	return err
 }
 
+// maxRebaseAutoContinues bounds RebaseAutoContinue on a branch whose every
+// commit stops.
+const maxRebaseAutoContinues = 100
+
+// RebaseAutoContinue rebases the current branch onto the given ref and
+// carries on past trivial stops: conflicts git rerere resolves from a
+// recorded resolution (the rr-cache is shared by all worktrees of a repo),
+// and commits that end up empty because their change is already upstream.
+// On any other conflict it aborts, leaving the branch as it was, and returns
+// the conflicting files. autoResolved counts the stops it carried on past.
+func (g *Git) RebaseAutoContinue(onto string) (conflicts []string, autoResolved int, err error) {
+	cfg := []string{"-c", "rerere.enabled=true", "-c", "rerere.autoUpdate=true", "-c", "core.editor=true"}
+	_, err = g.run(append(cfg, "rebase", onto)...)
+	for err != nil {
+		if op, opErr := g.InProgressOperation(); opErr != nil || op != OpRebase {
+			return nil, autoResolved, err
+		}
+		files, filesErr := g.GetConflictingFiles()
+		if filesErr != nil || len(files) > 0 || autoResolved >= maxRebaseAutoContinues {
+			_ = g.AbortRebase()
+			if filesErr != nil {
+				return nil, autoResolved, filesErr
+			}
+			if len(files) == 0 {
+				return nil, autoResolved, fmt.Errorf("rebase onto %s stopped more than %d times", onto, maxRebaseAutoContinues)
+			}
+			return files, autoResolved, nil
+		}
+		autoResolved++
+		// Nothing staged means the resolved commit is empty: skip it.
+		if _, diffErr := g.run("diff", "--cached", "--quiet"); diffErr == nil {
+			_, err = g.run(append(cfg, "rebase", "--skip")...)
+		} else {
+			_, err = g.run(append(cfg, "rebase", "--continue")...)
+		}
+	}
+	return nil, autoResolved, nil
+}
+
 // AbortMerge aborts a merge in progress.
 func (g *Git) AbortMerge() error {
 	_, err := g.run("merge", "--abort")
@@ -1102,6 +1431,51 @@ func (g *Git) AbortRebase() error {
 	return err
 }
 
+// Git operations that can be left unfinished in a worktree, as reported by
+// InProgressOperation.
+const (
+	OpMerge      = "merge"
+	OpRebase     = "rebase"
+	OpCherryPick = "cherry-pick"
+	OpRevert     = "revert"
+)
+
+// InProgressOperation returns the git operation left unfinished in the
+// worktree (OpMerge, OpRebase, OpCherryPick, OpRevert), or "" if there is
+// none. It checks the state files git keeps in the worktree's git dir, so it
+// works in linked worktrees too.
+func (g *Git) InProgressOperation() (string, error) {
+	markers := []struct{ path, op string }{
+		{"rebase-merge", OpRebase},
+		{"rebase-apply", OpRebase},
+		{"MERGE_HEAD", OpMerge},
+		{"CHERRY_PICK_HEAD", OpCherryPick},
+		{"REVERT_HEAD", OpRevert},
+	}
+	args := []string{"rev-parse"}
+	for _, m := range markers {
+		args = append(args, "--git-path", m.path)
+	}
+	out, err := g.run(args...)
+	if err != nil {
+		return "", err
+	}
+	paths := strings.Split(out, "\n")
+	for i, m := range markers {
+		if i >= len(paths) {
+			break
+		}
+		p := paths[i]
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(g.workDir, p)
+		}
+		if _, err := os.Stat(p); err == nil {
+			return m.op, nil
+		}
+	}
+	return "", nil
+}
+
 // CreateBranch creates a new branch.
 func (g *Git) CreateBranch(name string) error {
 	_, err := g.run("branch", name)
@@ -1443,6 +1817,14 @@ func (g *Git) WorktreeMove(oldPath, newPath string) error {
 	return err
 }
 
+// WorktreeRepair re-links worktrees after their directories (or the
+// repository itself) were moved by hand. Run it on the repository and pass
+// the worktrees' new paths.
+func (g *Git) WorktreeRepair(paths ...string) error {
+	_, err := g.run(append([]string{"worktree", "repair"}, paths...)...)
+	return err
+}
+
 // WorktreePrune removes worktree entries for deleted paths.
 func (g *Git) WorktreePrune() error {
 	_, err := g.run("worktree", "prune")
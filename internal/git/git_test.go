@@ -4,6 +4,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 )
@@ -2141,3 +2142,403 @@ func TestBranchPushedToRemote_NoPushURL(t *testing.T) {
 		t.Errorf("BranchPushedToRemote unpushed = %d, want >= 1", unpushed)
 	}
 }
+
+func TestInProgressOperation(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+
+	if op, err := g.InProgressOperation(); err != nil || op != "" {
+		t.Fatalf("clean repo: InProgressOperation() = %q, %v", op, err)
+	}
+
+	// Two branches that change README.md differently.
+	base, err := g.CurrentBranch()
+	if err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "checkout", "-b", "other")
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("other\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "commit", "-am", "other")
+	runGit(t, dir, "checkout", base)
+	if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("base\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, dir, "commit", "-am", "base")
+
+	_ = g.Merge("other") // conflicts
+	if op, err := g.InProgressOperation(); err != nil || op != OpMerge {
+		t.Errorf("mid-merge: InProgressOperation() = %q, %v; want %q", op, err, OpMerge)
+	}
+	if files, _ := g.GetConflictingFiles(); len(files) != 1 || files[0] != "README.md" {
+		t.Errorf("conflicts = %v, want [README.md]", files)
+	}
+	if err := g.AbortMerge(); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = g.Rebase("other") // conflicts
+	if op, err := g.InProgressOperation(); err != nil || op != OpRebase {
+		t.Errorf("mid-rebase: InProgressOperation() = %q, %v; want %q", op, err, OpRebase)
+	}
+
+	// A linked worktree keeps its own state, separate from the main one.
+	wt := filepath.Join(t.TempDir(), "wt")
+	runGit(t, dir, "worktree", "add", "-b", "wt-branch", wt, "other")
+	if op, err := NewGit(wt).InProgressOperation(); err != nil || op != "" {
+		t.Errorf("linked worktree: InProgressOperation() = %q, %v; want none", op, err)
+	}
+}
+
+func TestDetectDefaultBranch(t *testing.T) {
+	gitEnv := append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	src := t.TempDir()
+	run("init", "-b", "trunk", src)
+	run("-C", src, "commit", "--allow-empty", "-m", "init")
+
+	bare := filepath.Join(t.TempDir(), "repo.git")
+	run("clone", "--bare", src, bare)
+	if got := NewGitWithDir(bare, "").DetectDefaultBranch(); got != "trunk" {
+		t.Errorf("bare clone: DetectDefaultBranch() = %q, want trunk", got)
+	}
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	run("clone", src, clone)
+	run("-C", clone, "checkout", "-b", "polecat/x")
+	if got := NewGit(clone).DetectDefaultBranch(); got != "trunk" {
+		t.Errorf("clone on feature branch: DetectDefaultBranch() = %q, want trunk", got)
+	}
+
+	// Without origin/HEAD, fall back to a well-known branch that exists on origin.
+	run("-C", clone, "remote", "set-head", "origin", "--delete")
+	run("-C", clone, "update-ref", "refs/remotes/origin/master", "HEAD")
+	if got := NewGit(clone).DetectDefaultBranch(); got != "master" {
+		t.Errorf("clone without origin/HEAD: DetectDefaultBranch() = %q, want master", got)
+	}
+
+	// No remote at all: don't guess.
+	if got := NewGit(src).DetectDefaultBranch(); got != "" {
+		t.Errorf("repo without remote: DetectDefaultBranch() = %q, want empty", got)
+	}
+}
+
+func TestShallowCloneAndDeepen(t *testing.T) {
+	gitEnv := append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	src := t.TempDir()
+	run("init", "-b", "main", src)
+	run("-C", src, "commit", "--allow-empty", "-m", "base")
+	run("-C", src, "branch", "feature")
+	for i := 0; i < 5; i++ {
+		run("-C", src, "commit", "--allow-empty", "-m", "main "+strconv.Itoa(i))
+	}
+	run("-C", src, "checkout", "-q", "feature")
+	run("-C", src, "commit", "--allow-empty", "-m", "feature work")
+	run("-C", src, "checkout", "-q", "main")
+	// --depth is ignored for plain local paths
+	url := "file://" + filepath.ToSlash(src)
+
+	bare := filepath.Join(t.TempDir(), "repo.git")
+	g := NewGitWithDir(bare, "")
+	if err := g.CloneBareShallow(url, bare, "main", "", "", 2); err != nil {
+		t.Fatalf("CloneBareShallow: %v", err)
+	}
+	if shallow, err := g.IsShallow(); err != nil || !shallow {
+		t.Fatalf("IsShallow() = %v, %v; want true", shallow, err)
+	}
+	if n := run("--git-dir", bare, "rev-list", "--count", "origin/main"); n != "2" {
+		t.Errorf("origin/main has %s commits after depth-2 clone, want 2", n)
+	}
+	if err := g.Deepen("origin", 2); err != nil {
+		t.Fatalf("Deepen: %v", err)
+	}
+	if n := run("--git-dir", bare, "rev-list", "--count", "origin/main"); n != "4" {
+		t.Errorf("origin/main has %s commits after deepening by 2, want 4", n)
+	}
+
+	clone := filepath.Join(t.TempDir(), "clone")
+	c := NewGit(clone)
+	if err := c.CloneBranchShallow(url, clone, "main", "", "", 1); err != nil {
+		t.Fatalf("CloneBranchShallow: %v", err)
+	}
+	run("-C", clone, "fetch", "-q", "--depth", "1", "origin", "feature:refs/remotes/origin/feature")
+	if _, err := c.MergeBase("origin/feature", "main"); err == nil {
+		t.Fatal("expected no merge base in a depth-1 clone")
+	}
+	if err := c.DeepenUntilMergeBase("origin", "origin/feature", "main"); err != nil {
+		t.Fatalf("DeepenUntilMergeBase: %v", err)
+	}
+	if _, err := c.MergeBase("origin/feature", "main"); err != nil {
+		t.Errorf("no merge base after DeepenUntilMergeBase: %v", err)
+	}
+
+	// Full-history repos are left alone.
+	if err := NewGit(src).DeepenUntilMergeBase("origin", "feature", "main"); err != nil {
+		t.Errorf("DeepenUntilMergeBase on a full repo: %v", err)
+	}
+}
+
+func TestRebaseAutoContinue(t *testing.T) {
+	dir := initTestRepo(t)
+	g := NewGit(dir)
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	commitFile := func(content, msg string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+		run("add", "a.txt")
+		run("commit", "-m", msg)
+	}
+
+	base := run("rev-parse", "--abbrev-ref", "HEAD")
+	commitFile("base\n", "base")
+	run("checkout", "-b", "feature")
+	commitFile("feature\n", "feature change")
+	featureTip := run("rev-parse", "HEAD")
+	run("checkout", base)
+	commitFile("upstream\n", "upstream change")
+	run("checkout", "feature")
+
+	// A real conflict aborts and leaves the branch alone.
+	conflicts, auto, err := g.RebaseAutoContinue(base)
+	if err != nil {
+		t.Fatalf("RebaseAutoContinue: %v", err)
+	}
+	if len(conflicts) != 1 || conflicts[0] != "a.txt" || auto != 0 {
+		t.Fatalf("conflicts = %v, auto = %d; want [a.txt], 0", conflicts, auto)
+	}
+	if op, _ := g.InProgressOperation(); op != "" {
+		t.Errorf("rebase left %q in progress", op)
+	}
+	if head := run("rev-parse", "HEAD"); head != featureTip {
+		t.Errorf("HEAD moved to %s after aborted rebase, want %s", head, featureTip)
+	}
+
+	// Record a resolution with rerere, then rewind and rebase again: the
+	// recorded resolution makes the conflict trivial.
+	run("config", "rerere.enabled", "true")
+	cmd := exec.Command("git", "rebase", base)
+	cmd.Dir = dir
+	_ = cmd.Run()
+	if err := os.WriteFile(filepath.Join(dir, "a.txt"), []byte("resolved\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("add", "a.txt")
+	run("-c", "core.editor=true", "rebase", "--continue")
+	run("reset", "--hard", featureTip)
+
+	conflicts, auto, err = g.RebaseAutoContinue(base)
+	if err != nil {
+		t.Fatalf("RebaseAutoContinue with recorded resolution: %v", err)
+	}
+	if len(conflicts) != 0 || auto != 1 {
+		t.Fatalf("conflicts = %v, auto = %d; want none, 1", conflicts, auto)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "a.txt")); string(data) != "resolved\n" {
+		t.Errorf("a.txt = %q, want the recorded resolution", data)
+	}
+	if ok, err := g.IsAncestor(base, "HEAD"); err != nil || !ok {
+		t.Errorf("%s is not an ancestor of HEAD after rebase (%v)", base, err)
+	}
+}
+
+func TestWorktreeConfigOnBareRepo(t *testing.T) {
+	gitEnv := append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+
+	src := t.TempDir()
+	run("init", "-b", "main", src)
+	run("-C", src, "commit", "--allow-empty", "-m", "base")
+	bare := filepath.Join(t.TempDir(), "repo.git")
+	run("clone", "--bare", src, bare)
+	wtA := filepath.Join(t.TempDir(), "a")
+	wtB := filepath.Join(t.TempDir(), "b")
+	run("-C", bare, "worktree", "add", "-b", "a", wtA)
+	run("-C", bare, "worktree", "add", "-b", "b", wtB)
+
+	g := NewGit(wtA)
+	for i := 0; i < 2; i++ { // idempotent
+		if err := g.EnableWorktreeConfig(); err != nil {
+			t.Fatalf("EnableWorktreeConfig: %v", err)
+		}
+	}
+	if err := g.SetWorktreeConfig("commit.gpgsign", "true"); err != nil {
+		t.Fatalf("SetWorktreeConfig: %v", err)
+	}
+
+	if v, _ := g.ConfigGet("commit.gpgsign"); v != "true" {
+		t.Errorf("worktree a commit.gpgsign = %q, want true", v)
+	}
+	if v, _ := NewGit(wtB).ConfigGet("commit.gpgsign"); v != "" {
+		t.Errorf("worktree b commit.gpgsign = %q, want unset", v)
+	}
+	// Linked worktrees must still be work trees, and the shared repo still bare.
+	if got := run("-C", wtB, "rev-parse", "--is-bare-repository"); got != "false" {
+		t.Errorf("worktree b is-bare = %s, want false", got)
+	}
+	run("-C", wtB, "status")
+	if got := run("-C", bare, "rev-parse", "--is-bare-repository"); got != "true" {
+		t.Errorf("shared repo is-bare = %s, want true", got)
+	}
+}
+
+func TestUsesLFS(t *testing.T) {
+	gitEnv := append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	dir := t.TempDir()
+	run("init", "-b", "main", dir)
+	if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.txt text\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("-C", dir, "add", ".")
+	run("-C", dir, "commit", "-m", "attrs")
+
+	g := NewGit(dir)
+	if uses, err := g.UsesLFS(); err != nil || uses {
+		t.Fatalf("UsesLFS() = %v, %v; want false", uses, err)
+	}
+
+	// A filter=lfs rule in a nested .gitattributes counts.
+	if err := os.MkdirAll(filepath.Join(dir, "assets"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "assets", ".gitattributes"), []byte("*.png filter=lfs diff=lfs merge=lfs -text\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("-C", dir, "add", ".")
+	run("-C", dir, "commit", "-m", "lfs")
+	if uses, err := g.UsesLFS(); err != nil || !uses {
+		t.Fatalf("UsesLFS() = %v, %v; want true", uses, err)
+	}
+}
+
+func TestExportHelpers(t *testing.T) {
+	gitEnv := append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	run := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	write := func(dir, name, content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := t.TempDir()
+	run("init", "-b", "main", dir)
+	write(dir, "a.txt", "one\n")
+	run("-C", dir, "add", ".")
+	run("-C", dir, "commit", "-m", "base")
+	run("-C", dir, "checkout", "-q", "-b", "feature")
+	for _, n := range []string{"b", "c"} {
+		write(dir, n+".txt", n+"\n")
+		run("-C", dir, "add", ".")
+		run("-C", dir, "commit", "-m", "add "+n)
+	}
+
+	g := NewGit(dir)
+	if diff, err := g.WorkingTreeDiff(); err != nil || diff != "" {
+		t.Fatalf("clean WorkingTreeDiff() = %q, %v; want empty", diff, err)
+	}
+
+	// Uncommitted: a modified tracked file and an untracked one.
+	write(dir, "a.txt", "one\ntwo\n")
+	write(dir, "new.txt", "new\n")
+	diff, err := g.WorkingTreeDiff()
+	if err != nil {
+		t.Fatalf("WorkingTreeDiff: %v", err)
+	}
+	if !strings.Contains(diff, "+two") || !strings.Contains(diff, "new.txt") {
+		t.Errorf("WorkingTreeDiff() missing changes:\n%s", diff)
+	}
+	if staged := run("-C", dir, "diff", "--cached", "--name-only"); staged != "" {
+		t.Errorf("WorkingTreeDiff touched the real index: %q staged", staged)
+	}
+
+	patchDir := filepath.Join(t.TempDir(), "patches")
+	files, err := g.FormatPatch("main", "HEAD", patchDir)
+	if err != nil {
+		t.Fatalf("FormatPatch: %v", err)
+	}
+	if len(files) != 2 || !strings.HasSuffix(files[0], "0001-add-b.patch") {
+		t.Errorf("FormatPatch() = %v, want two patches starting with add-b", files)
+	}
+
+	bundle := filepath.Join(t.TempDir(), "work.bundle")
+	if err := g.CreateBundle(bundle, "main", "feature"); err != nil {
+		t.Fatalf("CreateBundle: %v", err)
+	}
+	// A repo with the base commit can fetch the branch from the bundle and
+	// apply the uncommitted diff on top.
+	other := filepath.Join(t.TempDir(), "other")
+	run("clone", "-q", "-b", "main", dir, other)
+	run("-C", other, "fetch", "-q", bundle, "feature:feature")
+	run("-C", other, "checkout", "-q", "feature")
+	diffFile := filepath.Join(t.TempDir(), "uncommitted.diff")
+	if err := os.WriteFile(diffFile, []byte(diff), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("-C", other, "apply", diffFile)
+	if data, err := os.ReadFile(filepath.Join(other, "new.txt")); err != nil || string(data) != "new\n" {
+		t.Errorf("applied diff: new.txt = %q, %v", data, err)
+	}
+}
@@ -0,0 +1,171 @@
+package git
+
+import (
+	"errors"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/logging"
+)
+
+// Retry constants for network git operations (clone, fetch, pull, push).
+// Four attempts with 1s, 2s, 4s backoff ride out a momentary network blip
+// without stalling a polecat for long on a remote that is really down.
+const (
+	networkMaxAttempts = 4
+	networkBaseBackoff = 1 * time.Second
+	networkBackoffMax  = 15 * time.Second
+)
+
+// networkRetrySleep is time.Sleep, swapped out in tests.
+var networkRetrySleep = time.Sleep
+
+// permanentNetworkErrors mark failures retrying can't fix: auth, missing
+// repos or refs, and rejected pushes. They are checked before the transient
+// patterns because git often reports them alongside a transient-looking
+// "Could not read from remote repository".
+var permanentNetworkErrors = []string{
+	"permission denied",
+	"authentication failed",
+	"could not read username",
+	"repository not found",
+	"does not appear to be a git repository",
+	"couldn't find remote ref",
+	"[rejected]",
+	"[remote rejected]",
+	"non-fast-forward",
+	"pre-receive hook declined",
+	"protected branch",
+}
+
+// transientNetworkErrors mark failures that are worth retrying: DNS,
+// connection and TLS errors, dropped transfers, and server-side 5xx/429s.
+var transientNetworkErrors = []string{
+	"could not resolve host",
+	"temporary failure in name resolution",
+	"name or service not known",
+	"connection timed out",
+	"operation timed out",
+	"connection reset",
+	"connection refused",
+	"connection closed",
+	"broken pipe",
+	"network is unreachable",
+	"no route to host",
+	"the remote end hung up unexpectedly",
+	"early eof",
+	"rpc failed",
+	"unexpected disconnect",
+	"ssl_read",
+	"ssl_connect",
+	"gnutls",
+	"tls handshake",
+	"kex_exchange_identification",
+	"ssh_exchange_identification",
+	"could not read from remote repository",
+	"internal server error",
+	"bad gateway",
+	"service unavailable",
+	"gateway time-out",
+	"gateway timeout",
+	"too many requests",
+	"error: 500",
+	"error: 502",
+	"error: 503",
+	"error: 504",
+	"error: 429",
+}
+
+// IsTransientNetworkError reports whether err from a network git operation
+// looks like a momentary network or server failure that a retry may fix,
+// as opposed to an auth, ref or rejection failure that it won't.
+func IsTransientNetworkError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	var ge *GitError
+	if errors.As(err, &ge) {
+		msg = ge.Stderr + "\n" + ge.Stdout
+	}
+	msg = strings.ToLower(msg)
+	for _, p := range permanentNetworkErrors {
+		if strings.Contains(msg, p) {
+			return false
+		}
+	}
+	for _, p := range transientNetworkErrors {
+		if strings.Contains(msg, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// networkBackoff calculates exponential backoff with ±25% jitter for a given attempt (1-indexed).
+// Formula: base * 2^(attempt-1) * (1 ± 25% random), capped at networkBackoffMax.
+func networkBackoff(attempt int) time.Duration {
+	backoff := networkBaseBackoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff > networkBackoffMax {
+			backoff = networkBackoffMax
+			break
+		}
+	}
+	// Apply ±25% jitter
+	jitter := 1.0 + (rand.Float64()-0.5)*0.5 // range [0.75, 1.25]
+	result := time.Duration(float64(backoff) * jitter)
+	if result > networkBackoffMax {
+		result = networkBackoffMax
+	}
+	return result
+}
+
+// withNetworkRetry runs op, retrying with backoff while it fails with a
+// transient network error (see IsTransientNetworkError). Other errors, and
+// the last transient one, are returned as is. name identifies the
+// operation in the log (e.g. "fetch").
+func withNetworkRetry(name string, op func() error) error {
+	var err error
+	for attempt := 1; attempt <= networkMaxAttempts; attempt++ {
+		if err = op(); err == nil || !IsTransientNetworkError(err) {
+			return err
+		}
+		if attempt < networkMaxAttempts {
+			backoff := networkBackoff(attempt)
+			logging.Component("git").Info("transient network failure, retrying",
+				"op", name, "attempt", attempt, "backoff", backoff, "err", err)
+			networkRetrySleep(backoff)
+		}
+	}
+	logging.Component("git").Info("network operation failed after retries",
+		"op", name, "attempts", networkMaxAttempts, "err", err)
+	return err
+}
+
+// runNetwork is run for commands that talk to a remote, retried on
+// transient network failures. Only use it for operations that are safe to
+// repeat: fetches, and pushes (a push whose ref update landed before the
+// connection dropped is a no-op the second time).
+func (g *Git) runNetwork(args ...string) (string, error) {
+	var out string
+	err := withNetworkRetry(args[0], func() error {
+		var err error
+		out, err = g.run(args...)
+		return err
+	})
+	return out, err
+}
+
+// runNetworkWithEnv is runNetwork with additional environment variables.
+func (g *Git) runNetworkWithEnv(args []string, extraEnv []string) (string, error) {
+	var out string
+	err := withNetworkRetry(args[0], func() error {
+		var err error
+		out, err = g.runWithEnv(args, extraEnv)
+		return err
+	})
+	return out, err
+}
@@ -0,0 +1,90 @@
+package git
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestIsTransientNetworkError(t *testing.T) {
+	tests := []struct {
+		name   string
+		stderr string
+		want   bool
+	}{
+		{"dns", "fatal: unable to access 'https://github.com/x/y.git/': Could not resolve host: github.com", true},
+		{"hung up", "error: RPC failed; curl 56 GnuTLS recv error (-9)\nfatal: the remote end hung up unexpectedly\nfatal: early EOF", true},
+		{"ssh reset", "kex_exchange_identification: read: Connection reset by peer\nfatal: Could not read from remote repository.", true},
+		{"server 503", "fatal: unable to access 'https://example.com/r.git/': The requested URL returned error: 503", true},
+		{"auth", "git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.", false},
+		{"missing repo", "fatal: '/nope' does not appear to be a git repository\nfatal: Could not read from remote repository.", false},
+		{"rejected", " ! [rejected]        main -> main (non-fast-forward)\nerror: failed to push some refs", false},
+		{"missing ref", "fatal: couldn't find remote ref feature/x", false},
+		{"conflict", "CONFLICT (content): Merge conflict in a.go", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := &GitError{Command: "push", Stderr: tt.stderr, Err: errors.New("exit status 128")}
+			if got := IsTransientNetworkError(err); got != tt.want {
+				t.Errorf("IsTransientNetworkError(%q) = %v, want %v", tt.stderr, got, tt.want)
+			}
+		})
+	}
+	if IsTransientNetworkError(nil) {
+		t.Error("IsTransientNetworkError(nil) = true")
+	}
+}
+
+func TestWithNetworkRetry(t *testing.T) {
+	var slept []time.Duration
+	orig := networkRetrySleep
+	networkRetrySleep = func(d time.Duration) { slept = append(slept, d) }
+	t.Cleanup(func() { networkRetrySleep = orig })
+
+	transient := &GitError{Stderr: "fatal: the remote end hung up unexpectedly"}
+	permanent := &GitError{Stderr: "fatal: Authentication failed"}
+
+	// Recovers after transient failures.
+	calls := 0
+	err := withNetworkRetry("test", func() error {
+		calls++
+		if calls < 3 {
+			return transient
+		}
+		return nil
+	})
+	if err != nil || calls != 3 || len(slept) != 2 {
+		t.Errorf("recovering: err=%v calls=%d sleeps=%d, want nil/3/2", err, calls, len(slept))
+	}
+
+	// Gives up after networkMaxAttempts, returning the last error.
+	calls, slept = 0, nil
+	err = withNetworkRetry("test", func() error { calls++; return transient })
+	if err != transient || calls != networkMaxAttempts || len(slept) != networkMaxAttempts-1 {
+		t.Errorf("exhausted: err=%v calls=%d sleeps=%d", err, calls, len(slept))
+	}
+	for i, d := range slept {
+		if d <= 0 || d > networkBackoffMax {
+			t.Errorf("sleep %d = %v, want in (0, %v]", i, d, networkBackoffMax)
+		}
+	}
+
+	// Permanent errors are not retried.
+	calls, slept = 0, nil
+	err = withNetworkRetry("test", func() error { calls++; return permanent })
+	if err != permanent || calls != 1 || len(slept) != 0 {
+		t.Errorf("permanent: err=%v calls=%d sleeps=%d, want 1 call and no sleep", err, calls, len(slept))
+	}
+}
+
+func TestPushToMissingRemoteNotRetried(t *testing.T) {
+	orig := networkRetrySleep
+	networkRetrySleep = func(time.Duration) { t.Fatal("push to a missing remote was retried") }
+	t.Cleanup(func() { networkRetrySleep = orig })
+
+	g := NewGit(initTestRepo(t))
+	if err := g.Push(filepath.Join(t.TempDir(), "missing.git"), "HEAD", false); err == nil {
+		t.Fatal("expected push to a missing remote to fail")
+	}
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// issuesPerPage is the page size for listing issues (GitHub's maximum).
+const issuesPerPage = 100
+
+// Issue is a GitHub issue as used by bead sync.
+type Issue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"` // "open" or "closed"
+	HTMLURL   string    `json:"html_url"`
+	Assignees []string  `json:"assignees"`
+	Labels    []string  `json:"labels"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IssueUpdate holds the fields to change on an issue. Nil fields are left
+// unchanged.
+type IssueUpdate struct {
+	Title     *string   `json:"title,omitempty"`
+	State     *string   `json:"state,omitempty"`
+	Assignees *[]string `json:"assignees,omitempty"`
+}
+
+// restIssue is the REST shape of an issue, before flattening users and labels.
+type restIssue struct {
+	Number    int       `json:"number"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	State     string    `json:"state"`
+	HTMLURL   string    `json:"html_url"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+func (r restIssue) issue() Issue {
+	is := Issue{
+		Number:    r.Number,
+		Title:     r.Title,
+		Body:      r.Body,
+		State:     r.State,
+		HTMLURL:   r.HTMLURL,
+		UpdatedAt: r.UpdatedAt,
+	}
+	for _, a := range r.Assignees {
+		is.Assignees = append(is.Assignees, a.Login)
+	}
+	for _, l := range r.Labels {
+		is.Labels = append(is.Labels, l.Name)
+	}
+	return is
+}
+
+// ListIssues returns all issues (open and closed) in a repo carrying label.
+// Pull requests, which the issues API also returns, are skipped.
+func (c *Client) ListIssues(ctx context.Context, owner, repo, label string) ([]Issue, error) {
+	var issues []Issue
+	for page := 1; ; page++ {
+		q := url.Values{}
+		q.Set("state", "all")
+		q.Set("per_page", strconv.Itoa(issuesPerPage))
+		q.Set("page", strconv.Itoa(page))
+		if label != "" {
+			q.Set("labels", label)
+		}
+		var resp []restIssue
+		path := fmt.Sprintf("/repos/%s/%s/issues?%s", owner, repo, q.Encode())
+		if err := c.restRequest(ctx, "GET", path, nil, &resp); err != nil {
+			return nil, fmt.Errorf("list issues: %w", err)
+		}
+		for _, r := range resp {
+			if r.PullRequest == nil {
+				issues = append(issues, r.issue())
+			}
+		}
+		if len(resp) < issuesPerPage {
+			return issues, nil
+		}
+	}
+}
+
+// GetIssue returns one issue by number.
+func (c *Client) GetIssue(ctx context.Context, owner, repo string, number int) (Issue, error) {
+	var resp restIssue
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d", owner, repo, number)
+	if err := c.restRequest(ctx, "GET", path, nil, &resp); err != nil {
+		return Issue{}, fmt.Errorf("get issue #%d: %w", number, err)
+	}
+	return resp.issue(), nil
+}
+
+// CreateIssue opens a new issue.
+func (c *Client) CreateIssue(ctx context.Context, owner, repo, title, body string, labels, assignees []string) (Issue, error) {
+	reqBody := map[string]any{
+		"title": title,
+		"body":  body,
+	}
+	if len(labels) > 0 {
+		reqBody["labels"] = labels
+	}
+	if len(assignees) > 0 {
+		reqBody["assignees"] = assignees
+	}
+	var resp restIssue
+	path := fmt.Sprintf("/repos/%s/%s/issues", owner, repo)
+	if err := c.restRequest(ctx, "POST", path, reqBody, &resp); err != nil {
+		return Issue{}, fmt.Errorf("create issue: %w", err)
+	}
+	return resp.issue(), nil
+}
+
+// UpdateIssue changes an issue's title, state, or assignees.
+func (c *Client) UpdateIssue(ctx context.Context, owner, repo string, number int, update IssueUpdate) error {
+	path := fmt.Sprintf("/repos/%s/%s/issues/%d", owner, repo, number)
+	if err := c.restRequest(ctx, "PATCH", path, update, nil); err != nil {
+		return fmt.Errorf("update issue #%d: %w", number, err)
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListIssues_PaginatesAndSkipsPRs(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/octo/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gastown", r.URL.Query().Get("labels"))
+		assert.Equal(t, "all", r.URL.Query().Get("state"))
+
+		var page []map[string]any
+		if r.URL.Query().Get("page") == "1" {
+			for i := 1; i <= issuesPerPage; i++ {
+				item := map[string]any{"number": i, "title": fmt.Sprintf("Issue %d", i), "state": "open"}
+				if i == 2 {
+					item["pull_request"] = map[string]any{}
+				}
+				page = append(page, item)
+			}
+		} else {
+			page = append(page, map[string]any{
+				"number":    101,
+				"title":     "Last",
+				"state":     "closed",
+				"assignees": []map[string]any{{"login": "octocat"}},
+				"labels":    []map[string]any{{"name": "gastown"}},
+			})
+		}
+		json.NewEncoder(w).Encode(page)
+	})
+
+	c, _ := newTestClient(t, mux)
+	issues, err := c.ListIssues(t.Context(), "octo", "repo", "gastown")
+	require.NoError(t, err)
+	require.Len(t, issues, issuesPerPage) // 100 + 1, minus the PR
+	last := issues[len(issues)-1]
+	assert.Equal(t, 101, last.Number)
+	assert.Equal(t, "closed", last.State)
+	assert.Equal(t, []string{"octocat"}, last.Assignees)
+	assert.Equal(t, []string{"gastown"}, last.Labels)
+}
+
+func TestCreateIssue(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /repos/octo/repo/issues", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Fix it", body["title"])
+		assert.Equal(t, []any{"gastown"}, body["labels"])
+		assert.NotContains(t, body, "assignees")
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{
+			"number":   9,
+			"title":    "Fix it",
+			"state":    "open",
+			"html_url": "https://github.com/octo/repo/issues/9",
+		})
+	})
+
+	c, _ := newTestClient(t, mux)
+	issue, err := c.CreateIssue(t.Context(), "octo", "repo", "Fix it", "body", []string{"gastown"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 9, issue.Number)
+	assert.Equal(t, "https://github.com/octo/repo/issues/9", issue.HTMLURL)
+}
+
+func TestUpdateIssue_OnlySendsSetFields(t *testing.T) {
+	t.Parallel()
+	mux := http.NewServeMux()
+	mux.HandleFunc("PATCH /repos/octo/repo/issues/9", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]any{"state": "closed", "assignees": []any{}}, body)
+		w.Write([]byte(`{}`))
+	})
+
+	c, _ := newTestClient(t, mux)
+	closed := "closed"
+	none := []string{}
+	err := c.UpdateIssue(t.Context(), "octo", "repo", 9, IssueUpdate{State: &closed, Assignees: &none})
+	require.NoError(t, err)
+}
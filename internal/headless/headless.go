@@ -0,0 +1,192 @@
+// Package headless runs town agents (the Mayor and Deacon) as supervised
+// background processes instead of tmux sessions.
+//
+// A headless agent has no terminal. A supervisor process ('gt headless run
+// <role>') runs the agent's non-interactive mode once per cycle with a
+// prompt to do one round of its work, appends the output to a log file, and
+// waits out the configured interval before the next cycle. Failed cycles are
+// retried with exponential backoff.
+package headless
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/runtime"
+	"github.com/steveyegge/gastown/internal/session"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// DefaultInterval is the pause between cycles when none is configured.
+const DefaultInterval = 5 * time.Minute
+
+// startupGrace is how long Start waits for the supervisor to write its
+// PID file.
+const startupGrace = 2 * time.Second
+
+// Common errors
+var (
+	ErrAlreadyRunning = errors.New("headless agent already running")
+	ErrNotRunning     = errors.New("headless agent not running")
+)
+
+// Roles are the agents that can run headless.
+var Roles = []string{constants.RoleMayor, constants.RoleDeacon}
+
+// Enabled reports whether the town runs role headless.
+func Enabled(townRoot, role string) bool {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil {
+		return false
+	}
+	return settings.IsHeadless(role)
+}
+
+// Interval returns the configured pause between cycles.
+func Interval(townRoot string) time.Duration {
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err == nil && settings.Headless != nil && settings.Headless.Interval != "" {
+		if d, err := time.ParseDuration(settings.Headless.Interval); err == nil && d > 0 {
+			return d
+		}
+	}
+	return DefaultInterval
+}
+
+// PidFilePath returns the path of the supervisor's PID file for role.
+func PidFilePath(townRoot, role string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "headless", role+".pid")
+}
+
+// LogFilePath returns the path of the log file for role.
+func LogFilePath(townRoot, role string) string {
+	return filepath.Join(townRoot, "logs", "headless-"+role+".log")
+}
+
+// WorkDir returns the directory the agent runs in.
+func WorkDir(townRoot, role string) string {
+	return filepath.Join(townRoot, role)
+}
+
+// Running returns the supervisor's PID and whether it is alive.
+func Running(townRoot, role string) (int, bool) {
+	data, err := os.ReadFile(PidFilePath(townRoot, role)) //nolint:gosec // G304: path is constructed from trusted townRoot
+	if err != nil {
+		return 0, false
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, false
+	}
+	return pid, processAlive(pid)
+}
+
+// Start launches the supervisor for role as a detached 'gt headless run'
+// process.
+func Start(townRoot, role string) error {
+	if _, running := Running(townRoot, role); running {
+		return ErrAlreadyRunning
+	}
+	// Agent settings (hooks, plugins) are installed the same way a tmux
+	// session would install them.
+	dir := WorkDir(townRoot, role)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating %s directory: %w", role, err)
+	}
+	rc := config.ResolveRoleAgentConfig(role, townRoot, "")
+	if err := runtime.EnsureSettingsForRole(dir, dir, role, rc); err != nil {
+		return fmt.Errorf("ensuring runtime settings: %w", err)
+	}
+
+	gtPath, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating gt binary: %w", err)
+	}
+
+	cmd := exec.Command(gtPath, "headless", "run", role)
+	cmd.Dir = townRoot
+	// Detach from parent I/O; the supervisor writes its own log.
+	cmd.Stdin = nil
+	cmd.Stdout = nil
+	cmd.Stderr = nil
+	util.SetDetachedProcessGroup(cmd)
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("starting headless %s: %w", role, err)
+	}
+	_ = cmd.Process.Release()
+
+	deadline := time.Now().Add(startupGrace)
+	for time.Now().Before(deadline) {
+		if _, running := Running(townRoot, role); running {
+			return nil
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	return fmt.Errorf("headless %s failed to start (see %s)", role, LogFilePath(townRoot, role))
+}
+
+// Stop terminates the supervisor for role, which stops any cycle in
+// progress.
+func Stop(townRoot, role string) error {
+	pid, running := Running(townRoot, role)
+	if !running {
+		_ = os.Remove(PidFilePath(townRoot, role))
+		return ErrNotRunning
+	}
+	p, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	if err := terminate(p); err != nil {
+		return fmt.Errorf("stopping headless %s (PID %d): %w", role, pid, err)
+	}
+	return nil
+}
+
+// Prompt returns the prompt each cycle runs with.
+func Prompt(role string, interval time.Duration) string {
+	beacon := session.FormatStartupBeacon(session.BeaconConfig{
+		Recipient: role,
+		Sender:    "daemon",
+		Topic:     "headless",
+	})
+	work := "check your mail and hooked work and act on it."
+	if role == constants.RoleDeacon {
+		work = "run one patrol cycle."
+	}
+	return fmt.Sprintf("%s\n\nYou are running headless: there is no terminal and nobody will attach. "+
+		"Run 'gt prime' for your context, then %s When you are done, exit. "+
+		"You will be run again in %s.", beacon, work, interval)
+}
+
+// Command returns the agent invocation for one cycle of role. The process is
+// killed if ctx is canceled.
+func Command(ctx context.Context, townRoot, role string, interval time.Duration) (*exec.Cmd, error) {
+	dir := WorkDir(townRoot, role)
+	rc := config.ResolveRoleAgentConfig(role, townRoot, "")
+	args, err := rc.BuildNonInteractiveArgs(Prompt(role, interval))
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+	for k, v := range session.AgentSessionEnv(config.AgentEnvConfig{Role: role, TownRoot: townRoot}, "") {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	for k, v := range rc.Env {
+		cmd.Env = append(cmd.Env, k+"="+v)
+	}
+	cmd.Env = append(cmd.Env, "GT_HEADLESS=1")
+	return cmd, nil
+}
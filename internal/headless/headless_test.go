@@ -0,0 +1,96 @@
+package headless
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func writeSettings(t *testing.T, townRoot, body string) {
+	t.Helper()
+	path := config.TownSettingsPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestEnabledAndInterval(t *testing.T) {
+	townRoot := t.TempDir()
+	if Enabled(townRoot, "mayor") {
+		t.Error("headless should be off without settings")
+	}
+	if got := Interval(townRoot); got != DefaultInterval {
+		t.Errorf("Interval = %s, want default %s", got, DefaultInterval)
+	}
+
+	writeSettings(t, townRoot, `{"type":"town-settings","version":1,"headless":{"roles":["deacon"],"interval":"90s"}}`)
+	if Enabled(townRoot, "mayor") {
+		t.Error("mayor is not in headless roles")
+	}
+	if !Enabled(townRoot, "deacon") {
+		t.Error("deacon should be headless")
+	}
+	if got := Interval(townRoot); got != 90*time.Second {
+		t.Errorf("Interval = %s, want 90s", got)
+	}
+}
+
+func TestRunning_StalePidFile(t *testing.T) {
+	townRoot := t.TempDir()
+	if _, running := Running(townRoot, "mayor"); running {
+		t.Fatal("no PID file should mean not running")
+	}
+
+	path := PidFilePath(townRoot, "mayor")
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// PIDs this high are never allocated.
+	if err := os.WriteFile(path, []byte("999999999"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, running := Running(townRoot, "mayor"); running {
+		t.Error("stale PID should not count as running")
+	}
+	if err := Stop(townRoot, "mayor"); err != ErrNotRunning {
+		t.Errorf("Stop = %v, want ErrNotRunning", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("Stop should remove a stale PID file")
+	}
+}
+
+func TestNextDelay(t *testing.T) {
+	interval := 5 * time.Minute
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, interval},
+		{1, 30 * time.Second},
+		{2, time.Minute},
+		{3, 2 * time.Minute},
+		{10, maxBackoff},
+	}
+	for _, tt := range tests {
+		if got := nextDelay(interval, tt.failures); got != tt.want {
+			t.Errorf("nextDelay(%d failures) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	p := Prompt("deacon", 5*time.Minute)
+	for _, want := range []string{"headless", "gt prime", "patrol", "5m0s"} {
+		if !strings.Contains(p, want) {
+			t.Errorf("prompt missing %q:\n%s", want, p)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+//go:build !windows
+
+package headless
+
+import (
+	"os"
+	"syscall"
+)
+
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
+
+// terminate asks the supervisor to stop; it kills its current cycle and
+// removes its PID file on the way out.
+func terminate(p *os.Process) error {
+	return p.Signal(syscall.SIGTERM)
+}
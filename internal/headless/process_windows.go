@@ -0,0 +1,29 @@
+//go:build windows
+
+package headless
+
+import (
+	"math"
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// processAlive reports whether a process with the given PID exists and is
+// still running. ERROR_ACCESS_DENIED still means the process is alive.
+func processAlive(pid int) bool {
+	if pid <= 0 || pid > math.MaxUint32 {
+		return false
+	}
+	handle, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, uint32(pid))
+	if err != nil {
+		return err == windows.ERROR_ACCESS_DENIED
+	}
+	_ = windows.CloseHandle(handle)
+	return true
+}
+
+// terminate kills the supervisor; Windows has no SIGTERM to deliver.
+func terminate(p *os.Process) error {
+	return p.Kill()
+}
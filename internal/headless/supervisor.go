@@ -0,0 +1,105 @@
+package headless
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Backoff bounds for failed cycles.
+const (
+	initialBackoff = 30 * time.Second
+	maxBackoff     = 10 * time.Minute
+)
+
+// nextDelay returns how long to wait before the next cycle: the interval
+// after a clean exit, otherwise a backoff that doubles with each
+// consecutive failure, capped at maxBackoff.
+func nextDelay(interval time.Duration, failures int) time.Duration {
+	if failures == 0 {
+		return interval
+	}
+	d := initialBackoff
+	for i := 1; i < failures && d < maxBackoff; i++ {
+		d *= 2
+	}
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+	return d
+}
+
+// Supervise runs role's cycles until ctx is canceled, logging to the role's
+// log file. It owns the role's PID file for its lifetime.
+func Supervise(ctx context.Context, townRoot, role string) error {
+	if _, running := Running(townRoot, role); running {
+		return ErrAlreadyRunning
+	}
+
+	pidPath := PidFilePath(townRoot, role)
+	if err := os.MkdirAll(filepath.Dir(pidPath), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		return fmt.Errorf("writing PID file: %w", err)
+	}
+	defer os.Remove(pidPath)
+
+	logPath := LogFilePath(townRoot, role)
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return err
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644) //nolint:gosec // G302: log file, not secret
+	if err != nil {
+		return fmt.Errorf("opening log: %w", err)
+	}
+	defer logFile.Close()
+
+	logf := func(format string, args ...interface{}) {
+		fmt.Fprintf(logFile, "=== %s %s\n", time.Now().Format(time.RFC3339), fmt.Sprintf(format, args...))
+	}
+	logf("headless %s supervisor started (PID %d)", role, os.Getpid())
+
+	failures := 0
+	for {
+		interval := Interval(townRoot)
+		if err := runCycle(ctx, townRoot, role, interval, logFile, logf); err != nil {
+			failures++
+			logf("cycle failed (%d in a row): %v", failures, err)
+		} else {
+			failures = 0
+		}
+
+		delay := nextDelay(interval, failures)
+		logf("next cycle in %s", delay)
+		select {
+		case <-ctx.Done():
+			logf("headless %s supervisor stopping", role)
+			return nil
+		case <-time.After(delay):
+		}
+	}
+}
+
+// runCycle runs the agent once, streaming its output to out.
+func runCycle(ctx context.Context, townRoot, role string, interval time.Duration, out io.Writer, logf func(string, ...interface{})) error {
+	cmd, err := Command(ctx, townRoot, role, interval)
+	if err != nil {
+		return err
+	}
+	cmd.Stdout = out
+	cmd.Stderr = out
+
+	logf("cycle start: %s", cmd.Path)
+	start := time.Now()
+	err = cmd.Run()
+	if ctx.Err() != nil {
+		return nil // Stopped, not failed
+	}
+	logf("cycle done in %s", time.Since(start).Round(time.Second))
+	return err
+}
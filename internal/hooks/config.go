@@ -12,6 +12,9 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/state"
 )
 
 // HookEntry represents a single hook matcher with its associated hooks.
@@ -175,6 +178,7 @@ type Target struct {
 	Rig      string // Rig name or empty for town-level
 	Role     string // Informational only — does NOT participate in override resolution (Key does). Singular form matching RoleSettingsDir: crew, witness, refinery, polecat, mayor, deacon.
 	Provider string // Hook provider: "claude" (default/empty) or "gemini", etc.
+	TownRoot string // Town the target belongs to, for its shared hooks config (see ComputeExpectedForTown)
 }
 
 // DisplayKey returns a human-readable label for the target.
@@ -225,6 +229,25 @@ func DefaultOverrides() map[string]*HooksConfig {
 					},
 				},
 			},
+			// Protected-branch guard: polecats land through the refinery, so a
+			// commit or merge on main (or any branches.protected entry) is
+			// always a mistake. Pushes are covered by the base push guard.
+			PreToolUse: []HookEntry{
+				{
+					Matcher: "Bash(*git commit*)",
+					Hooks: []Hook{{
+						Type:    "command",
+						Command: hookChain(pathSetup, "gt tap guard protected-branch"),
+					}},
+				},
+				{
+					Matcher: "Bash(*git merge*)",
+					Hooks: []Hook{{
+						Type:    "command",
+						Command: hookChain(pathSetup, "gt tap guard protected-branch"),
+					}},
+				},
+			},
 		},
 		// Crew workers: auto-cycle session on context compaction (gt-op78).
 		// Instead of compacting (lossy), replace with fresh session that
@@ -363,18 +386,36 @@ func DefaultOverrides() map[string]*HooksConfig {
 // are merged first, then on-disk overrides layer on top. On-disk overrides can
 // replace or extend base hooks by providing matching PreToolUse entries.
 func ComputeExpected(target string) (*HooksConfig, error) {
-	base, err := LoadBase()
-	if err != nil {
-		if os.IsNotExist(err) {
-			base = DefaultBase()
-		} else {
-			return nil, fmt.Errorf("loading base config: %w", err)
+	return ComputeExpectedForTown("", target)
+}
+
+// ComputeExpectedForTown is ComputeExpected for a target in the town at
+// townRoot. When the town has synced a shared config ('gt config sync'), its
+// hooks base and overrides layer between the built-in defaults and the
+// user's own on-disk configs, so a team's policies apply everywhere while
+// each user can still override them. An empty townRoot skips the shared layer.
+func ComputeExpectedForTown(townRoot, target string) (*HooksConfig, error) {
+	sharedDir := ""
+	if townRoot != "" {
+		sharedDir = config.SharedConfigDir(townRoot)
+	}
+
+	// Backfill: DefaultBase is the floor, so new hook types added to it are
+	// always present, with the shared and then the user's on-disk base on top.
+	base := DefaultBase()
+	if sharedDir != "" {
+		shared, err := loadConfig(filepath.Join(sharedDir, config.SharedHooksBaseFile))
+		if err == nil {
+			base = Merge(base, shared)
+		} else if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("loading shared base config: %w", err)
 		}
-	} else {
-		// Backfill: merge DefaultBase as floor, then on-disk base on top.
-		// This ensures new hook types added to DefaultBase are always present,
-		// while preserving user customizations from the on-disk base.
-		base = Merge(DefaultBase(), base)
+	}
+	local, err := LoadBase()
+	if err == nil {
+		base = Merge(base, local)
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("loading base config: %w", err)
 	}
 
 	defaults := DefaultOverrides()
@@ -385,6 +426,17 @@ func ComputeExpected(target string) (*HooksConfig, error) {
 			result = Merge(result, def)
 		}
 
+		// Then the shared override, if any
+		if sharedDir != "" {
+			safe := strings.ReplaceAll(overrideKey, "/", "__")
+			shared, err := loadConfig(filepath.Join(sharedDir, config.SharedHooksOverrides, safe+".json"))
+			if err == nil {
+				result = Merge(result, shared)
+			} else if !os.IsNotExist(err) {
+				return nil, fmt.Errorf("loading shared override %q: %w", overrideKey, err)
+			}
+		}
+
 		// Then layer on-disk overrides on top
 		override, err := LoadOverride(overrideKey)
 		if err != nil {
@@ -486,6 +538,9 @@ func DiscoverTargets(townRoot string) ([]Target, error) {
 
 	}
 
+	for i := range targets {
+		targets[i].TownRoot = townRoot
+	}
 	return targets, nil
 }
 
@@ -653,26 +708,20 @@ func (c *HooksConfig) AddEntry(eventType string, entry HookEntry) bool {
 	return true
 }
 
-// gtPrimaryDir returns the highest-priority .gt config directory.
-// If GT_HOME is set, returns $GT_HOME/.gt; otherwise returns ~/.gt.
-// This is the target for all write operations and the first location checked
-// during cascaded reads.
+// gtPrimaryDir returns the highest-priority hooks config directory:
+// $GT_HOME/.gt if GT_HOME is set, otherwise the XDG config directory
+// ($XDG_CONFIG_HOME/gastown) or, for installs not yet migrated, ~/.gt (see
+// state.HooksConfigDir). This is the target for all write operations and the
+// first location checked during cascaded reads.
 func gtPrimaryDir() string {
-	if h := os.Getenv("GT_HOME"); h != "" {
-		return filepath.Join(h, ".gt")
-	}
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return filepath.Join(os.TempDir(), ".gt")
-	}
-	return filepath.Join(home, ".gt")
+	return state.HooksConfigDir()
 }
 
 // gtConfigDirs returns the ordered list of directories to search for hook
 // configs, from highest to lowest priority:
 //
-//  1. $GT_HOME/.gt  (only when GT_HOME is set and differs from $HOME)
-//  2. ~/.gt
+//  1. $GT_HOME/.gt  (only when GT_HOME is set and differs from the user dir)
+//  2. The user's hooks config dir (XDG config dir or ~/.gt)
 //
 // The binary's built-in defaults act as the implicit final fallback and are
 // NOT represented here — callers handle them separately.
@@ -680,15 +729,11 @@ func gtConfigDirs() []string {
 	primary := gtPrimaryDir()
 	dirs := []string{primary}
 
-	// Add ~/.gt as a lower-priority fallback only when GT_HOME redirects
-	// the primary dir away from the user's home directory.
+	// Add the user's dir as a lower-priority fallback only when GT_HOME
+	// redirects the primary dir away from it.
 	if os.Getenv("GT_HOME") != "" {
-		home, err := os.UserHomeDir()
-		if err == nil {
-			fallback := filepath.Join(home, ".gt")
-			if fallback != primary {
-				dirs = append(dirs, fallback)
-			}
+		if fallback := state.UserHooksConfigDir(); fallback != primary {
+			dirs = append(dirs, fallback)
 		}
 	}
 	return dirs
@@ -746,18 +791,73 @@ func LoadOverride(target string) (*HooksConfig, error) {
 	return nil, os.ErrNotExist
 }
 
-// SaveBase writes the base hooks configuration to the primary .gt directory
-// ($GT_HOME/.gt if set, otherwise ~/.gt).
+// SaveBase writes the base hooks configuration to the primary hooks config
+// directory (see gtPrimaryDir).
 func SaveBase(cfg *HooksConfig) error {
 	return saveConfig(BasePath(), cfg)
 }
 
 // SaveOverride writes an override configuration for the given target to the
-// primary .gt directory.
+// primary hooks config directory.
 func SaveOverride(target string, cfg *HooksConfig) error {
 	return saveConfig(OverridePath(target), cfg)
 }
 
+// rigOverrideFiles returns the override files in the primary dir that target
+// rig (e.g. "gastown__crew.json" for rig "gastown").
+func rigOverrideFiles(rig string) ([]string, error) {
+	entries, err := os.ReadDir(OverridesDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasPrefix(e.Name(), rig+"__") && strings.HasSuffix(e.Name(), ".json") {
+			files = append(files, e.Name())
+		}
+	}
+	return files, nil
+}
+
+// RenameRigOverrides moves the overrides for oldRig's roles to newRig after a
+// rig rename. It returns the new targets (e.g. "newrig/crew").
+func RenameRigOverrides(oldRig, newRig string) ([]string, error) {
+	files, err := rigOverrideFiles(oldRig)
+	if err != nil {
+		return nil, err
+	}
+	var targets []string
+	for _, name := range files {
+		role := strings.TrimSuffix(strings.TrimPrefix(name, oldRig+"__"), ".json")
+		target := newRig + "/" + role
+		if err := os.Rename(filepath.Join(OverridesDir(), name), OverridePath(target)); err != nil {
+			return targets, err
+		}
+		targets = append(targets, target)
+	}
+	return targets, nil
+}
+
+// RemoveRigOverrides deletes the overrides for rig's roles. It returns the
+// removed targets.
+func RemoveRigOverrides(rig string) ([]string, error) {
+	files, err := rigOverrideFiles(rig)
+	if err != nil {
+		return nil, err
+	}
+	var targets []string
+	for _, name := range files {
+		if err := os.Remove(filepath.Join(OverridesDir(), name)); err != nil {
+			return targets, err
+		}
+		targets = append(targets, rig+"/"+strings.TrimSuffix(strings.TrimPrefix(name, rig+"__"), ".json"))
+	}
+	return targets, nil
+}
+
 // MarshalConfig serializes a HooksConfig to pretty-printed JSON.
 func MarshalConfig(cfg *HooksConfig) ([]byte, error) {
 	return json.MarshalIndent(cfg, "", "  ")
@@ -776,6 +876,10 @@ func NormalizeTarget(target string) (string, bool) {
 		"crew": true, "witness": true, "refinery": true,
 		"polecats": true, "mayor": true, "deacon": true,
 	}
+	// Custom roles declared in town.json are targets too.
+	for _, name := range config.CustomRoleNames() {
+		validRoles[name] = true
+	}
 
 	// Simple role target
 	if validRoles[target] {
@@ -801,7 +905,8 @@ func NormalizeTarget(target string) (string, bool) {
 }
 
 // ValidTarget returns true if the target string is a valid override target.
-// Valid targets are roles (crew, witness, etc.) or rig/role combinations.
+// Valid targets are roles (crew, witness, etc., plus custom roles loaded with
+// config.LoadCustomRoles) or rig/role combinations.
 // Accepts singular aliases (e.g., "polecat") — use NormalizeTarget to get canonical form.
 func ValidTarget(target string) bool {
 	_, ok := NormalizeTarget(target)
@@ -857,6 +962,13 @@ func DefaultBase() *HooksConfig {
 					Command: hookChain(pathSetup, "gt tap guard dangerous-command"),
 				}},
 			},
+			{
+				Matcher: "Bash(*git push*)",
+				Hooks: []Hook{{
+					Type:    "command",
+					Command: hookChain(pathSetup, "gt tap guard push"),
+				}},
+			},
 		},
 		SessionStart: []HookEntry{
 			{
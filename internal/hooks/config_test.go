@@ -7,16 +7,21 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
 )
 
 // setTestHome sets HOME (and USERPROFILE on Windows) so that
-// os.UserHomeDir() returns tmpDir on all platforms.
+// os.UserHomeDir() returns tmpDir on all platforms, and clears
+// XDG_CONFIG_HOME and GT_HOME so the hooks config dir resolves under it.
 func setTestHome(t *testing.T, tmpDir string) {
 	t.Helper()
 	t.Setenv("HOME", tmpDir)
 	if runtime.GOOS == "windows" {
 		t.Setenv("USERPROFILE", tmpDir)
 	}
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("GT_HOME", "")
 }
 
 func TestLoadSaveBase(t *testing.T) {
@@ -124,7 +129,7 @@ func TestLoadSaveOverrideRigRole(t *testing.T) {
 		t.Fatalf("SaveOverride failed: %v", err)
 	}
 
-	expectedPath := filepath.Join(tmpDir, ".gt", "hooks-overrides", "gastown__crew.json")
+	expectedPath := filepath.Join(tmpDir, ".config", "gastown", "hooks-overrides", "gastown__crew.json")
 	if _, err := os.Stat(expectedPath); err != nil {
 		t.Fatalf("expected override file at %s: %v", expectedPath, err)
 	}
@@ -139,6 +144,45 @@ func TestLoadSaveOverrideRigRole(t *testing.T) {
 	}
 }
 
+func TestRenameAndRemoveRigOverrides(t *testing.T) {
+	tmpDir := t.TempDir()
+	setTestHome(t, tmpDir)
+
+	cfg := &HooksConfig{}
+	for _, target := range []string{"alpha/crew", "alpha/witness", "alphabet/crew", "crew"} {
+		if err := SaveOverride(target, cfg); err != nil {
+			t.Fatalf("SaveOverride(%s): %v", target, err)
+		}
+	}
+
+	renamed, err := RenameRigOverrides("alpha", "beta")
+	if err != nil {
+		t.Fatalf("RenameRigOverrides: %v", err)
+	}
+	if len(renamed) != 2 || renamed[0] != "beta/crew" || renamed[1] != "beta/witness" {
+		t.Errorf("renamed = %v, want [beta/crew beta/witness]", renamed)
+	}
+	for _, target := range []string{"beta/crew", "beta/witness", "alphabet/crew", "crew"} {
+		if _, err := LoadOverride(target); err != nil {
+			t.Errorf("LoadOverride(%s): %v", target, err)
+		}
+	}
+	if _, err := LoadOverride("alpha/crew"); !os.IsNotExist(err) {
+		t.Errorf("alpha/crew should be gone, got %v", err)
+	}
+
+	removed, err := RemoveRigOverrides("beta")
+	if err != nil {
+		t.Fatalf("RemoveRigOverrides: %v", err)
+	}
+	if len(removed) != 2 {
+		t.Errorf("removed = %v, want 2 targets", removed)
+	}
+	if _, err := LoadOverride("alphabet/crew"); err != nil {
+		t.Errorf("other rigs' overrides must survive: %v", err)
+	}
+}
+
 func TestLoadMissingFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	setTestHome(t, tmpDir)
@@ -217,6 +261,20 @@ func TestNormalizeTarget(t *testing.T) {
 	}
 }
 
+func TestNormalizeTargetCustomRole(t *testing.T) {
+	if ValidTarget("reviewer") {
+		t.Fatal("reviewer is a valid target before it is registered")
+	}
+	config.SetCustomRoles(map[string]*config.CustomRoleConfig{"reviewer": {}})
+	t.Cleanup(func() { config.SetCustomRoles(nil) })
+
+	for _, target := range []string{"reviewer", "gastown/reviewer"} {
+		if got, ok := NormalizeTarget(target); !ok || got != target {
+			t.Errorf("NormalizeTarget(%q) = %q, %v; want %q, true", target, got, ok, target)
+		}
+	}
+}
+
 func TestGetApplicableOverrides(t *testing.T) {
 	tests := []struct {
 		target   string
@@ -462,6 +520,65 @@ func TestComputeExpected(t *testing.T) {
 	}
 }
 
+func TestComputeExpectedForTown_SharedLayer(t *testing.T) {
+	tmpDir := t.TempDir()
+	setTestHome(t, tmpDir)
+	townRoot := t.TempDir()
+	sharedDir := config.SharedConfigDir(townRoot)
+
+	writeShared := func(rel string, cfg *HooksConfig) {
+		t.Helper()
+		if err := saveConfig(filepath.Join(sharedDir, rel), cfg); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeShared("hooks-base.json", &HooksConfig{
+		Stop: []HookEntry{{Matcher: "", Hooks: []Hook{{Type: "command", Command: "team-stop"}}}},
+	})
+	writeShared("hooks-overrides/crew.json", &HooksConfig{
+		PreToolUse: []HookEntry{
+			{Matcher: "Bash(rm*)", Hooks: []Hook{{Type: "command", Command: "team-rm-guard"}}},
+			{Matcher: "Bash(git*)", Hooks: []Hook{{Type: "command", Command: "team-git-guard"}}},
+		},
+	})
+
+	// The user's own override for the same matcher wins over the team's.
+	if err := SaveOverride("crew", &HooksConfig{
+		PreToolUse: []HookEntry{{Matcher: "Bash(git*)", Hooks: []Hook{{Type: "command", Command: "my-git-guard"}}}},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected, err := ComputeExpectedForTown(townRoot, "gastown/crew")
+	if err != nil {
+		t.Fatalf("ComputeExpectedForTown: %v", err)
+	}
+	if len(expected.Stop) != 1 || expected.Stop[0].Hooks[0].Command != "team-stop" {
+		t.Errorf("Stop = %+v, want the shared base's", expected.Stop)
+	}
+	commands := map[string]string{}
+	for _, e := range expected.PreToolUse {
+		commands[e.Matcher] = e.Hooks[0].Command
+	}
+	if commands["Bash(rm*)"] != "team-rm-guard" {
+		t.Errorf("shared crew override missing: %v", commands)
+	}
+	if commands["Bash(git*)"] != "my-git-guard" {
+		t.Errorf("Bash(git*) = %q, want the user's override", commands["Bash(git*)"])
+	}
+
+	// Without a town, the shared layer is skipped.
+	plain, err := ComputeExpected("gastown/crew")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range plain.PreToolUse {
+		if e.Matcher == "Bash(rm*)" {
+			t.Error("ComputeExpected applied a town's shared override")
+		}
+	}
+}
+
 // TestComputeExpectedBackfillsSessionStart reproduces gt-y22: on-disk base
 // created before SessionStart was added to DefaultBase. SessionStart should
 // be backfilled from DefaultBase so settings.json files contain PATH exports.
@@ -0,0 +1,166 @@
+// Package jsonschema derives JSON Schemas from the Go types that config files
+// are decoded into, so the schemas always match what gt actually reads.
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Draft is the JSON Schema dialect emitted.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema document or subschema. An empty Schema accepts
+// any value.
+type Schema struct {
+	Dialect              string             `json:"$schema,omitempty"`
+	ID                   string             `json:"$id,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Defs                 map[string]*Schema `json:"$defs,omitempty"`
+}
+
+var (
+	timeType     = reflect.TypeOf(time.Time{})
+	rawType      = reflect.TypeOf(json.RawMessage{})
+	marshalerTyp = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// Generate returns the schema for values decoded by encoding/json into v's
+// type. Named struct types, the root included, become $defs referenced by
+// $ref, which keeps recursive types finite.
+func Generate(v any) *Schema {
+	g := &generator{defs: make(map[string]*Schema), names: make(map[reflect.Type]string)}
+	root := g.schema(reflect.TypeOf(v))
+	root.Dialect = Draft
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root
+}
+
+type generator struct {
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+func (g *generator) schema(t reflect.Type) *Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawType:
+		return &Schema{}
+	case t.Implements(marshalerTyp) || reflect.PointerTo(t).Implements(marshalerTyp):
+		// Custom encodings can be anything.
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.structSchema(t)
+		}
+		return &Schema{Ref: "#/$defs/" + g.define(t)}
+	}
+	return &Schema{}
+}
+
+// define adds t to $defs, once, and returns its name there.
+func (g *generator) define(t reflect.Type) string {
+	if name, ok := g.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := g.defs[name]; taken {
+		name = pkgName(t) + "." + name
+	}
+	g.names[t] = name
+	g.defs[name] = nil // reserve the name before recursing
+	g.defs[name] = g.structSchema(t)
+	return name
+}
+
+func (g *generator) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(s, t)
+	return s
+}
+
+// addFields adds t's JSON fields to s, inlining embedded structs the way
+// encoding/json does.
+func (g *generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		ft := f.Type
+		for ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			g.addFields(s, ft)
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if _, exists := s.Properties[name]; exists {
+			continue // the shallower field wins, as in encoding/json
+		}
+		if hasOpt(opts, "string") {
+			s.Properties[name] = &Schema{Type: "string"}
+			continue
+		}
+		s.Properties[name] = g.schema(f.Type)
+	}
+}
+
+func hasOpt(opts, want string) bool {
+	for _, o := range strings.Split(opts, ",") {
+		if o == want {
+			return true
+		}
+	}
+	return false
+}
+
+func pkgName(t reflect.Type) string {
+	path := t.PkgPath()
+	if i := strings.LastIndex(path, "/"); i >= 0 {
+		path = path[i+1:]
+	}
+	return path
+}
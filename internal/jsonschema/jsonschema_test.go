@@ -0,0 +1,80 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+type inner struct {
+	Name string `json:"name"`
+}
+
+type Embedded struct {
+	Shared bool `json:"shared"`
+}
+
+type node struct {
+	Embedded
+	Children []*node           `json:"children,omitempty"`
+	Meta     map[string]inner  `json:"meta"`
+	Count    *int              `json:"count,omitempty"`
+	Ratio    float64           `json:"ratio"`
+	When     time.Time         `json:"when"`
+	Raw      json.RawMessage   `json:"raw"`
+	ID       int64             `json:"id,string"`
+	Skipped  string            `json:"-"`
+	hidden   string            //nolint:unused // unexported fields are not encoded
+	Tags     []string          `json:"tags"`
+	Anon     struct{ X int }   `json:"anon"`
+	Lookup   map[string]string `json:"lookup"`
+}
+
+func TestGenerate(t *testing.T) {
+	s := Generate(&node{})
+	if s.Dialect != Draft || s.Ref != "#/$defs/node" {
+		t.Fatalf("root = %+v", s)
+	}
+	n := s.Defs["node"]
+	if n == nil {
+		t.Fatal("node not defined")
+	}
+	want := map[string]string{
+		"shared":   "boolean",
+		"children": "array",
+		"meta":     "object",
+		"count":    "integer",
+		"ratio":    "number",
+		"when":     "string",
+		"raw":      "",
+		"id":       "string",
+		"tags":     "array",
+		"anon":     "object",
+		"lookup":   "object",
+	}
+	for name, typ := range want {
+		p := n.Properties[name]
+		if p == nil {
+			t.Errorf("missing property %q", name)
+			continue
+		}
+		if p.Type != typ {
+			t.Errorf("%s type = %q, want %q", name, p.Type, typ)
+		}
+	}
+	if len(n.Properties) != len(want) {
+		t.Errorf("properties = %d, want %d", len(n.Properties), len(want))
+	}
+	if got := n.Properties["children"].Items.Ref; got != "#/$defs/node" {
+		t.Errorf("recursive items ref = %q", got)
+	}
+	if got := n.Properties["meta"].AdditionalProperties.Ref; got != "#/$defs/inner" {
+		t.Errorf("map value ref = %q", got)
+	}
+	if n.Properties["when"].Format != "date-time" {
+		t.Errorf("time format = %q", n.Properties["when"].Format)
+	}
+	if _, err := json.Marshal(s); err != nil {
+		t.Errorf("marshal: %v", err)
+	}
+}
@@ -0,0 +1,314 @@
+// Package logging is gt's structured diagnostic log, built on log/slog.
+//
+// Diagnostics (retries, skipped entries, best-effort failures) go through
+// slog instead of ad-hoc prints to stderr, so they are kept even when
+// nobody was watching the terminal. Setup installs a default logger that
+// writes to two places:
+//
+//   - stderr, at the level chosen with --log-level / GT_LOG_LEVEL
+//     (default warn, so routine diagnostics stay off screen)
+//   - per-component files at info level or lower: <town>/mayor/logs/<component>.log,
+//     or <town>/<rig>/logs/<component>.log for loggers scoped to a rig
+//
+// Packages get a logger with Component or ForRig. Before Setup runs (tests,
+// other binaries) those loggers use slog's default logger.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+const (
+	// EnvLevel is the env var for the stderr log level (debug, info, warn, error).
+	EnvLevel = "GT_LOG_LEVEL"
+
+	// EnvFormat is the env var for the log format (text or json).
+	EnvFormat = "GT_LOG_FORMAT"
+
+	// FormatText is slog's key=value text format.
+	FormatText = "text"
+
+	// FormatJSON is one JSON object per line.
+	FormatJSON = "json"
+
+	// DefaultLevel is the stderr level when none is configured.
+	DefaultLevel = slog.LevelWarn
+
+	// ComponentKey and RigKey are the attributes that route records to files.
+	ComponentKey = "component"
+	RigKey       = "rig"
+
+	// defaultComponent names the file for records logged without a component.
+	defaultComponent = "gt"
+
+	// maxLogFileSize is the size at which a log file is moved to <file>.1
+	// when it is opened, keeping at most two generations per component.
+	maxLogFileSize = 10 << 20
+)
+
+// Options configures Setup.
+type Options struct {
+	// Level is the minimum level written to stderr. Files get records at
+	// this level or info, whichever is lower.
+	Level slog.Level
+
+	// Format is FormatText or FormatJSON, for stderr and files alike.
+	Format string
+
+	// TownRoot enables file output. Empty means stderr only.
+	TownRoot string
+
+	// Command is recorded on every file record (e.g. "gt polecat add"),
+	// since many gt processes append to the same files.
+	Command string
+
+	// Stderr overrides os.Stderr (for tests).
+	Stderr io.Writer
+}
+
+// ParseLevel parses a level name: debug, info, warn (or warning), error.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("invalid log level %q (want debug, info, warn or error)", s)
+}
+
+// Setup installs gt's default slog logger. The returned function closes
+// the log files; call it on exit.
+func Setup(opts Options) (func() error, error) {
+	if opts.Format == "" {
+		opts.Format = FormatText
+	}
+	if opts.Format != FormatText && opts.Format != FormatJSON {
+		return nil, fmt.Errorf("invalid log format %q (want %s or %s)", opts.Format, FormatText, FormatJSON)
+	}
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	handlers := []slog.Handler{newHandler(stderr, opts.Format, opts.Level, true)}
+	files := &fileSet{files: make(map[string]*os.File)}
+	if opts.TownRoot != "" {
+		fileLevel := min(opts.Level, slog.LevelInfo)
+		fh := &fileHandler{townRoot: opts.TownRoot, format: opts.Format, level: fileLevel, files: files}
+		attrs := []slog.Attr{slog.Int("pid", os.Getpid())}
+		if opts.Command != "" {
+			attrs = append(attrs, slog.String("cmd", opts.Command))
+		}
+		handlers = append(handlers, fh.WithAttrs(attrs))
+	}
+	slog.SetDefault(slog.New(fanoutHandler(handlers)))
+	return files.Close, nil
+}
+
+// Component returns a logger for a town-level component; its file records
+// go to <town>/mayor/logs/<name>.log.
+func Component(name string) *slog.Logger {
+	return slog.Default().With(ComponentKey, name)
+}
+
+// ForRig returns a logger for a component working in a rig; its file
+// records go to <town>/<rig>/logs/<component>.log.
+func ForRig(rig, component string) *slog.Logger {
+	return slog.Default().With(ComponentKey, component, RigKey, rig)
+}
+
+// newHandler returns a text or JSON handler. Terminal output drops the
+// timestamp, which only adds noise next to the command that printed it.
+func newHandler(w io.Writer, format string, level slog.Level, terminal bool) slog.Handler {
+	opts := &slog.HandlerOptions{Level: level}
+	if terminal {
+		opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return a
+		}
+	}
+	if format == FormatJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// fanoutHandler sends each record to every handler that is enabled for it.
+type fanoutHandler []slog.Handler
+
+func (f fanoutHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range f {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f fanoutHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	for _, h := range f {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+func (f fanoutHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	out := make(fanoutHandler, len(f))
+	for i, h := range f {
+		out[i] = h.WithAttrs(attrs)
+	}
+	return out
+}
+
+func (f fanoutHandler) WithGroup(name string) slog.Handler {
+	out := make(fanoutHandler, len(f))
+	for i, h := range f {
+		out[i] = h.WithGroup(name)
+	}
+	return out
+}
+
+// fileHandler routes records to a per-component file, picked from the
+// component and rig attributes added with Logger.With. Other attributes
+// and groups are replayed onto the file's handler when a record is written.
+type fileHandler struct {
+	townRoot  string
+	format    string
+	level     slog.Level
+	files     *fileSet
+	component string
+	rig       string
+	grouped   bool // routing attrs inside a group don't count
+	ops       []func(slog.Handler) slog.Handler
+}
+
+func (h *fileHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level
+}
+
+func (h *fileHandler) Handle(ctx context.Context, r slog.Record) error {
+	f, err := h.files.open(h.path())
+	if err != nil {
+		return err
+	}
+	var out slog.Handler = newHandler(f, h.format, h.level, false)
+	for _, op := range h.ops {
+		out = op(out)
+	}
+	return out.Handle(ctx, r)
+}
+
+func (h *fileHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	c := *h
+	for _, a := range attrs {
+		switch {
+		case a.Key == ComponentKey && !h.grouped:
+			c.component = a.Value.String()
+		case a.Key == RigKey && !h.grouped:
+			c.rig = a.Value.String()
+		}
+	}
+	c.ops = append(h.ops[:len(h.ops):len(h.ops)], func(sh slog.Handler) slog.Handler { return sh.WithAttrs(attrs) })
+	return &c
+}
+
+func (h *fileHandler) WithGroup(name string) slog.Handler {
+	c := *h
+	c.grouped = true
+	c.ops = append(h.ops[:len(h.ops):len(h.ops)], func(sh slog.Handler) slog.Handler { return sh.WithGroup(name) })
+	return &c
+}
+
+// path returns the log file for the handler's component and rig. Records
+// for a rig that doesn't exist in the town go to mayor/logs instead.
+func (h *fileHandler) path() string {
+	component := h.component
+	if component == "" {
+		component = defaultComponent
+	}
+	name := sanitizeName(component) + ".log"
+	if h.rig != "" {
+		rigPath := filepath.Join(h.townRoot, sanitizeName(h.rig))
+		if info, err := os.Stat(rigPath); err == nil && info.IsDir() {
+			return filepath.Join(constants.RigLogsPath(rigPath), name)
+		}
+	}
+	return filepath.Join(constants.MayorLogsPath(h.townRoot), name)
+}
+
+// sanitizeName keeps a component or rig name to a single path element.
+func sanitizeName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '-'
+		}
+		return r
+	}, s)
+	if s == "" || s == "." || s == ".." {
+		return defaultComponent
+	}
+	return s
+}
+
+// fileSet opens each log file once per process, in append mode so that
+// concurrent gt processes interleave whole lines.
+type fileSet struct {
+	mu    sync.Mutex
+	files map[string]*os.File
+}
+
+func (s *fileSet) open(path string) (*os.File, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if f, ok := s.files[path]; ok {
+		return f, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > maxLogFileSize {
+		_ = os.Rename(path, path+".1")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	s.files[path] = f
+	return f, nil
+}
+
+// Close closes all open log files.
+func (s *fileSet) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var firstErr error
+	for path, f := range s.files {
+		if err := f.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(s.files, path)
+	}
+	return firstErr
+}
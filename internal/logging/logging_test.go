@@ -0,0 +1,119 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// setupTest installs a logger for the test and restores the previous
+// default logger afterwards.
+func setupTest(t *testing.T, opts Options) *bytes.Buffer {
+	t.Helper()
+	prev := slog.Default()
+	var stderr bytes.Buffer
+	opts.Stderr = &stderr
+	closeFn, err := Setup(opts)
+	if err != nil {
+		t.Fatalf("Setup: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = closeFn()
+		slog.SetDefault(prev)
+	})
+	return &stderr
+}
+
+func readLines(t *testing.T, path string) []string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading %s: %v", path, err)
+	}
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func TestParseLevel(t *testing.T) {
+	for in, want := range map[string]slog.Level{
+		"debug": slog.LevelDebug, "INFO": slog.LevelInfo, "warn": slog.LevelWarn,
+		"warning": slog.LevelWarn, " error ": slog.LevelError,
+	} {
+		got, err := ParseLevel(in)
+		if err != nil || got != want {
+			t.Errorf("ParseLevel(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("ParseLevel(loud) succeeded")
+	}
+	if _, err := Setup(Options{Format: "xml"}); err == nil {
+		t.Error("Setup with format xml succeeded")
+	}
+}
+
+func TestSetupLevelsAndRouting(t *testing.T) {
+	town := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(town, "gastown"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	stderr := setupTest(t, Options{Level: slog.LevelWarn, TownRoot: town, Command: "gt test"})
+
+	Component("git").Info("retrying", "attempt", 1)
+	Component("git").Debug("too detailed")
+	ForRig("gastown", "polecat").Warn("nudge failed", "session", "gt-Toast")
+	ForRig("nosuchrig", "polecat").Info("no rig dir")
+	slog.Info("no component")
+
+	// stderr only gets warn and above, without timestamps.
+	if out := stderr.String(); strings.Contains(out, "retrying") || !strings.Contains(out, "nudge failed") || strings.Contains(out, "time=") {
+		t.Errorf("stderr = %q, want only the warning, without time", out)
+	}
+
+	gitLog := readLines(t, filepath.Join(town, "mayor", "logs", "git.log"))
+	if len(gitLog) != 1 || !strings.Contains(gitLog[0], "msg=retrying") ||
+		!strings.Contains(gitLog[0], `cmd="gt test"`) || !strings.Contains(gitLog[0], "time=") {
+		t.Errorf("git.log = %q, want one info record with cmd and time", gitLog)
+	}
+	rigLog := readLines(t, filepath.Join(town, "gastown", "logs", "polecat.log"))
+	if len(rigLog) != 1 || !strings.Contains(rigLog[0], "nudge failed") || !strings.Contains(rigLog[0], "rig=gastown") {
+		t.Errorf("gastown/logs/polecat.log = %q", rigLog)
+	}
+	if lines := readLines(t, filepath.Join(town, "mayor", "logs", "polecat.log")); len(lines) != 1 || !strings.Contains(lines[0], "no rig dir") {
+		t.Errorf("unknown rig should fall back to mayor/logs, got %q", lines)
+	}
+	if lines := readLines(t, filepath.Join(town, "mayor", "logs", defaultComponent+".log")); len(lines) != 1 {
+		t.Errorf("records without a component should go to %s.log, got %q", defaultComponent, lines)
+	}
+}
+
+func TestSetupJSONDebug(t *testing.T) {
+	town := t.TempDir()
+	stderr := setupTest(t, Options{Level: slog.LevelDebug, Format: FormatJSON, TownRoot: town})
+
+	Component("git").WithGroup("req").Debug("detail", "component", "inner")
+
+	var rec map[string]any
+	if err := json.Unmarshal(stderr.Bytes(), &rec); err != nil {
+		t.Fatalf("stderr is not JSON: %v: %q", err, stderr.String())
+	}
+	if rec["msg"] != "detail" || rec["level"] != "DEBUG" {
+		t.Errorf("stderr record = %v", rec)
+	}
+	// A component attr inside a group doesn't reroute the record.
+	lines := readLines(t, filepath.Join(town, "mayor", "logs", "git.log"))
+	if err := json.Unmarshal([]byte(lines[0]), &rec); err != nil || rec["component"] != "git" {
+		t.Errorf("git.log record = %v (%v)", rec, err)
+	}
+}
+
+func TestSetupStderrOnly(t *testing.T) {
+	stderr := setupTest(t, Options{Level: slog.LevelInfo})
+	Component("git").Info("hello")
+	if !strings.Contains(stderr.String(), "msg=hello component=git") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
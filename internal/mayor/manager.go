@@ -12,6 +12,7 @@ import (
 	"github.com/steveyegge/gastown/internal/acp"
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/headless"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/templates"
 	"github.com/steveyegge/gastown/internal/tmux"
@@ -114,6 +115,9 @@ func (m *Manager) mayorDir() string {
 // It checks both TMUX and ACP modes and returns ErrAlreadyRunning if active.
 // agentOverride optionally specifies a different agent alias to use.
 func (m *Manager) Start(agentOverride string) error {
+	if headless.Enabled(m.townRoot, constants.RoleMayor) {
+		return m.startHeadless()
+	}
 	status, err := m.CombinedStatus()
 	if err == nil && status.Active {
 		switch status.Mode {
@@ -129,6 +133,9 @@ func (m *Manager) Start(agentOverride string) error {
 // StartTMUX starts the mayor session in TMUX mode.
 // agentOverride optionally specifies a different agent alias to use.
 func (m *Manager) StartTMUX(agentOverride string) error {
+	if headless.Enabled(m.townRoot, constants.RoleMayor) {
+		return m.startHeadless()
+	}
 	if IsACPActive(m.townRoot) {
 		return ErrAlreadyRunning
 	}
@@ -149,6 +156,35 @@ func (m *Manager) StartTMUX(agentOverride string) error {
 		return fmt.Errorf("creating mayor directory: %w", err)
 	}
 
+	// Use unified session lifecycle for config → settings → command → create → env → theme → wait.
+	_, err = session.StartSession(t, m.sessionConfig(agentOverride))
+	if err != nil {
+		return err
+	}
+
+	time.Sleep(session.ShutdownDelay())
+
+	return nil
+}
+
+// startHeadless starts the mayor's headless supervisor instead of a tmux
+// session.
+func (m *Manager) startHeadless() error {
+	if IsACPActive(m.townRoot) {
+		return ErrACPActive
+	}
+	if err := headless.Start(m.townRoot, constants.RoleMayor); err != nil {
+		if errors.Is(err, headless.ErrAlreadyRunning) {
+			return ErrAlreadyRunning
+		}
+		return err
+	}
+	return nil
+}
+
+// sessionConfig returns the session lifecycle config for the mayor's tmux
+// session.
+func (m *Manager) sessionConfig(agentOverride string) session.SessionConfig {
 	// Resolve CLAUDE_CONFIG_DIR from accounts.json so the mayor session
 	// uses the correct account. Same pattern as crew startup (start.go).
 	accountsPath := constants.MayorAccountsPath(m.townRoot)
@@ -157,11 +193,9 @@ func (m *Manager) StartTMUX(agentOverride string) error {
 		claudeConfigDir = os.Getenv("CLAUDE_CONFIG_DIR")
 	}
 
-	// Use unified session lifecycle for config → settings → command → create → env → theme → wait.
-	theme := tmux.ResolveSessionTheme(m.townRoot, "", "mayor")
-	_, err = session.StartSession(t, session.SessionConfig{
-		SessionID:        sessionID,
-		WorkDir:          mayorDir,
+	return session.SessionConfig{
+		SessionID:        m.SessionName(),
+		WorkDir:          m.mayorDir(),
 		Role:             "mayor",
 		TownRoot:         m.townRoot,
 		AgentName:        "Mayor",
@@ -172,19 +206,17 @@ func (m *Manager) StartTMUX(agentOverride string) error {
 			Topic:     "cold-start",
 		},
 		AgentOverride: agentOverride,
-		Theme:         theme,
+		Theme:         tmux.ResolveSessionTheme(m.townRoot, "", "mayor"),
 		WaitForAgent:  true,
 		WaitFatal:     true,
 		AutoRespawn:   true,
 		AcceptBypass:  true,
-	})
-	if err != nil {
-		return err
 	}
+}
 
-	time.Sleep(session.ShutdownDelay())
-
-	return nil
+// Plan returns the tmux session StartTMUX would create, without creating it.
+func (m *Manager) Plan(agentOverride string) (*session.StartPlan, error) {
+	return session.PlanSession(m.sessionConfig(agentOverride))
 }
 
 // StartACP starts the mayor session in ACP mode.
@@ -208,11 +240,11 @@ func (m *Manager) StartACP(ctx context.Context, agentOverride, rigName string) e
 	}
 
 	// Prepare environment
-	envVars := config.AgentEnv(config.AgentEnvConfig{
+	envVars := session.AgentSessionEnv(config.AgentEnvConfig{
 		Role:     "mayor",
 		Rig:      rigName,
 		TownRoot: m.townRoot,
-	})
+	}, "")
 	for k, v := range envVars {
 		os.Setenv(k, v)
 	}
@@ -325,8 +357,12 @@ func (m *Manager) StartACP(ctx context.Context, agentOverride, rigName string) e
 	return proxy.Forward()
 }
 
-// Stop stops the mayor session.
+// Stop stops the mayor session, or its headless supervisor.
 func (m *Manager) Stop() error {
+	if _, running := headless.Running(m.townRoot, constants.RoleMayor); running {
+		return headless.Stop(m.townRoot, constants.RoleMayor)
+	}
+
 	t := tmux.NewTmux()
 	sessionID := m.SessionName()
 
@@ -343,12 +379,8 @@ func (m *Manager) Stop() error {
 	_ = t.SendKeysRaw(sessionID, "C-c")
 	time.Sleep(100 * time.Millisecond)
 
-	// Kill the session and all its processes
-	if err := t.KillSessionWithProcesses(sessionID); err != nil {
-		return fmt.Errorf("killing session: %w", err)
-	}
-
-	return nil
+	// Kill the session and all its processes, then run stop hooks
+	return session.TerminateSession(t, m.townRoot, sessionID)
 }
 
 // IsRunning checks if the mayor session is active in TMUX mode.
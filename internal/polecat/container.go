@@ -0,0 +1,133 @@
+package polecat
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// defaultContainerRuntime and defaultContainerNetwork apply when the rig's
+// container config leaves them empty.
+const (
+	defaultContainerRuntime = "docker"
+	defaultContainerNetwork = "host"
+)
+
+// loadContainerConfig returns the rig's polecat container config, or nil if
+// polecats run directly on the host.
+func loadContainerConfig(rigPath string) *config.ContainerConfig {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil || settings.Container == nil || settings.Container.Image == "" {
+		return nil
+	}
+	return settings.Container
+}
+
+// containerRuntime returns the container CLI for cfg.
+func containerRuntime(cfg *config.ContainerConfig) string {
+	if cfg.Runtime != "" {
+		return cfg.Runtime
+	}
+	return defaultContainerRuntime
+}
+
+// containerCommand wraps a polecat startup command so it runs in a container
+// named after the session. The town root is mounted read-only; on top of it
+// the polecat's own directory (worktree, state, logs), the town's .runtime
+// (heartbeats, pids, locks) and the rig's shared .repo.git, which the
+// worktree's .git file points into, are mounted read-write at their host
+// paths. The container runs as the invoking user so files it creates aren't
+// owned by root. The original command, with its GT_* env prefix, runs
+// unchanged inside the container via sh -c, and GT_* variables are also
+// passed with -e so the container's environment matches the session's. Vars
+// in inherit (secrets) are passed by name and take their values from the
+// session's environment.
+func containerCommand(cfg *config.ContainerConfig, name, townRoot, rigPath, polecatDir, workDir, command string, env, inherit map[string]string) string {
+	network := cfg.Network
+	if network == "" {
+		network = defaultContainerNetwork
+	}
+	runtime := containerRuntime(cfg)
+
+	args := []string{
+		"exec", runtime, "run", "--rm", "-i", "-t",
+		"--name", name,
+		"--network", network,
+	}
+	if uid, gid := os.Getuid(), os.Getgid(); uid >= 0 {
+		if runtime == "podman" {
+			// Rootless podman maps the host user to root in the container;
+			// keep-id maps it to itself so --user matches host ownership.
+			args = append(args, "--userns=keep-id")
+		}
+		args = append(args, "--user", fmt.Sprintf("%d:%d", uid, gid))
+	}
+	runtimeDir := filepath.Join(townRoot, ".runtime")
+	args = append(args,
+		"-v", townRoot+":"+townRoot+":ro",
+		"-v", runtimeDir+":"+runtimeDir,
+		"-v", polecatDir+":"+polecatDir,
+	)
+	if repoGit := filepath.Join(rigPath, ".repo.git"); dirExists(repoGit) {
+		args = append(args, "-v", repoGit+":"+repoGit)
+	}
+	for _, m := range cfg.Mounts {
+		args = append(args, "-v", expandHome(m))
+	}
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", k+"="+env[k])
+	}
+	// Inherited vars (secrets) pass by name only, so their values stay off
+	// the command line.
+	inheritKeys := make([]string, 0, len(inherit))
+	for k := range inherit {
+		inheritKeys = append(inheritKeys, k)
+	}
+	sort.Strings(inheritKeys)
+	for _, k := range inheritKeys {
+		args = append(args, "-e", k)
+	}
+	args = append(args, "-w", workDir)
+	args = append(args, cfg.Args...)
+	args = append(args, cfg.Image, "sh", "-c", command)
+
+	quoted := make([]string, len(args))
+	for i, a := range args {
+		quoted[i] = config.ShellQuote(a)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// removeContainer force-removes a polecat's container. Killing the tmux
+// session normally stops it (--rm cleans up), but a wedged runtime client
+// can leave the container running.
+func removeContainer(cfg *config.ContainerConfig, name string) error {
+	return exec.Command(containerRuntime(cfg), "rm", "-f", name).Run() //nolint:gosec // G204: runtime is validated to docker/podman
+}
+
+// expandHome expands a leading "~/" in the host side of a mount spec.
+func expandHome(mount string) string {
+	if !strings.HasPrefix(mount, "~/") {
+		return mount
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return mount
+	}
+	return filepath.Join(home, mount[2:])
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
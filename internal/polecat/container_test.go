@@ -0,0 +1,143 @@
+package polecat
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestContainerCommand(t *testing.T) {
+	town := t.TempDir()
+	rigPath := filepath.Join(town, "gastown")
+	if err := os.MkdirAll(filepath.Join(rigPath, ".repo.git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	workDir := filepath.Join(rigPath, "polecats", "Toast", "gastown")
+
+	cfg := &config.ContainerConfig{
+		Image:   "ghcr.io/example/polecat:latest",
+		Runtime: "podman",
+		Mounts:  []string{"/opt/cache:/opt/cache"},
+		Args:    []string{"--memory", "4g"},
+	}
+	inner := "export GT_RIG=gastown && exec env GT_ROLE=polecat claude 'do the thing'"
+	polecatDir := filepath.Dir(workDir)
+	got := containerCommand(cfg, "gt-Toast", town, rigPath, polecatDir, workDir, inner,
+		map[string]string{"GT_RIG": "gastown", "GT_POLECAT": "Toast"}, map[string]string{"GITHUB_TOKEN": "s3cret"})
+
+	// Parse the wrapped command the way the pane's shell will.
+	out, err := exec.Command("sh", "-c", "set -- "+strings.TrimPrefix(got, "exec ")+`; printf '%s\n' "$@"`).Output()
+	if err != nil {
+		t.Fatalf("sh: %v", err)
+	}
+	args := strings.Split(strings.TrimSuffix(string(out), "\n"), "\n")
+
+	want := []string{
+		"podman", "run", "--rm", "-i", "-t",
+		"--name", "gt-Toast",
+		"--network", "host",
+		"--userns=keep-id",
+		"--user", fmt.Sprintf("%d:%d", os.Getuid(), os.Getgid()),
+		"-v", town + ":" + town + ":ro",
+		"-v", filepath.Join(town, ".runtime") + ":" + filepath.Join(town, ".runtime"),
+		"-v", polecatDir + ":" + polecatDir,
+		"-v", filepath.Join(rigPath, ".repo.git") + ":" + filepath.Join(rigPath, ".repo.git"),
+		"-v", "/opt/cache:/opt/cache",
+		"-e", "GT_POLECAT=Toast",
+		"-e", "GT_RIG=gastown",
+		"-e", "GITHUB_TOKEN",
+		"-w", workDir,
+		"--memory", "4g",
+		"ghcr.io/example/polecat:latest", "sh", "-c", inner,
+	}
+	if strings.Join(args, "\x00") != strings.Join(want, "\x00") {
+		t.Errorf("container args:\n got %q\nwant %q", args, want)
+	}
+	if strings.Contains(got, "s3cret") {
+		t.Errorf("secret value on the command line: %q", got)
+	}
+	if !strings.HasPrefix(got, "exec ") {
+		t.Errorf("command should exec the runtime so the pane runs it directly: %q", got)
+	}
+}
+
+func TestContainerCommand_WritableStateMounts(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("container polecats run on Unix hosts")
+	}
+	town := t.TempDir()
+	rigPath := filepath.Join(town, "gastown")
+	polecatDir := filepath.Join(rigPath, "polecats", "Toast")
+	workDir := filepath.Join(polecatDir, "gastown")
+
+	got := containerCommand(&config.ContainerConfig{Image: "polecat:dev"}, "gt-Toast", town, rigPath, polecatDir, workDir, "claude", nil, nil)
+
+	mounts := map[string]bool{}
+	fields := strings.Fields(got)
+	for i, f := range fields {
+		if f == "-v" && i+1 < len(fields) {
+			mounts[strings.Trim(fields[i+1], "'")] = true
+		}
+	}
+	runtimeDir := filepath.Join(town, ".runtime")
+	for _, want := range []string{
+		town + ":" + town + ":ro",
+		runtimeDir + ":" + runtimeDir,
+		polecatDir + ":" + polecatDir,
+	} {
+		if !mounts[want] {
+			t.Errorf("missing mount %q in %v", want, mounts)
+		}
+	}
+	for m := range mounts {
+		if strings.HasPrefix(m, runtimeDir+":") && strings.HasSuffix(m, ":ro") {
+			t.Errorf(".runtime mounted read-only: %q", m)
+		}
+	}
+	if want := fmt.Sprintf("--user %d:%d", os.Getuid(), os.Getgid()); !strings.Contains(got, want) {
+		t.Errorf("docker command missing %q: %s", want, got)
+	}
+	if strings.Contains(got, "--userns") {
+		t.Errorf("--userns is podman-only: %s", got)
+	}
+}
+
+func TestLoadContainerConfig(t *testing.T) {
+	rigPath := t.TempDir()
+	if loadContainerConfig(rigPath) != nil {
+		t.Fatal("no settings should mean no container")
+	}
+
+	settingsPath := config.RigSettingsPath(rigPath)
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0755); err != nil {
+		t.Fatal(err)
+	}
+	write := func(data string) {
+		t.Helper()
+		if err := os.WriteFile(settingsPath, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"type":"rig-settings","version":1,"container":{"image":""}}`)
+	if loadContainerConfig(rigPath) != nil {
+		t.Error("empty image should disable containers")
+	}
+
+	write(`{"type":"rig-settings","version":1,"container":{"image":"polecat:dev"}}`)
+	cfg := loadContainerConfig(rigPath)
+	if cfg == nil || cfg.Image != "polecat:dev" || containerRuntime(cfg) != "docker" {
+		t.Errorf("loadContainerConfig = %+v, want polecat:dev on docker", cfg)
+	}
+
+	write(`{"type":"rig-settings","version":1,"container":{"image":"polecat:dev","runtime":"lxc"}}`)
+	if loadContainerConfig(rigPath) != nil {
+		t.Error("invalid runtime should be rejected")
+	}
+}
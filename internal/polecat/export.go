@@ -0,0 +1,117 @@
+package polecat
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/vcs"
+)
+
+// Export formats.
+const (
+	ExportPatch  = "patch"
+	ExportBundle = "bundle"
+)
+
+// UncommittedDiffName is the file an export writes the polecat's
+// uncommitted changes to (in the patch directory, or next to the bundle
+// with this suffix).
+const UncommittedDiffName = "uncommitted.diff"
+
+// ExportResult describes what Export wrote.
+type ExportResult struct {
+	Branch string
+	Base   string
+
+	// Commits is the number of commits on the branch since Base.
+	Commits int
+
+	// Files are the patch files or bundle written; empty when the branch
+	// has no commits of its own.
+	Files []string
+
+	// Uncommitted is the diff file for uncommitted changes, or "" if the
+	// worktree was clean.
+	Uncommitted string
+}
+
+// Export writes a polecat's work out of the town without pushing it: the
+// commits on its branch since the rig's default branch, and any
+// uncommitted changes (untracked files included) as a separate diff.
+//
+// With ExportPatch, out is a directory that receives a format-patch series
+// and uncommitted.diff. With ExportBundle, out is the bundle file, and the
+// diff goes to out + ".uncommitted.diff". Applying the bundle requires the
+// base commit, which the rig's remote has.
+func (m *Manager) Export(name, format, out string) (*ExportResult, error) {
+	if format != ExportPatch && format != ExportBundle {
+		return nil, fmt.Errorf("unknown export format %q (want %s or %s)", format, ExportPatch, ExportBundle)
+	}
+	if !m.exists(name) {
+		return nil, ErrPolecatNotFound
+	}
+	// git runs in the worktree, so relative paths would land there.
+	out, err := filepath.Abs(out)
+	if err != nil {
+		return nil, err
+	}
+	if vcs.RigKind(m.rig.Path) == config.VCSJujutsu {
+		return nil, fmt.Errorf("exporting is not supported on jj rigs")
+	}
+
+	g := git.NewGit(m.clonePath(name))
+	branch, err := g.CurrentBranch()
+	if err != nil {
+		return nil, fmt.Errorf("getting branch: %w", err)
+	}
+	result := &ExportResult{
+		Branch: branch,
+		Base:   "origin/" + m.rig.DefaultBranch(),
+	}
+	if result.Commits, err = g.CommitsAhead(result.Base, "HEAD"); err != nil {
+		return nil, fmt.Errorf("counting commits since %s: %w", result.Base, err)
+	}
+	diff, err := g.WorkingTreeDiff()
+	if err != nil {
+		return nil, fmt.Errorf("diffing uncommitted changes: %w", err)
+	}
+	if result.Commits == 0 && diff == "" {
+		return result, nil
+	}
+
+	uncommittedPath := out + "." + UncommittedDiffName
+	switch format {
+	case ExportPatch:
+		if err := os.MkdirAll(out, 0755); err != nil {
+			return nil, fmt.Errorf("creating %s: %w", out, err)
+		}
+		uncommittedPath = filepath.Join(out, UncommittedDiffName)
+		if result.Commits > 0 {
+			if result.Files, err = g.FormatPatch(result.Base, "HEAD", out); err != nil {
+				return nil, fmt.Errorf("formatting patches: %w", err)
+			}
+		}
+	case ExportBundle:
+		if err := os.MkdirAll(filepath.Dir(out), 0755); err != nil {
+			return nil, fmt.Errorf("creating %s: %w", filepath.Dir(out), err)
+		}
+		if result.Commits > 0 {
+			// Bundle the branch ref (not HEAD) so fetching from it names the branch.
+			if err := g.CreateBundle(out, result.Base, branch); err != nil {
+				return nil, fmt.Errorf("creating bundle: %w", err)
+			}
+			result.Files = []string{out}
+		}
+	}
+
+	if diff != "" {
+		if err := os.WriteFile(uncommittedPath, []byte(diff), 0644); err != nil {
+			return nil, fmt.Errorf("writing uncommitted diff: %w", err)
+		}
+		result.Uncommitted = uncommittedPath
+	}
+	return result, nil
+}
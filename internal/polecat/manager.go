@@ -6,6 +6,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 	"math/rand/v2"
 	"os"
 	"os/exec"
@@ -21,6 +22,7 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
@@ -29,6 +31,7 @@ import (
 	"github.com/steveyegge/gastown/internal/templates"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/vcs"
 	"github.com/steveyegge/gastown/internal/workspace"
 )
 
@@ -105,6 +108,7 @@ var (
 	ErrShellInWorktree    = errors.New("shell working directory is inside polecat worktree")
 	ErrDoltUnhealthy      = errors.New("dolt health check failed")
 	ErrDoltAtCapacity     = errors.New("dolt server at connection capacity")
+	ErrProtectedBranch    = errors.New("branch is protected")
 )
 
 // UncommittedWorkError provides details about uncommitted work.
@@ -181,6 +185,11 @@ func NewManager(r *rig.Rig, g *git.Git, t *tmux.Tmux) *Manager {
 	}
 }
 
+// log returns the logger for this rig's polecat diagnostics.
+func (m *Manager) log() *slog.Logger {
+	return logging.ForRig(m.rig.Name, "polecat")
+}
+
 // GetNamePool returns the manager's name pool for external use (e.g., pool init).
 func (m *Manager) GetNamePool() *NamePool {
 	return m.namePool
@@ -249,7 +258,7 @@ func (m *Manager) CheckDoltHealth() error {
 		lastErr = err
 		if attempt < doltMaxRetries {
 			backoff := doltBackoff(attempt)
-			style.PrintWarning("Dolt health check attempt %d failed, retrying in %v...", attempt, backoff)
+			m.log().Info("Dolt health check failed, retrying", "attempt", attempt, "backoff", backoff, "err", err)
 			time.Sleep(backoff)
 		}
 	}
@@ -324,7 +333,7 @@ func (m *Manager) createAgentBeadWithRetry(agentID string, fields *beads.AgentFi
 		}
 		if attempt < doltMaxRetries {
 			backoff := doltBackoff(attempt)
-			style.PrintWarning("agent bead creation attempt %d failed, retrying in %v: %v", attempt, backoff, err)
+			m.log().Info("agent bead creation failed, retrying", "agent", agentID, "attempt", attempt, "backoff", backoff, "err", err)
 			time.Sleep(backoff)
 		}
 	}
@@ -351,7 +360,7 @@ func (m *Manager) SetAgentStateWithRetry(name string, state string) error {
 		}
 		if attempt < doltMaxRetries {
 			backoff := doltBackoff(attempt)
-			style.PrintWarning("SetAgentState attempt %d failed, retrying in %v: %v", attempt, backoff, err)
+			m.log().Info("SetAgentState failed, retrying", "polecat", name, "state", state, "attempt", attempt, "backoff", backoff, "err", err)
 			time.Sleep(backoff)
 		}
 	}
@@ -453,6 +462,12 @@ func (m *Manager) repoBase() (*git.Git, error) {
 	return git.NewGit(mayorPath), nil
 }
 
+// repoVCS returns the backend polecat worktrees are created and removed
+// with: git worktrees on repoBase, or jj workspaces for jj rigs.
+func (m *Manager) repoVCS() (vcs.Backend, error) {
+	return vcs.ForRig(m.rig.Path)
+}
+
 // polecatDir returns the parent directory for a polecat.
 // This is polecats/<name>/ - the polecat's home directory.
 func (m *Manager) polecatDir(name string) string {
@@ -508,6 +523,46 @@ type AddOptions struct {
 	BaseBranch string // Override base branch for worktree (e.g., "origin/integration/gt-epic")
 }
 
+// setupLFS fetches Git LFS content into a polecat's worktree when the rig
+// uses LFS. Failures are warnings: the polecat still works, but LFS files
+// stay pointer files until someone runs 'git lfs pull'.
+func (m *Manager) setupLFS(clonePath string) {
+	if vcs.RigKind(m.rig.Path) == config.VCSJujutsu {
+		return
+	}
+	if _, err := rig.SetupLFS(clonePath, rig.LoadLFSConfig(m.rig.Path)); err != nil {
+		style.PrintWarning("could not set up Git LFS: %v (LFS files are pointer files)", err)
+	}
+}
+
+// configureSigning sets up commit signing in a polecat's worktree from the
+// rig's signing config. Errors only when signing is required; otherwise a
+// failed setup is a warning and the polecat commits unsigned.
+func (m *Manager) configureSigning(clonePath string) error {
+	cfg := rig.LoadSigningConfig(m.rig.Path)
+	if cfg == nil {
+		return nil
+	}
+	err := rig.ConfigureCommitSigning(clonePath, cfg, "polecat", rig.SigningAccount(filepath.Dir(m.rig.Path)))
+	if err == nil {
+		return nil
+	}
+	if cfg.Required {
+		return fmt.Errorf("configuring commit signing: %w", err)
+	}
+	style.PrintWarning("could not configure commit signing: %v", err)
+	return nil
+}
+
+// checkBranchPolicy refuses branch names the rig protects, so a
+// misconfigured branch template can never put a polecat on main.
+func (m *Manager) checkBranchPolicy(branch string) error {
+	if rig.LoadBranchPolicy(m.rig.Path).IsProtected(branch) {
+		return fmt.Errorf("%w: %s (check polecat_branch_template and branches.prefix)", ErrProtectedBranch, branch)
+	}
+	return nil
+}
+
 // Add creates a new polecat as a git worktree from the repo base.
 // Uses the shared bare repo (.repo.git) if available, otherwise mayor/rig.
 // This is much faster than a full clone and shares objects with all worktrees.
@@ -521,21 +576,33 @@ type AddOptions struct {
 // - {description}: sanitized issue title
 // - {timestamp}: unique timestamp
 //
-// If no template is configured or template is empty, uses default format:
-// - polecat/{name}/{issue}@{timestamp} when issue is available
-// - polecat/{name}-{timestamp} otherwise
+// If no template is configured or template is empty, uses default format,
+// where the prefix is the rig's branches.prefix template (default
+// polecat/{name}):
+// - <prefix>/{issue}@{timestamp} when issue is available
+// - <prefix>-{timestamp} otherwise
 func (m *Manager) buildBranchName(name, issue string) string {
 	template := m.rig.GetStringConfig("polecat_branch_template")
 
 	// No template configured - use default behavior for backward compatibility
 	if template == "" {
+		prefix := "polecat/" + name
+		if p := rig.LoadBranchPolicy(m.rig.Path).Prefix; p != rig.DefaultPolecatBranchPrefix {
+			prefix = m.expandBranchTemplate(p, name, issue)
+		}
 		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 36)
 		if issue != "" {
-			return fmt.Sprintf("polecat/%s/%s@%s", name, issue, timestamp)
+			return fmt.Sprintf("%s/%s@%s", prefix, issue, timestamp)
 		}
-		return fmt.Sprintf("polecat/%s-%s", name, timestamp)
+		return fmt.Sprintf("%s-%s", prefix, timestamp)
 	}
 
+	return m.expandBranchTemplate(template, name, issue)
+}
+
+// expandBranchTemplate substitutes the branch template variables (see
+// buildBranchName) into template and drops empty path segments.
+func (m *Manager) expandBranchTemplate(template, name, issue string) string {
 	// Build template variables
 	vars := make(map[string]string)
 
@@ -705,7 +772,7 @@ func (m *Manager) addWithOptionsLocked(name string, opts AddOptions, polecatDir
 		_ = m.beads.ResetAgentBeadForReuse(aid, "spawn rollback")
 
 		if worktreeCreated {
-			if rg, repoErr := m.repoBase(); repoErr == nil {
+			if rg, repoErr := m.repoVCS(); repoErr == nil {
 				_ = rg.WorktreeRemove(clonePath, true)
 			}
 		}
@@ -716,13 +783,18 @@ func (m *Manager) addWithOptionsLocked(name string, opts AddOptions, polecatDir
 		_ = m.namePool.Save()
 	}
 
-	repoGit, err := m.repoBase()
+	if err := m.checkBranchPolicy(branchName); err != nil {
+		cleanupOnError()
+		return nil, err
+	}
+
+	repo, err := m.repoVCS()
 	if err != nil {
 		cleanupOnError()
 		return nil, fmt.Errorf("finding repo base: %w", err)
 	}
 
-	if err := repoGit.Fetch("origin"); err != nil {
+	if err := repo.Fetch("origin"); err != nil {
 		style.PrintWarning("could not fetch origin: %v", err)
 	}
 
@@ -730,14 +802,11 @@ func (m *Manager) addWithOptionsLocked(name string, opts AddOptions, polecatDir
 	if opts.BaseBranch != "" {
 		startPoint = opts.BaseBranch
 	} else {
-		defaultBranch := "main"
-		if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
-			defaultBranch = rigCfg.DefaultBranch
-		}
+		defaultBranch := m.rig.DefaultBranch()
 		startPoint = fmt.Sprintf("origin/%s", defaultBranch)
 	}
 
-	if exists, err := repoGit.RefExists(startPoint); err != nil {
+	if exists, err := repo.RefExists(startPoint); err != nil {
 		cleanupOnError()
 		return nil, fmt.Errorf("checking ref %s: %w", startPoint, err)
 	} else if !exists {
@@ -745,13 +814,13 @@ func (m *Manager) addWithOptionsLocked(name string, opts AddOptions, polecatDir
 		return nil, fmt.Errorf("configured default_branch not found as %s in bare repo\n\n"+
 			"Possible causes:\n"+
 			"  - Branch doesn't exist on the remote (create it there first)\n"+
-			"  - default_branch is misconfigured (check %s/config.json)\n"+
+			"  - default_branch is misconfigured (check mayor/rigs.json or %s/config.json)\n"+
 			"  - Bare repo fetch failed (try: git -C %s fetch origin)\n\n"+
 			"Run 'gt doctor' to diagnose.",
 			startPoint, m.rig.Path, filepath.Join(m.rig.Path, ".repo.git"))
 	}
 
-	if err := repoGit.WorktreeAddFromRef(clonePath, branchName, startPoint); err != nil {
+	if err := repo.WorktreeAdd(clonePath, branchName, startPoint); err != nil {
 		cleanupOnError()
 		return nil, fmt.Errorf("creating worktree from %s: %w", startPoint, err)
 	}
@@ -780,6 +849,13 @@ func (m *Manager) addWithOptionsLocked(name string, opts AddOptions, polecatDir
 		style.PrintWarning("could not update local git excludes: %v", err)
 	}
 
+	m.setupLFS(clonePath)
+
+	if err := m.configureSigning(clonePath); err != nil {
+		cleanupOnError()
+		return nil, err
+	}
+
 	townRoot := filepath.Dir(m.rig.Path)
 	runtimeConfig := config.ResolveRoleAgentConfig("polecat", townRoot, m.rig.Path)
 	polecatSettingsDir := config.RoleSettingsDir("polecat", m.rig.Path)
@@ -839,6 +915,9 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 
 	// Build branch name using configured template or default format
 	branchName := m.buildBranchName(name, opts.HookBead)
+	if err := m.checkBranchPolicy(branchName); err != nil {
+		return nil, err
+	}
 
 	// Create polecat directory (polecats/<name>/)
 	if err := os.MkdirAll(polecatDir, 0755); err != nil {
@@ -865,7 +944,7 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 		// Remove git worktree registration if worktree was successfully added.
 		// Must happen before directory removal so git can clean up properly.
 		if worktreeCreated {
-			if rg, repoErr := m.repoBase(); repoErr == nil {
+			if rg, repoErr := m.repoVCS(); repoErr == nil {
 				_ = rg.WorktreeRemove(clonePath, true)
 			}
 		}
@@ -880,14 +959,14 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 	}
 
 	// Get the repo base (bare repo or mayor/rig)
-	repoGit, err := m.repoBase()
+	repo, err := m.repoVCS()
 	if err != nil {
 		cleanupOnError()
 		return nil, fmt.Errorf("finding repo base: %w", err)
 	}
 
 	// Fetch latest from origin to ensure worktree starts from up-to-date code
-	if err := repoGit.Fetch("origin"); err != nil {
+	if err := repo.Fetch("origin"); err != nil {
 		// Non-fatal - proceed with potentially stale code
 		style.PrintWarning("could not fetch origin: %v", err)
 	}
@@ -897,15 +976,12 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 	if opts.BaseBranch != "" {
 		startPoint = opts.BaseBranch
 	} else {
-		defaultBranch := "main"
-		if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
-			defaultBranch = rigCfg.DefaultBranch
-		}
+		defaultBranch := m.rig.DefaultBranch()
 		startPoint = fmt.Sprintf("origin/%s", defaultBranch)
 	}
 
 	// Validate that startPoint ref exists before attempting worktree creation
-	if exists, err := repoGit.RefExists(startPoint); err != nil {
+	if exists, err := repo.RefExists(startPoint); err != nil {
 		cleanupOnError()
 		return nil, fmt.Errorf("checking ref %s: %w", startPoint, err)
 	} else if !exists {
@@ -913,7 +989,7 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 		return nil, fmt.Errorf("configured default_branch not found as %s in bare repo\n\n"+
 			"Possible causes:\n"+
 			"  - Branch doesn't exist on the remote (create it there first)\n"+
-			"  - default_branch is misconfigured (check %s/config.json)\n"+
+			"  - default_branch is misconfigured (check mayor/rigs.json or %s/config.json)\n"+
 			"  - Bare repo fetch failed (try: git -C %s fetch origin)\n\n"+
 			"Run 'gt doctor' to diagnose.",
 			startPoint, m.rig.Path, filepath.Join(m.rig.Path, ".repo.git"))
@@ -922,7 +998,7 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 	// Always create fresh branch - unique name guarantees no collision
 	// git worktree add -b polecat/<name>-<timestamp> <path> <startpoint>
 	// Worktree goes in polecats/<name>/<rigname>/ for LLM ergonomics
-	if err := repoGit.WorktreeAddFromRef(clonePath, branchName, startPoint); err != nil {
+	if err := repo.WorktreeAdd(clonePath, branchName, startPoint); err != nil {
 		cleanupOnError()
 		return nil, fmt.Errorf("creating worktree from %s: %w", startPoint, err)
 	}
@@ -967,6 +1043,16 @@ func (m *Manager) AddWithOptions(name string, opts AddOptions) (_ *Polecat, retE
 		style.PrintWarning("could not update local git excludes: %v", err)
 	}
 
+	// Replace LFS pointer files with their content (LFS repos only).
+	m.setupLFS(clonePath)
+
+	// Sign commits per the rig's signing config. Fatal when signing is
+	// required: the refinery would reject everything this polecat commits.
+	if err := m.configureSigning(clonePath); err != nil {
+		cleanupOnError()
+		return nil, err
+	}
+
 	// Install runtime settings in the shared polecats parent directory.
 	// Settings are passed to Claude Code via --settings flag.
 	townRoot := filepath.Dir(m.rig.Path)
@@ -1063,6 +1149,16 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear, selfNuke bool)
 			if err := m.checkCleanupStatus(name, cleanupStatus, force); err != nil {
 				return err
 			}
+		} else if vcs.RigKind(m.rig.Path) == config.VCSJujutsu {
+			// Fallback path for jj rigs: the workspace has no .git to inspect.
+			if repo, err := m.repoVCS(); err == nil && !force {
+				if st, err := repo.Status(clonePath); err == nil && !st.Clean {
+					return &UncommittedWorkError{PolecatName: name, Status: &git.UncommittedWorkStatus{
+						HasUncommittedChanges: true,
+						ModifiedFiles:         append(append(st.Modified, st.Added...), st.Deleted...),
+					}}
+				}
+			}
 		} else {
 			// Fallback path: Check git directly (for polecats that haven't reported yet)
 			polecatGit := git.NewGit(clonePath)
@@ -1141,10 +1237,13 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear, selfNuke bool)
 	}
 
 	// Get repo base to remove the worktree properly
-	repoGit, err := m.repoBase()
+	repo, err := m.repoVCS()
 	if err != nil {
 		// Best-effort: try to prune stale worktree entries from both possible repo locations.
 		// This handles edge cases where the repo base is corrupted but worktree entries exist.
+		if wl, lockErr := vcs.LockWorktrees(m.rig.Path); lockErr == nil {
+			defer func() { _ = wl.Unlock() }()
+		}
 		bareRepoPath := filepath.Join(m.rig.Path, ".repo.git")
 		if info, statErr := os.Stat(bareRepoPath); statErr == nil && info.IsDir() {
 			bareGit := git.NewGitWithDir(bareRepoPath, "")
@@ -1160,7 +1259,7 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear, selfNuke bool)
 	}
 
 	// Try to remove as a worktree first (use force flag for worktree removal too)
-	if err := repoGit.WorktreeRemove(clonePath, force); err != nil {
+	if err := repo.WorktreeRemove(clonePath, force); err != nil {
 		// Fall back to direct removal if worktree removal fails
 		// (e.g., if this is an old-style clone, not a worktree)
 		if removeErr := os.RemoveAll(clonePath); removeErr != nil {
@@ -1182,7 +1281,7 @@ func (m *Manager) RemoveWithOptions(name string, force, nuclear, selfNuke bool)
 	}
 
 	// Prune any stale worktree entries (non-fatal: cleanup only)
-	_ = repoGit.WorktreePrune()
+	_ = repo.WorktreePrune()
 
 	// Verify removal succeeded (fixes #618)
 	// The above removal attempts may fail silently on permissions, symlinks, or busy files
@@ -1348,6 +1447,9 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	if !m.exists(name) {
 		return nil, ErrPolecatNotFound
 	}
+	if vcs.RigKind(m.rig.Path) == config.VCSJujutsu {
+		return nil, fmt.Errorf("repairing worktrees is not supported on jj rigs; nuke %s and sling again", name)
+	}
 
 	// Get the old clone path (may be old or new structure)
 	oldClonePath := m.clonePath(name)
@@ -1384,10 +1486,7 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	if opts.BaseBranch != "" {
 		startPoint = opts.BaseBranch
 	} else {
-		defaultBranch := "main"
-		if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
-			defaultBranch = rigCfg.DefaultBranch
-		}
+		defaultBranch := m.rig.DefaultBranch()
 		startPoint = fmt.Sprintf("origin/%s", defaultBranch)
 	}
 
@@ -1398,18 +1497,31 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 		return nil, fmt.Errorf("configured default_branch not found as %s in bare repo\n\n"+
 			"Possible causes:\n"+
 			"  - Branch doesn't exist on the remote (create it there first)\n"+
-			"  - default_branch is misconfigured (check %s/config.json)\n"+
+			"  - default_branch is misconfigured (check mayor/rigs.json or %s/config.json)\n"+
 			"  - Bare repo fetch failed (try: git -C %s fetch origin)\n\n"+
 			"Run 'gt doctor' to diagnose.",
 			startPoint, m.rig.Path, filepath.Join(m.rig.Path, ".repo.git"))
 	}
 
+	// Hold the rig's worktree lock while swapping worktrees so a concurrent
+	// prune can't drop the temp worktree's registration mid-swap.
+	wl, err := vcs.LockWorktrees(m.rig.Path)
+	if err != nil {
+		return nil, err
+	}
+	unlockWorktrees := func() { _ = wl.Unlock() }
+
 	// Create fresh worktree to a temporary path first, so we can roll back if it fails.
 	// This prevents destroying the old worktree before the new one is confirmed working.
 	branchName := m.buildBranchName(name, opts.HookBead)
+	if err := m.checkBranchPolicy(branchName); err != nil {
+		unlockWorktrees()
+		return nil, err
+	}
 	tmpClonePath := newClonePath + ".repair-tmp"
 	_ = os.RemoveAll(tmpClonePath) // clean up any leftover temp dir
 	if err := repoGit.WorktreeAddFromRef(tmpClonePath, branchName, startPoint); err != nil {
+		unlockWorktrees()
 		return nil, fmt.Errorf("creating fresh worktree from %s: %w", startPoint, err)
 	}
 
@@ -1422,6 +1534,7 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 			// Clean up temp worktree before returning
 			_ = repoGit.WorktreeRemove(tmpClonePath, true)
 			_ = os.RemoveAll(tmpClonePath)
+			unlockWorktrees()
 			return nil, fmt.Errorf("removing old clone path: %w", removeErr)
 		}
 	}
@@ -1446,8 +1559,10 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 		// Clean up temp worktree if move fails
 		_ = repoGit.WorktreeRemove(tmpClonePath, true)
 		_ = os.RemoveAll(tmpClonePath)
+		unlockWorktrees()
 		return nil, fmt.Errorf("moving repaired worktree to final path: %w", err)
 	}
+	unlockWorktrees()
 
 	// Provision CLAUDE.md (same as spawn path — repair creates a fresh worktree).
 	repairRigName := filepath.Base(m.rig.Path)
@@ -1457,8 +1572,7 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 
 	// Set up shared beads — fatal during repair too, same reason as spawn.
 	if err := m.setupSharedBeads(newClonePath); err != nil {
-		_ = repoGit.WorktreeRemove(newClonePath, true)
-		_ = os.RemoveAll(newClonePath)
+		removeRepairedWorktree(m.rig.Path, repoGit, newClonePath)
 		return nil, fmt.Errorf("setting up shared beads after repair: %w (polecat cannot submit MRs without shared beads)", err)
 	}
 
@@ -1472,6 +1586,13 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 		style.PrintWarning("could not update local git excludes: %v", err)
 	}
 
+	m.setupLFS(newClonePath)
+
+	if err := m.configureSigning(newClonePath); err != nil {
+		removeRepairedWorktree(m.rig.Path, repoGit, newClonePath)
+		return nil, err
+	}
+
 	// NOTE: Slash commands inherited from town level - no per-workspace copies needed.
 
 	// Create or reopen agent bead for ZFC compliance
@@ -1485,8 +1606,7 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 		HookBead:   opts.HookBead, // Set atomically at spawn time
 	}); err != nil {
 		// Hard fail — clean up the new worktree since we can't track this polecat
-		_ = repoGit.WorktreeRemove(newClonePath, true)
-		_ = os.RemoveAll(newClonePath)
+		removeRepairedWorktree(m.rig.Path, repoGit, newClonePath)
 		// Remove polecatDir to prevent limbo state where m.exists(name) returns true
 		// but no valid worktree exists. Matches AddWithOptions cleanupOnError behavior.
 		_ = os.RemoveAll(polecatDir)
@@ -1506,6 +1626,16 @@ func (m *Manager) RepairWorktreeWithOptions(name string, force bool, opts AddOpt
 	}, nil
 }
 
+// removeRepairedWorktree removes a worktree RepairWorktreeWithOptions
+// created, under the rig's worktree lock.
+func removeRepairedWorktree(rigPath string, repoGit *git.Git, clonePath string) {
+	if wl, err := vcs.LockWorktrees(rigPath); err == nil {
+		defer func() { _ = wl.Unlock() }()
+	}
+	_ = repoGit.WorktreeRemove(clonePath, true)
+	_ = os.RemoveAll(clonePath)
+}
+
 // ReuseIdlePolecat prepares an idle polecat for new work using branch-only operations.
 // Unlike RepairWorktreeWithOptions, this does NOT create/remove git worktrees.
 // It simply creates a fresh branch on the existing worktree, which eliminates the
@@ -1571,10 +1701,7 @@ func (m *Manager) ReuseIdlePolecat(name string, opts AddOptions) (*Polecat, erro
 	if opts.BaseBranch != "" {
 		startPoint = opts.BaseBranch
 	} else {
-		defaultBranch := "main"
-		if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
-			defaultBranch = rigCfg.DefaultBranch
-		}
+		defaultBranch := m.rig.DefaultBranch()
 		startPoint = fmt.Sprintf("origin/%s", defaultBranch)
 	}
 
@@ -1604,6 +1731,9 @@ func (m *Manager) ReuseIdlePolecat(name string, opts AddOptions) (*Polecat, erro
 
 	// Create fresh branch from start point (branch-only, no worktree add/remove)
 	branchName := m.buildBranchName(name, opts.HookBead)
+	if err := m.checkBranchPolicy(branchName); err != nil {
+		return nil, err
+	}
 	if err := polecatGit.CheckoutNewBranch(branchName, startPoint); err != nil {
 		// checkout -b fails if branch already exists or other edge case.
 		// Fall back to: checkout start point, then create branch.
@@ -1618,6 +1748,8 @@ func (m *Manager) ReuseIdlePolecat(name string, opts AddOptions) (*Polecat, erro
 		return nil, fmt.Errorf("branch mismatch after checkout: expected %s, got %s", branchName, actual)
 	}
 
+	m.setupLFS(clonePath)
+
 	// Reset agent bead for reuse
 	agentID := m.agentBeadID(name)
 	if err := m.beads.ResetAgentBeadForReuse(agentID, "idle polecat reuse"); err != nil {
@@ -1716,8 +1848,8 @@ func (m *Manager) reconcilePoolInternal() {
 	m.ReconcilePoolWith(namesWithDirs, namesWithSessions)
 
 	// Prune any stale git worktree entries (handles manually deleted directories)
-	if repoGit, err := m.repoBase(); err == nil {
-		_ = repoGit.WorktreePrune()
+	if repo, err := m.repoVCS(); err == nil {
+		_ = repo.WorktreePrune()
 	}
 }
 
@@ -2322,11 +2454,8 @@ func (m *Manager) DetectStalePolecats(threshold int) ([]*StalenessInfo, error) {
 		return nil, nil
 	}
 
-	// Get default branch from rig config
-	defaultBranch := "main"
-	if rigCfg, err := rig.LoadRigConfig(m.rig.Path); err == nil && rigCfg.DefaultBranch != "" {
-		defaultBranch = rigCfg.DefaultBranch
-	}
+	// Get the rig's default branch
+	defaultBranch := m.rig.DefaultBranch()
 
 	var results []*StalenessInfo
 	for _, p := range polecats {
@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/session"
@@ -870,6 +871,34 @@ func TestBuildBranchName(t *testing.T) {
 	}
 }
 
+func TestBranchPolicyPrefixAndProtection(t *testing.T) {
+	tmpDir := t.TempDir()
+	r := &rig.Rig{Name: "test-rig", Path: tmpDir}
+	m := NewManager(r, git.NewGit(tmpDir), nil)
+
+	settings := config.NewRigSettings()
+	settings.Branches = &config.BranchPolicyConfig{Prefix: "agents/{name}", Protected: []string{"release/*"}}
+	if err := config.SaveRigSettings(config.RigSettingsPath(tmpDir), settings); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := m.buildBranchName("alpha", "gt-123"); !strings.HasPrefix(got, "agents/alpha/gt-123@") {
+		t.Errorf("buildBranchName() = %q, want prefix agents/alpha/gt-123@", got)
+	}
+	if got := m.buildBranchName("alpha", ""); !strings.HasPrefix(got, "agents/alpha-") {
+		t.Errorf("buildBranchName() = %q, want prefix agents/alpha-", got)
+	}
+
+	if err := m.checkBranchPolicy("agents/alpha-xyz"); err != nil {
+		t.Errorf("checkBranchPolicy(polecat branch) = %v, want nil", err)
+	}
+	for _, branch := range []string{"main", "release/1.0"} {
+		if err := m.checkBranchPolicy(branch); !errors.Is(err, ErrProtectedBranch) {
+			t.Errorf("checkBranchPolicy(%q) = %v, want ErrProtectedBranch", branch, err)
+		}
+	}
+}
+
 func TestAddWithOptions_NoPrimeMDCreatedLocally(t *testing.T) {
 	// This test verifies that ProvisionPrimeMDForWorktree does NOT create
 	// a local .beads/PRIME.md in the worktree when there's no tracked one.
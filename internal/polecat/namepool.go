@@ -12,6 +12,7 @@ import (
 	"sync"
 
 	"github.com/steveyegge/gastown/internal/lock"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/util"
 )
 
@@ -586,15 +587,15 @@ func ParseThemeFile(path string) ([]string, error) {
 		}
 		name := strings.ToLower(line)
 		if len(name) <= 3 {
-			fmt.Fprintf(os.Stderr, "warning: skipping name %q (must be >3 characters)\n", name)
+			logging.Component("polecat").Warn("skipping theme name: must be >3 characters", "file", path, "name", name)
 			continue
 		}
 		if !validPoolNameRe.MatchString(name) {
-			fmt.Fprintf(os.Stderr, "warning: skipping invalid name %q (must be lowercase alphanumeric with hyphens)\n", name)
+			logging.Component("polecat").Warn("skipping theme name: must be lowercase alphanumeric with hyphens", "file", path, "name", name)
 			continue
 		}
 		if ReservedInfraAgentNames[name] {
-			fmt.Fprintf(os.Stderr, "warning: skipping reserved name %q\n", name)
+			logging.Component("polecat").Warn("skipping theme name: reserved", "file", path, "name", name)
 			continue
 		}
 		if seen[name] {
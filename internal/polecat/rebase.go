@@ -0,0 +1,86 @@
+package polecat
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/vcs"
+)
+
+// RebaseResult describes the outcome of rebasing a polecat's branch.
+type RebaseResult struct {
+	Branch string
+	Onto   string
+
+	// Rebased is false when the branch already contained Onto.
+	Rebased bool
+
+	// AutoResolved counts trivial stops carried on past (see
+	// git.RebaseAutoContinue).
+	AutoResolved int
+
+	// Conflicts lists the files that stopped the rebase. When set, the
+	// rebase was aborted and the branch is unchanged.
+	Conflicts []string
+}
+
+// Rebase fetches origin and rebases the polecat's branch onto the rig's
+// default branch, so long-lived branches don't rot. Trivial conflicts are
+// resolved automatically; on any other conflict the rebase is aborted and
+// the conflicting files are returned in the result. Tracked files in the
+// worktree must have no uncommitted changes.
+func (m *Manager) Rebase(name string) (*RebaseResult, error) {
+	fl, err := m.lockPolecat(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = fl.Unlock() }()
+
+	if !m.exists(name) {
+		return nil, ErrPolecatNotFound
+	}
+	if vcs.RigKind(m.rig.Path) == config.VCSJujutsu {
+		return nil, fmt.Errorf("rebasing is not supported on jj rigs; use 'jj rebase' in the workspace")
+	}
+
+	g := git.NewGit(m.clonePath(name))
+	if op, err := g.InProgressOperation(); err != nil {
+		return nil, fmt.Errorf("checking worktree: %w", err)
+	} else if op != "" {
+		return nil, fmt.Errorf("worktree has a %s in progress", op)
+	}
+	// Untracked files don't block a rebase; changes to tracked files do.
+	status, err := g.Status()
+	if err != nil {
+		return nil, fmt.Errorf("checking worktree: %w", err)
+	}
+	if len(status.Modified)+len(status.Added)+len(status.Deleted) > 0 {
+		return nil, ErrHasChanges
+	}
+
+	branch, err := g.CurrentBranch()
+	if err != nil {
+		return nil, fmt.Errorf("getting branch: %w", err)
+	}
+	result := &RebaseResult{
+		Branch: branch,
+		Onto:   "origin/" + m.rig.DefaultBranch(),
+	}
+
+	if err := g.Fetch("origin"); err != nil {
+		return nil, fmt.Errorf("fetching origin: %w", err)
+	}
+	if upToDate, err := g.IsAncestor(result.Onto, "HEAD"); err == nil && upToDate {
+		return result, nil
+	}
+
+	conflicts, autoResolved, err := g.RebaseAutoContinue(result.Onto)
+	if err != nil {
+		return nil, fmt.Errorf("rebasing onto %s: %w", result.Onto, err)
+	}
+	result.AutoResolved = autoResolved
+	result.Conflicts = conflicts
+	result.Rebased = len(conflicts) == 0
+	return result, nil
+}
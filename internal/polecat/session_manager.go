@@ -3,11 +3,9 @@ package polecat
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"strconv"
 	"strings"
@@ -18,12 +16,15 @@ import (
 	"github.com/steveyegge/gastown/internal/config"
 	"github.com/steveyegge/gastown/internal/constants"
 	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/rig"
 	"github.com/steveyegge/gastown/internal/runtime"
 	"github.com/steveyegge/gastown/internal/session"
 	"github.com/steveyegge/gastown/internal/style"
+	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/tmux"
-	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/vcs"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // debugSession logs non-fatal errors during session startup when GT_DEBUG_SESSION=1.
@@ -46,10 +47,21 @@ type SessionManager struct {
 	rig  *rig.Rig
 }
 
+// TmuxForRig returns t pointed at the tmux server where r's polecat sessions
+// run: the rig's remote host over SSH if one is configured, otherwise t.
+func TmuxForRig(t *tmux.Tmux, r *rig.Rig) *tmux.Tmux {
+	if r == nil {
+		return t
+	}
+	return t.OnHost(r.RemoteHost)
+}
+
 // NewSessionManager creates a new polecat session manager for a rig.
+// For rigs with a remote host, sessions are created and controlled on that
+// host over SSH (see TmuxForRig).
 func NewSessionManager(t *tmux.Tmux, r *rig.Rig) *SessionManager {
 	return &SessionManager{
-		tmux: t,
+		tmux: TmuxForRig(t, r),
 		rig:  r,
 	}
 }
@@ -103,6 +115,9 @@ type SessionInfo struct {
 
 	// LastActivity is when the session last had activity.
 	LastActivity time.Time `json:"last_activity,omitempty"`
+
+	// Idle indicates the agent is at its prompt waiting for input.
+	Idle bool `json:"idle"`
 }
 
 // SessionName generates the tmux session name for a polecat.
@@ -218,8 +233,19 @@ func (m *SessionManager) polecatSlot(polecat string) int {
 	return slot
 }
 
-// Start creates and starts a new session for a polecat.
+// Start creates and starts a new session for a polecat. When tracing is
+// on, the spawn is a span and the session's gt commands join its trace.
 func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
+	ctx, span := telemetry.StartSpan(context.Background(), "polecat.spawn",
+		attribute.String("gt.rig", m.rig.Name),
+		attribute.String("gt.polecat", polecat),
+		attribute.String("gt.issue", opts.Issue))
+	err := m.start(ctx, polecat, opts)
+	telemetry.EndSpan(span, err)
+	return err
+}
+
+func (m *SessionManager) start(ctx context.Context, polecat string, opts SessionStartOptions) error {
 	if !m.hasPolecat(polecat) {
 		return fmt.Errorf("%w: %s", ErrPolecatNotFound, polecat)
 	}
@@ -327,9 +353,10 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// under concurrent load (gt-5cc2p). Changes merge at gt done time.
 	command = config.PrependEnv(command, map[string]string{"BD_DOLT_AUTO_COMMIT": "off"})
 
-	// FIX (ga-6s284): Prepend GT_RIG, GT_POLECAT, GT_ROLE to startup command
-	// so they're inherited by Kimi and other agents. Setting via tmux.SetEnvironment
-	// after session creation doesn't work for all agent types.
+	// FIX (ga-6s284): Prepend the session env (GT_RIG, GT_POLECAT, GT_ROLE, and
+	// any configured session_env vars) to the startup command so they're
+	// inherited by Kimi and other agents. Setting via tmux.SetEnvironment after
+	// session creation doesn't work for all agent types.
 	//
 	// GT_BRANCH and GT_POLECAT_PATH are critical for gt done's nuked-worktree fallback:
 	// when the polecat's cwd is deleted before gt done finishes, these env vars allow
@@ -342,7 +369,7 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 			// After gt done merges a polecat's work, the worktree reverts to main/master.
 			// Starting a new session on main triggers the PRIME.md branch guard, which
 			// nukes the polecat — causing the zombie loop seen in production (hq-h01n8).
-			defaultBranch := g.DefaultBranch()
+			defaultBranch := m.rig.DefaultBranch()
 			if polecatGitBranch == defaultBranch || polecatGitBranch == "master" || polecatGitBranch == "main" {
 				newBranch := m.freshBranchName(polecat, opts.Issue)
 				if err := g.CheckoutNewBranch(newBranch, defaultBranch); err != nil {
@@ -354,44 +381,69 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 			}
 		}
 	}
+	if polecatGitBranch == "" && vcs.RigKind(m.rig.Path) == config.VCSJujutsu {
+		// jj workspaces have no .git; the bookmark stands in for the branch.
+		if repo, err := vcs.OpenJJ(m.rig.Path); err == nil {
+			polecatGitBranch, _ = repo.CurrentBranch(workDir)
+		}
+	}
 	// Generate the GASTA run ID — the root identifier for all telemetry emitted
 	// by this polecat session and its subprocesses (bd, mail, …).
 	runID := uuid.New().String()
-	envVarsToInject := map[string]string{
-		"GT_RIG":          m.rig.Name,
-		"GT_POLECAT":      polecat,
-		"GT_ROLE":         fmt.Sprintf("%s/polecats/%s", m.rig.Name, polecat),
+	envVars := session.AgentSessionEnv(config.AgentEnvConfig{
+		Role:             "polecat",
+		Rig:              m.rig.Name,
+		AgentName:        polecat,
+		TownRoot:         townRoot,
+		RuntimeConfigDir: opts.RuntimeConfigDir,
+		Agent:            opts.Agent,
+		SessionName:      sessionID,
+	}, m.rig.Path)
+	envVarsToInject := config.MergeEnv(envVars, map[string]string{
 		"GT_POLECAT_PATH": workDir,
 		"GT_TOWN_ROOT":    townRoot,
 		"GT_RUN":          runID,
 		"POLECAT_SLOT":    fmt.Sprintf("%d", m.polecatSlot(polecat)),
-	}
+	})
 	if polecatGitBranch != "" {
 		envVarsToInject["GT_BRANCH"] = polecatGitBranch
 	}
-	command = config.PrependEnv(command, envVarsToInject)
+	traceParent := telemetry.TraceParent(ctx)
+	if traceParent != "" {
+		envVarsToInject[telemetry.EnvTraceParent] = traceParent
+	}
+	// Secrets go to tmux via -e, not on the command line where ps shows them.
+	plainEnv, secretEnv := session.SplitSecretEnv(envVarsToInject, townRoot, m.rig.Path, "polecat")
+	command = config.PrependEnv(command, plainEnv)
+
+	// Run the agent inside a per-polecat container when the rig asks for it.
+	container := loadContainerConfig(m.rig.Path)
+	if container != nil {
+		// Create .runtime up front so the runtime doesn't create the mount
+		// source as root.
+		_ = os.MkdirAll(filepath.Join(townRoot, ".runtime"), 0755)
+		command = containerCommand(container, sessionID, townRoot, m.rig.Path, m.polecatDir(polecat), workDir, command, plainEnv, secretEnv)
+	}
 
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
-	if err := m.tmux.NewSessionWithCommand(sessionID, workDir, command); err != nil {
+	if err := m.tmux.NewSessionWithCommandAndEnv(sessionID, workDir, command, secretEnv); err != nil {
 		return fmt.Errorf("creating session: %w", err)
 	}
 
+	// Capture pane output to polecats/<name>/logs so scrollback survives crashes (non-fatal).
+	debugSession("EnableSessionLogCapture", session.EnableSessionLogCapture(m.tmux, townRoot, sessionID))
+
+	// Create the polecat's configured panes/windows (non-fatal).
+	debugSession("ApplySessionLayout", session.ApplySessionLayout(m.tmux, townRoot, "polecat", sessionID, workDir))
+
+	session.RunSessionHooks(townRoot, session.HookStart, sessionID, workDir)
+
+	// Restore operator-assigned labels and display name (non-fatal).
+	debugSession("ApplySessionMeta", session.ApplySessionMeta(m.tmux, townRoot, sessionID))
+
 	// Set environment (non-fatal: session works without these)
-	// Use centralized AgentEnv for consistency across all role startup paths
-	// Note: townRoot already defined above for ResolveRoleAgentConfig
-	envVars := config.AgentEnv(config.AgentEnvConfig{
-		Role:             "polecat",
-		Rig:              m.rig.Name,
-		AgentName:        polecat,
-		TownRoot:         townRoot,
-		RuntimeConfigDir: opts.RuntimeConfigDir,
-		Agent:            opts.Agent,
-		SessionName:      sessionID,
-	})
-	for k, v := range envVars {
-		debugSession("SetEnvironment "+k, m.tmux.SetEnvironment(sessionID, k, v))
-	}
+	debugSession("SetSessionEnv", session.SetSessionEnv(m.tmux, sessionID, envVars))
 
 	// Fallback: set GT_AGENT from resolved config when no explicit --agent override.
 	// AgentEnv only emits GT_AGENT when opts.Agent is non-empty (explicit override).
@@ -413,6 +465,10 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	debugSession("SetEnvironment GT_TOWN_ROOT", m.tmux.SetEnvironment(sessionID, "GT_TOWN_ROOT", townRoot))
 	// Set GT_RUN in the session environment so respawned processes also inherit it.
 	debugSession("SetEnvironment GT_RUN", m.tmux.SetEnvironment(sessionID, "GT_RUN", runID))
+	// Same for the trace, so the polecat's work after a handoff stays in it.
+	if traceParent != "" {
+		debugSession("SetEnvironment TRACEPARENT", m.tmux.SetEnvironment(sessionID, telemetry.EnvTraceParent, traceParent))
+	}
 
 	// Disable Dolt auto-commit in tmux session environment (gt-5cc2p).
 	// This ensures respawned processes also inherit the setting.
@@ -422,6 +478,10 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 	// shadow built-in preset names (e.g., custom "codex" running "opencode"),
 	// so we resolve process names from both agent name and actual command.
 	processNames := config.ResolveProcessNames(runtimeConfig.ResolvedAgent, runtimeConfig.Command)
+	if container != nil {
+		// The pane runs the container client; the agent lives inside the container.
+		processNames = append(processNames, containerRuntime(container))
+	}
 	debugSession("SetEnvironment GT_PROCESS_NAMES", m.tmux.SetEnvironment(sessionID, "GT_PROCESS_NAMES", strings.Join(processNames, ",")))
 
 	// Record agent's pane_id for ZFC-compliant liveness checks (gt-qmsx).
@@ -472,9 +532,14 @@ func (m *SessionManager) Start(polecat string, opts SessionStartOptions) error {
 
 		if fallbackInfo.StartupNudgeDelayMs > 0 {
 			// Wait for agent to finish processing beacon + gt prime before sending work instructions.
-			// Uses prompt-based detection where available; falls back to max(ReadyDelayMs, StartupNudgeDelayMs).
-			primeWaitRC := runtime.RuntimeConfigWithMinDelay(runtimeConfig, fallbackInfo.StartupNudgeDelayMs)
-			debugSession("WaitForPrimeReady", m.tmux.WaitForRuntimeReady(sessionID, primeWaitRC, constants.ClaudeStartTimeout))
+			// Agents with prompt detection are waited on until they are back at a quiet prompt;
+			// others fall back to max(ReadyDelayMs, StartupNudgeDelayMs).
+			if runtimeConfig != nil && runtimeConfig.Tmux != nil && runtimeConfig.Tmux.ReadyPromptPrefix != "" {
+				debugSession("WaitForPrimeReady", m.tmux.WaitForPrompt(sessionID, constants.ClaudeStartTimeout))
+			} else {
+				primeWaitRC := runtime.RuntimeConfigWithMinDelay(runtimeConfig, fallbackInfo.StartupNudgeDelayMs)
+				debugSession("WaitForPrimeReady", m.tmux.WaitForRuntimeReady(sessionID, primeWaitRC, constants.ClaudeStartTimeout))
+			}
 		}
 
 		if fallbackInfo.SendStartupNudge {
@@ -563,13 +628,16 @@ func (m *SessionManager) Stop(polecat string, force bool) error {
 		session.WaitForSessionExit(m.tmux, sessionID, constants.GracefulShutdownTimeout)
 	}
 
-	// Use KillSessionWithProcesses to ensure all descendant processes are killed.
-	// This prevents orphan bash processes from Claude's Bash tool surviving session termination.
-	if err := m.tmux.KillSessionWithProcesses(sessionID); err != nil {
-		return fmt.Errorf("killing session: %w", err)
+	// Kill all descendant processes so orphan bash processes from Claude's
+	// Bash tool don't survive session termination, then run stop hooks.
+	err = session.TerminateSession(m.tmux, filepath.Dir(m.rig.Path), sessionID)
+
+	// Make sure a containerized polecat's container goes away with its session.
+	if container := loadContainerConfig(m.rig.Path); container != nil && m.tmux.RemoteHost() == "" {
+		debugSession("removeContainer", removeContainer(container, sessionID))
 	}
 
-	return nil
+	return err
 }
 
 // IsRunning checks if a polecat session is active and healthy.
@@ -632,6 +700,10 @@ func (m *SessionManager) Status(polecat string) (*SessionInfo, error) {
 		}
 	}
 
+	if idle, err := m.tmux.Idle(sessionID); err == nil {
+		info.Idle = idle.Idle
+	}
+
 	return info, nil
 }
 
@@ -776,29 +848,15 @@ func (m *SessionManager) resolveBeadsDir(issueID, fallbackDir string) string {
 // This must be called before starting a session to avoid CPU spin loops
 // from agents retrying work on invalid issues.
 func (m *SessionManager) validateIssue(issueID, workDir string) error {
-	bdWorkDir := m.resolveBeadsDir(issueID, workDir)
-
-	ctx, cancel := context.WithTimeout(context.Background(), constants.BdCommandTimeout)
-	defer cancel()
-	cmd := exec.CommandContext(ctx, "bd", "show", issueID, "--json") //nolint:gosec // G204: bd is a trusted internal tool
-	util.SetDetachedProcessGroup(cmd)
-	cmd.Dir = bdWorkDir
-	output, err := cmd.Output()
-	if err != nil {
-		return fmt.Errorf("%w: %s", ErrIssueInvalid, issueID)
-	}
+	client, cleanup := beads.OpenClient(m.resolveBeadsDir(issueID, workDir))
+	defer cleanup()
 
-	var issues []struct {
-		Status string `json:"status"`
-	}
-	if err := json.Unmarshal(output, &issues); err != nil {
-		return fmt.Errorf("parsing issue: %w", err)
-	}
-	if len(issues) == 0 {
+	issue, err := client.Show(issueID)
+	if err != nil {
 		return fmt.Errorf("%w: %s", ErrIssueInvalid, issueID)
 	}
-	if beads.IssueStatus(issues[0].Status).IsTerminal() {
-		return fmt.Errorf("%w: %s has terminal status %s", ErrIssueInvalid, issueID, issues[0].Status)
+	if beads.IssueStatus(issue.Status).IsTerminal() {
+		return fmt.Errorf("%w: %s has terminal status %s", ErrIssueInvalid, issueID, issue.Status)
 	}
 	return nil
 }
@@ -833,6 +891,7 @@ func (m *SessionManager) verifyStartupNudgeDelivery(sessionID string, rc *config
 	maxRetries := sessionCfg.StartupNudgeMaxRetriesV()
 
 	nudgeContent := runtime.StartupNudgeContent()
+	log := logging.ForRig(m.rig.Name, "polecat")
 
 	for attempt := 1; attempt <= maxRetries; attempt++ {
 		// Wait for the agent to process the nudge before checking.
@@ -854,10 +913,10 @@ func (m *SessionManager) verifyStartupNudgeDelivery(sessionID string, rc *config
 		}
 
 		// Agent is truly idle (no busy indicator, prompt visible) — nudge was likely lost. Retry.
-		fmt.Fprintf(os.Stderr, "[startup-nudge] attempt %d/%d: agent %s idle at prompt, retrying nudge\n",
-			attempt, maxRetries, sessionID)
+		log.Info("startup nudge: agent idle at prompt, retrying nudge",
+			"session", sessionID, "attempt", attempt, "max", maxRetries)
 		if err := m.tmux.NudgeSession(sessionID, nudgeContent); err != nil {
-			fmt.Fprintf(os.Stderr, "[startup-nudge] retry nudge failed for %s: %v\n", sessionID, err)
+			log.Warn("startup nudge: retry nudge failed", "session", sessionID, "err", err)
 			return
 		}
 	}
@@ -865,23 +924,19 @@ func (m *SessionManager) verifyStartupNudgeDelivery(sessionID string, rc *config
 	// If we exhausted retries and the agent is still idle, log a warning.
 	// The witness zombie patrol will handle this case.
 	if m.tmux.IsIdle(sessionID) {
-		fmt.Fprintf(os.Stderr, "[startup-nudge] WARNING: agent %s still idle after %d nudge retries\n",
-			sessionID, maxRetries)
+		log.Warn("startup nudge: agent still idle after nudge retries",
+			"session", sessionID, "retries", maxRetries)
 	}
 }
 
-// hookIssue pins an issue to a polecat's hook using bd update.
+// hookIssue pins an issue to a polecat's hook.
 func (m *SessionManager) hookIssue(issueID, agentID, workDir string) error {
-	bdWorkDir := m.resolveBeadsDir(issueID, workDir)
-
-	ctx, cancel := context.WithTimeout(context.Background(), constants.BdCommandTimeout)
-	defer cancel()
-	cmd := exec.CommandContext(ctx, "bd", "update", issueID, "--status=hooked", "--assignee="+agentID) //nolint:gosec // G204: bd is a trusted internal tool
-	util.SetDetachedProcessGroup(cmd)
-	cmd.Dir = bdWorkDir
-	cmd.Stderr = os.Stderr
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("bd update failed: %w", err)
+	client, cleanup := beads.OpenClient(m.resolveBeadsDir(issueID, workDir))
+	defer cleanup()
+
+	status := beads.StatusHooked
+	if err := client.Update(issueID, beads.UpdateOptions{Status: &status, Assignee: &agentID}); err != nil {
+		return fmt.Errorf("hooking %s: %w", issueID, err)
 	}
 	fmt.Printf("✓ Hooked issue %s to %s\n", issueID, agentID)
 	return nil
@@ -57,6 +57,24 @@ func TestSessionName(t *testing.T) {
 	}
 }
 
+func TestTmuxForRig(t *testing.T) {
+	local := tmux.NewTmux()
+	if got := TmuxForRig(local, nil); got != local {
+		t.Error("nil rig should keep the local server")
+	}
+	if got := TmuxForRig(local, &rig.Rig{Name: "gastown"}); got.RemoteHost() != "" {
+		t.Errorf("rig without remote host resolved to %q", got.RemoteHost())
+	}
+	r := &rig.Rig{Name: "gastown", RemoteHost: "builder@farm"}
+	if got := TmuxForRig(local, r).RemoteHost(); got != "builder@farm" {
+		t.Errorf("RemoteHost() = %q, want builder@farm", got)
+	}
+	// The session manager addresses the same server.
+	if got := NewSessionManager(local, r).tmux.RemoteHost(); got != "builder@farm" {
+		t.Errorf("session manager tmux host = %q, want builder@farm", got)
+	}
+}
+
 func TestSessionManagerPolecatDir(t *testing.T) {
 	r := &rig.Rig{
 		Name:     "gastown",
@@ -23,7 +23,9 @@ import (
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/mail"
 	"github.com/steveyegge/gastown/internal/rig"
+	"github.com/steveyegge/gastown/internal/telemetry"
 	"github.com/steveyegge/gastown/internal/util"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // shortSHA returns at most 8 characters of a SHA for display.
@@ -212,6 +214,7 @@ type MRInfo struct {
 	ConvoyCreatedAt *time.Time // Convoy creation time
 	CreatedAt       time.Time  // MR creation time
 	BlockedBy       string     // Task ID blocking this MR
+	TraceParent     string     // W3C traceparent of the polecat's trace
 
 	// Pre-verification fields (Phase 3: polecat-owned rebasing)
 	// When set, the refinery can skip gates if VerifiedBase matches target HEAD.
@@ -462,8 +465,58 @@ type ProcessResult struct {
 	NeedsApproval  bool // PR exists but lacks required approving review (merge_strategy=pr)
 }
 
-// doMerge performs the actual git merge operation.
+// checkSignatures enforces the rig's signing.required setting: every commit
+// the branch would land must carry a good signature. It also configures the
+// refinery worktree to sign its own merge commits. Returns ok=false with the
+// failure result when the branch can't land.
+func (e *Engineer) checkSignatures(branch, target string) (ProcessResult, bool) {
+	cfg := rig.LoadSigningConfig(e.rig.Path)
+	if cfg == nil || !cfg.Required {
+		return ProcessResult{}, true
+	}
+
+	if err := rig.ConfigureCommitSigning(e.workDir, cfg, "refinery", rig.SigningAccount(filepath.Dir(e.rig.Path))); err != nil {
+		return ProcessResult{
+			Success: false,
+			Error:   fmt.Sprintf("signing is required but the refinery can't sign: %v", err),
+		}, false
+	}
+
+	_, _ = fmt.Fprintf(e.output, "[Engineer] Verifying commit signatures...\n")
+	unsigned, err := e.git.UnsignedCommits(target, branch, cfg.AllowedSigners)
+	if err != nil {
+		return ProcessResult{
+			Success: false,
+			Error:   fmt.Sprintf("failed to verify signatures on %s: %v", branch, err),
+		}, false
+	}
+	if len(unsigned) > 0 {
+		return ProcessResult{
+			Success: false,
+			Error:   fmt.Sprintf("signing is required but %d commit(s) on %s lack a good signature: %s", len(unsigned), branch, strings.Join(unsigned, "; ")),
+		}, false
+	}
+	return ProcessResult{}, true
+}
+
+// doMerge performs the actual git merge operation, traced as a
+// "refinery.merge" span.
 func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue string, skipGates ...bool) ProcessResult {
+	ctx, span := telemetry.StartSpan(ctx, "refinery.merge",
+		attribute.String("gt.branch", branch),
+		attribute.String("gt.target", target),
+		attribute.String("gt.issue", sourceIssue))
+	result := e.merge(ctx, branch, target, sourceIssue, skipGates...)
+	var err error
+	if !result.Success && result.Error != "" {
+		err = errors.New(result.Error)
+	}
+	telemetry.EndSpan(span, err)
+	return result
+}
+
+// merge does the work of doMerge.
+func (e *Engineer) merge(ctx context.Context, branch, target, sourceIssue string, skipGates ...bool) ProcessResult {
 	// GH#2778: Check no_merge flag on source issue before merging. The polecat
 	// normally skips MR creation when no_merge is set, but if an MR is created
 	// manually (e.g., gh pr create) the refinery would otherwise auto-merge it.
@@ -508,6 +561,16 @@ func (e *Engineer) doMerge(ctx context.Context, branch, target, sourceIssue stri
 		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: pull from origin/%s: %v (continuing)\n", target, err)
 	}
 
+	// Rigs added with --depth may not have the merge base locally yet
+	if err := e.git.DeepenUntilMergeBase("origin", branch, target); err != nil {
+		_, _ = fmt.Fprintf(e.output, "[Engineer] Warning: deepening shallow history: %v (continuing)\n", err)
+	}
+
+	// Rigs with signing.required only land signed commits
+	if result, ok := e.checkSignatures(branch, target); !ok {
+		return result
+	}
+
 	// Step 3: Check for merge conflicts (using local branch)
 	_, _ = fmt.Fprintf(e.output, "[Engineer] Checking for conflicts...\n")
 	conflicts, err := e.git.CheckConflicts(branch, target)
@@ -1099,8 +1162,8 @@ func (e *Engineer) ProcessMRInfo(ctx context.Context, mr *MRInfo) ProcessResult
 		}
 	}
 
-	// Use the shared merge logic
-	return e.doMerge(ctx, mr.Branch, mr.Target, mr.SourceIssue, skipGates)
+	// Use the shared merge logic, in the trace of the polecat that did the work
+	return e.doMerge(mr.traceContext(ctx), mr.Branch, mr.Target, mr.SourceIssue, skipGates)
 }
 
 // HandleMRInfoSuccess handles a successful merge from MRInfo.
@@ -1505,12 +1568,19 @@ func issueToMRInfo(issue *beads.Issue, fields *beads.MRFields) *MRInfo {
 		PreVerified:     fields.PreVerified,
 		PreVerifiedAt:   preVerifiedAt,
 		PreVerifiedBase: fields.PreVerifiedBase,
+		TraceParent:     fields.TraceParent,
 		CreatedAt:       createdAt,
 		UpdatedAt:       updatedAt,
 		Assignee:        issue.Assignee,
 	}
 }
 
+// traceContext returns ctx carrying the MR's trace, so its merge span joins
+// the trace of the polecat that submitted it.
+func (mr *MRInfo) traceContext(ctx context.Context) context.Context {
+	return telemetry.ContextWithTraceParent(ctx, mr.TraceParent)
+}
+
 // firstOpenBlocker returns the ID of the first open blocker for an issue,
 // or empty string if none are open.
 func (e *Engineer) firstOpenBlocker(issue *beads.Issue) string {
@@ -0,0 +1,86 @@
+package refinery
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// setupSSHSigning generates an ssh signing key and an allowed-signers file
+// trusting it, and writes a rig settings file requiring signed commits.
+// Returns the private key path.
+func setupSSHSigning(t *testing.T, rigPath string) string {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not available")
+	}
+	keyDir := t.TempDir()
+	key := filepath.Join(keyDir, "id_ed25519")
+	run(t, keyDir, "ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", key)
+	pub, err := os.ReadFile(key + ".pub")
+	if err != nil {
+		t.Fatal(err)
+	}
+	allowed := filepath.Join(keyDir, "allowed_signers")
+	writeFile(t, keyDir, "allowed_signers", "test@test.com "+string(pub))
+
+	settings := config.RigSettings{
+		Type:    "rig-settings",
+		Version: 1,
+		Signing: &config.SigningConfig{
+			Required:       true,
+			Format:         "ssh",
+			Key:            key,
+			AllowedSigners: allowed,
+		},
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(rigPath, "settings"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(config.RigSettingsPath(rigPath), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestDoMerge_SigningRequired(t *testing.T) {
+	workDir, g, _ := testGitRepo(t)
+	e := newTestEngineer(t, workDir, g)
+	e.rig.Path = t.TempDir()
+	key := setupSSHSigning(t, e.rig.Path)
+
+	createFeatureBranch(t, workDir, "feat/unsigned", "unsigned.txt", "hello")
+	result := e.doMerge(context.Background(), "feat/unsigned", "main", "")
+	if result.Success {
+		t.Fatal("expected unsigned branch to be rejected")
+	}
+	if !strings.Contains(result.Error, "lack a good signature") {
+		t.Errorf("error = %q, want unsigned-commit report", result.Error)
+	}
+
+	run(t, workDir, "git", "checkout", "-b", "feat/signed", "main")
+	writeFile(t, workDir, "signed.txt", "hello")
+	run(t, workDir, "git", "add", ".")
+	run(t, workDir, "git", "-c", "gpg.format=ssh", "-c", "user.signingkey="+key,
+		"commit", "-S", "-m", "feat: signed")
+	run(t, workDir, "git", "checkout", "main")
+
+	result = e.doMerge(context.Background(), "feat/signed", "main", "")
+	if !result.Success {
+		t.Fatalf("expected signed branch to merge, got: %s", result.Error)
+	}
+	// The refinery signs what it lands, too.
+	if got := run(t, workDir, "git", "config", "--worktree", "commit.gpgsign"); strings.TrimSpace(got) != "true" {
+		t.Errorf("refinery commit.gpgsign = %q, want true", got)
+	}
+}
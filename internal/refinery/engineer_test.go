@@ -803,6 +803,9 @@ func TestNotifyDeaconConvoyFeeding_AttemptsWhenConvoyID(t *testing.T) {
 		t.Fatal(err)
 	}
 	defer os.RemoveAll(tmpDir)
+	// The feed event is written to the town found from cwd; run outside the
+	// source tree so it doesn't land in internal/.events.jsonl.
+	t.Chdir(tmpDir)
 
 	rigDir := filepath.Join(tmpDir, "testrig")
 	if err := os.MkdirAll(rigDir, 0755); err != nil {
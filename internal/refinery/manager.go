@@ -25,6 +25,7 @@ import (
 	"github.com/steveyegge/gastown/internal/style"
 	"github.com/steveyegge/gastown/internal/tmux"
 	"github.com/steveyegge/gastown/internal/util"
+	"github.com/steveyegge/gastown/internal/vcs"
 )
 
 // Common errors
@@ -138,26 +139,11 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 	// Background mode: spawn a Claude agent in a tmux session
 	// The Claude agent handles MR processing using git commands and beads
 
-	// Working directory is the refinery worktree (shares .git with mayor/polecats).
-	// If the worktree is missing (pruned, deleted, or corrupted), auto-repair it
-	// from the shared bare repo (.repo.git) instead of falling back to mayor/rig.
-	// Falling back to mayor/rig causes the refinery to operate in the mayor's
-	// clone, which can interfere with mayor operations and confuse agents.
-	//
-	// Rigs using a standard .git clone (e.g. beads) never have a .repo.git bare
-	// repo, so the repair path is not applicable for them. Fall back to mayor/rig
-	// silently in that case — the fallback is correct and the warning would be noise.
-	refineryRigDir := filepath.Join(m.rig.Path, "refinery", "rig")
-	if _, err := os.Stat(refineryRigDir); os.IsNotExist(err) {
-		bareRepoPath := filepath.Join(m.rig.Path, ".repo.git")
-		_, bareErr := os.Stat(bareRepoPath)
-		standardGitPath := filepath.Join(m.rig.Path, ".git")
-		_, standardGitErr := os.Stat(standardGitPath)
-		if os.IsNotExist(bareErr) && standardGitErr == nil {
-			// Rig uses standard .git layout — worktree repair is not applicable.
-			// Fall back to mayor/rig silently; the fallback works correctly here.
-			refineryRigDir = filepath.Join(m.rig.Path, "mayor", "rig")
-		} else if repairErr := m.repairRefineryWorktree(refineryRigDir); repairErr != nil {
+	// Working directory is the refinery worktree. If the worktree is missing
+	// (pruned, deleted, or corrupted), auto-repair it from the shared bare repo.
+	refineryRigDir, needsRepair := m.sessionWorkDir()
+	if needsRepair {
+		if repairErr := m.repairRefineryWorktree(refineryRigDir); repairErr != nil {
 			// Repair failed — fall back to mayor/rig as last resort.
 			_, _ = fmt.Fprintf(m.output, "⚠ Could not repair refinery worktree: %v (falling back to mayor/rig)\n", repairErr)
 			refineryRigDir = filepath.Join(m.rig.Path, "mayor", "rig")
@@ -178,22 +164,9 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 		style.PrintWarning("could not update refinery .gitignore: %v", err)
 	}
 
-	initialPrompt := session.BuildStartupPrompt(session.BeaconConfig{
-		Recipient: session.BeaconRecipient("refinery", "", m.rig.Name),
-		Sender:    "deacon",
-		Topic:     "patrol",
-	}, "Run `gt prime --hook` and begin patrol.")
-
-	command, err := config.BuildStartupCommandFromConfig(config.AgentEnvConfig{
-		Role:        "refinery",
-		Rig:         m.rig.Name,
-		TownRoot:    townRoot,
-		Prompt:      initialPrompt,
-		Topic:       "patrol",
-		SessionName: sessionID,
-	}, m.rig.Path, initialPrompt, agentOverride)
+	initialPrompt, command, err := m.startupCommand(townRoot, sessionID, agentOverride)
 	if err != nil {
-		return fmt.Errorf("building startup command: %w", err)
+		return err
 	}
 
 	// Generate the GASTA run ID for this refinery session.
@@ -201,29 +174,19 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
-	if err := t.NewSessionWithCommand(sessionID, refineryRigDir, command); err != nil {
+	envVars := m.sessionEnv(session.AgentSessionEnv(m.agentEnvConfig(townRoot, sessionID, agentOverride), m.rig.Path), runtimeConfig)
+	envVars["GT_RUN"] = runID
+	if err := session.NewAgentSession(t, sessionID, refineryRigDir, command, envVars, townRoot, m.rig.Path, "refinery"); err != nil {
 		return fmt.Errorf("creating tmux session: %w", err)
 	}
 
-	// Set environment variables (non-fatal: session works without these)
-	// Use centralized AgentEnv for consistency across all role startup paths
-	envVars := config.AgentEnv(config.AgentEnvConfig{
-		Role:        "refinery",
-		Rig:         m.rig.Name,
-		TownRoot:    townRoot,
-		Agent:       agentOverride,
-		SessionName: sessionID,
-	})
-	envVars = session.MergeRuntimeLivenessEnv(envVars, runtimeConfig)
+	// Capture pane output to refinery/logs so scrollback survives crashes (non-fatal).
+	_ = session.EnableSessionLogCapture(t, townRoot, sessionID)
 
-	// Add refinery-specific flag
-	envVars["GT_REFINERY"] = "1"
+	// Create the refinery's configured panes/windows (non-fatal).
+	_ = session.ApplySessionLayout(t, townRoot, "refinery", sessionID, refineryRigDir)
 
-	// Set all env vars in tmux session (for debugging) and they'll also be exported to Claude
-	for k, v := range envVars {
-		_ = t.SetEnvironment(sessionID, k, v)
-	}
-	_ = t.SetEnvironment(sessionID, "GT_RUN", runID)
+	session.RunSessionHooks(townRoot, session.HookStart, sessionID, refineryRigDir)
 
 	// Apply theme (non-fatal: theming failure doesn't affect operation)
 	theme := tmux.ResolveSessionTheme(townRoot, m.rig.Name, "refinery")
@@ -270,6 +233,90 @@ func (m *Manager) Start(foreground bool, agentOverride string) error {
 	return nil
 }
 
+// sessionWorkDir returns the refinery's working directory: the refinery worktree
+// (shares .git with mayor/polecats). needsRepair reports that the worktree
+// is missing and should be recreated from the shared bare repo (.repo.git)
+// before use, instead of falling back to mayor/rig. Falling back to
+// mayor/rig causes the refinery to operate in the mayor's clone, which can
+// interfere with mayor operations and confuse agents.
+//
+// Rigs using a standard .git clone (e.g. beads) never have a .repo.git bare
+// repo, so the repair path is not applicable for them. Fall back to mayor/rig
+// silently in that case — the fallback is correct and a warning would be noise.
+func (m *Manager) sessionWorkDir() (dir string, needsRepair bool) {
+	refineryRigDir := filepath.Join(m.rig.Path, "refinery", "rig")
+	if _, err := os.Stat(refineryRigDir); !os.IsNotExist(err) {
+		return refineryRigDir, false
+	}
+	_, bareErr := os.Stat(filepath.Join(m.rig.Path, ".repo.git"))
+	_, standardGitErr := os.Stat(filepath.Join(m.rig.Path, ".git"))
+	if os.IsNotExist(bareErr) && standardGitErr == nil {
+		return filepath.Join(m.rig.Path, "mayor", "rig"), false
+	}
+	return refineryRigDir, true
+}
+
+// startupCommand builds the refinery's startup prompt and the command its
+// session runs.
+func (m *Manager) startupCommand(townRoot, sessionID, agentOverride string) (prompt, command string, err error) {
+	prompt = session.BuildStartupPrompt(session.BeaconConfig{
+		Recipient: session.BeaconRecipient("refinery", "", m.rig.Name),
+		Sender:    "deacon",
+		Topic:     "patrol",
+	}, "Run `gt prime --hook` and begin patrol.")
+
+	command, err = config.BuildStartupCommandFromConfig(config.AgentEnvConfig{
+		Role:        "refinery",
+		Rig:         m.rig.Name,
+		TownRoot:    townRoot,
+		Prompt:      prompt,
+		Topic:       "patrol",
+		SessionName: sessionID,
+	}, m.rig.Path, prompt, agentOverride)
+	if err != nil {
+		return "", "", fmt.Errorf("building startup command: %w", err)
+	}
+	return prompt, command, nil
+}
+
+// agentEnvConfig returns the AgentEnv settings for the refinery session.
+func (m *Manager) agentEnvConfig(townRoot, sessionID, agentOverride string) config.AgentEnvConfig {
+	return config.AgentEnvConfig{
+		Role:        "refinery",
+		Rig:         m.rig.Name,
+		TownRoot:    townRoot,
+		Agent:       agentOverride,
+		SessionName: sessionID,
+	}
+}
+
+// sessionEnv returns the environment set on the refinery session: agentEnv
+// (see session.AgentSessionEnv) plus liveness and refinery-specific vars.
+func (m *Manager) sessionEnv(agentEnv map[string]string, runtimeConfig *config.RuntimeConfig) map[string]string {
+	envVars := session.MergeRuntimeLivenessEnv(agentEnv, runtimeConfig)
+
+	// Add refinery-specific flag
+	envVars["GT_REFINERY"] = "1"
+	return envVars
+}
+
+// Plan returns the tmux session Start would create, without creating it or
+// repairing a missing worktree.
+func (m *Manager) Plan(agentOverride string) (*session.StartPlan, error) {
+	townRoot := filepath.Dir(m.rig.Path)
+	sessionID := m.SessionName()
+	_, command, err := m.startupCommand(townRoot, sessionID, agentOverride)
+	if err != nil {
+		return nil, err
+	}
+	workDir, _ := m.sessionWorkDir()
+	runtimeConfig := config.ResolveRoleAgentConfig("refinery", townRoot, m.rig.Path)
+	env := m.sessionEnv(session.PlannedAgentSessionEnv(m.agentEnvConfig(townRoot, sessionID, agentOverride), m.rig.Path), runtimeConfig)
+	env["GT_RUN"] = session.RunIDPlaceholder
+	command = session.AgentSessionCommand(command, townRoot, m.rig.Path, "refinery")
+	return &session.StartPlan{SessionID: sessionID, WorkDir: workDir, Command: command, Env: env}, nil
+}
+
 // repairRefineryWorktree recreates a missing refinery/rig worktree from the
 // shared bare repo (.repo.git). The refinery worktree is created during
 // `gt rig add` but can be lost if `git worktree prune` runs, the directory
@@ -286,13 +333,20 @@ func (m *Manager) repairRefineryWorktree(refineryRigDir string) error {
 		return fmt.Errorf("creating refinery dir: %w", err)
 	}
 
+	fl, err := vcs.LockWorktrees(m.rig.Path)
+	if err != nil {
+		return err
+	}
+
 	// Prune stale worktree entries so git doesn't reject the add
 	bareGit := git.NewGitWithDir(bareRepoPath, "")
 	_ = bareGit.WorktreePrune()
 
 	// Create worktree on the rig's default branch
 	defaultBranch := m.rig.DefaultBranch()
-	if err := bareGit.WorktreeAddExisting(refineryRigDir, defaultBranch); err != nil {
+	err = bareGit.WorktreeAddExisting(refineryRigDir, defaultBranch)
+	_ = fl.Unlock()
+	if err != nil {
 		return fmt.Errorf("git worktree add: %w", err)
 	}
 
@@ -319,8 +373,8 @@ func (m *Manager) Stop() error {
 		return ErrNotRunning
 	}
 
-	// Kill the tmux session
-	return t.KillSession(sessionID)
+	// Kill the tmux session and run stop hooks
+	return session.TerminateSession(t, filepath.Dir(m.rig.Path), sessionID)
 }
 
 // Queue returns the current merge queue.
@@ -0,0 +1,94 @@
+package rig
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// ResolveDefaultBranch returns the default branch of the rig at rigPath.
+// The rig's default_branch in mayor/rigs.json wins, then the one in
+// <rig>/config.json, then the branch detected from the rig's repos
+// (DetectDefaultBranch). "main" is only used when all of those come up empty.
+func ResolveDefaultBranch(rigPath string) string {
+	townRoot, name := filepath.Dir(rigPath), filepath.Base(rigPath)
+	if rigsCfg, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json")); err == nil {
+		if entry, ok := rigsCfg.Rigs[name]; ok && entry.DefaultBranch != "" {
+			return entry.DefaultBranch
+		}
+	}
+	if cfg, err := LoadRigConfig(rigPath); err == nil && cfg.DefaultBranch != "" {
+		return cfg.DefaultBranch
+	}
+	if branch := DetectDefaultBranch(rigPath); branch != "" {
+		return branch
+	}
+	return "main"
+}
+
+// DetectDefaultBranch detects the rig's default branch from its shared bare
+// repo, falling back to the mayor's clone. Returns "" if neither can tell.
+func DetectDefaultBranch(rigPath string) string {
+	bareRepoPath := filepath.Join(rigPath, ".repo.git")
+	if _, err := os.Stat(bareRepoPath); err == nil {
+		if branch := git.NewGitWithDir(bareRepoPath, "").DetectDefaultBranch(); branch != "" {
+			return branch
+		}
+	}
+	mayorRigPath := filepath.Join(rigPath, "mayor", "rig")
+	if _, err := os.Stat(mayorRigPath); err == nil {
+		return git.NewGit(mayorRigPath).DetectDefaultBranch()
+	}
+	return ""
+}
+
+// DefaultPolecatBranchPrefix is the per-polecat branch prefix template used
+// when the rig doesn't set branches.prefix.
+const DefaultPolecatBranchPrefix = "polecat/{name}"
+
+// BranchPolicy is a rig's resolved branch naming and protection policy
+// (see config.BranchPolicyConfig).
+type BranchPolicy struct {
+	// Prefix is the template for the per-polecat part of branch names.
+	Prefix string
+
+	// Protected lists branch names and globs agents must not commit to.
+	// Always includes the rig's default branch.
+	Protected []string
+}
+
+// LoadBranchPolicy returns the branch policy for the rig at rigPath,
+// applying defaults for anything its settings leave unset.
+func LoadBranchPolicy(rigPath string) *BranchPolicy {
+	p := &BranchPolicy{Prefix: DefaultPolecatBranchPrefix}
+	if settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath)); err == nil && settings.Branches != nil {
+		if settings.Branches.Prefix != "" {
+			p.Prefix = settings.Branches.Prefix
+		}
+		p.Protected = append(p.Protected, settings.Branches.Protected...)
+	}
+	p.Protected = append(p.Protected, ResolveDefaultBranch(rigPath))
+	return p
+}
+
+// IsProtected reports whether branch (a short name or refs/heads/ ref)
+// matches the policy's protected list.
+func (p *BranchPolicy) IsProtected(branch string) bool {
+	branch = strings.TrimPrefix(branch, "refs/heads/")
+	if branch == "" {
+		return false
+	}
+	for _, pattern := range p.Protected {
+		if pattern == branch {
+			return true
+		}
+		if ok, _ := path.Match(pattern, branch); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,103 @@
+package rig
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestResolveDefaultBranch(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "legacy")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nothing recorded and nothing to detect from.
+	if got := ResolveDefaultBranch(rigPath); got != "main" {
+		t.Errorf("empty rig: got %q, want main", got)
+	}
+
+	// Detected from the shared bare repo.
+	gitEnv := append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	src := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-b", "master", src},
+		{"-C", src, "commit", "--allow-empty", "-m", "init"},
+		{"clone", "--bare", src, filepath.Join(rigPath, ".repo.git")},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+	if got := ResolveDefaultBranch(rigPath); got != "master" {
+		t.Errorf("detected: got %q, want master", got)
+	}
+
+	// config.json beats detection.
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(`{"type":"rig","name":"legacy","default_branch":"develop"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := ResolveDefaultBranch(rigPath); got != "develop" {
+		t.Errorf("config.json: got %q, want develop", got)
+	}
+
+	// rigs.json beats config.json.
+	rigsCfg := &config.RigsConfig{Version: config.CurrentRigsVersion, Rigs: map[string]config.RigEntry{
+		"legacy": {DefaultBranch: "trunk"},
+	}}
+	if err := config.SaveRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"), rigsCfg); err != nil {
+		t.Fatal(err)
+	}
+	if got := (&Rig{Name: "legacy", Path: rigPath}).DefaultBranch(); got != "trunk" {
+		t.Errorf("rigs.json: got %q, want trunk", got)
+	}
+}
+
+func TestBranchPolicy(t *testing.T) {
+	townRoot := t.TempDir()
+	rigPath := filepath.Join(townRoot, "demo")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	p := LoadBranchPolicy(rigPath)
+	if p.Prefix != DefaultPolecatBranchPrefix {
+		t.Errorf("default prefix = %q, want %q", p.Prefix, DefaultPolecatBranchPrefix)
+	}
+	if !p.IsProtected("main") || !p.IsProtected("refs/heads/main") {
+		t.Error("default branch should always be protected")
+	}
+
+	settings := config.NewRigSettings()
+	settings.Branches = &config.BranchPolicyConfig{
+		Prefix:    "agents/{name}",
+		Protected: []string{"release/*", "prod"},
+	}
+	if err := config.SaveRigSettings(config.RigSettingsPath(rigPath), settings); err != nil {
+		t.Fatal(err)
+	}
+	p = LoadBranchPolicy(rigPath)
+	if p.Prefix != "agents/{name}" {
+		t.Errorf("prefix = %q, want agents/{name}", p.Prefix)
+	}
+	for branch, want := range map[string]bool{
+		"main":             true,
+		"prod":             true,
+		"release/1.2":      true,
+		"release":          false,
+		"polecat/toast-ab": false,
+		"HEAD":             false,
+		"":                 false,
+	} {
+		if got := p.IsProtected(branch); got != want {
+			t.Errorf("IsProtected(%q) = %v, want %v", branch, got, want)
+		}
+	}
+}
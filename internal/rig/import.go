@@ -0,0 +1,84 @@
+package rig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// Checkout is an existing local clone that 'gt rig import' can adopt as a
+// rig's mayor clone.
+type Checkout struct {
+	Path   string // absolute, symlinks resolved
+	GitURL string // origin's URL, "" if it has no origin
+	Dirty  bool   // has uncommitted changes
+}
+
+// InspectCheckout checks that path is the top of a regular (non-bare) git
+// checkout that could become a rig's mayor clone, and describes it.
+// Linked worktrees and submodules are rejected: their git data lives
+// elsewhere, so moving them breaks the link.
+func InspectCheckout(path string) (*Checkout, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if abs, err = filepath.EvalSymlinks(abs); err != nil {
+		return nil, fmt.Errorf("checkout not found: %w", err)
+	}
+	info, err := os.Stat(filepath.Join(abs, ".git"))
+	if err != nil {
+		return nil, fmt.Errorf("%s is not the top of a git checkout (no .git)", abs)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("%s is a linked worktree or submodule; import the main checkout it belongs to", abs)
+	}
+
+	g := git.NewGit(abs)
+	c := &Checkout{Path: abs}
+	if url, err := g.RemoteURL("origin"); err == nil {
+		c.GitURL = strings.TrimSpace(url)
+	}
+	dirty, err := g.HasUncommittedChanges()
+	if err != nil {
+		return nil, fmt.Errorf("checking %s: %w", abs, err)
+	}
+	c.Dirty = dirty
+	return c, nil
+}
+
+// placeImportedClone puts the checkout at src in place as the mayor clone at
+// dest: moved there, or with link, symlinked to it. Linked worktrees of a
+// moved checkout are re-linked. It returns a func that puts src back.
+func placeImportedClone(townRoot, src, dest string, link bool) (undo func(), err error) {
+	if rel, err := filepath.Rel(townRoot, src); err == nil && filepath.IsLocal(rel) {
+		return nil, fmt.Errorf("%s is inside the town; import a checkout from outside it", src)
+	}
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return nil, fmt.Errorf("creating mayor dir: %w", err)
+	}
+
+	if link {
+		if err := os.Symlink(src, dest); err != nil {
+			return nil, fmt.Errorf("linking %s: %w", src, err)
+		}
+		return func() { _ = os.Remove(dest) }, nil
+	}
+
+	if err := os.Rename(src, dest); err != nil {
+		return nil, fmt.Errorf("moving %s into the rig (use --link for a checkout on another filesystem): %w", src, err)
+	}
+	undo = func() {
+		if err := os.Rename(dest, src); err == nil {
+			_ = git.NewGit(src).WorktreeRepair()
+		}
+	}
+	if err := git.NewGit(dest).WorktreeRepair(); err != nil {
+		undo()
+		return nil, fmt.Errorf("re-linking worktrees of %s: %w", src, err)
+	}
+	return undo, nil
+}
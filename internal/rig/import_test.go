@@ -0,0 +1,128 @@
+package rig
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// cloneCheckout clones url into a fresh directory, as a user's existing
+// checkout, with some uncommitted work in it.
+func cloneCheckout(t *testing.T, url string) string {
+	t.Helper()
+	dir := filepath.Join(t.TempDir(), "my-checkout")
+	if out, err := exec.Command("git", "clone", "-q", url, dir).CombinedOutput(); err != nil {
+		t.Fatalf("git clone: %v\n%s", err, out)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "wip.txt"), []byte("work in progress"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestInspectCheckout(t *testing.T) {
+	remote := createTestGitRepoForRig(t, "remote")
+	checkout := cloneCheckout(t, remote)
+
+	c, err := InspectCheckout(checkout)
+	if err != nil {
+		t.Fatalf("InspectCheckout: %v", err)
+	}
+	if c.GitURL != remote || !c.Dirty {
+		t.Errorf("InspectCheckout = %+v, want origin %s and dirty", c, remote)
+	}
+
+	if _, err := InspectCheckout(filepath.Join(checkout, "sub")); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+	if err := os.Mkdir(filepath.Join(checkout, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InspectCheckout(filepath.Join(checkout, "sub")); err == nil {
+		t.Error("expected an error for a subdirectory of a checkout")
+	}
+	worktree := filepath.Join(t.TempDir(), "wt")
+	if err := git.NewGit(checkout).WorktreeAdd(worktree, "wt-branch"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := InspectCheckout(worktree); err == nil {
+		t.Error("expected an error for a linked worktree")
+	}
+}
+
+func TestAddRig_Import(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell-based bd shim not reliable on Windows CI")
+	}
+	fakeBDForAddRig(t)
+	remote := createTestGitRepoForRig(t, "remote")
+
+	t.Run("move", func(t *testing.T) {
+		root, rigsConfig := setupTestTown(t)
+		checkout := cloneCheckout(t, remote)
+		manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+		if _, err := manager.AddRig(AddRigOptions{
+			Name: "imported", GitURL: remote, BeadsPrefix: "im",
+			ImportPath: checkout, SkipDoltCheck: true,
+		}); err != nil {
+			t.Fatalf("AddRig: %v", err)
+		}
+		rigPath := filepath.Join(root, "imported")
+		if _, err := os.Stat(filepath.Join(rigPath, "mayor", "rig", "wip.txt")); err != nil {
+			t.Errorf("uncommitted work not in mayor clone: %v", err)
+		}
+		if _, err := os.Stat(checkout); !os.IsNotExist(err) {
+			t.Error("checkout should have been moved")
+		}
+		if _, err := os.Stat(filepath.Join(rigPath, ".repo.git", "objects", "info", "alternates")); !os.IsNotExist(err) {
+			t.Error("bare repo must not depend on the mayor clone's objects")
+		}
+		if _, err := os.Stat(filepath.Join(rigPath, "refinery", "rig", "README.md")); err != nil {
+			t.Errorf("refinery worktree not created: %v", err)
+		}
+		if _, ok := rigsConfig.Rigs["imported"]; !ok {
+			t.Error("rig not registered")
+		}
+	})
+
+	t.Run("link", func(t *testing.T) {
+		root, rigsConfig := setupTestTown(t)
+		checkout := cloneCheckout(t, remote)
+		manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+		if _, err := manager.AddRig(AddRigOptions{
+			Name: "linked", GitURL: remote, BeadsPrefix: "ln",
+			ImportPath: checkout, ImportLink: true, SkipDoltCheck: true,
+		}); err != nil {
+			t.Fatalf("AddRig: %v", err)
+		}
+		target, err := os.Readlink(filepath.Join(root, "linked", "mayor", "rig"))
+		if err != nil || target != checkout {
+			t.Errorf("mayor/rig link = %q, %v; want %s", target, err, checkout)
+		}
+	})
+
+	t.Run("failure puts the checkout back", func(t *testing.T) {
+		root, rigsConfig := setupTestTown(t)
+		checkout := cloneCheckout(t, remote)
+		manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+		if _, err := manager.AddRig(AddRigOptions{
+			Name: "broken", GitURL: "file:///nonexistent/repo.git", BeadsPrefix: "br",
+			ImportPath: checkout, SkipDoltCheck: true,
+		}); err == nil {
+			t.Fatal("expected AddRig to fail for an unreachable remote")
+		}
+		if _, err := os.Stat(filepath.Join(checkout, "wip.txt")); err != nil {
+			t.Errorf("checkout not restored: %v", err)
+		}
+		if _, err := os.Stat(filepath.Join(root, "broken")); !os.IsNotExist(err) {
+			t.Error("rig directory should be cleaned up")
+		}
+	})
+}
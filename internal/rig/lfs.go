@@ -0,0 +1,63 @@
+package rig
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// ErrLFSNotInstalled indicates a repo uses Git LFS but git-lfs isn't on PATH.
+var ErrLFSNotInstalled = errors.New("repo uses Git LFS but git-lfs is not installed")
+
+// LoadLFSConfig returns the rig's LFS config, or nil if the rig leaves LFS
+// to auto-detection.
+func LoadLFSConfig(rigPath string) *config.LFSConfig {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil {
+		return nil
+	}
+	return settings.LFS
+}
+
+// SetupLFS makes Git LFS content real in a freshly created worktree.
+// Worktrees cut from a bare clone check out pointer files unless git-lfs is
+// set up globally, so this installs the LFS filters and hooks in the repo's
+// config, applies the rig's fetch filters, and pulls the LFS objects.
+// Returns false with no changes when the rig doesn't use LFS.
+func SetupLFS(worktreePath string, cfg *config.LFSConfig) (bool, error) {
+	if cfg != nil && cfg.Enabled != nil && !*cfg.Enabled {
+		return false, nil
+	}
+
+	g := git.NewGit(worktreePath)
+	if cfg == nil || cfg.Enabled == nil {
+		uses, err := g.UsesLFS()
+		if err != nil {
+			return false, fmt.Errorf("detecting LFS: %w", err)
+		}
+		if !uses {
+			return false, nil
+		}
+	}
+
+	if _, err := exec.LookPath("git-lfs"); err != nil {
+		return false, ErrLFSNotInstalled
+	}
+	if err := g.LFSInstall(); err != nil {
+		return false, fmt.Errorf("installing LFS filters: %w", err)
+	}
+	var include, exclude []string
+	if cfg != nil {
+		include, exclude = cfg.Include, cfg.Exclude
+	}
+	if err := g.LFSSetFetchFilters(include, exclude); err != nil {
+		return false, fmt.Errorf("setting LFS fetch filters: %w", err)
+	}
+	if err := g.LFSPull(); err != nil {
+		return false, fmt.Errorf("fetching LFS objects: %w", err)
+	}
+	return true, nil
+}
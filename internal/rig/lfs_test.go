@@ -0,0 +1,52 @@
+package rig
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestSetupLFS(t *testing.T) {
+	gitEnv := append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	run := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	dir := t.TempDir()
+	run("init", "-b", "main", dir)
+	run("-C", dir, "commit", "--allow-empty", "-m", "init")
+
+	// No LFS rules: nothing to do.
+	if ok, err := SetupLFS(dir, nil); ok || err != nil {
+		t.Fatalf("SetupLFS(no lfs) = %v, %v; want false, nil", ok, err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.bin filter=lfs diff=lfs merge=lfs -text\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run("-C", dir, "add", ".")
+	run("-C", dir, "commit", "-m", "lfs")
+
+	// Explicitly disabled: skipped even though the repo uses LFS.
+	disabled := false
+	if ok, err := SetupLFS(dir, &config.LFSConfig{Enabled: &disabled}); ok || err != nil {
+		t.Fatalf("SetupLFS(disabled) = %v, %v; want false, nil", ok, err)
+	}
+
+	if _, err := exec.LookPath("git-lfs"); err == nil {
+		t.Skip("git-lfs is installed; missing-binary path not testable")
+	}
+	if _, err := SetupLFS(dir, nil); !errors.Is(err, ErrLFSNotInstalled) {
+		t.Fatalf("SetupLFS(lfs repo, no git-lfs) = %v, want ErrLFSNotInstalled", err)
+	}
+}
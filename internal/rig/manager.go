@@ -19,6 +19,7 @@ import (
 	"github.com/steveyegge/gastown/internal/doltserver"
 	"github.com/steveyegge/gastown/internal/git"
 	"github.com/steveyegge/gastown/internal/hooks"
+	"github.com/steveyegge/gastown/internal/logging"
 	"github.com/steveyegge/gastown/internal/templates/commands"
 	"github.com/steveyegge/gastown/internal/util"
 )
@@ -125,14 +126,14 @@ func NewManager(townRoot string, rigsConfig *config.RigsConfig, g *git.Git) *Man
 }
 
 // DiscoverRigs returns all rigs registered in the workspace.
-// Rigs that fail to load are logged to stderr and skipped; partial results are returned.
+// Rigs that fail to load are logged and skipped; partial results are returned.
 func (m *Manager) DiscoverRigs() ([]*Rig, error) {
 	var rigs []*Rig
 
 	for name, entry := range m.config.Rigs {
 		rig, err := m.loadRig(name, entry)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to load rig %q: %v\n", name, err)
+			logging.ForRig(name, "rig").Warn("failed to load rig, skipping", "err", err)
 			continue
 		}
 		rigs = append(rigs, rig)
@@ -188,12 +189,13 @@ func (m *Manager) loadRig(name string, entry config.RigEntry) (*Rig, error) {
 	}
 
 	rig := &Rig{
-		Name:      name,
-		Path:      rigPath,
-		GitURL:    entry.GitURL,
-		PushURL:   strings.TrimSpace(entry.PushURL),
-		LocalRepo: entry.LocalRepo,
-		Config:    entry.BeadsConfig,
+		Name:       name,
+		Path:       rigPath,
+		GitURL:     entry.GitURL,
+		PushURL:    strings.TrimSpace(entry.PushURL),
+		LocalRepo:  entry.LocalRepo,
+		RemoteHost: strings.TrimSpace(entry.RemoteHost),
+		Config:     entry.BeadsConfig,
 	}
 
 	// Scan for polecats
@@ -253,7 +255,10 @@ type AddRigOptions struct {
 	DefaultBranch   string   // Default branch (defaults to auto-detected from remote)
 	SkipDoltCheck   bool     // Skip Dolt server availability check (for tests with mocked beads)
 	CloneFilter     string   // Git clone filter spec (e.g. "blob:none", "tree:0") for partial clones
+	CloneDepth      int      // Shallow history depth for the bare repo and mayor clone; 0 keeps the defaults
 	SparseCheckout  []string // Sparse checkout paths (cone mode); empty means no sparse checkout
+	ImportPath      string   // Existing checkout to adopt as the mayor clone instead of cloning (see InspectCheckout)
+	ImportLink      bool     // With ImportPath, symlink the checkout instead of moving it
 }
 
 func resolveLocalRepo(path, gitURL string) (string, string) {
@@ -298,26 +303,16 @@ func resolveLocalRepo(path, gitURL string) (string, string) {
 //	├── witness/               # Witness agent (no clone)
 //	├── polecats/              # Worker directories (empty)
 //	└── crew/<crew>/           # Default human workspace
+//
+// With ImportPath, an existing checkout becomes mayor/rig instead of a fresh
+// clone, and the bare repo borrows its objects.
 func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 	if m.RigExists(opts.Name) {
 		return nil, ErrRigExists
 	}
 
-	// Validate rig name: reject characters that break agent ID parsing
-	// Agent IDs use format <prefix>-<rig>-<role>[-<name>] with hyphens as delimiters
-	if strings.ContainsAny(opts.Name, "-. /\\") {
-		sanitized := strings.NewReplacer("-", "_", ".", "_", " ", "_", "/", "_", "\\", "_").Replace(opts.Name)
-		sanitized = strings.TrimLeft(sanitized, "_")
-		sanitized = strings.ToLower(sanitized)
-		return nil, fmt.Errorf("rig name %q contains invalid characters; hyphens, dots, spaces, and path separators are not allowed. Try %q instead (underscores are allowed)", opts.Name, sanitized)
-	}
-
-	// Reject reserved names that collide with town-level infrastructure.
-	// "hq" is special-cased by EnsureMetadata and dolt routing as the town-level alias.
-	for _, reserved := range reservedRigNames {
-		if strings.EqualFold(opts.Name, reserved) {
-			return nil, fmt.Errorf("rig name %q is reserved for town-level infrastructure", opts.Name)
-		}
+	if err := validateRigName(opts.Name); err != nil {
+		return nil, err
 	}
 
 	// Dolt server is required — refuse to proceed without it.
@@ -370,6 +365,27 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 		}
 	}()
 
+	// An imported checkout is put in place first: the bare repo borrows its
+	// objects instead of downloading them again. Put it back on failure,
+	// before the cleanup above removes the rig directory.
+	mayorRigPath := filepath.Join(rigPath, "mayor", "rig")
+	if opts.ImportPath != "" {
+		undo, err := placeImportedClone(m.townRoot, opts.ImportPath, mayorRigPath, opts.ImportLink)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if !success {
+				undo()
+			}
+		}()
+		if opts.ImportLink {
+			fmt.Printf("   ✓ Linked %s as mayor clone\n", opts.ImportPath)
+		} else {
+			fmt.Printf("   ✓ Moved %s to mayor clone\n", opts.ImportPath)
+		}
+	}
+
 	// Create rig config
 	rigConfig := &RigConfig{
 		Type:        "rig",
@@ -397,6 +413,17 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 	// When branch is non-empty, git clone --branch is passed so HEAD and the initial
 	// single-branch fetch both target the user-specified branch instead of the remote HEAD.
 	cloneBareWith := func(branch string) error {
+		if opts.CloneDepth > 0 {
+			if localRepo != "" {
+				err := m.git.CloneBareShallow(opts.GitURL, bareRepoPath, branch, opts.CloneFilter, localRepo, opts.CloneDepth)
+				if err == nil {
+					return nil
+				}
+				fmt.Printf("  Warning: could not use local repo reference: %v\n", err)
+				_ = os.RemoveAll(bareRepoPath)
+			}
+			return m.git.CloneBareShallow(opts.GitURL, bareRepoPath, branch, opts.CloneFilter, "", opts.CloneDepth)
+		}
 		if opts.CloneFilter != "" && localRepo != "" {
 			if err := m.git.CloneBarePartialWithReferenceAndBranch(opts.GitURL, bareRepoPath, opts.CloneFilter, localRepo, branch); err != nil {
 				fmt.Printf("  Warning: could not use local repo reference with filter: %v\n", err)
@@ -417,10 +444,18 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 		return m.git.CloneBareWithBranch(opts.GitURL, bareRepoPath, branch)
 	}
 
-	if err := cloneBareWith(opts.DefaultBranch); err != nil {
+	if opts.ImportPath != "" {
+		if err := m.git.CloneBareDissociated(opts.GitURL, bareRepoPath, mayorRigPath, opts.DefaultBranch); err != nil {
+			return nil, wrapCloneError(err, opts.GitURL)
+		}
+	} else if err := cloneBareWith(opts.DefaultBranch); err != nil {
 		return nil, wrapCloneError(err, opts.GitURL)
 	}
-	if opts.CloneFilter != "" {
+	if opts.CloneDepth > 0 && opts.CloneFilter != "" {
+		fmt.Printf("   ✓ Created shared bare repo (depth %d, partial: --filter=%s)\n", opts.CloneDepth, opts.CloneFilter)
+	} else if opts.CloneDepth > 0 {
+		fmt.Printf("   ✓ Created shared bare repo (depth %d)\n", opts.CloneDepth)
+	} else if opts.CloneFilter != "" {
 		fmt.Printf("   ✓ Created shared bare repo (partial: --filter=%s)\n", opts.CloneFilter)
 	} else {
 		fmt.Printf("   ✓ Created shared bare repo\n")
@@ -486,25 +521,34 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 	// This also allows mayor to stay on the default branch without conflicting with refinery.
 	// Uses --reference to borrow objects from the bare repo we just created,
 	// avoiding a redundant download from the remote (GH#1059).
-	fmt.Printf("  Creating mayor clone...\n")
-	mayorRigPath := filepath.Join(rigPath, "mayor", "rig")
-	if err := os.MkdirAll(filepath.Dir(mayorRigPath), 0755); err != nil {
-		return nil, fmt.Errorf("creating mayor dir: %w", err)
-	}
-	if opts.CloneFilter != "" {
-		if err := m.git.CloneBranchPartialWithReference(opts.GitURL, mayorRigPath, defaultBranch, opts.CloneFilter, bareRepoPath); err != nil {
-			fmt.Printf("  Warning: could not use bare repo as reference with filter: %v\n", err)
+	if opts.ImportPath == "" {
+		fmt.Printf("  Creating mayor clone...\n")
+		if err := os.MkdirAll(filepath.Dir(mayorRigPath), 0755); err != nil {
+			return nil, fmt.Errorf("creating mayor dir: %w", err)
+		}
+		if opts.CloneDepth > 0 {
+			if err := m.git.CloneBranchShallow(opts.GitURL, mayorRigPath, defaultBranch, opts.CloneFilter, bareRepoPath, opts.CloneDepth); err != nil {
+				fmt.Printf("  Warning: could not use bare repo as reference: %v\n", err)
+				_ = os.RemoveAll(mayorRigPath)
+				if err := m.git.CloneBranchShallow(opts.GitURL, mayorRigPath, defaultBranch, opts.CloneFilter, "", opts.CloneDepth); err != nil {
+					return nil, fmt.Errorf("cloning for mayor: %w", err)
+				}
+			}
+		} else if opts.CloneFilter != "" {
+			if err := m.git.CloneBranchPartialWithReference(opts.GitURL, mayorRigPath, defaultBranch, opts.CloneFilter, bareRepoPath); err != nil {
+				fmt.Printf("  Warning: could not use bare repo as reference with filter: %v\n", err)
+				_ = os.RemoveAll(mayorRigPath)
+				if err := m.git.CloneBranchPartial(opts.GitURL, mayorRigPath, defaultBranch, opts.CloneFilter); err != nil {
+					return nil, fmt.Errorf("cloning for mayor: %w", err)
+				}
+			}
+		} else if err := m.git.CloneBranchWithReference(opts.GitURL, mayorRigPath, defaultBranch, bareRepoPath); err != nil {
+			fmt.Printf("  Warning: could not use bare repo as reference: %v\n", err)
 			_ = os.RemoveAll(mayorRigPath)
-			if err := m.git.CloneBranchPartial(opts.GitURL, mayorRigPath, defaultBranch, opts.CloneFilter); err != nil {
+			if err := m.git.CloneBranch(opts.GitURL, mayorRigPath, defaultBranch); err != nil {
 				return nil, fmt.Errorf("cloning for mayor: %w", err)
 			}
 		}
-	} else if err := m.git.CloneBranchWithReference(opts.GitURL, mayorRigPath, defaultBranch, bareRepoPath); err != nil {
-		fmt.Printf("  Warning: could not use bare repo as reference: %v\n", err)
-		_ = os.RemoveAll(mayorRigPath)
-		if err := m.git.CloneBranch(opts.GitURL, mayorRigPath, defaultBranch); err != nil {
-			return nil, fmt.Errorf("cloning for mayor: %w", err)
-		}
 	}
 
 	// Set up sparse checkout on mayor clone if requested
@@ -529,7 +573,9 @@ func (m *Manager) AddRig(opts AddRigOptions) (*Rig, error) {
 			return nil, fmt.Errorf("configuring mayor upstream remote: %w", err)
 		}
 	}
-	fmt.Printf("   ✓ Created mayor clone\n")
+	if opts.ImportPath == "" {
+		fmt.Printf("   ✓ Created mayor clone\n")
+	}
 
 	// Check if source repo has tracked .beads/ directory.
 	// If so, we need to initialize the database (it doesn't exist after clone since DB files are gitignored).
@@ -841,11 +887,12 @@ Use crew for your own workspace. Polecats are for batch work dispatch.
 
 	// Register in town config
 	m.config.Rigs[opts.Name] = config.RigEntry{
-		GitURL:      opts.GitURL,
-		PushURL:     opts.PushURL,
-		UpstreamURL: opts.UpstreamURL,
-		LocalRepo:   localRepo,
-		AddedAt:     time.Now(),
+		GitURL:        opts.GitURL,
+		PushURL:       opts.PushURL,
+		UpstreamURL:   opts.UpstreamURL,
+		LocalRepo:     localRepo,
+		AddedAt:       time.Now(),
+		DefaultBranch: defaultBranch,
 		BeadsConfig: &config.BeadsConfig{
 			Prefix: opts.BeadsPrefix,
 		},
@@ -1394,6 +1441,27 @@ func detectBeadsPrefixFromConfig(configPath string) string {
 	return ""
 }
 
+// validateRigName rejects names that can't be used for a rig.
+func validateRigName(name string) error {
+	// Reject characters that break agent ID parsing.
+	// Agent IDs use format <prefix>-<rig>-<role>[-<name>] with hyphens as delimiters
+	if strings.ContainsAny(name, "-. /\\") {
+		sanitized := strings.NewReplacer("-", "_", ".", "_", " ", "_", "/", "_", "\\", "_").Replace(name)
+		sanitized = strings.TrimLeft(sanitized, "_")
+		sanitized = strings.ToLower(sanitized)
+		return fmt.Errorf("rig name %q contains invalid characters; hyphens, dots, spaces, and path separators are not allowed. Try %q instead (underscores are allowed)", name, sanitized)
+	}
+
+	// Reject reserved names that collide with town-level infrastructure.
+	// "hq" is special-cased by EnsureMetadata and dolt routing as the town-level alias.
+	for _, reserved := range reservedRigNames {
+		if strings.EqualFold(name, reserved) {
+			return fmt.Errorf("rig name %q is reserved for town-level infrastructure", name)
+		}
+	}
+	return nil
+}
+
 // RemoveRig unregisters a rig (does not delete files).
 func (m *Manager) RemoveRig(name string) error {
 	if !m.RigExists(name) {
@@ -1580,12 +1648,19 @@ func (m *Manager) RegisterRig(opts RegisterRigOptions) (*RegisterRigResult, erro
 		}
 	}
 
+	// Record the default branch: existing config.json, else detect it from
+	// the adopted repos so older master/trunk repos don't get main assumed.
+	if result.DefaultBranch == "" {
+		result.DefaultBranch = DetectDefaultBranch(rigPath)
+	}
+
 	// Register in town config
 	m.config.Rigs[opts.Name] = config.RigEntry{
-		GitURL:      result.GitURL,
-		PushURL:     pushURL,
-		UpstreamURL: opts.UpstreamURL,
-		AddedAt:     time.Now(),
+		GitURL:        result.GitURL,
+		PushURL:       pushURL,
+		UpstreamURL:   opts.UpstreamURL,
+		AddedAt:       time.Now(),
+		DefaultBranch: result.DefaultBranch,
 		BeadsConfig: &config.BeadsConfig{
 			Prefix: result.BeadsPrefix,
 		},
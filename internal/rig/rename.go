@@ -0,0 +1,207 @@
+package rig
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// worktreeSearchDepth bounds how far below the rig directory RenameRig looks
+// for worktrees: polecats/<name>/<rig> is the deepest standard layout.
+const worktreeSearchDepth = 3
+
+// RenameRig renames a registered rig: it moves the rig directory, moves
+// polecat worktrees named after the rig (polecats/<name>/<rig>), repairs
+// git worktree links broken by the move, updates the name in the rig's
+// config.json, and re-registers the rig under the new name. The caller
+// saves rigs.json and updates anything else keyed by rig name (beads
+// routes, daemon patrols, hooks overrides). If a step after the move
+// fails, the rig is moved back and nothing is re-registered.
+//
+// Sessions must be stopped first: running agents hold the old paths.
+func (m *Manager) RenameRig(oldName, newName string) error {
+	entry, ok := m.config.Rigs[oldName]
+	if !ok {
+		return ErrRigNotFound
+	}
+	if oldName == newName {
+		return fmt.Errorf("rig is already named %q", newName)
+	}
+	if m.RigExists(newName) {
+		return ErrRigExists
+	}
+	if err := validateRigName(newName); err != nil {
+		return err
+	}
+
+	oldPath := filepath.Join(m.townRoot, oldName)
+	newPath := filepath.Join(m.townRoot, newName)
+	if _, err := os.Lstat(newPath); err == nil {
+		return fmt.Errorf("%s already exists", newPath)
+	}
+	if err := os.Rename(oldPath, newPath); err != nil {
+		return fmt.Errorf("moving rig directory: %w", err)
+	}
+
+	if err := fixupMovedRig(newPath, oldPath, oldName, newName); err != nil {
+		// Put the rig back where rigs.json and the routes still expect it.
+		if rbErr := undoRigMove(oldPath, newPath, oldName, newName); rbErr != nil {
+			return fmt.Errorf("%w (rolling back also failed: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	delete(m.config.Rigs, oldName)
+	m.config.Rigs[newName] = entry
+	return nil
+}
+
+// fixupMovedRig updates a rig directory just moved from oldPath to rigPath:
+// polecat worktrees named after the rig, git worktree links, and the name
+// in config.json.
+func fixupMovedRig(rigPath, oldPath, oldName, newName string) error {
+	if err := renamePolecatClones(rigPath, oldName, newName); err != nil {
+		return err
+	}
+	if err := repairWorktrees(rigPath, oldPath); err != nil {
+		return err
+	}
+	return renameRigConfig(rigPath, newName)
+}
+
+// undoRigMove moves a rig back from newPath to oldPath after fixupMovedRig
+// failed, and reverts the polecat and worktree fixups. config.json needs no
+// undo: it is written last, so it still carries the old name.
+func undoRigMove(oldPath, newPath, oldName, newName string) error {
+	if err := os.Rename(newPath, oldPath); err != nil {
+		return fmt.Errorf("moving rig directory back: %w", err)
+	}
+	if err := renamePolecatClones(oldPath, newName, oldName); err != nil {
+		return err
+	}
+	return repairWorktrees(oldPath, newPath)
+}
+
+// renamePolecatClones moves polecats/<name>/<oldName> worktrees to
+// polecats/<name>/<newName>, the layout polecat.Manager expects.
+func renamePolecatClones(rigPath, oldName, newName string) error {
+	entries, err := os.ReadDir(filepath.Join(rigPath, "polecats"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading polecats: %w", err)
+	}
+	for _, e := range entries {
+		if !e.IsDir() {
+			continue
+		}
+		from := filepath.Join(rigPath, "polecats", e.Name(), oldName)
+		if _, err := os.Stat(from); err != nil {
+			continue
+		}
+		to := filepath.Join(rigPath, "polecats", e.Name(), newName)
+		if err := os.Rename(from, to); err != nil {
+			return fmt.Errorf("moving polecat %s: %w", e.Name(), err)
+		}
+	}
+	return nil
+}
+
+// repairWorktrees re-links the worktrees under rigPath after the rig moved
+// from oldPath. Worktrees are grouped by the repository their .git file
+// points at (.repo.git for the refinery and polecats), and each repository
+// runs 'git worktree repair' on its own worktrees.
+func repairWorktrees(rigPath, oldPath string) error {
+	byRepo := make(map[string][]string)
+	var order []string
+	err := findWorktrees(rigPath, 0, func(worktree, gitDir string) {
+		// gitDir is <repo>/worktrees/<id>, still under the old rig path.
+		repo := filepath.Dir(filepath.Dir(gitDir))
+		if rel, err := filepath.Rel(oldPath, repo); err == nil && !strings.HasPrefix(rel, "..") {
+			repo = filepath.Join(rigPath, rel)
+		}
+		if _, seen := byRepo[repo]; !seen {
+			order = append(order, repo)
+		}
+		byRepo[repo] = append(byRepo[repo], worktree)
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, repo := range order {
+		g := git.NewGitWithDir(repo, rigPath)
+		if err := g.WorktreeRepair(byRepo[repo]...); err != nil {
+			return fmt.Errorf("repairing worktrees of %s: %w", repo, err)
+		}
+	}
+	return nil
+}
+
+// findWorktrees calls fn for each linked worktree (a directory whose .git is
+// a "gitdir:" file) within worktreeSearchDepth levels of dir. It doesn't
+// descend into git checkouts or hidden directories.
+func findWorktrees(dir string, depth int, fn func(worktree, gitDir string)) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if !e.IsDir() || strings.HasPrefix(e.Name(), ".") {
+			continue
+		}
+		sub := filepath.Join(dir, e.Name())
+		info, err := os.Lstat(filepath.Join(sub, ".git"))
+		switch {
+		case err == nil && info.Mode().IsRegular():
+			data, err := os.ReadFile(filepath.Join(sub, ".git")) //nolint:gosec // G304: inside the rig directory
+			if err != nil {
+				return err
+			}
+			gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+			if !ok {
+				continue
+			}
+			gitDir = strings.TrimSpace(gitDir)
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(sub, gitDir)
+			}
+			fn(sub, gitDir)
+		case err == nil:
+			// A full clone (mayor/rig): nothing to repair inside it.
+		case depth+1 < worktreeSearchDepth:
+			if err := findWorktrees(sub, depth+1, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renameRigConfig sets the name in the rig's config.json, keeping any
+// fields this version of gt doesn't know about.
+func renameRigConfig(rigPath, name string) error {
+	configPath := filepath.Join(rigPath, "config.json")
+	data, err := os.ReadFile(configPath) //nolint:gosec // G304: inside the rig directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading rig config: %w", err)
+	}
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing rig config: %w", err)
+	}
+	doc["name"], _ = json.Marshal(name)
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(configPath, out, 0644)
+}
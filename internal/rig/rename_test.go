@@ -0,0 +1,169 @@
+package rig
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// setupRenameRig builds a rig with a .repo.git bare repo, a refinery
+// worktree and a polecat worktree in the polecats/<name>/<rig> layout.
+func setupRenameRig(t *testing.T, root, name string) {
+	t.Helper()
+	rigPath := filepath.Join(root, name)
+	src := t.TempDir()
+	runGit(t, src, "init", "-q", "-b", "main")
+	runGit(t, src, "-c", "user.email=t@example.com", "-c", "user.name=t", "commit", "-q", "--allow-empty", "-m", "init")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, rigPath, "clone", "-q", "--bare", src, ".repo.git")
+	bare := filepath.Join(rigPath, ".repo.git")
+	runGit(t, rigPath, "--git-dir="+bare, "worktree", "add", "-q", filepath.Join(rigPath, "refinery", "rig"), "main")
+	runGit(t, rigPath, "--git-dir="+bare, "worktree", "add", "-q", "-b", "polecat/toast", filepath.Join(rigPath, "polecats", "toast", name), "main")
+
+	cfg := `{"type":"rig","version":1,"name":"` + name + `","git_url":"` + src + `","future_field":true}`
+	if err := os.WriteFile(filepath.Join(rigPath, "config.json"), []byte(cfg), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestRenameRig(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	setupRenameRig(t, root, "alpha")
+	rigsConfig.Rigs["alpha"] = config.RigEntry{GitURL: "https://example.com/alpha.git"}
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	if err := manager.RenameRig("alpha", "beta"); err != nil {
+		t.Fatalf("RenameRig: %v", err)
+	}
+
+	if manager.RigExists("alpha") || !manager.RigExists("beta") {
+		t.Fatalf("registry = %v, want only beta", manager.ListRigNames())
+	}
+	if got := rigsConfig.Rigs["beta"].GitURL; got != "https://example.com/alpha.git" {
+		t.Errorf("entry not carried over: git_url = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(root, "alpha")); !os.IsNotExist(err) {
+		t.Errorf("old rig directory still present: %v", err)
+	}
+
+	rigPath := filepath.Join(root, "beta")
+	polecat := filepath.Join(rigPath, "polecats", "toast", "beta")
+	for _, wt := range []string{filepath.Join(rigPath, "refinery", "rig"), polecat} {
+		if got := runGit(t, wt, "rev-parse", "--show-toplevel"); got != wt {
+			t.Errorf("worktree %s resolves to %q after rename", wt, got)
+		}
+	}
+	list := runGit(t, rigPath, "--git-dir="+filepath.Join(rigPath, ".repo.git"), "worktree", "list")
+	if strings.Contains(list, filepath.Join(root, "alpha")) || strings.Contains(list, "prunable") {
+		t.Errorf("worktree registry still references the old rig:\n%s", list)
+	}
+
+	data, err := os.ReadFile(filepath.Join(rigPath, "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["name"] != "beta" || doc["future_field"] != true {
+		t.Errorf("config.json = %s, want name beta and unknown fields kept", data)
+	}
+}
+
+// TestRenameRig_RollsBackOnFailure verifies a rename that fails after the
+// directory moved puts the rig back under its old name, with its polecat
+// worktree and git links restored.
+func TestRenameRig_RollsBackOnFailure(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	setupRenameRig(t, root, "alpha")
+	rigsConfig.Rigs["alpha"] = config.RigEntry{}
+	// An unparseable config.json fails the last step, after the polecat
+	// worktree has been moved and the worktree links repaired.
+	if err := os.WriteFile(filepath.Join(root, "alpha", "config.json"), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	err := manager.RenameRig("alpha", "beta")
+	if err == nil || !strings.Contains(err.Error(), "parsing rig config") {
+		t.Fatalf("RenameRig = %v, want rig config error", err)
+	}
+	if strings.Contains(err.Error(), "rolling back") {
+		t.Fatalf("rollback failed: %v", err)
+	}
+
+	if !manager.RigExists("alpha") || manager.RigExists("beta") {
+		t.Errorf("registry = %v, want only alpha", manager.ListRigNames())
+	}
+	if _, err := os.Stat(filepath.Join(root, "beta")); !os.IsNotExist(err) {
+		t.Errorf("new rig directory left behind: %v", err)
+	}
+	rigPath := filepath.Join(root, "alpha")
+	polecat := filepath.Join(rigPath, "polecats", "toast", "alpha")
+	for _, wt := range []string{filepath.Join(rigPath, "refinery", "rig"), polecat} {
+		if got := runGit(t, wt, "rev-parse", "--show-toplevel"); got != wt {
+			t.Errorf("worktree %s resolves to %q after rollback", wt, got)
+		}
+	}
+	list := runGit(t, rigPath, "--git-dir="+filepath.Join(rigPath, ".repo.git"), "worktree", "list")
+	if strings.Contains(list, filepath.Join(root, "beta")) || strings.Contains(list, "prunable") {
+		t.Errorf("worktree registry still references the new name:\n%s", list)
+	}
+}
+
+func TestRenameRig_Rejects(t *testing.T) {
+	root, rigsConfig := setupTestTown(t)
+	rigsConfig.Rigs["alpha"] = config.RigEntry{}
+	rigsConfig.Rigs["taken"] = config.RigEntry{}
+	if err := os.MkdirAll(filepath.Join(root, "alpha"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "stray"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	manager := NewManager(root, rigsConfig, git.NewGit(root))
+
+	tests := []struct {
+		old, new string
+		want     string
+	}{
+		{"missing", "beta", "not found"},
+		{"alpha", "taken", "already exists"},
+		{"alpha", "my-rig", "invalid characters"},
+		{"alpha", "hq", "reserved"},
+		{"alpha", "stray", "already exists"},
+		{"alpha", "alpha", "already named"},
+	}
+	for _, tt := range tests {
+		err := manager.RenameRig(tt.old, tt.new)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("RenameRig(%q, %q) = %v, want error containing %q", tt.old, tt.new, err, tt.want)
+		}
+	}
+	if !manager.RigExists("alpha") {
+		t.Error("rejected renames must leave the rig registered")
+	}
+	if _, err := os.Stat(filepath.Join(root, "alpha")); err != nil {
+		t.Errorf("rejected renames must leave the directory: %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package rig
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// LoadSigningConfig returns the rig's commit signing config, or nil if the
+// rig doesn't configure signing.
+func LoadSigningConfig(rigPath string) *config.SigningConfig {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil {
+		return nil
+	}
+	return settings.Signing
+}
+
+// SigningAccount returns the handle of the account agents in the town run
+// under (GT_ACCOUNT, else the default in mayor/accounts.json), for picking
+// a per-account signing key. Empty if no account is configured.
+func SigningAccount(townRoot string) string {
+	_, handle, _ := config.ResolveAccountConfigDir(constants.MayorAccountsPath(townRoot), "")
+	return handle
+}
+
+// ConfigureCommitSigning writes cfg into the worktree's own git config, so
+// commits made there are signed with the key for role and account without
+// touching the shared repo or other worktrees. A nil cfg is a no-op.
+func ConfigureCommitSigning(worktreePath string, cfg *config.SigningConfig, role, account string) error {
+	if cfg == nil {
+		return nil
+	}
+	key := cfg.KeyFor(role, account)
+	if key == "" {
+		if cfg.Required {
+			return fmt.Errorf("signing is required but no key is configured for role %q", role)
+		}
+		return nil
+	}
+
+	g := git.NewGit(worktreePath)
+	if err := g.EnableWorktreeConfig(); err != nil {
+		return fmt.Errorf("enabling per-worktree config: %w", err)
+	}
+
+	settings := [][2]string{
+		{"commit.gpgsign", "true"},
+		{"tag.gpgsign", "true"},
+		{"user.signingkey", key},
+	}
+	switch cfg.Format {
+	case "ssh":
+		settings = append(settings, [2]string{"gpg.format", "ssh"})
+	case "gpg":
+		settings = append(settings, [2]string{"gpg.format", "openpgp"})
+	}
+	if cfg.AllowedSigners != "" {
+		settings = append(settings, [2]string{"gpg.ssh.allowedSignersFile", cfg.AllowedSigners})
+	}
+	for _, kv := range settings {
+		if err := g.SetWorktreeConfig(kv[0], kv[1]); err != nil {
+			return fmt.Errorf("setting %s: %w", kv[0], err)
+		}
+	}
+	return nil
+}
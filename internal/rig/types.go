@@ -23,6 +23,10 @@ type Rig struct {
 	// LocalRepo is an optional local repository used for reference clones.
 	LocalRepo string `json:"local_repo,omitempty"`
 
+	// RemoteHost is the ssh destination where polecat sessions run.
+	// Empty means sessions run on the local tmux server.
+	RemoteHost string `json:"remote_host,omitempty"`
+
 	// Config is the rig-level configuration.
 	Config *config.BeadsConfig `json:"config,omitempty"`
 
@@ -87,12 +91,8 @@ func (r *Rig) BeadsPath() string {
 	return r.Path
 }
 
-// DefaultBranch returns the configured default branch for this rig.
-// Falls back to "main" if not configured or if config cannot be loaded.
+// DefaultBranch returns the default branch for this rig.
+// See ResolveDefaultBranch.
 func (r *Rig) DefaultBranch() string {
-	cfg, err := LoadRigConfig(r.Path)
-	if err != nil || cfg.DefaultBranch == "" {
-		return "main"
-	}
-	return cfg.DefaultBranch
+	return ResolveDefaultBranch(r.Path)
 }
@@ -0,0 +1,26 @@
+package secrets
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+func readKeychain(name string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", KeychainService, "-a", name, "-w").Output()
+	if err != nil {
+		return "", fmt.Errorf("%w: keychain item %s/%s: %v", ErrNotFound, KeychainService, name, err)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// writeKeychain passes -w last so security prompts for the password and its
+// confirmation on stdin, keeping the value off the command line.
+func writeKeychain(name, value string) error {
+	cmd := exec.Command("security", "add-generic-password", "-U", "-s", KeychainService, "-a", name, "-w")
+	cmd.Stdin = strings.NewReader(value + "\n" + value + "\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("writing keychain item %s/%s: %s: %w", KeychainService, name, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
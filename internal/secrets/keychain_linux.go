@@ -0,0 +1,30 @@
+package secrets
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// On Linux the keychain is the Secret Service (GNOME Keyring, KWallet),
+// reached through secret-tool from libsecret.
+
+func readKeychain(name string) (string, error) {
+	out, err := exec.Command("secret-tool", "lookup", "service", KeychainService, "account", name).Output()
+	if err != nil {
+		if _, lookErr := exec.LookPath("secret-tool"); lookErr != nil {
+			return "", fmt.Errorf("keychain:%s needs secret-tool (libsecret-tools): %w", name, lookErr)
+		}
+		return "", fmt.Errorf("%w: keychain item %s/%s", ErrNotFound, KeychainService, name)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+func writeKeychain(name, value string) error {
+	cmd := exec.Command("secret-tool", "store", "--label", KeychainService+" "+name, "service", KeychainService, "account", name)
+	cmd.Stdin = strings.NewReader(value)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("writing keychain item %s/%s: %s: %w", KeychainService, name, strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
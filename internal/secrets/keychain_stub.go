@@ -0,0 +1,10 @@
+//go:build !darwin && !linux
+
+package secrets
+
+import "errors"
+
+var errNoKeychain = errors.New("keychain secrets are only supported on macOS and Linux; use gpg: or env:")
+
+func readKeychain(_ string) (string, error) { return "", errNoKeychain }
+func writeKeychain(_, _ string) error       { return errNoKeychain }
@@ -0,0 +1,104 @@
+// Package secrets resolves secret references from town and rig config.
+//
+// Config never holds a secret's value, only a reference to where it lives:
+//
+//	keychain:NAME   the OS keychain (macOS Keychain, or the Secret Service
+//	                via secret-tool on Linux), service "gastown", account NAME
+//	gpg:PATH        a gpg-encrypted file, decrypted with gpg --decrypt
+//	env:NAME        a variable from gt's own environment
+//
+// References are resolved when an agent session is spawned.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// KeychainService is the keychain service name secrets are stored under.
+const KeychainService = "gastown"
+
+// Reference schemes.
+const (
+	SchemeKeychain = "keychain"
+	SchemeGPG      = "gpg"
+	SchemeEnv      = "env"
+)
+
+// ErrInvalidRef indicates a value that is not a supported secret reference,
+// including plaintext values.
+var ErrInvalidRef = errors.New("invalid secret reference")
+
+// ErrNotFound indicates a reference that resolved to nothing.
+var ErrNotFound = errors.New("secret not found")
+
+// ParseRef splits a reference into its scheme and name.
+func ParseRef(ref string) (scheme, name string, err error) {
+	scheme, name, ok := strings.Cut(ref, ":")
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("%w: %q (want keychain:NAME, gpg:PATH or env:NAME; plaintext values are not allowed)", ErrInvalidRef, ref)
+	}
+	switch scheme {
+	case SchemeKeychain, SchemeGPG, SchemeEnv:
+		return scheme, name, nil
+	}
+	return "", "", fmt.Errorf("%w: unknown scheme %q in %q", ErrInvalidRef, scheme, ref)
+}
+
+// Resolve returns the value a reference points to.
+func Resolve(ref string) (string, error) {
+	scheme, name, err := ParseRef(ref)
+	if err != nil {
+		return "", err
+	}
+	var value string
+	switch scheme {
+	case SchemeKeychain:
+		value, err = readKeychain(name)
+	case SchemeGPG:
+		value, err = decryptGPG(name)
+	case SchemeEnv:
+		var ok bool
+		if value, ok = os.LookupEnv(name); !ok {
+			err = fmt.Errorf("%w: $%s is not set", ErrNotFound, name)
+		}
+	}
+	if err != nil {
+		return "", err
+	}
+	if value == "" {
+		return "", fmt.Errorf("%w: %s is empty", ErrNotFound, ref)
+	}
+	return value, nil
+}
+
+// Store saves value in the OS keychain under name, for keychain:NAME.
+func Store(name, value string) error {
+	if name == "" {
+		return fmt.Errorf("%w: empty keychain name", ErrInvalidRef)
+	}
+	return writeKeychain(name, value)
+}
+
+// decryptGPG decrypts a gpg file. A trailing newline is dropped, since
+// secrets are usually written with echo.
+func decryptGPG(path string) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			path = filepath.Join(home, rest)
+		}
+	}
+	out, err := exec.Command("gpg", "--batch", "--quiet", "--decrypt", path).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(exitErr.Stderr) > 0 {
+			return "", fmt.Errorf("decrypting %s: %s", path, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", fmt.Errorf("decrypting %s: %w", path, err)
+	}
+	return strings.TrimRight(string(out), "\r\n"), nil
+}
@@ -0,0 +1,47 @@
+package secrets
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseRef(t *testing.T) {
+	tests := []struct {
+		ref, scheme, name string
+		wantErr           bool
+	}{
+		{"keychain:GITHUB_TOKEN", SchemeKeychain, "GITHUB_TOKEN", false},
+		{"gpg:~/.secrets/jira.gpg", SchemeGPG, "~/.secrets/jira.gpg", false},
+		{"env:CI_TOKEN", SchemeEnv, "CI_TOKEN", false},
+		{"ghp_plaintexttoken", "", "", true},
+		{"keychain:", "", "", true},
+		{"vault:secret/x", "", "", true},
+	}
+	for _, tt := range tests {
+		scheme, name, err := ParseRef(tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseRef(%q) error = %v, wantErr %v", tt.ref, err, tt.wantErr)
+			continue
+		}
+		if err != nil && !errors.Is(err, ErrInvalidRef) {
+			t.Errorf("ParseRef(%q) error = %v, want ErrInvalidRef", tt.ref, err)
+		}
+		if scheme != tt.scheme || name != tt.name {
+			t.Errorf("ParseRef(%q) = %q, %q", tt.ref, scheme, name)
+		}
+	}
+}
+
+func TestResolveEnv(t *testing.T) {
+	t.Setenv("GT_TEST_SECRET", "s3cret")
+	if got, err := Resolve("env:GT_TEST_SECRET"); err != nil || got != "s3cret" {
+		t.Errorf("Resolve = %q, %v", got, err)
+	}
+	if _, err := Resolve("env:GT_TEST_SECRET_UNSET"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unset var: err = %v, want ErrNotFound", err)
+	}
+	t.Setenv("GT_TEST_SECRET", "")
+	if _, err := Resolve("env:GT_TEST_SECRET"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("empty var: err = %v, want ErrNotFound", err)
+	}
+}
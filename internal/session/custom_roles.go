@@ -0,0 +1,62 @@
+package session
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// CustomRoleSessionName returns the session name for a worker of a custom
+// role: hq-<role>-<name> for town-scoped roles, <prefix>-<role>-<name> for
+// rig-scoped ones.
+func CustomRoleSessionName(rigPrefix, role, name string) string {
+	if rigPrefix == "" {
+		return fmt.Sprintf("%s%s-%s", HQPrefix, role, name)
+	}
+	return fmt.Sprintf("%s-%s-%s", rigPrefix, role, name)
+}
+
+// customRole returns the custom role definition for r, if r is one.
+func customRole(r Role) (*config.CustomRoleConfig, bool) {
+	return config.LookupCustomRole(string(r))
+}
+
+// parseCustomRoleRest matches the part of a session name after its prefix
+// ("<role>-<name>") against custom roles of the given scope.
+func parseCustomRoleRest(rest string, townScoped bool) (Role, string, bool) {
+	role, name, ok := strings.Cut(rest, "-")
+	if !ok || name == "" {
+		return "", "", false
+	}
+	cfg, found := config.LookupCustomRole(role)
+	if !found || cfg.IsTownScoped() != townScoped {
+		return "", "", false
+	}
+	return Role(role), name, true
+}
+
+// customSessionName, customAddress, and customRoleDir are the custom-role
+// cases of the AgentIdentity methods.
+func (a *AgentIdentity) customSessionName(cfg *config.CustomRoleConfig) string {
+	if cfg.IsTownScoped() {
+		return CustomRoleSessionName("", string(a.Role), a.Name)
+	}
+	return CustomRoleSessionName(a.prefix(), string(a.Role), a.Name)
+}
+
+func (a *AgentIdentity) customAddress(cfg *config.CustomRoleConfig) string {
+	if cfg.IsTownScoped() {
+		return fmt.Sprintf("%s/%s", a.Role, a.Name)
+	}
+	return fmt.Sprintf("%s/%s/%s", a.Rig, a.Role, a.Name)
+}
+
+func (a *AgentIdentity) customRoleDir(townRoot string, cfg *config.CustomRoleConfig) string {
+	dir := cfg.WorkerDir(string(a.Role))
+	if cfg.IsTownScoped() {
+		return filepath.Join(townRoot, dir, a.Name)
+	}
+	return filepath.Join(townRoot, a.Rig, dir, a.Name)
+}
@@ -0,0 +1,81 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func setTestCustomRoles(t *testing.T) {
+	t.Helper()
+	config.SetCustomRoles(map[string]*config.CustomRoleConfig{
+		"reviewer":   {Dir: "reviewers"},
+		"researcher": {Scope: config.CustomRoleScopeTown},
+	})
+	t.Cleanup(func() { config.SetCustomRoles(nil) })
+}
+
+func TestCustomRoleIdentity(t *testing.T) {
+	setTestCustomRoles(t)
+	reg := testRegistry()
+	townRoot := "/town"
+
+	tests := []struct {
+		address string
+		session string
+		rig     string
+		role    Role
+		name    string
+		dir     string
+	}{
+		{"gastown/reviewer/ann", "gt-reviewer-ann", "gastown", "reviewer", "ann", filepath.Join(townRoot, "gastown", "reviewers", "ann")},
+		{"researcher/bob", "hq-researcher-bob", "", "researcher", "bob", filepath.Join(townRoot, "researcher", "bob")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			id, err := ParseAddress(tt.address)
+			if err != nil {
+				t.Fatalf("ParseAddress(%q): %v", tt.address, err)
+			}
+			if id.Role != tt.role || id.Rig != tt.rig || id.Name != tt.name {
+				t.Errorf("ParseAddress(%q) = %+v", tt.address, id)
+			}
+			if got := id.SessionName(); got != tt.session {
+				t.Errorf("SessionName() = %q, want %q", got, tt.session)
+			}
+			if got := id.RoleDir(townRoot); got != tt.dir {
+				t.Errorf("RoleDir() = %q, want %q", got, tt.dir)
+			}
+
+			fromSession, err := ParseSessionNameWithRegistry(tt.session, reg)
+			if err != nil {
+				t.Fatalf("ParseSessionNameWithRegistry(%q): %v", tt.session, err)
+			}
+			if fromSession.Role != tt.role || fromSession.Rig != tt.rig || fromSession.Name != tt.name {
+				t.Errorf("ParseSessionNameWithRegistry(%q) = %+v", tt.session, fromSession)
+			}
+			if got := fromSession.Address(); got != tt.address {
+				t.Errorf("Address() = %q, want %q", got, tt.address)
+			}
+		})
+	}
+}
+
+func TestCustomRoleScopeMismatch(t *testing.T) {
+	setTestCustomRoles(t)
+
+	// A town-scoped role has no rig-level address.
+	if _, err := ParseAddress("gastown/researcher/bob"); err == nil {
+		t.Error("ParseAddress(gastown/researcher/bob) succeeded for a town-scoped role")
+	}
+
+	// Unregistered roles still parse as polecats.
+	id, err := ParseSessionNameWithRegistry("gt-auditor-cat", testRegistry())
+	if err != nil {
+		t.Fatalf("ParseSessionNameWithRegistry: %v", err)
+	}
+	if id.Role != RolePolecat || id.Name != "auditor-cat" {
+		t.Errorf("gt-auditor-cat = %+v, want polecat auditor-cat", id)
+	}
+}
@@ -0,0 +1,99 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// envSetter is the tmux operation needed to write a session's environment.
+// Satisfied by *tmux.Tmux.
+type envSetter interface {
+	SetEnvironment(session, key, value string) error
+}
+
+// AgentSessionEnv returns the environment for an agent session: custom
+// session_env vars from town and rig settings, overlaid with the standard
+// AgentEnv set (GT_ROLE, GT_RIG, GT_POLECAT, GT_CREW, BD_ACTOR, ...).
+//
+// Spawn, start, restart, and crew paths all build session env here so that
+// no path can drop an identity var that gt prime and the tap guards rely on.
+// rigPath may be empty for town-level agents.
+func AgentSessionEnv(cfg config.AgentEnvConfig, rigPath string) map[string]string {
+	return withAgentEnv(config.ConfiguredSessionEnv(cfg.TownRoot, rigPath, cfg.Role), cfg)
+}
+
+// PlannedAgentSessionEnv is AgentSessionEnv for dry runs: secrets are not
+// resolved and show as references (see config.PlannedSessionEnv).
+func PlannedAgentSessionEnv(cfg config.AgentEnvConfig, rigPath string) map[string]string {
+	return withAgentEnv(config.PlannedSessionEnv(cfg.TownRoot, rigPath, cfg.Role), cfg)
+}
+
+// SplitSecretEnv separates the secrets configured for role out of env.
+// The plain vars may go on a command line (PrependEnv); the secrets must be
+// handed to tmux instead, where ps does not show them.
+func SplitSecretEnv(env map[string]string, townRoot, rigPath, role string) (plain, secret map[string]string) {
+	refs := config.ConfiguredSecretRefs(townRoot, rigPath, role)
+	plain = make(map[string]string, len(env))
+	secret = make(map[string]string, len(refs))
+	for k, v := range env {
+		if _, ok := refs[k]; ok {
+			secret[k] = v
+		} else {
+			plain[k] = v
+		}
+	}
+	return plain, secret
+}
+
+// withAgentEnv overlays the AgentEnv set for cfg onto env.
+func withAgentEnv(env map[string]string, cfg config.AgentEnvConfig) map[string]string {
+	for k, v := range config.AgentEnv(cfg) {
+		env[k] = v
+	}
+	return env
+}
+
+// SetSessionEnv writes env into the tmux session table in key order, so
+// respawned processes inherit it. Every var is attempted; failures are
+// joined into the returned error, which callers may treat as non-fatal.
+func SetSessionEnv(t envSetter, sessionID string, env map[string]string) error {
+	var errs []error
+	for _, k := range mapKeysSorted(env) {
+		if err := t.SetEnvironment(sessionID, k, env[k]); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", k, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// agentSessionOps is the tmux operations NewAgentSession needs.
+// Satisfied by *tmux.Tmux.
+type agentSessionOps interface {
+	envSetter
+	NewSessionWithCommandAndEnv(name, workDir, command string, env map[string]string) error
+}
+
+// AgentSessionCommand returns command with the session_env vars configured
+// for role exported in front of it, so the agent's first process inherits
+// them. Secrets are left out: ps shows the command line.
+func AgentSessionCommand(command, townRoot, rigPath, role string) string {
+	return config.PrependEnv(command, config.ConfiguredSessionVars(townRoot, rigPath, role))
+}
+
+// NewAgentSession creates sessionID running command in workDir for an agent
+// whose environment is env (see AgentSessionEnv). The session_env vars are
+// exported on the command line (AgentSessionCommand), the secrets configured
+// for role are handed to tmux at creation, and all of env is then written to
+// the session table so respawned processes inherit it. Only the session
+// creation is fatal.
+func NewAgentSession(t agentSessionOps, sessionID, workDir, command string, env map[string]string, townRoot, rigPath, role string) error {
+	_, secretEnv := SplitSecretEnv(env, townRoot, rigPath, role)
+	command = AgentSessionCommand(command, townRoot, rigPath, role)
+	if err := t.NewSessionWithCommandAndEnv(sessionID, workDir, command, secretEnv); err != nil {
+		return err
+	}
+	_ = SetSessionEnv(t, sessionID, env)
+	return nil
+}
@@ -0,0 +1,111 @@
+package session
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+type fakeEnvSetter struct {
+	set  map[string]string
+	fail string
+}
+
+func (f *fakeEnvSetter) SetEnvironment(_, key, value string) error {
+	if key == f.fail {
+		return errors.New("boom")
+	}
+	f.set[key] = value
+	return nil
+}
+
+type fakeSessionCreator struct {
+	fakeEnvSetter
+	command string
+	initEnv map[string]string
+}
+
+func (f *fakeSessionCreator) NewSessionWithCommandAndEnv(_, _, command string, env map[string]string) error {
+	f.command = command
+	f.initEnv = env
+	return nil
+}
+
+func TestAgentSessionEnv_IdentityWinsOverConfig(t *testing.T) {
+	townRoot := t.TempDir()
+	town := config.NewTownSettings()
+	town.SessionEnv = &config.SessionEnvConfig{Vars: map[string]string{"TEAM": "core"}}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+
+	env := AgentSessionEnv(config.AgentEnvConfig{
+		Role:        "polecat",
+		Rig:         "gastown",
+		AgentName:   "Toast",
+		TownRoot:    townRoot,
+		SessionName: "gt-Toast",
+	}, "")
+
+	want := map[string]string{
+		"TEAM":       "core",
+		"GT_ROLE":    "gastown/polecats/Toast",
+		"GT_RIG":     "gastown",
+		"GT_POLECAT": "Toast",
+		"GT_SESSION": "gt-Toast",
+		"GT_ROOT":    townRoot,
+	}
+	for k, v := range want {
+		if env[k] != v {
+			t.Errorf("%s = %q, want %q", k, env[k], v)
+		}
+	}
+}
+
+func TestSetSessionEnv_ContinuesPastErrors(t *testing.T) {
+	f := &fakeEnvSetter{set: map[string]string{}, fail: "B"}
+	err := SetSessionEnv(f, "gt-x", map[string]string{"A": "1", "B": "2", "C": "3"})
+	if err == nil {
+		t.Fatal("expected error for B")
+	}
+	if f.set["A"] != "1" || f.set["C"] != "3" {
+		t.Errorf("set = %v, want A and C written", f.set)
+	}
+}
+
+func TestNewAgentSession_SecretsStayOffCommandLine(t *testing.T) {
+	townRoot := t.TempDir()
+	t.Setenv("GT_TEST_WITNESS_TOKEN", "s3cret")
+	town := config.NewTownSettings()
+	town.SessionEnv = &config.SessionEnvConfig{Roles: map[string]map[string]string{"witness": {"TEAM": "core"}}}
+	town.Secrets = &config.SessionEnvConfig{Vars: map[string]string{"API_TOKEN": "env:GT_TEST_WITNESS_TOKEN"}}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+
+	env := AgentSessionEnv(config.AgentEnvConfig{Role: "witness", Rig: "gastown", TownRoot: townRoot}, "")
+	f := &fakeSessionCreator{fakeEnvSetter: fakeEnvSetter{set: map[string]string{}}}
+	if err := NewAgentSession(f, "gt-witness", townRoot, "exec claude", env, townRoot, "", "witness"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(f.command, "TEAM=core") {
+		t.Errorf("command %q does not export session_env TEAM", f.command)
+	}
+	if strings.Contains(f.command, "s3cret") || strings.Contains(f.command, "API_TOKEN") {
+		t.Errorf("command %q leaks the secret", f.command)
+	}
+	if f.initEnv["API_TOKEN"] != "s3cret" {
+		t.Errorf("creation env API_TOKEN = %q, want the resolved secret", f.initEnv["API_TOKEN"])
+	}
+	if _, ok := f.initEnv["TEAM"]; ok {
+		t.Errorf("creation env = %v, want only secrets", f.initEnv)
+	}
+	for _, k := range []string{"TEAM", "API_TOKEN", "GT_ROLE"} {
+		if f.set[k] == "" {
+			t.Errorf("session table missing %s: %v", k, f.set)
+		}
+	}
+}
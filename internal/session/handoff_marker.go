@@ -0,0 +1,94 @@
+package session
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// HandoffMarkerVersion is the current handoff marker schema version.
+const HandoffMarkerVersion = 1
+
+// HandoffMarker is the document a session leaves in its successor's runtime
+// dir (.runtime/handoff_to_successor) when it hands off. gt prime reads it to
+// detect the post-handoff state and to brief the new session.
+//
+// Markers were once a bare "session_id\nreason" text file; ReadHandoffMarker
+// still accepts that form.
+type HandoffMarker struct {
+	// Version is the schema version (HandoffMarkerVersion when written).
+	Version int `json:"version"`
+
+	// PrevSession is the session that handed off.
+	PrevSession string `json:"prev_session"`
+
+	// Timestamp is when the handoff happened.
+	Timestamp time.Time `json:"timestamp,omitempty"`
+
+	// Reason is why the handoff happened (e.g. "compaction", "restore").
+	Reason string `json:"reason,omitempty"`
+
+	// NextSteps are hints for the successor, shown by gt prime.
+	NextSteps []string `json:"next_steps,omitempty"`
+
+	// Beads references beads relevant to the handoff (the handoff mail bead,
+	// hooked work).
+	Beads []string `json:"beads,omitempty"`
+}
+
+// HandoffMarkerPath returns the handoff marker path for an agent's workDir.
+func HandoffMarkerPath(workDir string) string {
+	return filepath.Join(workDir, constants.DirRuntime, constants.FileHandoffMarker)
+}
+
+// WriteHandoffMarker writes m as workDir's handoff marker, filling in the
+// version and timestamp.
+func WriteHandoffMarker(workDir string, m *HandoffMarker) error {
+	m.Version = HandoffMarkerVersion
+	if m.Timestamp.IsZero() {
+		m.Timestamp = time.Now().UTC()
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding handoff marker: %w", err)
+	}
+	path := HandoffMarkerPath(workDir)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating runtime dir: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
+
+// ReadHandoffMarker reads workDir's handoff marker. It returns an error
+// satisfying os.IsNotExist if there is none.
+func ReadHandoffMarker(workDir string) (*HandoffMarker, error) {
+	data, err := os.ReadFile(HandoffMarkerPath(workDir))
+	if err != nil {
+		return nil, err
+	}
+	return ParseHandoffMarker(data), nil
+}
+
+// ParseHandoffMarker decodes a handoff marker. JSON markers of any version
+// are read for the fields this version knows; anything else is treated as
+// the legacy "session_id\nreason" form, so a marker is never rejected.
+func ParseHandoffMarker(data []byte) *HandoffMarker {
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("{")) {
+		var m HandoffMarker
+		if err := json.Unmarshal(trimmed, &m); err == nil {
+			return &m
+		}
+	}
+	session, reason, _ := strings.Cut(string(trimmed), "\n")
+	return &HandoffMarker{
+		PrevSession: strings.TrimSpace(session),
+		Reason:      strings.TrimSpace(reason),
+	}
+}
@@ -0,0 +1,60 @@
+package session
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestHandoffMarkerRoundTrip(t *testing.T) {
+	workDir := t.TempDir()
+	want := &HandoffMarker{
+		PrevSession: "gt-crew-max",
+		Reason:      "compaction",
+		NextSteps:   []string{"Finish the parser tests"},
+		Beads:       []string{"gt-abc", "hq-mail1"},
+	}
+	if err := WriteHandoffMarker(workDir, want); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadHandoffMarker(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Version != HandoffMarkerVersion || got.Timestamp.IsZero() {
+		t.Errorf("version/timestamp not filled in: %+v", got)
+	}
+	if got.PrevSession != want.PrevSession || got.Reason != want.Reason ||
+		!reflect.DeepEqual(got.NextSteps, want.NextSteps) || !reflect.DeepEqual(got.Beads, want.Beads) {
+		t.Errorf("ReadHandoffMarker() = %+v, want %+v", got, want)
+	}
+}
+
+func TestReadHandoffMarkerMissing(t *testing.T) {
+	if _, err := ReadHandoffMarker(t.TempDir()); !os.IsNotExist(err) {
+		t.Errorf("ReadHandoffMarker() error = %v, want not-exist", err)
+	}
+}
+
+func TestParseHandoffMarker(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		session string
+		reason  string
+		steps   int
+	}{
+		{"legacy session only", "gt-Toast", "gt-Toast", "", 0},
+		{"legacy with reason", "gt-Toast\ncompaction\n", "gt-Toast", "compaction", 0},
+		{"json", `{"version":1,"prev_session":"gt-Toast","reason":"cycle","next_steps":["a","b"]}`, "gt-Toast", "cycle", 2},
+		{"newer json", `{"version":9,"prev_session":"gt-Toast","future_field":true}`, "gt-Toast", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := ParseHandoffMarker([]byte(tt.data))
+			if m.PrevSession != tt.session || m.Reason != tt.reason || len(m.NextSteps) != tt.steps {
+				t.Errorf("ParseHandoffMarker(%q) = %+v", tt.data, m)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package session
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 )
 
@@ -51,6 +52,11 @@ func ParseAddress(address string) (*AgentIdentity, error) {
 		return nil, fmt.Errorf("invalid address %q", address)
 	}
 
+	// Town-scoped custom role: <role>/<name>
+	if cfg, ok := customRole(Role(parts[0])); ok && cfg.IsTownScoped() && len(parts) == 2 {
+		return &AgentIdentity{Role: Role(parts[0]), Name: parts[1]}, nil
+	}
+
 	rig := parts[0]
 	prefix := PrefixFor(rig)
 	switch len(parts) {
@@ -75,6 +81,9 @@ func ParseAddress(address string) (*AgentIdentity, error) {
 		case "polecats":
 			return &AgentIdentity{Role: RolePolecat, Rig: rig, Name: name, Prefix: prefix}, nil
 		default:
+			if cfg, ok := customRole(Role(role)); ok && !cfg.IsTownScoped() {
+				return &AgentIdentity{Role: Role(role), Rig: rig, Name: name, Prefix: prefix}, nil
+			}
 			return nil, fmt.Errorf("invalid address %q", address)
 		}
 	default:
@@ -92,6 +101,8 @@ func ParseAddress(address string) (*AgentIdentity, error) {
 //   - <prefix>-witness → Role: witness (e.g., gt-witness for gastown)
 //   - <prefix>-refinery → Role: refinery (e.g., gt-refinery for gastown)
 //   - <prefix>-crew-<name> → Role: crew (e.g., gt-crew-max for gastown)
+//   - <prefix>-<role>-<name> → custom rig-scoped role from town.json (e.g., gt-reviewer-ann)
+//   - hq-<role>-<name> → custom town-scoped role from town.json
 //   - <prefix>-<name> → Role: polecat (e.g., gt-furiosa for gastown)
 //
 // The prefix is the rig's beads prefix (e.g., "gt" for gastown, "dolt" for beads).
@@ -133,6 +144,10 @@ func ParseSessionNameWithRegistry(session string, registry *PrefixRegistry) (*Ag
 				}
 				return &AgentIdentity{Role: RoleDog, Name: name}, nil
 			}
+			// Town-scoped custom roles: hq-<role>-<name>
+			if role, name, ok := parseCustomRoleRest(suffix, true); ok {
+				return &AgentIdentity{Role: role, Name: name}, nil
+			}
 			// Fall through to rig-level parsing — "hq" may be a rig prefix.
 		}
 	}
@@ -165,6 +180,11 @@ func ParseSessionNameWithRegistry(session string, registry *PrefixRegistry) (*Ag
 		return &AgentIdentity{Role: RoleCrew, Rig: rig, Name: name, Prefix: prefix}, nil
 	}
 
+	// Rig-scoped custom roles: <prefix>-<role>-<name>
+	if role, name, ok := parseCustomRoleRest(rest, false); ok {
+		return &AgentIdentity{Role: role, Rig: rig, Name: name, Prefix: prefix}, nil
+	}
+
 	// Default: polecat
 	// rest is the polecat name (may contain dashes)
 	if rest == "" {
@@ -196,6 +216,9 @@ func (a *AgentIdentity) SessionName() string {
 	case RoleDog:
 		return DogSessionName(a.Name)
 	default:
+		if cfg, ok := customRole(a.Role); ok {
+			return a.customSessionName(cfg)
+		}
 		return ""
 	}
 }
@@ -239,6 +262,9 @@ func (a *AgentIdentity) BeaconAddress() string {
 	case RoleDog:
 		return BeaconRecipient("dog", a.Name, "")
 	default:
+		if _, ok := customRole(a.Role); ok {
+			return BeaconRecipient(string(a.Role), a.Name, a.Rig)
+		}
 		return ""
 	}
 }
@@ -270,6 +296,9 @@ func (a *AgentIdentity) Address() string {
 	case RoleDog:
 		return fmt.Sprintf("deacon/dogs/%s", a.Name)
 	default:
+		if cfg, ok := customRole(a.Role); ok {
+			return a.customAddress(cfg)
+		}
 		return ""
 	}
 }
@@ -283,3 +312,39 @@ func (a *AgentIdentity) GTRole() string {
 	}
 	return a.Address()
 }
+
+// RoleDir returns the canonical home directory for this identity under townRoot.
+// Examples:
+//   - mayor → "<town>/mayor"
+//   - boot → "<town>/deacon/dogs/boot"
+//   - witness → "<town>/gastown/witness"
+//   - crew → "<town>/gastown/crew/max"
+//   - polecat → "<town>/gastown/polecats/Toast"
+//
+// Returns "" for roles without a home directory (overseer).
+func (a *AgentIdentity) RoleDir(townRoot string) string {
+	switch a.Role {
+	case RoleMayor:
+		return filepath.Join(townRoot, "mayor")
+	case RoleDeacon:
+		if a.Name == "boot" {
+			return filepath.Join(townRoot, "deacon", "dogs", "boot")
+		}
+		return filepath.Join(townRoot, "deacon")
+	case RoleWitness:
+		return filepath.Join(townRoot, a.Rig, "witness")
+	case RoleRefinery:
+		return filepath.Join(townRoot, a.Rig, "refinery")
+	case RoleCrew:
+		return filepath.Join(townRoot, a.Rig, "crew", a.Name)
+	case RolePolecat:
+		return filepath.Join(townRoot, a.Rig, "polecats", a.Name)
+	case RoleDog:
+		return filepath.Join(townRoot, "deacon", "dogs", a.Name)
+	default:
+		if cfg, ok := customRole(a.Role); ok {
+			return a.customRoleDir(townRoot, cfg)
+		}
+		return ""
+	}
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"path/filepath"
 	"testing"
 )
 
@@ -483,3 +484,34 @@ func TestPrefixRegistry(t *testing.T) {
 		t.Errorf("RigForPrefix(zz) = %q, want %q", got, "zz")
 	}
 }
+
+func TestAgentIdentity_RoleDir(t *testing.T) {
+	town := "/town"
+	tests := []struct {
+		name     string
+		identity AgentIdentity
+		want     string
+	}{
+		{"mayor", AgentIdentity{Role: RoleMayor}, "/town/mayor"},
+		{"deacon", AgentIdentity{Role: RoleDeacon}, "/town/deacon"},
+		{"boot", AgentIdentity{Role: RoleDeacon, Name: "boot"}, "/town/deacon/dogs/boot"},
+		{"witness", AgentIdentity{Role: RoleWitness, Rig: "gastown"}, "/town/gastown/witness"},
+		{"refinery", AgentIdentity{Role: RoleRefinery, Rig: "gastown"}, "/town/gastown/refinery"},
+		{"crew", AgentIdentity{Role: RoleCrew, Rig: "gastown", Name: "max"}, "/town/gastown/crew/max"},
+		{"polecat", AgentIdentity{Role: RolePolecat, Rig: "gastown", Name: "Toast"}, "/town/gastown/polecats/Toast"},
+		{"dog", AgentIdentity{Role: RoleDog, Name: "alpha"}, "/town/deacon/dogs/alpha"},
+		{"overseer", AgentIdentity{Role: RoleOverseer}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := tt.want
+			if want != "" {
+				want = filepath.FromSlash(want)
+			}
+			if got := tt.identity.RoleDir(town); got != want {
+				t.Errorf("AgentIdentity.RoleDir() = %q, want %q", got, want)
+			}
+		})
+	}
+}
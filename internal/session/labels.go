@@ -0,0 +1,209 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/lock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Tmux user options mirroring a session's Gas Town metadata, so status lines
+// and tmux formats can show them (e.g., #{@gt_labels}).
+const (
+	LabelsOption      = "@gt_labels"
+	DisplayNameOption = "@gt_name"
+)
+
+// SessionMeta is operator-assigned metadata for a session: a display name and
+// free-form labels. It is keyed by tmux session name, which is stable across
+// restarts, so labels survive an agent being stopped and started again.
+type SessionMeta struct {
+	DisplayName string   `json:"display_name,omitempty"`
+	Labels      []string `json:"labels,omitempty"`
+}
+
+// HasLabels reports whether m carries every label in want.
+func (m *SessionMeta) HasLabels(want []string) bool {
+	for _, w := range want {
+		found := false
+		if m != nil {
+			for _, l := range m.Labels {
+				if l == w {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// sessionOptionOps is the subset of tmux operations needed to mirror metadata
+// into session options. Satisfied by *tmux.Tmux.
+type sessionOptionOps interface {
+	SetSessionOption(session, name, value string) error
+	UnsetSessionOption(session, name string) error
+}
+
+// SessionMetaPath returns the Gas Town state file holding session metadata.
+func SessionMetaPath(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "session_meta.json")
+}
+
+// LoadSessionMeta reads all session metadata. A missing file yields an empty map.
+func LoadSessionMeta(townRoot string) (map[string]*SessionMeta, error) {
+	meta := make(map[string]*SessionMeta)
+	data, err := os.ReadFile(SessionMetaPath(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return meta, nil
+		}
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, fmt.Errorf("parsing session metadata: %w", err)
+	}
+	return meta, nil
+}
+
+// updateSessionMeta applies fn to a session's metadata under an exclusive
+// file lock held from load to save, so concurrent label and rename commands
+// don't drop each other's changes. The file is replaced atomically (temp
+// file plus rename). Returns a copy of the updated metadata.
+func updateSessionMeta(townRoot, sessionID string, fn func(m *SessionMeta)) (SessionMeta, error) {
+	path := SessionMetaPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return SessionMeta{}, err
+	}
+	unlock, err := lock.FlockAcquire(path + ".flock")
+	if err != nil {
+		return SessionMeta{}, fmt.Errorf("locking session metadata: %w", err)
+	}
+	defer unlock()
+
+	meta, err := LoadSessionMeta(townRoot)
+	if err != nil {
+		return SessionMeta{}, err
+	}
+	m := meta[sessionID]
+	if m == nil {
+		m = &SessionMeta{}
+		meta[sessionID] = m
+	}
+	fn(m)
+	updated := SessionMeta{DisplayName: m.DisplayName, Labels: append([]string(nil), m.Labels...)}
+
+	for id, m := range meta {
+		if m.DisplayName == "" && len(m.Labels) == 0 {
+			delete(meta, id)
+		}
+	}
+	if err := util.AtomicWriteJSON(path, meta); err != nil {
+		return SessionMeta{}, fmt.Errorf("saving session metadata: %w", err)
+	}
+	return updated, nil
+}
+
+// ValidateLabel checks that a label is a short token usable on the command
+// line and in tmux options: letters, digits, '-', '_', and '.'.
+func ValidateLabel(label string) error {
+	if label == "" {
+		return fmt.Errorf("label cannot be empty")
+	}
+	for _, r := range label {
+		if !(r >= 'a' && r <= 'z') && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') && r != '-' && r != '_' && r != '.' {
+			return fmt.Errorf("invalid label %q: use letters, digits, '-', '_', or '.'", label)
+		}
+	}
+	return nil
+}
+
+// UpdateSessionLabels adds and removes labels on a session and returns the
+// resulting label set. The labels are saved to Gas Town state and mirrored to
+// the session's @gt_labels option when the session is running.
+func UpdateSessionLabels(t sessionOptionOps, townRoot, sessionID string, add, remove []string) ([]string, error) {
+	for _, l := range append(append([]string{}, add...), remove...) {
+		if err := ValidateLabel(l); err != nil {
+			return nil, err
+		}
+	}
+	updated, err := updateSessionMeta(townRoot, sessionID, func(m *SessionMeta) {
+		set := make(map[string]bool, len(m.Labels)+len(add))
+		for _, l := range m.Labels {
+			set[l] = true
+		}
+		for _, l := range add {
+			set[l] = true
+		}
+		for _, l := range remove {
+			delete(set, l)
+		}
+		m.Labels = m.Labels[:0]
+		for l := range set {
+			m.Labels = append(m.Labels, l)
+		}
+		sort.Strings(m.Labels)
+	})
+	if err != nil {
+		return nil, err
+	}
+	_ = mirrorOption(t, sessionID, LabelsOption, strings.Join(updated.Labels, ","))
+	return updated.Labels, nil
+}
+
+// RenameSession sets a session's display name. The tmux session name is left
+// unchanged: Gas Town derives agent identity from it. An empty name clears
+// the display name.
+func RenameSession(t sessionOptionOps, townRoot, sessionID, name string) error {
+	updated, err := updateSessionMeta(townRoot, sessionID, func(m *SessionMeta) {
+		m.DisplayName = strings.TrimSpace(name)
+	})
+	if err != nil {
+		return err
+	}
+	_ = mirrorOption(t, sessionID, DisplayNameOption, updated.DisplayName)
+	return nil
+}
+
+// ApplySessionMeta copies a session's saved metadata into its tmux options.
+// Called after a session is (re)created so labels outlive restarts.
+//
+// Non-fatal by design: callers should ignore or debug-log the error.
+func ApplySessionMeta(t sessionOptionOps, townRoot, sessionID string) error {
+	if townRoot == "" {
+		return nil
+	}
+	meta, err := LoadSessionMeta(townRoot)
+	if err != nil {
+		return err
+	}
+	m := meta[sessionID]
+	if m == nil {
+		return nil
+	}
+	if m.DisplayName != "" {
+		if err := t.SetSessionOption(sessionID, DisplayNameOption, m.DisplayName); err != nil {
+			return err
+		}
+	}
+	if len(m.Labels) > 0 {
+		return t.SetSessionOption(sessionID, LabelsOption, strings.Join(m.Labels, ","))
+	}
+	return nil
+}
+
+func mirrorOption(t sessionOptionOps, sessionID, name, value string) error {
+	if value == "" {
+		return t.UnsetSessionOption(sessionID, name)
+	}
+	return t.SetSessionOption(sessionID, name, value)
+}
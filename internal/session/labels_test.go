@@ -0,0 +1,125 @@
+package session
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+type fakeOptionOps struct {
+	opts map[string]string
+}
+
+func (f *fakeOptionOps) SetSessionOption(_, name, value string) error {
+	f.opts[name] = value
+	return nil
+}
+
+func (f *fakeOptionOps) UnsetSessionOption(_, name string) error {
+	delete(f.opts, name)
+	return nil
+}
+
+type nopOptionOps struct{}
+
+func (nopOptionOps) SetSessionOption(_, _, _ string) error { return nil }
+func (nopOptionOps) UnsetSessionOption(_, _ string) error  { return nil }
+
+func TestUpdateSessionLabels_ConcurrentUpdatesKeepAllLabels(t *testing.T) {
+	town := t.TempDir()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if _, err := UpdateSessionLabels(nopOptionOps{}, town, "gt-Toast", []string{fmt.Sprintf("l%d", i)}, nil); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	meta, err := LoadSessionMeta(town)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(meta["gt-Toast"].Labels); got != 8 {
+		t.Errorf("labels = %v, want all 8 concurrent additions", meta["gt-Toast"].Labels)
+	}
+}
+
+func TestUpdateSessionLabels(t *testing.T) {
+	town := t.TempDir()
+	ops := &fakeOptionOps{opts: map[string]string{}}
+
+	labels, err := UpdateSessionLabels(ops, town, "gt-Toast", []string{"urgent", "demo", "urgent"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(labels, []string{"demo", "urgent"}) {
+		t.Errorf("labels = %v", labels)
+	}
+	if ops.opts[LabelsOption] != "demo,urgent" {
+		t.Errorf("%s = %q", LabelsOption, ops.opts[LabelsOption])
+	}
+
+	labels, err = UpdateSessionLabels(ops, town, "gt-Toast", nil, []string{"demo", "urgent"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 0 {
+		t.Errorf("labels = %v, want none", labels)
+	}
+	if _, ok := ops.opts[LabelsOption]; ok {
+		t.Error("labels option should be unset when no labels remain")
+	}
+	meta, err := LoadSessionMeta(town)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := meta["gt-Toast"]; ok {
+		t.Error("empty metadata should be pruned from state")
+	}
+
+	if _, err := UpdateSessionLabels(ops, town, "gt-Toast", []string{"has space"}, nil); err == nil {
+		t.Error("expected invalid label error")
+	}
+}
+
+func TestRenameAndApplySessionMeta(t *testing.T) {
+	town := t.TempDir()
+	ops := &fakeOptionOps{opts: map[string]string{}}
+
+	if err := RenameSession(ops, town, "gt-crew-max", "release prep"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := UpdateSessionLabels(ops, town, "gt-crew-max", []string{"demo"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A restarted session has fresh tmux options; metadata is restored from state.
+	fresh := &fakeOptionOps{opts: map[string]string{}}
+	if err := ApplySessionMeta(fresh, town, "gt-crew-max"); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{DisplayNameOption: "release prep", LabelsOption: "demo"}
+	if !reflect.DeepEqual(fresh.opts, want) {
+		t.Errorf("opts = %v, want %v", fresh.opts, want)
+	}
+}
+
+func TestSessionMetaHasLabels(t *testing.T) {
+	m := &SessionMeta{Labels: []string{"demo", "urgent"}}
+	if !m.HasLabels(nil) || !m.HasLabels([]string{"urgent"}) || !m.HasLabels([]string{"demo", "urgent"}) {
+		t.Error("expected labels to match")
+	}
+	if m.HasLabels([]string{"urgent", "other"}) {
+		t.Error("all labels must match")
+	}
+	var none *SessionMeta
+	if !none.HasLabels(nil) || none.HasLabels([]string{"demo"}) {
+		t.Error("nil metadata matches only an empty filter")
+	}
+}
@@ -0,0 +1,104 @@
+package session
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// layoutOps is the subset of tmux operations needed to build a session layout.
+// Satisfied by *tmux.Tmux; narrowed so managers with mock tmux can use it.
+type layoutOps interface {
+	GetPaneID(session string) (string, error)
+	NewWindow(session, name, workDir, command string, env map[string]string) (string, error)
+	SplitWindow(target, workDir, command string, horizontal bool, percent int, env map[string]string) (string, error)
+	SetPaneRemainOnExit(pane string, on bool) error
+	GetAllEnvironment(session string) (map[string]string, error)
+}
+
+// LoadSessionLayout returns the configured layout for role from town settings,
+// or nil if the town has none.
+func LoadSessionLayout(townRoot, role string) *config.SessionLayout {
+	if townRoot == "" || role == "" {
+		return nil
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil || settings.SessionLayouts == nil {
+		return nil
+	}
+	return settings.SessionLayouts[role]
+}
+
+// ApplySessionLayout creates the role's configured panes and windows around the
+// agent pane of a freshly spawned session. The agent pane keeps focus so that
+// nudges, captures, and liveness checks that target the session still reach
+// the agent. Auxiliary panes close normally on exit rather than triggering
+// the agent's respawn hook.
+//
+// Each pane and window gets the agent's Gas Town identity (GT_* and the other
+// identity vars) passed to tmux explicitly, so tools run in them act as the
+// agent even when the session env was set after the pane's shell started.
+//
+// Non-fatal by design: callers should ignore or debug-log the error.
+func ApplySessionLayout(t layoutOps, townRoot, role, sessionID, workDir string) error {
+	layout := LoadSessionLayout(townRoot, role)
+	if layout == nil {
+		return nil
+	}
+
+	logPath, _ := SessionLogPath(townRoot, sessionID)
+	expand := strings.NewReplacer(
+		"{workdir}", config.ShellQuote(workDir),
+		"{session}", config.ShellQuote(sessionID),
+		"{log}", config.ShellQuote(logPath),
+	)
+
+	agentPane, err := t.GetPaneID(sessionID)
+	if err != nil {
+		return fmt.Errorf("resolving agent pane: %w", err)
+	}
+	env := layoutPaneEnv(t, sessionID)
+
+	var errs []string
+	for i, p := range layout.Panes {
+		horizontal := strings.EqualFold(p.Split, "horizontal") || p.Split == "h"
+		pane, err := t.SplitWindow(agentPane, workDir, expand.Replace(p.Command), horizontal, p.Size, env)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("pane %d: %v", i, err))
+			continue
+		}
+		_ = t.SetPaneRemainOnExit(pane, false)
+	}
+	for i, w := range layout.Windows {
+		pane, err := t.NewWindow(sessionID, w.Name, workDir, expand.Replace(w.Command), env)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("window %d (%s): %v", i, w.Name, err))
+			continue
+		}
+		_ = t.SetPaneRemainOnExit(pane, false)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("applying %s layout: %s", role, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// layoutPaneEnv returns the session's GT_* and identity vars for layout
+// panes. Secrets and other session vars are left to tmux's inheritance
+// rather than put on a command line.
+func layoutPaneEnv(t layoutOps, sessionID string) map[string]string {
+	all, err := t.GetAllEnvironment(sessionID)
+	if err != nil {
+		return nil
+	}
+	env := make(map[string]string)
+	for k, v := range all {
+		if strings.HasPrefix(k, "GT_") || slices.Contains(config.IdentityEnvVars, k) {
+			env[k] = v
+		}
+	}
+	return env
+}
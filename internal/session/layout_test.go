@@ -0,0 +1,122 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+type fakeLayoutOps struct {
+	splits      []string
+	windows     []string
+	remainOff   []string
+	horizontals []bool
+	sessionEnv  map[string]string
+	paneEnvs    []map[string]string
+}
+
+func (f *fakeLayoutOps) GetPaneID(_ string) (string, error) { return "%0", nil }
+
+func (f *fakeLayoutOps) NewWindow(_, name, _, command string, env map[string]string) (string, error) {
+	f.windows = append(f.windows, name+":"+command)
+	f.paneEnvs = append(f.paneEnvs, env)
+	return "%w", nil
+}
+
+func (f *fakeLayoutOps) SplitWindow(target, _, command string, horizontal bool, _ int, env map[string]string) (string, error) {
+	f.splits = append(f.splits, target+":"+command)
+	f.horizontals = append(f.horizontals, horizontal)
+	f.paneEnvs = append(f.paneEnvs, env)
+	return "%p", nil
+}
+
+func (f *fakeLayoutOps) GetAllEnvironment(_ string) (map[string]string, error) {
+	return f.sessionEnv, nil
+}
+
+func (f *fakeLayoutOps) SetPaneRemainOnExit(pane string, on bool) error {
+	if !on {
+		f.remainOff = append(f.remainOff, pane)
+	}
+	return nil
+}
+
+func writeTownLayouts(t *testing.T, layouts map[string]*config.SessionLayout) string {
+	t.Helper()
+	town := t.TempDir()
+	settings := config.NewTownSettings()
+	settings.SessionLayouts = layouts
+	data, err := json.Marshal(settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := config.TownSettingsPath(town)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	return town
+}
+
+func TestApplySessionLayout(t *testing.T) {
+	old := DefaultRegistry()
+	SetDefaultRegistry(testRegistry())
+	defer func() { SetDefaultRegistry(old) }()
+
+	town := writeTownLayouts(t, map[string]*config.SessionLayout{
+		"polecat": {
+			Panes:   []config.SessionPaneConfig{{Command: "tail -F {log}", Split: "horizontal", Size: 30}},
+			Windows: []config.SessionWindowConfig{{Name: "shell"}, {Name: "tests", Command: "cd {workdir} && go test ./..."}},
+		},
+	})
+
+	ops := &fakeLayoutOps{sessionEnv: map[string]string{
+		"GT_ROLE":           "gastown/polecats/Toast",
+		"BD_ACTOR":          "gastown/polecats/Toast",
+		"ANTHROPIC_API_KEY": "secret",
+	}}
+	if err := ApplySessionLayout(ops, town, "polecat", "gt-Toast", "/work/tree"); err != nil {
+		t.Fatalf("ApplySessionLayout: %v", err)
+	}
+
+	if len(ops.splits) != 1 || !strings.HasPrefix(ops.splits[0], "%0:tail -F ") ||
+		!strings.Contains(ops.splits[0], filepath.Join("polecats", "Toast", "logs", "gt-Toast.log")) {
+		t.Errorf("splits = %v, want agent pane split tailing the session log", ops.splits)
+	}
+	if !ops.horizontals[0] {
+		t.Error("split should be horizontal")
+	}
+	if len(ops.windows) != 2 || ops.windows[0] != "shell:" || ops.windows[1] != "tests:cd /work/tree && go test ./..." {
+		t.Errorf("windows = %v", ops.windows)
+	}
+	if len(ops.remainOff) != 3 {
+		t.Errorf("remain-on-exit disabled on %d aux panes, want 3", len(ops.remainOff))
+	}
+	for i, env := range ops.paneEnvs {
+		if env["GT_ROLE"] != "gastown/polecats/Toast" || env["BD_ACTOR"] != "gastown/polecats/Toast" {
+			t.Errorf("pane %d env = %v, want agent identity", i, env)
+		}
+		if _, ok := env["ANTHROPIC_API_KEY"]; ok {
+			t.Errorf("pane %d env leaks non-identity var: %v", i, env)
+		}
+	}
+}
+
+func TestApplySessionLayout_NoLayoutForRole(t *testing.T) {
+	town := writeTownLayouts(t, map[string]*config.SessionLayout{
+		"crew": {Windows: []config.SessionWindowConfig{{Name: "shell"}}},
+	})
+	ops := &fakeLayoutOps{}
+	if err := ApplySessionLayout(ops, town, "polecat", "gt-Toast", "/work"); err != nil {
+		t.Fatalf("ApplySessionLayout: %v", err)
+	}
+	if len(ops.windows)+len(ops.splits) != 0 {
+		t.Errorf("layout applied for role without config: windows=%v splits=%v", ops.windows, ops.splits)
+	}
+}
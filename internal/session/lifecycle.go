@@ -188,17 +188,34 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 		})
 	}
 
-	// Prepend GT_RUN (GASTA run ID) and any extra env vars into the command so
-	// that they are inherited by the initial shell before tmux SetEnvironment runs.
-	extraWithRun := make(map[string]string, len(cfg.ExtraEnv)+1)
+	// Resolve the session env once, up front: secrets in it are handed to
+	// tmux at session creation rather than put on the command line.
+	envVars := AgentSessionEnv(config.AgentEnvConfig{
+		Role:             cfg.Role,
+		Rig:              cfg.RigName,
+		AgentName:        cfg.AgentName,
+		TownRoot:         cfg.TownRoot,
+		RuntimeConfigDir: cfg.RuntimeConfigDir,
+		Agent:            cfg.AgentOverride,
+		SessionName:      cfg.SessionID,
+	}, cfg.RigPath)
+	envVars = MergeRuntimeLivenessEnv(envVars, runtimeConfig)
+	_, secretEnv := SplitSecretEnv(envVars, cfg.TownRoot, cfg.RigPath, cfg.Role)
+
+	// Prepend custom session_env vars, GT_RUN (GASTA run ID), and any extra env
+	// vars into the command so that they are inherited by the initial shell
+	// before tmux SetEnvironment runs. Secrets are left out: ps shows the
+	// command line.
+	extraWithRun := config.ConfiguredSessionVars(cfg.TownRoot, cfg.RigPath, cfg.Role)
 	for k, v := range cfg.ExtraEnv {
 		extraWithRun[k] = v
+		delete(secretEnv, k)
 	}
 	extraWithRun["GT_RUN"] = runID
 	command = config.PrependEnv(command, extraWithRun)
 
 	// 4. Create tmux session with command.
-	if err := t.NewSessionWithCommand(cfg.SessionID, cfg.WorkDir, command); err != nil {
+	if err := t.NewSessionWithCommandAndEnv(cfg.SessionID, cfg.WorkDir, command, secretEnv); err != nil {
 		return nil, fmt.Errorf("creating session: %w", err)
 	}
 
@@ -207,25 +224,23 @@ func StartSession(t *tmux.Tmux, cfg SessionConfig) (_ *StartResult, retErr error
 		_ = t.SetRemainOnExit(cfg.SessionID, true)
 	}
 
+	// Capture pane output under <role dir>/logs so scrollback survives crashes (non-fatal).
+	_ = EnableSessionLogCapture(t, cfg.TownRoot, cfg.SessionID)
+
+	// Create the role's configured panes/windows around the agent (non-fatal).
+	_ = ApplySessionLayout(t, cfg.TownRoot, cfg.Role, cfg.SessionID, cfg.WorkDir)
+
+	// Restore operator-assigned labels and display name (non-fatal).
+	_ = ApplySessionMeta(t, cfg.TownRoot, cfg.SessionID)
+
+	// Run town session_hooks for session start (non-fatal).
+	RunSessionHooks(cfg.TownRoot, HookStart, cfg.SessionID, cfg.WorkDir)
+
 	// 6. Set environment variables.
-	envVars := config.AgentEnv(config.AgentEnvConfig{
-		Role:             cfg.Role,
-		Rig:              cfg.RigName,
-		AgentName:        cfg.AgentName,
-		TownRoot:         cfg.TownRoot,
-		RuntimeConfigDir: cfg.RuntimeConfigDir,
-		Agent:            cfg.AgentOverride,
-		SessionName:      cfg.SessionID,
-	})
-	envVars = MergeRuntimeLivenessEnv(envVars, runtimeConfig)
-	for _, k := range mapKeysSorted(envVars) {
-		_ = t.SetEnvironment(cfg.SessionID, k, envVars[k])
-	}
+	_ = SetSessionEnv(t, cfg.SessionID, envVars)
 	// Set GT_RUN in the session environment so respawned processes also inherit it.
 	_ = t.SetEnvironment(cfg.SessionID, "GT_RUN", runID)
-	for _, k := range mapKeysSorted(cfg.ExtraEnv) {
-		_ = t.SetEnvironment(cfg.SessionID, k, cfg.ExtraEnv[k])
-	}
+	_ = SetSessionEnv(t, cfg.SessionID, cfg.ExtraEnv)
 
 	// 7. Apply theme.
 	if cfg.Theme != nil {
@@ -337,11 +352,12 @@ func RecordAgentInstantiateFromDir(ctx context.Context, runID, resolvedAgent, ro
 	})
 }
 
-// StopSession stops a tmux session with optional graceful shutdown.
+// StopSession stops a tmux session with optional graceful shutdown, then
+// runs the town's stop session_hooks.
 //
 // If graceful is true, sends Ctrl-C first and waits for the session to exit
 // before force-killing. This allows the agent to clean up.
-func StopSession(t *tmux.Tmux, sessionID string, graceful bool) error {
+func StopSession(t *tmux.Tmux, townRoot, sessionID string, graceful bool) error {
 	running, err := t.HasSession(sessionID)
 	if err != nil {
 		return fmt.Errorf("checking session: %w", err)
@@ -359,11 +375,7 @@ func StopSession(t *tmux.Tmux, sessionID string, graceful bool) error {
 	// the tmux session, to avoid orphan processes accumulating over time.
 	DeactivateAgentLogging(sessionID)
 
-	if err := t.KillSessionWithProcesses(sessionID); err != nil {
-		return fmt.Errorf("killing session: %w", err)
-	}
-
-	return nil
+	return TerminateSession(t, townRoot, sessionID)
 }
 
 func mapKeysSorted(m map[string]string) []string {
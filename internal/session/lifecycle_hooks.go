@@ -0,0 +1,161 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Session lifecycle hook events.
+const (
+	HookStart   = "start"
+	HookStop    = "stop"
+	HookRestart = "restart"
+)
+
+// defaultLifecycleHookTimeout bounds a hook without an explicit timeout.
+const defaultLifecycleHookTimeout = 30 * time.Second
+
+// RunLifecycleHooks runs the town's session_hooks for event against the
+// session sessionID. workDir is the session's working directory, if known.
+//
+// Every matching hook runs even if an earlier one fails; failures are joined
+// into the returned error. Callers treat the error as a warning: a broken
+// hook must not prevent a session from starting or stopping.
+func RunLifecycleHooks(townRoot, event, sessionID, workDir string) error {
+	if townRoot == "" {
+		return nil
+	}
+	settings, err := config.LoadOrCreateTownSettings(config.TownSettingsPath(townRoot))
+	if err != nil || settings.SessionHooks == nil {
+		return nil
+	}
+	hooks := lifecycleHooksFor(settings.SessionHooks, event)
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	role, rig, agent := lifecycleHookIdentity(sessionID)
+	env := append(os.Environ(),
+		"GT_HOOK_EVENT="+event,
+		"GT_SESSION="+sessionID,
+		"GT_SESSION_ROLE="+role,
+		"GT_SESSION_RIG="+rig,
+		"GT_SESSION_AGENT="+agent,
+		"GT_SESSION_WORKDIR="+workDir,
+		"GT_TOWN_ROOT="+townRoot,
+	)
+
+	var errs []error
+	for _, h := range hooks {
+		if !hookAppliesToRole(h, role) {
+			continue
+		}
+		if err := runLifecycleHook(townRoot, h, env); err != nil {
+			errs = append(errs, fmt.Errorf("%s hook %q for %s: %w", event, h.Command, sessionID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// RunSessionHooks runs the town's session_hooks for event and prints any
+// failure as a warning. StartSession and TerminateSession call it; start
+// paths that build their own session call it once the session exists.
+func RunSessionHooks(townRoot, event, sessionID, workDir string) {
+	if err := RunLifecycleHooks(townRoot, event, sessionID, workDir); err != nil {
+		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+}
+
+// sessionKiller is the tmux operation TerminateSession needs, so managers
+// with a fake tmux can share the stop path.
+type sessionKiller interface {
+	KillSessionWithProcesses(name string) error
+}
+
+// TerminateSession kills sessionID and all its descendant processes, then
+// runs the town's stop session_hooks. Every role's stop path ends here.
+func TerminateSession(t sessionKiller, townRoot, sessionID string) error {
+	if err := t.KillSessionWithProcesses(sessionID); err != nil {
+		return fmt.Errorf("killing session: %w", err)
+	}
+	RunSessionHooks(townRoot, HookStop, sessionID, "")
+	return nil
+}
+
+func lifecycleHooksFor(cfg *config.SessionHooksConfig, event string) []config.SessionHook {
+	switch event {
+	case HookStart:
+		return cfg.Start
+	case HookStop:
+		return cfg.Stop
+	case HookRestart:
+		return cfg.Restart
+	}
+	return nil
+}
+
+// lifecycleHookIdentity returns the role, rig, and agent name for a session,
+// using "boot" as the role for the Boot watchdog as role-keyed settings do.
+func lifecycleHookIdentity(sessionID string) (role, rig, agent string) {
+	identity, err := ParseSessionName(sessionID)
+	if err != nil {
+		return "", "", ""
+	}
+	role = string(identity.Role)
+	if identity.Role == RoleDeacon && identity.Name == "boot" {
+		role = "boot"
+	}
+	return role, identity.Rig, identity.Name
+}
+
+func hookAppliesToRole(h config.SessionHook, role string) bool {
+	if len(h.Roles) == 0 {
+		return true
+	}
+	for _, r := range h.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func runLifecycleHook(townRoot string, h config.SessionHook, env []string) error {
+	if strings.TrimSpace(h.Command) == "" {
+		return fmt.Errorf("empty command")
+	}
+	timeout := defaultLifecycleHookTimeout
+	if h.Timeout != "" {
+		d, err := time.ParseDuration(h.Timeout)
+		if err != nil || d <= 0 {
+			return fmt.Errorf("invalid timeout %q", h.Timeout)
+		}
+		timeout = d
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", h.Command) //nolint:gosec // G204: hook commands are from trusted town settings
+	util.SetProcessGroup(cmd)
+	cmd.Dir = townRoot
+	cmd.Env = env
+	out, err := cmd.CombinedOutput()
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("timed out after %s", timeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%w: %s", err, util.FirstLine(msg))
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package session
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func writeTownHooks(t *testing.T, hooks *config.SessionHooksConfig) string {
+	t.Helper()
+	town := t.TempDir()
+	settings := config.NewTownSettings()
+	settings.SessionHooks = hooks
+	path := config.TownSettingsPath(town)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := config.SaveTownSettings(path, settings); err != nil {
+		t.Fatal(err)
+	}
+	return town
+}
+
+func TestRunLifecycleHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run with sh")
+	}
+	old := DefaultRegistry()
+	SetDefaultRegistry(testRegistry())
+	defer func() { SetDefaultRegistry(old) }()
+
+	out := filepath.Join(t.TempDir(), "hooks.log")
+	town := writeTownHooks(t, &config.SessionHooksConfig{
+		Start: []config.SessionHook{
+			{Command: `echo "$GT_HOOK_EVENT $GT_SESSION $GT_SESSION_ROLE $GT_SESSION_RIG $GT_SESSION_AGENT $GT_SESSION_WORKDIR" >> ` + out},
+			{Command: `echo crew-only >> ` + out, Roles: []string{"crew"}},
+		},
+		Stop: []config.SessionHook{
+			{Command: "echo broken >&2; exit 3"},
+			{Command: `echo stopped >> ` + out},
+			{Command: "sleep 5", Timeout: "100ms"},
+		},
+	})
+
+	if err := RunLifecycleHooks(town, HookStart, "gt-Toast", "/work/tree"); err != nil {
+		t.Fatalf("start hooks: %v", err)
+	}
+	err := RunLifecycleHooks(town, HookStop, "gt-Toast", "")
+	if err == nil || !strings.Contains(err.Error(), "broken") || !strings.Contains(err.Error(), "timed out") {
+		t.Errorf("stop hooks error = %v, want the failing and timed-out hooks reported", err)
+	}
+	if err := RunLifecycleHooks(town, HookRestart, "gt-Toast", ""); err != nil {
+		t.Errorf("no restart hooks configured, got %v", err)
+	}
+
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "start gt-Toast polecat gastown Toast /work/tree\nstopped\n"
+	if string(data) != want {
+		t.Errorf("hook output = %q, want %q", data, want)
+	}
+}
+
+func TestRunLifecycleHooks_NoConfig(t *testing.T) {
+	if err := RunLifecycleHooks(t.TempDir(), HookStart, "gt-Toast", ""); err != nil {
+		t.Errorf("unexpected error without session_hooks: %v", err)
+	}
+	if err := RunLifecycleHooks("", HookStart, "gt-Toast", ""); err != nil {
+		t.Errorf("unexpected error without a town root: %v", err)
+	}
+}
+
+type fakeKiller struct {
+	killed []string
+	err    error
+}
+
+func (f *fakeKiller) KillSessionWithProcesses(name string) error {
+	f.killed = append(f.killed, name)
+	return f.err
+}
+
+func TestTerminateSession_RunsStopHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run with sh")
+	}
+	out := filepath.Join(t.TempDir(), "hooks.log")
+	town := writeTownHooks(t, &config.SessionHooksConfig{
+		Stop: []config.SessionHook{{Command: `echo "$GT_HOOK_EVENT $GT_SESSION" >> ` + out}},
+	})
+
+	k := &fakeKiller{}
+	if err := TerminateSession(k, town, "gt-Toast"); err != nil {
+		t.Fatalf("TerminateSession: %v", err)
+	}
+	if len(k.killed) != 1 || k.killed[0] != "gt-Toast" {
+		t.Errorf("killed = %v, want [gt-Toast]", k.killed)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "stop gt-Toast\n" {
+		t.Errorf("hook output = %q, want stop hook to run once", data)
+	}
+
+	// A failed kill leaves the session up, so stop hooks must not run.
+	if err := os.Remove(out); err != nil {
+		t.Fatal(err)
+	}
+	if err := TerminateSession(&fakeKiller{err: errors.New("boom")}, town, "gt-Toast"); err == nil {
+		t.Error("expected kill error")
+	}
+	if _, err := os.Stat(out); !os.IsNotExist(err) {
+		t.Error("stop hooks ran after a failed kill")
+	}
+}
@@ -43,6 +43,11 @@ func pidFile(townRoot, sessionID string) string {
 // as non-fatal since the primary kill mechanism (KillSessionWithProcesses)
 // doesn't depend on PID files.
 func TrackSessionPID(townRoot, sessionID string, t *tmux.Tmux) error {
+	// Remote pane PIDs are meaningless locally; tracking them would let
+	// orphan cleanup signal unrelated local processes.
+	if t.RemoteHost() != "" {
+		return nil
+	}
 	pidStr, err := t.GetPanePID(sessionID)
 	if err != nil {
 		return fmt.Errorf("getting pane PID: %w", err)
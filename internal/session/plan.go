@@ -0,0 +1,74 @@
+package session
+
+import (
+	"fmt"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// RunIDPlaceholder stands in for the GT_RUN id in a StartPlan, since the
+// real id is generated when the session starts.
+const RunIDPlaceholder = "<generated at start>"
+
+// StartPlan describes the tmux session an agent start would create: where
+// it runs, the command it runs, and the environment it gets. Used by dry
+// runs, so building one must not touch tmux or the filesystem.
+type StartPlan struct {
+	SessionID string
+	WorkDir   string
+	Command   string
+	Env       map[string]string
+}
+
+// PlanSession returns the session StartSession would create for cfg,
+// without creating it or writing runtime settings.
+func PlanSession(cfg SessionConfig) (*StartPlan, error) {
+	if cfg.SessionID == "" {
+		return nil, fmt.Errorf("SessionID is required")
+	}
+	if cfg.WorkDir == "" {
+		return nil, fmt.Errorf("WorkDir is required")
+	}
+	if cfg.Role == "" {
+		return nil, fmt.Errorf("Role is required")
+	}
+	runtimeConfig := config.ResolveRoleAgentConfig(cfg.Role, cfg.TownRoot, cfg.RigPath)
+
+	command := cfg.Command
+	if command == "" {
+		var err error
+		command, err = buildCommand(cfg, buildPrompt(cfg))
+		if err != nil {
+			return nil, fmt.Errorf("building startup command: %w", err)
+		}
+	}
+	if runtimeConfig.Session != nil && runtimeConfig.Session.ConfigDirEnv != "" && cfg.RuntimeConfigDir != "" {
+		command = config.PrependEnv(command, map[string]string{
+			runtimeConfig.Session.ConfigDirEnv: cfg.RuntimeConfigDir,
+		})
+	}
+	// Secrets never go on the command line; see StartSession.
+	extraWithRun := config.ConfiguredSessionVars(cfg.TownRoot, cfg.RigPath, cfg.Role)
+	for k, v := range cfg.ExtraEnv {
+		extraWithRun[k] = v
+	}
+	extraWithRun["GT_RUN"] = RunIDPlaceholder
+	command = config.PrependEnv(command, extraWithRun)
+
+	env := PlannedAgentSessionEnv(config.AgentEnvConfig{
+		Role:             cfg.Role,
+		Rig:              cfg.RigName,
+		AgentName:        cfg.AgentName,
+		TownRoot:         cfg.TownRoot,
+		RuntimeConfigDir: cfg.RuntimeConfigDir,
+		Agent:            cfg.AgentOverride,
+		SessionName:      cfg.SessionID,
+	}, cfg.RigPath)
+	env = MergeRuntimeLivenessEnv(env, runtimeConfig)
+	env["GT_RUN"] = RunIDPlaceholder
+	for k, v := range cfg.ExtraEnv {
+		env[k] = v
+	}
+
+	return &StartPlan{SessionID: cfg.SessionID, WorkDir: cfg.WorkDir, Command: command, Env: env}, nil
+}
@@ -0,0 +1,48 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlanSession_RequiresRole(t *testing.T) {
+	_, err := PlanSession(SessionConfig{SessionID: "gt-test", WorkDir: "/tmp"})
+	if err == nil || err.Error() != "Role is required" {
+		t.Fatalf("err = %v, want Role is required", err)
+	}
+}
+
+func TestPlanSession_DescribesSessionWithoutSideEffects(t *testing.T) {
+	townRoot := t.TempDir()
+	workDir := filepath.Join(townRoot, "mayor")
+
+	plan, err := PlanSession(SessionConfig{
+		SessionID: "hq-mayor",
+		WorkDir:   workDir,
+		Role:      "mayor",
+		TownRoot:  townRoot,
+		AgentName: "Mayor",
+		Beacon:    BeaconConfig{Recipient: "mayor", Sender: "human", Topic: "cold-start"},
+		ExtraEnv:  map[string]string{"GT_EXTRA": "1"},
+	})
+	if err != nil {
+		t.Fatalf("PlanSession: %v", err)
+	}
+	if plan.SessionID != "hq-mayor" || plan.WorkDir != workDir {
+		t.Errorf("plan = %+v, want session hq-mayor in %s", plan, workDir)
+	}
+	if plan.Env["GT_ROLE"] != "mayor" {
+		t.Errorf("GT_ROLE = %q, want mayor", plan.Env["GT_ROLE"])
+	}
+	if plan.Env["GT_RUN"] != RunIDPlaceholder || plan.Env["GT_EXTRA"] != "1" {
+		t.Errorf("env missing GT_RUN placeholder or extra env: %v", plan.Env)
+	}
+	if !strings.Contains(plan.Command, "GT_EXTRA=") {
+		t.Errorf("command should export extra env: %s", plan.Command)
+	}
+	if _, err := os.Stat(workDir); !os.IsNotExist(err) {
+		t.Errorf("PlanSession created %s", workDir)
+	}
+}
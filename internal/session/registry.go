@@ -145,6 +145,11 @@ func InitRegistry(townRoot string) error {
 		errs = append(errs, fmt.Errorf("agent registry: %w", err))
 	}
 
+	// Custom roles from town.json extend session naming and addressing.
+	if err := config.LoadCustomRoles(townRoot); err != nil {
+		errs = append(errs, fmt.Errorf("custom roles: %w", err))
+	}
+
 	return errors.Join(errs...)
 }
 
@@ -0,0 +1,106 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+const (
+	// SessionLogMaxSizeMB is the size at which a session log is rotated.
+	SessionLogMaxSizeMB = 10
+
+	// SessionLogMaxBackups is the number of rotated session logs kept per agent.
+	SessionLogMaxBackups = 5
+
+	// sessionLogDisableEnv disables pane output capture when set to "0" or "false".
+	sessionLogDisableEnv = "GT_SESSION_LOGS"
+)
+
+// panePiper is the subset of tmux operations needed for log capture.
+// Satisfied by *tmux.Tmux; narrowed so managers with mock tmux can use it.
+type panePiper interface {
+	PipePane(target, command string) error
+}
+
+// remoteHoster is implemented by tmux wrappers that may target a remote host.
+type remoteHoster interface {
+	RemoteHost() string
+}
+
+// SessionLogDir returns the directory holding captured output for a session:
+// <role dir>/logs (e.g., ~/gt/gastown/polecats/Toast/logs).
+//
+// Crew role dirs are the crew member's git clone, so crew logs go to
+// <role dir>/.logs instead, which is already in Gas Town's ignore patterns
+// and keeps `git status` clean.
+func SessionLogDir(townRoot, sessionID string) (string, error) {
+	identity, err := ParseSessionName(sessionID)
+	if err != nil {
+		return "", err
+	}
+	roleDir := identity.RoleDir(townRoot)
+	if roleDir == "" {
+		return "", fmt.Errorf("session %s has no role directory", sessionID)
+	}
+	if identity.Role == RoleCrew {
+		return filepath.Join(roleDir, ".logs"), nil
+	}
+	return filepath.Join(roleDir, "logs"), nil
+}
+
+// SessionLogPath returns the active log file for a session.
+// Rotated logs sit next to it with a timestamp suffix.
+func SessionLogPath(townRoot, sessionID string) (string, error) {
+	dir, err := SessionLogDir(townRoot, sessionID)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, sessionID+".log"), nil
+}
+
+// SessionLogsEnabled reports whether pane output capture is enabled.
+// Capture is on by default; set GT_SESSION_LOGS=0 to disable it.
+func SessionLogsEnabled() bool {
+	switch os.Getenv(sessionLogDisableEnv) {
+	case "0", "false", "off":
+		return false
+	}
+	return true
+}
+
+// EnableSessionLogCapture pipes the session's pane output through
+// `gt session log-pipe`, which appends it to a size-rotated log under the
+// agent's role directory. This preserves scrollback after the session dies.
+//
+// Non-fatal by design: callers should ignore or debug-log the error, since
+// log capture must never block agent startup.
+func EnableSessionLogCapture(t panePiper, townRoot, sessionID string) error {
+	if !SessionLogsEnabled() || townRoot == "" {
+		return nil
+	}
+	logPath, err := SessionLogPath(townRoot, sessionID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(logPath), 0755); err != nil {
+		return fmt.Errorf("creating log dir: %w", err)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("resolving executable: %w", err)
+	}
+	// The pipe runs on the session's host; a remote host has its own gt on PATH.
+	if r, ok := t.(remoteHoster); ok && r.RemoteHost() != "" {
+		exe = "gt"
+	}
+	return t.PipePane(sessionID, logPipeCommand(exe, logPath))
+}
+
+// logPipeCommand builds the shell command tmux runs for pipe-pane.
+func logPipeCommand(exe, logPath string) string {
+	return fmt.Sprintf("exec %s session log-pipe --file %s",
+		config.ShellQuote(exe), config.ShellQuote(logPath))
+}
@@ -0,0 +1,99 @@
+package session
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+type fakePiper struct {
+	target  string
+	command string
+	err     error
+}
+
+func (f *fakePiper) PipePane(target, command string) error {
+	f.target = target
+	f.command = command
+	return f.err
+}
+
+func TestSessionLogDir(t *testing.T) {
+	old := DefaultRegistry()
+	SetDefaultRegistry(testRegistry())
+	defer func() { SetDefaultRegistry(old) }()
+
+	town := filepath.FromSlash("/town")
+	tests := []struct {
+		session string
+		want    string
+	}{
+		{"hq-mayor", "/town/mayor/logs"},
+		{"hq-deacon", "/town/deacon/logs"},
+		{"gt-witness", "/town/gastown/witness/logs"},
+		{"gt-Toast", "/town/gastown/polecats/Toast/logs"},
+		{"gt-crew-max", "/town/gastown/crew/max/.logs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.session, func(t *testing.T) {
+			got, err := SessionLogDir(town, tt.session)
+			if err != nil {
+				t.Fatalf("SessionLogDir(%q) error: %v", tt.session, err)
+			}
+			if got != filepath.FromSlash(tt.want) {
+				t.Errorf("SessionLogDir(%q) = %q, want %q", tt.session, got, tt.want)
+			}
+		})
+	}
+
+	if _, err := SessionLogDir(town, "hq-overseer"); err == nil {
+		t.Error("SessionLogDir(hq-overseer) should fail: overseer has no role dir")
+	}
+}
+
+func TestEnableSessionLogCapture(t *testing.T) {
+	old := DefaultRegistry()
+	SetDefaultRegistry(testRegistry())
+	defer func() { SetDefaultRegistry(old) }()
+
+	town := t.TempDir()
+	p := &fakePiper{}
+	if err := EnableSessionLogCapture(p, town, "gt-Toast"); err != nil {
+		t.Fatalf("EnableSessionLogCapture: %v", err)
+	}
+	if p.target != "gt-Toast" {
+		t.Errorf("piped target = %q, want gt-Toast", p.target)
+	}
+	if !strings.Contains(p.command, "session log-pipe --file") {
+		t.Errorf("pipe command = %q, want gt session log-pipe invocation", p.command)
+	}
+	wantPath := filepath.Join(town, "gastown", "polecats", "Toast", "logs", "gt-Toast.log")
+	if !strings.Contains(p.command, wantPath) {
+		t.Errorf("pipe command = %q, want log path %q", p.command, wantPath)
+	}
+
+	p.err = errors.New("boom")
+	if err := EnableSessionLogCapture(p, town, "gt-Toast"); err == nil {
+		t.Error("expected PipePane error to propagate")
+	}
+}
+
+func TestEnableSessionLogCapture_Disabled(t *testing.T) {
+	t.Setenv("GT_SESSION_LOGS", "0")
+	p := &fakePiper{}
+	if err := EnableSessionLogCapture(p, t.TempDir(), "hq-mayor"); err != nil {
+		t.Fatalf("EnableSessionLogCapture: %v", err)
+	}
+	if p.target != "" {
+		t.Errorf("PipePane called with GT_SESSION_LOGS=0 (target %q)", p.target)
+	}
+}
+
+func TestLogPipeCommand_QuotesPaths(t *testing.T) {
+	got := logPipeCommand("/opt/my tools/gt", "/town/a b/logs/x.log")
+	want := "exec '/opt/my tools/gt' session log-pipe --file '/town/a b/logs/x.log'"
+	if got != want {
+		t.Errorf("logPipeCommand() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,66 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// ShutdownManifest records the town's topology at a graceful shutdown, so
+// 'gt start --resume-last' can bring back exactly the same agents. Each
+// session's work dir and environment live in its snapshot (see Snapshot).
+type ShutdownManifest struct {
+	WrittenAt time.Time         `json:"written_at"`
+	Sessions  []ManifestSession `json:"sessions"`
+}
+
+// ManifestSession is one agent session in a ShutdownManifest.
+type ManifestSession struct {
+	Session          string `json:"session"`
+	Address          string `json:"address"`
+	Role             string `json:"role"`
+	Rig              string `json:"rig,omitempty"`
+	HookedIssue      string `json:"hooked_issue,omitempty"`
+	RuntimeSessionID string `json:"runtime_session_id,omitempty"`
+
+	// Snapshotted is false when the session could not be snapshotted; it
+	// is then started fresh rather than resumed.
+	Snapshotted bool `json:"snapshotted"`
+}
+
+// ShutdownManifestPath returns the path of the shutdown manifest:
+// <town>/mayor/.runtime/shutdown-manifest.json.
+func ShutdownManifestPath(townRoot string) string {
+	return filepath.Join(townRoot, "mayor", constants.DirRuntime, "shutdown-manifest.json")
+}
+
+// WriteShutdownManifest writes m, replacing any earlier manifest.
+func WriteShutdownManifest(townRoot string, m *ShutdownManifest) error {
+	path := ShutdownManifestPath(townRoot)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// LoadShutdownManifest reads the manifest written by the last graceful
+// shutdown.
+func LoadShutdownManifest(townRoot string) (*ShutdownManifest, error) {
+	data, err := os.ReadFile(ShutdownManifestPath(townRoot))
+	if err != nil {
+		return nil, err
+	}
+	var m ShutdownManifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("parsing shutdown manifest: %w", err)
+	}
+	return &m, nil
+}
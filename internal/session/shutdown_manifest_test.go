@@ -0,0 +1,37 @@
+package session
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestShutdownManifestRoundTrip(t *testing.T) {
+	townRoot := t.TempDir()
+	if _, err := LoadShutdownManifest(townRoot); !os.IsNotExist(err) {
+		t.Fatalf("LoadShutdownManifest with no manifest: err = %v, want not-exist", err)
+	}
+
+	want := &ShutdownManifest{
+		WrittenAt: time.Now().UTC().Truncate(time.Second),
+		Sessions: []ManifestSession{{
+			Session:          "gt-crew-max",
+			Address:          "gastown/crew/max",
+			Role:             "crew",
+			Rig:              "gastown",
+			HookedIssue:      "gt-abc",
+			RuntimeSessionID: "1234",
+			Snapshotted:      true,
+		}},
+	}
+	if err := WriteShutdownManifest(townRoot, want); err != nil {
+		t.Fatalf("WriteShutdownManifest: %v", err)
+	}
+	got, err := LoadShutdownManifest(townRoot)
+	if err != nil {
+		t.Fatalf("LoadShutdownManifest: %v", err)
+	}
+	if !got.WrittenAt.Equal(want.WrittenAt) || len(got.Sessions) != 1 || got.Sessions[0] != want.Sessions[0] {
+		t.Errorf("round trip = %+v, want %+v", got, want)
+	}
+}
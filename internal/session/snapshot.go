@@ -0,0 +1,234 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/tmux"
+)
+
+// SnapshotRestoreReason is written as the handoff marker reason when a session
+// is restored from a snapshot, so gt prime treats the new session as a
+// successor rather than a fresh start.
+const SnapshotRestoreReason = "restore"
+
+// Snapshot is the on-disk record of a running agent session, sufficient to
+// recreate it after a reboot or tmux server crash.
+type Snapshot struct {
+	Session          string            `json:"session"`
+	Address          string            `json:"address"`
+	Role             string            `json:"role"`
+	Rig              string            `json:"rig,omitempty"`
+	WorkDir          string            `json:"work_dir"`
+	Agent            string            `json:"agent,omitempty"`
+	RuntimeSessionID string            `json:"runtime_session_id,omitempty"`
+	Env              map[string]string `json:"env,omitempty"`
+	PaneFile         string            `json:"pane_file,omitempty"`
+	TakenAt          time.Time         `json:"taken_at"`
+}
+
+// snapshotOps is the subset of tmux operations needed to snapshot a session.
+// Satisfied by *tmux.Tmux.
+type snapshotOps interface {
+	CapturePaneAll(session string) (string, error)
+	GetPaneWorkDir(session string) (string, error)
+	GetAllEnvironment(session string) (map[string]string, error)
+}
+
+// SnapshotDir returns the directory holding session snapshots: <town>/.runtime/snapshots.
+func SnapshotDir(townRoot string) string {
+	return filepath.Join(townRoot, constants.DirRuntime, "snapshots")
+}
+
+// TakeSnapshot captures a session's pane contents, environment, working
+// directory, and runtime (Claude) session ID, and writes them under
+// SnapshotDir, readable only by the owner. Configured secrets are left out
+// of the saved environment. An existing snapshot for the same session is
+// replaced.
+func TakeSnapshot(t snapshotOps, townRoot, sessionID string) (*Snapshot, error) {
+	identity, err := ParseSessionName(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	workDir, err := t.GetPaneWorkDir(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("getting work dir: %w", err)
+	}
+	env, err := t.GetAllEnvironment(sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("getting environment: %w", err)
+	}
+
+	// Secrets are not persisted; RestoreSnapshot resolves them again.
+	rigPath := ""
+	if identity.Rig != "" {
+		rigPath = filepath.Join(townRoot, identity.Rig)
+	}
+	env, _ = SplitSecretEnv(env, townRoot, rigPath, string(identity.Role))
+
+	snap := &Snapshot{
+		Session: sessionID,
+		Address: identity.Address(),
+		Role:    string(identity.Role),
+		Rig:     identity.Rig,
+		WorkDir: workDir,
+		Agent:   env["GT_AGENT"],
+		Env:     env,
+		TakenAt: time.Now().UTC(),
+	}
+	snap.RuntimeSessionID = runtimeSessionID(workDir, env)
+
+	dir := SnapshotDir(townRoot)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("creating snapshot dir: %w", err)
+	}
+
+	// Pane contents are best-effort: a dead pane still yields a useful snapshot.
+	if content, err := t.CapturePaneAll(sessionID); err == nil {
+		paneFile := filepath.Join(dir, sessionID+".pane.txt")
+		if err := os.WriteFile(paneFile, []byte(content), 0600); err == nil {
+			snap.PaneFile = paneFile
+		}
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, sessionID+".json"), data, 0600); err != nil {
+		return nil, fmt.Errorf("writing snapshot: %w", err)
+	}
+	return snap, nil
+}
+
+// runtimeSessionID finds the agent runtime's conversation ID for a session.
+// Prefers the ID persisted by the SessionStart hook (gt prime --hook) in the
+// work dir, then falls back to the session environment.
+func runtimeSessionID(workDir string, env map[string]string) string {
+	if workDir != "" {
+		if data, err := os.ReadFile(filepath.Join(workDir, constants.DirRuntime, "session_id")); err == nil {
+			if id := strings.TrimSpace(strings.SplitN(string(data), "\n", 2)[0]); id != "" {
+				return id
+			}
+		}
+	}
+	for _, key := range []string{"GT_SESSION_ID", "CLAUDE_SESSION_ID"} {
+		if id := env[key]; id != "" {
+			return id
+		}
+	}
+	return ""
+}
+
+// LoadSnapshot reads the snapshot for a session.
+func LoadSnapshot(townRoot, sessionID string) (*Snapshot, error) {
+	data, err := os.ReadFile(filepath.Join(SnapshotDir(townRoot), sessionID+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("parsing snapshot for %s: %w", sessionID, err)
+	}
+	return &snap, nil
+}
+
+// ListSnapshots returns all saved snapshots, sorted by address.
+// Unreadable snapshot files are skipped.
+func ListSnapshots(townRoot string) ([]*Snapshot, error) {
+	entries, err := os.ReadDir(SnapshotDir(townRoot))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var snaps []*Snapshot
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		snap, err := LoadSnapshot(townRoot, strings.TrimSuffix(name, ".json"))
+		if err != nil {
+			continue
+		}
+		snaps = append(snaps, snap)
+	}
+	sort.Slice(snaps, func(i, j int) bool { return snaps[i].Address < snaps[j].Address })
+	return snaps, nil
+}
+
+// RestoreCommand returns the command that relaunches the snapshotted agent.
+// When the runtime supports it, the agent resumes its previous conversation
+// (e.g., claude --resume <id>); otherwise a normal startup command is built.
+func (s *Snapshot) RestoreCommand(townRoot string) string {
+	agent := s.Agent
+	if agent == "" {
+		agent = string(config.AgentClaude)
+	}
+	if cmd := config.BuildResumeCommand(agent, s.RuntimeSessionID); cmd != "" {
+		return "exec " + cmd
+	}
+	rigPath := ""
+	if s.Rig != "" {
+		rigPath = filepath.Join(townRoot, s.Rig)
+	}
+	return config.BuildAgentStartupCommand(s.Role, s.Rig, townRoot, rigPath, "")
+}
+
+// RestoreSnapshot recreates a session from its snapshot, with the secrets
+// configured for its role resolved afresh. It writes a handoff
+// marker (reason "restore") into the work dir so the successor's gt prime
+// knows it is continuing prior work, then re-enables log capture and layout.
+func RestoreSnapshot(t *tmux.Tmux, townRoot string, snap *Snapshot) error {
+	running, err := t.HasSession(snap.Session)
+	if err != nil {
+		return fmt.Errorf("checking session: %w", err)
+	}
+	if running {
+		return fmt.Errorf("session %s is already running", snap.Session)
+	}
+
+	if err := writeRestoreMarker(snap.WorkDir, snap.Session); err != nil {
+		return err
+	}
+
+	env := make(map[string]string, len(snap.Env))
+	for k, v := range snap.Env {
+		env[k] = v
+	}
+	rigPath := ""
+	if snap.Rig != "" {
+		rigPath = filepath.Join(townRoot, snap.Rig)
+	}
+	for k, v := range config.ResolveSessionSecrets(townRoot, rigPath, snap.Role) {
+		env[k] = v
+	}
+
+	if err := t.NewSessionWithCommandAndEnv(snap.Session, snap.WorkDir, snap.RestoreCommand(townRoot), env); err != nil {
+		return fmt.Errorf("creating session: %w", err)
+	}
+
+	_ = EnableSessionLogCapture(t, townRoot, snap.Session)
+	_ = ApplySessionLayout(t, townRoot, snap.Role, snap.Session, snap.WorkDir)
+	return nil
+}
+
+// writeRestoreMarker writes the handoff marker consumed by gt prime's
+// post-handoff detection.
+func writeRestoreMarker(workDir, sessionID string) error {
+	return WriteHandoffMarker(workDir, &HandoffMarker{
+		PrevSession: sessionID,
+		Reason:      SnapshotRestoreReason,
+		NextSteps:   []string{"The town was restored from a snapshot; check your hook and mail before resuming work."},
+	})
+}
@@ -0,0 +1,107 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+type fakeSnapshotOps struct {
+	workDir string
+	env     map[string]string
+	pane    string
+}
+
+func (f *fakeSnapshotOps) CapturePaneAll(_ string) (string, error) { return f.pane, nil }
+
+func (f *fakeSnapshotOps) GetPaneWorkDir(_ string) (string, error) { return f.workDir, nil }
+
+func (f *fakeSnapshotOps) GetAllEnvironment(_ string) (map[string]string, error) {
+	return f.env, nil
+}
+
+func TestTakeAndLoadSnapshot(t *testing.T) {
+	old := DefaultRegistry()
+	SetDefaultRegistry(testRegistry())
+	defer func() { SetDefaultRegistry(old) }()
+
+	town := t.TempDir()
+	workDir := t.TempDir()
+	runtimeDir := filepath.Join(workDir, constants.DirRuntime)
+	if err := os.MkdirAll(runtimeDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(runtimeDir, "session_id"), []byte("abc-123\n2026-01-01T00:00:00Z\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	settings := config.NewTownSettings()
+	settings.Secrets = &config.SessionEnvConfig{Vars: map[string]string{"API_TOKEN": "env:GT_TEST_API_TOKEN"}}
+	if err := config.SaveTownSettings(config.TownSettingsPath(town), settings); err != nil {
+		t.Fatal(err)
+	}
+	ops := &fakeSnapshotOps{
+		workDir: workDir,
+		env:     map[string]string{"GT_ROLE": "polecat", "GT_RIG": "gastown", "API_TOKEN": "tok-123"},
+		pane:    "working on gt-abc\n",
+	}
+	snap, err := TakeSnapshot(ops, town, "gt-Toast")
+	if err != nil {
+		t.Fatalf("TakeSnapshot: %v", err)
+	}
+	if snap.RuntimeSessionID != "abc-123" {
+		t.Errorf("RuntimeSessionID = %q, want abc-123", snap.RuntimeSessionID)
+	}
+	if snap.Address != "gastown/polecats/Toast" || snap.Role != "polecat" || snap.Rig != "gastown" {
+		t.Errorf("identity = %s/%s/%s", snap.Address, snap.Role, snap.Rig)
+	}
+	if data, err := os.ReadFile(snap.PaneFile); err != nil || string(data) != ops.pane {
+		t.Errorf("pane file = %q, %v", data, err)
+	}
+	if _, ok := snap.Env["API_TOKEN"]; ok {
+		t.Error("snapshot kept a secret from the session env")
+	}
+	if fi, err := os.Stat(filepath.Join(SnapshotDir(town), "gt-Toast.json")); err != nil || fi.Mode().Perm() != 0600 {
+		t.Errorf("snapshot file mode = %v, %v; want 0600", fi.Mode().Perm(), err)
+	}
+
+	snaps, err := ListSnapshots(town)
+	if err != nil {
+		t.Fatalf("ListSnapshots: %v", err)
+	}
+	if len(snaps) != 1 || snaps[0].Session != "gt-Toast" || snaps[0].Env["GT_ROLE"] != "polecat" {
+		t.Fatalf("ListSnapshots = %+v", snaps)
+	}
+
+	if cmd := snaps[0].RestoreCommand(town); !strings.Contains(cmd, "--resume abc-123") {
+		t.Errorf("RestoreCommand = %q, want claude resume", cmd)
+	}
+}
+
+func TestRuntimeSessionID_EnvFallback(t *testing.T) {
+	env := map[string]string{"CLAUDE_SESSION_ID": "from-env"}
+	if got := runtimeSessionID(t.TempDir(), env); got != "from-env" {
+		t.Errorf("runtimeSessionID = %q, want from-env", got)
+	}
+	if got := runtimeSessionID("", nil); got != "" {
+		t.Errorf("runtimeSessionID = %q, want empty", got)
+	}
+}
+
+func TestWriteRestoreMarker(t *testing.T) {
+	workDir := t.TempDir()
+	if err := writeRestoreMarker(workDir, "gt-Toast"); err != nil {
+		t.Fatal(err)
+	}
+	m, err := ReadHandoffMarker(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Version != HandoffMarkerVersion || m.PrevSession != "gt-Toast" || m.Reason != SnapshotRestoreReason {
+		t.Errorf("marker = %+v", m)
+	}
+}
@@ -0,0 +1,67 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Town hook events, run around 'gt start' and 'gt shutdown'.
+const (
+	TownHookPreStart     = "pre_start"
+	TownHookPostStart    = "post_start"
+	TownHookPreShutdown  = "pre_shutdown"
+	TownHookPostShutdown = "post_shutdown"
+)
+
+// TownHookResult is the outcome of one town hook.
+type TownHookResult struct {
+	Hook config.TownHook
+	Err  error
+}
+
+// TownHooks returns the hooks mayor/town.json declares for event.
+func TownHooks(townRoot, event string) []config.TownHook {
+	if townRoot == "" {
+		return nil
+	}
+	cfg, err := config.LoadTownConfig(filepath.Join(townRoot, "mayor", "town.json"))
+	if err != nil || cfg.Hooks == nil {
+		return nil
+	}
+	switch event {
+	case TownHookPreStart:
+		return cfg.Hooks.PreStart
+	case TownHookPostStart:
+		return cfg.Hooks.PostStart
+	case TownHookPreShutdown:
+		return cfg.Hooks.PreShutdown
+	case TownHookPostShutdown:
+		return cfg.Hooks.PostShutdown
+	}
+	return nil
+}
+
+// RunTownHooks runs the town's hooks for event in order and returns each
+// one's result. Every hook runs even if an earlier one fails.
+func RunTownHooks(townRoot, event string) []TownHookResult {
+	hooks := TownHooks(townRoot, event)
+	if len(hooks) == 0 {
+		return nil
+	}
+	env := append(os.Environ(),
+		"GT_HOOK_EVENT="+event,
+		"GT_TOWN_ROOT="+townRoot,
+	)
+	results := make([]TownHookResult, 0, len(hooks))
+	for _, h := range hooks {
+		err := runLifecycleHook(townRoot, config.SessionHook{Command: h.Command, Timeout: h.Timeout}, env)
+		if err != nil {
+			err = fmt.Errorf("%s hook %q: %w", event, h.Command, err)
+		}
+		results = append(results, TownHookResult{Hook: h, Err: err})
+	}
+	return results
+}
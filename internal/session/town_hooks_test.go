@@ -0,0 +1,76 @@
+package session
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func writeTownJSONHooks(t *testing.T, hooks *config.TownHooksConfig) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	if err := config.SaveTownConfig(filepath.Join(townRoot, "mayor", "town.json"), &config.TownConfig{
+		Type:      "town",
+		Version:   config.CurrentTownVersion,
+		Name:      "test",
+		CreatedAt: time.Now(),
+		Hooks:     hooks,
+	}); err != nil {
+		t.Fatalf("SaveTownConfig: %v", err)
+	}
+	return townRoot
+}
+
+func TestRunTownHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks run with sh -c")
+	}
+	townRoot := writeTownJSONHooks(t, &config.TownHooksConfig{
+		PreStart: []config.TownHook{
+			{Command: `echo "$GT_HOOK_EVENT" > pre.out`},
+			{Command: "echo nope >&2; exit 3", Required: true},
+		},
+	})
+
+	results := RunTownHooks(townRoot, TownHookPreStart)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	if results[0].Err != nil {
+		t.Errorf("first hook failed: %v", results[0].Err)
+	}
+	if err := results[1].Err; err == nil || !strings.Contains(err.Error(), "nope") || !results[1].Hook.Required {
+		t.Errorf("second hook result = %+v, want required failure mentioning its output", results[1])
+	}
+	data, err := os.ReadFile(filepath.Join(townRoot, "pre.out"))
+	if err != nil || strings.TrimSpace(string(data)) != TownHookPreStart {
+		t.Errorf("hook ran with GT_HOOK_EVENT=%q (err %v), want %q", data, err, TownHookPreStart)
+	}
+
+	if got := RunTownHooks(townRoot, TownHookPostShutdown); len(got) != 0 {
+		t.Errorf("no post_shutdown hooks configured, got %v", got)
+	}
+}
+
+func TestTownHooks_ParsesTownJSON(t *testing.T) {
+	townRoot := t.TempDir()
+	raw := `{"type":"town","version":2,"name":"t","created_at":"2026-01-01T00:00:00Z",
+		"hooks":{"post_shutdown":[{"command":"notify-send bye","timeout":"5s"}]}}`
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte(raw), 0644); err != nil {
+		t.Fatal(err)
+	}
+	hooks := TownHooks(townRoot, TownHookPostShutdown)
+	if len(hooks) != 1 || hooks[0].Command != "notify-send bye" || hooks[0].Timeout != "5s" {
+		got, _ := json.Marshal(hooks)
+		t.Errorf("hooks = %s", got)
+	}
+}
@@ -0,0 +1,467 @@
+// Package sharedconfig fetches a town's org-shared config: town.json
+// defaults, a rig list, and hooks base config and overrides kept in one git
+// repo or archive, so a team's towns stay consistent.
+//
+// Sync copies the shared files into the town's shared config directory
+// (config.SharedConfigDir). From there they are read as defaults: the town's
+// own mayor/town.json and the user's hooks config layer on top. Nothing is
+// applied until the next sync, so a bad push to the shared repo can't reach
+// a town mid-run.
+package sharedconfig
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/git"
+	"github.com/steveyegge/gastown/internal/hooks"
+)
+
+// stampName is the file in the shared config directory recording the last sync.
+const stampName = ".sync.json"
+
+// maxArchiveSize bounds downloaded archives; shared config is a few small
+// JSON files.
+const maxArchiveSize = 16 << 20
+
+// ErrNoSharedFiles means the source holds none of the files a shared config
+// may provide.
+var ErrNoSharedFiles = errors.New("no shared config files found")
+
+// Stamp records where the town's shared config came from.
+type Stamp struct {
+	Source   string    `json:"source"`
+	Ref      string    `json:"ref,omitempty"`
+	Path     string    `json:"path,omitempty"`
+	Revision string    `json:"revision"` // git commit, or sha256 of the archive
+	SyncedAt time.Time `json:"synced_at"`
+	Files    []string  `json:"files"`
+}
+
+// Rig is a shared rig list entry.
+type Rig struct {
+	Name   string
+	GitURL string
+}
+
+// Result describes what Sync did, or in a dry run would do. File names are
+// relative to the shared config directory.
+type Result struct {
+	Stamp   Stamp
+	Added   []string
+	Changed []string
+	Removed []string
+
+	// MissingRigs are rigs in the shared rig list that the town doesn't
+	// have. Sync doesn't add them: that clones repos, so it's left to
+	// 'gt rig add'.
+	MissingRigs []Rig
+}
+
+// Unchanged reports whether the sync left the shared files as they were.
+func (r *Result) Unchanged() bool {
+	return len(r.Added) == 0 && len(r.Changed) == 0 && len(r.Removed) == 0
+}
+
+// HTTPClient fetches archive sources. Tests may replace it.
+var HTTPClient = &http.Client{Timeout: 60 * time.Second}
+
+// Sync fetches src and replaces the town's shared config directory with the
+// shared files it holds. Every file is parsed before anything is replaced,
+// so a broken source leaves the previous shared config in place. With
+// dryRun, the directory is left alone and the changes are only reported.
+func Sync(townRoot string, src *config.SharedConfigSource, dryRun bool) (*Result, error) {
+	if src == nil || src.Source == "" {
+		return nil, fmt.Errorf("no shared config source")
+	}
+	if src.Path != "" && !filepath.IsLocal(src.Path) {
+		return nil, fmt.Errorf("shared config path %q must be relative, inside the source", src.Path)
+	}
+
+	files, revision, err := fetch(townRoot, src)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrNoSharedFiles, describe(src))
+	}
+	if err := validate(files); err != nil {
+		return nil, err
+	}
+
+	dir := config.SharedConfigDir(townRoot)
+	current, err := readDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", dir, err)
+	}
+
+	result := &Result{Stamp: Stamp{
+		Source:   src.Source,
+		Ref:      src.Ref,
+		Path:     src.Path,
+		Revision: revision,
+		SyncedAt: time.Now().UTC(),
+	}}
+	for _, name := range sortedKeys(files) {
+		result.Stamp.Files = append(result.Stamp.Files, name)
+		old, ok := current[name]
+		switch {
+		case !ok:
+			result.Added = append(result.Added, name)
+		case !bytes.Equal(old, files[name]):
+			result.Changed = append(result.Changed, name)
+		}
+	}
+	for _, name := range sortedKeys(current) {
+		if _, ok := files[name]; !ok {
+			result.Removed = append(result.Removed, name)
+		}
+	}
+	if result.MissingRigs, err = missingRigs(townRoot, files[config.SharedRigsFile]); err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if err := replaceDir(dir, files, &result.Stamp); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// LoadStamp returns the town's last sync record, or nil if it has never synced.
+func LoadStamp(townRoot string) (*Stamp, error) {
+	data, err := os.ReadFile(filepath.Join(config.SharedConfigDir(townRoot), stampName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var stamp Stamp
+	if err := json.Unmarshal(data, &stamp); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", stampName, err)
+	}
+	return &stamp, nil
+}
+
+func describe(src *config.SharedConfigSource) string {
+	s := src.Source
+	if src.Ref != "" {
+		s += "@" + src.Ref
+	}
+	if src.Path != "" {
+		s += " (" + src.Path + ")"
+	}
+	return s
+}
+
+// isArchiveURL reports whether source names a downloadable archive rather
+// than a git repository.
+func isArchiveURL(source string) bool {
+	if !strings.HasPrefix(source, "https://") && !strings.HasPrefix(source, "http://") {
+		return false
+	}
+	u := source
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+	return strings.HasSuffix(u, ".tar.gz") || strings.HasSuffix(u, ".tgz")
+}
+
+// fetch returns the shared files in src, keyed by their shared config name,
+// and the revision they came from.
+func fetch(townRoot string, src *config.SharedConfigSource) (map[string][]byte, string, error) {
+	if isArchiveURL(src.Source) {
+		if src.Ref != "" {
+			return nil, "", fmt.Errorf("ref %q only applies to git sources", src.Ref)
+		}
+		return fetchArchive(src)
+	}
+
+	runtimeDir := filepath.Join(townRoot, constants.DirRuntime)
+	if err := os.MkdirAll(runtimeDir, 0755); err != nil {
+		return nil, "", err
+	}
+	tmp, err := os.MkdirTemp(runtimeDir, "shared-config-fetch-")
+	if err != nil {
+		return nil, "", err
+	}
+	defer func() { _ = os.RemoveAll(tmp) }()
+
+	clone := filepath.Join(tmp, "repo")
+	g := git.NewGit(tmp)
+	if src.Ref != "" {
+		err = g.CloneBranch(src.Source, clone, src.Ref)
+	} else {
+		err = g.Clone(src.Source, clone)
+	}
+	if err != nil {
+		return nil, "", fmt.Errorf("cloning %s: %w", describe(src), err)
+	}
+	revision, err := git.NewGit(clone).Rev("HEAD")
+	if err != nil {
+		return nil, "", fmt.Errorf("reading revision: %w", err)
+	}
+
+	files := make(map[string][]byte)
+	root := filepath.Join(clone, src.Path)
+	err = filepath.WalkDir(root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == root {
+				return fmt.Errorf("%s has no %s directory", src.Source, src.Path)
+			}
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return fs.SkipDir
+		}
+		rel, err := filepath.Rel(root, p)
+		if err != nil || !d.Type().IsRegular() || !isSharedFile(filepath.ToSlash(rel)) {
+			return err
+		}
+		data, err := os.ReadFile(p) //nolint:gosec // G304: file in our own fresh clone
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return files, revision, nil
+}
+
+func fetchArchive(src *config.SharedConfigSource) (map[string][]byte, string, error) {
+	resp, err := HTTPClient.Get(src.Source)
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading %s: %w", src.Source, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", fmt.Errorf("downloading %s: %s", src.Source, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxArchiveSize+1))
+	if err != nil {
+		return nil, "", fmt.Errorf("downloading %s: %w", src.Source, err)
+	}
+	if len(body) > maxArchiveSize {
+		return nil, "", fmt.Errorf("downloading %s: archive larger than %d MB", src.Source, maxArchiveSize>>20)
+	}
+	sum := sha256.Sum256(body)
+
+	gz, err := gzip.NewReader(bytes.NewReader(body))
+	if err != nil {
+		return nil, "", fmt.Errorf("reading %s: %w", src.Source, err)
+	}
+	defer gz.Close()
+	members := make(map[string][]byte)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, "", fmt.Errorf("reading %s: %w", src.Source, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading %s: %w", src.Source, err)
+		}
+		members[path.Clean(strings.TrimPrefix(hdr.Name, "./"))] = data
+	}
+
+	// Archives made from a repo (e.g. a forge's "download tarball") put
+	// everything under one top-level directory; look inside it.
+	prefix := commonTopDir(members)
+	if src.Path != "" {
+		prefix = path.Join(prefix, filepath.ToSlash(src.Path))
+	}
+	files := make(map[string][]byte)
+	for name, data := range members {
+		rel := name
+		if prefix != "" {
+			var ok bool
+			if rel, ok = strings.CutPrefix(name, prefix+"/"); !ok {
+				continue
+			}
+		}
+		if isSharedFile(rel) {
+			files[rel] = data
+		}
+	}
+	return files, hex.EncodeToString(sum[:]), nil
+}
+
+// commonTopDir returns the directory every member is under, if there is
+// exactly one, or "".
+func commonTopDir(members map[string][]byte) string {
+	top := ""
+	for name := range members {
+		dir, _, ok := strings.Cut(name, "/")
+		if !ok || (top != "" && dir != top) {
+			return ""
+		}
+		top = dir
+	}
+	return top
+}
+
+// isSharedFile reports whether rel (slash-separated, relative to the shared
+// config root) is a file a shared config may provide.
+func isSharedFile(rel string) bool {
+	switch rel {
+	case config.SharedTownFile, config.SharedRigsFile, config.SharedHooksBaseFile:
+		return true
+	}
+	dir, name := path.Split(rel)
+	return dir == config.SharedHooksOverrides+"/" && strings.HasSuffix(name, ".json") &&
+		hooks.ValidTarget(strings.ReplaceAll(strings.TrimSuffix(name, ".json"), "__", "/"))
+}
+
+// validate parses every shared file as what it configures.
+func validate(files map[string][]byte) error {
+	for _, name := range sortedKeys(files) {
+		data := files[name]
+		var err error
+		switch name {
+		case config.SharedTownFile:
+			var cfg config.TownConfig
+			err = json.Unmarshal(data, &cfg)
+		case config.SharedRigsFile:
+			var rigs config.RigsConfig
+			err = json.Unmarshal(data, &rigs)
+		default:
+			var cfg hooks.HooksConfig
+			err = json.Unmarshal(data, &cfg)
+		}
+		if err != nil {
+			return fmt.Errorf("shared %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// missingRigs lists the rigs in the shared rig list data that the town
+// hasn't registered.
+func missingRigs(townRoot string, data []byte) ([]Rig, error) {
+	if data == nil {
+		return nil, nil
+	}
+	var shared config.RigsConfig
+	if err := json.Unmarshal(data, &shared); err != nil {
+		return nil, fmt.Errorf("shared %s: %w", config.SharedRigsFile, err)
+	}
+	local, err := config.LoadRigsConfig(filepath.Join(townRoot, constants.DirMayor, constants.FileRigsJSON))
+	if err != nil && !errors.Is(err, config.ErrNotFound) {
+		return nil, fmt.Errorf("loading rigs config: %w", err)
+	}
+	var missing []Rig
+	for name, entry := range shared.Rigs {
+		if local != nil {
+			if _, ok := local.Rigs[name]; ok {
+				continue
+			}
+		}
+		missing = append(missing, Rig{Name: name, GitURL: entry.GitURL})
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i].Name < missing[j].Name })
+	return missing, nil
+}
+
+// readDir returns the shared files currently in dir.
+func readDir(dir string) (map[string][]byte, error) {
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && p == dir {
+				return nil
+			}
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil || !d.Type().IsRegular() || !isSharedFile(filepath.ToSlash(rel)) {
+			return err
+		}
+		data, err := os.ReadFile(p) //nolint:gosec // G304: file in the town's shared config dir
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	return files, err
+}
+
+// replaceDir writes files and stamp to a fresh directory next to dir, then
+// swaps it in.
+func replaceDir(dir string, files map[string][]byte, stamp *Stamp) error {
+	if err := os.MkdirAll(filepath.Dir(dir), 0755); err != nil {
+		return err
+	}
+	next, err := os.MkdirTemp(filepath.Dir(dir), filepath.Base(dir)+"-new-")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.RemoveAll(next) }()
+
+	for name, data := range files {
+		p := filepath.Join(next, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, data, 0644); err != nil { //nolint:gosec // G306: config, not secrets
+			return err
+		}
+	}
+	stampData, err := json.MarshalIndent(stamp, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(next, stampName), stampData, 0644); err != nil { //nolint:gosec // G306: config, not secrets
+		return err
+	}
+
+	old := next + "-old"
+	if err := os.Rename(dir, old); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("replacing %s: %w", dir, err)
+	}
+	if err := os.Rename(next, dir); err != nil {
+		_ = os.Rename(old, dir)
+		return fmt.Errorf("replacing %s: %w", dir, err)
+	}
+	_ = os.RemoveAll(old)
+	return nil
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,191 @@
+package sharedconfig
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func writeFile(t *testing.T, root, rel, content string) {
+	t.Helper()
+	p := filepath.Join(root, rel)
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(p, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test", "GIT_COMMITTER_EMAIL=test@example.com")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %v\n%s", args, err, out)
+	}
+}
+
+// setupTown makes a town with one registered rig.
+func setupTown(t *testing.T) string {
+	t.Helper()
+	townRoot := t.TempDir()
+	writeFile(t, townRoot, "mayor/town.json", `{"type":"town","version":2,"name":"hq"}`)
+	writeFile(t, townRoot, "mayor/rigs.json", `{"version":1,"rigs":{"alpha":{"git_url":"https://example.com/alpha.git"}}}`)
+	return townRoot
+}
+
+// setupRepo makes a shared config repo and returns its path.
+func setupRepo(t *testing.T) string {
+	t.Helper()
+	repo := t.TempDir()
+	runGit(t, repo, "init", "-q", "-b", "main")
+	writeFile(t, repo, "town.json", `{"schedule":[{"cron":"0 22 * * *","action":"shutdown"}]}`)
+	writeFile(t, repo, "rigs.json", `{"version":1,"rigs":{"alpha":{"git_url":"https://example.com/alpha.git"},"beta":{"git_url":"https://example.com/beta.git"}}}`)
+	writeFile(t, repo, "hooks-base.json", `{"Stop":[{"matcher":"","hooks":[{"type":"command","command":"team-stop"}]}]}`)
+	writeFile(t, repo, "hooks-overrides/crew.json", `{}`)
+	writeFile(t, repo, "README.md", "not config")
+	runGit(t, repo, "add", ".")
+	runGit(t, repo, "commit", "-q", "-m", "shared config")
+	return repo
+}
+
+func TestSync_Git(t *testing.T) {
+	townRoot := setupTown(t)
+	repo := setupRepo(t)
+	src := &config.SharedConfigSource{Source: repo}
+
+	dry, err := Sync(townRoot, src, true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if _, err := os.Stat(config.SharedConfigDir(townRoot)); !os.IsNotExist(err) {
+		t.Error("dry run wrote the shared config dir")
+	}
+	wantFiles := []string{"hooks-base.json", "hooks-overrides/crew.json", "rigs.json", "town.json"}
+	if !slices.Equal(dry.Added, wantFiles) {
+		t.Errorf("Added = %v, want %v", dry.Added, wantFiles)
+	}
+
+	result, err := Sync(townRoot, src, false)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if len(result.Stamp.Revision) != 40 {
+		t.Errorf("Revision = %q, want a commit hash", result.Stamp.Revision)
+	}
+	if len(result.MissingRigs) != 1 || result.MissingRigs[0] != (Rig{Name: "beta", GitURL: "https://example.com/beta.git"}) {
+		t.Errorf("MissingRigs = %+v, want beta", result.MissingRigs)
+	}
+	if _, err := os.Stat(filepath.Join(config.SharedConfigDir(townRoot), "README.md")); !os.IsNotExist(err) {
+		t.Error("files that aren't shared config must not be copied")
+	}
+
+	cfg, err := config.LoadTownConfig(filepath.Join(townRoot, "mayor", "town.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Name != "hq" || len(cfg.Schedule) != 1 {
+		t.Errorf("town config = %+v, want the shared schedule under the town's name", cfg)
+	}
+
+	stamp, err := LoadStamp(townRoot)
+	if err != nil || stamp == nil || stamp.Revision != result.Stamp.Revision {
+		t.Errorf("LoadStamp = %+v, %v", stamp, err)
+	}
+
+	// A second sync with an updated repo reports what changed.
+	writeFile(t, repo, "hooks-base.json", `{}`)
+	if err := os.Remove(filepath.Join(repo, "town.json")); err != nil {
+		t.Fatal(err)
+	}
+	runGit(t, repo, "commit", "-q", "-am", "update")
+	result, err = Sync(townRoot, src, false)
+	if err != nil {
+		t.Fatalf("second Sync: %v", err)
+	}
+	if !slices.Equal(result.Changed, []string{"hooks-base.json"}) || !slices.Equal(result.Removed, []string{"town.json"}) {
+		t.Errorf("Changed = %v, Removed = %v", result.Changed, result.Removed)
+	}
+	if _, err := os.Stat(filepath.Join(config.SharedConfigDir(townRoot), "town.json")); !os.IsNotExist(err) {
+		t.Error("a file removed from the source should be removed from the town")
+	}
+}
+
+func TestSync_InvalidSourceKeepsPrevious(t *testing.T) {
+	townRoot := setupTown(t)
+	repo := setupRepo(t)
+	src := &config.SharedConfigSource{Source: repo}
+	if _, err := Sync(townRoot, src, false); err != nil {
+		t.Fatal(err)
+	}
+
+	writeFile(t, repo, "hooks-base.json", `{"Stop": "not a list"}`)
+	runGit(t, repo, "commit", "-q", "-am", "broken")
+	if _, err := Sync(townRoot, src, false); err == nil {
+		t.Fatal("expected an error for an invalid hooks-base.json")
+	}
+	data, err := os.ReadFile(filepath.Join(config.SharedConfigDir(townRoot), "hooks-base.json"))
+	if err != nil || !bytes.Contains(data, []byte("team-stop")) {
+		t.Errorf("previous shared config not kept: %s, %v", data, err)
+	}
+
+	if _, err := Sync(townRoot, &config.SharedConfigSource{Source: repo, Path: "teams/none"}, false); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+	if _, err := Sync(townRoot, &config.SharedConfigSource{Source: repo, Path: "../escape"}, false); err == nil {
+		t.Error("expected an error for a path outside the source")
+	}
+}
+
+func TestSync_Archive(t *testing.T) {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range map[string]string{
+		"gt-config-main/teams/platform/hooks-overrides/gastown__crew.json": `{}`,
+		"gt-config-main/teams/platform/rigs.json":                          `{"version":1,"rigs":{}}`,
+		"gt-config-main/teams/other/town.json":                             `{}`,
+	} {
+		_ = tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg})
+		_, _ = tw.Write([]byte(content))
+	}
+	_ = tw.Close()
+	_ = gz.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/gt-config.tar.gz" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write(buf.Bytes())
+	}))
+	defer srv.Close()
+
+	townRoot := setupTown(t)
+	result, err := Sync(townRoot, &config.SharedConfigSource{Source: srv.URL + "/gt-config.tar.gz", Path: "teams/platform"}, false)
+	if err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+	if want := []string{"hooks-overrides/gastown__crew.json", "rigs.json"}; !slices.Equal(result.Stamp.Files, want) {
+		t.Errorf("Files = %v, want %v", result.Stamp.Files, want)
+	}
+	if len(result.Stamp.Revision) != 64 {
+		t.Errorf("Revision = %q, want the archive's sha256", result.Stamp.Revision)
+	}
+
+	if _, err := Sync(townRoot, &config.SharedConfigSource{Source: srv.URL + "/missing.tar.gz"}, false); err == nil {
+		t.Error("expected an error for a 404")
+	}
+}
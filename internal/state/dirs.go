@@ -0,0 +1,136 @@
+// ABOUTME: Resolution of gt's per-user directories: XDG paths with a
+// ABOUTME: fallback to the legacy ~/.gt directory, and migration between them.
+
+package state
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Before XDG support, gt kept hooks config and logs together in ~/.gt.
+// Each kind now has its own XDG directory; ~/.gt is still used for a kind
+// while it holds that kind's files and the XDG directory doesn't, so
+// existing installs keep working until 'gt config migrate-xdg' moves them.
+
+// HooksConfigFiles are the hooks config entries, in the config directory.
+var HooksConfigFiles = []string{"hooks-base.json", "hooks-overrides"}
+
+// LogFiles are the log entries, in the state directory.
+var LogFiles = []string{"costs.jsonl", "cmd-usage.jsonl"}
+
+// GTHomeDir returns $GT_HOME/.gt, or "" when GT_HOME is unset. GT_HOME pins
+// every gt file next to the workspace, ahead of XDG and ~/.gt.
+func GTHomeDir() string {
+	if h := os.Getenv("GT_HOME"); h != "" {
+		return filepath.Join(h, ".gt")
+	}
+	return ""
+}
+
+// LegacyDir returns the pre-XDG ~/.gt directory.
+func LegacyDir() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join(os.TempDir(), ".gt")
+	}
+	return filepath.Join(home, ".gt")
+}
+
+// HooksConfigDir returns the directory holding hooks-base.json and
+// hooks-overrides/.
+func HooksConfigDir() string {
+	if d := GTHomeDir(); d != "" {
+		return d
+	}
+	return UserHooksConfigDir()
+}
+
+// UserHooksConfigDir is HooksConfigDir ignoring GT_HOME: the user's own
+// hooks config directory.
+func UserHooksConfigDir() string {
+	return resolveDir(ConfigDir(), HooksConfigFiles)
+}
+
+// LogDir returns the directory for gt's logs (cost records, command usage).
+func LogDir() string {
+	if d := GTHomeDir(); d != "" {
+		return d
+	}
+	return resolveDir(StateDir(), LogFiles)
+}
+
+// resolveDir picks between xdgDir and the legacy directory for files named
+// names: whichever already holds them (XDG first), and XDG for new installs.
+func resolveDir(xdgDir string, names []string) string {
+	if hasAny(xdgDir, names) {
+		return xdgDir
+	}
+	if legacy := LegacyDir(); hasAny(legacy, names) {
+		return legacy
+	}
+	return xdgDir
+}
+
+func hasAny(dir string, names []string) bool {
+	for _, name := range names {
+		if _, err := os.Lstat(filepath.Join(dir, name)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Move is one legacy entry moved (or to be moved) to its XDG directory.
+type Move struct {
+	From, To string
+}
+
+// ErrGTHomeSet means GT_HOME pins gt's files, so there is nothing to migrate.
+var ErrGTHomeSet = errors.New("GT_HOME is set")
+
+// MigrateLegacyDir moves hooks config and logs from ~/.gt to the XDG config
+// and state directories. Entries that already exist at the destination are
+// left in place and returned as skipped. ~/.gt is removed once empty. With
+// dryRun, nothing is changed and the moves that would happen are returned.
+func MigrateLegacyDir(dryRun bool) (moved, skipped []Move, err error) {
+	if GTHomeDir() != "" {
+		return nil, nil, fmt.Errorf("%w: files stay in %s", ErrGTHomeSet, GTHomeDir())
+	}
+	legacy := LegacyDir()
+	for _, group := range []struct {
+		dir   string
+		names []string
+	}{
+		{ConfigDir(), HooksConfigFiles},
+		{StateDir(), LogFiles},
+	} {
+		for _, name := range group.names {
+			m := Move{From: filepath.Join(legacy, name), To: filepath.Join(group.dir, name)}
+			if _, err := os.Lstat(m.From); err != nil {
+				continue
+			}
+			if _, err := os.Lstat(m.To); err == nil {
+				skipped = append(skipped, m)
+				continue
+			}
+			moved = append(moved, m)
+			if dryRun {
+				continue
+			}
+			if err := os.MkdirAll(group.dir, 0755); err != nil {
+				return moved[:len(moved)-1], skipped, err
+			}
+			if err := os.Rename(m.From, m.To); err != nil {
+				return moved[:len(moved)-1], skipped, fmt.Errorf("moving %s: %w", m.From, err)
+			}
+		}
+	}
+	if !dryRun {
+		// Only succeeds when nothing else is left in ~/.gt.
+		_ = os.Remove(legacy)
+	}
+	return moved, skipped, nil
+}
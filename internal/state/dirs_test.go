@@ -0,0 +1,120 @@
+// ABOUTME: Tests for XDG and legacy ~/.gt directory resolution.
+// ABOUTME: Verifies fallback for unmigrated installs and the migration.
+
+package state
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func setDirsHome(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("GT_HOME", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_STATE_HOME", "")
+	return home
+}
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHooksConfigDir(t *testing.T) {
+	t.Run("new install uses XDG", func(t *testing.T) {
+		home := setDirsHome(t)
+		if got, want := HooksConfigDir(), filepath.Join(home, ".config", "gastown"); got != want {
+			t.Errorf("HooksConfigDir() = %q, want %q", got, want)
+		}
+		xdg := t.TempDir()
+		t.Setenv("XDG_CONFIG_HOME", xdg)
+		if got, want := HooksConfigDir(), filepath.Join(xdg, "gastown"); got != want {
+			t.Errorf("HooksConfigDir() with XDG_CONFIG_HOME = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("unmigrated install keeps ~/.gt", func(t *testing.T) {
+		home := setDirsHome(t)
+		touch(t, filepath.Join(home, ".gt", "hooks-overrides", "crew.json"))
+		if got, want := HooksConfigDir(), filepath.Join(home, ".gt"); got != want {
+			t.Errorf("HooksConfigDir() = %q, want %q", got, want)
+		}
+		// Logs are resolved separately: none in ~/.gt, so they go to XDG.
+		if got, want := LogDir(), filepath.Join(home, ".local", "state", "gastown"); got != want {
+			t.Errorf("LogDir() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("XDG wins once it has files", func(t *testing.T) {
+		home := setDirsHome(t)
+		touch(t, filepath.Join(home, ".gt", "hooks-base.json"))
+		touch(t, filepath.Join(home, ".config", "gastown", "hooks-base.json"))
+		if got, want := HooksConfigDir(), filepath.Join(home, ".config", "gastown"); got != want {
+			t.Errorf("HooksConfigDir() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("GT_HOME pins everything", func(t *testing.T) {
+		setDirsHome(t)
+		gtHome := t.TempDir()
+		t.Setenv("GT_HOME", gtHome)
+		want := filepath.Join(gtHome, ".gt")
+		if HooksConfigDir() != want || LogDir() != want {
+			t.Errorf("HooksConfigDir() = %q, LogDir() = %q, want %q", HooksConfigDir(), LogDir(), want)
+		}
+	})
+}
+
+func TestMigrateLegacyDir(t *testing.T) {
+	home := setDirsHome(t)
+	legacy := filepath.Join(home, ".gt")
+	touch(t, filepath.Join(legacy, "hooks-base.json"))
+	touch(t, filepath.Join(legacy, "hooks-overrides", "crew.json"))
+	touch(t, filepath.Join(legacy, "costs.jsonl"))
+	touch(t, filepath.Join(legacy, "cmd-usage.jsonl"))
+	touch(t, filepath.Join(home, ".local", "state", "gastown", "cmd-usage.jsonl"))
+
+	moved, skipped, err := MigrateLegacyDir(true)
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	if len(moved) != 3 || len(skipped) != 1 {
+		t.Fatalf("dry run moved %v, skipped %v", moved, skipped)
+	}
+	if _, err := os.Stat(filepath.Join(legacy, "hooks-base.json")); err != nil {
+		t.Fatal("dry run must not move files")
+	}
+
+	if _, _, err := MigrateLegacyDir(false); err != nil {
+		t.Fatalf("MigrateLegacyDir: %v", err)
+	}
+	for _, p := range []string{
+		filepath.Join(home, ".config", "gastown", "hooks-base.json"),
+		filepath.Join(home, ".config", "gastown", "hooks-overrides", "crew.json"),
+		filepath.Join(home, ".local", "state", "gastown", "costs.jsonl"),
+		filepath.Join(legacy, "cmd-usage.jsonl"),
+	} {
+		if _, err := os.Stat(p); err != nil {
+			t.Errorf("expected %s: %v", p, err)
+		}
+	}
+	if got, want := HooksConfigDir(), filepath.Join(home, ".config", "gastown"); got != want {
+		t.Errorf("after migration HooksConfigDir() = %q, want %q", got, want)
+	}
+
+	t.Setenv("GT_HOME", t.TempDir())
+	if _, _, err := MigrateLegacyDir(false); !errors.Is(err, ErrGTHomeSet) {
+		t.Errorf("with GT_HOME set: %v, want ErrGTHomeSet", err)
+	}
+}
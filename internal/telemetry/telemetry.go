@@ -132,16 +132,9 @@ func Init(ctx context.Context, serviceName, serviceVersion string) (*Provider, e
 		logsURL = DefaultLogsURL
 	}
 
-	res, err := resource.New(ctx,
-		resource.WithAttributes(
-			semconv.ServiceName(serviceName),
-			semconv.ServiceVersion(serviceVersion),
-		),
-		resource.WithHost(),
-		resource.WithOS(),
-	)
+	res, err := newResource(ctx, serviceName, serviceVersion)
 	if err != nil {
-		return nil, fmt.Errorf("creating OTel resource: %w", err)
+		return nil, err
 	}
 
 	p := &Provider{}
@@ -183,3 +176,19 @@ func Init(ctx context.Context, serviceName, serviceVersion string) (*Provider, e
 	globalProvider = p
 	return p, nil
 }
+
+// newResource describes this process to OTel backends.
+func newResource(ctx context.Context, serviceName, serviceVersion string) (*resource.Resource, error) {
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(serviceName),
+			semconv.ServiceVersion(serviceVersion),
+		),
+		resource.WithHost(),
+		resource.WithOS(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTel resource: %w", err)
+	}
+	return res, nil
+}
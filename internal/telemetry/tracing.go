@@ -0,0 +1,176 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// EnvTraceParent carries the W3C trace context between gt processes. Each
+// traced gt command sets it to its own span, so commands it runs and agent
+// sessions it starts with the value join the same trace.
+const EnvTraceParent = "TRACEPARENT"
+
+// tracerName is the instrumentation scope of gt's spans.
+const tracerName = "github.com/steveyegge/gastown"
+
+// TracingOptions configures InitTracing (mirrors town.json "tracing").
+type TracingOptions struct {
+	// Endpoint is the collector's OTLP HTTP traces URL, e.g.
+	// "http://localhost:4318/v1/traces". An http:// URL disables TLS.
+	Endpoint string
+
+	// Headers are sent with every export.
+	Headers map[string]string
+
+	// SampleRatio is the fraction of new traces recorded (0 to 1).
+	SampleRatio float64
+}
+
+// package-level tracing state: the command span every other span falls
+// back to as parent, and whether a tracer provider is installed.
+var (
+	tracingMu  sync.Mutex
+	tracingOn  bool
+	commandCtx = context.Background()
+)
+
+// InitTracing installs a tracer provider exporting spans to opts.Endpoint.
+// Returns (nil, nil) when no endpoint is set. The caller must Shutdown the
+// provider on exit to flush spans. Like Init, only the first call counts.
+func InitTracing(ctx context.Context, opts TracingOptions, serviceName, serviceVersion string) (*Provider, error) {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+	if tracingOn || opts.Endpoint == "" {
+		return nil, nil
+	}
+
+	res, err := newResource(ctx, serviceName, serviceVersion)
+	if err != nil {
+		return nil, err
+	}
+	exporterOpts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(opts.Endpoint)}
+	if len(opts.Headers) > 0 {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithHeaders(opts.Headers))
+	}
+	exp, err := otlptracehttp.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithResource(res),
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(tp)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	tracingOn = true
+	return &Provider{shutdowns: []func(context.Context) error{tp.Shutdown}}, nil
+}
+
+// TracingActive reports whether InitTracing installed a tracer provider.
+func TracingActive() bool {
+	tracingMu.Lock()
+	defer tracingMu.Unlock()
+	return tracingOn
+}
+
+// StartCommandSpan starts the span for a gt command (name like "gt done"),
+// continuing the trace in TRACEPARENT when set. It becomes the parent of
+// spans started without one, and TRACEPARENT is pointed at it so commands
+// this process runs nest under it. The returned function ends the span.
+// No-op when tracing is off.
+func StartCommandSpan(name string) (context.Context, func(error)) {
+	if !TracingActive() {
+		return context.Background(), func(error) {}
+	}
+	parent := ContextWithTraceParent(context.Background(), os.Getenv(EnvTraceParent))
+	ctx, span := otel.Tracer(tracerName).Start(parent, name,
+		trace.WithSpanKind(trace.SpanKindInternal),
+		trace.WithAttributes(append(gtContextAttrs(), attribute.String("gt.command", name))...))
+
+	tracingMu.Lock()
+	commandCtx = ctx
+	tracingMu.Unlock()
+	if tp := TraceParent(ctx); tp != "" {
+		_ = os.Setenv(EnvTraceParent, tp)
+	}
+	return ctx, func(err error) { EndSpan(span, err) }
+}
+
+// StartSpan starts a span for one step of a workflow. When ctx carries no
+// span, the span is parented to the current command's span, so library
+// code without a context still lands in the command's trace.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		tracingMu.Lock()
+		ctx = commandCtx
+		tracingMu.Unlock()
+	}
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan records err (if any) on span and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// TraceParent returns the W3C traceparent of the span in ctx (or of the
+// command span when ctx has none), for handing the trace to another
+// process. Returns "" when there is nothing to propagate.
+func TraceParent(ctx context.Context) string {
+	if ctx == nil || !trace.SpanContextFromContext(ctx).IsValid() {
+		tracingMu.Lock()
+		ctx = commandCtx
+		tracingMu.Unlock()
+	}
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier["traceparent"]
+}
+
+// ContextWithTraceParent returns ctx carrying the remote span context from
+// a W3C traceparent, or ctx unchanged if tp is empty or malformed.
+func ContextWithTraceParent(ctx context.Context, tp string) context.Context {
+	if tp == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": tp})
+}
+
+// gtContextAttrs returns the agent identity of this process as span
+// attributes, from the same GT_* variables as OTEL_RESOURCE_ATTRIBUTES.
+func gtContextAttrs() []attribute.KeyValue {
+	var attrs []attribute.KeyValue
+	// Polecat and crew carry their agent name in different vars.
+	if v := os.Getenv("GT_POLECAT"); v != "" {
+		attrs = append(attrs, attribute.String("gt.agent", v))
+	} else if v := os.Getenv("GT_CREW"); v != "" {
+		attrs = append(attrs, attribute.String("gt.agent", v))
+	}
+	for _, kv := range []struct{ key, env string }{
+		{"gt.role", "GT_ROLE"},
+		{"gt.rig", "GT_RIG"},
+		{"gt.session", "GT_SESSION"},
+		{"gt.run_id", "GT_RUN"},
+		{"gt.work_bead", "GT_WORK_BEAD"},
+	} {
+		if v := os.Getenv(kv.env); v != "" {
+			attrs = append(attrs, attribute.String(kv.key, v))
+		}
+	}
+	return attrs
+}
@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// withRecordedTracing turns tracing on with an in-memory exporter and
+// restores the package state afterwards.
+func withRecordedTracing(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp))
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	tracingMu.Lock()
+	tracingOn = true
+	tracingMu.Unlock()
+	t.Cleanup(func() {
+		_ = tp.Shutdown(context.Background())
+		otel.SetTracerProvider(prev)
+		tracingMu.Lock()
+		tracingOn = false
+		commandCtx = context.Background()
+		tracingMu.Unlock()
+	})
+	return exp
+}
+
+func TestInitTracing_NoEndpoint_ReturnsNil(t *testing.T) {
+	p, err := InitTracing(context.Background(), TracingOptions{}, "test-svc", "0.0.1")
+	if err != nil || p != nil {
+		t.Errorf("InitTracing without endpoint = %v, %v; want nil, nil", p, err)
+	}
+	if TracingActive() {
+		t.Error("tracing active without an endpoint")
+	}
+}
+
+func TestStartCommandSpan_Inactive_IsNoop(t *testing.T) {
+	t.Setenv(EnvTraceParent, "")
+	ctx, end := StartCommandSpan("gt status")
+	end(nil)
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		t.Error("command span started with tracing off")
+	}
+	if got := os.Getenv(EnvTraceParent); got != "" {
+		t.Errorf("TRACEPARENT = %q; want it untouched", got)
+	}
+}
+
+func TestTraceParentRoundTrip(t *testing.T) {
+	const tp = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	ctx := ContextWithTraceParent(context.Background(), tp)
+	if got := TraceParent(ctx); got != tp {
+		t.Errorf("TraceParent = %q, want %q", got, tp)
+	}
+	if ctx := ContextWithTraceParent(context.Background(), "garbage"); trace.SpanContextFromContext(ctx).IsValid() {
+		t.Error("malformed traceparent produced a span context")
+	}
+}
+
+func TestCommandSpanJoinsTraceAndParentsSpans(t *testing.T) {
+	exp := withRecordedTracing(t)
+	const parentTraceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	t.Setenv(EnvTraceParent, "00-"+parentTraceID+"-00f067aa0ba902b7-01")
+	t.Setenv("GT_POLECAT", "Toast")
+
+	_, end := StartCommandSpan("gt done")
+	// A span started without a context nests under the command span.
+	_, span := StartSpan(context.Background(), "polecat.spawn")
+	EndSpan(span, errors.New("boom"))
+	handoff := os.Getenv(EnvTraceParent)
+	end(nil)
+
+	spans := exp.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	child, cmd := spans[0], spans[1]
+	if cmd.Name != "gt done" || cmd.SpanContext.TraceID().String() != parentTraceID {
+		t.Errorf("command span %q in trace %s; want gt done in the TRACEPARENT trace", cmd.Name, cmd.SpanContext.TraceID())
+	}
+	if child.Parent.SpanID() != cmd.SpanContext.SpanID() {
+		t.Error("spawn span is not a child of the command span")
+	}
+	if child.Status.Code != codes.Error {
+		t.Errorf("spawn span status = %v, want error", child.Status.Code)
+	}
+	if want := "00-" + parentTraceID + "-" + cmd.SpanContext.SpanID().String() + "-01"; handoff != want {
+		t.Errorf("TRACEPARENT = %q, want the command span %q", handoff, want)
+	}
+	var agent string
+	for _, kv := range cmd.Attributes {
+		if kv.Key == "gt.agent" {
+			agent = kv.Value.AsString()
+		}
+	}
+	if agent != "Toast" {
+		t.Errorf("gt.agent = %q, want Toast", agent)
+	}
+}
@@ -0,0 +1,216 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+)
+
+// Town boot service identifiers. Unlike the daemon supervisor, which keeps
+// 'gt daemon run' alive, the town service runs 'gt start' at login/boot and
+// 'gt shutdown --graceful' when the service is stopped.
+const (
+	townServiceLaunchdLabel = "com.gastown.town"
+	townServiceSystemdUnit  = "gastown-town.service"
+)
+
+// TownServiceData contains information for rendering town service templates.
+type TownServiceData struct {
+	GTPath   string // Path to the gt binary
+	TownRoot string // Path to the Gas Town workspace
+	Path     string // PATH for the service, so gt can find tmux and agent CLIs
+
+	// ServicePath is where the service file is installed. The launchd job
+	// only shuts the town down on SIGTERM while this file still exists.
+	ServicePath string
+}
+
+// TownServiceStatus describes the installed town boot service.
+type TownServiceStatus struct {
+	Installed bool   // Service file exists
+	Active    bool   // Service manager reports it loaded/active
+	Path      string // Service file path
+	TownRoot  string // Town the installed service starts, if readable
+}
+
+// TownServicePath returns where the town service file lives for goos.
+func TownServicePath(goos string) (string, error) {
+	switch goos {
+	case "darwin":
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("finding home directory: %w", err)
+		}
+		return filepath.Join(homeDir, "Library", "LaunchAgents", townServiceLaunchdLabel+".plist"), nil
+	case "linux":
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				return "", fmt.Errorf("finding home directory: %w", err)
+			}
+			dataHome = filepath.Join(homeDir, ".local", "share")
+		}
+		return filepath.Join(dataHome, "systemd", "user", townServiceSystemdUnit), nil
+	default:
+		return "", fmt.Errorf("town service is not supported on %s", goos)
+	}
+}
+
+// RenderTownService renders the town service file for goos.
+func RenderTownService(goos string, data TownServiceData) ([]byte, error) {
+	var name string
+	switch goos {
+	case "darwin":
+		name = "launchd/" + townServiceLaunchdLabel + ".plist"
+	case "linux":
+		name = "systemd/" + townServiceSystemdUnit
+	default:
+		return nil, fmt.Errorf("town service is not supported on %s", goos)
+	}
+
+	templateContent, err := supervisorFS.ReadFile(name)
+	if err != nil {
+		return nil, fmt.Errorf("reading town service template: %w", err)
+	}
+	tmpl, err := template.New("town-service").Parse(string(templateContent))
+	if err != nil {
+		return nil, fmt.Errorf("parsing town service template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("rendering town service template: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// InstallTownService writes and enables a service that starts townRoot at
+// login/boot and shuts it down gracefully when stopped. It replaces any
+// existing town service, so only one town is started at boot.
+// Returns a message describing what was installed.
+func InstallTownService(townRoot string) (string, error) {
+	gtPath, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("finding gt executable: %w", err)
+	}
+	path, err := TownServicePath(runtime.GOOS)
+	if err != nil {
+		return "", err
+	}
+	data := TownServiceData{GTPath: gtPath, TownRoot: townRoot, Path: os.Getenv("PATH"), ServicePath: path}
+
+	content, err := RenderTownService(runtime.GOOS, data)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("creating service directory: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Join(townRoot, "daemon"), 0755); err != nil {
+		return "", fmt.Errorf("creating daemon directory: %w", err)
+	}
+
+	if runtime.GOOS == "darwin" {
+		// Replace any loaded job without shutting the town down: remove the
+		// old plist first so the job's SIGTERM trap skips the shutdown.
+		_ = os.Remove(path)
+		_ = exec.Command("launchctl", "remove", townServiceLaunchdLabel).Run()
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return "", fmt.Errorf("writing plist file: %w", err)
+		}
+		// RunAtLoad starts the job now; 'gt start' is a no-op for agents
+		// that are already running.
+		if output, err := exec.Command("launchctl", "load", "-w", path).CombinedOutput(); err != nil {
+			return "", fmt.Errorf("loading launchd service: %s", string(output))
+		}
+		return "Installed launchd service: " + townServiceLaunchdLabel, nil
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", fmt.Errorf("writing service file: %w", err)
+	}
+	if output, err := exec.Command("systemctl", "--user", "daemon-reload").CombinedOutput(); err != nil {
+		return "", fmt.Errorf("reloading systemd: %s", string(output))
+	}
+	if output, err := exec.Command("systemctl", "--user", "enable", townServiceSystemdUnit).CombinedOutput(); err != nil {
+		return "", fmt.Errorf("enabling systemd service: %s", string(output))
+	}
+	return "Installed and enabled systemd user service: " + townServiceSystemdUnit, nil
+}
+
+// UninstallTownService disables and removes the town service. The running
+// town is left alone. Returns a message describing what was removed.
+func UninstallTownService() (string, error) {
+	path, err := TownServicePath(runtime.GOOS)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return "Town service is not installed", nil
+	}
+
+	if runtime.GOOS == "linux" {
+		_ = exec.Command("systemctl", "--user", "disable", townServiceSystemdUnit).Run()
+	}
+	// Remove the plist before unloading: unloading delivers SIGTERM, and the
+	// job skips the shutdown once its plist is gone.
+	if err := os.Remove(path); err != nil {
+		return "", fmt.Errorf("removing service file: %w", err)
+	}
+	if runtime.GOOS == "darwin" {
+		_ = exec.Command("launchctl", "remove", townServiceLaunchdLabel).Run()
+	} else {
+		_ = exec.Command("systemctl", "--user", "daemon-reload").Run()
+	}
+	return "Removed town service: " + path, nil
+}
+
+// GetTownServiceStatus reports whether the town service is installed and
+// active, and which town it starts.
+func GetTownServiceStatus() (TownServiceStatus, error) {
+	path, err := TownServicePath(runtime.GOOS)
+	if err != nil {
+		return TownServiceStatus{}, err
+	}
+	st := TownServiceStatus{Path: path}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return st, nil
+		}
+		return st, fmt.Errorf("reading service file: %w", err)
+	}
+	st.Installed = true
+	st.TownRoot = parseTownServiceRoot(string(content))
+
+	if runtime.GOOS == "darwin" {
+		st.Active = exec.Command("launchctl", "list", townServiceLaunchdLabel).Run() == nil
+	} else {
+		st.Active = exec.Command("systemctl", "--user", "is-active", "--quiet", townServiceSystemdUnit).Run() == nil
+	}
+	return st, nil
+}
+
+// parseTownServiceRoot extracts the town root from a rendered service file.
+func parseTownServiceRoot(content string) string {
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if dir, ok := strings.CutPrefix(line, "WorkingDirectory="); ok {
+			return dir
+		}
+	}
+	// launchd: <key>WorkingDirectory</key> followed by <string>...</string>
+	if _, rest, ok := strings.Cut(content, "<key>WorkingDirectory</key>"); ok {
+		if _, rest, ok := strings.Cut(rest, "<string>"); ok {
+			if dir, _, ok := strings.Cut(rest, "</string>"); ok {
+				return dir
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,54 @@
+package templates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderTownService(t *testing.T) {
+	data := TownServiceData{
+		GTPath:      "/usr/local/bin/gt",
+		TownRoot:    "/home/me/gt",
+		Path:        "/usr/local/bin:/usr/bin",
+		ServicePath: "/home/me/Library/LaunchAgents/com.gastown.town.plist",
+	}
+
+	unit, err := RenderTownService("linux", data)
+	if err != nil {
+		t.Fatalf("linux: %v", err)
+	}
+	for _, want := range []string{
+		"ExecStart=/usr/local/bin/gt start\n",
+		"ExecStop=/usr/local/bin/gt shutdown --graceful --yes\n",
+		"RemainAfterExit=yes",
+		`Environment="PATH=/usr/local/bin:/usr/bin"`,
+	} {
+		if !strings.Contains(string(unit), want) {
+			t.Errorf("systemd unit missing %q:\n%s", want, unit)
+		}
+	}
+	if got := parseTownServiceRoot(string(unit)); got != data.TownRoot {
+		t.Errorf("parseTownServiceRoot(systemd) = %q, want %q", got, data.TownRoot)
+	}
+
+	plist, err := RenderTownService("darwin", data)
+	if err != nil {
+		t.Fatalf("darwin: %v", err)
+	}
+	for _, want := range []string{
+		"<string>com.gastown.town</string>",
+		"'/usr/local/bin/gt' start;",
+		"[ -f '" + data.ServicePath + "' ] &amp;&amp; '/usr/local/bin/gt' shutdown --graceful --yes",
+	} {
+		if !strings.Contains(string(plist), want) {
+			t.Errorf("launchd plist missing %q:\n%s", want, plist)
+		}
+	}
+	if got := parseTownServiceRoot(string(plist)); got != data.TownRoot {
+		t.Errorf("parseTownServiceRoot(launchd) = %q, want %q", got, data.TownRoot)
+	}
+
+	if _, err := RenderTownService("plan9", data); err == nil {
+		t.Error("expected an error for an unsupported OS")
+	}
+}
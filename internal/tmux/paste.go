@@ -0,0 +1,161 @@
+package tmux
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/steveyegge/gastown/internal/constants"
+)
+
+// ErrTextNotDelivered is returned by SendText when the target pane shows no
+// sign of the text arriving.
+var ErrTextNotDelivered = errors.New("text not delivered: pane content unchanged")
+
+const (
+	// pasteVerifyLines is how much of the pane is compared before and after
+	// delivery when checking that text arrived.
+	pasteVerifyLines = 40
+
+	// pasteVerifyTimeout bounds how long SendText waits for the pane to show
+	// delivered text.
+	pasteVerifyTimeout = 1500 * time.Millisecond
+
+	// echoFingerprintLen is how many trailing alphanumeric characters of the
+	// text must appear in the pane for an echo match.
+	echoFingerprintLen = 24
+)
+
+// needsPaste reports whether text should go through a paste buffer rather
+// than send-keys -l. With send-keys, every newline is an Enter keypress that
+// submits a partial prompt, and long argument lists get split or dropped
+// under load. A bracketed paste delivers the whole text as one input event.
+func needsPaste(text string) bool {
+	return len(text) > sendKeysChunkSize || strings.ContainsAny(text, "\n\r")
+}
+
+// pasteText loads text into a one-shot tmux buffer and pastes it into target.
+// -p wraps the paste in bracketed-paste sequences when the application has
+// enabled that mode (Claude Code and other TUIs do), so newlines and
+// characters that look like key bindings are taken literally. -d deletes
+// the buffer afterwards.
+//
+// Like single-line sends, the paste retries through the agent's startup
+// race for up to timeout; see sendKeysLiteralWithRetry.
+func (t *Tmux) pasteText(target, text string, timeout time.Duration) error {
+	buf := fmt.Sprintf("gt-paste-%d", time.Now().UnixNano())
+	if _, err := t.runWithInput(text, "load-buffer", "-b", buf, "-"); err != nil {
+		return err
+	}
+	err := retryUntilReady(timeout, func() error {
+		_, err := t.run("paste-buffer", "-p", "-d", "-b", buf, "-t", target)
+		return err
+	})
+	if err != nil {
+		_, _ = t.run("delete-buffer", "-b", buf)
+		return err
+	}
+	return nil
+}
+
+// SendText types text into target's input without submitting it. Multi-line
+// or long text is sent as a bracketed paste; short single-line text uses
+// send-keys -l. SendText then waits for the tail of the text to show up in
+// the pane (capture-pane echo check), so a following Enter cannot overtake
+// the text.
+//
+// A changed pane without a visible echo is accepted: TUIs may collapse large
+// pastes (e.g., "[Pasted text #1 +40 lines]") or scroll the input. If the
+// pane does not change at all, ErrTextNotDelivered is returned. The text is
+// not re-sent, since an application that has not drawn yet may still read
+// it from its tty.
+func (t *Tmux) SendText(target, text string) error {
+	before, _ := t.CapturePane(target, pasteVerifyLines)
+
+	if err := t.sendMessageToTarget(target, text); err != nil {
+		return err
+	}
+	if !t.waitForTextEcho(target, before, text) {
+		return fmt.Errorf("sending to %s: %w", target, ErrTextNotDelivered)
+	}
+	return nil
+}
+
+// waitForTextEcho polls target until the tail of text shows up in the pane.
+// If it never does within pasteVerifyTimeout, any change to the pane counts
+// as delivery; false means the pane did not change at all.
+func (t *Tmux) waitForTextEcho(target, before, text string) bool {
+	deadline := time.Now().Add(pasteVerifyTimeout)
+	for {
+		after, err := t.CapturePane(target, pasteVerifyLines)
+		if err != nil {
+			return true // Can't verify — don't risk a duplicate send.
+		}
+		if after != before && textEchoed(after, text) {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return after != before
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+
+// textEchoed reports whether pane content shows the tail of text. Only
+// letters and digits are compared, so line wrapping, prompt decorations,
+// and collapsed whitespace in the pane don't cause false negatives.
+func textEchoed(pane, text string) bool {
+	want := alnumOnly(text)
+	if len(want) > echoFingerprintLen {
+		want = want[len(want)-echoFingerprintLen:]
+	}
+	if want == "" {
+		return false
+	}
+	return strings.Contains(alnumOnly(pane), want) || strings.Contains(pane, "[Pasted text")
+}
+
+func alnumOnly(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// sendLiteralChunks sends text with send-keys -l in sendKeysChunkSize pieces.
+// The first chunk retries through the agent's startup race; see
+// sendKeysLiteralWithRetry.
+func (t *Tmux) sendLiteralChunks(target, text string) error {
+	for i := 0; i < len(text); {
+		end := i + sendKeysChunkSize
+		if end >= len(text) {
+			end = len(text)
+		} else {
+			// Don't split a multi-byte rune across chunks.
+			for end > i+1 && !utf8.RuneStart(text[end]) {
+				end--
+			}
+		}
+		chunk := text[i:end]
+		if i == 0 {
+			if err := t.sendKeysLiteralWithRetry(target, chunk, constants.NudgeReadyTimeout); err != nil {
+				return err
+			}
+		} else if _, err := t.run("send-keys", "-t", target, "-l", chunk); err != nil {
+			return err
+		}
+		// Small delay between chunks to let the terminal process
+		if end < len(text) {
+			time.Sleep(10 * time.Millisecond)
+		}
+		i = end
+	}
+	return nil
+}
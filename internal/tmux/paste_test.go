@@ -0,0 +1,107 @@
+package tmux
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNeedsPaste(t *testing.T) {
+	tests := []struct {
+		text string
+		want bool
+	}{
+		{"short prompt", false},
+		{"line one\nline two", true},
+		{"carriage\rreturn", true},
+		{strings.Repeat("x", sendKeysChunkSize), false},
+		{strings.Repeat("x", sendKeysChunkSize+1), true},
+	}
+	for _, tt := range tests {
+		if got := needsPaste(tt.text); got != tt.want {
+			t.Errorf("needsPaste(%.20q) = %v, want %v", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestTextEchoed(t *testing.T) {
+	text := "Work on gt-abc12: fix the flaky\nrefinery merge test and push."
+
+	// Wrapped and decorated, but the tail is intact.
+	pane := "> Work on gt-abc12: fix the flaky refinery merge te\n│ st and push. │"
+	if !textEchoed(pane, text) {
+		t.Error("expected wrapped echo to match")
+	}
+	if !textEchoed("> [Pasted text #1 +2 lines]", text) {
+		t.Error("expected collapsed paste to match")
+	}
+	if textEchoed("> Work on gt-abc12: fix the fl", text) {
+		t.Error("truncated echo should not match")
+	}
+	if textEchoed("anything", "  \n ") {
+		t.Error("text without letters or digits never matches")
+	}
+}
+
+func TestRetryUntilReady(t *testing.T) {
+	notReady := errors.New("exit status 1: not in a mode")
+
+	calls := 0
+	err := retryUntilReady(5*time.Second, func() error {
+		calls++
+		if calls < 2 {
+			return notReady
+		}
+		return nil
+	})
+	if err != nil || calls != 2 {
+		t.Errorf("transient error: err=%v calls=%d, want nil after 2 calls", err, calls)
+	}
+
+	calls = 0
+	gone := errors.New("can't find session")
+	err = retryUntilReady(5*time.Second, func() error {
+		calls++
+		return gone
+	})
+	if !errors.Is(err, gone) || calls != 1 {
+		t.Errorf("non-transient error: err=%v calls=%d, want immediate failure", err, calls)
+	}
+
+	err = retryUntilReady(10*time.Millisecond, func() error { return notReady })
+	if !isTransientSendKeysError(err) {
+		t.Errorf("timeout error %v should still read as not ready", err)
+	}
+}
+
+func TestSendText_MultilinePasteArrivesWhole(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-paste-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	if err := tm.NewSessionWithCommand(sessionName, t.TempDir(), "cat"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	time.Sleep(200 * time.Millisecond)
+
+	var lines []string
+	for i := 0; i < 12; i++ {
+		lines = append(lines, "dispatch line "+strings.Repeat("z", 60)+" END"+string(rune('A'+i)))
+	}
+	text := strings.Join(lines, "\n")
+	if err := tm.SendText(sessionName, text); err != nil {
+		t.Fatalf("SendText: %v", err)
+	}
+
+	out, err := tm.CapturePane(sessionName, 100)
+	if err != nil {
+		t.Fatalf("CapturePane: %v", err)
+	}
+	for i := 0; i < 11; i++ {
+		if marker := " END" + string(rune('A'+i)); !strings.Contains(out, marker) {
+			t.Errorf("pane missing %q:\n%s", marker, out)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package tmux
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPingTimeout bounds each tmux call made by Ping. A server that takes
+// longer than this to answer a display-message is treated as unresponsive.
+const DefaultPingTimeout = 3 * time.Second
+
+var (
+	// ErrPaneDead is returned by Ping when the session exists but its pane
+	// process has exited (e.g. a pane kept open by remain-on-exit).
+	ErrPaneDead = errors.New("pane process is not running")
+
+	// ErrPingTimeout is returned by Ping when tmux does not answer in time.
+	ErrPingTimeout = errors.New("tmux did not respond")
+)
+
+// Ping checks that session is alive, not merely present: the session exists,
+// its active pane's process is running, and the tmux server answers commands
+// promptly. Returns nil if so, or ErrSessionNotFound, ErrNoServer,
+// ErrPaneDead, or ErrPingTimeout.
+//
+// HasSession is true for a session whose agent has exited and left a dead
+// pane or a bare shell behind; Ping catches the former cheaply, without the
+// process-tree walk IsAgentAlive does. Pair it with IsAgentAlive to also
+// confirm the agent, rather than a shell, is what's running.
+func (t *Tmux) Ping(session string) error {
+	return t.PingWithTimeout(session, DefaultPingTimeout)
+}
+
+// PingWithTimeout is Ping with an explicit per-call timeout.
+func (t *Tmux) PingWithTimeout(session string, timeout time.Duration) error {
+	target := "=" + session + ":"
+	if runtime.GOOS == "windows" {
+		target = session
+	}
+
+	out, err := t.runWithTimeout(timeout, "display-message", "-t", target, "-p", "#{pane_dead}\t#{pane_pid}")
+	if err != nil {
+		return err
+	}
+	dead, pid, err := parsePingFields(out)
+	if err != nil {
+		return err
+	}
+	if dead {
+		return ErrPaneDead
+	}
+
+	// tmux only notices a dead pane once the process has been reaped; a
+	// zombie or vanished pane process still reads as alive above. Confirm
+	// with ps where the pane runs on this host.
+	if t.remoteHost == "" && runtime.GOOS != "windows" {
+		if !processRunning(pid, timeout) {
+			return ErrPaneDead
+		}
+	}
+	return nil
+}
+
+// processRunning reports whether pid exists and is not a zombie.
+func processRunning(pid int, timeout time.Duration) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, "ps", "-o", "stat=", "-p", strconv.Itoa(pid)).Output()
+	if err != nil {
+		// ps exits non-zero when the pid does not exist. If ps itself timed
+		// out, give the pane the benefit of the doubt.
+		return ctx.Err() != nil
+	}
+	stat := strings.TrimSpace(string(out))
+	return stat != "" && !strings.HasPrefix(stat, "Z")
+}
+
+// parsePingFields parses Ping's display-message output: pane_dead and
+// pane_pid separated by a tab.
+func parsePingFields(out string) (dead bool, pid int, err error) {
+	deadStr, pidStr, ok := strings.Cut(strings.TrimSpace(out), "\t")
+	if deadStr == "1" {
+		// A dead pane may have no pid, leaving just "1" once trimmed.
+		return true, 0, nil
+	}
+	if !ok {
+		return false, 0, fmt.Errorf("unexpected ping output %q", out)
+	}
+	if _, err := fmt.Sscanf(pidStr, "%d", &pid); err != nil || pid <= 0 {
+		return false, 0, fmt.Errorf("unexpected pane pid %q", pidStr)
+	}
+	return false, pid, nil
+}
+
+// runWithTimeout is like run but gives up with ErrPingTimeout if tmux has
+// not exited within timeout, killing the client.
+func (t *Tmux) runWithTimeout(timeout time.Duration, args ...string) (string, error) {
+	allArgs := []string{"-u"}
+	if t.socketName != "" {
+		allArgs = append(allArgs, "-L", t.socketName)
+	}
+	allArgs = append(allArgs, args...)
+	cmd := t.command(allArgs)
+	hideConsoleWindow(cmd)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Start(); err != nil {
+		return "", t.wrapError(err, "", args)
+	}
+	done := make(chan error, 1)
+	go func() { done <- cmd.Wait() }()
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-done:
+		if err != nil {
+			return "", t.wrapError(err, stderr.String(), args)
+		}
+		return strings.TrimSpace(stdout.String()), nil
+	case <-timer.C:
+		_ = cmd.Process.Kill()
+		<-done
+		return "", fmt.Errorf("%w: %s after %s", ErrPingTimeout, args[0], timeout)
+	}
+}
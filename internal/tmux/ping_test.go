@@ -0,0 +1,69 @@
+package tmux
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParsePingFields(t *testing.T) {
+	tests := []struct {
+		out     string
+		dead    bool
+		pid     int
+		wantErr bool
+	}{
+		{"0\t1234", false, 1234, false},
+		{"1\t", true, 0, false},
+		{"1", true, 0, false},
+		{"1\t1234", true, 0, false},
+		{"0\t", false, 0, true},
+		{"garbage", false, 0, true},
+	}
+	for _, tt := range tests {
+		dead, pid, err := parsePingFields(tt.out)
+		if dead != tt.dead || pid != tt.pid || (err != nil) != tt.wantErr {
+			t.Errorf("parsePingFields(%q) = %v, %d, %v; want %v, %d, err=%v",
+				tt.out, dead, pid, err, tt.dead, tt.pid, tt.wantErr)
+		}
+	}
+}
+
+func TestPing(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-ping-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	if err := tm.Ping(sessionName); !errors.Is(err, ErrSessionNotFound) && !errors.Is(err, ErrNoServer) {
+		t.Errorf("Ping(missing) = %v, want ErrSessionNotFound", err)
+	}
+
+	if err := tm.NewSessionWithCommand(sessionName, t.TempDir(), "sleep 1; exit 0"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+	if err := tm.SetRemainOnExit(sessionName, true); err != nil {
+		t.Fatalf("SetRemainOnExit: %v", err)
+	}
+
+	if err := tm.Ping(sessionName); err != nil {
+		t.Errorf("Ping(running) = %v, want nil", err)
+	}
+
+	// Once the command exits the pane stays open but dead. HasSession still
+	// reports the session; Ping must not.
+	deadline := time.Now().Add(5 * time.Second)
+	var err error
+	for time.Now().Before(deadline) {
+		if err = tm.Ping(sessionName); err != nil {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if !errors.Is(err, ErrPaneDead) {
+		t.Errorf("Ping(dead pane) = %v, want ErrPaneDead", err)
+	}
+	if ok, _ := tm.HasSession(sessionName); !ok {
+		t.Error("HasSession should still report the dead-pane session")
+	}
+}
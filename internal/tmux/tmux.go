@@ -46,6 +46,7 @@ var (
 	ErrSessionRunning     = errors.New("session already running with healthy agent")
 	ErrInvalidSessionName = errors.New("invalid session name")
 	ErrIdleTimeout        = errors.New("agent not idle before timeout")
+	ErrPromptTimeout      = errors.New("agent not ready for input before timeout")
 )
 
 // validateSessionName checks that a session name contains only safe characters.
@@ -182,6 +183,7 @@ func BuildCommandContext(ctx context.Context, args ...string) *exec.Cmd {
 // Tmux wraps tmux operations.
 type Tmux struct {
 	socketName string // tmux socket name (-L flag), empty = default socket
+	remoteHost string // ssh destination for remote rigs, empty = local tmux
 }
 
 // noTownSocket is a sentinel socket name used when no town socket is configured.
@@ -217,10 +219,67 @@ func NewTmuxWithSocket(socket string) *Tmux {
 	return &Tmux{socketName: socket}
 }
 
+// OnHost returns a Tmux wrapper that runs tmux on a remote host over SSH,
+// using the same socket name. The host is an ssh destination (user@host or an
+// ssh_config alias) with tmux and gt installed and the town at the same path.
+// An empty host returns t unchanged.
+func (t *Tmux) OnHost(host string) *Tmux {
+	if host == "" {
+		return t
+	}
+	return &Tmux{socketName: t.socketName, remoteHost: host}
+}
+
+// RemoteHost returns the ssh destination for a remote wrapper, or "" if local.
+func (t *Tmux) RemoteHost() string {
+	return t.remoteHost
+}
+
+// sshOptions keep remote tmux calls non-interactive and reuse one SSH
+// connection per host, since every tmux operation is a separate invocation.
+var sshOptions = []string{
+	"-o", "BatchMode=yes",
+	"-o", "ConnectTimeout=10",
+	"-o", "ControlMaster=auto",
+	"-o", "ControlPath=~/.ssh/gt-%r@%h:%p",
+	"-o", "ControlPersist=60s",
+}
+
+// command builds the exec.Cmd for a tmux invocation. Remote wrappers run
+// tmux through ssh, quoting each argument for the remote shell.
+func (t *Tmux) command(args []string) *exec.Cmd {
+	if t.remoteHost == "" {
+		return exec.Command("tmux", args...)
+	}
+	return exec.Command("ssh", remoteTmuxArgs(t.remoteHost, args, false)...)
+}
+
+// remoteTmuxArgs builds ssh arguments that run tmux with args on host.
+// ssh joins its trailing arguments into a single remote shell command line,
+// so every tmux argument is single-quoted.
+func remoteTmuxArgs(host string, args []string, tty bool) []string {
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, "tmux")
+	for _, a := range args {
+		quoted = append(quoted, "'"+strings.ReplaceAll(a, "'", `'\''`)+"'")
+	}
+	sshArgs := append([]string(nil), sshOptions...)
+	if tty {
+		sshArgs = append(sshArgs, "-t")
+	}
+	return append(sshArgs, host, "--", strings.Join(quoted, " "))
+}
+
 // run executes a tmux command and returns stdout.
 // All commands include -u flag for UTF-8 support regardless of locale settings.
 // See: https://github.com/steveyegge/gastown/issues/1219
 func (t *Tmux) run(args ...string) (string, error) {
+	return t.runWithInput("", args...)
+}
+
+// runWithInput is like run but feeds input to tmux's stdin, for commands
+// that read from "-" such as load-buffer.
+func (t *Tmux) runWithInput(input string, args ...string) (string, error) {
 	// Prepend global flags: -u (UTF-8 mode, PATCH-004) and optionally -L (socket).
 	// The -L flag must come before the subcommand, so it goes in the prefix.
 	allArgs := []string{"-u"}
@@ -228,11 +287,14 @@ func (t *Tmux) run(args ...string) (string, error) {
 		allArgs = append(allArgs, "-L", t.socketName)
 	}
 	allArgs = append(allArgs, args...)
-	cmd := exec.Command("tmux", allArgs...)
+	cmd := t.command(allArgs)
 	hideConsoleWindow(cmd)
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
+	if input != "" {
+		cmd.Stdin = strings.NewReader(input)
+	}
 
 	err := cmd.Run()
 	if err != nil {
@@ -286,6 +348,25 @@ func (t *Tmux) NewSession(name, workDir string) error {
 	return nil
 }
 
+// validateLaunch checks that a session's work directory and command binary
+// exist. Skipped for remote hosts, whose filesystem is not visible locally;
+// there, tmux reports a bad directory or binary when the session starts.
+func (t *Tmux) validateLaunch(workDir, command string) error {
+	if t.remoteHost != "" {
+		return nil
+	}
+	if workDir != "" {
+		info, err := os.Stat(workDir)
+		if err != nil {
+			return fmt.Errorf("invalid work directory %q: %w", workDir, err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("work directory %q is not a directory", workDir)
+		}
+	}
+	return validateCommandBinary(command)
+}
+
 // NewSessionWithCommand creates a new detached tmux session that immediately runs a command.
 // Unlike NewSession + SendKeys, this avoids race conditions where the shell isn't ready
 // or the command arrives before the shell prompt. The command runs directly as the
@@ -299,16 +380,7 @@ func (t *Tmux) NewSessionWithCommand(name, workDir, command string) error {
 	if err := validateSessionName(name); err != nil {
 		return err
 	}
-	if workDir != "" {
-		info, err := os.Stat(workDir)
-		if err != nil {
-			return fmt.Errorf("invalid work directory %q: %w", workDir, err)
-		}
-		if !info.IsDir() {
-			return fmt.Errorf("work directory %q is not a directory", workDir)
-		}
-	}
-	if err := validateCommandBinary(command); err != nil {
+	if err := t.validateLaunch(workDir, command); err != nil {
 		return err
 	}
 
@@ -369,8 +441,11 @@ func (t *Tmux) NewSessionWithCommand(name, workDir, command string) error {
 //
 // The command should still use 'exec env' for WaitForCommand detection compatibility,
 // but -e provides defense-in-depth for the initial shell environment.
-// Requires tmux >= 3.2.
+// Requires tmux >= 3.2. With an empty env it is NewSessionWithCommand.
 func (t *Tmux) NewSessionWithCommandAndEnv(name, workDir, command string, env map[string]string) error {
+	if len(env) == 0 {
+		return t.NewSessionWithCommand(name, workDir, command)
+	}
 	if err := validateSessionName(name); err != nil {
 		return err
 	}
@@ -379,16 +454,7 @@ func (t *Tmux) NewSessionWithCommandAndEnv(name, workDir, command string, env ma
 	// This is best-effort: failures are silently ignored.
 	t.killSplitBrainSession(name)
 
-	if workDir != "" {
-		info, err := os.Stat(workDir)
-		if err != nil {
-			return fmt.Errorf("invalid work directory %q: %w", workDir, err)
-		}
-		if !info.IsDir() {
-			return fmt.Errorf("work directory %q is not a directory", workDir)
-		}
-	}
-	if err := validateCommandBinary(command); err != nil {
+	if err := t.validateLaunch(workDir, command); err != nil {
 		return err
 	}
 
@@ -399,15 +465,7 @@ func (t *Tmux) NewSessionWithCommandAndEnv(name, workDir, command string, env ma
 		args = append(args, "-c", workDir)
 	}
 	// Add -e flags to set environment variables in the session before the shell starts.
-	// Keys are sorted for deterministic behavior.
-	keys := make([]string, 0, len(env))
-	for k := range env {
-		keys = append(keys, k)
-	}
-	sort.Strings(keys)
-	for _, k := range keys {
-		args = append(args, "-e", fmt.Sprintf("%s=%s", k, env[k]))
-	}
+	args = appendEnvArgs(args, env)
 	if _, err := t.run(args...); err != nil {
 		return err
 	}
@@ -537,6 +595,12 @@ func (t *Tmux) EnsureSessionFresh(name, workDir string) error {
 //
 // If an existing session has a healthy agent, returns ErrSessionRunning.
 func (t *Tmux) EnsureSessionFreshWithCommand(name, workDir, command string) error {
+	return t.EnsureSessionFreshWithCommandAndEnv(name, workDir, command, nil)
+}
+
+// EnsureSessionFreshWithCommandAndEnv is EnsureSessionFreshWithCommand with
+// env set in the new session via -e (see NewSessionWithCommandAndEnv).
+func (t *Tmux) EnsureSessionFreshWithCommandAndEnv(name, workDir, command string, env map[string]string) error {
 	if err := validateSessionName(name); err != nil {
 		return err
 	}
@@ -558,7 +622,7 @@ func (t *Tmux) EnsureSessionFreshWithCommand(name, workDir, command string) erro
 	}
 
 	// Create session with command as the initial process
-	return t.NewSessionWithCommand(name, workDir, command)
+	return t.NewSessionWithCommandAndEnv(name, workDir, command, env)
 }
 
 // KillSession terminates a tmux session. Idempotent: returns nil if the
@@ -601,6 +665,12 @@ func (t *Tmux) KillSessionWithProcesses(name string) error {
 	_ = t.SetRemainOnExit(name, false)
 	_, _ = t.run("set-hook", "-t", name, "-u", "pane-died")
 
+	// Pane PIDs belong to the remote host; never signal local processes.
+	// kill-session hangs up the remote pane's process group instead.
+	if t.remoteHost != "" {
+		return t.killRemoteSession(name)
+	}
+
 	// Get the pane PID
 	pid, err := t.GetPanePID(name)
 	if err != nil {
@@ -672,6 +742,9 @@ func (t *Tmux) KillSessionWithProcessesExcluding(name string, excludePIDs []stri
 	// Disarm auto-respawn BEFORE killing anything (same as KillSessionWithProcesses).
 	_ = t.SetRemainOnExit(name, false)
 	_, _ = t.run("set-hook", "-t", name, "-u", "pane-died")
+	if t.remoteHost != "" {
+		return t.killRemoteSession(name)
+	}
 
 	// Build exclusion set for O(1) lookup
 	exclude := make(map[string]bool)
@@ -769,7 +842,7 @@ func (t *Tmux) KillSessionWithProcessesExcluding(name string, excludePIDs []stri
 // the default server may not be running, etc. — none of these should block
 // session creation on the correct socket.
 func (t *Tmux) killSplitBrainSession(name string) {
-	if t.socketName == "" || t.socketName == "default" || t.socketName == noTownSocket {
+	if t.remoteHost != "" || t.socketName == "" || t.socketName == "default" || t.socketName == noTownSocket {
 		return // Already on default or no town context — nothing to clean up
 	}
 	other := NewTmuxWithSocket("default")
@@ -778,6 +851,16 @@ func (t *Tmux) killSplitBrainSession(name string) {
 	}
 }
 
+// killRemoteSession kills a session on a remote host, treating an already
+// missing session or server as success.
+func (t *Tmux) killRemoteSession(name string) error {
+	err := t.KillSession(name)
+	if err == ErrSessionNotFound || err == ErrNoServer {
+		return nil
+	}
+	return err
+}
+
 // collectReparentedGroupMembers returns process group members that have been
 // reparented to init (PPID == 1) but are not in the known descendant set.
 // These are processes that were likely children in our tree but outlived their
@@ -839,6 +922,10 @@ func getAllDescendants(pid string) []string {
 // This ensures Claude processes and all their children are properly terminated
 // before respawning the pane.
 func (t *Tmux) KillPaneProcesses(pane string) error {
+	if t.remoteHost != "" {
+		return nil // respawn-pane -k hangs up the remote pane's processes
+	}
+
 	// Get the pane PID
 	pid, err := t.GetPanePID(pane)
 	if err != nil {
@@ -901,6 +988,10 @@ func (t *Tmux) KillPaneProcesses(pane string) error {
 // survive. After this function returns, RespawnPane's -k flag will send SIGHUP to
 // clean up the remaining processes.
 func (t *Tmux) KillPaneProcessesExcluding(pane string, excludePIDs []string) error {
+	if t.remoteHost != "" {
+		return nil // respawn-pane -k hangs up the remote pane's processes
+	}
+
 	// Build exclusion set for O(1) lookup
 	exclude := make(map[string]bool)
 	for _, pid := range excludePIDs {
@@ -990,7 +1081,7 @@ func (t *Tmux) SetExitEmpty(on bool) error {
 
 // IsAvailable checks if tmux is installed and can be invoked.
 func (t *Tmux) IsAvailable() bool {
-	cmd := exec.Command("tmux", "-V")
+	cmd := t.command([]string{"-V"})
 	hideConsoleWindow(cmd)
 	return cmd.Run() == nil
 }
@@ -1051,6 +1142,168 @@ func (t *Tmux) ListSessions() ([]string, error) {
 	return sessions, nil
 }
 
+// SessionDetail is a snapshot of one session's runtime state. Pane fields
+// describe the session's agent pane: the pane recorded in GT_PANE_ID, or
+// else the first pane of the first window, where the agent is started. The
+// active pane may be a layout pane or one the operator switched to.
+type SessionDetail struct {
+	Name     string
+	Clients  int // number of attached clients
+	Windows  int
+	Created  time.Time
+	Activity time.Time
+	PanePID  int
+	WorkDir  string
+}
+
+// sessionDetailFormat is the list-sessions format parsed by parseSessionDetails.
+const sessionDetailFormat = "#{session_name}\t#{session_attached}\t#{session_windows}\t#{session_created}\t#{session_activity}"
+
+// paneDetailFormat is the list-panes format parsed by parsePaneDetails.
+// Tab-separated because pane_current_path may contain "|".
+const paneDetailFormat = "#{session_name}\t#{pane_id}\t#{window_index}\t#{pane_index}\t#{pane_pid}\t#{pane_current_path}"
+
+// paneDetail is one pane row from list-panes -a.
+type paneDetail struct {
+	ID      string
+	Window  int
+	Index   int
+	PID     int
+	WorkDir string
+}
+
+// ListSessionDetails returns runtime details for every session on the server.
+// Sessions and panes are each listed in one call; GT_PANE_ID is only read for
+// sessions with more than one pane, where the first pane might not be the
+// agent's.
+func (t *Tmux) ListSessionDetails() ([]SessionDetail, error) {
+	out, err := t.run("list-sessions", "-F", sessionDetailFormat)
+	if err != nil {
+		if errors.Is(err, ErrNoServer) {
+			return nil, nil // No server = no sessions
+		}
+		return nil, err
+	}
+	details := parseSessionDetails(out)
+
+	paneOut, err := t.run("list-panes", "-a", "-F", paneDetailFormat)
+	if err != nil {
+		return details, nil // Session fields are still useful without panes.
+	}
+	panes := parsePaneDetails(paneOut)
+	for i := range details {
+		sessionPanes := panes[details[i].Name]
+		recorded := ""
+		if len(sessionPanes) > 1 {
+			recorded, _ = t.GetEnvironment(details[i].Name, "GT_PANE_ID")
+		}
+		if p, ok := agentPaneDetail(sessionPanes, recorded); ok {
+			details[i].PanePID = p.PID
+			details[i].WorkDir = p.WorkDir
+		}
+	}
+	return details, nil
+}
+
+// parseSessionDetails parses list-sessions output in sessionDetailFormat.
+// Malformed lines are skipped.
+func parseSessionDetails(out string) []SessionDetail {
+	var details []SessionDetail
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parts := strings.Split(line, "\t")
+		if len(parts) < 5 {
+			continue
+		}
+		d := SessionDetail{Name: parts[0]}
+		d.Clients, _ = strconv.Atoi(parts[1])
+		d.Windows, _ = strconv.Atoi(parts[2])
+		if ts, err := strconv.ParseInt(parts[3], 10, 64); err == nil && ts > 0 {
+			d.Created = time.Unix(ts, 0)
+		}
+		if ts, err := strconv.ParseInt(parts[4], 10, 64); err == nil && ts > 0 {
+			d.Activity = time.Unix(ts, 0)
+		}
+		details = append(details, d)
+	}
+	return details
+}
+
+// parsePaneDetails parses list-panes -a output in paneDetailFormat into
+// panes grouped by session. Malformed lines are skipped.
+func parsePaneDetails(out string) map[string][]paneDetail {
+	panes := make(map[string][]paneDetail)
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		parts := strings.SplitN(line, "\t", 6)
+		if len(parts) < 6 {
+			continue
+		}
+		p := paneDetail{ID: parts[1], WorkDir: parts[5]}
+		p.Window, _ = strconv.Atoi(parts[2])
+		p.Index, _ = strconv.Atoi(parts[3])
+		p.PID, _ = strconv.Atoi(parts[4])
+		panes[parts[0]] = append(panes[parts[0]], p)
+	}
+	return panes
+}
+
+// agentPaneDetail picks the agent pane from a session's panes: the recorded
+// pane ID if it is present, otherwise the lowest pane of the lowest window.
+func agentPaneDetail(panes []paneDetail, recordedID string) (paneDetail, bool) {
+	if len(panes) == 0 {
+		return paneDetail{}, false
+	}
+	first := panes[0]
+	for _, p := range panes {
+		if recordedID != "" && p.ID == recordedID {
+			return p, true
+		}
+		if p.Window < first.Window || (p.Window == first.Window && p.Index < first.Index) {
+			first = p
+		}
+	}
+	return first, true
+}
+
+// DeadPane is a pane whose process has exited but which was kept open by
+// remain-on-exit, e.g. an agent that crashed without being respawned.
+type DeadPane struct {
+	Session    string
+	PaneID     string
+	ExitStatus int
+}
+
+// ListDeadPanes returns every dead pane on the server.
+func (t *Tmux) ListDeadPanes() ([]DeadPane, error) {
+	out, err := t.run("list-panes", "-a", "-F", "#{session_name}\t#{pane_id}\t#{pane_dead}\t#{pane_dead_status}")
+	if err != nil {
+		if errors.Is(err, ErrNoServer) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return parseDeadPanes(out), nil
+}
+
+// parseDeadPanes parses ListDeadPanes output, keeping only dead panes.
+func parseDeadPanes(out string) []DeadPane {
+	var panes []DeadPane
+	for _, line := range strings.Split(out, "\n") {
+		parts := strings.SplitN(strings.TrimSpace(line), "\t", 4)
+		if len(parts) < 4 || parts[2] != "1" {
+			continue
+		}
+		status, _ := strconv.Atoi(parts[3])
+		panes = append(panes, DeadPane{Session: parts[0], PaneID: parts[1], ExitStatus: status})
+	}
+	return panes
+}
+
 // SessionSet provides O(1) session existence checks by caching session names.
 // Use this when you need to check multiple sessions to avoid N+1 subprocess calls.
 type SessionSet struct {
@@ -1177,8 +1430,11 @@ func (t *Tmux) SendKeys(session, keys string) error {
 // This prevents race conditions where Enter arrives before paste is processed.
 func (t *Tmux) SendKeysDebounced(session, keys string, debounceMs int) (retErr error) {
 	defer func() { telemetry.RecordPromptSend(context.Background(), session, keys, debounceMs, retErr) }()
-	// Send text using literal mode (-l) to handle special chars
-	if _, err := t.run("send-keys", "-t", session, "-l", keys); err != nil {
+	// Send text as literal keys or a bracketed paste, verified against the
+	// pane, so long or multi-line prompts arrive whole. See SendText.
+	// An unchanged pane usually means the app hasn't drawn yet; the text is
+	// queued on its tty, so Enter is still sent.
+	if err := t.SendText(session, keys); err != nil && !errors.Is(err, ErrTextNotDelivered) {
 		return err
 	}
 	// Wait for paste to be processed
@@ -1366,7 +1622,7 @@ func sanitizeNudgeMessage(msg string) string {
 		switch {
 		case r == '\t': // TAB → space (avoid triggering completion)
 			b.WriteRune(' ')
-		case r == '\n': // preserve newlines (send-keys -l treats as Enter, known limitation)
+		case r == '\n': // preserve newlines (delivered inside a bracketed paste)
 			b.WriteRune(r)
 		case r < 0x20: // strip all other control chars (ESC, CR, BS, etc.)
 			continue
@@ -1509,9 +1765,11 @@ func adaptiveTextDelay(messageLen int) time.Duration {
 	return delay
 }
 
-// sendMessageToTarget sends a sanitized message to a tmux target. For small
-// messages (< sendKeysChunkSize), uses send-keys -l. For larger messages,
-// sends in chunks with delays to avoid overwhelming the TTY input buffer.
+// sendMessageToTarget sends a sanitized message to a tmux target. Short
+// single-line messages use send-keys -l. Multi-line or long messages are
+// delivered as a single bracketed paste (see pasteText), falling back to
+// send-keys -l in sendKeysChunkSize chunks if the paste fails (e.g., a tmux
+// port without load-buffer).
 //
 // NOTE: The Linux TTY canonical mode buffer is 4096 bytes. Messages longer
 // than ~4000 bytes may be truncated by the kernel's line discipline when
@@ -1521,33 +1779,17 @@ func adaptiveTextDelay(messageLen int) time.Duration {
 const sendKeysChunkSize = 512
 
 func (t *Tmux) sendMessageToTarget(target, text string) error {
-	if len(text) <= sendKeysChunkSize {
+	if !needsPaste(text) {
 		return t.sendKeysLiteralWithRetry(target, text, constants.NudgeReadyTimeout)
 	}
-	// Send in chunks to avoid tmux send-keys argument length limits.
-	// Each chunk is sent with a small delay to let the terminal process it.
-	for i := 0; i < len(text); i += sendKeysChunkSize {
-		end := i + sendKeysChunkSize
-		if end > len(text) {
-			end = len(text)
-		}
-		chunk := text[i:end]
-		if i == 0 {
-			// First chunk uses retry logic for startup race
-			if err := t.sendKeysLiteralWithRetry(target, chunk, constants.NudgeReadyTimeout); err != nil {
-				return err
-			}
-		} else {
-			if _, err := t.run("send-keys", "-t", target, "-l", chunk); err != nil {
-				return err
-			}
-		}
-		// Small delay between chunks to let the terminal process
-		if end < len(text) {
-			time.Sleep(10 * time.Millisecond)
-		}
+	if err := t.pasteText(target, text, constants.NudgeReadyTimeout); err == nil {
+		return nil
+	} else if errors.Is(err, ErrNoServer) || errors.Is(err, ErrSessionNotFound) || isTransientSendKeysError(err) {
+		// Gone, or still not ready after the full wait: chunked send-keys
+		// would fail the same way.
+		return err
 	}
-	return nil
+	return t.sendLiteralChunks(target, text)
 }
 
 // sendKeysLiteralWithRetry sends literal text to a tmux target, retrying on
@@ -1564,12 +1806,22 @@ func (t *Tmux) sendMessageToTarget(target, text string) error {
 // This function ONLY addresses the startup race where the agent TUI hasn't
 // initialized yet, causing tmux send-keys to fail with "not in a mode".
 func (t *Tmux) sendKeysLiteralWithRetry(target, text string, timeout time.Duration) error {
+	return retryUntilReady(timeout, func() error {
+		_, err := t.run("send-keys", "-t", target, "-l", text)
+		return err
+	})
+}
+
+// retryUntilReady calls send until it succeeds, fails with a non-transient
+// error, or timeout elapses. Transient errors (see isTransientSendKeysError)
+// are retried with backoff: the agent's TUI is still starting up.
+func retryUntilReady(timeout time.Duration, send func() error) error {
 	deadline := time.Now().Add(timeout)
 	interval := constants.NudgeRetryInterval
 	var lastErr error
 
 	for time.Now().Before(deadline) {
-		_, err := t.run("send-keys", "-t", target, "-l", text)
+		err := send()
 		if err == nil {
 			return nil
 		}
@@ -2333,9 +2585,102 @@ func (t *Tmux) CapturePaneLines(session string, lines int) ([]string, error) {
 	return strings.Split(out, "\n"), nil
 }
 
+// appendEnvArgs adds a -e KEY=VALUE flag for each env var, in key order so
+// the command line is deterministic.
+func appendEnvArgs(args []string, env map[string]string) []string {
+	keys := make([]string, 0, len(env))
+	for k := range env {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		args = append(args, "-e", fmt.Sprintf("%s=%s", k, env[k]))
+	}
+	return args
+}
+
+// NewWindow creates a detached window in session and returns its pane ID.
+// An empty command starts the default shell; env is set for it in addition to
+// the session's environment. The current window keeps focus, so
+// session-targeted operations continue to address the agent pane.
+func (t *Tmux) NewWindow(session, name, workDir, command string, env map[string]string) (string, error) {
+	args := []string{"new-window", "-d", "-P", "-F", "#{pane_id}", "-t", session + ":"}
+	if name != "" {
+		args = append(args, "-n", name)
+	}
+	if workDir != "" {
+		args = append(args, "-c", workDir)
+	}
+	args = appendEnvArgs(args, env)
+	if command != "" {
+		args = append(args, command)
+	}
+	return t.run(args...)
+}
+
+// SplitWindow splits the target pane and returns the new pane's ID.
+// The split is detached so focus stays on the target. horizontal places the
+// new pane beside the target; otherwise it goes below. percent <= 0 uses
+// tmux's default size. env is set for the new pane's command.
+func (t *Tmux) SplitWindow(target, workDir, command string, horizontal bool, percent int, env map[string]string) (string, error) {
+	args := []string{"split-window", "-d", "-P", "-F", "#{pane_id}", "-t", target}
+	if horizontal {
+		args = append(args, "-h")
+	} else {
+		args = append(args, "-v")
+	}
+	if percent > 0 && percent < 100 {
+		args = append(args, "-l", fmt.Sprintf("%d%%", percent))
+	}
+	if workDir != "" {
+		args = append(args, "-c", workDir)
+	}
+	args = appendEnvArgs(args, env)
+	if command != "" {
+		args = append(args, command)
+	}
+	return t.run(args...)
+}
+
+// SetPaneRemainOnExit sets remain-on-exit for a single pane, overriding the
+// session-level setting. Auxiliary panes use this to close normally on exit
+// instead of firing the agent's pane-died respawn hook.
+func (t *Tmux) SetPaneRemainOnExit(pane string, on bool) error {
+	value := "on"
+	if !on {
+		value = "off"
+	}
+	_, err := t.run("set-option", "-p", "-t", pane, "remain-on-exit", value)
+	return err
+}
+
+// PipePane pipes all output from a pane to a shell command (tmux pipe-pane).
+// Any existing pipe on the pane is closed first, so calling this repeatedly
+// (e.g., on respawn) never stacks duplicate writers.
+func (t *Tmux) PipePane(target, command string) error {
+	_, err := t.run("pipe-pane", "-t", target, command)
+	return err
+}
+
+// StopPipePane closes the output pipe on a pane, if one is open.
+func (t *Tmux) StopPipePane(target string) error {
+	_, err := t.run("pipe-pane", "-t", target)
+	return err
+}
+
 // AttachSession attaches to an existing session.
 // Note: This replaces the current process with tmux attach.
 func (t *Tmux) AttachSession(session string) error {
+	if t.remoteHost != "" {
+		args := []string{"-u"}
+		if t.socketName != "" {
+			args = append(args, "-L", t.socketName)
+		}
+		args = append(args, "attach-session", "-t", session)
+		cmd := exec.Command("ssh", remoteTmuxArgs(t.remoteHost, args, true)...)
+		cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+		return cmd.Run()
+	}
 	_, err := t.run("attach-session", "-t", session)
 	return err
 }
@@ -2402,6 +2747,19 @@ func (t *Tmux) SetEnvironment(session, key, value string) error {
 	return err
 }
 
+// SetSessionOption sets a session option. User options (names starting with
+// "@") can hold arbitrary values and are readable in formats as #{@name}.
+func (t *Tmux) SetSessionOption(session, name, value string) error {
+	_, err := t.run("set-option", "-t", session, name, value)
+	return err
+}
+
+// UnsetSessionOption removes a session option.
+func (t *Tmux) UnsetSessionOption(session, name string) error {
+	_, err := t.run("set-option", "-t", session, "-u", name)
+	return err
+}
+
 // GetEnvironment gets an environment variable from the session.
 func (t *Tmux) GetEnvironment(session, key string) (string, error) {
 	out, err := t.run("show-environment", "-t", session, key)
@@ -2974,6 +3332,185 @@ func (t *Tmux) IsIdle(session string) bool {
 	return false
 }
 
+// DefaultIdleQuietPeriod is how long a pane must go without output before
+// Idle trusts a visible prompt. Claude Code redraws its spinner and status bar
+// continuously while working, so a quiet pane plus a prompt means the agent is
+// genuinely waiting for input rather than between tool calls.
+const DefaultIdleQuietPeriod = 3 * time.Second
+
+// IdleState is a point-in-time assessment of whether an agent is waiting for input.
+type IdleState struct {
+	// Idle is the combined verdict: prompt visible, no busy indicator, and
+	// the pane has been quiet for at least the requested period.
+	Idle bool
+
+	// AtPrompt is true if the agent's ready prompt is visible in the pane.
+	AtPrompt bool
+
+	// Busy is true if a busy indicator ("esc to interrupt") is visible.
+	Busy bool
+
+	// LastActivity is the last time the pane produced output (zero if unknown).
+	LastActivity time.Time
+
+	// QuietFor is how long the pane has been without output (zero if unknown).
+	QuietFor time.Duration
+}
+
+// Idle reports whether the agent in session is waiting for input, combining
+// the pane's last-activity timestamp with a capture-pane prompt heuristic.
+// Uses DefaultIdleQuietPeriod; see IdleWithQuiet.
+//
+// Callers that need to avoid interrupting work (graceful shutdown, liveness
+// checks, dispatch throttling) should prefer this over IsIdle, which looks
+// only at the pane contents and can catch the prompt between tool calls.
+func (t *Tmux) Idle(session string) (*IdleState, error) {
+	return t.IdleWithQuiet(session, DefaultIdleQuietPeriod)
+}
+
+// IdleWithQuiet is Idle with an explicit quiet period. A zero quiet period
+// disables the activity check and relies on the prompt heuristic alone.
+func (t *Tmux) IdleWithQuiet(session string, quiet time.Duration) (*IdleState, error) {
+	lines, err := t.CapturePaneLines(session, 5)
+	if err != nil {
+		return nil, err
+	}
+
+	promptPrefix := readyPromptPrefixForSession(t, session)
+	return classifyIdle(lines, promptPrefix, t.windowActivity(session), time.Now(), quiet), nil
+}
+
+// windowActivity returns the last time session's window produced output, or
+// the zero time if unknown. Best-effort: older tmux or psmux may not expose it.
+func (t *Tmux) windowActivity(session string) time.Time {
+	out, err := t.run("display-message", "-t", session, "-p", "#{window_activity}")
+	if err != nil {
+		return time.Time{}
+	}
+	ts, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil || ts <= 0 {
+		return time.Time{}
+	}
+	return time.Unix(ts, 0)
+}
+
+// promptQuietPeriod is how long the pane must be without output before
+// WaitForPrompt trusts a visible prompt. Claude Code draws its prompt before
+// SessionStart hooks finish, and shows it between tool calls; in both cases
+// the pane keeps changing, so a prompt on a quiet pane means input will be read.
+const promptQuietPeriod = 2 * time.Second
+
+// minReadyFallback is the shortest wait for agents without prompt detection,
+// matching the fixed delay used before WaitForPrompt existed.
+const minReadyFallback = time.Second
+
+// WaitForPrompt blocks until the agent in session is ready for its next
+// instruction: it has finished starting up, or finished its current turn.
+// Use it before delivering a prompt in place of a fixed sleep.
+//
+// For agents with prompt detection (a ReadyPromptPrefix in their preset),
+// ready means the prompt is visible, no busy indicator is shown, and the pane
+// has been quiet for promptQuietPeriod, on two consecutive polls.
+//
+// Agents without prompt detection wait for a quiet pane, bounded by their
+// ready_delay_ms (at least minReadyFallback) rather than timeout, and are
+// treated as ready once that bound passes. Where the pane reports no
+// activity (e.g., psmux), the bound is waited out in full.
+//
+// Returns ErrPromptTimeout if the agent is not ready in time, or the tmux
+// error if the session is gone.
+func (t *Tmux) WaitForPrompt(session string, timeout time.Duration) error {
+	promptPrefix, detectable, fallback := agentReadiness(t, session)
+	if !detectable && fallback < timeout {
+		timeout = fallback
+	}
+
+	consecutive := 0
+	const requiredConsecutive = 2
+	deadline := time.Now().Add(timeout)
+	for {
+		lines, err := t.CapturePaneLines(session, 10)
+		if errors.Is(err, ErrSessionNotFound) || errors.Is(err, ErrNoServer) {
+			return err
+		}
+		if err == nil && promptReady(lines, promptPrefix, detectable, t.windowActivity(session), time.Now()) {
+			consecutive++
+			if consecutive >= requiredConsecutive {
+				return nil
+			}
+		} else {
+			consecutive = 0
+		}
+		if time.Now().After(deadline) {
+			if !detectable {
+				return nil // Bounded fallback, not a readiness check.
+			}
+			return ErrPromptTimeout
+		}
+		time.Sleep(200 * time.Millisecond)
+	}
+}
+
+// promptReady is the pure decision logic behind WaitForPrompt.
+func promptReady(lines []string, promptPrefix string, detectable bool, lastActivity, now time.Time) bool {
+	if detectable {
+		return classifyIdle(lines, promptPrefix, lastActivity, now, promptQuietPeriod).Idle
+	}
+	for _, line := range lines {
+		if hasBusyIndicator(line) {
+			return false
+		}
+	}
+	// No prompt to look for: rely on the pane going quiet. Without activity
+	// information there is nothing to observe, so WaitForPrompt waits out
+	// its fallback bound.
+	return !lastActivity.IsZero() && now.Sub(lastActivity) >= promptQuietPeriod
+}
+
+// agentReadiness returns the ready-prompt prefix for the agent running in
+// session, whether the agent supports prompt detection at all, and how long
+// to wait for agents that don't: the preset's ReadyDelayMs, at least
+// minReadyFallback. Sessions without GT_AGENT are Claude Code; unknown agents
+// get no prompt detection.
+func agentReadiness(t *Tmux, session string) (string, bool, time.Duration) {
+	agentName, err := t.GetEnvironment(session, "GT_AGENT")
+	if err != nil || agentName == "" {
+		return DefaultReadyPromptPrefix, true, 0
+	}
+	preset := config.GetAgentPresetByName(agentName)
+	if preset == nil {
+		return "", false, minReadyFallback
+	}
+	if preset.ReadyPromptPrefix != "" {
+		return preset.ReadyPromptPrefix, true, 0
+	}
+	return "", false, max(time.Duration(preset.ReadyDelayMs)*time.Millisecond, minReadyFallback)
+}
+
+// classifyIdle is the pure decision logic behind Idle.
+func classifyIdle(lines []string, promptPrefix string, lastActivity, now time.Time, quiet time.Duration) *IdleState {
+	state := &IdleState{LastActivity: lastActivity}
+	if !lastActivity.IsZero() && now.After(lastActivity) {
+		state.QuietFor = now.Sub(lastActivity)
+	}
+
+	for _, line := range lines {
+		if hasBusyIndicator(line) {
+			state.Busy = true
+		}
+		if matchesPromptPrefix(line, promptPrefix) {
+			state.AtPrompt = true
+		}
+	}
+
+	if state.Busy || !state.AtPrompt {
+		return state
+	}
+	// Unknown activity falls back to the prompt heuristic alone.
+	state.Idle = quiet <= 0 || lastActivity.IsZero() || state.QuietFor >= quiet
+	return state
+}
+
 // GetSessionInfo returns detailed information about a session.
 func (t *Tmux) GetSessionInfo(name string) (*SessionInfo, error) {
 	format := "#{session_name}|#{session_windows}|#{session_created}|#{session_attached}|#{session_activity}|#{session_last_attached}"
@@ -3233,6 +3770,16 @@ func (t *Tmux) RespawnPane(pane, command string) error {
 	return err
 }
 
+// RespawnDeadPane restarts a dead pane with its original command and keeps
+// remain-on-exit on so a later exit is visible again. Fails if the pane is
+// still running, so it never interrupts a live process.
+func (t *Tmux) RespawnDeadPane(pane string) error {
+	if _, err := t.run("respawn-pane", "-t", pane); err != nil {
+		return err
+	}
+	return t.SetPaneRemainOnExit(pane, true)
+}
+
 // RespawnPaneWithWorkDir kills all processes in a pane and starts a new command
 // in the specified working directory. Use this when the pane's current working
 // directory may have been deleted.
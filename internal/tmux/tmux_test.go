@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
@@ -2256,3 +2257,382 @@ func TestValidateCommandBinary(t *testing.T) {
 		})
 	}
 }
+
+func TestPipePane(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-pipe-" + t.Name()
+
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	logFile := filepath.Join(t.TempDir(), "pane.log")
+	if err := tm.PipePane(sessionName, "cat >> "+logFile); err != nil {
+		t.Fatalf("PipePane: %v", err)
+	}
+	if err := tm.SendKeys(sessionName, "echo PIPE_PANE_MARKER"); err != nil {
+		t.Fatalf("SendKeys: %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		data, _ := os.ReadFile(logFile)
+		if strings.Contains(string(data), "PIPE_PANE_MARKER") {
+			if err := tm.StopPipePane(sessionName); err != nil {
+				t.Fatalf("StopPipePane: %v", err)
+			}
+			return
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatal("pane output never reached the piped log file")
+}
+
+func TestParseSessionDetails(t *testing.T) {
+	out := "hq-mayor\t1\t2\t1700000000\t1700000100\n" +
+		"\n" +
+		"malformed line\n" +
+		"gt-Toast\t0\t1\t1700000000\t"
+	got := parseSessionDetails(out)
+	if len(got) != 2 {
+		t.Fatalf("parseSessionDetails returned %d entries, want 2: %+v", len(got), got)
+	}
+
+	mayor := got[0]
+	if mayor.Name != "hq-mayor" || mayor.Clients != 1 || mayor.Windows != 2 {
+		t.Errorf("mayor = %+v", mayor)
+	}
+	if !mayor.Activity.Equal(time.Unix(1700000100, 0)) {
+		t.Errorf("mayor.Activity = %v, want unix 1700000100", mayor.Activity)
+	}
+
+	toast := got[1]
+	if !toast.Activity.IsZero() {
+		t.Errorf("toast.Activity = %v, want zero for empty field", toast.Activity)
+	}
+}
+
+func TestAgentPaneDetail(t *testing.T) {
+	out := "gt-Toast\t%5\t1\t1\t500\t/town/logs\n" +
+		"gt-Toast\t%3\t0\t0\t300\t/town/a|b\n" +
+		"gt-Toast\t%4\t0\t1\t400\t/town/shell\n" +
+		"malformed\n"
+	panes := parsePaneDetails(out)["gt-Toast"]
+	if len(panes) != 3 {
+		t.Fatalf("parsePaneDetails returned %d panes, want 3: %+v", len(panes), panes)
+	}
+
+	// Without a recorded pane, the first pane of the first window is the
+	// agent's, whichever pane is active.
+	p, ok := agentPaneDetail(panes, "")
+	if !ok || p.ID != "%3" || p.PID != 300 || p.WorkDir != "/town/a|b" {
+		t.Errorf("agentPaneDetail(no record) = %+v, %v; want pane %%3", p, ok)
+	}
+
+	p, ok = agentPaneDetail(panes, "%4")
+	if !ok || p.ID != "%4" {
+		t.Errorf("agentPaneDetail(%%4) = %+v, %v; want the recorded pane", p, ok)
+	}
+
+	// A stale record falls back to the first pane.
+	if p, _ := agentPaneDetail(panes, "%99"); p.ID != "%3" {
+		t.Errorf("agentPaneDetail(stale) = %+v, want pane %%3", p)
+	}
+
+	if _, ok := agentPaneDetail(nil, ""); ok {
+		t.Error("agentPaneDetail(nil) should report no pane")
+	}
+}
+
+func TestListSessionDetails(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-details-" + t.Name()
+
+	_ = tm.KillSession(sessionName)
+	dir := t.TempDir()
+	if err := tm.NewSession(sessionName, dir); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	agentPID, err := tm.GetPanePID(sessionName)
+	if err != nil {
+		t.Fatalf("GetPanePID: %v", err)
+	}
+	agentPane, err := tm.GetPaneID(sessionName)
+	if err != nil {
+		t.Fatalf("GetPaneID: %v", err)
+	}
+	// Focus a second pane: details must still describe the agent pane.
+	other, err := tm.SplitWindow(agentPane, "", "", true, 30, nil)
+	if err != nil {
+		t.Fatalf("SplitWindow: %v", err)
+	}
+	if _, err := tm.run("select-pane", "-t", other); err != nil {
+		t.Fatalf("select-pane: %v", err)
+	}
+
+	details, err := tm.ListSessionDetails()
+	if err != nil {
+		t.Fatalf("ListSessionDetails: %v", err)
+	}
+	for _, d := range details {
+		if d.Name != sessionName {
+			continue
+		}
+		if fmt.Sprint(d.PanePID) != agentPID {
+			t.Errorf("PanePID = %d, want agent pane pid %s", d.PanePID, agentPID)
+		}
+		if d.Created.IsZero() {
+			t.Error("Created is zero")
+		}
+		return
+	}
+	t.Fatalf("session %s not in ListSessionDetails output", sessionName)
+}
+
+func TestClassifyIdle(t *testing.T) {
+	now := time.Unix(1700000100, 0)
+	prompt := DefaultReadyPromptPrefix
+	atPrompt := []string{"some output", "❯ ", "⏵⏵ bypass permissions on"}
+	busy := []string{"✻ Thinking…", "❯ ", "⏵⏵ bypass permissions on · esc to interrupt"}
+	working := []string{"Reading file...", "⏺ Bash(go test ./...)"}
+
+	tests := []struct {
+		name     string
+		lines    []string
+		activity time.Time
+		quiet    time.Duration
+		wantIdle bool
+		wantBusy bool
+	}{
+		{"prompt and quiet pane", atPrompt, now.Add(-10 * time.Second), DefaultIdleQuietPeriod, true, false},
+		{"prompt but recent output", atPrompt, now.Add(-1 * time.Second), DefaultIdleQuietPeriod, false, false},
+		{"prompt with unknown activity", atPrompt, time.Time{}, DefaultIdleQuietPeriod, true, false},
+		{"prompt with quiet check disabled", atPrompt, now, 0, true, false},
+		{"busy indicator wins over prompt", busy, now.Add(-time.Minute), DefaultIdleQuietPeriod, false, true},
+		{"no prompt visible", working, now.Add(-time.Minute), DefaultIdleQuietPeriod, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyIdle(tt.lines, prompt, tt.activity, now, tt.quiet)
+			if got.Idle != tt.wantIdle {
+				t.Errorf("Idle = %v, want %v (state %+v)", got.Idle, tt.wantIdle, got)
+			}
+			if got.Busy != tt.wantBusy {
+				t.Errorf("Busy = %v, want %v", got.Busy, tt.wantBusy)
+			}
+		})
+	}
+}
+
+func TestIdle_SessionNotFound(t *testing.T) {
+	tm := newTestTmux(t)
+	if _, err := tm.Idle("gt-test-no-such-session-idle"); err == nil {
+		t.Error("Idle on missing session should return an error")
+	}
+}
+
+func TestNewWindowAndSplitWindow_KeepAgentFocus(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-layout-" + t.Name()
+
+	_ = tm.KillSession(sessionName)
+	if err := tm.NewSession(sessionName, ""); err != nil {
+		t.Fatalf("NewSession: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	agentPane, err := tm.GetPaneID(sessionName)
+	if err != nil {
+		t.Fatalf("GetPaneID: %v", err)
+	}
+
+	split, err := tm.SplitWindow(agentPane, "", "", true, 30, map[string]string{"GT_ROLE": "crew"})
+	if err != nil {
+		t.Fatalf("SplitWindow: %v", err)
+	}
+	if !strings.HasPrefix(split, "%") || split == agentPane {
+		t.Errorf("SplitWindow pane = %q, want new pane ID", split)
+	}
+	if err := tm.SetPaneRemainOnExit(split, false); err != nil {
+		t.Fatalf("SetPaneRemainOnExit: %v", err)
+	}
+
+	win, err := tm.NewWindow(sessionName, "shell", "", "", nil)
+	if err != nil {
+		t.Fatalf("NewWindow: %v", err)
+	}
+	if !strings.HasPrefix(win, "%") {
+		t.Errorf("NewWindow pane = %q, want pane ID", win)
+	}
+
+	// Session-targeted operations must still resolve to the agent pane.
+	if got, _ := tm.GetPaneID(sessionName); got != agentPane {
+		t.Errorf("active pane = %q after layout, want agent pane %q", got, agentPane)
+	}
+}
+
+func TestRemoteTmuxArgs(t *testing.T) {
+	args := remoteTmuxArgs("build-01", []string{"-u", "-L", "gt", "send-keys", "-t", "gt-Toast", "it's $HOME; rm -rf ~"}, false)
+
+	if got := args[len(args)-3]; got != "build-01" {
+		t.Fatalf("host arg = %q, want build-01 (args=%v)", got, args)
+	}
+	if args[len(args)-2] != "--" {
+		t.Fatalf("expected -- before remote command, got %v", args)
+	}
+	for _, a := range args {
+		if a == "-t" {
+			t.Fatalf("non-tty invocation should not request a tty: %v", args)
+		}
+	}
+
+	// The remote command must survive a POSIX shell unchanged.
+	remote := args[len(args)-1]
+	out, err := exec.Command("sh", "-c", "set -- "+strings.TrimPrefix(remote, "tmux ")+`; printf '%s\n' "$@"`).Output()
+	if err != nil {
+		t.Fatalf("sh: %v", err)
+	}
+	want := "-u\n-L\ngt\nsend-keys\n-t\ngt-Toast\nit's $HOME; rm -rf ~\n"
+	if string(out) != want {
+		t.Errorf("remote shell parsed %q, want %q", out, want)
+	}
+
+	tty := remoteTmuxArgs("build-01", []string{"attach-session"}, true)
+	if tty[len(tty)-4] != "-t" {
+		t.Errorf("tty invocation should pass -t to ssh: %v", tty)
+	}
+}
+
+func TestOnHost(t *testing.T) {
+	local := NewTmuxWithSocket("gt-test")
+	if local.OnHost("") != local {
+		t.Error("OnHost(\"\") should return the receiver")
+	}
+	remote := local.OnHost("user@build-01")
+	if remote.RemoteHost() != "user@build-01" || remote.socketName != "gt-test" {
+		t.Errorf("OnHost = %+v, want host user@build-01 on socket gt-test", remote)
+	}
+	if local.RemoteHost() != "" {
+		t.Error("OnHost must not modify the receiver")
+	}
+	// Local launch checks are skipped for remote hosts.
+	if err := remote.validateLaunch("/nonexistent/remote/path", "/nonexistent/bin/claude"); err != nil {
+		t.Errorf("validateLaunch on remote host = %v, want nil", err)
+	}
+	if err := local.validateLaunch("/nonexistent/remote/path", ""); err == nil {
+		t.Error("validateLaunch on local host should reject a missing work dir")
+	}
+}
+
+func TestParseDeadPanes(t *testing.T) {
+	out := "hq-mayor\t%0\t0\t\ngt-witness\t%3\t1\t2\nbad line\ngt-crew-max\t%7\t1\t0\n"
+	got := parseDeadPanes(out)
+	want := []DeadPane{
+		{Session: "gt-witness", PaneID: "%3", ExitStatus: 2},
+		{Session: "gt-crew-max", PaneID: "%7", ExitStatus: 0},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("parseDeadPanes = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("pane %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestPromptReady(t *testing.T) {
+	now := time.Unix(1700000100, 0)
+	atPrompt := []string{"some output", "❯ ", "⏵⏵ bypass permissions on"}
+	busy := []string{"✻ Thinking…", "⏵⏵ bypass permissions on · esc to interrupt"}
+	plain := []string{"> "}
+
+	tests := []struct {
+		name       string
+		lines      []string
+		detectable bool
+		activity   time.Time
+		want       bool
+	}{
+		{"prompt on quiet pane", atPrompt, true, now.Add(-5 * time.Second), true},
+		{"prompt while still drawing", atPrompt, true, now.Add(-500 * time.Millisecond), false},
+		{"busy", busy, true, now.Add(-time.Minute), false},
+		{"no prompt detection, quiet pane", plain, false, now.Add(-5 * time.Second), true},
+		{"no prompt detection, active pane", plain, false, now, false},
+		{"no prompt detection, busy indicator", busy, false, now.Add(-time.Minute), false},
+		{"no prompt detection, no activity info", plain, false, time.Time{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := promptReady(tt.lines, DefaultReadyPromptPrefix, tt.detectable, tt.activity, now); got != tt.want {
+				t.Errorf("promptReady = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWaitForPrompt(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-waitprompt-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	// The prompt only appears after a startup delay.
+	if err := tm.NewSessionWithCommand(sessionName, t.TempDir(), `sh -c 'sleep 1; printf "❯ "; exec cat'`); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	start := time.Now()
+	if err := tm.WaitForPrompt(sessionName, 15*time.Second); err != nil {
+		t.Fatalf("WaitForPrompt: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("WaitForPrompt returned after %v, before the prompt was drawn", elapsed)
+	}
+
+	if err := tm.WaitForPrompt("gt-test-no-such-session", time.Second); err == nil {
+		t.Error("WaitForPrompt on a missing session should fail")
+	}
+}
+
+func TestWaitForPrompt_UndetectableAgentIsBounded(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-waitprompt-bounded-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	// An agent without prompt detection whose pane never goes quiet: the
+	// wait is bounded by the fallback delay, not the caller's timeout.
+	env := map[string]string{"GT_AGENT": "no-such-agent"}
+	if err := tm.NewSessionWithCommandAndEnv(sessionName, t.TempDir(), `sh -c 'while :; do echo busy; sleep 0.1; done'`, env); err != nil {
+		t.Fatalf("NewSessionWithCommandAndEnv: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	start := time.Now()
+	if err := tm.WaitForPrompt(sessionName, 30*time.Second); err != nil {
+		t.Fatalf("WaitForPrompt: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < minReadyFallback || elapsed > 5*time.Second {
+		t.Errorf("WaitForPrompt took %v, want about %v", elapsed, minReadyFallback)
+	}
+}
+
+func TestWaitForPrompt_TimesOutWithoutPrompt(t *testing.T) {
+	tm := newTestTmux(t)
+	sessionName := "gt-test-waitprompt-none-" + t.Name()
+	_ = tm.KillSession(sessionName)
+
+	if err := tm.NewSessionWithCommand(sessionName, t.TempDir(), "cat"); err != nil {
+		t.Fatalf("NewSessionWithCommand: %v", err)
+	}
+	defer func() { _ = tm.KillSession(sessionName) }()
+
+	if err := tm.WaitForPrompt(sessionName, 500*time.Millisecond); !errors.Is(err, ErrPromptTimeout) {
+		t.Errorf("WaitForPrompt = %v, want ErrPromptTimeout", err)
+	}
+}
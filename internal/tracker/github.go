@@ -0,0 +1,124 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/github"
+)
+
+// GitHubConnector syncs with the issues of one GitHub repository that carry
+// the sync label.
+type GitHubConnector struct {
+	client *github.Client
+	owner  string
+	repo   string
+	label  string
+}
+
+var _ Connector = (*GitHubConnector)(nil)
+
+// NewGitHubConnector returns a connector for cfg. opts configure the
+// underlying client (token, base URL).
+func NewGitHubConnector(cfg *config.GitHubSyncConfig, opts ...github.Option) (*GitHubConnector, error) {
+	owner, repo, ok := strings.Cut(cfg.Repo, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return nil, fmt.Errorf("github: repo must be \"owner/name\", got %q", cfg.Repo)
+	}
+	client, err := github.NewClient(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &GitHubConnector{client: client, owner: owner, repo: repo, label: cfg.GetLabel()}, nil
+}
+
+// Name implements Connector.
+func (g *GitHubConnector) Name() string { return "github" }
+
+// TracksProgress implements Connector. GitHub issues are only open or closed.
+func (g *GitHubConnector) TracksProgress() bool { return false }
+
+// List implements Connector.
+func (g *GitHubConnector) List(ctx context.Context) ([]Issue, error) {
+	ghIssues, err := g.client.ListIssues(ctx, g.owner, g.repo, g.label)
+	if err != nil {
+		return nil, err
+	}
+	issues := make([]Issue, 0, len(ghIssues))
+	for _, gi := range ghIssues {
+		issues = append(issues, githubIssue(gi))
+	}
+	return issues, nil
+}
+
+// Get implements Connector.
+func (g *GitHubConnector) Get(ctx context.Context, id string) (Issue, error) {
+	number, err := strconv.Atoi(id)
+	if err != nil {
+		return Issue{}, fmt.Errorf("github: bad issue number %q", id)
+	}
+	gi, err := g.client.GetIssue(ctx, g.owner, g.repo, number)
+	if err != nil {
+		return Issue{}, err
+	}
+	return githubIssue(gi), nil
+}
+
+func githubIssue(gi github.Issue) Issue {
+	issue := Issue{
+		ID:     strconv.Itoa(gi.Number),
+		URL:    gi.HTMLURL,
+		Title:  gi.Title,
+		Body:   gi.Body,
+		Status: StatusOpen,
+	}
+	if gi.State == "closed" {
+		issue.Status = StatusClosed
+	}
+	if len(gi.Assignees) > 0 {
+		issue.Assignee = gi.Assignees[0]
+	}
+	return issue
+}
+
+// Create implements Connector.
+func (g *GitHubConnector) Create(ctx context.Context, issue Issue) (Issue, error) {
+	var assignees []string
+	if issue.Assignee != "" {
+		assignees = []string{issue.Assignee}
+	}
+	gi, err := g.client.CreateIssue(ctx, g.owner, g.repo, issue.Title, issue.Body, []string{g.label}, assignees)
+	if err != nil {
+		return Issue{}, err
+	}
+	issue.ID = strconv.Itoa(gi.Number)
+	issue.URL = gi.HTMLURL
+	return issue, nil
+}
+
+// Update implements Connector.
+func (g *GitHubConnector) Update(ctx context.Context, id string, change Change) error {
+	number, err := strconv.Atoi(id)
+	if err != nil {
+		return fmt.Errorf("github: bad issue number %q", id)
+	}
+	update := github.IssueUpdate{Title: change.Title}
+	if change.Status != nil {
+		state := "open"
+		if *change.Status == StatusClosed {
+			state = "closed"
+		}
+		update.State = &state
+	}
+	if change.Assignee != nil {
+		assignees := []string{}
+		if *change.Assignee != "" {
+			assignees = []string{*change.Assignee}
+		}
+		update.Assignees = &assignees
+	}
+	return g.client.UpdateIssue(ctx, g.owner, g.repo, number, update)
+}
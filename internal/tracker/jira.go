@@ -0,0 +1,321 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// jiraPageSize is how many issues each search request fetches.
+const jiraPageSize = 100
+
+// JiraConnector syncs with the issues of one Jira project that carry the
+// sync label. It uses REST API v2, which Jira Cloud and Data Center share.
+type JiraConnector struct {
+	httpClient *http.Client
+	baseURL    string
+	project    string
+	label      string
+	issueType  string
+	email      string // Set on Jira Cloud: basic auth, assignees are account IDs
+	token      string
+	titleField string
+	descField  string
+	statuses   map[string]string // Synced status -> Jira status name
+}
+
+var _ Connector = (*JiraConnector)(nil)
+
+// NewJiraConnector returns a connector for cfg, authenticating with the
+// JIRA_API_TOKEN environment variable. httpClient may be nil.
+func NewJiraConnector(cfg *config.JiraSyncConfig, httpClient *http.Client) (*JiraConnector, error) {
+	if cfg.URL == "" || cfg.Project == "" {
+		return nil, fmt.Errorf("jira: url and project are required")
+	}
+	token := os.Getenv("JIRA_API_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("jira: JIRA_API_TOKEN is required")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	j := &JiraConnector{
+		httpClient: httpClient,
+		baseURL:    strings.TrimSuffix(cfg.URL, "/"),
+		project:    cfg.Project,
+		label:      cfg.GetLabel(),
+		issueType:  cfg.GetIssueType(),
+		email:      cfg.Email,
+		token:      token,
+		titleField: cfg.Field("title"),
+		descField:  cfg.Field("description"),
+		statuses:   make(map[string]string),
+	}
+	for _, s := range []string{StatusOpen, StatusInProgress, StatusClosed} {
+		j.statuses[s] = cfg.StatusName(s)
+	}
+	return j, nil
+}
+
+// cloud reports whether the site is Jira Cloud rather than Data Center.
+func (j *JiraConnector) cloud() bool { return j.email != "" }
+
+// Name implements Connector.
+func (j *JiraConnector) Name() string { return "jira" }
+
+// TracksProgress implements Connector.
+func (j *JiraConnector) TracksProgress() bool { return true }
+
+// jiraIssue is the REST shape of an issue. Title and description are read
+// from Fields by their configured field IDs.
+type jiraIssue struct {
+	Key    string                     `json:"key"`
+	Fields map[string]json.RawMessage `json:"fields"`
+}
+
+func (j *JiraConnector) fieldList() string {
+	return strings.Join([]string{j.titleField, j.descField, "status", "assignee"}, ",")
+}
+
+func (j *JiraConnector) issue(ji jiraIssue) Issue {
+	issue := Issue{
+		ID:     ji.Key,
+		URL:    j.baseURL + "/browse/" + ji.Key,
+		Title:  jiraString(ji.Fields[j.titleField]),
+		Body:   jiraString(ji.Fields[j.descField]),
+		Status: StatusOpen,
+	}
+
+	var status struct {
+		StatusCategory struct {
+			Key string `json:"key"`
+		} `json:"statusCategory"`
+	}
+	if raw := ji.Fields["status"]; raw != nil && json.Unmarshal(raw, &status) == nil {
+		switch status.StatusCategory.Key {
+		case "indeterminate":
+			issue.Status = StatusInProgress
+		case "done":
+			issue.Status = StatusClosed
+		}
+	}
+
+	var assignee *struct {
+		AccountID string `json:"accountId"`
+		Name      string `json:"name"`
+	}
+	if raw := ji.Fields["assignee"]; raw != nil && json.Unmarshal(raw, &assignee) == nil && assignee != nil {
+		issue.Assignee = assignee.Name
+		if j.cloud() {
+			issue.Assignee = assignee.AccountID
+		}
+	}
+	return issue
+}
+
+// jiraString decodes a text field, treating null or non-string values as "".
+func jiraString(raw json.RawMessage) string {
+	var s string
+	if raw != nil && json.Unmarshal(raw, &s) == nil {
+		return s
+	}
+	return ""
+}
+
+// List implements Connector.
+func (j *JiraConnector) List(ctx context.Context) ([]Issue, error) {
+	jql := fmt.Sprintf("project = %q AND labels = %q ORDER BY key", j.project, j.label)
+	var issues []Issue
+
+	// Cloud pages with nextPageToken on /search/jql; Data Center with
+	// startAt on /search.
+	startAt, pageToken := 0, ""
+	for {
+		q := url.Values{}
+		q.Set("jql", jql)
+		q.Set("fields", j.fieldList())
+		q.Set("maxResults", strconv.Itoa(jiraPageSize))
+		path := "/rest/api/2/search"
+		if j.cloud() {
+			path = "/rest/api/2/search/jql"
+			if pageToken != "" {
+				q.Set("nextPageToken", pageToken)
+			}
+		} else {
+			q.Set("startAt", strconv.Itoa(startAt))
+		}
+
+		var resp struct {
+			Issues        []jiraIssue `json:"issues"`
+			Total         int         `json:"total"`
+			NextPageToken string      `json:"nextPageToken"`
+		}
+		if err := j.request(ctx, "GET", path+"?"+q.Encode(), nil, &resp); err != nil {
+			return nil, err
+		}
+		for _, ji := range resp.Issues {
+			issues = append(issues, j.issue(ji))
+		}
+
+		if j.cloud() {
+			if resp.NextPageToken == "" {
+				return issues, nil
+			}
+			pageToken = resp.NextPageToken
+		} else {
+			startAt += len(resp.Issues)
+			if len(resp.Issues) == 0 || startAt >= resp.Total {
+				return issues, nil
+			}
+		}
+	}
+}
+
+// Get implements Connector.
+func (j *JiraConnector) Get(ctx context.Context, id string) (Issue, error) {
+	var ji jiraIssue
+	path := "/rest/api/2/issue/" + url.PathEscape(id) + "?fields=" + url.QueryEscape(j.fieldList())
+	if err := j.request(ctx, "GET", path, nil, &ji); err != nil {
+		return Issue{}, err
+	}
+	return j.issue(ji), nil
+}
+
+// Create implements Connector.
+func (j *JiraConnector) Create(ctx context.Context, issue Issue) (Issue, error) {
+	body := map[string]any{
+		"fields": map[string]any{
+			"project":    map[string]string{"key": j.project},
+			"issuetype":  map[string]string{"name": j.issueType},
+			j.titleField: issue.Title,
+			j.descField:  issue.Body,
+			"labels":     []string{j.label},
+		},
+	}
+	var resp struct {
+		Key string `json:"key"`
+	}
+	if err := j.request(ctx, "POST", "/rest/api/2/issue", body, &resp); err != nil {
+		return Issue{}, err
+	}
+	issue.ID = resp.Key
+	issue.URL = j.baseURL + "/browse/" + resp.Key
+	if issue.Assignee != "" {
+		if err := j.assign(ctx, resp.Key, issue.Assignee); err != nil {
+			return issue, err
+		}
+	}
+	return issue, nil
+}
+
+// Update implements Connector. Status changes run the workflow transition
+// that leads to the configured status for the synced status.
+func (j *JiraConnector) Update(ctx context.Context, id string, change Change) error {
+	if change.Title != nil {
+		body := map[string]any{"fields": map[string]any{j.titleField: *change.Title}}
+		if err := j.request(ctx, "PUT", "/rest/api/2/issue/"+url.PathEscape(id), body, nil); err != nil {
+			return err
+		}
+	}
+	if change.Assignee != nil {
+		if err := j.assign(ctx, id, *change.Assignee); err != nil {
+			return err
+		}
+	}
+	if change.Status != nil {
+		if err := j.transition(ctx, id, j.statuses[*change.Status]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (j *JiraConnector) assign(ctx context.Context, id, user string) error {
+	key := "name"
+	if j.cloud() {
+		key = "accountId"
+	}
+	var value any // nil unassigns
+	if user != "" {
+		value = user
+	}
+	return j.request(ctx, "PUT", "/rest/api/2/issue/"+url.PathEscape(id)+"/assignee", map[string]any{key: value}, nil)
+}
+
+// transition moves an issue to the named status via whichever available
+// transition leads there.
+func (j *JiraConnector) transition(ctx context.Context, id, status string) error {
+	var resp struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	path := "/rest/api/2/issue/" + url.PathEscape(id) + "/transitions"
+	if err := j.request(ctx, "GET", path, nil, &resp); err != nil {
+		return err
+	}
+	for _, t := range resp.Transitions {
+		if strings.EqualFold(t.To.Name, status) || strings.EqualFold(t.Name, status) {
+			return j.request(ctx, "POST", path, map[string]any{"transition": map[string]string{"id": t.ID}}, nil)
+		}
+	}
+	return fmt.Errorf("jira: %s has no transition to %q", id, status)
+}
+
+// request makes an authenticated REST request and decodes the JSON response.
+func (j *JiraConnector) request(ctx context.Context, method, path string, body, result any) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("jira: marshal request: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, j.baseURL+path, reqBody)
+	if err != nil {
+		return fmt.Errorf("jira: create request: %w", err)
+	}
+	if j.cloud() {
+		req.SetBasicAuth(j.email, j.token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+j.token)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := j.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("jira: %s %s: %w", method, path, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("jira: read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("jira: %s %s returned %d: %s", method, path, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	if result != nil && len(respBody) > 0 {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("jira: decode response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func newTestJira(t *testing.T, cfg config.JiraSyncConfig, handler http.Handler) *JiraConnector {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	t.Setenv("JIRA_API_TOKEN", "secret")
+	cfg.URL = srv.URL
+	if cfg.Project == "" {
+		cfg.Project = "ENG"
+	}
+	j, err := NewJiraConnector(&cfg, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return j
+}
+
+func TestJira_ListMapsFieldsAndStatus(t *testing.T) {
+	cfg := config.JiraSyncConfig{
+		Email:  "bot@example.com",
+		Fields: map[string]string{"title": "customfield_1"},
+	}
+	pages := 0
+	j := newTestJira(t, cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search/jql" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "bot@example.com" || pass != "secret" {
+			t.Errorf("basic auth = %q %q %v", user, pass, ok)
+		}
+		if got, want := r.URL.Query().Get("jql"), `project = "ENG" AND labels = "gastown" ORDER BY key`; got != want {
+			t.Errorf("jql = %q, want %q", got, want)
+		}
+		pages++
+		if r.URL.Query().Get("nextPageToken") == "" {
+			_, _ = w.Write([]byte(`{"nextPageToken": "p2", "issues": [
+				{"key": "ENG-1", "fields": {"customfield_1": "First", "description": "Body",
+					"status": {"statusCategory": {"key": "indeterminate"}},
+					"assignee": {"accountId": "acc-1", "name": "max"}}}]}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"issues": [
+			{"key": "ENG-2", "fields": {"customfield_1": "Second", "description": null,
+				"status": {"statusCategory": {"key": "done"}}, "assignee": null}}]}`))
+	}))
+
+	issues, err := j.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pages != 2 || len(issues) != 2 {
+		t.Fatalf("got %d issues over %d pages, want 2 over 2", len(issues), pages)
+	}
+	first := issues[0]
+	if first.ID != "ENG-1" || first.Title != "First" || first.Body != "Body" ||
+		first.Status != StatusInProgress || first.Assignee != "acc-1" {
+		t.Errorf("first = %+v", first)
+	}
+	if first.URL != j.baseURL+"/browse/ENG-1" {
+		t.Errorf("URL = %s", first.URL)
+	}
+	if second := issues[1]; second.Status != StatusClosed || second.Assignee != "" {
+		t.Errorf("second = %+v", second)
+	}
+}
+
+func TestJira_UpdateTransitionsToConfiguredStatus(t *testing.T) {
+	cfg := config.JiraSyncConfig{Statuses: map[string]string{"closed": "Resolved"}}
+	var transitioned, assigned string
+	j := newTestJira(t, cfg, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("Authorization = %q", got)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /rest/api/2/issue/ENG-7/transitions":
+			_, _ = w.Write([]byte(`{"transitions": [
+				{"id": "11", "name": "Start", "to": {"name": "In Progress"}},
+				{"id": "31", "name": "Resolve", "to": {"name": "Resolved"}}]}`))
+		case "POST /rest/api/2/issue/ENG-7/transitions":
+			var body struct {
+				Transition struct {
+					ID string `json:"id"`
+				} `json:"transition"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			transitioned = body.Transition.ID
+			w.WriteHeader(http.StatusNoContent)
+		case "PUT /rest/api/2/issue/ENG-7/assignee":
+			var body map[string]any
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			if v, ok := body["name"]; !ok || v != nil {
+				t.Errorf("assignee body = %v, want name: null", body)
+			}
+			assigned = "cleared"
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	closed, nobody := StatusClosed, ""
+	if err := j.Update(context.Background(), "ENG-7", Change{Status: &closed, Assignee: &nobody}); err != nil {
+		t.Fatal(err)
+	}
+	if transitioned != "31" {
+		t.Errorf("transition = %q, want 31", transitioned)
+	}
+	if assigned != "cleared" {
+		t.Error("assignee was not cleared")
+	}
+
+	open := StatusOpen
+	if err := j.Update(context.Background(), "ENG-7", Change{Status: &open}); err == nil {
+		t.Error("expected an error when no transition leads to the status")
+	}
+}
+
+func TestJira_CreateUsesProjectAndLabel(t *testing.T) {
+	j := newTestJira(t, config.JiraSyncConfig{IssueType: "Bug"}, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Fields map[string]any `json:"fields"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body.Fields["summary"] != "Fix it" {
+			t.Errorf("summary = %v", body.Fields["summary"])
+		}
+		if p, _ := body.Fields["project"].(map[string]any); p["key"] != "ENG" {
+			t.Errorf("project = %v", body.Fields["project"])
+		}
+		if it, _ := body.Fields["issuetype"].(map[string]any); it["name"] != "Bug" {
+			t.Errorf("issuetype = %v", body.Fields["issuetype"])
+		}
+		if labels, _ := body.Fields["labels"].([]any); len(labels) != 1 || labels[0] != "gastown" {
+			t.Errorf("labels = %v", body.Fields["labels"])
+		}
+		_, _ = w.Write([]byte(`{"id": "10001", "key": "ENG-9"}`))
+	}))
+
+	issue, err := j.Create(context.Background(), Issue{Title: "Fix it"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.ID != "ENG-9" || issue.URL != j.baseURL+"/browse/ENG-9" {
+		t.Errorf("created = %+v", issue)
+	}
+}
+
+func TestNewJiraConnector_RequiresToken(t *testing.T) {
+	t.Setenv("JIRA_API_TOKEN", "")
+	if _, err := NewJiraConnector(&config.JiraSyncConfig{URL: "https://x", Project: "ENG"}, nil); err == nil {
+		t.Error("expected an error without JIRA_API_TOKEN")
+	}
+}
@@ -0,0 +1,448 @@
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// DefaultLinearAPIURL is Linear's GraphQL endpoint.
+const DefaultLinearAPIURL = "https://api.linear.app/graphql"
+
+// linearPageSize is how many issues each list query fetches.
+const linearPageSize = 100
+
+// LinearConnector syncs with the issues of one Linear team that carry the
+// sync label. Tracker users are identified by email.
+type LinearConnector struct {
+	httpClient *http.Client
+	apiURL     string
+	apiKey     string
+	teamKey    string
+	label      string
+	statuses   map[string]string // Synced status -> configured state name
+
+	mu      sync.Mutex
+	team    *linearTeam       // Resolved on first use
+	userIDs map[string]string // Email -> user ID
+}
+
+// linearTeam is what writes need to know about the team.
+type linearTeam struct {
+	id      string
+	labelID string
+	states  []linearState
+}
+
+type linearState struct {
+	ID       string  `json:"id"`
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Position float64 `json:"position"`
+}
+
+var _ Connector = (*LinearConnector)(nil)
+
+// NewLinearConnector returns a connector for cfg, authenticating with the
+// LINEAR_API_KEY environment variable. An empty apiURL means
+// DefaultLinearAPIURL; httpClient may be nil.
+func NewLinearConnector(cfg *config.LinearSyncConfig, apiURL string, httpClient *http.Client) (*LinearConnector, error) {
+	if cfg.Team == "" {
+		return nil, fmt.Errorf("linear: team is required")
+	}
+	apiKey := os.Getenv("LINEAR_API_KEY")
+	if apiKey == "" {
+		return nil, fmt.Errorf("linear: LINEAR_API_KEY is required")
+	}
+	if apiURL == "" {
+		apiURL = DefaultLinearAPIURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &LinearConnector{
+		httpClient: httpClient,
+		apiURL:     apiURL,
+		apiKey:     apiKey,
+		teamKey:    cfg.Team,
+		label:      cfg.GetLabel(),
+		statuses:   cfg.Statuses,
+		userIDs:    make(map[string]string),
+	}, nil
+}
+
+// Name implements Connector.
+func (l *LinearConnector) Name() string { return "linear" }
+
+// TracksProgress implements Connector.
+func (l *LinearConnector) TracksProgress() bool { return true }
+
+// linearIssueFields is the GraphQL selection for an issue.
+const linearIssueFields = `identifier title description url state { type } assignee { email }`
+
+type linearIssue struct {
+	Identifier  string `json:"identifier"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	State       struct {
+		Type string `json:"type"`
+	} `json:"state"`
+	Assignee *struct {
+		Email string `json:"email"`
+	} `json:"assignee"`
+}
+
+func (li linearIssue) issue() Issue {
+	issue := Issue{
+		ID:     li.Identifier,
+		URL:    li.URL,
+		Title:  li.Title,
+		Body:   li.Description,
+		Status: StatusOpen,
+	}
+	switch li.State.Type {
+	case "started":
+		issue.Status = StatusInProgress
+	case "completed", "canceled":
+		issue.Status = StatusClosed
+	}
+	if li.Assignee != nil {
+		issue.Assignee = li.Assignee.Email
+	}
+	return issue
+}
+
+// List implements Connector.
+func (l *LinearConnector) List(ctx context.Context) ([]Issue, error) {
+	query := `query($team: String!, $label: String!, $first: Int!, $after: String) {
+		issues(first: $first, after: $after, filter: {
+			team: { key: { eq: $team } }
+			labels: { name: { eq: $label } }
+		}) {
+			nodes { ` + linearIssueFields + ` }
+			pageInfo { hasNextPage endCursor }
+		}
+	}`
+	var issues []Issue
+	var after *string
+	for {
+		var data struct {
+			Issues struct {
+				Nodes    []linearIssue `json:"nodes"`
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+			} `json:"issues"`
+		}
+		vars := map[string]any{"team": l.teamKey, "label": l.label, "first": linearPageSize, "after": after}
+		if err := l.graphql(ctx, query, vars, &data); err != nil {
+			return nil, err
+		}
+		for _, li := range data.Issues.Nodes {
+			issues = append(issues, li.issue())
+		}
+		if !data.Issues.PageInfo.HasNextPage {
+			return issues, nil
+		}
+		cursor := data.Issues.PageInfo.EndCursor
+		after = &cursor
+	}
+}
+
+// Get implements Connector.
+func (l *LinearConnector) Get(ctx context.Context, id string) (Issue, error) {
+	query := `query($id: String!) { issue(id: $id) { ` + linearIssueFields + ` } }`
+	var data struct {
+		Issue linearIssue `json:"issue"`
+	}
+	if err := l.graphql(ctx, query, map[string]any{"id": id}, &data); err != nil {
+		return Issue{}, err
+	}
+	return data.Issue.issue(), nil
+}
+
+// Create implements Connector.
+func (l *LinearConnector) Create(ctx context.Context, issue Issue) (Issue, error) {
+	team, err := l.resolveTeam(ctx)
+	if err != nil {
+		return Issue{}, err
+	}
+	input := map[string]any{
+		"teamId":      team.id,
+		"title":       issue.Title,
+		"description": issue.Body,
+		"labelIds":    []string{team.labelID},
+	}
+	if issue.Assignee != "" {
+		userID, err := l.userID(ctx, issue.Assignee)
+		if err != nil {
+			return Issue{}, err
+		}
+		input["assigneeId"] = userID
+	}
+
+	query := `mutation($input: IssueCreateInput!) {
+		issueCreate(input: $input) { success issue { identifier url } }
+	}`
+	var data struct {
+		IssueCreate struct {
+			Success bool `json:"success"`
+			Issue   struct {
+				Identifier string `json:"identifier"`
+				URL        string `json:"url"`
+			} `json:"issue"`
+		} `json:"issueCreate"`
+	}
+	if err := l.graphql(ctx, query, map[string]any{"input": input}, &data); err != nil {
+		return Issue{}, err
+	}
+	if !data.IssueCreate.Success {
+		return Issue{}, fmt.Errorf("linear: issueCreate was not successful")
+	}
+	issue.ID = data.IssueCreate.Issue.Identifier
+	issue.URL = data.IssueCreate.Issue.URL
+	return issue, nil
+}
+
+// Update implements Connector. Status changes move the issue to the
+// configured workflow state, or the team's first state of the matching type.
+func (l *LinearConnector) Update(ctx context.Context, id string, change Change) error {
+	input := map[string]any{}
+	if change.Title != nil {
+		input["title"] = *change.Title
+	}
+	if change.Assignee != nil {
+		var assigneeID any // nil unassigns
+		if *change.Assignee != "" {
+			userID, err := l.userID(ctx, *change.Assignee)
+			if err != nil {
+				return err
+			}
+			assigneeID = userID
+		}
+		input["assigneeId"] = assigneeID
+	}
+	if change.Status != nil {
+		team, err := l.resolveTeam(ctx)
+		if err != nil {
+			return err
+		}
+		stateID, err := l.stateFor(team, *change.Status)
+		if err != nil {
+			return err
+		}
+		input["stateId"] = stateID
+	}
+	if len(input) == 0 {
+		return nil
+	}
+
+	query := `mutation($id: String!, $input: IssueUpdateInput!) {
+		issueUpdate(id: $id, input: $input) { success }
+	}`
+	var data struct {
+		IssueUpdate struct {
+			Success bool `json:"success"`
+		} `json:"issueUpdate"`
+	}
+	if err := l.graphql(ctx, query, map[string]any{"id": id, "input": input}, &data); err != nil {
+		return err
+	}
+	if !data.IssueUpdate.Success {
+		return fmt.Errorf("linear: issueUpdate %s was not successful", id)
+	}
+	return nil
+}
+
+// linearStateTypes are the workflow state types each synced status moves
+// to by default.
+var linearStateTypes = map[string]string{
+	StatusOpen:       "unstarted",
+	StatusInProgress: "started",
+	StatusClosed:     "completed",
+}
+
+// stateFor picks the workflow state a synced status moves to.
+func (l *LinearConnector) stateFor(team *linearTeam, status string) (string, error) {
+	if name := l.statuses[status]; name != "" {
+		for _, s := range team.states {
+			if strings.EqualFold(s.Name, name) {
+				return s.ID, nil
+			}
+		}
+		return "", fmt.Errorf("linear: team %s has no workflow state %q", l.teamKey, name)
+	}
+	var best *linearState
+	for i, s := range team.states {
+		if s.Type == linearStateTypes[status] && (best == nil || s.Position < best.Position) {
+			best = &team.states[i]
+		}
+	}
+	if best == nil {
+		return "", fmt.Errorf("linear: team %s has no %s workflow state", l.teamKey, linearStateTypes[status])
+	}
+	return best.ID, nil
+}
+
+// resolveTeam looks up the team's ID, workflow states, and sync label,
+// creating the label on the team if it doesn't exist yet.
+func (l *LinearConnector) resolveTeam(ctx context.Context) (*linearTeam, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.team != nil {
+		return l.team, nil
+	}
+
+	query := `query($team: String!, $label: String!) {
+		teams(filter: { key: { eq: $team } }) {
+			nodes {
+				id
+				states { nodes { id name type position } }
+				labels(filter: { name: { eq: $label } }) { nodes { id } }
+			}
+		}
+		issueLabels(filter: { name: { eq: $label }, team: { null: true } }) { nodes { id } }
+	}`
+	type idNodes struct {
+		Nodes []struct {
+			ID string `json:"id"`
+		} `json:"nodes"`
+	}
+	var data struct {
+		Teams struct {
+			Nodes []struct {
+				ID     string `json:"id"`
+				States struct {
+					Nodes []linearState `json:"nodes"`
+				} `json:"states"`
+				Labels idNodes `json:"labels"`
+			} `json:"nodes"`
+		} `json:"teams"`
+		IssueLabels idNodes `json:"issueLabels"` // Workspace-wide labels
+	}
+	if err := l.graphql(ctx, query, map[string]any{"team": l.teamKey, "label": l.label}, &data); err != nil {
+		return nil, err
+	}
+	if len(data.Teams.Nodes) == 0 {
+		return nil, fmt.Errorf("linear: team %q not found", l.teamKey)
+	}
+	t := data.Teams.Nodes[0]
+	team := &linearTeam{id: t.ID, states: t.States.Nodes}
+
+	switch {
+	case len(t.Labels.Nodes) > 0:
+		team.labelID = t.Labels.Nodes[0].ID
+	case len(data.IssueLabels.Nodes) > 0:
+		team.labelID = data.IssueLabels.Nodes[0].ID
+	default:
+		create := `mutation($input: IssueLabelCreateInput!) {
+			issueLabelCreate(input: $input) { success issueLabel { id } }
+		}`
+		var created struct {
+			IssueLabelCreate struct {
+				IssueLabel struct {
+					ID string `json:"id"`
+				} `json:"issueLabel"`
+			} `json:"issueLabelCreate"`
+		}
+		input := map[string]any{"name": l.label, "teamId": team.id}
+		if err := l.graphql(ctx, create, map[string]any{"input": input}, &created); err != nil {
+			return nil, fmt.Errorf("creating label %q: %w", l.label, err)
+		}
+		team.labelID = created.IssueLabelCreate.IssueLabel.ID
+	}
+
+	l.team = team
+	return team, nil
+}
+
+// userID resolves a user's email to their Linear ID.
+func (l *LinearConnector) userID(ctx context.Context, email string) (string, error) {
+	l.mu.Lock()
+	id, ok := l.userIDs[email]
+	l.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	query := `query($email: String!) { users(filter: { email: { eq: $email } }) { nodes { id } } }`
+	var data struct {
+		Users struct {
+			Nodes []struct {
+				ID string `json:"id"`
+			} `json:"nodes"`
+		} `json:"users"`
+	}
+	if err := l.graphql(ctx, query, map[string]any{"email": email}, &data); err != nil {
+		return "", err
+	}
+	if len(data.Users.Nodes) == 0 {
+		return "", fmt.Errorf("linear: no user with email %q", email)
+	}
+	id = data.Users.Nodes[0].ID
+
+	l.mu.Lock()
+	l.userIDs[email] = id
+	l.mu.Unlock()
+	return id, nil
+}
+
+// graphql runs a query and decodes its data into result.
+func (l *LinearConnector) graphql(ctx context.Context, query string, vars map[string]any, result any) error {
+	body, err := json.Marshal(map[string]any{"query": query, "variables": vars})
+	if err != nil {
+		return fmt.Errorf("linear: marshal request: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", l.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("linear: create request: %w", err)
+	}
+	req.Header.Set("Authorization", l.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := l.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("linear: request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("linear: read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("linear: API returned %d: %s", resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	var envelope struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	if err := json.Unmarshal(respBody, &envelope); err != nil {
+		return fmt.Errorf("linear: decode response: %w", err)
+	}
+	if len(envelope.Errors) > 0 {
+		msgs := make([]string, len(envelope.Errors))
+		for i, e := range envelope.Errors {
+			msgs[i] = e.Message
+		}
+		return fmt.Errorf("linear: %s", strings.Join(msgs, "; "))
+	}
+	if result != nil {
+		if err := json.Unmarshal(envelope.Data, result); err != nil {
+			return fmt.Errorf("linear: decode data: %w", err)
+		}
+	}
+	return nil
+}
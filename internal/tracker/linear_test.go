@@ -0,0 +1,167 @@
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+type graphqlRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// newTestLinear serves GraphQL requests by handing each to respond, which
+// returns the "data" object.
+func newTestLinear(t *testing.T, cfg config.LinearSyncConfig, respond func(req graphqlRequest) string) *LinearConnector {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("Authorization"); got != "lin_key" {
+			t.Errorf("Authorization = %q", got)
+		}
+		var req graphqlRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatal(err)
+		}
+		_, _ = w.Write([]byte(`{"data": ` + respond(req) + `}`))
+	}))
+	t.Cleanup(srv.Close)
+	t.Setenv("LINEAR_API_KEY", "lin_key")
+	if cfg.Team == "" {
+		cfg.Team = "ENG"
+	}
+	l, err := NewLinearConnector(&cfg, srv.URL, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return l
+}
+
+const linearTeamData = `{
+	"teams": {"nodes": [{
+		"id": "team-1",
+		"states": {"nodes": [
+			{"id": "s-backlog", "name": "Backlog", "type": "backlog", "position": 0},
+			{"id": "s-todo", "name": "Todo", "type": "unstarted", "position": 1},
+			{"id": "s-review", "name": "In Review", "type": "started", "position": 3},
+			{"id": "s-doing", "name": "In Progress", "type": "started", "position": 2},
+			{"id": "s-done", "name": "Done", "type": "completed", "position": 4}
+		]},
+		"labels": {"nodes": [{"id": "label-1"}]}
+	}]},
+	"issueLabels": {"nodes": []}
+}`
+
+func TestLinear_ListPagesAndMapsStates(t *testing.T) {
+	l := newTestLinear(t, config.LinearSyncConfig{}, func(req graphqlRequest) string {
+		if req.Variables["team"] != "ENG" || req.Variables["label"] != "gastown" {
+			t.Errorf("variables = %v", req.Variables)
+		}
+		if req.Variables["after"] == nil {
+			return `{"issues": {"nodes": [
+				{"identifier": "ENG-1", "title": "One", "description": "Body", "url": "https://linear.app/x/ENG-1",
+				 "state": {"type": "started"}, "assignee": {"email": "max@example.com"}}
+			], "pageInfo": {"hasNextPage": true, "endCursor": "c1"}}}`
+		}
+		return `{"issues": {"nodes": [
+			{"identifier": "ENG-2", "title": "Two", "state": {"type": "canceled"}, "assignee": null},
+			{"identifier": "ENG-3", "title": "Three", "state": {"type": "backlog"}, "assignee": null}
+		], "pageInfo": {"hasNextPage": false}}}`
+	})
+
+	issues, err := l.List(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("got %d issues, want 3", len(issues))
+	}
+	if got := issues[0]; got.ID != "ENG-1" || got.Status != StatusInProgress || got.Assignee != "max@example.com" || got.Body != "Body" {
+		t.Errorf("issues[0] = %+v", got)
+	}
+	if issues[1].Status != StatusClosed || issues[2].Status != StatusOpen {
+		t.Errorf("statuses = %s, %s; want closed, open", issues[1].Status, issues[2].Status)
+	}
+}
+
+func TestLinear_UpdateResolvesStateAndAssignee(t *testing.T) {
+	var input map[string]any
+	l := newTestLinear(t, config.LinearSyncConfig{}, func(req graphqlRequest) string {
+		switch {
+		case strings.Contains(req.Query, "teams("):
+			return linearTeamData
+		case strings.Contains(req.Query, "users("):
+			return `{"users": {"nodes": [{"id": "user-7"}]}}`
+		case strings.Contains(req.Query, "issueUpdate"):
+			if req.Variables["id"] != "ENG-5" {
+				t.Errorf("id = %v", req.Variables["id"])
+			}
+			input, _ = req.Variables["input"].(map[string]any)
+			return `{"issueUpdate": {"success": true}}`
+		}
+		t.Errorf("unexpected query: %s", req.Query)
+		return `{}`
+	})
+
+	status, who := StatusInProgress, "max@example.com"
+	if err := l.Update(context.Background(), "ENG-5", Change{Status: &status, Assignee: &who}); err != nil {
+		t.Fatal(err)
+	}
+	// The lowest-positioned started state wins.
+	if input["stateId"] != "s-doing" || input["assigneeId"] != "user-7" {
+		t.Errorf("input = %v", input)
+	}
+
+	nobody := ""
+	if err := l.Update(context.Background(), "ENG-5", Change{Assignee: &nobody}); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := input["assigneeId"]; !ok || v != nil {
+		t.Errorf("input = %v, want assigneeId: null", input)
+	}
+}
+
+func TestLinear_ConfiguredStatusName(t *testing.T) {
+	l := newTestLinear(t, config.LinearSyncConfig{Statuses: map[string]string{"in_progress": "in review"}}, func(req graphqlRequest) string {
+		return linearTeamData
+	})
+	team, err := l.resolveTeam(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id, err := l.stateFor(team, StatusInProgress); err != nil || id != "s-review" {
+		t.Errorf("stateFor(in_progress) = %q, %v; want s-review", id, err)
+	}
+	if id, err := l.stateFor(team, StatusClosed); err != nil || id != "s-done" {
+		t.Errorf("stateFor(closed) = %q, %v; want s-done", id, err)
+	}
+}
+
+func TestLinear_CreateAddsTeamAndLabel(t *testing.T) {
+	l := newTestLinear(t, config.LinearSyncConfig{}, func(req graphqlRequest) string {
+		if strings.Contains(req.Query, "teams(") {
+			return linearTeamData
+		}
+		input, _ := req.Variables["input"].(map[string]any)
+		if input["teamId"] != "team-1" || input["title"] != "New" {
+			t.Errorf("input = %v", input)
+		}
+		if ids, _ := input["labelIds"].([]any); len(ids) != 1 || ids[0] != "label-1" {
+			t.Errorf("labelIds = %v", input["labelIds"])
+		}
+		return `{"issueCreate": {"success": true, "issue": {"identifier": "ENG-8", "url": "https://linear.app/x/ENG-8"}}}`
+	})
+
+	issue, err := l.Create(context.Background(), Issue{Title: "New"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.ID != "ENG-8" || issue.URL != "https://linear.app/x/ENG-8" {
+		t.Errorf("created = %+v", issue)
+	}
+}
@@ -0,0 +1,136 @@
+package tracker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+// Configured is a connector enabled in a rig's settings.
+type Configured struct {
+	Connector Connector
+	Settings  *config.TrackerSyncConfig
+}
+
+// RigConnectors returns the connectors enabled in a rig's settings. A
+// connector that is configured but can't be built (bad repo, missing
+// token) is reported in the error; the others are still returned.
+func RigConnectors(rigPath string) ([]Configured, error) {
+	settings, err := config.LoadRigSettings(config.RigSettingsPath(rigPath))
+	if err != nil {
+		if errors.Is(err, config.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var configured []Configured
+	var errs []error
+	if settings.GitHub != nil {
+		conn, err := NewGitHubConnector(settings.GitHub)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			configured = append(configured, Configured{Connector: conn, Settings: &settings.GitHub.TrackerSyncConfig})
+		}
+	}
+	if settings.Jira != nil {
+		conn, err := NewJiraConnector(settings.Jira, nil)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			configured = append(configured, Configured{Connector: conn, Settings: &settings.Jira.TrackerSyncConfig})
+		}
+	}
+	if settings.Linear != nil {
+		conn, err := NewLinearConnector(settings.Linear, "", nil)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			configured = append(configured, Configured{Connector: conn, Settings: &settings.Linear.TrackerSyncConfig})
+		}
+	}
+	return configured, errors.Join(errs...)
+}
+
+// Due reports whether a connector's scheduled sync should run now.
+func Due(rigPath string, c Configured, now time.Time) bool {
+	interval := c.Settings.GetInterval()
+	if interval <= 0 {
+		return false
+	}
+	state, err := LoadState(StatePath(rigPath, c.Connector.Name()))
+	if err != nil {
+		return false
+	}
+	return now.Sub(state.LastSync) >= interval
+}
+
+// SyncRig runs one sync of a connector against the rig's beads and saves
+// the resulting state.
+func SyncRig(ctx context.Context, rigPath string, c Configured, dryRun bool) (Result, error) {
+	statePath := StatePath(rigPath, c.Connector.Name())
+	unlock, err := LockState(statePath)
+	if err != nil {
+		return Result{}, err
+	}
+	defer unlock()
+	state, err := LoadState(statePath)
+	if err != nil {
+		return Result{}, fmt.Errorf("loading sync state: %w", err)
+	}
+
+	res, syncErr := Sync(ctx, c.Connector, beads.New(rigPath), state, Options{
+		Label:     c.Settings.GetLabel(),
+		Assignees: c.Settings.Assignees,
+		DryRun:    dryRun,
+	})
+	// Save even after a partial failure so links made before it stick.
+	if !dryRun {
+		if err := SaveState(statePath, state); err != nil && syncErr == nil {
+			syncErr = fmt.Errorf("saving sync state: %w", err)
+		}
+	}
+	return res, syncErr
+}
+
+// SyncRigBead pushes one bead's current state to every connector the rig
+// has linked it to, so status changes such as a polecat's gt done reach
+// the tracker without waiting for the next scheduled sync.
+func SyncRigBead(ctx context.Context, rigPath, beadID string) error {
+	connectors, err := RigConnectors(rigPath)
+	errs := []error{err}
+	for _, c := range connectors {
+		if err := syncRigBead(ctx, rigPath, c, beadID); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.Connector.Name(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// syncRigBead pushes one bead through one connector, holding the state
+// lock from load to save.
+func syncRigBead(ctx context.Context, rigPath string, c Configured, beadID string) error {
+	statePath := StatePath(rigPath, c.Connector.Name())
+	unlock, err := LockState(statePath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	state, err := LoadState(statePath)
+	if err != nil {
+		return fmt.Errorf("loading sync state: %w", err)
+	}
+	_, syncErr := SyncBead(ctx, c.Connector, beads.New(rigPath), state, Options{
+		Label:     c.Settings.GetLabel(),
+		Assignees: c.Settings.Assignees,
+	}, beadID)
+	if err := SaveState(statePath, state); err != nil {
+		return errors.Join(syncErr, fmt.Errorf("saving sync state: %w", err))
+	}
+	return syncErr
+}
@@ -0,0 +1,79 @@
+package tracker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/constants"
+	"github.com/steveyegge/gastown/internal/lock"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// Snapshot is the synced fields of a linked bead/issue pair, in bead terms
+// (Assignee is a bead assignee).
+type Snapshot struct {
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee,omitempty"`
+}
+
+// Link pairs a bead with a tracker issue.
+type Link struct {
+	BeadID  string   `json:"bead_id"`
+	IssueID string   `json:"issue_id"`
+	URL     string   `json:"url,omitempty"`
+	Synced  Snapshot `json:"synced"` // Fields as of the last sync
+}
+
+// State is a connector's sync state for one rig.
+type State struct {
+	LastSync time.Time `json:"last_sync,omitempty"`
+	Links    []Link    `json:"links"`
+}
+
+// StatePath returns where a connector's state lives for a rig.
+func StatePath(rigPath, connector string) string {
+	return filepath.Join(rigPath, constants.DirRuntime, "tracker-sync", connector+".json")
+}
+
+// LoadState reads sync state, returning empty state if there is none yet.
+func LoadState(path string) (*State, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is under the rig runtime dir
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{}, nil
+		}
+		return nil, err
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return &s, nil
+}
+
+// LockState takes an exclusive lock on a state file, so a scheduled sync
+// and a gt done push can't both load, change and save it and drop each
+// other's links. Hold it from LoadState through SaveState; call the
+// returned func to release.
+func LockState(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	unlock, err := lock.FlockAcquire(path + ".flock")
+	if err != nil {
+		return nil, fmt.Errorf("locking %s: %w", path, err)
+	}
+	return unlock, nil
+}
+
+// SaveState writes sync state atomically (temp file plus rename).
+func SaveState(path string, s *State) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return util.AtomicWriteJSON(path, s)
+}
@@ -0,0 +1,368 @@
+// Package tracker syncs a rig's beads with external issue trackers.
+//
+// Each tracker (GitHub Issues, Jira, Linear) is a Connector. Sync links beads
+// to tracker issues and keeps title, status and assignee in step in both
+// directions. Beads carrying the connector's label are pushed to
+// the tracker; tracker issues carrying it are pulled into the rig's beads,
+// where they land in the ready queue like any other work.
+//
+// For each link, State records the fields both sides agreed on at the last
+// sync. A field that changed on only one side since then is copied to the
+// other; a field that changed on both is a conflict, and the bead wins.
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/beads"
+)
+
+// Synced statuses, in bead terms. Bead statuses collapse onto these:
+// hooked counts as in progress, and everything not started or finished is
+// open.
+const (
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+	StatusClosed     = "closed"
+)
+
+// Issue is a work item in an external tracker.
+type Issue struct {
+	ID       string // Tracker's stable ID (GitHub issue number, Jira key, ...)
+	URL      string
+	Title    string
+	Body     string
+	Status   string // StatusOpen, StatusInProgress or StatusClosed
+	Assignee string // Tracker user; "" if unassigned
+}
+
+// Connector talks to one external tracker, scoped to the issues that
+// should be synced (e.g., one repo and label).
+type Connector interface {
+	// Name identifies the connector ("github"). It names the state file.
+	Name() string
+	// TracksProgress reports whether the tracker distinguishes in-progress
+	// from open. If not, in-progress beads sync as open.
+	TracksProgress() bool
+	// List returns every in-scope issue, open and closed.
+	List(ctx context.Context) ([]Issue, error)
+	// Get returns one issue by ID.
+	Get(ctx context.Context, id string) (Issue, error)
+	// Create opens an issue in scope and returns it with ID and URL set.
+	Create(ctx context.Context, issue Issue) (Issue, error)
+	// Update applies the set fields of change to an issue.
+	Update(ctx context.Context, id string, change Change) error
+}
+
+// Change holds the fields to update on a tracker issue. Nil fields are left
+// as they are.
+type Change struct {
+	Title    *string
+	Status   *string
+	Assignee *string // Tracker user; "" unassigns
+}
+
+// BeadStore is the subset of *beads.Beads that Sync needs.
+type BeadStore interface {
+	Show(id string) (*beads.Issue, error)
+	List(opts beads.ListOptions) ([]*beads.Issue, error)
+	Create(opts beads.CreateOptions) (*beads.Issue, error)
+	Update(id string, opts beads.UpdateOptions) error
+}
+
+var _ BeadStore = (*beads.Beads)(nil)
+
+// Options controls a sync run.
+type Options struct {
+	// Label marks synced beads. Imported issues get it too.
+	Label string
+	// Assignees maps bead assignees to tracker users.
+	Assignees map[string]string
+	// DryRun reports what would change without writing to either side.
+	DryRun bool
+}
+
+// Result summarizes a sync run.
+type Result struct {
+	Pushed    int      // Tracker issues updated from beads
+	Pulled    int      // Beads updated from tracker issues
+	Created   int      // Tracker issues created for unlinked beads
+	Imported  int      // Beads created for unlinked tracker issues
+	Conflicts []string // Fields changed on both sides (bead kept)
+}
+
+// syncer holds what one sync run needs.
+type syncer struct {
+	conn      Connector
+	store     BeadStore
+	opts      Options
+	toTracker map[string]string
+	toBead    map[string]string
+	res       Result
+}
+
+func newSyncer(conn Connector, store BeadStore, opts Options) *syncer {
+	s := &syncer{conn: conn, store: store, opts: opts}
+	s.toTracker, s.toBead = assigneeMaps(opts.Assignees)
+	return s
+}
+
+// Sync reconciles the store's labeled beads with the connector's issues and
+// records the outcome in state. state is updated in place; the caller saves it.
+func Sync(ctx context.Context, conn Connector, store BeadStore, state *State, opts Options) (Result, error) {
+	s := newSyncer(conn, store, opts)
+
+	remote, err := conn.List(ctx)
+	if err != nil {
+		return s.res, fmt.Errorf("listing %s issues: %w", conn.Name(), err)
+	}
+	local, err := store.List(beads.ListOptions{Status: "all", Label: opts.Label, Priority: -1})
+	if err != nil {
+		return s.res, fmt.Errorf("listing beads: %w", err)
+	}
+
+	remoteByID := make(map[string]Issue, len(remote))
+	for _, r := range remote {
+		remoteByID[r.ID] = r
+	}
+	localByID := make(map[string]*beads.Issue, len(local))
+	for _, b := range local {
+		localByID[b.ID] = b
+	}
+
+	// Reconcile linked pairs.
+	linkedBeads := make(map[string]bool)
+	linkedIssues := make(map[string]bool)
+	for i := range state.Links {
+		link := &state.Links[i]
+		linkedBeads[link.BeadID] = true
+		linkedIssues[link.IssueID] = true
+
+		b, okB := localByID[link.BeadID]
+		r, okR := remoteByID[link.IssueID]
+		if !okB || !okR {
+			continue // Out of scope on one side (label removed, deleted)
+		}
+		if err := s.reconcile(ctx, link, b, r); err != nil {
+			return s.res, err
+		}
+	}
+
+	// Push unlinked beads. Closed ones were never worth tracking.
+	for _, b := range local {
+		if linkedBeads[b.ID] || b.Status == StatusClosed {
+			continue
+		}
+		s.res.Created++
+		if opts.DryRun {
+			continue
+		}
+		snap := s.beadSnapshot(b)
+		created, err := conn.Create(ctx, Issue{
+			Title:    b.Title,
+			Body:     strings.TrimSpace(fmt.Sprintf("%s\n\n_Synced from bead %s._", b.Description, b.ID)),
+			Status:   StatusOpen,
+			Assignee: s.toTracker[snap.Assignee],
+		})
+		if err != nil {
+			return s.res, fmt.Errorf("creating %s issue for %s: %w", conn.Name(), b.ID, err)
+		}
+		// Record what the tracker has; a bead already in progress is
+		// pushed as a change on the next sync.
+		snap.Status = StatusOpen
+		state.Links = append(state.Links, Link{BeadID: b.ID, IssueID: created.ID, URL: created.URL, Synced: snap})
+	}
+
+	// Pull unlinked open issues.
+	for _, r := range remote {
+		if linkedIssues[r.ID] || r.Status == StatusClosed {
+			continue
+		}
+		s.res.Imported++
+		if opts.DryRun {
+			continue
+		}
+		b, err := store.Create(beads.CreateOptions{
+			Title:       r.Title,
+			Labels:      []string{"gt:task", opts.Label},
+			Priority:    2,
+			Description: strings.TrimSpace(fmt.Sprintf("%s\n\nImported from %s", r.Body, r.URL)),
+		})
+		if err != nil {
+			return s.res, fmt.Errorf("importing %s issue %s: %w", conn.Name(), r.ID, err)
+		}
+		// Imported beads start open; pull the rest as a change next sync.
+		snap := Snapshot{Title: r.Title, Status: StatusOpen}
+		if assignee := s.toBead[r.Assignee]; assignee != "" {
+			if err := store.Update(b.ID, beads.UpdateOptions{Assignee: &assignee}); err != nil {
+				return s.res, fmt.Errorf("assigning imported bead %s: %w", b.ID, err)
+			}
+			snap.Assignee = assignee
+		}
+		state.Links = append(state.Links, Link{BeadID: b.ID, IssueID: r.ID, URL: r.URL, Synced: snap})
+	}
+
+	if !opts.DryRun {
+		state.LastSync = time.Now()
+	}
+	return s.res, nil
+}
+
+// SyncBead reconciles a single linked bead right away, without listing
+// either side. It does nothing if the bead isn't linked.
+func SyncBead(ctx context.Context, conn Connector, store BeadStore, state *State, opts Options, beadID string) (Result, error) {
+	s := newSyncer(conn, store, opts)
+	for i := range state.Links {
+		link := &state.Links[i]
+		if link.BeadID != beadID {
+			continue
+		}
+		b, err := store.Show(beadID)
+		if err != nil {
+			return s.res, fmt.Errorf("reading bead %s: %w", beadID, err)
+		}
+		r, err := conn.Get(ctx, link.IssueID)
+		if err != nil {
+			return s.res, fmt.Errorf("reading %s issue %s: %w", conn.Name(), link.IssueID, err)
+		}
+		if err := s.reconcile(ctx, link, b, r); err != nil {
+			return s.res, err
+		}
+	}
+	return s.res, nil
+}
+
+// reconcile merges one linked pair and writes the result to both sides.
+func (s *syncer) reconcile(ctx context.Context, link *Link, b *beads.Issue, r Issue) error {
+	bSnap := s.beadSnapshot(b)
+	rSnap := s.issueSnapshot(r)
+	merged, conflicts := merge(link.Synced, bSnap, rSnap)
+	for _, field := range conflicts {
+		s.res.Conflicts = append(s.res.Conflicts, fmt.Sprintf("%s/%s %s", link.BeadID, link.IssueID, field))
+	}
+
+	if merged != rSnap {
+		if !s.opts.DryRun {
+			if err := s.conn.Update(ctx, r.ID, trackerChange(rSnap, merged, s.toTracker)); err != nil {
+				return fmt.Errorf("updating %s issue %s: %w", s.conn.Name(), r.ID, err)
+			}
+		}
+		s.res.Pushed++
+	}
+	if merged != bSnap {
+		if !s.opts.DryRun {
+			if err := s.store.Update(b.ID, beadUpdate(bSnap, merged)); err != nil {
+				return fmt.Errorf("updating bead %s: %w", b.ID, err)
+			}
+		}
+		s.res.Pulled++
+	}
+	if !s.opts.DryRun {
+		link.Synced = merged
+	}
+	return nil
+}
+
+// assigneeMaps returns the bead→tracker mapping and its inverse.
+func assigneeMaps(m map[string]string) (toTracker, toBead map[string]string) {
+	toTracker = make(map[string]string, len(m))
+	toBead = make(map[string]string, len(m))
+	for bead, user := range m {
+		toTracker[bead] = user
+		toBead[user] = bead
+	}
+	return toTracker, toBead
+}
+
+// beadSnapshot returns the synced fields of a bead. Assignees without a
+// tracker mapping read as unassigned so they neither sync nor churn.
+func (s *syncer) beadSnapshot(b *beads.Issue) Snapshot {
+	snap := Snapshot{Title: b.Title, Status: s.collapse(beadStatus(b.Status))}
+	if _, ok := s.toTracker[b.Assignee]; ok {
+		snap.Assignee = b.Assignee
+	}
+	return snap
+}
+
+// issueSnapshot returns the synced fields of a tracker issue.
+func (s *syncer) issueSnapshot(r Issue) Snapshot {
+	return Snapshot{Title: r.Title, Status: s.collapse(r.Status), Assignee: s.toBead[r.Assignee]}
+}
+
+// collapse folds in-progress into open for trackers that don't track it.
+func (s *syncer) collapse(status string) string {
+	if status == StatusInProgress && !s.conn.TracksProgress() {
+		return StatusOpen
+	}
+	return status
+}
+
+// beadStatus maps a bead status onto the synced statuses.
+func beadStatus(status string) string {
+	switch status {
+	case "closed", "tombstone":
+		return StatusClosed
+	case "in_progress", "hooked":
+		return StatusInProgress
+	default:
+		return StatusOpen
+	}
+}
+
+// merge combines both sides' changes since base, field by field. Fields
+// changed on both sides to different values are conflicts; the bead wins.
+func merge(base, bead, remote Snapshot) (Snapshot, []string) {
+	var conflicts []string
+	pick := func(name, b, r, baseVal string) string {
+		bChanged, rChanged := b != baseVal, r != baseVal
+		switch {
+		case bChanged && rChanged && b != r:
+			conflicts = append(conflicts, name)
+			return b
+		case rChanged && !bChanged:
+			return r
+		default:
+			return b
+		}
+	}
+	merged := Snapshot{
+		Title:    pick("title", bead.Title, remote.Title, base.Title),
+		Status:   pick("status", bead.Status, remote.Status, base.Status),
+		Assignee: pick("assignee", bead.Assignee, remote.Assignee, base.Assignee),
+	}
+	return merged, conflicts
+}
+
+// beadUpdate returns the update that turns bead state from into to.
+func beadUpdate(from, to Snapshot) beads.UpdateOptions {
+	var opts beads.UpdateOptions
+	if to.Title != from.Title {
+		opts.Title = &to.Title
+	}
+	if to.Status != from.Status {
+		opts.Status = &to.Status
+	}
+	if to.Assignee != from.Assignee {
+		opts.Assignee = &to.Assignee
+	}
+	return opts
+}
+
+// trackerChange returns the change that turns issue state from into to.
+func trackerChange(from, to Snapshot, toTracker map[string]string) Change {
+	var c Change
+	if to.Title != from.Title {
+		c.Title = &to.Title
+	}
+	if to.Status != from.Status {
+		c.Status = &to.Status
+	}
+	if to.Assignee != from.Assignee {
+		user := toTracker[to.Assignee]
+		c.Assignee = &user
+	}
+	return c
+}
@@ -0,0 +1,343 @@
+package tracker
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+type fakeConnector struct {
+	issues   map[string]*Issue
+	nextID   int
+	updates  []string
+	progress bool
+}
+
+func newFakeConnector(issues ...Issue) *fakeConnector {
+	f := &fakeConnector{issues: make(map[string]*Issue), nextID: 100}
+	for i := range issues {
+		is := issues[i]
+		f.issues[is.ID] = &is
+	}
+	return f
+}
+
+func (f *fakeConnector) Name() string { return "fake" }
+
+func (f *fakeConnector) TracksProgress() bool { return f.progress }
+
+func (f *fakeConnector) Get(ctx context.Context, id string) (Issue, error) {
+	return *f.issues[id], nil
+}
+
+func (f *fakeConnector) List(ctx context.Context) ([]Issue, error) {
+	var out []Issue
+	for _, is := range f.issues {
+		out = append(out, *is)
+	}
+	return out, nil
+}
+
+func (f *fakeConnector) Create(ctx context.Context, issue Issue) (Issue, error) {
+	f.nextID++
+	issue.ID = fmt.Sprint(f.nextID)
+	issue.URL = "https://tracker/" + issue.ID
+	if issue.Status == "" {
+		issue.Status = StatusOpen
+	}
+	f.issues[issue.ID] = &issue
+	return issue, nil
+}
+
+func (f *fakeConnector) Update(ctx context.Context, id string, c Change) error {
+	is := f.issues[id]
+	if c.Title != nil {
+		is.Title = *c.Title
+	}
+	if c.Status != nil {
+		is.Status = *c.Status
+	}
+	if c.Assignee != nil {
+		is.Assignee = *c.Assignee
+	}
+	f.updates = append(f.updates, id)
+	return nil
+}
+
+type fakeStore struct {
+	issues map[string]*beads.Issue
+	nextID int
+}
+
+func newFakeStore(issues ...*beads.Issue) *fakeStore {
+	s := &fakeStore{issues: make(map[string]*beads.Issue)}
+	for _, is := range issues {
+		s.issues[is.ID] = is
+	}
+	return s
+}
+
+func (s *fakeStore) Show(id string) (*beads.Issue, error) {
+	return s.issues[id], nil
+}
+
+func (s *fakeStore) List(opts beads.ListOptions) ([]*beads.Issue, error) {
+	var out []*beads.Issue
+	for _, is := range s.issues {
+		for _, l := range is.Labels {
+			if l == opts.Label {
+				out = append(out, is)
+				break
+			}
+		}
+	}
+	return out, nil
+}
+
+func (s *fakeStore) Create(opts beads.CreateOptions) (*beads.Issue, error) {
+	s.nextID++
+	is := &beads.Issue{ID: fmt.Sprintf("gt-new%d", s.nextID), Title: opts.Title, Status: "open", Labels: opts.Labels, Description: opts.Description}
+	s.issues[is.ID] = is
+	return is, nil
+}
+
+func (s *fakeStore) Update(id string, opts beads.UpdateOptions) error {
+	is := s.issues[id]
+	if opts.Title != nil {
+		is.Title = *opts.Title
+	}
+	if opts.Status != nil {
+		is.Status = *opts.Status
+	}
+	if opts.Assignee != nil {
+		is.Assignee = *opts.Assignee
+	}
+	return nil
+}
+
+func TestSync_CreatesAndImports(t *testing.T) {
+	conn := newFakeConnector(
+		Issue{ID: "1", Title: "From tracker", URL: "https://tracker/1", Status: StatusOpen, Assignee: "octocat"},
+		Issue{ID: "2", Title: "Closed upstream", Status: StatusClosed},
+	)
+	store := newFakeStore(
+		&beads.Issue{ID: "gt-a", Title: "From beads", Status: "open", Labels: []string{"gastown"}},
+		&beads.Issue{ID: "gt-b", Title: "Done already", Status: "closed", Labels: []string{"gastown"}},
+		&beads.Issue{ID: "gt-c", Title: "Not synced", Status: "open"},
+	)
+	state := &State{}
+	opts := Options{Label: "gastown", Assignees: map[string]string{"gastown/crew/max": "octocat"}}
+
+	res, err := Sync(context.Background(), conn, store, state, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Created != 1 || res.Imported != 1 {
+		t.Fatalf("result = %+v, want 1 created, 1 imported", res)
+	}
+	if len(state.Links) != 2 {
+		t.Fatalf("links = %+v, want 2", state.Links)
+	}
+	imported := store.issues["gt-new1"]
+	if imported == nil || imported.Title != "From tracker" || imported.Assignee != "gastown/crew/max" {
+		t.Errorf("imported bead = %+v", imported)
+	}
+
+	// A second sync with no changes is a no-op.
+	res, err = Sync(context.Background(), conn, store, state, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Created+res.Imported+res.Pushed+res.Pulled != 0 || len(res.Conflicts) != 0 {
+		t.Errorf("second sync = %+v, want no changes", res)
+	}
+}
+
+func TestSync_PropagatesChangesBothWays(t *testing.T) {
+	conn := newFakeConnector(Issue{ID: "1", Title: "Old title", Status: StatusOpen}, Issue{ID: "2", Title: "Two", Status: StatusOpen})
+	store := newFakeStore(
+		&beads.Issue{ID: "gt-a", Title: "Old title", Status: "open", Labels: []string{"gastown"}},
+		&beads.Issue{ID: "gt-b", Title: "Two", Status: "open", Labels: []string{"gastown"}},
+	)
+	state := &State{Links: []Link{
+		{BeadID: "gt-a", IssueID: "1", Synced: Snapshot{Title: "Old title", Status: StatusOpen}},
+		{BeadID: "gt-b", IssueID: "2", Synced: Snapshot{Title: "Two", Status: StatusOpen}},
+	}}
+
+	// Bead a closed locally; issue 2 retitled upstream.
+	store.issues["gt-a"].Status = "closed"
+	conn.issues["2"].Title = "Two (renamed)"
+
+	res, err := Sync(context.Background(), conn, store, state, Options{Label: "gastown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Pushed != 1 || res.Pulled != 1 {
+		t.Fatalf("result = %+v, want 1 pushed, 1 pulled", res)
+	}
+	if conn.issues["1"].Status != StatusClosed {
+		t.Error("issue 1 should be closed from bead")
+	}
+	if store.issues["gt-b"].Title != "Two (renamed)" {
+		t.Errorf("bead gt-b title = %q, want pulled title", store.issues["gt-b"].Title)
+	}
+	if state.Links[0].Synced.Status != StatusClosed || state.Links[1].Synced.Title != "Two (renamed)" {
+		t.Errorf("synced snapshots not updated: %+v", state.Links)
+	}
+}
+
+func TestSync_ConflictKeepsBead(t *testing.T) {
+	conn := newFakeConnector(Issue{ID: "1", Title: "Tracker edit", Status: StatusOpen})
+	store := newFakeStore(&beads.Issue{ID: "gt-a", Title: "Bead edit", Status: "open", Labels: []string{"gastown"}})
+	state := &State{Links: []Link{{BeadID: "gt-a", IssueID: "1", Synced: Snapshot{Title: "Original", Status: StatusOpen}}}}
+
+	res, err := Sync(context.Background(), conn, store, state, Options{Label: "gastown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res.Conflicts) != 1 {
+		t.Fatalf("conflicts = %v, want 1", res.Conflicts)
+	}
+	if conn.issues["1"].Title != "Bead edit" {
+		t.Errorf("tracker title = %q, want bead's", conn.issues["1"].Title)
+	}
+}
+
+func TestSync_UnmappedAssigneeIsNotSynced(t *testing.T) {
+	conn := newFakeConnector(Issue{ID: "1", Title: "T", Status: StatusOpen, Assignee: "someone-else"})
+	store := newFakeStore(&beads.Issue{ID: "gt-a", Title: "T", Status: "open", Assignee: "gastown/polecats/nux", Labels: []string{"gastown"}})
+	state := &State{Links: []Link{{BeadID: "gt-a", IssueID: "1", Synced: Snapshot{Title: "T", Status: StatusOpen}}}}
+
+	res, err := Sync(context.Background(), conn, store, state, Options{Label: "gastown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Pushed+res.Pulled != 0 || len(conn.updates) != 0 {
+		t.Errorf("unmapped assignees should not sync: %+v, updates %v", res, conn.updates)
+	}
+	if store.issues["gt-a"].Assignee != "gastown/polecats/nux" {
+		t.Error("bead assignee should be untouched")
+	}
+}
+
+func TestSync_DryRunWritesNothing(t *testing.T) {
+	conn := newFakeConnector(Issue{ID: "1", Title: "New upstream", Status: StatusOpen})
+	store := newFakeStore(&beads.Issue{ID: "gt-a", Title: "New locally", Status: "open", Labels: []string{"gastown"}})
+	state := &State{}
+
+	res, err := Sync(context.Background(), conn, store, state, Options{Label: "gastown", DryRun: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.Created != 1 || res.Imported != 1 {
+		t.Errorf("result = %+v, want 1 created, 1 imported", res)
+	}
+	if len(conn.issues) != 1 || len(store.issues) != 1 || len(state.Links) != 0 || !state.LastSync.IsZero() {
+		t.Error("dry run should not write")
+	}
+}
+
+func TestStateRoundTrip(t *testing.T) {
+	path := StatePath(t.TempDir(), "github")
+	s, err := LoadState(path)
+	if err != nil || len(s.Links) != 0 {
+		t.Fatalf("LoadState(missing) = %+v, %v", s, err)
+	}
+	s.Links = append(s.Links, Link{BeadID: "gt-a", IssueID: "1", Synced: Snapshot{Title: "T", Status: StatusClosed}})
+	if err := SaveState(path, s); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Links) != 1 || got.Links[0] != s.Links[0] {
+		t.Errorf("round trip = %+v, want %+v", got.Links, s.Links)
+	}
+	if filepath.Base(filepath.Dir(path)) != "tracker-sync" {
+		t.Errorf("StatePath = %s", path)
+	}
+}
+
+func TestLockState_SerializesLoadSave(t *testing.T) {
+	path := StatePath(t.TempDir(), "github")
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			unlock, err := LockState(path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			defer unlock()
+			s, err := LoadState(path)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			s.Links = append(s.Links, Link{BeadID: fmt.Sprintf("gt-%d", i), IssueID: fmt.Sprint(i)})
+			if err := SaveState(path, s); err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	got, err := LoadState(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Links) != 8 {
+		t.Errorf("got %d links, want 8 (a concurrent save dropped some)", len(got.Links))
+	}
+}
+
+func TestNewGitHubConnector_ValidatesRepo(t *testing.T) {
+	for _, repo := range []string{"", "noslash", "/name", "owner/", "a/b/c"} {
+		if _, err := NewGitHubConnector(&config.GitHubSyncConfig{Repo: repo}); err == nil {
+			t.Errorf("repo %q should be rejected", repo)
+		}
+	}
+	t.Setenv("GITHUB_TOKEN", "x")
+	c, err := NewGitHubConnector(&config.GitHubSyncConfig{Repo: "octo/repo"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.label != config.DefaultTrackerSyncLabel {
+		t.Errorf("label = %q, want default", c.label)
+	}
+}
+
+func TestSync_ProgressFollowsTracker(t *testing.T) {
+	for _, progress := range []bool{true, false} {
+		conn := newFakeConnector(Issue{ID: "1", Title: "T", Status: StatusOpen})
+		conn.progress = progress
+		store := newFakeStore(&beads.Issue{ID: "gt-a", Title: "T", Status: "hooked", Labels: []string{"gastown"}})
+		state := &State{Links: []Link{{BeadID: "gt-a", IssueID: "1", Synced: Snapshot{Title: "T", Status: StatusOpen}}}}
+
+		res, err := SyncBead(context.Background(), conn, store, state, Options{Label: "gastown"}, "gt-a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := StatusOpen
+		if progress {
+			want = StatusInProgress
+		}
+		if conn.issues["1"].Status != want {
+			t.Errorf("progress=%v: tracker status = %q, want %q", progress, conn.issues["1"].Status, want)
+		}
+		if store.issues["gt-a"].Status != "hooked" {
+			t.Errorf("progress=%v: bead status changed to %q", progress, store.issues["gt-a"].Status)
+		}
+		if progress != (res.Pushed == 1) {
+			t.Errorf("progress=%v: result = %+v", progress, res)
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package picker
+
+import "github.com/charmbracelet/bubbles/key"
+
+// KeyMap defines the key bindings for the session picker. Letters are typed
+// into the filter, so navigation uses arrows and control keys only.
+type KeyMap struct {
+	Up     key.Binding
+	Down   key.Binding
+	Select key.Binding
+	Clear  key.Binding
+	Quit   key.Binding
+}
+
+// DefaultKeyMap returns the default key bindings.
+func DefaultKeyMap() KeyMap {
+	return KeyMap{
+		Up: key.NewBinding(
+			key.WithKeys("up", "ctrl+p", "ctrl+k"),
+			key.WithHelp("↑/ctrl+p", "up"),
+		),
+		Down: key.NewBinding(
+			key.WithKeys("down", "ctrl+n", "ctrl+j"),
+			key.WithHelp("↓/ctrl+n", "down"),
+		),
+		Select: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "attach"),
+		),
+		Clear: key.NewBinding(
+			key.WithKeys("ctrl+u"),
+			key.WithHelp("ctrl+u", "clear filter"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("esc", "ctrl+c"),
+			key.WithHelp("esc", "cancel"),
+		),
+	}
+}
+
+// ShortHelp returns keybindings to show in the help view.
+func (k KeyMap) ShortHelp() []key.Binding {
+	return []key.Binding{k.Up, k.Down, k.Select, k.Clear, k.Quit}
+}
+
+// FullHelp returns keybindings for the expanded help view.
+func (k KeyMap) FullHelp() [][]key.Binding {
+	return [][]key.Binding{k.ShortHelp()}
+}
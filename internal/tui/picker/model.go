@@ -0,0 +1,282 @@
+package picker
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// refreshInterval is how often the picker reloads session status.
+const refreshInterval = 2 * time.Second
+
+// Item is a session offered by the picker.
+type Item struct {
+	Session  string // tmux session name, e.g. "gt-Toast"
+	Address  string // agent address, e.g. "gastown/polecats/Toast"
+	Status   string // e.g. "working", "idle", "no agent"
+	Attached bool   // a client is already attached
+}
+
+// Loader returns the current set of sessions. It is called at startup and on
+// every refresh, so the picker shows live status.
+type Loader func() ([]Item, error)
+
+// Model is the bubbletea model for the session picker.
+type Model struct {
+	load Loader
+
+	items    []Item
+	matches  []Item
+	query    []rune
+	cursor   int
+	selected string
+	loaded   bool
+	err      error
+
+	// UI state
+	keys   KeyMap
+	help   help.Model
+	width  int
+	height int
+
+	// mu protects all fields read by View() from concurrent access.
+	mu sync.RWMutex
+}
+
+// New creates a picker that lists sessions returned by load.
+func New(load Loader) *Model {
+	return &Model{
+		load: load,
+		keys: DefaultKeyMap(),
+		help: help.New(),
+	}
+}
+
+// Selected returns the session chosen with enter, or "" if the picker was
+// canceled.
+func (m *Model) Selected() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.selected
+}
+
+// itemsMsg is the result of loading sessions.
+type itemsMsg struct {
+	items []Item
+	err   error
+}
+
+// tickMsg triggers a refresh.
+type tickMsg struct{}
+
+// Init initializes the model.
+func (m *Model) Init() tea.Cmd {
+	return tea.Batch(m.fetch, tick())
+}
+
+func (m *Model) fetch() tea.Msg {
+	items, err := m.load()
+	return itemsMsg{items: items, err: err}
+}
+
+func tick() tea.Cmd {
+	return tea.Tick(refreshInterval, func(time.Time) tea.Msg { return tickMsg{} })
+}
+
+// Update handles messages.
+func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.mu.Lock()
+		m.width = msg.Width
+		m.height = msg.Height
+		m.help.Width = msg.Width
+		m.mu.Unlock()
+		return m, nil
+
+	case tickMsg:
+		return m, tea.Batch(m.fetch, tick())
+
+	case itemsMsg:
+		m.mu.Lock()
+		m.err = msg.err
+		if msg.err == nil {
+			m.items = msg.items
+			m.loaded = true
+			m.refilterLocked(true)
+		}
+		m.mu.Unlock()
+		return m, nil
+
+	case tea.KeyMsg:
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		switch {
+		case key.Matches(msg, m.keys.Quit):
+			return m, tea.Quit
+
+		case key.Matches(msg, m.keys.Select):
+			if m.cursor < len(m.matches) {
+				m.selected = m.matches[m.cursor].Session
+				return m, tea.Quit
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Down):
+			if m.cursor < len(m.matches)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.Clear):
+			m.query = nil
+			m.refilterLocked(false)
+			return m, nil
+
+		case msg.Type == tea.KeyBackspace:
+			if len(m.query) > 0 {
+				m.query = m.query[:len(m.query)-1]
+				m.refilterLocked(false)
+			}
+			return m, nil
+
+		case msg.Type == tea.KeyRunes || msg.Type == tea.KeySpace:
+			m.query = append(m.query, msg.Runes...)
+			if msg.Type == tea.KeySpace {
+				m.query = append(m.query, ' ')
+			}
+			m.refilterLocked(false)
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+// View renders the picker.
+func (m *Model) View() string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.renderView()
+}
+
+// refilterLocked recomputes matches for the current query. With keepCursor
+// the cursor stays on the same session if it is still listed; otherwise it
+// moves to the best match.
+// Caller must hold m.mu.
+func (m *Model) refilterLocked(keepCursor bool) {
+	var current string
+	if keepCursor && m.cursor < len(m.matches) {
+		current = m.matches[m.cursor].Session
+	}
+	m.matches = Filter(m.items, string(m.query))
+	m.cursor = 0
+	for i, it := range m.matches {
+		if it.Session == current {
+			m.cursor = i
+			break
+		}
+	}
+}
+
+// Filter returns the items matching query, best match first. Items are
+// matched on their address and session name; an empty query keeps every
+// item in its original order. Whitespace separates terms that must all match.
+func Filter(items []Item, query string) []Item {
+	terms := strings.Fields(query)
+	type scored struct {
+		item  Item
+		score int
+		index int
+	}
+	var results []scored
+	for i, it := range items {
+		haystack := it.Address + " " + it.Session
+		total := 0
+		ok := true
+		for _, term := range terms {
+			s, match := fuzzyScore(term, haystack)
+			if !match {
+				ok = false
+				break
+			}
+			total += s
+		}
+		if ok {
+			results = append(results, scored{item: it, score: total, index: i})
+		}
+	}
+	sort.SliceStable(results, func(a, b int) bool {
+		if results[a].score != results[b].score {
+			return results[a].score > results[b].score
+		}
+		return results[a].index < results[b].index
+	})
+	out := make([]Item, len(results))
+	for i, r := range results {
+		out[i] = r.item
+	}
+	return out
+}
+
+// fuzzyScore reports whether pattern's runes appear in order in s, ignoring
+// case, and scores the best match: consecutive runes and runes at the start
+// of a word (after '/', '-', or a space) score higher, gaps score lower.
+func fuzzyScore(pattern, s string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	if len(p) == 0 {
+		return 0, true
+	}
+	r := []rune(strings.ToLower(s))
+	best, found := 0, false
+	// Greedy matching from the leftmost occurrence can miss a better
+	// alignment later on, so try every starting position.
+	for start := range r {
+		if r[start] != p[0] {
+			continue
+		}
+		if score, ok := fuzzyScoreFrom(p, r, start); ok && (!found || score > best) {
+			best, found = score, true
+		}
+	}
+	return best, found
+}
+
+// fuzzyScoreFrom greedily matches p in r starting at r[start].
+func fuzzyScoreFrom(p, r []rune, start int) (int, bool) {
+	score := 0
+	pi := 0
+	last := -1
+	for i := start; i < len(r) && pi < len(p); i++ {
+		if r[i] != p[pi] {
+			continue
+		}
+		score++
+		if last >= 0 && i == last+1 {
+			score += 5
+		} else if last >= 0 {
+			score -= min(i-last-1, 3)
+		}
+		if i == 0 || isWordBoundary(r[i-1]) {
+			score += 3
+		}
+		last = i
+		pi++
+	}
+	return score, pi == len(p)
+}
+
+func isWordBoundary(r rune) bool {
+	return r == '/' || r == '-' || r == '_' || unicode.IsSpace(r)
+}
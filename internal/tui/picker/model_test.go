@@ -0,0 +1,101 @@
+package picker
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func testItems() []Item {
+	return []Item{
+		{Session: "hq-mayor", Address: "mayor/", Status: "idle"},
+		{Session: "gt-witness", Address: "gastown/witness", Status: "working"},
+		{Session: "gt-Toast", Address: "gastown/polecats/Toast", Status: "working"},
+		{Session: "gt-crew-max", Address: "gastown/crew/max", Status: "idle"},
+		{Session: "bd-Toast", Address: "beads/polecats/Toast", Status: "no agent"},
+	}
+}
+
+func sessions(items []Item) string {
+	var names []string
+	for _, it := range items {
+		names = append(names, it.Session)
+	}
+	return strings.Join(names, ",")
+}
+
+func TestFilter(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"", "hq-mayor,gt-witness,gt-Toast,gt-crew-max,bd-Toast"},
+		{"toast", "gt-Toast,bd-Toast"},
+		{"gtoast", "gt-Toast"},
+		{"bd toast", "bd-Toast"},
+		{"cmax", "gt-crew-max"},
+		{"zzz", ""},
+	}
+	for _, tt := range tests {
+		if got := sessions(Filter(testItems(), tt.query)); got != tt.want {
+			t.Errorf("Filter(%q) = %s, want %s", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFuzzyScore_PrefersWordStarts(t *testing.T) {
+	wordStart, ok1 := fuzzyScore("wit", "gastown/witness")
+	scattered, ok2 := fuzzyScore("wit", "gastown/wisp-time")
+	if !ok1 || !ok2 {
+		t.Fatal("expected both to match")
+	}
+	if wordStart <= scattered {
+		t.Errorf("contiguous word-start score %d should beat scattered %d", wordStart, scattered)
+	}
+}
+
+func TestModel_TypeAndSelect(t *testing.T) {
+	m := New(func() ([]Item, error) { return testItems(), nil })
+	m.Update(m.fetch())
+
+	for _, r := range "crew" {
+		m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if got := sessions(m.matches); got != "gt-crew-max" {
+		t.Fatalf("matches = %s, want gt-crew-max", got)
+	}
+	if !strings.Contains(m.View(), "gastown/crew/max") {
+		t.Errorf("view does not show the match:\n%s", m.View())
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("enter should quit")
+	}
+	if m.Selected() != "gt-crew-max" {
+		t.Errorf("Selected() = %q, want gt-crew-max", m.Selected())
+	}
+}
+
+func TestModel_RefreshKeepsCursor(t *testing.T) {
+	m := New(func() ([]Item, error) { return testItems(), nil })
+	m.Update(m.fetch())
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	if m.matches[m.cursor].Session != "gt-Toast" {
+		t.Fatalf("cursor on %s, want gt-Toast", m.matches[m.cursor].Session)
+	}
+
+	// A new session appearing ahead of the cursor must not move the selection.
+	items := append([]Item{{Session: "hq-deacon", Address: "deacon/"}}, testItems()...)
+	m.Update(itemsMsg{items: items})
+	if m.matches[m.cursor].Session != "gt-Toast" {
+		t.Errorf("after refresh cursor on %s, want gt-Toast", m.matches[m.cursor].Session)
+	}
+
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	if cmd == nil || m.Selected() != "" {
+		t.Errorf("esc should quit without a selection (selected %q)", m.Selected())
+	}
+}
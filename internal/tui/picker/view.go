@@ -0,0 +1,121 @@
+package picker
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Styles for the session picker
+var (
+	titleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("12"))
+
+	promptStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("12"))
+
+	selectedStyle = lipgloss.NewStyle().
+			Background(lipgloss.Color("236")).
+			Foreground(lipgloss.Color("15"))
+
+	workingStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("10")) // green
+
+	idleStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("11")) // yellow
+
+	deadStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")) // red
+
+	dimStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("8")) // gray
+
+	errorStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("9")) // red
+)
+
+// chromeLines is the number of lines the view uses besides the session list.
+const chromeLines = 6
+
+// renderView renders the entire view.
+// Caller must hold m.mu.
+func (m *Model) renderView() string {
+	var b strings.Builder
+
+	b.WriteString(titleStyle.Render("Attach to session"))
+	b.WriteString(dimStyle.Render(fmt.Sprintf("  %d/%d", len(m.matches), len(m.items))))
+	b.WriteString("\n\n")
+	b.WriteString(promptStyle.Render("> "))
+	b.WriteString(string(m.query))
+	b.WriteString("█\n\n")
+
+	if m.err != nil {
+		b.WriteString(errorStyle.Render(fmt.Sprintf("Error: %v", m.err)))
+		b.WriteString("\n")
+	}
+
+	switch {
+	case !m.loaded && m.err == nil:
+		b.WriteString(dimStyle.Render("Loading sessions..."))
+		b.WriteString("\n")
+	case m.loaded && len(m.items) == 0:
+		b.WriteString("No Gas Town sessions running.\n")
+	case len(m.matches) == 0:
+		b.WriteString(dimStyle.Render("No sessions match."))
+		b.WriteString("\n")
+	}
+
+	start, end := m.visibleRangeLocked()
+	addrWidth := 0
+	for _, it := range m.matches[start:end] {
+		addrWidth = max(addrWidth, len(it.Address))
+	}
+	for i := start; i < end; i++ {
+		it := m.matches[i]
+		attached := " "
+		if it.Attached {
+			attached = "●"
+		}
+		line := fmt.Sprintf("%s %-*s  %-14s", attached, addrWidth, it.Address, it.Session)
+		status := statusStyle(it.Status).Render(it.Status)
+		if i == m.cursor {
+			b.WriteString(selectedStyle.Render(line + "  " + it.Status))
+		} else {
+			b.WriteString(line + "  " + status)
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.help.View(m.keys))
+	return b.String()
+}
+
+// visibleRangeLocked returns the slice of matches that fits on screen,
+// scrolled so the cursor is visible.
+// Caller must hold m.mu.
+func (m *Model) visibleRangeLocked() (int, int) {
+	n := len(m.matches)
+	rows := m.height - chromeLines
+	if m.height == 0 || rows >= n {
+		return 0, n
+	}
+	rows = max(rows, 1)
+	start := max(m.cursor-rows+1, 0)
+	return start, min(start+rows, n)
+}
+
+func statusStyle(status string) lipgloss.Style {
+	switch status {
+	case "working":
+		return workingStyle
+	case "idle":
+		return idleStyle
+	case "no agent":
+		return deadStyle
+	default:
+		return dimStyle
+	}
+}
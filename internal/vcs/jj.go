@@ -0,0 +1,220 @@
+package vcs
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/util"
+)
+
+// JJRepoDir is the rig-relative directory of a jj rig's repository. It is
+// backed by the rig's shared .repo.git, so bookmarks polecats create are
+// ordinary git branches to the refinery and the remote.
+const JJRepoDir = ".repo.jj"
+
+// ErrNoJJRepo is returned when a rig is configured for jj but has no
+// jj repository yet.
+var ErrNoJJRepo = errors.New("rig has no jj repository")
+
+// OpenJJ opens the rig's jj repository.
+func OpenJJ(rigPath string) (Backend, error) {
+	repo := filepath.Join(rigPath, JJRepoDir)
+	if _, err := os.Stat(filepath.Join(repo, ".jj")); err != nil {
+		return nil, fmt.Errorf("%w at %s (run 'gt rig vcs %s jj')", ErrNoJJRepo, repo, filepath.Base(rigPath))
+	}
+	return &jjBackend{rigPath: rigPath, repo: repo}, nil
+}
+
+// InitJJ creates the rig's jj repository on top of its shared .repo.git.
+// It is a no-op if the repository already exists.
+func InitJJ(rigPath string) error {
+	repo := filepath.Join(rigPath, JJRepoDir)
+	if _, err := os.Stat(filepath.Join(repo, ".jj")); err == nil {
+		return nil
+	}
+	bareRepoPath := filepath.Join(rigPath, ".repo.git")
+	if info, err := os.Stat(bareRepoPath); err != nil || !info.IsDir() {
+		return fmt.Errorf("jj needs the rig's shared repo at %s", bareRepoPath)
+	}
+	_, err := runJJ(rigPath, "git", "init", "--git-repo", bareRepoPath, repo)
+	return err
+}
+
+// jjBackend implements Backend with jj workspaces. Each worktree is a jj
+// workspace named after its rig-relative path, with a bookmark on its
+// working-copy commit standing in for the git branch.
+type jjBackend struct {
+	rigPath string
+	repo    string
+}
+
+func (b *jjBackend) Kind() string { return config.VCSJujutsu }
+
+func (b *jjBackend) Fetch(remote string) error {
+	_, err := runJJ(b.repo, "git", "fetch", "--remote", remote)
+	return err
+}
+
+func (b *jjBackend) RefExists(ref string) (bool, error) {
+	_, err := runJJ(b.repo, "log", "--no-graph", "-r", jjRevision(ref), "-T", "commit_id", "--limit", "1")
+	if err != nil {
+		if strings.Contains(err.Error(), "doesn't exist") {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (b *jjBackend) WorktreeAdd(path, branch, startPoint string) error {
+	name, err := b.workspaceName(path)
+	if err != nil {
+		return err
+	}
+	fl, err := LockWorktrees(b.rigPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+	if _, err := runJJ(b.repo, "workspace", "add", "--name", name, "-r", jjRevision(startPoint), path); err != nil {
+		return err
+	}
+	if _, err := runJJ(path, "bookmark", "create", branch, "-r", "@"); err != nil {
+		_, _ = runJJ(b.repo, "workspace", "forget", name)
+		_ = os.RemoveAll(path)
+		return err
+	}
+	return nil
+}
+
+func (b *jjBackend) WorktreeRemove(path string, force bool) error {
+	name, err := b.workspaceName(path)
+	if err != nil {
+		return err
+	}
+	if !force {
+		if st, err := b.Status(path); err == nil && !st.Clean {
+			return fmt.Errorf("workspace %s has uncommitted changes (use force)", name)
+		}
+	}
+	fl, err := LockWorktrees(b.rigPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+	if _, err := runJJ(b.repo, "workspace", "forget", name); err != nil {
+		return err
+	}
+	return os.RemoveAll(path)
+}
+
+func (b *jjBackend) WorktreePrune() error {
+	fl, err := LockWorktrees(b.rigPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+	out, err := runJJ(b.repo, "workspace", "list")
+	if err != nil {
+		return err
+	}
+	for _, line := range strings.Split(out, "\n") {
+		name, _, ok := strings.Cut(line, ":")
+		if !ok || name == "default" {
+			continue
+		}
+		if _, err := os.Stat(filepath.Join(b.rigPath, filepath.FromSlash(name))); os.IsNotExist(err) {
+			if _, err := runJJ(b.repo, "workspace", "forget", name); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (b *jjBackend) Status(dir string) (*Status, error) {
+	out, err := runJJ(dir, "diff", "--summary", "-r", "@")
+	if err != nil {
+		return nil, err
+	}
+	st := &Status{Clean: true}
+	for _, line := range strings.Split(out, "\n") {
+		kind, path, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		st.Clean = false
+		switch kind {
+		case "A", "C":
+			st.Added = append(st.Added, path)
+		case "D":
+			st.Deleted = append(st.Deleted, path)
+		default:
+			st.Modified = append(st.Modified, path)
+		}
+	}
+	return st, nil
+}
+
+// CurrentBranch returns the bookmark on the working-copy commit, or on its
+// parent after 'jj commit' has moved the working copy past it.
+func (b *jjBackend) CurrentBranch(dir string) (string, error) {
+	for _, rev := range []string{"@", "@-"} {
+		out, err := runJJ(dir, "log", "--no-graph", "-r", rev, "-T", `local_bookmarks.map(|b| b.name()).join("\n")`)
+		if err != nil {
+			return "", err
+		}
+		if name, _, _ := strings.Cut(out, "\n"); name != "" {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no bookmark on @ or @- in %s", dir)
+}
+
+func (b *jjBackend) DeleteBranch(name string, force bool) error {
+	_, err := runJJ(b.repo, "bookmark", "delete", name)
+	return err
+}
+
+// workspaceName names the workspace at path after its rig-relative path
+// (e.g. "polecats/toast/gastown"), so WorktreePrune can find it again.
+func (b *jjBackend) workspaceName(path string) (string, error) {
+	rel, err := filepath.Rel(b.rigPath, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return "", fmt.Errorf("workspace %s is outside rig %s", path, b.rigPath)
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// jjRevision translates a git remote-tracking ref ("origin/main") to jj's
+// remote bookmark syntax ("main@origin"). Other refs pass through.
+func jjRevision(ref string) string {
+	if branch, ok := strings.CutPrefix(ref, "origin/"); ok {
+		return branch + "@origin"
+	}
+	return ref
+}
+
+// runJJ runs jj in dir and returns its trimmed stdout.
+func runJJ(dir string, args ...string) (string, error) {
+	cmd := exec.Command("jj", append([]string{"--no-pager", "--color=never"}, args...)...)
+	util.SetDetachedProcessGroup(cmd)
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return "", fmt.Errorf("jj %s: %s", args[0], msg)
+	}
+	return strings.TrimSpace(stdout.String()), nil
+}
@@ -0,0 +1,30 @@
+package vcs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+)
+
+// LockWorktrees acquires the rig's exclusive worktree lock. It serializes
+// worktree removal and prune across gt processes (a Witness cleanup and a
+// manual 'gt polecat remove', say), which would otherwise race on the
+// shared repo's worktree metadata. Backends take it themselves in
+// WorktreeAdd, WorktreeRemove and WorktreePrune; code that manages
+// worktrees with git directly must take it around those calls.
+//
+// The lock is not reentrant: don't call Backend worktree methods while
+// holding it. Caller must defer fl.Unlock().
+func LockWorktrees(rigPath string) (*flock.Flock, error) {
+	lockDir := filepath.Join(rigPath, ".runtime", "locks")
+	if err := os.MkdirAll(lockDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating lock dir: %w", err)
+	}
+	fl := flock.New(filepath.Join(lockDir, "worktrees.lock"))
+	if err := fl.Lock(); err != nil {
+		return nil, fmt.Errorf("acquiring worktree lock: %w", err)
+	}
+	return fl, nil
+}
@@ -0,0 +1,135 @@
+// Package vcs abstracts the version-control operations gt performs on a
+// rig's repository for polecat worktrees, so a rig can use git (the
+// default) or Jujutsu.
+package vcs
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/git"
+)
+
+// Status is the state of a worktree's uncommitted changes.
+type Status = git.GitStatus
+
+// Backend is a rig's shared repository. Worktree paths are absolute;
+// refs use git's remote-tracking form ("origin/main"), which each backend
+// translates as needed.
+type Backend interface {
+	// Kind returns the backend name (config.VCSGit or config.VCSJujutsu).
+	Kind() string
+
+	// Fetch updates remote-tracking refs from remote.
+	Fetch(remote string) error
+
+	// RefExists reports whether ref resolves to a commit.
+	RefExists(ref string) (bool, error)
+
+	// WorktreeAdd creates a worktree at path on a new branch started at
+	// startPoint.
+	WorktreeAdd(path, branch, startPoint string) error
+
+	// WorktreeRemove removes the worktree at path. Without force it
+	// refuses when the worktree has uncommitted changes.
+	WorktreeRemove(path string, force bool) error
+
+	// WorktreePrune drops registrations of worktrees whose directories
+	// are gone.
+	WorktreePrune() error
+
+	// Status returns the uncommitted changes in the worktree at dir.
+	Status(dir string) (*Status, error)
+
+	// CurrentBranch returns the branch the worktree at dir is working on.
+	CurrentBranch(dir string) (string, error)
+
+	// DeleteBranch deletes a branch. force deletes it even if unmerged
+	// (git only; jj keeps the commits in its operation log).
+	DeleteBranch(name string, force bool) error
+}
+
+// RigKind returns the VCS configured for the rig at rigPath in
+// mayor/rigs.json, config.VCSGit when unset.
+func RigKind(rigPath string) string {
+	townRoot, name := filepath.Dir(rigPath), filepath.Base(rigPath)
+	if rigsCfg, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json")); err == nil {
+		if entry, ok := rigsCfg.Rigs[name]; ok && entry.VCS != "" {
+			return entry.VCS
+		}
+	}
+	return config.VCSGit
+}
+
+// ForRig opens the backend the rig at rigPath is configured to use.
+func ForRig(rigPath string) (Backend, error) {
+	if RigKind(rigPath) == config.VCSJujutsu {
+		return OpenJJ(rigPath)
+	}
+	return OpenGit(rigPath)
+}
+
+// OpenGit opens the rig's git repository: the shared bare repo, or the
+// mayor's clone for rigs that predate it.
+func OpenGit(rigPath string) (Backend, error) {
+	bareRepoPath := filepath.Join(rigPath, ".repo.git")
+	if info, err := os.Stat(bareRepoPath); err == nil && info.IsDir() {
+		return &gitBackend{rigPath: rigPath, repo: git.NewGitWithDir(bareRepoPath, "")}, nil
+	}
+	mayorPath := filepath.Join(rigPath, "mayor", "rig")
+	if _, err := os.Stat(mayorPath); os.IsNotExist(err) {
+		return nil, fmt.Errorf("no repo base found (neither .repo.git nor mayor/rig exists)")
+	}
+	return &gitBackend{rigPath: rigPath, repo: git.NewGit(mayorPath)}, nil
+}
+
+// gitBackend implements Backend with git worktrees.
+type gitBackend struct {
+	rigPath string
+	repo    *git.Git
+}
+
+func (b *gitBackend) Kind() string { return config.VCSGit }
+
+func (b *gitBackend) Fetch(remote string) error { return b.repo.Fetch(remote) }
+
+func (b *gitBackend) RefExists(ref string) (bool, error) { return b.repo.RefExists(ref) }
+
+func (b *gitBackend) WorktreeAdd(path, branch, startPoint string) error {
+	fl, err := LockWorktrees(b.rigPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+	return b.repo.WorktreeAddFromRef(path, branch, startPoint)
+}
+
+func (b *gitBackend) WorktreeRemove(path string, force bool) error {
+	fl, err := LockWorktrees(b.rigPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+	return b.repo.WorktreeRemove(path, force)
+}
+
+func (b *gitBackend) WorktreePrune() error {
+	fl, err := LockWorktrees(b.rigPath)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = fl.Unlock() }()
+	return b.repo.WorktreePrune()
+}
+
+func (b *gitBackend) Status(dir string) (*Status, error) { return git.NewGit(dir).Status() }
+
+func (b *gitBackend) CurrentBranch(dir string) (string, error) {
+	return git.NewGit(dir).CurrentBranch()
+}
+
+func (b *gitBackend) DeleteBranch(name string, force bool) error {
+	return b.repo.DeleteBranch(name, force)
+}
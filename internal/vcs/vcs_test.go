@@ -0,0 +1,214 @@
+package vcs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+)
+
+func TestRigKind(t *testing.T) {
+	townRoot := t.TempDir()
+	rigsCfg := &config.RigsConfig{Version: config.CurrentRigsVersion, Rigs: map[string]config.RigEntry{
+		"plain": {},
+		"jjrig": {VCS: config.VCSJujutsu},
+	}}
+	if err := config.SaveRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"), rigsCfg); err != nil {
+		t.Fatal(err)
+	}
+	for rig, want := range map[string]string{"plain": config.VCSGit, "jjrig": config.VCSJujutsu, "unknown": config.VCSGit} {
+		if got := RigKind(filepath.Join(townRoot, rig)); got != want {
+			t.Errorf("RigKind(%s) = %q, want %q", rig, got, want)
+		}
+	}
+}
+
+func TestGitBackend_WorktreeLifecycle(t *testing.T) {
+	gitEnv := append(os.Environ(), "GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_SYSTEM=/dev/null",
+		"GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+	src := t.TempDir()
+	rigPath := t.TempDir()
+	for _, args := range [][]string{
+		{"init", "-b", "main", src},
+		{"-C", src, "commit", "--allow-empty", "-m", "init"},
+		{"clone", "--bare", src, filepath.Join(rigPath, ".repo.git")},
+		{"-C", filepath.Join(rigPath, ".repo.git"), "config", "remote.origin.fetch", "+refs/heads/*:refs/remotes/origin/*"},
+		{"-C", filepath.Join(rigPath, ".repo.git"), "fetch", "origin"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Env = gitEnv
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %s", args, out)
+		}
+	}
+
+	b, err := ForRig(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Kind() != config.VCSGit {
+		t.Fatalf("Kind() = %q, want git", b.Kind())
+	}
+	if ok, err := b.RefExists("origin/main"); err != nil || !ok {
+		t.Fatalf("RefExists(origin/main) = %v, %v", ok, err)
+	}
+
+	wt := filepath.Join(rigPath, "polecats", "toast", "rig")
+	if err := b.WorktreeAdd(wt, "polecat/toast", "origin/main"); err != nil {
+		t.Fatalf("WorktreeAdd: %v", err)
+	}
+	if branch, err := b.CurrentBranch(wt); err != nil || branch != "polecat/toast" {
+		t.Errorf("CurrentBranch = %q, %v", branch, err)
+	}
+	if err := os.WriteFile(filepath.Join(wt, "new.txt"), []byte("x"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if st, err := b.Status(wt); err != nil || st.Clean {
+		t.Errorf("Status after write = %+v, %v; want dirty", st, err)
+	}
+	if err := b.WorktreeRemove(wt, true); err != nil {
+		t.Fatalf("WorktreeRemove: %v", err)
+	}
+	if _, err := os.Stat(wt); !os.IsNotExist(err) {
+		t.Errorf("worktree still exists after removal")
+	}
+	if err := b.DeleteBranch("polecat/toast", true); err != nil {
+		t.Errorf("DeleteBranch: %v", err)
+	}
+}
+
+// installFakeJJ puts a jj on PATH that logs its arguments and prints
+// canned output for the subcommands the backend parses.
+func installFakeJJ(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake jj is a shell script")
+	}
+	binDir := t.TempDir()
+	logPath := filepath.Join(binDir, "jj.log")
+	script := `#!/bin/sh
+echo "$@" >> "` + logPath + `"
+case "$*" in
+  *"workspace list"*) printf 'default: abc123 (empty)\npolecats/toast/rig: def456 wip\npolecats/gone/rig: 789abc wip\n' ;;
+  *"diff --summary"*) printf 'M main.go\nA new.go\n' ;;
+  *"log --no-graph -r @ -T"*) printf 'polecat/toast\n' ;;
+  *"missing@origin"*) echo "Error: Revision ` + "`missing@origin`" + ` doesn't exist" >&2; exit 1 ;;
+esac
+exit 0
+`
+	if err := os.WriteFile(filepath.Join(binDir, "jj"), []byte(script), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	return logPath
+}
+
+func TestJJBackend(t *testing.T) {
+	logPath := installFakeJJ(t)
+	rigPath := t.TempDir()
+
+	if _, err := OpenJJ(rigPath); err == nil {
+		t.Fatal("OpenJJ without .repo.jj should fail")
+	}
+	if err := os.MkdirAll(filepath.Join(rigPath, JJRepoDir, ".jj"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	b, err := OpenJJ(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := b.RefExists("origin/main"); err != nil || !ok {
+		t.Errorf("RefExists(origin/main) = %v, %v", ok, err)
+	}
+	if ok, err := b.RefExists("origin/missing"); err != nil || ok {
+		t.Errorf("RefExists(origin/missing) = %v, %v; want false, nil", ok, err)
+	}
+
+	wt := filepath.Join(rigPath, "polecats", "toast", "rig")
+	if err := os.MkdirAll(wt, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.WorktreeAdd(wt, "polecat/toast", "origin/main"); err != nil {
+		t.Fatalf("WorktreeAdd: %v", err)
+	}
+	if branch, err := b.CurrentBranch(wt); err != nil || branch != "polecat/toast" {
+		t.Errorf("CurrentBranch = %q, %v", branch, err)
+	}
+	st, err := b.Status(wt)
+	if err != nil || st.Clean || len(st.Modified) != 1 || len(st.Added) != 1 {
+		t.Errorf("Status = %+v, %v", st, err)
+	}
+	if err := b.WorktreeRemove(wt, false); err == nil {
+		t.Error("WorktreeRemove without force should refuse a dirty workspace")
+	}
+	if err := b.WorktreeRemove(wt, true); err != nil {
+		t.Fatalf("WorktreeRemove(force): %v", err)
+	}
+	if err := os.MkdirAll(wt, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := b.WorktreePrune(); err != nil {
+		t.Fatalf("WorktreePrune: %v", err)
+	}
+
+	data, err := os.ReadFile(logPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	log := string(data)
+	for _, want := range []string{
+		"workspace add --name polecats/toast/rig -r main@origin " + wt,
+		"bookmark create polecat/toast -r @",
+		"workspace forget polecats/toast/rig",
+		"workspace forget polecats/gone/rig",
+	} {
+		if !strings.Contains(log, want) {
+			t.Errorf("jj was not run with %q; log:\n%s", want, log)
+		}
+	}
+	if strings.Count(log, "workspace forget polecats/toast/rig") != 1 {
+		t.Errorf("prune should not forget a workspace whose directory exists; log:\n%s", log)
+	}
+}
+
+func TestWorktreePruneWaitsForLock(t *testing.T) {
+	rigPath := t.TempDir()
+	cmd := exec.Command("git", "init", "--bare", filepath.Join(rigPath, ".repo.git"))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git init: %s", out)
+	}
+	b, err := OpenGit(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fl, err := LockWorktrees(rigPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() { done <- b.WorktreePrune() }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("WorktreePrune returned (%v) while the rig's worktree lock was held", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+	if err := fl.Unlock(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("WorktreePrune: %v", err)
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("WorktreePrune did not finish after the lock was released")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -263,12 +264,26 @@ func (h *APIHandler) runGtCommand(ctx context.Context, timeout time.Duration, ar
 	}
 
 	if err != nil {
-		return output, fmt.Errorf("command failed: %v", err)
+		return output, fmt.Errorf("command failed: %w", err)
 	}
 
 	return output, nil
 }
 
+// statusOutputUsable reports whether 'gt status' output can be used despite
+// err: status exits 2 (degraded) or 3 (down) after printing a full report.
+func statusOutputUsable(err error) bool {
+	if err == nil {
+		return true
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(err, &exitErr) {
+		return false
+	}
+	code := exitErr.ExitCode()
+	return code == 2 || code == 3
+}
+
 // sendError sends a JSON error response.
 func (h *APIHandler) sendError(w http.ResponseWriter, message string, status int) {
 	w.Header().Set("Content-Type", "application/json")
@@ -795,7 +810,7 @@ func (h *APIHandler) handleOptions(w http.ResponseWriter, r *http.Request) {
 	// Fetch agents - shorter timeout, skip if slow
 	go func() {
 		defer wg.Done()
-		if output, err := h.runGtCommand(r.Context(), 5*time.Second, []string{"status", "--json"}); err == nil {
+		if output, err := h.runGtCommand(r.Context(), 5*time.Second, []string{"status", "--json"}); statusOutputUsable(err) {
 			mu.Lock()
 			resp.Agents = parseAgentsFromStatus(output)
 			mu.Unlock()
@@ -2121,7 +2136,7 @@ func (h *APIHandler) computeDashboardHash(ctx context.Context) string {
 	// Check worker/polecat state
 	go func() {
 		defer wg.Done()
-		if out, err := h.runGtCommand(ctx, 3*time.Second, []string{"status", "--json"}); err == nil {
+		if out, err := h.runGtCommand(ctx, 3*time.Second, []string{"status", "--json"}); statusOutputUsable(err) {
 			mu.Lock()
 			parts = append(parts, "status:"+out)
 			mu.Unlock()
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"os/exec"
 	"strings"
 	"sync"
 	"testing"
@@ -1158,3 +1159,25 @@ func TestHandleSessionPreviewPrefixValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusOutputUsable(t *testing.T) {
+	exitErr := func(code string) error {
+		return exec.Command("sh", "-c", "exit "+code).Run()
+	}
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"success", nil, true},
+		{"degraded", exitErr("2"), true},
+		{"down", exitErr("3"), true},
+		{"error", exitErr("1"), false},
+		{"timeout", context.DeadlineExceeded, false},
+	}
+	for _, tt := range tests {
+		if got := statusOutputUsable(tt.err); got != tt.want {
+			t.Errorf("%s: statusOutputUsable = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
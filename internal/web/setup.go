@@ -375,7 +375,7 @@ func (h *SetupAPIHandler) handleCheckWorkspace(w http.ResponseWriter, r *http.Re
 func (h *SetupAPIHandler) handleStatus(w http.ResponseWriter, r *http.Request) {
 	// Check if we can find a workspace now
 	output, err := h.runGtCommand(r.Context(), 5*time.Second, []string{"status"})
-	if err != nil {
+	if !statusOutputUsable(err) {
 		h.sendJSON(w, SetupResponse{
 			Success: false,
 			Error:   "No workspace configured",
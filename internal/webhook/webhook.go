@@ -0,0 +1,200 @@
+// Package webhook notifies external HTTP endpoints when beads change state,
+// so dashboards and chat bots can react without polling the town.
+//
+// Deliveries come from two sources: gt's own state changes, read from the
+// events log (sling and hook → hooked, done → done, escalation → escalated),
+// and a periodic scan for beads agents have moved to in_progress with bd
+// directly, which gt never sees happen.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+// Bead states a webhook can subscribe to.
+const (
+	StateHooked     = "hooked"
+	StateInProgress = "in_progress"
+	StateDone       = "done"
+	StateEscalated  = "escalated"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of a delivery's body.
+const SignatureHeader = "X-Gastown-Signature"
+
+// Delivery is the JSON body POSTed to webhooks.
+type Delivery struct {
+	Event     string                 `json:"event"` // "bead.<state>"
+	State     string                 `json:"state"`
+	Bead      string                 `json:"bead"`
+	Actor     string                 `json:"actor,omitempty"`
+	Timestamp string                 `json:"ts"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+}
+
+// NewDelivery returns a delivery for a bead entering state.
+func NewDelivery(state, bead, actor string, at time.Time, details map[string]interface{}) Delivery {
+	return Delivery{
+		Event:     "bead." + state,
+		State:     state,
+		Bead:      bead,
+		Actor:     actor,
+		Timestamp: at.UTC().Format(time.RFC3339),
+		Details:   details,
+	}
+}
+
+// FromEvent maps an events log entry to a delivery. ok is false for events
+// that aren't bead state changes.
+func FromEvent(e events.Event) (d Delivery, ok bool) {
+	var state, beadKey string
+	switch e.Type {
+	case events.TypeSling, events.TypeHook:
+		state, beadKey = StateHooked, "bead"
+	case events.TypeDone:
+		state, beadKey = StateDone, "bead"
+	case events.TypeEscalationSent:
+		// EscalationPayload stores the escalation bead under "rig".
+		state, beadKey = StateEscalated, "rig"
+	default:
+		return Delivery{}, false
+	}
+	bead, _ := e.Payload[beadKey].(string)
+	if bead == "" {
+		return Delivery{}, false
+	}
+
+	details := make(map[string]interface{}, len(e.Payload))
+	for k, v := range e.Payload {
+		if k != beadKey {
+			details[k] = v
+		}
+	}
+	if len(details) == 0 {
+		details = nil
+	}
+	at, err := time.Parse(time.RFC3339, e.Timestamp)
+	if err != nil {
+		at = time.Now()
+	}
+	return NewDelivery(state, bead, e.Actor, at, details), true
+}
+
+// wants reports whether hook subscribes to state.
+func wants(hook *config.WebhookConfig, state string) bool {
+	return len(hook.States) == 0 || slices.Contains(hook.States, state)
+}
+
+// Sign returns the SignatureHeader value for body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// resolveSecret reads "$NAME" secrets from the environment.
+func resolveSecret(secret string) string {
+	if name, ok := strings.CutPrefix(secret, "$"); ok {
+		return os.Getenv(name)
+	}
+	return secret
+}
+
+// Sender POSTs deliveries, retrying transient failures.
+type Sender struct {
+	client  *http.Client
+	retries int
+	backoff time.Duration
+}
+
+// NewSender returns a sender using client, or a client with a 10s timeout
+// when nil.
+func NewSender(client *http.Client) *Sender {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Sender{client: client, retries: 3, backoff: time.Second}
+}
+
+// Send delivers d to every hook subscribed to its state. A hook that still
+// fails after retries is reported in the error; the others are unaffected.
+func (s *Sender) Send(ctx context.Context, hooks []*config.WebhookConfig, d Delivery) error {
+	body, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("marshaling delivery: %w", err)
+	}
+	var errs []error
+	for _, hook := range hooks {
+		if hook == nil || hook.URL == "" || !wants(hook, d.State) {
+			continue
+		}
+		if err := s.post(ctx, hook, body); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", hook.URL, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// errPermanent marks a response that retrying won't fix.
+var errPermanent = errors.New("permanent failure")
+
+func (s *Sender) post(ctx context.Context, hook *config.WebhookConfig, body []byte) error {
+	var err error
+	for attempt := 0; attempt < s.retries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(s.backoff << (attempt - 1)):
+			}
+		}
+		if err = s.postOnce(ctx, hook, body); err == nil || errors.Is(err, errPermanent) {
+			return err
+		}
+	}
+	return err
+}
+
+func (s *Sender) postOnce(ctx context.Context, hook *config.WebhookConfig, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, "POST", hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", errPermanent, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "gastown-webhook")
+	if secret := resolveSecret(hook.Secret); secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	_ = resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return fmt.Errorf("status %d", resp.StatusCode)
+	default:
+		return fmt.Errorf("%w: status %d", errPermanent, resp.StatusCode)
+	}
+}
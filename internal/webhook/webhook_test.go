@@ -0,0 +1,123 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/events"
+)
+
+func TestFromEvent(t *testing.T) {
+	tests := []struct {
+		name      string
+		event     events.Event
+		wantOK    bool
+		wantState string
+		wantBead  string
+	}{
+		{"sling", events.Event{Type: events.TypeSling, Payload: events.SlingPayload("gt-1", "gastown/polecats/toast")}, true, StateHooked, "gt-1"},
+		{"hook", events.Event{Type: events.TypeHook, Payload: events.HookPayload("gt-2")}, true, StateHooked, "gt-2"},
+		{"done", events.Event{Type: events.TypeDone, Payload: events.DonePayload("gt-3", "polecat/toast")}, true, StateDone, "gt-3"},
+		{"escalation", events.Event{Type: events.TypeEscalationSent, Payload: events.EscalationPayload("hq-9", "toast", "mayor/", "stuck")}, true, StateEscalated, "hq-9"},
+		{"mail", events.Event{Type: events.TypeMail, Payload: events.MailPayload("mayor/", "hi")}, false, "", ""},
+		{"no bead", events.Event{Type: events.TypeDone, Payload: map[string]interface{}{}}, false, "", ""},
+	}
+	for _, tt := range tests {
+		tt.event.Timestamp = "2026-03-10T12:00:00Z"
+		d, ok := FromEvent(tt.event)
+		if ok != tt.wantOK {
+			t.Errorf("%s: ok = %v, want %v", tt.name, ok, tt.wantOK)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if d.State != tt.wantState || d.Bead != tt.wantBead || d.Event != "bead."+tt.wantState {
+			t.Errorf("%s: delivery = %+v", tt.name, d)
+		}
+		if d.Timestamp != "2026-03-10T12:00:00Z" {
+			t.Errorf("%s: ts = %s", tt.name, d.Timestamp)
+		}
+	}
+
+	d, _ := FromEvent(events.Event{Type: events.TypeDone, Payload: events.DonePayload("gt-3", "polecat/toast")})
+	if _, dup := d.Details["bead"]; dup || d.Details["branch"] != "polecat/toast" {
+		t.Errorf("details = %v, want branch only", d.Details)
+	}
+}
+
+func TestSend_SignsAndFiltersByState(t *testing.T) {
+	t.Setenv("HOOK_SECRET", "s3cret")
+	var got []Delivery
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if sig := r.Header.Get(SignatureHeader); sig != Sign("s3cret", body) {
+			t.Errorf("signature = %q", sig)
+		}
+		var d Delivery
+		_ = json.Unmarshal(body, &d)
+		got = append(got, d)
+	}))
+	defer srv.Close()
+
+	hooks := []*config.WebhookConfig{
+		{URL: srv.URL, Secret: "$HOOK_SECRET", States: []string{StateDone}},
+	}
+	s := NewSender(srv.Client())
+	now := time.Now()
+	if err := s.Send(context.Background(), hooks, NewDelivery(StateHooked, "gt-1", "", now, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send(context.Background(), hooks, NewDelivery(StateDone, "gt-1", "toast", now, nil)); err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Event != "bead.done" || got[0].Actor != "toast" {
+		t.Errorf("deliveries = %+v, want only bead.done", got)
+	}
+}
+
+func TestSend_RetriesTransientFailures(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client())
+	s.backoff = time.Millisecond
+	hooks := []*config.WebhookConfig{{URL: srv.URL}}
+	if err := s.Send(context.Background(), hooks, NewDelivery(StateDone, "gt-1", "", time.Now(), nil)); err != nil {
+		t.Fatalf("Send: %v", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("calls = %d, want 3", calls.Load())
+	}
+}
+
+func TestSend_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	s := NewSender(srv.Client())
+	s.backoff = time.Millisecond
+	hooks := []*config.WebhookConfig{{URL: srv.URL}}
+	if err := s.Send(context.Background(), hooks, NewDelivery(StateDone, "gt-1", "", time.Now(), nil)); err == nil {
+		t.Error("expected an error for 401")
+	}
+	if calls.Load() != 1 {
+		t.Errorf("calls = %d, want 1", calls.Load())
+	}
+}
@@ -2,6 +2,7 @@ package witness
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -52,6 +53,19 @@ func workDirToTownRoot(workDir string) string {
 	return workDir
 }
 
+// polecatTmux returns the tmux that runs a rig's polecat sessions: on the
+// rig's remote host when rigs.json sets one, otherwise the local server.
+// Polecat liveness checks must go through it, or every remote polecat
+// looks dead.
+func polecatTmux(townRoot, rigName string) *tmux.Tmux {
+	t := tmux.NewTmux()
+	rigsConfig, err := config.LoadRigsConfig(filepath.Join(townRoot, "mayor", "rigs.json"))
+	if err != nil {
+		return t
+	}
+	return t.OnHost(strings.TrimSpace(rigsConfig.Rigs[rigName].RemoteHost))
+}
+
 // registryMu serializes calls to initRegistryFromTownRoot so that concurrent
 // callers (including parallel tests) don't race on the global registries.
 var registryMu sync.Mutex
@@ -62,20 +76,59 @@ var registryMu sync.Mutex
 type BdCli struct {
 	Exec func(workDir string, args ...string) (string, error)
 	Run  func(workDir string, args ...string) error
+
+	// Show reads a single bead through the beads client. Optional: when
+	// nil, reads go through Exec and the bd show JSON is decoded here.
+	Show func(workDir, id string) (*beads.Issue, error)
+}
+
+// show reads one bead, returning beads.ErrNotFound when it doesn't exist.
+func (bd *BdCli) show(workDir, id string) (*beads.Issue, error) {
+	if bd.Show != nil {
+		return bd.Show(workDir, id)
+	}
+	output, err := bd.Exec(workDir, "show", id, "--json")
+	if err != nil {
+		return nil, err
+	}
+	if output == "" {
+		return nil, fmt.Errorf("bd show %s: empty output", id)
+	}
+	// bd show --json returns an array
+	var issues []*beads.Issue
+	if err := json.Unmarshal([]byte(output), &issues); err != nil {
+		return nil, fmt.Errorf("parsing bd show output: %w", err)
+	}
+	if len(issues) == 0 {
+		return nil, beads.ErrNotFound
+	}
+	return issues[0], nil
 }
 
 // DefaultBdCli returns a BdCli that shells out to the real bd binary.
 func DefaultBdCli() *BdCli {
 	return &BdCli{
 		Exec: func(workDir string, args ...string) (string, error) {
+			// Hooked-work and ready-queue scans go through the shared
+			// bead query cache; patrols repeat them every cycle.
+			if beads.CacheableQuery(args) {
+				out, err := beads.New(workDir).Run(args...)
+				return strings.TrimSpace(string(out)), err
+			}
+			// Writes must invalidate that cache; reads are a no-op.
+			defer beads.InvalidateQueryCache(workDir, os.Getenv("BEADS_DIR"), args)
 			// bd v0.59+ requires --flat for list --json to produce JSON
 			args = beads.InjectFlatForListJSON(args)
 			return util.ExecWithOutput(workDir, "bd", args...)
 		},
 		Run: func(workDir string, args ...string) error {
+			defer beads.InvalidateQueryCache(workDir, os.Getenv("BEADS_DIR"), args)
 			args = beads.InjectFlatForListJSON(args)
 			return util.ExecRun(workDir, "bd", args...)
 		},
+		Show: func(workDir, id string) (*beads.Issue, error) {
+			return beads.New(workDir).Show(id)
+		},
 	}
 }
 
@@ -320,11 +373,15 @@ func isStalePolecatDone(workDir, rigName, polecatName string, msg *mail.Message)
 
 	initRegistryFromWorkDir(workDir)
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
-	createdAt, err := session.SessionCreatedAt(sessionName)
+	info, err := polecatTmux(workDirToTownRoot(workDir), rigName).GetSessionInfo(sessionName)
 	if err != nil {
 		// Session not found or tmux not running - can't determine staleness, allow message
 		return false, ""
 	}
+	createdAt, err := session.ParseTmuxSessionCreated(info.Created)
+	if err != nil {
+		return false, ""
+	}
 
 	return session.StaleReasonForTimes(msg.Timestamp, createdAt)
 }
@@ -456,7 +513,7 @@ func HandleMergeFailed(workDir, rigName string, msg *mail.Message, router *mail.
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), payload.PolecatName)
 	nudgeMsg := fmt.Sprintf("MERGE_FAILED: branch=%s issue=%s type=%s error=%s — fix and resubmit with 'gt done'",
 		payload.Branch, payload.IssueID, payload.FailureType, payload.Error)
-	t := tmux.NewTmux()
+	t := polecatTmux(workDirToTownRoot(workDir), rigName)
 	if err := t.NudgeSession(sessionName, nudgeMsg); err != nil {
 		result.Error = fmt.Errorf("nudging polecat about failure: %w", err)
 		return result
@@ -593,11 +650,6 @@ func findCleanupWisp(bd *BdCli, workDir, polecatName string) (string, error) {
 	return "", nil
 }
 
-// agentBeadResponse is used to parse the bd show --json response for agent beads.
-type agentBeadResponse struct {
-	Description string `json:"description"`
-}
-
 // getCleanupStatus retrieves the cleanup_status from a polecat's agent bead.
 // Returns the status string: "clean", "has_uncommitted", "has_stash", "has_unpushed"
 // Returns empty string if agent bead doesn't exist or has no cleanup_status.
@@ -615,24 +667,14 @@ func getCleanupStatus(bd *BdCli, workDir, rigName, polecatName string) string {
 	prefix := beads.GetPrefixForRig(townRoot, rigName)
 	agentBeadID := beads.PolecatBeadIDWithPrefix(prefix, rigName, polecatName)
 
-	output, err := bd.Exec(workDir, "show", agentBeadID, "--json")
+	issue, err := bd.show(workDir, agentBeadID)
 	if err != nil {
 		// Agent bead doesn't exist or bd failed - return empty (unknown status)
 		return ""
 	}
 
-	if output == "" {
-		return ""
-	}
-
-	// Parse the JSON response — bd show --json returns an array
-	var issues []agentBeadResponse
-	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
-		return ""
-	}
-
 	// Use structured field parser instead of ad-hoc string parsing
-	fields := beads.ParseAgentFields(issues[0].Description)
+	fields := beads.ParseAgentFields(issue.Description)
 	return fields.CleanupStatus
 }
 
@@ -849,7 +891,7 @@ func NukePolecat(bd *BdCli, workDir, rigName, polecatName string) error {
 	// session due to rig loading issues or race conditions with IsRunning checks.
 	// See: gt-g9ft5 - sessions were piling up because nuke wasn't killing them.
 	sessionName := session.PolecatSessionName(session.PrefixFor(rigName), polecatName)
-	t := tmux.NewTmux()
+	t := polecatTmux(townRoot, rigName)
 
 	// Check if session exists and kill it
 	if running, _ := t.HasSession(sessionName); running {
@@ -915,11 +957,8 @@ func _verifyCommitOnMain(workDir, rigName, polecatName string) (bool, error) {
 		return false, fmt.Errorf("finding town root: %v", err)
 	}
 
-	// Get configured default branch for this rig
-	defaultBranch := "main" // fallback
-	if rigCfg, err := rig.LoadRigConfig(filepath.Join(townRoot, rigName)); err == nil && rigCfg.DefaultBranch != "" {
-		defaultBranch = rigCfg.DefaultBranch
-	}
+	// Get the rig's default branch
+	defaultBranch := rig.ResolveDefaultBranch(filepath.Join(townRoot, rigName))
 
 	// Construct polecat path, handling both new and old structures
 	// New structure: polecats/<name>/<rigname>/
@@ -1074,7 +1113,7 @@ func DetectZombiePolecats(bd *BdCli, workDir, rigName string, router *mail.Route
 		return result
 	}
 
-	t := tmux.NewTmux()
+	t := polecatTmux(townRoot, rigName)
 
 	for _, entry := range entries {
 		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
@@ -1259,6 +1298,11 @@ func detectZombieLiveSession(bd *BdCli, workDir, townRoot, rigName, polecatName,
 			WasActive:      true,
 			Action:         "restarted-bead-closed-polecat",
 		}
+		// A closed bead with the agent mid-turn is usually gt done still
+		// running; restarting would cut it off. Check again next patrol.
+		if agentMidTurn(t, sessionName) {
+			return ZombieResult{}, false
+		}
 		// TOCTOU guard (gt-0pst): Re-check session liveness before restarting.
 		// The session could have exited normally between our initial check and here.
 		if alive, _ := t.HasSession(sessionName); !alive {
@@ -1274,6 +1318,20 @@ func detectZombieLiveSession(bd *BdCli, workDir, townRoot, rigName, polecatName,
 	return ZombieResult{}, false
 }
 
+// agentAtPrompt reports whether tmux.Idle sees the agent waiting at its ready
+// prompt with no pane output for at least quiet. Errors count as not idle.
+func agentAtPrompt(t *tmux.Tmux, sessionName string, quiet time.Duration) bool {
+	st, err := t.IdleWithQuiet(sessionName, quiet)
+	return err == nil && st.Idle
+}
+
+// agentMidTurn reports whether tmux.Idle sees the agent working: a busy
+// indicator is showing in the pane. Errors count as not working.
+func agentMidTurn(t *tmux.Tmux, sessionName string) bool {
+	st, err := t.Idle(sessionName)
+	return err == nil && st.Busy
+}
+
 // detectZombieDeadSession checks a polecat with a dead tmux session for zombie indicators:
 // stale done-intent, or active agent state / hooked bead with no session.
 //
@@ -1550,6 +1608,7 @@ type DetectStalledPolecatsResult struct {
 // rather than screen-scraping pane content. A session is considered stalled when:
 //   - It is older than StartupStallThreshold (90s)
 //   - Its last tmux activity is older than StartupActivityGrace (60s)
+//   - tmux.Idle doesn't see the agent waiting at its ready prompt
 //
 // When a startup stall is detected, DismissStartupDialogsBlind is called to
 // send blind key sequences that dismiss known blocking dialogs (workspace trust,
@@ -1577,7 +1636,7 @@ func DetectStalledPolecats(workDir, rigName string) *DetectStalledPolecatsResult
 		return result // No polecats directory
 	}
 
-	t := tmux.NewTmux()
+	t := polecatTmux(townRoot, rigName)
 	now := time.Now()
 
 	for _, entry := range entries {
@@ -1635,6 +1694,9 @@ func DetectStalledPolecats(workDir, rigName string) *DetectStalledPolecatsResult
 		if activityAge < activityGrace {
 			continue // Recent activity — agent is making progress
 		}
+		if agentAtPrompt(t, sessionName, activityGrace) {
+			continue // Quiet at its ready prompt — waiting for input, not blocked on a dialog
+		}
 
 		// Session is old enough and has no recent activity: startup stall.
 		// Send blind key sequences to dismiss any startup dialogs without
@@ -1911,39 +1973,23 @@ func (s *agentBeadSnapshot) cleanupStatus() string {
 // including completion metadata (exit_type, mr_id, branch, mr_failed, completion_time).
 // Returns nil if the bead doesn't exist or can't be parsed.
 func getAgentBeadFields(bd *BdCli, workDir, agentBeadID string) *beads.AgentFields {
-	output, err := bd.Exec(workDir, "show", agentBeadID, "--json")
-	if err != nil || output == "" {
-		return nil
-	}
-
-	var issues []struct {
-		Description string `json:"description"`
-	}
-	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
+	issue, err := bd.show(workDir, agentBeadID)
+	if err != nil {
 		return nil
 	}
-
-	return beads.ParseAgentFields(issues[0].Description)
+	return beads.ParseAgentFields(issue.Description)
 }
 
 // clearCompletionMetadata removes completion metadata fields from an agent bead
 // by reading the current description, clearing the fields, and writing back.
 // This prevents the same completion from being re-processed on the next patrol cycle.
 func clearCompletionMetadata(bd *BdCli, workDir, agentBeadID string) error {
-	output, err := bd.Exec(workDir, "show", agentBeadID, "--json")
-	if err != nil || output == "" {
+	issue, err := bd.show(workDir, agentBeadID)
+	if err != nil {
 		return fmt.Errorf("reading agent bead %s: %w", agentBeadID, err)
 	}
 
-	var issues []struct {
-		Title       string `json:"title"`
-		Description string `json:"description"`
-	}
-	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
-		return fmt.Errorf("parsing agent bead JSON for %s: %w", agentBeadID, err)
-	}
-
-	fields := beads.ParseAgentFields(issues[0].Description)
+	fields := beads.ParseAgentFields(issue.Description)
 	if fields == nil {
 		return nil
 	}
@@ -1955,29 +2001,18 @@ func clearCompletionMetadata(bd *BdCli, workDir, agentBeadID string) error {
 	fields.MRFailed = false
 	fields.CompletionTime = ""
 
-	newDesc := beads.FormatAgentDescription(issues[0].Title, fields)
+	newDesc := beads.FormatAgentDescription(issue.Title, fields)
 	return bd.Run(workDir, "update", agentBeadID, "--description", newDesc)
 }
 
 // getAgentBeadState reads agent_state and hook_bead from an agent bead.
 // Returns the agent_state string and hook_bead ID.
 func getAgentBeadState(bd *BdCli, workDir, agentBeadID string) (agentState, hookBead string) {
-	output, err := bd.Exec(workDir, "show", agentBeadID, "--json")
-	if err != nil || output == "" {
-		return "", ""
-	}
-
-	// Parse JSON response — bd show --json returns an array
-	var issues []struct {
-		AgentState  string `json:"agent_state"`
-		HookBead    string `json:"hook_bead"`
-		Description string `json:"description"`
-	}
-	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
+	issue, err := bd.show(workDir, agentBeadID)
+	if err != nil {
 		return "", ""
 	}
-
-	return beads.ResolveAgentState(issues[0].Description, issues[0].AgentState), issues[0].HookBead
+	return beads.ResolveAgentState(issue.Description, issue.AgentState), issue.HookBead
 }
 
 // getAgentBeadAge returns the time since the agent bead was last updated.
@@ -1985,22 +2020,15 @@ func getAgentBeadState(bd *BdCli, workDir, agentBeadID string) (agentState, hook
 // spawning). Returns a large duration if the bead can't be queried, so callers
 // don't accidentally skip zombie detection on query failure. See GH#2036.
 func getAgentBeadAge(bd *BdCli, workDir, agentBeadID string) time.Duration {
-	output, err := bd.Exec(workDir, "show", agentBeadID, "--json")
-	if err != nil || output == "" {
+	issue, err := bd.show(workDir, agentBeadID)
+	if err != nil {
 		return 24 * time.Hour // Fail open: treat as old so zombie detection proceeds
 	}
 
-	var issues []struct {
-		UpdatedAt string `json:"updated_at"`
-	}
-	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
-		return 24 * time.Hour
-	}
-
-	updatedAt, err := time.Parse(time.RFC3339, issues[0].UpdatedAt)
+	updatedAt, err := time.Parse(time.RFC3339, issue.UpdatedAt)
 	if err != nil {
 		// Try common alternative formats
-		updatedAt, err = time.Parse("2006-01-02 15:04:05", issues[0].UpdatedAt)
+		updatedAt, err = time.Parse("2006-01-02 15:04:05", issue.UpdatedAt)
 		if err != nil {
 			return 24 * time.Hour
 		}
@@ -2016,18 +2044,15 @@ func getBeadStatus(bd *BdCli, workDir, beadID string) (string, bool) {
 	if beadID == "" {
 		return "", false
 	}
-	output, err := bd.Exec(workDir, "show", beadID, "--json")
-	if err != nil || output == "" {
-		return "", false
-	}
-	var issues []struct {
-		Status string `json:"status"`
-	}
-	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
+	issue, err := bd.show(workDir, beadID)
+	if errors.Is(err, beads.ErrNotFound) {
 		// Valid response but no results — bead was reaped/deleted.
 		return "", true
 	}
-	return issues[0].Status, true
+	if err != nil {
+		return "", false
+	}
+	return issue.Status, true
 }
 
 // resetAbandonedBead resets a dead polecat's hooked bead so it can be re-dispatched.
@@ -2163,6 +2188,30 @@ type OrphanedBeadResult struct {
 	BeadRecovered bool
 }
 
+// scanBeads runs the bd list calls covering q and decodes each result into
+// T. A call that fails is reported and skipped so the others still count.
+func scanBeads[T any](bd *BdCli, workDir string, q beads.Query) ([]T, []error) {
+	var items []T
+	var errs []error
+	for _, args := range q.ListArgs() {
+		output, err := bd.Exec(workDir, args...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("bd %s: %w", strings.Join(args, " "), err))
+			continue
+		}
+		if output == "" {
+			continue
+		}
+		var batch []T
+		if err := json.Unmarshal([]byte(output), &batch); err != nil {
+			errs = append(errs, fmt.Errorf("parsing bd %s: %w", strings.Join(args, " "), err))
+			continue
+		}
+		items = append(items, batch...)
+	}
+	return items, errs
+}
+
 // DetectOrphanedBeadsResult contains the results of an orphaned bead scan.
 type DetectOrphanedBeadsResult struct {
 	Checked int
@@ -2187,31 +2236,13 @@ func DetectOrphanedBeads(bd *BdCli, workDir, rigName string, router *mail.Router
 
 	// Scan both in_progress and hooked beads — resetAbandonedBead handles both
 	// states, and orphaned beads can be stuck in either.
-	var beadList []struct {
+	beadList, errs := scanBeads[struct {
 		ID       string `json:"id"`
 		Assignee string `json:"assignee"`
-	}
-	for _, status := range []string{"in_progress", "hooked"} {
-		output, err := bd.Exec(workDir, "list", "--status="+status, "--json", "--limit=0")
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Errorf("listing %s beads: %w", status, err))
-			continue
-		}
-		if output == "" {
-			continue
-		}
-		var batch []struct {
-			ID       string `json:"id"`
-			Assignee string `json:"assignee"`
-		}
-		if err := json.Unmarshal([]byte(output), &batch); err != nil {
-			result.Errors = append(result.Errors, fmt.Errorf("parsing %s beads: %w", status, err))
-			continue
-		}
-		beadList = append(beadList, batch...)
-	}
+	}](bd, workDir, beads.Query{Statuses: []string{"in_progress", "hooked"}})
+	result.Errors = append(result.Errors, errs...)
 
-	t := tmux.NewTmux()
+	t := polecatTmux(townRoot, rigName)
 
 	for _, bead := range beadList {
 		if bead.Assignee == "" {
@@ -2329,23 +2360,8 @@ func DetectOrphanedMolecules(bd *BdCli, workDir, rigName string, router *mail.Ro
 		ID       string `json:"id"`
 		Assignee string `json:"assignee"`
 	}
-	var allBeads []beadSummary
-	for _, status := range []string{"hooked", "in_progress"} {
-		output, err := bd.Exec(workDir, "list", "--status="+status, "--json", "--limit=0")
-		if err != nil {
-			result.Errors = append(result.Errors, fmt.Errorf("listing %s beads: %w", status, err))
-			continue
-		}
-		if output == "" {
-			continue
-		}
-		var items []beadSummary
-		if err := json.Unmarshal([]byte(output), &items); err != nil {
-			result.Errors = append(result.Errors, fmt.Errorf("parsing %s beads: %w", status, err))
-			continue
-		}
-		allBeads = append(allBeads, items...)
-	}
+	allBeads, errs := scanBeads[beadSummary](bd, workDir, beads.Query{Statuses: []string{"hooked", "in_progress"}})
+	result.Errors = append(result.Errors, errs...)
 
 	if len(allBeads) == 0 {
 		return result
@@ -2353,7 +2369,7 @@ func DetectOrphanedMolecules(bd *BdCli, workDir, rigName string, router *mail.Ro
 
 	// Step 2: Check each polecat-assigned bead
 	polecatPrefix := rigName + "/polecats/"
-	t := tmux.NewTmux()
+	t := polecatTmux(townRoot, rigName)
 	polecatsDir := filepath.Join(townRoot, rigName, "polecats")
 
 	for _, b := range allBeads {
@@ -2439,19 +2455,11 @@ func DetectOrphanedMolecules(bd *BdCli, workDir, rigName string, router *mail.Ro
 
 // getAttachedMoleculeID reads a bead and returns its attached_molecule ID, if any.
 func getAttachedMoleculeID(bd *BdCli, workDir, beadID string) string {
-	output, err := bd.Exec(workDir, "show", beadID, "--json")
-	if err != nil || output == "" {
-		return ""
-	}
-
-	var issues []struct {
-		Description string `json:"description"`
-	}
-	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
+	issue, err := bd.show(workDir, beadID)
+	if err != nil {
 		return ""
 	}
-
-	fields := beads.ParseAttachmentFields(&beads.Issue{Description: issues[0].Description})
+	fields := beads.ParseAttachmentFields(issue)
 	if fields == nil {
 		return ""
 	}
@@ -2570,19 +2578,11 @@ func extractDoneIntent(labels []string) *DoneIntent {
 
 // getAgentBeadLabels reads the labels from an agent bead.
 func getAgentBeadLabels(bd *BdCli, workDir, agentBeadID string) []string {
-	output, err := bd.Exec(workDir, "show", agentBeadID, "--json")
-	if err != nil || output == "" {
-		return nil
-	}
-
-	var issues []struct {
-		Labels []string `json:"labels"`
-	}
-	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
+	issue, err := bd.show(workDir, agentBeadID)
+	if err != nil {
 		return nil
 	}
-
-	return issues[0].Labels
+	return issue.Labels
 }
 
 // sessionRecreated checks whether a tmux session was (re)created after the
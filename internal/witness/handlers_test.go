@@ -2,8 +2,10 @@ package witness
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"runtime"
 	"strings"
@@ -119,6 +121,27 @@ func TestDetectZombiePolecatsResult_EmptyResult(t *testing.T) {
 	}
 }
 
+func TestPolecatTmux_RemoteHost(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	rigs := `{"version":1,"rigs":{"heavy":{"git_url":"x","remote_host":"build-01"},"local":{"git_url":"y"}}}`
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "rigs.json"), []byte(rigs), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := polecatTmux(townRoot, "heavy").RemoteHost(); got != "build-01" {
+		t.Errorf("polecatTmux(heavy) host = %q, want build-01", got)
+	}
+	if got := polecatTmux(townRoot, "local").RemoteHost(); got != "" {
+		t.Errorf("polecatTmux(local) host = %q, want local tmux", got)
+	}
+	if got := polecatTmux(t.TempDir(), "heavy").RemoteHost(); got != "" {
+		t.Errorf("polecatTmux without rigs.json host = %q, want local tmux", got)
+	}
+}
+
 func TestDetectZombiePolecats_NonexistentDir(t *testing.T) {
 	t.Parallel()
 	// Should handle missing polecats directory gracefully
@@ -173,6 +196,40 @@ func TestDetectZombiePolecats_DirectoryScanning(t *testing.T) {
 	}
 }
 
+func TestDetectZombiePolecats_RemoteRigUsesRemoteHost(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("ssh"); err != nil {
+		t.Skip("ssh not installed")
+	}
+	// A polecat on a remote rig must be checked on the rig's host. The host
+	// here can't be reached, so the check errors out instead of the local
+	// tmux reporting no session and the polecat being treated as dead.
+	tmpDir := t.TempDir()
+	rigName := "testrig"
+	if err := os.MkdirAll(filepath.Join(tmpDir, "mayor"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	rigs := `{"version":1,"rigs":{"testrig":{"git_url":"x","remote_host":"gt-witness-test.invalid"}}}`
+	if err := os.WriteFile(filepath.Join(tmpDir, "mayor", "rigs.json"), []byte(rigs), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, rigName, "polecats", "alpha"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	result := DetectZombiePolecats(DefaultBdCli(), tmpDir, rigName, nil)
+
+	if result.Checked != 1 {
+		t.Errorf("Checked = %d, want 1", result.Checked)
+	}
+	if len(result.Zombies) != 0 {
+		t.Errorf("Zombies = %+v, want none when the remote host can't be checked", result.Zombies)
+	}
+	if len(result.Errors) == 0 {
+		t.Error("expected a session check error from the unreachable remote host")
+	}
+}
+
 func TestDetectZombiePolecats_EmptyPolecatsDir(t *testing.T) {
 	t.Parallel()
 	// Empty polecats directory should return 0 checked
@@ -236,6 +293,46 @@ func TestSessionRecreated_DetectedAtEdgeCases(t *testing.T) {
 	}
 }
 
+func TestAgentIdleHelpers(t *testing.T) {
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux not installed")
+	}
+	socket := fmt.Sprintf("gt-test-witness-idle-%d", os.Getpid())
+	t.Cleanup(func() { _ = exec.Command("tmux", "-L", socket, "kill-server").Run() })
+	tm := tmux.NewTmuxWithSocket(socket)
+
+	start := func(name, output string) {
+		t.Helper()
+		cmd := fmt.Sprintf("printf '%s\\n'; sleep 30", output)
+		if out, err := exec.Command("tmux", "-L", socket, "new-session", "-d", "-s", name, cmd).CombinedOutput(); err != nil {
+			t.Fatalf("new-session %s: %v\n%s", name, err, out)
+		}
+	}
+	start("at-prompt", "❯ ")
+	start("busy", "* Working… (esc to interrupt)")
+
+	deadline := time.Now().Add(3 * time.Second)
+	for time.Now().Before(deadline) && !(agentAtPrompt(tm, "at-prompt", 0) && agentMidTurn(tm, "busy")) {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	if !agentAtPrompt(tm, "at-prompt", 0) {
+		t.Error("agentAtPrompt(at-prompt) = false, want true")
+	}
+	if agentMidTurn(tm, "at-prompt") {
+		t.Error("agentMidTurn(at-prompt) = true, want false")
+	}
+	if !agentMidTurn(tm, "busy") {
+		t.Error("agentMidTurn(busy) = false, want true")
+	}
+	if agentAtPrompt(tm, "busy", 0) {
+		t.Error("agentAtPrompt(busy) = true, want false")
+	}
+	if agentAtPrompt(tm, "missing", 0) || agentMidTurn(tm, "missing") {
+		t.Error("missing session should be neither idle nor mid-turn")
+	}
+}
+
 func TestZombieClassification_SpawningState(t *testing.T) {
 	t.Parallel()
 	// Verify that "spawning" agent state is treated as a zombie indicator.
@@ -1913,3 +2010,35 @@ func TestNotifyRefineryMergeReady_EmitsChannelEvent(t *testing.T) {
 		t.Errorf("payload.rig = %v, want dashboard", payload["rig"])
 	}
 }
+
+func TestBdCliShow(t *testing.T) {
+	t.Parallel()
+	bd, _ := mockBd(func(args []string) (string, error) {
+		switch args[1] {
+		case "gt-abc":
+			return `[{"id":"gt-abc","status":"hooked","labels":["gt:task"]}]`, nil
+		case "gt-gone":
+			return `[]`, nil
+		}
+		return "", errors.New("bd failed")
+	}, func(args []string) error { return nil })
+
+	issue, err := bd.show("/tmp", "gt-abc")
+	if err != nil || issue.Status != "hooked" || len(issue.Labels) != 1 {
+		t.Fatalf("show = %+v, %v", issue, err)
+	}
+	if status, ok := getBeadStatus(bd, "/tmp", "gt-gone"); !ok || status != "" {
+		t.Errorf("reaped bead: got (%q, %v), want (\"\", true)", status, ok)
+	}
+	if _, ok := getBeadStatus(bd, "/tmp", "gt-err"); ok {
+		t.Error("failed lookup should report ok=false")
+	}
+
+	// The Show hook takes precedence over Exec.
+	bd.Show = func(workDir, id string) (*beads.Issue, error) {
+		return &beads.Issue{ID: id, Status: "closed"}, nil
+	}
+	if status, _ := getBeadStatus(bd, "/tmp", "gt-abc"); status != "closed" {
+		t.Errorf("status = %q, want closed from Show hook", status)
+	}
+}
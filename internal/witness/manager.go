@@ -190,40 +190,19 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 
 	// Create session with command directly to avoid send-keys race condition.
 	// See: https://github.com/anthropics/gastown/issues/280
-	if err := t.NewSessionWithCommand(sessionID, witnessDir, command); err != nil {
+	agentEnv := session.AgentSessionEnv(m.agentEnvConfig(townRoot, sessionID, agentOverride), m.rig.Path)
+	env := m.sessionEnv(agentEnv, townRoot, runID, runtimeConfig, roleConfig, envOverrides)
+	if err := session.NewAgentSession(t, sessionID, witnessDir, command, env, townRoot, m.rig.Path, "witness"); err != nil {
 		return fmt.Errorf("creating tmux session: %w", err)
 	}
 
-	// Set environment variables (non-fatal: session works without these)
-	// Use centralized AgentEnv for consistency across all role startup paths
-	envVars := config.AgentEnv(config.AgentEnvConfig{
-		Role:        "witness",
-		Rig:         m.rig.Name,
-		TownRoot:    townRoot,
-		Agent:       agentOverride,
-		SessionName: sessionID,
-	})
-	envVars = session.MergeRuntimeLivenessEnv(envVars, runtimeConfig)
-	for k, v := range envVars {
-		_ = t.SetEnvironment(sessionID, k, v)
-	}
-	_ = t.SetEnvironment(sessionID, "GT_RUN", runID)
-	// Apply role config env vars if present (non-fatal).
-	// Skip keys already set by AgentEnv to prevent TOML env overriding
-	// the canonical qualified GT_ROLE (e.g., "gastown/witness" not "witness").
-	// See: https://github.com/steveyegge/gastown/issues/2492
-	for key, value := range roleConfigEnvVars(roleConfig, townRoot, m.rig.Name) {
-		if _, alreadySet := envVars[key]; alreadySet {
-			continue
-		}
-		_ = t.SetEnvironment(sessionID, key, value)
-	}
-	// Apply CLI env overrides (highest priority, non-fatal).
-	for _, override := range envOverrides {
-		if key, value, ok := strings.Cut(override, "="); ok {
-			_ = t.SetEnvironment(sessionID, key, value)
-		}
-	}
+	// Capture pane output to witness/logs so scrollback survives crashes (non-fatal).
+	_ = session.EnableSessionLogCapture(t, townRoot, sessionID)
+
+	// Create the witness's configured panes/windows (non-fatal).
+	_ = session.ApplySessionLayout(t, townRoot, "witness", sessionID, witnessDir)
+
+	session.RunSessionHooks(townRoot, session.HookStart, sessionID, witnessDir)
 
 	// Apply Gas Town theming (non-fatal: theming failure doesn't affect operation)
 	theme := tmux.ResolveSessionTheme(townRoot, m.rig.Name, "witness")
@@ -277,6 +256,67 @@ func (m *Manager) Start(foreground bool, agentOverride string, envOverrides []st
 	return nil
 }
 
+// agentEnvConfig returns the AgentEnv settings for the witness session.
+func (m *Manager) agentEnvConfig(townRoot, sessionID, agentOverride string) config.AgentEnvConfig {
+	return config.AgentEnvConfig{
+		Role:        "witness",
+		Rig:         m.rig.Name,
+		TownRoot:    townRoot,
+		Agent:       agentOverride,
+		SessionName: sessionID,
+	}
+}
+
+// sessionEnv returns the environment set on the witness session, in
+// increasing priority: agentEnv (session_env plus AgentEnv, see
+// session.AgentSessionEnv), the run ID, role config env vars, and CLI env
+// overrides.
+func (m *Manager) sessionEnv(agentEnv map[string]string, townRoot, runID string, runtimeConfig *config.RuntimeConfig, roleConfig *beads.RoleConfig, envOverrides []string) map[string]string {
+	envVars := session.MergeRuntimeLivenessEnv(agentEnv, runtimeConfig)
+	env := make(map[string]string, len(envVars)+1)
+	for k, v := range envVars {
+		env[k] = v
+	}
+	env["GT_RUN"] = runID
+	// Skip keys already set by the agent env to prevent TOML env overriding
+	// the canonical qualified GT_ROLE (e.g., "gastown/witness" not "witness").
+	// See: https://github.com/steveyegge/gastown/issues/2492
+	for key, value := range roleConfigEnvVars(roleConfig, townRoot, m.rig.Name) {
+		if _, alreadySet := envVars[key]; alreadySet {
+			continue
+		}
+		env[key] = value
+	}
+	for _, override := range envOverrides {
+		if key, value, ok := strings.Cut(override, "="); ok {
+			env[key] = value
+		}
+	}
+	return env
+}
+
+// Plan returns the tmux session Start would create, without creating it.
+func (m *Manager) Plan(agentOverride string, envOverrides []string) (*session.StartPlan, error) {
+	townRoot := m.townRoot()
+	sessionID := m.SessionName()
+	roleConfig, err := m.roleConfig()
+	if err != nil {
+		roleConfig = nil
+	}
+	command, err := buildWitnessStartCommand(m.rig.Path, m.rig.Name, townRoot, sessionID, agentOverride, roleConfig)
+	if err != nil {
+		return nil, err
+	}
+	runtimeConfig := config.ResolveRoleAgentConfig("witness", townRoot, m.rig.Path)
+	agentEnv := session.PlannedAgentSessionEnv(m.agentEnvConfig(townRoot, sessionID, agentOverride), m.rig.Path)
+	return &session.StartPlan{
+		SessionID: sessionID,
+		WorkDir:   m.witnessDir(),
+		Command:   session.AgentSessionCommand(command, townRoot, m.rig.Path, "witness"),
+		Env:       m.sessionEnv(agentEnv, townRoot, session.RunIDPlaceholder, runtimeConfig, roleConfig, envOverrides),
+	}, nil
+}
+
 func (m *Manager) roleConfig() (*beads.RoleConfig, error) {
 	townRoot := m.townRoot()
 	roleDef, err := config.LoadRoleDefinition(townRoot, m.rig.Path, "witness")
@@ -376,6 +416,6 @@ func (m *Manager) Stop() error {
 		return ErrNotRunning
 	}
 
-	// Kill the tmux session
-	return t.KillSession(sessionID)
+	// Kill the tmux session and run stop hooks
+	return session.TerminateSession(t, m.townRoot(), sessionID)
 }
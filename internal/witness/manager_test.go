@@ -1,10 +1,14 @@
 package witness
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/steveyegge/gastown/internal/beads"
+	"github.com/steveyegge/gastown/internal/config"
+	"github.com/steveyegge/gastown/internal/rig"
 )
 
 func TestBuildWitnessStartCommand_UsesRoleConfig(t *testing.T) {
@@ -85,3 +89,43 @@ func TestBuildWitnessStartCommand_AgentOverrideWins(t *testing.T) {
 		t.Errorf("expected GT_ROLE=gastown/witness in command, got %q", got)
 	}
 }
+
+func TestPlan_IncludesConfiguredSessionEnv(t *testing.T) {
+	townRoot := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(townRoot, "mayor"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(townRoot, "mayor", "town.json"), []byte("{}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	town := config.NewTownSettings()
+	town.SessionEnv = &config.SessionEnvConfig{Roles: map[string]map[string]string{"witness": {"TEAM": "core"}}}
+	town.Secrets = &config.SessionEnvConfig{Vars: map[string]string{"API_TOKEN": "env:GT_TEST_WITNESS_TOKEN"}}
+	if err := config.SaveTownSettings(config.TownSettingsPath(townRoot), town); err != nil {
+		t.Fatal(err)
+	}
+	rigPath := filepath.Join(townRoot, "gastown")
+	if err := os.MkdirAll(rigPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	plan, err := NewManager(&rig.Rig{Name: "gastown", Path: rigPath}).Plan("", nil)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	if plan.Env["TEAM"] != "core" {
+		t.Errorf("Env[TEAM] = %q, want %q", plan.Env["TEAM"], "core")
+	}
+	if plan.Env["GT_ROLE"] != "gastown/witness" {
+		t.Errorf("Env[GT_ROLE] = %q, want %q", plan.Env["GT_ROLE"], "gastown/witness")
+	}
+	if plan.Env["API_TOKEN"] != "<secret env:GT_TEST_WITNESS_TOKEN>" {
+		t.Errorf("Env[API_TOKEN] = %q, want the secret reference", plan.Env["API_TOKEN"])
+	}
+	if !strings.Contains(plan.Command, "TEAM=core") {
+		t.Errorf("Command %q does not export TEAM", plan.Command)
+	}
+	if strings.Contains(plan.Command, "API_TOKEN") {
+		t.Errorf("Command %q mentions the secret", plan.Command)
+	}
+}
@@ -164,18 +164,11 @@ func getTrackingConvoysCLI(bd *BdCli, workDir, issueID string) []string {
 
 // getBeadLabels returns the labels for a bead.
 func getBeadLabels(bd *BdCli, workDir, beadID string) []string {
-	output, err := bd.Exec(workDir, "show", beadID, "--json")
-	if err != nil || output == "" {
+	issue, err := bd.show(workDir, beadID)
+	if err != nil {
 		return nil
 	}
-
-	var issues []struct {
-		Labels []string `json:"labels"`
-	}
-	if err := json.Unmarshal([]byte(output), &issues); err != nil || len(issues) == 0 {
-		return nil
-	}
-	return issues[0].Labels
+	return issue.Labels
 }
 
 // hasLabel checks if a label list contains a specific label.
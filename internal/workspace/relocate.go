@@ -0,0 +1,162 @@
+package workspace
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// LocationFile records the absolute path the town was last set up at,
+// relative to the town root. Comparing it with the current root is how a
+// renamed or moved town is detected.
+const LocationFile = ".runtime/town-root"
+
+// relocatePatterns are the town-relative globs of files known to store
+// absolute town paths: JSON state and config, Dolt's server config, and
+// git's worktree linkage (".git" files in worktrees, "gitdir" files in the
+// repo's admin dirs) and remote URLs. Repo working trees are never walked,
+// so user source files are never touched.
+var relocatePatterns = []string{
+	"mayor/*.json",
+	"mayor/.runtime/*.json",
+	"daemon/*.json",
+	"settings/*.json",
+	".runtime/*.json",
+	".runtime/*/*.json",
+	".dolt-data/config.yaml",
+	"*/config.json",
+	"*/settings/*.json",
+	"*/.runtime/*.json",
+	"*/crew/*/state.json",
+	"*/.repo.git/config",
+	"*/.repo.git/worktrees/*/gitdir",
+	"*/mayor/rig/.git",
+	"*/mayor/rig/.git/config",
+	"*/mayor/rig/.git/worktrees/*/gitdir",
+	"*/refinery/rig/.git",
+	"*/refinery/rig/.git/config",
+	"*/polecats/*/.git",
+	"*/polecats/*/*/.git",
+	"*/crew/*/.git",
+	"*/crew/*/.git/config",
+	"*/crew/*/.git/worktrees/*/gitdir",
+}
+
+// RecordLocation writes townRoot to the town's LocationFile.
+func RecordLocation(townRoot string) error {
+	path := filepath.Join(townRoot, LocationFile)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(townRoot+"\n"), 0644)
+}
+
+// PreviousLocation returns the root the town was at before it was moved to
+// townRoot, or "" if it has not moved. It uses LocationFile when present
+// and otherwise infers the old root from a rig worktree's .git file, which
+// git writes with an absolute path.
+func PreviousLocation(townRoot string) string {
+	if data, err := os.ReadFile(filepath.Join(townRoot, LocationFile)); err == nil {
+		if old := strings.TrimSpace(string(data)); old != "" && old != townRoot {
+			return old
+		}
+		return ""
+	}
+
+	matches, _ := filepath.Glob(filepath.Join(townRoot, "*", "refinery", "rig", ".git"))
+	for _, gitFile := range matches {
+		data, err := os.ReadFile(gitFile)
+		if err != nil {
+			continue
+		}
+		gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir: ")
+		if !ok || !filepath.IsAbs(gitdir) {
+			continue
+		}
+		rigName := filepath.Base(filepath.Dir(filepath.Dir(filepath.Dir(gitFile))))
+		marker := string(filepath.Separator) + rigName + string(filepath.Separator) + ".repo.git" + string(filepath.Separator)
+		if i := strings.LastIndex(gitdir, marker); i > 0 && gitdir[:i] != townRoot {
+			return gitdir[:i]
+		}
+	}
+	return ""
+}
+
+// Relocate rewrites absolute paths under oldRoot to newRoot in the state,
+// config and git metadata files of the town now at newRoot, then records
+// newRoot as the town's location. It returns the town-relative paths of
+// the files it changed.
+func Relocate(oldRoot, newRoot string) ([]string, error) {
+	oldRoot = filepath.Clean(oldRoot)
+	newRoot = filepath.Clean(newRoot)
+	if oldRoot == newRoot {
+		return nil, nil
+	}
+
+	seen := make(map[string]bool)
+	var changed []string
+	for _, pattern := range relocatePatterns {
+		matches, err := filepath.Glob(filepath.Join(newRoot, pattern))
+		if err != nil {
+			return changed, err
+		}
+		for _, path := range matches {
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			info, err := os.Stat(path)
+			if err != nil || !info.Mode().IsRegular() {
+				continue
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return changed, fmt.Errorf("reading %s: %w", path, err)
+			}
+			updated, n := replaceRoot(string(data), oldRoot, newRoot)
+			if n == 0 {
+				continue
+			}
+			if err := os.WriteFile(path, []byte(updated), info.Mode().Perm()); err != nil {
+				return changed, fmt.Errorf("writing %s: %w", path, err)
+			}
+			rel, _ := filepath.Rel(newRoot, path)
+			changed = append(changed, rel)
+		}
+	}
+	sort.Strings(changed)
+
+	if err := RecordLocation(newRoot); err != nil {
+		return changed, fmt.Errorf("recording town location: %w", err)
+	}
+	return changed, nil
+}
+
+// replaceRoot replaces each occurrence of oldRoot that is a whole path
+// prefix (followed by a separator, quote, whitespace or end of text) and
+// returns the new text and the number of replacements. /town does not
+// match inside /town2.
+func replaceRoot(text, oldRoot, newRoot string) (string, int) {
+	var b strings.Builder
+	n := 0
+	for {
+		i := strings.Index(text, oldRoot)
+		if i < 0 {
+			b.WriteString(text)
+			break
+		}
+		end := i + len(oldRoot)
+		boundary := end == len(text) || strings.ContainsRune("/\\\"' \t\r\n", rune(text[end]))
+		b.WriteString(text[:i])
+		if boundary {
+			b.WriteString(newRoot)
+			n++
+		} else {
+			b.WriteString(oldRoot)
+		}
+		text = text[end:]
+	}
+	return b.String(), n
+}
@@ -0,0 +1,95 @@
+package workspace
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReplaceRoot(t *testing.T) {
+	got, n := replaceRoot(`{"a":"/town","b":"/town/rig","c":"/town2/x"}`+"\ngitdir: /town/rig/.repo.git\n", "/town", "/new/gt")
+	want := `{"a":"/new/gt","b":"/new/gt/rig","c":"/town2/x"}` + "\ngitdir: /new/gt/rig/.repo.git\n"
+	if got != want || n != 3 {
+		t.Errorf("replaceRoot = %q (%d), want %q (3)", got, n, want)
+	}
+}
+
+func TestRelocate_GitWorktreesAndState(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	base := realPath(t, t.TempDir())
+	oldRoot := filepath.Join(base, "old")
+	newRoot := filepath.Join(base, "new")
+	rigPath := filepath.Join(oldRoot, "gastown")
+	for _, dir := range []string{filepath.Join(oldRoot, "mayor"), filepath.Join(rigPath, "crew", "max"), filepath.Join(rigPath, "refinery")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := RecordLocation(oldRoot); err != nil {
+		t.Fatal(err)
+	}
+
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=t", "GIT_AUTHOR_EMAIL=t@t", "GIT_COMMITTER_NAME=t", "GIT_COMMITTER_EMAIL=t@t")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	src := filepath.Join(base, "src")
+	git(base, "init", "-q", "-b", "main", src)
+	git(src, "commit", "-q", "--allow-empty", "-m", "init")
+	git(base, "clone", "-q", "--bare", src, filepath.Join(rigPath, ".repo.git"))
+	git(rigPath, "--git-dir", ".repo.git", "worktree", "add", "-q", filepath.Join(rigPath, "refinery", "rig"), "main")
+
+	state := `{"name":"max","clone_path":"` + filepath.Join(rigPath, "crew", "max") + `"}`
+	if err := os.WriteFile(filepath.Join(rigPath, "crew", "max", "state.json"), []byte(state), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Rename(oldRoot, newRoot); err != nil {
+		t.Fatal(err)
+	}
+	if got := PreviousLocation(newRoot); got != oldRoot {
+		t.Fatalf("PreviousLocation = %q, want %q", got, oldRoot)
+	}
+	// Without the record, the old root is inferred from the refinery worktree.
+	if err := os.Remove(filepath.Join(newRoot, LocationFile)); err != nil {
+		t.Fatal(err)
+	}
+	if got := PreviousLocation(newRoot); got != oldRoot {
+		t.Fatalf("inferred PreviousLocation = %q, want %q", got, oldRoot)
+	}
+
+	changed, err := Relocate(oldRoot, newRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		filepath.Join("gastown", ".repo.git", "worktrees", "rig", "gitdir"),
+		filepath.Join("gastown", "crew", "max", "state.json"),
+		filepath.Join("gastown", "refinery", "rig", ".git"),
+	} {
+		if !slices.Contains(changed, want) {
+			t.Errorf("Relocate changed %v, missing %s", changed, want)
+		}
+	}
+	data, _ := os.ReadFile(filepath.Join(newRoot, "gastown", "crew", "max", "state.json"))
+	if strings.Contains(string(data), oldRoot+"/") {
+		t.Errorf("state.json still references old root: %s", data)
+	}
+	cmd := exec.Command("git", "-C", filepath.Join(newRoot, "gastown", "refinery", "rig"), "status", "--porcelain")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Errorf("refinery worktree broken after relocate: %v\n%s", err, out)
+	}
+	if got := PreviousLocation(newRoot); got != "" {
+		t.Errorf("PreviousLocation after relocate = %q, want empty", got)
+	}
+}